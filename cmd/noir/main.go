@@ -9,9 +9,12 @@ import (
 	noir "github.com/net-prophet/noir/pkg/noir"
 	"github.com/net-prophet/noir/pkg/noir/jobs"
 	"github.com/net-prophet/noir/pkg/noir/servers"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/viper"
 	"net/http"
 	"os"
+	"time"
 
 	log "github.com/pion/ion-log"
 )
@@ -28,12 +31,12 @@ var (
 	key             string
 	publicJrpcAddr  string
 	adminJrpcAddr   string
+	adminHTTPAddr   string
 	webGrpcAddr     string
+	metricsAddr     string
 	SFU             noir.NoirSFU
-)
-
-const (
-	portRangeLimit = 100
+	// audioMixCodec is set by builds with the opus tag, see opus.go
+	audioMixCodec   noir.AudioCodec
 )
 
 func showHelp() {
@@ -43,8 +46,10 @@ func showHelp() {
 	fmt.Println("      -d {demo http addr}")
 	fmt.Println("      -j {public jsonrpc addr}")
 	fmt.Println("      -a {admin jsonrpc addr}")
+	fmt.Println("      -r {admin http addr}")
 	fmt.Println("      -g {admin-grpc addr}")
 	fmt.Println("      -w {web admin-grpc addr}")
+	fmt.Println("      -m {prometheus metrics addr}")
 	fmt.Println("      -h (show help info)")
 }
 
@@ -68,16 +73,6 @@ func load() bool {
 		return false
 	}
 
-	if len(conf.Ion.WebRTC.ICEPortRange) > 2 {
-		fmt.Printf("config file %s loaded failed. range port must be [min,max]\n", file)
-		return false
-	}
-
-	if len(conf.Ion.WebRTC.ICEPortRange) != 0 && conf.Ion.WebRTC.ICEPortRange[1]-conf.Ion.WebRTC.ICEPortRange[0] < portRangeLimit {
-		fmt.Printf("config file %s loaded failed. range port must be [min, max] and max - min >= %d\n", file, portRangeLimit)
-		return false
-	}
-
 	fmt.Printf("config %s load ok!\n", file)
 	return true
 }
@@ -89,8 +84,10 @@ func parse() bool {
 	flag.StringVar(&demoAddr, "d", "", "http addr to listen for demo")
 	flag.StringVar(&publicJrpcAddr, "j", "", "jsonrpc addr for public")
 	flag.StringVar(&adminJrpcAddr, "a", "", "jsonrpc addr for admin")
+	flag.StringVar(&adminHTTPAddr, "r", "", "http addr for the admin api")
 	flag.StringVar(&webGrpcAddr, "w", "", "web grpc addr for admin")
 	flag.StringVar(&grpcAddr, "g", "", "grpc addr for admin")
+	flag.StringVar(&metricsAddr, "m", "", "http addr for prometheus /metrics")
 	flag.StringVar(&cert, "cert", "", "public jsonrpc https cert file")
 	flag.StringVar(&key, "key", "", "public jsonrpc https key file")
 	help := flag.Bool("h", false, "help info")
@@ -126,35 +123,57 @@ func main() {
 		DB:       0,
 	})
 
-	// Test the connection
-	_, err := rdb.Ping().Result()
-
+	// Wait for redis, the node can neither check in nor read its queues without it
+	if err := noir.WaitForRedis(rdb, time.Duration(conf.Timeouts.RedisConnect)*time.Second); err != nil {
+		log.Errorf("can't connect to the redis database: %s", err)
+		os.Exit(-1)
+	}
+	conf.Node = noir.NodeConfig{Redis: rdb, ID: id, Services: nodeServices}
+	mgr, err := noir.NewManagerWithConfig(conf)
 	if err != nil {
-		log.Infof("can't connect to the redis database at %s, got error:\n%v", redisURL, err)
+		log.Errorf("config error: %s", err)
+		os.Exit(-1)
 	}
-	sfu := noir.NewNoirSFU(conf)
-
-	mgr := noir.SetupNoir(&sfu, rdb, id, nodeServices)
 
 	worker := *(mgr.GetWorker())
-	worker.RegisterHandler(jobs.LabelPlayFile, jobs.NewPlayFileHandler(&mgr))
-	// worker.RegisterHandler(jobs.LabelRTMPSend, jobs.NewRTMPSendHandler(&mgr))
+	worker.RegisterHandler(jobs.LabelPlayFile, jobs.NewPlayFileHandler(mgr))
+	worker.RegisterHandler(jobs.LabelRecord, jobs.NewRecordHandler(mgr))
+	// mixes need a codec, without the opus tag they stay off
+	if audioMixCodec != nil {
+		mgr.SetAudioMixCodec(audioMixCodec)
+	}
+	worker.RegisterHandler(jobs.LabelAudioMix, jobs.NewAudioMixHandler(mgr))
+	// worker.RegisterHandler(jobs.LabelRTMPSend, jobs.NewRTMPSendHandler(mgr))
 
 	go mgr.Noir()
 	defer mgr.Cleanup()
 
 	if publicJrpcAddr != "" {
-		go servers.PublicJSONRPC(&mgr, publicJrpcAddr, key, cert)
+		go servers.PublicJSONRPC(mgr, publicJrpcAddr, key, cert)
 	}
 	if adminJrpcAddr != "" {
-		go servers.AdminJSONRPC(&mgr, adminJrpcAddr, key, cert)
+		go servers.AdminJSONRPC(mgr, adminJrpcAddr, key, cert)
+	}
+	if adminHTTPAddr != "" {
+		go servers.AdminHTTP(mgr, adminHTTPAddr, conf.Auth.AdminToken, key, cert)
 	}
 	if grpcAddr != "" {
-		go servers.AdminGRPC(&mgr, grpcAddr)
+		go servers.AdminGRPC(mgr, grpcAddr)
 	}
 
 	if webGrpcAddr != "" {
-		go servers.AdminGRPCWeb(&mgr, webGrpcAddr)
+		go servers.AdminGRPCWeb(mgr, webGrpcAddr)
+	}
+
+	if metricsAddr != "" {
+		registry := prometheus.NewRegistry()
+		if err := noir.RegisterMetrics(registry); err != nil {
+			log.Errorf("error registering metrics: %s", err)
+		}
+		metrics := http.NewServeMux()
+		metrics.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+		log.Infof("metrics running at http://%s/metrics", metricsAddr)
+		go http.ListenAndServe(metricsAddr, metrics)
 	}
 
 	if demoAddr != "" {
@@ -0,0 +1,11 @@
+//go:build opus
+// +build opus
+
+package main
+
+import "github.com/net-prophet/noir/pkg/noir/opus"
+
+// mixes encode and decode with libopus
+func init() {
+	audioMixCodec = opus.Codec{}
+}
@@ -8,6 +8,9 @@ require (
 	github.com/golang/protobuf v1.4.3
 	github.com/gorilla/websocket v1.4.2
 	github.com/improbable-eng/grpc-web v0.13.0
+	github.com/klauspost/compress v1.11.12
+	github.com/nats-io/nats.go v1.11.0
+	github.com/pion/interceptor v0.0.5
 	github.com/pion/ion-log v1.0.0
 	github.com/pion/ion-sfu v1.6.4
 	github.com/pion/randutil v0.1.0
@@ -15,9 +18,11 @@ require (
 	github.com/pion/rtp v1.6.1
 	github.com/pion/sdp/v3 v3.0.3
 	github.com/pion/webrtc/v3 v3.0.0-beta.15.0.20201209023348-63401a8837fb
+	github.com/prometheus/client_golang v1.8.0
 	github.com/soheilhy/cmux v0.1.4
 	github.com/sourcegraph/jsonrpc2 v0.0.0-20200429184054-15c2290dcb37
 	github.com/spf13/viper v1.7.1
+	golang.org/x/crypto v0.0.0-20210314154223-e6e6c4f2bb5b
 	golang.org/x/sync v0.0.0-20201207232520-09787c993a3a
 	google.golang.org/grpc v1.35.0
 	google.golang.org/protobuf v1.25.0
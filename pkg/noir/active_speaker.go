@@ -0,0 +1,364 @@
+package noir
+
+import (
+	"errors"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	log "github.com/pion/ion-log"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"github.com/pion/rtp"
+	"github.com/pion/sdp/v3"
+	"github.com/pion/webrtc/v3"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+Active Speaker:
+Browsers put the level of every audio packet in an RFC 6464 header extension when the answer
+asks for it, so every publisher's answer does. Levels are in -dBov, 0 is the loudest and 127
+silence. For rooms with an activeSpeakerThreshold, see EnableActiveSpeaker, each worker reads
+the levels of the audio its peers publish, and every ActiveSpeakerInterval ranks them by how
+much they spoke at the threshold or louder. Once a new peer has been the loudest for
+ActiveSpeakerHold, the room gets an ACTIVE_SPEAKER event and every peer in it an ActiveSpeaker
+reply. Silence keeps the last speaker. Reading the levels costs a little for every audio packet,
+which is why rooms have it off unless they turn it on.
+*/
+
+const (
+	// How often the rooms' speakers are ranked
+	ActiveSpeakerInterval = 500 * time.Millisecond
+	// How long a new speaker has to stay the loudest before the room hears about it
+	ActiveSpeakerHold = time.Second
+
+	audioLevelURI = "urn:ietf:params:rtp-hdrext:ssrc-audio-level"
+)
+
+var ErrBadSpeakerThreshold = errors.New("active speaker threshold must be 1 to 127 -dBov")
+
+// EnableActiveSpeaker turns on active speaker events for the room, peers count as speaking when
+// their audio is threshold -dBov or louder, 127 being silence
+func (m *Manager) EnableActiveSpeaker(sid string, threshold int) error {
+	if threshold < 1 || threshold > 127 {
+		return ErrBadSpeakerThreshold
+	}
+	return m.setSpeakerThreshold(sid, int32(threshold))
+}
+
+// DisableActiveSpeaker turns active speaker events off for the room
+func (m *Manager) DisableActiveSpeaker(sid string) error {
+	return m.setSpeakerThreshold(sid, 0)
+}
+
+func (m *Manager) setSpeakerThreshold(sid string, threshold int32) error {
+	room, err := m.GetRoomData(sid)
+	if err != nil {
+		return err
+	}
+	if room.Options == nil {
+		room.Options = &pb.RoomOptions{MaxAgeSeconds: -1}
+	}
+	room.Options.ActiveSpeakerThreshold = threshold
+	return m.SetRoomData(room)
+}
+
+// AcceptAudioLevel adds the audio level extension the offer has to the answer's audio, so the
+// client sends its levels
+func AcceptAudioLevel(offer webrtc.SessionDescription, answer *webrtc.SessionDescription) error {
+	if answer == nil {
+		return nil
+	}
+	offered, err := offer.Unmarshal()
+	if err != nil {
+		return err
+	}
+	answered, err := answer.Unmarshal()
+	if err != nil {
+		return err
+	}
+	accepted := false
+	for i, media := range answered.MediaDescriptions {
+		if media.MediaName.Media != "audio" || i >= len(offered.MediaDescriptions) {
+			continue
+		}
+		extension, ok := audioLevelAttribute(offered.MediaDescriptions[i])
+		if !ok {
+			continue
+		}
+		if _, ok := audioLevelAttribute(media); !ok {
+			media.Attributes = append(media.Attributes, extension)
+			accepted = true
+		}
+	}
+	if !accepted {
+		return nil
+	}
+	raw, err := answered.Marshal()
+	if err != nil {
+		return err
+	}
+	answer.SDP = string(raw)
+	return nil
+}
+
+func audioLevelAttribute(media *sdp.MediaDescription) (sdp.Attribute, bool) {
+	for _, attr := range media.Attributes {
+		if fields := strings.Fields(attr.Value); attr.Key == "extmap" && len(fields) > 1 && fields[1] == audioLevelURI {
+			return attr, true
+		}
+	}
+	return sdp.Attribute{}, false
+}
+
+// audioLevelID is the extension id the client's levels come in, 0 when it does not send them
+func audioLevelID(desc *webrtc.SessionDescription) uint8 {
+	if desc == nil {
+		return 0
+	}
+	parsed, err := desc.Unmarshal()
+	if err != nil {
+		return 0
+	}
+	for _, media := range parsed.MediaDescriptions {
+		if media.MediaName.Media != "audio" {
+			continue
+		}
+		if attr, ok := audioLevelAttribute(media); ok {
+			id, err := strconv.Atoi(strings.Split(strings.Fields(attr.Value)[0], "/")[0])
+			if err == nil && id > 0 && id < 256 {
+				return uint8(id)
+			}
+		}
+	}
+	return 0
+}
+
+// speakerTracker ranks the speakers of one room on this worker
+type speakerTracker struct {
+	roomID     string
+	observerID string
+	mu         sync.Mutex
+	threshold  uint8
+	// how much each peer spoke since the last rank, and the sum of its levels over its packets
+	loudness  map[string]int
+	levelSums map[string]int
+	packets   map[string]int
+	speaker   string
+	candidate string
+	since     time.Time
+	// only touched by the ranking goroutine
+	observed map[sfu.Receiver]bool
+}
+
+func newSpeakerTracker(roomID string) *speakerTracker {
+	return &speakerTracker{
+		roomID:     roomID,
+		observerID: "active-speaker-" + RandomString(12),
+		loudness:   map[string]int{},
+		levelSums:  map[string]int{},
+		packets:    map[string]int{},
+		observed:   map[sfu.Receiver]bool{},
+	}
+}
+
+// hear counts one of pid's audio packets
+func (s *speakerTracker) hear(pid string, level uint8) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if level > s.threshold {
+		return
+	}
+	s.loudness[pid] += 128 - int(level)
+	s.levelSums[pid] += int(level)
+	s.packets[pid]++
+}
+
+// rank picks the loudest peer since the last rank, true when it takes over as the speaker
+func (s *speakerTracker) rank(now time.Time) (string, int32, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	loudest, most := "", 0
+	for pid, loudness := range s.loudness {
+		if loudness > most || (loudness == most && pid < loudest) {
+			loudest, most = pid, loudness
+		}
+	}
+	level := int32(127)
+	if loudest != "" {
+		level = int32(s.levelSums[loudest] / s.packets[loudest])
+	}
+	s.loudness, s.levelSums, s.packets = map[string]int{}, map[string]int{}, map[string]int{}
+
+	if loudest == "" || loudest == s.speaker {
+		s.candidate = ""
+		return s.speaker, level, false
+	}
+	if loudest != s.candidate {
+		s.candidate, s.since = loudest, now
+	}
+	if s.speaker != "" && now.Sub(s.since) < ActiveSpeakerHold {
+		return s.speaker, level, false
+	}
+	s.speaker, s.candidate = loudest, ""
+	return loudest, level, true
+}
+
+// levelReader is the downtrack a tracker observes one of pid's audio tracks with
+type levelReader struct {
+	tracker *speakerTracker
+	pid     string
+	id      uint8
+}
+
+func (r *levelReader) WriteRTP(header *rtp.Header, payload []byte) (int, error) {
+	if raw := header.GetExtension(r.id); raw != nil {
+		var level rtp.AudioLevelExtension
+		if err := level.Unmarshal(raw); err == nil {
+			r.tracker.hear(r.pid, level.Level)
+		}
+	}
+	return len(payload), nil
+}
+
+func (r *levelReader) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+// observe starts reading the levels of audio tracks published in the room it was not reading yet
+func (m *Manager) observe(tracker *speakerTracker) {
+	current := map[sfu.Receiver]bool{}
+	for _, pid := range m.redis.HKeys(m.Keys().RoomUsers(tracker.roomID)).Val() {
+		peer := m.localPeer(pid)
+		if peer == nil {
+			continue
+		}
+		publisher := publisherOf(peer)
+		pc := publisherConnection(publisher)
+		if pc == nil {
+			continue
+		}
+		id := audioLevelID(pc.RemoteDescription())
+		if id == 0 {
+			continue
+		}
+		for _, receiver := range receiversOf(publisher) {
+			if receiver.Kind() != webrtc.RTPCodecTypeAudio {
+				continue
+			}
+			current[receiver] = true
+			if tracker.observed[receiver] {
+				continue
+			}
+			if err := observeReceiver(receiver, tracker.observerID, &levelReader{tracker, pid, id}); err != nil {
+				log.Errorf("error reading audio levels of %s: %s", pid, err)
+				continue
+			}
+			tracker.observed[receiver] = true
+		}
+	}
+	// receivers of peers that left are gone with them
+	for receiver := range tracker.observed {
+		if !current[receiver] {
+			delete(tracker.observed, receiver)
+		}
+	}
+}
+
+// stopObserving stops reading every level the tracker reads
+func (s *speakerTracker) stopObserving() {
+	for receiver := range s.observed {
+		receiver.DeleteDownTrack(0, s.observerID)
+	}
+	s.observed = map[sfu.Receiver]bool{}
+}
+
+// rankSpeakers ranks every local room that has active speaker on, once
+func (m *Manager) rankSpeakers(trackers map[string]*speakerTracker, now time.Time) {
+	m.mu.RLock()
+	local := make(map[string]bool, len(m.rooms))
+	for roomID := range m.rooms {
+		local[roomID] = true
+	}
+	m.mu.RUnlock()
+	for roomID, tracker := range trackers {
+		if !local[roomID] {
+			tracker.stopObserving()
+			delete(trackers, roomID)
+		}
+	}
+
+	for roomID := range local {
+		tracker := trackers[roomID]
+		room, err := m.GetRoomData(roomID)
+		threshold := room.GetOptions().GetActiveSpeakerThreshold()
+		if err != nil || threshold <= 0 {
+			if tracker != nil {
+				tracker.stopObserving()
+				delete(trackers, roomID)
+			}
+			continue
+		}
+		if tracker == nil {
+			tracker = newSpeakerTracker(roomID)
+			trackers[roomID] = tracker
+		}
+		tracker.mu.Lock()
+		tracker.threshold = uint8(threshold)
+		tracker.mu.Unlock()
+
+		m.observe(tracker)
+		if pid, level, changed := tracker.rank(now); changed {
+			m.announceSpeaker(roomID, pid, level)
+		}
+	}
+}
+
+// announceSpeaker tells the room and each of its peers who speaks now
+func (m *Manager) announceSpeaker(roomID string, speaker string, level int32) {
+	log.Debugf("%s speaks in %s at -%ddBov", speaker, roomID, level)
+	err := m.PublishRoomEvent(&pb.RoomEvent{
+		Type:       pb.RoomEvent_ACTIVE_SPEAKER,
+		Pid:        speaker,
+		Sid:        roomID,
+		AudioLevel: level,
+	})
+	if err != nil {
+		log.Errorf("error announcing the speaker of %s: %s", roomID, err)
+	}
+	for _, pid := range m.redis.HKeys(m.Keys().RoomUsers(roomID)).Val() {
+		err := m.EnqueueReply(m.GetQueue(m.Keys().TopicFromPeer(pid)), &pb.NoirReply{
+			Command: &pb.NoirReply_Signal{
+				Signal: &pb.SignalReply{
+					Id: pid,
+					Payload: &pb.SignalReply_ActiveSpeaker{
+						ActiveSpeaker: &pb.ActiveSpeaker{Pid: speaker, AudioLevel: level},
+					},
+				},
+			},
+		})
+		if err != nil {
+			log.Debugf("error telling %s who speaks: %s", pid, err)
+			continue
+		}
+		m.redis.Publish(m.Keys().PeerNewsChannel(pid), pid)
+	}
+}
+
+// watchSpeakers ranks the speakers of this worker's rooms until the worker stops
+func (w *worker) watchSpeakers() {
+	ticker := time.NewTicker(ActiveSpeakerInterval)
+	defer ticker.Stop()
+	trackers := map[string]*speakerTracker{}
+	for {
+		select {
+		case <-w.done:
+			for _, tracker := range trackers {
+				tracker.stopObserving()
+			}
+			return
+		case now := <-ticker.C:
+			w.manager.rankSpeakers(trackers, now)
+		}
+	}
+}
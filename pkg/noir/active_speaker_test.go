@@ -0,0 +1,89 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/webrtc/v3"
+	"strings"
+	"testing"
+	"time"
+)
+
+const audioLevelOffer = "v=0\r\no=- 1 2 IN IP4 127.0.0.1\r\ns=-\r\nt=0 0\r\na=group:BUNDLE 0\r\nm=audio 9 UDP/TLS/RTP/SAVPF 111\r\nc=IN IP4 0.0.0.0\r\na=mid:0\r\na=extmap:1 urn:ietf:params:rtp-hdrext:ssrc-audio-level\r\na=extmap:3 urn:ietf:params:rtp-hdrext:sdes:mid\r\na=sendonly\r\na=rtpmap:111 opus/48000/2\r\n"
+
+const audioLevelAnswer = "v=0\r\no=- 3 4 IN IP4 127.0.0.1\r\ns=-\r\nt=0 0\r\na=group:BUNDLE 0\r\nm=audio 9 UDP/TLS/RTP/SAVPF 111\r\nc=IN IP4 0.0.0.0\r\na=mid:0\r\na=extmap:3 urn:ietf:params:rtp-hdrext:sdes:mid\r\na=recvonly\r\na=rtpmap:111 opus/48000/2\r\n"
+
+func TestAcceptAudioLevel(t *testing.T) {
+	offer := webrtc.SessionDescription{Type: webrtc.SDPTypeOffer, SDP: audioLevelOffer}
+	answer := &webrtc.SessionDescription{Type: webrtc.SDPTypeAnswer, SDP: audioLevelAnswer}
+	if id := audioLevelID(answer); id != 0 {
+		t.Errorf("got extension %d want none before accepting", id)
+	}
+	if err := AcceptAudioLevel(offer, answer); err != nil {
+		t.Fatalf("error accepting audio levels: %s", err)
+	}
+	if !strings.Contains(answer.SDP, "a=extmap:1 urn:ietf:params:rtp-hdrext:ssrc-audio-level") {
+		t.Errorf("got %s want the offer's audio level extension", answer.SDP)
+	}
+	if id := audioLevelID(&offer); id != 1 {
+		t.Errorf("got extension %d want 1", id)
+	}
+}
+
+func TestSpeakerTracker(t *testing.T) {
+	tracker := newSpeakerTracker("speakers")
+	tracker.threshold = 60
+	now := time.Now()
+	talk := func(pid string, level uint8, packets int) {
+		for i := 0; i < packets; i++ {
+			tracker.hear(pid, level)
+		}
+	}
+
+	talk("alice", 30, 25)
+	talk("bob", 80, 50) // quieter than the threshold, not speaking
+	if pid, level, changed := tracker.rank(now); !changed || pid != "alice" || level != 30 {
+		t.Fatalf("got %s at %d (%v) want alice to take over a quiet room", pid, level, changed)
+	}
+
+	// bob has to stay the loudest for ActiveSpeakerHold
+	talk("bob", 20, 25)
+	talk("alice", 40, 5)
+	if pid, _, changed := tracker.rank(now.Add(ActiveSpeakerInterval)); changed || pid != "alice" {
+		t.Errorf("got %s (%v) want alice to keep speaking through an interjection", pid, changed)
+	}
+	if _, _, changed := tracker.rank(now.Add(2 * ActiveSpeakerInterval)); changed {
+		t.Errorf("silence should keep the speaker")
+	}
+	talk("bob", 20, 25)
+	tracker.rank(now.Add(3 * ActiveSpeakerInterval))
+	talk("bob", 20, 25)
+	if pid, _, changed := tracker.rank(now.Add(3*ActiveSpeakerInterval + ActiveSpeakerHold)); !changed || pid != "bob" {
+		t.Errorf("got %s (%v) want bob after being the loudest for ActiveSpeakerHold", pid, changed)
+	}
+}
+
+func TestEnableActiveSpeaker(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	redis.Del(pb.KeyRoomData("speakers"))
+	defer redis.Del(pb.KeyRoomData("speakers"))
+	mgr.SetRoomData(&pb.RoomData{Id: "speakers"})
+
+	if err := mgr.EnableActiveSpeaker("speakers", 128); err != ErrBadSpeakerThreshold {
+		t.Errorf("got %v want a threshold past silence refused", err)
+	}
+	if err := mgr.EnableActiveSpeaker("speakers", 50); err != nil {
+		t.Fatalf("error enabling active speaker: %s", err)
+	}
+	room, _ := mgr.GetRoomData("speakers")
+	if threshold := room.GetOptions().GetActiveSpeakerThreshold(); threshold != 50 {
+		t.Errorf("got threshold %d want 50", threshold)
+	}
+	mgr.DisableActiveSpeaker("speakers")
+	room, _ = mgr.GetRoomData("speakers")
+	if threshold := room.GetOptions().GetActiveSpeakerThreshold(); threshold != 0 {
+		t.Errorf("got threshold %d want active speaker off", threshold)
+	}
+	if err := mgr.EnableActiveSpeaker("no-such-room", 50); err == nil {
+		t.Errorf("a missing room should fail")
+	}
+}
@@ -0,0 +1,341 @@
+package noir
+
+import (
+	"errors"
+	"fmt"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"github.com/pion/rtcp"
+	"github.com/pion/webrtc/v3"
+	"sync"
+	"time"
+)
+
+/*
+Adaptive Layers:
+A subscriber on a poor network is better off with a lower simulcast layer than with one it can not
+take, and should not need its app to watch the connection and call setlayer. A peer that joined
+with adaptiveLayers has its layers picked for it while its PeerChannel runs: every
+AdaptiveLayerInterval its latest bandwidth estimate is shared out and each simulcast track it
+receives is switched to the highest layer the publisher sends that fits its share. ion-sfu v1.6
+negotiates goog-remb with subscribers and not transport-cc, so the estimate is the REMB the
+subscriber's browser sends, at most its subscribeKbps, and one older than REMBTimeout is not used. As in rtcp_feedback.go
+each video track the subscriber receives is counted an equal share of the estimate, here of the
+TargetUtilization of it, and a track switches
+
+	down  as soon as its share no longer fits the layer it is sent
+	up    once its share fits the higher layer with Hysteresis to spare, and has for
+	      AdaptiveLayerUpHold
+
+so an estimate hovering around a layer's bitrate does not flip the track between two layers. Each
+switch is sent to the peer as an adapted SetLayerReply with the estimate it was switched for, and
+a setlayer of the peer's own is the highest layer its stream is adapted to from then on. ion-sfu
+does not measure the bitrate of each layer, they are taken to be LayerKbps, by default about what
+browsers simulcast a 720p camera at. A switch the sfu has not taken yet is tried again.
+*/
+
+const (
+	// How often the layers of a peer with adaptiveLayers are picked
+	AdaptiveLayerInterval = time.Second
+	// How long a track's share has to fit a higher layer before it is switched up
+	AdaptiveLayerUpHold = 5 * time.Second
+)
+
+// Defaults for Manager.AdaptiveLayers()
+const (
+	DefaultTargetUtilization = 0.85
+	DefaultLayerHysteresis   = 0.2
+)
+
+// DefaultLayerKbps are the bitrates of the spatial layers of a 720p camera, lowest first
+var DefaultLayerKbps = []int{150, 500, 1500}
+
+var ErrInvalidAdaptiveLayers = errors.New("invalid adaptive layers")
+
+// AdaptiveLayerConfig tunes adaptive layers, from the [adaptivelayers] config
+type AdaptiveLayerConfig struct {
+	// TargetUtilization is the share of its estimate a subscriber's video may take, 0 is the default
+	TargetUtilization float64 `mapstructure:"targetutilization"`
+	// Hysteresis is how much more than a higher layer's bitrate a share has to be to switch up to
+	// it, 0 is the default and a negative one switches up as soon as the layer fits
+	Hysteresis float64 `mapstructure:"hysteresis"`
+	// LayerKbps are the bitrates of the spatial layers, lowest first, empty is DefaultLayerKbps
+	LayerKbps []int `mapstructure:"layerkbps"`
+}
+
+// ValidateAdaptiveLayers refuses utilizations outside 0 to 1 and layers that do not rise
+func ValidateAdaptiveLayers(config AdaptiveLayerConfig) error {
+	if config.TargetUtilization < 0 || config.TargetUtilization > 1 {
+		return fmt.Errorf("%w: targetUtilization %g is not between 0 and 1", ErrInvalidAdaptiveLayers, config.TargetUtilization)
+	}
+	if len(config.LayerKbps) == 0 {
+		return nil
+	}
+	if len(config.LayerKbps) != MaxSpatialLayer+1 {
+		return fmt.Errorf("%w: %d layerKbps, one for each of the %d layers", ErrInvalidAdaptiveLayers, len(config.LayerKbps), MaxSpatialLayer+1)
+	}
+	for layer, kbps := range config.LayerKbps {
+		if kbps <= 0 || (layer > 0 && kbps <= config.LayerKbps[layer-1]) {
+			return fmt.Errorf("%w: layerKbps %v do not rise from low to high", ErrInvalidAdaptiveLayers, config.LayerKbps)
+		}
+	}
+	return nil
+}
+
+// SetAdaptiveLayers tunes the adaptive layers of peers joining from now on
+func (m *Manager) SetAdaptiveLayers(config AdaptiveLayerConfig) error {
+	if err := ValidateAdaptiveLayers(config); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.adaptiveLayers = config
+	return nil
+}
+
+// AdaptiveLayers is the manager's AdaptiveLayerConfig, with the defaults of what it leaves out
+func (m *Manager) AdaptiveLayers() AdaptiveLayerConfig {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	config := m.adaptiveLayers
+	if config.TargetUtilization == 0 {
+		config.TargetUtilization = DefaultTargetUtilization
+	}
+	if config.Hysteresis == 0 {
+		config.Hysteresis = DefaultLayerHysteresis
+	} else if config.Hysteresis < 0 {
+		config.Hysteresis = 0
+	}
+	if len(config.LayerKbps) == 0 {
+		config.LayerKbps = DefaultLayerKbps
+	}
+	return config
+}
+
+// AdaptLayer is the layer up to highest a track sent current should be sent for a share of share
+// bits per second, lower at once when current does not fit and higher only with Hysteresis to
+// spare. The config needs its defaults, see Manager.AdaptiveLayers
+func (c AdaptiveLayerConfig) AdaptLayer(current int32, highest int32, share uint64) int32 {
+	fits := func(layer int32, headroom float64) bool {
+		return float64(c.LayerKbps[layer])*1000*(1+headroom) <= float64(share)
+	}
+	if current > highest {
+		current = highest
+	}
+	if !fits(current, 0) {
+		for current > 0 && !fits(current, 0) {
+			current--
+		}
+		return current
+	}
+	for current < highest && fits(current+1, c.Hysteresis) {
+		current++
+	}
+	return current
+}
+
+// adaptedTrack is what a layerAdapter knows of a downtrack
+type adaptedTrack struct {
+	streamID string
+	layer    int32
+	// since when the track could go up a layer, zero while it can not
+	upSince time.Time
+}
+
+// layerAdapter picks the layers of a subscriber's simulcast tracks from its REMBs
+type layerAdapter struct {
+	mu     sync.Mutex
+	config AdaptiveLayerConfig
+	tracks map[*sfu.DownTrack]*adaptedTrack
+	// the subscriber's last estimate, of its whole downlink
+	estimate   uint64
+	estimateAt time.Time
+	// the subscriber's subscribe cap, see bitrate.go
+	subscribeCap uint64
+	// the highest layer the peer asked for of each stream, by stream id
+	ceilings map[string]int32
+}
+
+func newLayerAdapter(config AdaptiveLayerConfig) *layerAdapter {
+	return &layerAdapter{config: config, tracks: map[*sfu.DownTrack]*adaptedTrack{}, ceilings: map[string]int32{}}
+}
+
+// watch adapts the subscriber's new video downtracks, and forgets the ones it lost
+func (a *layerAdapter) watch(tracks []*sfu.DownTrack) {
+	current := make(map[*sfu.DownTrack]bool, len(tracks))
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, track := range tracks {
+		current[track] = true
+		if _, observed := a.tracks[track]; !observed && downTrackSender(track) != nil {
+			a.tracks[track] = &adaptedTrack{streamID: track.StreamID(), layer: downTrackLayer(track)}
+		}
+	}
+	for track := range a.tracks {
+		if !current[track] {
+			delete(a.tracks, track)
+		}
+	}
+}
+
+// observe keeps the estimate of the last REMB among packets
+func (a *layerAdapter) observe(packets []rtcp.Packet, now time.Time) {
+	for _, packet := range packets {
+		if remb, ok := packet.(*rtcp.ReceiverEstimatedMaximumBitrate); ok {
+			a.mu.Lock()
+			a.estimate, a.estimateAt = capBitrate(remb.Bitrate, a.subscribeCap), now
+			a.mu.Unlock()
+		}
+	}
+}
+
+// share is the estimate, and what each of tracks video tracks may take of it, false without a recent one
+func (a *layerAdapter) share(tracks int, now time.Time) (uint64, uint64, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if tracks == 0 || a.estimate == 0 || now.Sub(a.estimateAt) >= REMBTimeout {
+		return 0, 0, false
+	}
+	return a.estimate, uint64(float64(a.estimate) * a.config.TargetUtilization / float64(tracks)), true
+}
+
+// next is the layer the track should be switched to, its own layer to leave it be
+func (a *layerAdapter) next(track *adaptedTrack, highest int32, share uint64, now time.Time) int32 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if ceiling, ok := a.ceilings[track.streamID]; ok && ceiling < highest {
+		highest = ceiling
+	}
+	layer := a.config.AdaptLayer(track.layer, highest, share)
+	if layer <= track.layer {
+		track.upSince = time.Time{}
+		return layer
+	}
+	if track.upSince.IsZero() {
+		track.upSince = now
+	}
+	if now.Sub(track.upSince) < AdaptiveLayerUpHold {
+		return track.layer
+	}
+	return layer
+}
+
+// switched records the track is sent layer now
+func (a *layerAdapter) switched(track *adaptedTrack, layer int32) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	track.layer, track.upSince = layer, time.Time{}
+}
+
+// cap keeps the stream at most at the layer the peer switched it to itself
+func (a *layerAdapter) cap(streamID string, layer int32) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.ceilings[streamID] = layer
+	for _, track := range a.tracks {
+		if track.streamID == streamID {
+			track.layer, track.upSince = layer, time.Time{}
+		}
+	}
+}
+
+// highestLayer is the highest spatial layer the publisher sends of the downtrack's track
+func highestLayer(track *sfu.DownTrack) int32 {
+	receiver := downTrackReceiver(track)
+	if receiver == nil {
+		return 0
+	}
+	for layer := MaxSpatialLayer; layer > 0; layer-- {
+		if receiver.SSRC(layer) != 0 {
+			return int32(layer)
+		}
+	}
+	return 0
+}
+
+// capAdaptedLayer has a peer's adaptive layers keep to the layer it switched a stream to itself
+func (w *worker) capAdaptedLayer(pid string, streamID string, layer int32) {
+	if adapter, ok := w.layerAdapters.Load(pid); ok {
+		adapter.(*layerAdapter).cap(streamID, layer)
+	}
+}
+
+// adaptLayers switches the peer's simulcast tracks to the layers its estimate fits every
+// AdaptiveLayerInterval, until stop is closed
+func (w *worker) adaptLayers(pid string, peer *sfu.Peer, adapter *layerAdapter, stop <-chan struct{}, logger Logger) {
+	ticker := time.NewTicker(AdaptiveLayerInterval)
+	defer ticker.Stop()
+	var unobserve func()
+	defer func() {
+		if unobserve != nil {
+			unobserve()
+		}
+	}()
+	for {
+		var now time.Time
+		select {
+		case now = <-ticker.C:
+		case <-stop:
+			return
+		}
+		subscriber := subscriberOf(peer)
+		if subscriber == nil {
+			continue
+		}
+		if unobserve == nil {
+			var ok bool
+			unobserve, ok = observeSubscriberRTCP(subscriber, func(packets []rtcp.Packet) { adapter.observe(packets, time.Now()) })
+			if !ok {
+				continue
+			}
+		}
+		videos := []*sfu.DownTrack{}
+		for _, track := range downTracksOf(subscriber) {
+			if track.Kind() == webrtc.RTPCodecTypeVideo {
+				videos = append(videos, track)
+			}
+		}
+		adapter.watch(videos)
+		estimate, share, ok := adapter.share(len(videos), now)
+		if !ok {
+			continue
+		}
+		for _, track := range videos {
+			adapter.mu.Lock()
+			adapted, observed := adapter.tracks[track]
+			adapter.mu.Unlock()
+			if !observed || downTrackLayer(track) < 0 {
+				continue
+			}
+			layer := adapter.next(adapted, highestLayer(track), share, now)
+			if layer == adapted.layer {
+				continue
+			}
+			applied, temporal, ok := switchDownTrack(track, layer)
+			if !ok || applied != layer {
+				continue
+			}
+			adapter.switched(adapted, layer)
+			logger.Debugf("adapted %s to layer %d for an estimate of %d kbps", adapted.streamID, layer, estimate/1000)
+			err := w.SignalReply(pid, &pb.NoirReply{
+				Command: &pb.NoirReply_Signal{
+					Signal: &pb.SignalReply{
+						Id: pid,
+						Payload: &pb.SignalReply_SetLayer{
+							SetLayer: &pb.SetLayerReply{
+								StreamId:     adapted.streamID,
+								Spatial:      applied,
+								Temporal:     temporal,
+								Adapted:      true,
+								EstimateKbps: uint32(estimate / 1000),
+							},
+						},
+					},
+				},
+			})
+			if err != nil {
+				logger.Debugf("error sending an adapted layer: %s", err)
+			}
+		}
+	}
+}
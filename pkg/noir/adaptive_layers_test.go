@@ -0,0 +1,116 @@
+package noir
+
+import (
+	"errors"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"github.com/pion/rtcp"
+	"github.com/pion/webrtc/v3"
+	"testing"
+	"time"
+)
+
+func TestAdaptLayer(t *testing.T) {
+	mgr, _ := NewTestSetup()
+	config := mgr.AdaptiveLayers()
+	if config.TargetUtilization != DefaultTargetUtilization || config.Hysteresis != DefaultLayerHysteresis || len(config.LayerKbps) != 3 {
+		t.Errorf("got %+v want the defaults", config)
+	}
+	for _, test := range []struct {
+		current, highest int32
+		share            uint64
+		want             int32
+	}{
+		// down as soon as the layer does not fit
+		{2, 2, 1400000, 1},
+		{2, 2, 100000, 0},
+		{1, 2, 499999, 0},
+		// the lowest layer is kept however little fits
+		{0, 2, 10000, 0},
+		// staying while the layer fits, up only with the hysteresis to spare
+		{1, 2, 1500000, 1},
+		{1, 2, 1799999, 1},
+		{1, 2, 1800000, 2},
+		{0, 2, 5000000, 2},
+		// never above what the publisher sends
+		{0, 1, 5000000, 1},
+		{2, 1, 5000000, 1},
+	} {
+		if got := config.AdaptLayer(test.current, test.highest, test.share); got != test.want {
+			t.Errorf("got layer %d want %d from %d of %d for %d bps", got, test.want, test.current, test.highest, test.share)
+		}
+	}
+
+	if err := mgr.SetAdaptiveLayers(AdaptiveLayerConfig{Hysteresis: -1, LayerKbps: []int{100, 300, 900}}); err != nil {
+		t.Fatalf("error tuning adaptive layers: %s", err)
+	}
+	if got := mgr.AdaptiveLayers().AdaptLayer(0, 2, 900000); got != 2 {
+		t.Errorf("got layer %d want up as soon as it fits without hysteresis", got)
+	}
+	for _, config := range []AdaptiveLayerConfig{
+		{TargetUtilization: -0.1},
+		{LayerKbps: []int{100, 300}},
+		{LayerKbps: []int{100, 0, 900}},
+	} {
+		if err := mgr.SetAdaptiveLayers(config); !errors.Is(err, ErrInvalidAdaptiveLayers) {
+			t.Errorf("got %v want %+v refused", err, config)
+		}
+	}
+}
+
+func TestLayerAdapter(t *testing.T) {
+	mgr, _ := NewTestSetup()
+	adapter := newLayerAdapter(mgr.AdaptiveLayers())
+	now := time.Now()
+	if _, _, ok := adapter.share(2, now); ok {
+		t.Errorf("there is no share without an estimate")
+	}
+	adapter.observe([]rtcp.Packet{&rtcp.ReceiverEstimatedMaximumBitrate{Bitrate: 4000000, SSRCs: []uint32{1, 2}}}, now)
+	estimate, share, ok := adapter.share(2, now)
+	if !ok || estimate != 4000000 || share != 1700000 {
+		t.Errorf("got %d of %d want each of 2 tracks its share of 85%%", share, estimate)
+	}
+	if _, _, ok := adapter.share(2, now.Add(REMBTimeout)); ok {
+		t.Errorf("an estimate past REMBTimeout should not count")
+	}
+
+	track := &adaptedTrack{streamID: "camera", layer: 0}
+	// up only once the share held for AdaptiveLayerUpHold
+	if layer := adapter.next(track, 2, 1900000, now); layer != 0 {
+		t.Errorf("got layer %d want the track held", layer)
+	}
+	if layer := adapter.next(track, 2, 1900000, now.Add(AdaptiveLayerUpHold/2)); layer != 0 {
+		t.Errorf("got layer %d want the track still held", layer)
+	}
+	if layer := adapter.next(track, 2, 1900000, now.Add(AdaptiveLayerUpHold)); layer != 2 {
+		t.Errorf("got layer %d want the track up once the share held", layer)
+	}
+	adapter.switched(track, 2)
+	// down at once, and a dip restarts the hold
+	if layer := adapter.next(track, 2, 600000, now.Add(AdaptiveLayerUpHold)); layer != 1 {
+		t.Errorf("got layer %d want the track down at once", layer)
+	}
+	adapter.switched(track, 1)
+	adapter.next(track, 2, 1900000, now)
+	adapter.next(track, 2, 600000, now.Add(time.Second))
+	if layer := adapter.next(track, 2, 1900000, now.Add(AdaptiveLayerUpHold)); layer != 1 {
+		t.Errorf("got layer %d want the hold restarted by the dip", layer)
+	}
+
+	// the peer's own setlayer caps the stream
+	receiver := sfu.NewWebRTCReceiver(nil, &webrtc.TrackRemote{}, "adapted-pub")
+	downTrack, _ := sfu.NewDownTrack(webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeVP8}, receiver, "adapted")
+	adapter.tracks[downTrack] = track
+	adapter.cap("camera", 0)
+	if track.layer != 0 {
+		t.Errorf("got layer %d want the peer's switch known", track.layer)
+	}
+	adapter.next(track, 2, 5000000, now)
+	if layer := adapter.next(track, 2, 5000000, now.Add(AdaptiveLayerUpHold)); layer != 0 {
+		t.Errorf("got layer %d want the stream kept to the peer's layer", layer)
+	}
+	other := &adaptedTrack{streamID: "screen", layer: 0}
+	adapter.next(other, 2, 5000000, now)
+	if layer := adapter.next(other, 2, 5000000, now.Add(AdaptiveLayerUpHold)); layer != 2 {
+		t.Errorf("got layer %d want other streams adapted", layer)
+	}
+}
@@ -0,0 +1,52 @@
+package noir
+
+import (
+	"errors"
+	"fmt"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	log "github.com/pion/ion-log"
+)
+
+/*
+Announcements:
+A moderator or admin can send everyone in a room a notice, eg. "meeting ends in 5 minutes",
+with the room admin announce action. The manager running it writes the notice to each peer's
+from-peer topic, found through the room's peer index, so peers on every worker get it. Unlike
+data relays it comes from the server and needs a moderator. A peer that left while the
+announcement went out is skipped and not counted as delivered.
+*/
+
+var ErrEmptyAnnouncement = errors.New("an announcement needs a text")
+
+// Announce sends the announcement to every peer in roomID, and returns how many it reached
+func (m *Manager) Announce(roomID string, announcement *pb.Announcement) (int, error) {
+	if announcement.GetText() == "" {
+		return 0, ErrEmptyAnnouncement
+	}
+	pids, err := m.redis.HKeys(m.Keys().RoomUsers(roomID)).Result()
+	if err != nil {
+		return 0, fmt.Errorf("error listing the peers of %s: %w", roomID, err)
+	}
+	delivered := 0
+	for _, pid := range pids {
+		// the peer may have left since the room was listed
+		if !m.redis.HExists(m.Keys().RoomUsers(roomID), pid).Val() {
+			continue
+		}
+		err := m.EnqueueReply(m.GetQueue(m.Keys().TopicFromPeer(pid)), &pb.NoirReply{
+			Command: &pb.NoirReply_Signal{
+				Signal: &pb.SignalReply{
+					Id:      pid,
+					Payload: &pb.SignalReply_Announcement{Announcement: announcement},
+				},
+			},
+		})
+		if err != nil {
+			log.Debugf("error announcing to %s: %s", pid, err)
+			continue
+		}
+		m.redis.Publish(m.Keys().PeerNewsChannel(pid), pid)
+		delivered++
+	}
+	return delivered, nil
+}
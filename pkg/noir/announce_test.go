@@ -0,0 +1,72 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"google.golang.org/protobuf/proto"
+	"testing"
+)
+
+func TestAnnounce(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	roomID := "announced"
+	pids := []string{"announced-a", "announced-b"}
+	redis.Del(pb.KeyRoomUsers(roomID))
+	for _, pid := range pids {
+		redis.HSet(pb.KeyRoomUsers(roomID), pid, 1)
+		mgr.GetQueue(pb.KeyTopicFromPeer(pid)).Cleanup()
+	}
+	defer redis.Del(pb.KeyRoomUsers(roomID))
+
+	if _, err := mgr.Announce(roomID, &pb.Announcement{}); err != ErrEmptyAnnouncement {
+		t.Errorf("got %v want an empty announcement refused", err)
+	}
+
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+		AdminID: "announcer",
+		Command: &pb.NoirRequest_Admin{
+			Admin: &pb.AdminRequest{
+				Payload: &pb.AdminRequest_RoomAdmin{
+					RoomAdmin: &pb.RoomAdminRequest{
+						RoomID: roomID,
+						Method: &pb.RoomAdminRequest_Announce{
+							Announce: &pb.Announcement{Text: "meeting ends in 5 minutes", Severity: pb.Announcement_WARNING},
+						},
+					},
+				},
+			},
+		},
+	})
+	if err := worker.HandleNext(0); err != nil {
+		t.Fatalf("error announcing: %s", err)
+	}
+
+	adminReply := pb.NoirReply{}
+	msg, err := mgr.GetQueue(pb.KeyTopicToAdmin("announcer")).Next()
+	if err != nil {
+		t.Fatalf("no admin reply: %s", err)
+	}
+	proto.Unmarshal(msg, &adminReply)
+	if delivered := adminReply.GetAdmin().GetRoomAdmin().GetAnnounce().GetDelivered(); delivered != 2 {
+		t.Errorf("got %d delivered want 2", delivered)
+	}
+
+	for _, pid := range pids {
+		reply := pb.NoirReply{}
+		msg, err := mgr.GetQueue(pb.KeyTopicFromPeer(pid)).Next()
+		if err != nil {
+			t.Fatalf("%s got no announcement: %s", pid, err)
+		}
+		proto.Unmarshal(msg, &reply)
+		announcement := reply.GetSignal().GetAnnouncement()
+		if announcement.GetText() != "meeting ends in 5 minutes" || announcement.GetSeverity() != pb.Announcement_WARNING || reply.GetSignal().GetId() != pid {
+			t.Errorf("got %s want the announcement for %s", &reply, pid)
+		}
+	}
+
+	// once everyone left there is nobody to deliver to
+	redis.Del(pb.KeyRoomUsers(roomID))
+	if delivered, err := mgr.Announce(roomID, &pb.Announcement{Text: "anyone?"}); err != nil || delivered != 0 {
+		t.Errorf("got %d, %v want nothing delivered to an empty room", delivered, err)
+	}
+}
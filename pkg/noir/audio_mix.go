@@ -0,0 +1,237 @@
+package noir
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	log "github.com/pion/ion-log"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+Audio Mix:
+A room's audio mix is one Opus track of everyone in it talking, for SIP bridges and other
+listeners that take a single stream. EnableAudioMix has a worker run the AudioMixJobHandler job,
+jobs.NewAudioMixHandler, which joins the room as AudioMixPeerID, subscribes to every audio track
+published in it and publishes the mix back into the room: each AudioMixFrame the next AudioMixFrame
+of every track is summed, clipped and encoded. Tracks are mixed from the moment they are
+published until they end, a track falling behind is cut to AudioMixBacklog of its latest audio
+and a silent one adds nothing, so a late or lost packet does not hold up the mix. The mixes of
+other rooms or of the same room are not mixed in.
+
+EnableAudioMixExcluding leaves a peer's own audio out of the mix, n-1 mixing, so a listener
+bridged in as that peer does not hear itself. Each mix decodes every audio track of the room, so
+mixes are only run on request, DisableAudioMix ends one.
+
+Mixes need an AudioCodec, EnableAudioMix fails with ErrNoAudioCodec until SetAudioMixCodec was
+given one. The Opus one, package opus, binds libopus and is only built with the opus tag, so a
+stock build has none: cmd/noir built with -tags opus, make build_opus, mixes, and an embedder
+passes opus.Codec{} or its own. Decoders are handed each rtp payload of a track and give its
+audio as mono AudioMixRate pcm, encoders are handed a frame of the mix at a time.
+
+With Exclude set, a track whose publisher is not known yet, its TrackInfos can come after the
+track, is left out until it is: it could be the excluded peer's.
+*/
+
+// AudioMixJobHandler is the job audio mixes run as, register jobs.NewAudioMixHandler under it
+const AudioMixJobHandler = "AudioMix"
+
+const (
+	// The sample rate of the pcm mixed, mono
+	AudioMixRate = 48000
+	// How long each frame of the mix is
+	AudioMixFrame = 20 * time.Millisecond
+	// The samples of a frame of the mix
+	AudioMixFrameSamples = AudioMixRate / int(time.Second/AudioMixFrame)
+	// How much of a track's audio waits to be mixed at most, older audio is dropped
+	AudioMixBacklog = 5 * AudioMixFrame
+)
+
+var ErrNoAudioCodec = errors.New("no audio codec, see SetAudioMixCodec")
+
+// AudioMixOptions are the RoomJobRequest options of an AudioMixJobHandler job
+type AudioMixOptions struct {
+	MixID string `json:"mix_id"`
+	// Exclude is the pid whose audio is left out of the mix, empty mixes everyone
+	Exclude string `json:"exclude,omitempty"`
+}
+
+// AudioMixPeerID is the pid the mix joins the room as, the same one NewPeerJob gives it
+func AudioMixPeerID(mixID string) string {
+	return "job-" + AudioMixJobHandler + "-" + mixID
+}
+
+// AudioCodec makes the decoders and encoders of mixes, see the top of the file
+type AudioCodec interface {
+	NewDecoder() (AudioDecoder, error)
+	NewEncoder() (AudioEncoder, error)
+}
+
+// AudioDecoder decodes one track, its audio as mono AudioMixRate pcm
+type AudioDecoder interface {
+	Decode(payload []byte) ([]int16, error)
+}
+
+// AudioEncoder encodes the mix a frame of AudioMixFrameSamples at a time
+type AudioEncoder interface {
+	Encode(pcm []int16) ([]byte, error)
+}
+
+// SetAudioMixCodec decodes and encodes audio mixes with codec, nil leaves mixes off. Builds with
+// the opus tag have opus.Codec
+func (m *Manager) SetAudioMixCodec(codec AudioCodec) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.audioMixCodec = codec
+}
+
+func (m *Manager) AudioMixCodec() AudioCodec {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.audioMixCodec
+}
+
+// EnableAudioMix has a worker publish the mix of the room's audio, mixPid is the peer it publishes as
+func (m *Manager) EnableAudioMix(sid string) (string, error) {
+	return m.EnableAudioMixExcluding(sid, "")
+}
+
+// EnableAudioMixExcluding is EnableAudioMix leaving the audio of pid out of the mix
+func (m *Manager) EnableAudioMixExcluding(sid string, pid string) (string, error) {
+	if m.AudioMixCodec() == nil {
+		return "", ErrNoAudioCodec
+	}
+	if _, err := m.GetRoomData(sid); err != nil {
+		return "", fmt.Errorf("room %s not found", sid)
+	}
+	mixID := RandomString(16)
+	options, err := json.Marshal(AudioMixOptions{MixID: mixID, Exclude: pid})
+	if err != nil {
+		return "", err
+	}
+	mixPid := AudioMixPeerID(mixID)
+	log.Infof("mixing the audio of room %s as %s", sid, mixPid)
+	router := *m.GetRouter()
+	return mixPid, EnqueueRequest(*router.GetQueue(), &pb.NoirRequest{
+		// the job is the node's own doing, see checkAdminCaller
+		AdminID: m.ID(),
+		Command: &pb.NoirRequest_Admin{
+			Admin: &pb.AdminRequest{
+				Payload: &pb.AdminRequest_RoomAdmin{
+					RoomAdmin: &pb.RoomAdminRequest{
+						RoomID: sid,
+						Method: &pb.RoomAdminRequest_RoomJob{
+							RoomJob: &pb.RoomJobRequest{
+								Handler: AudioMixJobHandler,
+								Pid:     mixPid,
+								Options: options,
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+}
+
+// DisableAudioMix ends the mix EnableAudioMix published as mixPid
+func (m *Manager) DisableAudioMix(mixPid string) error {
+	if !IsAudioMixPeer(mixPid) {
+		return fmt.Errorf("%s is not an audio mix", mixPid)
+	}
+	m.CloseClient(mixPid, pb.CloseReason_JOB_ENDED)
+	return nil
+}
+
+// IsAudioMixPeer is true of the pids mixes publish as, they are never mixed
+func IsAudioMixPeer(pid string) bool {
+	return strings.HasPrefix(pid, AudioMixPeerID(""))
+}
+
+// mixInput is the audio of a track waiting to be mixed
+type mixInput struct {
+	pid     string
+	samples []int16
+}
+
+// AudioMixer sums the pcm of tracks a frame at a time, leaving out those of one pid
+type AudioMixer struct {
+	mu      sync.Mutex
+	exclude string
+	inputs  map[string]*mixInput
+}
+
+// NewAudioMixer mixes every track but those of mixes and those exclude publishes, an empty exclude
+// leaves out only the mixes
+func NewAudioMixer(exclude string) *AudioMixer {
+	return &AudioMixer{exclude: exclude, inputs: map[string]*mixInput{}}
+}
+
+// Excludes is true of pids the mixer leaves out, with an exclude an unknown pid "" is one
+func (a *AudioMixer) Excludes(pid string) bool {
+	return (a.exclude != "" && (pid == a.exclude || pid == "")) || IsAudioMixPeer(pid)
+}
+
+// Write adds pcm of the track trackID pid publishes to the mix, the first write of a track adds it
+func (a *AudioMixer) Write(trackID string, pid string, pcm []int16) {
+	if a.Excludes(pid) {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	input, ok := a.inputs[trackID]
+	if !ok {
+		input = &mixInput{pid: pid}
+		a.inputs[trackID] = input
+	}
+	input.samples = append(input.samples, pcm...)
+	if backlog := int(AudioMixBacklog/AudioMixFrame) * AudioMixFrameSamples; len(input.samples) > backlog {
+		input.samples = append(input.samples[:0], input.samples[len(input.samples)-backlog:]...)
+	}
+}
+
+// Remove takes the track out of the mix once it ended
+func (a *AudioMixer) Remove(trackID string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.inputs, trackID)
+}
+
+// Inputs is how many tracks are mixed
+func (a *AudioMixer) Inputs() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.inputs)
+}
+
+// Mix is the next frame of the mix, AudioMixFrameSamples of them, silence when nothing is waiting
+func (a *AudioMixer) Mix() []int16 {
+	sum := make([]int32, AudioMixFrameSamples)
+	a.mu.Lock()
+	for _, input := range a.inputs {
+		n := len(input.samples)
+		if n > AudioMixFrameSamples {
+			n = AudioMixFrameSamples
+		}
+		for i, sample := range input.samples[:n] {
+			sum[i] += int32(sample)
+		}
+		input.samples = input.samples[n:]
+	}
+	a.mu.Unlock()
+
+	mix := make([]int16, AudioMixFrameSamples)
+	for i, sample := range sum {
+		if sample > math.MaxInt16 {
+			sample = math.MaxInt16
+		} else if sample < math.MinInt16 {
+			sample = math.MinInt16
+		}
+		mix[i] = int16(sample)
+	}
+	return mix
+}
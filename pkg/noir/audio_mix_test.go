@@ -0,0 +1,125 @@
+package noir
+
+import (
+	"encoding/json"
+	"errors"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"testing"
+)
+
+// pcmCodec passes pcm through as it is, mixes are tested without an Opus codec
+type pcmCodec struct{}
+
+func (pcmCodec) NewDecoder() (AudioDecoder, error) { return pcmCodec{}, nil }
+func (pcmCodec) NewEncoder() (AudioEncoder, error) { return pcmCodec{}, nil }
+
+func (pcmCodec) Decode(payload []byte) ([]int16, error) {
+	pcm := make([]int16, len(payload)/2)
+	for i := range pcm {
+		pcm[i] = int16(payload[2*i]) | int16(payload[2*i+1])<<8
+	}
+	return pcm, nil
+}
+
+func (pcmCodec) Encode(pcm []int16) ([]byte, error) {
+	payload := make([]byte, 2*len(pcm))
+	for i, sample := range pcm {
+		payload[2*i], payload[2*i+1] = byte(sample), byte(sample>>8)
+	}
+	return payload, nil
+}
+
+func frameOf(sample int16) []int16 {
+	frame := make([]int16, AudioMixFrameSamples)
+	for i := range frame {
+		frame[i] = sample
+	}
+	return frame
+}
+
+func TestAudioMixer(t *testing.T) {
+	mixer := NewAudioMixer("listener")
+	mixer.Write("first-audio", "first", frameOf(100))
+	mixer.Write("second-audio", "second", frameOf(-30))
+	// n-1: the listener does not hear itself, nor other mixes
+	mixer.Write("listener-audio", "listener", frameOf(1000))
+	mixer.Write("mix-audio", AudioMixPeerID("other"), frameOf(1000))
+	// nor a track whose publisher is not known yet, it could be the listener's
+	mixer.Write("unknown-audio", "", frameOf(1000))
+	if inputs := mixer.Inputs(); inputs != 2 {
+		t.Errorf("got %d tracks mixed want 2", inputs)
+	}
+	if everyone := NewAudioMixer(""); everyone.Excludes("") || everyone.Excludes("first") {
+		t.Errorf("without an exclude only mixes should be left out")
+	}
+	if mix := mixer.Mix(); len(mix) != AudioMixFrameSamples || mix[0] != 70 || mix[len(mix)-1] != 70 {
+		t.Errorf("got %d samples starting %d want a frame of the sum", len(mix), mix[0])
+	}
+	// nothing waiting is silence
+	if mix := mixer.Mix(); mix[0] != 0 {
+		t.Errorf("got %d want silence once the frames were mixed", mix[0])
+	}
+
+	mixer.Write("first-audio", "first", frameOf(30000))
+	mixer.Write("second-audio", "second", frameOf(30000))
+	if mix := mixer.Mix(); mix[0] != 32767 {
+		t.Errorf("got %d want the sum clipped", mix[0])
+	}
+	mixer.Remove("second-audio")
+	mixer.Write("first-audio", "first", frameOf(5))
+	mixer.Write("second-audio-2", "second", frameOf(7))
+	if mix := mixer.Mix(); mix[0] != 12 || mixer.Inputs() != 2 {
+		t.Errorf("got %d of %d tracks want the tracks published since mixed", mix[0], mixer.Inputs())
+	}
+
+	// a track far ahead of the mix keeps only its latest audio
+	backlog := int(AudioMixBacklog / AudioMixFrame)
+	for i := 0; i < backlog+3; i++ {
+		mixer.Write("first-audio", "first", frameOf(int16(i)))
+	}
+	if mix := mixer.Mix(); mix[0] != 3 {
+		t.Errorf("got %d want the oldest frames dropped", mix[0])
+	}
+}
+
+func TestEnableAudioMix(t *testing.T) {
+	mgr, _ := NewTestSetup()
+	mgr.SetRoomData(&pb.RoomData{Id: "mixed"})
+	routerQueue := *(*mgr.GetRouter()).GetQueue()
+	routerQueue.Cleanup()
+
+	if _, err := mgr.EnableAudioMix("mixed"); !errors.Is(err, ErrNoAudioCodec) {
+		t.Errorf("got %v want mixes refused without a codec", err)
+	}
+	mgr.SetAudioMixCodec(pcmCodec{})
+	mixPid, err := mgr.EnableAudioMixExcluding("mixed", "sip-bridge")
+	if err != nil {
+		t.Fatalf("error enabling the mix: %s", err)
+	}
+	if !IsAudioMixPeer(mixPid) {
+		t.Errorf("got %s want the pid of a mix", mixPid)
+	}
+
+	msg, _ := routerQueue.Next()
+	request := &pb.NoirRequest{}
+	UnmarshalRequest(msg, request)
+	job := request.GetAdmin().GetRoomAdmin().GetRoomJob()
+	options := AudioMixOptions{}
+	json.Unmarshal(job.GetOptions(), &options)
+	if job.GetHandler() != AudioMixJobHandler || job.GetPid() != mixPid || AudioMixPeerID(options.MixID) != mixPid {
+		t.Errorf("got %s want a %s job", request, AudioMixJobHandler)
+	}
+	if options.Exclude != "sip-bridge" {
+		t.Errorf("got %q want the listener left out of the mix", options.Exclude)
+	}
+
+	if _, err := mgr.EnableAudioMix("not-a-room"); err == nil {
+		t.Errorf("mixing a missing room should fail")
+	}
+	if err := mgr.DisableAudioMix("sip-bridge"); err == nil {
+		t.Errorf("only mixes should be disabled as mixes")
+	}
+	if err := mgr.DisableAudioMix(mixPid); err != nil {
+		t.Errorf("error disabling the mix: %s", err)
+	}
+}
@@ -0,0 +1,126 @@
+package noir
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"strings"
+	"time"
+)
+
+// Authenticator decides who may join, it returns the identity behind the join's token
+type Authenticator interface {
+	Authenticate(signal *pb.SignalRequest) (identity string, err error)
+}
+
+// AuthenticatorFunc lets a plain func be an Authenticator
+type AuthenticatorFunc func(signal *pb.SignalRequest) (string, error)
+
+func (f AuthenticatorFunc) Authenticate(signal *pb.SignalRequest) (string, error) {
+	return f(signal)
+}
+
+// jwtAuthenticator accepts HS256 JWTs signed with a shared secret, the "sub" claim is the identity,
+// an optional "sid" claim pins the room and an optional "role" claim is the most the peer may join as
+type jwtAuthenticator struct {
+	secret []byte
+}
+
+type jwtClaims struct {
+	Subject   string `json:"sub"`
+	Sid       string `json:"sid,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+	NotBefore int64  `json:"nbf,omitempty"`
+	Role      string `json:"role,omitempty"`
+}
+
+func NewJWTAuthenticator(secret string) (Authenticator, error) {
+	if secret == "" {
+		return nil, errors.New("jwt authenticator needs a secret")
+	}
+	return &jwtAuthenticator{secret: []byte(secret)}, nil
+}
+
+func (a *jwtAuthenticator) Authenticate(signal *pb.SignalRequest) (string, error) {
+	claims, err := a.verify(signal)
+	if err != nil {
+		return "", err
+	}
+	return claims.Subject, nil
+}
+
+// AuthorizeRole grants the role asked for when it is no more than the token's, publisher by default
+func (a *jwtAuthenticator) AuthorizeRole(signal *pb.SignalRequest, identity string) (pb.Role, error) {
+	requested := signal.GetJoin().GetRole()
+	claims, err := a.verify(signal)
+	if err != nil {
+		return requested, err
+	}
+	granted, err := ParseRole(claims.Role)
+	if err != nil {
+		return requested, fmt.Errorf("bad token: %s", err)
+	}
+	if roleRank(requested) > roleRank(granted) {
+		return requested, fmt.Errorf("token does not grant role %s", requested)
+	}
+	return requested, nil
+}
+
+func (a *jwtAuthenticator) verify(signal *pb.SignalRequest) (*jwtClaims, error) {
+	join := signal.GetJoin()
+	parts := strings.Split(join.GetToken(), ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, err
+	}
+	if header.Alg != "HS256" {
+		return nil, fmt.Errorf("unsupported token algorithm %s", header.Alg)
+	}
+
+	mac := hmac.New(sha256.New, a.secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, errors.New("bad token signature")
+	}
+
+	var claims jwtClaims
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, err
+	}
+	now := time.Now().Unix()
+	if claims.ExpiresAt != 0 && now >= claims.ExpiresAt {
+		return nil, errors.New("token expired")
+	}
+	if claims.NotBefore != 0 && now < claims.NotBefore {
+		return nil, errors.New("token not valid yet")
+	}
+	if claims.Sid != "" && claims.Sid != join.GetSid() {
+		return nil, fmt.Errorf("token is not valid for room %s", join.GetSid())
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("token has no subject")
+	}
+	return &claims, nil
+}
+
+func decodeJWTPart(part string, into interface{}) error {
+	decoded, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return fmt.Errorf("malformed token: %s", err)
+	}
+	if err := json.Unmarshal(decoded, into); err != nil {
+		return fmt.Errorf("malformed token: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package noir
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"testing"
+)
+
+func signTestJWT(secret string, claims string) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(claims))
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(header + "." + payload))
+	return header + "." + payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	authenticator, _ := NewJWTAuthenticator("secret")
+	tests := []struct {
+		name  string
+		token string
+		want  string
+	}{
+		{"valid", signTestJWT("secret", `{"sub":"alice","exp":4102444800}`), "alice"},
+		{"room", signTestJWT("secret", `{"sub":"alice","sid":"test"}`), "alice"},
+		{"other room", signTestJWT("secret", `{"sub":"alice","sid":"other"}`), ""},
+		{"expired", signTestJWT("secret", `{"sub":"alice","exp":1}`), ""},
+		{"bad signature", signTestJWT("wrong", `{"sub":"alice"}`), ""},
+		{"no subject", signTestJWT("secret", `{}`), ""},
+		{"garbage", "abc", ""},
+	}
+	for _, tt := range tests {
+		signal := &pb.SignalRequest{
+			Payload: &pb.SignalRequest_Join{Join: &pb.JoinRequest{Sid: "test", Token: tt.token}},
+		}
+		got, err := authenticator.Authenticate(signal)
+		if tt.want == "" && err == nil {
+			t.Errorf("%s: got identity %s want an error", tt.name, got)
+		} else if tt.want != "" && got != tt.want {
+			t.Errorf("%s: got %s (%v) want %s", tt.name, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package noir
+
+import (
+	"errors"
+	"fmt"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"strings"
+	"time"
+)
+
+/*
+Backpressure:
+Replies wait in a peer's from-peer topic until its client picks them up. A reply that cannot be
+added is tried again Retries times, BackoffMs apart and doubling, before it is given up with
+ErrQueueFull or ErrQueueLost. A topic holding MaxQueued replies is full, kills still get in.
+With DropTrickle, candidates sent to a full topic are dropped at once with ErrReplyDropped:
+ICE gathers plenty of them, and one stuck behind a backlog is worth little. Joins, answers and
+offers are never dropped.
+*/
+
+var ErrQueueFull = errors.New("queue full")
+var ErrQueueLost = errors.New("queue connection lost")
+var ErrReplyDropped = errors.New("reply dropped")
+
+// BackpressureConfig bounds how long a reply waits on a slow or full peer topic
+type BackpressureConfig struct {
+	// MaxQueued replies fill a topic, 0 never fills one
+	MaxQueued int64 `mapstructure:"maxqueued"`
+	Retries   int   `mapstructure:"retries"`
+	BackoffMs int   `mapstructure:"backoffms"`
+	// DropTrickle drops candidates to a full topic instead of retrying them
+	DropTrickle bool `mapstructure:"droptrickle"`
+}
+
+// DefaultBackpressure is used by EnqueueReply, and by managers that never SetBackpressure
+var DefaultBackpressure = BackpressureConfig{Retries: 3, BackoffMs: 20}
+
+// EnqueueReplyWith adds a reply, retrying and dropping it as backpressure says
+func EnqueueReplyWith(queue Queue, value *pb.NoirReply, backpressure BackpressureConfig) error {
+	command, err := MarshalReply(value)
+	if err != nil {
+		return err
+	}
+	return enqueueReply(queue, command, ReplyPriority(value), backpressure)
+}
+
+func enqueueReply(queue Queue, command []byte, priority Priority, backpressure BackpressureConfig) error {
+	backoff := time.Duration(backpressure.BackoffMs) * time.Millisecond
+	for attempt := 1; ; attempt++ {
+		err := addReply(queue, command, priority, backpressure.MaxQueued)
+		if err == nil {
+			return nil
+		}
+		if errors.Is(err, ErrQueueFull) && backpressure.DropTrickle && priority == PriorityLow {
+			return fmt.Errorf("%w: trickle to %s", ErrReplyDropped, queue.Topic())
+		}
+		if attempt > backpressure.Retries {
+			return fmt.Errorf("%w, gave up after %d attempts", err, attempt)
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func addReply(queue Queue, command []byte, priority Priority, maxQueued int64) error {
+	if maxQueued > 0 && priority != PriorityHigh {
+		count, err := queue.Count()
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrQueueLost, err)
+		}
+		if count >= maxQueued {
+			return fmt.Errorf("%w: %s holds %d replies", ErrQueueFull, queue.Topic(), count)
+		}
+	}
+	err := AddPriority(queue, command, priority)
+	if err != nil && strings.HasPrefix(err.Error(), "OOM") {
+		// redis is out of maxmemory
+		return fmt.Errorf("%w: %s", ErrQueueFull, err)
+	} else if err != nil {
+		return fmt.Errorf("%w: %s", ErrQueueLost, err)
+	}
+	return nil
+}
+
+// SetBackpressure applies to every reply the manager sends from now on, zero keeps DefaultBackpressure
+func (m *Manager) SetBackpressure(backpressure BackpressureConfig) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.backpressure = backpressure
+}
+
+func (m *Manager) Backpressure() BackpressureConfig {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.backpressure == (BackpressureConfig{}) {
+		return DefaultBackpressure
+	}
+	return m.backpressure
+}
+
+// EnqueueReply adds a reply under the manager's Backpressure, compressed for the peer it is to
+// when it reads replies compressed, see compression.go
+func (m *Manager) EnqueueReply(queue Queue, value *pb.NoirReply) error {
+	compression := m.PeerCompression(value.GetSignal().GetId())
+	command, err := MarshalCompressedReply(value, compression, m.CompressMinBytes())
+	if err != nil {
+		return err
+	}
+	return enqueueReply(queue, command, ReplyPriority(value), m.Backpressure())
+}
@@ -0,0 +1,76 @@
+package noir
+
+import (
+	"errors"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"testing"
+)
+
+// brokenQueue fails every Add, like a redis that went away
+type brokenQueue struct {
+	Queue
+	adds int
+}
+
+func (q *brokenQueue) Add(value []byte) error {
+	q.adds++
+	return errors.New("dial tcp: connection refused")
+}
+
+func signalReply(signal *pb.SignalReply) *pb.NoirReply {
+	signal.Id = "backpressure-peer"
+	return &pb.NoirReply{Command: &pb.NoirReply_Signal{Signal: signal}}
+}
+
+func TestEnqueueReplyBackpressure(t *testing.T) {
+	backpressure := BackpressureConfig{MaxQueued: 2, Retries: 2, BackoffMs: 1, DropTrickle: true}
+	queue := NewListQueue("backpressure")
+	description := signalReply(&pb.SignalReply{Payload: &pb.SignalReply_Description{Description: []byte("{}")}})
+	trickle := signalReply(&pb.SignalReply{Payload: &pb.SignalReply_Trickle{Trickle: &pb.Trickle{}}})
+	kill := signalReply(&pb.SignalReply{Payload: &pb.SignalReply_Kill{Kill: true}})
+
+	for i := 0; i < 2; i++ {
+		if err := EnqueueReplyWith(queue, description, backpressure); err != nil {
+			t.Fatalf("error queueing under the cap: %s", err)
+		}
+	}
+	if err := EnqueueReplyWith(queue, trickle, backpressure); !errors.Is(err, ErrReplyDropped) {
+		t.Errorf("got %v want trickle dropped from a full topic", err)
+	}
+	if err := EnqueueReplyWith(queue, description, backpressure); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("got %v want ErrQueueFull for a description", err)
+	}
+	if err := EnqueueReplyWith(queue, kill, backpressure); err != nil {
+		t.Errorf("kills should get into a full topic, got %s", err)
+	}
+	if count, _ := queue.Count(); count != 3 {
+		t.Errorf("got %d replies want 3", count)
+	}
+
+	backpressure.DropTrickle = false
+	if err := EnqueueReplyWith(queue, trickle, backpressure); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("got %v want ErrQueueFull for trickle without droptrickle", err)
+	}
+
+	broken := &brokenQueue{Queue: NewListQueue("broken")}
+	if err := EnqueueReplyWith(broken, description, backpressure); !errors.Is(err, ErrQueueLost) {
+		t.Errorf("got %v want ErrQueueLost", err)
+	}
+	if broken.adds != backpressure.Retries+1 {
+		t.Errorf("got %d attempts want %d", broken.adds, backpressure.Retries+1)
+	}
+}
+
+func TestManagerBackpressure(t *testing.T) {
+	mgr, _ := NewTestSetup()
+	if mgr.Backpressure() != DefaultBackpressure {
+		t.Errorf("got %v want the DefaultBackpressure", mgr.Backpressure())
+	}
+	mgr.SetBackpressure(BackpressureConfig{MaxQueued: 1})
+	queue := NewListQueue("manager-backpressure")
+	reply := signalReply(&pb.SignalReply{Payload: &pb.SignalReply_Description{Description: []byte("{}")}})
+	mgr.EnqueueReply(queue, reply)
+	if err := mgr.EnqueueReply(queue, reply); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("got %v want the manager's MaxQueued applied", err)
+	}
+}
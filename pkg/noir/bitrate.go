@@ -0,0 +1,109 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/sdp/v3"
+	"github.com/pion/webrtc/v3"
+)
+
+/*
+Bitrate Limits:
+Peers can ask for publishKbps/subscribeKbps caps when joining. Anything left at 0
+falls back to the manager default, and requests above the manager max are clamped
+down to the max rather than rejected.
+
+The publish cap is enforced by writing b=AS / b=TIAS into every answer we send the
+peer, which tells the browser's congestion controller not to send us more than that.
+ion-sfu does not limit what it sends a subscriber, so the subscribe cap is applied
+to the bandwidth estimates noir acts on: a subscriber's REMBs count as at most its
+cap, both where they make the targets of the publishers it receives, see
+rtcp_feedback.go, and where they pick its adaptive layers, see adaptive_layers.go.
+A peer in a room without an rtcp aggregation and without adaptiveLayers is sent
+all its publishers send.
+*/
+
+func (m *Manager) SetBitrateLimits(defaults *pb.BitrateLimits, max *pb.BitrateLimits) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.defaultBitrate = defaults
+	m.maxBitrate = max
+}
+
+// setSubscribeCap keeps the subscribe cap of a local peer, 0 drops it
+func (m *Manager) setSubscribeCap(pid string, kbps uint32) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if kbps == 0 {
+		delete(m.subscribeCaps, pid)
+	} else {
+		m.subscribeCaps[pid] = uint64(kbps) * 1000
+	}
+}
+
+// subscribeCapOf is the subscribe cap of the local peer the sfu knows by sfuID, in bits per
+// second, 0 without one
+func (m *Manager) subscribeCapOf(sfuID string) uint64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if len(m.subscribeCaps) == 0 {
+		return 0
+	}
+	return m.subscribeCaps[m.pidsBySFUID()[sfuID]]
+}
+
+// capBitrate is what an estimate counts as for a subscriber capped at cap bits per second, a
+// cap of 0 has no limit
+func capBitrate(estimate uint64, cap uint64) uint64 {
+	if cap > 0 && estimate > cap {
+		return cap
+	}
+	return estimate
+}
+
+// BitrateLimitsFor resolves the limits a joining peer asked for against the manager defaults and max
+func (m *Manager) BitrateLimitsFor(requested *pb.BitrateLimits) *pb.BitrateLimits {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return &pb.BitrateLimits{
+		PublishKbps: clampKbps(requested.GetPublishKbps(),
+			m.defaultBitrate.GetPublishKbps(), m.maxBitrate.GetPublishKbps()),
+		SubscribeKbps: clampKbps(requested.GetSubscribeKbps(),
+			m.defaultBitrate.GetSubscribeKbps(), m.maxBitrate.GetSubscribeKbps()),
+	}
+}
+
+func clampKbps(requested uint32, fallback uint32, max uint32) uint32 {
+	if requested == 0 {
+		requested = fallback
+	}
+	if max > 0 && (requested == 0 || requested > max) {
+		return max
+	}
+	return requested
+}
+
+// LimitBitrate rewrites the audio/video sections of a description to ask for at most kbps
+func LimitBitrate(desc *webrtc.SessionDescription, kbps uint32) error {
+	if desc == nil || kbps == 0 {
+		return nil
+	}
+	parsed, err := desc.Unmarshal()
+	if err != nil {
+		return err
+	}
+	for _, media := range parsed.MediaDescriptions {
+		switch media.MediaName.Media {
+		case "audio", "video":
+			media.Bandwidth = []sdp.Bandwidth{
+				{Type: "AS", Bandwidth: uint64(kbps)},
+				{Type: "TIAS", Bandwidth: uint64(kbps) * 1000},
+			}
+		}
+	}
+	raw, err := parsed.Marshal()
+	if err != nil {
+		return err
+	}
+	desc.SDP = string(raw)
+	return nil
+}
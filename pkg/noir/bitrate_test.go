@@ -0,0 +1,46 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"testing"
+)
+
+func TestBitrateLimitsFor(t *testing.T) {
+	mgr := Manager{}
+	mgr.SetBitrateLimits(&pb.BitrateLimits{PublishKbps: 500}, &pb.BitrateLimits{PublishKbps: 1000, SubscribeKbps: 2000})
+
+	tests := []struct {
+		requested *pb.BitrateLimits
+		publish   uint32
+		subscribe uint32
+	}{
+		{nil, 500, 2000},
+		{&pb.BitrateLimits{PublishKbps: 800}, 800, 2000},
+		{&pb.BitrateLimits{PublishKbps: 5000, SubscribeKbps: 100}, 1000, 100},
+	}
+
+	for _, tt := range tests {
+		got := mgr.BitrateLimitsFor(tt.requested)
+		if got.PublishKbps != tt.publish || got.SubscribeKbps != tt.subscribe {
+			t.Errorf("got %d/%d want %d/%d", got.PublishKbps, got.SubscribeKbps, tt.publish, tt.subscribe)
+		}
+	}
+}
+
+func TestSubscribeCap(t *testing.T) {
+	mgr, _ := NewTestSetup()
+	// a fake's sfu peer never joined, so the sfu knows it by ""
+	mgr.users["capped-peer"] = newFakePeer()
+	defer delete(mgr.users, "capped-peer")
+	mgr.setSubscribeCap("capped-peer", 300)
+	if cap := mgr.subscribeCapOf(""); cap != 300000 {
+		t.Errorf("got %d want the peer's subscribeKbps in bits per second", cap)
+	}
+	if estimate := capBitrate(1000000, mgr.subscribeCapOf("")); estimate != 300000 {
+		t.Errorf("got %d want the estimate capped", estimate)
+	}
+	mgr.setSubscribeCap("capped-peer", 0)
+	if estimate := capBitrate(1000000, mgr.subscribeCapOf("")); estimate != 1000000 {
+		t.Errorf("got %d want the estimate left without a cap", estimate)
+	}
+}
@@ -0,0 +1,121 @@
+package noir
+
+import (
+	"errors"
+	"fmt"
+	pb "github.com/net-prophet/noir/pkg/proto"
+)
+
+/*
+Capabilities:
+A client can ask what joining would settle before it joins, so it picks codecs, skips a video
+track in an audioOnly room or asks for a password up front instead of learning each from a
+refused join. A CapabilitiesRequest is a SignalRequest like a join, its id is the pid the reply
+goes to, and its sid the room about to be joined, if any. It is answered with one Capabilities
+and nothing else: no peer is set up, nothing is saved, and the router does not claim the room
+for a worker while routing it, a room that exists goes to its node and one that does not to any.
+The reply tells whether a room exists, its password, paused and maxPeers, so with an
+Authenticator set the request carries the token the join would, and is checked as that join
+first: a refused token is answered UNAUTHORIZED and nothing else. Clients ask with the
+"capabilities" JSON-RPC method or Client.Capabilities, both under a pid of their own so the
+reply does not mix with a peer's.
+
+The version is what a join of the request's version would be answered in, the way
+NegotiateProtocolVersion settles it, and the iceServers are the urls the JoinReply would hand
+out, without credentials, which are only minted for a join. codecs are those the sfu answers
+with that the room's MediaConfig allows. recording, playback and audioMix are the jobs the
+answering worker runs, workers of a cluster are expected to run the same ones.
+*/
+
+// SFUCodecs are the mime types ion-sfu v1.6 answers offers with
+var SFUCodecs = []string{"audio/opus", "video/VP8", "video/VP9", "video/H264"}
+
+// AllowedCodecs are the SFUCodecs the MediaConfig lets peers negotiate
+func (c MediaConfig) AllowedCodecs() []string {
+	codecs := []string{}
+	for _, codec := range SFUCodecs {
+		if c.allowsCodec(codec) {
+			codecs = append(codecs, codec)
+		}
+	}
+	return codecs
+}
+
+// Capabilities are what a peer of version joining sid would get, an empty sid leaves out the room.
+// The jobs are left for the worker answering to fill in, see HandleCapabilities
+func (m *Manager) Capabilities(sid string, version string) (*pb.Capabilities, error) {
+	negotiated, err := NegotiateProtocolVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	capabilities := &pb.Capabilities{
+		Version:           negotiated,
+		SupportedVersions: m.SupportedVersions(),
+		Codecs:            m.MediaConfig().AllowedCodecs(),
+	}
+	m.mu.RLock()
+	capabilities.AuthRequired = m.authenticator != nil
+	m.mu.RUnlock()
+
+	iceServers, err := m.ICEServers("")
+	if err != nil {
+		return nil, err
+	}
+	for _, server := range iceServers {
+		capabilities.IceServers = append(capabilities.IceServers, &pb.ICEServer{Urls: server.Urls})
+	}
+
+	if sid == "" {
+		return capabilities, nil
+	}
+	capabilities.Room = &pb.RoomCapabilities{AutoCreate: m.AllowAutoCreateRooms()}
+	room, err := m.GetRoomData(sid)
+	if errors.Is(err, ErrRoomNotFound) {
+		return capabilities, nil
+	} else if err != nil {
+		return nil, err
+	}
+	options := room.GetOptions()
+	capabilities.Room.Exists = true
+	capabilities.Room.AudioOnly = options.GetAudioOnly()
+	capabilities.Room.MaxPeers = options.GetMaxPeers()
+	capabilities.Room.PasswordRequired = RoomHasPassword(options)
+	capabilities.Room.IsChannel = options.GetIsChannel()
+	capabilities.Room.Paused = room.GetPaused()
+	capabilities.Codecs = m.RoomMediaConfig(room).AllowedCodecs()
+	return capabilities, nil
+}
+
+// HandleCapabilities answers a CapabilitiesRequest, with the jobs this worker runs
+func (w *worker) HandleCapabilities(request *pb.NoirRequest) error {
+	signal := request.GetSignal()
+	ask := signal.GetCapabilities()
+	// checked as the join would be, a refused token learns nothing of the room
+	_, err := w.manager.Authenticate(&pb.SignalRequest{
+		Id:      signal.Id,
+		Payload: &pb.SignalRequest_Join{Join: &pb.JoinRequest{Sid: ask.GetSid(), Token: ask.GetToken()}},
+	})
+	if err != nil {
+		return w.SignalError(request, pb.SignalError_UNAUTHORIZED, fmt.Errorf("unauthorized: %s", err))
+	}
+	capabilities, err := w.manager.Capabilities(ask.GetSid(), request.Version)
+	if err != nil {
+		return w.SignalError(request, pb.SignalError_UNKNOWN, err)
+	}
+	_, capabilities.Recording = w.jobHandlers[RecordJobHandler]
+	_, capabilities.Playback = w.jobHandlers[PlayFileJobHandler]
+	_, mixes := w.jobHandlers[AudioMixJobHandler]
+	capabilities.AudioMix = mixes && w.manager.AudioMixCodec() != nil
+	return w.SignalReply(signal.Id, &pb.NoirReply{
+		Id:      request.Id,
+		TraceID: request.TraceID,
+		Version: capabilities.Version,
+		Command: &pb.NoirReply_Signal{
+			Signal: &pb.SignalReply{
+				Id:        signal.Id,
+				RequestId: signal.RequestId,
+				Payload:   &pb.SignalReply_Capabilities{Capabilities: capabilities},
+			},
+		},
+	})
+}
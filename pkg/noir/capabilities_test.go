@@ -0,0 +1,122 @@
+package noir
+
+import (
+	"errors"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"strings"
+	"testing"
+	"time"
+)
+
+func askCapabilities(t *testing.T, mgr *Manager, pid string, sid string) *pb.NoirReply {
+	return askCapabilitiesWith(t, mgr, pid, &pb.CapabilitiesRequest{Sid: sid})
+}
+
+func askCapabilitiesWith(t *testing.T, mgr *Manager, pid string, ask *pb.CapabilitiesRequest) *pb.NoirReply {
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+		Version: "1.3",
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id:        pid,
+				RequestId: "capabilities-1",
+				Payload: &pb.SignalRequest_Capabilities{
+					Capabilities: ask,
+				},
+			},
+		},
+	})
+	worker.HandleNext(0)
+	message, err := mgr.GetQueue(pb.KeyTopicFromPeer(pid)).BlockUntilNext(time.Second)
+	if err != nil {
+		t.Fatalf("error reading the capabilities: %s", err)
+	}
+	reply := &pb.NoirReply{}
+	UnmarshalReply(message, reply)
+	return reply
+}
+
+func TestAllowedCodecs(t *testing.T) {
+	if codecs := strings.Join(MediaConfig{}.AllowedCodecs(), ","); codecs != strings.Join(SFUCodecs, ",") {
+		t.Errorf("got %s want every codec the sfu answers with", codecs)
+	}
+	voice := MediaConfig{Codecs: []string{"audio/OPUS", "audio/PCMU"}}
+	if codecs := strings.Join(voice.AllowedCodecs(), ","); codecs != "audio/opus" {
+		t.Errorf("got %s want only the allowed codecs the sfu has", codecs)
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	keys := append(peerKeys("capable-peer"), pb.KeyRoomData("capable"), pb.KeyRoomUsers("capable"), pb.KeyRoomData("not-created"))
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+	provider, _ := NewTURNRESTProvider([]string{"stun:stun.example.org"}, []string{"turn:turn.example.org"}, "secret", time.Hour)
+	mgr.SetICEServerProvider(provider)
+	(*mgr.GetWorker()).RegisterHandler(RecordJobHandler, func(*pb.NoirRequest) RunnableJob { return nil })
+
+	options := &pb.RoomOptions{AudioOnly: true, MaxPeers: 4, JoinPassword: "letmein", Codecs: []string{"audio/opus"}}
+	SealRoomPassword(options)
+	mgr.SetRoomData(&pb.RoomData{Id: "capable", Options: options})
+
+	reply := askCapabilities(t, mgr, "capable-peer", "capable")
+	capabilities := reply.GetSignal().GetCapabilities()
+	if capabilities == nil || reply.GetSignal().GetRequestId() != "capabilities-1" {
+		t.Fatalf("got %s want the capabilities", reply)
+	}
+	if capabilities.Version != "1.0" || reply.Version != "1.0" {
+		t.Errorf("got %s want the version a join would settle", capabilities.Version)
+	}
+	if room := capabilities.GetRoom(); !room.GetExists() || !room.GetAudioOnly() || room.GetMaxPeers() != 4 || !room.GetPasswordRequired() {
+		t.Errorf("got %s want the room's settings", room)
+	}
+	if codecs := strings.Join(capabilities.Codecs, ","); codecs != "audio/opus" {
+		t.Errorf("got %s want the room's codecs", codecs)
+	}
+	if !capabilities.Recording || capabilities.Playback || capabilities.AudioMix {
+		t.Errorf("got %s want only recording, the one job the worker runs", capabilities)
+	}
+	if servers := capabilities.GetIceServers(); len(servers) != 2 || servers[1].GetUrls()[0] != "turn:turn.example.org" || servers[1].GetCredential() != "" {
+		t.Errorf("got %s want the ice server urls without credentials", servers)
+	}
+	// asking has no peer set up
+	if user, _ := mgr.GetRemoteUserData("capable-peer"); user != nil {
+		t.Errorf("got %s want no peer for asking", user)
+	}
+
+	// nor a missing room created
+	reply = askCapabilities(t, mgr, "capable-peer", "not-created")
+	if room := reply.GetSignal().GetCapabilities().GetRoom(); room == nil || room.GetExists() || room.GetAutoCreate() {
+		t.Errorf("got %s want a room that does not exist", room)
+	}
+	if exists, _ := mgr.GetRemoteRoomExists("not-created"); exists {
+		t.Errorf("asking about a room should not create it")
+	}
+	if reply := askCapabilities(t, mgr, "capable-peer", ""); reply.GetSignal().GetCapabilities().GetRoom() != nil {
+		t.Errorf("got %s want only the server's capabilities without a sid", reply)
+	}
+}
+
+func TestCapabilitiesAuth(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	keys := append(peerKeys("capable-guest"), pb.KeyRoomData("guarded"))
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+	mgr.SetRoomData(&pb.RoomData{Id: "guarded", Options: &pb.RoomOptions{MaxPeers: 2}})
+	mgr.SetAuthenticator(AuthenticatorFunc(func(signal *pb.SignalRequest) (string, error) {
+		if join := signal.GetJoin(); join.GetToken() != "good" || join.GetSid() != "guarded" {
+			return "", errors.New("bad token")
+		}
+		return "guest", nil
+	}))
+	defer mgr.SetAuthenticator(nil)
+
+	refused := askCapabilitiesWith(t, mgr, "capable-guest", &pb.CapabilitiesRequest{Sid: "guarded", Token: "bad"})
+	if refused.GetSignal().GetFailure().GetCode() != pb.SignalError_UNAUTHORIZED || refused.GetSignal().GetCapabilities() != nil {
+		t.Errorf("got %s want a refused token told nothing", refused)
+	}
+	reply := askCapabilitiesWith(t, mgr, "capable-guest", &pb.CapabilitiesRequest{Sid: "guarded", Token: "good"})
+	if room := reply.GetSignal().GetCapabilities().GetRoom(); !reply.GetSignal().GetCapabilities().GetAuthRequired() || room.GetMaxPeers() != 2 {
+		t.Errorf("got %s want the room's capabilities for the join's token", reply)
+	}
+}
@@ -0,0 +1,65 @@
+package noir
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+/*
+Node Capacity:
+A node with SetMaxPeers takes no more than that many peers at once. ConnectUser claims a slot
+before it sets up the sfu peer, counting the peers connected and those still connecting under
+the manager's lock, so concurrent joins can not pass the cap. A join past it fails with
+ErrNodeAtCapacity, which HandleJoin replies as a NODE_AT_CAPACITY worth retrying, for a router
+to place it on another node. The cap goes out with every heartbeat, and routers place no new
+rooms on a worker whose last heartbeat was Full, see placeableWorkers. Rooms it already runs
+still route to it, their joins are refused until a peer leaves.
+*/
+
+// How long a join refused for NODE_AT_CAPACITY is told to wait before trying again
+const NodeCapacityRetryAfter = time.Second
+
+var ErrNodeAtCapacity = errors.New("node at capacity")
+
+// SetMaxPeers caps the peers this node runs at once, 0 or less is no cap
+func (m *Manager) SetMaxPeers(peers int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.maxPeers = peers
+}
+
+func (m *Manager) MaxPeers() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.maxPeers < 0 {
+		return 0
+	}
+	return m.maxPeers
+}
+
+// claimNodeSlot counts pid as connecting until releaseNodeSlot, unless the node is full.
+// A pid already connected, rejoining, takes no other slot
+func (m *Manager) claimNodeSlot(pid string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, connected := m.users[pid]
+	if m.maxPeers > 0 && !connected && len(m.users)+m.joining >= m.maxPeers {
+		joinsAtCapacity.Inc()
+		return fmt.Errorf("%w: it runs its %d peers", ErrNodeAtCapacity, m.maxPeers)
+	}
+	if !connected {
+		if err := m.busy(); err != nil {
+			return err
+		}
+	}
+	m.joining++
+	return nil
+}
+
+// releaseNodeSlot ends a claimNodeSlot, the peer holds its slot from m.users once connected
+func (m *Manager) releaseNodeSlot() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.joining--
+}
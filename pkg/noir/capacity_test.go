@@ -0,0 +1,83 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/protobuf/proto"
+	"testing"
+	"time"
+)
+
+func TestNodeAtCapacity(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	mgr.SetMaxPeers(1)
+	defer mgr.SetMaxPeers(0)
+	keys := []string{pb.KeyRoomData("capacity-room"), pb.KeyRoomUsers("capacity-room")}
+	for _, pid := range []string{"capacity-first", "capacity-second"} {
+		keys = append(keys, pb.KeyTopicFromPeer(pid), pb.KeyTopicToPeer(pid), pb.KeyPeerOwner(pid))
+	}
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	worker := *mgr.GetWorker()
+	join := func(pid string) error {
+		EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+			Command: &pb.NoirRequest_Signal{
+				Signal: &pb.SignalRequest{
+					Id: pid,
+					Payload: &pb.SignalRequest_Join{
+						Join: &pb.JoinRequest{Sid: "capacity-room", Description: []byte(EXAMPLE_EMPTY_SDP)},
+					},
+				},
+			},
+		})
+		return worker.HandleNext(0)
+	}
+	if err := join("capacity-first"); err != nil {
+		t.Fatalf("error joining under the cap: %s", err)
+	}
+	defer mgr.DisconnectUser("capacity-first")
+
+	rejectedBefore := testutil.ToFloat64(joinsAtCapacity)
+	if err := join("capacity-second"); err == nil {
+		mgr.DisconnectUser("capacity-second")
+		t.Fatalf("a join past the node's max peers should fail")
+	}
+	message, err := mgr.GetQueue(pb.KeyTopicFromPeer("capacity-second")).BlockUntilNext(time.Second)
+	if err != nil {
+		t.Fatalf("got no reply: %s", err)
+	}
+	reply := pb.NoirReply{}
+	proto.Unmarshal(message, &reply)
+	if failure := reply.GetSignal().GetFailure(); failure.GetCode() != pb.SignalError_NODE_AT_CAPACITY || failure.GetRetryAfterMs() == 0 {
+		t.Errorf("got %s want a NODE_AT_CAPACITY worth retrying", &reply)
+	}
+	if rejected := testutil.ToFloat64(joinsAtCapacity) - rejectedBefore; rejected != 1 {
+		t.Errorf("got %v joins counted at capacity want 1", rejected)
+	}
+	if redis.HExists(pb.KeyRoomUsers("capacity-room"), "capacity-second").Val() {
+		t.Errorf("a join refused for capacity should give up its room slot")
+	}
+
+	if err := mgr.Heartbeat(worker.ID(), 1); err != nil {
+		t.Fatalf("error writing heartbeat: %s", err)
+	}
+	workers, _ := mgr.ListWorkers()
+	for _, status := range workers {
+		if status.ID == worker.ID() && (status.MaxPeers != 1 || !status.Full()) {
+			t.Errorf("got %+v want the heartbeat of a full worker", status)
+		}
+	}
+	// new rooms go to the workers with room for them
+	mgr.UpdateAvailableNodes()
+	if nodes := mgr.NodesForService("sfu"); len(nodes) != 0 {
+		t.Errorf("got %v want no new rooms placed on a full worker", nodes)
+	}
+	mgr.SetMaxPeers(2)
+	mgr.Heartbeat(worker.ID(), 1)
+	mgr.UpdateAvailableNodes()
+	if nodes := mgr.NodesForService("sfu"); len(nodes) != 1 {
+		t.Errorf("got %v want the worker placed on again under its cap", nodes)
+	}
+}
@@ -0,0 +1,102 @@
+package noir
+
+import (
+	"context"
+	"encoding/json"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	log "github.com/pion/ion-log"
+	"github.com/pion/webrtc/v3"
+)
+
+// clientPeer is a Client with a pc per direction, like a browser, for the peers noir runs itself:
+// it trickles its candidates, and answers the subscriber's offers once handed the Events
+type clientPeer struct {
+	client *Client
+	pub    *webrtc.PeerConnection
+	sub    *webrtc.PeerConnection
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// newClientPeer makes the client and both pcs, which stop with ctx or close
+func newClientPeer(ctx context.Context, manager *Manager) (*clientPeer, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	peer := &clientPeer{client: NewClient(ctx, manager), ctx: ctx, cancel: cancel}
+	var err error
+	if peer.pub, err = webrtc.NewPeerConnection(webrtc.Configuration{}); err != nil {
+		peer.close()
+		return nil, err
+	}
+	if peer.sub, err = webrtc.NewPeerConnection(webrtc.Configuration{}); err != nil {
+		peer.close()
+		return nil, err
+	}
+	for pc, target := range map[*webrtc.PeerConnection]pb.Trickle_Target{peer.pub: pb.Trickle_PUBLISHER, peer.sub: pb.Trickle_SUBSCRIBER} {
+		target := target
+		pc.OnICECandidate(func(candidate *webrtc.ICECandidate) {
+			if candidate == nil {
+				return
+			}
+			if err := peer.client.Trickle(candidate.ToJSON(), target); err != nil {
+				log.Debugf("client peer trickle error %s", err)
+			}
+		})
+	}
+	return peer, nil
+}
+
+// handle answers a subscriber offer or adds candidates, other replies are left to the caller
+func (b *clientPeer) handle(signal *pb.SignalReply) error {
+	switch payload := signal.Payload.(type) {
+	case *pb.SignalReply_Description:
+		offer := webrtc.SessionDescription{}
+		if err := json.Unmarshal(payload.Description, &offer); err != nil || offer.Type != webrtc.SDPTypeOffer {
+			return nil
+		}
+		return b.answer(signal.RequestId, offer)
+	case *pb.SignalReply_Trickle:
+		b.trickle(payload.Trickle)
+	case *pb.SignalReply_TrickleBatch:
+		for _, trickle := range payload.TrickleBatch.Candidates {
+			b.trickle(trickle)
+		}
+	}
+	return nil
+}
+
+func (b *clientPeer) answer(offerID string, offer webrtc.SessionDescription) error {
+	if err := b.sub.SetRemoteDescription(offer); err != nil {
+		return err
+	}
+	answer, err := b.sub.CreateAnswer(nil)
+	if err != nil {
+		return err
+	}
+	if err := b.sub.SetLocalDescription(answer); err != nil {
+		return err
+	}
+	return b.client.Answer(offerID, answer)
+}
+
+func (b *clientPeer) trickle(trickle *pb.Trickle) {
+	candidate := webrtc.ICECandidateInit{}
+	if err := json.Unmarshal([]byte(trickle.Init), &candidate); err != nil {
+		return
+	}
+	pc := b.pub
+	if trickle.Target == pb.Trickle_SUBSCRIBER {
+		pc = b.sub
+	}
+	pc.AddICECandidate(candidate)
+}
+
+func (b *clientPeer) close() {
+	b.client.Close()
+	b.cancel()
+	if b.pub != nil {
+		b.pub.Close()
+	}
+	if b.sub != nil {
+		b.sub.Close()
+	}
+}
@@ -0,0 +1,383 @@
+package noir
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	log "github.com/pion/ion-log"
+	"github.com/pion/webrtc/v3"
+	"io"
+	"sync"
+	"time"
+)
+
+// How many uncorrelated replies Client.Events holds before the client stops reading replies
+const ClientEventBuffer = 64
+
+var (
+	ErrClientJoined    = errors.New("client already joined")
+	ErrClientNotJoined = errors.New("client has not joined")
+	ErrClientClosed    = errors.New("client closed")
+)
+
+// SignalFailure is a SignalError reply to one of the Client's requests
+type SignalFailure struct {
+	Code    pb.SignalError_Code
+	Message string
+	// RetryAfter is set when trying again later may work, eg. when RATE_LIMITED
+	RetryAfter time.Duration
+	// TraceID is the trace of the refused request, see request_tracing.go
+	TraceID string
+}
+
+func (f *SignalFailure) Error() string {
+	return fmt.Sprintf("%s: %s", f.Code, f.Message)
+}
+
+// Client signals one peer through the queues, the way the jsonrpc bridge does, for Go services,
+// bots and tests. Replies to its requests are matched up by RequestId, everything else the worker
+// sends (trickle, subscriber offers, room data, kill) comes out of Events, which has to be drained.
+// Its requests carry the trace SetTraceID gives them, or each its own
+type Client struct {
+	manager *Manager
+	ctx     context.Context
+	cancel  context.CancelFunc
+	events  chan *pb.SignalReply
+	stopped chan struct{}
+
+	mu        sync.Mutex
+	pid       string
+	pending   map[string]chan *pb.NoirReply
+	closed    bool
+	traceID   string
+	lastTrace string
+}
+
+// NewClient makes a client that stops when ctx is done or it is closed
+func NewClient(ctx context.Context, manager *Manager) *Client {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Client{
+		manager: manager,
+		ctx:     ctx,
+		cancel:  cancel,
+		events:  make(chan *pb.SignalReply, ClientEventBuffer),
+		stopped: make(chan struct{}),
+		pending: map[string]chan *pb.NoirReply{},
+	}
+}
+
+// Events are the replies nobody asked for, closed once the peer is killed or the client closes
+func (c *Client) Events() <-chan *pb.SignalReply {
+	return c.events
+}
+
+func (c *Client) PeerID() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.pid
+}
+
+// SetTraceID has the requests sent from now on carry traceID, eg. of the call they are part of,
+// empty gives each request a new trace
+func (c *Client) SetTraceID(traceID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.traceID = traceID
+}
+
+// LastTraceID is the traceID the latest reply to one of the client's requests carried back
+func (c *Client) LastTraceID() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastTrace
+}
+
+func (c *Client) trace() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.traceID
+}
+
+// Join routes a join to the room's worker as pid and waits for its answer, the peer's PeerID is
+// the one in the answer when the server assigns them. The answer comes on a topic of the join's
+// own, so a refusal of a pid another client has is not read as theirs
+func (c *Client) Join(sid string, pid string, offer webrtc.SessionDescription) (*webrtc.SessionDescription, error) {
+	c.mu.Lock()
+	if c.pid != "" {
+		c.mu.Unlock()
+		return nil, ErrClientJoined
+	}
+	c.pid = pid
+	c.mu.Unlock()
+	replyTo := "join-" + NewTraceID()
+	go c.listen(replyTo)
+
+	router := *c.manager.GetRouter()
+	reply, err := c.request(*router.GetQueue(), &pb.SignalRequest{
+		Payload: &pb.SignalRequest_Join{
+			Join: &pb.JoinRequest{Sid: sid, Description: []byte(offer.SDP), Compression: SupportedCompressions, ReplyTo: replyTo},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	answer := &webrtc.SessionDescription{}
+	if err := json.Unmarshal(reply.GetJoin().GetDescription(), answer); err != nil {
+		return nil, err
+	}
+	return answer, nil
+}
+
+// Offer renegotiates the publisher and waits for the answer
+func (c *Client) Offer(offer webrtc.SessionDescription) (*webrtc.SessionDescription, error) {
+	queue, err := c.toPeer()
+	if err != nil {
+		return nil, err
+	}
+	packed, err := json.Marshal(Negotiation{Desc: offer})
+	if err != nil {
+		return nil, err
+	}
+	reply, err := c.request(queue, &pb.SignalRequest{
+		Payload: &pb.SignalRequest_Description{Description: packed},
+	})
+	if err != nil {
+		return nil, err
+	}
+	answer := &webrtc.SessionDescription{}
+	if err := json.Unmarshal(reply.GetDescription(), answer); err != nil {
+		return nil, err
+	}
+	return answer, nil
+}
+
+// Answer answers an offer from Events, the sfu offers when the subscriber has tracks to add.
+// offerID is the RequestId the offer came with, an offer left unanswered is sent again
+func (c *Client) Answer(offerID string, answer webrtc.SessionDescription) error {
+	packed, err := json.Marshal(Negotiation{Desc: answer})
+	if err != nil {
+		return err
+	}
+	return c.send(&pb.SignalRequest{
+		RequestId: offerID,
+		Payload:   &pb.SignalRequest_Description{Description: packed},
+	})
+}
+
+func (c *Client) Trickle(candidate webrtc.ICECandidateInit, target pb.Trickle_Target) error {
+	packed, err := json.Marshal(candidate)
+	if err != nil {
+		return err
+	}
+	return c.send(&pb.SignalRequest{
+		Payload: &pb.SignalRequest_Trickle{
+			Trickle: &pb.Trickle{Init: string(packed), Target: target},
+		},
+	})
+}
+
+// Subscribe receives these publishers too, and returns every publisher the peer receives now
+func (c *Client) Subscribe(pids ...string) ([]string, error) {
+	return c.subscriptions(&pb.SignalRequest{
+		Payload: &pb.SignalRequest_Subscribe{Subscribe: &pb.SubscribeRequest{Pids: pids}},
+	})
+}
+
+// Unsubscribe stops receiving these publishers, or every one without pids
+func (c *Client) Unsubscribe(pids ...string) ([]string, error) {
+	return c.subscriptions(&pb.SignalRequest{
+		Payload: &pb.SignalRequest_Unsubscribe{Unsubscribe: &pb.SubscribeRequest{Pids: pids}},
+	})
+}
+
+func (c *Client) subscriptions(signal *pb.SignalRequest) ([]string, error) {
+	queue, err := c.toPeer()
+	if err != nil {
+		return nil, err
+	}
+	reply, err := c.request(queue, signal)
+	if err != nil {
+		return nil, err
+	}
+	return reply.GetSubscriptions().GetPids(), nil
+}
+
+// Close kills the peer, if it joined, and stops the client, also once its ctx is done
+func (c *Client) Close() error {
+	c.mu.Lock()
+	closed := c.closed
+	c.closed = true
+	c.mu.Unlock()
+	var err error
+	if c.PeerID() != "" && !closed {
+		err = c.send(&pb.SignalRequest{Payload: &pb.SignalRequest_Kill{Kill: true}, CloseReason: pb.CloseReason_LEFT})
+	}
+	c.cancel()
+	if c.PeerID() != "" {
+		<-c.stopped
+	}
+	return err
+}
+
+func (c *Client) toPeer() (Queue, error) {
+	pid := c.PeerID()
+	if pid == "" {
+		return nil, ErrClientNotJoined
+	}
+	return c.manager.GetQueue(c.manager.Keys().TopicToPeer(pid)), nil
+}
+
+func (c *Client) send(signal *pb.SignalRequest) error {
+	queue, err := c.toPeer()
+	if err != nil {
+		return err
+	}
+	signal.Id = c.PeerID()
+	return EnqueueRequest(queue, &pb.NoirRequest{
+		TraceID: c.trace(),
+		Command: &pb.NoirRequest_Signal{Signal: signal},
+	})
+}
+
+// request sends a signal and waits up to the WebrtcTimeout for the reply with its RequestId
+func (c *Client) request(queue Queue, signal *pb.SignalRequest) (*pb.SignalReply, error) {
+	signal.Id = c.PeerID()
+	signal.RequestId = RandomString(16)
+	replied := make(chan *pb.NoirReply, 1)
+	c.mu.Lock()
+	c.pending[signal.RequestId] = replied
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, signal.RequestId)
+		c.mu.Unlock()
+	}()
+
+	err := EnqueueRequest(queue, &pb.NoirRequest{
+		TraceID: c.trace(),
+		Command: &pb.NoirRequest_Signal{Signal: signal},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, c.manager.WebrtcTimeout())
+	defer cancel()
+	select {
+	case reply := <-replied:
+		return c.replied(reply)
+	case <-c.stopped:
+		return nil, ErrClientClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// replied is the signal of a reply to one of the client's requests, or its failure
+func (c *Client) replied(reply *pb.NoirReply) (*pb.SignalReply, error) {
+	c.mu.Lock()
+	c.lastTrace = reply.TraceID
+	c.mu.Unlock()
+	if failure := reply.GetSignal().GetFailure(); failure != nil {
+		return nil, &SignalFailure{
+			Code:       failure.Code,
+			Message:    failure.Message,
+			RetryAfter: time.Duration(failure.RetryAfterMs) * time.Millisecond,
+			TraceID:    reply.TraceID,
+		}
+	}
+	return reply.GetSignal(), nil
+}
+
+// Capabilities asks what joining sid would settle, before joining or without, token is the one the
+// join would carry. It is answered under a pid of its own, see capabilities.go
+func (c *Client) Capabilities(sid string, token string) (*pb.Capabilities, error) {
+	replyTo := "capabilities-" + NewTraceID()
+	fromPeer := c.manager.GetQueue(c.manager.Keys().TopicFromPeer(replyTo))
+	defer fromPeer.Cleanup()
+	router := *c.manager.GetRouter()
+	err := EnqueueRequest(*router.GetQueue(), &pb.NoirRequest{
+		TraceID: c.trace(),
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id:        replyTo,
+				RequestId: RandomString(16),
+				Payload: &pb.SignalRequest_Capabilities{
+					Capabilities: &pb.CapabilitiesRequest{Sid: sid, Token: token},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	message, err := fromPeer.BlockUntilNext(c.manager.WebrtcTimeout())
+	if err == io.EOF {
+		return nil, context.DeadlineExceeded
+	} else if err != nil {
+		return nil, err
+	}
+	var reply pb.NoirReply
+	if err := UnmarshalReply(message, &reply); err != nil {
+		return nil, err
+	}
+	signal, err := c.replied(&reply)
+	if err != nil {
+		return nil, err
+	}
+	return signal.GetCapabilities(), nil
+}
+
+// listen reads the replies on replyTo until the join's answer says the pid to read them as
+func (c *Client) listen(replyTo string) {
+	defer close(c.stopped)
+	defer close(c.events)
+	fromPeer := c.manager.GetQueue(c.manager.Keys().TopicFromPeer(replyTo))
+	for c.ctx.Err() == nil {
+		message, err := fromPeer.BlockUntilNext(WorkerPollTimeout)
+		if err == io.EOF {
+			continue
+		} else if err != nil {
+			log.Errorf("client %s queue error: %s", c.PeerID(), err)
+			return
+		}
+		var reply pb.NoirReply
+		if err := UnmarshalReply(message, &reply); err != nil {
+			log.Errorf("client %s unmarshal error: %s", c.PeerID(), err)
+			continue
+		}
+		signal := reply.GetSignal()
+		if signal == nil {
+			continue
+		}
+		// the join's topic is done with once the answer says the peer's pid
+		if assigned := signal.GetJoin().GetPid(); assigned != "" {
+			c.mu.Lock()
+			c.pid = assigned
+			c.mu.Unlock()
+			fromPeer = c.manager.GetQueue(c.manager.Keys().TopicFromPeer(assigned))
+		}
+
+		c.mu.Lock()
+		replied, waiting := c.pending[signal.RequestId]
+		c.mu.Unlock()
+		if waiting && signal.RequestId != "" {
+			select {
+			case replied <- &reply:
+			default: // only the first reply counts
+			}
+			continue
+		}
+
+		select {
+		case c.events <- signal:
+		case <-c.ctx.Done():
+			return
+		}
+		if signal.GetKill() {
+			return
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package noir
+
+import (
+	"context"
+	"errors"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/webrtc/v3"
+	"regexp"
+	"testing"
+	"time"
+)
+
+// routeOnce routes and handles the next request, as a router and worker would
+func routeOnce(mgr *Manager) {
+	router := *mgr.GetRouter()
+	worker := *mgr.GetWorker()
+	if err := router.HandleNext(); err == nil {
+		worker.HandleNext(time.Second)
+	}
+}
+
+func TestClientJoin(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	redis.Del(pb.KeyRoomData("sdk"), pb.KeyRoomUsers("sdk"), pb.KeyTopicFromPeer("sdk-peer"), pb.KeyTopicToPeer("sdk-peer"))
+
+	client := NewClient(context.Background(), mgr)
+	client.SetTraceID("sdk-trace")
+	go routeOnce(mgr)
+	answer, err := client.Join("sdk", "sdk-peer", webrtc.SessionDescription{Type: webrtc.SDPTypeOffer, SDP: EXAMPLE_EMPTY_SDP})
+	if err != nil {
+		t.Fatalf("error joining: %s", err)
+	}
+	if answer.Type != webrtc.SDPTypeAnswer {
+		t.Errorf("got %s want an answer", answer.Type)
+	}
+	if trace := client.LastTraceID(); trace != "sdk-trace" {
+		t.Errorf("got trace %q want the join's trace back", trace)
+	}
+	if _, err := client.Join("sdk", "sdk-peer", *answer); err != ErrClientJoined {
+		t.Errorf("got %v want %s joining twice", err, ErrClientJoined)
+	}
+
+	if err := client.Close(); err != nil {
+		t.Errorf("error closing: %s", err)
+	}
+	for range client.Events() {
+	}
+	mgr.DisconnectUser("sdk-peer")
+}
+
+func TestClientJoinFailure(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	redis.Del(pb.KeyRoomData("sdk-broken"), pb.KeyRoomUsers("sdk-broken"), pb.KeyTopicFromPeer("sdk-broken-peer"))
+
+	noFingerprint := regexp.MustCompile(`a=fingerprint:[^\r]*\r\n`).ReplaceAllString(EXAMPLE_EMPTY_SDP, "")
+	client := NewClient(context.Background(), mgr)
+	defer client.Close()
+	go routeOnce(mgr)
+	_, err := client.Join("sdk-broken", "sdk-broken-peer", webrtc.SessionDescription{Type: webrtc.SDPTypeOffer, SDP: noFingerprint})
+	var failure *SignalFailure
+	if !errors.As(err, &failure) || failure.Code != pb.SignalError_JOIN_FAILED {
+		t.Fatalf("got %v want JOIN_FAILED", err)
+	}
+	if failure.TraceID == "" || failure.TraceID != client.LastTraceID() {
+		t.Errorf("got trace %q want the trace the join was given", failure.TraceID)
+	}
+}
+
+func TestClientCapabilities(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	redis.Del(pb.KeyRoomData("sdk-capable"))
+	mgr.SetRoomData(&pb.RoomData{Id: "sdk-capable", Options: &pb.RoomOptions{AudioOnly: true}})
+	defer redis.Del(pb.KeyRoomData("sdk-capable"))
+
+	client := NewClient(context.Background(), mgr)
+	defer client.Close()
+	go routeOnce(mgr)
+	capabilities, err := client.Capabilities("sdk-capable", "")
+	if err != nil {
+		t.Fatalf("error asking for capabilities: %s", err)
+	}
+	if room := capabilities.GetRoom(); !room.GetExists() || !room.GetAudioOnly() {
+		t.Errorf("got %s want the room's capabilities", capabilities)
+	}
+	if client.PeerID() != "" {
+		t.Errorf("got pid %s want asking to leave the client unjoined", client.PeerID())
+	}
+}
+
+func TestClientCancel(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	redis.Del(pb.KeyTopicFromPeer("sdk-cancel"))
+	(*(*mgr.GetRouter()).GetQueue()).Cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client := NewClient(ctx, mgr)
+	time.AfterFunc(100*time.Millisecond, cancel)
+	// nothing routes the join, so only the cancel ends it
+	if _, err := client.Join("sdk", "sdk-cancel", webrtc.SessionDescription{}); err == nil {
+		t.Errorf("join should fail once its context is cancelled")
+	}
+	(*(*mgr.GetRouter()).GetQueue()).Cleanup()
+}
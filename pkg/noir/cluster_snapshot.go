@@ -0,0 +1,212 @@
+package noir
+
+import (
+	"fmt"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	log "github.com/pion/ion-log"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"io"
+	"sort"
+	"time"
+)
+
+/*
+Cluster Snapshot:
+ClusterSnapshot gathers what the cluster looks like in one call, for dashboards and for working
+out what went wrong across nodes. Workers come from their heartbeats, and each live one is sent
+a WorkerStateRequest like GetRoomStats sends RoomStatsRequests, replying with the peers, rooms
+and peer goroutines it runs and the messages waiting for its peers. Rooms and their peers are
+read from redis, and so are the depths of the router's, the workers' and the dead letter queues.
+Nothing is read atomically, each section says when it was read, and a worker's reply when
+it was made. A worker that does not reply within ClusterSnapshotTimeout keeps its heartbeat in
+the snapshot and is listed in Missing, so one stuck node still leaves the rest to look at.
+*/
+
+// How long ClusterSnapshot waits for the workers to reply
+const ClusterSnapshotTimeout = 2 * time.Second
+
+// ClusterState is what ClusterSnapshot found, see the top of the file
+type ClusterState struct {
+	Workers     []WorkerState `json:"workers"`
+	WorkersAsOf time.Time     `json:"workers_as_of"`
+	Rooms       []RoomState   `json:"rooms"`
+	RoomsAsOf   time.Time     `json:"rooms_as_of"`
+	// messages waiting in each queue by its topic
+	Queues     map[string]int64 `json:"queues"`
+	QueuesAsOf time.Time        `json:"queues_as_of"`
+	// Missing lists the workers that did not reply in time
+	Missing []string `json:"missing"`
+}
+
+// WorkerState is a worker's heartbeat and, when it replied, what it said it runs
+type WorkerState struct {
+	WorkerStatus
+	Replied        bool             `json:"replied"`
+	AsOf           time.Time        `json:"as_of"`
+	Connected      []string         `json:"connected"`
+	Sessions       []string         `json:"sessions"`
+	PeerGoroutines int              `json:"peer_goroutines"`
+	PeerQueues     map[string]int64 `json:"peer_queues"`
+}
+
+// RoomState is a room and the pids of its peers
+type RoomState struct {
+	ID     string   `json:"id"`
+	NodeID string   `json:"node_id"`
+	Peers  []string `json:"peers"`
+}
+
+// ClusterSnapshot gathers the workers, rooms and queue depths of the cluster
+func (m *Manager) ClusterSnapshot() (ClusterState, error) {
+	state := ClusterState{Queues: map[string]int64{}, Missing: []string{}}
+	workers, err := m.ListWorkers()
+	state.WorkersAsOf = time.Now()
+	if err != nil {
+		return state, err
+	}
+	sort.Slice(workers, func(i, j int) bool { return workers[i].ID < workers[j].ID })
+
+	adminID := "snapshot-" + RandomString(16)
+	replies := m.GetQueue(m.Keys().TopicToAdmin(adminID))
+	defer replies.Cleanup()
+	waiting := map[string]int{}
+	for i, status := range workers {
+		state.Workers = append(state.Workers, WorkerState{WorkerStatus: status})
+		err := EnqueueRequest(*m.GetRemoteWorkerQueue(status.ID), &pb.NoirRequest{
+			AdminID: adminID,
+			Command: &pb.NoirRequest_Admin{
+				Admin: &pb.AdminRequest{
+					Payload: &pb.AdminRequest_WorkerState{WorkerState: &pb.WorkerStateRequest{}},
+				},
+			},
+		})
+		if err != nil {
+			log.Errorf("error asking %s for its state: %s", status.ID, err)
+		}
+		waiting[status.ID] = i
+	}
+
+	// redis is read while the workers reply
+	if err := m.snapshotRooms(&state); err != nil {
+		return state, err
+	}
+	if err := m.snapshotQueues(&state, workers); err != nil {
+		return state, err
+	}
+
+	deadline := time.Now().Add(ClusterSnapshotTimeout)
+	for len(waiting) > 0 && time.Now().Before(deadline) {
+		// redis blocks for whole seconds, so this may run past the deadline by one poll
+		message, err := replies.BlockUntilNext(WorkerPollTimeout)
+		if err == io.EOF {
+			continue
+		} else if err != nil {
+			return state, err
+		}
+		var reply pb.NoirReply
+		if err := proto.Unmarshal(message, &reply); err != nil {
+			log.Errorf("worker state unmarshal err: %s", err)
+			continue
+		}
+		workerState := reply.GetAdmin().GetWorkerState()
+		i, ok := waiting[workerState.GetNodeID()]
+		if !ok {
+			continue
+		}
+		delete(waiting, workerState.NodeID)
+		worker := &state.Workers[i]
+		worker.Replied = true
+		worker.AsOf = workerState.AsOf.AsTime()
+		worker.Connected = workerState.Peers
+		worker.Sessions = workerState.Rooms
+		worker.PeerGoroutines = int(workerState.PeerGoroutines)
+		worker.PeerQueues = workerState.PeerQueues
+	}
+	for node := range waiting {
+		state.Missing = append(state.Missing, node)
+	}
+	sort.Strings(state.Missing)
+	return state, nil
+}
+
+func (m *Manager) snapshotRooms(state *ClusterState) error {
+	rooms, err := m.ListRooms()
+	state.RoomsAsOf = time.Now()
+	if err != nil {
+		return err
+	}
+	sort.Slice(rooms, func(i, j int) bool { return rooms[i].Id < rooms[j].Id })
+	for _, room := range rooms {
+		pids, err := m.redis.HKeys(m.Keys().RoomUsers(room.Id)).Result()
+		if err != nil {
+			return fmt.Errorf("error reading the peers of %s: %w", room.Id, err)
+		}
+		sort.Strings(pids)
+		state.Rooms = append(state.Rooms, RoomState{ID: room.Id, NodeID: room.NodeID, Peers: pids})
+	}
+	return nil
+}
+
+func (m *Manager) snapshotQueues(state *ClusterState, workers []WorkerStatus) error {
+	queues := []Queue{m.NewQueue(m.RouterTopic(), m.RouterMaxAge())}
+	for _, status := range workers {
+		queues = append(queues, *m.GetRemoteWorkerQueue(status.ID))
+	}
+	for _, queue := range queues {
+		count, err := queue.Count()
+		if err != nil {
+			return fmt.Errorf("error counting %s: %w", queue.Topic(), err)
+		}
+		state.Queues[queue.Topic()] = count
+	}
+	for _, status := range workers {
+		key := m.Keys().DeadLetter(status.ID)
+		count, err := m.redis.LLen(key).Result()
+		if err != nil {
+			return fmt.Errorf("error counting %s: %w", key, err)
+		}
+		state.Queues[key] = count
+	}
+	state.QueuesAsOf = time.Now()
+	return nil
+}
+
+// HandleWorkerState replies with what this worker runs
+func (w *worker) HandleWorkerState(request *pb.NoirRequest) error {
+	mgr := w.manager
+	mgr.mu.RLock()
+	peers := make([]string, 0, len(mgr.users))
+	for pid := range mgr.users {
+		peers = append(peers, pid)
+	}
+	rooms := make([]string, 0, len(mgr.rooms))
+	for roomID := range mgr.rooms {
+		rooms = append(rooms, roomID)
+	}
+	mgr.mu.RUnlock()
+	sort.Strings(peers)
+	sort.Strings(rooms)
+	queued := map[string]int64{}
+	for _, pid := range peers {
+		if count, err := mgr.GetQueue(mgr.Keys().TopicToPeer(pid)).Count(); err == nil && count > 0 {
+			queued[pid] = count
+		}
+	}
+	return w.Reply(request, &pb.NoirReply{
+		Command: &pb.NoirReply_Admin{
+			Admin: &pb.AdminReply{
+				Payload: &pb.AdminReply_WorkerState{
+					WorkerState: &pb.WorkerStateReply{
+						NodeID:         w.id,
+						AsOf:           timestamppb.Now(),
+						Peers:          peers,
+						Rooms:          rooms,
+						PeerGoroutines: int32(mgr.PeerGoroutines()),
+						PeerQueues:     queued,
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,78 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"testing"
+	"time"
+)
+
+func TestClusterSnapshot(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	keys := append(peerKeys("snapshot-peer"), pb.KeyRoomData("snapshot-room"), pb.KeyRoomUsers("snapshot-room"))
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+	joinFake(t, mgr, "snapshot-peer", "snapshot-room", newFakePeer())
+	defer mgr.DisconnectUser("snapshot-peer")
+
+	// a worker whose heartbeat is current but which never answers
+	mgr.Heartbeat("snapshot-silent", 4)
+	defer mgr.ClearHeartbeat("snapshot-silent")
+
+	worker := *mgr.GetWorker()
+	done := make(chan error)
+	go func() { done <- worker.HandleNext(2 * time.Second) }()
+	started := time.Now()
+	state, err := mgr.ClusterSnapshot()
+	if err != nil {
+		t.Fatalf("error taking the snapshot: %s", err)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("error answering the snapshot: %s", err)
+	}
+	if state.WorkersAsOf.Before(started) || state.RoomsAsOf.Before(started) || state.QueuesAsOf.Before(started) {
+		t.Errorf("every section should say when it was read")
+	}
+
+	var own, silent *WorkerState
+	for i := range state.Workers {
+		switch state.Workers[i].ID {
+		case worker.ID():
+			own = &state.Workers[i]
+		case "snapshot-silent":
+			silent = &state.Workers[i]
+		}
+	}
+	if own == nil || !own.Replied || own.AsOf.Before(started) {
+		t.Fatalf("got %+v want this worker's reply", own)
+	}
+	if len(own.Connected) != 1 || own.Connected[0] != "snapshot-peer" || own.PeerGoroutines == 0 {
+		t.Errorf("got %+v want the worker's peer and its goroutines", own)
+	}
+	if silent == nil || silent.Replied || silent.Peers != 4 {
+		t.Errorf("got %+v want the silent worker's heartbeat without a reply", silent)
+	}
+	missing := false
+	for _, node := range state.Missing {
+		missing = missing || node == "snapshot-silent"
+		if node == worker.ID() {
+			t.Errorf("a worker that replied should not be missing")
+		}
+	}
+	if !missing {
+		t.Errorf("got missing %v want the silent worker", state.Missing)
+	}
+
+	found := false
+	for _, room := range state.Rooms {
+		if room.ID == "snapshot-room" {
+			found = len(room.Peers) == 1 && room.Peers[0] == "snapshot-peer"
+		}
+	}
+	if !found {
+		t.Errorf("got %+v want the room and its peer", state.Rooms)
+	}
+	if _, ok := state.Queues[pb.KeyWorkerTopic(worker.ID())]; !ok {
+		t.Errorf("got %v want the worker's queue depth", state.Queues)
+	}
+}
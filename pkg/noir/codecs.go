@@ -0,0 +1,195 @@
+package noir
+
+import (
+	"errors"
+	"fmt"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/sdp/v3"
+	"github.com/pion/webrtc/v3"
+	"strings"
+)
+
+/*
+Codecs:
+ion-sfu v1.6 builds its publisher media engine internally and gives us no way to swap it,
+so a MediaConfig is applied to the offers instead. Every join and renegotiation offer has
+the codecs and header extensions we don't allow stripped out before the sfu.Peer sees it,
+which leaves the sfu only the allowed ones to answer with. An empty list allows everything.
+A room can have its own codecs in RoomOptions.codecs, eg. only opus for a voice room, which
+replace the manager's for the peers in it. They are checked when the room is saved: a room
+has to allow an audio codec, and a video codec too unless it is audioOnly.
+*/
+
+var (
+	ErrNoCompatibleCodec = errors.New("no compatible codec")
+	ErrInvalidRoomCodecs = errors.New("invalid room codecs")
+)
+
+// MediaConfig restricts what peers may negotiate, codecs are mime types like webrtc.MimeTypeVP8
+// and header extensions are their uris, like sdp.TransportCCURI
+type MediaConfig struct {
+	Codecs           []string `mapstructure:"codecs"`
+	HeaderExtensions []string `mapstructure:"headerextensions"`
+}
+
+func (m *Manager) SetMediaConfig(media MediaConfig) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.media = media
+}
+
+func (m *Manager) MediaConfig() MediaConfig {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.media
+}
+
+// RoomMediaConfig is the manager's MediaConfig, with the room's codecs when it has any
+func (m *Manager) RoomMediaConfig(room *pb.RoomData) MediaConfig {
+	media := m.MediaConfig()
+	if codecs := room.GetOptions().GetCodecs(); len(codecs) > 0 {
+		media.Codecs = codecs
+	}
+	return media
+}
+
+// ValidateRoomCodecs checks the room's codecs are mime types that leave it every kind it sends
+func ValidateRoomCodecs(options *pb.RoomOptions) error {
+	codecs := options.GetCodecs()
+	if len(codecs) == 0 {
+		return nil
+	}
+	audio, video := false, false
+	for _, codec := range codecs {
+		kind := strings.ToLower(codec)
+		switch {
+		case strings.HasPrefix(kind, "audio/"):
+			audio = true
+		case strings.HasPrefix(kind, "video/"):
+			video = true
+		default:
+			return fmt.Errorf("%w: %s is not an audio or video mime type", ErrInvalidRoomCodecs, codec)
+		}
+	}
+	if !audio {
+		return fmt.Errorf("%w: no audio codec in %s", ErrInvalidRoomCodecs, strings.Join(codecs, ","))
+	}
+	if !video && !options.GetAudioOnly() {
+		return fmt.Errorf("%w: no video codec in %s, make it audioOnly for a voice room", ErrInvalidRoomCodecs, strings.Join(codecs, ","))
+	}
+	return nil
+}
+
+func (c MediaConfig) allowsCodec(mimeType string) bool {
+	return len(c.Codecs) == 0 || containsFold(c.Codecs, mimeType)
+}
+
+func (c MediaConfig) allowsHeaderExtension(uri string) bool {
+	return len(c.HeaderExtensions) == 0 || containsFold(c.HeaderExtensions, uri)
+}
+
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterOffer returns the offer with only the allowed codecs and header extensions left in it,
+// it fails with ErrNoCompatibleCodec when an audio or video section has no codec left
+func (c MediaConfig) FilterOffer(offer webrtc.SessionDescription) (webrtc.SessionDescription, error) {
+	if len(c.Codecs) == 0 && len(c.HeaderExtensions) == 0 {
+		return offer, nil
+	}
+	parsed, err := offer.Unmarshal()
+	if err != nil {
+		return offer, err
+	}
+	for _, media := range parsed.MediaDescriptions {
+		kind := media.MediaName.Media
+		// Port 0 is a section the peer has already rejected
+		if (kind != "audio" && kind != "video") || media.MediaName.Port.Value == 0 {
+			continue
+		}
+		if err := c.filterMedia(media); err != nil {
+			return offer, err
+		}
+	}
+	filtered, err := parsed.Marshal()
+	if err != nil {
+		return offer, err
+	}
+	offer.SDP = string(filtered)
+	return offer, nil
+}
+
+func (c MediaConfig) filterMedia(media *sdp.MediaDescription) error {
+	kind := media.MediaName.Media
+	allowed := map[string]bool{}
+	offered := []string{}
+	// rtx and the like name the payload they repair in "apt=", they live and die with it
+	repairs := map[string]string{}
+	for _, attr := range media.Attributes {
+		pt, rest := splitPayload(attr.Value)
+		switch attr.Key {
+		case "rtpmap":
+			codec := rest
+			if slash := strings.Index(codec, "/"); slash >= 0 {
+				codec = codec[:slash]
+			}
+			offered = append(offered, codec)
+			if c.allowsCodec(kind + "/" + codec) {
+				allowed[pt] = true
+			}
+		case "fmtp":
+			for _, param := range strings.Split(rest, ";") {
+				if apt := strings.TrimSpace(param); strings.HasPrefix(apt, "apt=") {
+					repairs[pt] = strings.TrimPrefix(apt, "apt=")
+				}
+			}
+		}
+	}
+	for pt, apt := range repairs {
+		allowed[pt] = allowed[apt]
+	}
+
+	formats := []string{}
+	for _, format := range media.MediaName.Formats {
+		if allowed[format] {
+			formats = append(formats, format)
+		}
+	}
+	if len(formats) == 0 {
+		return fmt.Errorf("%w for %s, offered %s", ErrNoCompatibleCodec, kind, strings.Join(offered, ","))
+	}
+	media.MediaName.Formats = formats
+
+	attributes := []sdp.Attribute{}
+	for _, attr := range media.Attributes {
+		switch attr.Key {
+		case "rtpmap", "fmtp", "rtcp-fb":
+			if pt, _ := splitPayload(attr.Value); pt != "*" && !allowed[pt] {
+				continue
+			}
+		case "extmap":
+			// "3 urn:ietf:params:rtp-hdrext:sdes:mid", sometimes with a direction or attributes
+			_, rest := splitPayload(attr.Value)
+			if uri, _ := splitPayload(rest); !c.allowsHeaderExtension(uri) {
+				continue
+			}
+		}
+		attributes = append(attributes, attr)
+	}
+	media.Attributes = attributes
+	return nil
+}
+
+// splitPayload splits an attribute value like "96 VP8/90000" into "96" and "VP8/90000"
+func splitPayload(value string) (string, string) {
+	if space := strings.Index(value, " "); space >= 0 {
+		return value[:space], strings.TrimSpace(value[space+1:])
+	}
+	return value, ""
+}
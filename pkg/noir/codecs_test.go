@@ -0,0 +1,177 @@
+package noir
+
+import (
+	"errors"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/webrtc/v3"
+	"google.golang.org/protobuf/proto"
+	"strings"
+	"testing"
+)
+
+const codecsTestSDP = "v=0\r\n" +
+	"o=- 1 2 IN IP4 127.0.0.1\r\n" +
+	"s=-\r\n" +
+	"t=0 0\r\n" +
+	"m=audio 9 UDP/TLS/RTP/SAVPF 111 0\r\n" +
+	"c=IN IP4 0.0.0.0\r\n" +
+	"a=mid:0\r\n" +
+	"a=extmap:1 urn:ietf:params:rtp-hdrext:sdes:mid\r\n" +
+	"a=extmap:2 urn:ietf:params:rtp-hdrext:ssrc-audio-level\r\n" +
+	"a=rtpmap:111 opus/48000/2\r\n" +
+	"a=fmtp:111 minptime=10;useinbandfec=1\r\n" +
+	"a=rtpmap:0 PCMU/8000\r\n" +
+	"m=video 9 UDP/TLS/RTP/SAVPF 96 97 102 103\r\n" +
+	"c=IN IP4 0.0.0.0\r\n" +
+	"a=mid:1\r\n" +
+	"a=extmap:1 urn:ietf:params:rtp-hdrext:sdes:mid\r\n" +
+	"a=rtpmap:96 VP8/90000\r\n" +
+	"a=rtcp-fb:96 nack\r\n" +
+	"a=rtpmap:97 rtx/90000\r\n" +
+	"a=fmtp:97 apt=96\r\n" +
+	"a=rtpmap:102 H264/90000\r\n" +
+	"a=rtcp-fb:102 nack\r\n" +
+	"a=fmtp:102 profile-level-id=42e01f\r\n" +
+	"a=rtpmap:103 rtx/90000\r\n" +
+	"a=fmtp:103 apt=102\r\n"
+
+func TestMediaConfigFilterOffer(t *testing.T) {
+	offer := webrtc.SessionDescription{Type: webrtc.SDPTypeOffer, SDP: codecsTestSDP}
+
+	unrestricted, err := MediaConfig{}.FilterOffer(offer)
+	if err != nil || unrestricted.SDP != codecsTestSDP {
+		t.Errorf("an empty config should leave the offer alone, got %s", err)
+	}
+
+	vp8Only := MediaConfig{
+		Codecs:           []string{webrtc.MimeTypeOpus, webrtc.MimeTypeVP8},
+		HeaderExtensions: []string{"urn:ietf:params:rtp-hdrext:sdes:mid"},
+	}
+	filtered, err := vp8Only.FilterOffer(offer)
+	if err != nil {
+		t.Fatalf("error filtering offer: %s", err)
+	}
+	parsed, _ := filtered.Unmarshal()
+	audio, video := parsed.MediaDescriptions[0], parsed.MediaDescriptions[1]
+	if got := strings.Join(audio.MediaName.Formats, " "); got != "111" {
+		t.Errorf("got audio formats %s want 111", got)
+	}
+	if got := strings.Join(video.MediaName.Formats, " "); got != "96 97" {
+		t.Errorf("got video formats %s want VP8 and its rtx, 96 97", got)
+	}
+	for _, unwanted := range []string{"H264", "PCMU", "apt=102", "rtcp-fb:102", "ssrc-audio-level"} {
+		if strings.Contains(filtered.SDP, unwanted) {
+			t.Errorf("filtered offer still has %s", unwanted)
+		}
+	}
+	if !strings.Contains(filtered.SDP, "a=fmtp:111 minptime=10;useinbandfec=1") {
+		t.Errorf("filtered offer lost the opus fmtp")
+	}
+
+	_, err = MediaConfig{Codecs: []string{webrtc.MimeTypeOpus, webrtc.MimeTypeVP9}}.FilterOffer(offer)
+	if !errors.Is(err, ErrNoCompatibleCodec) {
+		t.Errorf("got %s want ErrNoCompatibleCodec for VP9 only", err)
+	}
+}
+
+func TestWorkerJoinNoCompatibleCodec(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	mgr.SetMediaConfig(MediaConfig{Codecs: []string{webrtc.MimeTypeOpus, webrtc.MimeTypeVP9}})
+	defer mgr.SetMediaConfig(MediaConfig{})
+	redis.Del(pb.KeyRoomData("vp9-only"), pb.KeyRoomUsers("vp9-only"), pb.KeyTopicFromPeer("vp8-peer"))
+
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id:        "vp8-peer",
+				RequestId: "join-1",
+				Payload: &pb.SignalRequest_Join{
+					Join: &pb.JoinRequest{
+						Sid:         "vp9-only",
+						Description: []byte(codecsTestSDP),
+					},
+				},
+			},
+		},
+	})
+	if err := worker.HandleNext(0); err == nil {
+		t.Errorf("join offering no allowed video codec should fail")
+	}
+
+	reply := pb.NoirReply{}
+	msg, _ := mgr.GetQueue(pb.KeyTopicFromPeer("vp8-peer")).Next()
+	proto.Unmarshal(msg, &reply)
+	failure := reply.GetSignal().GetFailure()
+	if failure.GetCode() != pb.SignalError_NO_COMPATIBLE_CODEC || !strings.Contains(failure.GetMessage(), "no compatible codec") {
+		t.Errorf("got %s want NO_COMPATIBLE_CODEC", &reply)
+	}
+	if exists := redis.HExists(pb.KeyRoomUsers("vp9-only"), "vp8-peer").Val(); exists {
+		t.Errorf("failed join should not leave the peer in the room")
+	}
+}
+
+func TestValidateRoomCodecs(t *testing.T) {
+	for _, test := range []struct {
+		options *pb.RoomOptions
+		valid   bool
+	}{
+		{nil, true},
+		{&pb.RoomOptions{Codecs: []string{webrtc.MimeTypeOpus, webrtc.MimeTypeVP8}}, true},
+		{&pb.RoomOptions{Codecs: []string{webrtc.MimeTypeOpus}, AudioOnly: true}, true},
+		{&pb.RoomOptions{Codecs: []string{webrtc.MimeTypeOpus}}, false},
+		{&pb.RoomOptions{Codecs: []string{webrtc.MimeTypeVP8, webrtc.MimeTypeVP9}}, false},
+		{&pb.RoomOptions{Codecs: []string{webrtc.MimeTypeOpus, "VP8"}}, false},
+	} {
+		if err := ValidateRoomCodecs(test.options); (err == nil) != test.valid || (err != nil && !errors.Is(err, ErrInvalidRoomCodecs)) {
+			t.Errorf("got %v for %v want valid %v", err, test.options.GetCodecs(), test.valid)
+		}
+	}
+}
+
+func TestRoomCodecs(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	// the manager's codecs would turn the offer's VP8 away
+	mgr.SetMediaConfig(MediaConfig{Codecs: []string{webrtc.MimeTypeOpus, webrtc.MimeTypeVP9}})
+	defer mgr.SetMediaConfig(MediaConfig{})
+	keys := []string{pb.KeyRoomData("vp8-room"), pb.KeyRoomUsers("vp8-room"), pb.KeyPeerOwner("room-codec-peer"),
+		pb.KeyTopicFromPeer("room-codec-peer"), pb.KeyTopicToPeer("room-codec-peer")}
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	if _, _, err := mgr.OpenRoom("vp8-room", &pb.CreateRoomRequest{Options: &pb.RoomOptions{Codecs: []string{"video/vp8"}}}); !errors.Is(err, ErrInvalidRoomCodecs) {
+		t.Fatalf("got %v want a room without audio codecs refused", err)
+	}
+	room, _, err := mgr.OpenRoom("vp8-room", &pb.CreateRoomRequest{
+		Options: &pb.RoomOptions{Codecs: []string{webrtc.MimeTypeOpus, webrtc.MimeTypeVP8}},
+	})
+	if err != nil {
+		t.Fatalf("error opening room: %s", err)
+	}
+	filtered, err := mgr.RoomMediaConfig(room).FilterOffer(webrtc.SessionDescription{Type: webrtc.SDPTypeOffer, SDP: codecsTestSDP})
+	if err != nil || strings.Contains(filtered.SDP, "H264") || !strings.Contains(filtered.SDP, "VP8") {
+		t.Errorf("got %v want the offer down to the room's codecs", err)
+	}
+
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id: "room-codec-peer",
+				Payload: &pb.SignalRequest_Join{
+					Join: &pb.JoinRequest{Sid: "vp8-room", Description: []byte(codecsTestSDP)},
+				},
+			},
+		},
+	})
+	worker.HandleNext(0)
+	defer mgr.DisconnectUser("room-codec-peer")
+	// the test offer has no ice, so the sfu turns it away, past the codecs
+	reply := pb.NoirReply{}
+	msg, _ := mgr.GetQueue(pb.KeyTopicFromPeer("room-codec-peer")).Next()
+	proto.Unmarshal(msg, &reply)
+	if code := reply.GetSignal().GetFailure().GetCode(); code == pb.SignalError_NO_COMPATIBLE_CODEC {
+		t.Errorf("got %s want a join offering a codec the room allows past the codecs", &reply)
+	}
+}
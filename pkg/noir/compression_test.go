@@ -0,0 +1,146 @@
+package noir
+
+import (
+	"encoding/json"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/webrtc/v3"
+	"google.golang.org/protobuf/proto"
+	"strings"
+	"testing"
+	"time"
+)
+
+// exampleJoinReply is the reply to a browser joining with a camera and a microphone
+func exampleJoinReply() *pb.NoirReply {
+	video := "m=video 9 UDP/TLS/RTP/SAVPF 96 97 98 99 100 101 102\r\nc=IN IP4 0.0.0.0\r\na=rtcp:9 IN IP4 0.0.0.0\r\n" +
+		"a=ice-ufrag:1vfB\r\na=ice-pwd:C2aiDmz9WfYOyF93NC36kqaU\r\na=setup:active\r\na=mid:1\r\na=recvonly\r\na=rtcp-mux\r\n"
+	for _, codec := range []string{"96 VP8/90000", "97 rtx/90000", "98 VP9/90000", "99 rtx/90000", "100 H264/90000", "101 rtx/90000", "102 red/90000"} {
+		video += "a=rtpmap:" + codec + "\r\na=rtcp-fb:" + strings.Fields(codec)[0] + " goog-remb\r\na=rtcp-fb:" +
+			strings.Fields(codec)[0] + " transport-cc\r\na=rtcp-fb:" + strings.Fields(codec)[0] + " nack pli\r\n"
+	}
+	audio := "m=audio 9 UDP/TLS/RTP/SAVPF 111\r\nc=IN IP4 0.0.0.0\r\na=mid:2\r\na=recvonly\r\na=rtpmap:111 opus/48000/2\r\n" +
+		"a=fmtp:111 minptime=10;useinbandfec=1\r\na=extmap:1 urn:ietf:params:rtp-hdrext:ssrc-audio-level\r\n"
+	answer, _ := json.Marshal(webrtc.SessionDescription{Type: webrtc.SDPTypeAnswer, SDP: EXAMPLE_EMPTY_SDP + video + audio})
+	return &pb.NoirReply{
+		Id: "reply-id",
+		Command: &pb.NoirReply_Signal{
+			Signal: &pb.SignalReply{
+				Id:      "compressed-peer",
+				Payload: &pb.SignalReply_Join{Join: &pb.JoinReply{Description: answer}},
+			},
+		},
+	}
+}
+
+func TestReplyCompression(t *testing.T) {
+	reply := exampleJoinReply()
+	plain, _ := MarshalReply(reply)
+	for _, compression := range SupportedCompressions {
+		message, err := MarshalCompressedReply(reply, compression, DefaultCompressMinBytes)
+		if err != nil {
+			t.Fatalf("error compressing with %s: %s", compression, err)
+		}
+		if len(message) >= len(plain) {
+			t.Errorf("got %d bytes with %s want under the %d of the plain reply", len(message), compression, len(plain))
+		}
+		sent := pb.NoirReply{}
+		proto.Unmarshal(message, &sent)
+		if sent.Compression != compression || sent.GetSignal() != nil {
+			t.Errorf("got %s want the reply flagged %s and only in compressed", &sent, compression)
+		}
+		read := pb.NoirReply{}
+		if err := UnmarshalReply(message, &read); err != nil || !proto.Equal(&read, reply) {
+			t.Errorf("got %s %v want the reply back from %s", &read, err, compression)
+		}
+	}
+
+	trickle := &pb.NoirReply{Command: &pb.NoirReply_Signal{Signal: &pb.SignalReply{Id: "compressed-peer", Payload: &pb.SignalReply_Kill{Kill: true}}}}
+	message, _ := MarshalCompressedReply(trickle, pb.Compression_ZSTD, DefaultCompressMinBytes)
+	read := pb.NoirReply{}
+	if err := UnmarshalReply(message, &read); err != nil || read.Compression != pb.Compression_NONE || !read.GetSignal().GetKill() {
+		t.Errorf("got %s %v want a reply under the threshold sent as is", &read, err)
+	}
+
+	if _, err := ParseCompressions([]string{"zstd", "lz4"}); err == nil {
+		t.Errorf("an unknown compression should be a config error")
+	}
+	mgr, _ := NewTestSetup()
+	compressions, _ := ParseCompressions([]string{"GZIP", "zstd"})
+	mgr.SetReplyCompression(compressions)
+	if got := mgr.NegotiateCompression(SupportedCompressions); got != pb.Compression_GZIP {
+		t.Errorf("got %s want the manager's first choice the client reads", got)
+	}
+	if got := mgr.NegotiateCompression(nil); got != pb.Compression_NONE {
+		t.Errorf("got %s want replies to a client listing none sent as is", got)
+	}
+}
+
+func TestJoinCompression(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	mgr.SetReplyCompression([]pb.Compression{pb.Compression_ZSTD})
+	mgr.SetCompressMinBytes(-1)
+	defer mgr.SetReplyCompression(nil)
+	defer mgr.SetCompressMinBytes(0)
+	keys := []string{pb.KeyRoomData("compressed-room"), pb.KeyRoomUsers("compressed-room"), pb.KeyPeerOwner("compressed-peer"),
+		pb.KeyTopicFromPeer("compressed-peer"), pb.KeyTopicToPeer("compressed-peer")}
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id: "compressed-peer",
+				Payload: &pb.SignalRequest_Join{
+					Join: &pb.JoinRequest{Sid: "compressed-room", Description: []byte(EXAMPLE_EMPTY_SDP), Compression: SupportedCompressions},
+				},
+			},
+		},
+	})
+	if err := worker.HandleNext(0); err != nil {
+		t.Fatalf("error joining: %s", err)
+	}
+	defer mgr.DisconnectUser("compressed-peer")
+
+	replies := mgr.GetQueue(pb.KeyTopicFromPeer("compressed-peer"))
+	for {
+		message, err := replies.BlockUntilNext(time.Second)
+		if err != nil {
+			t.Fatalf("got no join reply: %s", err)
+		}
+		reply := pb.NoirReply{}
+		if err := UnmarshalReply(message, &reply); err != nil {
+			t.Fatalf("error reading a reply: %s", err)
+		}
+		if reply.GetSignal().GetJoin() == nil {
+			continue
+		}
+		sent := pb.NoirReply{}
+		proto.Unmarshal(message, &sent)
+		if sent.Compression != pb.Compression_ZSTD || reply.GetSignal().GetJoin().GetDescription() == nil {
+			t.Errorf("got %s sent with %s want the join's answer sent compressed", &reply, sent.Compression)
+		}
+		break
+	}
+
+	mgr.DisconnectUser("compressed-peer")
+	if got := mgr.PeerCompression("compressed-peer"); got != pb.Compression_NONE {
+		t.Errorf("got %s want a closed peer's compression forgotten", got)
+	}
+}
+
+func BenchmarkReplyCompression(b *testing.B) {
+	reply := exampleJoinReply()
+	plain, _ := MarshalReply(reply)
+	for _, compression := range []pb.Compression{pb.Compression_NONE, pb.Compression_GZIP, pb.Compression_ZSTD} {
+		b.Run(compression.String(), func(b *testing.B) {
+			var message []byte
+			for i := 0; i < b.N; i++ {
+				message, _ = MarshalCompressedReply(reply, compression, 0)
+				UnmarshalReply(message, &pb.NoirReply{})
+			}
+			b.ReportMetric(float64(len(message))/float64(len(plain)), "ratio")
+		})
+	}
+}
@@ -1,11 +1,433 @@
 package noir
 
 import (
+	"errors"
+	"fmt"
+	"github.com/go-redis/redis"
+	pb "github.com/net-prophet/noir/pkg/proto"
 	log "github.com/pion/ion-log"
 	"github.com/pion/ion-sfu/pkg/sfu"
+	"strings"
+	"time"
 )
 
+/*
+Config:
+A Config is what the config file says, see config.toml, and NewManagerWithConfig is the one place
+it is turned into a manager: it checks the Config with Validate, then makes the manager, applies
+every section and sets it up. Whatever is left at zero keeps its default, so the Config of a
+manager built with only a Node, like SetupNoir's, behaves as the Set* methods describe. A Config
+that cannot work, eg. a negative timeout or a codec that is not a mime type, is an
+ErrInvalidConfig naming the setting, rather than a node that starts and misbehaves.
+*/
+
+// The fewest ports ICEPortRange may span
+const MinICEPortRange = 100
+
+var ErrInvalidConfig = errors.New("invalid config")
+
 type Config struct {
-	Ion sfu.Config
-	Log log.Config `mapstructure:"log"`
+	Ion       sfu.Config
+	Log       log.Config       `mapstructure:"log"`
+	Bitrate   BitrateConfig    `mapstructure:"bitrate"`
+	Rooms     RoomsConfig      `mapstructure:"rooms"`
+	Turn      TurnConfig       `mapstructure:"turn"`
+	Timeouts  TimeoutsConfig   `mapstructure:"timeouts"`
+	Auth      AuthConfig       `mapstructure:"auth"`
+	Recording RecordingConfig  `mapstructure:"recording"`
+	Playback  PlaybackConfig   `mapstructure:"playback"`
+	Queue     QueueConfig      `mapstructure:"queue"`
+	Trickle   TrickleConfig    `mapstructure:"trickle"`
+	Media     MediaConfig      `mapstructure:"media"`
+	ICE       ICEPolicyConfig  `mapstructure:"ice"`
+	RTCP      RTCPPolicyConfig `mapstructure:"rtcp"`
+	Keyframes KeyframeConfig   `mapstructure:"keyframes"`
+	SDP       SDPLimitsConfig  `mapstructure:"sdp"`
+	RateLimit RateLimitConfig  `mapstructure:"ratelimit"`
+	Peers     PeersConfig      `mapstructure:"peers"`
+	// AdaptiveLayers tunes the layers of peers that joined with adaptiveLayers
+	AdaptiveLayers AdaptiveLayerConfig `mapstructure:"adaptivelayers"`
+	// Backpressure zero keeps DefaultBackpressure
+	Backpressure BackpressureConfig `mapstructure:"backpressure"`
+	// Node is what the manager runs on, it is not read from the config file
+	Node NodeConfig `mapstructure:"-"`
+}
+
+// NodeConfig is the node NewManagerWithConfig makes a manager for
+type NodeConfig struct {
+	Redis *redis.Client
+	// ID names the node, empty picks a random one
+	ID string
+	// Services the node runs, comma separated, empty runs every one
+	Services string
+	// SFU nil makes one from the Config
+	SFU *NoirSFU
+	// Queues nil queues messages in redis, as [queue] says
+	Queues QueueFactory
+}
+
+// PeersConfig says how joins get their pid
+type PeersConfig struct {
+	// IDs is client, unique or assigned, empty is client, see Manager.SetPeerIDMode
+	IDs string `mapstructure:"ids"`
+	// Reconnect is replace or reject, empty is replace, see Manager.SetReconnectPolicy
+	Reconnect string `mapstructure:"reconnect"`
+}
+
+// TrickleConfig batches the candidates the sfu gathers, a batchsize under 2 sends them one by one
+type TrickleConfig struct {
+	BatchSize int `mapstructure:"batchsize"`
+	// FlushMs is how long a candidate waits for company, 0 keeps the default of TrickleFlushInterval
+	FlushMs int `mapstructure:"flushms"`
+}
+
+// KeyframeConfig is how keyframes are asked for new subscribers, see Manager.SetKeyframeCoalesce
+type KeyframeConfig struct {
+	// CoalesceMs zero keeps DefaultKeyframeCoalesce, negative asks for no keyframes on subscribe
+	CoalesceMs int `mapstructure:"coalescems"`
+}
+
+// QueueConfig picks how redis holds queued messages, every node must agree
+type QueueConfig struct {
+	// Priority hands kills, mutes and room admin out before trickle, see RedisPriorityQueueFactory
+	Priority bool `mapstructure:"priority"`
+	// RouterStreams reads the router topic through a redis stream consumer group, see RouterStreamQueueFactory
+	RouterStreams bool `mapstructure:"routerstreams"`
+	// MaxMessageSize in bytes, larger messages are dead lettered unread, 0 keeps the default of MaxMessageSize, -1 reads any size
+	MaxMessageSize int `mapstructure:"maxmessagesize"`
+	// KeyPrefix is what every redis key and topic starts with, empty is noir/, see Manager.SetKeyPrefix
+	KeyPrefix string `mapstructure:"keyprefix"`
+	// EncryptKey names the key of EncryptionKeys queued messages are encrypted with, empty sends them in the clear
+	EncryptKey string `mapstructure:"encryptkey"`
+	// EncryptionKeys are base64 AES keys by key id, see QueueKeyring
+	EncryptionKeys map[string]string `mapstructure:"encryptionkeys"`
+	// Compression is the compressions replies may be sent in, by preference, eg. zstd or gzip, see Manager.SetReplyCompression
+	Compression []string `mapstructure:"compression"`
+	// CompressMinBytes is the smallest reply compressed, 0 keeps the default of DefaultCompressMinBytes, -1 compresses every reply
+	CompressMinBytes int `mapstructure:"compressminbytes"`
+}
+
+type RecordingConfig struct {
+	// Dir is where recordings go, a moderator's startrecording outputDir is a dir under it and only
+	// the admin api's names another
+	Dir string `mapstructure:"dir"`
+}
+
+type PlaybackConfig struct {
+	// Dir is where the files peers play into rooms are read from, a play names a file under it
+	Dir string `mapstructure:"dir"`
+}
+
+// AuthConfig turns on join authentication, peers send an HS256 JWT as the join token
+type AuthConfig struct {
+	JWTSecret string `mapstructure:"jwtsecret"`
+	// AdminToken is the bearer token the admin HTTP API wants, none leaves it open
+	AdminToken string `mapstructure:"admintoken"`
+}
+
+// TimeoutsConfig in seconds, 0 keeps the defaults (WebrtcTimeout, RouterMaxAge)
+type TimeoutsConfig struct {
+	WebRTC       int `mapstructure:"webrtc"`
+	RouterMaxAge int `mapstructure:"routermaxage"`
+	// ResumeGrace keeps peers whose client dropped, 0 disconnects them right away
+	ResumeGrace int `mapstructure:"resumegrace"`
+	// PeerQueueRetries 0 keeps the default of PeerQueueRetries
+	PeerQueueRetries int `mapstructure:"peerqueueretries"`
+	// Heartbeat is how often workers report they are alive, 0 keeps the default of WorkerHeartbeatInterval
+	Heartbeat int `mapstructure:"heartbeat"`
+	// FailoverWindow is how long a silent worker has before its peers are told to rejoin, 0 is 3 heartbeats
+	FailoverWindow int `mapstructure:"failoverwindow"`
+	// DedupWindow is how long a request id is remembered, 0 keeps the default of DedupWindow, -1 turns it off
+	DedupWindow int `mapstructure:"dedupwindow"`
+	// ICEGrace is how long a peer whose ICE failed has to reconnect, 0 keeps the default of ICEGrace, -1 never disconnects it
+	ICEGrace int `mapstructure:"icegrace"`
+	// QualityInterval is how often peers that asked get their ConnectionQuality, 0 keeps the default of QualityInterval, -1 sends none
+	QualityInterval int `mapstructure:"qualityinterval"`
+	// Keepalive is how often peers that asked are pinged, 0 keeps the default of KeepaliveInterval, -1 pings none
+	Keepalive int `mapstructure:"keepalive"`
+	// KeepaliveMisses is how many pings in a row a peer may leave unanswered, 0 keeps the default of KeepaliveMisses
+	KeepaliveMisses int `mapstructure:"keepalivemisses"`
+	// IdleTimeout is how long a peer may neither publish nor signal before it is closed, 0 never closes idle peers
+	IdleTimeout int `mapstructure:"idletimeout"`
+	// StatsWindowMs is how long a peer's GetStatsRequest samples it, 0 keeps the default of StatsWindow
+	StatsWindowMs int `mapstructure:"statswindowms"`
+	// OfferBundleMs is how long a joining peer's renegotiations have to settle before it is offered them
+	// at once, 0 sends every offer as the sfu makes it
+	OfferBundleMs int `mapstructure:"offerbundlems"`
+	// RedisConnect is how long startup waits for redis to answer, 0 waits for as long as it takes
+	RedisConnect int `mapstructure:"redisconnect"`
+	// Drain is how long a quitting node lets its peers finish, taking no joins, 0 stops it right away
+	Drain int `mapstructure:"drain"`
+}
+
+// TurnConfig hands out ICE servers to joining peers, with TURN REST API credentials
+type TurnConfig struct {
+	STUN   []string `mapstructure:"stun"`
+	URLs   []string `mapstructure:"urls"`
+	Secret string   `mapstructure:"secret"`
+	TTL    int      `mapstructure:"ttl"` // seconds
+}
+
+type RoomsConfig struct {
+	// AutoCreate lets peers create a room by joining it
+	AutoCreate bool `mapstructure:"autocreate"`
+	// MaxTracks caps the audio and video tracks a peer may publish, 0 is no cap
+	MaxTracks int `mapstructure:"maxtracks"`
+	// MaxPeers caps the peers this node runs at once, 0 is no cap
+	MaxPeers int `mapstructure:"maxpeers"`
+	// MaxPeerGoroutines refuses joins while peers run that many goroutines, 0 is no cap
+	MaxPeerGoroutines int `mapstructure:"maxpeergoroutines"`
+	// EmptyRoom is age, close or keep, empty is age, see Manager.SetEmptyRoomPolicy
+	EmptyRoom string `mapstructure:"emptyroom"`
+	// EmptyGrace is how long (seconds) keep keeps empty rooms, 0 until they expire
+	EmptyGrace int `mapstructure:"emptygrace"`
+}
+
+// BitrateConfig sets per-peer limits in kbps, 0 means no limit
+type BitrateConfig struct {
+	DefaultPublish   uint32 `mapstructure:"defaultpublish"`
+	DefaultSubscribe uint32 `mapstructure:"defaultsubscribe"`
+	MaxPublish       uint32 `mapstructure:"maxpublish"`
+	MaxSubscribe     uint32 `mapstructure:"maxsubscribe"`
+}
+
+// Validate is an ErrInvalidConfig for the first setting of the Config that cannot work
+func (c Config) Validate() error {
+	invalid := func(format string, args ...interface{}) error {
+		return fmt.Errorf("%w: %s", ErrInvalidConfig, fmt.Sprintf(format, args...))
+	}
+	if c.Node.Redis == nil {
+		return invalid("no redis client for the node")
+	}
+	if ports := c.Ion.WebRTC.ICEPortRange; len(ports) != 0 {
+		if len(ports) != 2 || ports[1] < ports[0] || ports[1]-ports[0] < MinICEPortRange {
+			return invalid("ion.webrtc.portrange %v must be [min, max] with max - min >= %d", ports, MinICEPortRange)
+		}
+	}
+
+	for name, value := range map[string]int{
+		"timeouts.webrtc":           c.Timeouts.WebRTC,
+		"timeouts.routermaxage":     c.Timeouts.RouterMaxAge,
+		"timeouts.resumegrace":      c.Timeouts.ResumeGrace,
+		"timeouts.peerqueueretries": c.Timeouts.PeerQueueRetries,
+		"timeouts.heartbeat":        c.Timeouts.Heartbeat,
+		"timeouts.failoverwindow":   c.Timeouts.FailoverWindow,
+		"timeouts.idletimeout":      c.Timeouts.IdleTimeout,
+		"timeouts.statswindowms":    c.Timeouts.StatsWindowMs,
+		"timeouts.offerbundlems":    c.Timeouts.OfferBundleMs,
+		"timeouts.redisconnect":     c.Timeouts.RedisConnect,
+		"timeouts.drain":            c.Timeouts.Drain,
+		"timeouts.keepalivemisses":  c.Timeouts.KeepaliveMisses,
+		"rooms.maxtracks":           c.Rooms.MaxTracks,
+		"rooms.maxpeers":            c.Rooms.MaxPeers,
+		"rooms.maxpeergoroutines":   c.Rooms.MaxPeerGoroutines,
+		"rooms.emptygrace":          c.Rooms.EmptyGrace,
+		"trickle.batchsize":         c.Trickle.BatchSize,
+		"trickle.flushms":           c.Trickle.FlushMs,
+		"turn.ttl":                  c.Turn.TTL,
+		"ratelimit.joins":           c.RateLimit.Joins,
+		"ratelimit.interval":        c.RateLimit.Interval,
+		"backpressure.maxqueued":    int(c.Backpressure.MaxQueued),
+		"backpressure.retries":      c.Backpressure.Retries,
+		"backpressure.backoffms":    c.Backpressure.BackoffMs,
+	} {
+		if value < 0 {
+			return invalid("%s %d is negative", name, value)
+		}
+	}
+	// the rest take -1 to turn them off
+	for name, value := range map[string]int{
+		"timeouts.dedupwindow":     c.Timeouts.DedupWindow,
+		"timeouts.icegrace":        c.Timeouts.ICEGrace,
+		"timeouts.qualityinterval": c.Timeouts.QualityInterval,
+		"timeouts.keepalive":       c.Timeouts.Keepalive,
+		"queue.maxmessagesize":     c.Queue.MaxMessageSize,
+	} {
+		if value < -1 {
+			return invalid("%s %d is under -1", name, value)
+		}
+	}
+	heartbeat := WorkerHeartbeatInterval
+	if c.Timeouts.Heartbeat > 0 {
+		heartbeat = time.Duration(c.Timeouts.Heartbeat) * time.Second
+	}
+	if failover := time.Duration(c.Timeouts.FailoverWindow) * time.Second; failover > 0 && failover <= heartbeat {
+		return invalid("timeouts.failoverwindow %s fails over workers between heartbeats every %s", failover, heartbeat)
+	}
+
+	if strings.ContainsAny(c.Queue.KeyPrefix, " \t\n") {
+		return invalid("queue.keyprefix %q has whitespace", c.Queue.KeyPrefix)
+	}
+	if _, err := ParsePeerIDMode(c.Peers.IDs); err != nil {
+		return invalid("peers.ids: %s", err)
+	}
+	if _, err := ParseReconnectPolicy(c.Peers.Reconnect); err != nil {
+		return invalid("peers.reconnect: %s", err)
+	}
+	if _, err := ParseEmptyRoomPolicy(c.Rooms.EmptyRoom); err != nil {
+		return invalid("rooms.emptyroom: %s", err)
+	}
+	if _, err := ParseCompressions(c.Queue.Compression); err != nil {
+		return invalid("queue.compression: %s", err)
+	}
+	if _, err := c.Queue.Keyring(); err != nil {
+		return invalid("queue encryption: %s", err)
+	}
+	for _, codec := range c.Media.Codecs {
+		kind := strings.ToLower(codec)
+		if !strings.HasPrefix(kind, "audio/") && !strings.HasPrefix(kind, "video/") {
+			return invalid("media.codecs %s is not an audio or video mime type", codec)
+		}
+	}
+	for _, extension := range c.Media.HeaderExtensions {
+		if strings.TrimSpace(extension) == "" {
+			return invalid("media.headerextensions has an empty uri")
+		}
+	}
+	if _, err := ParseAggregation(c.RTCP.Aggregation); err != nil {
+		return invalid("rtcp.aggregation: %s", err)
+	}
+	if err := ValidateRTCPPolicy(c.RTCP.Policy()); err != nil {
+		return invalid("rtcp: %s", err)
+	}
+	if err := ValidateAdaptiveLayers(c.AdaptiveLayers); err != nil {
+		return invalid("adaptivelayers: %s", err)
+	}
+	if c.Bitrate.MaxPublish > 0 && c.Bitrate.DefaultPublish > c.Bitrate.MaxPublish {
+		return invalid("bitrate.defaultpublish %d is over bitrate.maxpublish %d", c.Bitrate.DefaultPublish, c.Bitrate.MaxPublish)
+	}
+	if c.Bitrate.MaxSubscribe > 0 && c.Bitrate.DefaultSubscribe > c.Bitrate.MaxSubscribe {
+		return invalid("bitrate.defaultsubscribe %d is over bitrate.maxsubscribe %d", c.Bitrate.DefaultSubscribe, c.Bitrate.MaxSubscribe)
+	}
+	if len(c.Turn.URLs) > 0 && c.Turn.Secret == "" {
+		return invalid("turn.urls need turn.secret")
+	}
+	if c.RateLimit.Joins > 0 && c.RateLimit.Interval == 0 {
+		return invalid("ratelimit.joins need a ratelimit.interval")
+	}
+	return nil
+}
+
+// NewManagerWithConfig makes and sets up the manager of the Config's node, or says why the Config
+// cannot work, see the top of the file
+func NewManagerWithConfig(config Config) (*Manager, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	node := config.Node
+	if node.ID == "" {
+		node.ID = RandomString(8)
+	}
+	if node.Services == "" {
+		node.Services = "*"
+	}
+	keys := pb.NewKeys(config.Queue.KeyPrefix)
+
+	// everything that may fail is made before the manager, which the sfu is attached to
+	keyring, _ := config.Queue.Keyring()
+	compressions, _ := ParseCompressions(config.Queue.Compression)
+	var iceServers ICEServerProvider
+	if len(config.Turn.STUN) > 0 || len(config.Turn.URLs) > 0 {
+		ttl := time.Duration(config.Turn.TTL) * time.Second
+		if ttl == 0 {
+			ttl = time.Hour
+		}
+		provider, err := NewTURNRESTProvider(config.Turn.STUN, config.Turn.URLs, config.Turn.Secret, ttl)
+		if err != nil {
+			return nil, fmt.Errorf("%w: turn: %s", ErrInvalidConfig, err)
+		}
+		iceServers = provider
+	}
+	var authenticator Authenticator
+	if config.Auth.JWTSecret != "" {
+		jwt, err := NewJWTAuthenticator(config.Auth.JWTSecret)
+		if err != nil {
+			return nil, fmt.Errorf("%w: auth: %s", ErrInvalidConfig, err)
+		}
+		authenticator = jwt
+	}
+	var limiter JoinLimiter
+	if config.RateLimit.Joins > 0 {
+		joins, err := NewRedisJoinLimiter(node.Redis, keys, config.RateLimit.Joins, time.Duration(config.RateLimit.Interval)*time.Second)
+		if err != nil {
+			return nil, fmt.Errorf("%w: ratelimit: %s", ErrInvalidConfig, err)
+		}
+		limiter = joins
+	}
+	if node.SFU == nil {
+		sfu := NewNoirSFU(config)
+		node.SFU = &sfu
+	}
+
+	mgr := NewRedisManager(node.SFU, node.Redis, node.ID, node.Services)
+	// these are read by SetupManager
+	mgr.SetKeyPrefix(config.Queue.KeyPrefix)
+	mgr.SetTimeouts(time.Duration(config.Timeouts.WebRTC)*time.Second,
+		time.Duration(config.Timeouts.RouterMaxAge)*time.Second)
+	mgr.SetHeartbeatInterval(time.Duration(config.Timeouts.Heartbeat) * time.Second)
+	mgr.SetQueueKeyring(keyring)
+
+	mgr.SetResumeGrace(time.Duration(config.Timeouts.ResumeGrace) * time.Second)
+	mgr.SetPeerQueueRetries(config.Timeouts.PeerQueueRetries)
+	mgr.SetFailoverWindow(time.Duration(config.Timeouts.FailoverWindow) * time.Second)
+	mgr.SetDrainTimeout(time.Duration(config.Timeouts.Drain) * time.Second)
+	mgr.SetDedupWindow(time.Duration(config.Timeouts.DedupWindow) * time.Second)
+	mgr.SetICEGrace(time.Duration(config.Timeouts.ICEGrace) * time.Second)
+	mgr.SetQualityInterval(time.Duration(config.Timeouts.QualityInterval) * time.Second)
+	mgr.SetKeepalive(time.Duration(config.Timeouts.Keepalive)*time.Second, config.Timeouts.KeepaliveMisses)
+	mgr.SetIdleTimeout(time.Duration(config.Timeouts.IdleTimeout) * time.Second)
+	mgr.SetStatsWindow(time.Duration(config.Timeouts.StatsWindowMs) * time.Millisecond)
+	mgr.SetOfferBundling(time.Duration(config.Timeouts.OfferBundleMs) * time.Millisecond)
+	mgr.SetTrickleBatching(config.Trickle.BatchSize, time.Duration(config.Trickle.FlushMs)*time.Millisecond)
+	mgr.SetMediaConfig(config.Media)
+	mgr.SetBackpressure(config.Backpressure)
+	mgr.SetICEPolicy(config.ICE.Policy())
+	mgr.SetRTCPPolicy(config.RTCP.Policy())
+	mgr.SetAdaptiveLayers(config.AdaptiveLayers)
+	mgr.SetKeyframeCoalesce(time.Duration(config.Keyframes.CoalesceMs) * time.Millisecond)
+	mgr.SetSDPLimits(config.SDP.Limits())
+	mgr.SetReplyCompression(compressions)
+	mgr.SetCompressMinBytes(config.Queue.CompressMinBytes)
+	peerIDs, _ := ParsePeerIDMode(config.Peers.IDs)
+	mgr.SetPeerIDMode(peerIDs)
+	reconnect, _ := ParseReconnectPolicy(config.Peers.Reconnect)
+	mgr.SetReconnectPolicy(reconnect)
+
+	queues := node.Queues
+	if queues == nil {
+		queues = RedisQueueFactory(node.Redis)
+		if config.Queue.Priority {
+			queues = RedisPriorityQueueFactory(node.Redis)
+		}
+		if config.Queue.RouterStreams {
+			queues = RouterStreamQueueFactory(queues, node.Redis, node.ID, mgr.RouterTopic())
+		}
+	}
+	SetupManager(mgr, queues)
+
+	mgr.SetMaxMessageSize(config.Queue.MaxMessageSize)
+	mgr.SetAllowAutoCreateRooms(config.Rooms.AutoCreate)
+	mgr.SetMaxPeers(config.Rooms.MaxPeers)
+	mgr.SetMaxPeerGoroutines(config.Rooms.MaxPeerGoroutines)
+	emptyRoom, _ := ParseEmptyRoomPolicy(config.Rooms.EmptyRoom)
+	mgr.SetEmptyRoomPolicy(emptyRoom, time.Duration(config.Rooms.EmptyGrace)*time.Second)
+	if config.Rooms.MaxTracks > 0 {
+		mgr.SetOfferPolicy(OfferPolicies(AudioOnlyPolicy(), MaxTracksPolicy(config.Rooms.MaxTracks)))
+	}
+	if iceServers != nil {
+		mgr.SetICEServerProvider(iceServers)
+	}
+	if authenticator != nil {
+		mgr.SetAuthenticator(authenticator)
+	}
+	if limiter != nil {
+		mgr.SetJoinLimiter(limiter)
+	}
+	mgr.SetRecordingDir(config.Recording.Dir)
+	mgr.SetPlaybackDir(config.Playback.Dir)
+	mgr.SetBitrateLimits(
+		&pb.BitrateLimits{PublishKbps: config.Bitrate.DefaultPublish, SubscribeKbps: config.Bitrate.DefaultSubscribe},
+		&pb.BitrateLimits{PublishKbps: config.Bitrate.MaxPublish, SubscribeKbps: config.Bitrate.MaxSubscribe},
+	)
+	return mgr, nil
 }
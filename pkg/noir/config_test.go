@@ -0,0 +1,136 @@
+package noir
+
+import (
+	"errors"
+	"github.com/go-redis/redis"
+	"github.com/pion/webrtc/v3"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfigValidate(t *testing.T) {
+	client := redis.NewClient(&redis.Options{})
+	defer client.Close()
+	valid := func() Config { return Config{Node: NodeConfig{Redis: client}} }
+	if err := valid().Validate(); err != nil {
+		t.Fatalf("got %s want the defaults valid", err)
+	}
+
+	for _, test := range []struct {
+		setting string
+		invalid func(c *Config)
+	}{
+		{"redis", func(c *Config) { c.Node.Redis = nil }},
+		{"ion.webrtc.portrange", func(c *Config) { c.Ion.WebRTC.ICEPortRange = []uint16{50000, 50010} }},
+		{"ion.webrtc.portrange", func(c *Config) { c.Ion.WebRTC.ICEPortRange = []uint16{50000} }},
+		{"ion.webrtc.portrange", func(c *Config) { c.Ion.WebRTC.ICEPortRange = []uint16{60000, 50000} }},
+		{"timeouts.webrtc", func(c *Config) { c.Timeouts.WebRTC = -1 }},
+		{"timeouts.routermaxage", func(c *Config) { c.Timeouts.RouterMaxAge = -5 }},
+		{"timeouts.resumegrace", func(c *Config) { c.Timeouts.ResumeGrace = -1 }},
+		{"timeouts.peerqueueretries", func(c *Config) { c.Timeouts.PeerQueueRetries = -1 }},
+		{"timeouts.heartbeat", func(c *Config) { c.Timeouts.Heartbeat = -1 }},
+		{"timeouts.failoverwindow", func(c *Config) { c.Timeouts.FailoverWindow = -1 }},
+		{"timeouts.failoverwindow", func(c *Config) { c.Timeouts.FailoverWindow = 5 }},
+		{"timeouts.failoverwindow", func(c *Config) { c.Timeouts.Heartbeat, c.Timeouts.FailoverWindow = 10, 8 }},
+		{"timeouts.idletimeout", func(c *Config) { c.Timeouts.IdleTimeout = -1 }},
+		{"timeouts.statswindowms", func(c *Config) { c.Timeouts.StatsWindowMs = -1 }},
+		{"timeouts.offerbundlems", func(c *Config) { c.Timeouts.OfferBundleMs = -1 }},
+		{"timeouts.redisconnect", func(c *Config) { c.Timeouts.RedisConnect = -1 }},
+		{"timeouts.drain", func(c *Config) { c.Timeouts.Drain = -1 }},
+		{"timeouts.dedupwindow", func(c *Config) { c.Timeouts.DedupWindow = -2 }},
+		{"timeouts.icegrace", func(c *Config) { c.Timeouts.ICEGrace = -2 }},
+		{"timeouts.qualityinterval", func(c *Config) { c.Timeouts.QualityInterval = -2 }},
+		{"timeouts.keepalive", func(c *Config) { c.Timeouts.Keepalive = -2 }},
+		{"timeouts.keepalivemisses", func(c *Config) { c.Timeouts.KeepaliveMisses = -1 }},
+		{"rooms.maxtracks", func(c *Config) { c.Rooms.MaxTracks = -1 }},
+		{"rooms.maxpeers", func(c *Config) { c.Rooms.MaxPeers = -1 }},
+		{"rooms.maxpeergoroutines", func(c *Config) { c.Rooms.MaxPeerGoroutines = -1 }},
+		{"trickle.batchsize", func(c *Config) { c.Trickle.BatchSize = -1 }},
+		{"trickle.flushms", func(c *Config) { c.Trickle.FlushMs = -1 }},
+		{"queue.maxmessagesize", func(c *Config) { c.Queue.MaxMessageSize = -2 }},
+		{"queue.keyprefix", func(c *Config) { c.Queue.KeyPrefix = "my noir/" }},
+		{"queue.compression", func(c *Config) { c.Queue.Compression = []string{"lz4"} }},
+		{"queue encryption", func(c *Config) { c.Queue.EncryptKey = "missing" }},
+		{"media.codecs", func(c *Config) { c.Media.Codecs = []string{webrtc.MimeTypeOpus, "vp8"} }},
+		{"media.headerextensions", func(c *Config) { c.Media.HeaderExtensions = []string{" "} }},
+		{"rtcp", func(c *Config) { c.RTCP.MaxRetransmits = -1 }},
+		{"rtcp.aggregation", func(c *Config) { c.RTCP.Aggregation = "average" }},
+		{"rtcp", func(c *Config) { c.RTCP.BufferMs = -1 }},
+		{"adaptivelayers", func(c *Config) { c.AdaptiveLayers.TargetUtilization = 1.5 }},
+		{"adaptivelayers", func(c *Config) { c.AdaptiveLayers.LayerKbps = []int{500, 150, 1500} }},
+		{"peers.ids", func(c *Config) { c.Peers.IDs = "random" }},
+		{"peers.reconnect", func(c *Config) { c.Peers.Reconnect = "merge" }},
+		{"rooms.emptyroom", func(c *Config) { c.Rooms.EmptyRoom = "forever" }},
+		{"rooms.emptygrace", func(c *Config) { c.Rooms.EmptyGrace = -1 }},
+		{"bitrate.defaultpublish", func(c *Config) { c.Bitrate.DefaultPublish, c.Bitrate.MaxPublish = 2000, 1000 }},
+		{"bitrate.defaultsubscribe", func(c *Config) { c.Bitrate.DefaultSubscribe, c.Bitrate.MaxSubscribe = 2000, 1000 }},
+		{"turn.ttl", func(c *Config) { c.Turn.TTL = -1 }},
+		{"turn.urls", func(c *Config) { c.Turn.URLs = []string{"turn:turn.example.com:3478"} }},
+		{"ratelimit.joins", func(c *Config) { c.RateLimit.Joins = -1 }},
+		{"ratelimit.joins", func(c *Config) { c.RateLimit.Joins = 10 }},
+		{"ratelimit.interval", func(c *Config) { c.RateLimit.Interval = -1 }},
+		{"backpressure.maxqueued", func(c *Config) { c.Backpressure.MaxQueued = -1 }},
+		{"backpressure.retries", func(c *Config) { c.Backpressure.Retries = -1 }},
+		{"backpressure.backoffms", func(c *Config) { c.Backpressure.BackoffMs = -1 }},
+	} {
+		config := valid()
+		test.invalid(&config)
+		err := config.Validate()
+		if !errors.Is(err, ErrInvalidConfig) || !strings.Contains(err.Error(), test.setting) {
+			t.Errorf("got %v want an ErrInvalidConfig naming %s", err, test.setting)
+			continue
+		}
+		if _, err := NewManagerWithConfig(config); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("got %v want no manager with a bad %s", err, test.setting)
+		}
+	}
+
+	// the settings that take -1 to turn them off
+	config := valid()
+	config.Timeouts.DedupWindow, config.Timeouts.ICEGrace, config.Timeouts.QualityInterval = -1, -1, -1
+	config.Queue.MaxMessageSize = -1
+	if err := config.Validate(); err != nil {
+		t.Errorf("got %s want -1 to turn settings off", err)
+	}
+}
+
+func TestNewManagerWithConfig(t *testing.T) {
+	_, client := NewTestSetup()
+	cleanup := func() {
+		if keys := client.Keys("configured/*").Val(); len(keys) > 0 {
+			client.Del(keys...)
+		}
+	}
+	cleanup()
+	defer cleanup()
+
+	config := Config{Node: NodeConfig{Redis: client, ID: "configured-worker"}}
+	config.Queue.KeyPrefix = "configured"
+	config.Timeouts.WebRTC = 7
+	config.Rooms.AutoCreate = true
+	config.Rooms.MaxPeers = 3
+	config.Queue.MaxMessageSize = 4096
+	mgr, err := NewManagerWithConfig(config)
+	if err != nil {
+		t.Fatalf("error making the manager: %s", err)
+	}
+	if mgr.ID() != "configured-worker" || !mgr.IsServiceEnabled("sfu") {
+		t.Errorf("got %s want configured-worker running every service", mgr.ID())
+	}
+	if mgr.GetWorker() == nil || mgr.GetRouter() == nil {
+		t.Errorf("the manager should be set up")
+	}
+	if mgr.RouterTopic() != "configured/" {
+		t.Errorf("got %s want the keys under configured/", mgr.RouterTopic())
+	}
+	if mgr.WebrtcTimeout() != 7*time.Second || mgr.RouterMaxAge() != 7*time.Second {
+		t.Errorf("got %s and %s want the timeouts from the config", mgr.WebrtcTimeout(), mgr.RouterMaxAge())
+	}
+	if !mgr.AllowAutoCreateRooms() || mgr.MaxPeers() != 3 || mgr.MaxMessageSize() != 4096 {
+		t.Errorf("the rooms and queue sections should be applied")
+	}
+	if mgr.ICEGrace() != ICEGrace || mgr.SDPLimits().GetMaxBytes() != DefaultMaxSDPBytes {
+		t.Errorf("the settings left at zero should keep their defaults")
+	}
+}
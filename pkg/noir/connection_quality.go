@@ -0,0 +1,219 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"github.com/pion/rtcp"
+	"sync"
+	"time"
+)
+
+/*
+Connection Quality:
+A peer that joined with qualityReports is sent a ConnectionQuality every QualityInterval(), so
+its app can warn about a bad connection or ask for a lower simulcast layer. ion-sfu sends each
+subscriber sender reports and reads the receiver reports it sends back, but keeps nothing of
+them, so a qualityMonitor reads them too, on every downtrack the peer gets. An estimate is the
+worst loss, round trip and jitter of the peer's tracks since the last one, and none is sent
+after an interval without reports. The monitor runs beside the PeerChannel and stops with it.
+*/
+
+// Default for Manager.QualityInterval()
+const QualityInterval = 5 * time.Second
+
+// Where a connection stops being GOOD, and becomes POOR
+const (
+	FairFractionLost = 0.02
+	PoorFractionLost = 0.1
+	FairRTT          = 300 * time.Millisecond
+	PoorRTT          = 600 * time.Millisecond
+	FairJitter       = 30 * time.Millisecond
+	PoorJitter       = 100 * time.Millisecond
+)
+
+// seconds from the NTP epoch in 1900 to the unix one
+const ntpEpochOffset = 2208988800
+
+// SetQualityInterval is how often peers that asked are sent their ConnectionQuality, 0 keeps the
+// default and a negative interval sends none
+func (m *Manager) SetQualityInterval(interval time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.qualityInterval = interval
+}
+
+func (m *Manager) QualityInterval() time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.qualityInterval == 0 {
+		return QualityInterval
+	}
+	return m.qualityInterval
+}
+
+// GradeQuality is the level of a connection, its worst number decides
+func GradeQuality(fractionLost float32, rtt time.Duration, jitter time.Duration) pb.ConnectionQuality_Level {
+	if fractionLost >= PoorFractionLost || rtt >= PoorRTT || jitter >= PoorJitter {
+		return pb.ConnectionQuality_POOR
+	}
+	if fractionLost >= FairFractionLost || rtt >= FairRTT || jitter >= FairJitter {
+		return pb.ConnectionQuality_FAIR
+	}
+	return pb.ConnectionQuality_GOOD
+}
+
+// qualityMonitor keeps the worst of the receiver reports about a subscriber's downtracks
+type qualityMonitor struct {
+	mu sync.Mutex
+	// the downtracks reported on, with their clock rate by ssrc
+	observed   map[*sfu.DownTrack]uint32
+	clockRates map[uint32]uint32
+
+	reported     bool
+	fractionLost float32
+	rtt          time.Duration
+	jitter       time.Duration
+}
+
+func newQualityMonitor() *qualityMonitor {
+	return &qualityMonitor{observed: map[*sfu.DownTrack]uint32{}, clockRates: map[uint32]uint32{}}
+}
+
+// watch learns the clock rates of the subscriber's new downtracks, and forgets the ones it lost
+func (q *qualityMonitor) watch(subscriber *sfu.Subscriber) {
+	tracks := downTracksOf(subscriber)
+	current := make(map[*sfu.DownTrack]bool, len(tracks))
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, track := range tracks {
+		current[track] = true
+		if _, observed := q.observed[track]; observed {
+			continue
+		}
+		// not bound yet, it is sent nothing to report on
+		ssrc := downTrackSSRC(track)
+		if ssrc == 0 {
+			continue
+		}
+		q.observed[track] = ssrc
+		q.clockRates[ssrc] = track.Codec().ClockRate
+	}
+	for track, ssrc := range q.observed {
+		if !current[track] {
+			delete(q.observed, track)
+			delete(q.clockRates, ssrc)
+		}
+	}
+}
+
+// observe keeps the worst of the receiver reports among packets
+func (q *qualityMonitor) observe(packets []rtcp.Packet) {
+	arrived := ntpMiddle(time.Now())
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, packet := range packets {
+		report, ok := packet.(*rtcp.ReceiverReport)
+		if !ok {
+			continue
+		}
+		for _, block := range report.Reports {
+			clockRate, ok := q.clockRates[block.SSRC]
+			if !ok || clockRate == 0 {
+				continue
+			}
+			q.reported = true
+			if lost := float32(block.FractionLost) / 256; lost > q.fractionLost {
+				q.fractionLost = lost
+			}
+			if jitter := time.Duration(uint64(block.Jitter) * uint64(time.Second) / uint64(clockRate)); jitter > q.jitter {
+				q.jitter = jitter
+			}
+			if rtt := reportRTT(arrived, block); rtt > q.rtt {
+				q.rtt = rtt
+			}
+		}
+	}
+}
+
+// estimate is the ConnectionQuality since the last one, false without any reports
+func (q *qualityMonitor) estimate() (*pb.ConnectionQuality, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.reported {
+		return nil, false
+	}
+	quality := &pb.ConnectionQuality{
+		Level:        GradeQuality(q.fractionLost, q.rtt, q.jitter),
+		FractionLost: q.fractionLost,
+		RttMs:        int32(q.rtt.Milliseconds()),
+		JitterMs:     int32(q.jitter.Milliseconds()),
+	}
+	q.reported, q.fractionLost, q.rtt, q.jitter = false, 0, 0, 0
+	return quality, true
+}
+
+// ntpMiddle is the middle 32 bits of t as an NTP timestamp, as receiver reports count time
+func ntpMiddle(t time.Time) uint32 {
+	seconds := uint64(t.Unix()) + ntpEpochOffset
+	fraction := (uint64(t.Nanosecond()) << 32) / uint64(time.Second)
+	return uint32(seconds<<16 | fraction>>16)
+}
+
+// reportRTT is the round trip of the sender report a block answers, 0 when it answers none
+func reportRTT(arrived uint32, block rtcp.ReceptionReport) time.Duration {
+	if block.LastSenderReport == 0 {
+		return 0
+	}
+	// in 1/65536 of a second
+	delay := arrived - block.LastSenderReport - block.Delay
+	if int32(delay) < 0 {
+		return 0
+	}
+	return time.Duration(uint64(delay) * uint64(time.Second) / 65536)
+}
+
+// reportQuality sends pid its ConnectionQuality every interval until stop is closed
+func (w *worker) reportQuality(pid string, peer *sfu.Peer, interval time.Duration, stop <-chan struct{}, logger Logger) {
+	monitor := newQualityMonitor()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var unobserve func()
+	defer func() {
+		if unobserve != nil {
+			unobserve()
+		}
+	}()
+	for {
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+		subscriber := subscriberOf(peer)
+		if subscriber == nil {
+			continue
+		}
+		if unobserve == nil {
+			var ok bool
+			if unobserve, ok = observeSubscriberRTCP(subscriber, monitor.observe); !ok {
+				continue
+			}
+		}
+		monitor.watch(subscriber)
+		quality, ok := monitor.estimate()
+		if !ok {
+			continue
+		}
+		err := w.SignalReply(pid, &pb.NoirReply{
+			Command: &pb.NoirReply_Signal{
+				Signal: &pb.SignalReply{
+					Id:      pid,
+					Payload: &pb.SignalReply_Quality{Quality: quality},
+				},
+			},
+		})
+		if err != nil {
+			logger.Debugf("error sending connection quality: %s", err)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/rtcp"
+	"testing"
+	"time"
+)
+
+func TestGradeQuality(t *testing.T) {
+	tests := []struct {
+		lost   float32
+		rtt    time.Duration
+		jitter time.Duration
+		want   pb.ConnectionQuality_Level
+	}{
+		{0, 0, 0, pb.ConnectionQuality_GOOD},
+		{0.01, 100 * time.Millisecond, 10 * time.Millisecond, pb.ConnectionQuality_GOOD},
+		{0.05, 100 * time.Millisecond, 10 * time.Millisecond, pb.ConnectionQuality_FAIR},
+		{0.01, 400 * time.Millisecond, 10 * time.Millisecond, pb.ConnectionQuality_FAIR},
+		{0.01, 100 * time.Millisecond, 50 * time.Millisecond, pb.ConnectionQuality_FAIR},
+		{0.2, 100 * time.Millisecond, 10 * time.Millisecond, pb.ConnectionQuality_POOR},
+		{0, time.Second, 0, pb.ConnectionQuality_POOR},
+	}
+	for _, tt := range tests {
+		if got := GradeQuality(tt.lost, tt.rtt, tt.jitter); got != tt.want {
+			t.Errorf("got %s want %s for %.2f lost, %s rtt, %s jitter", got, tt.want, tt.lost, tt.rtt, tt.jitter)
+		}
+	}
+}
+
+func TestQualityMonitor(t *testing.T) {
+	monitor := newQualityMonitor()
+	monitor.clockRates[1234] = 48000
+	if _, ok := monitor.estimate(); ok {
+		t.Errorf("there is no estimate without reports")
+	}
+
+	sent := ntpMiddle(time.Now().Add(-200 * time.Millisecond))
+	monitor.observe([]rtcp.Packet{
+		&rtcp.PictureLossIndication{MediaSSRC: 1234},
+		&rtcp.ReceiverReport{Reports: []rtcp.ReceptionReport{
+			{SSRC: 1234, FractionLost: 64, Jitter: 2400, LastSenderReport: sent},
+			// not a track the monitor watches
+			{SSRC: 99, FractionLost: 255},
+		}},
+	})
+	monitor.observe([]rtcp.Packet{
+		&rtcp.ReceiverReport{Reports: []rtcp.ReceptionReport{{SSRC: 1234, FractionLost: 16}}},
+	})
+
+	quality, ok := monitor.estimate()
+	if !ok {
+		t.Fatalf("got no estimate after reports")
+	}
+	if quality.Level != pb.ConnectionQuality_POOR || quality.FractionLost != 0.25 || quality.JitterMs != 50 {
+		t.Errorf("got %s want the worst report, POOR at 25%% lost and 50ms jitter", quality)
+	}
+	if quality.RttMs < 190 || quality.RttMs > 400 {
+		t.Errorf("got rtt %dms want about 200ms", quality.RttMs)
+	}
+	if _, ok := monitor.estimate(); ok {
+		t.Errorf("an estimate should start over")
+	}
+}
+
+func TestReportRTT(t *testing.T) {
+	arrived := ntpMiddle(time.Now())
+	// sent 1.5s ago, and held for 1s by the receiver
+	block := rtcp.ReceptionReport{LastSenderReport: arrived - 3*65536/2, Delay: 65536}
+	if rtt := reportRTT(arrived, block); rtt < 499*time.Millisecond || rtt > 501*time.Millisecond {
+		t.Errorf("got %s want 500ms", rtt)
+	}
+	if rtt := reportRTT(arrived, rtcp.ReceptionReport{}); rtt != 0 {
+		t.Errorf("got %s want none before a sender report", rtt)
+	}
+	if rtt := reportRTT(arrived, rtcp.ReceptionReport{LastSenderReport: arrived, Delay: 65536}); rtt != 0 {
+		t.Errorf("got %s want none for a delay longer than the round trip", rtt)
+	}
+}
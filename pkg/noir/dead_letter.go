@@ -0,0 +1,100 @@
+package noir
+
+import (
+	"github.com/go-redis/redis"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	log "github.com/pion/ion-log"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+// Default for Manager.DeadLetterCap(), older dead letters are dropped past it
+const DeadLetterCap = 1000
+
+// DeadLetterEntry is a message this worker could not parse, and where it came from
+type DeadLetterEntry struct {
+	Topic   string    `json:"topic"`
+	Error   string    `json:"error"`
+	At      time.Time `json:"at"`
+	Message []byte    `json:"message"`
+}
+
+func (m *Manager) SetDeadLetterCap(size int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.deadLetterCap = size
+}
+
+func (m *Manager) DeadLetterCap() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.deadLetterCap > 0 {
+		return m.deadLetterCap
+	}
+	return DeadLetterCap
+}
+
+// DeadLetter keeps a message that failed to parse, failing to keep it is only logged
+func (m *Manager) DeadLetter(topic string, message []byte, parseErr error) {
+	value, err := proto.Marshal(&pb.DeadLetter{
+		Topic:   topic,
+		Error:   parseErr.Error(),
+		At:      timestamppb.Now(),
+		Message: message,
+	})
+	if err != nil {
+		log.Errorf("error packing dead letter from %s: %s", topic, err)
+		return
+	}
+	key := m.Keys().DeadLetter(m.id)
+	_, err = m.redis.TxPipelined(func(pipe redis.Pipeliner) error {
+		pipe.LPush(key, value)
+		pipe.LTrim(key, 0, int64(m.DeadLetterCap()-1))
+		return nil
+	})
+	if err != nil {
+		log.Errorf("error dead lettering message from %s: %s", topic, err)
+	}
+}
+
+// DrainDeadLetter removes and returns up to limit of this worker's dead letters, oldest first.
+// A limit of 0 or less drains them all
+func (m *Manager) DrainDeadLetter(limit int) ([]DeadLetterEntry, error) {
+	key := m.Keys().DeadLetter(m.id)
+	start, keep := int64(0), int64(0)
+	if limit > 0 {
+		// the oldest are at the end of the list
+		start, keep = -int64(limit), -int64(limit)-1
+	}
+	var popped *redis.StringSliceCmd
+	_, err := m.redis.TxPipelined(func(pipe redis.Pipeliner) error {
+		popped = pipe.LRange(key, start, -1)
+		if limit > 0 {
+			pipe.LTrim(key, 0, keep)
+		} else {
+			pipe.Del(key)
+		}
+		return nil
+	})
+	if err != nil {
+		return []DeadLetterEntry{}, err
+	}
+
+	values := popped.Val()
+	entries := make([]DeadLetterEntry, 0, len(values))
+	for i := len(values) - 1; i >= 0; i-- {
+		var letter pb.DeadLetter
+		if err := proto.Unmarshal([]byte(values[i]), &letter); err != nil {
+			log.Warnf("skipping unreadable dead letter: %s", err)
+			continue
+		}
+		entries = append(entries, DeadLetterEntry{
+			Topic:   letter.Topic,
+			Error:   letter.Error,
+			At:      letter.At.AsTime(),
+			Message: letter.Message,
+		})
+	}
+	return entries, nil
+}
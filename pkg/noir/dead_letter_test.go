@@ -0,0 +1,49 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"strconv"
+	"testing"
+)
+
+func TestDeadLetter(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	redis.Del(pb.KeyDeadLetter("test-worker"))
+
+	worker := *mgr.GetWorker()
+	queue := *worker.GetQueue()
+	queue.Add([]byte("not a protobuf \xff\xff"))
+	if err := worker.HandleNext(0); err == nil {
+		t.Errorf("handling garbage should fail")
+	}
+
+	entries, err := mgr.DrainDeadLetter(10)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("got %v %v want 1 dead letter", entries, err)
+	}
+	if entries[0].Topic != queue.Topic() || entries[0].Error == "" || string(entries[0].Message) != "not a protobuf \xff\xff" {
+		t.Errorf("got %+v want the message, its topic and the parse error", entries[0])
+	}
+	if entries, _ := mgr.DrainDeadLetter(10); len(entries) != 0 {
+		t.Errorf("got %d dead letters want none after draining", len(entries))
+	}
+}
+
+func TestDeadLetterCap(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	redis.Del(pb.KeyDeadLetter("test-worker"))
+	mgr.SetDeadLetterCap(3)
+
+	for i := 0; i < 5; i++ {
+		mgr.DeadLetter("tests/dead-letter", []byte(strconv.Itoa(i)), ErrUnknownKind)
+	}
+
+	oldest, _ := mgr.DrainDeadLetter(1)
+	rest, _ := mgr.DrainDeadLetter(0)
+	if len(oldest) != 1 || string(oldest[0].Message) != "2" {
+		t.Errorf("got %v want the oldest kept letter, 2", oldest)
+	}
+	if len(rest) != 2 || string(rest[0].Message) != "3" || string(rest[1].Message) != "4" {
+		t.Errorf("got %v want 3 and 4", rest)
+	}
+}
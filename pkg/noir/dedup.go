@@ -0,0 +1,56 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	log "github.com/pion/ion-log"
+	"time"
+)
+
+/*
+Request Dedup:
+The same NoirRequest can reach a handler twice, when a queue hands it out again or a client
+retries, and a second Join would make a second peer. Every request carries an Id, see
+FillDefaults, and the first worker to handle it claims that id in redis for the DedupWindow.
+The claim is one SETNX, so of two workers handed the same message only one runs it; the
+other skips it and counts it in noir_requests_deduped_total. A client retrying on purpose
+has to send the same Id, a new one is a new request.
+*/
+
+// How long a request id is remembered unless SetDedupWindow says otherwise
+const DedupWindow = 30 * time.Second
+
+// SetDedupWindow is how long a handled request id is skipped for, 0 keeps the default and
+// a negative window turns dedup off
+func (m *Manager) SetDedupWindow(window time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.dedupWindow = window
+}
+
+func (m *Manager) DedupWindow() time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.dedupWindow == 0 {
+		return DedupWindow
+	}
+	return m.dedupWindow
+}
+
+// FirstDelivery claims the request's id, and is false when it was handled within the window
+func (m *Manager) FirstDelivery(request *pb.NoirRequest) bool {
+	window := m.DedupWindow()
+	if window < 0 || request.Id == "" {
+		return true
+	}
+	first, err := m.redis.SetNX(m.Keys().RequestSeen(request.Id), m.id, window).Result()
+	if err != nil {
+		// handling it twice is better than not at all
+		log.Errorf("error claiming request %s: %s", request.Id, err)
+		return true
+	}
+	if !first {
+		log.Warnf("skipping %s request %s, already handled", request.Action, request.Id)
+		requestsDeduped.WithLabelValues(request.Action).Inc()
+	}
+	return first
+}
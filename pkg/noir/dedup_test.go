@@ -0,0 +1,64 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"testing"
+	"time"
+)
+
+func TestDuplicateJoin(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	redis.Del(pb.KeyRoomData("dedup"), pb.KeyRoomUsers("dedup"), pb.KeyTopicFromPeer("dedup-peer"))
+
+	join := &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id: "dedup-peer",
+				Payload: &pb.SignalRequest_Join{
+					Join: &pb.JoinRequest{
+						Sid:         "dedup",
+						Description: []byte(EXAMPLE_EMPTY_SDP),
+					},
+				},
+			},
+		},
+	}
+	worker := *mgr.GetWorker()
+	queue := *worker.GetQueue()
+	EnqueueRequest(queue, join)
+	if err := worker.HandleNext(0); err != nil {
+		t.Fatalf("error joining: %s", err)
+	}
+	mgr.DisconnectUser("dedup-peer")
+
+	// redelivered with the same id after the peer left, it must not join again
+	EnqueueRequest(queue, join)
+	if err := worker.HandleNext(0); err != nil {
+		t.Fatalf("got %s want the duplicate skipped", err)
+	}
+	mgr.mu.RLock()
+	rejoined := mgr.users["dedup-peer"] != nil
+	mgr.mu.RUnlock()
+	if rejoined {
+		mgr.DisconnectUser("dedup-peer")
+		t.Errorf("a duplicate join made the peer again")
+	}
+}
+
+func TestFirstDelivery(t *testing.T) {
+	mgr, _ := NewTestSetup()
+	request := &pb.NoirRequest{Id: "dedup-" + RandomString(8), Action: "request.servers.trickle"}
+	if !mgr.FirstDelivery(request) {
+		t.Fatalf("the first delivery should be handled")
+	}
+	if mgr.FirstDelivery(request) {
+		t.Errorf("the second delivery should be skipped")
+	}
+
+	mgr.SetDedupWindow(-time.Second)
+	defer mgr.SetDedupWindow(0)
+	if !mgr.FirstDelivery(request) {
+		t.Errorf("with dedup off every delivery should be handled")
+	}
+}
@@ -0,0 +1,120 @@
+package noir
+
+import (
+	"fmt"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	log "github.com/pion/ion-log"
+	"time"
+)
+
+/*
+Worker Drain:
+For a rolling upgrade a worker is drained before it is stopped. Drain has it take no more joins:
+HandleJoin refuses them with a NODE_DRAINING worth retrying, and its heartbeat says it is
+draining, so routers place new rooms on other workers and hand a room it hosts to another worker
+with the room's next join once nobody is in it. The peers it runs carry on until they leave,
+Drain returns once they all did or its deadline passed, and Stop kills whatever peers are left.
+A room is never split across workers: while peers are still in it the routers leave it on the
+draining worker, whose NODE_DRAINING tells its joins to come back, and of routers racing to hand
+it off the room store's MoveNode lets one move it, the others follow it.
+*/
+
+// How long a join refused for NODE_DRAINING is told to wait before trying again
+const DrainRetryAfter = time.Second
+
+// How often Drain checks whether the worker's peers left
+const DrainPollInterval = 100 * time.Millisecond
+
+// Drain stops the worker taking joins and waits up to deadline for its peers to leave, true when
+// they all did
+func (w *worker) Drain(deadline time.Duration) bool {
+	if !w.draining.get() {
+		w.draining.set(true)
+		log.Infof("worker %s draining %d peers", w.id, w.PeerCount())
+		if err := w.manager.heartbeat(w.id, w.PeerCount(), true); err != nil {
+			log.Errorf("error writing heartbeat: %s", err)
+		}
+		// this node's routers stop placing joins here before the next health tick
+		w.manager.updatePlaceableWorkers()
+	}
+	poll := time.NewTicker(DrainPollInterval)
+	defer poll.Stop()
+	expired := time.After(deadline)
+	for w.PeerCount() > 0 {
+		select {
+		case <-poll.C:
+		case <-expired:
+			log.Warnf("worker %s still runs %d peers after draining for %s", w.id, w.PeerCount(), deadline)
+			return false
+		}
+	}
+	return true
+}
+
+// Draining is true once Drain was called
+func (w *worker) Draining() bool {
+	return w.draining.get()
+}
+
+// refuseDraining is a NODE_DRAINING failure for the join
+func (w *worker) refuseDraining(request *pb.NoirRequest) error {
+	err := fmt.Errorf("worker %s is draining, join again to be placed elsewhere", w.id)
+	w.SignalFailure(request, &pb.SignalError{
+		Code:         pb.SignalError_NODE_DRAINING,
+		Message:      err.Error(),
+		RetryAfterMs: DrainRetryAfter.Milliseconds(),
+	})
+	return err
+}
+
+// SetDrainTimeout is how long a quitting node drains its worker before stopping it, 0 or less
+// stops it right away
+func (m *Manager) SetDrainTimeout(timeout time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.drainTimeout = timeout
+}
+
+func (m *Manager) DrainTimeout() time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.drainTimeout
+}
+
+// WorkerDraining is true when the worker's last heartbeat said it was draining
+func (m *Manager) WorkerDraining(workerID string) bool {
+	loaded, err := m.LoadData(m.Keys().WorkerHeartbeat(workerID))
+	return err == nil && loaded.GetHeartbeat().GetDraining()
+}
+
+// handOffRoom moves a room off its draining worker to another, for the join being routed. A room
+// with peers in it stays, its joins are refused NODE_DRAINING until they left
+func (m *Manager) handOffRoom(roomData *pb.RoomData) (string, error) {
+	from := roomData.NodeID
+	peers, err := m.redis.HLen(m.Keys().RoomUsers(roomData.Id)).Result()
+	if err != nil {
+		return "", err
+	} else if peers > 0 {
+		log.Debugf("room %s stays on draining %s while %d peers are in it", roomData.Id, from, peers)
+		return from, nil
+	}
+	target, err := m.RandomNodeForService("sfu")
+	if err != nil {
+		return "", err
+	}
+	moved, err := m.RoomStore().MoveNode(roomData.Id, from, target)
+	if err != nil {
+		return "", err
+	} else if !moved {
+		// another router handed it off first
+		current, err := m.GetRemoteRoomData(roomData.Id)
+		if err != nil {
+			return "", err
+		}
+		return current.NodeID, nil
+	}
+	m.redis.HDel(m.Keys().NodeRooms(from), roomData.Id)
+	m.redis.HSet(m.Keys().NodeRooms(target), roomData.Id, 1)
+	log.Infof("room %s handed off from draining %s to %s", roomData.Id, from, target)
+	return target, nil
+}
@@ -0,0 +1,114 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"testing"
+	"time"
+)
+
+func TestWorkerDrain(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	mgr.Checkin()
+	mgr.UpdateAvailableNodes()
+	keys := []string{pb.KeyRoomData("drain-room"), pb.KeyRoomUsers("drain-room")}
+	for _, pid := range []string{"drain-stays", "drain-refused"} {
+		keys = append(keys, pb.KeyTopicFromPeer(pid), pb.KeyTopicToPeer(pid), pb.KeyPeerOwner(pid))
+	}
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	w := (*mgr.GetWorker()).(*worker)
+	defer func() {
+		w.draining.set(false)
+		mgr.Heartbeat(w.ID(), 0)
+	}()
+	join := func(pid string) error {
+		EnqueueRequest(*w.GetQueue(), &pb.NoirRequest{
+			Command: &pb.NoirRequest_Signal{
+				Signal: &pb.SignalRequest{
+					Id: pid,
+					Payload: &pb.SignalRequest_Join{
+						Join: &pb.JoinRequest{Sid: "drain-room", Description: []byte(EXAMPLE_EMPTY_SDP)},
+					},
+				},
+			},
+		})
+		return w.HandleNext(0)
+	}
+	if err := join("drain-stays"); err != nil {
+		t.Fatalf("error joining: %s", err)
+	}
+	defer mgr.DisconnectUser("drain-stays")
+
+	if w.Drain(3 * DrainPollInterval) {
+		t.Errorf("drain should time out while a peer is still joined")
+	}
+	if !w.Draining() || !mgr.WorkerDraining(w.ID()) {
+		t.Errorf("the worker and its heartbeat should say it is draining")
+	}
+	if nodes := mgr.NodesForService("sfu"); len(nodes) != 0 {
+		t.Errorf("got %v want no nodes to place joins on while draining", nodes)
+	}
+
+	if err := join("drain-refused"); err == nil {
+		mgr.DisconnectUser("drain-refused")
+		t.Fatalf("a draining worker should refuse joins")
+	}
+	message, err := mgr.GetQueue(pb.KeyTopicFromPeer("drain-refused")).BlockUntilNext(time.Second)
+	if err != nil {
+		t.Fatalf("got no reply: %s", err)
+	}
+	reply := pb.NoirReply{}
+	proto.Unmarshal(message, &reply)
+	if failure := reply.GetSignal().GetFailure(); failure.GetCode() != pb.SignalError_NODE_DRAINING || failure.GetRetryAfterMs() == 0 {
+		t.Errorf("got %s want a NODE_DRAINING worth retrying", &reply)
+	}
+
+	mgr.DisconnectUser("drain-stays")
+	if !w.Drain(time.Second) {
+		t.Errorf("drain should finish once the peers left, %d left", w.PeerCount())
+	}
+}
+
+func TestHandOffRoom(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.Checkin()
+	keys := []string{pb.KeyRoomData("handoff-room"), pb.KeyRoomUsers("handoff-room"), pb.KeyNodeRooms(mgr.ID()),
+		pb.KeyNodeRooms("handoff-target"), pb.KeyWorkerHeartbeat("handoff-target")}
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+	// another node to hand the room to
+	node, _ := proto.Marshal(&pb.NoirObject{
+		Data: &pb.NoirObject_Node{Node: &pb.NodeData{Id: "handoff-target", LastUpdate: timestamppb.Now(), Services: mgr.nodeServices}},
+	})
+	redis.HSet(pb.KeyNodeMap(), "handoff-target", node)
+	defer redis.HDel(pb.KeyNodeMap(), "handoff-target")
+	mgr.Heartbeat("handoff-target", 0)
+	mgr.heartbeat(mgr.ID(), 0, true)
+	defer mgr.Heartbeat(mgr.ID(), 0)
+	mgr.UpdateAvailableNodes()
+
+	room := &pb.RoomData{NodeID: mgr.ID()}
+	SaveRoomData("handoff-room", room, mgr)
+	redis.HSet(pb.KeyRoomUsers("handoff-room"), "handoff-peer", 1)
+	if target, err := mgr.handOffRoom(room); err != nil || target != mgr.ID() {
+		t.Errorf("got %s %v want a room with peers left on the draining node", target, err)
+	}
+	redis.HDel(pb.KeyRoomUsers("handoff-room"), "handoff-peer")
+	if target, err := mgr.handOffRoom(room); err != nil || target != "handoff-target" {
+		t.Fatalf("got %s %v want the empty room handed off", target, err)
+	}
+	if stored, _ := mgr.GetRemoteRoomData("handoff-room"); stored.GetNodeID() != "handoff-target" {
+		t.Errorf("got %s want the room saved on handoff-target", stored.GetNodeID())
+	}
+	if !redis.HExists(pb.KeyNodeRooms("handoff-target"), "handoff-room").Val() {
+		t.Errorf("the room should be filed under handoff-target")
+	}
+	// a router that read the room before it moved follows it
+	if target, err := mgr.handOffRoom(room); err != nil || target != "handoff-target" {
+		t.Errorf("got %s %v want the room already handed off followed", target, err)
+	}
+}
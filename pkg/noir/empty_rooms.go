@@ -0,0 +1,208 @@
+package noir
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-redis/redis"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	log "github.com/pion/ion-log"
+	"time"
+)
+
+/*
+Empty Rooms:
+What becomes of a room once its last peer left is its EmptyRoomPolicy, of its options or else the
+manager's, see SetEmptyRoomPolicy:
+
+	BY_AGE  a room that never expires, maxAgeSeconds -1, is closed, others are kept until they
+	        expire, as before there were policies. The manager's default
+	CLOSE   the room is closed
+	KEEP    the room is kept for its emptyGraceSeconds, or the manager's grace, so peers that
+	        reconnect find it as they left it, and closed after unless one joined. A grace of 0
+	        keeps it until it expires
+
+Closing an empty room stops its recordings, ends the jobs left in it, deletes its data and
+publishes its CLOSED. Unlike CloseRoom it does not mark it closed, a later join may create it
+again. Rooms with debug set are kept whatever the policy.
+
+A room is empty once the last of its peers that the node does not run itself is out of its peer
+index, jobs, whose pids start with job-, and relays, whose pids start with relay-, keep no room
+open: a room relayed elsewhere closes once its last peer but the relay's leaves, and so ends the
+relay. Leaving is one lua script, which takes the peer out and says if that emptied
+the room, so of peers leaving at once exactly one empties it. A join at the same time as the
+close must not end up in a room whose data is deleted under it: closing claims the room with
+another script, which marks it closing only while it is still empty and emptied by the same leave.
+ReservePeerSlot refuses joins into a room marked closing with ErrRoomClosing, the join is told
+ROOM_CLOSED with retryAfterMs, and a join that reserved its slot first keeps the room open. A join
+that read the room before its close and reserves after it is refused the same way, unless rooms
+are created by their joins, as its room would be recreated without its options. A join or move
+that fails after reserving gives its slot back with ReleasePeerSlot, which is a leave like any
+other and may empty the room. The grace of a kept room is timed on the node its last peer left
+from, a room whose node went away before it ran out is kept until it expires.
+*/
+
+// How long a room is marked closing at most, should its node die closing it
+const RoomClosingTimeout = 10 * time.Second
+
+// How long a join refused for a room being closed is told to wait
+const RoomClosingRetryAfter = time.Second
+
+var ErrRoomClosing = errors.New("room closing")
+
+// nodePeer is whether pid is a job's or a relay's, the scripts below leave them out
+const nodePeer = `
+	local function nodePeer(pid)
+		return string.sub(pid, 1, 4) == 'job-' or string.sub(pid, 1, 6) == 'relay-'
+	end
+`
+
+var leaveRoom = redis.NewScript(nodePeer + `
+	if redis.call('HDEL', KEYS[1], ARGV[1]) == 0 or nodePeer(ARGV[1]) then
+		return 0
+	end
+	for _, pid in ipairs(redis.call('HKEYS', KEYS[1])) do
+		if not nodePeer(pid) then
+			return 0
+		end
+	end
+	if tonumber(ARGV[3]) > 0 then
+		redis.call('SET', KEYS[2], ARGV[2], 'PX', ARGV[3])
+	end
+	return 1
+`)
+
+var claimEmptyRoom = redis.NewScript(nodePeer + `
+	if redis.call('GET', KEYS[2]) ~= ARGV[1] then
+		return 0
+	end
+	for _, pid in ipairs(redis.call('HKEYS', KEYS[1])) do
+		if not nodePeer(pid) then
+			return 0
+		end
+	end
+	redis.call('DEL', KEYS[2])
+	redis.call('SET', KEYS[3], 1, 'PX', ARGV[2])
+	return 1
+`)
+
+// SetEmptyRoomPolicy is what becomes of empty rooms whose options set no policy, grace is how long
+// KEEP keeps them when their options set none, see empty_rooms.go
+func (m *Manager) SetEmptyRoomPolicy(policy pb.EmptyRoomPolicy, grace time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.emptyRoomPolicy = policy
+	m.emptyRoomGrace = grace
+}
+
+func (m *Manager) EmptyRoomPolicy() (pb.EmptyRoomPolicy, time.Duration) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.emptyRoomPolicy == pb.EmptyRoomPolicy_EMPTY_ROOM_DEFAULT {
+		return pb.EmptyRoomPolicy_EMPTY_ROOM_BY_AGE, m.emptyRoomGrace
+	}
+	return m.emptyRoomPolicy, m.emptyRoomGrace
+}
+
+// ParseEmptyRoomPolicy reads [rooms] emptyroom, age, close or keep, empty is age
+func ParseEmptyRoomPolicy(name string) (pb.EmptyRoomPolicy, error) {
+	switch name {
+	case "", "age":
+		return pb.EmptyRoomPolicy_EMPTY_ROOM_BY_AGE, nil
+	case "close":
+		return pb.EmptyRoomPolicy_EMPTY_ROOM_CLOSE, nil
+	case "keep":
+		return pb.EmptyRoomPolicy_EMPTY_ROOM_KEEP, nil
+	}
+	return pb.EmptyRoomPolicy_EMPTY_ROOM_DEFAULT, fmt.Errorf("unknown empty room policy %s, want age, close or keep", name)
+}
+
+// roomEmptyPolicy is what becomes of the room when it is empty, and for how long KEEP keeps it
+func (m *Manager) roomEmptyPolicy(room *pb.RoomData) (pb.EmptyRoomPolicy, time.Duration) {
+	policy, grace := m.EmptyRoomPolicy()
+	options := room.GetOptions()
+	if options.GetEmptyRoom() != pb.EmptyRoomPolicy_EMPTY_ROOM_DEFAULT {
+		policy = options.GetEmptyRoom()
+	}
+	if seconds := options.GetEmptyGraceSeconds(); seconds > 0 {
+		grace = time.Duration(seconds) * time.Second
+	}
+	if policy == pb.EmptyRoomPolicy_EMPTY_ROOM_BY_AGE {
+		if options.GetMaxAgeSeconds() == -1 {
+			return pb.EmptyRoomPolicy_EMPTY_ROOM_CLOSE, 0
+		}
+		return pb.EmptyRoomPolicy_EMPTY_ROOM_KEEP, 0
+	}
+	return policy, grace
+}
+
+// emptyPolicyOf is what becomes of the room once it is empty, and how long its empty mark has to
+// last for that, 0 when nothing does
+func (m *Manager) emptyPolicyOf(roomID string) (pb.EmptyRoomPolicy, time.Duration, time.Duration) {
+	room, err := m.GetRoomData(roomID)
+	if err != nil || room.GetOptions().GetDebug() != 0 {
+		return pb.EmptyRoomPolicy_EMPTY_ROOM_KEEP, 0, 0
+	}
+	policy, grace := m.roomEmptyPolicy(room)
+	if policy == pb.EmptyRoomPolicy_EMPTY_ROOM_CLOSE {
+		return policy, 0, RoomClosingTimeout
+	} else if grace > 0 {
+		return policy, grace, grace + RoomClosingTimeout
+	}
+	return policy, 0, 0
+}
+
+// leaveRoom takes a connected peer out of the room's index, if that emptied a room its policy
+// closes it is the token of the leave emptiedRoom needs, else ""
+func (m *Manager) leaveRoom(roomID string, pid string) string {
+	_, _, lasting := m.emptyPolicyOf(roomID)
+	token := RandomString(16)
+	emptied, err := leaveRoom.Run(m.redis, []string{m.Keys().RoomUsers(roomID), m.Keys().RoomEmpty(roomID)},
+		pid, token, lasting.Milliseconds()).Int()
+	if err != nil {
+		log.Errorf("error taking %s out of room %s: %s", pid, roomID, err)
+		return ""
+	} else if emptied == 0 || lasting == 0 {
+		return ""
+	}
+	return token
+}
+
+// emptiedRoom closes the room the leave of token emptied, now or after its grace
+func (m *Manager) emptiedRoom(roomID string, token string) {
+	switch policy, grace, _ := m.emptyPolicyOf(roomID); {
+	case policy == pb.EmptyRoomPolicy_EMPTY_ROOM_CLOSE:
+		m.closeEmptyRoom(roomID, token)
+	case grace > 0:
+		m.Logger().With(LogFields{"sid": roomID}).Debugf("keeping empty room %s for %s", roomID, grace)
+		time.AfterFunc(grace, func() { m.closeEmptyRoom(roomID, token) })
+	}
+}
+
+// closeEmptyRoom closes the room if it is still empty since the leave of token
+func (m *Manager) closeEmptyRoom(roomID string, token string) {
+	claimed, err := claimEmptyRoom.Run(m.redis,
+		[]string{m.Keys().RoomUsers(roomID), m.Keys().RoomEmpty(roomID), m.Keys().RoomClosing(roomID)},
+		token, RoomClosingTimeout.Milliseconds()).Int()
+	if err != nil {
+		log.Errorf("error claiming empty room %s: %s", roomID, err)
+		return
+	} else if claimed == 0 {
+		return
+	}
+	defer m.redis.Del(m.Keys().RoomClosing(roomID))
+	logger := m.Logger().With(LogFields{"sid": roomID})
+	if _, err := m.StopRecording(roomID, ""); err != nil {
+		logger.Errorf("error stopping the recordings of empty room %s: %s", roomID, err)
+	}
+	for _, pid := range m.redis.HKeys(m.Keys().RoomUsers(roomID)).Val() {
+		m.CloseClient(pid, pb.CloseReason_ROOM_CLOSED)
+	}
+	if err := m.RoomStore().Delete(roomID); err != nil {
+		logger.Errorf("error deleting empty room %s: %s", roomID, err)
+		return
+	}
+	m.redis.Del(m.Keys().RoomUsers(roomID))
+	m.redis.ZRem(m.Keys().RoomScores(), roomID)
+	m.EmitRoomEvent(roomID, "", pb.RoomEvent_CLOSED)
+	logger.Infof("closed empty room %s", roomID)
+}
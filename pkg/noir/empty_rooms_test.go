@@ -0,0 +1,190 @@
+package noir
+
+import (
+	"errors"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"testing"
+	"time"
+)
+
+func emptyRoomKeys(sid string, pids ...string) []string {
+	return append(peerKeys(pids...), pb.KeyRoomData(sid), pb.KeyRoomUsers(sid), pb.KeyRoomEmpty(sid),
+		pb.KeyRoomClosing(sid), pb.KeyRoomClosed(sid))
+}
+
+func roomExists(mgr *Manager, sid string) bool {
+	exists, _ := mgr.GetRemoteRoomExists(sid)
+	return exists
+}
+
+func TestParseEmptyRoomPolicy(t *testing.T) {
+	for name, want := range map[string]pb.EmptyRoomPolicy{
+		"":      pb.EmptyRoomPolicy_EMPTY_ROOM_BY_AGE,
+		"age":   pb.EmptyRoomPolicy_EMPTY_ROOM_BY_AGE,
+		"close": pb.EmptyRoomPolicy_EMPTY_ROOM_CLOSE,
+		"keep":  pb.EmptyRoomPolicy_EMPTY_ROOM_KEEP,
+	} {
+		if policy, err := ParseEmptyRoomPolicy(name); err != nil || policy != want {
+			t.Errorf("got %s %v want %s for %q", policy, err, want, name)
+		}
+	}
+	if _, err := ParseEmptyRoomPolicy("forever"); err == nil {
+		t.Errorf("an unknown policy should be refused")
+	}
+}
+
+func TestEmptyRoomClose(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetEmptyRoomPolicy(pb.EmptyRoomPolicy_EMPTY_ROOM_CLOSE, 0)
+	keys := emptyRoomKeys("empty-close", "empty-first", "empty-second")
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	if _, _, err := mgr.OpenRoom("empty-close", &pb.CreateRoomRequest{}); err != nil {
+		t.Fatalf("error opening: %s", err)
+	}
+	joinFake(t, mgr, "empty-first", "empty-close", newFakePeer())
+	joinFake(t, mgr, "empty-second", "empty-close", newFakePeer())
+	// a job or relay left behind does not keep the room open
+	relayIn, _ := RelayPeerIDs("empty-relay")
+	redis.HSet(pb.KeyRoomUsers("empty-close"), RecordingPeerID("empty-recording"), 1)
+	redis.HSet(pb.KeyRoomUsers("empty-close"), relayIn, 1)
+
+	mgr.DisconnectUser("empty-first")
+	if !roomExists(mgr, "empty-close") {
+		t.Fatalf("a room should stay open while a peer is in it")
+	}
+	mgr.DisconnectUser("empty-second")
+	if roomExists(mgr, "empty-close") {
+		t.Errorf("the room should be closed once its last peer left")
+	}
+	if users := redis.HLen(pb.KeyRoomUsers("empty-close")).Val(); users != 0 {
+		t.Errorf("got %d peers left in the index want the job and relay ended", users)
+	}
+	if mgr.IsRoomClosed("empty-close") {
+		t.Errorf("an empty room closing should not keep the room from being created again")
+	}
+}
+
+func TestEmptyRoomKeep(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetEmptyRoomPolicy(pb.EmptyRoomPolicy_EMPTY_ROOM_CLOSE, 0)
+	keys := emptyRoomKeys("empty-keep", "empty-leaving", "empty-back")
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	// the room's own policy wins over the manager's
+	options := &pb.RoomOptions{EmptyRoom: pb.EmptyRoomPolicy_EMPTY_ROOM_KEEP, EmptyGraceSeconds: 1}
+	if _, _, err := mgr.OpenRoom("empty-keep", &pb.CreateRoomRequest{Options: options}); err != nil {
+		t.Fatalf("error opening: %s", err)
+	}
+	joinFake(t, mgr, "empty-leaving", "empty-keep", newFakePeer())
+	mgr.DisconnectUser("empty-leaving")
+	if !roomExists(mgr, "empty-keep") {
+		t.Fatalf("a kept room should outlive its last peer")
+	}
+
+	// a peer back within the grace keeps it open past it
+	joinFake(t, mgr, "empty-back", "empty-keep", newFakePeer())
+	time.Sleep(1500 * time.Millisecond)
+	if !roomExists(mgr, "empty-keep") {
+		t.Fatalf("a room rejoined within its grace should stay open")
+	}
+	mgr.DisconnectUser("empty-back")
+	waitFor(t, "the room's grace to run out", func() bool { return !roomExists(mgr, "empty-keep") })
+}
+
+func TestEmptyRoomByAge(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	keys := append(emptyRoomKeys("empty-expiring", "empty-aging"), emptyRoomKeys("empty-forever", "empty-ageless")...)
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	if policy, _ := mgr.EmptyRoomPolicy(); policy != pb.EmptyRoomPolicy_EMPTY_ROOM_BY_AGE {
+		t.Errorf("got %s want rooms closed by their age by default", policy)
+	}
+	mgr.OpenRoom("empty-expiring", &pb.CreateRoomRequest{Options: &pb.RoomOptions{MaxAgeSeconds: 60}})
+	mgr.OpenRoom("empty-forever", &pb.CreateRoomRequest{Options: &pb.RoomOptions{MaxAgeSeconds: -1}})
+	joinFake(t, mgr, "empty-aging", "empty-expiring", newFakePeer())
+	joinFake(t, mgr, "empty-ageless", "empty-forever", newFakePeer())
+	mgr.DisconnectUser("empty-aging")
+	mgr.DisconnectUser("empty-ageless")
+	if !roomExists(mgr, "empty-expiring") {
+		t.Errorf("a room that expires should be kept until it does")
+	}
+	if roomExists(mgr, "empty-forever") {
+		t.Errorf("a room that never expires should be closed once empty")
+	}
+}
+
+func TestEmptyRoomClosingRefusesJoins(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	keys := emptyRoomKeys("empty-closing", "empty-late")
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	if _, _, err := mgr.OpenRoom("empty-closing", &pb.CreateRoomRequest{}); err != nil {
+		t.Fatalf("error opening: %s", err)
+	}
+	// the room's last leave claimed it, a join now must not land in it
+	redis.Set(pb.KeyRoomEmpty("empty-closing"), "leave", 0)
+	if claimed, _ := claimEmptyRoom.Run(redis, []string{pb.KeyRoomUsers("empty-closing"), pb.KeyRoomEmpty("empty-closing"),
+		pb.KeyRoomClosing("empty-closing")}, "leave", RoomClosingTimeout.Milliseconds()).Int(); claimed != 1 {
+		t.Fatalf("an empty room should be claimed by the leave that emptied it")
+	}
+	if err := requestJoin(mgr, "empty-late", "empty-closing", "join-1", newFakePeer()); !errors.Is(err, ErrRoomClosing) {
+		mgr.DisconnectUser("empty-late")
+		t.Fatalf("got %v want a join refused while its room closes", err)
+	}
+	reply := nextSignalReply(t, mgr, "empty-late", func(reply *pb.SignalReply) bool { return reply.GetFailure() != nil })
+	if failure := reply.GetFailure(); failure.GetCode() != pb.SignalError_ROOM_CLOSED || failure.GetRetryAfterMs() == 0 {
+		t.Errorf("got %s want a ROOM_CLOSED worth retrying", reply)
+	}
+	if redis.HExists(pb.KeyRoomUsers("empty-closing"), "empty-late").Val() {
+		t.Errorf("a join refused as closing should not take a slot")
+	}
+
+	// and a join that got in first keeps the room from being claimed
+	redis.Del(pb.KeyRoomClosing("empty-closing"))
+	redis.Set(pb.KeyRoomEmpty("empty-closing"), "leave", 0)
+	if reserved, err := mgr.ReservePeerSlot("empty-closing", "empty-late", 0); err != nil || !reserved {
+		t.Fatalf("got %v %v want a slot once the room closed", reserved, err)
+	}
+	mgr.closeEmptyRoom("empty-closing", "leave")
+	if !roomExists(mgr, "empty-closing") {
+		t.Errorf("a room a peer joined should not be closed as empty")
+	}
+}
+
+func TestEmptyRoomReleasedSlot(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetEmptyRoomPolicy(pb.EmptyRoomPolicy_EMPTY_ROOM_CLOSE, 0)
+	keys := emptyRoomKeys("empty-released", "empty-failed")
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	if _, _, err := mgr.OpenRoom("empty-released", &pb.CreateRoomRequest{}); err != nil {
+		t.Fatalf("error opening: %s", err)
+	}
+	// a join that failed after reserving was the room's only peer
+	if reserved, err := mgr.ReservePeerSlot("empty-released", "empty-failed", 0); err != nil || !reserved {
+		t.Fatalf("got %v %v want a slot", reserved, err)
+	}
+	mgr.ReleasePeerSlot("empty-released", "empty-failed")
+	if roomExists(mgr, "empty-released") {
+		t.Errorf("the room should be closed once the slot that kept it was given back")
+	}
+
+	// a join that read the room before it closed finds no slot in it after
+	if reserved, err := mgr.ReservePeerSlot("empty-released", "empty-failed", 0); !errors.Is(err, ErrRoomClosing) || reserved {
+		t.Errorf("got %v %v want a reservation in a deleted room refused", reserved, err)
+	}
+	if redis.HExists(pb.KeyRoomUsers("empty-released"), "empty-failed").Val() {
+		t.Errorf("a refused reservation should not keep its slot")
+	}
+	mgr.SetAllowAutoCreateRooms(true)
+	defer mgr.SetAllowAutoCreateRooms(false)
+	if reserved, err := mgr.ReservePeerSlot("empty-released", "empty-failed", 0); err != nil || !reserved {
+		t.Errorf("got %v %v want a slot in a room its join creates", reserved, err)
+	}
+}
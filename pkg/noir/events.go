@@ -0,0 +1,78 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"google.golang.org/protobuf/proto"
+	"sync"
+)
+
+/*
+In-Process Events:
+Go services embedding noir, like controllers and bots, can have the room events this node
+publishes without a round trip through redis: SubscribeEvents hands out a channel fed by
+PublishRoomEvent, the same place every event on KeyTopicRoomEvents comes from, for every room.
+Only this node's events arrive, those of other nodes still need SubscribeRoomEvents. A
+subscriber that falls EventBuffer events behind loses its oldest ones, counted in
+noir_events_dropped_total, so a slow consumer never holds up signaling.
+*/
+
+// How many events a SubscribeEvents channel holds before the oldest are dropped
+const EventBuffer = 64
+
+// Event is a room event this node published, peers joining and leaving, rooms opening and
+// closing, and the rest of pb.RoomEvent_Type. It is shared by every subscriber, so read only
+type Event struct {
+	*pb.RoomEvent
+}
+
+type eventBus struct {
+	mu          sync.RWMutex
+	subscribers map[chan Event]bool
+}
+
+// SubscribeEvents receives the room events published from here on until cancel is called,
+// which closes the channel
+func (m *Manager) SubscribeEvents() (<-chan Event, func()) {
+	events := make(chan Event, EventBuffer)
+	m.events.mu.Lock()
+	if m.events.subscribers == nil {
+		m.events.subscribers = map[chan Event]bool{}
+	}
+	m.events.subscribers[events] = true
+	m.events.mu.Unlock()
+
+	var cancelled sync.Once
+	cancel := func() {
+		cancelled.Do(func() {
+			m.events.mu.Lock()
+			defer m.events.mu.Unlock()
+			delete(m.events.subscribers, events)
+			close(events)
+		})
+	}
+	return events, cancel
+}
+
+// deliverEvent hands every subscriber a copy of the event, making room by dropping their oldest
+func (m *Manager) deliverEvent(roomEvent *pb.RoomEvent) {
+	m.events.mu.RLock()
+	defer m.events.mu.RUnlock()
+	if len(m.events.subscribers) == 0 {
+		return
+	}
+	event := Event{proto.Clone(roomEvent).(*pb.RoomEvent)}
+	for events := range m.events.subscribers {
+		for delivered := false; !delivered; {
+			select {
+			case events <- event:
+				delivered = true
+			default:
+				select {
+				case <-events:
+					eventsDropped.Inc()
+				default:
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/webrtc/v3"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"testing"
+	"time"
+)
+
+func TestSubscribeEvents(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	redis.Del(pb.KeyRoomData("events-room"), pb.KeyRoomClosed("events-room"))
+	defer redis.Del(pb.KeyRoomData("events-room"), pb.KeyRoomClosed("events-room"))
+
+	events, cancel := mgr.SubscribeEvents()
+	defer cancel()
+	next := func() Event {
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for an event")
+			return Event{}
+		}
+	}
+
+	if _, _, err := mgr.OpenRoom("events-room", &pb.CreateRoomRequest{}); err != nil {
+		t.Fatalf("error opening room: %s", err)
+	}
+	mgr.EmitRoomEvent("events-room", "events-peer", pb.RoomEvent_JOINED)
+	if _, err := mgr.EvictRoom("events-room"); err != nil {
+		t.Fatalf("error closing room: %s", err)
+	}
+	for _, want := range []pb.RoomEvent_Type{pb.RoomEvent_OPENED, pb.RoomEvent_JOINED, pb.RoomEvent_CLOSED} {
+		if event := next(); event.Type != want || event.Sid != "events-room" {
+			t.Errorf("got %s in %s want %s", event.Type, event.Sid, want)
+		}
+	}
+
+	cancel()
+	if _, open := <-events; open {
+		t.Errorf("cancel should close the channel")
+	}
+}
+
+func TestSubscribeEventsSlowConsumer(t *testing.T) {
+	mgr, _ := NewTestSetup()
+	events, cancel := mgr.SubscribeEvents()
+	defer cancel()
+
+	droppedBefore := testutil.ToFloat64(eventsDropped)
+	for i := 0; i < EventBuffer+5; i++ {
+		mgr.deliverEvent(&pb.RoomEvent{Type: pb.RoomEvent_MUTED, Sid: "slow-room", AudioLevel: int32(i)})
+	}
+	if dropped := testutil.ToFloat64(eventsDropped) - droppedBefore; dropped != 5 {
+		t.Errorf("got %v dropped events want 5", dropped)
+	}
+	if len(events) != EventBuffer {
+		t.Fatalf("got %d buffered events want %d", len(events), EventBuffer)
+	}
+	if oldest := <-events; oldest.AudioLevel != 5 {
+		t.Errorf("got %d as the oldest event kept want 5, the oldest are dropped", oldest.AudioLevel)
+	}
+}
+
+func TestLeftOnceJoined(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	keys := emptyRoomKeys("left-room", "left-joined", "left-never")
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+	events, cancel := mgr.SubscribeEvents()
+	defer cancel()
+
+	joined, never := newFakePeer(), newFakePeer()
+	joinFake(t, mgr, "left-joined", "left-room", joined)
+	joinFake(t, mgr, "left-never", "left-room", never)
+	joined.mu.Lock()
+	connected := joined.onState
+	joined.mu.Unlock()
+	connected(webrtc.ICEConnectionStateConnected)
+
+	// the peer that never connected leaves first, its LEFT would come ahead of the other's
+	mgr.CloseClient("left-never", pb.CloseReason_LEFT)
+	mgr.CloseClient("left-joined", pb.CloseReason_LEFT)
+	mgr.CloseClient("left-joined", pb.CloseReason_LEFT)
+	got := []string{}
+	for len(got) < 2 {
+		select {
+		case event := <-events:
+			if event.Type == pb.RoomEvent_JOINED || event.Type == pb.RoomEvent_LEFT {
+				got = append(got, event.Pid+" "+event.Type.String())
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("got %v want left-joined JOINED and LEFT", got)
+		}
+	}
+	if got[0] != "left-joined JOINED" || got[1] != "left-joined LEFT" {
+		t.Errorf("got %v want left-joined JOINED and LEFT, and no LEFT for a peer that never joined", got)
+	}
+	select {
+	case event := <-events:
+		if event.Type == pb.RoomEvent_LEFT {
+			t.Errorf("got a LEFT for %s again want one per join", event.Pid)
+		}
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,71 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	log "github.com/pion/ion-log"
+)
+
+// Supervise reclaims every checked in worker whose heartbeat expired, any number of managers can run it
+func (m *Manager) Supervise() {
+	m.updatePlaceableWorkers()
+	alive, err := m.aliveWorkers()
+	if err != nil {
+		log.Errorf("error reading heartbeats: %s", err)
+		return
+	}
+	for _, nodeID := range m.redis.HKeys(m.Keys().NodeMap()).Val() {
+		if alive[nodeID] || nodeID == m.id {
+			continue
+		}
+		reclaimed, err := m.ReclaimWorker(nodeID)
+		if err != nil {
+			log.Errorf("error reclaiming worker %s: %s", nodeID, err)
+		} else if reclaimed > 0 {
+			log.Warnf("worker %s stopped sending heartbeats, told %d peers to rejoin", nodeID, reclaimed)
+		}
+	}
+}
+
+// ReclaimWorker tells the clients of every peer a dead worker owned to join again, WebRTC state
+// cannot move to another worker. Their rooms move on their next join. Only the first manager
+// to reclaim a worker does anything, the rest get 0
+func (m *Manager) ReclaimWorker(nodeID string) (int, error) {
+	acquired, err := m.redis.SetNX(m.Keys().WorkerReclaim(nodeID), m.id, m.FailoverWindow()).Result()
+	if err != nil || !acquired {
+		return 0, err
+	}
+
+	reclaimed := 0
+	for _, roomID := range m.redis.HKeys(m.Keys().NodeRooms(nodeID)).Val() {
+		for _, pid := range m.redis.HKeys(m.Keys().RoomUsers(roomID)).Val() {
+			m.requireReconnect(pid)
+			m.redis.Del(m.Keys().UserData(pid), m.Keys().UserResume(pid))
+			m.redis.HDel(m.Keys().RoomUsers(roomID), pid)
+			if m.announcedLeft(pid) {
+				m.EmitRoomEvent(roomID, pid, pb.RoomEvent_LEFT)
+			}
+			reclaimed++
+		}
+	}
+	m.MarkOffline(nodeID)
+	return reclaimed, nil
+}
+
+// requireReconnect sends a reconnect, then a Kill to end the client's Listen
+func (m *Manager) requireReconnect(pid string) {
+	// Nobody reads the dead worker's to-peer topic any more
+	m.GetQueue(m.Keys().TopicToPeer(pid)).Cleanup()
+
+	fromPeer := m.GetQueue(m.Keys().TopicFromPeer(pid))
+	for _, reply := range []*pb.SignalReply{
+		{Id: pid, Payload: &pb.SignalReply_Reconnect{Reconnect: true}},
+		{Id: pid, Payload: &pb.SignalReply_Kill{Kill: true}},
+	} {
+		if err := m.EnqueueReply(fromPeer, &pb.NoirReply{
+			Command: &pb.NoirReply_Signal{Signal: reply},
+		}); err != nil {
+			log.Errorf("error telling %s to reconnect: %s", pid, err)
+		}
+	}
+	m.redis.Publish(m.Keys().PeerNewsChannel(pid), pid)
+}
@@ -0,0 +1,45 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"google.golang.org/protobuf/proto"
+	"testing"
+)
+
+func TestReclaimWorker(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	redis.Del(pb.KeyWorkerReclaim("dead-worker"), pb.KeyTopicFromPeer("stranded"))
+	redis.HSet(pb.KeyNodeMap(), "dead-worker", "")
+	redis.HSet(pb.KeyNodeRooms("dead-worker"), "stranded-room", 1)
+	redis.HSet(pb.KeyRoomUsers("stranded-room"), "stranded", 1)
+
+	mgr.Supervise()
+
+	fromPeer := mgr.GetQueue(pb.KeyTopicFromPeer("stranded"))
+	reply := pb.NoirReply{}
+	msg, _ := fromPeer.Next()
+	proto.Unmarshal(msg, &reply)
+	if !reply.GetSignal().GetReconnect() {
+		t.Errorf("got %s want a reconnect", &reply)
+	}
+	msg, _ = fromPeer.Next()
+	proto.Unmarshal(msg, &reply)
+	if !reply.GetSignal().GetKill() {
+		t.Errorf("got %s want a kill after the reconnect", &reply)
+	}
+
+	if redis.HExists(pb.KeyNodeMap(), "dead-worker").Val() || redis.HExists(pb.KeyRoomUsers("stranded-room"), "stranded").Val() {
+		t.Errorf("reclaimed worker and its peers should be gone")
+	}
+	if redis.HExists(pb.KeyNodeMap(), "test-worker").Val() == false {
+		t.Errorf("live worker should not be reclaimed")
+	}
+
+	// A second manager finds the worker already reclaimed
+	redis.HSet(pb.KeyNodeRooms("dead-worker"), "stranded-room", 1)
+	redis.HSet(pb.KeyRoomUsers("stranded-room"), "stranded", 1)
+	if reclaimed, err := mgr.ReclaimWorker("dead-worker"); reclaimed != 0 || err != nil {
+		t.Errorf("got %d %v want nothing reclaimed twice", reclaimed, err)
+	}
+	redis.Del(pb.KeyNodeRooms("dead-worker"), pb.KeyRoomUsers("stranded-room"))
+}
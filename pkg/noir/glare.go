@@ -0,0 +1,61 @@
+package noir
+
+import (
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"github.com/pion/webrtc/v3"
+)
+
+/*
+Glare:
+A peer negotiates both ways, the client offers to the publisher and the subscriber offers to
+the client whenever tracks come or go. A client with a pc per direction never sees the offers
+collide, but one running perfect negotiation over a single pc does, and as the impolite peer it
+ignores our offer. We are the polite peer: a client offer that arrives while our offer still
+waits for its answer is answered first, then we offer again.
+pion cannot roll back a local offer yet, so instead of rolling back and offering anew we send
+the pending offer a second time, which the client answers once its own offer is done. Should it
+answer both times, the answer that finds the subscriber stable is dropped.
+*/
+
+// glareState is what a peer's Negotiate remembers about the offers it sent again
+type glareState struct {
+	// answers the client may still send to offers it was sent twice
+	resent int
+}
+
+// pendingOffer is true while the subscriber's offer waits for an answer
+func (w *worker) pendingOffer(peer *sfu.Peer) bool {
+	peer.Lock()
+	defer peer.Unlock()
+	pc := subscriberConnection(subscriberOf(peer))
+	return pc != nil && pc.SignalingState() == webrtc.SignalingStateHaveLocalOffer
+}
+
+// reoffer sends the subscriber's pending offer again, with the candidates gathered since
+func (w *worker) reoffer(peer *sfu.Peer, glare *glareState) {
+	peer.Lock()
+	defer peer.Unlock()
+	pc := subscriberConnection(subscriberOf(peer))
+	if pc == nil || pc.SignalingState() != webrtc.SignalingStateHaveLocalOffer {
+		return
+	}
+	glare.resent++
+	if peer.OnOffer != nil {
+		peer.OnOffer(pc.PendingLocalDescription())
+	}
+}
+
+// staleAnswer is true for an answer that finds no offer pending, after we sent one twice
+func (w *worker) staleAnswer(peer *sfu.Peer, glare *glareState) bool {
+	if glare.resent == 0 {
+		return false
+	}
+	peer.Lock()
+	pc := subscriberConnection(subscriberOf(peer))
+	stable := pc != nil && pc.SignalingState() == webrtc.SignalingStateStable
+	peer.Unlock()
+	if stable {
+		glare.resent--
+	}
+	return stable
+}
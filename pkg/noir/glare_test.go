@@ -0,0 +1,112 @@
+package noir
+
+import (
+	"encoding/json"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/webrtc/v3"
+	"google.golang.org/protobuf/proto"
+	"sync"
+	"testing"
+)
+
+// nextDescription reads the next description replied to pid
+func nextDescription(t *testing.T, mgr *Manager, pid string) webrtc.SessionDescription {
+	reply := pb.NoirReply{}
+	msg, err := mgr.GetQueue(pb.KeyTopicFromPeer(pid)).Next()
+	if err != nil {
+		t.Fatalf("got %s want a reply", err)
+	}
+	proto.Unmarshal(msg, &reply)
+	desc := webrtc.SessionDescription{}
+	if err := json.Unmarshal(reply.GetSignal().GetDescription(), &desc); err != nil {
+		t.Fatalf("got %s want a description", &reply)
+	}
+	return desc
+}
+
+func describe(pid string, desc webrtc.SessionDescription) *pb.NoirRequest {
+	packed, _ := json.Marshal(Negotiation{Desc: desc})
+	return &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id:      pid,
+				Payload: &pb.SignalRequest_Description{Description: packed},
+			},
+		},
+	}
+}
+
+func TestNegotiationGlare(t *testing.T) {
+	mgr, _ := NewTestSetup()
+	w := (*mgr.GetWorker()).(*worker)
+	pid := "glare-peer"
+	mgr.SetRoomData(&pb.RoomData{Id: "glare"})
+	mgr.GetQueue(pb.KeyTopicFromPeer(pid)).Cleanup()
+	defer mgr.GetQueue(pb.KeyTopicFromPeer(pid)).Cleanup()
+
+	// the client sends from one pc and receives on another
+	send, _ := webrtc.NewPeerConnection(webrtc.Configuration{})
+	defer send.Close()
+	recv, _ := webrtc.NewPeerConnection(webrtc.Configuration{})
+	defer recv.Close()
+	send.CreateDataChannel("data", nil)
+	offer, _ := send.CreateOffer(nil)
+	send.SetLocalDescription(offer)
+
+	client := NewSFUPeer(*mgr.sfu)
+	defer client.Close()
+	peer := client.SFUPeer()
+	answer, err := client.Join("glare", offer)
+	if err != nil {
+		t.Fatalf("error joining: %s", err)
+	}
+	send.SetRemoteDescription(*answer)
+	peer.OnOffer = func(offer *webrtc.SessionDescription) {
+		packed, _ := json.Marshal(offer)
+		w.SignalReply(pid, &pb.NoirReply{
+			Command: &pb.NoirReply_Signal{
+				Signal: &pb.SignalReply{Id: pid, Payload: &pb.SignalReply_Description{Description: packed}},
+			},
+		})
+	}
+
+	// the subscriber offered, and the client offers again before it saw that offer
+	peer.Lock()
+	subscriberOf(peer).CreateOffer()
+	peer.Unlock()
+	offer, _ = send.CreateOffer(nil)
+	send.SetLocalDescription(offer)
+
+	userData := &pb.UserData{Id: pid, RoomID: "glare"}
+	var userMu sync.Mutex
+	deadline := newSFUDeadline()
+	glare := &glareState{}
+	w.HandleDescription(describe(pid, offer), userData, client, &userMu, deadline, glare)
+
+	if answer := nextDescription(t, mgr, pid); answer.Type != webrtc.SDPTypeAnswer {
+		t.Fatalf("got %s want the client's offer answered first", answer.Type)
+	} else if err := send.SetRemoteDescription(answer); err != nil {
+		t.Fatalf("error setting answer: %s", err)
+	}
+	reoffer := nextDescription(t, mgr, pid)
+	if reoffer.Type != webrtc.SDPTypeOffer {
+		t.Fatalf("got %s want the subscriber to offer again", reoffer.Type)
+	}
+
+	recv.SetRemoteDescription(reoffer)
+	recvAnswer, _ := recv.CreateAnswer(nil)
+	recv.SetLocalDescription(recvAnswer)
+	w.HandleDescription(describe(pid, recvAnswer), userData, client, &userMu, deadline, glare)
+	// answering the offer a second time is dropped, not failed
+	w.HandleDescription(describe(pid, recvAnswer), userData, client, &userMu, deadline, glare)
+	if msg, _ := mgr.GetQueue(pb.KeyTopicFromPeer(pid)).Next(); msg != nil {
+		t.Errorf("the stale answer should be dropped quietly")
+	}
+
+	if state := subscriberConnection(subscriberOf(peer)).SignalingState(); state != webrtc.SignalingStateStable {
+		t.Errorf("got subscriber %s want stable", state)
+	}
+	if state := send.SignalingState(); state != webrtc.SignalingStateStable {
+		t.Errorf("got publisher %s want stable", state)
+	}
+}
@@ -0,0 +1,107 @@
+package noir
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-redis/redis"
+	log "github.com/pion/ion-log"
+	"io"
+	"time"
+)
+
+/*
+Health:
+Every node needs its redis, for the queues and for the cluster state. WaitForRedis holds startup
+until redis answers a PING, backing off from RedisConnectBackoff up to RedisConnectMaxBackoff
+and logging each failed attempt, so a node started ahead of its redis neither panics checking in
+nor loops on queue errors. Once running, a worker or router that loses its queue backs off the
+same way PeerChannel does instead of retrying every second, and logs when it has the queue back.
+Manager.Ping checks redis answers, and Worker.Ready is true while the worker consumes its topic,
+its last read from it did not fail, and redis answers. The admin HTTP API serves both on /healthz
+for orchestrators to gate traffic on.
+*/
+
+// WaitForRedis backs off from RedisConnectBackoff up to RedisConnectMaxBackoff between attempts
+const (
+	RedisConnectBackoff    = 250 * time.Millisecond
+	RedisConnectMaxBackoff = 10 * time.Second
+)
+
+// the reason Manager.Health gives while the worker is not running or lost its queue
+var ErrNotReady = errors.New("worker is not consuming its queue")
+
+// WaitForRedis pings client until it answers, giving up after timeout, or never when it is 0
+func WaitForRedis(client *redis.Client, timeout time.Duration) error {
+	started := time.Now()
+	backoff := RedisConnectBackoff
+	for attempt := 1; ; attempt++ {
+		err := client.Ping().Err()
+		if err == nil {
+			if attempt > 1 {
+				log.Infof("redis at %s is up after %d attempts", client.Options().Addr, attempt)
+			}
+			return nil
+		}
+		if timeout > 0 && time.Since(started)+backoff > timeout {
+			return fmt.Errorf("redis at %s unavailable after %d attempts: %s", client.Options().Addr, attempt, err)
+		}
+		log.Warnf("redis at %s unavailable (attempt %d): %s, retrying in %s", client.Options().Addr, attempt, err, backoff)
+		time.Sleep(backoff)
+		if backoff *= 2; backoff > RedisConnectMaxBackoff {
+			backoff = RedisConnectMaxBackoff
+		}
+	}
+}
+
+// Ping checks redis answers
+func (m *Manager) Ping() error {
+	return m.redis.Ping().Err()
+}
+
+// Health is nil when this node's worker is ready, or why it is not
+func (m *Manager) Health() error {
+	if err := m.Ping(); err != nil {
+		return fmt.Errorf("redis unavailable: %s", err)
+	}
+	if m.worker == nil || !m.worker.Ready() {
+		return ErrNotReady
+	}
+	return nil
+}
+
+// Ready is true while the worker consumes its topic and redis answers
+func (w *worker) Ready() bool {
+	select {
+	case <-w.done:
+		return false
+	default:
+	}
+	return w.running.get() && !w.health.down.get() && w.manager.Ping() == nil
+}
+
+// queueHealth follows the reads of a worker or router queue, for Ready and to back off while they fail
+type queueHealth struct {
+	down atomicBool
+	// counted by the HandleForever loop only
+	failures int
+}
+
+// read notes how the last read of the queue went, nothing queued is fine
+func (h *queueHealth) read(err error) {
+	h.down.set(err != nil && err != io.EOF)
+}
+
+// backoff is how long HandleForever waits before reading again, 0 while the queue is fine
+func (h *queueHealth) backoff(topic string) time.Duration {
+	if !h.down.get() {
+		if h.failures > 0 {
+			log.Infof("queue %s is back after %d errors", topic, h.failures)
+			h.failures = 0
+		}
+		return 0
+	}
+	h.failures++
+	backoff := peerQueueBackoff(h.failures)
+	log.Errorf("queue %s unavailable, retrying in %s", topic, backoff)
+	return backoff
+}
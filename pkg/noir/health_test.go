@@ -0,0 +1,64 @@
+package noir
+
+import (
+	"errors"
+	"github.com/go-redis/redis"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestWaitForRedis(t *testing.T) {
+	_, rdb := NewTestSetup()
+	if err := WaitForRedis(rdb, time.Second); err != nil {
+		t.Errorf("got %s want the test redis up", err)
+	}
+
+	down := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1"})
+	started := time.Now()
+	if err := WaitForRedis(down, 600*time.Millisecond); err == nil {
+		t.Errorf("got a connection want an error for a redis that is down")
+	}
+	if waited := time.Since(started); waited > time.Second {
+		t.Errorf("waited %s want to give up within the timeout", waited)
+	}
+}
+
+func TestWorkerReady(t *testing.T) {
+	mgr, _ := NewTestSetup()
+	worker := *mgr.GetWorker()
+	(*worker.GetQueue()).Cleanup()
+	if worker.Ready() || mgr.Health() == nil {
+		t.Errorf("a worker not consuming its queue yet should not be ready")
+	}
+
+	go worker.HandleForever()
+	time.Sleep(100 * time.Millisecond)
+	if !worker.Ready() {
+		t.Errorf("a running worker with redis up should be ready")
+	}
+	if err := mgr.Health(); err != nil {
+		t.Errorf("got %s want the node healthy", err)
+	}
+
+	worker.Stop()
+	if worker.Ready() {
+		t.Errorf("a stopped worker should not be ready")
+	}
+}
+
+func TestQueueHealthBackoff(t *testing.T) {
+	health := queueHealth{}
+	health.read(errors.New("connection refused"))
+	if backoff := health.backoff("tests"); backoff != PeerQueueBackoff {
+		t.Errorf("got %s want %s after the first error", backoff, PeerQueueBackoff)
+	}
+	health.read(errors.New("connection refused"))
+	if backoff := health.backoff("tests"); backoff != 2*PeerQueueBackoff {
+		t.Errorf("got %s want %s after the second", backoff, 2*PeerQueueBackoff)
+	}
+	health.read(io.EOF)
+	if backoff := health.backoff("tests"); backoff != 0 || health.failures != 0 {
+		t.Errorf("got %s after %d errors want no backoff once the queue answers", backoff, health.failures)
+	}
+}
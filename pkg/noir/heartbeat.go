@@ -0,0 +1,157 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	log "github.com/pion/ion-log"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+// WorkerStatus is what a live worker last said about itself
+type WorkerStatus struct {
+	ID       string    `json:"id"`
+	LastSeen time.Time `json:"last_seen"`
+	Peers    int       `json:"peers"`
+	// 0 is no limit, see Manager.MaxPeers
+	MaxPeers int `json:"max_peers"`
+	// takes no more joins, see Worker.Drain
+	Draining bool `json:"draining"`
+}
+
+// Full is true for a worker that takes no more peers
+func (s WorkerStatus) Full() bool {
+	return s.MaxPeers > 0 && s.Peers >= s.MaxPeers
+}
+
+func (m *Manager) SetHeartbeatInterval(interval time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.heartbeatInterval = interval
+}
+
+func (m *Manager) HeartbeatInterval() time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.heartbeatInterval > 0 {
+		return m.heartbeatInterval
+	}
+	return WorkerHeartbeatInterval
+}
+
+func (m *Manager) SetFailoverWindow(window time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failoverWindow = window
+}
+
+// FailoverWindow is how long a worker can miss heartbeats before it counts as dead,
+// 3 heartbeat intervals unless set
+func (m *Manager) FailoverWindow() time.Duration {
+	m.mu.RLock()
+	window := m.failoverWindow
+	m.mu.RUnlock()
+	if window > 0 {
+		return window
+	}
+	return 3 * m.HeartbeatInterval()
+}
+
+// Heartbeat marks a worker alive for the FailoverWindow
+func (m *Manager) Heartbeat(workerID string, peers int) error {
+	return m.heartbeat(workerID, peers, false)
+}
+
+func (m *Manager) heartbeat(workerID string, peers int, draining bool) error {
+	return m.SaveData(m.Keys().WorkerHeartbeat(workerID), &pb.NoirObject{
+		Data: &pb.NoirObject_Heartbeat{
+			Heartbeat: &pb.WorkerHeartbeat{
+				Id:       workerID,
+				LastSeen: timestamppb.Now(),
+				Peers:    int32(peers),
+				MaxPeers: int32(m.MaxPeers()),
+				Draining: draining,
+			},
+		},
+	}, m.FailoverWindow())
+}
+
+// ClearHeartbeat marks a worker dead right away, instead of when its heartbeat expires
+func (m *Manager) ClearHeartbeat(workerID string) error {
+	return m.redis.Del(m.Keys().WorkerHeartbeat(workerID)).Err()
+}
+
+// ListWorkers returns every worker whose heartbeat has not expired
+func (m *Manager) ListWorkers() ([]WorkerStatus, error) {
+	workers := []WorkerStatus{}
+	cursor := uint64(0)
+	for {
+		keys, next, err := m.redis.Scan(cursor, m.Keys().WorkerHeartbeatPrefix()+"*", RoomScanCount).Result()
+		if err != nil {
+			return workers, err
+		}
+		if len(keys) > 0 {
+			values, err := m.redis.MGet(keys...).Result()
+			if err != nil {
+				return workers, err
+			}
+			for i, value := range values {
+				// expired between the SCAN and the MGET
+				data, ok := value.(string)
+				if !ok {
+					continue
+				}
+				var load pb.NoirObject
+				if err := proto.Unmarshal([]byte(data), &load); err != nil || load.GetHeartbeat() == nil {
+					log.Warnf("skipping unreadable heartbeat %s", keys[i])
+					continue
+				}
+				heartbeat := load.GetHeartbeat()
+				workers = append(workers, WorkerStatus{
+					ID:       heartbeat.Id,
+					LastSeen: heartbeat.LastSeen.AsTime(),
+					Peers:    int(heartbeat.Peers),
+					MaxPeers: int(heartbeat.MaxPeers),
+					Draining: heartbeat.Draining,
+				})
+			}
+		}
+		if next == 0 {
+			return workers, nil
+		}
+		cursor = next
+	}
+}
+
+func (m *Manager) aliveWorkers() (map[string]bool, error) {
+	workers, err := m.ListWorkers()
+	alive := make(map[string]bool, len(workers))
+	for _, status := range workers {
+		alive[status.ID] = true
+	}
+	return alive, err
+}
+
+// updatePlaceableWorkers reads which workers NodesForService places joins on, with every
+// UpdateAvailableNodes and Supervise, so routing a join reads no heartbeats. While they cannot
+// be read joins are placed on every node
+func (m *Manager) updatePlaceableWorkers() {
+	placeable, err := m.placeableWorkers()
+	if err != nil {
+		log.Errorf("error reading heartbeats: %s", err)
+		placeable = nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.placeable = placeable
+}
+
+// placeableWorkers are the live workers that take joins, the draining and full ones left out
+func (m *Manager) placeableWorkers() (map[string]bool, error) {
+	workers, err := m.ListWorkers()
+	placeable := make(map[string]bool, len(workers))
+	for _, status := range workers {
+		placeable[status.ID] = !status.Draining && !status.Full()
+	}
+	return placeable, err
+}
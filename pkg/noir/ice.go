@@ -0,0 +1,67 @@
+package noir
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+// ICEServerProvider hands out the STUN/TURN servers a joining peer should use
+type ICEServerProvider interface {
+	ICEServers(pid string) ([]*pb.ICEServer, error)
+}
+
+// turnRESTProvider issues short-lived TURN credentials from a secret shared with the
+// TURN server (the "TURN REST API" scheme coturn calls use-auth-secret):
+// username is "<expiry unix>:<pid>" and credential is base64(HMAC-SHA1(secret, username))
+type turnRESTProvider struct {
+	stunURLs []string
+	turnURLs []string
+	secret   []byte
+	ttl      time.Duration
+}
+
+func NewTURNRESTProvider(stunURLs []string, turnURLs []string, secret string, ttl time.Duration) (ICEServerProvider, error) {
+	if len(turnURLs) > 0 && secret == "" {
+		return nil, errors.New("turn urls need a shared secret")
+	}
+	if ttl <= 0 {
+		return nil, errors.New("turn credential ttl must be positive")
+	}
+	return &turnRESTProvider{
+		stunURLs: stunURLs,
+		turnURLs: turnURLs,
+		secret:   []byte(secret),
+		ttl:      ttl,
+	}, nil
+}
+
+func (p *turnRESTProvider) ICEServers(pid string) ([]*pb.ICEServer, error) {
+	servers := []*pb.ICEServer{}
+	if len(p.stunURLs) > 0 {
+		servers = append(servers, &pb.ICEServer{Urls: p.stunURLs})
+	}
+	if len(p.turnURLs) > 0 {
+		expires := time.Now().Add(p.ttl)
+		username, credential := p.Credentials(pid, expires)
+		servers = append(servers, &pb.ICEServer{
+			Urls:       p.turnURLs,
+			Username:   username,
+			Credential: credential,
+			Expires:    timestamppb.New(expires),
+		})
+	}
+	return servers, nil
+}
+
+func (p *turnRESTProvider) Credentials(pid string, expires time.Time) (string, string) {
+	username := fmt.Sprintf("%d:%s", expires.Unix(), pid)
+	mac := hmac.New(sha1.New, p.secret)
+	mac.Write([]byte(username))
+	return username, base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,115 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/sdp/v3"
+	"github.com/pion/webrtc/v3"
+	"strings"
+)
+
+/*
+ICE Policy:
+Some deployments may only use relayed or TCP candidates, or must not tell peers each other's
+local addresses. An ICEPolicy drops the candidates that do not match it, in the trickle we send
+and receive and in the candidates of every description, and counts each one in
+noir_ice_candidates_filtered_total. The manager's policy applies to every room whose options
+carry no icePolicy of their own. A peer left with no matching candidate fails to connect,
+which is the point: relayOnly needs a TURN server, see TurnConfig.
+*/
+
+// ICEPolicyConfig is the manager's ICEPolicy in config.toml
+type ICEPolicyConfig struct {
+	RelayOnly bool `mapstructure:"relayonly"`
+	TCPOnly   bool `mapstructure:"tcponly"`
+	NoHost    bool `mapstructure:"nohost"`
+}
+
+func (c ICEPolicyConfig) Policy() *pb.ICEPolicy {
+	if c == (ICEPolicyConfig{}) {
+		return nil
+	}
+	return &pb.ICEPolicy{RelayOnly: c.RelayOnly, TcpOnly: c.TCPOnly, NoHost: c.NoHost}
+}
+
+// SetICEPolicy filters the candidates of peers joining from now on, nil allows all of them
+func (m *Manager) SetICEPolicy(policy *pb.ICEPolicy) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.icePolicy = policy
+}
+
+// RoomICEPolicy is the room's own policy, or the manager's when the room has none
+func (m *Manager) RoomICEPolicy(roomID string) *pb.ICEPolicy {
+	if roomData, err := m.GetRemoteRoomData(roomID); err == nil {
+		if policy := roomData.GetOptions().GetIcePolicy(); policy != nil {
+			return policy
+		}
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.icePolicy
+}
+
+// AllowCandidate is true for a candidate matching the policy, and for anything that is not a candidate
+func AllowCandidate(policy *pb.ICEPolicy, candidate string) bool {
+	if policy == nil {
+		return true
+	}
+	fields := strings.Fields(strings.TrimPrefix(strings.TrimPrefix(candidate, "a="), "candidate:"))
+	// foundation component protocol priority address port typ type ...
+	if len(fields) < 8 || fields[6] != "typ" {
+		return true
+	}
+	protocol, kind := strings.ToLower(fields[2]), fields[7]
+	switch {
+	case policy.RelayOnly && kind != webrtc.ICECandidateTypeRelay.String():
+		return false
+	case policy.NoHost && kind == webrtc.ICECandidateTypeHost.String():
+		return false
+	case policy.TcpOnly && protocol != webrtc.ICEProtocolTCP.String():
+		return false
+	}
+	return true
+}
+
+// allowTrickle checks a trickled candidate, counting it when it is dropped
+func allowTrickle(policy *pb.ICEPolicy, candidate webrtc.ICECandidateInit, direction string) bool {
+	if AllowCandidate(policy, candidate.Candidate) {
+		return true
+	}
+	candidatesFiltered.WithLabelValues(direction).Inc()
+	return false
+}
+
+// FilterCandidates drops the candidates of a description that do not match the policy
+func FilterCandidates(desc *webrtc.SessionDescription, policy *pb.ICEPolicy, direction string) error {
+	if desc == nil || policy == nil {
+		return nil
+	}
+	parsed, err := desc.Unmarshal()
+	if err != nil {
+		return err
+	}
+	filtered := 0
+	for _, media := range parsed.MediaDescriptions {
+		kept := media.Attributes[:0]
+		for _, attr := range media.Attributes {
+			if attr.Key == sdp.AttrKeyCandidate && !AllowCandidate(policy, attr.Value) {
+				filtered++
+				continue
+			}
+			kept = append(kept, attr)
+		}
+		media.Attributes = kept
+	}
+	if filtered == 0 {
+		return nil
+	}
+	candidatesFiltered.WithLabelValues(direction).Add(float64(filtered))
+	packed, err := parsed.Marshal()
+	if err != nil {
+		return err
+	}
+	desc.SDP = string(packed)
+	return nil
+}
@@ -0,0 +1,68 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/webrtc/v3"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"strings"
+	"testing"
+)
+
+const (
+	hostCandidate  = "candidate:2070692838 1 udp 2130706431 192.0.2.2 48086 typ host"
+	tcpCandidate   = "candidate:1 1 tcp 1518280447 192.0.2.2 9 typ host tcptype passive"
+	relayCandidate = "candidate:3 1 udp 41885439 203.0.113.5 3478 typ relay raddr 0.0.0.0 rport 0"
+)
+
+func TestAllowCandidate(t *testing.T) {
+	for _, test := range []struct {
+		policy    *pb.ICEPolicy
+		candidate string
+		allowed   bool
+	}{
+		{nil, hostCandidate, true},
+		{&pb.ICEPolicy{RelayOnly: true}, hostCandidate, false},
+		{&pb.ICEPolicy{RelayOnly: true}, relayCandidate, true},
+		{&pb.ICEPolicy{TcpOnly: true}, hostCandidate, false},
+		{&pb.ICEPolicy{TcpOnly: true}, tcpCandidate, true},
+		{&pb.ICEPolicy{NoHost: true}, tcpCandidate, false},
+		{&pb.ICEPolicy{NoHost: true}, "a=" + relayCandidate, true},
+		{&pb.ICEPolicy{RelayOnly: true}, "", true},
+	} {
+		if allowed := AllowCandidate(test.policy, test.candidate); allowed != test.allowed {
+			t.Errorf("%v %q: got allowed=%t want %t", test.policy, test.candidate, allowed, test.allowed)
+		}
+	}
+}
+
+func TestFilterCandidates(t *testing.T) {
+	desc := &webrtc.SessionDescription{Type: webrtc.SDPTypeAnswer,
+		SDP: EXAMPLE_EMPTY_SDP + "a=" + hostCandidate + "\r\na=" + relayCandidate + "\r\n"}
+	before := testutil.ToFloat64(candidatesFiltered.WithLabelValues("outbound"))
+	if err := FilterCandidates(desc, &pb.ICEPolicy{NoHost: true}, "outbound"); err != nil {
+		t.Fatalf("error filtering: %s", err)
+	}
+	if strings.Contains(desc.SDP, "typ host") || !strings.Contains(desc.SDP, "typ relay") {
+		t.Errorf("got %s want only the relay candidate", desc.SDP)
+	}
+	if filtered := testutil.ToFloat64(candidatesFiltered.WithLabelValues("outbound")) - before; filtered != 1 {
+		t.Errorf("got %v filtered candidates counted want 1", filtered)
+	}
+}
+
+func TestRoomICEPolicy(t *testing.T) {
+	mgr, _ := NewTestSetup()
+	mgr.SetICEPolicy(ICEPolicyConfig{NoHost: true}.Policy())
+	mgr.SetRoomData(&pb.RoomData{Id: "ice-default"})
+	mgr.SetRoomData(&pb.RoomData{Id: "ice-relay", Options: &pb.RoomOptions{IcePolicy: &pb.ICEPolicy{RelayOnly: true}}})
+
+	if policy := mgr.RoomICEPolicy("ice-default"); !policy.GetNoHost() || policy.GetRelayOnly() {
+		t.Errorf("got %v want the manager's policy", policy)
+	}
+	if policy := mgr.RoomICEPolicy("ice-relay"); !policy.GetRelayOnly() || policy.GetNoHost() {
+		t.Errorf("got %v want the room's own policy", policy)
+	}
+	if (ICEPolicyConfig{}).Policy() != nil {
+		t.Errorf("an empty config should allow every candidate")
+	}
+}
@@ -0,0 +1,39 @@
+package noir
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTURNRESTProvider(t *testing.T) {
+	provider, err := NewTURNRESTProvider([]string{"stun:stun.example.org"}, []string{"turn:turn.example.org"}, "secret", time.Hour)
+	if err != nil {
+		t.Fatalf("error creating provider %s", err)
+	}
+
+	servers, err := provider.ICEServers("peer-1")
+	if err != nil || len(servers) != 2 {
+		t.Fatalf("got %d servers (%v) want 2", len(servers), err)
+	}
+	turn := servers[1]
+
+	if !strings.HasSuffix(turn.Username, ":peer-1") {
+		t.Errorf("got username %s want <expiry>:peer-1", turn.Username)
+	}
+	mac := hmac.New(sha1.New, []byte("secret"))
+	mac.Write([]byte(turn.Username))
+	if want := base64.StdEncoding.EncodeToString(mac.Sum(nil)); turn.Credential != want {
+		t.Errorf("got credential %s want %s", turn.Credential, want)
+	}
+	if ttl := time.Until(turn.Expires.AsTime()); ttl <= 59*time.Minute || ttl > time.Hour {
+		t.Errorf("got expiry in %s want 1h", ttl)
+	}
+
+	if _, err := NewTURNRESTProvider(nil, []string{"turn:turn.example.org"}, "", time.Hour); err == nil {
+		t.Errorf("turn without a secret should fail")
+	}
+}
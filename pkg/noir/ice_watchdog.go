@@ -0,0 +1,83 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/webrtc/v3"
+	"sync"
+	"time"
+)
+
+/*
+ICE Watchdog:
+A peer whose ICE failed or disconnected still holds its room slot, and the rest of the room
+still sees it. Each joined peer gets an iceWatchdog on its ICE state: failed or disconnected
+starts a timer of ICEGrace(), getting connected again stops it, and a peer that does not recover
+in time is disconnected, which tells the room it LEFT and frees its slot. Disconnected is often
+a blip on a flaky network, so the grace should be longer than a few missed ICE checks.
+*/
+
+// Default for Manager.ICEGrace()
+const ICEGrace = 15 * time.Second
+
+// SetICEGrace is how long a peer whose ICE failed or disconnected has to reconnect, 0 keeps the
+// default and a negative grace leaves those peers up
+func (m *Manager) SetICEGrace(grace time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.iceGrace = grace
+}
+
+func (m *Manager) ICEGrace() time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.iceGrace == 0 {
+		return ICEGrace
+	}
+	return m.iceGrace
+}
+
+// iceWatchdog calls expire once ICE has been failed or disconnected for grace
+type iceWatchdog struct {
+	mu     sync.Mutex
+	grace  time.Duration
+	timer  *time.Timer
+	expire func()
+}
+
+func newICEWatchdog(grace time.Duration, expire func()) *iceWatchdog {
+	return &iceWatchdog{grace: grace, expire: expire}
+}
+
+// observe starts or stops the timer for the peer's new ICE state
+func (d *iceWatchdog) observe(state webrtc.ICEConnectionState) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	switch state {
+	case webrtc.ICEConnectionStateFailed, webrtc.ICEConnectionStateDisconnected:
+		if d.timer == nil && d.grace >= 0 {
+			d.timer = time.AfterFunc(d.grace, d.expire)
+		}
+	case webrtc.ICEConnectionStateConnected, webrtc.ICEConnectionStateCompleted, webrtc.ICEConnectionStateClosed:
+		d.stop()
+	}
+}
+
+// stop cancels a running timer, d.mu has to be held
+func (d *iceWatchdog) stop() {
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+}
+
+// disconnectLost disconnects pid if it still is the peer whose ICE was lost, and not a rejoin
+func (m *Manager) disconnectLost(pid string, client PeerAdapter) {
+	m.mu.RLock()
+	current := m.users[pid]
+	m.mu.RUnlock()
+	if current != client {
+		return
+	}
+	m.Logger().With(LogFields{"pid": pid}).Infof("%s did not reconnect within %s, disconnecting", pid, m.ICEGrace())
+	m.CloseClient(pid, pb.CloseReason_ICE_FAILED)
+}
@@ -0,0 +1,60 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/webrtc/v3"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestICEWatchdog(t *testing.T) {
+	var expired int32
+	watchdog := newICEWatchdog(50*time.Millisecond, func() { atomic.AddInt32(&expired, 1) })
+
+	watchdog.observe(webrtc.ICEConnectionStateDisconnected)
+	watchdog.observe(webrtc.ICEConnectionStateConnected)
+	time.Sleep(100 * time.Millisecond)
+	if atomic.LoadInt32(&expired) != 0 {
+		t.Errorf("a peer that reconnected should not be disconnected")
+	}
+
+	watchdog.observe(webrtc.ICEConnectionStateDisconnected)
+	watchdog.observe(webrtc.ICEConnectionStateFailed)
+	time.Sleep(100 * time.Millisecond)
+	if got := atomic.LoadInt32(&expired); got != 1 {
+		t.Errorf("got %d expiries want 1 for a peer that never came back", got)
+	}
+
+	never := newICEWatchdog(-1, func() { t.Errorf("a negative grace should never expire") })
+	never.observe(webrtc.ICEConnectionStateFailed)
+}
+
+func TestDisconnectLost(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SaveData(pb.KeyUserData("lost-peer"), &pb.NoirObject{
+		Data: &pb.NoirObject_User{User: &pb.UserData{Id: "lost-peer", RoomID: "lost-room", Options: &pb.UserOptions{}}},
+	}, 0)
+	redis.HSet(pb.KeyRoomUsers("lost-room"), "lost-peer", 1)
+	defer redis.Del(pb.KeyUserData("lost-peer"), pb.KeyRoomUsers("lost-room"), pb.KeyTopicToPeer("lost-peer"))
+	lost, rejoined := NewSFUPeer(*mgr.sfu), NewSFUPeer(*mgr.sfu)
+	mgr.mu.Lock()
+	mgr.users["lost-peer"] = rejoined
+	mgr.mu.Unlock()
+
+	// the pid rejoined since, the new peer stays
+	mgr.disconnectLost("lost-peer", lost)
+	if !redis.HExists(pb.KeyRoomUsers("lost-room"), "lost-peer").Val() {
+		t.Errorf("a rejoined peer should keep its slot")
+	}
+
+	mgr.disconnectLost("lost-peer", rejoined)
+	if redis.HExists(pb.KeyRoomUsers("lost-room"), "lost-peer").Val() {
+		t.Errorf("a lost peer should give up its slot")
+	}
+	mgr.mu.RLock()
+	defer mgr.mu.RUnlock()
+	if mgr.users["lost-peer"] != nil {
+		t.Errorf("a lost peer should be disconnected")
+	}
+}
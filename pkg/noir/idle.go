@@ -0,0 +1,97 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"sync/atomic"
+	"time"
+)
+
+/*
+Idle Peers:
+A peer that joins and then does nothing still holds a slot of its room. With an idle timeout set,
+on the manager or by a room's idleTimeoutSeconds, a peer that neither publishes media nor signals
+for that long after joining is closed for being IDLE, and the room gets its LEFT event like for any
+other peer leaving. Trickled candidates keep the connection up but are not activity, so a peer
+that only trickles and never publishes is idle all the same, as is one that only watches.
+*/
+
+// how many times per timeout a peer is checked for activity
+const idleChecks = 4
+
+// SetIdleTimeout is how long peers may go without publishing or signaling before they are closed,
+// 0, the default, and negative timeouts never close idle peers
+func (m *Manager) SetIdleTimeout(timeout time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.idleTimeout = timeout
+}
+
+func (m *Manager) IdleTimeout() time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.idleTimeout
+}
+
+// RoomIdleTimeout is the room's idle timeout if it sets one, else the manager's
+func (m *Manager) RoomIdleTimeout(roomID string) time.Duration {
+	if roomData, err := m.GetRoomData(roomID); err == nil {
+		if seconds := roomData.GetOptions().GetIdleTimeoutSeconds(); seconds != 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return m.IdleTimeout()
+}
+
+// idleWatch is when a peer was last active
+type idleWatch struct {
+	last int64
+}
+
+func newIdleWatch() *idleWatch {
+	return &idleWatch{last: time.Now().UnixNano()}
+}
+
+func (i *idleWatch) touch() {
+	atomic.StoreInt64(&i.last, time.Now().UnixNano())
+}
+
+// idle is how long since the peer was last active
+func (i *idleWatch) idle() time.Duration {
+	return time.Since(time.Unix(0, atomic.LoadInt64(&i.last)))
+}
+
+// activeSignal is false for the signals a peer sends just to stay connected, or poll its stats,
+// and for the room's pause, which is not the peer's doing
+func activeSignal(signal *pb.SignalRequest) bool {
+	switch signal.Payload.(type) {
+	case *pb.SignalRequest_Trickle, *pb.SignalRequest_TrickleBatch, *pb.SignalRequest_GetStats, *pb.SignalRequest_Pause,
+		*pb.SignalRequest_Pong:
+		return false
+	}
+	return true
+}
+
+// closeIdle closes pid once it has not been active for timeout, counting the rtp it publishes
+// as activity, until stop is closed
+func (w *worker) closeIdle(pid string, peer *sfu.Peer, timeout time.Duration, activity *idleWatch, stop <-chan struct{}, logger Logger) {
+	ticker := time.NewTicker(timeout / idleChecks)
+	defer ticker.Stop()
+	var published uint64
+	for {
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+		if received, _ := receivedPackets(publisherOf(peer)); received != published {
+			published = received
+			activity.touch()
+		}
+		if idle := activity.idle(); idle >= timeout {
+			logger.Infof("closing %s, idle for %s", pid, idle.Round(time.Millisecond))
+			w.manager.CloseClient(pid, pb.CloseReason_IDLE)
+			return
+		}
+	}
+}
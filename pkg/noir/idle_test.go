@@ -0,0 +1,75 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"google.golang.org/protobuf/proto"
+	"testing"
+	"time"
+)
+
+func TestRoomIdleTimeout(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetIdleTimeout(time.Minute)
+	defer mgr.SetIdleTimeout(0)
+	mgr.SetRoomData(&pb.RoomData{Id: "idle-never", Options: &pb.RoomOptions{MaxAgeSeconds: -1, IdleTimeoutSeconds: -1}})
+	mgr.SetRoomData(&pb.RoomData{Id: "idle-quick", Options: &pb.RoomOptions{MaxAgeSeconds: -1, IdleTimeoutSeconds: 2}})
+	defer redis.Del(pb.KeyRoomData("idle-never"), pb.KeyRoomData("idle-quick"))
+
+	for roomID, want := range map[string]time.Duration{"idle-never": -time.Second, "idle-quick": 2 * time.Second, "idle-missing": time.Minute} {
+		if got := mgr.RoomIdleTimeout(roomID); got != want {
+			t.Errorf("%s got %s want %s", roomID, got, want)
+		}
+	}
+}
+
+func TestCloseIdle(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	mgr.SetIdleTimeout(200 * time.Millisecond)
+	defer mgr.SetIdleTimeout(0)
+	redis.Del(pb.KeyRoomData("idle-room"), pb.KeyRoomUsers("idle-room"), pb.KeyTopicFromPeer("idle-peer"), pb.KeyTopicToPeer("idle-peer"))
+	defer redis.Del(pb.KeyRoomData("idle-room"), pb.KeyRoomUsers("idle-room"), pb.KeyTopicFromPeer("idle-peer"), pb.KeyTopicToPeer("idle-peer"))
+	if activeSignal(&pb.SignalRequest{Payload: &pb.SignalRequest_Trickle{Trickle: &pb.Trickle{}}}) {
+		t.Errorf("a trickle should not keep a peer from being idle")
+	}
+
+	events := mgr.SubscribeRoomEvents("idle-room")
+	defer events.Close()
+	if _, err := events.Receive(); err != nil {
+		t.Fatalf("error subscribing: %s", err)
+	}
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id: "idle-peer",
+				Payload: &pb.SignalRequest_Join{
+					Join: &pb.JoinRequest{Sid: "idle-room", Description: []byte(EXAMPLE_EMPTY_SDP)},
+				},
+			},
+		},
+	})
+	if err := worker.HandleNext(0); err != nil {
+		t.Fatalf("error joining: %s", err)
+	}
+	defer mgr.DisconnectUser("idle-peer")
+	// as if it connected, only a peer announced JOINED is announced LEFT
+	redis.Set(pb.KeyUserJoined("idle-peer"), 1, 0)
+
+	// past the OPENED of the room the join created
+	reply := pb.NoirReply{}
+	for reply.GetEvent().GetType() != pb.RoomEvent_LEFT {
+		select {
+		case message := <-events.Channel():
+			proto.Unmarshal([]byte(message.Payload), &reply)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for the idle peer to leave")
+		}
+	}
+	if event := reply.GetEvent(); event.GetType() != pb.RoomEvent_LEFT || event.GetReason() != pb.CloseReason_IDLE {
+		t.Errorf("got %s want idle-peer LEFT for being idle", &reply)
+	}
+	if redis.HExists(pb.KeyRoomUsers("idle-room"), "idle-peer").Val() {
+		t.Errorf("an idle peer should give up its slot")
+	}
+}
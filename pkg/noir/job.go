@@ -2,12 +2,12 @@ package noir
 
 import (
 	"encoding/json"
-	"github.com/golang/protobuf/proto"
 	pb "github.com/net-prophet/noir/pkg/proto"
 	log "github.com/pion/ion-log"
 	"github.com/pion/webrtc/v3"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"io"
+	"sync"
 )
 
 type Job struct {
@@ -22,6 +22,18 @@ type PeerJob struct {
 	peerJobData *pb.PeerJobData
 	pc          *webrtc.PeerConnection
 	mediaEngine *webrtc.MediaEngine
+	// ion-sfu sends media down a second pc, only jobs that subscribe need it
+	sub            *webrtc.PeerConnection
+	subMediaEngine *webrtc.MediaEngine
+	// closed by Kill, a pointer so jobs can embed PeerJob by value
+	killed *jobKilled
+	// handed the TrackInfos sent ahead of the sfu's offers, see OnTrackInfos
+	onTrackInfos func(*pb.TrackInfos)
+}
+
+type jobKilled struct {
+	once sync.Once
+	done chan struct{}
 }
 
 type RunnableJob interface {
@@ -46,8 +58,10 @@ func NewBaseJob(manager *Manager, handler string, jobID string) *Job {
 func NewPeerJob(manager *Manager, handler string, roomID string, jobID string) *PeerJob {
 	userID := "job-" + handler + "-" + jobID
 	return &PeerJob{
-		Job:         *NewBaseJob(manager, handler, jobID),
-		mediaEngine: &webrtc.MediaEngine{},
+		Job:            *NewBaseJob(manager, handler, jobID),
+		mediaEngine:    &webrtc.MediaEngine{},
+		subMediaEngine: &webrtc.MediaEngine{},
+		killed:         &jobKilled{done: make(chan struct{})},
 		peerJobData: &pb.PeerJobData{
 			RoomID:          roomID,
 			UserID:          userID,
@@ -58,14 +72,14 @@ func NewPeerJob(manager *Manager, handler string, roomID string, jobID string) *
 }
 
 func (j *Job) GetCommandQueue() Queue {
-	return j.manager.GetQueue(pb.KeyTopicFromJob(j.id))
+	return j.manager.GetQueue(j.manager.Keys().TopicFromJob(j.id))
 }
 
 func (j *PeerJob) GetQueueFromPeer() Queue {
-	return j.manager.GetQueue(pb.KeyTopicFromPeer(j.peerJobData.GetUserID()))
+	return j.manager.GetQueue(j.manager.Keys().TopicFromPeer(j.peerJobData.GetUserID()))
 }
 func (j *PeerJob) GetQueueToPeer() Queue {
-	return j.manager.GetQueue(pb.KeyTopicToPeer(j.peerJobData.GetUserID()))
+	return j.manager.GetQueue(j.manager.Keys().TopicToPeer(j.peerJobData.GetUserID()))
 }
 
 func (j *Job) GetManager() *Manager {
@@ -81,10 +95,14 @@ func (j *Job) Kill(code int) {
 }
 func (j *PeerJob) Kill(code int) {
 	log.Infof("exited %s handler=%s jobid=%s userid=%s", code, j.jobData.GetHandler(), j.id, j.peerJobData.UserID)
-	j.manager.DisconnectUser(j.peerJobData.UserID)
+	j.killed.once.Do(func() { close(j.killed.done) })
+	j.manager.CloseClient(j.peerJobData.UserID, pb.CloseReason_JOB_ENDED)
 	if j.pc != nil {
 		j.pc.Close()
 	}
+	if j.sub != nil {
+		j.sub.Close()
+	}
 }
 
 func (j *Job) KillWithError(err error) {
@@ -97,6 +115,22 @@ func (j *PeerJob) KillWithError(err error) {
 	j.Kill(1)
 }
 
+// Done is closed once the job is killed, long running jobs should watch it
+func (j *PeerJob) Done() <-chan struct{} {
+	return j.killed.done
+}
+
+// SetUserID picks the pid the job joins as, call it before SendJoin
+func (j *PeerJob) SetUserID(pid string) {
+	j.peerJobData.UserID = pid
+}
+
+// OnTrackInfos hands handler the TrackInfos of the tracks the job subscribes to, which say whose
+// they are. They come ahead of the offer that adds the tracks, call it before SendJoin
+func (j *PeerJob) OnTrackInfos(handler func(*pb.TrackInfos)) {
+	j.onTrackInfos = handler
+}
+
 func (j *PeerJob) GetPeerData() *pb.PeerJobData {
 	return j.peerJobData
 }
@@ -154,6 +188,73 @@ func (j *PeerJob) GetPeerConnection() (*webrtc.PeerConnection, error) {
 	return j.pc, nil
 }
 
+// GetSubscriberConnection is the pc that answers the sfu's offers, OnTrack fires on it for every
+// track in the room including ones published after we joined. Call it before SendJoin
+func (j *PeerJob) GetSubscriberConnection() (*webrtc.PeerConnection, error) {
+	if j.sub == nil {
+		if err := j.subMediaEngine.RegisterDefaultCodecs(); err != nil {
+			return nil, err
+		}
+		api := webrtc.NewAPI(webrtc.WithMediaEngine(j.subMediaEngine))
+		sub, err := api.NewPeerConnection(webrtc.Configuration{
+			ICEServers: []webrtc.ICEServer{
+				{
+					URLs: []string{"stun:stun.l.google.com:19302"},
+				},
+			},
+		})
+		if err != nil {
+			log.Errorf("error getting subscriber pc %s", err)
+			return nil, err
+		}
+		j.sub = sub
+
+		sub.OnICECandidate(func(c *webrtc.ICECandidate) {
+			if c == nil {
+				return
+			}
+			bytes, err := json.Marshal(c.ToJSON())
+			if err != nil {
+				log.Errorf("OnIceCandidate error %s", err)
+				return
+			}
+			err = j.SendSignalRequest(&pb.SignalRequest{
+				Payload: &pb.SignalRequest_Trickle{
+					Trickle: &pb.Trickle{
+						Init:   string(bytes),
+						Target: pb.Trickle_SUBSCRIBER,
+					},
+				},
+			})
+			if err != nil {
+				log.Errorf("OnIceCandidate error %s", err)
+			}
+		})
+	}
+	return j.sub, nil
+}
+
+// answerSubscriber accepts an offer from the sfu on the subscriber pc
+func (j *PeerJob) answerSubscriber(offer webrtc.SessionDescription) error {
+	if err := j.sub.SetRemoteDescription(offer); err != nil {
+		return err
+	}
+	answer, err := j.sub.CreateAnswer(nil)
+	if err != nil {
+		return err
+	}
+	if err := j.sub.SetLocalDescription(answer); err != nil {
+		return err
+	}
+	bytes, err := json.Marshal(Negotiation{Desc: answer})
+	if err != nil {
+		return err
+	}
+	return j.SendSignalRequest(&pb.SignalRequest{
+		Payload: &pb.SignalRequest_Description{Description: bytes},
+	})
+}
+
 func (j *PeerJob) SendJoin() error {
 	router := j.GetManager().GetRouter()
 	queue := (*router).GetQueue()
@@ -165,7 +266,9 @@ func (j *PeerJob) SendJoin() error {
 	}
 
 	log.Infof("joining room=%s as=%s", roomID, userID)
+	// the node's own join, HandleJoin trusts it without a token, see trustedJoin
 	return EnqueueRequest(*queue, &pb.NoirRequest{
+		AdminID: j.GetManager().ID(),
 		Command: &pb.NoirRequest_Signal{
 			Signal: &pb.SignalRequest{
 				Id: userID,
@@ -173,6 +276,7 @@ func (j *PeerJob) SendJoin() error {
 					Join: &pb.JoinRequest{
 						Sid:         roomID,
 						Description: []byte(pc.LocalDescription().SDP),
+						Compression: SupportedCompressions,
 					},
 				},
 			},
@@ -194,7 +298,7 @@ func (j *PeerJob) WaitForReply() (*pb.NoirReply, error) {
 
 	var reply pb.NoirReply
 
-	err = proto.Unmarshal(message, &reply)
+	err = UnmarshalReply(message, &reply)
 	if err != nil {
 		j.KillWithError(err)
 		return nil, err
@@ -235,12 +339,13 @@ func (j *PeerJob) PeerBridge() {
 
 			if trickle := signal.Signal.GetTrickle(); trickle != nil {
 				//log.Debugf("job trickle %s", trickle)
-				var candidate webrtc.ICECandidateInit
-				_ = json.Unmarshal([]byte(trickle.Init), &candidate)
-				err := j.pc.AddICECandidate(candidate)
-				if err != nil {
-					log.Errorf("error adding ice candidate: %e", err)
-				}
+				j.addCandidate(trickle)
+			}
+			for _, trickle := range signal.Signal.GetTrickleBatch().GetCandidates() {
+				j.addCandidate(trickle)
+			}
+			if infos := signal.Signal.GetTracks(); infos != nil && j.onTrackInfos != nil {
+				j.onTrackInfos(infos)
 			}
 
 			if data := signal.Signal.GetDescription(); data != nil {
@@ -251,6 +356,11 @@ func (j *PeerJob) PeerBridge() {
 					log.Errorf("Unmarshal negotiate error %s", err)
 					continue
 				}
+				if desc.Type == webrtc.SDPTypeOffer && j.sub != nil {
+					if err := j.answerSubscriber(desc); err != nil {
+						log.Errorf("error answering subscriber offer %s", err)
+					}
+				}
 			}
 			if signal.Signal.GetKill() {
 				log.Debugf("signal killed job=%s", signal.Signal.Id)
@@ -261,3 +371,16 @@ func (j *PeerJob) PeerBridge() {
 	}
 
 }
+
+func (j *PeerJob) addCandidate(trickle *pb.Trickle) {
+	var candidate webrtc.ICECandidateInit
+	_ = json.Unmarshal([]byte(trickle.Init), &candidate)
+	pc := j.pc
+	if trickle.Target == pb.Trickle_SUBSCRIBER && j.sub != nil {
+		pc = j.sub
+	}
+	err := pc.AddICECandidate(candidate)
+	if err != nil {
+		log.Errorf("error adding ice candidate: %e", err)
+	}
+}
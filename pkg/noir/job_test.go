@@ -0,0 +1,116 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"testing"
+)
+
+// sendJobJoin joins a job to sid the way the jobs do, through the router, and returns its reply
+func sendJobJoin(t *testing.T, mgr *Manager, job *PeerJob) *pb.SignalReply {
+	fake := newFakePeer()
+	mgr.SetPeerFactory(func(sfu.SessionProvider) PeerAdapter { return fake })
+	defer mgr.SetPeerFactory(nil)
+
+	pc, err := job.GetPeerConnection()
+	if err != nil {
+		t.Fatalf("error getting the job's pc: %s", err)
+	}
+	if _, err := pc.CreateDataChannel("job", nil); err != nil {
+		t.Fatalf("error adding a data channel: %s", err)
+	}
+	offer, err := pc.CreateOffer(nil)
+	if err != nil {
+		t.Fatalf("error creating an offer: %s", err)
+	}
+	if err := pc.SetLocalDescription(offer); err != nil {
+		t.Fatalf("error setting the offer: %s", err)
+	}
+	if err := job.SendJoin(); err != nil {
+		t.Fatalf("error sending the join: %s", err)
+	}
+	routeOnce(mgr)
+	return nextSignalReply(t, mgr, job.GetPeerData().GetUserID(), func(reply *pb.SignalReply) bool {
+		return reply.GetJoin() != nil || reply.GetFailure() != nil
+	})
+}
+
+func TestJobJoinWithAuthenticator(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	authenticator, _ := NewJWTAuthenticator("secret")
+	mgr.SetAuthenticator(authenticator)
+	defer mgr.SetAuthenticator(nil)
+	job := NewPeerJob(mgr, "test", "job-auth", "1")
+	pid := job.GetPeerData().GetUserID()
+	keys := emptyRoomKeys("job-auth", pid, "job-auth-client")
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	reply := sendJobJoin(t, mgr, job)
+	defer job.Kill(0)
+	if reply.GetJoin() == nil {
+		t.Fatalf("got %s want the job joined without a token", reply.GetFailure())
+	}
+
+	// the node's jobs are trusted, clients still need a token
+	fake := newFakePeer()
+	mgr.SetPeerFactory(func(sfu.SessionProvider) PeerAdapter { return fake })
+	defer mgr.SetPeerFactory(nil)
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id: "job-auth-client",
+				Payload: &pb.SignalRequest_Join{
+					Join: &pb.JoinRequest{Sid: "job-auth", Description: []byte(EXAMPLE_EMPTY_SDP)},
+				},
+			},
+		},
+	})
+	worker.HandleNext(0)
+	refused := nextSignalReply(t, mgr, "job-auth-client", func(reply *pb.SignalReply) bool { return reply.GetFailure() != nil })
+	if code := refused.GetFailure().GetCode(); code != pb.SignalError_UNAUTHORIZED {
+		t.Errorf("got %s want a client join without a token UNAUTHORIZED", code)
+	}
+}
+
+func TestJobJoinPasswordRoom(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	job := NewPeerJob(mgr, "test", "job-locked", "1")
+	keys := emptyRoomKeys("job-locked", job.GetPeerData().GetUserID())
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+	mgr.SetRoomData(&pb.RoomData{Id: "job-locked", NodeID: mgr.ID(), Options: &pb.RoomOptions{JoinPassword: "secret"}})
+
+	reply := sendJobJoin(t, mgr, job)
+	defer job.Kill(0)
+	if reply.GetJoin() == nil {
+		t.Errorf("got %s want the job joined without the room's password", reply.GetFailure())
+	}
+}
+
+func TestJobJoinAssignedPeerIDs(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	mgr.SetPeerIDMode(AssignedPeerIDs)
+	defer mgr.SetPeerIDMode(ClientPeerIDs)
+	job := NewPeerJob(mgr, "test", "job-assigned", "1")
+	pid := job.GetPeerData().GetUserID()
+	keys := emptyRoomKeys("job-assigned", pid)
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	// the job's join is answered on its own topic, as the pid it joined as
+	reply := sendJobJoin(t, mgr, job)
+	defer job.Kill(0)
+	if reply.GetJoin() == nil {
+		t.Fatalf("got %s want the job joined", reply.GetFailure())
+	}
+	if assigned := reply.GetJoin().GetPid(); assigned != "" && assigned != pid {
+		t.Errorf("got pid %s want the job to keep %s", assigned, pid)
+	}
+	if user, err := mgr.GetRemoteUserData(pid); err != nil || user.GetRoomID() != "job-assigned" {
+		t.Errorf("got %v %v want %s in the room", user, err, pid)
+	}
+}
@@ -0,0 +1,211 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/net-prophet/noir/pkg/noir"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	log "github.com/pion/ion-log"
+	"github.com/pion/webrtc/v3"
+	"github.com/pion/webrtc/v3/pkg/media"
+	"sync"
+	"time"
+)
+
+// AudioMixJob subscribes to every audio track of a room and publishes their mix, see audio_mix.go
+type AudioMixJob struct {
+	noir.PeerJob
+	options *noir.AudioMixOptions
+	codec   noir.AudioCodec
+	mixer   *noir.AudioMixer
+	// the publishers of the tracks subscribed to, by track id, from the TrackInfos
+	mu         sync.Mutex
+	publishers map[string]string
+}
+
+const LabelAudioMix = noir.AudioMixJobHandler
+
+func NewAudioMixJob(manager *noir.Manager, roomID string, options *noir.AudioMixOptions) *AudioMixJob {
+	return &AudioMixJob{
+		PeerJob:    *noir.NewPeerJob(manager, LabelAudioMix, roomID, options.MixID),
+		options:    options,
+		codec:      manager.AudioMixCodec(),
+		mixer:      noir.NewAudioMixer(options.Exclude),
+		publishers: map[string]string{},
+	}
+}
+
+func NewAudioMixHandler(manager *noir.Manager) noir.JobHandler {
+	return func(request *pb.NoirRequest) noir.RunnableJob {
+		admin := request.GetAdmin()
+		roomAdmin := admin.GetRoomAdmin()
+		options := &noir.AudioMixOptions{}
+		packed := roomAdmin.GetRoomJob().GetOptions()
+		if len(packed) > 0 {
+			err := json.Unmarshal(packed, options)
+			if err != nil {
+				log.Errorf("error unmarshalling job options")
+				return nil
+			}
+		}
+		if options.MixID == "" {
+			options.MixID = noir.RandomString(16)
+		}
+		return NewAudioMixJob(manager, roomAdmin.GetRoomID(), options)
+	}
+}
+
+func (j *AudioMixJob) Handle() {
+	if j.codec == nil {
+		j.KillWithError(noir.ErrNoAudioCodec)
+		return
+	}
+	encoder, err := j.codec.NewEncoder()
+	if err != nil {
+		j.KillWithError(err)
+		return
+	}
+	if err := j.GetMediaEngine().RegisterDefaultCodecs(); err != nil {
+		j.KillWithError(err)
+		return
+	}
+
+	peerConnection, err := j.GetPeerConnection()
+	if err != nil {
+		j.KillWithError(err)
+		return
+	}
+	track, err := webrtc.NewTrackLocalStaticSample(
+		webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeOpus},
+		"audio-mix",
+		j.GetPeerData().UserID,
+	)
+	if err != nil {
+		j.KillWithError(err)
+		return
+	}
+	if _, err := peerConnection.AddTrack(track); err != nil {
+		j.KillWithError(err)
+		return
+	}
+
+	subscriber, err := j.GetSubscriberConnection()
+	if err != nil {
+		j.KillWithError(err)
+		return
+	}
+	j.OnTrackInfos(func(infos *pb.TrackInfos) {
+		j.mu.Lock()
+		defer j.mu.Unlock()
+		for _, info := range infos.GetTracks() {
+			j.publishers[info.GetTrackID()] = info.GetPid()
+		}
+	})
+	subscriber.OnTrack(func(remote *webrtc.TrackRemote, receiver *webrtc.RTPReceiver) {
+		if remote.Kind() == webrtc.RTPCodecTypeAudio {
+			j.decode(remote)
+		}
+	})
+
+	connected, connectedCancel := context.WithCancel(context.Background())
+	peerConnection.OnICEConnectionStateChange(func(state webrtc.ICEConnectionState) {
+		if state == webrtc.ICEConnectionStateConnected {
+			connectedCancel()
+		}
+	})
+	go func() {
+		defer j.Kill(0)
+		select {
+		case <-connected.Done():
+		case <-j.Done():
+			return
+		}
+		if err := j.mix(track, encoder); err != nil {
+			j.KillWithError(err)
+		}
+	}()
+
+	gatherComplete := webrtc.GatheringCompletePromise(peerConnection)
+	offer, err := peerConnection.CreateOffer(nil)
+	if err != nil {
+		j.KillWithError(err)
+		return
+	}
+	if err = peerConnection.SetLocalDescription(offer); err != nil {
+		j.KillWithError(err)
+		return
+	}
+	<-gatherComplete
+
+	log.Infof("mixing the audio of room %s as %s", j.GetPeerData().RoomID, j.GetPeerData().UserID)
+	if err = j.SendJoin(); err != nil {
+		j.KillWithError(err)
+		return
+	}
+
+	go j.PeerBridge()
+}
+
+// decode feeds the mixer the track until it ends
+func (j *AudioMixJob) decode(remote *webrtc.TrackRemote) {
+	pid := j.publisher(remote.ID())
+	// the mixer leaves them out as well, they are not worth decoding
+	if pid != "" && j.mixer.Excludes(pid) {
+		return
+	}
+	decoder, err := j.codec.NewDecoder()
+	if err != nil {
+		log.Errorf("error mixing track %s: %s", remote.ID(), err)
+		return
+	}
+	log.Infof("mixing track %s of %s", remote.ID(), pid)
+	defer j.mixer.Remove(remote.ID())
+	for {
+		packet, err := remote.ReadRTP()
+		if err != nil {
+			// The track ended or we were killed
+			return
+		}
+		if pid == "" {
+			// the TrackInfos naming the publisher can come after the track
+			if pid = j.publisher(remote.ID()); pid != "" && j.mixer.Excludes(pid) {
+				return
+			}
+		}
+		if j.mixer.Excludes(pid) {
+			continue
+		}
+		pcm, err := decoder.Decode(packet.Payload)
+		if err != nil {
+			log.Debugf("error decoding track %s: %s", remote.ID(), err)
+			continue
+		}
+		j.mixer.Write(remote.ID(), pid, pcm)
+	}
+}
+
+func (j *AudioMixJob) publisher(trackID string) string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.publishers[trackID]
+}
+
+// mix publishes a frame of the mix every AudioMixFrame until the job is killed
+func (j *AudioMixJob) mix(track *webrtc.TrackLocalStaticSample, encoder noir.AudioEncoder) error {
+	ticker := time.NewTicker(noir.AudioMixFrame)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-j.Done():
+			return nil
+		case <-ticker.C:
+			payload, err := encoder.Encode(j.mixer.Mix())
+			if err != nil {
+				return err
+			}
+			if err := track.WriteSample(media.Sample{Data: payload, Duration: noir.AudioMixFrame}); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -11,22 +11,20 @@ import (
 	"github.com/pion/webrtc/v3"
 	"github.com/pion/webrtc/v3/pkg/media"
 	"github.com/pion/webrtc/v3/pkg/media/ivfreader"
+	"github.com/pion/webrtc/v3/pkg/media/oggreader"
 	"io"
 	"os"
 	"time"
 )
 
-type PlayFileOptions struct {
-	Filename string `json:"filename"`
-	Repeat   int    `json:"repeat"`
-}
+type PlayFileOptions = noir.PlayFileOptions
 
 type PlayFileJob struct {
 	noir.PeerJob
 	options *PlayFileOptions
 }
 
-const LabelPlayFile = "PlayFile"
+const LabelPlayFile = noir.PlayFileJobHandler
 
 func NewPlayFileJob(manager *noir.Manager, roomID string, filename string, repeat int) *PlayFileJob {
 	return &PlayFileJob{
@@ -51,20 +49,44 @@ func NewPlayFileHandler(manager *noir.Manager) noir.JobHandler {
 			options.Filename = "test.video"
 			options.Repeat = 0
 		}
+		// a moderator's job plays files under the playback dir, the admin api's and HandlePlay's any
+		if request.GetAdminID() == "" {
+			filename, err := manager.PlaybackPath(options.Filename)
+			if err != nil {
+				log.Errorf("refused play job: %s", err)
+				return nil
+			}
+			options.Filename = filename
+		}
 
-		return NewPlayFileJob(manager, roomAdmin.GetRoomID(), options.Filename, options.Repeat)
+		job := NewPlayFileJob(manager, roomAdmin.GetRoomID(), options.Filename, options.Repeat)
+		job.SetSegment(options.StartMs, options.EndMs)
+		if pid := roomAdmin.GetRoomJob().GetPid(); pid != "" {
+			job.SetUserID(pid)
+		}
+		return job
 	}
 }
 
+// SetSegment plays, and repeats, only the part of the file from startMs to endMs, 0 is its end
+func (j *PlayFileJob) SetSegment(startMs int64, endMs int64) {
+	j.options.StartMs = startMs
+	j.options.EndMs = endMs
+}
+
 func (j *PlayFileJob) Handle() {
-	// Assert that we have an audio or video file
+	// Assert that we have an audio or video file we know how to send
 	filename := j.options.Filename
-	_, err := os.Stat(filename)
+	mimeType, err := noir.ProbeMediaFile(filename)
 
 	if err != nil {
 		j.KillWithError(err)
 		return
 	}
+	if err := noir.CheckPlayRange(filename, *j.options); err != nil {
+		j.KillWithError(err)
+		return
+	}
 
 	err = j.GetMediaEngine().RegisterDefaultCodecs()
 	if err != nil {
@@ -78,81 +100,48 @@ func (j *PlayFileJob) Handle() {
 		j.KillWithError(err)
 		return
 	}
-	iceConnectedCtx, iceConnectedCtxCancel := context.WithCancel(context.Background())
-
-	videoTrack, err := webrtc.NewTrackLocalStaticSample(
-		webrtc.RTPCodecCapability{MimeType: "video/vp8"},
-		fmt.Sprintf("video-%d", randutil.NewMathRandomGenerator().Uint32()),
-		fmt.Sprintf("video-%d", randutil.NewMathRandomGenerator().Uint32()),
+	kind := "video"
+	if mimeType == webrtc.MimeTypeOpus {
+		kind = "audio"
+	}
+	track, err := webrtc.NewTrackLocalStaticSample(
+		webrtc.RTPCodecCapability{MimeType: mimeType},
+		fmt.Sprintf("%s-%d", kind, randutil.NewMathRandomGenerator().Uint32()),
+		j.GetPeerData().UserID,
 	)
+	if err != nil {
+		j.KillWithError(err)
+		return
+	}
 
-	// Create a video track
-	_, err = peerConnection.AddTrack(videoTrack)
+	_, err = peerConnection.AddTrack(track)
 	if err != nil {
 		j.KillWithError(err)
 		return
 	}
 
+	iceConnectedCtx, iceConnectedCtxCancel := context.WithCancel(context.Background())
+
 	go func() {
 		defer j.Kill(0)
 
-		// Open a IVF file and start reading using our IVFReader
-		file, ivfErr := os.Open(filename)
-		if ivfErr != nil {
-			j.KillWithError(ivfErr)
-			return
-		}
-
-		ivf, header, ivfErr := ivfreader.NewWith(file)
-		if ivfErr != nil {
-			j.KillWithError(ivfErr)
+		log.Infof("waiting for connection...")
+		// Wait for connection established, or for a Kill before we ever connected
+		select {
+		case <-iceConnectedCtx.Done():
+		case <-j.Done():
 			return
 		}
-
-		log.Infof("waiting for connection...")
-		// Wait for connection established
-		<-iceConnectedCtx.Done()
 		log.Infof("done waiting, job connected!")
 
-		// A positive repeat will play the file N times, a negative repeat will loop forever
-		repeat := j.options.Repeat
-
-		// Send our video file frame at a time. Pace our sending so we send it at the same speed it should be played back as.
-		// This isn't required since the video is timestamped, but we will such much higher loss if we send all at once.
-		sleepTime := time.Millisecond * time.Duration((float32(header.TimebaseNumerator)/float32(header.TimebaseDenominator))*1000)
-		for {
-			frame, _, ivfErr := ivf.ParseNextFrame()
-			if ivfErr == io.EOF {
-				if repeat == -1 || repeat > 0 {
-					file.Seek(0, 0)
-					ivf, header, ivfErr = ivfreader.NewWith(file)
-					frame, _, ivfErr = ivf.ParseNextFrame()
-					if ivfErr != nil {
-						j.KillWithError(ivfErr)
-						return
-					}
-					if repeat > 0 {
-						log.Debugf("repeating %s %d more times", filename, repeat)
-						repeat = repeat - 1
-					}
-
-				} else {
-					fmt.Printf("All video frames parsed and sent")
-					j.Kill(0)
-					return
-				}
-			}
-
-			if ivfErr != nil {
-				j.KillWithError(ivfErr)
-				return
-			}
-
-			time.Sleep(sleepTime)
-			if ivfErr = videoTrack.WriteSample(media.Sample{Data: frame, Duration: time.Second}); ivfErr != nil {
-				j.KillWithError(ivfErr)
-				return
-			}
+		var playErr error
+		if mimeType == webrtc.MimeTypeOpus {
+			playErr = j.playOgg(track)
+		} else {
+			playErr = j.playIVF(track, mimeType)
+		}
+		if playErr != nil {
+			j.KillWithError(playErr)
 		}
 	}()
 
@@ -195,6 +184,128 @@ func (j *PlayFileJob) Handle() {
 
 }
 
+// repeatAgain counts down the repeats, it is false once the file should stop playing
+func (j *PlayFileJob) repeatAgain() bool {
+	if j.options.Repeat == 0 {
+		return false
+	}
+	if j.options.Repeat > 0 {
+		log.Debugf("repeating %s %d more times", j.options.Filename, j.options.Repeat)
+		j.options.Repeat = j.options.Repeat - 1
+	}
+	return true
+}
+
+// playIVF sends the file's frames at its frame rate. Neither ivf nor ogg files can be seeked by
+// time, so a segment is played by reading the file from its start and dropping what is ahead of
+// it, for video up to the first keyframe in it
+func (j *PlayFileJob) playIVF(track *webrtc.TrackLocalStaticSample, mimeType string) error {
+	// Open a IVF file and start reading using our IVFReader
+	file, err := os.Open(j.options.Filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	ivf, header, err := ivfreader.NewWith(file)
+	if err != nil {
+		return err
+	}
+
+	// Send our video file frame at a time. Pace our sending so we send it at the same speed it should be played back as.
+	// This isn't required since the video is timestamped, but we will such much higher loss if we send all at once.
+	sleepTime := time.Millisecond * time.Duration((float32(header.TimebaseNumerator)/float32(header.TimebaseDenominator))*1000)
+	start, end := j.options.Segment()
+	seeking := start > 0
+	for {
+		frame, frameHeader, err := ivf.ParseNextFrame()
+		if err == io.EOF || (err == nil && end > 0 && noir.IVFDuration(header, frameHeader.Timestamp) >= end) {
+			if seeking {
+				return fmt.Errorf("%w, %s has no keyframe from %s to %s", noir.ErrPlayRange, j.options.Filename, start, end)
+			}
+			if !j.repeatAgain() {
+				log.Infof("all video frames parsed and sent")
+				return nil
+			}
+			file.Seek(0, 0)
+			if ivf, header, err = ivfreader.NewWith(file); err != nil {
+				return err
+			}
+			seeking = start > 0
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if seeking {
+			if noir.IVFDuration(header, frameHeader.Timestamp) < start || !noir.IsKeyframe(mimeType, frame) {
+				continue
+			}
+			seeking = false
+		}
+
+		select {
+		case <-j.Done():
+			return nil
+		case <-time.After(sleepTime):
+		}
+		if err = track.WriteSample(media.Sample{Data: frame, Duration: time.Second}); err != nil {
+			return err
+		}
+	}
+}
+
+func (j *PlayFileJob) playOgg(track *webrtc.TrackLocalStaticSample) error {
+	file, err := os.Open(j.options.Filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	ogg, _, err := oggreader.NewWith(file)
+	if err != nil {
+		return err
+	}
+
+	// Pages are paced by how many samples they hold, opus always runs at 48kHz
+	start, end := j.options.Segment()
+	var lastGranule uint64
+	for {
+		page, pageHeader, err := ogg.ParseNextPage()
+		if err == io.EOF || (err == nil && end > 0 && noir.OpusDuration(lastGranule) >= end) {
+			if !j.repeatAgain() {
+				log.Infof("all audio pages parsed and sent")
+				return nil
+			}
+			file.Seek(0, 0)
+			if ogg, _, err = oggreader.NewWith(file); err != nil {
+				return err
+			}
+			lastGranule = 0
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		sampleCount := float64(pageHeader.GranulePosition - lastGranule)
+		lastGranule = pageHeader.GranulePosition
+		if start > 0 && noir.OpusDuration(lastGranule) <= start {
+			continue
+		}
+		duration := time.Duration((sampleCount/48000)*1000) * time.Millisecond
+
+		if err = track.WriteSample(media.Sample{Data: page, Duration: duration}); err != nil {
+			return err
+		}
+		select {
+		case <-j.Done():
+			return nil
+		case <-time.After(duration):
+		}
+	}
+}
+
 // Search for Codec PayloadType
 //
 // Since we are answering we need to match the remote PayloadType
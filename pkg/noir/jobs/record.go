@@ -0,0 +1,202 @@
+package jobs
+
+import (
+	"encoding/json"
+	"github.com/net-prophet/noir/pkg/noir"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	log "github.com/pion/ion-log"
+	"github.com/pion/rtcp"
+	"github.com/pion/webrtc/v3"
+	"github.com/pion/webrtc/v3/pkg/media"
+	"github.com/pion/webrtc/v3/pkg/media/h264writer"
+	"github.com/pion/webrtc/v3/pkg/media/ivfwriter"
+	"github.com/pion/webrtc/v3/pkg/media/oggwriter"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// RecordJob joins a room as a silent peer and writes every track it receives to its own file:
+// VP8 to .ivf, H264 to .h264 and Opus to .ogg, the other codecs are skipped
+type RecordJob struct {
+	noir.PeerJob
+	options *noir.RecordOptions
+}
+
+const LabelRecord = noir.RecordJobHandler
+
+// How often we ask publishers for a keyframe, so a recording never waits long for a decodable frame
+const RecordPLIInterval = 3 * time.Second
+
+func NewRecordJob(manager *noir.Manager, roomID string, options *noir.RecordOptions) *RecordJob {
+	return &RecordJob{
+		PeerJob: *noir.NewPeerJob(manager, LabelRecord, roomID, options.RecordingID),
+		options: options,
+	}
+}
+
+func NewRecordHandler(manager *noir.Manager) noir.JobHandler {
+	return func(request *pb.NoirRequest) noir.RunnableJob {
+		admin := request.GetAdmin()
+		roomAdmin := admin.GetRoomAdmin()
+		options := &noir.RecordOptions{}
+		packed := roomAdmin.GetRoomJob().GetOptions()
+		if len(packed) > 0 {
+			err := json.Unmarshal(packed, options)
+			if err != nil {
+				log.Errorf("error unmarshalling job options")
+				return nil
+			}
+		}
+		if options.RecordingID == "" {
+			options.RecordingID = noir.RandomString(16)
+		}
+		if err := noir.CheckRecordingID(options.RecordingID); err != nil {
+			log.Errorf("refused record job: %s", err)
+			return nil
+		}
+		// a moderator's job records under the recording dir, the admin api's and StartRecording's anywhere
+		dir, err := manager.RecordingOutputDir(options.OutputDir, request.GetAdminID() != "")
+		if err != nil {
+			log.Errorf("refused record job: %s", err)
+			return nil
+		}
+		options.OutputDir = dir
+		return NewRecordJob(manager, roomAdmin.GetRoomID(), options)
+	}
+}
+
+func (j *RecordJob) Dir() string {
+	return filepath.Join(j.options.OutputDir, j.options.RecordingID)
+}
+
+func (j *RecordJob) Handle() {
+	if err := os.MkdirAll(j.Dir(), 0755); err != nil {
+		j.KillWithError(err)
+		return
+	}
+
+	if err := j.GetMediaEngine().RegisterDefaultCodecs(); err != nil {
+		j.KillWithError(err)
+		return
+	}
+
+	peerConnection, err := j.GetPeerConnection()
+	if err != nil {
+		j.KillWithError(err)
+		return
+	}
+
+	// We publish nothing, but the join offer needs something in it
+	if _, err := peerConnection.CreateDataChannel("noir-record", nil); err != nil {
+		j.KillWithError(err)
+		return
+	}
+
+	subscriber, err := j.GetSubscriberConnection()
+	if err != nil {
+		j.KillWithError(err)
+		return
+	}
+	subscriber.OnTrack(func(track *webrtc.TrackRemote, receiver *webrtc.RTPReceiver) {
+		j.record(subscriber, track)
+	})
+
+	gatherComplete := webrtc.GatheringCompletePromise(peerConnection)
+	offer, err := peerConnection.CreateOffer(nil)
+	if err != nil {
+		j.KillWithError(err)
+		return
+	}
+	if err = peerConnection.SetLocalDescription(offer); err != nil {
+		j.KillWithError(err)
+		return
+	}
+	<-gatherComplete
+
+	log.Infof("recording room %s into %s", j.GetPeerData().RoomID, j.Dir())
+	if err = j.SendJoin(); err != nil {
+		j.KillWithError(err)
+		return
+	}
+
+	go j.PeerBridge()
+}
+
+func (j *RecordJob) record(subscriber *webrtc.PeerConnection, track *webrtc.TrackRemote) {
+	codec := track.Codec()
+	writer, err := j.newWriter(track)
+	if err != nil {
+		log.Errorf("error recording track %s: %s", track.ID(), err)
+		return
+	}
+	if writer == nil {
+		log.Warnf("not recording track %s, unsupported codec %s", track.ID(), codec.MimeType)
+		return
+	}
+	defer writer.Close()
+	log.Infof("recording track %s (%s) of stream %s", track.ID(), codec.MimeType, track.StreamID())
+
+	done := make(chan struct{})
+	defer close(done)
+	if track.Kind() == webrtc.RTPCodecTypeVideo {
+		go func() {
+			ticker := time.NewTicker(RecordPLIInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-done:
+					return
+				case <-ticker.C:
+					if err := subscriber.WriteRTCP([]rtcp.Packet{
+						&rtcp.PictureLossIndication{MediaSSRC: uint32(track.SSRC())},
+					}); err != nil {
+						log.Errorf("error sending pli: %s", err)
+					}
+				}
+			}
+		}()
+	}
+
+	for {
+		packet, err := track.ReadRTP()
+		if err != nil {
+			// The track ended or we were killed
+			return
+		}
+		if err := writer.WriteRTP(packet); err != nil {
+			log.Errorf("error writing track %s: %s", track.ID(), err)
+			return
+		}
+	}
+}
+
+// newWriter returns a nil writer for codecs we cannot record
+func (j *RecordJob) newWriter(track *webrtc.TrackRemote) (media.Writer, error) {
+	codec := track.Codec()
+	name := filepath.Join(j.Dir(), safeFilename(track.StreamID())+"-"+safeFilename(track.ID()))
+	switch strings.ToLower(codec.MimeType) {
+	case strings.ToLower(webrtc.MimeTypeVP8):
+		return ivfwriter.New(name + ".ivf")
+	case strings.ToLower(webrtc.MimeTypeH264):
+		return h264writer.New(name + ".h264")
+	case strings.ToLower(webrtc.MimeTypeOpus):
+		channels := codec.Channels
+		if channels == 0 {
+			channels = 2
+		}
+		return oggwriter.New(name+".ogg", codec.ClockRate, channels)
+	}
+	return nil, nil
+}
+
+// Track and stream ids come from the publisher, keep them from walking out of the directory
+func safeFilename(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == 0 {
+			return '_'
+		}
+		return r
+	}, strings.TrimLeft(name, "."))
+}
@@ -1,14 +1,168 @@
 package noir
 
-import "github.com/pion/webrtc/v3"
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/webrtc/v3"
+	"time"
+)
 
 // Join message sent when initializing a peer connection
 type Join struct {
-	Sid   string                    `json:"sid"`
-	Offer webrtc.SessionDescription `json:"offer"`
+	Sid             string                    `json:"sid"`
+	Offer           webrtc.SessionDescription `json:"offer"`
+	Limits          *pb.BitrateLimits         `json:"limits,omitempty"`
+	Token           string                    `json:"token,omitempty"`
+	Password        string                    `json:"password,omitempty"`        // needed to join a room that has one
+	QualityReports  bool                      `json:"qualityReports,omitempty"`  // asks for quality notifications, see ConnectionQuality
+	Keepalive       bool                      `json:"keepalive,omitempty"`       // asks to be pinged, see Ping
+	Role            string                    `json:"role,omitempty"`            // publisher (default), subscriber or moderator
+	TrackLabels     map[string]string         `json:"trackLabels,omitempty"`     // the label of each stream published, by stream id
+	Subscribe       []string                  `json:"subscribe,omitempty"`       // the only publishers to receive, see JoinRequest.subscribe
+	NoAutoSubscribe bool                      `json:"noAutoSubscribe,omitempty"` // with no subscribe, receive no one until subscribing
+	AdaptiveLayers  bool                      `json:"adaptiveLayers,omitempty"`  // asks for its simulcast layers to fit its downlink
 }
 
-// Negotiation message sent when renegotiating the peer connection
+// Negotiation message sent when renegotiating the peer connection, offers may label the streams
+// they publish like Join does
 type Negotiation struct {
-	Desc webrtc.SessionDescription `json:"desc"`
+	Desc        webrtc.SessionDescription `json:"desc"`
+	TrackLabels map[string]string         `json:"trackLabels,omitempty"`
+}
+
+// Leave message sent when another peer leaves the room
+type Leave struct {
+	Pid    string `json:"pid"`
+	Sid    string `json:"sid"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// Closed message sent to a peer as it is disconnected, Reason is why, eg. kicked or room_closed
+type Closed struct {
+	Reason string `json:"reason"`
+}
+
+// Assigned message sent ahead of the join's answer when the server assigned the peer its pid,
+// the one to resume as
+type Assigned struct {
+	Pid string `json:"pid"`
+}
+
+// Resumable message sent ahead of the join's answer with the token to resume the peer with
+type Resumable struct {
+	Token string `json:"token"`
+}
+
+// Resume message sent by a reconnecting client to take back its peer, with the token it was sent
+// in Resumable
+type Resume struct {
+	Pid   string `json:"pid"`
+	Token string `json:"token"`
+}
+
+// CapabilitiesQuery message sent to ask what joining sid would settle, before joining, with the
+// token the join would carry. It is answered with the room's Capabilities
+type CapabilitiesQuery struct {
+	Sid   string `json:"sid,omitempty"`
+	Token string `json:"token,omitempty"`
+}
+
+// SetLayer message sent by a subscriber to pick a simulcast layer, also the reply, and sent unasked
+// as Adapted when adaptive layers switched a stream
+type SetLayer struct {
+	StreamID     string `json:"streamId"`
+	Spatial      int32  `json:"spatial"`
+	Temporal     int32  `json:"temporal"`
+	Adapted      bool   `json:"adapted,omitempty"`
+	EstimateKbps uint32 `json:"estimateKbps,omitempty"`
+}
+
+// Data message relayed between peers, an empty To broadcasts to the room
+type Data struct {
+	Label   string `json:"label"`
+	Payload []byte `json:"payload"`
+	To      string `json:"to,omitempty"`
+	From    string `json:"from,omitempty"`
+}
+
+// Presence message sent when a peer joins or leaves the room
+type Presence struct {
+	Pid   string    `json:"pid"`
+	Sid   string    `json:"sid"`
+	Event string    `json:"event"`
+	At    time.Time `json:"at"`
+	Kind  string    `json:"kind,omitempty"` // audio or video, when muted or unmuted
+	// why the peer was disconnected, when it left
+	Reason string `json:"reason,omitempty"`
+}
+
+// ActiveSpeaker message sent when the room's dominant speaker changes, AudioLevel is in -dBov
+type ActiveSpeaker struct {
+	Pid        string `json:"pid"`
+	AudioLevel int32  `json:"audioLevel"`
+}
+
+// Ping message sent to peers that joined with keepalive, and the pong they answer it with, of the same Seq
+type Ping struct {
+	Seq uint64 `json:"seq"`
+}
+
+// ConnectionQuality message sent to peers that joined with qualityReports, Level is good, fair or poor,
+// FractionLost is from 0 to 1 and RttMs is 0 until it is known
+type ConnectionQuality struct {
+	Level        string  `json:"level"`
+	FractionLost float32 `json:"fractionLost"`
+	RttMs        int32   `json:"rttMs"`
+	JitterMs     int32   `json:"jitterMs"`
+}
+
+// Announcement message sent when a moderator or admin announces something to the room,
+// Severity is info, warning or critical
+type Announcement struct {
+	Text     string `json:"text"`
+	Severity string `json:"severity"`
+	From     string `json:"from,omitempty"`
+}
+
+// Paused message sent when the room's media is paused or resumed, and on joining a paused room,
+// By is the moderator that paused it
+type Paused struct {
+	Sid    string `json:"sid"`
+	Paused bool   `json:"paused"`
+	By     string `json:"by,omitempty"`
+}
+
+// Subscriptions message sent by a subscriber to subscribe or unsubscribe publishers, also the reply,
+// and sent unasked with Left set when a subscribed publisher leaves, or Capped set when publishers
+// were not subscribed because of the room's track limit
+type Subscriptions struct {
+	Pids   []string `json:"pids"`
+	Left   string   `json:"left,omitempty"`
+	Capped []string `json:"capped,omitempty"`
+}
+
+// TrackLayers message sent when the layers a publisher in the room sends of a video track change,
+// lowest first, see pb.TrackLayers
+type TrackLayers struct {
+	Pid      string       `json:"pid"`
+	TrackID  string       `json:"trackId"`
+	StreamID string       `json:"streamId"`
+	Layers   []TrackLayer `json:"layers"`
+}
+
+// TrackLayer is one of TrackLayers, Rid is q, h or f, or empty when the track is not simulcast
+type TrackLayer struct {
+	Spatial int32  `json:"spatial"`
+	Rid     string `json:"rid,omitempty"`
+	Active  bool   `json:"active"`
+}
+
+// TrackInfo message sent for every track a subscriber receives ahead of each offer that changes
+// them, see TrackInfos
+type TrackInfo struct {
+	Mid      string `json:"mid"`
+	TrackID  string `json:"trackId"`
+	StreamID string `json:"streamId"`
+	Pid      string `json:"pid"`
+	Kind     string `json:"kind"`
+	Label    string `json:"label,omitempty"`
 }
@@ -0,0 +1,104 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"sync/atomic"
+	"time"
+)
+
+/*
+Keepalive:
+ICE can stay connected for a while after the app on the other end is gone, eg. a browser tab
+that froze, so a peer may look present when nobody is there. A peer that joined with keepalive
+is sent a ping over signaling every keepalive interval, numbered from 1, and answers each with a
+pong of its number. A ping left unanswered when the next is due is missed, any pong since then
+counts for it, and a peer that misses KeepaliveMisses in a row is closed for KEEPALIVE_TIMEOUT.
+Pongs keep the peer alive but are not activity, a peer that only answers pings is still idle.
+*/
+
+// Defaults for Manager.Keepalive()
+const (
+	KeepaliveInterval = 10 * time.Second
+	KeepaliveMisses   = 3
+)
+
+// SetKeepalive is how often peers that asked are pinged, and how many pings in a row they may miss
+// before they are closed. 0 keeps the defaults, a negative interval pings nobody
+func (m *Manager) SetKeepalive(interval time.Duration, misses int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.keepaliveInterval = interval
+	m.keepaliveMisses = misses
+}
+
+func (m *Manager) Keepalive() (time.Duration, int) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	interval, misses := m.keepaliveInterval, m.keepaliveMisses
+	if interval == 0 {
+		interval = KeepaliveInterval
+	}
+	if misses <= 0 {
+		misses = KeepaliveMisses
+	}
+	return interval, misses
+}
+
+// keepaliveWatch is the last ping sent to a peer and the last it answered
+type keepaliveWatch struct {
+	sent     uint64
+	answered uint64
+}
+
+// pong records the peer answered seq, pongs for pings never sent are ignored
+func (k *keepaliveWatch) pong(seq uint64) {
+	if seq > atomic.LoadUint64(&k.sent) {
+		return
+	}
+	for {
+		answered := atomic.LoadUint64(&k.answered)
+		if seq <= answered || atomic.CompareAndSwapUint64(&k.answered, answered, seq) {
+			return
+		}
+	}
+}
+
+// ping is the number of the next ping, and whether the last one was left unanswered
+func (k *keepaliveWatch) ping() (uint64, bool) {
+	sent := atomic.LoadUint64(&k.sent)
+	missed := sent > 0 && atomic.LoadUint64(&k.answered) < sent
+	return atomic.AddUint64(&k.sent, 1), missed
+}
+
+// keepAlive pings pid every interval until stop is closed, and closes it once it missed misses in a row
+func (w *worker) keepAlive(pid string, interval time.Duration, misses int, watch *keepaliveWatch, stop <-chan struct{}, logger Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	missed := 0
+	for {
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+		seq, unanswered := watch.ping()
+		if !unanswered {
+			missed = 0
+		} else if missed++; missed >= misses {
+			logger.Infof("closing %s, %d pings in a row unanswered", pid, missed)
+			w.manager.CloseClient(pid, pb.CloseReason_KEEPALIVE_TIMEOUT)
+			return
+		}
+		err := w.SignalReply(pid, &pb.NoirReply{
+			Command: &pb.NoirReply_Signal{
+				Signal: &pb.SignalReply{
+					Id:      pid,
+					Payload: &pb.SignalReply_Ping{Ping: seq},
+				},
+			},
+		})
+		if err != nil {
+			logger.Debugf("error pinging %s: %s", pid, err)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"testing"
+	"time"
+)
+
+func TestKeepaliveWatch(t *testing.T) {
+	watch := &keepaliveWatch{}
+	if seq, missed := watch.ping(); seq != 1 || missed {
+		t.Errorf("got %d %t want the first ping, nothing missed yet", seq, missed)
+	}
+	watch.pong(7)
+	if _, missed := watch.ping(); !missed {
+		t.Errorf("a pong for a ping never sent should not count")
+	}
+	watch.pong(2)
+	watch.pong(1)
+	if seq, missed := watch.ping(); seq != 3 || missed {
+		t.Errorf("got %d %t want the answered ping counted, a late pong for an older one changing nothing", seq, missed)
+	}
+
+	mgr, _ := NewTestSetup()
+	if interval, misses := mgr.Keepalive(); interval != KeepaliveInterval || misses != KeepaliveMisses {
+		t.Errorf("got %s %d want the defaults", interval, misses)
+	}
+	// a peer only answering pings is idle
+	if activeSignal(&pb.SignalRequest{Payload: &pb.SignalRequest_Pong{Pong: 1}}) {
+		t.Errorf("a pong should not keep a peer from being idle")
+	}
+}
+
+func TestKeepaliveTimeout(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	mgr.SetKeepalive(100*time.Millisecond, 2)
+	keys := []string{pb.KeyRoomData("keepalive-room"), pb.KeyRoomUsers("keepalive-room"), pb.KeyUserData("keepalive-peer"),
+		pb.KeyPeerOwner("keepalive-peer"), pb.KeyTopicFromPeer("keepalive-peer"), pb.KeyTopicToPeer("keepalive-peer")}
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	fake := newFakePeer()
+	mgr.SetPeerFactory(func(sfu.SessionProvider) PeerAdapter { return fake })
+	defer mgr.SetPeerFactory(nil)
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id: "keepalive-peer",
+				Payload: &pb.SignalRequest_Join{
+					Join: &pb.JoinRequest{Sid: "keepalive-room", Description: []byte(EXAMPLE_EMPTY_SDP), Keepalive: true},
+				},
+			},
+		},
+	})
+	if err := worker.HandleNext(0); err != nil {
+		t.Fatalf("error joining: %s", err)
+	}
+	defer mgr.DisconnectUser("keepalive-peer")
+
+	// answered pings keep the peer connected, for longer than it may miss them
+	toPeer := mgr.GetQueue(pb.KeyTopicToPeer("keepalive-peer"))
+	for want := uint64(1); want <= 4; want++ {
+		ping := nextSignalReply(t, mgr, "keepalive-peer", func(reply *pb.SignalReply) bool { return reply.GetPing() != 0 })
+		if ping.GetPing() != want {
+			t.Fatalf("got ping %d want %d", ping.GetPing(), want)
+		}
+		EnqueueRequest(toPeer, &pb.NoirRequest{
+			Command: &pb.NoirRequest_Signal{
+				Signal: &pb.SignalRequest{Id: "keepalive-peer", Payload: &pb.SignalRequest_Pong{Pong: ping.GetPing()}},
+			},
+		})
+	}
+	fake.mu.Lock()
+	if fake.closed {
+		t.Fatalf("a peer answering its pings should stay connected")
+	}
+	fake.mu.Unlock()
+
+	// then the app goes away
+	killed := nextSignalReply(t, mgr, "keepalive-peer", func(reply *pb.SignalReply) bool { return reply.GetKill() })
+	if killed.GetCloseReason() != pb.CloseReason_KEEPALIVE_TIMEOUT {
+		t.Errorf("got %s want the peer closed for KEEPALIVE_TIMEOUT", killed.GetCloseReason())
+	}
+	waitFor(t, "the peer to be closed", func() bool {
+		fake.mu.Lock()
+		defer fake.mu.Unlock()
+		return fake.closed
+	})
+}
+
+func TestNoKeepalive(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	mgr.SetKeepalive(50*time.Millisecond, 1)
+	keys := []string{pb.KeyRoomData("unpinged-room"), pb.KeyRoomUsers("unpinged-room"), pb.KeyUserData("unpinged-peer"),
+		pb.KeyPeerOwner("unpinged-peer"), pb.KeyTopicFromPeer("unpinged-peer"), pb.KeyTopicToPeer("unpinged-peer")}
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	// without keepalive in its join the peer is never pinged
+	fake := newFakePeer()
+	joinFake(t, mgr, "unpinged-peer", "unpinged-room", fake)
+	defer mgr.DisconnectUser("unpinged-peer")
+	time.Sleep(200 * time.Millisecond)
+	replies := mgr.GetQueue(pb.KeyTopicFromPeer("unpinged-peer"))
+	for {
+		message, err := replies.Next()
+		if err != nil || message == nil {
+			break
+		}
+		reply := pb.NoirReply{}
+		UnmarshalReply(message, &reply)
+		if reply.GetSignal().GetPing() != 0 || reply.GetSignal().GetKill() {
+			t.Errorf("got %s want no pings for a peer that did not ask", &reply)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package noir
+
+import (
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"github.com/pion/rtcp"
+	"github.com/pion/webrtc/v3"
+	"sync"
+	"time"
+)
+
+/*
+Keyframes On Subscribe:
+A subscriber given a downtrack of a video track already being published sees nothing until the
+publisher's next keyframe. ion-sfu only asks for one once the first packets reach the bound
+downtrack, so noir asks the publisher for a keyframe itself as soon as the downtrack is bound,
+whether the subscriber got it at join, by auto-subscribe or from Subscribe. The requests for one
+published track within Manager.KeyframeCoalesce() of each other are sent as a single PLI, so a
+crowd joining at once does not set off a keyframe storm, and a room's rtcp policy minPliIntervalMs
+throttles them like the subscribers' own. A negative window asks for no keyframes on subscribe.
+*/
+
+// Default for Manager.KeyframeCoalesce()
+const DefaultKeyframeCoalesce = 100 * time.Millisecond
+
+// keyframeRequests are the keyframe requests waiting out the coalescing window, by published stream
+type keyframeRequests struct {
+	mu      sync.Mutex
+	pending map[keyframeStream]bool
+}
+
+type keyframeStream struct {
+	receiver sfu.Receiver
+	ssrc     uint32
+}
+
+// SetKeyframeCoalesce is how long keyframe requests for a new subscriber wait to be sent as one
+// PLI with the requests of others, 0 keeps the default and a negative window sends none
+func (m *Manager) SetKeyframeCoalesce(window time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.keyframeCoalesce = window
+}
+
+func (m *Manager) KeyframeCoalesce() time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.keyframeCoalesce == 0 {
+		return DefaultKeyframeCoalesce
+	}
+	return m.keyframeCoalesce
+}
+
+// requestKeyframesOnBind asks for a keyframe of every video downtrack the sfu adds to the peer
+// once the subscriber bound it, false before the peer joined
+func (m *Manager) requestKeyframesOnBind(peer *sfu.Peer) bool {
+	if m.KeyframeCoalesce() < 0 {
+		return true
+	}
+	subscriber := subscriberOf(peer)
+	if subscriber == nil {
+		return false
+	}
+	hooked := map[*sfu.DownTrack]bool{}
+	var mu sync.Mutex
+	hookNew := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, track := range downTracksOf(subscriber) {
+			if hooked[track] || track.Kind() != webrtc.RTPCodecTypeVideo {
+				continue
+			}
+			hooked[track] = true
+			track := track
+			bound := downTrackOnBind(track)
+			track.OnBind(func() {
+				if bound != nil {
+					bound()
+				}
+				receiver := downTrackReceiver(track)
+				layer := int(downTrackLayer(track))
+				if layer < 0 {
+					layer = 0
+				}
+				m.requestKeyframe(receiver, receiver.SSRC(layer))
+			})
+		}
+	}
+	hookNew()
+	_, ok := hookNegotiate(subscriber, hookNew)
+	return ok
+}
+
+// requestKeyframe sends the publisher of receiver a PLI for ssrc at the end of the coalescing
+// window, unless one is already on its way
+func (m *Manager) requestKeyframe(receiver sfu.Receiver, ssrc uint32) {
+	window := m.KeyframeCoalesce()
+	if receiver == nil || window < 0 {
+		return
+	}
+	stream := keyframeStream{receiver: receiver, ssrc: ssrc}
+	m.keyframes.mu.Lock()
+	defer m.keyframes.mu.Unlock()
+	if m.keyframes.pending == nil {
+		m.keyframes.pending = map[keyframeStream]bool{}
+	}
+	if m.keyframes.pending[stream] {
+		return
+	}
+	m.keyframes.pending[stream] = true
+	time.AfterFunc(window, func() {
+		m.keyframes.mu.Lock()
+		delete(m.keyframes.pending, stream)
+		m.keyframes.mu.Unlock()
+		// a room's minPliIntervalMs made a throttle for the track
+		if throttle, ok := keyframeThrottles.Load(receiver); ok && !throttle.(*keyframeThrottle).allow() {
+			return
+		}
+		receiver.SendRTCP([]rtcp.Packet{&rtcp.PictureLossIndication{SenderSSRC: ssrc, MediaSSRC: ssrc}})
+		keyframesRequested.Inc()
+	})
+}
@@ -0,0 +1,87 @@
+package noir
+
+import (
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"github.com/pion/rtcp"
+	"github.com/pion/webrtc/v3"
+	"sync"
+	"testing"
+	"time"
+)
+
+// pliReceiver is a published track that counts the PLIs sent to its publisher
+type pliReceiver struct {
+	sfu.Receiver
+	mu   sync.Mutex
+	plis []uint32
+}
+
+func (r *pliReceiver) TrackID() string  { return "pli-track" }
+func (r *pliReceiver) StreamID() string { return "pli-stream" }
+
+func (r *pliReceiver) SendRTCP(packets []rtcp.Packet) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, packet := range packets {
+		if pli, ok := packet.(*rtcp.PictureLossIndication); ok {
+			r.plis = append(r.plis, pli.MediaSSRC)
+		}
+	}
+}
+
+func (r *pliReceiver) sent() []uint32 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]uint32{}, r.plis...)
+}
+
+func TestRequestKeyframe(t *testing.T) {
+	mgr, _ := NewTestSetup()
+	mgr.SetKeyframeCoalesce(30 * time.Millisecond)
+	defer mgr.SetKeyframeCoalesce(0)
+
+	receiver := &pliReceiver{}
+	for i := 0; i < 5; i++ {
+		mgr.requestKeyframe(receiver, 1)
+	}
+	mgr.requestKeyframe(receiver, 2)
+	if sent := receiver.sent(); len(sent) != 0 {
+		t.Errorf("got %v want the plis held for the window", sent)
+	}
+	time.Sleep(60 * time.Millisecond)
+	if sent := receiver.sent(); len(sent) != 2 {
+		t.Errorf("got %v want one pli for each ssrc", sent)
+	}
+	mgr.requestKeyframe(receiver, 1)
+	time.Sleep(60 * time.Millisecond)
+	if sent := receiver.sent(); len(sent) != 3 {
+		t.Errorf("got %v want another pli after the window", sent)
+	}
+
+	mgr.SetKeyframeCoalesce(-1)
+	mgr.requestKeyframe(receiver, 1)
+	time.Sleep(20 * time.Millisecond)
+	if sent := receiver.sent(); len(sent) != 3 {
+		t.Errorf("got %v want no pli with keyframes on subscribe off", sent)
+	}
+	if mgr.SetKeyframeCoalesce(0); mgr.KeyframeCoalesce() != DefaultKeyframeCoalesce {
+		t.Errorf("got window %s want the default", mgr.KeyframeCoalesce())
+	}
+}
+
+func TestDownTrackOnBind(t *testing.T) {
+	track, err := sfu.NewDownTrack(webrtc.RTPCodecCapability{MimeType: "video/VP8"}, &pliReceiver{}, "bound-peer")
+	if err != nil {
+		t.Fatalf("error making a downtrack: %s", err)
+	}
+	if downTrackOnBind(track) != nil {
+		t.Errorf("a new downtrack should have nothing to call on bind")
+	}
+	called := false
+	track.OnBind(func() { called = true })
+	if bound := downTrackOnBind(track); bound == nil {
+		t.Errorf("got no onBind after setting it")
+	} else if bound(); !called {
+		t.Errorf("got another onBind than the one set")
+	}
+}
@@ -0,0 +1,275 @@
+package noir
+
+import (
+	"context"
+	"fmt"
+	log "github.com/pion/ion-log"
+	"github.com/pion/webrtc/v3"
+	"github.com/pion/webrtc/v3/pkg/media"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+/*
+LoadBot:
+A LoadBot drives many peers through the real router, worker and queue paths, to measure a
+deployment. Give it a manager on the deployment's redis that runs no worker of its own, so the
+joins are handled by the deployment, then Spawn bots into a room and read Stats when done.
+Each bot is a Client with a pc per direction: it joins with an offer, trickles its candidates,
+answers the subscriber's offers and reads every track it is sent. Join latency is how long the
+join reply took to come back, which covers routing, the worker and the sfu's answer.
+*/
+
+// How often a publishing bot sends a frame of silence
+const LoadBotFrame = 20 * time.Millisecond
+
+// an opus frame of silence
+var opusSilence = []byte{0xf8, 0xff, 0xfe}
+
+type LoadBotConfig struct {
+	// JoinRate is how many bots join per second while ramping up, 0 joins them all at once
+	JoinRate float64
+	// Churn is how often a bot leaves and a new one joins once ramp up is done, 0 never
+	Churn time.Duration
+	// Publish sends a silent opus track from every bot, otherwise bots only open a data channel
+	Publish bool
+}
+
+// LoadStats sums up a LoadBot's run so far
+type LoadStats struct {
+	Joins    int
+	Failures int
+	// Packets is how many rtp packets the bots were sent
+	Packets int64
+	P50     time.Duration
+	P95     time.Duration
+}
+
+func (s LoadStats) String() string {
+	return fmt.Sprintf("%d joins (%d failed), join latency p50 %s p95 %s, %d packets received",
+		s.Joins, s.Failures, s.P50, s.P95, s.Packets)
+}
+
+type LoadBot struct {
+	manager *Manager
+	config  LoadBotConfig
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	packets int64
+
+	mu        sync.Mutex
+	bots      map[string]*clientPeer
+	latencies []time.Duration
+	failures  int
+}
+
+// NewLoadBot makes a LoadBot whose bots leave when ctx is done or it is stopped
+func NewLoadBot(ctx context.Context, manager *Manager, config LoadBotConfig) *LoadBot {
+	ctx, cancel := context.WithCancel(ctx)
+	return &LoadBot{
+		manager: manager,
+		config:  config,
+		ctx:     ctx,
+		cancel:  cancel,
+		bots:    map[string]*clientPeer{},
+	}
+}
+
+// Spawn joins n bots to sid at the JoinRate and returns once they all tried, churn goes on until Stop
+func (l *LoadBot) Spawn(n int, sid string) {
+	var interval time.Duration
+	if l.config.JoinRate > 0 {
+		interval = time.Duration(float64(time.Second) / l.config.JoinRate)
+	}
+	var joins sync.WaitGroup
+	for i := 0; i < n && l.ctx.Err() == nil; i++ {
+		joins.Add(1)
+		go func() {
+			defer joins.Done()
+			l.join(sid)
+		}()
+		if interval > 0 {
+			select {
+			case <-time.After(interval):
+			case <-l.ctx.Done():
+			}
+		}
+	}
+	joins.Wait()
+	if l.config.Churn > 0 && l.ctx.Err() == nil {
+		l.wg.Add(1)
+		go l.churn(sid)
+	}
+}
+
+// Stop makes every bot leave, and returns the final Stats
+func (l *LoadBot) Stop() LoadStats {
+	l.cancel()
+	l.mu.Lock()
+	bots := l.bots
+	l.bots = map[string]*clientPeer{}
+	l.mu.Unlock()
+	for _, bot := range bots {
+		bot.close()
+	}
+	l.wg.Wait()
+	return l.Stats()
+}
+
+func (l *LoadBot) Stats() LoadStats {
+	l.mu.Lock()
+	latencies := append([]time.Duration{}, l.latencies...)
+	stats := LoadStats{Joins: len(l.latencies), Failures: l.failures}
+	l.mu.Unlock()
+	stats.Packets = atomic.LoadInt64(&l.packets)
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	stats.P50 = percentile(latencies, 50)
+	stats.P95 = percentile(latencies, 95)
+	return stats
+}
+
+// percentile of sorted durations, 0 for none
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	return sorted[(len(sorted)-1)*p/100]
+}
+
+func (l *LoadBot) churn(sid string) {
+	defer l.wg.Done()
+	ticker := time.NewTicker(l.config.Churn)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-l.ctx.Done():
+			return
+		}
+		var leaving *clientPeer
+		l.mu.Lock()
+		for pid, bot := range l.bots {
+			leaving = bot
+			delete(l.bots, pid)
+			break
+		}
+		l.mu.Unlock()
+		if leaving != nil {
+			leaving.close()
+		}
+		l.join(sid)
+	}
+}
+
+func (l *LoadBot) join(sid string) {
+	pid := "loadbot-" + RandomString(12)
+	bot, offer, err := l.newPeer(pid)
+	if err != nil {
+		log.Errorf("error making bot %s: %s", pid, err)
+		l.fail()
+		return
+	}
+
+	started := time.Now()
+	answer, err := bot.client.Join(sid, pid, offer)
+	if err != nil {
+		log.Errorf("bot %s failed to join %s: %s", pid, sid, err)
+		bot.close()
+		l.fail()
+		return
+	}
+	latency := time.Since(started)
+
+	// candidates are gathered now, so they all trickle after the join
+	if err := bot.pub.SetLocalDescription(offer); err != nil {
+		log.Errorf("bot %s error setting offer: %s", pid, err)
+	}
+	if err := bot.pub.SetRemoteDescription(*answer); err != nil {
+		log.Errorf("bot %s error setting answer: %s", pid, err)
+	}
+
+	l.mu.Lock()
+	l.latencies = append(l.latencies, latency)
+	if l.ctx.Err() == nil {
+		l.bots[pid] = bot
+	}
+	l.mu.Unlock()
+	if l.ctx.Err() != nil {
+		bot.close()
+		return
+	}
+	l.wg.Add(1)
+	go l.listen(pid, bot)
+}
+
+func (l *LoadBot) fail() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.failures++
+}
+
+// newPeer makes a bot's client and pcs, and the offer it joins with
+func (l *LoadBot) newPeer(pid string) (*clientPeer, webrtc.SessionDescription, error) {
+	bot, err := newClientPeer(l.ctx, l.manager)
+	if err != nil {
+		return nil, webrtc.SessionDescription{}, err
+	}
+	bot.sub.OnTrack(func(track *webrtc.TrackRemote, receiver *webrtc.RTPReceiver) {
+		for {
+			if _, err := track.ReadRTP(); err != nil {
+				return
+			}
+			atomic.AddInt64(&l.packets, 1)
+		}
+	})
+
+	if _, err := bot.pub.CreateDataChannel("loadbot", nil); err != nil {
+		bot.close()
+		return nil, webrtc.SessionDescription{}, err
+	}
+	if l.config.Publish {
+		// subscribers skip a track with the same ids as one they get, so every bot has its own stream
+		track, err := webrtc.NewTrackLocalStaticSample(webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeOpus}, "audio", pid)
+		if err == nil {
+			_, err = bot.pub.AddTrack(track)
+		}
+		if err != nil {
+			bot.close()
+			return nil, webrtc.SessionDescription{}, err
+		}
+		go publishSilence(bot.ctx, track)
+	}
+
+	offer, err := bot.pub.CreateOffer(nil)
+	if err != nil {
+		bot.close()
+		return nil, webrtc.SessionDescription{}, err
+	}
+	return bot, offer, nil
+}
+
+func publishSilence(ctx context.Context, track *webrtc.TrackLocalStaticSample) {
+	ticker := time.NewTicker(LoadBotFrame)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			track.WriteSample(media.Sample{Data: opusSilence, Duration: LoadBotFrame})
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// listen answers the subscriber's offers and adds the sfu's candidates until the bot leaves
+func (l *LoadBot) listen(pid string, bot *clientPeer) {
+	defer l.wg.Done()
+	for signal := range bot.client.Events() {
+		if err := bot.handle(signal); err != nil {
+			log.Errorf("bot %s error answering: %s", pid, err)
+		}
+	}
+}
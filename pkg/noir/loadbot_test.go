@@ -0,0 +1,45 @@
+package noir
+
+import (
+	"context"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"testing"
+	"time"
+)
+
+func TestLoadBot(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	redis.Del(pb.KeyRoomData("loadbot"), pb.KeyRoomUsers("loadbot"))
+	go func() {
+		for i := 0; i < 3; i++ {
+			routeOnce(mgr)
+		}
+	}()
+
+	bots := NewLoadBot(context.Background(), mgr, LoadBotConfig{JoinRate: 20})
+	bots.Spawn(3, "loadbot")
+	stats := bots.Stop()
+	if stats.Joins != 3 || stats.Failures != 0 {
+		t.Fatalf("got %s want 3 joins", stats)
+	}
+	if stats.P50 <= 0 || stats.P95 < stats.P50 || stats.P95 > 5*time.Second {
+		t.Errorf("got p50 %s p95 %s want sensible join latencies", stats.P50, stats.P95)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := []time.Duration{}
+	for i := 1; i <= 100; i++ {
+		sorted = append(sorted, time.Duration(i)*time.Millisecond)
+	}
+	if got := percentile(sorted, 50); got != 50*time.Millisecond {
+		t.Errorf("got p50 %s want 50ms", got)
+	}
+	if got := percentile(sorted, 95); got != 95*time.Millisecond {
+		t.Errorf("got p95 %s want 95ms", got)
+	}
+	if got := percentile(nil, 95); got != 0 {
+		t.Errorf("got %s want 0 without samples", got)
+	}
+}
@@ -0,0 +1,129 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	log "github.com/pion/ion-log"
+	"sort"
+	"strings"
+)
+
+/*
+Structured Logging:
+Lines logged while handling a request carry the worker, action, pid and sid they are about, and
+the request's trace, see request_tracing.go, as fields, so one peer's lines can be filtered out
+of a busy node. Handle starts a Logger with the request's fields and hands it down to the
+handler, PeerChannel keeps one for its peer. The default Logger writes through ion-log with the
+fields in front of the message, SetLogger plugs in another backend, eg. zap or logrus behind a
+small adapter.
+*/
+
+// LogFields are the key/values a Logger puts on each of its lines
+type LogFields map[string]string
+
+// Logger is what the manager and workers log through
+type Logger interface {
+	Debugf(format string, v ...interface{})
+	Infof(format string, v ...interface{})
+	Warnf(format string, v ...interface{})
+	Errorf(format string, v ...interface{})
+	// With is a Logger that adds the fields to this one's, an empty value drops the field
+	With(fields LogFields) Logger
+}
+
+// ionLogger writes through ion-log, prefixing each message with its fields as key=value
+type ionLogger struct {
+	prefix string
+	fields LogFields
+}
+
+// NewIonLogger is the default Logger, it writes to ion-log
+func NewIonLogger() Logger {
+	return &ionLogger{fields: LogFields{}}
+}
+
+func (l *ionLogger) With(fields LogFields) Logger {
+	merged := make(LogFields, len(l.fields)+len(fields))
+	for key, value := range l.fields {
+		merged[key] = value
+	}
+	for key, value := range fields {
+		if value == "" {
+			delete(merged, key)
+		} else {
+			merged[key] = value
+		}
+	}
+	keys := make([]string, 0, len(merged))
+	for key := range merged {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + "=" + merged[key]
+	}
+	prefix := strings.Join(pairs, " ")
+	if prefix != "" {
+		prefix += " "
+	}
+	return &ionLogger{prefix: prefix, fields: merged}
+}
+
+func (l *ionLogger) Debugf(format string, v ...interface{}) {
+	log.Debugf(l.prefix+format, v...)
+}
+
+func (l *ionLogger) Infof(format string, v ...interface{}) {
+	log.Infof(l.prefix+format, v...)
+}
+
+func (l *ionLogger) Warnf(format string, v ...interface{}) {
+	log.Warnf(l.prefix+format, v...)
+}
+
+func (l *ionLogger) Errorf(format string, v ...interface{}) {
+	log.Errorf(l.prefix+format, v...)
+}
+
+// SetLogger replaces the Logger the manager and its worker log through, nil goes back to ion-log
+func (m *Manager) SetLogger(logger Logger) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.logger = logger
+}
+
+// Logger is the manager's Logger
+func (m *Manager) Logger() Logger {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.logger == nil {
+		return defaultLogger
+	}
+	return m.logger
+}
+
+var defaultLogger = NewIonLogger()
+
+// RequestFields are the fields of the lines logged about the request
+func RequestFields(request *pb.NoirRequest) LogFields {
+	fields := LogFields{"action": request.GetAction(), "trace": request.GetTraceID()}
+	if signal := request.GetSignal(); signal != nil {
+		fields["pid"] = signal.Id
+		if sid := signal.GetJoin().GetSid(); sid != "" {
+			fields["sid"] = sid
+		} else if sid := signal.GetPlay().GetSid(); sid != "" {
+			fields["sid"] = sid
+		}
+	}
+	if roomAdmin := request.GetAdmin().GetRoomAdmin(); roomAdmin != nil {
+		fields["sid"] = roomAdmin.RoomID
+	}
+	return fields
+}
+
+// requestLogger logs about the request on this worker
+func (w *worker) requestLogger(request *pb.NoirRequest) Logger {
+	fields := RequestFields(request)
+	fields["worker"] = w.id
+	return w.manager.Logger().With(fields)
+}
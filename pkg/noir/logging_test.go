@@ -0,0 +1,92 @@
+package noir
+
+import (
+	"fmt"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"sync"
+	"testing"
+)
+
+// recordingLogger keeps every line with its fields
+type recordingLogger struct {
+	fields LogFields
+	lines  *[]LogFields
+	mu     *sync.Mutex
+}
+
+func newRecordingLogger() *recordingLogger {
+	return &recordingLogger{fields: LogFields{}, lines: &[]LogFields{}, mu: &sync.Mutex{}}
+}
+
+func (l *recordingLogger) record(format string, v ...interface{}) {
+	line := LogFields{"msg": fmt.Sprintf(format, v...)}
+	for key, value := range l.fields {
+		line[key] = value
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	*l.lines = append(*l.lines, line)
+}
+
+func (l *recordingLogger) Debugf(format string, v ...interface{}) { l.record(format, v...) }
+func (l *recordingLogger) Infof(format string, v ...interface{})  { l.record(format, v...) }
+func (l *recordingLogger) Warnf(format string, v ...interface{})  { l.record(format, v...) }
+func (l *recordingLogger) Errorf(format string, v ...interface{}) { l.record(format, v...) }
+
+func (l *recordingLogger) With(fields LogFields) Logger {
+	with := &recordingLogger{fields: LogFields{}, lines: l.lines, mu: l.mu}
+	for key, value := range l.fields {
+		with.fields[key] = value
+	}
+	for key, value := range fields {
+		with.fields[key] = value
+	}
+	return with
+}
+
+func (l *recordingLogger) Lines() []LogFields {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]LogFields{}, *l.lines...)
+}
+
+func TestIonLoggerFields(t *testing.T) {
+	logger := NewIonLogger().With(LogFields{"sid": "room", "pid": "peer"}).With(LogFields{"action": "request.servers.join", "pid": ""})
+	if prefix := logger.(*ionLogger).prefix; prefix != "action=request.servers.join sid=room " {
+		t.Errorf("got prefix %q want sorted fields without the dropped pid", prefix)
+	}
+}
+
+func TestWorkerLogsRequestFields(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	recorder := newRecordingLogger()
+	mgr.SetLogger(recorder)
+	defer mgr.SetLogger(nil)
+	mgr.SetAllowAutoCreateRooms(false)
+	redis.Del(pb.KeyRoomData("logged"), pb.KeyTopicFromPeer("logged-peer"))
+
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id: "logged-peer",
+				Payload: &pb.SignalRequest_Join{
+					Join: &pb.JoinRequest{Sid: "logged", Description: []byte(EXAMPLE_EMPTY_SDP)},
+				},
+			},
+		},
+	})
+	if err := worker.HandleNext(0); err == nil {
+		t.Fatalf("joining a missing room should fail")
+	}
+
+	lines := recorder.Lines()
+	if len(lines) == 0 {
+		t.Fatalf("nothing was logged")
+	}
+	for _, line := range lines {
+		if line["pid"] != "logged-peer" || line["sid"] != "logged" || line["action"] != "request.servers.join" || line["worker"] != worker.ID() {
+			t.Errorf("got %v want the join's pid, sid, action and worker", line)
+		}
+	}
+}
@@ -8,7 +8,6 @@ import (
 	pb "github.com/net-prophet/noir/pkg/proto"
 	log "github.com/pion/ion-log"
 	sfu "github.com/pion/ion-sfu/pkg/sfu"
-	"github.com/pion/sdp/v3"
 	"github.com/pion/webrtc/v3"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"math/rand"
@@ -24,6 +23,8 @@ import (
 const (
 	ManagerPingFrequency = 10 * time.Second
 	QueueMessageTimeout  = 25 * time.Second
+	// How many keys ListRooms asks redis to SCAN at a time
+	RoomScanCount = 100
 )
 
 type Manager struct {
@@ -35,35 +36,182 @@ type Manager struct {
 	config       sfu.Config
 	sfu          *NoirSFU
 	nodes        map[string]pb.NodeData
-	users        map[string]*sfu.Peer
+	users        map[string]PeerAdapter
 	rooms        map[string]Room
 	nodeServices []string
+	queues       QueueFactory
 	mu           sync.RWMutex
+	// when false, peers can only join rooms that were created first
+	allowAutoCreateRooms bool
+	iceServers           ICEServerProvider
+	authenticator        Authenticator
+	offerPolicy          OfferPolicy
+	recordingDir         string
+	playbackDir          string
+	webrtcTimeout        time.Duration
+	routerMaxAge         time.Duration
+	// how long a peer outlives its client, see DetachClient
+	resumeGrace       time.Duration
+	peerQueueRetries  int
+	heartbeatInterval time.Duration
+	failoverWindow    time.Duration
+	dedupWindow       time.Duration
+	iceGrace          time.Duration
+	qualityInterval   time.Duration
+	idleTimeout       time.Duration
+	// see SetKeepalive
+	keepaliveInterval time.Duration
+	keepaliveMisses   int
+	statsWindow       time.Duration
+	offerBundling     time.Duration
+	deadLetterCap     int
+	maxMessageSize    int
+	// see capacity.go
+	maxPeers int
+	joining  int
+	// see peer_goroutines.go, peerGoroutines is only used atomically
+	maxPeerGoroutines int
+	peerGoroutines    int64
+	// see SetTrickleBatching
+	trickleBatchSize     int
+	trickleFlushInterval time.Duration
+	// bitrate limits for joining peers, see bitrate.go
+	defaultBitrate *pb.BitrateLimits
+	maxBitrate     *pb.BitrateLimits
+	// the subscribe cap of each local peer, in bits per second
+	subscribeCaps map[string]uint64
+	// what peers may negotiate, see codecs.go
+	media MediaConfig
+	// see signal_trace.go
+	tracer signalTracer
+	// how replies wait on full peer topics, see backpressure.go
+	backpressure BackpressureConfig
+	// see ice_policy.go
+	icePolicy *pb.ICEPolicy
+	// see rtcp_policy.go
+	rtcpPolicy *pb.RTCPPolicy
+	// see sdp_limits.go
+	sdpLimits *pb.SDPLimits
+	// the publishers of peers that picked theirs, see subscribe.go
+	selections map[string]*selection
+	// see rate_limit.go
+	joinLimiter JoinLimiter
+	// see logging.go
+	logger Logger
+	// the rooms this manager relays, see relay.go
+	relays map[string]*roomRelay
+	// see sdp_hooks.go
+	beforeSendAnswer SDPHook
+	beforeSendOffer  SDPHook
+	// in-process subscribers to room events, see events.go
+	events eventBus
+	// the rooms this node was told are paused, see room_pause.go
+	pausedRooms map[string]bool
+	// the kinds of track the publishers on this node are muted for, see mute.go
+	mutedKinds map[mutedKind]bool
+	// every redis key and topic, under the prefix from SetKeyPrefix
+	keys pb.Keys
+	// the keyframe requests of new subscribers, see keyframes.go
+	keyframeCoalesce time.Duration
+	keyframes        keyframeRequests
+	// how long a quitting node drains its worker, see drain.go
+	drainTimeout time.Duration
+	queueKeyring *QueueKeyring
+	// how replies are compressed, for each peer reading them compressed, see compression.go
+	replyCompression []pb.Compression
+	compressMinBytes int
+	compressions     map[string]pb.Compression
+	// makes the peers of joins, see peer_adapter.go
+	peerFactory PeerFactory
+	// the layers last announced of each local publisher's video tracks, see track_layers.go
+	layers map[string][]*pb.TrackLayers
+	// what each local publisher's subscribers allow it, by track, see rtcp_feedback.go
+	targets map[string]map[string]uint64
+	// how joins get their pid, see peer_ids.go
+	peerIDMode PeerIDMode
+	// what a join of a connected pid does, see reconnect.go
+	reconnectPolicy ReconnectPolicy
+	// the options of rooms created from a template, by its name, see room_templates.go
+	roomTemplates map[string]*pb.RoomOptions
+	// where rooms are kept, nil is redis, see room_store.go
+	roomStore RoomStore
+	// what the media of joining peers is copied to, by name, see packet_taps.go
+	packetTaps map[string]PacketTap
+	// what becomes of rooms their last peer left, see empty_rooms.go
+	emptyRoomPolicy pb.EmptyRoomPolicy
+	emptyRoomGrace  time.Duration
+	// decodes and encodes audio mixes, see audio_mix.go
+	audioMixCodec AudioCodec
+	// reports the steps of handling requests, see request_tracing.go
+	spanTracer SpanTracer
+	// see adaptive_layers.go
+	adaptiveLayers AdaptiveLayerConfig
+	// the cachedOwner of each peer this node's workers read the owner of, by pid, see peer_owner.go
+	peerOwners sync.Map
+	// the workers NodesForService places joins on, as of the last health tick, nil places on all
+	placeable map[string]bool
 }
 
-func SetupNoir(sfu *NoirSFU, client *redis.Client, nodeID string, services string) Manager {
-	routerQueue := NewRedisQueue(client, RouterTopic, RouterMaxAge)
-	workerQueue := NewRedisQueue(client, pb.KeyWorkerTopic(nodeID), RouterMaxAge)
+func SetupNoir(sfu *NoirSFU, client *redis.Client, nodeID string, services string) *Manager {
+	return SetupNoirWithQueues(sfu, client, RedisQueueFactory(client), nodeID, services)
+}
+
+// SetupNoirWithQueues is SetupNoir with messaging on another broker, redis still stores the cluster state.
+// It is NewManagerWithConfig with every default, so it only fails without a redis client
+func SetupNoirWithQueues(sfu *NoirSFU, client *redis.Client, queues QueueFactory, nodeID string, services string) *Manager {
+	manager, err := NewManagerWithConfig(Config{
+		Node: NodeConfig{Redis: client, ID: nodeID, Services: services, SFU: sfu, Queues: queues},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return manager
+}
+
+// SetupManager creates the worker and router for a manager, so settings like
+// SetTimeouts have to be made before calling it
+func SetupManager(manager *Manager, queues QueueFactory) *Manager {
+	nodeID := manager.ID()
+	if keyring := manager.QueueKeyring(); keyring != nil {
+		queues = EncryptedQueueFactory(queues, keyring, manager)
+	}
+	queues = TracingQueueFactory(queues, manager)
+	routerQueue := queues(manager.RouterTopic(), manager.RouterMaxAge())
+	workerQueue := queues(manager.Keys().WorkerTopic(nodeID), manager.RouterMaxAge())
 	workerQueue.Cleanup()
-	manager := NewRedisManager(sfu, client, nodeID, services)
-	worker := NewWorker(nodeID, &manager, workerQueue)
-	router := NewRouter(routerQueue, &manager)
+	manager.SetQueueFactory(queues)
+	worker := NewWorker(nodeID, manager, workerQueue)
+	router := NewRouter(routerQueue, manager)
 	manager.SetWorker(&worker)
 	manager.SetRouter(&router)
 	manager.Checkin()
+	manager.Heartbeat(nodeID, 0)
 	return manager
 }
 
-func NewRedisManager(provider *NoirSFU, client *redis.Client, nodeID string, services string) Manager {
-	manager := Manager{redis: client,
-		nodes:        make(map[string]pb.NodeData),
-		users:        make(map[string]*sfu.Peer),
-		rooms:        make(map[string]Room),
-		sfu:          provider,
-		id:           nodeID,
-		nodeServices: strings.Split(services, ","),
+func NewRedisManager(provider *NoirSFU, client *redis.Client, nodeID string, services string) *Manager {
+	manager := &Manager{redis: client,
+		nodes:         make(map[string]pb.NodeData),
+		users:         make(map[string]PeerAdapter),
+		rooms:         make(map[string]Room),
+		selections:    make(map[string]*selection),
+		relays:        make(map[string]*roomRelay),
+		pausedRooms:   make(map[string]bool),
+		mutedKinds:    make(map[mutedKind]bool),
+		compressions:  make(map[string]pb.Compression),
+		layers:        make(map[string][]*pb.TrackLayers),
+		targets:       make(map[string]map[string]uint64),
+		subscribeCaps: make(map[string]uint64),
+		roomTemplates: make(map[string]*pb.RoomOptions),
+		packetTaps:    make(map[string]PacketTap),
+		sfu:           provider,
+		id:            nodeID,
+		nodeServices:  strings.Split(services, ","),
+		queues:        RedisQueueFactory(client),
+		offerPolicy:   AudioOnlyPolicy(),
+		keys:          pb.DefaultKeys,
 	}
-	(*provider).AttachManager(&manager)
+	(*provider).AttachManager(manager)
 	return manager
 }
 
@@ -77,6 +225,7 @@ func (m *Manager) Noir() {
 	info := time.NewTicker(5 * time.Second)
 	updateNodes := time.NewTicker(20 * time.Second)
 	checkin := time.NewTicker(15 * time.Second)
+	supervise := time.NewTicker(m.HeartbeatInterval())
 	quit := make(chan os.Signal)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 	if err := m.Checkin(); err != nil {
@@ -100,6 +249,8 @@ func (m *Manager) Noir() {
 			if err := m.Checkin(); err != nil {
 				panic("unable to checkin node as healthy")
 			}
+		case <-supervise.C:
+			m.Supervise()
 		case <-updateNodes.C:
 			if err := m.UpdateAvailableNodes(); err != nil {
 				panic("unable to retrieve cluster status")
@@ -116,6 +267,10 @@ func (m *Manager) Noir() {
 			)
 		case <-quit:
 			log.Warnf("quit requested, cleaning up...")
+			if timeout := m.DrainTimeout(); timeout > 0 {
+				m.worker.Drain(timeout)
+			}
+			m.worker.Stop()
 			info.Stop()
 			updateNodes.Stop()
 			m.Cleanup()
@@ -147,68 +302,398 @@ func (m *Manager) BindRoomSession(room Room, session *sfu.Session) *Room {
 	return &room
 }
 
-func (m *Manager) CloseRoom(roomID string) {
+// UnbindRoom forgets a room whose session closed on this node
+func (m *Manager) UnbindRoom(roomID string) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	delete(m.rooms, roomID)
-	m.redis.ZRem(pb.KeyRoomScores(), roomID)
+	m.redis.ZRem(m.Keys().RoomScores(), roomID)
+}
+
+// CloseRoom evicts every peer in the room and deletes it, see EvictRoom
+func (m *Manager) CloseRoom(roomID string) error {
+	_, err := m.EvictRoom(roomID)
+	return err
+}
+
+// EvictRoom closes a room and kicks out all of its peers, returning how many were evicted.
+// The room is marked closed *before* peers are listed, so a join racing the close
+// either gets listed and evicted here or sees the mark and rejects itself (see HandleJoin).
+// Joins are refused until the room is created again.
+func (m *Manager) EvictRoom(roomID string) (int, error) {
+	exists, err := m.GetRemoteRoomExists(roomID)
+	if err != nil {
+		return 0, err
+	}
+	if exists == false {
+		return 0, errors.New("no such room")
+	}
+
+	if err := m.redis.Set(m.Keys().RoomClosed(roomID), 1, 0).Err(); err != nil {
+		return 0, err
+	}
+
+	evicted := 0
+	for _, pid := range m.redis.HKeys(m.Keys().RoomUsers(roomID)).Val() {
+		err := EnqueueRequest(m.GetQueue(m.Keys().TopicToPeer(pid)), &pb.NoirRequest{
+			AdminID: m.ID(),
+			Command: &pb.NoirRequest_Signal{
+				Signal: &pb.SignalRequest{
+					Id: pid,
+					Payload: &pb.SignalRequest_Kill{
+						Kill: true,
+					},
+					CloseReason: pb.CloseReason_ROOM_CLOSED,
+				},
+			},
+		})
+		if err != nil {
+			m.Logger().With(LogFields{"pid": pid, "sid": roomID}).Errorf("error evicting %s from %s: %s", pid, roomID, err)
+			continue
+		}
+		evicted += 1
+	}
+
+	m.RoomStore().Delete(roomID)
+	m.redis.Del(m.Keys().RoomUsers(roomID))
+	m.redis.ZRem(m.Keys().RoomScores(), roomID)
+	m.EmitRoomEvent(roomID, "", pb.RoomEvent_CLOSED)
+	m.Logger().With(LogFields{"sid": roomID}).Infof("closed room %s, evicted %d peers", roomID, evicted)
+	return evicted, nil
 }
 
+// ReservePeerSlot atomically adds pid to the room's peer set unless the room already has maxPeers,
+// so concurrent joins on different workers can't overfill a room. maxPeers <= 0 means no limit.
+// A room its empty room policy is closing is ErrRoomClosing, see empty_rooms.go, and so is one
+// whose data was deleted by the time it reserved, unless rooms are created by their joins
+func (m *Manager) ReservePeerSlot(roomID string, pid string, maxPeers int32) (bool, error) {
+	reserve := redis.NewScript(`
+		if redis.call('EXISTS', KEYS[2]) == 1 then
+			return -1
+		end
+		local max = tonumber(ARGV[2])
+		if max > 0 and redis.call('HEXISTS', KEYS[1], ARGV[1]) == 0 and redis.call('HLEN', KEYS[1]) >= max then
+			return 0
+		end
+		redis.call('HSET', KEYS[1], ARGV[1], 1)
+		redis.call('DEL', KEYS[3])
+		return 1
+	`)
+	reserved, err := reserve.Run(m.redis,
+		[]string{m.Keys().RoomUsers(roomID), m.Keys().RoomClosing(roomID), m.Keys().RoomEmpty(roomID)},
+		pid, maxPeers).Int()
+	if err != nil {
+		return false, err
+	} else if reserved == -1 {
+		return false, ErrRoomClosing
+	}
+	// closed after the caller read it, ConnectUser would create it again without its options
+	if reserved == 1 && !m.AllowAutoCreateRooms() {
+		if exists, err := m.GetRemoteRoomExists(roomID); err == nil && !exists {
+			m.redis.HDel(m.Keys().RoomUsers(roomID), pid)
+			return false, fmt.Errorf("%w: %s was closed", ErrRoomClosing, roomID)
+		}
+	}
+	return reserved == 1, nil
+}
+
+// pruneRoomUsers drops the pids of the room's peer index that are neither saved nor owned by a
+// worker, left by a session that ended without them leaving. A join saves its peer before its
+// session starts, and one still reserving writes its pid again as it connects
+func (m *Manager) pruneRoomUsers(roomID string) (int, error) {
+	prune := redis.NewScript(`
+		local pruned = 0
+		for _, pid in ipairs(redis.call('HKEYS', KEYS[1])) do
+			if redis.call('EXISTS', ARGV[1] .. pid) == 0 and redis.call('EXISTS', ARGV[2] .. pid) == 0 then
+				redis.call('HDEL', KEYS[1], pid)
+				pruned = pruned + 1
+			end
+		end
+		return pruned
+	`)
+	return prune.Run(m.redis, []string{m.Keys().RoomUsers(roomID)},
+		m.Keys().UserData(""), m.Keys().PeerOwner("")).Int()
+}
+
+// ReleasePeerSlot frees a slot taken by ReservePeerSlot, DisconnectUser does this for connected
+// peers. Like their leave it may empty the room, see empty_rooms.go
+func (m *Manager) ReleasePeerSlot(roomID string, pid string) {
+	if emptied := m.leaveRoom(roomID, pid); emptied != "" {
+		m.emptiedRoom(roomID, emptied)
+	}
+}
+
+// IsRoomClosed is true between CloseRoom and the room being created again
+func (m *Manager) IsRoomClosed(roomID string) bool {
+	closed, _ := m.redis.Exists(m.Keys().RoomClosed(roomID)).Result()
+	return closed == 1
+}
+
+// ReopenRoom allows joining a room that was closed
+func (m *Manager) ReopenRoom(roomID string) {
+	m.redis.Del(m.Keys().RoomClosed(roomID))
+}
+
+// DisconnectUser is CloseClient for an UNKNOWN reason
 func (m *Manager) DisconnectUser(userID string) {
+	m.CloseClient(userID, pb.CloseReason_UNKNOWN)
+}
+
+// CloseClient disconnects a peer, its LEFT event and the Kill its client is sent say why
+func (m *Manager) CloseClient(userID string, reason pb.CloseReason) {
 	userData, err := m.GetRemoteUserData(userID)
+	m.Logger().With(LogFields{"pid": userID}).Debugf("closing %s: %s", userID, reason)
 
 	// Cleanup the SFU peer
+	m.mu.RLock()
 	client := m.users[userID]
+	m.mu.RUnlock()
 	if client != nil {
 		client.Close()
 	}
+	m.dropSelections(userID)
 
 	if userData != nil && err == nil {
 
 		if userData.Options.MaxAgeSeconds == -1 {
-			defer m.redis.Del(pb.KeyUserData(userID))
+			defer m.redis.Del(m.Keys().UserData(userID))
 		}
 
-		defer m.redis.HDel(pb.KeyRoomUsers(userData.RoomID), userID)
+		// out of the room's index before its LEFT, so whoever hears it finds the slot free
+		emptied := m.leaveRoom(userData.RoomID, userID)
+		m.redis.Del(m.Keys().PeerStatus(userID), m.Keys().UserResume(userID))
+		if userData.Identity != "" {
+			defer m.redis.SRem(m.Keys().IdentityPeers(userData.Identity), userID)
+		}
 
 		m.UpdateRoomScore(userData.RoomID)
+		if m.announcedLeft(userID) {
+			m.PublishRoomEvent(&pb.RoomEvent{
+				Type:   pb.RoomEvent_LEFT,
+				Pid:    userID,
+				Sid:    userData.RoomID,
+				Reason: reason,
+			})
+		}
+		m.promoteOwner(userData.RoomID, userID)
+		if emptied != "" {
+			m.emptiedRoom(userData.RoomID, emptied)
+		}
 	}
 
 	// Send Kill to the Peer Queues
-	toPeerQueue := m.GetQueue(pb.KeyTopicToPeer(userID))
-	fromPeerQueue := m.GetQueue(pb.KeyTopicFromPeer(userID))
+	toPeerQueue := m.GetQueue(m.Keys().TopicToPeer(userID))
+	fromPeerQueue := m.GetQueue(m.Keys().TopicFromPeer(userID))
 
 	EnqueueRequest(toPeerQueue, &pb.NoirRequest{
+		// the node's own kill, its reason counts, see PeerChannel
+		AdminID: m.ID(),
 		Command: &pb.NoirRequest_Signal{
 			Signal: &pb.SignalRequest{
 				Id: userID,
 				Payload: &pb.SignalRequest_Kill{
 					Kill: true,
 				},
+				CloseReason: reason,
 			},
 		},
 	})
 
-	EnqueueReply(fromPeerQueue, &pb.NoirReply{
+	m.EnqueueReply(fromPeerQueue, &pb.NoirReply{
 		Command: &pb.NoirReply_Signal{
 			Signal: &pb.SignalReply{
 				Id: userID,
 				Payload: &pb.SignalReply_Kill{
 					Kill: true,
 				},
+				CloseReason: reason,
 			},
 		},
 	})
 
 	m.mu.Lock()
 	delete(m.users, userID)
+	delete(m.compressions, userID)
 	m.mu.Unlock()
 }
 
-func (m *Manager) ConnectUser(signal *pb.SignalRequest) (*sfu.Peer, *pb.UserData, error) {
+// DetachClient is called when a client goes away without leaving, eg. its websocket dropped.
+// The peer stays up for ResumeGrace() so the client can reconnect and ResumeClient it
+func (m *Manager) DetachClient(pid string) {
+	grace := m.ResumeGrace()
+	if grace <= 0 {
+		m.CloseClient(pid, pb.CloseReason_DETACHED)
+		return
+	}
+	m.Logger().With(LogFields{"pid": pid}).Infof("user %s detached, disconnecting in %s unless resumed", pid, grace)
+	m.redis.Set(m.Keys().UserDetached(pid), m.ID(), 2*grace)
+	time.AfterFunc(grace, func() {
+		// Whoever deletes the key first wins, either the resume or us
+		if m.redis.Del(m.Keys().UserDetached(pid)).Val() == 1 {
+			m.CloseClient(pid, pb.CloseReason_DETACHED)
+		}
+	})
+}
+
+// ResumeClient reattaches a detached pid, it fails if the peer is not on this node, was not detached,
+// or token is not the resumeToken of its JoinReply, see resume.go
+func (m *Manager) ResumeClient(pid string, token string) error {
+	m.mu.RLock()
+	_, local := m.users[pid]
+	m.mu.RUnlock()
+	if !local {
+		return fmt.Errorf("peer %s is not alive", pid)
+	}
+	if err := m.CheckResumeToken(pid, token); err != nil {
+		return err
+	}
+	if m.redis.Del(m.Keys().UserDetached(pid)).Val() != 1 {
+		return fmt.Errorf("peer %s is not detached", pid)
+	}
+	m.Logger().With(LogFields{"pid": pid}).Infof("user %s resumed", pid)
+	return nil
+}
+
+// LeaveClient tells the other peers in the room that pid left, then disconnects it.
+// Leaving is idempotent: a peer that is already gone (eg. ICE failure) is a no-op
+func (m *Manager) LeaveClient(pid string) error {
+	m.mu.RLock()
+	_, local := m.users[pid]
+	m.mu.RUnlock()
+
+	userData, err := m.GetRemoteUserData(pid)
+	if err != nil || userData == nil {
+		if local {
+			m.CloseClient(pid, pb.CloseReason_LEFT)
+		}
+		return nil
+	}
+
+	m.Logger().With(LogFields{"pid": pid, "sid": userData.RoomID}).Infof("user %s leaving room %s", pid, userData.RoomID)
+
+	m.NotifyRoomPeers(userData.RoomID, pid, &pb.NoirReply{
+		Command: &pb.NoirReply_Signal{
+			Signal: &pb.SignalReply{
+				Payload: &pb.SignalReply_Leave{
+					Leave: &pb.PeerLeave{
+						Pid:    pid,
+						Sid:    userData.RoomID,
+						Reason: pb.CloseReason_LEFT,
+					},
+				},
+			},
+		},
+	})
+
+	m.CloseClient(pid, pb.CloseReason_LEFT)
+	return nil
+}
+
+// EmitRoomEvent publishes a presence event to everyone subscribed to the room
+func (m *Manager) EmitRoomEvent(roomID string, pid string, eventType pb.RoomEvent_Type) error {
+	return m.PublishRoomEvent(&pb.RoomEvent{
+		Type: eventType,
+		Pid:  pid,
+		Sid:  roomID,
+	})
+}
+
+// announceJoined publishes pid's JOINED and keeps that it was, see announcedLeft
+func (m *Manager) announceJoined(roomID string, pid string) error {
+	if err := m.redis.Set(m.Keys().UserJoined(pid), 1, 0).Err(); err != nil {
+		return err
+	}
+	return m.EmitRoomEvent(roomID, pid, pb.RoomEvent_JOINED)
+}
+
+// announcedLeft is true once for a peer whose JOINED was announced, it is the leave that announces
+// its LEFT. A peer that never joined, eg. one whose join failed, leaves without a LEFT
+func (m *Manager) announcedLeft(pid string) bool {
+	return m.redis.Del(m.Keys().UserJoined(pid)).Val() == 1
+}
+
+// PublishRoomEvent is EmitRoomEvent for events with more than a type, At is filled in when unset
+func (m *Manager) PublishRoomEvent(roomEvent *pb.RoomEvent) error {
+	if roomEvent.At == nil {
+		roomEvent.At = timestamppb.Now()
+	}
+	event, err := MarshalReply(&pb.NoirReply{
+		Id:      "event-" + RandomString(24),
+		Command: &pb.NoirReply_Event{Event: roomEvent},
+	})
+	if err != nil {
+		return err
+	}
+	m.deliverEvent(roomEvent)
+	return m.redis.Publish(m.Keys().TopicRoomEvents(roomEvent.Sid), event).Err()
+}
+
+// SubscribeRoomEvents listens for the presence events of a room, see EmitRoomEvent
+func (m *Manager) SubscribeRoomEvents(roomID string) *redis.PubSub {
+	return m.redis.Subscribe(m.Keys().TopicRoomEvents(roomID))
+}
+
+// RelayData forwards a data message from a peer to one peer in its room, or all of them.
+// Each sender's messages are relayed in order from its PeerChannel, so they arrive in order
+func (m *Manager) RelayData(from *pb.UserData, data *pb.DataMessage) error {
+	relay := &pb.DataMessage{
+		Label:   data.GetLabel(),
+		Payload: data.GetPayload(),
+		To:      data.GetTo(),
+		From:    from.Id,
+	}
+	reply := &pb.NoirReply{
+		Command: &pb.NoirReply_Signal{
+			Signal: &pb.SignalReply{
+				Id:      relay.To,
+				Payload: &pb.SignalReply_Data{Data: relay},
+			},
+		},
+	}
+
+	if relay.To == "" {
+		m.NotifyRoomPeers(from.RoomID, from.Id, reply)
+		return nil
+	}
+
+	inRoom, err := m.redis.HExists(m.Keys().RoomUsers(from.RoomID), relay.To).Result()
+	if err != nil {
+		return err
+	}
+	if !inRoom {
+		return errors.New("no such peer in room")
+	}
+	if err := m.EnqueueReply(m.GetQueue(m.Keys().TopicFromPeer(relay.To)), reply); err != nil {
+		return err
+	}
+	return m.redis.Publish(m.Keys().PeerNewsChannel(relay.To), relay.To).Err()
+}
+
+// NotifyRoomPeers sends a signal reply to every peer in the room except one
+func (m *Manager) NotifyRoomPeers(roomID string, except string, reply *pb.NoirReply) {
+	for _, pid := range m.redis.HKeys(m.Keys().RoomUsers(roomID)).Val() {
+		if pid == except {
+			continue
+		}
+		if signal := reply.GetSignal(); signal != nil {
+			signal.Id = pid
+		}
+		if err := m.EnqueueReply(m.GetQueue(m.Keys().TopicFromPeer(pid)), reply); err != nil {
+			log.Errorf("error notifying peer %s: %s", pid, err)
+			continue
+		}
+		m.redis.Publish(m.Keys().PeerNewsChannel(pid), pid)
+	}
+}
+
+// ConnectUser creates the peer for a join, identity comes from Authenticate
+func (m *Manager) ConnectUser(signal *pb.SignalRequest, identity string, role pb.Role, version string) (PeerAdapter, *pb.UserData, error) {
 	join := signal.GetJoin()
 	pid := signal.Id
-	provider := *m.SFU()
+	if err := m.claimNodeSlot(pid); err != nil {
+		return nil, nil, err
+	}
+	defer m.releaseNodeSlot()
 	room, err := m.CreateRoomIfNotExists(join.Sid)
 
 	var offer webrtc.SessionDescription
@@ -242,18 +727,31 @@ func (m *Manager) ConnectUser(signal *pb.SignalRequest) (*sfu.Peer, *pb.UserData
 		publishing = true
 	}
 
-	peer := sfu.NewPeer(provider)
+	peer := m.CreateClient(pid)
 
 	// TODO -- Check if user exists first
 	userData := &pb.UserData{
-		Id:         pid,
-		LastUpdate: timestamppb.Now(),
-		RoomID:     join.Sid,
-		Publishing: publishing,
-		Options:    &pb.UserOptions{MaxAgeSeconds: -1},
+		Id:             pid,
+		Created:        timestamppb.Now(),
+		LastUpdate:     timestamppb.Now(),
+		RoomID:         join.Sid,
+		Publishing:     publishing,
+		Options:        &pb.UserOptions{MaxAgeSeconds: -1},
+		Limits:         m.BitrateLimitsFor(join.GetLimits()),
+		Identity:       identity,
+		Role:           role,
+		QualityReports: join.GetQualityReports(),
+		Keepalive:      join.GetKeepalive(),
+		AdaptiveLayers: join.GetAdaptiveLayers(),
+		Version:        version,
+		TrackLabels:    signal.GetTrackLabels(),
 	}
 
-	m.SaveData(pb.KeyUserData(pid), &pb.NoirObject{Data: &pb.NoirObject_User{User: userData}}, 0)
+	m.SaveData(m.Keys().UserData(pid), &pb.NoirObject{Data: &pb.NoirObject_User{User: userData}}, 0)
+	m.redis.HSet(m.Keys().RoomUsers(join.Sid), pid, 1)
+	if identity != "" {
+		m.redis.SAdd(m.Keys().IdentityPeers(identity), pid)
+	}
 
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -268,19 +766,150 @@ func (m *Manager) UpdateRoomScore(roomID string) {
 	if room, ok := m.rooms[roomID]; ok {
 		score := float64(len(room.session.Peers()))
 		if score > 0 {
-			m.redis.ZAdd(pb.KeyRoomScores(), redis.Z{
+			m.redis.ZAdd(m.Keys().RoomScores(), redis.Z{
 				Score:  score,
 				Member: roomID,
 			})
 		} else {
-			m.redis.ZRem(pb.KeyRoomScores(), roomID)
+			m.redis.ZRem(m.Keys().RoomScores(), roomID)
 		}
 	} else {
 	}
 }
 
 func (m *Manager) GetQueue(topic string) Queue {
-	return NewRedisQueue(m.redis, topic, QueueMessageTimeout)
+	return m.NewQueue(topic, QueueMessageTimeout)
+}
+
+func (m *Manager) NewQueue(topic string, maxAge time.Duration) Queue {
+	return m.queues(topic, maxAge)
+}
+
+func (m *Manager) AllowAutoCreateRooms() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.allowAutoCreateRooms
+}
+
+func (m *Manager) SetAllowAutoCreateRooms(allow bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.allowAutoCreateRooms = allow
+}
+
+func (m *Manager) SetICEServerProvider(provider ICEServerProvider) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.iceServers = provider
+}
+
+// ICEServers for a joining peer, nil when no provider is configured
+func (m *Manager) ICEServers(pid string) ([]*pb.ICEServer, error) {
+	m.mu.RLock()
+	provider := m.iceServers
+	m.mu.RUnlock()
+	if provider == nil {
+		return nil, nil
+	}
+	return provider.ICEServers(pid)
+}
+
+// SetAuthenticator checks every join from now on, nil lets anyone join
+func (m *Manager) SetAuthenticator(authenticator Authenticator) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.authenticator = authenticator
+}
+
+// Authenticate returns the identity behind a join, it is empty when there is no Authenticator
+func (m *Manager) Authenticate(signal *pb.SignalRequest) (string, error) {
+	m.mu.RLock()
+	authenticator := m.authenticator
+	m.mu.RUnlock()
+	if authenticator == nil {
+		return "", nil
+	}
+	return authenticator.Authenticate(signal)
+}
+
+// SetTimeouts overrides WebrtcTimeout and RouterMaxAge for this manager, 0 keeps the default
+func (m *Manager) SetTimeouts(webrtcTimeout time.Duration, routerMaxAge time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.webrtcTimeout = webrtcTimeout
+	m.routerMaxAge = routerMaxAge
+}
+
+// SetKeyPrefix puts every redis key and topic of this manager under prefix, "" is noir/. Like
+// SetTimeouts it has to be made before SetupManager, and every node of a deployment needs the same.
+// The keys are read without the manager's lock, which is often held around them
+func (m *Manager) SetKeyPrefix(prefix string) {
+	m.keys = pb.NewKeys(prefix)
+}
+
+// Keys builds this manager's redis keys and topics
+func (m *Manager) Keys() pb.Keys {
+	return m.keys
+}
+
+// RouterTopic is the topic of this manager's router, its key prefix
+func (m *Manager) RouterTopic() string {
+	return m.Keys().Prefix()
+}
+
+func (m *Manager) WebrtcTimeout() time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.webrtcTimeout > 0 {
+		return m.webrtcTimeout
+	}
+	return WebrtcTimeout
+}
+
+// RouterMaxAge is how long routed requests wait on a worker queue, it follows WebrtcTimeout unless set
+func (m *Manager) RouterMaxAge() time.Duration {
+	m.mu.RLock()
+	maxAge := m.routerMaxAge
+	m.mu.RUnlock()
+	if maxAge > 0 {
+		return maxAge
+	}
+	return m.WebrtcTimeout()
+}
+
+// SetResumeGrace keeps peers alive this long after their client goes away, 0 disconnects immediately
+func (m *Manager) SetResumeGrace(grace time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.resumeGrace = grace
+}
+
+func (m *Manager) ResumeGrace() time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.resumeGrace
+}
+
+// SetPeerQueueRetries is how many queue errors in a row a PeerChannel survives, 0 keeps the default
+func (m *Manager) SetPeerQueueRetries(retries int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.peerQueueRetries = retries
+}
+
+func (m *Manager) PeerQueueRetries() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.peerQueueRetries > 0 {
+		return m.peerQueueRetries
+	}
+	return PeerQueueRetries
+}
+
+func (m *Manager) SetQueueFactory(queues QueueFactory) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.queues = queues
 }
 
 func (m *Manager) WorkerForRoom(roomID string) (string, error) {
@@ -289,7 +918,7 @@ func (m *Manager) WorkerForRoom(roomID string) (string, error) {
 }
 
 func (m *Manager) NodeCount() int {
-	return int(m.redis.HLen(pb.KeyNodeMap()).Val())
+	return int(m.redis.HLen(m.Keys().NodeMap()).Val())
 }
 
 func (m *Manager) RoomCount() int {
@@ -321,7 +950,7 @@ func (m *Manager) Checkin() error {
 	if err != nil {
 		return err
 	}
-	err = m.redis.HSet(pb.KeyNodeMap(), id, value).Err()
+	err = m.redis.HSet(m.Keys().NodeMap(), id, value).Err()
 	if err != nil {
 		return err
 	}
@@ -341,7 +970,7 @@ func (m *Manager) SetRouter(r *Router) {
 }
 
 func (m *Manager) RandomWorkerId() (string, error) {
-	ids, err := m.redis.HKeys(pb.KeyNodeMap()).Result()
+	ids, err := m.redis.HKeys(m.Keys().NodeMap()).Result()
 	if err != nil || len(ids) == 0 {
 		return "", errors.New("no nodes available")
 	}
@@ -349,7 +978,7 @@ func (m *Manager) RandomWorkerId() (string, error) {
 }
 
 func (m *Manager) GetRemoteWorkerQueue(id string) *Queue {
-	queue := NewRedisWorkerQueue(m.redis, id)
+	queue := m.NewQueue(m.Keys().WorkerTopic(id), m.RouterMaxAge())
 	return &queue
 }
 
@@ -373,6 +1002,9 @@ func (m *Manager) NodesForService(service string) []string {
 	defer m.mu.RUnlock()
 	available := []string{}
 	for _, nodeData := range m.nodes {
+		if m.placeable != nil && !m.placeable[nodeData.Id] {
+			continue
+		}
 		if ServiceInList(service, nodeData.Services) && ValidateHealthy(&nodeData) {
 			available = append(available, nodeData.Id)
 		}
@@ -391,9 +1023,10 @@ func (m *Manager) RandomNodeForService(service string) (string, error) {
 }
 
 func (m *Manager) UpdateAvailableNodes() error {
+	m.updatePlaceableWorkers()
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	ids, err := m.redis.HKeys(pb.KeyNodeMap()).Result()
+	ids, err := m.redis.HKeys(m.Keys().NodeMap()).Result()
 	if err != nil {
 		log.Errorf("error getting nodes from redis %s", err)
 		return err
@@ -402,7 +1035,7 @@ func (m *Manager) UpdateAvailableNodes() error {
 	m.nodes = make(map[string]pb.NodeData)
 
 	for _, id := range ids {
-		status, err := m.redis.HGet(pb.KeyNodeMap(), id).Result()
+		status, err := m.redis.HGet(m.Keys().NodeMap(), id).Result()
 		if err != nil {
 			log.Errorf("error getting worker jobData %s", err)
 			return err
@@ -441,6 +1074,10 @@ func (m *Manager) LookupSignalRoomID(signal *pb.SignalRequest) (string, error) {
 	switch signal.Payload.(type) {
 	case *pb.SignalRequest_Join:
 		return signal.GetJoin().Sid, nil
+	case *pb.SignalRequest_Play:
+		return signal.GetPlay().Sid, nil
+	case *pb.SignalRequest_Capabilities:
+		return signal.GetCapabilities().Sid, nil
 	}
 	user, err := m.GetRemoteUserData(signal.Id)
 	if err != nil || user == nil {
@@ -455,21 +1092,98 @@ func (m *Manager) GetLocalRoom(roomID string) (*Room, error) {
 }
 
 func (m *Manager) GetRemoteRoomExists(roomID string) (bool, error) {
-	val, err := m.redis.Exists(pb.KeyRoomData(roomID)).Result()
-	return int(val) == 1, err
+	_, err := m.RoomStore().Get(roomID)
+	if errors.Is(err, ErrRoomNotFound) {
+		return false, nil
+	}
+	return err == nil, err
 }
 
 func (m *Manager) GetRemoteRoomData(roomID string) (*pb.RoomData, error) {
-	loaded, err := m.LoadData(pb.KeyRoomData(roomID))
+	loaded, err := m.RoomStore().Get(roomID)
 	if err != nil {
 		log.Errorf("error loading room jobData! %s", err)
 		return nil, err
 	}
 	room := m.rooms[roomID]
-	room.UpdateData(loaded.GetRoom())
+	room.UpdateData(loaded)
 	return room.LatestData(), nil
 }
 
+// GetRoomData loads a room straight from its store, without touching the local room
+func (m *Manager) GetRoomData(roomID string) (*pb.RoomData, error) {
+	return m.RoomStore().Get(roomID)
+}
+
+// SetRoomData saves a room's data, its options decide when it expires
+func (m *Manager) SetRoomData(data *pb.RoomData) error {
+	if data.GetId() == "" {
+		return errors.New("room data needs an id")
+	}
+	if data.Created == nil {
+		data.Created = timestamppb.Now()
+	}
+	if data.Options == nil {
+		data.Options = &pb.RoomOptions{MaxAgeSeconds: -1}
+	}
+	data.LastUpdate = timestamppb.Now()
+	return SaveRoomData(data.Id, data, m)
+}
+
+// ListRooms returns every room in the cluster, a page of RoomScanCount keys at a time
+func (m *Manager) ListRooms() ([]*pb.RoomData, error) {
+	rooms := []*pb.RoomData{}
+	cursor := uint64(0)
+	for {
+		page, next, err := m.ListRoomsPage(cursor, RoomScanCount)
+		if err != nil {
+			return rooms, err
+		}
+		rooms = append(rooms, page...)
+		if next == 0 {
+			return rooms, nil
+		}
+		cursor = next
+	}
+}
+
+// ListRoomPeers returns the data of every peer in the room, skipping peers that left since it was read
+func (m *Manager) ListRoomPeers(roomID string) ([]*pb.UserData, error) {
+	pids, err := m.redis.HKeys(m.Keys().RoomUsers(roomID)).Result()
+	if err != nil {
+		return []*pb.UserData{}, err
+	}
+	peers := make([]*pb.UserData, 0, len(pids))
+	for _, pid := range pids {
+		userData, err := m.GetRemoteUserData(pid)
+		if err != nil || userData == nil {
+			continue
+		}
+		peers = append(peers, userData)
+	}
+	return peers, nil
+}
+
+// ListRoomsPage is one page of the room store, start with cursor 0 and stop when the returned cursor is 0.
+// Pages can be empty or repeat rooms, as with any redis SCAN. Rooms past their cleanup time are dropped
+func (m *Manager) ListRoomsPage(cursor uint64, count int64) ([]*pb.RoomData, uint64, error) {
+	page, next, err := m.RoomStore().List(cursor, count)
+	if err != nil {
+		return []*pb.RoomData{}, next, err
+	}
+	rooms := make([]*pb.RoomData, 0, len(page))
+	for _, room := range page {
+		if IsRoomStale(room) {
+			log.Infof("room %s is past its cleanup time, removing", room.Id)
+			m.RoomStore().Delete(room.Id)
+			m.redis.ZRem(m.Keys().RoomScores(), room.Id)
+			continue
+		}
+		rooms = append(rooms, room)
+	}
+	return rooms, next, nil
+}
+
 func (m *Manager) ClaimRoomNode(roomID string, nodeID string) (bool, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -478,7 +1192,7 @@ func (m *Manager) ClaimRoomNode(roomID string, nodeID string) (bool, error) {
 		data := &room.data
 		data.NodeID = m.id
 		err := SaveRoomData(roomID, data, m)
-		m.redis.HSet(pb.KeyNodeRooms(m.id), roomID, 1)
+		m.redis.HSet(m.Keys().NodeRooms(m.id), roomID, 1)
 		log.Infof("claimed room %s", roomID)
 		return err == nil, err
 	} else {
@@ -490,7 +1204,7 @@ func (m *Manager) ClaimRoomNode(roomID string, nodeID string) (bool, error) {
 			}
 			data.NodeID = m.id
 			err := SaveRoomData(roomID, data, m)
-			m.redis.HSet(pb.KeyNodeRooms(m.id), roomID, 1)
+			m.redis.HSet(m.Keys().NodeRooms(m.id), roomID, 1)
 			log.Infof("claimed room %s", roomID)
 			return err == nil, err
 		} else if err != nil {
@@ -541,13 +1255,13 @@ func (m *Manager) WorkerData(id string) *pb.NodeData {
 }
 
 func (m *Manager) MarkOffline(nodeID string) {
-	for _, room := range m.redis.HKeys(pb.KeyNodeRooms(nodeID)).Val() {
-		for _, user := range m.redis.HKeys(pb.KeyRoomUsers(room)).Val() {
-			m.redis.Del(pb.KeyUserData(user))
+	for _, room := range m.redis.HKeys(m.Keys().NodeRooms(nodeID)).Val() {
+		for _, user := range m.redis.HKeys(m.Keys().RoomUsers(room)).Val() {
+			m.redis.Del(m.Keys().UserData(user), m.Keys().UserResume(user), m.Keys().UserJoined(user))
 		}
 	}
-	m.redis.Del(pb.KeyNodeRooms(nodeID))
-	m.redis.HDel(pb.KeyNodeMap(), nodeID)
+	m.redis.Del(m.Keys().NodeRooms(nodeID))
+	m.redis.HDel(m.Keys().NodeMap(), nodeID)
 }
 
 func (m *Manager) CountMatchingKeys(pattern string) (int64, error) {
@@ -575,13 +1289,13 @@ func (m *Manager) CreateRoomIfNotExists(roomID string) (*pb.RoomData, error) {
 	if room, ok := m.rooms[roomID]; ok {
 		return &room.data, nil // Room exists
 	}
-	exists, err := m.redis.Exists(pb.KeyRoomData(roomID)).Result()
+	exists, err := m.GetRemoteRoomExists(roomID)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if exists == 1 {
+	if exists {
 		data, err := m.GetRemoteRoomData(roomID)
 		if err == nil {
 			return data, nil // Room exists
@@ -595,19 +1309,21 @@ func (m *Manager) CreateRoomIfNotExists(roomID string) (*pb.RoomData, error) {
 		room.data.NodeID = m.id
 		room.data.LastUpdate = timestamppb.Now()
 
-		SaveRoomData(roomID, &room.data, m)
+		created, err := createRoomData(roomID, &room.data, m)
+		if err != nil {
+			return nil, err
+		}
+		if !created {
+			// another node created it first
+			return m.GetRemoteRoomData(roomID)
+		}
 
 		return &room.data, nil
 	}
 }
 
-func (m *Manager) ValidateOffer(room *pb.RoomData, userID string, offer webrtc.SessionDescription) (*sdp.SessionDescription, error) {
-	desc, err := ParseSDP(offer)
-	return desc, err
-}
-
 func (m *Manager) GetRemoteUserData(userID string) (*pb.UserData, error) {
-	loaded, err := m.LoadData(pb.KeyUserData(userID))
+	loaded, err := m.LoadData(m.Keys().UserData(userID))
 	if err != nil {
 		log.Errorf("error loading user#%s jobData! %s", userID, err)
 		return nil, err
@@ -617,7 +1333,7 @@ func (m *Manager) GetRemoteUserData(userID string) (*pb.UserData, error) {
 
 func (m *Manager) GetRemoteNodeData(nodeID string) (*pb.NodeData, error) {
 	loaded := &pb.NoirObject{}
-	data, err := m.redis.HGet(pb.KeyNodeMap(), nodeID).Result()
+	data, err := m.redis.HGet(m.Keys().NodeMap(), nodeID).Result()
 	if err != nil {
 		log.Warnf("failed loading noirstatus %s", err)
 		return nil, err
@@ -636,7 +1352,7 @@ func (m *Manager) GetRemoteNodeData(nodeID string) (*pb.NodeData, error) {
 }
 
 func (m *Manager) ValidateHealthyNodeID(nodeID string) error {
-	exists, _ := m.redis.HExists(pb.KeyNodeMap(), nodeID).Result()
+	exists, _ := m.redis.HExists(m.Keys().NodeMap(), nodeID).Result()
 	if exists == false {
 		return errors.New("no such room")
 	}
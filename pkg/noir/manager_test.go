@@ -1,9 +1,11 @@
 package noir
 
 import (
+	"github.com/go-redis/redis"
 	pb "github.com/net-prophet/noir/pkg/proto"
 	log "github.com/pion/ion-log"
 	"testing"
+	"time"
 )
 
 func TestHealth_Checkin(t *testing.T) {
@@ -21,6 +23,38 @@ func TestHealth_Checkin(t *testing.T) {
 	}
 }
 
+func TestHealth_Heartbeat(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetHeartbeatInterval(2 * time.Second)
+	if err := mgr.Heartbeat("test-worker", 3); err != nil {
+		t.Fatalf("error writing heartbeat: %s", err)
+	}
+	if ttl := redis.TTL(pb.KeyWorkerHeartbeat("test-worker")).Val(); ttl <= 4*time.Second || ttl > 6*time.Second {
+		t.Errorf("got heartbeat ttl %s want 3 intervals", ttl)
+	}
+
+	workers, err := mgr.ListWorkers()
+	if err != nil || len(workers) != 1 || workers[0].ID != "test-worker" || workers[0].Peers != 3 {
+		t.Errorf("got %v %v want test-worker with 3 peers", workers, err)
+	}
+
+	// routing goes by the heartbeats of the last health tick
+	mgr.UpdateAvailableNodes()
+	mgr.ClearHeartbeat("test-worker")
+	if nodes := mgr.NodesForService("sfu"); len(nodes) != 1 {
+		t.Errorf("got %v want test-worker until the next tick", nodes)
+	}
+	mgr.Supervise()
+	if nodes := mgr.NodesForService("sfu"); len(nodes) != 0 {
+		t.Errorf("got %v want no nodes once the heartbeat is gone", nodes)
+	}
+	mgr.Heartbeat("test-worker", 0)
+	mgr.UpdateAvailableNodes()
+	if nodes := mgr.NodesForService("sfu"); len(nodes) != 1 {
+		t.Errorf("got %v want test-worker back", nodes)
+	}
+}
+
 func TestHealth_AvailableWorkers(t *testing.T) {
 	mgr, _ := NewTestSetup()
 	router := *mgr.GetRouter()
@@ -99,3 +133,102 @@ func TestHealth_Rooms(t *testing.T) {
 	}
 
 }
+
+func TestRelayData(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	roomID := "test data room"
+	peers := []string{"data-a", "data-b", "data-c"}
+	redis.Del(pb.KeyRoomUsers(roomID))
+	for _, pid := range peers {
+		redis.Del(pb.KeyTopicFromPeer(pid))
+		redis.HSet(pb.KeyRoomUsers(roomID), pid, 1)
+	}
+	from := &pb.UserData{Id: "data-a", RoomID: roomID}
+
+	tests := []struct {
+		data *pb.DataMessage
+		want []int64
+	}{
+		{&pb.DataMessage{Label: "chat", Payload: []byte("hi all")}, []int64{0, 1, 1}},
+		{&pb.DataMessage{Label: "chat", Payload: []byte("hi c"), To: "data-c"}, []int64{0, 1, 2}},
+	}
+
+	for _, tt := range tests {
+		if err := mgr.RelayData(from, tt.data); err != nil {
+			t.Errorf("error relaying %s", err)
+		}
+		for i, pid := range peers {
+			got, _ := mgr.GetQueue(pb.KeyTopicFromPeer(pid)).Count()
+			if got != tt.want[i] {
+				t.Errorf("%s got %d messages want %d", pid, got, tt.want[i])
+			}
+		}
+	}
+
+	if err := mgr.RelayData(from, &pb.DataMessage{To: "not-in-room"}); err == nil {
+		t.Errorf("relaying to a peer outside the room should fail")
+	}
+
+	redis.Del(pb.KeyRoomUsers(roomID))
+	for _, pid := range peers {
+		redis.Del(pb.KeyTopicFromPeer(pid))
+	}
+}
+
+// newPrefixedManager is a manager like NewTestSetup's, with its keys under prefix
+func newPrefixedManager(client *redis.Client, nodeID string, prefix string) *Manager {
+	sfu := NewNoirSFU(Config{})
+	mgr := NewRedisManager(&sfu, client, nodeID, "*")
+	mgr.SetKeyPrefix(prefix)
+	return SetupManager(mgr, RedisQueueFactory(client))
+}
+
+func TestKeyPrefix(t *testing.T) {
+	_, client := NewTestSetup()
+	cleanup := func() {
+		for _, pattern := range []string{"alpha/*", "beta/*"} {
+			if keys := client.Keys(pattern).Val(); len(keys) > 0 {
+				client.Del(keys...)
+			}
+		}
+	}
+	cleanup()
+	defer cleanup()
+	alpha := newPrefixedManager(client, "alpha-worker", "alpha")
+	beta := newPrefixedManager(client, "beta-worker", "beta/")
+
+	if key := alpha.Keys().RoomData("prefixed-room"); key != "alpha/obj/room/prefixed-room" {
+		t.Errorf("got %s want the room under alpha/", key)
+	}
+	if alpha.RouterTopic() == beta.RouterTopic() {
+		t.Errorf("got router topic %s for both prefixes", alpha.RouterTopic())
+	}
+	sfu := NewNoirSFU(Config{})
+	if NewRedisManager(&sfu, client, "default-worker", "*").Keys() != pb.DefaultKeys {
+		t.Errorf("a manager should use noir/ until SetKeyPrefix")
+	}
+
+	alpha.SetRoomData(&pb.RoomData{Id: "prefixed-room"})
+	if _, err := alpha.GetRoomData("prefixed-room"); err != nil {
+		t.Errorf("error getting alpha's own room: %s", err)
+	}
+	if _, err := beta.GetRoomData("prefixed-room"); err == nil {
+		t.Errorf("beta should not see a room of alpha")
+	}
+
+	for _, topic := range []func(m *Manager) string{
+		func(m *Manager) string { return m.RouterTopic() },
+		func(m *Manager) string { return m.Keys().TopicToPeer("prefixed-peer") },
+		func(m *Manager) string { return m.Keys().WorkerTopic("shared-worker") },
+	} {
+		if err := alpha.GetQueue(topic(alpha)).Add([]byte("for alpha")); err != nil {
+			t.Fatalf("error queueing for alpha: %s", err)
+		}
+		if count, _ := beta.GetQueue(topic(beta)).Count(); count != 0 {
+			t.Errorf("got %d messages in %s want none of alpha's", count, topic(beta))
+		}
+		if count, _ := alpha.GetQueue(topic(alpha)).Count(); count != 1 {
+			t.Errorf("got %d messages in %s want alpha's", count, topic(alpha))
+		}
+	}
+}
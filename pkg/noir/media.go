@@ -0,0 +1,212 @@
+package noir
+
+import (
+	"errors"
+	"fmt"
+	"github.com/pion/webrtc/v3"
+	"github.com/pion/webrtc/v3/pkg/media/ivfreader"
+	"github.com/pion/webrtc/v3/pkg/media/oggreader"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// PlayFileJobHandler is the job files play as, register jobs.NewPlayFileHandler under it
+const PlayFileJobHandler = "PlayFile"
+
+// PlayFileOptions are the RoomJobRequest options of a PlayFileJobHandler job
+type PlayFileOptions struct {
+	Filename string `json:"filename"`
+	// A positive repeat will play the file N more times, a negative repeat will loop forever
+	Repeat int `json:"repeat"`
+	// Only the segment from StartMs to EndMs into the file plays, and repeats, 0 is the end
+	StartMs int64 `json:"startMs,omitempty"`
+	EndMs   int64 `json:"endMs,omitempty"`
+}
+
+// Segment is where in the file playing starts and ends, an end of 0 is the end of the file
+func (o PlayFileOptions) Segment() (time.Duration, time.Duration) {
+	return time.Duration(o.StartMs) * time.Millisecond, time.Duration(o.EndMs) * time.Millisecond
+}
+
+// Used when SetPlaybackDir was not called
+const DefaultPlaybackDir = "media"
+
+var ErrUnsupportedCodec = errors.New("unsupported codec")
+
+// ErrPlaybackPath is a play of a file outside the playback dir
+var ErrPlaybackPath = errors.New("file outside the playback dir")
+
+func (m *Manager) SetPlaybackDir(dir string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.playbackDir = dir
+}
+
+func (m *Manager) PlaybackDir() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.playbackDir != "" {
+		return m.playbackDir
+	}
+	return DefaultPlaybackDir
+}
+
+// PlaybackPath is the file a play of filename reads, filename is taken under PlaybackDir and
+// refused when it leaves it
+func (m *Manager) PlaybackPath(filename string) (string, error) {
+	root := m.PlaybackDir()
+	path := filepath.Join(root, filename)
+	if rel, err := filepath.Rel(root, path); err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %s", ErrPlaybackPath, filename)
+	}
+	return path, nil
+}
+
+// ErrPlayRange is a PlayFileOptions segment that is not in the file
+var ErrPlayRange = errors.New("play range outside the file")
+
+// ProbeMediaFile returns the mime type of a file we can play into a room:
+// IVF with VP8 or VP9, or Ogg with Opus
+func ProbeMediaFile(filename string) (string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(file, magic); err != nil {
+		return "", fmt.Errorf("%w, %s is too short: %s", ErrUnsupportedCodec, filename, err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	switch string(magic) {
+	case "DKIF":
+		_, header, err := ivfreader.NewWith(file)
+		if err != nil {
+			return "", fmt.Errorf("%w, bad ivf file %s: %s", ErrUnsupportedCodec, filename, err)
+		}
+		switch header.FourCC {
+		case "VP80":
+			return webrtc.MimeTypeVP8, nil
+		case "VP90":
+			return webrtc.MimeTypeVP9, nil
+		}
+		return "", fmt.Errorf("%w %s in %s", ErrUnsupportedCodec, header.FourCC, filename)
+	case "OggS":
+		if _, _, err := oggreader.NewWith(file); err != nil {
+			return "", fmt.Errorf("%w, %s is not an opus ogg file: %s", ErrUnsupportedCodec, filename, err)
+		}
+		return webrtc.MimeTypeOpus, nil
+	}
+	return "", fmt.Errorf("%w, %s is not an ivf or ogg file", ErrUnsupportedCodec, filename)
+}
+
+// CheckPlayRange is an ErrPlayRange unless the options' segment is in the file
+func CheckPlayRange(filename string, options PlayFileOptions) error {
+	start, end := options.Segment()
+	if start < 0 || end < 0 || (end > 0 && end <= start) {
+		return fmt.Errorf("%w, %s to %s is not a segment", ErrPlayRange, start, end)
+	}
+	if start == 0 {
+		return nil
+	}
+	length, err := MediaFileDuration(filename)
+	if err != nil {
+		return err
+	}
+	if start >= length {
+		return fmt.Errorf("%w, %s starts at %s but is %s long", ErrPlayRange, filename, start, length)
+	}
+	return nil
+}
+
+// MediaFileDuration is how long a file ProbeMediaFile accepts plays for, read to its end since
+// neither format says
+func MediaFileDuration(filename string) (time.Duration, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(file, magic); err != nil {
+		return 0, fmt.Errorf("%w, %s is too short: %s", ErrUnsupportedCodec, filename, err)
+	}
+	file.Seek(0, io.SeekStart)
+	if string(magic) == "OggS" {
+		ogg, _, err := oggreader.NewWith(file)
+		if err != nil {
+			return 0, err
+		}
+		var granule uint64
+		for {
+			_, header, err := ogg.ParseNextPage()
+			if err == io.EOF {
+				return OpusDuration(granule), nil
+			} else if err != nil {
+				return 0, err
+			}
+			granule = header.GranulePosition
+		}
+	}
+	ivf, header, err := ivfreader.NewWith(file)
+	if err != nil {
+		return 0, err
+	}
+	var frames uint64
+	for {
+		_, frame, err := ivf.ParseNextFrame()
+		if err == io.EOF {
+			return IVFDuration(header, frames), nil
+		} else if err != nil {
+			return 0, err
+		}
+		frames = frame.Timestamp + 1
+	}
+}
+
+// OpusDuration is how long an ogg opus granule position is into the file, opus always runs at 48kHz
+func OpusDuration(granule uint64) time.Duration {
+	return time.Duration(granule) * time.Second / 48000
+}
+
+// IVFDuration is how long a timestamp of an ivf file, in its timebase, is into it
+func IVFDuration(header *ivfreader.IVFFileHeader, timestamp uint64) time.Duration {
+	if header.TimebaseDenominator == 0 {
+		return 0
+	}
+	return time.Duration(timestamp) * time.Second * time.Duration(header.TimebaseNumerator) / time.Duration(header.TimebaseDenominator)
+}
+
+// IsKeyframe is whether a VP8 or VP9 frame of an ivf file can be decoded on its own, which playing
+// from the middle of a file has to wait for
+func IsKeyframe(mimeType string, frame []byte) bool {
+	if len(frame) == 0 {
+		return false
+	}
+	switch mimeType {
+	case webrtc.MimeTypeVP8:
+		// the frame tag's lowest bit is 0 for a key frame
+		return frame[0]&0x01 == 0
+	case webrtc.MimeTypeVP9:
+		// frame_marker, profile, [reserved_zero], show_existing_frame, frame_type from the top bit down
+		profile := (frame[0]>>5)&1 | (frame[0]>>4)&1<<1
+		showExisting := uint(3)
+		if profile == 3 {
+			showExisting = 2
+		}
+		if (frame[0]>>showExisting)&1 == 1 {
+			// shows a frame decoded before
+			return false
+		}
+		return (frame[0]>>(showExisting-1))&1 == 0
+	}
+	return true
+}
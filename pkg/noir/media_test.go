@@ -0,0 +1,158 @@
+package noir
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"github.com/pion/rtp"
+	"github.com/pion/webrtc/v3"
+	"github.com/pion/webrtc/v3/pkg/media/ivfwriter"
+	"github.com/pion/webrtc/v3/pkg/media/oggwriter"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestProbeMediaFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "noir-media")
+	if err != nil {
+		t.Fatalf("error making temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	var ivf bytes.Buffer
+	ivfWriter, _ := ivfwriter.NewWith(&ivf)
+	ivfWriter.Close()
+	h264 := append([]byte{}, ivf.Bytes()...)
+	copy(h264[8:12], "H264")
+
+	var ogg bytes.Buffer
+	oggWriter, _ := oggwriter.NewWith(&ogg, 48000, 2)
+	oggWriter.Close()
+
+	files := map[string][]byte{
+		"video.ivf": ivf.Bytes(),
+		"h264.ivf":  h264,
+		"audio.ogg": ogg.Bytes(),
+		"text.txt":  []byte("not a media file"),
+	}
+	for name, contents := range files {
+		ioutil.WriteFile(filepath.Join(dir, name), contents, 0644)
+	}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"video.ivf", webrtc.MimeTypeVP8},
+		{"audio.ogg", webrtc.MimeTypeOpus},
+		{"h264.ivf", ""},
+		{"text.txt", ""},
+	}
+	for _, tt := range tests {
+		got, err := ProbeMediaFile(filepath.Join(dir, tt.name))
+		if tt.want == "" && !errors.Is(err, ErrUnsupportedCodec) {
+			t.Errorf("%s: got %s %v want ErrUnsupportedCodec", tt.name, got, err)
+		} else if tt.want != "" && got != tt.want {
+			t.Errorf("%s: got %s %v want %s", tt.name, got, err, tt.want)
+		}
+	}
+
+	if _, err := ProbeMediaFile(filepath.Join(dir, "missing.ivf")); !os.IsNotExist(err) {
+		t.Errorf("got %v want a not exist error", err)
+	}
+}
+
+// writeTestIVF writes a second of 30fps VP8, keyframes every 15 frames
+func writeTestIVF(filename string) {
+	var ivf bytes.Buffer
+	header := make([]byte, 32)
+	copy(header, "DKIF")
+	binary.LittleEndian.PutUint16(header[6:], 32)
+	copy(header[8:], "VP80")
+	binary.LittleEndian.PutUint32(header[16:], 30)
+	binary.LittleEndian.PutUint32(header[20:], 1)
+	ivf.Write(header)
+	for frame := uint64(0); frame < 30; frame++ {
+		frameHeader := make([]byte, 12)
+		binary.LittleEndian.PutUint32(frameHeader, 1)
+		binary.LittleEndian.PutUint64(frameHeader[4:], frame)
+		ivf.Write(frameHeader)
+		if frame%15 == 0 {
+			ivf.WriteByte(0x00)
+		} else {
+			ivf.WriteByte(0x01)
+		}
+	}
+	ioutil.WriteFile(filename, ivf.Bytes(), 0644)
+}
+
+// writeTestOgg writes a second of opus in 20ms pages
+func writeTestOgg(filename string) {
+	var ogg bytes.Buffer
+	oggWriter, _ := oggwriter.NewWith(&ogg, 48000, 2)
+	for packet := uint32(0); packet <= 50; packet++ {
+		oggWriter.WriteRTP(&rtp.Packet{Header: rtp.Header{Timestamp: 1 + packet*960}, Payload: []byte{0xfc}})
+	}
+	oggWriter.Close()
+	ioutil.WriteFile(filename, ogg.Bytes(), 0644)
+}
+
+func TestCheckPlayRange(t *testing.T) {
+	dir, err := ioutil.TempDir("", "noir-media")
+	if err != nil {
+		t.Fatalf("error making temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	video, audio := filepath.Join(dir, "video.ivf"), filepath.Join(dir, "audio.ogg")
+	writeTestIVF(video)
+	writeTestOgg(audio)
+
+	for _, filename := range []string{video, audio} {
+		// give or take a frame or page
+		if length, err := MediaFileDuration(filename); err != nil || length < 960*time.Millisecond || length > time.Second {
+			t.Errorf("%s: got %s %v want 1s", filename, length, err)
+		}
+	}
+	for _, test := range []struct {
+		options PlayFileOptions
+		valid   bool
+	}{
+		{PlayFileOptions{}, true},
+		{PlayFileOptions{StartMs: 500, EndMs: 800}, true},
+		{PlayFileOptions{EndMs: 5000}, true},
+		{PlayFileOptions{StartMs: 1000}, false},
+		{PlayFileOptions{StartMs: 500, EndMs: 500}, false},
+		{PlayFileOptions{StartMs: -1}, false},
+	} {
+		for _, filename := range []string{video, audio} {
+			if err := CheckPlayRange(filename, test.options); (err == nil) != test.valid || (err != nil && !errors.Is(err, ErrPlayRange)) {
+				t.Errorf("%s %+v: got %v want valid %t", filename, test.options, err, test.valid)
+			}
+		}
+	}
+}
+
+func TestIsKeyframe(t *testing.T) {
+	for _, test := range []struct {
+		mimeType string
+		frame    []byte
+		key      bool
+	}{
+		{webrtc.MimeTypeVP8, []byte{0x10}, true},
+		{webrtc.MimeTypeVP8, []byte{0x11}, false},
+		// profile 0 key and inter frames
+		{webrtc.MimeTypeVP9, []byte{0x82}, true},
+		{webrtc.MimeTypeVP9, []byte{0x86}, false},
+		// a shown existing frame, and a profile 3 key frame
+		{webrtc.MimeTypeVP9, []byte{0x88}, false},
+		{webrtc.MimeTypeVP9, []byte{0xb0}, true},
+		{webrtc.MimeTypeVP8, nil, false},
+	} {
+		if key := IsKeyframe(test.mimeType, test.frame); key != test.key {
+			t.Errorf("%s %x: got keyframe %t want %t", test.mimeType, test.frame, key, test.key)
+		}
+	}
+}
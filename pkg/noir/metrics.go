@@ -0,0 +1,207 @@
+package noir
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sync"
+	"time"
+)
+
+// Signaling round trips are mostly sub-second, so the buckets are too
+var SignalingBuckets = []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5}
+
+var (
+	handleLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "noir",
+		Name:      "handle_duration_seconds",
+		Help:      "Time from a request being popped off the worker queue to being handled",
+		Buckets:   SignalingBuckets,
+	}, []string{"action"})
+
+	requestsHandled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "noir",
+		Name:      "requests_handled_total",
+		Help:      "Requests handled by this worker",
+	}, []string{"action"})
+
+	requestsErrored = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "noir",
+		Name:      "requests_errored_total",
+		Help:      "Requests that returned an error from the handler",
+	}, []string{"action"})
+
+	requestsDeduped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "noir",
+		Name:      "requests_deduped_total",
+		Help:      "Requests skipped because their id was already handled within the dedup window",
+	}, []string{"action"})
+
+	subscriptionsLimited = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "noir",
+		Name:      "subscriptions_limited_total",
+		Help:      "Peers that reached their room's maxSubscribedTracks and were left publishers to swap in",
+	})
+
+	peerChannels = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "noir",
+		Name:      "peer_channels",
+		Help:      "Active PeerChannel goroutines",
+	}, []string{"worker"})
+
+	joinsAtCapacity = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "noir",
+		Name:      "joins_at_capacity_total",
+		Help:      "Joins refused because this node already ran its Manager.MaxPeers",
+	})
+
+	peerGoroutines = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "noir",
+		Name:      "peer_goroutines",
+		Help:      "Goroutines running for peers, PeerChannel and those it starts",
+	}, []string{"worker"})
+
+	joinsBusy = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "noir",
+		Name:      "joins_busy_total",
+		Help:      "Joins refused because this node already ran its Manager.MaxPeerGoroutines",
+	})
+
+	subscriberOffers = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "noir",
+		Name:      "subscriber_offers_total",
+		Help:      "Offers the sfu sent its subscribers, one per renegotiation",
+	})
+
+	offersBundled = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "noir",
+		Name:      "offers_bundled_total",
+		Help:      "Renegotiations of joining subscribers folded into their first offer by Manager.OfferBundling",
+	})
+
+	eventsDropped = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "noir",
+		Name:      "events_dropped_total",
+		Help:      "Room events dropped from a slow SubscribeEvents consumer's buffer to make room for newer ones",
+	})
+
+	packetTapDrops = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "noir",
+		Name:      "packet_tap_drops_total",
+		Help:      "Packets not handed to a packet tap because it was PacketTapBacklog behind, by the tap",
+	}, []string{"tap"})
+
+	candidatesFiltered = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "noir",
+		Name:      "ice_candidates_filtered_total",
+		Help:      "ICE candidates dropped by the ICE policy, inbound from clients or outbound from the sfu",
+	}, []string{"direction"})
+
+	keyframesRequested = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "noir",
+		Name:      "keyframes_requested_total",
+		Help:      "PLIs sent to publishers for their new subscribers, one per coalescing window and track",
+	})
+
+	sdpRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "noir",
+		Name:      "sdp_rejected_total",
+		Help:      "Join offers refused for going over the room's SDP limits, by the limit, eg. bytes or media_sections",
+	}, []string{"reason"})
+
+	queueUndecryptable = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "noir",
+		Name:      "queue_undecryptable_total",
+		Help:      "Queued messages dead lettered because they did not decrypt with any of the node's keys",
+	})
+
+	replyCompressionBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "noir",
+		Name:      "reply_compression_bytes_total",
+		Help:      "Bytes of the replies sent compressed, before, plain, and after, compressed, by compression",
+	}, []string{"compression", "stage"})
+
+	queueDepths = &queueDepthCollector{
+		queues: map[string]Queue{},
+		desc: prometheus.NewDesc("noir_queue_depth",
+			"Messages waiting on a queue, sampled when scraped",
+			[]string{"topic"}, nil),
+	}
+
+	roomStatsGauges = newRoomStatsCollector()
+)
+
+// RegisterMetrics adds the noir collectors to a registry, eg. the one behind your /metrics
+func RegisterMetrics(registry *prometheus.Registry) error {
+	for _, collector := range []prometheus.Collector{
+		handleLatency,
+		requestsHandled,
+		requestsErrored,
+		requestsDeduped,
+		subscriptionsLimited,
+		peerChannels,
+		joinsAtCapacity,
+		peerGoroutines,
+		joinsBusy,
+		subscriberOffers,
+		offersBundled,
+		eventsDropped,
+		packetTapDrops,
+		candidatesFiltered,
+		keyframesRequested,
+		sdpRejected,
+		queueUndecryptable,
+		replyCompressionBytes,
+		queueDepths,
+		roomStatsGauges,
+	} {
+		if err := registry.Register(collector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func observeHandle(action string, started time.Time, err error) {
+	handleLatency.WithLabelValues(action).Observe(time.Since(started).Seconds())
+	requestsHandled.WithLabelValues(action).Inc()
+	if err != nil {
+		requestsErrored.WithLabelValues(action).Inc()
+	}
+}
+
+// TrackQueueDepth reports the queue's Count() on every scrape, until the func it returns is called
+func TrackQueueDepth(queue Queue) func() {
+	topic := queue.Topic()
+	queueDepths.mu.Lock()
+	defer queueDepths.mu.Unlock()
+	queueDepths.queues[topic] = queue
+	return func() {
+		queueDepths.mu.Lock()
+		defer queueDepths.mu.Unlock()
+		// the topic may be tracked again since, by whoever consumes it now
+		if queueDepths.queues[topic] == queue {
+			delete(queueDepths.queues, topic)
+		}
+	}
+}
+
+type queueDepthCollector struct {
+	mu     sync.Mutex
+	queues map[string]Queue
+	desc   *prometheus.Desc
+}
+
+func (c *queueDepthCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *queueDepthCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for topic, queue := range c.queues {
+		count, err := queue.Count()
+		if err != nil {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(count), topic)
+	}
+}
@@ -0,0 +1,131 @@
+package noir
+
+import (
+	"errors"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	"testing"
+)
+
+// gathered is the value of the sample of name with labels, the count of a histogram, 0 if none
+func gathered(t *testing.T, registry *prometheus.Registry, name string, labels map[string]string) float64 {
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("error gathering metrics: %s", err)
+	}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if want, ok := labels[label.GetName()]; ok && want != label.GetValue() {
+					continue metrics
+				}
+			}
+			switch {
+			case metric.GetHistogram() != nil:
+				return float64(metric.GetHistogram().GetSampleCount())
+			case metric.GetCounter() != nil:
+				return metric.GetCounter().GetValue()
+			default:
+				return metric.GetGauge().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
+func TestMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	if err := RegisterMetrics(registry); err != nil {
+		t.Fatalf("error registering metrics: %s", err)
+	}
+	if err := RegisterMetrics(registry); err == nil {
+		t.Errorf("registering twice should fail")
+	}
+
+	mgr, redis := NewTestSetup()
+	keys := emptyRoomKeys("metrics-room", "metrics-peer")
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+	w := (*mgr.GetWorker()).(*worker)
+	worker := *mgr.GetWorker()
+	queue := *worker.GetQueue()
+	queue.Cleanup()
+	defer queue.Cleanup()
+	TrackQueueDepth(queue)
+
+	worker.RegisterActionHandler("request.metrics.", func(request *pb.NoirRequest) error {
+		if request.Action == "request.metrics.fail" {
+			return errors.New("failed")
+		}
+		return nil
+	})
+	for _, action := range []string{"request.metrics.ok", "request.metrics.fail"} {
+		EnqueueRequest(queue, &pb.NoirRequest{Action: action})
+	}
+	if depth := gathered(t, registry, "noir_queue_depth", map[string]string{"topic": queue.Topic()}); depth != 2 {
+		t.Errorf("got depth %.0f want the 2 queued", depth)
+	}
+
+	ok := map[string]string{"action": "request.metrics.ok"}
+	fail := map[string]string{"action": "request.metrics.fail"}
+	handled, errored := gathered(t, registry, "noir_requests_handled_total", ok), gathered(t, registry, "noir_requests_errored_total", fail)
+	timed := gathered(t, registry, "noir_handle_duration_seconds", ok)
+	worker.HandleNext(0)
+	worker.HandleNext(0)
+	if got := gathered(t, registry, "noir_requests_handled_total", ok); got != handled+1 {
+		t.Errorf("got %.0f handled want %.0f", got, handled+1)
+	}
+	if got := gathered(t, registry, "noir_requests_errored_total", fail); got != errored+1 {
+		t.Errorf("got %.0f errored want %.0f", got, errored+1)
+	}
+	if got := gathered(t, registry, "noir_requests_errored_total", ok); got != 0 {
+		t.Errorf("got %.0f errored want a handled request not counted", got)
+	}
+	if got := gathered(t, registry, "noir_handle_duration_seconds", ok); got != timed+1 {
+		t.Errorf("got %.0f timed want %.0f", got, timed+1)
+	}
+	if depth := gathered(t, registry, "noir_queue_depth", map[string]string{"topic": queue.Topic()}); depth != 0 {
+		t.Errorf("got depth %.0f want the queue drained", depth)
+	}
+
+	byWorker := map[string]string{"worker": w.id}
+	channels := gathered(t, registry, "noir_peer_channels", byWorker)
+	mgr.SetAllowAutoCreateRooms(true)
+	joinFake(t, mgr, "metrics-peer", "metrics-room", newFakePeer())
+	waitFor(t, "the peer channel counted", func() bool {
+		return gathered(t, registry, "noir_peer_channels", byWorker) == channels+1
+	})
+	mgr.DisconnectUser("metrics-peer")
+	waitFor(t, "the peer channel gone", func() bool {
+		return gathered(t, registry, "noir_peer_channels", byWorker) == channels
+	})
+}
+
+func TestMetricsUntrackedOnStop(t *testing.T) {
+	mgr, _ := NewTestSetup()
+	w := NewWorker("metrics-stopped", mgr, NewListQueue("metrics-stopped-topic")).(*worker)
+	tracked := func() (bool, bool) {
+		queueDepths.mu.Lock()
+		_, queue := queueDepths.queues["metrics-stopped-topic"]
+		queueDepths.mu.Unlock()
+		roomStatsGauges.mu.Lock()
+		_, rooms := roomStatsGauges.managers[mgr.id]
+		roomStatsGauges.mu.Unlock()
+		return queue, rooms
+	}
+	go w.HandleForever()
+	waitFor(t, "the worker to run", w.running.get)
+	if queue, rooms := tracked(); !queue || !rooms {
+		t.Errorf("got queue %v rooms %v tracked want both while the worker runs", queue, rooms)
+	}
+
+	// a stopped worker's queue and rooms are not scraped
+	w.Stop()
+	if queue, rooms := tracked(); queue || rooms {
+		t.Errorf("got queue %v rooms %v tracked want neither after Stop", queue, rooms)
+	}
+}
@@ -0,0 +1,201 @@
+package noir
+
+import (
+	"errors"
+	"fmt"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/ion-sfu/pkg/sfu"
+)
+
+/*
+Peer Move:
+A peer moving between breakout rooms keeps its transport. MovePeer checks the destination has
+room for it, reserving its slot, and asks the peer's worker to move it: the peer's tracks are
+taken out of the old room's subscribers and what it received from the old room out of its
+subscriber, then the sfu files it under the destination's session, which adds the tracks both
+ways, so one renegotiation swaps them and the offer comes with its TrackInfos. The old room gets
+the peer's LEFT, with reason MOVED, and the new room its JOINED, the peer itself gets RoomMoved.
+The destination's mutes, pause and maxSubscribedTracks apply from the move on, a selection of
+publishers the peer made in the old room is dropped.
+
+Rooms live on one node, so a peer only moves into a room on its node, or one nobody hosts yet,
+and not into a closed or full room: for those MovePeer errors and the peer stays where it is.
+The ICE, RTCP and idle policies and the data channels it got when joining stay those of the
+room it joined.
+*/
+
+// MovePeer has the worker owning pid move it to the room newSid on the transport it already has
+func (m *Manager) MovePeer(pid string, newSid string) error {
+	userData, err := m.GetRemoteUserData(pid)
+	if err != nil || userData == nil {
+		return fmt.Errorf("%w: %s", ErrPeerNotFound, pid)
+	}
+	if userData.RoomID == newSid {
+		return nil
+	}
+	if m.IsRoomClosed(newSid) {
+		return fmt.Errorf("room %s is closed", newSid)
+	}
+	room, err := m.GetRemoteRoomData(newSid)
+	if err != nil && !m.AllowAutoCreateRooms() {
+		return fmt.Errorf("room %s not found", newSid)
+	}
+	if node := room.GetNodeID(); node != "" {
+		if current, err := m.GetRemoteRoomData(userData.RoomID); err == nil && current.NodeID != node {
+			return fmt.Errorf("room %s is on node %s, %s is on %s", newSid, node, pid, current.NodeID)
+		}
+	}
+	reserved, err := m.ReservePeerSlot(newSid, pid, room.GetOptions().GetMaxPeers())
+	if errors.Is(err, ErrRoomClosing) {
+		return fmt.Errorf("room %s is closing", newSid)
+	} else if err != nil {
+		return fmt.Errorf("error reserving a slot in %s: %s", newSid, err)
+	}
+	if !reserved {
+		return fmt.Errorf("room %s is full", newSid)
+	}
+	err = EnqueueRequest(m.GetQueue(m.Keys().TopicToPeer(pid)), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id: pid,
+				Payload: &pb.SignalRequest_Move{
+					Move: &pb.MoveRequest{Sid: newSid},
+				},
+			},
+		},
+	})
+	if err != nil {
+		m.ReleasePeerSlot(newSid, pid)
+	}
+	return err
+}
+
+// HandleMove moves the peer this PeerChannel owns to another room, see the top of the file
+func (w *worker) HandleMove(request *pb.NoirRequest, userData *pb.UserData, peer *sfu.Peer) error {
+	mgr := w.manager
+	pid := userData.Id
+	from, to := userData.RoomID, request.GetSignal().GetMove().GetSid()
+	if to == "" || to == from {
+		return nil
+	}
+
+	if mgr.IsRoomClosed(to) {
+		mgr.ReleasePeerSlot(to, pid)
+		return w.SignalError(request, pb.SignalError_ROOM_CLOSED, fmt.Errorf("room %s is closed", to))
+	}
+	room, err := mgr.GetRemoteRoomData(to)
+	if err != nil && mgr.AllowAutoCreateRooms() {
+		room, err = mgr.CreateRoomIfNotExists(to)
+	}
+	if err != nil {
+		mgr.ReleasePeerSlot(to, pid)
+		return w.SignalError(request, pb.SignalError_ROOM_NOT_FOUND, fmt.Errorf("room %s not found", to))
+	}
+	if room.NodeID != "" && room.NodeID != mgr.ID() {
+		mgr.ReleasePeerSlot(to, pid)
+		return w.SignalError(request, pb.SignalError_ROOM_NOT_FOUND, fmt.Errorf("room %s is on node %s", to, room.NodeID))
+	}
+	// a move sent without MovePeer has not reserved it a slot yet
+	reserved, err := mgr.ReservePeerSlot(to, pid, room.GetOptions().GetMaxPeers())
+	if errors.Is(err, ErrRoomClosing) {
+		w.SignalFailure(request, &pb.SignalError{
+			Code:         pb.SignalError_ROOM_CLOSED,
+			Message:      fmt.Sprintf("room %s is closing", to),
+			RetryAfterMs: RoomClosingRetryAfter.Milliseconds(),
+		})
+		return fmt.Errorf("room %s is closing", to)
+	} else if err != nil {
+		return w.SignalError(request, pb.SignalError_INTERNAL, fmt.Errorf("error reserving a slot in %s: %s", to, err))
+	}
+	if !reserved {
+		return w.SignalError(request, pb.SignalError_ROOM_FULL, fmt.Errorf("room %s is full", to))
+	}
+
+	// the destination's pause holds the tracks added by the move, see holdWhilePaused
+	mgr.setRoomPaused(to, room.GetPaused())
+	userData.RoomID = to
+	w.peerRooms.Store(pid, to)
+	old, _ := mgr.GetLocalRoom(from)
+	session, _ := (*mgr.SFU()).GetSession(to)
+	if !movePeerSession(peer, old.Session(), session) {
+		userData.RoomID = from
+		w.peerRooms.Store(pid, from)
+		mgr.ReleasePeerSlot(to, pid)
+		return w.SignalError(request, pb.SignalError_INTERNAL, fmt.Errorf("peer %s has not joined yet", pid))
+	}
+
+	mgr.SaveData(mgr.Keys().UserData(pid), &pb.NoirObject{
+		Data: &pb.NoirObject_User{User: userData},
+	}, 0)
+	mgr.movedTaps(pid, to)
+	emptied := mgr.leaveRoom(from, pid)
+	mgr.UpdateRoomScore(from)
+	mgr.UpdateRoomScore(to)
+	mgr.ApplyRoomMutes(to)
+	mgr.dropSelections(pid)
+	if limit := room.GetOptions().GetMaxSubscribedTracks(); limit > 0 {
+		if err := mgr.LimitSubscriptions(pid, peer, int(limit)); err != nil {
+			w.requestLogger(request).Errorf("error limiting the subscriptions of %s: %s", pid, err)
+		}
+	}
+
+	mgr.PublishRoomEvent(&pb.RoomEvent{Type: pb.RoomEvent_LEFT, Pid: pid, Sid: from, Reason: pb.CloseReason_MOVED})
+	mgr.promoteOwner(from, pid)
+	if emptied != "" {
+		mgr.emptiedRoom(from, emptied)
+	}
+	mgr.EmitRoomEvent(to, pid, pb.RoomEvent_JOINED)
+	w.requestLogger(request).Infof("moved %s from %s to %s", pid, from, to)
+
+	err = w.SignalReply(pid, &pb.NoirReply{
+		Id:      request.Id,
+		TraceID: request.TraceID,
+		Command: &pb.NoirReply_Signal{
+			Signal: &pb.SignalReply{
+				Id:        pid,
+				RequestId: request.GetSignal().RequestId,
+				Payload:   &pb.SignalReply_Moved{Moved: &pb.RoomMoved{From: from, To: to}},
+			},
+		},
+	})
+	if err == nil && room.GetPaused() {
+		err = w.sendPaused(pid, to, &pb.RoomPaused{Paused: true})
+	}
+	return err
+}
+
+// movePeerSession takes the peer's tracks out of the session from, both ways, and adds it to the
+// session to, which subscribes it and publishes its tracks. False before the peer joined
+func movePeerSession(peer *sfu.Peer, from *sfu.Session, to *sfu.Session) bool {
+	publisher := publisherOf(peer)
+	subscriber := subscriberOf(peer)
+	if publisher == nil || subscriber == nil {
+		return false
+	}
+	id := sfuPeerID(peer)
+	if from != nil {
+		from.RemovePeer(id)
+		for _, other := range from.Peers() {
+			if otherSubscriber := subscriberOf(other); otherSubscriber != nil {
+				for _, track := range downTracksOf(otherSubscriber) {
+					if downTrackPublisher(track) == id {
+						removeDownTrack(otherSubscriber, track)
+					}
+				}
+			}
+		}
+	}
+	for _, track := range downTracksOf(subscriber) {
+		removeDownTrack(subscriber, track)
+	}
+	if !setPeerSession(peer, to) {
+		return false
+	}
+	to.AddPeer(peer)
+	to.Subscribe(peer)
+	router := publisher.GetRouter()
+	for _, receiver := range receiversOf(publisher) {
+		to.Publish(router, receiver)
+	}
+	return true
+}
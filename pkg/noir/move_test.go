@@ -0,0 +1,153 @@
+package noir
+
+import (
+	"context"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"google.golang.org/protobuf/proto"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestMovePeer(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	rooms := []string{"move-from", "move-to", "move-full", "move-closed"}
+	keys := []string{pb.KeyPeerOwner("move-peer"), pb.KeyTopicFromPeer("move-peer"), pb.KeyTopicToPeer("move-peer"),
+		pb.KeyUserData("move-peer"), pb.KeyRoomClosed("move-closed")}
+	for _, roomID := range rooms {
+		keys = append(keys, pb.KeyRoomData(roomID), pb.KeyRoomUsers(roomID))
+	}
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+	mgr.SetRoomData(&pb.RoomData{Id: "move-full", Options: &pb.RoomOptions{MaxPeers: 1}})
+	redis.HSet(pb.KeyRoomUsers("move-full"), "move-other", 1)
+	redis.Set(pb.KeyRoomClosed("move-closed"), 1, 0)
+
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id: "move-peer",
+				Payload: &pb.SignalRequest_Join{
+					Join: &pb.JoinRequest{Sid: "move-from", Description: []byte(EXAMPLE_EMPTY_SDP)},
+				},
+			},
+		},
+	})
+	if err := worker.HandleNext(0); err != nil {
+		t.Fatalf("error joining: %s", err)
+	}
+	defer mgr.DisconnectUser("move-peer")
+
+	for _, roomID := range []string{"move-full", "move-closed"} {
+		if err := mgr.MovePeer("move-peer", roomID); err == nil {
+			t.Errorf("moving to %s should fail", roomID)
+		}
+	}
+	if userData, _ := mgr.GetRemoteUserData("move-peer"); userData.GetRoomID() != "move-from" {
+		t.Errorf("got %s want a peer that could not move left in its room", userData.GetRoomID())
+	}
+
+	events, cancel := mgr.SubscribeEvents()
+	defer cancel()
+	if err := mgr.MovePeer("move-peer", "move-to"); err != nil {
+		t.Fatalf("error moving: %s", err)
+	}
+	replies := mgr.GetQueue(pb.KeyTopicFromPeer("move-peer"))
+	for {
+		message, err := replies.BlockUntilNext(time.Second)
+		if err != nil {
+			t.Fatalf("got no RoomMoved: %s", err)
+		}
+		reply := pb.NoirReply{}
+		proto.Unmarshal(message, &reply)
+		if moved := reply.GetSignal().GetMoved(); moved != nil {
+			if moved.From != "move-from" || moved.To != "move-to" {
+				t.Errorf("got %s want moved from move-from to move-to", moved)
+			}
+			break
+		}
+	}
+
+	if userData, _ := mgr.GetRemoteUserData("move-peer"); userData.GetRoomID() != "move-to" {
+		t.Errorf("got %s want the peer saved in move-to", userData.GetRoomID())
+	}
+	if redis.HExists(pb.KeyRoomUsers("move-from"), "move-peer").Val() || !redis.HExists(pb.KeyRoomUsers("move-to"), "move-peer").Val() {
+		t.Errorf("the room index should list the peer in move-to only")
+	}
+	room, _ := mgr.GetLocalRoom("move-to")
+	if session := room.Session(); session == nil || len(session.Peers()) != 1 {
+		t.Errorf("the peer should be in the session of move-to")
+	}
+	for _, want := range []pb.RoomEvent_Type{pb.RoomEvent_LEFT, pb.RoomEvent_JOINED} {
+		var event Event
+		for event.GetPid() != "move-peer" {
+			select {
+			case event = <-events:
+			case <-time.After(time.Second):
+				t.Fatalf("got no %s event", want)
+			}
+		}
+		if event.Type != want {
+			t.Errorf("got %s want %s", event.Type, want)
+		}
+		if event.Type == pb.RoomEvent_LEFT && (event.Sid != "move-from" || event.Reason != pb.CloseReason_MOVED) {
+			t.Errorf("got LEFT %s for %s want move-from MOVED", event.Sid, event.Reason)
+		}
+	}
+}
+
+func TestMovePeerTracks(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	keys := append(emptyRoomKeys("swap-from"), emptyRoomKeys("swap-to")...)
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+	go func() {
+		for i := 0; i < 3; i++ {
+			routeOnce(mgr)
+		}
+	}()
+	from := NewLoadBot(context.Background(), mgr, LoadBotConfig{Publish: true})
+	defer from.Stop()
+	from.Spawn(2, "swap-from")
+	to := NewLoadBot(context.Background(), mgr, LoadBotConfig{Publish: true})
+	defer to.Stop()
+	to.Spawn(1, "swap-to")
+	pids := []string{}
+	for pid := range from.bots {
+		pids = append(pids, pid)
+	}
+	for pid := range to.bots {
+		pids = append(pids, pid)
+	}
+	if len(pids) != 3 {
+		t.Fatalf("got %d bots want 3", len(pids))
+	}
+	sort.Strings(pids[:2])
+	mover, left, there := pids[0], pids[1], pids[2]
+	waitFor(t, "the tracks of swap-from", func() bool {
+		return receiving(mgr, mover, left) && receiving(mgr, left, mover)
+	})
+	waitFor(t, "the publisher of swap-to", func() bool { return publishing(mgr, to) == 1 })
+	peer := mgr.localPeer(mover)
+
+	if err := mgr.MovePeer(mover, "swap-to"); err != nil {
+		t.Fatalf("error moving: %s", err)
+	}
+	// the mover's tracks are swapped for the destination's on its own pcs, both ways
+	waitFor(t, "the tracks of swap-to", func() bool {
+		return receiving(mgr, mover, there) && receiving(mgr, there, mover)
+	})
+	if receiving(mgr, mover, left) || receiving(mgr, left, mover) {
+		t.Errorf("the mover and swap-from should not be sent each other's tracks once moved")
+	}
+	if mgr.localPeer(mover) != peer {
+		t.Errorf("the mover should keep its peer and transport")
+	}
+	waitFor(t, "the mover saved in swap-to", func() bool {
+		userData, _ := mgr.GetRemoteUserData(mover)
+		return userData.GetRoomID() == "swap-to"
+	})
+}
@@ -0,0 +1,198 @@
+package noir
+
+import (
+	"errors"
+	"fmt"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	log "github.com/pion/ion-log"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"github.com/pion/webrtc/v3"
+	"sync"
+)
+
+var ErrUnknownKind = errors.New("kind must be audio or video")
+
+// mutedKind is a kind of track a publisher, by its sfu peer id, is muted for
+type mutedKind struct {
+	publisher string
+	kind      webrtc.RTPCodecType
+}
+
+// MutePeer has the worker owning pid stop, or with muted=false resume, forwarding its tracks of a kind,
+// pid has to be in roomID
+func (m *Manager) MutePeer(roomID string, pid string, kind string, muted bool) error {
+	if webrtc.NewRTPCodecType(kind) == 0 {
+		return ErrUnknownKind
+	}
+	userData, err := m.GetRemoteUserData(pid)
+	if err != nil || userData == nil {
+		return fmt.Errorf("peer %s not found", pid)
+	}
+	if !m.redis.HExists(m.Keys().RoomUsers(roomID), pid).Val() {
+		return fmt.Errorf("peer %s not found in %s", pid, roomID)
+	}
+	return EnqueueRequest(m.GetQueue(m.Keys().TopicToPeer(pid)), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id: pid,
+				Payload: &pb.SignalRequest_Mute{
+					Mute: &pb.MuteRequest{Kind: kind, Muted: muted},
+				},
+			},
+		},
+	})
+}
+
+// MutePublisher mutes the publisher's tracks of a kind for everyone else in the session and
+// returns how many downtracks it changed. Unmuting resumes at the next keyframe, no renegotiation
+func MutePublisher(session *sfu.Session, publisher *sfu.Peer, kind webrtc.RTPCodecType, muted bool) int {
+	publisherID := sfuPeerID(publisher)
+	changed := 0
+	for _, peer := range session.Peers() {
+		if peer == publisher {
+			continue
+		}
+		subscriber := subscriberOf(peer)
+		if subscriber == nil {
+			continue
+		}
+		for _, track := range downTracksOf(subscriber) {
+			if track.Kind() == kind && downTrackPublisher(track) == publisherID {
+				track.Mute(muted)
+				changed++
+			}
+		}
+	}
+	return changed
+}
+
+// setPublisherMuted keeps whether the publisher is muted for a kind on this node, see holdMuted
+func (m *Manager) setPublisherMuted(publisher *sfu.Peer, kind webrtc.RTPCodecType, muted bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if muted {
+		m.mutedKinds[mutedKind{sfuPeerID(publisher), kind}] = true
+	} else {
+		delete(m.mutedKinds, mutedKind{sfuPeerID(publisher), kind})
+	}
+}
+
+func (m *Manager) publisherMuted(publisherID string, kind webrtc.RTPCodecType) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.mutedKinds[mutedKind{publisherID, kind}]
+}
+
+// forgetMutes drops the mutes kept for a publisher leaving this node
+func (m *Manager) forgetMutes(publisher *sfu.Peer) {
+	if publisher == nil {
+		return
+	}
+	for _, kind := range []webrtc.RTPCodecType{webrtc.RTPCodecTypeAudio, webrtc.RTPCodecTypeVideo} {
+		m.setPublisherMuted(publisher, kind, false)
+	}
+}
+
+// holdMuted mutes the downtracks added to a joined peer, as a muted publisher publishes or the
+// peer subscribes, of the publishers muted on this node. Muting a downtrack before it is bound
+// does nothing and binding enables it, so each is hooked ahead of the renegotiation that offers
+// it and muted once bound. False before the peer joined
+func (m *Manager) holdMuted(peer *sfu.Peer) bool {
+	subscriber := subscriberOf(peer)
+	if subscriber == nil {
+		return false
+	}
+	hooked := map[*sfu.DownTrack]bool{}
+	var mu sync.Mutex
+	hookNew := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, track := range downTracksOf(subscriber) {
+			if hooked[track] {
+				continue
+			}
+			hooked[track] = true
+			track := track
+			bound := downTrackOnBind(track)
+			track.OnBind(func() {
+				if bound != nil {
+					bound()
+				}
+				if m.publisherMuted(downTrackPublisher(track), track.Kind()) {
+					track.Mute(true)
+				}
+			})
+		}
+	}
+	hookNew()
+	_, ok := hookNegotiate(subscriber, hookNew)
+	return ok
+}
+
+// ApplyRoomMutes mutes the room's muted publishers again, so subscribers that joined since are muted too
+func (m *Manager) ApplyRoomMutes(roomID string) {
+	room, _ := m.GetLocalRoom(roomID)
+	session := room.Session()
+	if session == nil {
+		return
+	}
+	for _, pid := range m.redis.HKeys(m.Keys().RoomUsers(roomID)).Val() {
+		userData, err := m.GetRemoteUserData(pid)
+		if err != nil || userData == nil || !(userData.AudioMuted || userData.VideoMuted) {
+			continue
+		}
+		peer := m.localPeer(pid)
+		if peer == nil {
+			continue
+		}
+		if userData.AudioMuted {
+			m.setPublisherMuted(peer, webrtc.RTPCodecTypeAudio, true)
+			MutePublisher(session, peer, webrtc.RTPCodecTypeAudio, true)
+		}
+		if userData.VideoMuted {
+			m.setPublisherMuted(peer, webrtc.RTPCodecTypeVideo, true)
+			MutePublisher(session, peer, webrtc.RTPCodecTypeVideo, true)
+		}
+	}
+}
+
+// HandleMute applies a MuteRequest to the peer this PeerChannel owns and tells the room
+func (w *worker) HandleMute(userData *pb.UserData, peer *sfu.Peer, mute *pb.MuteRequest) error {
+	kind := webrtc.NewRTPCodecType(mute.Kind)
+	if kind == 0 {
+		return ErrUnknownKind
+	}
+	room, _ := w.manager.GetLocalRoom(userData.RoomID)
+	session := room.Session()
+	if session == nil {
+		return fmt.Errorf("room %s has no session on this node", userData.RoomID)
+	}
+
+	w.manager.setPublisherMuted(peer, kind, mute.Muted)
+	changed := 0
+	// a paused room forwards nothing, resuming it unmutes the publisher
+	if mute.Muted || !w.manager.RoomPaused(userData.RoomID) {
+		changed = MutePublisher(session, peer, kind, mute.Muted)
+	}
+	log.Infof("muted=%t %s of %s for %d subscribers", mute.Muted, mute.Kind, userData.Id, changed)
+
+	if kind == webrtc.RTPCodecTypeAudio {
+		userData.AudioMuted = mute.Muted
+	} else {
+		userData.VideoMuted = mute.Muted
+	}
+	w.manager.SaveData(w.manager.Keys().UserData(userData.Id), &pb.NoirObject{
+		Data: &pb.NoirObject_User{User: userData},
+	}, 0)
+
+	eventType := pb.RoomEvent_UNMUTED
+	if mute.Muted {
+		eventType = pb.RoomEvent_MUTED
+	}
+	return w.manager.PublishRoomEvent(&pb.RoomEvent{
+		Type: eventType,
+		Pid:  userData.Id,
+		Sid:  userData.RoomID,
+		Kind: mute.Kind,
+	})
+}
@@ -0,0 +1,127 @@
+package noir
+
+import (
+	"context"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"github.com/pion/webrtc/v3"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"unsafe"
+)
+
+func TestMutePeer(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SaveData(pb.KeyUserData("muted"), &pb.NoirObject{
+		Data: &pb.NoirObject_User{User: &pb.UserData{Id: "muted", RoomID: "test"}},
+	}, 0)
+	redis.HSet(pb.KeyRoomUsers("test"), "muted", 1)
+	defer redis.HDel(pb.KeyRoomUsers("test"), "muted")
+	queue := mgr.GetQueue(pb.KeyTopicToPeer("muted"))
+	queue.Cleanup()
+
+	if err := mgr.MutePeer("test", "muted", "video", true); err != nil {
+		t.Fatalf("error muting peer: %s", err)
+	}
+	msg, _ := queue.Next()
+	request := &pb.NoirRequest{}
+	UnmarshalRequest(msg, request)
+	mute := request.GetSignal().GetMute()
+	if mute.GetKind() != "video" || !mute.GetMuted() {
+		t.Errorf("got %s want a video mute for the peer", request)
+	}
+
+	if err := mgr.MutePeer("test", "muted", "screen", true); err != ErrUnknownKind {
+		t.Errorf("got %v want %s", err, ErrUnknownKind)
+	}
+	if err := mgr.MutePeer("test", "not-a-peer", "audio", true); err == nil {
+		t.Errorf("muting a missing peer should fail")
+	}
+	// a moderator of one room may not mute the peers of another
+	if count, _ := queue.Count(); count != 0 {
+		t.Fatalf("got %d queued want the video mute read", count)
+	}
+	if err := mgr.MutePeer("other-room", "muted", "audio", true); err == nil {
+		t.Errorf("muting a peer of another room should fail")
+	}
+	if count, _ := queue.Count(); count != 0 {
+		t.Errorf("got %d queued want no mute for a peer of another room", count)
+	}
+}
+
+// forwarding is whether the sfu bound the downtrack and whether it forwards, false once muted
+func forwarding(track *sfu.DownTrack) (bound bool, enabled bool) {
+	value := reflect.ValueOf(track).Elem()
+	flag := func(name string) bool {
+		return atomic.LoadInt32((*int32)(unsafe.Pointer(value.FieldByName(name).UnsafeAddr()))) == 1
+	}
+	return flag("bound"), flag("enabled")
+}
+
+func TestMuteAddedTracks(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	redis.Del(pb.KeyRoomData("mute-added-room"), pb.KeyRoomUsers("mute-added-room"))
+	go func() {
+		for i := 0; i < 2; i++ {
+			routeOnce(mgr)
+		}
+	}()
+	publishers := NewLoadBot(context.Background(), mgr, LoadBotConfig{Publish: true})
+	defer publishers.Stop()
+	publishers.Spawn(1, "mute-added-room")
+	var pub string
+	for pid := range publishers.bots {
+		pub = pid
+	}
+	waitFor(t, "the publisher's track", func() bool { return publishing(mgr, publishers) == 1 })
+	publisherID := sfuPeerID(mgr.localPeer(pub))
+
+	if err := mgr.MutePeer("mute-added-room", pub, "audio", true); err != nil {
+		t.Fatalf("error muting: %s", err)
+	}
+	waitFor(t, "the mute", func() bool { return mgr.publisherMuted(publisherID, webrtc.RTPCodecTypeAudio) })
+
+	// the downtracks a subscriber joining since gets are muted, as are those of a track published since
+	subscribers := NewLoadBot(context.Background(), mgr, LoadBotConfig{})
+	defer subscribers.Stop()
+	subscribers.Spawn(1, "mute-added-room")
+	var sub string
+	for pid := range subscribers.bots {
+		sub = pid
+	}
+	sent := func() *sfu.DownTrack {
+		peer := mgr.localPeer(sub)
+		if peer == nil || subscriberOf(peer) == nil {
+			return nil
+		}
+		for _, track := range downTracksOf(subscriberOf(peer)) {
+			if downTrackPublisher(track) == publisherID {
+				return track
+			}
+		}
+		return nil
+	}
+	waitFor(t, "the publisher's track bound", func() bool {
+		if track := sent(); track != nil {
+			bound, _ := forwarding(track)
+			return bound
+		}
+		return false
+	})
+	if _, enabled := forwarding(sent()); enabled {
+		t.Errorf("got the track added after the mute forwarded want it muted")
+	}
+
+	if err := mgr.MutePeer("mute-added-room", pub, "audio", false); err != nil {
+		t.Fatalf("error unmuting: %s", err)
+	}
+	waitFor(t, "the track unmuted", func() bool {
+		_, enabled := forwarding(sent())
+		return enabled
+	})
+	if mgr.publisherMuted(publisherID, webrtc.RTPCodecTypeAudio) {
+		t.Errorf("the node should forget the mute once unmuted")
+	}
+}
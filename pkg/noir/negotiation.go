@@ -0,0 +1,231 @@
+package noir
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	log "github.com/pion/ion-log"
+	"github.com/pion/webrtc/v3"
+	"sync"
+	"time"
+)
+
+// How many descriptions a peer can have waiting on the one being negotiated
+const NegotiationBacklog = 8
+
+var ErrNegotiationTimeout = errors.New("negotiation timed out")
+
+// sfuDeadline runs one sfu operation at a time and stops waiting for it after a timeout.
+// An operation that overran keeps its slot until it returns, so a wedged peer piles up no goroutines
+type sfuDeadline struct {
+	slot chan struct{}
+}
+
+func newSFUDeadline() *sfuDeadline {
+	return &sfuDeadline{slot: make(chan struct{}, 1)}
+}
+
+func (d *sfuDeadline) Run(timeout time.Duration, operation func() error) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case d.slot <- struct{}{}:
+	case <-timer.C:
+		return ErrNegotiationTimeout
+	}
+	done := make(chan error, 1)
+	go func() {
+		defer func() { <-d.slot }()
+		done <- operation()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		return ErrNegotiationTimeout
+	}
+}
+
+// Negotiate handles a PeerChannel's descriptions in order, until the channel is closed
+func (w *worker) Negotiate(userData *pb.UserData, client PeerAdapter, userMu *sync.Mutex, negotiations chan *pb.NoirRequest) {
+	deadline := newSFUDeadline()
+	glare := &glareState{}
+	for request := range negotiations {
+		w.HandleDescription(request, userData, client, userMu, deadline, glare)
+	}
+}
+
+// HandleDescription sets a peer's answer, or answers its offer, giving up after the WebrtcTimeout.
+// An offer colliding with the subscriber's own is resolved as described in glare.go
+func (w *worker) HandleDescription(request *pb.NoirRequest, userData *pb.UserData, client PeerAdapter, userMu *sync.Mutex, deadline *sfuDeadline, glare *glareState) {
+	signal := request.GetSignal()
+	// the PeerChannel moves and mutes the peer meanwhile, so its room and role are read from a copy
+	user := copyUser(userData, userMu)
+	peer := client.SFUPeer()
+	timeout := w.manager.WebrtcTimeout()
+
+	var desc Negotiation
+	err := json.Unmarshal(signal.GetDescription(), &desc)
+	if err != nil {
+		log.Errorf("unmarshal err: %s", err)
+		return
+	}
+	defer func() { w.manager.recordPeerStatus(copyUser(userData, userMu), peer) }()
+	icePolicy := w.manager.RoomICEPolicy(user.GetRoomID())
+	if err := FilterCandidates(&desc.Desc, icePolicy, "inbound"); err != nil {
+		log.Errorf("error filtering candidates of %s: %s", userData.Id, err)
+	}
+	if desc.Desc.Type == webrtc.SDPTypeAnswer {
+		if offers, ok := w.serverOffers.Load(userData.Id); ok && !offers.(*offerTracker).answered(signal.RequestId) {
+			log.Debugf("answer from %s is to offer %s, since replaced", userData.Id, signal.RequestId)
+		}
+		if w.staleAnswer(peer, glare) {
+			log.Debugf("dropping answer from %s to an offer it already answered", userData.Id)
+			return
+		}
+		log.Debugf("got answer, setting description")
+		err := deadline.Run(timeout, func() error {
+			return client.SetRemoteDescription(desc.Desc)
+		})
+		if err != nil {
+			w.SignalError(request, pb.SignalError_NEGOTIATION_FAILED, fmt.Errorf("error setting answer: %s", err))
+		}
+		return
+	} else if desc.Desc.Type != webrtc.SDPTypeOffer {
+		return
+	}
+
+	roomData, err := w.manager.GetRemoteRoomData(user.GetRoomID())
+	if err != nil {
+		w.SignalError(request, pb.SignalError_ROOM_NOT_FOUND, fmt.Errorf("error getting room to validate offer: %s", err))
+		return
+	}
+
+	// a renegotiation is held to the same limits as the join's offer
+	if err := CheckSDPLimits([]byte(desc.Desc.SDP), w.manager.RoomSDPLimits(roomData)); err != nil {
+		w.SignalError(request, pb.SignalError_OFFER_TOO_COMPLEX, err)
+		return
+	}
+
+	desc.Desc, err = w.manager.RoomMediaConfig(roomData).FilterOffer(desc.Desc)
+	if errors.Is(err, ErrNoCompatibleCodec) {
+		w.SignalError(request, pb.SignalError_NO_COMPATIBLE_CODEC, err)
+		return
+	} else if err != nil {
+		w.SignalError(request, pb.SignalError_OFFER_REJECTED, fmt.Errorf("bad offer: %s", err))
+		return
+	}
+
+	validated, err := w.manager.ValidateOffer(roomData, userData.Id, user.GetRole(), desc.Desc)
+	if err != nil {
+		w.SignalError(request, pb.SignalError_OFFER_REJECTED, fmt.Errorf("rejected offer: %s", err))
+		return
+	}
+
+	A, V, D, summary := TrackSummary(validated)
+
+	roomType := "room"
+	publishing := user.Publishing
+
+	// Just one jobData track
+	if D == 1 && A == 0 && V == 0 {
+		publishing = false
+	} else if A > 0 || V > 0 {
+		// Publishing
+		options := roomData.GetOptions()
+
+		if options.GetIsChannel() == true {
+			roomType = "channel"
+			if roomData.GetPublisher() != "" {
+				w.SignalError(request, pb.SignalError_OFFER_REJECTED, fmt.Errorf("channel %s already has a publisher", roomData.Id))
+				return
+			} else if A > 1 || V > 1 {
+				w.SignalError(request, pb.SignalError_OFFER_REJECTED, fmt.Errorf("cannot publish multiple video or audio tracks into channel %s: %s", roomData.Id, summary))
+				return
+			} else {
+				roomData.Publisher = userData.Id
+				SaveRoomData(user.RoomID, roomData, w.manager)
+			}
+		}
+		publishing = true
+		log.Infof("publishing [%dA/%dV/%dD] into %s %s: %s", A, V, D, roomType, user.RoomID, summary)
+	}
+
+	if labels := signal.GetTrackLabels(); len(labels) > 0 {
+		// saved before answering, so the subscribers' tracks are labelled from the start
+		userMu.Lock()
+		labelTracks(userData, labels)
+		w.manager.SaveData(w.manager.Keys().UserData(userData.Id), &pb.NoirObject{
+			Data: &pb.NoirObject_User{User: userData},
+		}, 0)
+		userMu.Unlock()
+	}
+
+	var glared bool
+	err = deadline.Run(timeout, func() error {
+		glared = w.pendingOffer(peer)
+		return nil
+	})
+	if err != nil {
+		w.SignalError(request, pb.SignalError_NEGOTIATION_FAILED, fmt.Errorf("error checking for glare: %s", err))
+		return
+	}
+	if glared {
+		// offered again once the client has its answer, even if answering failed
+		defer w.reoffer(peer, glare)
+	}
+
+	var answer *webrtc.SessionDescription
+	err = deadline.Run(timeout, func() error {
+		var err error
+		answer, err = client.Answer(desc.Desc)
+		return err
+	})
+	if err != nil {
+		w.SignalError(request, pb.SignalError_NEGOTIATION_FAILED, fmt.Errorf("error answering offer: %s", err))
+		return
+	}
+	if err := LimitBitrate(answer, user.GetLimits().GetPublishKbps()); err != nil {
+		log.Errorf("error limiting bitrate for %s: %s", userData.Id, err)
+	}
+	if err := AcceptAudioLevel(desc.Desc, answer); err != nil {
+		log.Errorf("error asking %s for audio levels: %s", userData.Id, err)
+	}
+	if err := FilterCandidates(answer, icePolicy, "outbound"); err != nil {
+		log.Errorf("error filtering candidates for %s: %s", userData.Id, err)
+	}
+	if w.manager.RoomRTCPPolicy(user.GetRoomID()).GetDisableNack() {
+		if err := StripNACK(answer); err != nil {
+			log.Errorf("error disabling nack for %s: %s", userData.Id, err)
+		}
+	}
+	answer.SDP = w.manager.BeforeSendAnswer(userData.Id, answer.SDP)
+	bytes, err := json.Marshal(answer)
+	if err != nil {
+		w.SignalError(request, pb.SignalError_INTERNAL, fmt.Errorf("error packing answer: %s", err))
+		return
+	}
+	log.Debugf("answering offer from %s: %s", request.Id, summary)
+	err = w.SignalReply(userData.Id, &pb.NoirReply{
+		Id:      request.Id,
+		TraceID: request.TraceID,
+		Command: &pb.NoirReply_Signal{
+			Signal: &pb.SignalReply{
+				Id:        userData.Id,
+				RequestId: signal.RequestId,
+				Payload:   &pb.SignalReply_Description{Description: bytes},
+			},
+		},
+	})
+	if err != nil {
+		log.Errorf("answer send error %v ", err)
+	}
+
+	userMu.Lock()
+	defer userMu.Unlock()
+	userData.Publishing = publishing
+	w.manager.SaveData(w.manager.Keys().UserData(userData.Id), &pb.NoirObject{
+		Data: &pb.NoirObject_User{User: userData},
+	}, 0)
+}
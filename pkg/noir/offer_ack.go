@@ -0,0 +1,125 @@
+package noir
+
+import (
+	"sync"
+	"time"
+)
+
+/*
+Offer Acknowledgment:
+The subscriber offers to the client whenever its tracks change, and a client that never gets
+the offer, or whose answer is lost, leaves the peer with media it can not receive. Each offer
+OnOffer sends carries a negotiation id as its RequestId, and is tracked until the client
+answers. An offer still unanswered after WebrtcTimeout() is sent once more, with the same id,
+and a peer silent for another WebrtcTimeout() is closed as NEGOTIATION_TIMEOUT.
+Clients answer with the offer's RequestId. An answer with the id of an offer since replaced
+settles nothing, and one with no id we sent, from a client that does not echo it, settles the
+pending offer.
+*/
+
+// how many replaced offers a peer remembers, to tell late answers to them apart
+const replacedOffers = NegotiationBacklog
+
+// offerTracker is the pending offer of a peer's subscriber, resent by timer until answered
+type offerTracker struct {
+	timeout time.Duration
+	resend  func(requestID string, offer []byte)
+	expire  func()
+
+	mu       sync.Mutex
+	pending  string
+	offer    []byte
+	resent   bool
+	timer    *time.Timer
+	replaced []string
+	stopped  bool
+}
+
+func newOfferTracker(timeout time.Duration, resend func(requestID string, offer []byte), expire func()) *offerTracker {
+	return &offerTracker{timeout: timeout, resend: resend, expire: expire}
+}
+
+// offered tracks a new offer in place of the pending one, and returns the id to send it with
+func (t *offerTracker) offered(offer []byte) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.pending != "" {
+		t.replace()
+	}
+	t.pending, t.offer, t.resent = RandomString(16), offer, false
+	if !t.stopped {
+		t.timer = time.AfterFunc(t.timeout, t.expired(t.pending))
+	}
+	return t.pending
+}
+
+// replace forgets the pending offer but not its id, t.mu has to be held
+func (t *offerTracker) replace() {
+	if t.timer != nil {
+		t.timer.Stop()
+		t.timer = nil
+	}
+	t.replaced = append(t.replaced, t.pending)
+	if len(t.replaced) > replacedOffers {
+		t.replaced = t.replaced[1:]
+	}
+	t.pending, t.offer = "", nil
+}
+
+// answered settles the pending offer, true unless requestID names an offer since replaced
+func (t *offerTracker) answered(requestID string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, replaced := range t.replaced {
+		if requestID == replaced {
+			return false
+		}
+	}
+	if t.timer != nil {
+		t.timer.Stop()
+		t.timer = nil
+	}
+	t.pending, t.offer = "", nil
+	return true
+}
+
+// expired resends the offer the first time it is due, and gives up on the peer the second
+func (t *offerTracker) expired(requestID string) func() {
+	return func() {
+		t.mu.Lock()
+		if t.stopped || t.pending != requestID {
+			t.mu.Unlock()
+			return
+		}
+		if t.resent {
+			t.stopped = true
+			t.mu.Unlock()
+			t.expire()
+			return
+		}
+		t.resent = true
+		offer := t.offer
+		t.timer = time.AfterFunc(t.timeout, t.expired(requestID))
+		t.mu.Unlock()
+		t.resend(requestID, offer)
+	}
+}
+
+// stop stops tracking, for a peer that is gone
+func (t *offerTracker) stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stopped = true
+	if t.timer != nil {
+		t.timer.Stop()
+		t.timer = nil
+	}
+}
+
+// dropOffers stops tracking pid's offers, unless it already rejoined with a new tracker
+func (w *worker) dropOffers(pid string, offers *offerTracker) {
+	offers.stop()
+	if current, ok := w.serverOffers.Load(pid); ok && current == offers {
+		w.serverOffers.Delete(pid)
+	}
+}
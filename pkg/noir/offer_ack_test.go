@@ -0,0 +1,98 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/webrtc/v3"
+	"google.golang.org/protobuf/proto"
+	"testing"
+	"time"
+)
+
+func TestOfferTrackerAnswered(t *testing.T) {
+	resent := make(chan string, 4)
+	offers := newOfferTracker(50*time.Millisecond, func(requestID string, offer []byte) { resent <- requestID }, func() {})
+	defer offers.stop()
+
+	first := offers.offered([]byte("first"))
+	second := offers.offered([]byte("second"))
+	if offers.answered(first) {
+		t.Errorf("an answer to a replaced offer should settle nothing")
+	}
+	if !offers.answered(second) {
+		t.Errorf("an answer with the pending offer's id should settle it")
+	}
+	select {
+	case requestID := <-resent:
+		t.Errorf("got %s sent again after it was answered", requestID)
+	case <-time.After(150 * time.Millisecond):
+	}
+}
+
+func TestDroppedAnswer(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	mgr.SetTimeouts(300*time.Millisecond, 5*time.Second)
+	defer mgr.SetTimeouts(0, 0)
+	keys := []string{pb.KeyRoomData("offer-room"), pb.KeyRoomUsers("offer-room"), pb.KeyPeerOwner("offer-peer"),
+		pb.KeyTopicFromPeer("offer-peer"), pb.KeyTopicToPeer("offer-peer")}
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	events := mgr.SubscribeRoomEvents("offer-room")
+	defer events.Close()
+	if _, err := events.Receive(); err != nil {
+		t.Fatalf("error subscribing: %s", err)
+	}
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id: "offer-peer",
+				Payload: &pb.SignalRequest_Join{
+					Join: &pb.JoinRequest{Sid: "offer-room", Description: []byte(EXAMPLE_EMPTY_SDP)},
+				},
+			},
+		},
+	})
+	if err := worker.HandleNext(0); err != nil {
+		t.Fatalf("error joining: %s", err)
+	}
+	defer mgr.DisconnectUser("offer-peer")
+	// as if it connected, only a peer announced JOINED is announced LEFT
+	redis.Set(pb.KeyUserJoined("offer-peer"), 1, 0)
+
+	// the sfu offers, and the client's answer never arrives
+	peer := mgr.localPeer("offer-peer")
+	peer.OnOffer(&webrtc.SessionDescription{Type: webrtc.SDPTypeOffer, SDP: EXAMPLE_EMPTY_SDP})
+
+	replies := mgr.GetQueue(pb.KeyTopicFromPeer("offer-peer"))
+	var offerIDs []string
+	for len(offerIDs) < 2 {
+		message, err := replies.BlockUntilNext(time.Second)
+		if err != nil {
+			t.Fatalf("got %d offers want the offer and its retransmission: %s", len(offerIDs), err)
+		}
+		reply := pb.NoirReply{}
+		proto.Unmarshal(message, &reply)
+		if signal := reply.GetSignal(); signal.GetDescription() != nil {
+			offerIDs = append(offerIDs, signal.RequestId)
+		}
+	}
+	if offerIDs[0] == "" || offerIDs[0] != offerIDs[1] {
+		t.Errorf("got offer ids %v want the offer sent twice with one negotiation id", offerIDs)
+	}
+
+	// past the OPENED of the room the join created
+	reply := pb.NoirReply{}
+	for reply.GetEvent().GetType() != pb.RoomEvent_LEFT {
+		select {
+		case message := <-events.Channel():
+			proto.Unmarshal([]byte(message.Payload), &reply)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for the peer to be closed")
+		}
+	}
+	if event := reply.GetEvent(); event.GetType() != pb.RoomEvent_LEFT || event.GetReason() != pb.CloseReason_NEGOTIATION_TIMEOUT {
+		t.Errorf("got %s want offer-peer LEFT for not answering", &reply)
+	}
+}
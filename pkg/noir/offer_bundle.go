@@ -0,0 +1,129 @@
+package noir
+
+import (
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"sync"
+	"time"
+)
+
+/*
+Offer Bundling:
+A subscriber joining a busy room is sent the tracks of the publishers in it, and of the ones
+still adding theirs, and every track added asks the sfu to renegotiate. ion-sfu debounces those
+by 250ms, so tracks that come in over a longer stretch, or while an offer waits for its answer,
+cost a negotiation each. With OfferBundling the joining peer's offers are held back until no
+track asked for a renegotiation for the window, at most MaxOfferBundleHold, and it is sent one
+offer with all of them. The hold is the sfu's own: the peer's offer is marked as waiting for its
+answer, which makes the sfu keep note of the renegotiations instead of offering.
+A peer that was sent its first offer before the hold could start, because its join was slow,
+falls back to an offer per renegotiation, as without bundling. noir_subscriber_offers_total
+counts the offers sent and noir_offers_bundled_total the renegotiations that were held back.
+*/
+
+// The longest a joining peer's offers are held back, however busy its room
+const MaxOfferBundleHold = 2 * time.Second
+
+// SetOfferBundling holds back a joining peer's offers until no track needed a renegotiation for
+// the window, 0 or less sends every offer as the sfu makes it
+func (m *Manager) SetOfferBundling(window time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.offerBundling = window
+}
+
+func (m *Manager) OfferBundling() time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.offerBundling < 0 {
+		return 0
+	}
+	return m.offerBundling
+}
+
+// offerBundle holds back a peer's offers until its renegotiations settle
+type offerBundle struct {
+	peer       *sfu.Peer
+	subscriber *sfu.Subscriber
+	window     time.Duration
+	deadline   time.Time
+
+	mu    sync.Mutex
+	held  bool
+	timer *time.Timer
+	// takes renegotiating off the subscriber once the offers are released
+	unhook func()
+}
+
+// bundleOffers starts holding back the peer's offers, false when its first offer is already out
+func bundleOffers(peer *sfu.Peer, window time.Duration) bool {
+	subscriber := subscriberOf(peer)
+	if subscriber == nil {
+		return false
+	}
+	bundle := &offerBundle{peer: peer, subscriber: subscriber, window: window, deadline: time.Now().Add(MaxOfferBundleHold)}
+	peer.Lock()
+	answerPending, _ := negotiationFlags(peer)
+	if *answerPending {
+		peer.Unlock()
+		return false
+	}
+	*answerPending = true
+	peer.Unlock()
+
+	bundle.mu.Lock()
+	defer bundle.mu.Unlock()
+	bundle.held = true
+	bundle.timer = time.AfterFunc(window, bundle.release)
+	unhook, ok := hookNegotiate(subscriber, bundle.renegotiating)
+	if !ok {
+		bundle.held = false
+		bundle.timer.Stop()
+		peer.Lock()
+		*answerPending = false
+		peer.Unlock()
+		return false
+	}
+	bundle.unhook = unhook
+	return true
+}
+
+// renegotiating waits for another window of quiet, while the offers are still held
+func (b *offerBundle) renegotiating() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.held {
+		return
+	}
+	offersBundled.Inc()
+	wait := b.window
+	if left := time.Until(b.deadline); left < wait {
+		wait = left
+	}
+	b.timer.Reset(wait)
+}
+
+// release lets the offers go, offering once for whatever was held back
+func (b *offerBundle) release() {
+	b.mu.Lock()
+	if !b.held {
+		b.mu.Unlock()
+		return
+	}
+	b.held = false
+	unhook := b.unhook
+	b.mu.Unlock()
+	if unhook != nil {
+		unhook()
+	}
+
+	b.peer.Lock()
+	answerPending, negotiationPending := negotiationFlags(b.peer)
+	*answerPending = false
+	held := *negotiationPending
+	*negotiationPending = false
+	b.peer.Unlock()
+	// a renegotiation the sfu did not get to yet, still in its debounce, offers by itself
+	if held {
+		negotiate(b.subscriber)
+	}
+}
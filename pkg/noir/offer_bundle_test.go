@@ -0,0 +1,101 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/protobuf/proto"
+	"testing"
+	"time"
+)
+
+func TestOfferBundling(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	mgr.SetOfferBundling(400 * time.Millisecond)
+	defer mgr.SetOfferBundling(0)
+	keys := []string{pb.KeyRoomData("bundle-room"), pb.KeyRoomUsers("bundle-room"), pb.KeyPeerOwner("bundle-peer"),
+		pb.KeyTopicFromPeer("bundle-peer"), pb.KeyTopicToPeer("bundle-peer")}
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id: "bundle-peer",
+				Payload: &pb.SignalRequest_Join{
+					Join: &pb.JoinRequest{Sid: "bundle-room", Description: []byte(EXAMPLE_EMPTY_SDP)},
+				},
+			},
+		},
+	})
+	offeredBefore := testutil.ToFloat64(subscriberOffers)
+	bundledBefore := testutil.ToFloat64(offersBundled)
+	if err := worker.HandleNext(0); err != nil {
+		t.Fatalf("error joining: %s", err)
+	}
+	defer mgr.DisconnectUser("bundle-peer")
+
+	// publishers adding a track each, further apart than the sfu's debounce
+	peer := mgr.localPeer("bundle-peer")
+	const publishers = 3
+	for i := 0; i < publishers; i++ {
+		negotiate(subscriberOf(peer))
+		time.Sleep(300 * time.Millisecond)
+	}
+
+	replies := mgr.GetQueue(pb.KeyTopicFromPeer("bundle-peer"))
+	offers := 0
+	for {
+		message, err := replies.BlockUntilNext(time.Second)
+		if err != nil {
+			break
+		}
+		reply := pb.NoirReply{}
+		proto.Unmarshal(message, &reply)
+		if reply.GetSignal().GetDescription() != nil {
+			offers++
+		}
+	}
+	if offers != 1 || testutil.ToFloat64(subscriberOffers)-offeredBefore != 1 {
+		t.Errorf("got %d offers want the %d renegotiations in one", offers, publishers)
+	}
+	if bundled := testutil.ToFloat64(offersBundled) - bundledBefore; bundled != publishers {
+		t.Errorf("got %v renegotiations bundled want %d", bundled, publishers)
+	}
+}
+
+func TestOfferBundlingFallback(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	keys := []string{pb.KeyRoomData("unbundled-room"), pb.KeyRoomUsers("unbundled-room"), pb.KeyPeerOwner("unbundled-peer"),
+		pb.KeyTopicFromPeer("unbundled-peer"), pb.KeyTopicToPeer("unbundled-peer")}
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id: "unbundled-peer",
+				Payload: &pb.SignalRequest_Join{
+					Join: &pb.JoinRequest{Sid: "unbundled-room", Description: []byte(EXAMPLE_EMPTY_SDP)},
+				},
+			},
+		},
+	})
+	if err := worker.HandleNext(0); err != nil {
+		t.Fatalf("error joining: %s", err)
+	}
+	defer mgr.DisconnectUser("unbundled-peer")
+
+	peer := mgr.localPeer("unbundled-peer")
+	// as though its first offer was already out
+	peer.Lock()
+	answerPending, _ := negotiationFlags(peer)
+	*answerPending = true
+	peer.Unlock()
+	if bundleOffers(peer, time.Second) {
+		t.Errorf("a peer with an offer out should not have its offers bundled")
+	}
+}
@@ -0,0 +1,107 @@
+package noir
+
+import (
+	"fmt"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/sdp/v3"
+	"github.com/pion/webrtc/v3"
+)
+
+// OfferPolicy decides what a peer may offer, a non-nil error rejects the offer and is the reason
+// the peer is given. The room is nil when a peer joins a room that does not exist yet
+type OfferPolicy interface {
+	CheckOffer(offer *sdp.SessionDescription, room *pb.RoomData, pid string) error
+}
+
+// OfferPolicyFunc lets a plain func be an OfferPolicy
+type OfferPolicyFunc func(offer *sdp.SessionDescription, room *pb.RoomData, pid string) error
+
+func (f OfferPolicyFunc) CheckOffer(offer *sdp.SessionDescription, room *pb.RoomData, pid string) error {
+	return f(offer, room, pid)
+}
+
+// OfferPolicies allows an offer only when every one of policies does
+func OfferPolicies(policies ...OfferPolicy) OfferPolicy {
+	return OfferPolicyFunc(func(offer *sdp.SessionDescription, room *pb.RoomData, pid string) error {
+		for _, policy := range policies {
+			if err := policy.CheckOffer(offer, room, pid); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// MaxTracksPolicy rejects offers sending more than max audio and video tracks in total
+func MaxTracksPolicy(max int) OfferPolicy {
+	return OfferPolicyFunc(func(offer *sdp.SessionDescription, room *pb.RoomData, pid string) error {
+		sending := 0
+		for _, media := range offer.MediaDescriptions {
+			if kind := media.MediaName.Media; (kind == "audio" || kind == "video") && isSending(media) {
+				sending++
+			}
+		}
+		if sending > max {
+			return fmt.Errorf("%s may publish at most %d tracks, offered %d", pid, max, sending)
+		}
+		return nil
+	})
+}
+
+// AudioOnlyPolicy rejects sending video into rooms whose options are audioOnly
+func AudioOnlyPolicy() OfferPolicy {
+	return OfferPolicyFunc(func(offer *sdp.SessionDescription, room *pb.RoomData, pid string) error {
+		if !room.GetOptions().GetAudioOnly() {
+			return nil
+		}
+		for _, media := range offer.MediaDescriptions {
+			if media.MediaName.Media == "video" && isSending(media) {
+				return fmt.Errorf("room %s is audio only", room.GetId())
+			}
+		}
+		return nil
+	})
+}
+
+// isSending is true unless the section is rejected or only receives
+func isSending(media *sdp.MediaDescription) bool {
+	if media.MediaName.Port.Value == 0 {
+		return false
+	}
+	for _, attr := range media.Attributes {
+		switch attr.Key {
+		case webrtc.RTPTransceiverDirectionRecvonly.String(), webrtc.RTPTransceiverDirectionInactive.String():
+			return false
+		}
+	}
+	return true
+}
+
+// SetOfferPolicy checks every offer from now on, nil allows any offer
+func (m *Manager) SetOfferPolicy(policy OfferPolicy) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.offerPolicy = policy
+}
+
+// ValidateOffer parses an offer and checks it against the peer's role and the OfferPolicy
+func (m *Manager) ValidateOffer(room *pb.RoomData, userID string, role pb.Role, offer webrtc.SessionDescription) (*sdp.SessionDescription, error) {
+	desc, err := ParseSDP(offer)
+	if err != nil {
+		return nil, err
+	}
+	if role == pb.Role_SUBSCRIBER {
+		for _, media := range desc.MediaDescriptions {
+			if kind := media.MediaName.Media; (kind == "audio" || kind == "video") && isSending(media) {
+				return desc, fmt.Errorf("%s is a subscriber and may not publish %s", userID, kind)
+			}
+		}
+	}
+	m.mu.RLock()
+	policy := m.offerPolicy
+	m.mu.RUnlock()
+	if policy == nil {
+		return desc, nil
+	}
+	return desc, policy.CheckOffer(desc, room, userID)
+}
@@ -0,0 +1,82 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/webrtc/v3"
+	"google.golang.org/protobuf/proto"
+	"strings"
+	"testing"
+)
+
+func TestOfferPolicies(t *testing.T) {
+	mgr, _ := NewTestSetup()
+	defer mgr.SetOfferPolicy(AudioOnlyPolicy())
+	offer := webrtc.SessionDescription{Type: webrtc.SDPTypeOffer, SDP: codecsTestSDP}
+	recvOnly := webrtc.SessionDescription{Type: webrtc.SDPTypeOffer,
+		SDP: strings.ReplaceAll(codecsTestSDP, "a=mid:1\r\n", "a=mid:1\r\na=recvonly\r\n")}
+	room := &pb.RoomData{Id: "policy"}
+	audioOnly := &pb.RoomData{Id: "policy-audio", Options: &pb.RoomOptions{AudioOnly: true}}
+
+	mgr.SetOfferPolicy(OfferPolicies(AudioOnlyPolicy(), MaxTracksPolicy(1)))
+	for _, test := range []struct {
+		name    string
+		room    *pb.RoomData
+		offer   webrtc.SessionDescription
+		allowed bool
+	}{
+		{"audio and video", room, offer, false},
+		{"receiving video", room, recvOnly, true},
+		{"video into audio only", audioOnly, offer, false},
+		{"receiving video in audio only", audioOnly, recvOnly, true},
+		{"room not created yet", nil, recvOnly, true},
+	} {
+		desc, err := mgr.ValidateOffer(test.room, "policy-peer", pb.Role_PUBLISHER, test.offer)
+		if desc == nil {
+			t.Errorf("%s: offer did not parse", test.name)
+		}
+		if allowed := err == nil; allowed != test.allowed {
+			t.Errorf("%s: got allowed=%t want %t (%s)", test.name, allowed, test.allowed, err)
+		}
+	}
+
+	mgr.SetOfferPolicy(nil)
+	if _, err := mgr.ValidateOffer(audioOnly, "policy-peer", pb.Role_PUBLISHER, offer); err != nil {
+		t.Errorf("without a policy every offer is allowed, got %s", err)
+	}
+}
+
+func TestWorkerJoinAudioOnlyRoom(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	redis.Del(pb.KeyRoomUsers("audio-only"), pb.KeyTopicFromPeer("video-peer"))
+	mgr.SetRoomData(&pb.RoomData{Id: "audio-only", Options: &pb.RoomOptions{AudioOnly: true}})
+
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id:        "video-peer",
+				RequestId: "join-1",
+				Payload: &pb.SignalRequest_Join{
+					Join: &pb.JoinRequest{
+						Sid:         "audio-only",
+						Description: []byte(codecsTestSDP),
+					},
+				},
+			},
+		},
+	})
+	if err := worker.HandleNext(0); err == nil {
+		t.Errorf("publishing video into an audio only room should fail")
+	}
+
+	reply := pb.NoirReply{}
+	msg, _ := mgr.GetQueue(pb.KeyTopicFromPeer("video-peer")).Next()
+	proto.Unmarshal(msg, &reply)
+	failure := reply.GetSignal().GetFailure()
+	if failure.GetCode() != pb.SignalError_OFFER_REJECTED || !strings.Contains(failure.GetMessage(), "audio only") {
+		t.Errorf("got %s want OFFER_REJECTED because the room is audio only", &reply)
+	}
+	if exists := redis.HExists(pb.KeyRoomUsers("audio-only"), "video-peer").Val(); exists {
+		t.Errorf("rejected join should not leave the peer in the room")
+	}
+}
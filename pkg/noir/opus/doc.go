@@ -0,0 +1,7 @@
+// Package opus is the Opus codec of audio mixes, bindings of libopus built with the opus tag:
+//
+//	go build -tags opus ./cmd/noir
+//
+// needs libopus and its headers, found with pkg-config. A noir built with it mixes audio, see
+// noir.SetAudioMixCodec, without it the package is empty and mixes stay off.
+package opus
@@ -0,0 +1,88 @@
+//go:build opus
+// +build opus
+
+package opus
+
+/*
+#cgo pkg-config: opus
+#include <opus.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"github.com/net-prophet/noir/pkg/noir"
+	"unsafe"
+)
+
+// The samples of the longest Opus frame, 120ms, one decoded payload is never more
+const maxFrameSamples = noir.AudioMixRate * 120 / 1000
+
+// The largest packet an encoded frame is, libopus' recommended buffer
+const maxPacketBytes = 4000
+
+var ErrEmptyFrame = errors.New("empty frame")
+
+// Codec decodes and encodes mono noir.AudioMixRate Opus, pass it to Manager.SetAudioMixCodec
+type Codec struct{}
+
+// decoder keeps libopus' decoder state in Go memory, it is freed with the decoder
+type decoder struct {
+	state []byte
+}
+
+// encoder keeps libopus' encoder state in Go memory, tuned for voice
+type encoder struct {
+	state []byte
+}
+
+func opusError(code C.int) error {
+	return fmt.Errorf("opus: %s", C.GoString(C.opus_strerror(code)))
+}
+
+func (Codec) NewDecoder() (noir.AudioDecoder, error) {
+	state := make([]byte, C.opus_decoder_get_size(1))
+	if code := C.opus_decoder_init((*C.OpusDecoder)(unsafe.Pointer(&state[0])), C.opus_int32(noir.AudioMixRate), 1); code != C.OPUS_OK {
+		return nil, opusError(code)
+	}
+	return &decoder{state: state}, nil
+}
+
+func (Codec) NewEncoder() (noir.AudioEncoder, error) {
+	state := make([]byte, C.opus_encoder_get_size(1))
+	if code := C.opus_encoder_init((*C.OpusEncoder)(unsafe.Pointer(&state[0])), C.opus_int32(noir.AudioMixRate), 1, C.OPUS_APPLICATION_VOIP); code != C.OPUS_OK {
+		return nil, opusError(code)
+	}
+	return &encoder{state: state}, nil
+}
+
+// Decode is the payload's audio, an empty payload is a lost one, libopus conceals a frame of it
+func (d *decoder) Decode(payload []byte) ([]int16, error) {
+	var data *C.uchar
+	pcm := make([]int16, noir.AudioMixFrameSamples)
+	if len(payload) > 0 {
+		data = (*C.uchar)(unsafe.Pointer(&payload[0]))
+		pcm = make([]int16, maxFrameSamples)
+	}
+	samples := C.opus_decode((*C.OpusDecoder)(unsafe.Pointer(&d.state[0])), data, C.opus_int32(len(payload)),
+		(*C.opus_int16)(unsafe.Pointer(&pcm[0])), C.int(len(pcm)), 0)
+	if samples < 0 {
+		return nil, opusError(samples)
+	}
+	return pcm[:samples], nil
+}
+
+// Encode is one frame of pcm as a packet, pcm is 2.5, 5, 10, 20, 40 or 60ms of audio
+func (e *encoder) Encode(pcm []int16) ([]byte, error) {
+	if len(pcm) == 0 {
+		return nil, ErrEmptyFrame
+	}
+	packet := make([]byte, maxPacketBytes)
+	size := C.opus_encode((*C.OpusEncoder)(unsafe.Pointer(&e.state[0])), (*C.opus_int16)(unsafe.Pointer(&pcm[0])), C.int(len(pcm)),
+		(*C.uchar)(unsafe.Pointer(&packet[0])), C.opus_int32(len(packet)))
+	if size < 0 {
+		return nil, opusError(C.int(size))
+	}
+	return packet[:size], nil
+}
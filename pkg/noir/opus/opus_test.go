@@ -0,0 +1,44 @@
+//go:build opus
+// +build opus
+
+package opus
+
+import (
+	"github.com/net-prophet/noir/pkg/noir"
+	"math"
+	"testing"
+)
+
+func TestCodec(t *testing.T) {
+	encoder, err := Codec{}.NewEncoder()
+	if err != nil {
+		t.Fatalf("error making an encoder: %s", err)
+	}
+	decoder, err := Codec{}.NewDecoder()
+	if err != nil {
+		t.Fatalf("error making a decoder: %s", err)
+	}
+
+	// a 440Hz tone, a frame of the mix at a time
+	for frame := 0; frame < 10; frame++ {
+		pcm := make([]int16, noir.AudioMixFrameSamples)
+		for i := range pcm {
+			at := float64(frame*len(pcm)+i) / noir.AudioMixRate
+			pcm[i] = int16(8000 * math.Sin(2*math.Pi*440*at))
+		}
+		packet, err := encoder.Encode(pcm)
+		if err != nil || len(packet) == 0 {
+			t.Fatalf("got %d bytes %v encoding frame %d", len(packet), err, frame)
+		}
+		decoded, err := decoder.Decode(packet)
+		if err != nil || len(decoded) != noir.AudioMixFrameSamples {
+			t.Fatalf("got %d samples %v decoding frame %d want %d", len(decoded), err, frame, noir.AudioMixFrameSamples)
+		}
+	}
+	if _, err := encoder.Encode(nil); err != ErrEmptyFrame {
+		t.Errorf("got %v want an empty frame refused", err)
+	}
+	if concealed, err := decoder.Decode(nil); err != nil || len(concealed) == 0 {
+		t.Errorf("got %d samples %v want a lost packet concealed", len(concealed), err)
+	}
+}
@@ -0,0 +1,341 @@
+package noir
+
+import (
+	"errors"
+	"github.com/pion/interceptor"
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+	"github.com/pion/webrtc/v3"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+/*
+Packet Taps:
+A PacketTap inspects the media of peers without being part of it, eg. to measure loudness, count
+frames or look for watermarks. RegisterPacketTap names one, and every peer joining after it is
+tapped: CreateClient wraps the peer's adapter, which once the peer joined hooks its transports,
+so tracks the sfu makes later are tapped from their first packet. The tap is handed copies of
+
+	RTP   every packet the peer publishes, as the sfu's buffer of its ssrc got it, every
+	      simulcast layer too
+	RTCP  every batch the peer sends about the tracks it subscribes to, its receiver reports,
+	      NACKs, PLIs and estimates, before the sfu acts on them
+
+along with a PacketContext saying whose track it is and in which room the peer is. The media path
+never waits on a tap: packets are queued for each of the peer's taps, up to PacketTapBacklog,
+and a goroutine of the peer hands them over in order. When a tap falls that far behind the
+packets it has no room for are dropped and counted in noir_packet_tap_drops_total. The packets
+are the tap's to read, not to change, and it is told nothing more once the peer leaves. Taps live
+on each manager, a node only taps the peers it runs.
+*/
+
+// How many packets wait for a tap of each peer before more are dropped
+const PacketTapBacklog = 256
+
+// PacketContext says what a tapped packet is of
+type PacketContext struct {
+	// Pid is the peer the packets are from, Sid the room it is in
+	Pid string
+	Sid string
+	// Publisher is the peer that publishes the track, Pid itself for its rtp
+	Publisher string
+	TrackID   string
+	StreamID  string
+	Kind      string
+	MimeType  string
+}
+
+// PacketTap is handed copies of the media of peers, see the top of the file
+type PacketTap interface {
+	RTP(context PacketContext, packet *rtp.Packet)
+	RTCP(context PacketContext, packets []rtcp.Packet)
+}
+
+// RegisterPacketTap taps the peers joining from now on with tap, replacing any registered as name
+// before, a nil tap stops tapping new peers with name
+func (m *Manager) RegisterPacketTap(name string, tap PacketTap) error {
+	if name == "" {
+		return errors.New("packet taps need a name")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if tap == nil {
+		delete(m.packetTaps, name)
+	} else {
+		m.packetTaps[name] = tap
+	}
+	return nil
+}
+
+// namedTap is a tap registered as name
+type namedTap struct {
+	name string
+	tap  PacketTap
+}
+
+// tapsOfJoin are the taps of a peer joining now, in the order of their names
+func (m *Manager) tapsOfJoin() []namedTap {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	taps := make([]namedTap, 0, len(m.packetTaps))
+	for name, tap := range m.packetTaps {
+		taps = append(taps, namedTap{name, tap})
+	}
+	sort.Slice(taps, func(i, j int) bool { return taps[i].name < taps[j].name })
+	return taps
+}
+
+// tappedPacket is rtp or rtcp waiting for a tap
+type tappedPacket struct {
+	context PacketContext
+	rtp     *rtp.Packet
+	rtcp    []rtcp.Packet
+}
+
+// packetFeed queues the packets of one of a peer's taps
+type packetFeed struct {
+	name    string
+	tap     PacketTap
+	packets chan tappedPacket
+	// set once the peer left, the sfu may still hand over a packet or two
+	stopped int32
+}
+
+func (f *packetFeed) offer(packet tappedPacket) {
+	if atomic.LoadInt32(&f.stopped) == 1 {
+		return
+	}
+	select {
+	case f.packets <- packet:
+	default:
+		packetTapDrops.WithLabelValues(f.name).Inc()
+	}
+}
+
+// deliver hands the tap its packets until stop closes
+func (f *packetFeed) deliver(stop <-chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		case packet := <-f.packets:
+			if packet.rtp != nil {
+				f.tap.RTP(packet.context, packet.rtp)
+			} else {
+				f.tap.RTCP(packet.context, packet.rtcp)
+			}
+		}
+	}
+}
+
+// copyRTP is a copy of packet for taps, through its wire format as rtp keeps its extensions
+// unexported, nil when it does not marshal
+func copyRTP(packet *rtp.Packet) *rtp.Packet {
+	raw, err := packet.Marshal()
+	if err != nil {
+		return nil
+	}
+	copied := &rtp.Packet{}
+	if err := copied.Unmarshal(raw); err != nil {
+		return nil
+	}
+	return copied
+}
+
+// copyRTCP is a copy of packets for taps, nil when they do not marshal
+func copyRTCP(packets []rtcp.Packet) []rtcp.Packet {
+	raw, err := rtcp.Marshal(packets)
+	if err != nil {
+		return nil
+	}
+	copied, err := rtcp.Unmarshal(raw)
+	if err != nil {
+		return nil
+	}
+	return copied
+}
+
+// tappedPeer is the adapter of a peer joining while taps are registered, see CreateClient
+type tappedPeer struct {
+	PeerAdapter
+	manager *Manager
+	pid     string
+	feeds   []*packetFeed
+	// the room the peer is in, a string, see setRoom
+	room atomic.Value
+
+	mu sync.Mutex
+	// take the feeds off the peer's transports
+	untaps []func()
+	// what each ssrc the peer publishes and subscribes to is of, from its first packet
+	published  map[uint32]PacketContext
+	subscribed map[uint32]PacketContext
+}
+
+func (m *Manager) tapPeer(pid string, peer PeerAdapter, taps []namedTap) *tappedPeer {
+	tapped := &tappedPeer{
+		PeerAdapter: peer,
+		manager:     m,
+		pid:         pid,
+		published:   map[uint32]PacketContext{},
+		subscribed:  map[uint32]PacketContext{},
+	}
+	tapped.room.Store("")
+	for _, named := range taps {
+		tapped.feeds = append(tapped.feeds, &packetFeed{name: named.name, tap: named.tap, packets: make(chan tappedPacket, PacketTapBacklog)})
+	}
+	return tapped
+}
+
+// Join taps the peer's transports once it joined
+func (t *tappedPeer) Join(sid string, offer webrtc.SessionDescription) (*webrtc.SessionDescription, error) {
+	answer, err := t.PeerAdapter.Join(sid, offer)
+	if err != nil {
+		return answer, err
+	}
+	t.setRoom(sid)
+	peer := t.SFUPeer()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if untap, ok := observePublisherRTP(publisherOf(peer), t.offerRTP); ok {
+		t.untaps = append(t.untaps, untap)
+	}
+	if untap, ok := observeSubscriberRTCP(subscriberOf(peer), t.offerRTCP); ok {
+		t.untaps = append(t.untaps, untap)
+	}
+	return answer, nil
+}
+
+// Close takes the feeds off the peer before closing it
+func (t *tappedPeer) Close() error {
+	t.untap()
+	return t.PeerAdapter.Close()
+}
+
+// setRoom is the room of the packets from now on, the peer joined or moved to sid
+func (t *tappedPeer) setRoom(sid string) {
+	t.room.Store(sid)
+}
+
+// movedTaps has the packets of pid's taps say it is in sid, it moved there
+func (m *Manager) movedTaps(pid string, sid string) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if tapped, ok := m.users[pid].(*tappedPeer); ok {
+		tapped.setRoom(sid)
+	}
+}
+
+// untap takes the feeds off the peer's transports, and stops them for what was already read
+func (t *tappedPeer) untap() {
+	t.mu.Lock()
+	untaps := t.untaps
+	t.untaps = nil
+	t.mu.Unlock()
+	for _, untap := range untaps {
+		untap()
+	}
+	for _, feed := range t.feeds {
+		atomic.StoreInt32(&feed.stopped, 1)
+	}
+}
+
+func (t *tappedPeer) offer(context PacketContext, packet tappedPacket) {
+	context.Sid = t.room.Load().(string)
+	packet.context = context
+	for _, feed := range t.feeds {
+		feed.offer(packet)
+	}
+}
+
+// offerRTP hands the feeds a copy of a packet the peer published
+func (t *tappedPeer) offerRTP(info *interceptor.StreamInfo, packet *rtp.Packet) {
+	copied := copyRTP(packet)
+	if copied == nil {
+		return
+	}
+	t.offer(t.publishedContext(info), tappedPacket{rtp: copied})
+}
+
+// offerRTCP hands the feeds a copy of a batch the peer sent one of its senders
+func (t *tappedPeer) offerRTCP(packets []rtcp.Packet) {
+	copied := copyRTCP(packets)
+	if copied == nil {
+		return
+	}
+	t.offer(t.subscribedContext(packets), tappedPacket{rtcp: copied})
+}
+
+// publishedContext is what the stream of info is, from the receiver the sfu made of it
+func (t *tappedPeer) publishedContext(info *interceptor.StreamInfo) PacketContext {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if context, ok := t.published[info.SSRC]; ok {
+		return context
+	}
+	for _, receiver := range receiversOf(publisherOf(t.SFUPeer())) {
+		for layer := 0; layer <= MaxSpatialLayer; layer++ {
+			if receiver.SSRC(layer) != info.SSRC {
+				continue
+			}
+			context := PacketContext{
+				Pid:       t.pid,
+				Publisher: t.pid,
+				TrackID:   receiver.TrackID(),
+				StreamID:  receiver.StreamID(),
+				Kind:      receiver.Kind().String(),
+				MimeType:  receiver.Codec().MimeType,
+			}
+			t.published[info.SSRC] = context
+			return context
+		}
+	}
+	// the sfu has not made the receiver yet
+	return PacketContext{Pid: t.pid, Publisher: t.pid, TrackID: info.ID, MimeType: info.MimeType}
+}
+
+// subscribedContext is what the downtrack a batch is about is, from the ssrcs it covers
+func (t *tappedPeer) subscribedContext(packets []rtcp.Packet) PacketContext {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, packet := range packets {
+		for _, ssrc := range packet.DestinationSSRC() {
+			if context, ok := t.subscribed[ssrc]; ok {
+				return context
+			}
+		}
+	}
+	subscriber := subscriberOf(t.SFUPeer())
+	if subscriber == nil {
+		return PacketContext{Pid: t.pid}
+	}
+	var pids map[string]string
+	for _, track := range downTracksOf(subscriber) {
+		ssrc := downTrackSSRC(track)
+		for _, packet := range packets {
+			for _, destination := range packet.DestinationSSRC() {
+				if destination != ssrc {
+					continue
+				}
+				if pids == nil {
+					t.manager.mu.RLock()
+					pids = t.manager.pidsBySFUID()
+					t.manager.mu.RUnlock()
+				}
+				context := PacketContext{
+					Pid:       t.pid,
+					Publisher: pids[downTrackPublisher(track)],
+					TrackID:   track.ID(),
+					StreamID:  track.StreamID(),
+					Kind:      track.Kind().String(),
+					MimeType:  track.Codec().MimeType,
+				}
+				t.subscribed[ssrc] = context
+				return context
+			}
+		}
+	}
+	return PacketContext{Pid: t.pid}
+}
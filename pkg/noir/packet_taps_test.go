@@ -0,0 +1,152 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/interceptor"
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type recordingTap struct {
+	mu      sync.Mutex
+	packets []*rtp.Packet
+	reports int
+}
+
+func (r *recordingTap) RTP(context PacketContext, packet *rtp.Packet) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.packets = append(r.packets, packet)
+}
+
+func (r *recordingTap) RTCP(context PacketContext, packets []rtcp.Packet) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reports += len(packets)
+}
+
+func (r *recordingTap) handed() (int, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.packets), r.reports
+}
+
+func TestPacketTapBackpressure(t *testing.T) {
+	tap := &recordingTap{}
+	feed := &packetFeed{name: "backed-up", tap: tap, packets: make(chan tappedPacket, PacketTapBacklog)}
+
+	// the tap is not reading yet, so the media path has to drop what it cannot queue
+	droppedBefore := testutil.ToFloat64(packetTapDrops.WithLabelValues("backed-up"))
+	for i := 0; i < PacketTapBacklog+3; i++ {
+		feed.offer(tappedPacket{rtp: &rtp.Packet{Header: rtp.Header{Version: 2, SequenceNumber: uint16(i)}}})
+	}
+	feed.offer(tappedPacket{rtcp: []rtcp.Packet{&rtcp.PictureLossIndication{}}})
+	if dropped := testutil.ToFloat64(packetTapDrops.WithLabelValues("backed-up")) - droppedBefore; dropped != 4 {
+		t.Errorf("got %v packets dropped want 4", dropped)
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go feed.deliver(stop)
+	waitFor(t, "the queued packets to be handed over", func() bool {
+		packets, _ := tap.handed()
+		return packets == PacketTapBacklog
+	})
+	tap.mu.Lock()
+	for i, packet := range tap.packets {
+		if packet.SequenceNumber != uint16(i) {
+			t.Errorf("got packet %d handed over as %d want them in order", packet.SequenceNumber, i)
+			break
+		}
+	}
+	tap.mu.Unlock()
+
+	atomic.StoreInt32(&feed.stopped, 1)
+	feed.offer(tappedPacket{rtcp: []rtcp.Packet{&rtcp.PictureLossIndication{}}})
+	if _, reports := tap.handed(); reports != 0 {
+		t.Errorf("a tap should be handed nothing once its peer left")
+	}
+}
+
+func TestPacketTapCopies(t *testing.T) {
+	mgr, _ := NewTestSetup()
+	tapped := mgr.tapPeer("copied-peer", newFakePeer(), []namedTap{{"copies", &recordingTap{}}})
+	tapped.setRoom("copied-room")
+	feed := tapped.feeds[0]
+
+	packet := &rtp.Packet{Header: rtp.Header{Version: 2, SequenceNumber: 7}, Payload: []byte{1, 2, 3}}
+	packet.SetExtension(1, []byte{0x10})
+	tapped.offerRTP(&interceptor.StreamInfo{ID: "camera", SSRC: 5, MimeType: "video/VP8"}, packet)
+	// the sfu reuses what it read
+	packet.Payload[0] = 9
+	packet.SetExtension(1, []byte{0x20})
+	handed := <-feed.packets
+	if copied := handed.rtp; copied.Payload[0] != 1 || copied.SequenceNumber != 7 {
+		t.Errorf("got %v want the packet as it was read", copied)
+	} else if extension := copied.GetExtension(1); len(extension) != 1 || extension[0] != 0x10 {
+		t.Errorf("got extension %v want it as it was read", extension)
+	}
+	if context := handed.context; context.Pid != "copied-peer" || context.Sid != "copied-room" || context.TrackID != "camera" {
+		t.Errorf("got %+v want the peer, its room and the stream's track", context)
+	}
+
+	report := &rtcp.ReceiverReport{SSRC: 1, Reports: []rtcp.ReceptionReport{{SSRC: 5, FractionLost: 3}}}
+	batch := []rtcp.Packet{report}
+	tapped.offerRTCP(batch)
+	report.Reports[0].FractionLost = 200
+	batch[0] = &rtcp.PictureLossIndication{}
+	copied, ok := (<-feed.packets).rtcp[0].(*rtcp.ReceiverReport)
+	if !ok || copied.Reports[0].FractionLost != 3 {
+		t.Errorf("got %v want the batch as it was read", copied)
+	}
+
+	// and the peer's packets say it moved
+	mgr.users["copied-peer"] = tapped
+	defer delete(mgr.users, "copied-peer")
+	mgr.movedTaps("copied-peer", "moved-room")
+	tapped.offerRTCP(batch)
+	if context := (<-feed.packets).context; context.Sid != "moved-room" {
+		t.Errorf("got %s want the room the peer moved to", context.Sid)
+	}
+	tapped.Close()
+	tapped.offerRTCP(batch)
+	if len(feed.packets) != 0 {
+		t.Errorf("a closed peer should hand its taps nothing")
+	}
+}
+
+func TestPacketTapJoin(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	keys := append(peerKeys("tapped-plain", "tapped-peer"), pb.KeyRoomData("tapped-room"), pb.KeyRoomUsers("tapped-room"))
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	if err := mgr.RegisterPacketTap("", &recordingTap{}); err == nil {
+		t.Errorf("a tap without a name should be refused")
+	}
+	// a fake peer runs its PeerChannel, negotiation and layer watching
+	joinFake(t, mgr, "tapped-plain", "tapped-room", newFakePeer())
+	waitFor(t, "the untapped peer's goroutines", func() bool { return mgr.PeerGoroutines() == 3 })
+	mgr.DisconnectUser("tapped-plain")
+	waitFor(t, "the untapped peer to leave", func() bool { return mgr.PeerGoroutines() == 0 })
+
+	mgr.RegisterPacketTap("first", &recordingTap{})
+	mgr.RegisterPacketTap("second", &recordingTap{})
+	mgr.RegisterPacketTap("second", nil)
+	if taps := mgr.tapsOfJoin(); len(taps) != 1 || taps[0].name != "first" {
+		t.Errorf("got %v want the tap left registered", taps)
+	}
+	joinFake(t, mgr, "tapped-peer", "tapped-room", newFakePeer())
+	// and a tapped one hands its tap packets beside them
+	if _, ok := mgr.users["tapped-peer"].(*tappedPeer); !ok {
+		t.Errorf("want the peer joining with a tap tapped")
+	}
+	waitFor(t, "the tapped peer's goroutines", func() bool { return mgr.PeerGoroutines() == 4 })
+	mgr.DisconnectUser("tapped-peer")
+	waitFor(t, "the tapped peer's goroutines to return", func() bool { return mgr.PeerGoroutines() == 0 })
+}
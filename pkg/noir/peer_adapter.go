@@ -0,0 +1,119 @@
+package noir
+
+import (
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"github.com/pion/webrtc/v3"
+)
+
+/*
+Peer Adapter:
+The worker signals a peer through a PeerAdapter, the handful of sfu.Peer methods and callbacks
+joining, negotiating and trickling need, so tests can hand HandleJoin and PeerChannel a fake
+and watch the signaling go back and forth without an sfu. Manager.CreateClient makes them, with
+the PeerFactory from SetPeerFactory, ion-sfu peers by default.
+Features that reach into the sfu, eg. muting, pausing or stats, work on SFUPeer(). A fake
+returns a peer that never joined, sfu.NewPeer(nil), which they already treat as a peer still
+joining, so they do nothing for it.
+*/
+
+// PeerAdapter is what the worker signals a peer through, see the top of the file
+type PeerAdapter interface {
+	Join(sid string, offer webrtc.SessionDescription) (*webrtc.SessionDescription, error)
+	Answer(offer webrtc.SessionDescription) (*webrtc.SessionDescription, error)
+	SetRemoteDescription(answer webrtc.SessionDescription) error
+	Trickle(candidate webrtc.ICECandidateInit, target int) error
+	Close() error
+	// OnOffer is called with every offer the peer's subscriber makes after the join
+	OnOffer(handler func(offer *webrtc.SessionDescription))
+	OnIceCandidate(handler func(candidate *webrtc.ICECandidateInit, target int))
+	OnICEConnectionStateChange(handler func(state webrtc.ICEConnectionState))
+	// SFUPeer is the sfu's peer behind the adapter
+	SFUPeer() *sfu.Peer
+}
+
+// PeerFactory makes the PeerAdapter of a joining peer, in a session of the provider
+type PeerFactory func(provider sfu.SessionProvider) PeerAdapter
+
+// sfuPeer adapts an ion-sfu peer, whose callbacks are fields
+type sfuPeer struct {
+	peer *sfu.Peer
+}
+
+// NewSFUPeer is the PeerFactory of ion-sfu peers
+func NewSFUPeer(provider sfu.SessionProvider) PeerAdapter {
+	return &sfuPeer{peer: sfu.NewPeer(provider)}
+}
+
+func (p *sfuPeer) Join(sid string, offer webrtc.SessionDescription) (*webrtc.SessionDescription, error) {
+	answer, err := p.peer.Join(sid, offer)
+	if err == nil {
+		hookPeer(p.peer)
+	}
+	return answer, err
+}
+
+func (p *sfuPeer) Answer(offer webrtc.SessionDescription) (*webrtc.SessionDescription, error) {
+	return p.peer.Answer(offer)
+}
+
+func (p *sfuPeer) SetRemoteDescription(answer webrtc.SessionDescription) error {
+	return p.peer.SetRemoteDescription(answer)
+}
+
+func (p *sfuPeer) Trickle(candidate webrtc.ICECandidateInit, target int) error {
+	return p.peer.Trickle(candidate, target)
+}
+
+func (p *sfuPeer) Close() error {
+	unhookPeer(p.peer)
+	return p.peer.Close()
+}
+
+func (p *sfuPeer) OnOffer(handler func(offer *webrtc.SessionDescription)) {
+	p.peer.OnOffer = handler
+}
+
+func (p *sfuPeer) OnIceCandidate(handler func(candidate *webrtc.ICECandidateInit, target int)) {
+	p.peer.OnIceCandidate = handler
+}
+
+func (p *sfuPeer) OnICEConnectionStateChange(handler func(state webrtc.ICEConnectionState)) {
+	p.peer.OnICEConnectionStateChange = handler
+}
+
+func (p *sfuPeer) SFUPeer() *sfu.Peer {
+	return p.peer
+}
+
+// SetPeerFactory makes the peers of joins from now on, nil makes ion-sfu peers
+func (m *Manager) SetPeerFactory(factory PeerFactory) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.peerFactory = factory
+}
+
+// CreateClient makes the PeerAdapter of pid joining in this node's sfu, tapped by the packet taps
+// registered now, see packet_taps.go
+func (m *Manager) CreateClient(pid string) PeerAdapter {
+	m.mu.RLock()
+	factory := m.peerFactory
+	m.mu.RUnlock()
+	if factory == nil {
+		factory = NewSFUPeer
+	}
+	peer := factory(*m.SFU())
+	if taps := m.tapsOfJoin(); len(taps) > 0 {
+		return m.tapPeer(pid, peer, taps)
+	}
+	return peer
+}
+
+// localPeer is the sfu peer of pid when it is connected to this node, nil otherwise
+func (m *Manager) localPeer(pid string) *sfu.Peer {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if client := m.users[pid]; client != nil {
+		return client.SFUPeer()
+	}
+	return nil
+}
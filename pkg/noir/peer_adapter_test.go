@@ -0,0 +1,238 @@
+package noir
+
+import (
+	"encoding/json"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"github.com/pion/webrtc/v3"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakePeer is a PeerAdapter that records what the worker signals it and answers every offer
+type fakePeer struct {
+	mu         sync.Mutex
+	sid        string
+	joined     []webrtc.SessionDescription
+	offers     []webrtc.SessionDescription
+	answers    []webrtc.SessionDescription
+	candidates []webrtc.ICECandidateInit
+	closed     bool
+	// answers offers when set, fakeAnswer otherwise
+	answer      func(offer webrtc.SessionDescription) (*webrtc.SessionDescription, error)
+	onOffer     func(offer *webrtc.SessionDescription)
+	onCandidate func(candidate *webrtc.ICECandidateInit, target int)
+	onState     func(state webrtc.ICEConnectionState)
+	peer        *sfu.Peer
+}
+
+func newFakePeer() *fakePeer {
+	return &fakePeer{peer: sfu.NewPeer(nil)}
+}
+
+// fakeAnswer is the fake's answer to every offer
+func fakeAnswer() *webrtc.SessionDescription {
+	return &webrtc.SessionDescription{Type: webrtc.SDPTypeAnswer, SDP: EXAMPLE_EMPTY_SDP}
+}
+
+func (f *fakePeer) Join(sid string, offer webrtc.SessionDescription) (*webrtc.SessionDescription, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sid = sid
+	f.joined = append(f.joined, offer)
+	return fakeAnswer(), nil
+}
+
+func (f *fakePeer) Answer(offer webrtc.SessionDescription) (*webrtc.SessionDescription, error) {
+	f.mu.Lock()
+	f.offers = append(f.offers, offer)
+	answer := f.answer
+	f.mu.Unlock()
+	if answer != nil {
+		return answer(offer)
+	}
+	return fakeAnswer(), nil
+}
+
+func (f *fakePeer) SetRemoteDescription(answer webrtc.SessionDescription) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.answers = append(f.answers, answer)
+	return nil
+}
+
+func (f *fakePeer) Trickle(candidate webrtc.ICECandidateInit, target int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.candidates = append(f.candidates, candidate)
+	return nil
+}
+
+func (f *fakePeer) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+	return nil
+}
+
+func (f *fakePeer) OnOffer(handler func(offer *webrtc.SessionDescription)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.onOffer = handler
+}
+
+func (f *fakePeer) OnIceCandidate(handler func(candidate *webrtc.ICECandidateInit, target int)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.onCandidate = handler
+}
+
+func (f *fakePeer) OnICEConnectionStateChange(handler func(state webrtc.ICEConnectionState)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.onState = handler
+}
+
+func (f *fakePeer) SFUPeer() *sfu.Peer {
+	return f.peer
+}
+
+// offer is the fake's subscriber offering, as the sfu does when tracks are added
+func (f *fakePeer) offer(offer webrtc.SessionDescription) {
+	f.mu.Lock()
+	handler := f.onOffer
+	f.mu.Unlock()
+	handler(&offer)
+}
+
+// joinFake joins pid to sid through the worker, with fake as its peer
+func joinFake(t *testing.T, mgr *Manager, pid string, sid string, fake *fakePeer) {
+	mgr.SetPeerFactory(func(sfu.SessionProvider) PeerAdapter { return fake })
+	defer mgr.SetPeerFactory(nil)
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id:        pid,
+				RequestId: "join-1",
+				Payload: &pb.SignalRequest_Join{
+					Join: &pb.JoinRequest{Sid: sid, Description: []byte(EXAMPLE_EMPTY_SDP)},
+				},
+			},
+		},
+	})
+	if err := worker.HandleNext(0); err != nil {
+		t.Fatalf("error joining: %s", err)
+	}
+}
+
+// nextSignalReply reads the replies to pid until one is wanted
+func nextSignalReply(t *testing.T, mgr *Manager, pid string, wanted func(*pb.SignalReply) bool) *pb.SignalReply {
+	replies := mgr.GetQueue(pb.KeyTopicFromPeer(pid))
+	for {
+		message, err := replies.BlockUntilNext(time.Second)
+		if err != nil {
+			t.Fatalf("got %s waiting on a reply to %s", err, pid)
+		}
+		reply := pb.NoirReply{}
+		if err := UnmarshalReply(message, &reply); err != nil {
+			t.Fatalf("error reading a reply: %s", err)
+		}
+		if wanted(reply.GetSignal()) {
+			return reply.GetSignal()
+		}
+	}
+}
+
+func TestFakePeerJoin(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	keys := []string{pb.KeyRoomData("fake-room"), pb.KeyRoomUsers("fake-room"), pb.KeyPeerOwner("fake-peer"),
+		pb.KeyUserData("fake-peer"), pb.KeyTopicFromPeer("fake-peer"), pb.KeyTopicToPeer("fake-peer")}
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	fake := newFakePeer()
+	joinFake(t, mgr, "fake-peer", "fake-room", fake)
+	defer mgr.DisconnectUser("fake-peer")
+
+	fake.mu.Lock()
+	if fake.sid != "fake-room" || len(fake.joined) != 1 || fake.joined[0].SDP != EXAMPLE_EMPTY_SDP {
+		t.Errorf("got %s %d offers want the join's offer to fake-room", fake.sid, len(fake.joined))
+	}
+	fake.mu.Unlock()
+	join := nextSignalReply(t, mgr, "fake-peer", func(reply *pb.SignalReply) bool { return reply.GetJoin() != nil })
+	answer := webrtc.SessionDescription{}
+	if err := json.Unmarshal(join.GetJoin().GetDescription(), &answer); err != nil || answer.SDP != fakeAnswer().SDP {
+		t.Errorf("got %s %v want the fake's answer in the join reply", answer.SDP, err)
+	}
+	if join.GetRequestId() != "join-1" {
+		t.Errorf("got %s want the join reply to join-1", join.GetRequestId())
+	}
+
+	// the subscriber's offers go to the client
+	fake.offer(webrtc.SessionDescription{Type: webrtc.SDPTypeOffer, SDP: EXAMPLE_EMPTY_SDP})
+	offered := nextSignalReply(t, mgr, "fake-peer", func(reply *pb.SignalReply) bool { return reply.GetDescription() != nil })
+	offer := webrtc.SessionDescription{}
+	if err := json.Unmarshal(offered.GetDescription(), &offer); err != nil || offer.Type != webrtc.SDPTypeOffer {
+		t.Errorf("got %s %v want the subscriber's offer", offer.Type, err)
+	}
+
+	mgr.DisconnectUser("fake-peer")
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	if !fake.closed {
+		t.Errorf("a disconnected peer should be closed")
+	}
+}
+
+func TestFakePeerNegotiation(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	keys := []string{pb.KeyRoomData("fake-talk"), pb.KeyRoomUsers("fake-talk"), pb.KeyPeerOwner("talking-peer"),
+		pb.KeyUserData("talking-peer"), pb.KeyTopicFromPeer("talking-peer"), pb.KeyTopicToPeer("talking-peer")}
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	fake := newFakePeer()
+	joinFake(t, mgr, "talking-peer", "fake-talk", fake)
+	defer mgr.DisconnectUser("talking-peer")
+	nextSignalReply(t, mgr, "talking-peer", func(reply *pb.SignalReply) bool { return reply.GetJoin() != nil })
+
+	// the client renegotiates, its offer is answered
+	toPeer := mgr.GetQueue(pb.KeyTopicToPeer("talking-peer"))
+	offer := describe("talking-peer", webrtc.SessionDescription{Type: webrtc.SDPTypeOffer, SDP: EXAMPLE_EMPTY_SDP})
+	offer.GetSignal().RequestId = "offer-1"
+	EnqueueRequest(toPeer, offer)
+	answered := nextSignalReply(t, mgr, "talking-peer", func(reply *pb.SignalReply) bool { return reply.GetDescription() != nil })
+	answer := webrtc.SessionDescription{}
+	if err := json.Unmarshal(answered.GetDescription(), &answer); err != nil || answer.Type != webrtc.SDPTypeAnswer {
+		t.Errorf("got %s %v want an answer", answer.Type, err)
+	}
+	if answered.GetRequestId() != "offer-1" {
+		t.Errorf("got %s want the answer to offer-1", answered.GetRequestId())
+	}
+
+	// and its answers and candidates are handed to the peer
+	EnqueueRequest(toPeer, describe("talking-peer", *fakeAnswer()))
+	candidate, _ := json.Marshal(webrtc.ICECandidateInit{Candidate: "candidate:1 1 udp 2130706431 192.168.1.2 50000 typ host"})
+	EnqueueRequest(toPeer, &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id:      "talking-peer",
+				Payload: &pb.SignalRequest_Trickle{Trickle: &pb.Trickle{Init: string(candidate)}},
+			},
+		},
+	})
+	waitFor(t, "the peer to get the answer and the candidate", func() bool {
+		fake.mu.Lock()
+		defer fake.mu.Unlock()
+		return len(fake.answers) == 1 && len(fake.candidates) == 1
+	})
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	if len(fake.offers) != 1 || fake.offers[0].Type != webrtc.SDPTypeOffer {
+		t.Errorf("got %d offers want the client's offer answered by the peer", len(fake.offers))
+	}
+}
@@ -0,0 +1,70 @@
+package noir
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+/*
+Peer Goroutines:
+Every connected peer runs a PeerChannel, and that starts goroutines beside it for negotiation,
+layer watching and whichever of quality reports, estimate forwarding, keepalive and idle timeouts
+the peer and its room use, along with one per GetStats in flight. Each is started with goPeer,
+which counts it in PeerGoroutines and the noir_peer_goroutines gauge until it returns, and each
+returns once PeerChannel does: the long lived ones are stopped by channels PeerChannel closes
+as it exits, the rest finish their one job. A count that stays up after its peers are gone is a
+leak.
+
+With SetMaxPeerGoroutines a node whose peers already run that many refuses further joins with
+ErrNodeBusy, replied as a NODE_BUSY worth retrying elsewhere, the same way as NODE_AT_CAPACITY.
+Unlike MaxPeers it is not part of the heartbeat, it guards the node from peers costing more than
+planned rather than placing them.
+*/
+
+// How long a join refused for NODE_BUSY is told to wait before trying again
+const NodeBusyRetryAfter = NodeCapacityRetryAfter
+
+var ErrNodeBusy = errors.New("node busy")
+
+// SetMaxPeerGoroutines refuses joins while peers run that many goroutines, 0 or less is no cap
+func (m *Manager) SetMaxPeerGoroutines(goroutines int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.maxPeerGoroutines = goroutines
+}
+
+func (m *Manager) MaxPeerGoroutines() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.maxPeerGoroutines < 0 {
+		return 0
+	}
+	return m.maxPeerGoroutines
+}
+
+// PeerGoroutines is how many goroutines run for this manager's peers right now
+func (m *Manager) PeerGoroutines() int {
+	return int(atomic.LoadInt64(&m.peerGoroutines))
+}
+
+// busy is ErrNodeBusy when peers run MaxPeerGoroutines, called under m.mu
+func (m *Manager) busy() error {
+	running := m.PeerGoroutines()
+	if m.maxPeerGoroutines > 0 && running >= m.maxPeerGoroutines {
+		joinsBusy.Inc()
+		return fmt.Errorf("%w: its peers run %d goroutines", ErrNodeBusy, running)
+	}
+	return nil
+}
+
+// goPeer runs routine in a goroutine counted as one of a peer's
+func (w *worker) goPeer(routine func()) {
+	atomic.AddInt64(&w.manager.peerGoroutines, 1)
+	peerGoroutines.WithLabelValues(w.id).Inc()
+	go func() {
+		defer atomic.AddInt64(&w.manager.peerGoroutines, -1)
+		defer peerGoroutines.WithLabelValues(w.id).Dec()
+		routine()
+	}()
+}
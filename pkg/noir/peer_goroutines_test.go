@@ -0,0 +1,99 @@
+package noir
+
+import (
+	"errors"
+	"fmt"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestPeerGoroutinesLeak(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	mgr.SetQualityInterval(20 * time.Millisecond)
+	mgr.SetKeepalive(20*time.Millisecond, 1000)
+	pids := []string{"leaky-first", "leaky-second", "leaky-third"}
+	keys := append(peerKeys(pids...), pb.KeyRoomData("leaky-room"), pb.KeyRoomUsers("leaky-room"))
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	worker := *mgr.GetWorker()
+	before, gaugeBefore := runtime.NumGoroutine(), testutil.ToFloat64(peerGoroutines.WithLabelValues(worker.ID()))
+	for _, pid := range pids {
+		fake := newFakePeer()
+		mgr.SetPeerFactory(func(sfu.SessionProvider) PeerAdapter { return fake })
+		EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+			Command: &pb.NoirRequest_Signal{
+				Signal: &pb.SignalRequest{
+					Id: pid,
+					Payload: &pb.SignalRequest_Join{
+						Join: &pb.JoinRequest{Sid: "leaky-room", Description: []byte(EXAMPLE_EMPTY_SDP), QualityReports: true, Keepalive: true},
+					},
+				},
+			},
+		})
+		if err := worker.HandleNext(0); err != nil {
+			t.Fatalf("error joining %s: %s", pid, err)
+		}
+	}
+	mgr.SetPeerFactory(nil)
+	// each peer runs at least its PeerChannel, negotiation and layer watching
+	if running := mgr.PeerGoroutines(); running < 3*len(pids) {
+		t.Errorf("got %d goroutines want at least 3 for each of %d peers", running, len(pids))
+	}
+
+	// let a few pings and reports go out before the peers leave
+	time.Sleep(100 * time.Millisecond)
+	for _, pid := range pids {
+		mgr.DisconnectUser(pid)
+	}
+	waitFor(t, "the peers' goroutines to return", func() bool { return mgr.PeerGoroutines() == 0 })
+	if gauge := testutil.ToFloat64(peerGoroutines.WithLabelValues(worker.ID())); gauge > gaugeBefore {
+		t.Errorf("got %v in the gauge want the peers' taken off it", gauge)
+	}
+	waitFor(t, fmt.Sprintf("the %d goroutines from before the peers joined", before), func() bool {
+		return runtime.NumGoroutine() <= before
+	})
+}
+
+func TestNodeBusy(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	mgr.SetMaxPeerGoroutines(1)
+	keys := append(peerKeys("busy-first", "busy-second"), pb.KeyRoomData("busy-room"), pb.KeyRoomUsers("busy-room"))
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	if mgr.MaxPeerGoroutines() != 1 {
+		t.Errorf("got %d want the cap as set", mgr.MaxPeerGoroutines())
+	}
+	if err := requestJoin(mgr, "busy-first", "busy-room", "join-1", newFakePeer()); err != nil {
+		t.Fatalf("error joining under the cap: %s", err)
+	}
+	defer mgr.DisconnectUser("busy-first")
+
+	refusedBefore := testutil.ToFloat64(joinsBusy)
+	if err := requestJoin(mgr, "busy-second", "busy-room", "join-2", newFakePeer()); !errors.Is(err, ErrNodeBusy) {
+		mgr.DisconnectUser("busy-second")
+		t.Fatalf("got %v want a join refused while peers run the node's max goroutines", err)
+	}
+	reply := nextSignalReply(t, mgr, "busy-second", func(reply *pb.SignalReply) bool { return reply.GetFailure() != nil })
+	if failure := reply.GetFailure(); failure.GetCode() != pb.SignalError_NODE_BUSY || failure.GetRetryAfterMs() == 0 {
+		t.Errorf("got %s want a NODE_BUSY worth retrying", reply)
+	}
+	if refused := testutil.ToFloat64(joinsBusy) - refusedBefore; refused != 1 {
+		t.Errorf("got %v joins counted busy want 1", refused)
+	}
+	if redis.HExists(pb.KeyRoomUsers("busy-room"), "busy-second").Val() {
+		t.Errorf("a join refused as busy should give up its room slot")
+	}
+
+	// the connected peer may still rejoin
+	if err := requestJoin(mgr, "busy-first", "busy-room", "join-3", newFakePeer()); errors.Is(err, ErrNodeBusy) {
+		t.Errorf("a rejoin should not be refused as busy")
+	}
+}
@@ -0,0 +1,120 @@
+package noir
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-redis/redis"
+)
+
+/*
+Peer IDs:
+A peer's topics, data and ownership are all filed under its pid, and the pid of a join is the
+one its client signals, so two clients joining as the same pid would read each other's replies
+and take each other's peer. The manager's PeerIDMode, from [peers] ids, says what HandleJoin
+makes of it:
+
+	client    the pid is trusted, a second join of it takes the peer over, as a client that
+	          rejoins without resuming does. The default
+	unique    a join whose pid a connected peer has is refused with PEER_ID_TAKEN, and leaves
+	          that peer alone. The pid's topic is the connected peer's, so the refusal only goes
+	          to the join's replyTo, a join without one is refused unanswered. A peer owned by a
+	          worker whose heartbeat expired is not connected, its pid is free
+	assigned  every join is given a new pid, peer- and 32 random hex digits like a trace id, so
+	          they never collide. The join's pid only names the topic its reply goes to,
+	          JoinReply.pid says the peer's pid, which the client signals and reads replies as from
+	          then on. Clients should join as a random pid, as the jsonrpc gateway does. The
+	          node's own jobs keep their job- pids
+
+The ownership of a pid is taken with SETNX in unique mode, so of two joins of a pid racing on
+different workers only one gets it.
+
+In any mode a join's replyTo, when it has one, is the topic its answer and refusals go to in
+place of its pid's. The JoinReply then says the pid, as in assigned mode. The Go Client joins
+with a random replyTo.
+*/
+
+var ErrPeerIDTaken = errors.New("peer id taken")
+
+type PeerIDMode int
+
+const (
+	ClientPeerIDs PeerIDMode = iota
+	UniquePeerIDs
+	AssignedPeerIDs
+)
+
+var peerIDModes = map[string]PeerIDMode{"client": ClientPeerIDs, "unique": UniquePeerIDs, "assigned": AssignedPeerIDs}
+
+func (mode PeerIDMode) String() string {
+	for name, known := range peerIDModes {
+		if known == mode {
+			return name
+		}
+	}
+	return fmt.Sprintf("PeerIDMode(%d)", int(mode))
+}
+
+// ParsePeerIDMode reads [peers] ids, empty is client
+func ParsePeerIDMode(name string) (PeerIDMode, error) {
+	if name == "" {
+		return ClientPeerIDs, nil
+	}
+	mode, ok := peerIDModes[name]
+	if !ok {
+		return ClientPeerIDs, fmt.Errorf("unknown peer id mode %s, want client, unique or assigned", name)
+	}
+	return mode, nil
+}
+
+// SetPeerIDMode says how joins get their pid, see peer_ids.go
+func (m *Manager) SetPeerIDMode(mode PeerIDMode) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.peerIDMode = mode
+}
+
+func (m *Manager) PeerIDMode() PeerIDMode {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.peerIDMode
+}
+
+// AssignPeerID is a pid no other peer of the deployment was given, random so it says nothing
+// of how many came before it
+func (m *Manager) AssignPeerID() string {
+	return "peer-" + NewTraceID()
+}
+
+// ReservePeerID claims pid for workerID unless a connected peer has it, ErrPeerIDTaken when one does
+func (m *Manager) ReservePeerID(pid string, workerID string) error {
+	key := m.Keys().PeerOwner(pid)
+	reserved, err := m.redis.SetNX(key, workerID, m.FailoverWindow()).Result()
+	if err != nil || reserved {
+		return err
+	}
+	return m.redis.Watch(func(tx *redis.Tx) error {
+		owner, err := tx.Get(key).Result()
+		if err == redis.Nil {
+			owner = ""
+		} else if err != nil {
+			return err
+		}
+		if owner != "" && m.peerConnected(pid, owner) {
+			return fmt.Errorf("%w: %s is connected", ErrPeerIDTaken, pid)
+		}
+		// left by a worker that died, or by this one for a peer it no longer runs
+		_, err = tx.TxPipelined(func(pipe redis.Pipeliner) error {
+			pipe.Set(key, workerID, m.FailoverWindow())
+			return nil
+		})
+		return err
+	}, key)
+}
+
+// peerConnected is whether owner still runs pid, for another node its heartbeat is as good as it gets
+func (m *Manager) peerConnected(pid string, owner string) bool {
+	if m.localPeer(pid) != nil {
+		return true
+	}
+	return owner != m.id && m.redis.Exists(m.Keys().WorkerHeartbeat(owner)).Val() > 0
+}
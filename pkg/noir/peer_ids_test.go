@@ -0,0 +1,238 @@
+package noir
+
+import (
+	"context"
+	"errors"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"github.com/pion/webrtc/v3"
+	"strings"
+	"testing"
+)
+
+// requestJoin has the worker handle a join of sid as pid, with fake as the peer it would get
+func requestJoin(mgr *Manager, pid string, sid string, requestID string, fake *fakePeer) error {
+	mgr.SetPeerFactory(func(sfu.SessionProvider) PeerAdapter { return fake })
+	defer mgr.SetPeerFactory(nil)
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id:        pid,
+				RequestId: requestID,
+				Payload: &pb.SignalRequest_Join{
+					Join: &pb.JoinRequest{Sid: sid, Description: []byte(EXAMPLE_EMPTY_SDP)},
+				},
+			},
+		},
+	})
+	return worker.HandleNext(0)
+}
+
+func peerKeys(pids ...string) []string {
+	keys := []string{}
+	for _, pid := range pids {
+		keys = append(keys, pb.KeyPeerOwner(pid), pb.KeyUserData(pid), pb.KeyPeerStatus(pid), pb.KeyUserResume(pid), pb.KeyUserJoined(pid),
+			pb.KeyTopicFromPeer(pid), pb.KeyTopicToPeer(pid))
+	}
+	return keys
+}
+
+func TestParsePeerIDMode(t *testing.T) {
+	for name, want := range map[string]PeerIDMode{"": ClientPeerIDs, "client": ClientPeerIDs, "unique": UniquePeerIDs, "assigned": AssignedPeerIDs} {
+		if mode, err := ParsePeerIDMode(name); err != nil || mode != want {
+			t.Errorf("got %s %v want %s for %q", mode, err, want, name)
+		}
+	}
+	if _, err := ParsePeerIDMode("uuid"); err == nil {
+		t.Errorf("an unknown mode should be refused")
+	}
+}
+
+func TestUniquePeerIDs(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	mgr.SetPeerIDMode(UniquePeerIDs)
+	keys := append(peerKeys("unique-peer"), pb.KeyRoomData("unique-room"), pb.KeyRoomUsers("unique-room"),
+		pb.KeyRoomData("unique-other"), pb.KeyRoomUsers("unique-other"))
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	first := newFakePeer()
+	joinFake(t, mgr, "unique-peer", "unique-room", first)
+	defer mgr.DisconnectUser("unique-peer")
+	nextSignalReply(t, mgr, "unique-peer", func(reply *pb.SignalReply) bool { return reply.GetJoin() != nil })
+
+	// a second client claims the same pid, for another room
+	second := newFakePeer()
+	if err := requestJoin(mgr, "unique-peer", "unique-other", "join-2", second); !errors.Is(err, ErrPeerIDTaken) {
+		t.Errorf("got %v want the second join refused", err)
+	}
+	if count, _ := mgr.GetQueue(pb.KeyTopicFromPeer("unique-peer")).Count(); count != 0 {
+		t.Errorf("got %d replies want the refusal kept off the connected peer's topic", count)
+	}
+	// with a replyTo of its own it hears why
+	redis.Del(pb.KeyTopicFromPeer("unique-requester"))
+	defer redis.Del(pb.KeyTopicFromPeer("unique-requester"))
+	mgr.SetPeerFactory(func(sfu.SessionProvider) PeerAdapter { return second })
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id:        "unique-peer",
+				RequestId: "join-3",
+				Payload: &pb.SignalRequest_Join{
+					Join: &pb.JoinRequest{Sid: "unique-other", Description: []byte(EXAMPLE_EMPTY_SDP), ReplyTo: "unique-requester"},
+				},
+			},
+		},
+	})
+	if err := worker.HandleNext(0); !errors.Is(err, ErrPeerIDTaken) {
+		t.Errorf("got %v want the third join refused", err)
+	}
+	mgr.SetPeerFactory(nil)
+	refused := nextSignalReply(t, mgr, "unique-requester", func(reply *pb.SignalReply) bool { return reply.GetFailure() != nil })
+	if refused.GetFailure().GetCode() != pb.SignalError_PEER_ID_TAKEN || refused.GetRequestId() != "join-3" {
+		t.Errorf("got %s want PEER_ID_TAKEN for join-3", refused)
+	}
+
+	second.mu.Lock()
+	if len(second.joined) != 0 {
+		t.Errorf("the refused join should not have reached the sfu")
+	}
+	second.mu.Unlock()
+	first.mu.Lock()
+	if first.closed {
+		t.Errorf("the connected peer should be left alone")
+	}
+	first.mu.Unlock()
+	mgr.mu.RLock()
+	if mgr.users["unique-peer"] != PeerAdapter(first) {
+		t.Errorf("the connected peer should still be the first join's")
+	}
+	mgr.mu.RUnlock()
+	if owner, _ := mgr.PeerOwner("unique-peer"); owner != mgr.ID() {
+		t.Errorf("got %s want the peer still owned by %s", owner, mgr.ID())
+	}
+	if userData, err := mgr.GetRemoteUserData("unique-peer"); err != nil || userData.GetRoomID() != "unique-room" {
+		t.Errorf("got %s %v want the peer still in unique-room", userData.GetRoomID(), err)
+	}
+	if redis.HExists(pb.KeyRoomUsers("unique-other"), "unique-peer").Val() {
+		t.Errorf("the refused join should not be in unique-other")
+	}
+}
+
+func TestReservePeerID(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	keys := append(peerKeys("stale-peer", "remote-peer"), pb.KeyWorkerHeartbeat("remote-worker"))
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	if err := mgr.ReservePeerID("stale-peer", mgr.ID()); err != nil {
+		t.Fatalf("error reserving a free pid: %s", err)
+	}
+	// this node no longer runs a peer it owns
+	if err := mgr.ReservePeerID("stale-peer", mgr.ID()); err != nil {
+		t.Errorf("got %s want a pid this node does not run free", err)
+	}
+	// and a dead worker's peers are gone
+	mgr.ClaimPeer("stale-peer", "dead-worker")
+	if err := mgr.ReservePeerID("stale-peer", mgr.ID()); err != nil {
+		t.Errorf("got %s want a dead worker's pid free", err)
+	}
+	if owner, _ := mgr.PeerOwner("stale-peer"); owner != mgr.ID() {
+		t.Errorf("got %s want the stale pid taken over", owner)
+	}
+
+	mgr.Heartbeat("remote-worker", 1)
+	mgr.ClaimPeer("remote-peer", "remote-worker")
+	if err := mgr.ReservePeerID("remote-peer", mgr.ID()); !errors.Is(err, ErrPeerIDTaken) {
+		t.Errorf("got %v want a live worker's pid taken", err)
+	}
+	if owner, _ := mgr.PeerOwner("remote-peer"); owner != "remote-worker" {
+		t.Errorf("got %s want the live worker to keep its peer", owner)
+	}
+}
+
+func TestAssignedPeerIDs(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	mgr.SetPeerIDMode(AssignedPeerIDs)
+	keys := append(peerKeys("assigning"), pb.KeyRoomData("assigned-room"), pb.KeyRoomUsers("assigned-room"))
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	// two clients happen to join as the same pid
+	assigned := []string{}
+	fakes := []*fakePeer{newFakePeer(), newFakePeer()}
+	for _, fake := range fakes {
+		if err := requestJoin(mgr, "assigning", "assigned-room", "join-1", fake); err != nil {
+			t.Fatalf("error joining: %s", err)
+		}
+		join := nextSignalReply(t, mgr, "assigning", func(reply *pb.SignalReply) bool { return reply.GetJoin() != nil })
+		pid := join.GetJoin().GetPid()
+		if !strings.HasPrefix(pid, "peer-") || len(pid) != len("peer-")+32 || join.GetId() != pid {
+			t.Fatalf("got %s want the join reply to name the assigned pid", join)
+		}
+		assigned = append(assigned, pid)
+		defer redis.Del(peerKeys(pid)...)
+		defer mgr.DisconnectUser(pid)
+	}
+
+	if assigned[0] == assigned[1] {
+		t.Fatalf("got %s twice want every join its own pid", assigned[0])
+	}
+	mgr.mu.RLock()
+	for i, pid := range assigned {
+		if mgr.users[pid] != PeerAdapter(fakes[i]) {
+			t.Errorf("%s should be the peer of the join it was assigned to", pid)
+		}
+	}
+	if mgr.users["assigning"] != nil {
+		t.Errorf("nobody should have joined as the join's own pid")
+	}
+	mgr.mu.RUnlock()
+	for _, pid := range assigned {
+		if owner, _ := mgr.PeerOwner(pid); owner != mgr.ID() {
+			t.Errorf("got %s want %s owned by %s", owner, pid, mgr.ID())
+		}
+		if !redis.HExists(pb.KeyRoomUsers("assigned-room"), pid).Val() {
+			t.Errorf("%s should be in assigned-room", pid)
+		}
+	}
+
+	// failures go where the join came from
+	mgr.SetAllowAutoCreateRooms(false)
+	requestJoin(mgr, "assigning", "assigned-missing", "join-2", newFakePeer())
+	missing := nextSignalReply(t, mgr, "assigning", func(reply *pb.SignalReply) bool { return reply.GetFailure() != nil })
+	if missing.GetFailure().GetCode() != pb.SignalError_ROOM_NOT_FOUND || missing.GetRequestId() != "join-2" {
+		t.Errorf("got %s want ROOM_NOT_FOUND for join-2", missing)
+	}
+}
+
+func TestClientAssignedPeerID(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	mgr.SetPeerIDMode(AssignedPeerIDs)
+	keys := append(peerKeys("sdk-assigning"), pb.KeyRoomData("sdk-assigned"), pb.KeyRoomUsers("sdk-assigned"))
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+	fake := newFakePeer()
+	mgr.SetPeerFactory(func(sfu.SessionProvider) PeerAdapter { return fake })
+	defer mgr.SetPeerFactory(nil)
+
+	client := NewClient(context.Background(), mgr)
+	defer client.Close()
+	go routeOnce(mgr)
+	if _, err := client.Join("sdk-assigned", "sdk-assigning", webrtc.SessionDescription{Type: webrtc.SDPTypeOffer, SDP: EXAMPLE_EMPTY_SDP}); err != nil {
+		t.Fatalf("error joining: %s", err)
+	}
+	pid := client.PeerID()
+	defer redis.Del(peerKeys(pid)...)
+	defer mgr.DisconnectUser(pid)
+	mgr.mu.RLock()
+	defer mgr.mu.RUnlock()
+	if pid == "sdk-assigning" || mgr.users[pid] != PeerAdapter(fake) {
+		t.Errorf("got %s want the client to speak as its assigned pid", pid)
+	}
+}
@@ -0,0 +1,187 @@
+package noir
+
+import (
+	"github.com/go-redis/redis"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	log "github.com/pion/ion-log"
+	"time"
+)
+
+/*
+Peer Affinity:
+Everything sent to a peer goes through its to-peer topic, and only the worker owning the peer
+should consume it. HandleJoin records the joining worker in KeyPeerOwner before it starts the
+PeerChannel, so a peer rejoining on another worker is taken over by it. A PeerChannel that
+pops a message for a peer owned elsewhere puts the message back for the owner, closes its own
+stale sfu peer, and stops, it handles nothing. A signal for a peer that lands on a worker
+topic, the way a client sending to the wrong topic would, is forwarded to the peer's topic
+too. The owner's PeerChannel removes the record when it ends.
+
+The record expires after the FailoverWindow, like its worker's heartbeat, and the owner's
+heartbeat renews the records of the peers it runs, with their statuses, so a worker that died owns nothing once it
+counts as dead. A PeerChannel checks the owner for every message it pops, from what its node
+last read of it: ClaimPeer keeps it up to date on the node taking the peer, and tells the node of
+the worker it took the peer from on that worker's peerOwners channel. A notice lost on the way
+is made up for by reading the record again once PeerOwnerCacheTTL passed.
+*/
+
+// How long a node goes by what it last read of a peer's owner
+const PeerOwnerCacheTTL = 5 * time.Second
+
+var renewPeerOwner = redis.NewScript(`
+	if redis.call('GET', KEYS[1]) ~= ARGV[1] then
+		return 0
+	end
+	redis.call('PEXPIRE', KEYS[2], ARGV[2])
+	return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+`)
+
+type cachedOwner struct {
+	owner string
+	read  time.Time
+}
+
+// ClaimPeer records workerID as the owner of pid, taking it from any other worker, whose node is
+// told it lost the peer
+func (m *Manager) ClaimPeer(pid string, workerID string) error {
+	key := m.Keys().PeerOwner(pid)
+	var previous *redis.StringCmd
+	_, err := m.redis.TxPipelined(func(pipe redis.Pipeliner) error {
+		previous = pipe.GetSet(key, workerID)
+		pipe.PExpire(key, m.FailoverWindow())
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return err
+	}
+	m.peerOwners.Store(pid, cachedOwner{owner: workerID, read: time.Now()})
+	if from := previous.Val(); from != "" && from != workerID {
+		m.redis.Publish(m.Keys().PeerOwnerChannel(from), pid)
+	}
+	return nil
+}
+
+// cachedPeerOwner is PeerOwner, read again only once the node's last read is PeerOwnerCacheTTL old
+func (m *Manager) cachedPeerOwner(pid string) (string, error) {
+	if cached, ok := m.peerOwners.Load(pid); ok && time.Since(cached.(cachedOwner).read) < PeerOwnerCacheTTL {
+		return cached.(cachedOwner).owner, nil
+	}
+	owner, err := m.PeerOwner(pid)
+	if err == nil {
+		m.peerOwners.Store(pid, cachedOwner{owner: owner, read: time.Now()})
+	}
+	return owner, err
+}
+
+// renewPeerOwners keeps the owner records and statuses of the peers the worker runs from expiring
+func (w *worker) renewPeerOwners() {
+	w.mu.RLock()
+	pids := make([]string, 0, len(w.peers))
+	for pid := range w.peers {
+		pids = append(pids, pid)
+	}
+	w.mu.RUnlock()
+	window := w.manager.FailoverWindow().Milliseconds()
+	for _, pid := range pids {
+		keys := []string{w.manager.Keys().PeerOwner(pid), w.manager.Keys().PeerStatus(pid)}
+		if err := renewPeerOwner.Run(w.manager.redis, keys, w.id, window).Err(); err != nil {
+			log.Errorf("error renewing the owner of %s: %s", pid, err)
+		}
+	}
+}
+
+// watchPeerOwners forgets what the node read of the owners of peers taken from the worker
+func (w *worker) watchPeerOwners() {
+	news := w.manager.redis.Subscribe(w.manager.Keys().PeerOwnerChannel(w.id))
+	defer news.Close()
+	messages := news.Channel()
+	for {
+		select {
+		case <-w.done:
+			return
+		case message, ok := <-messages:
+			if !ok {
+				return
+			}
+			w.manager.peerOwners.Delete(message.Payload)
+		}
+	}
+}
+
+// PeerOwner is the worker that owns pid, empty when no worker does
+func (m *Manager) PeerOwner(pid string) (string, error) {
+	owner, err := m.redis.Get(m.Keys().PeerOwner(pid)).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	return owner, err
+}
+
+// releasePeer removes the ownership of pid, unless another worker took it over meanwhile
+func (m *Manager) releasePeer(pid string, workerID string) error {
+	key := m.Keys().PeerOwner(pid)
+	return m.redis.Watch(func(tx *redis.Tx) error {
+		owner, err := tx.Get(key).Result()
+		if err == redis.Nil || owner != workerID {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		_, err = tx.TxPipelined(func(pipe redis.Pipeliner) error {
+			pipe.Del(key)
+			return nil
+		})
+		if err == nil {
+			m.peerOwners.Delete(pid)
+		}
+		return err
+	}, key)
+}
+
+// ownedElsewhere is the owner of pid when it is a worker other than this one
+func (w *worker) ownedElsewhere(pid string) (string, bool) {
+	owner, err := w.manager.cachedPeerOwner(pid)
+	if err != nil {
+		// keep handling it, as before there were owners
+		w.manager.Logger().With(LogFields{"pid": pid}).Errorf("error getting the owner of %s: %s", pid, err)
+		return "", false
+	}
+	return owner, owner != "" && owner != w.id
+}
+
+// forwardStray sends a signal for an owned peer that reached the worker topic to the peer's
+// topic, true when it did
+func (w *worker) forwardStray(request *pb.NoirRequest, logger Logger) bool {
+	signal := request.GetSignal()
+	if signal == nil || signal.Id == "" || workerSignal(signal) {
+		return false
+	}
+	owner, err := w.manager.PeerOwner(signal.Id)
+	if err != nil || owner == "" {
+		return false
+	}
+	logger.Debugf("forwarding %s for %s to its owner %s", request.Action, signal.Id, owner)
+	if err := EnqueueRequest(w.manager.GetQueue(w.manager.Keys().TopicToPeer(signal.Id)), request); err != nil {
+		logger.Errorf("error forwarding %s to %s: %s", request.Action, signal.Id, err)
+	}
+	return true
+}
+
+// workerSignal is true for the signals HandleSignal handles, which are the worker's to take
+func workerSignal(signal *pb.SignalRequest) bool {
+	return signal.GetJoin() != nil || signal.GetLeave() || signal.GetResume() || signal.GetPlay() != nil
+}
+
+// handOver puts back a message the PeerChannel of a peer owned elsewhere popped, and closes
+// the stale sfu peer, leaving the peer's data to its owner
+func (w *worker) handOver(pid string, client PeerAdapter, recv Queue, message []byte, request *pb.NoirRequest) error {
+	m := w.manager
+	m.mu.Lock()
+	if m.users[pid] == client {
+		delete(m.users, pid)
+	}
+	m.mu.Unlock()
+	m.peerOwners.Delete(pid)
+	client.Close()
+	return Requeue(recv, message, RequestPriority(request))
+}
@@ -0,0 +1,149 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"google.golang.org/protobuf/proto"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPeerAffinity(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	keys := append(peerKeys("affinity-peer"), pb.KeyRoomData("affinity-room"), pb.KeyRoomUsers("affinity-room"))
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+	recorder := newRecordingLogger()
+	mgr.SetLogger(recorder)
+	defer mgr.SetLogger(nil)
+
+	first := *mgr.GetWorker()
+	second := NewWorker("affinity-worker", mgr, NewListQueue("affinity-worker"))
+	join := func(worker Worker, resumeToken string) {
+		EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+			Command: &pb.NoirRequest_Signal{
+				Signal: &pb.SignalRequest{
+					Id:          "affinity-peer",
+					RequestId:   "join-" + worker.ID(),
+					ResumeToken: resumeToken,
+					Payload: &pb.SignalRequest_Join{
+						Join: &pb.JoinRequest{Sid: "affinity-room", Description: []byte(EXAMPLE_EMPTY_SDP)},
+					},
+				},
+			},
+		})
+		if err := worker.HandleNext(0); err != nil {
+			t.Fatalf("error joining on %s: %s", worker.ID(), err)
+		}
+		if owner, _ := mgr.PeerOwner("affinity-peer"); owner != worker.ID() {
+			t.Fatalf("got owner %q want %s after it handled the join", owner, worker.ID())
+		}
+	}
+	// the peer rejoins on the second worker, the first one's PeerChannel is left waiting
+	join(first, "")
+	join(second, resumeTokenOf(t, mgr, "affinity-peer", "join-"+first.ID()))
+	defer mgr.DisconnectUser("affinity-peer")
+
+	replies := mgr.GetQueue(pb.KeyTopicFromPeer("affinity-peer"))
+	setLayer := func(requestID string) *pb.NoirRequest {
+		return &pb.NoirRequest{
+			Command: &pb.NoirRequest_Signal{
+				Signal: &pb.SignalRequest{
+					Id:        "affinity-peer",
+					RequestId: requestID,
+					Payload:   &pb.SignalRequest_SetLayer{SetLayer: &pb.SetLayerRequest{StreamId: "missing"}},
+				},
+			},
+		}
+	}
+	failed := func(requestID string) {
+		deadline := time.Now().Add(2 * time.Second)
+		for time.Now().Before(deadline) {
+			message, err := replies.BlockUntilNext(time.Second)
+			if err != nil {
+				continue
+			}
+			reply := pb.NoirReply{}
+			proto.Unmarshal(message, &reply)
+			if signal := reply.GetSignal(); signal.GetRequestId() == requestID && signal.GetFailure() != nil {
+				return
+			}
+		}
+		t.Fatalf("timed out waiting for a reply to %s", requestID)
+	}
+
+	// on the to-peer topic, the first worker may pop it but only the owner handles it
+	EnqueueRequest(mgr.GetQueue(pb.KeyTopicToPeer("affinity-peer")), setLayer("queued"))
+	failed("queued")
+	// a stray on a worker topic is forwarded to the owner
+	EnqueueRequest(*first.GetQueue(), setLayer("stray"))
+	if err := first.HandleNext(0); err != nil {
+		t.Fatalf("error handling the stray: %s", err)
+	}
+	failed("stray")
+
+	handled := map[string]int{}
+	for _, line := range recorder.Lines() {
+		if line["action"] == "request.servers.setlayer" && strings.HasPrefix(line["msg"], "signal error") {
+			handled[line["worker"]]++
+		}
+	}
+	if handled[first.ID()] != 0 || handled[second.ID()] != 2 {
+		t.Errorf("got %v signal errors by worker, want both from %s", handled, second.ID())
+	}
+}
+
+func TestPeerOwnerRecord(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	other, _ := NewTestSetup()
+	key := pb.KeyPeerOwner("owned-peer")
+	redis.Del(key)
+	defer redis.Del(key)
+	w := (*mgr.GetWorker()).(*worker)
+
+	mgr.ClaimPeer("owned-peer", w.id)
+	if ttl := redis.PTTL(key).Val(); ttl <= 0 || ttl > mgr.FailoverWindow() {
+		t.Errorf("got ttl %s want the record to expire with its worker's heartbeat", ttl)
+	}
+	// the owner's heartbeat renews it
+	w.mu.Lock()
+	w.peers["owned-peer"] = true
+	w.mu.Unlock()
+	defer func() {
+		w.mu.Lock()
+		delete(w.peers, "owned-peer")
+		w.mu.Unlock()
+	}()
+	redis.PExpire(key, time.Second)
+	redis.Set(pb.KeyPeerStatus("owned-peer"), "status", time.Second)
+	defer redis.Del(pb.KeyPeerStatus("owned-peer"))
+	w.renewPeerOwners()
+	if ttl := redis.PTTL(key).Val(); ttl <= time.Second {
+		t.Errorf("got ttl %s want the record renewed by its owner", ttl)
+	}
+	if ttl := redis.PTTL(pb.KeyPeerStatus("owned-peer")).Val(); ttl <= time.Second {
+		t.Errorf("got ttl %s want the peer's status renewed with it", ttl)
+	}
+
+	// a worker of another node takes the peer, this node hears of it before its read is stale
+	go w.watchPeerOwners()
+	channel := pb.KeyPeerOwnerChannel(w.id)
+	waitFor(t, "the worker to watch its peers' owners", func() bool {
+		return redis.PubSubNumSub(channel).Val()[channel] > 0
+	})
+	if owner, _ := mgr.cachedPeerOwner("owned-peer"); owner != w.id {
+		t.Fatalf("got owner %s want %s", owner, w.id)
+	}
+	other.ClaimPeer("owned-peer", "owner-elsewhere")
+	waitFor(t, "the takeover to be heard", func() bool {
+		owner, _ := mgr.cachedPeerOwner("owned-peer")
+		return owner == "owner-elsewhere"
+	})
+	// and the worker that lost it renews nothing
+	redis.PExpire(key, time.Second)
+	w.renewPeerOwners()
+	if ttl := redis.PTTL(key).Val(); ttl > time.Second {
+		t.Errorf("got ttl %s want the record left to its new owner", ttl)
+	}
+}
@@ -0,0 +1,205 @@
+package noir
+
+import (
+	"errors"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"github.com/pion/webrtc/v3"
+	"time"
+)
+
+/*
+Peer Stats:
+A peer can ask for its own stats with a GetStatsRequest, a webrtc-internals for app developers
+without access to the server. Unlike GetRoomStats it covers just the asking peer, and only the
+worker running it answers. The snapshot samples the peer twice, StatsWindow() apart, for the
+bitrates and the loss over the window, while jitter is the buffers' running estimate and the
+round trip that of the nominated ICE candidate pairs. Tracks are the ones the peer publishes,
+inbound, and those it is sent, outbound, with their simulcast layers. Sampling runs beside the
+PeerChannel, one at a time per peer, and a snapshot not taken within PeerStatsTimeout past the
+window is answered with an error instead.
+*/
+
+// Default for Manager.StatsWindow()
+const StatsWindow = 250 * time.Millisecond
+
+// How long past the window a peer waits for its stats
+const PeerStatsTimeout = time.Second
+
+var ErrStatsTimeout = errors.New("stats timed out")
+
+// SetStatsWindow is how long a GetStatsRequest samples the peer for its rates, 0 keeps the default
+func (m *Manager) SetStatsWindow(window time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.statsWindow = window
+}
+
+func (m *Manager) StatsWindow() time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.statsWindow <= 0 {
+		return StatsWindow
+	}
+	return m.statsWindow
+}
+
+// HandleGetStats replies with the peer's PeerStatsSnapshot, or an error once it took too long
+func (w *worker) HandleGetStats(request *pb.NoirRequest, pid string, peer *sfu.Peer, deadline *sfuDeadline) error {
+	signal := request.GetSignal()
+	window := w.manager.StatsWindow()
+	var snapshot *pb.PeerStatsSnapshot
+	err := deadline.Run(window+PeerStatsTimeout, func() error {
+		snapshot = PeerStatsSnapshotOf(pid, peer, window)
+		return nil
+	})
+	if errors.Is(err, ErrNegotiationTimeout) {
+		return w.SignalError(request, pb.SignalError_INTERNAL, ErrStatsTimeout)
+	}
+	targets := w.manager.TargetBitrates(pid)
+	snapshot.Totals.TargetBitrate = w.manager.TargetBitrate(pid)
+	for _, track := range snapshot.Tracks {
+		if track.Inbound {
+			track.TargetBitrate = targets[track.TrackId]
+		}
+	}
+	return w.SignalReply(signal.Id, &pb.NoirReply{
+		Id:      request.Id,
+		TraceID: request.TraceID,
+		Command: &pb.NoirReply_Signal{
+			Signal: &pb.SignalReply{
+				Id:        signal.Id,
+				RequestId: signal.RequestId,
+				Payload: &pb.SignalReply_Stats{
+					Stats: snapshot,
+				},
+			},
+		},
+	})
+}
+
+// PeerStatsSnapshotOf samples the peer's transports and tracks over the window
+func PeerStatsSnapshotOf(pid string, peer *sfu.Peer, window time.Duration) *pb.PeerStatsSnapshot {
+	publisher := publisherOf(peer)
+	subscriber := subscriberOf(peer)
+	sentBefore, receivedBefore := transportBytes(publisher, subscriber)
+	streamsBefore := streamsOf(publisher)
+	started := time.Now()
+	time.Sleep(window)
+
+	totals := PeerStatsOf(pid, peer)
+	elapsed := time.Since(started)
+	streams := streamsOf(publisher)
+	snapshot := &pb.PeerStatsSnapshot{
+		Totals:          totals,
+		WindowMs:        int32(elapsed / time.Millisecond),
+		InboundBitrate:  bitrate(totals.BytesReceived-receivedBefore, elapsed),
+		OutboundBitrate: bitrate(totals.BytesSent-sentBefore, elapsed),
+		FractionLost:    fractionLost(streamsBefore, streams),
+		RttMs:           int32(candidatePairRTT(publisher, subscriber) / time.Millisecond),
+	}
+
+	bySSRC := make(map[uint32]rtpStream, len(streams))
+	for _, stream := range streams {
+		bySSRC[stream.ssrc] = stream
+		if jitter := int32(streamJitter(stream) / time.Millisecond); jitter > snapshot.JitterMs {
+			snapshot.JitterMs = jitter
+		}
+	}
+	for _, receiver := range receiversOf(publisher) {
+		track := &pb.TrackStats{
+			TrackId:  receiver.TrackID(),
+			StreamId: receiver.StreamID(),
+			Kind:     receiver.Kind().String(),
+			Inbound:  true,
+		}
+		for layer := 0; layer < 3; layer++ {
+			ssrc := receiver.SSRC(layer)
+			if ssrc == 0 {
+				continue
+			}
+			track.Layers++
+			stream, ok := bySSRC[ssrc]
+			if !ok {
+				continue
+			}
+			track.Packets += stream.packets
+			if stream.expected > stream.packets {
+				track.PacketsLost += stream.expected - stream.packets
+			}
+			if jitter := int32(streamJitter(stream) / time.Millisecond); jitter > track.JitterMs {
+				track.JitterMs = jitter
+			}
+		}
+		snapshot.Tracks = append(snapshot.Tracks, track)
+	}
+	if subscriber != nil {
+		for _, downTrack := range downTracksOf(subscriber) {
+			packets, octets := downTrackSent(downTrack)
+			snapshot.Tracks = append(snapshot.Tracks, &pb.TrackStats{
+				TrackId:      downTrack.ID(),
+				StreamId:     downTrack.StreamID(),
+				Kind:         downTrack.Kind().String(),
+				Packets:      uint64(packets),
+				Bytes:        uint64(octets),
+				SpatialLayer: downTrackLayer(downTrack),
+			})
+		}
+	}
+	return snapshot
+}
+
+func bitrate(bytes uint64, elapsed time.Duration) uint64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return uint64(float64(bytes*8) / elapsed.Seconds())
+}
+
+// fractionLost is the share of the rtp expected between the samples that never arrived
+func fractionLost(before []rtpStream, after []rtpStream) float32 {
+	counted := make(map[uint32]rtpStream, len(before))
+	for _, stream := range before {
+		counted[stream.ssrc] = stream
+	}
+	var expected, received uint64
+	for _, stream := range after {
+		previous := counted[stream.ssrc]
+		if stream.expected < previous.expected || stream.packets < previous.packets {
+			continue
+		}
+		expected += stream.expected - previous.expected
+		received += stream.packets - previous.packets
+	}
+	if expected == 0 || received >= expected {
+		return 0
+	}
+	return float32(expected-received) / float32(expected)
+}
+
+func streamJitter(stream rtpStream) time.Duration {
+	if stream.clockRate == 0 {
+		return 0
+	}
+	return time.Duration(stream.jitter / float64(stream.clockRate) * float64(time.Second))
+}
+
+// candidatePairRTT is the worst round trip of the peer's nominated ICE candidate pairs
+func candidatePairRTT(publisher *sfu.Publisher, subscriber *sfu.Subscriber) time.Duration {
+	var rtt time.Duration
+	for _, pc := range []*webrtc.PeerConnection{publisherConnection(publisher), subscriberConnection(subscriber)} {
+		if pc == nil {
+			continue
+		}
+		for _, report := range pc.GetStats() {
+			pair, ok := report.(webrtc.ICECandidatePairStats)
+			if !ok || !pair.Nominated {
+				continue
+			}
+			if current := time.Duration(pair.CurrentRoundTripTime * float64(time.Second)); current > rtt {
+				rtt = current
+			}
+		}
+	}
+	return rtt
+}
@@ -0,0 +1,79 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"google.golang.org/protobuf/proto"
+	"testing"
+	"time"
+)
+
+func TestFractionLost(t *testing.T) {
+	before := []rtpStream{{ssrc: 1, packets: 100, expected: 100}, {ssrc: 2, packets: 10, expected: 20}}
+	after := []rtpStream{{ssrc: 1, packets: 190, expected: 200}, {ssrc: 2, packets: 10, expected: 20}, {ssrc: 3, packets: 0, expected: 0}}
+	if lost := fractionLost(before, after); lost != 0.1 {
+		t.Errorf("got %v lost want 0.1, only the loss between the samples", lost)
+	}
+	if lost := fractionLost(after, after); lost != 0 {
+		t.Errorf("got %v lost want none without rtp between the samples", lost)
+	}
+}
+
+func TestGetStats(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	mgr.SetStatsWindow(10 * time.Millisecond)
+	defer mgr.SetStatsWindow(0)
+	keys := []string{pb.KeyRoomData("stats-room"), pb.KeyRoomUsers("stats-room"), pb.KeyPeerOwner("stats-peer"),
+		pb.KeyTopicFromPeer("stats-peer"), pb.KeyTopicToPeer("stats-peer")}
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id: "stats-peer",
+				Payload: &pb.SignalRequest_Join{
+					Join: &pb.JoinRequest{Sid: "stats-room", Description: []byte(EXAMPLE_EMPTY_SDP)},
+				},
+			},
+		},
+	})
+	if err := worker.HandleNext(0); err != nil {
+		t.Fatalf("error joining: %s", err)
+	}
+	defer mgr.DisconnectUser("stats-peer")
+
+	requestID := "stats-" + RandomString(8)
+	EnqueueRequest(mgr.GetQueue(pb.KeyTopicToPeer("stats-peer")), &pb.NoirRequest{
+		Id: requestID,
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id:        "stats-peer",
+				RequestId: requestID,
+				Payload:   &pb.SignalRequest_GetStats{GetStats: &pb.GetStatsRequest{}},
+			},
+		},
+	})
+
+	replies := mgr.GetQueue(pb.KeyTopicFromPeer("stats-peer"))
+	reply := pb.NoirReply{}
+	for reply.GetSignal().GetStats() == nil {
+		message, err := replies.BlockUntilNext(time.Second)
+		if err != nil {
+			t.Fatalf("got no stats: %s", err)
+		}
+		reply = pb.NoirReply{}
+		proto.Unmarshal(message, &reply)
+		if failure := reply.GetSignal().GetFailure(); failure != nil {
+			t.Fatalf("got %s want the peer's stats", failure)
+		}
+	}
+	stats := reply.GetSignal().GetStats()
+	if reply.GetSignal().RequestId != requestID {
+		t.Errorf("got request id %s want %s", reply.GetSignal().RequestId, requestID)
+	}
+	if stats.GetTotals().GetPid() != "stats-peer" || stats.WindowMs < 10 {
+		t.Errorf("got %s want stats-peer sampled over the window", stats)
+	}
+}
@@ -0,0 +1,88 @@
+package noir
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-redis/redis"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	log "github.com/pion/ion-log"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+/*
+Peer Status:
+GetPeerStatus tells whether a peer is connected and how it stands, from any node and without
+touching the peer: its worker records a PeerStatus in KeyPeerStatus when the peer joins, when its
+ICE connection changes, after every negotiation and whenever tracks are added to it, and
+CloseClient deletes it, so a peer that is gone is an ErrPeerNotFound. The status is a snapshot
+of the worker's sfu peer, only as stale as the last of those.
+
+A status is only saved while the peer is in its room's index, checked in the same script that
+saves it, so a record made late, eg. by the goroutine the sfu's negotiations run it on, cannot
+bring back the status CloseClient deleted. It expires after the FailoverWindow like the peer's
+owner record, and the owner's heartbeat renews both, so the status of a peer whose worker died
+goes with it.
+*/
+
+var savePeerStatus = redis.NewScript(`
+	if redis.call('HEXISTS', KEYS[1], ARGV[1]) == 0 then
+		return 0
+	end
+	redis.call('SET', KEYS[2], ARGV[2], 'PX', ARGV[3])
+	return 1
+`)
+
+var ErrPeerNotFound = errors.New("peer not found")
+
+// GetPeerStatus is where pid stands, an ErrPeerNotFound unless it is connected
+func (m *Manager) GetPeerStatus(pid string) (*pb.PeerStatus, error) {
+	packed, err := m.redis.Get(m.Keys().PeerStatus(pid)).Bytes()
+	if err == redis.Nil {
+		return nil, fmt.Errorf("%w: %s", ErrPeerNotFound, pid)
+	} else if err != nil {
+		return nil, err
+	}
+	status := &pb.PeerStatus{}
+	if err := proto.Unmarshal(packed, status); err != nil {
+		return nil, fmt.Errorf("bad status of %s: %w", pid, err)
+	}
+	if status.Worker, err = m.PeerOwner(pid); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// recordPeerStatus saves where the peer of userData stands now, unless it left its room
+func (m *Manager) recordPeerStatus(userData *pb.UserData, peer *sfu.Peer) {
+	status := &pb.PeerStatus{
+		Pid:     userData.Id,
+		RoomID:  userData.RoomID,
+		Role:    userData.Role,
+		Joined:  userData.Created,
+		Updated: timestamppb.Now(),
+	}
+	if publisher := publisherOf(peer); publisher != nil {
+		status.PublishedTracks = int32(len(receiversOf(publisher)))
+		if connection := publisherConnection(publisher); connection != nil {
+			status.IceState = connection.ICEConnectionState().String()
+			status.PublisherSignaling = connection.SignalingState().String()
+		}
+	}
+	if subscriber := subscriberOf(peer); subscriber != nil {
+		status.SubscribedTracks = int32(len(downTracksOf(subscriber)))
+		if connection := subscriberConnection(subscriber); connection != nil {
+			status.SubscriberSignaling = connection.SignalingState().String()
+		}
+	}
+	packed, err := proto.Marshal(status)
+	if err == nil {
+		// the connection's last changes come in after CloseClient deleted the status
+		err = savePeerStatus.Run(m.redis, []string{m.Keys().RoomUsers(userData.RoomID), m.Keys().PeerStatus(userData.Id)},
+			userData.Id, packed, m.FailoverWindow().Milliseconds()).Err()
+	}
+	if err != nil {
+		log.Errorf("error recording the status of %s: %s", userData.Id, err)
+	}
+}
@@ -0,0 +1,62 @@
+package noir
+
+import (
+	"errors"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"testing"
+)
+
+func TestGetPeerStatus(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	keys := []string{pb.KeyRoomData("status-room"), pb.KeyRoomUsers("status-room"), pb.KeyPeerOwner("status-peer"),
+		pb.KeyPeerStatus("status-peer"), pb.KeyTopicFromPeer("status-peer"), pb.KeyTopicToPeer("status-peer")}
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+	mgr.SetRoomData(&pb.RoomData{Id: "status-room"})
+
+	if _, err := mgr.GetPeerStatus("status-peer"); !errors.Is(err, ErrPeerNotFound) {
+		t.Errorf("got %v want ErrPeerNotFound before the join", err)
+	}
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id: "status-peer",
+				Payload: &pb.SignalRequest_Join{
+					Join: &pb.JoinRequest{Sid: "status-room", Description: []byte(EXAMPLE_EMPTY_SDP)},
+				},
+			},
+		},
+	})
+	if err := worker.HandleNext(0); err != nil {
+		t.Fatalf("error joining: %s", err)
+	}
+	defer mgr.DisconnectUser("status-peer")
+
+	status, err := mgr.GetPeerStatus("status-peer")
+	if err != nil {
+		t.Fatalf("error getting status: %s", err)
+	}
+	if status.RoomID != "status-room" || status.Role != pb.Role_PUBLISHER || status.Worker != worker.ID() {
+		t.Errorf("got %s want a publisher in status-room owned by %s", status, worker.ID())
+	}
+	if status.Joined == nil || status.IceState == "" || status.PublisherSignaling != "stable" {
+		t.Errorf("got %s want the join time and connection states", status)
+	}
+	if status.PublishedTracks != 0 || status.SubscribedTracks != 0 {
+		t.Errorf("got %d published %d subscribed want no tracks", status.PublishedTracks, status.SubscribedTracks)
+	}
+	if ttl := redis.PTTL(pb.KeyPeerStatus("status-peer")).Val(); ttl <= 0 || ttl > mgr.FailoverWindow() {
+		t.Errorf("got ttl %s want the status to go with a worker that died", ttl)
+	}
+
+	mgr.DisconnectUser("status-peer")
+	if _, err := mgr.GetPeerStatus("status-peer"); !errors.Is(err, ErrPeerNotFound) {
+		t.Errorf("got %v want ErrPeerNotFound once it closed", err)
+	}
+	// a record the sfu made just before the close comes in after it
+	mgr.recordPeerStatus(&pb.UserData{Id: "status-peer", RoomID: "status-room"}, newFakePeer().SFUPeer())
+	if _, err := mgr.GetPeerStatus("status-peer"); !errors.Is(err, ErrPeerNotFound) {
+		t.Errorf("got %v want a late record of a closed peer dropped", err)
+	}
+}
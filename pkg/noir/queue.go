@@ -1,20 +1,66 @@
 package noir
 
 import (
+	"bytes"
+	"errors"
 	"github.com/go-redis/redis"
 	"io"
 	"time"
 )
 
+// ErrQueueClosed is what a reader gets once it reaches the end Close put on a topic
+var ErrQueueClosed = errors.New("queue closed")
+
+// closedMessage is the message Close adds, it is no protobuf so no request or reply is mistaken for it
+var closedMessage = []byte("\x00noir/queue-closed")
+
+func isClosedMessage(message []byte) bool {
+	return bytes.Equal(message, closedMessage)
+}
+
+// withoutClosed drops the end Close added from what a queue peeked, Next never returns it either
+func withoutClosed(peeked [][]byte) [][]byte {
+	kept := peeked[:0]
+	for _, message := range peeked {
+		if !isClosedMessage(message) {
+			kept = append(kept, message)
+		}
+	}
+	return kept
+}
+
+// Queue hands each message to one reader. Delivery is at-most-once, a message read by a reader
+// that dies before handling it is lost, unless the queue is an AckQueue
 type Queue interface {
 	Add(value []byte) error
 	Next() ([]byte, error)
 	BlockUntilNext(timeout time.Duration) ([]byte, error)
+	// Close ends the topic after what was added so far. Its reader gets those messages, then
+	// ErrQueueClosed from Next or BlockUntilNext, waking up if it was blocked. The end is taken
+	// like any message, so the topic reads on as usual after it, eg. for a peer that rejoins
+	Close() error
+	// Reopen drops an end Close left unread, so it cannot stop the topic's next reader, eg. the
+	// PeerChannel of a pid that joins again after its last one had gone
+	Reopen() error
 	Count() (int64, error)
+	// Peek reads up to the next n messages in the order Next would return them, without taking
+	// them. Consumers keep going meanwhile, so a peeked message may be gone by the time it is used.
+	// The end Close added is left out, so fewer than n may come back from a closed topic
+	Peek(n int) ([][]byte, error)
 	Cleanup() error
 	Topic() string
 }
 
+// QueueFactory builds the Queue for a topic, the manager uses it for every queue
+// so noir can run on brokers other than redis (see NATSQueueFactory)
+type QueueFactory func(topic string, maxAge time.Duration) Queue
+
+func RedisQueueFactory(client *redis.Client) QueueFactory {
+	return func(topic string, maxAge time.Duration) Queue {
+		return NewRedisQueue(client, topic, maxAge)
+	}
+}
+
 type redisQueue struct {
 	client *redis.Client
 	topic  string
@@ -33,6 +79,19 @@ func (q *redisQueue) Add(value []byte) error {
 	return err
 }
 
+// Requeue pushes the message on the end BRPop takes from
+func (q *redisQueue) Requeue(value []byte, _ Priority) error {
+	return q.client.RPush(q.topic, value).Err()
+}
+
+func (q *redisQueue) Close() error {
+	return q.Add(closedMessage)
+}
+
+func (q *redisQueue) Reopen() error {
+	return q.client.LRem(q.topic, 0, closedMessage).Err()
+}
+
 func (q *redisQueue) Cleanup() error {
 	return q.client.Del(q.topic).Err()
 }
@@ -48,6 +107,9 @@ func (q *redisQueue) Next() ([]byte, error) {
 	}
 	if count > 0 {
 		result, err := q.client.RPop(q.topic).Result()
+		if err == nil && isClosedMessage([]byte(result)) {
+			return nil, ErrQueueClosed
+		}
 		return []byte(result), err
 	}
 	return nil, nil
@@ -55,8 +117,13 @@ func (q *redisQueue) Next() ([]byte, error) {
 
 func (q *redisQueue) BlockUntilNext(timeout time.Duration) ([]byte, error) {
 	result, err := q.client.BRPop(timeout, q.topic).Result()
-	if err != nil {
-		return nil, io.EOF
+	if err == redis.Nil {
+		return nil, io.EOF // timed out
+	} else if err != nil {
+		return nil, err
+	}
+	if isClosedMessage([]byte(result[1])) {
+		return nil, ErrQueueClosed
 	}
 	return []byte(result[1]), nil
 }
@@ -65,6 +132,22 @@ func (q *redisQueue) Count() (int64, error) {
 	return q.client.LLen(q.topic).Result()
 }
 
+// Peek reads from the right of the list, where RPop takes messages from
+func (q *redisQueue) Peek(n int) ([][]byte, error) {
+	if n <= 0 {
+		return [][]byte{}, nil
+	}
+	listed, err := q.client.LRange(q.topic, int64(-n), -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	peeked := make([][]byte, len(listed))
+	for i, message := range listed {
+		peeked[len(listed)-1-i] = []byte(message)
+	}
+	return withoutClosed(peeked), nil
+}
+
 func (q *redisQueue) Subscribe() (chan []byte, chan struct{}) {
 	msg, quit := make(chan []byte), make(chan struct{})
 
@@ -0,0 +1,210 @@
+package noir
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	log "github.com/pion/ion-log"
+	"io"
+	"time"
+)
+
+/*
+Queue Encryption:
+Queued messages carry SDP and TURN credentials, so a deployment on a shared or managed redis can
+have them encrypted at rest. A manager given a QueueKeyring before SetupManager wraps every queue
+it hands out with EncryptedQueueFactory: what is added is sealed with AES-GCM under the keyring's
+current key, and what is read is opened with whichever key its header names. Every node must have
+the keys of the messages it reads. To rotate, add the new key everywhere, then make it the current
+one everywhere, and drop the old one once the messages sealed with it are gone. A message that
+does not open, sent in the clear or with a key the node lacks, is dead lettered and skipped.
+
+A sealed message is the length of its key id, the key id, a nonce and the AES-GCM ciphertext,
+which authenticates the key id with the payload.
+*/
+
+var ErrUndecryptable = errors.New("message does not decrypt")
+
+// QueueKeyring seals queued messages with its current key and opens them with any of its keys
+type QueueKeyring struct {
+	current string
+	keys    map[string]cipher.AEAD
+}
+
+// NewQueueKeyring takes AES keys of 16, 24 or 32 bytes by key id, current naming the one to seal with
+func NewQueueKeyring(current string, keys map[string][]byte) (*QueueKeyring, error) {
+	if len(current) > 255 {
+		return nil, fmt.Errorf("key id %s is over 255 bytes", current)
+	}
+	if _, ok := keys[current]; !ok {
+		return nil, fmt.Errorf("no queue key %s to encrypt with", current)
+	}
+	keyring := &QueueKeyring{current: current, keys: make(map[string]cipher.AEAD, len(keys))}
+	for id, key := range keys {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("queue key %s: %w", id, err)
+		}
+		keyring.keys[id], _ = cipher.NewGCM(block)
+	}
+	return keyring, nil
+}
+
+// Keyring is the QueueKeyring of EncryptionKeys, nil when no EncryptKey is set
+func (c QueueConfig) Keyring() (*QueueKeyring, error) {
+	if c.EncryptKey == "" {
+		return nil, nil
+	}
+	keys := make(map[string][]byte, len(c.EncryptionKeys))
+	for id, encoded := range c.EncryptionKeys {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("queue key %s is not base64: %w", id, err)
+		}
+		keys[id] = key
+	}
+	return NewQueueKeyring(c.EncryptKey, keys)
+}
+
+// Seal encrypts message with the current key
+func (k *QueueKeyring) Seal(message []byte) ([]byte, error) {
+	aead := k.keys[k.current]
+	header := append([]byte{byte(len(k.current))}, k.current...)
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	sealed := append(append([]byte{}, header...), nonce...)
+	return aead.Seal(sealed, nonce, message, header), nil
+}
+
+// Open decrypts a message sealed with any of the keys, an ErrUndecryptable if it was not
+func (k *QueueKeyring) Open(sealed []byte) ([]byte, error) {
+	if len(sealed) == 0 || len(sealed) < 1+int(sealed[0]) {
+		return nil, fmt.Errorf("%w: no key id", ErrUndecryptable)
+	}
+	header := sealed[:1+int(sealed[0])]
+	id := string(header[1:])
+	aead, ok := k.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: no key %q", ErrUndecryptable, id)
+	}
+	rest := sealed[len(header):]
+	if len(rest) < aead.NonceSize() {
+		return nil, fmt.Errorf("%w: no nonce", ErrUndecryptable)
+	}
+	message, err := aead.Open(nil, rest[:aead.NonceSize()], rest[aead.NonceSize():], header)
+	if err != nil {
+		return nil, fmt.Errorf("%w with key %q: %s", ErrUndecryptable, id, err)
+	}
+	return message, nil
+}
+
+// SetQueueKeyring encrypts the queues of SetupManager, nil sends messages in the clear
+func (m *Manager) SetQueueKeyring(keyring *QueueKeyring) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.queueKeyring = keyring
+}
+
+func (m *Manager) QueueKeyring() *QueueKeyring {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.queueKeyring
+}
+
+// EncryptedQueueFactory wraps the queues of queues to seal what is added and open what is read,
+// dead lettering with manager what does not open
+func EncryptedQueueFactory(queues QueueFactory, keyring *QueueKeyring, manager *Manager) QueueFactory {
+	return func(topic string, maxAge time.Duration) Queue {
+		return &encryptedQueue{Queue: queues(topic, maxAge), keyring: keyring, manager: manager}
+	}
+}
+
+type encryptedQueue struct {
+	Queue
+	keyring *QueueKeyring
+	manager *Manager
+}
+
+func (q *encryptedQueue) Add(value []byte) error {
+	sealed, err := q.keyring.Seal(value)
+	if err != nil {
+		return err
+	}
+	return q.Queue.Add(sealed)
+}
+
+func (q *encryptedQueue) AddWithPriority(value []byte, priority Priority) error {
+	sealed, err := q.keyring.Seal(value)
+	if err != nil {
+		return err
+	}
+	return AddPriority(q.Queue, sealed, priority)
+}
+
+func (q *encryptedQueue) Requeue(value []byte, priority Priority) error {
+	sealed, err := q.keyring.Seal(value)
+	if err != nil {
+		return err
+	}
+	return Requeue(q.Queue, sealed, priority)
+}
+
+func (q *encryptedQueue) Ack() error {
+	return Ack(q.Queue)
+}
+
+func (q *encryptedQueue) Next() ([]byte, error) {
+	for {
+		sealed, err := q.Queue.Next()
+		if err != nil || sealed == nil {
+			return sealed, err
+		}
+		if message, ok := q.open(sealed); ok {
+			return message, nil
+		}
+	}
+}
+
+// BlockUntilNext waits timeout over after every message that does not open
+func (q *encryptedQueue) BlockUntilNext(timeout time.Duration) ([]byte, error) {
+	for {
+		sealed, err := q.Queue.BlockUntilNext(timeout)
+		if err != nil {
+			return nil, err
+		}
+		if message, ok := q.open(sealed); ok {
+			return message, nil
+		}
+	}
+}
+
+// Peek leaves out what does not open, it is dead lettered once it is read
+func (q *encryptedQueue) Peek(n int) ([][]byte, error) {
+	peeked, err := q.Queue.Peek(n)
+	if err != nil {
+		return nil, err
+	}
+	opened := make([][]byte, 0, len(peeked))
+	for _, sealed := range peeked {
+		if message, err := q.keyring.Open(sealed); err == nil {
+			opened = append(opened, message)
+		}
+	}
+	return opened, nil
+}
+
+func (q *encryptedQueue) open(sealed []byte) ([]byte, bool) {
+	message, err := q.keyring.Open(sealed)
+	if err != nil {
+		log.Errorf("dropping message on %s: %s", q.Topic(), err)
+		queueUndecryptable.Inc()
+		q.manager.DeadLetter(q.Topic(), deadLetterSample(sealed), err)
+		return nil, false
+	}
+	return message, true
+}
@@ -0,0 +1,83 @@
+package noir
+
+import (
+	"bytes"
+	"errors"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"testing"
+	"time"
+)
+
+func TestQueueKeyring(t *testing.T) {
+	old, _ := NewQueueKeyring("old", map[string][]byte{"old": bytes.Repeat([]byte{1}, 16)})
+	rotated, err := NewQueueKeyring("new", map[string][]byte{
+		"old": bytes.Repeat([]byte{1}, 16),
+		"new": bytes.Repeat([]byte{2}, 32),
+	})
+	if err != nil {
+		t.Fatalf("error making keyring: %s", err)
+	}
+
+	sealed, _ := old.Seal([]byte("v=0 offer"))
+	if bytes.Contains(sealed, []byte("offer")) {
+		t.Errorf("a sealed message should not hold its payload in the clear")
+	}
+	if opened, err := rotated.Open(sealed); err != nil || string(opened) != "v=0 offer" {
+		t.Errorf("got %q %v want the old key's message opened after rotating", opened, err)
+	}
+	sealed, _ = rotated.Seal([]byte("v=0 answer"))
+	if _, err := old.Open(sealed); !errors.Is(err, ErrUndecryptable) {
+		t.Errorf("got %v want a message of a key the keyring lacks undecryptable", err)
+	}
+	sealed[len(sealed)-1] ^= 1
+	if _, err := rotated.Open(sealed); !errors.Is(err, ErrUndecryptable) {
+		t.Errorf("got %v want a tampered message undecryptable", err)
+	}
+
+	if _, err := NewQueueKeyring("missing", map[string][]byte{"old": bytes.Repeat([]byte{1}, 16)}); err == nil {
+		t.Errorf("a keyring should need the key it encrypts with")
+	}
+	if _, err := NewQueueKeyring("short", map[string][]byte{"short": []byte("short")}); err == nil {
+		t.Errorf("a keyring should refuse keys that are not AES keys")
+	}
+}
+
+func TestEncryptedQueue(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	redis.Del(pb.KeyDeadLetter("test-worker"))
+	keyring, _ := NewQueueKeyring("k1", map[string][]byte{"k1": bytes.Repeat([]byte{7}, 32)})
+	plain := NewTestQueue("tests/encrypted")
+	plain.Cleanup()
+	defer plain.Cleanup()
+	queue := EncryptedQueueFactory(func(topic string, _ time.Duration) Queue {
+		return NewTestQueue(topic)
+	}, keyring, mgr)("tests/encrypted", 0)
+
+	plain.Add([]byte("sent in the clear"))
+	EnqueueRequest(queue, &pb.NoirRequest{Action: "request.signal.kill"})
+	stored, _ := plain.Peek(2)
+	if len(stored) != 2 || bytes.Contains(stored[1], []byte("request.signal.kill")) {
+		t.Errorf("got %q want the request stored encrypted", stored)
+	}
+
+	message, err := queue.BlockUntilNext(time.Second)
+	if err != nil {
+		t.Fatalf("got no message: %s", err)
+	}
+	request := pb.NoirRequest{}
+	if err := UnmarshalRequest(message, &request); err != nil || request.Action != "request.signal.kill" {
+		t.Errorf("got %s %v want the request decrypted", &request, err)
+	}
+	entries, _ := mgr.DrainDeadLetter(10)
+	if len(entries) != 1 || string(entries[0].Message) != "sent in the clear" || entries[0].Topic != "tests/encrypted" {
+		t.Errorf("got %+v want the message sent in the clear dead lettered", entries)
+	}
+}
+
+func TestEncryptedQueueClose(t *testing.T) {
+	mgr, _ := NewTestSetup()
+	keyring, _ := NewQueueKeyring("k1", map[string][]byte{"k1": bytes.Repeat([]byte{7}, 32)})
+	checkClose(t, EncryptedQueueFactory(func(topic string, _ time.Duration) Queue {
+		return NewTestQueue(topic)
+	}, keyring, mgr)("tests/encrypted/close", 0))
+}
@@ -0,0 +1,205 @@
+package noir
+
+import (
+	"github.com/nats-io/nats.go"
+	log "github.com/pion/ion-log"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// natsQueue is a Queue backed by a NATS JetStream work-queue stream,
+// every topic gets its own stream with one shared durable pull consumer
+// so, like a redis list, each message is delivered to only one reader
+type natsQueue struct {
+	js      nats.JetStreamContext
+	topic   string
+	subject string
+	stream  string
+	maxAge  time.Duration
+	sub     *nats.Subscription
+	mu      sync.Mutex
+}
+
+// streams we already created on this process, so GetQueue stays cheap
+var natsStreams sync.Map
+
+func NewNATSQueue(conn *nats.Conn, subject string, maxAge time.Duration) Queue {
+	js, err := conn.JetStream()
+	if err != nil {
+		log.Errorf("nats jetstream unavailable: %s", err)
+	}
+	q := &natsQueue{
+		js:      js,
+		topic:   subject,
+		subject: natsSubject(subject),
+		stream:  natsStreamName(subject),
+		maxAge:  maxAge,
+	}
+	if err := q.ensureStream(); err != nil {
+		log.Errorf("error creating nats stream %s: %s", q.stream, err)
+	}
+	return q
+}
+
+func NATSQueueFactory(conn *nats.Conn) QueueFactory {
+	return func(topic string, maxAge time.Duration) Queue {
+		return NewNATSQueue(conn, topic, maxAge)
+	}
+}
+
+// NATS subjects are dot separated, our topics are slash separated
+func natsSubject(topic string) string {
+	tokens := []string{}
+	for _, token := range strings.Split(topic, "/") {
+		if token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	return strings.Join(tokens, ".")
+}
+
+// Stream names cannot contain dots, wildcards, slashes or whitespace
+func natsStreamName(topic string) string {
+	return strings.NewReplacer(".", "_", "*", "_", ">", "_", "/", "_", " ", "_").Replace(topic)
+}
+
+func (q *natsQueue) ensureStream() error {
+	if _, ok := natsStreams.Load(q.stream); ok {
+		return nil
+	}
+	_, err := q.js.AddStream(&nats.StreamConfig{
+		Name:      q.stream,
+		Subjects:  []string{q.subject},
+		Retention: nats.WorkQueuePolicy,
+		MaxAge:    q.maxAge,
+	})
+	if err != nil && !strings.Contains(err.Error(), "already in use") {
+		return err
+	}
+	natsStreams.Store(q.stream, true)
+	return nil
+}
+
+func (q *natsQueue) subscription() (*nats.Subscription, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.sub != nil {
+		return q.sub, nil
+	}
+	sub, err := q.js.PullSubscribe(q.subject, q.stream)
+	if err != nil {
+		return nil, err
+	}
+	q.sub = sub
+	return sub, nil
+}
+
+func (q *natsQueue) Add(value []byte) error {
+	_, err := q.js.Publish(q.subject, value)
+	return err
+}
+
+func (q *natsQueue) Close() error {
+	return q.Add(closedMessage)
+}
+
+// Reopen deletes the ends left in the stream by sequence, as Peek reads it
+func (q *natsQueue) Reopen() error {
+	info, err := q.js.StreamInfo(q.stream)
+	if err != nil {
+		return err
+	}
+	for seq := info.State.FirstSeq; seq <= info.State.LastSeq && info.State.Msgs > 0; seq++ {
+		msg, err := q.js.GetMsg(q.stream, seq)
+		if err != nil || !isClosedMessage(msg.Data) {
+			continue
+		}
+		if err := q.js.DeleteMsg(q.stream, seq); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (q *natsQueue) Cleanup() error {
+	return q.js.PurgeStream(q.stream)
+}
+
+func (q *natsQueue) Topic() string {
+	return q.topic
+}
+
+func (q *natsQueue) Next() ([]byte, error) {
+	count, err := q.Count()
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return q.BlockUntilNext(QueueMessageTimeout)
+	}
+	return nil, nil
+}
+
+// BlockUntilNext waits up to timeout (forever when 0) and returns io.EOF if nothing arrived
+func (q *natsQueue) BlockUntilNext(timeout time.Duration) ([]byte, error) {
+	sub, err := q.subscription()
+	if err != nil {
+		return nil, err
+	}
+	wait := timeout
+	if timeout == 0 {
+		wait = WorkerPollTimeout
+	}
+	for {
+		msgs, err := sub.Fetch(1, nats.MaxWait(wait))
+		if err == nats.ErrTimeout {
+			if timeout == 0 {
+				continue
+			}
+			return nil, io.EOF
+		} else if err != nil {
+			return nil, err
+		}
+		if len(msgs) == 0 {
+			continue
+		}
+		msgs[0].Ack()
+		if isClosedMessage(msgs[0].Data) {
+			return nil, ErrQueueClosed
+		}
+		return msgs[0].Data, nil
+	}
+}
+
+func (q *natsQueue) Count() (int64, error) {
+	info, err := q.js.StreamInfo(q.stream)
+	if err != nil {
+		return 0, err
+	}
+	return int64(info.State.Msgs), nil
+}
+
+// Peek reads the stream by sequence, acked messages leave gaps that are skipped
+func (q *natsQueue) Peek(n int) ([][]byte, error) {
+	peeked := [][]byte{}
+	if n <= 0 {
+		return peeked, nil
+	}
+	info, err := q.js.StreamInfo(q.stream)
+	if err != nil {
+		return nil, err
+	}
+	for seq := info.State.FirstSeq; seq <= info.State.LastSeq && len(peeked) < n && info.State.Msgs > 0; seq++ {
+		msg, err := q.js.GetMsg(q.stream, seq)
+		if err != nil {
+			// consumed since we read the stream's state
+			continue
+		}
+		if !isClosedMessage(msg.Data) {
+			peeked = append(peeked, msg.Data)
+		}
+	}
+	return peeked, nil
+}
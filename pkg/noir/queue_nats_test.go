@@ -0,0 +1,73 @@
+package noir
+
+import (
+	"github.com/nats-io/nats.go"
+	"io"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func NewTestNATSQueue(t *testing.T, topic string) Queue {
+	url := os.Getenv("TEST_NATS")
+	if url == "" {
+		t.Skip("TEST_NATS not set")
+	}
+	conn, err := nats.Connect(url)
+	if err != nil {
+		t.Fatalf("error connecting to nats %s", err)
+	}
+	return NewNATSQueue(conn, topic, 60*time.Second)
+}
+
+func TestNATSQueueNext(t *testing.T) {
+	addTests := []struct {
+		add  []string
+		want []string
+	}{
+		{[]string{"a"}, []string{"a"}},
+		{[]string{"a", "b", "c"}, []string{"a", "b", "c"}},
+		{[]string{}, []string{}},
+	}
+
+	for n, tt := range addTests {
+		queue := NewTestNATSQueue(t, "tests/nats/next/"+strconv.Itoa(n))
+		queue.Cleanup()
+		for _, msg := range tt.add {
+			if err := queue.Add([]byte(msg)); err != nil {
+				t.Errorf("error adding %s: %s", msg, err)
+			}
+		}
+
+		if count, _ := queue.Count(); count != int64(len(tt.add)) {
+			t.Errorf("got %d want %d", count, len(tt.add))
+		}
+
+		for _, want := range tt.want {
+			got, err := queue.Next()
+			if err != nil {
+				t.Errorf("error getting next %s", err)
+			}
+			if string(got) != want {
+				t.Errorf("got %s want %s", got, want)
+			}
+		}
+
+		if _, err := queue.BlockUntilNext(time.Second); err != io.EOF {
+			t.Errorf("got %s want EOF on empty queue", err)
+		}
+
+		if err := queue.Cleanup(); err != nil {
+			t.Errorf("Error cleaning up: %s", err)
+		}
+	}
+}
+
+func TestNATSQueuePeek(t *testing.T) {
+	checkPeek(t, NewTestNATSQueue(t, "tests/nats/peek"))
+}
+
+func TestNATSQueueClose(t *testing.T) {
+	checkClose(t, NewTestNATSQueue(t, "tests/nats/close"))
+}
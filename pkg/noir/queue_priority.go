@@ -0,0 +1,233 @@
+package noir
+
+import (
+	"encoding/binary"
+	"errors"
+	"github.com/go-redis/redis"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"io"
+	"time"
+)
+
+type Priority int
+
+const (
+	PriorityLow    Priority = -1
+	PriorityNormal Priority = 0
+	PriorityHigh   Priority = 1
+	// A message jumps ahead of at most PriorityJump messages queued before it for each level
+	// it has over them, so a low priority message waits behind at most 2*PriorityJump urgent ones
+	PriorityJump = 1000
+)
+
+// PriorityQueue is a Queue that can hand out urgent messages first, AddPriority
+// falls back to Add for queues that are not
+type PriorityQueue interface {
+	Queue
+	AddWithPriority(value []byte, priority Priority) error
+}
+
+// AddPriority adds to any queue, in priority order when the queue supports it
+func AddPriority(queue Queue, value []byte, priority Priority) error {
+	if priorityQueue, ok := queue.(PriorityQueue); ok {
+		return priorityQueue.AddWithPriority(value, priority)
+	}
+	return queue.Add(value)
+}
+
+// RequeueQueue is a Queue that can put a message its reader took back at its head, Requeue falls
+// back to AddPriority for queues that are not. Queues wrapping another pass priority on for theirs
+type RequeueQueue interface {
+	Queue
+	Requeue(value []byte, priority Priority) error
+}
+
+// Requeue gives back a message taken from queue, first in line when the queue supports it, so
+// the next reader gets it before whatever was queued behind it
+func Requeue(queue Queue, value []byte, priority Priority) error {
+	if requeueQueue, ok := queue.(RequeueQueue); ok {
+		return requeueQueue.Requeue(value, priority)
+	}
+	return AddPriority(queue, value, priority)
+}
+
+// RequestPriority puts kills, mutes, pauses and room admin ahead of everything else, and trickle last
+func RequestPriority(request *pb.NoirRequest) Priority {
+	if signal := request.GetSignal(); signal != nil {
+		switch signal.Payload.(type) {
+		case *pb.SignalRequest_Kill, *pb.SignalRequest_Mute, *pb.SignalRequest_Pause:
+			return PriorityHigh
+		case *pb.SignalRequest_Trickle, *pb.SignalRequest_TrickleBatch:
+			return PriorityLow
+		}
+	} else if request.GetAdmin().GetRoomAdmin() != nil {
+		return PriorityHigh
+	}
+	return PriorityNormal
+}
+
+// ReplyPriority puts kills ahead of other replies, with the reconnect sent before a failover's Kill
+// so the Kill cannot overtake it, and trickle last
+func ReplyPriority(reply *pb.NoirReply) Priority {
+	switch reply.GetSignal().GetPayload().(type) {
+	case *pb.SignalReply_Kill, *pb.SignalReply_Reconnect:
+		return PriorityHigh
+	case *pb.SignalReply_Trickle, *pb.SignalReply_TrickleBatch:
+		return PriorityLow
+	}
+	return PriorityNormal
+}
+
+// RedisPriorityQueueFactory keeps every topic in a sorted set, all nodes sharing a redis must use it
+// because redis will not read a list topic as a sorted set
+func RedisPriorityQueueFactory(client *redis.Client) QueueFactory {
+	return func(topic string, maxAge time.Duration) Queue {
+		return NewRedisPriorityQueue(client, topic, maxAge)
+	}
+}
+
+// redisPriorityQueue scores each message by the order it was added, minus PriorityJump for each level
+// of priority. Messages of one priority stay FIFO, and nothing starves: every new message scores higher
+// than the last, so urgent messages stop jumping ahead of an old one once it is PriorityJump behind
+type redisPriorityQueue struct {
+	client *redis.Client
+	topic  string
+	maxAge time.Duration
+	jump   int64
+}
+
+func NewRedisPriorityQueue(client *redis.Client, topic string, maxAge time.Duration) PriorityQueue {
+	return &redisPriorityQueue{client, topic, maxAge, PriorityJump}
+}
+
+func (q *redisPriorityQueue) sequenceKey() string {
+	return q.topic + "/seq"
+}
+
+func (q *redisPriorityQueue) Add(value []byte) error {
+	return q.AddWithPriority(value, PriorityNormal)
+}
+
+func (q *redisPriorityQueue) AddWithPriority(value []byte, priority Priority) error {
+	seq, err := q.client.Incr(q.sequenceKey()).Result()
+	if err != nil {
+		return err
+	}
+	// The sequence prefix keeps identical messages from collapsing into one member
+	member := make([]byte, 8, 8+len(value))
+	binary.BigEndian.PutUint64(member, uint64(seq))
+	member = append(member, value...)
+	err = q.client.ZAdd(q.topic, redis.Z{
+		Score:  float64(seq - int64(priority)*q.jump),
+		Member: member,
+	}).Err()
+	if q.maxAge > 0 {
+		q.client.Expire(q.topic, q.maxAge)
+		q.client.Expire(q.sequenceKey(), q.maxAge)
+	}
+	return err
+}
+
+// Requeue scores the message below the first one queued, whatever their priorities
+func (q *redisPriorityQueue) Requeue(value []byte, _ Priority) error {
+	seq, err := q.client.Incr(q.sequenceKey()).Result()
+	if err != nil {
+		return err
+	}
+	score := float64(seq - int64(PriorityHigh)*q.jump)
+	if first, err := q.client.ZRangeWithScores(q.topic, 0, 0).Result(); err == nil && len(first) > 0 && first[0].Score <= score {
+		score = first[0].Score - 1
+	}
+	member := make([]byte, 8, 8+len(value))
+	binary.BigEndian.PutUint64(member, uint64(seq))
+	member = append(member, value...)
+	return q.client.ZAdd(q.topic, redis.Z{Score: score, Member: member}).Err()
+}
+
+// Close ends the topic behind everything added so far, whatever its priority
+func (q *redisPriorityQueue) Close() error {
+	return q.AddWithPriority(closedMessage, PriorityLow)
+}
+
+func (q *redisPriorityQueue) Reopen() error {
+	members, err := q.client.ZRange(q.topic, 0, -1).Result()
+	if err != nil {
+		return err
+	}
+	ends := []interface{}{}
+	for _, member := range members {
+		if message, err := unpackPriorityMember(member); err == nil && isClosedMessage(message) {
+			ends = append(ends, member)
+		}
+	}
+	if len(ends) == 0 {
+		return nil
+	}
+	return q.client.ZRem(q.topic, ends...).Err()
+}
+
+func (q *redisPriorityQueue) Cleanup() error {
+	return q.client.Del(q.topic, q.sequenceKey()).Err()
+}
+
+func (q *redisPriorityQueue) Topic() string {
+	return q.topic
+}
+
+func (q *redisPriorityQueue) Next() ([]byte, error) {
+	popped, err := q.client.ZPopMin(q.topic).Result()
+	if err != nil || len(popped) == 0 {
+		return nil, err
+	}
+	return unpackPriorityMessage(popped[0].Member)
+}
+
+func (q *redisPriorityQueue) BlockUntilNext(timeout time.Duration) ([]byte, error) {
+	popped, err := q.client.BZPopMin(timeout, q.topic).Result()
+	if err == redis.Nil {
+		return nil, io.EOF // timed out
+	} else if err != nil {
+		return nil, err
+	}
+	return unpackPriorityMessage(popped.Member)
+}
+
+func (q *redisPriorityQueue) Count() (int64, error) {
+	return q.client.ZCard(q.topic).Result()
+}
+
+func (q *redisPriorityQueue) Peek(n int) ([][]byte, error) {
+	if n <= 0 {
+		return [][]byte{}, nil
+	}
+	members, err := q.client.ZRange(q.topic, 0, int64(n-1)).Result()
+	if err != nil {
+		return nil, err
+	}
+	peeked := make([][]byte, 0, len(members))
+	for _, member := range members {
+		message, err := unpackPriorityMember(member)
+		if err != nil {
+			return nil, err
+		}
+		peeked = append(peeked, message)
+	}
+	return withoutClosed(peeked), nil
+}
+
+// unpackPriorityMessage is unpackPriorityMember for a reader, ErrQueueClosed for the end Close added
+func unpackPriorityMessage(member interface{}) ([]byte, error) {
+	message, err := unpackPriorityMember(member)
+	if err == nil && isClosedMessage(message) {
+		return nil, ErrQueueClosed
+	}
+	return message, err
+}
+
+func unpackPriorityMember(member interface{}) ([]byte, error) {
+	packed, ok := member.(string)
+	if !ok || len(packed) < 8 {
+		return nil, errors.New("malformed priority queue message")
+	}
+	return []byte(packed[8:]), nil
+}
@@ -0,0 +1,158 @@
+package noir
+
+import (
+	"errors"
+	"github.com/go-redis/redis"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"os"
+	"testing"
+	"time"
+)
+
+func newTestPriorityQueue(topic string, jump int64) *redisPriorityQueue {
+	rdb := redis.NewClient(&redis.Options{
+		Addr: os.Getenv("TEST_REDIS"),
+	})
+	queue := &redisPriorityQueue{rdb, topic, 0, jump}
+	queue.Cleanup()
+	return queue
+}
+
+func TestPriorityQueueOrder(t *testing.T) {
+	queue := newTestPriorityQueue("tests/queue/priority/order", PriorityJump)
+	defer queue.Cleanup()
+
+	AddPriority(queue, []byte("trickle"), PriorityLow)
+	AddPriority(queue, []byte("a"), PriorityNormal)
+	AddPriority(queue, []byte("a"), PriorityNormal)
+	AddPriority(queue, []byte("kill"), PriorityHigh)
+	queue.Add([]byte("b"))
+
+	for _, want := range []string{"kill", "a", "a", "b", "trickle"} {
+		got, err := queue.Next()
+		if err != nil || string(got) != want {
+			t.Errorf("got %s %v want %s", got, err, want)
+		}
+	}
+	if _, err := queue.BlockUntilNext(time.Second); err != io.EOF {
+		t.Errorf("got %v want io.EOF from an empty queue", err)
+	}
+}
+
+func TestReplyPriority(t *testing.T) {
+	queue := newTestPriorityQueue("tests/queue/priority/replies", PriorityJump)
+	defer queue.Cleanup()
+
+	// a failover's reconnect and the Kill after it, behind an offer
+	for _, reply := range []*pb.SignalReply{
+		{Payload: &pb.SignalReply_Description{Description: []byte("offer")}},
+		{Payload: &pb.SignalReply_Reconnect{Reconnect: true}},
+		{Payload: &pb.SignalReply_Kill{Kill: true}},
+	} {
+		value, _ := proto.Marshal(reply)
+		AddPriority(queue, value, ReplyPriority(&pb.NoirReply{Command: &pb.NoirReply_Signal{Signal: reply}}))
+	}
+	next := func() *pb.SignalReply {
+		got, _ := queue.Next()
+		reply := &pb.SignalReply{}
+		proto.Unmarshal(got, reply)
+		return reply
+	}
+	if reply := next(); !reply.GetReconnect() {
+		t.Errorf("got %s want the reconnect first", reply)
+	}
+	if reply := next(); !reply.GetKill() {
+		t.Errorf("got %s want the Kill after the reconnect", reply)
+	}
+}
+
+func TestPriorityQueueFairness(t *testing.T) {
+	queue := newTestPriorityQueue("tests/queue/priority/fairness", 4)
+	defer queue.Cleanup()
+
+	AddPriority(queue, []byte("trickle"), PriorityLow)
+	for i := 0; i < 20; i++ {
+		AddPriority(queue, []byte("kill"), PriorityHigh)
+	}
+	for position := 0; position < 21; position++ {
+		got, _ := queue.Next()
+		if string(got) == "trickle" {
+			// kills jump ahead, but no more than 2 levels of 4
+			if position == 0 || position > 8 {
+				t.Errorf("got trickle after %d kills want 1 to 8", position)
+			}
+			return
+		}
+	}
+	t.Errorf("trickle starved")
+}
+
+func TestAddPriorityFallback(t *testing.T) {
+	queue := NewTestQueue("tests/queue/priority/fallback")
+	defer queue.Cleanup()
+
+	AddPriority(queue, []byte("a"), PriorityLow)
+	AddPriority(queue, []byte("b"), PriorityHigh)
+	for _, want := range []string{"a", "b"} {
+		if got, _ := queue.Next(); string(got) != want {
+			t.Errorf("got %s want %s, queues without priorities stay FIFO", got, want)
+		}
+	}
+}
+
+func TestRequeue(t *testing.T) {
+	priority := newTestPriorityQueue("tests/queue/priority/requeue", PriorityJump)
+	defer priority.Cleanup()
+	list := NewTestQueue("tests/queue/requeue")
+	defer list.Cleanup()
+
+	for _, queue := range []Queue{priority, list} {
+		queue.Add([]byte("a"))
+		AddPriority(queue, []byte("kill"), PriorityHigh)
+		taken, _ := queue.Next()
+		queue.Add([]byte("b"))
+		// handed back, it is read before what was queued behind it, urgent or not
+		if err := Requeue(queue, taken, PriorityNormal); err != nil {
+			t.Fatalf("error requeueing on %s: %s", queue.Topic(), err)
+		}
+		AddPriority(queue, []byte("mute"), PriorityHigh)
+		next, _ := queue.Next()
+		if string(next) != string(taken) {
+			t.Errorf("got %s want the requeued %s first on %s", next, taken, queue.Topic())
+		}
+		queue.Cleanup()
+	}
+}
+
+func TestPriorityQueuePeek(t *testing.T) {
+	checkPeek(t, newTestPriorityQueue("tests/queue/priority/peek", PriorityJump))
+
+	queue := newTestPriorityQueue("tests/queue/priority/peek", PriorityJump)
+	defer queue.Cleanup()
+	queue.Add([]byte("a"))
+	AddPriority(queue, []byte("kill"), PriorityHigh)
+	if peeked, _ := queue.Peek(2); len(peeked) != 2 || string(peeked[0]) != "kill" {
+		t.Errorf("got %q want kill peeked first", peeked)
+	}
+}
+
+func TestPriorityQueueClose(t *testing.T) {
+	checkClose(t, newTestPriorityQueue("tests/queue/priority/close", PriorityJump))
+
+	// the end comes after everything added before it, kills too
+	queue := newTestPriorityQueue("tests/queue/priority/close", PriorityJump)
+	defer queue.Cleanup()
+	queue.Add([]byte("trickle"))
+	queue.Close()
+	AddPriority(queue, []byte("kill"), PriorityHigh)
+	for _, want := range []string{"kill", "trickle"} {
+		if message, err := queue.Next(); err != nil || string(message) != want {
+			t.Errorf("got %s %v want %s", message, err, want)
+		}
+	}
+	if _, err := queue.Next(); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("got %v want ErrQueueClosed last", err)
+	}
+}
@@ -0,0 +1,346 @@
+package noir
+
+import (
+	"github.com/go-redis/redis"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+Redis Stream Queues:
+Workers reading the router topic off a redis list each BRPOP on their own, and a message popped
+by a node that dies before routing it is lost. A redisStreamQueue keeps the topic in a redis
+stream read through a consumer group instead: each message goes to exactly one consumer of the
+group, and stays pending with it until it is acked. A message left pending for StreamClaimIdle,
+because its consumer crashed, is claimed by the next consumer to read and delivered again.
+Delivery is at-least-once when a reader acks after handling a message, as the router does, so
+a message handled just before a crash may be handled twice. The workers' dedup, see
+FirstDelivery, skips such redeliveries by request id. A reader that acks right after reading
+gets at-most-once instead: a message is never redelivered, and lost with a crash as it would be
+from a list. Acked messages are deleted from the stream, so it only holds the ones in flight.
+RouterStreamQueueFactory keeps the router topic in a stream and leaves the rest to lists, every
+node sharing a redis must agree on it, since stream commands will not read a list.
+*/
+
+// How long a message stays pending with a consumer before another may claim it
+const StreamClaimIdle = 30 * time.Second
+
+// The consumer group every node reads the router topic in
+const RouterConsumerGroup = "noir-routers"
+
+// the field of a stream entry that holds the message
+const streamField = "m"
+
+// AckQueue is a Queue whose messages stay pending with their reader until acked
+type AckQueue interface {
+	Queue
+	// Ack settles every message this queue handed out since the last Ack
+	Ack() error
+}
+
+// Ack settles what the queue handed out, for queues that deliver until acked and as a no-op for others
+func Ack(queue Queue) error {
+	if ackQueue, ok := queue.(AckQueue); ok {
+		return ackQueue.Ack()
+	}
+	return nil
+}
+
+// RouterStreamQueueFactory reads routerTopic, see Manager.RouterTopic(), through a consumer group,
+// as consumer, and every other topic from queues
+func RouterStreamQueueFactory(queues QueueFactory, client *redis.Client, consumer string, routerTopic string) QueueFactory {
+	return func(topic string, maxAge time.Duration) Queue {
+		if topic == routerTopic {
+			return NewRedisStreamQueue(client, topic, RouterConsumerGroup, consumer, maxAge)
+		}
+		return queues(topic, maxAge)
+	}
+}
+
+type redisStreamQueue struct {
+	client    *redis.Client
+	topic     string
+	group     string
+	consumer  string
+	maxAge    time.Duration
+	claimIdle time.Duration
+
+	mu sync.Mutex
+	// the ids of what was handed out and not acked yet
+	unacked []string
+}
+
+// consumer groups we already created on this process, so GetQueue stays cheap
+var streamGroups sync.Map
+
+// NewRedisStreamQueue reads topic as consumer in group, consumers sharing a group share its messages
+func NewRedisStreamQueue(client *redis.Client, topic string, group string, consumer string, maxAge time.Duration) AckQueue {
+	return &redisStreamQueue{
+		client:    client,
+		topic:     topic,
+		group:     group,
+		consumer:  consumer,
+		maxAge:    maxAge,
+		claimIdle: StreamClaimIdle,
+	}
+}
+
+func (q *redisStreamQueue) groupKey() string {
+	return q.topic + " " + q.group
+}
+
+// ensureGroup makes the consumer group and the stream, once per process unless forget is set
+func (q *redisStreamQueue) ensureGroup(forget bool) error {
+	if forget {
+		streamGroups.Delete(q.groupKey())
+	} else if _, ok := streamGroups.Load(q.groupKey()); ok {
+		return nil
+	}
+	err := q.client.XGroupCreateMkStream(q.topic, q.group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return err
+	}
+	streamGroups.Store(q.groupKey(), true)
+	return nil
+}
+
+func (q *redisStreamQueue) Add(value []byte) error {
+	err := q.client.XAdd(&redis.XAddArgs{
+		Stream: q.topic,
+		Values: map[string]interface{}{streamField: value},
+	}).Err()
+	if q.maxAge > 0 {
+		q.client.Expire(q.topic, q.maxAge)
+	}
+	return err
+}
+
+func (q *redisStreamQueue) Cleanup() error {
+	streamGroups.Delete(q.groupKey())
+	q.mu.Lock()
+	q.unacked = nil
+	q.mu.Unlock()
+	return q.client.Del(q.topic).Err()
+}
+
+func (q *redisStreamQueue) Topic() string {
+	return q.topic
+}
+
+func (q *redisStreamQueue) Next() ([]byte, error) {
+	message, err := q.read(-1)
+	if err == io.EOF {
+		return nil, nil
+	}
+	return message, err
+}
+
+// Close ends the topic for the consumer that reads up to it, the others of the group read on
+func (q *redisStreamQueue) Close() error {
+	return q.Add(closedMessage)
+}
+
+// Reopen deletes the ends left in the stream, an end read is settled already
+func (q *redisStreamQueue) Reopen() error {
+	listed, err := q.client.XRange(q.topic, "-", "+").Result()
+	if err != nil {
+		return err
+	}
+	ends := []string{}
+	for _, message := range listed {
+		if value, _ := message.Values[streamField].(string); isClosedMessage([]byte(value)) {
+			ends = append(ends, message.ID)
+		}
+	}
+	if len(ends) == 0 {
+		return nil
+	}
+	return q.client.XDel(q.topic, ends...).Err()
+}
+
+// BlockUntilNext waits up to timeout (forever when 0) and returns io.EOF if nothing arrived
+func (q *redisStreamQueue) BlockUntilNext(timeout time.Duration) ([]byte, error) {
+	if timeout > 0 {
+		return q.read(timeout)
+	}
+	for {
+		// polls, so messages of a crashed consumer are claimed while nothing new arrives
+		message, err := q.read(WorkerPollTimeout)
+		if err != io.EOF {
+			return message, err
+		}
+	}
+}
+
+// read claims a message left pending too long, or else waits for a new one, blocking up to
+// timeout and not at all when it is negative
+func (q *redisStreamQueue) read(timeout time.Duration) ([]byte, error) {
+	if err := q.ensureGroup(false); err != nil {
+		return nil, err
+	}
+	if message, ok, err := q.claim(); err != nil || ok {
+		return message, err
+	}
+	args := &redis.XReadGroupArgs{
+		Group:    q.group,
+		Consumer: q.consumer,
+		Streams:  []string{q.topic, ">"},
+		Count:    1,
+		Block:    timeout,
+	}
+	streams, err := q.client.XReadGroup(args).Result()
+	if err != nil && strings.HasPrefix(err.Error(), "NOGROUP") {
+		// the stream expired or was cleaned up since the group was made
+		if err := q.ensureGroup(true); err != nil {
+			return nil, err
+		}
+		streams, err = q.client.XReadGroup(args).Result()
+	}
+	if err == redis.Nil {
+		return nil, io.EOF
+	} else if err != nil {
+		return nil, err
+	}
+	for _, stream := range streams {
+		for _, message := range stream.Messages {
+			return q.handOut(message)
+		}
+	}
+	return nil, io.EOF
+}
+
+// claim takes over the oldest message pending with any consumer for longer than claimIdle
+func (q *redisStreamQueue) claim() ([]byte, bool, error) {
+	pending, err := q.client.XPendingExt(&redis.XPendingExtArgs{
+		Stream: q.topic,
+		Group:  q.group,
+		Start:  "-",
+		End:    "+",
+		Count:  1,
+	}).Result()
+	if err != nil && err != redis.Nil {
+		return nil, false, err
+	}
+	if len(pending) == 0 || pending[0].Idle < q.claimIdle {
+		return nil, false, nil
+	}
+	claimed, err := q.client.XClaim(&redis.XClaimArgs{
+		Stream:   q.topic,
+		Group:    q.group,
+		Consumer: q.consumer,
+		MinIdle:  q.claimIdle,
+		Messages: []string{pending[0].Id},
+	}).Result()
+	if err != nil && err != redis.Nil {
+		return nil, false, err
+	}
+	if len(claimed) == 0 {
+		// another consumer claimed it first
+		return nil, false, nil
+	}
+	message, err := q.handOut(claimed[0])
+	return message, true, err
+}
+
+// handOut keeps message pending until the next Ack, except the end Close added, which is settled
+// right away so it is never claimed by another consumer
+func (q *redisStreamQueue) handOut(message redis.XMessage) ([]byte, error) {
+	value, _ := message.Values[streamField].(string)
+	if isClosedMessage([]byte(value)) {
+		if err := q.settle(message.ID); err != nil {
+			return nil, err
+		}
+		return nil, ErrQueueClosed
+	}
+	q.mu.Lock()
+	q.unacked = append(q.unacked, message.ID)
+	q.mu.Unlock()
+	return []byte(value), nil
+}
+
+// Ack settles and deletes what this queue handed out
+func (q *redisStreamQueue) Ack() error {
+	q.mu.Lock()
+	ids := q.unacked
+	q.unacked = nil
+	q.mu.Unlock()
+	return q.settle(ids...)
+}
+
+func (q *redisStreamQueue) settle(ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := q.client.TxPipelined(func(pipe redis.Pipeliner) error {
+		pipe.XAck(q.topic, q.group, ids...)
+		pipe.XDel(q.topic, ids...)
+		return nil
+	})
+	return err
+}
+
+// pendingIDs are the messages handed out to a consumer and not acked yet
+func (q *redisStreamQueue) pendingIDs() (map[string]bool, error) {
+	ids := map[string]bool{}
+	pending, err := q.client.XPending(q.topic, q.group).Result()
+	if err != nil && err != redis.Nil && !strings.HasPrefix(err.Error(), "NOGROUP") {
+		return nil, err
+	}
+	if err != nil || pending.Count == 0 {
+		return ids, nil
+	}
+	listed, err := q.client.XPendingExt(&redis.XPendingExtArgs{
+		Stream: q.topic,
+		Group:  q.group,
+		Start:  "-",
+		End:    "+",
+		Count:  pending.Count,
+	}).Result()
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+	for _, entry := range listed {
+		ids[entry.Id] = true
+	}
+	return ids, nil
+}
+
+// Count is the messages not handed out yet
+func (q *redisStreamQueue) Count() (int64, error) {
+	length, err := q.client.XLen(q.topic).Result()
+	if err != nil {
+		return 0, err
+	}
+	pending, err := q.pendingIDs()
+	if err != nil {
+		return 0, err
+	}
+	return length - int64(len(pending)), nil
+}
+
+// Peek reads the stream from its oldest entry, skipping the ones handed out already
+func (q *redisStreamQueue) Peek(n int) ([][]byte, error) {
+	peeked := [][]byte{}
+	if n <= 0 {
+		return peeked, nil
+	}
+	pending, err := q.pendingIDs()
+	if err != nil {
+		return nil, err
+	}
+	listed, err := q.client.XRangeN(q.topic, "-", "+", int64(n+len(pending))).Result()
+	if err != nil {
+		return nil, err
+	}
+	for _, message := range listed {
+		if pending[message.ID] || len(peeked) == n {
+			continue
+		}
+		if value, _ := message.Values[streamField].(string); !isClosedMessage([]byte(value)) {
+			peeked = append(peeked, []byte(value))
+		}
+	}
+	return peeked, nil
+}
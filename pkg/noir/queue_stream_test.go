@@ -0,0 +1,90 @@
+package noir
+
+import (
+	"github.com/go-redis/redis"
+	"io"
+	"os"
+	"testing"
+	"time"
+)
+
+func newTestStreamQueue(topic string, consumer string) *redisStreamQueue {
+	rdb := redis.NewClient(&redis.Options{Addr: os.Getenv("TEST_REDIS")})
+	return NewRedisStreamQueue(rdb, topic, "tests", consumer, 60*time.Second).(*redisStreamQueue)
+}
+
+func TestStreamQueuePeek(t *testing.T) {
+	checkPeek(t, newTestStreamQueue("tests/stream/peek", "peeker"))
+}
+
+func TestStreamQueueClose(t *testing.T) {
+	checkClose(t, newTestStreamQueue("tests/stream/close", "closer"))
+	// the end settles itself, no other consumer claims it
+	queue := newTestStreamQueue("tests/stream/close", "closer")
+	defer queue.Cleanup()
+	queue.Close()
+	queue.Next()
+	if pending, _ := queue.pendingIDs(); len(pending) != 0 {
+		t.Errorf("got %v pending want the end acked as it was read", pending)
+	}
+}
+
+func TestStreamQueueGroup(t *testing.T) {
+	first := newTestStreamQueue("tests/stream/group", "first")
+	second := newTestStreamQueue("tests/stream/group", "second")
+	first.Cleanup()
+	defer first.Cleanup()
+
+	for _, msg := range []string{"a", "b"} {
+		if err := first.Add([]byte(msg)); err != nil {
+			t.Fatalf("error adding %s: %s", msg, err)
+		}
+	}
+	a, err := first.BlockUntilNext(time.Second)
+	if err != nil || string(a) != "a" {
+		t.Fatalf("got %s %v want a", a, err)
+	}
+	b, err := second.BlockUntilNext(time.Second)
+	if err != nil || string(b) != "b" {
+		t.Fatalf("got %s %v want b, a went to the other consumer", b, err)
+	}
+	if _, err := first.BlockUntilNext(time.Second); err != io.EOF {
+		t.Errorf("got %v want io.EOF, each message goes to one consumer", err)
+	}
+
+	first.Ack()
+	second.Ack()
+	if length := first.client.XLen(first.topic).Val(); length != 0 {
+		t.Errorf("got %d entries left want acked messages deleted", length)
+	}
+}
+
+func TestStreamQueueReclaim(t *testing.T) {
+	crashed := newTestStreamQueue("tests/stream/reclaim", "crashed")
+	survivor := newTestStreamQueue("tests/stream/reclaim", "survivor")
+	crashed.Cleanup()
+	defer crashed.Cleanup()
+	survivor.claimIdle = 100 * time.Millisecond
+
+	crashed.Add([]byte("routed"))
+	if message, err := crashed.Next(); err != nil || string(message) != "routed" {
+		t.Fatalf("got %s %v want the message", message, err)
+	}
+	if message, _ := survivor.Next(); message != nil {
+		t.Fatalf("got %s want nothing while the message is pending with its consumer", message)
+	}
+
+	// the consumer never acks, as though it crashed
+	time.Sleep(150 * time.Millisecond)
+	message, err := survivor.BlockUntilNext(time.Second)
+	if err != nil || string(message) != "routed" {
+		t.Fatalf("got %s %v want the unacked message delivered again", message, err)
+	}
+	survivor.Ack()
+	if count, _ := survivor.Count(); count != 0 {
+		t.Errorf("got %d left want none once acked", count)
+	}
+	if message, _ := survivor.Next(); message != nil {
+		t.Errorf("got %s want an acked message never delivered again", message)
+	}
+}
@@ -1,8 +1,11 @@
 package noir
 
 import (
+	"errors"
 	"strconv"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestQueueAdd(t *testing.T) {
@@ -72,3 +75,167 @@ func TestQueueNext(t *testing.T) {
 		}
 	}
 }
+
+// checkPeek runs a queue through Peek on empty, partly and fully read, and a consumer racing it
+func checkPeek(t *testing.T, queue Queue) {
+	t.Helper()
+	queue.Cleanup()
+	defer queue.Cleanup()
+
+	if peeked, err := queue.Peek(3); err != nil || len(peeked) != 0 {
+		t.Errorf("got %q %v want nothing from an empty queue", peeked, err)
+	}
+	for _, msg := range []string{"a", "b", "c"} {
+		queue.Add([]byte(msg))
+	}
+	if peeked, err := queue.Peek(2); err != nil || len(peeked) != 2 || string(peeked[0]) != "a" || string(peeked[1]) != "b" {
+		t.Errorf("got %q %v want a, b", peeked, err)
+	}
+	if peeked, _ := queue.Peek(10); len(peeked) != 3 {
+		t.Errorf("got %q want all 3 when peeking past the end", peeked)
+	}
+	if count, _ := queue.Count(); count != 3 {
+		t.Errorf("got %d left want peeking to take nothing", count)
+	}
+
+	// a consumer takes what the peeker sees while it peeks, both started at once
+	queue.Cleanup()
+	const racing = 200
+	for i := 0; i < racing; i++ {
+		queue.Add([]byte(strconv.Itoa(i)))
+	}
+	start := make(chan struct{})
+	var done sync.WaitGroup
+	done.Add(2)
+	taken := []string{}
+	go func() {
+		defer done.Done()
+		<-start
+		for i := 0; i < racing; i++ {
+			next, err := queue.Next()
+			if err != nil {
+				t.Errorf("error taking message %d: %s", i, err)
+				return
+			}
+			taken = append(taken, string(next))
+		}
+	}()
+	peeks := [][][]byte{}
+	go func() {
+		defer done.Done()
+		<-start
+		for {
+			peeked, err := queue.Peek(3)
+			if err != nil {
+				t.Errorf("error peeking: %s", err)
+				return
+			}
+			if len(peeked) == 0 {
+				return
+			}
+			peeks = append(peeks, peeked)
+		}
+	}()
+	close(start)
+	done.Wait()
+
+	for i, next := range taken {
+		if next != strconv.Itoa(i) {
+			t.Fatalf("got %s taken %dth want each message taken once in order", next, i)
+		}
+	}
+	if len(taken) != racing {
+		t.Errorf("got %d taken want %d", len(taken), racing)
+	}
+	// each peek is a run of what was left, never behind an earlier one
+	head := 0
+	for _, peeked := range peeks {
+		first, _ := strconv.Atoi(string(peeked[0]))
+		if first < head {
+			t.Errorf("got a peek from %d after one from %d want the head to only move on", first, head)
+		}
+		head = first
+		for i, message := range peeked {
+			if string(message) != strconv.Itoa(first+i) {
+				t.Errorf("got %q want a run from %d", peeked, first)
+				break
+			}
+		}
+	}
+	if peeked, _ := queue.Peek(3); len(peeked) != 0 {
+		t.Errorf("got %q want nothing left", peeked)
+	}
+}
+
+func TestQueuePeek(t *testing.T) {
+	checkPeek(t, NewTestQueue("tests/queue/peek"))
+	checkPeek(t, NewListQueue("tests/queue/peek"))
+}
+
+// checkClose has a reader blocked on queue woken by Close after the messages before it, then the topic read on
+func checkClose(t *testing.T, queue Queue) {
+	t.Helper()
+	queue.Cleanup()
+	defer queue.Cleanup()
+
+	queue.Add([]byte("a"))
+	if err := queue.Close(); err != nil {
+		t.Fatalf("error closing: %s", err)
+	}
+	if peeked, _ := queue.Peek(3); len(peeked) != 1 || string(peeked[0]) != "a" {
+		t.Errorf("got %q want the end left out of a peek", peeked)
+	}
+	if message, err := queue.BlockUntilNext(time.Second); err != nil || string(message) != "a" {
+		t.Errorf("got %s %v want a before the end", message, err)
+	}
+	if _, err := queue.BlockUntilNext(time.Second); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("got %v want ErrQueueClosed at the end", err)
+	}
+
+	// a reader waiting forever is woken up
+	closed := make(chan error, 1)
+	go func() {
+		_, err := queue.BlockUntilNext(0)
+		closed <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	queue.Close()
+	select {
+	case err := <-closed:
+		if !errors.Is(err, ErrQueueClosed) {
+			t.Errorf("got %v want ErrQueueClosed for the blocked reader", err)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatalf("a blocked reader should return once its queue is closed")
+	}
+
+	queue.Add([]byte("b"))
+	if message, err := queue.Next(); err != nil || string(message) != "b" {
+		t.Errorf("got %s %v want the topic to read on after its end", message, err)
+	}
+
+	// an end left unread is dropped by reopening, what was around it is not
+	queue.Add([]byte("c"))
+	queue.Close()
+	queue.Add([]byte("d"))
+	if err := queue.Reopen(); err != nil {
+		t.Fatalf("error reopening: %s", err)
+	}
+	for _, want := range []string{"c", "d"} {
+		if message, err := queue.BlockUntilNext(time.Second); err != nil || string(message) != want {
+			t.Errorf("got %s %v want %s with the end dropped", message, err, want)
+		}
+	}
+	if count, _ := queue.Count(); count != 0 {
+		t.Errorf("got %d left want the end gone", count)
+	}
+}
+
+func TestQueueClose(t *testing.T) {
+	checkClose(t, NewTestQueue("tests/queue/close"))
+	queue := NewListQueue("tests/queue/close")
+	queue.Close()
+	if _, err := queue.Next(); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("got %v want ErrQueueClosed from a closed list queue", err)
+	}
+}
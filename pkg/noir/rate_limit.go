@@ -0,0 +1,109 @@
+package noir
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-redis/redis"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"time"
+)
+
+/*
+Join Rate Limit:
+A client joining over and over could use up every room's slots. A JoinLimiter takes each join
+out of a budget before the peer is made, keyed by the join's identity, or its rateKey when it has
+none, which the gateway sets to the client's IP. A join over budget gets a RATE_LIMITED
+failure with retryAfterMs set. NewRedisJoinLimiter keeps one token bucket per key in redis and
+updates it in a lua script, so every worker spends from the same budget.
+*/
+
+// JoinLimiter decides if a key may join now, and if not how long until it may
+type JoinLimiter interface {
+	Allow(key string) (allowed bool, retryAfter time.Duration, err error)
+}
+
+// JoinLimiterFunc lets a plain func be a JoinLimiter
+type JoinLimiterFunc func(key string) (bool, time.Duration, error)
+
+func (f JoinLimiterFunc) Allow(key string) (bool, time.Duration, error) {
+	return f(key)
+}
+
+// RateLimitConfig allows Joins per Interval seconds from each identity or IP, 0 joins is no limit
+type RateLimitConfig struct {
+	Joins    int `mapstructure:"joins"`
+	Interval int `mapstructure:"interval"`
+}
+
+// redisJoinLimiter is a token bucket of joins tokens per key, refilled over interval
+type redisJoinLimiter struct {
+	client   *redis.Client
+	keys     pb.Keys
+	joins    int
+	interval time.Duration
+}
+
+var takeToken = redis.NewScript(`
+	local capacity = tonumber(ARGV[1])
+	local interval = tonumber(ARGV[2])
+	local now = tonumber(ARGV[3])
+	local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'at')
+	local tokens = tonumber(bucket[1]) or capacity
+	local at = tonumber(bucket[2]) or now
+	if now > at then
+		tokens = math.min(capacity, tokens + (now - at) * capacity / interval)
+		at = now
+	end
+	local wait = 0
+	if tokens >= 1 then
+		tokens = tokens - 1
+	else
+		wait = math.ceil((1 - tokens) * interval / capacity)
+	end
+	redis.call('HMSET', KEYS[1], 'tokens', tostring(tokens), 'at', tostring(at))
+	redis.call('PEXPIRE', KEYS[1], interval)
+	return wait
+`)
+
+// NewRedisJoinLimiter allows joins per interval from each key, shared by every worker on the redis
+// whose manager has keys
+func NewRedisJoinLimiter(client *redis.Client, keys pb.Keys, joins int, interval time.Duration) (JoinLimiter, error) {
+	if joins <= 0 || interval <= 0 {
+		return nil, errors.New("join limiter needs joins and an interval")
+	}
+	return &redisJoinLimiter{client: client, keys: keys, joins: joins, interval: interval}, nil
+}
+
+func (l *redisJoinLimiter) Allow(key string) (bool, time.Duration, error) {
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	wait, err := takeToken.Run(l.client, []string{l.keys.JoinRate(key)},
+		l.joins, l.interval.Milliseconds(), now).Int64()
+	if err != nil {
+		return true, 0, fmt.Errorf("error taking a join token for %s: %w", key, err)
+	}
+	return wait == 0, time.Duration(wait) * time.Millisecond, nil
+}
+
+// SetJoinLimiter limits every join from now on, nil lets joins through unlimited
+func (m *Manager) SetJoinLimiter(limiter JoinLimiter) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.joinLimiter = limiter
+}
+
+// AllowJoin spends one of the join's budget, joins with no identity or rateKey are not limited
+func (m *Manager) AllowJoin(signal *pb.SignalRequest, identity string) (bool, time.Duration, error) {
+	m.mu.RLock()
+	limiter := m.joinLimiter
+	m.mu.RUnlock()
+	if limiter == nil {
+		return true, 0, nil
+	}
+	if identity != "" {
+		return limiter.Allow("identity/" + identity)
+	}
+	if rateKey := signal.GetJoin().GetRateKey(); rateKey != "" {
+		return limiter.Allow("key/" + rateKey)
+	}
+	return true, 0, nil
+}
@@ -0,0 +1,80 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"google.golang.org/protobuf/proto"
+	"testing"
+	"time"
+)
+
+func TestRedisJoinLimiter(t *testing.T) {
+	_, redis := NewTestSetup()
+	redis.Del(pb.KeyJoinRate("limited"), pb.KeyJoinRate("other"))
+	defer redis.Del(pb.KeyJoinRate("limited"), pb.KeyJoinRate("other"))
+
+	limiter, err := NewRedisJoinLimiter(redis, pb.DefaultKeys, 2, time.Minute)
+	if err != nil {
+		t.Fatalf("error making limiter: %s", err)
+	}
+	for i := 0; i < 2; i++ {
+		if allowed, _, err := limiter.Allow("limited"); !allowed || err != nil {
+			t.Fatalf("join %d should be allowed, got %v", i, err)
+		}
+	}
+	allowed, retryAfter, err := limiter.Allow("limited")
+	if allowed || err != nil {
+		t.Fatalf("the third join in a minute should be limited, got %v", err)
+	}
+	// one token comes back every 30s
+	if retryAfter <= 0 || retryAfter > 30*time.Second {
+		t.Errorf("got retry after %s want at most 30s", retryAfter)
+	}
+	if allowed, _, _ := limiter.Allow("other"); !allowed {
+		t.Errorf("another key has its own budget")
+	}
+
+	if _, err := NewRedisJoinLimiter(redis, pb.DefaultKeys, 0, time.Minute); err == nil {
+		t.Errorf("a limiter without joins should be refused")
+	}
+}
+
+func TestWorkerJoinRateLimited(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	mgr.SetJoinLimiter(JoinLimiterFunc(func(key string) (bool, time.Duration, error) {
+		return key != "key/10.0.0.9", 5 * time.Second, nil
+	}))
+	defer mgr.SetJoinLimiter(nil)
+	redis.Del(pb.KeyRoomData("limited"), pb.KeyRoomUsers("limited"), pb.KeyTopicFromPeer("limited-peer"))
+
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id:        "limited-peer",
+				RequestId: "join-1",
+				Payload: &pb.SignalRequest_Join{
+					Join: &pb.JoinRequest{
+						Sid:         "limited",
+						Description: []byte(EXAMPLE_EMPTY_SDP),
+						RateKey:     "10.0.0.9",
+					},
+				},
+			},
+		},
+	})
+	if err := worker.HandleNext(0); err == nil {
+		t.Errorf("a rate limited join should fail")
+	}
+
+	reply := pb.NoirReply{}
+	msg, _ := mgr.GetQueue(pb.KeyTopicFromPeer("limited-peer")).Next()
+	proto.Unmarshal(msg, &reply)
+	failure := reply.GetSignal().GetFailure()
+	if failure.GetCode() != pb.SignalError_RATE_LIMITED || failure.GetRetryAfterMs() != 5000 {
+		t.Errorf("got %s want RATE_LIMITED retrying after 5s", &reply)
+	}
+	if exists := redis.HExists(pb.KeyRoomUsers("limited"), "limited-peer").Val(); exists {
+		t.Errorf("a rate limited join should not take a slot")
+	}
+}
@@ -0,0 +1,198 @@
+package noir
+
+import (
+	"errors"
+	"fmt"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"sync"
+	"time"
+)
+
+/*
+Reconnects:
+A client on a flaky network may join again as the pid it is still connected as, eg. when its
+app retries after a blip, before the old peer timed out. The manager's ReconnectPolicy, from
+[peers] reconnect, says what HandleJoin makes of a join whose pid is connected:
+
+	replace  the join is a reconnect, the new peer takes the old one's place. The old sfu peer
+	         is closed, and a join of the same room keeps its membership and the role it had
+	         without the room hearing it leave. A join of another room is a move, the old room
+	         hears it left as MOVED. The default
+	reject   the join is refused with ALREADY_CONNECTED, and the connected peer carries on.
+	         The client has to resume or leave it first
+
+Only the client that joined may replace its peer: the reconnect has to authenticate as the
+identity the peer joined as, and a peer that joined without one, with no authenticator, has to
+send the resumeToken of its JoinReply with the join; any other join is ALREADY_CONNECTED.
+
+A pid is connected while this node runs it, or a worker with a current heartbeat owns it, see
+peerConnected. The old PeerChannel on this node is stopped before the new one starts, the join
+waits for it to finish the read it is in, up to WorkerPollTimeout, and it puts back at the head
+of its topic any message it took, which is the new peer's. Its cleanup is left to the new peer.
+On another worker it is handed over as for any peer that moved, see peer_owner.go. The policy
+only applies to client pids: in unique mode a join of a connected pid is PEER_ID_TAKEN, and
+assigned pids never are connected.
+*/
+
+var ErrAlreadyConnected = errors.New("already connected")
+
+// peerChannel is a running PeerChannel, stop ends it once its read returns and done is closed
+// once it has
+type peerChannel struct {
+	client   PeerAdapter
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+type ReconnectPolicy int
+
+const (
+	ReplaceOnReconnect ReconnectPolicy = iota
+	RejectReconnect
+)
+
+var reconnectPolicies = map[string]ReconnectPolicy{"replace": ReplaceOnReconnect, "reject": RejectReconnect}
+
+func (policy ReconnectPolicy) String() string {
+	for name, known := range reconnectPolicies {
+		if known == policy {
+			return name
+		}
+	}
+	return fmt.Sprintf("ReconnectPolicy(%d)", int(policy))
+}
+
+// ParseReconnectPolicy reads [peers] reconnect, empty is replace
+func ParseReconnectPolicy(name string) (ReconnectPolicy, error) {
+	if name == "" {
+		return ReplaceOnReconnect, nil
+	}
+	policy, ok := reconnectPolicies[name]
+	if !ok {
+		return ReplaceOnReconnect, fmt.Errorf("unknown reconnect policy %s, want replace or reject", name)
+	}
+	return policy, nil
+}
+
+// SetReconnectPolicy says what a join of a connected pid does, see reconnect.go
+func (m *Manager) SetReconnectPolicy(policy ReconnectPolicy) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.reconnectPolicy = policy
+}
+
+func (m *Manager) ReconnectPolicy() ReconnectPolicy {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.reconnectPolicy
+}
+
+// connectedPeer is the data of the peer connected as pid, nil when there is none
+func (m *Manager) connectedPeer(pid string) *pb.UserData {
+	if m.localPeer(pid) == nil {
+		owner, err := m.PeerOwner(pid)
+		if err != nil || owner == "" || !m.peerConnected(pid, owner) {
+			return nil
+		}
+	}
+	userData, err := m.GetRemoteUserData(pid)
+	if err != nil {
+		return nil
+	}
+	return userData
+}
+
+// checkReconnect refuses a join replacing previous unless it is by the client that joined,
+// identity is what the join authenticated as and token the resumeToken it sent
+func (m *Manager) checkReconnect(previous *pb.UserData, identity string, token string) error {
+	if previous.Identity != identity {
+		return fmt.Errorf("%w: %s is connected as another identity", ErrAlreadyConnected, previous.Id)
+	}
+	if identity == "" && m.CheckResumeToken(previous.Id, token) != nil {
+		return fmt.Errorf("%w: %s is connected, reconnect with its resume token", ErrAlreadyConnected, previous.Id)
+	}
+	return nil
+}
+
+// reconnectedRole is the role a reconnect into roomID as identity keeps, role when it keeps none
+func reconnectedRole(previous *pb.UserData, roomID string, identity string, role pb.Role) pb.Role {
+	if previous == nil || previous.RoomID != roomID || previous.Identity != identity {
+		return role
+	}
+	return previous.Role
+}
+
+// replaced lets go of what was left of the peer client replaced by a reconnect into roomID,
+// client is nil when the peer ran on another worker
+func (w *worker) replaced(pid string, client PeerAdapter, previous *pb.UserData, roomID string) {
+	m := w.manager
+	if client != nil {
+		w.stopPeerChannel(pid, client)
+		if previous.RoomID == roomID {
+			// the sfu files peers by pid, so the old one closing in the room's session would take
+			// the new one out of it, and close the room when they are its only peer
+			setPeerSession(client.SFUPeer(), sfu.NewSession(roomID))
+		}
+		client.Close()
+	}
+	if offers, ok := w.serverOffers.Load(pid); ok {
+		w.dropOffers(pid, offers.(*offerTracker))
+	}
+	// the old peer's selection was of its subscriber, whoever picked pid keeps it
+	m.mu.Lock()
+	delete(m.selections, pid)
+	m.mu.Unlock()
+	// a detached peer's grace would end the new one
+	m.redis.Del(m.Keys().UserDetached(pid))
+	if previous.RoomID == roomID {
+		return
+	}
+	emptied := m.leaveRoom(previous.RoomID, pid)
+	m.UpdateRoomScore(previous.RoomID)
+	if m.announcedLeft(pid) {
+		m.PublishRoomEvent(&pb.RoomEvent{
+			Type:   pb.RoomEvent_LEFT,
+			Pid:    pid,
+			Sid:    previous.RoomID,
+			Reason: pb.CloseReason_MOVED,
+		})
+	}
+	m.promoteOwner(previous.RoomID, pid)
+	if emptied != "" {
+		m.emptiedRoom(previous.RoomID, emptied)
+	}
+}
+
+// stopPeerChannel has the PeerChannel of client stop reading pid's topic and waits for it to
+func (w *worker) stopPeerChannel(pid string, client PeerAdapter) {
+	running, ok := w.peerChannels.Load(pid)
+	if !ok || running.(*peerChannel).client != client {
+		return
+	}
+	channel := running.(*peerChannel)
+	channel.stopOnce.Do(func() { close(channel.stop) })
+	select {
+	case <-channel.done:
+	case <-time.After(2 * WorkerPollTimeout):
+		w.manager.Logger().With(LogFields{"pid": pid}).Errorf("the PeerChannel of the replaced %s did not stop", pid)
+	}
+}
+
+// supersededPeer is true once pid reconnected on this node as another sfu peer than peer,
+// whose goroutines leave what they filed under pid to the new one
+func (m *Manager) supersededPeer(pid string, peer *sfu.Peer) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	current := m.users[pid]
+	return current != nil && current.SFUPeer() != peer
+}
+
+// superseded is true once another client reconnected as pid on this node
+func (w *worker) superseded(pid string, client PeerAdapter) bool {
+	w.manager.mu.RLock()
+	defer w.manager.mu.RUnlock()
+	current := w.manager.users[pid]
+	return current != nil && current != client
+}
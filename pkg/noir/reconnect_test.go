@@ -0,0 +1,178 @@
+package noir
+
+import (
+	"errors"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"testing"
+)
+
+func TestParseReconnectPolicy(t *testing.T) {
+	for name, want := range map[string]ReconnectPolicy{"": ReplaceOnReconnect, "replace": ReplaceOnReconnect, "reject": RejectReconnect} {
+		if policy, err := ParseReconnectPolicy(name); err != nil || policy != want {
+			t.Errorf("got %s %v want %s for %q", policy, err, want, name)
+		}
+	}
+	if _, err := ParseReconnectPolicy("merge"); err == nil {
+		t.Errorf("an unknown policy should be refused")
+	}
+}
+
+// reconnect joins again as pid with the token and join token given, replies are read by requestID
+func reconnect(mgr *Manager, pid string, sid string, requestID string, resumeToken string, token string, fake *fakePeer) error {
+	mgr.SetPeerFactory(func(sfu.SessionProvider) PeerAdapter { return fake })
+	defer mgr.SetPeerFactory(nil)
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id:          pid,
+				RequestId:   requestID,
+				ResumeToken: resumeToken,
+				Payload: &pb.SignalRequest_Join{
+					Join: &pb.JoinRequest{Sid: sid, Description: []byte(EXAMPLE_EMPTY_SDP), Token: token},
+				},
+			},
+		},
+	})
+	return worker.HandleNext(0)
+}
+
+// resumeTokenOf reads pid's replies up to the answer of its join requestID, for its resume token
+func resumeTokenOf(t *testing.T, mgr *Manager, pid string, requestID string) string {
+	reply := nextSignalReply(t, mgr, pid, func(reply *pb.SignalReply) bool { return reply.GetRequestId() == requestID })
+	return reply.GetJoin().GetResumeToken()
+}
+
+func TestReconnectReplace(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	keys := append(peerKeys("flaky-peer"), pb.KeyRoomData("flaky-room"), pb.KeyRoomUsers("flaky-room"),
+		pb.KeyRoomData("flaky-other"), pb.KeyRoomUsers("flaky-other"))
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	first := newFakePeer()
+	joinFake(t, mgr, "flaky-peer", "flaky-room", first)
+	// the peer was made a moderator since it joined
+	userData, _ := mgr.GetRemoteUserData("flaky-peer")
+	userData.Role = pb.Role_MODERATOR
+	mgr.SaveData(pb.KeyUserData("flaky-peer"), &pb.NoirObject{Data: &pb.NoirObject_User{User: userData}}, 0)
+
+	token := resumeTokenOf(t, mgr, "flaky-peer", "join-1")
+
+	// someone else joining as the pid does not take the peer over
+	for _, wrong := range []string{"", "not-the-token"} {
+		if err := reconnect(mgr, "flaky-peer", "flaky-room", "join-stolen", wrong, "", newFakePeer()); !errors.Is(err, ErrAlreadyConnected) {
+			t.Errorf("got %v want a reconnect with resume token %q refused", err, wrong)
+		}
+	}
+	if reply := nextSignalReply(t, mgr, "flaky-peer", func(reply *pb.SignalReply) bool { return reply.GetRequestId() == "join-stolen" }); reply.GetFailure().GetCode() != pb.SignalError_ALREADY_CONNECTED {
+		t.Errorf("got %s want ALREADY_CONNECTED", reply)
+	}
+
+	second := newFakePeer()
+	if err := reconnect(mgr, "flaky-peer", "flaky-room", "join-2", token, "", second); err != nil {
+		t.Fatalf("error reconnecting: %s", err)
+	}
+	first.mu.Lock()
+	if !first.closed {
+		t.Errorf("the old peer should be closed")
+	}
+	first.mu.Unlock()
+	if reconnected, _ := mgr.GetRemoteUserData("flaky-peer"); reconnected.GetRole() != pb.Role_MODERATOR {
+		t.Errorf("got %s want the peer's role kept", reconnected.GetRole())
+	}
+	if !redis.HExists(pb.KeyRoomUsers("flaky-room"), "flaky-peer").Val() {
+		t.Errorf("the reconnected peer should still be in its room")
+	}
+
+	// the old PeerChannel stops, every peer runs at least it and its negotiation
+	worker := (*mgr.GetWorker()).(*worker)
+	waitFor(t, "the old PeerChannel to stop", func() bool { return mgr.PeerGoroutines() <= 3 })
+	if worker.PeerCount() != 1 {
+		t.Errorf("got %d peers want the reconnected one", worker.PeerCount())
+	}
+
+	// into another room it is a move, and the role was the old room's
+	third := newFakePeer()
+	if err := reconnect(mgr, "flaky-peer", "flaky-other", "join-3", resumeTokenOf(t, mgr, "flaky-peer", "join-2"), "", third); err != nil {
+		t.Fatalf("error reconnecting to another room: %s", err)
+	}
+	if redis.HExists(pb.KeyRoomUsers("flaky-room"), "flaky-peer").Val() {
+		t.Errorf("a peer reconnecting into another room should leave its old one")
+	}
+	if moved, _ := mgr.GetRemoteUserData("flaky-peer"); moved.GetRole() != pb.Role_PUBLISHER || moved.GetRoomID() != "flaky-other" {
+		t.Errorf("got %s want a publisher in the other room", moved)
+	}
+
+	// the kill goes to the new PeerChannel
+	mgr.DisconnectUser("flaky-peer")
+	waitFor(t, "the peer's goroutines to return", func() bool { return mgr.PeerGoroutines() == 0 })
+	third.mu.Lock()
+	defer third.mu.Unlock()
+	if !third.closed {
+		t.Errorf("the last peer should be closed")
+	}
+}
+
+func TestReconnectIdentity(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	// the join's token is who it is
+	mgr.SetAuthenticator(AuthenticatorFunc(func(signal *pb.SignalRequest) (string, error) {
+		return signal.GetJoin().GetToken(), nil
+	}))
+	keys := append(peerKeys("alice-peer"), pb.KeyRoomData("alice-room"), pb.KeyRoomUsers("alice-room"), pb.KeyIdentityPeers("alice"))
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	first := newFakePeer()
+	if err := reconnect(mgr, "alice-peer", "alice-room", "join-1", "", "alice", first); err != nil {
+		t.Fatalf("error joining: %s", err)
+	}
+	if err := reconnect(mgr, "alice-peer", "alice-room", "join-2", "", "mallory", newFakePeer()); !errors.Is(err, ErrAlreadyConnected) {
+		t.Errorf("got %v want a reconnect as another identity refused", err)
+	}
+	first.mu.Lock()
+	if first.closed {
+		t.Errorf("the peer should carry on when someone else joins as its pid")
+	}
+	first.mu.Unlock()
+
+	// its own identity is enough without the resume token
+	if err := reconnect(mgr, "alice-peer", "alice-room", "join-3", "", "alice", newFakePeer()); err != nil {
+		t.Fatalf("error reconnecting as the same identity: %s", err)
+	}
+	mgr.DisconnectUser("alice-peer")
+	waitFor(t, "the peer's goroutines to return", func() bool { return mgr.PeerGoroutines() == 0 })
+}
+
+func TestReconnectReject(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	mgr.SetReconnectPolicy(RejectReconnect)
+	keys := append(peerKeys("steady-peer"), pb.KeyRoomData("steady-room"), pb.KeyRoomUsers("steady-room"))
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	connected := newFakePeer()
+	joinFake(t, mgr, "steady-peer", "steady-room", connected)
+	defer mgr.DisconnectUser("steady-peer")
+
+	if err := requestJoin(mgr, "steady-peer", "steady-room", "join-2", newFakePeer()); !errors.Is(err, ErrAlreadyConnected) {
+		t.Fatalf("got %v want the second join refused", err)
+	}
+	reply := nextSignalReply(t, mgr, "steady-peer", func(reply *pb.SignalReply) bool { return reply.GetRequestId() == "join-2" })
+	if reply.GetFailure().GetCode() != pb.SignalError_ALREADY_CONNECTED {
+		t.Errorf("got %s want ALREADY_CONNECTED", reply)
+	}
+	connected.mu.Lock()
+	if connected.closed {
+		t.Errorf("the connected peer should carry on")
+	}
+	connected.mu.Unlock()
+	if !redis.HExists(pb.KeyRoomUsers("steady-room"), "steady-peer").Val() {
+		t.Errorf("the connected peer should keep its place in the room")
+	}
+}
@@ -0,0 +1,150 @@
+package noir
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	log "github.com/pion/ion-log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"path/filepath"
+	"strings"
+)
+
+// RecordJobHandler is the job recordings run as, register jobs.NewRecordHandler under it
+const RecordJobHandler = "Record"
+
+// Used when neither the request nor SetRecordingDir say where recordings go
+const DefaultRecordingDir = "recordings"
+
+// RecordOptions are the RoomJobRequest options of a RecordJobHandler job
+type RecordOptions struct {
+	RecordingID string `json:"recording_id"`
+	OutputDir   string `json:"output_dir"`
+}
+
+// ErrRecordingPath is a recording that would be written outside where it is allowed to go
+var ErrRecordingPath = errors.New("recording path not allowed")
+
+// RecordingPeerID is the pid the recorder joins the room as, the same one NewPeerJob gives it
+func RecordingPeerID(recordingID string) string {
+	return "job-" + RecordJobHandler + "-" + recordingID
+}
+
+func (m *Manager) SetRecordingDir(dir string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.recordingDir = dir
+}
+
+func (m *Manager) RecordingDir() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.recordingDir != "" {
+		return m.recordingDir
+	}
+	return DefaultRecordingDir
+}
+
+// CheckRecordingID refuses a recording id that is not a single path element, it names the
+// recording's dir
+func CheckRecordingID(recordingID string) error {
+	if recordingID == "." || recordingID == ".." || strings.ContainsAny(recordingID, `/\`) {
+		return fmt.Errorf("%w: recording id %q", ErrRecordingPath, recordingID)
+	}
+	return nil
+}
+
+// RecordingOutputDir is where a recording asked to go to outputDir goes. The admin api and the
+// node itself, admin, name any dir, a moderator's outputDir is taken under RecordingDir and
+// refused when it leaves it
+func (m *Manager) RecordingOutputDir(outputDir string, admin bool) (string, error) {
+	root := m.RecordingDir()
+	if outputDir == "" {
+		return root, nil
+	}
+	if admin {
+		return outputDir, nil
+	}
+	dir := filepath.Join(root, outputDir)
+	if rel, err := filepath.Rel(root, dir); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %s is outside %s", ErrRecordingPath, outputDir, root)
+	}
+	return dir, nil
+}
+
+// StartRecording saves the recording in the room data and has a worker run the recorder job,
+// which joins the room and writes each published track to outputDir/recordingID/. outputDir is
+// the caller's to trust, see RecordingOutputDir
+func (m *Manager) StartRecording(sid string, outputDir string) (string, error) {
+	room, err := m.GetRoomData(sid)
+	if err != nil {
+		return "", fmt.Errorf("room %s not found", sid)
+	}
+	if outputDir == "" {
+		outputDir = m.RecordingDir()
+	}
+
+	recordingID := RandomString(16)
+	if room.Recordings == nil {
+		room.Recordings = map[string]*pb.Recording{}
+	}
+	room.Recordings[recordingID] = &pb.Recording{
+		Id:        recordingID,
+		OutputDir: outputDir,
+		Pid:       RecordingPeerID(recordingID),
+		Started:   timestamppb.Now(),
+	}
+	if err := SaveRoomData(sid, room, m); err != nil {
+		return "", err
+	}
+
+	options, err := json.Marshal(RecordOptions{RecordingID: recordingID, OutputDir: outputDir})
+	if err != nil {
+		return "", err
+	}
+	log.Infof("recording room %s as %s into %s", sid, recordingID, outputDir)
+	router := *m.GetRouter()
+	return recordingID, EnqueueRequest(*router.GetQueue(), &pb.NoirRequest{
+		// the job is the node's own doing, see checkAdminCaller
+		AdminID: m.ID(),
+		Command: &pb.NoirRequest_Admin{
+			Admin: &pb.AdminRequest{
+				Payload: &pb.AdminRequest_RoomAdmin{
+					RoomAdmin: &pb.RoomAdminRequest{
+						RoomID: sid,
+						Method: &pb.RoomAdminRequest_RoomJob{
+							RoomJob: &pb.RoomJobRequest{
+								Handler: RecordJobHandler,
+								Pid:     RecordingPeerID(recordingID),
+								Options: options,
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+}
+
+// StopRecording disconnects the recorder, an empty recordingID stops every recording in the room
+func (m *Manager) StopRecording(sid string, recordingID string) ([]string, error) {
+	room, err := m.GetRoomData(sid)
+	if err != nil {
+		return nil, fmt.Errorf("room %s not found", sid)
+	}
+	stopped := []string{}
+	for id, recording := range room.Recordings {
+		if recording.Stopped != nil || (recordingID != "" && id != recordingID) {
+			continue
+		}
+		log.Infof("stopping recording %s of room %s", id, sid)
+		m.CloseClient(recording.Pid, pb.CloseReason_JOB_ENDED)
+		recording.Stopped = timestamppb.Now()
+		stopped = append(stopped, id)
+	}
+	if recordingID != "" && len(stopped) == 0 {
+		return stopped, fmt.Errorf("no recording %s in room %s", recordingID, sid)
+	}
+	return stopped, SaveRoomData(sid, room, m)
+}
@@ -0,0 +1,224 @@
+package noir
+
+import (
+	"context"
+	"errors"
+	log "github.com/pion/ion-log"
+	"github.com/pion/rtcp"
+	"github.com/pion/webrtc/v3"
+	"sync"
+)
+
+/*
+Room Relays:
+A relay carries one room's media into another, the seed of rooms cascaded over noir nodes.
+RelayRoom joins two peers noir runs itself, through the router like any client: one in the
+source room, which gets every publisher's tracks, and one in the destination room that
+publishes a copy of each of them and subscribes to nothing. A track that ends, because its
+publisher left the source, is unpublished from the destination, and keyframe requests from
+the destination go back to the source. StopRelay has both peers leave, and so does a room
+closing under either of them.
+*/
+
+var (
+	ErrRelayNotFound = errors.New("relay not found")
+	ErrRelaySameRoom = errors.New("a room can not be relayed into itself")
+)
+
+// RelayPeerIDs are the pids a relay joins its source and destination rooms as
+func RelayPeerIDs(relayID string) (source string, destination string) {
+	return "relay-" + relayID + "-in", "relay-" + relayID + "-out"
+}
+
+type roomRelay struct {
+	id      string
+	manager *Manager
+	ctx     context.Context
+	cancel  context.CancelFunc
+	in      *clientPeer
+	out     *clientPeer
+	// one renegotiation of the destination peer at a time
+	negotiating sync.Mutex
+	stopped     sync.Once
+}
+
+// RelayRoom forwards every track published in srcSid into dstSid until StopRelay
+func (m *Manager) RelayRoom(srcSid string, dstSid string) (string, error) {
+	if srcSid == dstSid {
+		return "", ErrRelaySameRoom
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	relay := &roomRelay{id: RandomString(12), manager: m, ctx: ctx, cancel: cancel}
+	inPid, outPid := RelayPeerIDs(relay.id)
+	var err error
+	if relay.in, err = newClientPeer(ctx, m); err != nil {
+		relay.stop()
+		return "", err
+	}
+	if relay.out, err = newClientPeer(ctx, m); err != nil {
+		relay.stop()
+		return "", err
+	}
+	relay.in.sub.OnTrack(relay.forward)
+
+	// the destination first, so it can publish the first track the source sends
+	if err := relay.join(relay.out, dstSid, outPid); err != nil {
+		relay.stop()
+		return "", err
+	}
+	if _, err := relay.out.client.Unsubscribe(); err != nil {
+		log.Errorf("relay %s could not unsubscribe from %s: %s", relay.id, dstSid, err)
+	}
+	if err := relay.join(relay.in, srcSid, inPid); err != nil {
+		relay.stop()
+		return "", err
+	}
+
+	m.mu.Lock()
+	m.relays[relay.id] = relay
+	m.mu.Unlock()
+	log.Infof("relaying %s into %s as %s", srcSid, dstSid, relay.id)
+	return relay.id, nil
+}
+
+// StopRelay has the relay's peers leave both rooms
+func (m *Manager) StopRelay(relayID string) error {
+	m.mu.Lock()
+	relay := m.relays[relayID]
+	delete(m.relays, relayID)
+	m.mu.Unlock()
+	if relay == nil {
+		return ErrRelayNotFound
+	}
+	relay.stop()
+	return nil
+}
+
+// join joins the peer to sid with a data channel, and handles its events until it leaves
+func (r *roomRelay) join(peer *clientPeer, sid string, pid string) error {
+	if _, err := peer.pub.CreateDataChannel("relay", nil); err != nil {
+		return err
+	}
+	offer, err := peer.pub.CreateOffer(nil)
+	if err != nil {
+		return err
+	}
+	answer, err := peer.client.Join(sid, pid, offer)
+	if err != nil {
+		return err
+	}
+	if err := peer.pub.SetLocalDescription(offer); err != nil {
+		return err
+	}
+	if err := peer.pub.SetRemoteDescription(*answer); err != nil {
+		return err
+	}
+	go r.listen(peer, pid)
+	return nil
+}
+
+func (r *roomRelay) listen(peer *clientPeer, pid string) {
+	for signal := range peer.client.Events() {
+		if err := peer.handle(signal); err != nil {
+			log.Errorf("relay peer %s error answering: %s", pid, err)
+		}
+	}
+	// killed, eg. its room closed, the relay is no use with one end
+	r.manager.mu.Lock()
+	if r.manager.relays[r.id] == r {
+		delete(r.manager.relays, r.id)
+	}
+	r.manager.mu.Unlock()
+	r.stop()
+}
+
+// forward publishes a copy of a source track in the destination until the track ends
+func (r *roomRelay) forward(remote *webrtc.TrackRemote, receiver *webrtc.RTPReceiver) {
+	local, err := webrtc.NewTrackLocalStaticRTP(remote.Codec().RTPCodecCapability, remote.ID(), remote.StreamID())
+	if err != nil {
+		log.Errorf("relay %s can not forward %s: %s", r.id, remote.ID(), err)
+		return
+	}
+	// sendonly, so unpublishing it leaves the transceiver inactive, which the sfu stops
+	transceiver, err := r.out.pub.AddTransceiverFromTrack(local, webrtc.RtpTransceiverInit{Direction: webrtc.RTPTransceiverDirectionSendonly})
+	if err != nil {
+		log.Errorf("relay %s can not publish %s: %s", r.id, remote.ID(), err)
+		return
+	}
+	sender := transceiver.Sender()
+	r.renegotiate()
+	go r.forwardKeyframeRequests(sender, remote)
+
+	for {
+		packet, err := remote.ReadRTP()
+		if err != nil {
+			break
+		}
+		if err := local.WriteRTP(packet); err != nil {
+			log.Debugf("relay %s error forwarding %s: %s", r.id, remote.ID(), err)
+		}
+	}
+
+	// the publisher left the source, or the relay stopped
+	if r.ctx.Err() != nil {
+		return
+	}
+	if err := r.out.pub.RemoveTrack(sender); err != nil {
+		log.Errorf("relay %s error unpublishing %s: %s", r.id, remote.ID(), err)
+		return
+	}
+	r.renegotiate()
+}
+
+// forwardKeyframeRequests asks the source publisher for a keyframe when the destination does
+func (r *roomRelay) forwardKeyframeRequests(sender *webrtc.RTPSender, remote *webrtc.TrackRemote) {
+	for {
+		packets, err := sender.ReadRTCP()
+		if err != nil {
+			return
+		}
+		for _, packet := range packets {
+			switch packet.(type) {
+			case *rtcp.PictureLossIndication, *rtcp.FullIntraRequest:
+				pli := []rtcp.Packet{&rtcp.PictureLossIndication{MediaSSRC: uint32(remote.SSRC())}}
+				if err := r.in.sub.WriteRTCP(pli); err != nil {
+					log.Debugf("relay %s error asking for a keyframe: %s", r.id, err)
+				}
+			}
+		}
+	}
+}
+
+// renegotiate sends the destination the tracks the relay publishes now
+func (r *roomRelay) renegotiate() {
+	r.negotiating.Lock()
+	defer r.negotiating.Unlock()
+	if r.ctx.Err() != nil {
+		return
+	}
+	offer, err := r.out.pub.CreateOffer(nil)
+	if err == nil {
+		err = r.out.pub.SetLocalDescription(offer)
+	}
+	var answer *webrtc.SessionDescription
+	if err == nil {
+		answer, err = r.out.client.Offer(offer)
+	}
+	if err == nil {
+		err = r.out.pub.SetRemoteDescription(*answer)
+	}
+	if err != nil {
+		log.Errorf("relay %s error renegotiating: %s", r.id, err)
+	}
+}
+
+func (r *roomRelay) stop() {
+	r.stopped.Do(func() {
+		r.cancel()
+		for _, peer := range []*clientPeer{r.in, r.out} {
+			if peer != nil {
+				peer.close()
+			}
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package noir
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRelayRoom(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	for _, room := range []string{"relay-source", "relay-destination"} {
+		redis.Del(emptyRoomKeys(room)...)
+		defer redis.Del(emptyRoomKeys(room)...)
+	}
+	if _, err := mgr.RelayRoom("relay-source", "relay-source"); err != ErrRelaySameRoom {
+		t.Errorf("got %v want a room relayed into itself refused", err)
+	}
+
+	listeners := NewLoadBot(context.Background(), mgr, LoadBotConfig{})
+	defer listeners.Stop()
+	go routeOnce(mgr)
+	listeners.Spawn(1, "relay-destination")
+	// the relay alone does not keep its source open
+	audience := NewLoadBot(context.Background(), mgr, LoadBotConfig{})
+	defer audience.Stop()
+	go routeOnce(mgr)
+	audience.Spawn(1, "relay-source")
+	publishers := NewLoadBot(context.Background(), mgr, LoadBotConfig{Publish: true})
+	go routeOnce(mgr)
+	publishers.Spawn(1, "relay-source")
+	waitFor(t, "the source to publish", func() bool { return publishing(mgr, publishers) == 1 })
+
+	go func() {
+		routeOnce(mgr)
+		routeOnce(mgr)
+	}()
+	relayID, err := mgr.RelayRoom("relay-source", "relay-destination")
+	if err != nil {
+		t.Fatalf("error relaying: %s", err)
+	}
+	_, outPid := RelayPeerIDs(relayID)
+	var listener string
+	for pid := range listeners.bots {
+		listener = pid
+	}
+	waitFor(t, "the destination to get the source's track", func() bool {
+		return receiving(mgr, listener, outPid) && listeners.Stats().Packets > 0
+	})
+
+	// the source publisher leaving unpublishes its copy
+	relayed := func() int {
+		out := mgr.localPeer(outPid)
+		if out == nil {
+			return 0
+		}
+		return len(receiversOf(publisherOf(out)))
+	}
+	if relayed() != 1 {
+		t.Errorf("got %d want the relay publishing the source's track", relayed())
+	}
+	publishers.Stop()
+	waitFor(t, "the relayed track to go", func() bool { return relayed() == 0 })
+
+	if err := mgr.StopRelay(relayID); err != nil {
+		t.Fatalf("error stopping relay: %s", err)
+	}
+	if err := mgr.StopRelay(relayID); err != ErrRelayNotFound {
+		t.Errorf("got %v want a stopped relay gone", err)
+	}
+	waitFor(t, "the relay to leave", func() bool {
+		mgr.mu.RLock()
+		defer mgr.mu.RUnlock()
+		return mgr.users[outPid] == nil
+	})
+}
+
+func TestRelayEndsWithSource(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	for _, room := range []string{"relay-ended", "relay-ended-destination"} {
+		redis.Del(emptyRoomKeys(room)...)
+		defer redis.Del(emptyRoomKeys(room)...)
+	}
+	source := NewLoadBot(context.Background(), mgr, LoadBotConfig{})
+	defer source.Stop()
+	go routeOnce(mgr)
+	source.Spawn(1, "relay-ended")
+
+	go func() {
+		routeOnce(mgr)
+		routeOnce(mgr)
+	}()
+	relayID, err := mgr.RelayRoom("relay-ended", "relay-ended-destination")
+	if err != nil {
+		t.Fatalf("error relaying: %s", err)
+	}
+	inPid, outPid := RelayPeerIDs(relayID)
+	waitFor(t, "the relay to join", func() bool { return mgr.localPeer(inPid) != nil && mgr.localPeer(outPid) != nil })
+
+	// the source's last peer leaving closes it, which ends the relay
+	source.Stop()
+	waitFor(t, "the relay to leave", func() bool {
+		mgr.mu.RLock()
+		defer mgr.mu.RUnlock()
+		return mgr.users[inPid] == nil && mgr.users[outPid] == nil
+	})
+	if err := mgr.StopRelay(relayID); err != ErrRelayNotFound {
+		t.Errorf("got %v want the relay gone with its source", err)
+	}
+}
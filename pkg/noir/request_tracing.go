@@ -0,0 +1,91 @@
+package noir
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"time"
+)
+
+/*
+Request Tracing:
+Every request carries a traceID through the queues, so one client action can be followed across
+the router, the workers and the peer topics. A client may send its own, eg. the trace of the call
+it is part of, and a request queued without one gets NewTraceID when it is marshaled, so a
+client's first hop hands it one and every hop after keeps it. Each reply to a request carries
+its traceID back, which is how a client learns a generated one. What the sfu sends of its own
+accord, trickle, offers, TrackInfos and room events, replies to no request and carries none.
+
+The traceID is the trace field of every line logged while handling its request, along with the
+action, pid and sid, see logging.go. SetSpanTracer has each step of handling a request reported
+as a span of its trace, eg. to OpenTelemetry behind a small adapter that starts the spans under
+the traceID, which NewTraceID makes the 32 hex digits of an OpenTelemetry trace id:
+
+	queue        the request waiting in a topic, from when it was first queued until it is read,
+	             with topic set
+	route        the router picking the worker, with worker set to it
+	handle       a worker handling a request from its topic
+	join         HandleJoin, from the join being read until the peer is connected or refused
+	peer         a peer's worker handling a request from the peer's topic
+*/
+
+// The longest traceID a request may carry, longer ones are refused as INVALID_REQUEST
+const MaxTraceIDLength = 128
+
+// Span is a step of handling a request, ended once, with the error it ended in if any
+type Span interface {
+	End(err error)
+}
+
+// SpanTracer starts the spans of a trace, see the top of the file
+type SpanTracer interface {
+	StartSpan(traceID string, name string, start time.Time, fields LogFields) Span
+}
+
+type noSpan struct{}
+
+func (noSpan) End(error) {}
+
+// NewTraceID is a random trace id, of 32 hex digits
+func NewTraceID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return RandomString(32)
+	}
+	return hex.EncodeToString(id)
+}
+
+// SetSpanTracer reports the steps of handling requests to tracer, nil reports none
+func (m *Manager) SetSpanTracer(tracer SpanTracer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.spanTracer = tracer
+}
+
+func (m *Manager) SpanTracer() SpanTracer {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.spanTracer
+}
+
+// startSpan starts a span of the request's trace, from start, with the request's fields and fields
+func (m *Manager) startSpan(request *pb.NoirRequest, name string, start time.Time, fields LogFields) Span {
+	tracer := m.SpanTracer()
+	if tracer == nil || request.GetTraceID() == "" {
+		return noSpan{}
+	}
+	spanFields := RequestFields(request)
+	for key, value := range fields {
+		spanFields[key] = value
+	}
+	return tracer.StartSpan(request.TraceID, name, start, spanFields)
+}
+
+// traceQueued reports how long the request waited in topic, from when it was first queued
+func (m *Manager) traceQueued(request *pb.NoirRequest, topic string) {
+	var queued time.Time
+	if err := queued.UnmarshalText([]byte(request.GetAt())); err != nil {
+		return
+	}
+	m.startSpan(request, "queue", queued, LogFields{"topic": topic}).End(nil)
+}
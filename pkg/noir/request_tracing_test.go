@@ -0,0 +1,132 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordedSpan struct {
+	traceID string
+	name    string
+	fields  LogFields
+	ended   bool
+	err     error
+}
+
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+func (r *recordingTracer) StartSpan(traceID string, name string, start time.Time, fields LogFields) Span {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	span := &recordedSpan{traceID: traceID, name: name, fields: fields}
+	r.spans = append(r.spans, span)
+	return &recordingSpan{r, span}
+}
+
+type recordingSpan struct {
+	tracer *recordingTracer
+	span   *recordedSpan
+}
+
+func (s *recordingSpan) End(err error) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.span.ended, s.span.err = true, err
+}
+
+// names are the spans of traceID, in the order they started
+func (r *recordingTracer) names(traceID string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	names := []string{}
+	for _, span := range r.spans {
+		if span.traceID == traceID && span.ended {
+			names = append(names, span.name)
+		}
+	}
+	return names
+}
+
+func joinTraced(mgr *Manager, pid string, sid string, traceID string) error {
+	mgr.SetPeerFactory(func(sfu.SessionProvider) PeerAdapter { return newFakePeer() })
+	defer mgr.SetPeerFactory(nil)
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+		TraceID: traceID,
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id:        pid,
+				RequestId: "join-1",
+				Payload: &pb.SignalRequest_Join{
+					Join: &pb.JoinRequest{Sid: sid, Description: []byte(EXAMPLE_EMPTY_SDP)},
+				},
+			},
+		},
+	})
+	return worker.HandleNext(0)
+}
+
+func TestNewTraceID(t *testing.T) {
+	first, second := NewTraceID(), NewTraceID()
+	if len(first) != 32 || strings.Trim(first, "0123456789abcdef") != "" {
+		t.Errorf("got %s want 32 hex digits", first)
+	}
+	if first == second {
+		t.Errorf("trace ids should not repeat")
+	}
+	request := &pb.NoirRequest{Command: &pb.NoirRequest_Signal{Signal: &pb.SignalRequest{Id: "traced"}}}
+	FillDefaults(request)
+	if request.TraceID == "" {
+		t.Errorf("a request queued without a trace should get one")
+	}
+	if fields := RequestFields(request); fields["trace"] != request.TraceID {
+		t.Errorf("got %v want the trace logged", fields)
+	}
+}
+
+func TestRequestTracing(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	tracer := &recordingTracer{}
+	mgr.SetSpanTracer(tracer)
+	defer mgr.SetSpanTracer(nil)
+	keys := append(peerKeys("traced-peer", "traced-long"), pb.KeyRoomData("traced-room"), pb.KeyRoomUsers("traced-room"))
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	// the client's own trace comes back with the reply
+	if err := joinTraced(mgr, "traced-peer", "traced-room", "client-trace"); err != nil {
+		t.Fatalf("error joining: %s", err)
+	}
+	defer mgr.DisconnectUser("traced-peer")
+	reply := mgr.GetQueue(pb.KeyTopicFromPeer("traced-peer"))
+	message, err := reply.BlockUntilNext(time.Second)
+	if err != nil {
+		t.Fatalf("error reading the join reply: %s", err)
+	}
+	joined := &pb.NoirReply{}
+	UnmarshalReply(message, joined)
+	if joined.GetSignal().GetJoin() == nil || joined.TraceID != "client-trace" {
+		t.Errorf("got %s want the join reply in the client's trace", joined)
+	}
+	if names := strings.Join(tracer.names("client-trace"), ","); names != "queue,handle,join" {
+		t.Errorf("got spans %s want the join's queue wait, handling and join", names)
+	}
+
+	// and a trace too long to be one is refused
+	if err := joinTraced(mgr, "traced-long", "traced-room", strings.Repeat("t", MaxTraceIDLength+1)); err == nil {
+		mgr.DisconnectUser("traced-long")
+		t.Errorf("a join with an oversized trace should be refused")
+	}
+	refused := nextSignalReply(t, mgr, "traced-long", func(reply *pb.SignalReply) bool { return reply.GetFailure() != nil })
+	if refused.GetFailure().GetCode() != pb.SignalError_INVALID_REQUEST {
+		t.Errorf("got %s want an INVALID_REQUEST", refused)
+	}
+}
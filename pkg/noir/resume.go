@@ -0,0 +1,46 @@
+package noir
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+/*
+Resume Tokens:
+Resuming a detached peer hands its queued replies and its place in the room to whoever asks,
+so only the client that joined may. Every JoinReply carries a resumeToken, a random secret
+minted for that join, and a resume has to send it back. The gateway checks it before the
+socket starts reading the peer's replies, and ResumeClient checks it again for resumes sent
+another way. Only its sha256 is kept, under the peer's userResume key next to its UserData,
+the token itself is never stored. A join again as the same pid, eg. a reconnect, mints a new
+token and the old one stops working. The key goes with the peer when it is closed or its
+worker is reclaimed.
+*/
+
+var ErrResumeRefused = errors.New("resume refused")
+
+func hashResumeToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// issueResumeToken mints the token a client resumes pid with, replacing any it had
+func (m *Manager) issueResumeToken(pid string) (string, error) {
+	token := NewTraceID()
+	if err := m.redis.Set(m.Keys().UserResume(pid), hashResumeToken(token), 0).Err(); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// CheckResumeToken refuses a token that is not the one issued to pid's join
+func (m *Manager) CheckResumeToken(pid string, token string) error {
+	hashed, err := m.redis.Get(m.Keys().UserResume(pid)).Result()
+	if err != nil || token == "" || subtle.ConstantTimeCompare([]byte(hashed), []byte(hashResumeToken(token))) != 1 {
+		return fmt.Errorf("%w: wrong resume token for %s", ErrResumeRefused, pid)
+	}
+	return nil
+}
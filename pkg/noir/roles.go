@@ -0,0 +1,154 @@
+package noir
+
+import (
+	"errors"
+	"fmt"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	log "github.com/pion/ion-log"
+	"strings"
+)
+
+/*
+Roles:
+A peer joins as a PUBLISHER unless it asks for another role. SUBSCRIBERs may only receive,
+their offers are rejected as soon as they try to send audio or video. MODERATORs may also
+run room admin in their own room, eg. mute, close or kick, by setting the callerID.
+The role has to be granted by a RoleAuthenticator, without one nobody may moderate but the
+room's owner, see room_owner.go.
+
+Only the admin api may run room admin without a callerID: its servers stamp the adminID the
+replies go to, and the manager stamps its own on the jobs it queues. A peer's room admin names
+it, the client gateway stamps the socket's pid over whatever the client sent, and a request
+with neither is refused.
+*/
+
+var ErrNotModerator = errors.New("only moderators may run room admin")
+
+// RoleAuthenticator is an Authenticator that also decides which role an identity may join as
+type RoleAuthenticator interface {
+	Authenticator
+	AuthorizeRole(signal *pb.SignalRequest, identity string) (pb.Role, error)
+}
+
+// ParseRole reads a role name like "subscriber", empty is a publisher
+func ParseRole(name string) (pb.Role, error) {
+	if name == "" {
+		return pb.Role_PUBLISHER, nil
+	}
+	role, ok := pb.Role_value[strings.ToUpper(name)]
+	if !ok {
+		return pb.Role_PUBLISHER, fmt.Errorf("unknown role %s", name)
+	}
+	return pb.Role(role), nil
+}
+
+// roleRank orders roles by how much they may do, for granting a role or any role below it
+func roleRank(role pb.Role) int {
+	switch role {
+	case pb.Role_SUBSCRIBER:
+		return 0
+	case pb.Role_PUBLISHER:
+		return 1
+	case pb.Role_MODERATOR:
+		return 2
+	}
+	return -1
+}
+
+// AuthorizeRole returns the role a join asked for once the Authenticator grants it,
+// without a RoleAuthenticator peers may join as anything but a moderator
+func (m *Manager) AuthorizeRole(signal *pb.SignalRequest, identity string) (pb.Role, error) {
+	m.mu.RLock()
+	authenticator := m.authenticator
+	m.mu.RUnlock()
+	if roles, ok := authenticator.(RoleAuthenticator); ok {
+		return roles.AuthorizeRole(signal, identity)
+	}
+	requested := signal.GetJoin().GetRole()
+	if requested == pb.Role_MODERATOR {
+		return requested, errors.New("the authenticator does not grant moderator")
+	}
+	return requested, nil
+}
+
+func (m *Manager) GetPeerRole(pid string) (pb.Role, error) {
+	userData, err := m.GetRemoteUserData(pid)
+	if err != nil || userData == nil {
+		return pb.Role_PUBLISHER, fmt.Errorf("peer %s not found", pid)
+	}
+	return userData.GetRole(), nil
+}
+
+// checkAdminCaller refuses a room admin request that is neither the admin api's nor a peer's
+func checkAdminCaller(request *pb.NoirRequest) error {
+	if request.GetAdmin().GetRoomAdmin().GetCallerID() == "" && request.GetAdminID() == "" {
+		return fmt.Errorf("%w, %s names no callerID and is not the admin api's", ErrNotModerator, request.Action)
+	}
+	return nil
+}
+
+// checkModerator lets callerID run room admin for roomID, an empty callerID is the admin api,
+// see checkAdminCaller
+func (m *Manager) checkModerator(roomID string, callerID string) error {
+	if callerID == "" || (m.isRoomOwner(roomID, callerID) && m.redis.HExists(m.Keys().RoomUsers(roomID), callerID).Val()) {
+		return nil
+	}
+	role, err := m.GetPeerRole(callerID)
+	if err != nil {
+		return err
+	}
+	if role != pb.Role_MODERATOR || !m.redis.HExists(m.Keys().RoomUsers(roomID), callerID).Val() {
+		return fmt.Errorf("%w, %s is not a moderator of %s", ErrNotModerator, callerID, roomID)
+	}
+	return nil
+}
+
+// KickPeer disconnects pid from the room, wherever it is connected
+func (m *Manager) KickPeer(roomID string, pid string) error {
+	if !m.redis.HExists(m.Keys().RoomUsers(roomID), pid).Val() {
+		return fmt.Errorf("peer %s not found in %s", pid, roomID)
+	}
+	return m.kick(roomID, pid)
+}
+
+// KickIdentity disconnects every peer joined as identity, wherever they are connected.
+// A peer that left since it was looked up counts as killed
+func (m *Manager) KickIdentity(identity string) (int, error) {
+	pids, err := m.redis.SMembers(m.Keys().IdentityPeers(identity)).Result()
+	if err != nil {
+		return 0, err
+	}
+	killed := 0
+	for _, pid := range pids {
+		userData, err := m.GetRemoteUserData(pid)
+		if err != nil || userData == nil || userData.Identity != identity {
+			m.redis.SRem(m.Keys().IdentityPeers(identity), pid)
+			killed++
+			continue
+		}
+		if err := m.kick(userData.RoomID, pid); err != nil {
+			return killed, fmt.Errorf("error kicking %s: %w", pid, err)
+		}
+		killed++
+	}
+	return killed, nil
+}
+
+// kick tells the room, then sends pid a Kill
+func (m *Manager) kick(roomID string, pid string) error {
+	if err := m.EmitRoomEvent(roomID, pid, pb.RoomEvent_KICKED); err != nil {
+		log.Errorf("error announcing %s was kicked: %s", pid, err)
+	}
+	return EnqueueRequest(m.GetQueue(m.Keys().TopicToPeer(pid)), &pb.NoirRequest{
+		AdminID: m.ID(),
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id: pid,
+				Payload: &pb.SignalRequest_Kill{
+					Kill: true,
+				},
+				CloseReason: pb.CloseReason_KICKED,
+			},
+		},
+	})
+}
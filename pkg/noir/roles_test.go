@@ -0,0 +1,221 @@
+package noir
+
+import (
+	"errors"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/webrtc/v3"
+	"google.golang.org/protobuf/proto"
+	"testing"
+)
+
+func joinAs(role pb.Role, token string) *pb.SignalRequest {
+	return &pb.SignalRequest{
+		Payload: &pb.SignalRequest_Join{Join: &pb.JoinRequest{Sid: "test", Role: role, Token: token}},
+	}
+}
+
+func TestAuthorizeRole(t *testing.T) {
+	mgr, _ := NewTestSetup()
+	defer mgr.SetAuthenticator(nil)
+
+	mgr.SetAuthenticator(nil)
+	if role, err := mgr.AuthorizeRole(joinAs(pb.Role_SUBSCRIBER, ""), ""); err != nil || role != pb.Role_SUBSCRIBER {
+		t.Errorf("got %s (%v) want subscriber without an authenticator", role, err)
+	}
+	if _, err := mgr.AuthorizeRole(joinAs(pb.Role_MODERATOR, ""), ""); err == nil {
+		t.Errorf("moderator should need a RoleAuthenticator")
+	}
+
+	authenticator, _ := NewJWTAuthenticator("secret")
+	mgr.SetAuthenticator(authenticator)
+	moderator := signTestJWT("secret", `{"sub":"alice","role":"moderator"}`)
+	publisher := signTestJWT("secret", `{"sub":"bob"}`)
+	for _, tt := range []struct {
+		name    string
+		signal  *pb.SignalRequest
+		allowed bool
+	}{
+		{"moderator token as moderator", joinAs(pb.Role_MODERATOR, moderator), true},
+		{"moderator token as subscriber", joinAs(pb.Role_SUBSCRIBER, moderator), true},
+		{"plain token as publisher", joinAs(pb.Role_PUBLISHER, publisher), true},
+		{"plain token as moderator", joinAs(pb.Role_MODERATOR, publisher), false},
+		{"bad role claim", joinAs(pb.Role_SUBSCRIBER, signTestJWT("secret", `{"sub":"eve","role":"admin"}`)), false},
+	} {
+		role, err := mgr.AuthorizeRole(tt.signal, "")
+		if allowed := err == nil; allowed != tt.allowed {
+			t.Errorf("%s: got allowed=%t want %t (%v)", tt.name, allowed, tt.allowed, err)
+		} else if allowed && role != tt.signal.GetJoin().GetRole() {
+			t.Errorf("%s: got role %s want %s", tt.name, role, tt.signal.GetJoin().GetRole())
+		}
+	}
+}
+
+func TestSubscriberOffer(t *testing.T) {
+	mgr, _ := NewTestSetup()
+	offer := webrtc.SessionDescription{Type: webrtc.SDPTypeOffer, SDP: codecsTestSDP}
+	if _, err := mgr.ValidateOffer(nil, "viewer", pb.Role_SUBSCRIBER, offer); err == nil {
+		t.Errorf("a subscriber sending tracks should be rejected")
+	}
+	empty := webrtc.SessionDescription{Type: webrtc.SDPTypeOffer, SDP: EXAMPLE_EMPTY_SDP}
+	if _, err := mgr.ValidateOffer(nil, "viewer", pb.Role_SUBSCRIBER, empty); err != nil {
+		t.Errorf("a subscriber offering only a datachannel should be allowed, got %s", err)
+	}
+}
+
+func TestRoomAdminNeedsModerator(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	worker := (*mgr.GetWorker()).(*worker)
+	for pid, role := range map[string]pb.Role{"mod": pb.Role_MODERATOR, "pub": pb.Role_PUBLISHER, "target": pb.Role_PUBLISHER} {
+		mgr.SaveData(pb.KeyUserData(pid), &pb.NoirObject{
+			Data: &pb.NoirObject_User{User: &pb.UserData{Id: pid, RoomID: "moderated", Role: role}},
+		}, 0)
+		redis.HSet(pb.KeyRoomUsers("moderated"), pid, "")
+	}
+	defer redis.Del(pb.KeyRoomUsers("moderated"))
+	queue := mgr.GetQueue(pb.KeyTopicToPeer("target"))
+	queue.Cleanup()
+
+	kick := func(caller string) error {
+		request := &pb.NoirRequest{
+			Command: &pb.NoirRequest_Admin{
+				Admin: &pb.AdminRequest{
+					Payload: &pb.AdminRequest_RoomAdmin{
+						RoomAdmin: &pb.RoomAdminRequest{
+							RoomID:   "moderated",
+							CallerID: caller,
+							Method: &pb.RoomAdminRequest_KickPeer{
+								KickPeer: &pb.KickPeerRequest{Pid: "target"},
+							},
+						},
+					},
+				},
+			},
+		}
+		// no callerID is the admin api's
+		if caller == "" {
+			request.AdminID = "moderation-admin"
+		}
+		return worker.HandleRoomAdmin(request)
+	}
+
+	for _, caller := range []string{"pub", "not-a-peer"} {
+		if err := kick(caller); err == nil {
+			t.Errorf("%s should not be allowed to kick", caller)
+		}
+	}
+	// nor may anyone else leave out who they are
+	unnamed := &pb.NoirRequest{Command: &pb.NoirRequest_Admin{Admin: &pb.AdminRequest{Payload: &pb.AdminRequest_RoomAdmin{
+		RoomAdmin: &pb.RoomAdminRequest{RoomID: "moderated", Method: &pb.RoomAdminRequest_KickPeer{KickPeer: &pb.KickPeerRequest{Pid: "target"}}},
+	}}}}
+	if err := worker.HandleRoomAdmin(unnamed); !errors.Is(err, ErrNotModerator) {
+		t.Errorf("got %v want a kick naming no caller outside the admin api refused", err)
+	}
+	if count, _ := queue.Count(); count != 0 {
+		t.Errorf("refused kicks should not reach the peer, got %d messages", count)
+	}
+	if role, _ := mgr.GetPeerRole("mod"); role != pb.Role_MODERATOR {
+		t.Errorf("got role %s want moderator", role)
+	}
+	for _, caller := range []string{"mod", ""} {
+		if err := kick(caller); err != nil {
+			t.Errorf("%q should be allowed to kick, got %s", caller, err)
+		}
+	}
+	if count, _ := queue.Count(); count != 2 {
+		t.Errorf("got %d kills want 2", count)
+	}
+}
+
+func TestKickIdentity(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	redis.Del(pb.KeyIdentityPeers("alice"))
+	for pid, roomID := range map[string]string{"alice-1": "kick-a", "alice-2": "kick-b"} {
+		mgr.SaveData(pb.KeyUserData(pid), &pb.NoirObject{
+			Data: &pb.NoirObject_User{User: &pb.UserData{Id: pid, RoomID: roomID, Identity: "alice", Options: &pb.UserOptions{}}},
+		}, 0)
+		mgr.GetQueue(pb.KeyTopicToPeer(pid)).Cleanup()
+		defer mgr.GetQueue(pb.KeyTopicToPeer(pid)).Cleanup()
+		redis.SAdd(pb.KeyIdentityPeers("alice"), pid)
+	}
+	// left between joining and the kick
+	redis.SAdd(pb.KeyIdentityPeers("alice"), "alice-gone")
+
+	events := mgr.SubscribeRoomEvents("kick-a")
+	defer events.Close()
+	if _, err := events.Receive(); err != nil {
+		t.Fatalf("error subscribing: %s", err)
+	}
+
+	killed, err := mgr.KickIdentity("alice")
+	if err != nil || killed != 3 {
+		t.Fatalf("got %d killed (%v) want 3", killed, err)
+	}
+	for _, pid := range []string{"alice-1", "alice-2"} {
+		request := pb.NoirRequest{}
+		msg, _ := mgr.GetQueue(pb.KeyTopicToPeer(pid)).Next()
+		UnmarshalRequest(msg, &request)
+		if !request.GetSignal().GetKill() || request.GetSignal().GetCloseReason() != pb.CloseReason_KICKED {
+			t.Errorf("%s got %s want a kill for being kicked", pid, &request)
+		}
+	}
+	if redis.SIsMember(pb.KeyIdentityPeers("alice"), "alice-gone").Val() {
+		t.Errorf("a peer that left should be dropped from its identity")
+	}
+
+	msg, err := events.ReceiveMessage()
+	if err != nil {
+		t.Fatalf("error reading room event: %s", err)
+	}
+	reply := pb.NoirReply{}
+	proto.Unmarshal([]byte(msg.Payload), &reply)
+	if event := reply.GetEvent(); event.GetType() != pb.RoomEvent_KICKED || event.GetPid() != "alice-1" {
+		t.Errorf("got %s want alice-1 KICKED", &reply)
+	}
+
+	// as its PeerChannel does with the kill, once its JOINED was announced
+	redis.Set(pb.KeyUserJoined("alice-1"), 1, 0)
+	mgr.CloseClient("alice-1", pb.CloseReason_KICKED)
+	if redis.SIsMember(pb.KeyIdentityPeers("alice"), "alice-1").Val() {
+		t.Errorf("a disconnected peer should be dropped from its identity")
+	}
+	msg, err = events.ReceiveMessage()
+	if err != nil {
+		t.Fatalf("error reading room event: %s", err)
+	}
+	proto.Unmarshal([]byte(msg.Payload), &reply)
+	if event := reply.GetEvent(); event.GetType() != pb.RoomEvent_LEFT || event.GetReason() != pb.CloseReason_KICKED {
+		t.Errorf("got %s want alice-1 LEFT for being kicked", &reply)
+	}
+}
+
+func TestClientKillReason(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	keys := emptyRoomKeys("kill-reason", "client-killer", "node-killed")
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+	for _, pid := range []string{"client-killer", "node-killed"} {
+		mgr.GetQueue(pb.KeyTopicFromPeer(pid)).Cleanup()
+		defer mgr.GetQueue(pb.KeyTopicFromPeer(pid)).Cleanup()
+		joinFake(t, mgr, pid, "kill-reason", newFakePeer())
+	}
+
+	// a client cannot say it was kicked, its kill leaves
+	EnqueueRequest(mgr.GetQueue(pb.KeyTopicToPeer("client-killer")), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{Signal: &pb.SignalRequest{
+			Id:          "client-killer",
+			Payload:     &pb.SignalRequest_Kill{Kill: true},
+			CloseReason: pb.CloseReason_KICKED,
+		}},
+	})
+	kill := nextSignalReply(t, mgr, "client-killer", func(reply *pb.SignalReply) bool { return reply.GetKill() })
+	if kill.GetCloseReason() != pb.CloseReason_LEFT {
+		t.Errorf("got %s want a client's kill closed as LEFT", kill.GetCloseReason())
+	}
+
+	mgr.CloseClient("node-killed", pb.CloseReason_KICKED)
+	kill = nextSignalReply(t, mgr, "node-killed", func(reply *pb.SignalReply) bool { return reply.GetKill() })
+	if kill.GetCloseReason() != pb.CloseReason_KICKED {
+		t.Errorf("got %s want the node's own kill to keep its reason", kill.GetCloseReason())
+	}
+}
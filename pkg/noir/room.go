@@ -79,17 +79,91 @@ func (r *Room) Save() error {
 
 func SaveRoomData(roomID string, data *pb.RoomData, m *Manager) error {
 	options := data.GetOptions()
+	data.Id = roomID
+	if err := ValidateRoomCodecs(options); err != nil {
+		return err
+	}
+	if err := ValidateRTCPPolicy(options.GetRtcpPolicy()); err != nil {
+		return err
+	}
+	// a join password is only ever saved as its hash
+	if err := SealRoomPassword(options); err != nil {
+		return err
+	}
+
+	return m.RoomStore().Set(data, roomDataExpiry(options))
+}
+
+// createRoomData saves data like SaveRoomData, only if roomID has none yet, false if it had
+func createRoomData(roomID string, data *pb.RoomData, m *Manager) (bool, error) {
+	data.Id = roomID
+	if err := ValidateRoomCodecs(data.GetOptions()); err != nil {
+		return false, err
+	}
+	if err := ValidateRTCPPolicy(data.GetOptions().GetRtcpPolicy()); err != nil {
+		return false, err
+	}
+	if err := SealRoomPassword(data.GetOptions()); err != nil {
+		return false, err
+	}
+	created, err := m.RoomStore().CreateIfAbsent(data, roomDataExpiry(data.GetOptions()))
+	if created {
+		m.EmitRoomEvent(roomID, "", pb.RoomEvent_OPENED)
+	}
+	return created, err
+}
+
+func roomDataExpiry(options *pb.RoomOptions) time.Duration {
 	expiry := options.GetMaxAgeSeconds()
 	if expiry == -1 {
 		expiry = 0
 	}
-	data.Id = roomID
+	return time.Duration(expiry * (options.GetKeyExpiryFactor()+1)) * time.Second
+}
 
-	return m.SaveData(pb.KeyRoomData(roomID), &pb.NoirObject{
-		Data: &pb.NoirObject_Room{
-			Room: data,
-		},
-	}, time.Duration(expiry * (options.GetKeyExpiryFactor()+1)) * time.Second)
+// OpenRoom creates roomID from create, or opens it if it exists already, created says which.
+// Of calls racing to create a room exactly one does. A room that existed keeps its settings,
+// unless create is from its owner, whose options and metadata replace them, its password stays
+// unless the owner gives a new one. The options are create's over those of its template, when
+// it names one, see room_templates.go
+func (m *Manager) OpenRoom(roomID string, create *pb.CreateRoomRequest) (*pb.RoomData, bool, error) {
+	options, err := m.templatedOptions(create)
+	if err != nil {
+		return nil, false, err
+	}
+	room := NewRoom(roomID)
+	if options != nil {
+		room.SetOptions(options)
+	}
+	room.data.Owner = create.GetOwner()
+	room.data.Metadata = create.GetMetadata()
+	created, err := createRoomData(roomID, &room.data, m)
+	if err != nil {
+		return nil, false, err
+	}
+	if created {
+		m.ReopenRoom(roomID)
+		return &room.data, true, nil
+	}
+
+	existing, err := m.GetRoomData(roomID)
+	if err != nil {
+		return nil, false, err
+	}
+	if create.GetOwner() == "" || create.GetOwner() != existing.Owner {
+		return existing, false, nil
+	}
+	if options != nil {
+		if options.GetJoinPassword() == "" && options.GetJoinPasswordHash() == "" {
+			options.JoinPasswordHash = existing.GetOptions().GetJoinPasswordHash()
+		}
+		existing.Options = options
+	}
+	if metadata := create.GetMetadata(); metadata != nil {
+		existing.Metadata = metadata
+	}
+	existing.LastUpdate = timestamppb.Now()
+	return existing, false, SaveRoomData(roomID, existing, m)
 }
 
 func (r *Room) SetOptions(options *pb.RoomOptions) {
@@ -102,6 +176,14 @@ func GetRoomEndTime(data *pb.RoomData) time.Time {
 	return data.Created.AsTime().Add(time.Duration(seconds) * time.Second)
 }
 
+// IsRoomStale is true once a room with a max age is past its cleanup time, its key should have expired
+func IsRoomStale(data *pb.RoomData) bool {
+	if data.GetOptions().GetMaxAgeSeconds() <= 0 || data.Created == nil {
+		return false
+	}
+	return time.Now().After(GetRoomCleanupTime(data))
+}
+
 func GetRoomCleanupTime(data *pb.RoomData) time.Time {
 	seconds := data.Options.GetMaxAgeSeconds() * (1 + data.Options.KeyExpiryFactor)
 	return data.Created.AsTime().Add(time.Duration(seconds) * time.Second)
@@ -0,0 +1,84 @@
+package noir
+
+import (
+	"errors"
+	"fmt"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	log "github.com/pion/ion-log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+/*
+Room Owner:
+A room's owner, the pid in its RoomData, moderates it whatever role it joined as. The owner, or an
+admin, hands the room to another of its peers with the room admin transferOwnership action, and
+the new owner may moderate from then on. A room with autoTransferOwnership in its options is
+handed to the peer that was connected the longest when its owner leaves, instead of keeping an
+owner that is gone. Either way the room gets an OWNER_CHANGED event with the new owner's pid.
+*/
+
+var ErrNotOwner = errors.New("only the room's owner may transfer it")
+
+// TransferOwnership makes newOwnerPid, a peer in sid, the room's owner
+func (m *Manager) TransferOwnership(sid string, newOwnerPid string) error {
+	room, err := m.GetRoomData(sid)
+	if err != nil {
+		return fmt.Errorf("room %s not found", sid)
+	}
+	if !m.redis.HExists(m.Keys().RoomUsers(sid), newOwnerPid).Val() {
+		return fmt.Errorf("peer %s not found in %s", newOwnerPid, sid)
+	}
+	if room.Owner == newOwnerPid {
+		return nil
+	}
+	room.Owner = newOwnerPid
+	room.LastUpdate = timestamppb.Now()
+	if err := SaveRoomData(sid, room, m); err != nil {
+		return err
+	}
+	return m.EmitRoomEvent(sid, newOwnerPid, pb.RoomEvent_OWNER_CHANGED)
+}
+
+// isRoomOwner is whether callerID owns roomID
+func (m *Manager) isRoomOwner(roomID string, callerID string) bool {
+	room, err := m.GetRoomData(roomID)
+	return err == nil && callerID != "" && room.Owner == callerID
+}
+
+// checkOwner lets callerID transfer roomID, an empty callerID is the admin api
+func (m *Manager) checkOwner(roomID string, callerID string) error {
+	if callerID == "" || m.isRoomOwner(roomID, callerID) {
+		return nil
+	}
+	return fmt.Errorf("%w, %s does not own %s", ErrNotOwner, callerID, roomID)
+}
+
+// promoteOwner hands roomID to the peer connected the longest when its owner leaving has left, if
+// the room's options ask for it. A room left empty keeps its owner
+func (m *Manager) promoteOwner(roomID string, leaving string) {
+	room, err := m.GetRoomData(roomID)
+	if err != nil || room.Owner != leaving || !room.GetOptions().GetAutoTransferOwnership() {
+		return
+	}
+	pids, err := m.redis.HKeys(m.Keys().RoomUsers(roomID)).Result()
+	if err != nil {
+		log.Errorf("error listing the peers of %s: %s", roomID, err)
+		return
+	}
+	var oldest *pb.UserData
+	for _, pid := range pids {
+		userData, err := m.GetRemoteUserData(pid)
+		if err != nil || userData == nil || pid == leaving {
+			continue
+		}
+		if oldest == nil || userData.GetCreated().AsTime().Before(oldest.GetCreated().AsTime()) {
+			oldest = userData
+		}
+	}
+	if oldest == nil {
+		return
+	}
+	if err := m.TransferOwnership(roomID, oldest.Id); err != nil {
+		log.Errorf("error handing %s to %s: %s", roomID, oldest.Id, err)
+	}
+}
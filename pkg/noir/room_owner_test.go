@@ -0,0 +1,124 @@
+package noir
+
+import (
+	"errors"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"testing"
+	"time"
+)
+
+// joinOwnerTestPeer puts pid in roomID the way a join does, connected since created
+func joinOwnerTestPeer(mgr *Manager, roomID string, pid string, created time.Time) {
+	mgr.SaveData(pb.KeyUserData(pid), &pb.NoirObject{Data: &pb.NoirObject_User{User: &pb.UserData{
+		Id: pid, RoomID: roomID, Created: timestamppb.New(created), Options: &pb.UserOptions{MaxAgeSeconds: -1},
+	}}}, 0)
+	mgr.redis.HSet(pb.KeyRoomUsers(roomID), pid, 1)
+}
+
+func transferOwnershipRequest(roomID string, pid string, callerID string) *pb.NoirRequest {
+	return &pb.NoirRequest{
+		Command: &pb.NoirRequest_Admin{
+			Admin: &pb.AdminRequest{
+				Payload: &pb.AdminRequest_RoomAdmin{
+					RoomAdmin: &pb.RoomAdminRequest{
+						RoomID:   roomID,
+						CallerID: callerID,
+						Method:   &pb.RoomAdminRequest_TransferOwnership{TransferOwnership: &pb.TransferOwnershipRequest{Pid: pid}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestTransferOwnership(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	roomID := "owned-room"
+	pids := []string{"owner-a", "owner-b", "owner-c"}
+	keys := []string{pb.KeyRoomData(roomID), pb.KeyRoomUsers(roomID)}
+	for _, pid := range pids {
+		keys = append(keys, pb.KeyUserData(pid))
+	}
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+	mgr.SetRoomData(&pb.RoomData{Id: roomID, Owner: "owner-a", Options: &pb.RoomOptions{JoinPassword: "owned"}})
+	for _, pid := range pids {
+		joinOwnerTestPeer(mgr, roomID, pid, time.Now())
+	}
+	events, cancel := mgr.SubscribeEvents()
+	defer cancel()
+
+	// publishers, but the owner moderates
+	if err := mgr.checkModerator(roomID, "owner-a"); err != nil {
+		t.Errorf("got %v want the owner to moderate", err)
+	}
+	if err := mgr.checkModerator(roomID, "owner-b"); !errors.Is(err, ErrNotModerator) {
+		t.Errorf("got %v want a publisher refused", err)
+	}
+
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), transferOwnershipRequest(roomID, "owner-c", "owner-b"))
+	if err := worker.HandleNext(0); err == nil {
+		t.Errorf("a peer that does not own the room should not transfer it")
+	}
+	EnqueueRequest(*worker.GetQueue(), transferOwnershipRequest(roomID, "owner-b", "owner-a"))
+	if err := worker.HandleNext(0); err != nil {
+		t.Fatalf("error transferring: %s", err)
+	}
+	room, _ := mgr.GetRoomData(roomID)
+	if room.GetOwner() != "owner-b" {
+		t.Errorf("got owner %s want owner-b", room.GetOwner())
+	}
+	if room.GetOptions().GetJoinPasswordHash() == "" || !CheckRoomPassword(room.GetOptions(), "owned") {
+		t.Errorf("a transfer should keep the room's password")
+	}
+	select {
+	case event := <-events:
+		if event.Type != pb.RoomEvent_OWNER_CHANGED || event.Pid != "owner-b" || event.Sid != roomID {
+			t.Errorf("got %s of %s want OWNER_CHANGED to owner-b", event.Type, event.Pid)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("got no room event for the transfer")
+	}
+	if err := mgr.checkModerator(roomID, "owner-b"); err != nil {
+		t.Errorf("got %v want the new owner to moderate", err)
+	}
+	if err := mgr.checkModerator(roomID, "owner-a"); err == nil {
+		t.Errorf("the old owner should not moderate any more")
+	}
+
+	if err := mgr.TransferOwnership(roomID, "not-in-room"); err == nil {
+		t.Errorf("a peer that is not in the room should not own it")
+	}
+}
+
+func TestAutoTransferOwnership(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	roomID := "handed-room"
+	pids := []string{"handed-owner", "handed-first", "handed-last"}
+	keys := []string{pb.KeyRoomData(roomID), pb.KeyRoomUsers(roomID)}
+	for _, pid := range pids {
+		keys = append(keys, pb.KeyUserData(pid), pb.KeyTopicToPeer(pid), pb.KeyTopicFromPeer(pid))
+	}
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+	mgr.SetRoomData(&pb.RoomData{Id: roomID, Owner: "handed-owner", Options: &pb.RoomOptions{
+		MaxAgeSeconds: -1, AutoTransferOwnership: true,
+	}})
+	joinOwnerTestPeer(mgr, roomID, "handed-owner", time.Now().Add(-time.Hour))
+	joinOwnerTestPeer(mgr, roomID, "handed-last", time.Now())
+	joinOwnerTestPeer(mgr, roomID, "handed-first", time.Now().Add(-time.Minute))
+
+	mgr.CloseClient("handed-owner", pb.CloseReason_LEFT)
+	if room, _ := mgr.GetRoomData(roomID); room.GetOwner() != "handed-first" {
+		t.Errorf("got owner %s want the peer connected the longest", room.GetOwner())
+	}
+
+	// without the option the owner stays even when it is gone
+	mgr.SetRoomData(&pb.RoomData{Id: roomID, Owner: "handed-first", Options: &pb.RoomOptions{MaxAgeSeconds: -1}})
+	mgr.CloseClient("handed-first", pb.CloseReason_LEFT)
+	if room, _ := mgr.GetRoomData(roomID); room.GetOwner() != "handed-first" {
+		t.Errorf("got owner %s want the room to keep its owner", room.GetOwner())
+	}
+}
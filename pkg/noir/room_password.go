@@ -0,0 +1,97 @@
+package noir
+
+import (
+	"crypto/subtle"
+	"fmt"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/protobuf/proto"
+)
+
+/*
+Room Passwords:
+A room created with a joinPassword only lets in joins that carry the same password. The
+password is never stored, SaveRoomData has SealRoomPassword swap it for its bcrypt hash in
+joinPasswordHash, and joins are checked against the hash. The hash never leaves the cluster
+either, the admin apis and replies show rooms through PublicRoomData.
+SetPasswordRequest rotates or clears it later, peers already joined stay. Passwords are left
+out of signaling traces like tokens, and never logged.
+*/
+
+// How much work hashing a room password takes, see bcrypt.GenerateFromPassword
+var RoomPasswordCost = bcrypt.DefaultCost
+
+// HashRoomPassword is the bcrypt hash of password, salted by bcrypt
+func HashRoomPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), RoomPasswordCost)
+	if err != nil {
+		return "", fmt.Errorf("error hashing room password: %w", err)
+	}
+	return string(hash), nil
+}
+
+// RoomHasPassword is whether joins need a password, it is cheap, nothing is hashed
+func RoomHasPassword(options *pb.RoomOptions) bool {
+	return options.GetJoinPasswordHash() != "" || options.GetJoinPassword() != ""
+}
+
+// CheckRoomPassword is true if the room has no password or password matches it
+func CheckRoomPassword(options *pb.RoomOptions, password string) bool {
+	stored := options.GetJoinPasswordHash()
+	if stored == "" {
+		if plain := options.GetJoinPassword(); plain != "" {
+			// room data saved before passwords were hashed
+			return subtle.ConstantTimeCompare([]byte(plain), []byte(password)) == 1
+		}
+		return true
+	}
+	return bcrypt.CompareHashAndPassword([]byte(stored), []byte(password)) == nil
+}
+
+// PublicRoomOptions is options without the password or its hash, for replies leaving the cluster
+func PublicRoomOptions(options *pb.RoomOptions) *pb.RoomOptions {
+	if options.GetJoinPassword() == "" && options.GetJoinPasswordHash() == "" {
+		return options
+	}
+	public := proto.Clone(options).(*pb.RoomOptions)
+	public.JoinPassword = ""
+	public.JoinPasswordHash = ""
+	return public
+}
+
+// PublicRoomData is room with PublicRoomOptions, for the admin apis
+func PublicRoomData(room *pb.RoomData) *pb.RoomData {
+	if options := PublicRoomOptions(room.GetOptions()); options != room.GetOptions() {
+		room = proto.Clone(room).(*pb.RoomData)
+		room.Options = options
+	}
+	return room
+}
+
+// SealRoomPassword replaces the options' joinPassword with its hash, so it is never saved
+func SealRoomPassword(options *pb.RoomOptions) error {
+	if options.GetJoinPassword() == "" {
+		return nil
+	}
+	hash, err := HashRoomPassword(options.JoinPassword)
+	if err != nil {
+		return err
+	}
+	options.JoinPasswordHash = hash
+	options.JoinPassword = ""
+	return nil
+}
+
+// SetRoomPassword rotates the password joins to roomID need, an empty password clears it
+func (m *Manager) SetRoomPassword(roomID string, password string) error {
+	room, err := m.GetRoomData(roomID)
+	if err != nil {
+		return fmt.Errorf("room %s not found", roomID)
+	}
+	if room.Options == nil {
+		room.Options = &pb.RoomOptions{}
+	}
+	room.Options.JoinPassword = password
+	room.Options.JoinPasswordHash = ""
+	return SaveRoomData(roomID, room, m)
+}
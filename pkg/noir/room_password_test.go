@@ -0,0 +1,155 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"google.golang.org/protobuf/proto"
+	"testing"
+	"time"
+)
+
+func TestCheckRoomPassword(t *testing.T) {
+	if !CheckRoomPassword(nil, "") || !CheckRoomPassword(&pb.RoomOptions{}, "anything") {
+		t.Errorf("a room without a password should let everyone in")
+	}
+	options := &pb.RoomOptions{JoinPassword: "secret"}
+	if err := SealRoomPassword(options); err != nil {
+		t.Fatalf("error sealing: %s", err)
+	}
+	if options.JoinPassword != "" || options.JoinPasswordHash == "" {
+		t.Errorf("got %s want only the hash", options)
+	}
+	if !RoomHasPassword(options) || RoomHasPassword(&pb.RoomOptions{}) || !RoomHasPassword(&pb.RoomOptions{JoinPassword: "old"}) {
+		t.Errorf("a room should need a password only once it has one, hashed or not")
+	}
+	if !CheckRoomPassword(options, "secret") {
+		t.Errorf("the right password should match")
+	}
+	if CheckRoomPassword(options, "Secret") || CheckRoomPassword(options, "") {
+		t.Errorf("a wrong password should not match")
+	}
+	again := &pb.RoomOptions{JoinPassword: "secret"}
+	SealRoomPassword(again)
+	if again.JoinPasswordHash == options.JoinPasswordHash {
+		t.Errorf("every hash should have its own salt")
+	}
+	if CheckRoomPassword(&pb.RoomOptions{JoinPasswordHash: "not a hash"}, "") {
+		t.Errorf("a broken hash should match nothing")
+	}
+	room := &pb.RoomData{Id: "sealed", Options: options}
+	if public := PublicRoomData(room); public.GetOptions().GetJoinPasswordHash() != "" || public.GetId() != "sealed" {
+		t.Errorf("got %s want the room without its hash", public)
+	}
+	if options.JoinPasswordHash == "" {
+		t.Errorf("the public copy should leave the room's own options alone")
+	}
+}
+
+func TestRoomPasswordJoin(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	redis.Del(pb.KeyRoomData("locked"), pb.KeyRoomUsers("locked"), pb.KeyTopicFromPeer("locked-peer"))
+	defer redis.Del(pb.KeyTopicToAdmin("password-admin"))
+	worker := *mgr.GetWorker()
+	queue := *worker.GetQueue()
+
+	EnqueueRequest(queue, &pb.NoirRequest{
+		AdminID: "password-admin",
+		Command: &pb.NoirRequest_Admin{
+			Admin: &pb.AdminRequest{
+				Payload: &pb.AdminRequest_RoomAdmin{
+					RoomAdmin: &pb.RoomAdminRequest{
+						RoomID: "locked",
+						Method: &pb.RoomAdminRequest_CreateRoom{
+							CreateRoom: &pb.CreateRoomRequest{Options: &pb.RoomOptions{JoinPassword: "secret"}},
+						},
+					},
+				},
+			},
+		},
+	})
+	if err := worker.HandleNext(0); err != nil {
+		t.Fatalf("error creating room: %s", err)
+	}
+	room, _ := mgr.GetRoomData("locked")
+	if room.GetOptions().GetJoinPassword() != "" || room.GetOptions().GetJoinPasswordHash() == "" {
+		t.Fatalf("got %s want the password saved as its hash", room.GetOptions())
+	}
+	created, _ := mgr.GetQueue(pb.KeyTopicToAdmin("password-admin")).Next()
+	createReply := pb.NoirReply{}
+	proto.Unmarshal(created, &createReply)
+	if options := createReply.GetAdmin().GetRoomAdmin().GetCreateRoom().GetOptions(); options.GetJoinPasswordHash() != "" || options.GetJoinPassword() != "" {
+		t.Errorf("got %s want the reply without the password", options)
+	}
+
+	join := func(password string) error {
+		EnqueueRequest(queue, &pb.NoirRequest{
+			Command: &pb.NoirRequest_Signal{
+				Signal: &pb.SignalRequest{
+					Id: "locked-peer",
+					Payload: &pb.SignalRequest_Join{
+						Join: &pb.JoinRequest{Sid: "locked", Description: []byte(EXAMPLE_EMPTY_SDP), Password: password},
+					},
+				},
+			},
+		})
+		return worker.HandleNext(0)
+	}
+	if err := join("wrong"); err == nil {
+		t.Errorf("join with a wrong password should fail")
+	}
+	reply := pb.NoirReply{}
+	msg, _ := mgr.GetQueue(pb.KeyTopicFromPeer("locked-peer")).Next()
+	proto.Unmarshal(msg, &reply)
+	if reply.GetSignal().GetFailure().GetCode() != pb.SignalError_UNAUTHORIZED {
+		t.Errorf("got %s want UNAUTHORIZED", &reply)
+	}
+
+	if err := mgr.SetRoomPassword("locked", "rotated"); err != nil {
+		t.Fatalf("error rotating password: %s", err)
+	}
+	room, _ = mgr.GetRoomData("locked")
+	if CheckRoomPassword(room.Options, "secret") || !CheckRoomPassword(room.Options, "rotated") {
+		t.Errorf("only the rotated password should match")
+	}
+	if err := mgr.SetRoomPassword("locked", ""); err != nil {
+		t.Fatalf("error clearing password: %s", err)
+	}
+	if err := join(""); err != nil {
+		t.Fatalf("join after clearing the password should work: %s", err)
+	}
+	mgr.DisconnectUser("locked-peer")
+	redis.Del(pb.KeyRoomData("locked"), pb.KeyRoomUsers("locked"), pb.KeyTopicFromPeer("locked-peer"))
+}
+
+func TestRoomPasswordJoinUnlocked(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	keys := emptyRoomKeys("locked-busy", "locked-busy-peer")
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+	mgr.SetRoomData(&pb.RoomData{Id: "locked-busy", Options: &pb.RoomOptions{JoinPassword: "secret"}})
+	w := (*mgr.GetWorker()).(*worker)
+
+	// the password is checked without the worker's lock, a wrong one is refused while it is held
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	refused := make(chan error, 1)
+	go func() {
+		refused <- w.HandleJoin(&pb.NoirRequest{
+			Command: &pb.NoirRequest_Signal{
+				Signal: &pb.SignalRequest{
+					Id: "locked-busy-peer",
+					Payload: &pb.SignalRequest_Join{
+						Join: &pb.JoinRequest{Sid: "locked-busy", Description: []byte(EXAMPLE_EMPTY_SDP), Password: "wrong"},
+					},
+				},
+			},
+		}, w.requestLogger(&pb.NoirRequest{}))
+	}()
+	select {
+	case err := <-refused:
+		if err == nil {
+			t.Errorf("join with a wrong password should fail")
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("the password check waited on the worker's lock")
+	}
+}
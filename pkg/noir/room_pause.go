@@ -0,0 +1,128 @@
+package noir
+
+import (
+	"fmt"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	log "github.com/pion/ion-log"
+	"github.com/pion/ion-sfu/pkg/sfu"
+)
+
+/*
+Room Pause:
+A moderator or admin can hold a room, for a breakout or to get everyone's attention, with the
+room admin pause action: no media is forwarded in it while every peer stays connected and keeps
+signaling. The room is saved as paused, and each of its peers is sent a pause on its to-peer
+topic, so the worker that owns it, wherever it runs, mutes every downtrack the peer is sent and
+tells its client with a RoomPaused. Muted downtracks drop the rtp of the forwarding path without
+renegotiating. Every node keeps the rooms it was told are paused, and mutes the downtracks
+added to a peer of one before they are offered, so tracks published during the pause stay
+paused too, until the room's last session on the node closes. Peers joining a paused room join
+paused. Resuming unmutes the downtracks, at the
+next keyframe, except those of publishers that were muted on their own, and is announced like
+the pause, with a RESUMED room event for the PAUSED one.
+*/
+
+// PauseRoom stops, or with paused=false resumes, forwarding media to every peer in roomID, by is
+// the moderator doing it, empty for an admin
+func (m *Manager) PauseRoom(roomID string, paused bool, by string) error {
+	room, err := m.GetRoomData(roomID)
+	if err != nil {
+		return fmt.Errorf("room %s not found", roomID)
+	}
+	if room.Paused != paused {
+		room.Paused = paused
+		if err := SaveRoomData(roomID, room, m); err != nil {
+			return err
+		}
+	}
+	pids, err := m.redis.HKeys(m.Keys().RoomUsers(roomID)).Result()
+	if err != nil {
+		return fmt.Errorf("error listing the peers of %s: %w", roomID, err)
+	}
+	for _, pid := range pids {
+		err := EnqueueRequest(m.GetQueue(m.Keys().TopicToPeer(pid)), &pb.NoirRequest{
+			Command: &pb.NoirRequest_Signal{
+				Signal: &pb.SignalRequest{
+					Id:      pid,
+					Payload: &pb.SignalRequest_Pause{Pause: &pb.RoomPaused{Sid: roomID, Paused: paused, By: by}},
+				},
+			},
+		})
+		if err != nil {
+			log.Debugf("error pausing %s: %s", pid, err)
+		}
+	}
+	eventType := pb.RoomEvent_RESUMED
+	if paused {
+		eventType = pb.RoomEvent_PAUSED
+	}
+	return m.PublishRoomEvent(&pb.RoomEvent{Type: eventType, Pid: by, Sid: roomID})
+}
+
+// RoomPaused is whether this node was told roomID is paused
+func (m *Manager) RoomPaused(roomID string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.pausedRooms[roomID]
+}
+
+func (m *Manager) setRoomPaused(roomID string, paused bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if paused {
+		m.pausedRooms[roomID] = true
+	} else {
+		delete(m.pausedRooms, roomID)
+	}
+}
+
+// pauseSubscriber mutes, or unmutes, every downtrack sent to the subscriber and returns how many
+func pauseSubscriber(subscriber *sfu.Subscriber, paused bool) int {
+	if subscriber == nil {
+		return 0
+	}
+	tracks := downTracksOf(subscriber)
+	for _, track := range tracks {
+		track.Mute(paused)
+	}
+	return len(tracks)
+}
+
+// holdWhilePaused mutes the downtracks added to a joined peer while its room is paused, ahead of
+// the renegotiation that offers them. The room is looked up each time, a peer may move rooms, and
+// the lookup cannot wait on the PeerChannel, whose subscribes and moves add the tracks
+func (m *Manager) holdWhilePaused(roomOf func() string, peer *sfu.Peer) bool {
+	subscriber := subscriberOf(peer)
+	if subscriber == nil {
+		return false
+	}
+	_, ok := hookNegotiate(subscriber, func() {
+		if m.RoomPaused(roomOf()) {
+			pauseSubscriber(subscriber, true)
+		}
+	})
+	return ok
+}
+
+// HandlePause applies a room's pause to the peer this PeerChannel owns and tells its client
+func (w *worker) HandlePause(userData *pb.UserData, peer *sfu.Peer, pause *pb.RoomPaused) error {
+	w.manager.setRoomPaused(userData.RoomID, pause.Paused)
+	changed := pauseSubscriber(subscriberOf(peer), pause.Paused)
+	if !pause.Paused {
+		// the publishers muted on their own stay muted
+		w.manager.ApplyRoomMutes(userData.RoomID)
+	}
+	log.Infof("paused=%t %d downtracks of %s", pause.Paused, changed, userData.Id)
+	return w.sendPaused(userData.Id, userData.RoomID, pause)
+}
+
+func (w *worker) sendPaused(pid string, roomID string, pause *pb.RoomPaused) error {
+	return w.SignalReply(pid, &pb.NoirReply{
+		Command: &pb.NoirReply_Signal{
+			Signal: &pb.SignalReply{
+				Id:      pid,
+				Payload: &pb.SignalReply_Paused{Paused: &pb.RoomPaused{Sid: roomID, Paused: pause.Paused, By: pause.By}},
+			},
+		},
+	})
+}
@@ -0,0 +1,162 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"google.golang.org/protobuf/proto"
+	"testing"
+	"time"
+)
+
+func TestPauseRoom(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	roomID := "paused-room"
+	pids := []string{"paused-a", "paused-b"}
+	redis.Del(pb.KeyRoomData(roomID), pb.KeyRoomUsers(roomID))
+	defer redis.Del(pb.KeyRoomData(roomID), pb.KeyRoomUsers(roomID))
+	mgr.SetRoomData(&pb.RoomData{Id: roomID})
+	for _, pid := range pids {
+		redis.HSet(pb.KeyRoomUsers(roomID), pid, 1)
+		mgr.GetQueue(pb.KeyTopicToPeer(pid)).Cleanup()
+		defer mgr.GetQueue(pb.KeyTopicToPeer(pid)).Cleanup()
+	}
+	events, cancel := mgr.SubscribeEvents()
+	defer cancel()
+
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+		AdminID: "pause-admin",
+		Command: &pb.NoirRequest_Admin{
+			Admin: &pb.AdminRequest{
+				Payload: &pb.AdminRequest_RoomAdmin{
+					RoomAdmin: &pb.RoomAdminRequest{
+						RoomID: roomID,
+						Method: &pb.RoomAdminRequest_PauseRoom{PauseRoom: &pb.PauseRoomRequest{Paused: true}},
+					},
+				},
+			},
+		},
+	})
+	if err := worker.HandleNext(0); err != nil {
+		t.Fatalf("error pausing: %s", err)
+	}
+	if room, _ := mgr.GetRoomData(roomID); !room.GetPaused() {
+		t.Errorf("got %s want the room saved as paused", room)
+	}
+	for _, pid := range pids {
+		msg, err := mgr.GetQueue(pb.KeyTopicToPeer(pid)).Next()
+		if err != nil || msg == nil {
+			t.Fatalf("%s got no pause: %v", pid, err)
+		}
+		request := &pb.NoirRequest{}
+		UnmarshalRequest(msg, request)
+		if pause := request.GetSignal().GetPause(); !pause.GetPaused() || pause.GetSid() != roomID {
+			t.Errorf("got %s want %s paused", request, pid)
+		}
+	}
+	select {
+	case event := <-events:
+		if event.Type != pb.RoomEvent_PAUSED || event.Sid != roomID {
+			t.Errorf("got %s in %s want PAUSED", event.Type, event.Sid)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("got no room event for the pause")
+	}
+
+	if err := mgr.PauseRoom(roomID, false, "moderator"); err != nil {
+		t.Fatalf("error resuming: %s", err)
+	}
+	if room, _ := mgr.GetRoomData(roomID); room.GetPaused() {
+		t.Errorf("got %s want the room resumed", room)
+	}
+	if event := <-events; event.Type != pb.RoomEvent_RESUMED || event.Pid != "moderator" {
+		t.Errorf("got %s by %s want RESUMED by the moderator", event.Type, event.Pid)
+	}
+	if err := mgr.PauseRoom("no-such-room", true, ""); err == nil {
+		t.Errorf("pausing a missing room should fail")
+	}
+}
+
+func TestJoinPausedRoom(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	keys := []string{pb.KeyRoomData("held-room"), pb.KeyRoomUsers("held-room"), pb.KeyPeerOwner("held-peer"),
+		pb.KeyTopicFromPeer("held-peer"), pb.KeyTopicToPeer("held-peer")}
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+	mgr.SetRoomData(&pb.RoomData{Id: "held-room", Paused: true})
+
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id: "held-peer",
+				Payload: &pb.SignalRequest_Join{
+					Join: &pb.JoinRequest{Sid: "held-room", Description: []byte(EXAMPLE_EMPTY_SDP)},
+				},
+			},
+		},
+	})
+	if err := worker.HandleNext(0); err != nil {
+		t.Fatalf("error joining: %s", err)
+	}
+	defer mgr.DisconnectUser("held-peer")
+	if !mgr.RoomPaused("held-room") {
+		t.Errorf("joining a paused room should hold it paused on the node")
+	}
+
+	replies := mgr.GetQueue(pb.KeyTopicFromPeer("held-peer"))
+	paused := func(want bool) {
+		for {
+			message, err := replies.BlockUntilNext(time.Second)
+			if err != nil {
+				t.Fatalf("got no paused notice want paused=%t: %s", want, err)
+			}
+			reply := pb.NoirReply{}
+			proto.Unmarshal(message, &reply)
+			if notice := reply.GetSignal().GetPaused(); notice != nil {
+				if notice.Paused != want || notice.Sid != "held-room" {
+					t.Errorf("got %s want paused=%t", notice, want)
+				}
+				return
+			}
+		}
+	}
+	paused(true)
+
+	if err := mgr.PauseRoom("held-room", false, ""); err != nil {
+		t.Fatalf("error resuming: %s", err)
+	}
+	paused(false)
+	if mgr.RoomPaused("held-room") {
+		t.Errorf("the node should forget the pause once resumed")
+	}
+}
+
+func TestPausedRoomSessionClosed(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	keys := append(peerKeys("held-last-peer"), pb.KeyRoomData("held-closed"), pb.KeyRoomUsers("held-closed"))
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+	mgr.SetRoomData(&pb.RoomData{Id: "held-closed", Paused: true, Options: &pb.RoomOptions{MaxAgeSeconds: -1}})
+
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id: "held-last-peer",
+				Payload: &pb.SignalRequest_Join{
+					Join: &pb.JoinRequest{Sid: "held-closed", Description: []byte(EXAMPLE_EMPTY_SDP)},
+				},
+			},
+		},
+	})
+	if err := worker.HandleNext(0); err != nil {
+		t.Fatalf("error joining: %s", err)
+	}
+	if !mgr.RoomPaused("held-closed") {
+		t.Fatalf("joining a paused room should hold it paused on the node")
+	}
+	mgr.DisconnectUser("held-last-peer")
+	waitFor(t, "the pause forgotten with the room's session", func() bool {
+		return !mgr.RoomPaused("held-closed")
+	})
+}
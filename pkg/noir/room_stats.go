@@ -0,0 +1,245 @@
+package noir
+
+import (
+	"fmt"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	log "github.com/pion/ion-log"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"github.com/pion/webrtc/v3"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"sync"
+	"time"
+)
+
+/*
+Room Stats:
+A room's peers may be spread over workers, eg. after a failover moved the room but not the peers
+that were already connected. GetRoomStats sends a RoomStatsRequest to every sfu worker and each
+replies with the stats of its own peers in the room, to a topic only this call reads. Workers that
+do not reply within RoomStatsTimeout are listed in Missing, and the stats cover the rest.
+Byte counts cover both of a peer's transports, packets and losses are the rtp the sfu received
+from its published tracks, and layers the simulcast layers of its video, see track_layers.go. Every worker also reports its rooms on each scrape as noir_room_*
+gauges, use rate() on the byte counts for a room's bitrate.
+*/
+
+// How long GetRoomStats waits for the workers to reply
+const RoomStatsTimeout = 2 * time.Second
+
+// RoomStats are the stats of a room's peers, with their sum in Total
+type RoomStats struct {
+	RoomID string
+	Peers  []*pb.PeerStats
+	Total  *pb.PeerStats
+	// Missing lists the workers that did not reply in time
+	Missing []string
+}
+
+// GetRoomStats gathers the stats of every peer of sid from the workers they are on
+func (m *Manager) GetRoomStats(sid string) (RoomStats, error) {
+	stats := RoomStats{RoomID: sid, Total: &pb.PeerStats{}}
+	nodes := m.NodesForService("sfu")
+	if len(nodes) == 0 {
+		return stats, fmt.Errorf("no sfu nodes available")
+	}
+	adminID := "roomstats-" + RandomString(16)
+	replies := m.GetQueue(m.Keys().TopicToAdmin(adminID))
+	defer replies.Cleanup()
+
+	waiting := map[string]bool{}
+	for _, node := range nodes {
+		err := EnqueueRequest(*m.GetRemoteWorkerQueue(node), &pb.NoirRequest{
+			AdminID: adminID,
+			Command: &pb.NoirRequest_Admin{
+				Admin: &pb.AdminRequest{
+					Payload: &pb.AdminRequest_RoomStats{
+						RoomStats: &pb.RoomStatsRequest{RoomID: sid},
+					},
+				},
+			},
+		})
+		if err != nil {
+			log.Errorf("error asking %s for room stats: %s", node, err)
+		}
+		waiting[node] = true
+	}
+
+	deadline := time.Now().Add(RoomStatsTimeout)
+	for len(waiting) > 0 && time.Now().Before(deadline) {
+		// redis blocks for whole seconds, so this may run past the deadline by one poll
+		message, err := replies.BlockUntilNext(WorkerPollTimeout)
+		if err == io.EOF {
+			continue
+		} else if err != nil {
+			return stats, err
+		}
+		var reply pb.NoirReply
+		if err := proto.Unmarshal(message, &reply); err != nil {
+			log.Errorf("room stats unmarshal err: %s", err)
+			continue
+		}
+		roomStats := reply.GetAdmin().GetRoomStats()
+		if roomStats == nil || !waiting[roomStats.NodeID] {
+			continue
+		}
+		delete(waiting, roomStats.NodeID)
+		for _, peer := range roomStats.Peers {
+			stats.Peers = append(stats.Peers, peer)
+			addPeerStats(stats.Total, peer)
+		}
+	}
+	for node := range waiting {
+		stats.Missing = append(stats.Missing, node)
+	}
+	return stats, nil
+}
+
+func addPeerStats(total *pb.PeerStats, peer *pb.PeerStats) {
+	total.BytesSent += peer.BytesSent
+	total.BytesReceived += peer.BytesReceived
+	total.PacketsReceived += peer.PacketsReceived
+	total.PacketsLost += peer.PacketsLost
+	total.PublishedTracks += peer.PublishedTracks
+	total.SubscribedTracks += peer.SubscribedTracks
+}
+
+// LocalRoomStats are the stats of the room's peers on this worker
+func (m *Manager) LocalRoomStats(roomID string) []*pb.PeerStats {
+	stats := []*pb.PeerStats{}
+	for _, pid := range m.redis.HKeys(m.Keys().RoomUsers(roomID)).Val() {
+		peer := m.localPeer(pid)
+		if peer == nil {
+			continue
+		}
+		peerStats := PeerStatsOf(pid, peer)
+		peerStats.Layers = m.publishedLayers(pid)
+		peerStats.TargetBitrate = m.TargetBitrate(pid)
+		stats = append(stats, peerStats)
+	}
+	return stats
+}
+
+// PeerStatsOf reads a peer's transports and tracks
+func PeerStatsOf(pid string, peer *sfu.Peer) *pb.PeerStats {
+	stats := &pb.PeerStats{Pid: pid}
+	publisher := publisherOf(peer)
+	subscriber := subscriberOf(peer)
+	stats.BytesSent, stats.BytesReceived = transportBytes(publisher, subscriber)
+	if pc := publisherConnection(publisher); pc != nil {
+		for _, receiver := range pc.GetReceivers() {
+			if receiver.Track() != nil {
+				stats.PublishedTracks++
+			}
+		}
+	}
+	if subscriber != nil {
+		stats.SubscribedTracks = int32(len(downTracksOf(subscriber)))
+	}
+	stats.PacketsReceived, stats.PacketsLost = receivedPackets(publisher)
+	return stats
+}
+
+// transportBytes sums what went over both of a peer's transports
+func transportBytes(publisher *sfu.Publisher, subscriber *sfu.Subscriber) (sent uint64, received uint64) {
+	for _, pc := range []*webrtc.PeerConnection{publisherConnection(publisher), subscriberConnection(subscriber)} {
+		if pc == nil {
+			continue
+		}
+		for _, report := range pc.GetStats() {
+			if transport, ok := report.(webrtc.TransportStats); ok {
+				sent += transport.BytesSent
+				received += transport.BytesReceived
+			}
+		}
+	}
+	return sent, received
+}
+
+// HandleRoomStats replies with the stats of this worker's peers in the room
+func (w *worker) HandleRoomStats(request *pb.NoirRequest) error {
+	roomStats := request.GetAdmin().GetRoomStats()
+	return w.Reply(request, &pb.NoirReply{
+		Command: &pb.NoirReply_Admin{
+			Admin: &pb.AdminReply{
+				Payload: &pb.AdminReply_RoomStats{
+					RoomStats: &pb.RoomStatsReply{
+						RoomID: roomStats.RoomID,
+						NodeID: w.id,
+						Peers:  w.manager.LocalRoomStats(roomStats.RoomID),
+					},
+				},
+			},
+		},
+	})
+}
+
+// TrackRoomStats reports the manager's local rooms on every scrape, until the func it returns is called
+func TrackRoomStats(m *Manager) func() {
+	roomStatsGauges.mu.Lock()
+	defer roomStatsGauges.mu.Unlock()
+	roomStatsGauges.managers[m.id] = m
+	return func() {
+		roomStatsGauges.mu.Lock()
+		defer roomStatsGauges.mu.Unlock()
+		if roomStatsGauges.managers[m.id] == m {
+			delete(roomStatsGauges.managers, m.id)
+		}
+	}
+}
+
+type roomStatsCollector struct {
+	mu       sync.Mutex
+	managers map[string]*Manager
+	descs    map[string]*prometheus.Desc
+}
+
+func newRoomStatsCollector() *roomStatsCollector {
+	desc := func(name string, help string) *prometheus.Desc {
+		return prometheus.NewDesc("noir_room_"+name, help, []string{"room"}, nil)
+	}
+	return &roomStatsCollector{
+		managers: map[string]*Manager{},
+		descs: map[string]*prometheus.Desc{
+			"bytes_sent":       desc("bytes_sent", "Bytes this worker sent to the room's peers"),
+			"bytes_received":   desc("bytes_received", "Bytes this worker received from the room's peers"),
+			"packets_received": desc("packets_received", "RTP packets this worker received on the room's published tracks"),
+			"packets_lost":     desc("packets_lost", "RTP packets lost on the way to this worker from the room's publishers"),
+			"tracks":           desc("tracks", "Tracks published to this worker in the room"),
+		},
+	}
+}
+
+func (c *roomStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range c.descs {
+		ch <- desc
+	}
+}
+
+func (c *roomStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, manager := range c.managers {
+		manager.mu.RLock()
+		rooms := make([]string, 0, len(manager.rooms))
+		for roomID := range manager.rooms {
+			rooms = append(rooms, roomID)
+		}
+		manager.mu.RUnlock()
+		for _, roomID := range rooms {
+			total := &pb.PeerStats{}
+			for _, peer := range manager.LocalRoomStats(roomID) {
+				addPeerStats(total, peer)
+			}
+			for name, value := range map[string]float64{
+				"bytes_sent":       float64(total.BytesSent),
+				"bytes_received":   float64(total.BytesReceived),
+				"packets_received": float64(total.PacketsReceived),
+				"packets_lost":     float64(total.PacketsLost),
+				"tracks":           float64(total.PublishedTracks),
+			} {
+				ch <- prometheus.MustNewConstMetric(c.descs[name], prometheus.GaugeValue, value, roomID)
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"testing"
+	"time"
+)
+
+func TestGetRoomStats(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.Checkin()
+	mgr.Heartbeat("test-worker", 0)
+	// a worker that never replies
+	silent, _ := proto.Marshal(&pb.NoirObject{Data: &pb.NoirObject_Node{Node: &pb.NodeData{
+		Id:         "stats-silent",
+		LastUpdate: timestamppb.Now(),
+		Services:   []string{"*"},
+	}}})
+	redis.HSet(pb.KeyNodeMap(), "stats-silent", silent)
+	defer redis.HDel(pb.KeyNodeMap(), "stats-silent")
+	mgr.Heartbeat("stats-silent", 0)
+	defer mgr.ClearHeartbeat("stats-silent")
+	mgr.UpdateAvailableNodes()
+
+	redis.Del(pb.KeyRoomUsers("stats-room"))
+	redis.HSet(pb.KeyRoomUsers("stats-room"), "stats-peer", "")
+	defer redis.Del(pb.KeyRoomUsers("stats-room"))
+	mgr.mu.Lock()
+	mgr.users["stats-peer"] = NewSFUPeer(*mgr.sfu)
+	mgr.mu.Unlock()
+
+	worker := *mgr.GetWorker()
+	go func() {
+		for i := 0; i < 3; i++ {
+			worker.HandleNext(time.Second)
+		}
+	}()
+
+	started := time.Now()
+	stats, err := mgr.GetRoomStats("stats-room")
+	if err != nil {
+		t.Fatalf("error getting room stats: %s", err)
+	}
+	if time.Since(started) < RoomStatsTimeout {
+		t.Errorf("returned after %s without waiting for the silent worker", time.Since(started))
+	}
+	if len(stats.Peers) != 1 || stats.Peers[0].Pid != "stats-peer" {
+		t.Errorf("got %v want stats-peer from test-worker", stats.Peers)
+	}
+	missing := map[string]bool{}
+	for _, node := range stats.Missing {
+		missing[node] = true
+	}
+	if !missing["stats-silent"] || missing["test-worker"] {
+		t.Errorf("got %v missing want only stats-silent", stats.Missing)
+	}
+}
+
+func TestAddPeerStats(t *testing.T) {
+	total := &pb.PeerStats{}
+	addPeerStats(total, &pb.PeerStats{BytesSent: 10, PacketsLost: 1, PublishedTracks: 2})
+	addPeerStats(total, &pb.PeerStats{BytesSent: 5, BytesReceived: 7, SubscribedTracks: 1})
+	if total.BytesSent != 15 || total.BytesReceived != 7 || total.PacketsLost != 1 ||
+		total.PublishedTracks != 2 || total.SubscribedTracks != 1 {
+		t.Errorf("got %v want the peers summed", total)
+	}
+}
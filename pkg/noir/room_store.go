@@ -0,0 +1,298 @@
+package noir
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-redis/redis"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	log "github.com/pion/ion-log"
+	"google.golang.org/protobuf/proto"
+	"sort"
+	"sync"
+	"time"
+)
+
+/*
+Room Store:
+The manager keeps each room's RoomData in a RoomStore, apart from the queues and the rest of the
+cluster state, so rooms can live in a database of their own. Every room method goes through it:
+OpenRoom and auto-created rooms create with CreateIfAbsent, SaveRoomData sets, CloseRoom and
+expiring empty rooms delete, and ListRooms, GetRoomData and the capacity checks of joins and moves
+read from it. The peers of a room, its closed mark and its score stay in redis. The default is
+NewRedisRoomStore on the manager's redis, under its keys, NewMemoryRoomStore keeps rooms in the
+process, for tests and single node setups.
+
+A store has to give these guarantees, the manager relies on them across nodes:
+
+	CreateIfAbsent  is atomic, of calls racing to create a room exactly one creates it
+	                and the others see it exists
+	MoveNode        is atomic too, of calls racing to move a room off a node exactly one
+	                moves it, and a room Set meanwhile is not moved
+	expiry          a room set with an expiry is gone once it passed, from Get and List alike,
+	                0 never expires. Setting a room again starts its expiry over
+	Get             of a room that is not there is ErrRoomNotFound
+	List            pages through the rooms a cursor at a time, from cursor 0 until the next one
+	                is 0. A room there all along is listed, maybe more than once like
+	                with a redis SCAN, a room set or deleted while paging may or may not be
+
+Nothing else is read and written atomically: like with redis alone the last Set wins. SetRoomStore has to be called before SetupManager, and every node of a deployment needs
+the same store.
+*/
+
+var ErrRoomNotFound = errors.New("room not found")
+
+// RoomStore keeps the data of every room of the cluster, see the top of the file
+type RoomStore interface {
+	Get(roomID string) (*pb.RoomData, error)
+	// Set saves data under its id, expiring after expiry, 0 keeps it until it is deleted
+	Set(data *pb.RoomData, expiry time.Duration) error
+	// CreateIfAbsent sets data unless its room exists, created says if it did
+	CreateIfAbsent(data *pb.RoomData, expiry time.Duration) (created bool, err error)
+	// MoveNode sets the room's NodeID to to if it still is from, keeping its expiry, moved says if it did
+	MoveNode(roomID string, from string, to string) (moved bool, err error)
+	Delete(roomID string) error
+	// List returns a page of about count rooms from cursor, and the cursor of the next page, 0 after the last
+	List(cursor uint64, count int64) (rooms []*pb.RoomData, next uint64, err error)
+}
+
+// SetRoomStore keeps rooms in store, nil is redis, see room_store.go. Like SetKeyPrefix it is read
+// without the manager's lock
+func (m *Manager) SetRoomStore(store RoomStore) {
+	m.roomStore = store
+}
+
+// RoomStore is where this manager keeps rooms
+func (m *Manager) RoomStore() RoomStore {
+	if m.roomStore == nil {
+		return NewRedisRoomStore(m.redis, m.Keys())
+	}
+	return m.roomStore
+}
+
+// redisRoomStore keeps each room as a NoirObject under its RoomData key
+type redisRoomStore struct {
+	client *redis.Client
+	keys   pb.Keys
+}
+
+// NewRedisRoomStore keeps rooms in redis, under keys
+func NewRedisRoomStore(client *redis.Client, keys pb.Keys) RoomStore {
+	return &redisRoomStore{client: client, keys: keys}
+}
+
+func encodeRoom(data *pb.RoomData) ([]byte, error) {
+	return proto.Marshal(&pb.NoirObject{
+		Data: &pb.NoirObject_Room{
+			Room: data,
+		},
+	})
+}
+
+func decodeRoom(key string, encoded []byte) (*pb.RoomData, error) {
+	var load pb.NoirObject
+	if err := proto.Unmarshal(encoded, &load); err != nil {
+		return nil, err
+	}
+	if load.GetRoom() == nil {
+		return nil, fmt.Errorf("%s is not a room", key)
+	}
+	return load.GetRoom(), nil
+}
+
+func (s *redisRoomStore) Get(roomID string) (*pb.RoomData, error) {
+	key := s.keys.RoomData(roomID)
+	encoded, err := s.client.Get(key).Bytes()
+	if err == redis.Nil {
+		return nil, fmt.Errorf("%w: %s", ErrRoomNotFound, roomID)
+	} else if err != nil {
+		return nil, err
+	}
+	return decodeRoom(key, encoded)
+}
+
+func (s *redisRoomStore) Set(data *pb.RoomData, expiry time.Duration) error {
+	encoded, err := encodeRoom(data)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(s.keys.RoomData(data.Id), encoded, expiry).Err()
+}
+
+func (s *redisRoomStore) CreateIfAbsent(data *pb.RoomData, expiry time.Duration) (bool, error) {
+	encoded, err := encodeRoom(data)
+	if err != nil {
+		return false, err
+	}
+	return s.client.SetNX(s.keys.RoomData(data.Id), encoded, expiry).Result()
+}
+
+// MoveNode rewrites the room in a MULTI, so a write of the room meanwhile voids the move
+func (s *redisRoomStore) MoveNode(roomID string, from string, to string) (bool, error) {
+	key := s.keys.RoomData(roomID)
+	moved := false
+	err := s.client.Watch(func(tx *redis.Tx) error {
+		encoded, err := tx.Get(key).Bytes()
+		if err == redis.Nil {
+			return fmt.Errorf("%w: %s", ErrRoomNotFound, roomID)
+		} else if err != nil {
+			return err
+		}
+		room, err := decodeRoom(key, encoded)
+		if err != nil || room.NodeID != from {
+			return err
+		}
+		expiry, err := tx.PTTL(key).Result()
+		if err != nil {
+			return err
+		} else if expiry < 0 {
+			expiry = 0
+		}
+		room.NodeID = to
+		if encoded, err = encodeRoom(room); err != nil {
+			return err
+		}
+		_, err = tx.TxPipelined(func(pipe redis.Pipeliner) error {
+			pipe.Set(key, encoded, expiry)
+			return nil
+		})
+		moved = err == nil
+		return err
+	}, key)
+	if err == redis.TxFailedErr {
+		return false, nil
+	}
+	return moved, err
+}
+
+func (s *redisRoomStore) Delete(roomID string) error {
+	return s.client.Del(s.keys.RoomData(roomID)).Err()
+}
+
+func (s *redisRoomStore) List(cursor uint64, count int64) ([]*pb.RoomData, uint64, error) {
+	keys, next, err := s.client.Scan(cursor, s.keys.RoomDataPrefix()+"*", count).Result()
+	if err != nil || len(keys) == 0 {
+		return []*pb.RoomData{}, next, err
+	}
+	values, err := s.client.MGet(keys...).Result()
+	if err != nil {
+		return []*pb.RoomData{}, next, err
+	}
+	rooms := make([]*pb.RoomData, 0, len(keys))
+	for i, value := range values {
+		// expired between the SCAN and the MGET
+		encoded, ok := value.(string)
+		if !ok {
+			continue
+		}
+		room, err := decodeRoom(keys[i], []byte(encoded))
+		if err != nil {
+			log.Warnf("skipping unreadable room %s", keys[i])
+			continue
+		}
+		rooms = append(rooms, room)
+	}
+	return rooms, next, nil
+}
+
+// memoryRoomStore keeps copies of the rooms, each until its deadline
+type memoryRoomStore struct {
+	mu        sync.Mutex
+	rooms     map[string]*pb.RoomData
+	deadlines map[string]time.Time
+}
+
+// NewMemoryRoomStore keeps rooms in this process, they are not shared with other nodes
+func NewMemoryRoomStore() RoomStore {
+	return &memoryRoomStore{rooms: map[string]*pb.RoomData{}, deadlines: map[string]time.Time{}}
+}
+
+// live is the room stored as roomID, forgetting it when it expired, called under s.mu
+func (s *memoryRoomStore) live(roomID string) (*pb.RoomData, bool) {
+	room, ok := s.rooms[roomID]
+	if !ok {
+		return nil, false
+	}
+	if deadline, expires := s.deadlines[roomID]; expires && !time.Now().Before(deadline) {
+		delete(s.rooms, roomID)
+		delete(s.deadlines, roomID)
+		return nil, false
+	}
+	return room, true
+}
+
+// set is Set, called under s.mu
+func (s *memoryRoomStore) set(data *pb.RoomData, expiry time.Duration) {
+	s.rooms[data.Id] = proto.Clone(data).(*pb.RoomData)
+	if expiry > 0 {
+		s.deadlines[data.Id] = time.Now().Add(expiry)
+	} else {
+		delete(s.deadlines, data.Id)
+	}
+}
+
+func (s *memoryRoomStore) Get(roomID string) (*pb.RoomData, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	room, ok := s.live(roomID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrRoomNotFound, roomID)
+	}
+	return proto.Clone(room).(*pb.RoomData), nil
+}
+
+func (s *memoryRoomStore) Set(data *pb.RoomData, expiry time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set(data, expiry)
+	return nil
+}
+
+func (s *memoryRoomStore) CreateIfAbsent(data *pb.RoomData, expiry time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.live(data.Id); ok {
+		return false, nil
+	}
+	s.set(data, expiry)
+	return true, nil
+}
+
+func (s *memoryRoomStore) MoveNode(roomID string, from string, to string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	room, ok := s.live(roomID)
+	if !ok {
+		return false, fmt.Errorf("%w: %s", ErrRoomNotFound, roomID)
+	}
+	if room.NodeID != from {
+		return false, nil
+	}
+	room.NodeID = to
+	return true, nil
+}
+
+func (s *memoryRoomStore) Delete(roomID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.rooms, roomID)
+	delete(s.deadlines, roomID)
+	return nil
+}
+
+// List lists every room in one page, in the order of their ids
+func (s *memoryRoomStore) List(cursor uint64, count int64) ([]*pb.RoomData, uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, 0, len(s.rooms))
+	for roomID := range s.rooms {
+		if _, ok := s.live(roomID); ok {
+			ids = append(ids, roomID)
+		}
+	}
+	sort.Strings(ids)
+	rooms := make([]*pb.RoomData, 0, len(ids))
+	for _, roomID := range ids {
+		rooms = append(rooms, proto.Clone(s.rooms[roomID]).(*pb.RoomData))
+	}
+	return rooms, 0, nil
+}
@@ -0,0 +1,171 @@
+package noir
+
+import (
+	"errors"
+	"fmt"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func testRoomStore(t *testing.T, store RoomStore) {
+	store.Delete("stored-room")
+	defer store.Delete("stored-room")
+
+	if _, err := store.Get("stored-room"); !errors.Is(err, ErrRoomNotFound) {
+		t.Fatalf("got %v want a missing room not found", err)
+	}
+	// of the creates racing exactly one wins
+	wins := make(chan bool, 10)
+	var racing sync.WaitGroup
+	for i := 0; i < cap(wins); i++ {
+		racing.Add(1)
+		go func() {
+			defer racing.Done()
+			created, err := store.CreateIfAbsent(&pb.RoomData{Id: "stored-room", NodeID: "racer"}, 0)
+			if err != nil {
+				t.Errorf("error creating: %s", err)
+			}
+			wins <- created
+		}()
+	}
+	racing.Wait()
+	close(wins)
+	created := 0
+	for won := range wins {
+		if won {
+			created++
+		}
+	}
+	if created != 1 {
+		t.Errorf("got %d rooms created want 1", created)
+	}
+
+	if err := store.Set(&pb.RoomData{Id: "stored-room", NodeID: "renamed"}, time.Minute); err != nil {
+		t.Fatalf("error setting: %s", err)
+	}
+	if room, err := store.Get("stored-room"); err != nil || room.NodeID != "renamed" {
+		t.Errorf("got %v %v want the room as set", room, err)
+	}
+	listed := false
+	for cursor := uint64(0); ; {
+		rooms, next, err := store.List(cursor, 10)
+		if err != nil {
+			t.Fatalf("error listing: %s", err)
+		}
+		for _, room := range rooms {
+			listed = listed || room.Id == "stored-room"
+		}
+		if cursor = next; cursor == 0 {
+			break
+		}
+	}
+	if !listed {
+		t.Errorf("the room should be listed")
+	}
+
+	// of the moves racing off the node exactly one moves the room
+	moves := make(chan bool, 10)
+	for i := 0; i < cap(moves); i++ {
+		racing.Add(1)
+		go func(i int) {
+			defer racing.Done()
+			moved, err := store.MoveNode("stored-room", "renamed", fmt.Sprintf("mover-%d", i))
+			if err != nil {
+				t.Errorf("error moving: %s", err)
+			}
+			moves <- moved
+		}(i)
+	}
+	racing.Wait()
+	close(moves)
+	moved := 0
+	for won := range moves {
+		if won {
+			moved++
+		}
+	}
+	room, _ := store.Get("stored-room")
+	if moved != 1 || !strings.HasPrefix(room.GetNodeID(), "mover-") {
+		t.Errorf("got %d moves to %s want the room moved once", moved, room.GetNodeID())
+	}
+	if _, err := store.MoveNode("stored-missing", "renamed", "mover"); !errors.Is(err, ErrRoomNotFound) {
+		t.Errorf("got %v want a missing room not found", err)
+	}
+
+	if err := store.Delete("stored-room"); err != nil {
+		t.Fatalf("error deleting: %s", err)
+	}
+	if _, err := store.Get("stored-room"); !errors.Is(err, ErrRoomNotFound) {
+		t.Errorf("got %v want a deleted room not found", err)
+	}
+}
+
+func TestRedisRoomStore(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	testRoomStore(t, mgr.RoomStore())
+
+	mgr.RoomStore().Set(&pb.RoomData{Id: "stored-expiring"}, time.Minute)
+	defer redis.Del(pb.KeyRoomData("stored-expiring"))
+	if ttl := redis.TTL(pb.KeyRoomData("stored-expiring")).Val(); ttl <= 0 || ttl > time.Minute {
+		t.Errorf("got ttl %s want the room's expiry", ttl)
+	}
+	mgr.RoomStore().MoveNode("stored-expiring", "", "moved")
+	if ttl := redis.TTL(pb.KeyRoomData("stored-expiring")).Val(); ttl <= 0 || ttl > time.Minute {
+		t.Errorf("got ttl %s want the room's expiry kept by a move", ttl)
+	}
+}
+
+func TestMemoryRoomStore(t *testing.T) {
+	store := NewMemoryRoomStore()
+	testRoomStore(t, store)
+
+	store.Set(&pb.RoomData{Id: "stored-expiring"}, 50*time.Millisecond)
+	if created, _ := store.CreateIfAbsent(&pb.RoomData{Id: "stored-expiring"}, 0); created {
+		t.Errorf("a room should not be created over one not expired yet")
+	}
+	time.Sleep(100 * time.Millisecond)
+	if _, err := store.Get("stored-expiring"); !errors.Is(err, ErrRoomNotFound) {
+		t.Errorf("got %v want an expired room gone", err)
+	}
+	if rooms, _, _ := store.List(0, 10); len(rooms) != 0 {
+		t.Errorf("got %v want an expired room left out of the list", rooms)
+	}
+
+	// the store keeps its own copy
+	room := &pb.RoomData{Id: "stored-copy", NodeID: "kept"}
+	store.Set(room, 0)
+	room.NodeID = "changed"
+	if stored, _ := store.Get("stored-copy"); stored.NodeID != "kept" {
+		t.Errorf("got %s want the room as it was set", stored.NodeID)
+	}
+}
+
+func TestManagerRoomStore(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetRoomStore(NewMemoryRoomStore())
+	redis.Del(pb.KeyRoomData("stored-open"), pb.KeyRoomUsers("stored-open"))
+	defer redis.Del(pb.KeyRoomUsers("stored-open"))
+
+	if _, created, err := mgr.OpenRoom("stored-open", &pb.CreateRoomRequest{Options: &pb.RoomOptions{MaxPeers: 3}}); err != nil || !created {
+		t.Fatalf("error opening: %v", err)
+	}
+	if redis.Exists(pb.KeyRoomData("stored-open")).Val() != 0 {
+		t.Errorf("a room opened on another store should not be saved in redis")
+	}
+	if room, err := mgr.GetRoomData("stored-open"); err != nil || room.GetOptions().GetMaxPeers() != 3 {
+		t.Errorf("got %v %v want the room from the store", room, err)
+	}
+	if rooms, _ := mgr.ListRooms(); len(rooms) != 1 || rooms[0].Id != "stored-open" {
+		t.Errorf("got %v want the store's rooms listed", rooms)
+	}
+	if err := mgr.CloseRoom("stored-open"); err != nil {
+		t.Fatalf("error closing: %s", err)
+	}
+	defer redis.Del(pb.KeyRoomClosed("stored-open"))
+	if exists, _ := mgr.GetRemoteRoomExists("stored-open"); exists {
+		t.Errorf("a closed room should be deleted from the store")
+	}
+}
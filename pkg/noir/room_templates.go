@@ -0,0 +1,71 @@
+package noir
+
+import (
+	"errors"
+	"fmt"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+/*
+Room Templates:
+Deployments tend to have a few kinds of rooms, eg. a webinar, a 1:1 call and a huddle, each
+opened with the same options every time. RegisterRoomTemplate names those options, and a
+CreateRoomRequest whose template names them opens its room with them. The options of the
+request are laid over the template's, field by field: whatever the request sets replaces the
+template's, codecs as a whole list, and policies like icePolicy and rtcpPolicy setting by
+setting. As with any proto3 field, a request cannot set one back to zero or false, so a template
+that is audioOnly makes audio only rooms. Templates live on each manager, every node that
+handles room admin has to register the same ones.
+*/
+
+var ErrUnknownRoomTemplate = errors.New("unknown room template")
+
+// RegisterRoomTemplate has rooms created from the template name get options, replacing any
+// registered as name before. The options are checked as a room's would be
+func (m *Manager) RegisterRoomTemplate(name string, options *pb.RoomOptions) error {
+	if name == "" {
+		return errors.New("room templates need a name")
+	}
+	if err := ValidateRoomCodecs(options); err != nil {
+		return err
+	}
+	if err := ValidateRTCPPolicy(options.GetRtcpPolicy()); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.roomTemplates[name] = proto.Clone(options).(*pb.RoomOptions)
+	return nil
+}
+
+// RoomTemplate is a copy of the options registered as name, false if none are
+func (m *Manager) RoomTemplate(name string) (*pb.RoomOptions, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	options, ok := m.roomTemplates[name]
+	if !ok {
+		return nil, false
+	}
+	return proto.Clone(options).(*pb.RoomOptions), true
+}
+
+// templatedOptions are the options create opens its room with, its own over its template's
+func (m *Manager) templatedOptions(create *pb.CreateRoomRequest) (*pb.RoomOptions, error) {
+	name := create.GetTemplate()
+	if name == "" {
+		return create.GetOptions(), nil
+	}
+	options, ok := m.RoomTemplate(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownRoomTemplate, name)
+	}
+	if overrides := create.GetOptions(); overrides != nil {
+		proto.Merge(options, overrides)
+		// merging appends lists, the request's codecs are meant to replace the template's
+		if len(overrides.GetCodecs()) > 0 {
+			options.Codecs = overrides.GetCodecs()
+		}
+	}
+	return options, nil
+}
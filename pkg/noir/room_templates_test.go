@@ -0,0 +1,90 @@
+package noir
+
+import (
+	"errors"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"testing"
+)
+
+func TestRegisterRoomTemplate(t *testing.T) {
+	mgr, _ := NewTestSetup()
+	if err := mgr.RegisterRoomTemplate("broken", &pb.RoomOptions{Codecs: []string{"vp8"}}); !errors.Is(err, ErrInvalidRoomCodecs) {
+		t.Errorf("got %v want a template with bad codecs refused", err)
+	}
+	if err := mgr.RegisterRoomTemplate("", &pb.RoomOptions{}); err == nil {
+		t.Errorf("a template without a name should be refused")
+	}
+
+	webinar := &pb.RoomOptions{MaxPeers: 100}
+	if err := mgr.RegisterRoomTemplate("webinar", webinar); err != nil {
+		t.Fatalf("error registering: %s", err)
+	}
+	// the manager keeps its own copy
+	webinar.MaxPeers = 5
+	if options, ok := mgr.RoomTemplate("webinar"); !ok || options.MaxPeers != 100 {
+		t.Errorf("got %s want the options as registered", options)
+	}
+	if _, ok := mgr.RoomTemplate("huddle"); ok {
+		t.Errorf("an unregistered template should not be found")
+	}
+}
+
+func TestOpenRoomFromTemplate(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	rooms := []string{"templated-webinar", "templated-override", "templated-missing"}
+	for _, roomID := range rooms {
+		redis.Del(pb.KeyRoomData(roomID))
+		defer redis.Del(pb.KeyRoomData(roomID))
+	}
+	mgr.RegisterRoomTemplate("webinar", &pb.RoomOptions{
+		MaxPeers:      100,
+		AudioOnly:     true,
+		Codecs:        []string{"audio/opus"},
+		RtcpPolicy:    &pb.RTCPPolicy{DisableNack: true, MinPliIntervalMs: 1000},
+		MaxAgeSeconds: -1,
+	})
+
+	room, created, err := mgr.OpenRoom("templated-webinar", &pb.CreateRoomRequest{Template: "webinar"})
+	if err != nil || !created {
+		t.Fatalf("error opening from a template: %v", err)
+	}
+	if options := room.GetOptions(); options.MaxPeers != 100 || !options.AudioOnly || len(options.Codecs) != 1 || !options.GetRtcpPolicy().GetDisableNack() {
+		t.Errorf("got %s want the template's options", options)
+	}
+
+	room, _, err = mgr.OpenRoom("templated-override", &pb.CreateRoomRequest{
+		Template: "webinar",
+		Options: &pb.RoomOptions{
+			MaxPeers:   2,
+			Title:      "1:1",
+			Codecs:     []string{"audio/opus", "video/VP8"},
+			RtcpPolicy: &pb.RTCPPolicy{MinPliIntervalMs: 2000},
+		},
+	})
+	if err != nil {
+		t.Fatalf("error opening from a template: %s", err)
+	}
+	options := room.GetOptions()
+	if options.MaxPeers != 2 || options.Title != "1:1" || !options.AudioOnly || options.MaxAgeSeconds != -1 {
+		t.Errorf("got %s want the request's options over the template's", options)
+	}
+	if len(options.Codecs) != 2 {
+		t.Errorf("got %v want the request's codecs to replace the template's", options.Codecs)
+	}
+	if policy := options.GetRtcpPolicy(); policy.GetMinPliIntervalMs() != 2000 || !policy.GetDisableNack() {
+		t.Errorf("got %s want the policy merged setting by setting", policy)
+	}
+	if stored, _ := mgr.GetRoomData("templated-override"); stored.GetOptions().GetMaxPeers() != 2 {
+		t.Errorf("got %s want the merged options saved", stored.GetOptions())
+	}
+	if template, _ := mgr.RoomTemplate("webinar"); template.MaxPeers != 100 || len(template.Codecs) != 1 {
+		t.Errorf("got %s want the template left as registered", template)
+	}
+
+	if _, _, err := mgr.OpenRoom("templated-missing", &pb.CreateRoomRequest{Template: "huddle"}); !errors.Is(err, ErrUnknownRoomTemplate) {
+		t.Errorf("got %v want an unknown template refused", err)
+	}
+	if _, err := mgr.GetRoomData("templated-missing"); err == nil {
+		t.Errorf("a room should not be created from an unknown template")
+	}
+}
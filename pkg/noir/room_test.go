@@ -1,8 +1,15 @@
 package noir
 
 import (
+	"errors"
 	pb "github.com/net-prophet/noir/pkg/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestOpenRoom(t *testing.T) {
@@ -34,3 +41,319 @@ func TestOpenRoom(t *testing.T) {
 	EnqueueRequest(*queue, request)
 	worker.HandleNext(0)
 }
+
+func TestOpenRoomIdempotent(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	roomID := "test open room"
+	redis.Del(pb.KeyRoomData(roomID), pb.KeyRoomClosed(roomID))
+	defer redis.Del(pb.KeyRoomData(roomID))
+
+	var wg sync.WaitGroup
+	var created int32
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(owner string) {
+			defer wg.Done()
+			_, fresh, err := mgr.OpenRoom(roomID, &pb.CreateRoomRequest{
+				Owner:   owner,
+				Options: &pb.RoomOptions{MaxPeers: 2, MaxAgeSeconds: -1, JoinPassword: "open room"},
+			})
+			if err != nil {
+				t.Errorf("error opening room %s", err)
+			}
+			if fresh {
+				atomic.AddInt32(&created, 1)
+			}
+		}("owner-" + strconv.Itoa(i))
+	}
+	wg.Wait()
+	if created != 1 {
+		t.Fatalf("got %d rooms created want 1", created)
+	}
+
+	room, _ := mgr.GetRoomData(roomID)
+	owner := room.Owner
+	opened, fresh, err := mgr.OpenRoom(roomID, &pb.CreateRoomRequest{Owner: "intruder", Options: &pb.RoomOptions{MaxPeers: 9}})
+	if err != nil || fresh {
+		t.Fatalf("got created=%t err=%v want the existing room", fresh, err)
+	}
+	if opened.Owner != owner || opened.Options.MaxPeers != 2 {
+		t.Errorf("got %s want a room opened by someone else unchanged", opened)
+	}
+
+	if _, _, err := mgr.OpenRoom(roomID, &pb.CreateRoomRequest{Owner: owner, Options: &pb.RoomOptions{MaxPeers: 9}}); err != nil {
+		t.Fatalf("error reopening room %s", err)
+	}
+	room, _ = mgr.GetRoomData(roomID)
+	if room.Options.MaxPeers != 9 {
+		t.Errorf("got %d max peers want the owner's update", room.Options.MaxPeers)
+	}
+	if room.Options.GetJoinPasswordHash() == "" || !CheckRoomPassword(room.Options, "open room") {
+		t.Errorf("an owner's update without a password should keep the room's")
+	}
+}
+
+func TestCloseRoom(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	roomID := "test close room"
+	redis.Del(pb.KeyRoomClosed(roomID), pb.KeyRoomUsers(roomID), pb.KeyTopicToPeer("close-peer"))
+
+	if err := mgr.CloseRoom(roomID); err == nil {
+		t.Errorf("closing a missing room should fail")
+	}
+
+	room := NewRoom(roomID)
+	SaveRoomData(roomID, &room.data, mgr)
+	redis.HSet(pb.KeyRoomUsers(roomID), "close-peer", 1)
+
+	evicted, err := mgr.EvictRoom(roomID)
+	if err != nil {
+		t.Errorf("error closing room %s", err)
+	}
+	if evicted != 1 {
+		t.Errorf("got %d evicted want 1", evicted)
+	}
+	if exists, _ := mgr.GetRemoteRoomExists(roomID); exists {
+		t.Errorf("room data should be deleted after close")
+	}
+	if !mgr.IsRoomClosed(roomID) {
+		t.Errorf("room should be marked closed")
+	}
+
+	kill := pb.NoirRequest{}
+	msg, _ := mgr.GetQueue(pb.KeyTopicToPeer("close-peer")).Next()
+	UnmarshalRequest(msg, &kill)
+	if kill.GetSignal().GetKill() != true {
+		t.Errorf("got %s want kill", &kill)
+	}
+
+	mgr.ReopenRoom(roomID)
+	if mgr.IsRoomClosed(roomID) {
+		t.Errorf("room should be open again")
+	}
+}
+
+func TestRoomCapacity(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	roomID := "test capacity room"
+	maxPeers := 5
+	redis.Del(pb.KeyRoomUsers(roomID))
+	SaveRoomData(roomID, &pb.RoomData{}, mgr)
+	defer redis.Del(pb.KeyRoomData(roomID))
+
+	var wg sync.WaitGroup
+	var rejected int32
+	joined := make(chan string, maxPeers+1)
+	for i := 0; i <= maxPeers; i++ {
+		wg.Add(1)
+		go func(pid string) {
+			defer wg.Done()
+			reserved, err := mgr.ReservePeerSlot(roomID, pid, int32(maxPeers))
+			if err != nil {
+				t.Errorf("error reserving slot %s", err)
+			}
+			if !reserved {
+				atomic.AddInt32(&rejected, 1)
+			} else {
+				joined <- pid
+			}
+		}("peer-" + strconv.Itoa(i))
+	}
+	wg.Wait()
+
+	if rejected != 1 {
+		t.Errorf("got %d rejected joins want 1", rejected)
+	}
+
+	// Leaving frees the slot, any peer who got one may be the one leaving
+	mgr.ReleasePeerSlot(roomID, <-joined)
+	if reserved, _ := mgr.ReservePeerSlot(roomID, "late-peer", int32(maxPeers)); !reserved {
+		t.Errorf("slot should be free after a peer leaves")
+	}
+	redis.Del(pb.KeyRoomUsers(roomID))
+}
+
+func TestListRooms(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	for _, key := range redis.Keys(pb.KeyRoomDataPrefix() + "*").Val() {
+		redis.Del(key)
+	}
+
+	for i := 0; i < 2*RoomScanCount+5; i++ {
+		if err := mgr.SetRoomData(&pb.RoomData{Id: "list-" + strconv.Itoa(i)}); err != nil {
+			t.Fatalf("error saving room: %s", err)
+		}
+	}
+	mgr.SetRoomData(&pb.RoomData{
+		Id:       "list-owned",
+		Owner:    "alice",
+		Metadata: map[string]string{"topic": "standup"},
+		Options:  &pb.RoomOptions{MaxPeers: 4, MaxAgeSeconds: -1},
+	})
+	// Created a long time ago with a 1s max age, so it is past cleanup
+	mgr.SetRoomData(&pb.RoomData{
+		Id:      "list-stale",
+		Created: timestamppb.New(time.Now().Add(-time.Hour)),
+		Options: &pb.RoomOptions{MaxAgeSeconds: 1, KeyExpiryFactor: 1},
+	})
+
+	rooms, err := mgr.ListRooms()
+	if err != nil {
+		t.Fatalf("error listing rooms: %s", err)
+	}
+	seen := map[string]bool{}
+	for _, room := range rooms {
+		seen[room.Id] = true
+	}
+	if len(seen) != 2*RoomScanCount+6 {
+		t.Errorf("got %d rooms want %d", len(seen), 2*RoomScanCount+6)
+	}
+	if seen["list-stale"] {
+		t.Errorf("stale room should not be listed")
+	}
+	if exists, _ := mgr.GetRemoteRoomExists("list-stale"); exists {
+		t.Errorf("stale room should be removed")
+	}
+
+	owned, err := mgr.GetRoomData("list-owned")
+	if err != nil {
+		t.Fatalf("error getting room: %s", err)
+	}
+	if owned.Owner != "alice" || owned.Metadata["topic"] != "standup" || owned.Options.MaxPeers != 4 {
+		t.Errorf("got %s want owner, metadata and max peers back", owned)
+	}
+	if owned.Created == nil {
+		t.Errorf("SetRoomData should fill in created")
+	}
+}
+
+func TestRecording(t *testing.T) {
+	mgr, _ := NewTestSetup()
+	mgr.SetRoomData(&pb.RoomData{Id: "recorded"})
+	routerQueue := *(*mgr.GetRouter()).GetQueue()
+	routerQueue.Cleanup()
+
+	recordingID, err := mgr.StartRecording("recorded", "/tmp/noir-test")
+	if err != nil {
+		t.Fatalf("error starting recording: %s", err)
+	}
+
+	room, _ := mgr.GetRoomData("recorded")
+	recording := room.Recordings[recordingID]
+	if recording == nil || recording.OutputDir != "/tmp/noir-test" || recording.Stopped != nil {
+		t.Errorf("got %s want an active recording in the room data", room)
+	}
+
+	msg, _ := routerQueue.Next()
+	request := &pb.NoirRequest{}
+	UnmarshalRequest(msg, request)
+	job := request.GetAdmin().GetRoomAdmin().GetRoomJob()
+	if job.GetHandler() != RecordJobHandler || job.GetPid() != RecordingPeerID(recordingID) {
+		t.Errorf("got %s want a %s job", request, RecordJobHandler)
+	}
+
+	stopped, err := mgr.StopRecording("recorded", "")
+	if err != nil || len(stopped) != 1 || stopped[0] != recordingID {
+		t.Errorf("got %v %v want [%s] stopped", stopped, err, recordingID)
+	}
+	room, _ = mgr.GetRoomData("recorded")
+	if room.Recordings[recordingID].Stopped == nil {
+		t.Errorf("stopped recording should have a stopped time")
+	}
+	if _, err := mgr.StopRecording("recorded", recordingID); err == nil {
+		t.Errorf("stopping a stopped recording should fail")
+	}
+	if _, err := mgr.StartRecording("not-a-room", ""); err == nil {
+		t.Errorf("recording a missing room should fail")
+	}
+}
+
+func TestRecordingOutputDir(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetRecordingDir("/srv/recordings")
+	defer mgr.SetRecordingDir("")
+	for _, tt := range []struct {
+		outputDir string
+		admin     bool
+		want      string
+	}{
+		{"", false, "/srv/recordings"},
+		{"team", false, "/srv/recordings/team"},
+		{"team/../standup", false, "/srv/recordings/standup"},
+		{"/etc", false, "/srv/recordings/etc"},
+		{"../elsewhere", false, ""},
+		{"team/../../..", false, ""},
+		{"/tmp/noir", true, "/tmp/noir"},
+	} {
+		dir, err := mgr.RecordingOutputDir(tt.outputDir, tt.admin)
+		if tt.want == "" && !errors.Is(err, ErrRecordingPath) {
+			t.Errorf("got %s %v want %q refused", dir, err, tt.outputDir)
+		} else if tt.want != "" && dir != filepath.FromSlash(tt.want) {
+			t.Errorf("got %s %v want %q to go to %s", dir, err, tt.outputDir, tt.want)
+		}
+	}
+	for id, ok := range map[string]bool{"abc123": true, "..": false, "a/b": false, `a\b`: false} {
+		if err := CheckRecordingID(id); (err == nil) != ok {
+			t.Errorf("got %v for recording id %q", err, id)
+		}
+	}
+
+	// a moderator's startrecording cannot leave the recording dir
+	keys := []string{pb.KeyRoomData("recorded-mod"), pb.KeyRoomUsers("recorded-mod"), pb.KeyUserData("recorded-owner")}
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+	mgr.SetRoomData(&pb.RoomData{Id: "recorded-mod", Owner: "recorded-owner"})
+	joinOwnerTestPeer(mgr, "recorded-mod", "recorded-owner", time.Now())
+	(*(*mgr.GetRouter()).GetQueue()).Cleanup()
+	worker := (*mgr.GetWorker()).(*worker)
+	record := func(outputDir string) error {
+		return worker.HandleRoomAdmin(&pb.NoirRequest{
+			Command: &pb.NoirRequest_Admin{
+				Admin: &pb.AdminRequest{
+					Payload: &pb.AdminRequest_RoomAdmin{
+						RoomAdmin: &pb.RoomAdminRequest{
+							RoomID:   "recorded-mod",
+							CallerID: "recorded-owner",
+							Method:   &pb.RoomAdminRequest_StartRecording{StartRecording: &pb.StartRecordingRequest{OutputDir: outputDir}},
+						},
+					},
+				},
+			},
+		})
+	}
+	if err := record("../../etc"); !errors.Is(err, ErrRecordingPath) {
+		t.Errorf("got %v want a moderator's dir outside the recording dir refused", err)
+	}
+	if err := record("team"); err != nil {
+		t.Fatalf("error starting recording: %s", err)
+	}
+	room, _ := mgr.GetRoomData("recorded-mod")
+	if len(room.Recordings) != 1 {
+		t.Fatalf("got %s want one recording", room)
+	}
+	for _, recording := range room.Recordings {
+		if recording.OutputDir != filepath.FromSlash("/srv/recordings/team") {
+			t.Errorf("got %s want the recording under the recording dir", recording.OutputDir)
+		}
+	}
+	(*(*mgr.GetRouter()).GetQueue()).Cleanup()
+}
+
+func TestPruneRoomUsers(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	keys := []string{pb.KeyRoomUsers("pruned"), pb.KeyUserData("pruned-saved"), pb.KeyPeerOwner("pruned-owned")}
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+	for _, pid := range []string{"pruned-saved", "pruned-owned", "pruned-stale"} {
+		redis.HSet(pb.KeyRoomUsers("pruned"), pid, 1)
+	}
+	redis.Set(pb.KeyUserData("pruned-saved"), "saved", 0)
+	redis.Set(pb.KeyPeerOwner("pruned-owned"), "other-worker", 0)
+
+	if pruned, err := mgr.pruneRoomUsers("pruned"); err != nil || pruned != 1 {
+		t.Errorf("got %d pruned %v want only the stale peer", pruned, err)
+	}
+	if pids := redis.HKeys(pb.KeyRoomUsers("pruned")).Val(); len(pids) != 2 || redis.HExists(pb.KeyRoomUsers("pruned"), "pruned-stale").Val() {
+		t.Errorf("got %v want the peers saved or owned kept", pids)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/go-redis/redis"
 	pb "github.com/net-prophet/noir/pkg/proto"
 	log "github.com/pion/ion-log"
+	"time"
 )
 
 type Router interface {
@@ -16,22 +17,27 @@ type Router interface {
 }
 
 type router struct {
-	queue Queue
-	mgr   *Manager
+	queue  Queue
+	mgr    *Manager
+	health queueHealth
 }
 
 func NewRedisRouter(client *redis.Client, mgr *Manager) Router {
-	queue := NewRedisQueue(client, pb.KeyRouterTopic(), RouterMaxAge)
-	return &router{queue, mgr}
+	queue := NewRedisQueue(client, mgr.Keys().RouterTopic(), mgr.RouterMaxAge())
+	return &router{queue: queue, mgr: mgr}
 }
 
 func NewRouter(queue Queue, mgr *Manager) Router {
-	return &router{queue, mgr}
+	return &router{queue: queue, mgr: mgr}
 }
 func (r *router) HandleForever() {
 	log.Debugf("router starting on topic %s", r.queue.Topic())
+	TrackQueueDepth(r.queue)
 	for {
-		if err := r.HandleNext(); err != nil {
+		err := r.HandleNext()
+		if backoff := r.health.backoff(r.queue.Topic()); backoff > 0 {
+			time.Sleep(backoff)
+		} else if err != nil {
 			log.Errorf("error routing command: %s", err)
 		}
 	}
@@ -42,11 +48,14 @@ func (r *router) HandleNext() error {
 	if err != nil {
 		return err
 	}
+	// once routed, or given up on, the request is not delivered again, see AckQueue
+	defer Ack(r.queue)
 	return r.Handle(request)
 }
 
 func (r *router) NextCommand() (*pb.NoirRequest, error) {
 	msg, popErr := r.queue.BlockUntilNext(0)
+	r.health.read(popErr)
 	if popErr != nil {
 		log.Errorf("queue error %s", popErr)
 		return nil, popErr
@@ -56,6 +65,7 @@ func (r *router) NextCommand() (*pb.NoirRequest, error) {
 	p_err := UnmarshalRequest(msg, &request)
 	if p_err != nil {
 		log.Errorf("message parse error: %s", p_err)
+		Ack(r.queue)
 		return nil, p_err
 	}
 	return &request, nil
@@ -67,7 +77,20 @@ func (r *router) TargetForSignal(action string, signal *pb.SignalRequest) (strin
 
 	roomExists, _ := r.mgr.GetRemoteRoomExists(roomID)
 
-	if roomExists == false {
+	if signal.GetCapabilities() != nil {
+		// Any worker can answer, the room's own knows whether it is paused, but none claims it
+		if roomExists {
+			if roomData, err := r.mgr.GetRoomData(roomID); err == nil && r.mgr.ValidateHealthyNodeID(roomData.NodeID) == nil {
+				return roomData.NodeID, nil
+			}
+		}
+		return r.mgr.RandomNodeForService("sfu")
+	}
+
+	if roomExists == false && (signal.GetPlay() != nil || signal.GetJoin() != nil && !r.mgr.AllowAutoCreateRooms()) {
+		// Don't claim a room nobody created, any worker can reject the join or play
+		return r.mgr.RandomNodeForService("sfu")
+	} else if roomExists == false {
 		// Assign the first peer queue a Room to a new worker based on capacity
 		log.Infof("no such roomID, routing to random worker")
 		target, err := r.mgr.RandomNodeForService("sfu")
@@ -86,6 +109,9 @@ func (r *router) TargetForSignal(action string, signal *pb.SignalRequest) (strin
 
 
 		if r.mgr.ValidateHealthyNodeID(roomData.NodeID) == nil {
+			if signal.GetJoin() != nil && r.mgr.WorkerDraining(roomData.NodeID) {
+				return r.mgr.handOffRoom(roomData)
+			}
 			log.Debugf("room %s is on healthy node %s", roomData.Id, roomData.NodeID)
 			return roomData.NodeID, nil
 		} else {
@@ -108,9 +134,12 @@ func (r *router) TargetForSignal(action string, signal *pb.SignalRequest) (strin
 	}
 }
 
-func (r *router) Handle(request *pb.NoirRequest) error {
+func (r *router) Handle(request *pb.NoirRequest) (err error) {
 	var routeErr error
 	target := ""
+	r.mgr.traceQueued(request, r.queue.Topic())
+	started := time.Now()
+	defer func() { r.mgr.startSpan(request, "route", started, LogFields{"worker": target}).End(err) }()
 	log.Infof("routing: %s", request.Action)
 	if request.GetSignal() != nil {
 		target, routeErr = r.TargetForSignal(request.Action, request.GetSignal())
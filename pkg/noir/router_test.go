@@ -4,6 +4,7 @@ import (
 	pb "github.com/net-prophet/noir/pkg/proto"
 	log "github.com/pion/ion-log"
 	"testing"
+	"time"
 )
 
 func TestRouterEnqueueRequest(t *testing.T) {
@@ -68,6 +69,86 @@ func TestRouterReadAction(t *testing.T) {
 				},
 			},
 		}, "request.servers.join"},
+		{&pb.NoirRequest{
+			Command: &pb.NoirRequest_Signal{
+				Signal: &pb.SignalRequest{
+					Id:      "123",
+					Payload: &pb.SignalRequest_Leave{Leave: true},
+				},
+			},
+		}, "request.servers.leave"},
+		{&pb.NoirRequest{
+			Command: &pb.NoirRequest_Signal{
+				Signal: &pb.SignalRequest{
+					Id:      "123",
+					Payload: &pb.SignalRequest_Resume{Resume: true},
+				},
+			},
+		}, "request.servers.resume"},
+		{&pb.NoirRequest{
+			Command: &pb.NoirRequest_Signal{
+				Signal: &pb.SignalRequest{
+					Id:      "123",
+					Payload: &pb.SignalRequest_TrickleBatch{TrickleBatch: &pb.TrickleBatch{}},
+				},
+			},
+		}, "request.servers.tricklebatch"},
+		{&pb.NoirRequest{
+			Command: &pb.NoirRequest_Admin{
+				Admin: &pb.AdminRequest{
+					Payload: &pb.AdminRequest_RoomAdmin{
+						RoomAdmin: &pb.RoomAdminRequest{
+							RoomID: "test",
+							Method: &pb.RoomAdminRequest_MutePeer{
+								MutePeer: &pb.MutePeerRequest{Pid: "123", Kind: "audio", Muted: true},
+							},
+						},
+					},
+				},
+			},
+		}, "request.admin.room.mutepeer"},
+		{&pb.NoirRequest{
+			Command: &pb.NoirRequest_Admin{
+				Admin: &pb.AdminRequest{
+					Payload: &pb.AdminRequest_RoomAdmin{
+						RoomAdmin: &pb.RoomAdminRequest{
+							RoomID: "test",
+							Method: &pb.RoomAdminRequest_KickPeer{
+								KickPeer: &pb.KickPeerRequest{Pid: "123"},
+							},
+						},
+					},
+				},
+			},
+		}, "request.admin.room.kickpeer"},
+		{&pb.NoirRequest{
+			Command: &pb.NoirRequest_Admin{
+				Admin: &pb.AdminRequest{
+					Payload: &pb.AdminRequest_RoomAdmin{
+						RoomAdmin: &pb.RoomAdminRequest{
+							RoomID: "test",
+							Method: &pb.RoomAdminRequest_TraceSignaling{
+								TraceSignaling: &pb.TraceSignalingRequest{Enabled: true},
+							},
+						},
+					},
+				},
+			},
+		}, "request.admin.room.tracesignaling"},
+		{&pb.NoirRequest{
+			Command: &pb.NoirRequest_Admin{
+				Admin: &pb.AdminRequest{
+					Payload: &pb.AdminRequest_RoomAdmin{
+						RoomAdmin: &pb.RoomAdminRequest{
+							RoomID: "test",
+							Method: &pb.RoomAdminRequest_SetPassword{
+								SetPassword: &pb.SetPasswordRequest{Password: "secret"},
+							},
+						},
+					},
+				},
+			},
+		}, "request.admin.room.setpassword"},
 	}
 
 	for _, tt := range tests {
@@ -179,3 +260,27 @@ func TestRouterHandleNext(t *testing.T) {
 		t.Errorf("worker got %s queue sent %s", got, want)
 	}
 }
+
+func TestRouterStreamAck(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	queue := RouterStreamQueueFactory(RedisQueueFactory(redis), redis, "stream-router", RouterTopic)(RouterTopic, time.Minute)
+	queue.Cleanup()
+	defer queue.Cleanup()
+	router := NewRouter(queue, mgr)
+
+	EnqueueRequest(queue, &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id:      "stream-peer",
+				Payload: &pb.SignalRequest_Join{Join: &pb.JoinRequest{Sid: "test", Description: []byte{}}},
+			},
+		},
+	})
+	if err := router.HandleNext(); err != nil {
+		t.Fatalf("error routing: %s", err)
+	}
+	(*mgr.GetRemoteWorkerQueue("test-worker")).Next()
+	if length := redis.XLen(RouterTopic).Val(); length != 0 {
+		t.Errorf("got %d entries left want the routed request acked", length)
+	}
+}
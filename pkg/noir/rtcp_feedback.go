@@ -0,0 +1,258 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"github.com/pion/rtcp"
+	"github.com/pion/webrtc/v3"
+	"sync"
+	"time"
+)
+
+/*
+RTCP Feedback:
+ion-sfu v1.6 forwards the keyframes subscribers ask for to the publisher, at most every 500ms per
+track, see rtcp_policy.go to space them out further, and answers their NACKs from its own packet
+buffer, so lost packets are resent without asking the publisher. The REMBs carrying subscribers'
+bandwidth estimates it drops, so a publisher only hears about its own uplink, and keeps sending
+more than its subscribers can take. When the RTCPPolicy of the room a peer joined has an
+aggregation, every REMBInterval the estimates the subscribers of its video tracks sent within
+REMBTimeout are combined into a target for each of its tracks:
+
+	MINIMUM    a track is allowed what its weakest subscriber can take
+	PER_LAYER  a simulcast layer is allowed what the weakest of the subscribers sent it can take,
+	           and its track the sum of its layers, so a subscriber of the low layer does not hold
+	           back the high one. A track without simulcast is allowed its MINIMUM
+
+A REMB estimates all of a subscriber's downlink, at most its subscribeKbps, so each track it covers
+is counted an equal share of it. ion-sfu keeps sending the publisher the REMBs of its own buffers, from what arrived of each
+ssrc, so rather than a competing REMB the target is fed into them: each ssrc of a track is capped
+at the track's target, the lower of the two estimates is what the publisher hears, and a track
+without estimates is left to ion-sfu. What was last capped at is the targetBitrate of the
+publisher's PeerStats, see GetRoomStats, and of each inbound track in its GetStats.
+*/
+
+const (
+	// How often a publisher is sent the estimates of its subscribers
+	REMBInterval = time.Second
+	// How long a subscriber's estimate counts after its REMB
+	REMBTimeout = 5 * time.Second
+)
+
+// aggregateBitrate combines the estimates of a track's subscribers, by the spatial layer each is
+// sent, 0 when there are none
+func aggregateBitrate(aggregation pb.RTCPPolicy_Aggregation, layers [][]uint64) uint64 {
+	var total uint64
+	for _, estimates := range layers {
+		var weakest uint64
+		for _, estimate := range estimates {
+			if weakest == 0 || estimate < weakest {
+				weakest = estimate
+			}
+		}
+		switch {
+		case weakest == 0:
+		case aggregation == pb.RTCPPolicy_PER_LAYER:
+			total += weakest
+		case total == 0 || weakest < total:
+			total = weakest
+		}
+	}
+	return total
+}
+
+// bandwidthEstimate is a subscriber's share of its last REMB for one downtrack
+type bandwidthEstimate struct {
+	bitrate uint64
+	at      time.Time
+	// the subscriber's subscribe cap, see bitrate.go
+	cap uint64
+	// takes the observer off the downtrack's sender
+	unobserve func()
+}
+
+// bandwidthMonitor keeps the estimates the subscribers of a publisher's tracks sent
+type bandwidthMonitor struct {
+	mu        sync.Mutex
+	estimates map[*sfu.DownTrack]*bandwidthEstimate
+	// the subscribe cap of a downtrack's subscriber, nil for none
+	capOf func(*sfu.DownTrack) uint64
+}
+
+func newBandwidthMonitor() *bandwidthMonitor {
+	return &bandwidthMonitor{estimates: map[*sfu.DownTrack]*bandwidthEstimate{}}
+}
+
+// watch starts observing the new downtracks of the receivers, and forgets the ones they lost
+func (b *bandwidthMonitor) watch(receivers []sfu.Receiver) {
+	current := map[*sfu.DownTrack]bool{}
+	for _, receiver := range receivers {
+		for _, tracks := range receiverDownTracks(receiver) {
+			for _, track := range tracks {
+				current[track] = true
+				b.mu.Lock()
+				_, observed := b.estimates[track]
+				b.mu.Unlock()
+				// the sfu's recordings and relays are sent no rtcp
+				sender := downTrackSender(track)
+				if observed || sender == nil {
+					continue
+				}
+				track := track
+				estimate := &bandwidthEstimate{}
+				if b.capOf != nil {
+					estimate.cap = b.capOf(track)
+				}
+				b.mu.Lock()
+				b.estimates[track] = estimate
+				b.mu.Unlock()
+				estimate.unobserve = observeSenderRTCP(sender, func(packets []rtcp.Packet) { b.observe(track, packets, time.Now()) })
+			}
+		}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for track, estimate := range b.estimates {
+		if !current[track] {
+			estimate.unobserve()
+			delete(b.estimates, track)
+		}
+	}
+}
+
+// stop takes the monitor's observers off every sender
+func (b *bandwidthMonitor) stop() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for track, estimate := range b.estimates {
+		estimate.unobserve()
+		delete(b.estimates, track)
+	}
+}
+
+// observe keeps the downtrack's share of the last REMB among packets
+func (b *bandwidthMonitor) observe(track *sfu.DownTrack, packets []rtcp.Packet, now time.Time) {
+	for _, packet := range packets {
+		remb, ok := packet.(*rtcp.ReceiverEstimatedMaximumBitrate)
+		if !ok || len(remb.SSRCs) == 0 {
+			continue
+		}
+		b.mu.Lock()
+		if estimate, ok := b.estimates[track]; ok {
+			estimate.bitrate, estimate.at = capBitrate(remb.Bitrate, estimate.cap)/uint64(len(remb.SSRCs)), now
+		}
+		b.mu.Unlock()
+	}
+}
+
+// target is what the receiver's subscribers allow it, from the estimates within REMBTimeout
+func (b *bandwidthMonitor) target(receiver sfu.Receiver, aggregation pb.RTCPPolicy_Aggregation, now time.Time) uint64 {
+	downTracks := receiverDownTracks(receiver)
+	layers := make([][]uint64, len(downTracks))
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for layer, tracks := range downTracks {
+		for _, track := range tracks {
+			estimate, ok := b.estimates[track]
+			if ok && estimate.bitrate > 0 && now.Sub(estimate.at) < REMBTimeout {
+				layers[layer] = append(layers[layer], estimate.bitrate)
+			}
+		}
+	}
+	return aggregateBitrate(aggregation, layers)
+}
+
+func (m *Manager) setTargetBitrates(pid string, targets map[string]uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(targets) == 0 {
+		delete(m.targets, pid)
+	} else {
+		m.targets[pid] = targets
+	}
+}
+
+// TargetBitrates are what pid was last allowed to publish of each video track by its subscribers,
+// by track id, when it is on this node, in bits per second
+func (m *Manager) TargetBitrates(pid string) map[string]uint64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.targets[pid]
+}
+
+// TargetBitrate is the sum of pid's TargetBitrates
+func (m *Manager) TargetBitrate(pid string) uint64 {
+	var total uint64
+	for _, target := range m.TargetBitrates(pid) {
+		total += target
+	}
+	return total
+}
+
+// forwardEstimates caps the REMBs ion-sfu sends the peer at what its subscribers allow, every
+// REMBInterval, until stop is closed
+func (w *worker) forwardEstimates(pid string, peer *sfu.Peer, aggregation pb.RTCPPolicy_Aggregation, stop <-chan struct{}, logger Logger) {
+	defer func() {
+		// a reconnect on this node files its own
+		if !w.manager.supersededPeer(pid, peer) {
+			w.manager.setTargetBitrates(pid, nil)
+		}
+	}()
+	monitor := newBandwidthMonitor()
+	monitor.capOf = func(track *sfu.DownTrack) uint64 { return w.manager.subscribeCapOf(downTrackSubscriber(track)) }
+	defer monitor.stop()
+	// the ssrcs capped on the last tick, released when their track loses its target
+	capped := map[uint32]bool{}
+	defer func() {
+		buffers := buffersOf(publisherOf(peer))
+		for ssrc := range capped {
+			capEstimate(buffers, ssrc, 0)
+		}
+	}()
+	ticker := time.NewTicker(REMBInterval)
+	defer ticker.Stop()
+	for {
+		var now time.Time
+		select {
+		case now = <-ticker.C:
+		case <-stop:
+			return
+		}
+		publisher := publisherOf(peer)
+		buffers := buffersOf(publisher)
+		if buffers == nil {
+			continue
+		}
+		receivers := []sfu.Receiver{}
+		for _, receiver := range receiversOf(publisher) {
+			if receiver.Kind() == webrtc.RTPCodecTypeVideo {
+				receivers = append(receivers, receiver)
+			}
+		}
+		monitor.watch(receivers)
+		targets := map[string]uint64{}
+		capping := map[uint32]bool{}
+		for _, receiver := range receivers {
+			target := monitor.target(receiver, aggregation, now)
+			if target == 0 {
+				continue
+			}
+			targets[receiver.TrackID()] = target
+			for layer := 0; layer <= MaxSpatialLayer; layer++ {
+				if ssrc := receiver.SSRC(layer); ssrc != 0 && capEstimate(buffers, ssrc, target) {
+					capping[ssrc] = true
+				}
+			}
+		}
+		for ssrc := range capped {
+			if !capping[ssrc] {
+				capEstimate(buffers, ssrc, 0)
+			}
+		}
+		if len(capping) != len(capped) {
+			logger.Debugf("capping the estimates of %d ssrcs at their subscribers' targets", len(capping))
+		}
+		capped = capping
+		w.manager.setTargetBitrates(pid, targets)
+	}
+}
@@ -0,0 +1,114 @@
+package noir
+
+import (
+	"errors"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"github.com/pion/rtcp"
+	"github.com/pion/webrtc/v3"
+	"testing"
+	"time"
+)
+
+func TestAggregateBitrate(t *testing.T) {
+	simulcast := [][]uint64{{300000, 150000}, {800000}, {2500000, 1800000}}
+	for _, test := range []struct {
+		aggregation pb.RTCPPolicy_Aggregation
+		layers      [][]uint64
+		want        uint64
+	}{
+		{pb.RTCPPolicy_MINIMUM, nil, 0},
+		{pb.RTCPPolicy_MINIMUM, [][]uint64{{}, {}, {}}, 0},
+		{pb.RTCPPolicy_MINIMUM, [][]uint64{{900000, 400000, 1200000}}, 400000},
+		{pb.RTCPPolicy_PER_LAYER, [][]uint64{{900000, 400000, 1200000}}, 400000},
+		{pb.RTCPPolicy_MINIMUM, simulcast, 150000},
+		// each layer is held back by its own weakest subscriber only
+		{pb.RTCPPolicy_PER_LAYER, simulcast, 150000 + 800000 + 1800000},
+		{pb.RTCPPolicy_PER_LAYER, [][]uint64{{}, {800000}, {}}, 800000},
+	} {
+		if got := aggregateBitrate(test.aggregation, test.layers); got != test.want {
+			t.Errorf("got %d want %d for the %s of %v", got, test.want, test.aggregation, test.layers)
+		}
+	}
+}
+
+func TestParseAggregation(t *testing.T) {
+	for name, want := range map[string]pb.RTCPPolicy_Aggregation{
+		"": pb.RTCPPolicy_NONE, "none": pb.RTCPPolicy_NONE, "minimum": pb.RTCPPolicy_MINIMUM, "PER_LAYER": pb.RTCPPolicy_PER_LAYER,
+	} {
+		if got, err := ParseAggregation(name); err != nil || got != want {
+			t.Errorf("got %s %v want %s for %q", got, err, want, name)
+		}
+	}
+	if _, err := ParseAggregation("average"); !errors.Is(err, ErrInvalidRTCPPolicy) {
+		t.Errorf("got %v want an unknown aggregation refused", err)
+	}
+	if err := ValidateRTCPPolicy(&pb.RTCPPolicy{Aggregation: 7}); !errors.Is(err, ErrInvalidRTCPPolicy) {
+		t.Errorf("got %v want an unknown aggregation refused in a room's policy", err)
+	}
+	if policy := (RTCPPolicyConfig{Aggregation: "per_layer"}).Policy(); policy.GetAggregation() != pb.RTCPPolicy_PER_LAYER {
+		t.Errorf("got %s want the [rtcp] aggregation in the manager's policy", policy)
+	}
+}
+
+func TestBandwidthMonitor(t *testing.T) {
+	receiver := sfu.NewWebRTCReceiver(nil, &webrtc.TrackRemote{}, "remb-pub")
+	near, _ := sfu.NewDownTrack(webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeVP8}, receiver, "near")
+	far, _ := sfu.NewDownTrack(webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeVP8}, receiver, "far")
+	receiver.AddDownTrack(near, false)
+	receiver.AddDownTrack(far, false)
+	if tracks := receiverDownTracks(receiver); len(tracks) != 3 || len(tracks[0]) != 2 {
+		t.Fatalf("got %v want both downtracks on the first layer", tracks)
+	}
+
+	monitor := newBandwidthMonitor()
+	monitor.estimates[near] = &bandwidthEstimate{}
+	monitor.estimates[far] = &bandwidthEstimate{}
+	start := time.Now()
+	if monitor.target(receiver, pb.RTCPPolicy_MINIMUM, start) != 0 {
+		t.Errorf("a track without estimates should have no target")
+	}
+	monitor.observe(near, []rtcp.Packet{
+		&rtcp.ReceiverReport{},
+		&rtcp.ReceiverEstimatedMaximumBitrate{Bitrate: 2000000, SSRCs: []uint32{1111}},
+	}, start)
+	// the far subscriber's estimate covers its audio too
+	monitor.observe(far, []rtcp.Packet{&rtcp.ReceiverEstimatedMaximumBitrate{Bitrate: 600000, SSRCs: []uint32{1111, 2222}}}, start)
+	if target := monitor.target(receiver, pb.RTCPPolicy_MINIMUM, start.Add(time.Second)); target != 300000 {
+		t.Errorf("got %d want the share of the weakest subscriber", target)
+	}
+
+	// the far subscriber stops sending estimates
+	monitor.observe(near, []rtcp.Packet{&rtcp.ReceiverEstimatedMaximumBitrate{Bitrate: 1500000, SSRCs: []uint32{1111}}}, start.Add(4*time.Second))
+	if target := monitor.target(receiver, pb.RTCPPolicy_MINIMUM, start.Add(REMBTimeout+time.Second)); target != 1500000 {
+		t.Errorf("got %d want the estimates older than REMBTimeout left out", target)
+	}
+
+	// a subscriber counts for no more than its subscribe cap
+	monitor.estimates[near].cap = 1000000
+	monitor.observe(near, []rtcp.Packet{&rtcp.ReceiverEstimatedMaximumBitrate{Bitrate: 1500000, SSRCs: []uint32{1111}}}, start.Add(6*time.Second))
+	if target := monitor.target(receiver, pb.RTCPPolicy_MINIMUM, start.Add(7*time.Second)); target != 1000000 {
+		t.Errorf("got %d want the estimate capped at the subscriber's subscribeKbps", target)
+	}
+}
+
+func TestTargetBitrateStats(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	redis.Del(pb.KeyRoomUsers("remb-room"))
+	defer redis.Del(pb.KeyRoomUsers("remb-room"))
+	redis.HSet(pb.KeyRoomUsers("remb-room"), "remb-pub", "")
+	mgr.mu.Lock()
+	mgr.users["remb-pub"] = NewSFUPeer(*mgr.sfu)
+	mgr.mu.Unlock()
+	defer mgr.DisconnectUser("remb-pub")
+
+	mgr.setTargetBitrates("remb-pub", map[string]uint64{"camera": 800000, "screen": 1200000})
+	stats := mgr.LocalRoomStats("remb-room")
+	if len(stats) != 1 || stats[0].TargetBitrate != 2000000 {
+		t.Errorf("got %v want the publisher's targets summed in its stats", stats)
+	}
+	mgr.setTargetBitrates("remb-pub", nil)
+	if mgr.TargetBitrate("remb-pub") != 0 || mgr.TargetBitrates("remb-pub") != nil {
+		t.Errorf("a publisher no longer aggregated should have no target")
+	}
+}
@@ -0,0 +1,318 @@
+package noir
+
+import (
+	"errors"
+	"fmt"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/interceptor"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+	"github.com/pion/webrtc/v3"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+RTCP Policy:
+On lossy or high latency networks the defaults of ion-sfu for lost packets and keyframes can cost
+more than they save. An RTCPPolicy, set on the manager from [rtcp] or on a room's options, which
+replaces the manager's, tunes them for the peers joining from then on:
+
+	disableNack       lost packets are neither asked for nor resent. The nack feedback is taken
+	                  out of the descriptions peers are sent, so browsers stop asking and answering
+	                  for them, and the NACKs a subscriber sends anyway are dropped
+	maxRetransmits    how many times one packet is resent to a subscriber that keeps asking,
+	                  0 resends it as often as ion-sfu does, at most every 500ms while buffered
+	minPliIntervalMs  how long after asking a publisher for a keyframe of a track it is asked
+	                  again, however many subscribers lost theirs, so they can not set off a
+	                  keyframe storm. ion-sfu waits 500ms by itself, shorter intervals change nothing
+	aggregation       how subscribers' bandwidth estimates bound what publishers send, see
+	                  rtcp_feedback.go, NONE drops them as ion-sfu does
+	bufferMs          how long the sfu keeps the packets a peer publishes to resend them. A
+	                  deeper buffer answers NACKs of subscribers with long round trips, at the cost
+	                  of memory, 0 keeps ion-sfu's 1000ms. It is the only buffer ion-sfu has, the
+	                  sfu forwards packets as they come and leaves playout to the browsers
+
+The zero policy is ion-sfu's behavior, and a policy with negative values is refused, for the
+manager and for rooms alike.
+*/
+
+var ErrInvalidRTCPPolicy = errors.New("invalid rtcp policy")
+
+// RTCPPolicyConfig is the manager's RTCPPolicy from the [rtcp] config
+type RTCPPolicyConfig struct {
+	DisableNACK      bool   `mapstructure:"disablenack"`
+	MaxRetransmits   int    `mapstructure:"maxretransmits"`
+	MinPLIIntervalMs int    `mapstructure:"minpliintervalms"`
+	Aggregation      string `mapstructure:"aggregation"`
+	BufferMs         int    `mapstructure:"bufferms"`
+}
+
+// Policy is nil for the zero config, an unknown aggregation is left out, see ParseAggregation
+func (c RTCPPolicyConfig) Policy() *pb.RTCPPolicy {
+	if c == (RTCPPolicyConfig{}) {
+		return nil
+	}
+	aggregation, _ := ParseAggregation(c.Aggregation)
+	return &pb.RTCPPolicy{
+		DisableNack:      c.DisableNACK,
+		MaxRetransmits:   int32(c.MaxRetransmits),
+		MinPliIntervalMs: int32(c.MinPLIIntervalMs),
+		Aggregation:      aggregation,
+		BufferMs:         int32(c.BufferMs),
+	}
+}
+
+// ParseAggregation reads the [rtcp] aggregation, none, minimum or per_layer, empty is none
+func ParseAggregation(name string) (pb.RTCPPolicy_Aggregation, error) {
+	if name == "" {
+		return pb.RTCPPolicy_NONE, nil
+	}
+	aggregation, ok := pb.RTCPPolicy_Aggregation_value[strings.ToUpper(name)]
+	if !ok {
+		return pb.RTCPPolicy_NONE, fmt.Errorf("%w: unknown aggregation %s", ErrInvalidRTCPPolicy, name)
+	}
+	return pb.RTCPPolicy_Aggregation(aggregation), nil
+}
+
+// ValidateRTCPPolicy refuses negative counts and intervals, nil is ion-sfu's behavior
+func ValidateRTCPPolicy(policy *pb.RTCPPolicy) error {
+	if policy.GetMaxRetransmits() < 0 {
+		return fmt.Errorf("%w: maxRetransmits %d is negative", ErrInvalidRTCPPolicy, policy.GetMaxRetransmits())
+	}
+	if policy.GetMinPliIntervalMs() < 0 {
+		return fmt.Errorf("%w: minPliIntervalMs %d is negative", ErrInvalidRTCPPolicy, policy.GetMinPliIntervalMs())
+	}
+	if policy.GetBufferMs() < 0 {
+		return fmt.Errorf("%w: bufferMs %d is negative", ErrInvalidRTCPPolicy, policy.GetBufferMs())
+	}
+	if _, ok := pb.RTCPPolicy_Aggregation_name[int32(policy.GetAggregation())]; !ok {
+		return fmt.Errorf("%w: unknown aggregation %d", ErrInvalidRTCPPolicy, policy.GetAggregation())
+	}
+	return nil
+}
+
+// SetRTCPPolicy tunes the rtcp of peers joining from now on, nil leaves it to ion-sfu
+func (m *Manager) SetRTCPPolicy(policy *pb.RTCPPolicy) error {
+	if err := ValidateRTCPPolicy(policy); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rtcpPolicy = policy
+	return nil
+}
+
+// RoomRTCPPolicy is the room's own policy, or the manager's when the room has none
+func (m *Manager) RoomRTCPPolicy(roomID string) *pb.RTCPPolicy {
+	if roomData, err := m.GetRemoteRoomData(roomID); err == nil {
+		if policy := roomData.GetOptions().GetRtcpPolicy(); policy != nil {
+			return policy
+		}
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.rtcpPolicy
+}
+
+// StripNACK takes the nack feedback out of every media section, leaving "nack pli"
+func StripNACK(desc *webrtc.SessionDescription) error {
+	if desc == nil {
+		return nil
+	}
+	parsed, err := desc.Unmarshal()
+	if err != nil {
+		return err
+	}
+	for _, media := range parsed.MediaDescriptions {
+		kept := media.Attributes[:0]
+		for _, attribute := range media.Attributes {
+			if attribute.Key == "rtcp-fb" {
+				if fields := strings.Fields(attribute.Value); len(fields) == 2 && fields[1] == "nack" {
+					continue
+				}
+			}
+			kept = append(kept, attribute)
+		}
+		media.Attributes = kept
+	}
+	raw, err := parsed.Marshal()
+	if err != nil {
+		return err
+	}
+	desc.SDP = string(raw)
+	return nil
+}
+
+// applyRTCPPolicy filters the rtcp of every downtrack the sfu adds to the peer, ahead of the
+// renegotiation that offers it, and sizes the buffers of what it publishes, false before the
+// peer joined
+func applyRTCPPolicy(peer *sfu.Peer, policy *pb.RTCPPolicy) bool {
+	subscriber := subscriberOf(peer)
+	if subscriber == nil {
+		return false
+	}
+	if ms := policy.GetBufferMs(); ms > 0 && !sizeBuffers(publisherOf(peer), uint32(ms)) {
+		return false
+	}
+	filtered := map[*sfu.DownTrack]bool{}
+	var mu sync.Mutex
+	filterNew := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		// the downtracks the subscriber lost are forgotten
+		current := map[*sfu.DownTrack]bool{}
+		for _, track := range downTracksOf(subscriber) {
+			sender := downTrackSender(track)
+			if sender == nil {
+				continue
+			}
+			current[track] = true
+			if !filtered[track] {
+				filterSenderRTCP(sender, newRTCPFilter(policy, downTrackReceiver(track)).filter)
+			}
+		}
+		filtered = current
+	}
+	filterNew()
+	_, ok := hookNegotiate(subscriber, filterNew)
+	return ok
+}
+
+// sizeBuffers keeps each ssrc the publisher sends for ms in the sfu's buffer of it, from its
+// first packet on, false before it joined
+func sizeBuffers(publisher *sfu.Publisher, ms uint32) bool {
+	buffers := buffersOf(publisher)
+	var mu sync.Mutex
+	sized := map[uint32]bool{}
+	_, ok := observePublisherRTP(publisher, func(info *interceptor.StreamInfo, packet *rtp.Packet) {
+		mu.Lock()
+		defer mu.Unlock()
+		if !sized[info.SSRC] {
+			sized[info.SSRC] = setBufferTime(buffers, info.SSRC, ms)
+		}
+	})
+	return ok
+}
+
+// the most packets a downtrack's rtcpFilter counts the retransmits of
+const retransmitsTracked = 512
+
+// rtcpFilter applies a policy to the rtcp one subscriber sends about one downtrack
+type rtcpFilter struct {
+	policy *pb.RTCPPolicy
+	// shared by the downtracks of the published track
+	keyframes *keyframeThrottle
+	// how many times each packet was asked for, oldest first
+	asked map[uint16]int
+	order []uint16
+}
+
+func newRTCPFilter(policy *pb.RTCPPolicy, receiver sfu.Receiver) *rtcpFilter {
+	return &rtcpFilter{
+		policy:    policy,
+		keyframes: keyframeThrottleOf(receiver, time.Duration(policy.GetMinPliIntervalMs())*time.Millisecond),
+		asked:     map[uint16]int{},
+	}
+}
+
+func (f *rtcpFilter) filter(packets []rtcp.Packet) []rtcp.Packet {
+	kept := packets[:0]
+	for _, packet := range packets {
+		switch p := packet.(type) {
+		case *rtcp.TransportLayerNack:
+			if f.policy.GetDisableNack() {
+				continue
+			}
+			if f.policy.GetMaxRetransmits() > 0 {
+				if p = f.capRetransmits(p); p == nil {
+					continue
+				}
+			}
+			packet = p
+		case *rtcp.PictureLossIndication, *rtcp.FullIntraRequest:
+			if !f.keyframes.allow() {
+				continue
+			}
+		}
+		kept = append(kept, packet)
+	}
+	return kept
+}
+
+// capRetransmits leaves out of the nack the packets it already had resent maxRetransmits times,
+// nil when that is all of them
+func (f *rtcpFilter) capRetransmits(nack *rtcp.TransportLayerNack) *rtcp.TransportLayerNack {
+	limit := int(f.policy.GetMaxRetransmits())
+	capped := &rtcp.TransportLayerNack{SenderSSRC: nack.SenderSSRC, MediaSSRC: nack.MediaSSRC}
+	for _, pair := range nack.Nacks {
+		for _, sn := range pair.PacketList() {
+			if f.asked[sn] >= limit {
+				continue
+			}
+			if f.asked[sn] == 0 {
+				f.order = append(f.order, sn)
+				if len(f.order) > retransmitsTracked {
+					delete(f.asked, f.order[0])
+					f.order = f.order[1:]
+				}
+			}
+			f.asked[sn]++
+			capped.Nacks = append(capped.Nacks, rtcp.NackPairsFromSequenceNumbers([]uint16{sn})...)
+		}
+	}
+	if len(capped.Nacks) == 0 {
+		return nil
+	}
+	return capped
+}
+
+// keyframeThrottle lets a keyframe be asked of a published track once per interval
+type keyframeThrottle struct {
+	mu       sync.Mutex
+	interval time.Duration
+	last     time.Time
+}
+
+// the throttles of published tracks, by their receiver, a throttle idle for keyframeThrottleExpiry
+// is forgotten when the next one is made, so closed tracks do not pile up
+var keyframeThrottles sync.Map
+
+const keyframeThrottleExpiry = time.Minute
+
+func keyframeThrottleOf(receiver sfu.Receiver, interval time.Duration) *keyframeThrottle {
+	if receiver == nil || interval <= 0 {
+		return &keyframeThrottle{}
+	}
+	throttle, loaded := keyframeThrottles.LoadOrStore(receiver, &keyframeThrottle{interval: interval, last: time.Now().Add(-interval)})
+	if !loaded {
+		keyframeThrottles.Range(func(key, value interface{}) bool {
+			if value.(*keyframeThrottle).idle() > keyframeThrottleExpiry {
+				keyframeThrottles.Delete(key)
+			}
+			return true
+		})
+	}
+	return throttle.(*keyframeThrottle)
+}
+
+func (t *keyframeThrottle) idle() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return time.Since(t.last)
+}
+
+func (t *keyframeThrottle) allow() bool {
+	if t.interval <= 0 {
+		return true
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if time.Since(t.last) < t.interval {
+		return false
+	}
+	t.last = time.Now()
+	return true
+}
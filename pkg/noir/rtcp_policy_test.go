@@ -0,0 +1,113 @@
+package noir
+
+import (
+	"errors"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"github.com/pion/rtcp"
+	"github.com/pion/webrtc/v3"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateRTCPPolicy(t *testing.T) {
+	for _, test := range []struct {
+		policy *pb.RTCPPolicy
+		valid  bool
+	}{
+		{nil, true},
+		{&pb.RTCPPolicy{DisableNack: true}, true},
+		{&pb.RTCPPolicy{MaxRetransmits: 2, MinPliIntervalMs: 1000}, true},
+		{&pb.RTCPPolicy{MaxRetransmits: -1}, false},
+		{&pb.RTCPPolicy{MinPliIntervalMs: -500}, false},
+		{&pb.RTCPPolicy{BufferMs: 3000}, true},
+		{&pb.RTCPPolicy{BufferMs: -1}, false},
+	} {
+		if err := ValidateRTCPPolicy(test.policy); (err == nil) != test.valid || (err != nil && !errors.Is(err, ErrInvalidRTCPPolicy)) {
+			t.Errorf("got %v for %v want valid %v", err, test.policy, test.valid)
+		}
+	}
+}
+
+func TestRoomRTCPPolicy(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	defer mgr.SetRTCPPolicy(nil)
+	redis.Del(pb.KeyRoomData("rtcp-default"), pb.KeyRoomData("rtcp-room"), pb.KeyRoomData("rtcp-negative"))
+	defer redis.Del(pb.KeyRoomData("rtcp-default"), pb.KeyRoomData("rtcp-room"), pb.KeyRoomData("rtcp-negative"))
+
+	if err := mgr.SetRTCPPolicy(&pb.RTCPPolicy{MaxRetransmits: -1}); !errors.Is(err, ErrInvalidRTCPPolicy) {
+		t.Errorf("got %v want the manager's negative retransmits refused", err)
+	}
+	if err := mgr.SetRTCPPolicy(RTCPPolicyConfig{MinPLIIntervalMs: 2000}.Policy()); err != nil {
+		t.Fatalf("error setting policy: %s", err)
+	}
+	mgr.SetRoomData(&pb.RoomData{Id: "rtcp-default"})
+	mgr.SetRoomData(&pb.RoomData{Id: "rtcp-room", Options: &pb.RoomOptions{RtcpPolicy: &pb.RTCPPolicy{DisableNack: true}}})
+	if policy := mgr.RoomRTCPPolicy("rtcp-default"); policy.GetMinPliIntervalMs() != 2000 || policy.GetDisableNack() {
+		t.Errorf("got %v want the manager's policy", policy)
+	}
+	if policy := mgr.RoomRTCPPolicy("rtcp-room"); !policy.GetDisableNack() || policy.GetMinPliIntervalMs() != 0 {
+		t.Errorf("got %v want the room's own policy", policy)
+	}
+	negative := &pb.RoomData{Options: &pb.RoomOptions{RtcpPolicy: &pb.RTCPPolicy{MinPliIntervalMs: -1}}}
+	if err := SaveRoomData("rtcp-negative", negative, mgr); !errors.Is(err, ErrInvalidRTCPPolicy) {
+		t.Errorf("got %v want a room with a negative pli interval refused", err)
+	}
+	if (RTCPPolicyConfig{}).Policy() != nil {
+		t.Errorf("an empty config should leave rtcp to ion-sfu")
+	}
+}
+
+func TestStripNACK(t *testing.T) {
+	desc := &webrtc.SessionDescription{Type: webrtc.SDPTypeOffer, SDP: codecsTestSDP + "a=rtcp-fb:96 nack pli\r\n"}
+	if err := StripNACK(desc); err != nil {
+		t.Fatalf("error stripping nack: %s", err)
+	}
+	if strings.Contains(desc.SDP, "nack\r\n") || !strings.Contains(desc.SDP, "a=rtcp-fb:96 nack pli") {
+		t.Errorf("got %s want only the nack feedback gone", desc.SDP)
+	}
+}
+
+func TestRTCPFilter(t *testing.T) {
+	nack := func(sns ...uint16) *rtcp.TransportLayerNack {
+		return &rtcp.TransportLayerNack{MediaSSRC: 1, Nacks: rtcp.NackPairsFromSequenceNumbers(sns)}
+	}
+
+	disabled := newRTCPFilter(&pb.RTCPPolicy{DisableNack: true}, nil)
+	if kept := disabled.filter([]rtcp.Packet{nack(1), &rtcp.PictureLossIndication{}}); len(kept) != 1 {
+		t.Errorf("got %v want only the pli with nack disabled", kept)
+	}
+
+	capped := newRTCPFilter(&pb.RTCPPolicy{MaxRetransmits: 2}, nil)
+	capped.filter([]rtcp.Packet{nack(1, 2)})
+	capped.filter([]rtcp.Packet{nack(1)})
+	kept := capped.filter([]rtcp.Packet{nack(1, 2, 3)})
+	if len(kept) != 1 {
+		t.Fatalf("got %v want a nack for the packets under the cap", kept)
+	}
+	var sns []uint16
+	for _, pair := range kept[0].(*rtcp.TransportLayerNack).Nacks {
+		sns = append(sns, pair.PacketList()...)
+	}
+	if len(sns) != 2 || sns[0] != 2 || sns[1] != 3 {
+		t.Errorf("got %v want packet 1 left out after 2 retransmits", sns)
+	}
+	if kept := capped.filter([]rtcp.Packet{nack(1)}); len(kept) != 0 {
+		t.Errorf("got %v want the nack dropped", kept)
+	}
+
+	receiver := &sfu.WebRTCReceiver{}
+	first := newRTCPFilter(&pb.RTCPPolicy{MinPliIntervalMs: 50}, receiver)
+	second := newRTCPFilter(&pb.RTCPPolicy{MinPliIntervalMs: 50}, receiver)
+	if kept := first.filter([]rtcp.Packet{&rtcp.PictureLossIndication{}}); len(kept) != 1 {
+		t.Errorf("got %v want the first pli", kept)
+	}
+	if kept := second.filter([]rtcp.Packet{&rtcp.FullIntraRequest{}}); len(kept) != 0 {
+		t.Errorf("got %v want another subscriber's keyframe request throttled", kept)
+	}
+	time.Sleep(60 * time.Millisecond)
+	if kept := second.filter([]rtcp.Packet{&rtcp.PictureLossIndication{}}); len(kept) != 1 {
+		t.Errorf("got %v want a pli after the interval", kept)
+	}
+}
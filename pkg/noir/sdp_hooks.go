@@ -0,0 +1,50 @@
+package noir
+
+/*
+SDP Hooks:
+Deployments that rewrite the SDP noir sends, to reorder codecs, cap bandwidth with b=AS lines or
+strip extensions, set a BeforeSendAnswer or BeforeSendOffer hook. The hooks get every answer and
+subscriber offer right before it is packed into the SignalReply, after noir's own changes like
+LimitBitrate and FilterCandidates, and return the sdp to send. Only the client sees what a hook
+returns, the sfu keeps its own description, so a hook can not add what the sfu did not negotiate,
+and malformed sdp breaks the peer. Unset hooks send the sdp unchanged.
+*/
+
+// SDPHook rewrites the sdp about to be sent to pid
+type SDPHook func(pid string, sdp string) string
+
+// SetBeforeSendAnswer rewrites every answer sent from now on, nil sends them unchanged
+func (m *Manager) SetBeforeSendAnswer(hook SDPHook) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.beforeSendAnswer = hook
+}
+
+// SetBeforeSendOffer rewrites every subscriber offer sent from now on, nil sends them unchanged
+func (m *Manager) SetBeforeSendOffer(hook SDPHook) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.beforeSendOffer = hook
+}
+
+// BeforeSendAnswer is the sdp of the answer to send pid
+func (m *Manager) BeforeSendAnswer(pid string, sdp string) string {
+	m.mu.RLock()
+	hook := m.beforeSendAnswer
+	m.mu.RUnlock()
+	if hook == nil {
+		return sdp
+	}
+	return hook(pid, sdp)
+}
+
+// BeforeSendOffer is the sdp of the offer to send pid
+func (m *Manager) BeforeSendOffer(pid string, sdp string) string {
+	m.mu.RLock()
+	hook := m.beforeSendOffer
+	m.mu.RUnlock()
+	if hook == nil {
+		return sdp
+	}
+	return hook(pid, sdp)
+}
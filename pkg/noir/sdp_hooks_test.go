@@ -0,0 +1,60 @@
+package noir
+
+import (
+	"encoding/json"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/webrtc/v3"
+	"google.golang.org/protobuf/proto"
+	"strings"
+	"testing"
+)
+
+func TestBeforeSendAnswer(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	redis.Del(pb.KeyRoomData("munged"), pb.KeyRoomUsers("munged"), pb.KeyTopicFromPeer("munged-peer"))
+	defer redis.Del(pb.KeyRoomData("munged"), pb.KeyRoomUsers("munged"), pb.KeyTopicFromPeer("munged-peer"))
+	if got := mgr.BeforeSendAnswer("munged-peer", "v=0\r\n"); got != "v=0\r\n" {
+		t.Errorf("got %q want no hook to leave the sdp as is", got)
+	}
+	mgr.SetBeforeSendAnswer(func(pid string, sdp string) string {
+		return strings.Replace(sdp, "c=IN IP4 0.0.0.0\r\n", "c=IN IP4 0.0.0.0\r\nb=AS:500\r\n", 1)
+	})
+	defer mgr.SetBeforeSendAnswer(nil)
+
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id: "munged-peer",
+				Payload: &pb.SignalRequest_Join{
+					Join: &pb.JoinRequest{Sid: "munged", Description: []byte(EXAMPLE_EMPTY_SDP)},
+				},
+			},
+		},
+	})
+	if err := worker.HandleNext(0); err != nil {
+		t.Fatalf("error joining: %s", err)
+	}
+	defer mgr.DisconnectUser("munged-peer")
+
+	// past the candidates trickled before it
+	var join *pb.JoinReply
+	replies := mgr.GetQueue(pb.KeyTopicFromPeer("munged-peer"))
+	for join == nil {
+		msg, err := replies.Next()
+		if err != nil || msg == nil {
+			t.Fatalf("got no join reply")
+		}
+		reply := pb.NoirReply{}
+		proto.Unmarshal(msg, &reply)
+		join = reply.GetSignal().GetJoin()
+	}
+	answer := webrtc.SessionDescription{}
+	if err := json.Unmarshal(join.Description, &answer); err != nil {
+		t.Fatalf("error unpacking answer: %s", err)
+	}
+	if !strings.Contains(answer.SDP, "b=AS:500\r\n") {
+		t.Errorf("got %q want the hook's b=AS line in the answer", answer.SDP)
+	}
+}
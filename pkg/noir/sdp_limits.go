@@ -0,0 +1,121 @@
+package noir
+
+import (
+	"errors"
+	"fmt"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"strings"
+)
+
+/*
+SDP Limits:
+An offer with thousands of media sections, codecs or candidates makes the sfu's join crawl, so
+HandleJoin counts them before anything parses the offer, with a scan of its lines, and refuses
+an offer over its room's SDPLimits with OFFER_TOO_COMPLEX. HandleDescription does the same for
+every offer a peer renegotiates with, so the limits hold past the join. The manager's limits come from [sdp],
+a room's options may replace any of them for that room, and every limit left at 0 has a default
+generous enough for a browser publishing a camera, a microphone and a screen with simulcast. A
+negative limit is no limit. Rejected offers are counted by the limit they broke.
+*/
+
+// Defaults for the limits of Manager.SDPLimits() left at 0
+const (
+	DefaultMaxSDPBytes         = 128 << 10
+	DefaultMaxSDPMediaSections = 64
+	DefaultMaxSDPCodecs        = 512
+	DefaultMaxSDPCandidates    = 128
+)
+
+var ErrOfferTooComplex = errors.New("offer too complex")
+
+// SDPLimitsConfig is the manager's SDPLimits from the [sdp] config
+type SDPLimitsConfig struct {
+	MaxBytes         int `mapstructure:"maxbytes"`
+	MaxMediaSections int `mapstructure:"maxmediasections"`
+	MaxCodecs        int `mapstructure:"maxcodecs"`
+	MaxCandidates    int `mapstructure:"maxcandidates"`
+}
+
+func (c SDPLimitsConfig) Limits() *pb.SDPLimits {
+	return &pb.SDPLimits{
+		MaxBytes:         int32(c.MaxBytes),
+		MaxMediaSections: int32(c.MaxMediaSections),
+		MaxCodecs:        int32(c.MaxCodecs),
+		MaxCandidates:    int32(c.MaxCandidates),
+	}
+}
+
+// SetSDPLimits bounds the offers peers join with from now on, nil keeps the defaults
+func (m *Manager) SetSDPLimits(limits *pb.SDPLimits) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sdpLimits = limits
+}
+
+// SDPLimits are the manager's limits, with the defaults for those left at 0
+func (m *Manager) SDPLimits() *pb.SDPLimits {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return mergeSDPLimits(m.sdpLimits, &pb.SDPLimits{
+		MaxBytes:         DefaultMaxSDPBytes,
+		MaxMediaSections: DefaultMaxSDPMediaSections,
+		MaxCodecs:        DefaultMaxSDPCodecs,
+		MaxCandidates:    DefaultMaxSDPCandidates,
+	})
+}
+
+// RoomSDPLimits are the limits the room's options set, and the manager's for the others
+func (m *Manager) RoomSDPLimits(room *pb.RoomData) *pb.SDPLimits {
+	return mergeSDPLimits(room.GetOptions().GetSdpLimits(), m.SDPLimits())
+}
+
+// mergeSDPLimits is limits, with the limits of fallback for those left at 0
+func mergeSDPLimits(limits *pb.SDPLimits, fallback *pb.SDPLimits) *pb.SDPLimits {
+	pick := func(limit int32, fallback int32) int32 {
+		if limit == 0 {
+			return fallback
+		}
+		return limit
+	}
+	return &pb.SDPLimits{
+		MaxBytes:         pick(limits.GetMaxBytes(), fallback.GetMaxBytes()),
+		MaxMediaSections: pick(limits.GetMaxMediaSections(), fallback.GetMaxMediaSections()),
+		MaxCodecs:        pick(limits.GetMaxCodecs(), fallback.GetMaxCodecs()),
+		MaxCandidates:    pick(limits.GetMaxCandidates(), fallback.GetMaxCandidates()),
+	}
+}
+
+// CheckSDPLimits is an ErrOfferTooComplex for an offer over limits
+func CheckSDPLimits(offer []byte, limits *pb.SDPLimits) error {
+	if limit := limits.GetMaxBytes(); limit > 0 && len(offer) > int(limit) {
+		sdpRejected.WithLabelValues("bytes").Inc()
+		return fmt.Errorf("%w: %d bytes, the most is %d", ErrOfferTooComplex, len(offer), limit)
+	}
+	sections, codecs, candidates := 0, 0, 0
+	for _, line := range strings.Split(string(offer), "\n") {
+		switch {
+		case strings.HasPrefix(line, "m="):
+			sections++
+		case strings.HasPrefix(line, "a=rtpmap:"):
+			codecs++
+		case strings.HasPrefix(line, "a=candidate:"):
+			candidates++
+		}
+	}
+	for _, count := range []struct {
+		reason string
+		name   string
+		count  int
+		limit  int32
+	}{
+		{"media_sections", "media sections", sections, limits.GetMaxMediaSections()},
+		{"codecs", "codecs", codecs, limits.GetMaxCodecs()},
+		{"candidates", "candidates", candidates, limits.GetMaxCandidates()},
+	} {
+		if count.limit > 0 && count.count > int(count.limit) {
+			sdpRejected.WithLabelValues(count.reason).Inc()
+			return fmt.Errorf("%w: %d %s, the most is %d", ErrOfferTooComplex, count.count, count.name, count.limit)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package noir
+
+import (
+	"errors"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/webrtc/v3"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/protobuf/proto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCheckSDPLimits(t *testing.T) {
+	sections := EXAMPLE_EMPTY_SDP + strings.Repeat("m=audio 9 UDP/TLS/RTP/SAVPF 111\r\na=rtpmap:111 opus/48000/2\r\n", 4)
+	candidates := EXAMPLE_EMPTY_SDP + strings.Repeat("a=candidate:1 1 udp 2122260223 10.0.0.1 54321 typ host\r\n", 3)
+	limits := &pb.SDPLimits{MaxBytes: 4096, MaxMediaSections: 4, MaxCodecs: 8, MaxCandidates: 2}
+	for _, test := range []struct {
+		offer  string
+		limits *pb.SDPLimits
+		reason string
+	}{
+		{EXAMPLE_EMPTY_SDP, limits, ""},
+		{strings.Repeat("a", 4097), limits, "bytes"},
+		{sections, limits, "media_sections"},
+		{sections, &pb.SDPLimits{MaxMediaSections: -1, MaxCodecs: 3}, "codecs"},
+		{candidates, limits, "candidates"},
+		{candidates, &pb.SDPLimits{MaxCandidates: -1}, ""},
+	} {
+		before := testutil.ToFloat64(sdpRejected.WithLabelValues(test.reason))
+		err := CheckSDPLimits([]byte(test.offer), test.limits)
+		if test.reason == "" {
+			if err != nil {
+				t.Errorf("got %v want the offer allowed under %v", err, test.limits)
+			}
+			continue
+		}
+		if !errors.Is(err, ErrOfferTooComplex) {
+			t.Errorf("got %v want an offer over %s refused", err, test.reason)
+		}
+		if rejected := testutil.ToFloat64(sdpRejected.WithLabelValues(test.reason)) - before; rejected != 1 {
+			t.Errorf("got %v offers counted over %s want 1", rejected, test.reason)
+		}
+	}
+}
+
+func TestRoomSDPLimits(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	mgr.SetSDPLimits(SDPLimitsConfig{MaxMediaSections: 2}.Limits())
+	defer mgr.SetSDPLimits(nil)
+	keys := []string{pb.KeyRoomData("sdp-room"), pb.KeyRoomUsers("sdp-room"), pb.KeyTopicFromPeer("sdp-peer")}
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	if limits := mgr.SDPLimits(); limits.MaxMediaSections != 2 || limits.MaxBytes != DefaultMaxSDPBytes {
+		t.Errorf("got %v want the manager's media sections and the default bytes", limits)
+	}
+	room := &pb.RoomData{Id: "sdp-room", Options: &pb.RoomOptions{SdpLimits: &pb.SDPLimits{MaxBytes: 100}}}
+	if limits := mgr.RoomSDPLimits(room); limits.MaxBytes != 100 || limits.MaxMediaSections != 2 {
+		t.Errorf("got %v want the room's bytes and the manager's media sections", limits)
+	}
+
+	mgr.SetRoomData(room)
+	w := (*mgr.GetWorker()).(*worker)
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id: "sdp-peer",
+				Payload: &pb.SignalRequest_Join{
+					Join: &pb.JoinRequest{Sid: "sdp-room", Description: []byte(EXAMPLE_EMPTY_SDP)},
+				},
+			},
+		},
+	})
+	if err := worker.HandleNext(0); !errors.Is(err, ErrOfferTooComplex) {
+		mgr.DisconnectUser("sdp-peer")
+		t.Fatalf("got %v want a join over the room's limits refused", err)
+	}
+	message, err := mgr.GetQueue(pb.KeyTopicFromPeer("sdp-peer")).BlockUntilNext(time.Second)
+	if err != nil {
+		t.Fatalf("got no reply: %s", err)
+	}
+	reply := pb.NoirReply{}
+	proto.Unmarshal(message, &reply)
+	if reply.GetSignal().GetFailure().GetCode() != pb.SignalError_OFFER_TOO_COMPLEX {
+		t.Errorf("got %s want OFFER_TOO_COMPLEX", &reply)
+	}
+
+	// a peer of the room renegotiating past its limits
+	fake := newFakePeer()
+	userData := &pb.UserData{Id: "sdp-peer", RoomID: "sdp-room"}
+	var userMu sync.Mutex
+	offer := webrtc.SessionDescription{Type: webrtc.SDPTypeOffer, SDP: EXAMPLE_EMPTY_SDP}
+	w.HandleDescription(describe("sdp-peer", offer), userData, fake, &userMu, newSFUDeadline(), &glareState{})
+	message, err = mgr.GetQueue(pb.KeyTopicFromPeer("sdp-peer")).BlockUntilNext(time.Second)
+	if err != nil {
+		t.Fatalf("got no reply to the renegotiation: %s", err)
+	}
+	reply = pb.NoirReply{}
+	proto.Unmarshal(message, &reply)
+	if reply.GetSignal().GetFailure().GetCode() != pb.SignalError_OFFER_TOO_COMPLEX {
+		t.Errorf("got %s want the renegotiation refused with OFFER_TOO_COMPLEX", &reply)
+	}
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	if len(fake.offers) != 0 {
+		t.Errorf("the refused offer should not have reached the sfu")
+	}
+}
@@ -77,7 +77,7 @@ func (s *SFUServer) Subscribe(client *pb.AdminClient, stream pb.Noir_SubscribeSe
 }
 
 func (s *SFUServer) AdminBridge(clientID string, stream pb.Noir_AdminServer) error {
-	topic := pb.KeyTopicToAdmin(clientID)
+	topic := s.manager.Keys().TopicToAdmin(clientID)
 	recv := s.manager.GetQueue(topic)
 
 	log.Infof("admin bridge %s", topic)
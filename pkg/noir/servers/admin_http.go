@@ -0,0 +1,224 @@
+package servers
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-redis/redis"
+	"github.com/net-prophet/noir/pkg/noir"
+	log "github.com/pion/ion-log"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"net/http"
+	"strings"
+)
+
+/*
+Admin HTTP API:
+Rooms and peers can be managed over plain HTTP, for dashboards and scripts that do not speak
+the queue protocol:
+
+	GET    /rooms             every room, as RoomData
+	GET    /rooms/{sid}       one room
+	DELETE /rooms/{sid}       closes the room, its peers are killed
+	GET    /rooms/{sid}/peers the room's peers, as UserData
+	GET    /peers/{pid}       where the peer stands, as PeerStatus, 404 once it is gone
+	DELETE /peers/{pid}       kicks the peer from its room
+	GET    /cluster           the workers, rooms and queue depths, see Manager.ClusterSnapshot
+	GET    /healthz           200 while this node is ready, 503 and why while it is not
+
+Responses are the proto types as protojson, and /cluster a ClusterState as json. Errors are
+{"error": "..."}. With a token every request but /healthz needs an "Authorization: Bearer <token>"
+header, so probes run without it.
+*/
+
+// AdminServer serves the admin HTTP API from the manager
+type AdminServer struct {
+	manager *noir.Manager
+	token   string
+}
+
+// NewAdminServer makes the admin HTTP API, an empty token lets anyone in
+func NewAdminServer(manager *noir.Manager, token string) *AdminServer {
+	return &AdminServer{manager: manager, token: token}
+}
+
+func (s *AdminServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.Trim(r.URL.Path, "/") == "healthz" && r.Method == http.MethodGet {
+		s.healthz(w)
+		return
+	}
+	if !s.authorized(r) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		httpError(w, http.StatusUnauthorized, fmt.Errorf("bad or missing bearer token"))
+		return
+	}
+	path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case len(path) == 1 && path[0] == "rooms" && r.Method == http.MethodGet:
+		s.listRooms(w)
+	case len(path) == 2 && path[0] == "rooms" && r.Method == http.MethodGet:
+		s.getRoom(w, path[1])
+	case len(path) == 2 && path[0] == "rooms" && r.Method == http.MethodDelete:
+		s.closeRoom(w, path[1])
+	case len(path) == 3 && path[0] == "rooms" && path[2] == "peers" && r.Method == http.MethodGet:
+		s.listPeers(w, path[1])
+	case len(path) == 2 && path[0] == "peers" && r.Method == http.MethodGet:
+		s.getPeer(w, path[1])
+	case len(path) == 2 && path[0] == "peers" && r.Method == http.MethodDelete:
+		s.kickPeer(w, path[1])
+	case len(path) == 1 && path[0] == "cluster" && r.Method == http.MethodGet:
+		s.cluster(w)
+	default:
+		httpError(w, http.StatusNotFound, fmt.Errorf("no %s %s", r.Method, r.URL.Path))
+	}
+}
+
+func (s *AdminServer) authorized(r *http.Request) bool {
+	if s.token == "" {
+		return true
+	}
+	given := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return subtle.ConstantTimeCompare([]byte(given), []byte(s.token)) == 1
+}
+
+func (s *AdminServer) healthz(w http.ResponseWriter) {
+	if err := s.manager.Health(); err != nil {
+		httpError(w, http.StatusServiceUnavailable, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+}
+
+func (s *AdminServer) listRooms(w http.ResponseWriter) {
+	rooms, err := s.manager.ListRooms()
+	if err != nil {
+		httpError(w, http.StatusInternalServerError, err)
+		return
+	}
+	messages := make([]proto.Message, len(rooms))
+	for i, room := range rooms {
+		messages[i] = noir.PublicRoomData(room)
+	}
+	writeProtos(w, messages)
+}
+
+func (s *AdminServer) getRoom(w http.ResponseWriter, sid string) {
+	room, err := s.manager.GetRoomData(sid)
+	if errors.Is(err, noir.ErrRoomNotFound) {
+		httpError(w, http.StatusNotFound, fmt.Errorf("no room %s", sid))
+		return
+	} else if err != nil {
+		httpError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeProto(w, noir.PublicRoomData(room))
+}
+
+func (s *AdminServer) closeRoom(w http.ResponseWriter, sid string) {
+	if exists, err := s.manager.GetRemoteRoomExists(sid); err != nil {
+		httpError(w, http.StatusInternalServerError, err)
+		return
+	} else if !exists {
+		httpError(w, http.StatusNotFound, fmt.Errorf("no room %s", sid))
+		return
+	}
+	if err := s.manager.CloseRoom(sid); err != nil {
+		httpError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *AdminServer) listPeers(w http.ResponseWriter, sid string) {
+	if exists, err := s.manager.GetRemoteRoomExists(sid); err != nil {
+		httpError(w, http.StatusInternalServerError, err)
+		return
+	} else if !exists {
+		httpError(w, http.StatusNotFound, fmt.Errorf("no room %s", sid))
+		return
+	}
+	peers, err := s.manager.ListRoomPeers(sid)
+	if err != nil {
+		httpError(w, http.StatusInternalServerError, err)
+		return
+	}
+	messages := make([]proto.Message, len(peers))
+	for i, peer := range peers {
+		messages[i] = peer
+	}
+	writeProtos(w, messages)
+}
+
+func (s *AdminServer) getPeer(w http.ResponseWriter, pid string) {
+	status, err := s.manager.GetPeerStatus(pid)
+	if errors.Is(err, noir.ErrPeerNotFound) {
+		httpError(w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		httpError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeProto(w, status)
+}
+
+func (s *AdminServer) kickPeer(w http.ResponseWriter, pid string) {
+	userData, err := s.manager.GetRemoteUserData(pid)
+	if err == redis.Nil || (err == nil && userData == nil) {
+		httpError(w, http.StatusNotFound, fmt.Errorf("no peer %s", pid))
+		return
+	} else if err != nil {
+		httpError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if err := s.manager.KickPeer(userData.RoomID, pid); err != nil {
+		httpError(w, http.StatusNotFound, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *AdminServer) cluster(w http.ResponseWriter) {
+	state, err := s.manager.ClusterSnapshot()
+	if err != nil {
+		httpError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(state)
+}
+
+func writeProto(w http.ResponseWriter, message proto.Message) {
+	data, err := protojson.Marshal(message)
+	if err != nil {
+		httpError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+func writeProtos(w http.ResponseWriter, messages []proto.Message) {
+	list := make([]json.RawMessage, len(messages))
+	for i, message := range messages {
+		data, err := protojson.Marshal(message)
+		if err != nil {
+			httpError(w, http.StatusInternalServerError, err)
+			return
+		}
+		list[i] = data
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+func httpError(w http.ResponseWriter, status int, err error) {
+	if status == http.StatusInternalServerError {
+		log.Errorf("admin http error: %s", err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,136 @@
+package servers
+
+import (
+	"encoding/json"
+	"github.com/net-prophet/noir/pkg/noir"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"google.golang.org/protobuf/proto"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func adminRequest(server *AdminServer, method string, path string, token string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, path, nil)
+	if token != "" {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, r)
+	return w
+}
+
+func TestAdminServer(t *testing.T) {
+	mgr, redis := noir.NewTestSetup()
+	redis.Del(pb.KeyRoomData("admin-http"), pb.KeyRoomUsers("admin-http"), pb.KeyRoomClosed("admin-http"))
+	mgr.SetRoomData(&pb.RoomData{Id: "admin-http", Options: &pb.RoomOptions{JoinPasswordHash: "admin-http-hash"}})
+	mgr.SaveData(pb.KeyUserData("admin-http-peer"), &pb.NoirObject{
+		Data: &pb.NoirObject_User{User: &pb.UserData{Id: "admin-http-peer", RoomID: "admin-http"}},
+	}, 0)
+	redis.HSet(pb.KeyRoomUsers("admin-http"), "admin-http-peer", 1)
+	defer redis.Del(pb.KeyUserData("admin-http-peer"), pb.KeyTopicToPeer("admin-http-peer"))
+
+	server := NewAdminServer(mgr, "secret")
+	if w := adminRequest(server, http.MethodGet, "/rooms", ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("got %d want 401 without the token", w.Code)
+	}
+	if w := adminRequest(server, http.MethodGet, "/rooms", "wrong"); w.Code != http.StatusUnauthorized {
+		t.Errorf("got %d want 401 for the wrong token", w.Code)
+	}
+
+	w := adminRequest(server, http.MethodGet, "/rooms/admin-http", "secret")
+	var room map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &room); w.Code != http.StatusOK || err != nil || room["id"] != "admin-http" {
+		t.Errorf("got %d %s want the room", w.Code, w.Body)
+	}
+	if strings.Contains(w.Body.String(), "admin-http-hash") {
+		t.Errorf("got %s want the room without its password hash", w.Body)
+	}
+	if w := adminRequest(server, http.MethodGet, "/rooms", "secret"); strings.Contains(w.Body.String(), "admin-http-hash") {
+		t.Errorf("got %s want the rooms without their password hashes", w.Body)
+	}
+	if w := adminRequest(server, http.MethodGet, "/rooms/no-such-room", "secret"); w.Code != http.StatusNotFound {
+		t.Errorf("got %d want 404 for a missing room", w.Code)
+	}
+
+	w = adminRequest(server, http.MethodGet, "/rooms/admin-http/peers", "secret")
+	var peers []map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &peers); err != nil || len(peers) != 1 || peers[0]["id"] != "admin-http-peer" {
+		t.Errorf("got %d %s want the room's peer", w.Code, w.Body)
+	}
+
+	if w := adminRequest(server, http.MethodGet, "/peers/admin-http-peer", "secret"); w.Code != http.StatusNotFound {
+		t.Errorf("got %d want 404 for a peer with no status", w.Code)
+	}
+	packed, _ := proto.Marshal(&pb.PeerStatus{Pid: "admin-http-peer", RoomID: "admin-http", IceState: "connected"})
+	redis.Set(pb.KeyPeerStatus("admin-http-peer"), packed, 0)
+	defer redis.Del(pb.KeyPeerStatus("admin-http-peer"))
+	w = adminRequest(server, http.MethodGet, "/peers/admin-http-peer", "secret")
+	var status map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &status); w.Code != http.StatusOK || err != nil || status["iceState"] != "connected" {
+		t.Errorf("got %d %s want the peer's status", w.Code, w.Body)
+	}
+
+	if w := adminRequest(server, http.MethodDelete, "/peers/admin-http-peer", "secret"); w.Code != http.StatusNoContent {
+		t.Errorf("got %d %s want the peer kicked", w.Code, w.Body)
+	}
+	if kill, _ := mgr.GetQueue(pb.KeyTopicToPeer("admin-http-peer")).Count(); kill != 1 {
+		t.Errorf("got %d messages to the peer want its kill", kill)
+	}
+	if w := adminRequest(server, http.MethodDelete, "/peers/no-such-peer", "secret"); w.Code != http.StatusNotFound {
+		t.Errorf("got %d want 404 for a missing peer", w.Code)
+	}
+
+	if w := adminRequest(server, http.MethodDelete, "/rooms/admin-http", "secret"); w.Code != http.StatusNoContent {
+		t.Errorf("got %d %s want the room closed", w.Code, w.Body)
+	}
+	if w := adminRequest(server, http.MethodPost, "/rooms", "secret"); w.Code != http.StatusNotFound {
+		t.Errorf("got %d want 404 for an unknown route", w.Code)
+	}
+}
+
+func TestAdminServerHealthz(t *testing.T) {
+	mgr, _ := noir.NewTestSetup()
+	server := NewAdminServer(mgr, "secret")
+	// the worker is not consuming its queue
+	w := adminRequest(server, http.MethodGet, "/healthz", "")
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("got %d %s want 503 before the worker runs", w.Code, w.Body)
+	}
+
+	worker := *mgr.GetWorker()
+	go worker.HandleForever()
+	defer worker.Stop()
+	time.Sleep(100 * time.Millisecond)
+	if w := adminRequest(server, http.MethodGet, "/healthz", ""); w.Code != http.StatusOK {
+		t.Errorf("got %d %s want 200 without a token once ready", w.Code, w.Body)
+	}
+}
+
+func TestAdminServerCluster(t *testing.T) {
+	mgr, redis := noir.NewTestSetup()
+	redis.Del(pb.KeyRoomData("admin-cluster"), pb.KeyRoomUsers("admin-cluster"))
+	defer redis.Del(pb.KeyRoomData("admin-cluster"), pb.KeyRoomUsers("admin-cluster"))
+	mgr.SetRoomData(&pb.RoomData{Id: "admin-cluster", Options: &pb.RoomOptions{MaxAgeSeconds: -1}})
+	worker := *mgr.GetWorker()
+	go worker.HandleNext(2 * time.Second)
+
+	server := NewAdminServer(mgr, "secret")
+	w := adminRequest(server, http.MethodGet, "/cluster", "secret")
+	var state noir.ClusterState
+	if err := json.Unmarshal(w.Body.Bytes(), &state); w.Code != http.StatusOK || err != nil {
+		t.Fatalf("got %d %s want the cluster state", w.Code, w.Body)
+	}
+	replied, found := false, false
+	for _, status := range state.Workers {
+		replied = replied || (status.ID == worker.ID() && status.Replied)
+	}
+	for _, room := range state.Rooms {
+		found = found || room.ID == "admin-cluster"
+	}
+	if !replied || !found {
+		t.Errorf("got %s want the worker's reply and the room", w.Body)
+	}
+}
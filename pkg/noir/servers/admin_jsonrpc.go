@@ -77,7 +77,7 @@ func (a *adminJSONRPC) Close() {
 }
 
 func (a *adminJSONRPC) Listen(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.Request) {
-	topic := pb.KeyTopicToAdmin(a.clientID)
+	topic := a.manager.Keys().TopicToAdmin(a.clientID)
 	recv := a.manager.GetQueue(topic)
 
 	log.Infof("admin bridge %s", topic)
@@ -4,19 +4,27 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/go-redis/redis"
 	"github.com/golang/protobuf/proto"
 	noir "github.com/net-prophet/noir/pkg/noir"
 	pb "github.com/net-prophet/noir/pkg/proto"
 	log "github.com/pion/ion-log"
 	"github.com/pion/webrtc/v3"
 	"github.com/sourcegraph/jsonrpc2"
+	"google.golang.org/protobuf/encoding/protojson"
+	"io"
 	strings "strings"
+	"sync"
 	"time"
 )
 
 type clientJSONRPCBridge struct {
+	mu      sync.Mutex
 	pid     string
 	manager *noir.Manager
+	events  *redis.PubSub
+	// joins without a token are rate limited by this, the client's IP behind a Gateway
+	rateKey string
 }
 
 // Trickle message sent when renegotiating the peer connection
@@ -29,24 +37,54 @@ func NewClientJSONRPCBridge(pid string, manager *noir.Manager) *clientJSONRPCBri
 	return &clientJSONRPCBridge{pid: pid, manager: manager}
 }
 
+// peerID is the pid the socket speaks for, a resume or an assigned pid changes it
+func (s *clientJSONRPCBridge) peerID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pid
+}
+
+func (s *clientJSONRPCBridge) setPeerID(pid string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pid = pid
+}
+
 // Handle incoming RPC call events like join, answer, offer and trickle
 func (s *clientJSONRPCBridge) Handle(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.Request) {
-	replyError := func(err error) {
+	replyError := func(code int64, err error) {
+		// notifications never get a reply, not even an error
+		if req.Notif {
+			log.Warnf("jsonrpc %s notification error: %s", req.Method, err)
+			return
+		}
 		_ = conn.ReplyWithError(ctx, req.ID, &jsonrpc2.Error{
-			Code:    500,
+			Code:    code,
 			Message: fmt.Sprintf("%s", err),
 		})
 	}
-	// TODO: why is this wrapped in quotes?
 
-	requestId := strings.Replace(req.ID.String(), "\"", "", -1)
+	requestId := ""
+	if !req.Notif {
+		requestId = requestID(req.ID)
+	}
+
+	// the trace the request is part of, the client's or a new one
+	traceID := paramsTraceID(req.Params)
+	if traceID == "" {
+		traceID = noir.NewTraceID()
+	}
+	enqueue := func(queue noir.Queue, request *pb.NoirRequest) {
+		request.TraceID = traceID
+		noir.EnqueueRequest(queue, request)
+	}
 
 	router := (*s.manager).GetRouter()
 	routerQueue := (*router).GetQueue()
 
-	toPeerQueue := s.manager.GetQueue(pb.KeyTopicToPeer(s.pid))
+	toPeerQueue := s.manager.GetQueue(s.manager.Keys().TopicToPeer(s.peerID()))
 
-	log.Debugf("from jsonrpc %s %s", s.pid, req.Method)
+	log.Debugf("from jsonrpc %s %s", s.peerID(), req.Method)
 
 	switch req.Method {
 
@@ -57,7 +95,12 @@ func (s *clientJSONRPCBridge) Handle(ctx context.Context, conn *jsonrpc2.Conn, r
 
 		if err != nil {
 			log.Errorf("connect: error parsing offer: %v", err)
-			replyError(err)
+			replyError(jsonrpc2.CodeInvalidParams, err)
+			break
+		}
+		role, err := noir.ParseRole(join.Role)
+		if err != nil {
+			replyError(jsonrpc2.CodeInvalidParams, err)
 			break
 		}
 
@@ -65,27 +108,43 @@ func (s *clientJSONRPCBridge) Handle(ctx context.Context, conn *jsonrpc2.Conn, r
 			Command: &pb.NoirRequest_Signal{
 				Signal: &pb.SignalRequest{
 					// SignalRequest.id should be called pid but we are ion-sfu compatible
-					Id:        s.pid,
+					Id:        s.peerID(),
 					RequestId: requestId,
 					Payload: &pb.SignalRequest_Join{&pb.JoinRequest{
-						Sid:         join.Sid,
-						Description: []byte(join.Offer.SDP),
+						Sid:             join.Sid,
+						Description:     []byte(join.Offer.SDP),
+						Limits:          join.Limits,
+						Token:           join.Token,
+						Password:        join.Password,
+						QualityReports:  join.QualityReports,
+						Keepalive:       join.Keepalive,
+						Subscribe:       join.Subscribe,
+						NoAutoSubscribe: join.NoAutoSubscribe,
+						AdaptiveLayers:  join.AdaptiveLayers,
+						Role:            role,
+						RateKey:         s.rateKey,
+						Compression:     noir.SupportedCompressions,
 					},
 					},
+					TrackLabels: join.TrackLabels,
 				},
 			}}
 
-		noir.EnqueueRequest(*routerQueue, command)
+		enqueue(*routerQueue, command)
 
 		go s.Listen(ctx, conn, req)
+		s.events = s.manager.SubscribeRoomEvents(join.Sid)
+		go s.ListenRoomEvents(ctx, conn, s.events)
 
 	case "offer":
 		var negotiation noir.Negotiation
 		err := json.Unmarshal(*req.Params, &negotiation)
+		labels := negotiation.TrackLabels
+		negotiation.TrackLabels = nil
 		marshaled, _ := json.Marshal(negotiation)
 		if err != nil {
 			log.Errorf("connect: error parsing offer: %v", err)
-			replyError(err)
+			replyError(jsonrpc2.CodeInvalidParams, err)
 			break
 		}
 
@@ -93,15 +152,16 @@ func (s *clientJSONRPCBridge) Handle(ctx context.Context, conn *jsonrpc2.Conn, r
 			Command: &pb.NoirRequest_Signal{
 				Signal: &pb.SignalRequest{
 					// SignalRequest.id should be called pid but we are ion-sfu compatible
-					Id:        s.pid,
+					Id:        s.peerID(),
 					RequestId: requestId,
 					Payload: &pb.SignalRequest_Description{
 						Description: marshaled,
 					},
+					TrackLabels: labels,
 				},
 			}}
 
-		noir.EnqueueRequest(toPeerQueue, command)
+		enqueue(toPeerQueue, command)
 
 	case "answer":
 		var negotiation noir.Negotiation
@@ -109,7 +169,7 @@ func (s *clientJSONRPCBridge) Handle(ctx context.Context, conn *jsonrpc2.Conn, r
 		marshaled, _ := json.Marshal(negotiation)
 		if err != nil {
 			log.Errorf("connect: error parsing offer: %v", err)
-			replyError(err)
+			replyError(jsonrpc2.CodeInvalidParams, err)
 			break
 		}
 
@@ -117,7 +177,7 @@ func (s *clientJSONRPCBridge) Handle(ctx context.Context, conn *jsonrpc2.Conn, r
 			Command: &pb.NoirRequest_Signal{
 				Signal: &pb.SignalRequest{
 					// SignalRequest.id should be called pid but we are ion-sfu compatible
-					Id:        s.pid,
+					Id:        s.peerID(),
 					RequestId: requestId,
 					Payload: &pb.SignalRequest_Description{
 						Description: marshaled,
@@ -125,55 +185,310 @@ func (s *clientJSONRPCBridge) Handle(ctx context.Context, conn *jsonrpc2.Conn, r
 				},
 			}}
 
-		noir.EnqueueRequest(toPeerQueue, command)
+		enqueue(toPeerQueue, command)
 
 	case "trickle":
 		var trickle Trickle
 		err := json.Unmarshal(*req.Params, &trickle)
 		if err != nil {
 			log.Errorf("connect: error parsing candidate: %v", err)
-			replyError(err)
+			replyError(jsonrpc2.CodeInvalidParams, err)
 			break
 		}
-		var target pb.Trickle_Target
-		if trickle.Target == 1 {
-			target = pb.Trickle_PUBLISHER
-		} else {
-			target = pb.Trickle_SUBSCRIBER
+		command := &pb.NoirRequest{
+			Command: &pb.NoirRequest_Signal{
+				Signal: &pb.SignalRequest{
+					// SignalRequest.id should be called pid but we are ion-sfu compatible
+					Id:        s.peerID(),
+					RequestId: requestId,
+					Payload:   &pb.SignalRequest_Trickle{Trickle: trickleToProto(trickle)},
+				},
+			}}
+		enqueue(toPeerQueue, command)
+
+	case "tricklebatch":
+		var trickles []Trickle
+		err := json.Unmarshal(*req.Params, &trickles)
+		if err != nil {
+			log.Errorf("connect: error parsing candidates: %v", err)
+			replyError(jsonrpc2.CodeInvalidParams, err)
+			break
+		}
+		batch := &pb.TrickleBatch{}
+		for _, trickle := range trickles {
+			batch.Candidates = append(batch.Candidates, trickleToProto(trickle))
 		}
-		marshaled, _ := json.Marshal(trickle.Candidate)
 
 		command := &pb.NoirRequest{
 			Command: &pb.NoirRequest_Signal{
 				Signal: &pb.SignalRequest{
 					// SignalRequest.id should be called pid but we are ion-sfu compatible
-					Id:        s.pid,
+					Id:        s.peerID(),
 					RequestId: requestId,
-					Payload: &pb.SignalRequest_Trickle{Trickle: &pb.Trickle{
-						Target: target,
-						Init:   string(marshaled),
+					Payload:   &pb.SignalRequest_TrickleBatch{TrickleBatch: batch},
+				},
+			}}
+		enqueue(toPeerQueue, command)
+
+	case "data":
+		var data noir.Data
+		err := json.Unmarshal(*req.Params, &data)
+		if err != nil {
+			log.Errorf("connect: error parsing data: %v", err)
+			replyError(jsonrpc2.CodeInvalidParams, err)
+			break
+		}
+
+		command := &pb.NoirRequest{
+			Command: &pb.NoirRequest_Signal{
+				Signal: &pb.SignalRequest{
+					// SignalRequest.id should be called pid but we are ion-sfu compatible
+					Id:        s.peerID(),
+					RequestId: requestId,
+					Payload: &pb.SignalRequest_Data{Data: &pb.DataMessage{
+						Label:   data.Label,
+						Payload: data.Payload,
+						To:      data.To,
 					}},
 				},
 			}}
-		noir.EnqueueRequest(toPeerQueue, command)
+		enqueue(toPeerQueue, command)
+
+	case "resume":
+		var resume noir.Resume
+		err := json.Unmarshal(*req.Params, &resume)
+		if err != nil {
+			log.Errorf("connect: error parsing resume: %v", err)
+			replyError(jsonrpc2.CodeInvalidParams, err)
+			break
+		}
+		if s.events != nil {
+			replyError(jsonrpc2.CodeInvalidRequest, fmt.Errorf("already joined as %s", s.peerID()))
+			break
+		}
+		userData, err := s.manager.GetRemoteUserData(resume.Pid)
+		if err != nil || userData == nil {
+			replyError(jsonrpc2.CodeInvalidParams, fmt.Errorf("peer %s not found", resume.Pid))
+			break
+		}
+		// the socket must not read the peer's replies unless it is the client that joined
+		if err := s.manager.CheckResumeToken(resume.Pid, resume.Token); err != nil {
+			replyError(jsonrpc2.CodeInvalidParams, err)
+			break
+		}
+
+		// From now on this socket speaks for the resumed peer
+		s.setPeerID(resume.Pid)
+
+		command := &pb.NoirRequest{
+			Command: &pb.NoirRequest_Signal{
+				Signal: &pb.SignalRequest{
+					Id:          s.peerID(),
+					RequestId:   requestId,
+					Payload:     &pb.SignalRequest_Resume{Resume: true},
+					ResumeToken: resume.Token,
+				},
+			}}
+
+		enqueue(*routerQueue, command)
+
+		go s.Listen(ctx, conn, req)
+		s.events = s.manager.SubscribeRoomEvents(userData.RoomID)
+		go s.ListenRoomEvents(ctx, conn, s.events)
+
+	case "capabilities":
+		var query noir.CapabilitiesQuery
+		if err := json.Unmarshal(*req.Params, &query); err != nil {
+			log.Errorf("connect: error parsing capabilities: %v", err)
+			replyError(jsonrpc2.CodeInvalidParams, err)
+			break
+		}
+		// answered under a pid of its own, so the answer does not mix with the peer's replies
+		replyTo := "capabilities-" + noir.NewTraceID()
+		enqueue(*routerQueue, &pb.NoirRequest{
+			Command: &pb.NoirRequest_Signal{
+				Signal: &pb.SignalRequest{
+					Id:        replyTo,
+					RequestId: requestId,
+					Payload: &pb.SignalRequest_Capabilities{
+						Capabilities: &pb.CapabilitiesRequest{Sid: query.Sid, Token: query.Token},
+					},
+				},
+			}})
+		go s.ListenOnce(ctx, conn, replyTo)
+
+	case "setlayer":
+		var setLayer noir.SetLayer
+		err := json.Unmarshal(*req.Params, &setLayer)
+		if err != nil {
+			log.Errorf("connect: error parsing setlayer: %v", err)
+			replyError(jsonrpc2.CodeInvalidParams, err)
+			break
+		}
+
+		command := &pb.NoirRequest{
+			Command: &pb.NoirRequest_Signal{
+				Signal: &pb.SignalRequest{
+					// SignalRequest.id should be called pid but we are ion-sfu compatible
+					Id:        s.peerID(),
+					RequestId: requestId,
+					Payload: &pb.SignalRequest_SetLayer{SetLayer: &pb.SetLayerRequest{
+						StreamId: setLayer.StreamID,
+						Spatial:  setLayer.Spatial,
+						Temporal: setLayer.Temporal,
+					}},
+				},
+			}}
+		enqueue(toPeerQueue, command)
+
+	case "subscribe", "unsubscribe":
+		var subscriptions noir.Subscriptions
+		err := json.Unmarshal(*req.Params, &subscriptions)
+		if err != nil {
+			log.Errorf("connect: error parsing %s: %v", req.Method, err)
+			replyError(jsonrpc2.CodeInvalidParams, err)
+			break
+		}
+		request := &pb.SubscribeRequest{Pids: subscriptions.Pids}
+		signal := &pb.SignalRequest{
+			// SignalRequest.id should be called pid but we are ion-sfu compatible
+			Id:        s.peerID(),
+			RequestId: requestId,
+			Payload:   &pb.SignalRequest_Subscribe{Subscribe: request},
+		}
+		if req.Method == "unsubscribe" {
+			signal.Payload = &pb.SignalRequest_Unsubscribe{Unsubscribe: request}
+		}
+		enqueue(toPeerQueue, &pb.NoirRequest{
+			Command: &pb.NoirRequest_Signal{Signal: signal},
+		})
+
+	case "pong":
+		var pong noir.Ping
+		if err := json.Unmarshal(*req.Params, &pong); err != nil {
+			replyError(jsonrpc2.CodeInvalidParams, err)
+			break
+		}
+		enqueue(toPeerQueue, &pb.NoirRequest{
+			Command: &pb.NoirRequest_Signal{
+				Signal: &pb.SignalRequest{
+					// SignalRequest.id should be called pid but we are ion-sfu compatible
+					Id:      s.peerID(),
+					Payload: &pb.SignalRequest_Pong{Pong: pong.Seq},
+				},
+			}})
+
+	case "getstats":
+		enqueue(toPeerQueue, &pb.NoirRequest{
+			Command: &pb.NoirRequest_Signal{
+				Signal: &pb.SignalRequest{
+					// SignalRequest.id should be called pid but we are ion-sfu compatible
+					Id:        s.peerID(),
+					RequestId: requestId,
+					Payload:   &pb.SignalRequest_GetStats{GetStats: &pb.GetStatsRequest{}},
+				},
+			}})
+
+	case "roomadmin":
+		// a moderator running room admin in its room, the params are a RoomAdminRequest as protojson
+		var roomAdmin pb.RoomAdminRequest
+		params, err := withoutTraceID(req.Params)
+		if err == nil {
+			err = protojson.Unmarshal(params, &roomAdmin)
+		}
+		if err != nil {
+			log.Errorf("connect: error parsing roomadmin: %v", err)
+			replyError(jsonrpc2.CodeInvalidParams, err)
+			break
+		}
+		if s.events == nil {
+			replyError(jsonrpc2.CodeInvalidRequest, fmt.Errorf("join before running room admin"))
+			break
+		}
+		// the worker checks the socket's peer may, whoever the client says it is, see roles.go
+		roomAdmin.CallerID = s.peerID()
+		enqueue(*routerQueue, &pb.NoirRequest{
+			Command: &pb.NoirRequest_Admin{
+				Admin: &pb.AdminRequest{
+					Payload: &pb.AdminRequest_RoomAdmin{RoomAdmin: &roomAdmin},
+				},
+			}})
+		if !req.Notif {
+			send(ctx, conn, rpcMessage{ID: &req.ID, Result: true, TraceID: traceID})
+		}
+
+	case "leave":
+		command := &pb.NoirRequest{
+			Command: &pb.NoirRequest_Signal{
+				Signal: &pb.SignalRequest{
+					// SignalRequest.id should be called pid but we are ion-sfu compatible
+					Id:        s.peerID(),
+					RequestId: requestId,
+					Payload:   &pb.SignalRequest_Leave{Leave: true},
+				},
+			}}
+		enqueue(toPeerQueue, command)
+
+	default:
+		replyError(jsonrpc2.CodeMethodNotFound, fmt.Errorf("unknown method %s", req.Method))
 	}
 }
 
+// Close detaches the peer, it is disconnected unless resumed within the manager's ResumeGrace()
 func (s *clientJSONRPCBridge) Close() {
-	s.manager.DisconnectUser(s.pid)
+	s.manager.DetachClient(s.peerID())
+	if s.events != nil {
+		s.events.Close()
+	}
+}
+
+// ListenRoomEvents forwards room presence to the client as "presence" notifications
+func (s *clientJSONRPCBridge) ListenRoomEvents(ctx context.Context, conn *jsonrpc2.Conn, events *redis.PubSub) {
+	for message := range events.Channel() {
+		var reply pb.NoirReply
+		if err := proto.Unmarshal([]byte(message.Payload), &reply); err != nil {
+			log.Errorf("unmarshal err: %s", err)
+			continue
+		}
+		event := reply.GetEvent()
+		// the peer hears about speakers and layers from its own replies, see translateReply, and
+		// presence is about other peers, not the room, but a peer is told when the room is handed to it
+		self := event.GetPid() == s.peerID() && event.GetType() != pb.RoomEvent_OWNER_CHANGED
+		fromReplies := event.GetType() == pb.RoomEvent_ACTIVE_SPEAKER || event.GetType() == pb.RoomEvent_LAYERS
+		if event == nil || self || event.Pid == "" || fromReplies {
+			continue
+		}
+		presence := noir.Presence{
+			Pid:   event.Pid,
+			Sid:   event.Sid,
+			Event: strings.ToLower(event.Type.String()),
+			At:    event.At.AsTime(),
+			Kind:  event.Kind,
+		}
+		if event.Type == pb.RoomEvent_LEFT {
+			presence.Reason = closeReason(event.Reason)
+		}
+		conn.Notify(ctx, "presence", presence)
+	}
 }
 
 func (s *clientJSONRPCBridge) Listen(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.Request) {
-	//send := s.manager.GetQueue(pb.KeyTopicToPeer(s.pid), noir.PeerPingFrequency)
-	recv := s.manager.GetQueue(pb.KeyTopicFromPeer(s.pid))
+	//send := s.manager.GetQueue(s.manager.Keys().TopicToPeer(s.pid), noir.PeerPingFrequency)
+	recv := s.manager.GetQueue(s.manager.Keys().TopicFromPeer(s.peerID()))
 
-	log.Infof("peer bridge %s", s.pid)
+	log.Infof("peer bridge %s", s.peerID())
 
 	for {
 
-		message, err := recv.BlockUntilNext(0)
+		// Poll so we notice the socket closing, a detached peer gets no Kill
+		message, err := recv.BlockUntilNext(noir.WorkerPollTimeout)
 
-		if err != nil {
+		if err == io.EOF {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		} else if err != nil {
 			log.Errorf("message err: %s", err)
 			time.Sleep(time.Second)
 			continue
@@ -181,54 +496,95 @@ func (s *clientJSONRPCBridge) Listen(ctx context.Context, conn *jsonrpc2.Conn, r
 
 		var reply pb.NoirReply
 
-		err = proto.Unmarshal(message, &reply)
+		err = noir.UnmarshalReply(message, &reply)
 		if err != nil {
 			log.Errorf("unmarshal err: %s", err)
 			continue
 		}
 
-		switch reply.Command.(type) {
-		case *pb.NoirReply_Signal:
-			signal := reply.GetSignal()
-			reqID := jsonrpc2.ID{Num: 0, Str: signal.RequestId, IsString: true}
-			switch signal.Payload.(type) {
-			case *pb.SignalReply_Kill:
-				return
-			case *pb.SignalReply_Join:
-				var answer webrtc.SessionDescription
-				json.Unmarshal(signal.GetJoin().Description, &answer)
-				conn.Reply(ctx, reqID, answer)
-				//log.Debugf("answer %s", answer)
-			case *pb.SignalReply_Description:
-				var desc webrtc.SessionDescription
-				json.Unmarshal(signal.GetDescription(), &desc)
-				var method string
-				if desc.Type == webrtc.SDPTypeAnswer {
-					method = "answer"
-				} else {
-					method = "offer"
-				}
-				if signal.RequestId == "" {
-					conn.Notify(ctx, method, desc)
-					//log.Debugf("notify %s %s", method, desc)
-				} else {
-					conn.Reply(ctx, reqID, desc)
-					//log.Debugf("notify %s %s", method, desc)
-				}
-			case *pb.SignalReply_Trickle:
-				trickle := signal.GetTrickle()
-				var candidate webrtc.ICECandidateInit
-				json.Unmarshal([]byte(trickle.GetInit()), &candidate)
-				conn.Notify(ctx, "trickle", Trickle{
-					Target:    int(trickle.Target.Number()),
-					Candidate: candidate,
-				})
-				//log.Debugf("trickle %s", trickle)
-			default:
-				log.Errorf("unknown servers reply %s", signal)
-			}
-		default:
+		signal := reply.GetSignal()
+		if signal == nil {
 			log.Warnf("non-servers reply on client channel %s", &reply)
+			continue
+		}
+		// the server assigned the peer its pid, the join's topic is done with
+		if assigned := signal.GetJoin().GetPid(); assigned != "" {
+			s.setPeerID(assigned)
+			recv = s.manager.GetQueue(s.manager.Keys().TopicFromPeer(assigned))
+		}
+		messages := translateReply(signal)
+		if messages == nil {
+			log.Errorf("unknown servers reply %s", signal)
+		}
+		for _, message := range messages {
+			message.TraceID = reply.TraceID
+			send(ctx, conn, message)
 		}
+		if signal.GetKill() {
+			return
+		}
+	}
+}
+
+// ListenOnce forwards the one reply to pid, a request answered apart from the peer's replies
+func (s *clientJSONRPCBridge) ListenOnce(ctx context.Context, conn *jsonrpc2.Conn, pid string) {
+	recv := s.manager.GetQueue(s.manager.Keys().TopicFromPeer(pid))
+	defer recv.Cleanup()
+	message, err := recv.BlockUntilNext(s.manager.WebrtcTimeout())
+	if err != nil {
+		log.Warnf("no reply to %s: %s", pid, err)
+		return
+	}
+	var reply pb.NoirReply
+	if err := noir.UnmarshalReply(message, &reply); err != nil {
+		log.Errorf("unmarshal err: %s", err)
+		return
+	}
+	for _, message := range translateReply(reply.GetSignal()) {
+		message.TraceID = reply.TraceID
+		send(ctx, conn, message)
+	}
+}
+
+// send writes the message to the socket as a reply or a notification
+func send(ctx context.Context, conn *jsonrpc2.Conn, message rpcMessage) {
+	var err error
+	if message.ID == nil {
+		var options []jsonrpc2.CallOption
+		if message.TraceID != "" {
+			options = append(options, jsonrpc2.Meta(rpcMeta{TraceID: message.TraceID}))
+		}
+		err = conn.Notify(ctx, message.Method, message.Params, options...)
+	} else {
+		var response *jsonrpc2.Response
+		if response, err = message.response(); err == nil {
+			err = conn.SendResponse(ctx, response)
+		}
+	}
+	if err != nil {
+		log.Debugf("jsonrpc send error %s", err)
+	}
+}
+
+func trickleToProto(trickle Trickle) *pb.Trickle {
+	var target pb.Trickle_Target
+	if trickle.Target == 1 {
+		target = pb.Trickle_PUBLISHER
+	} else {
+		target = pb.Trickle_SUBSCRIBER
+	}
+	marshaled, _ := json.Marshal(trickle.Candidate)
+	return &pb.Trickle{
+		Target: target,
+		Init:   string(marshaled),
+	}
+}
+
+func trickleFromProto(trickle *pb.Trickle) Trickle {
+	var candidate webrtc.ICECandidateInit
+	json.Unmarshal([]byte(trickle.GetInit()), &candidate)
+	return Trickle{
+		Target:    int(trickle.Target.Number()),
+		Candidate: candidate,
 	}
 }
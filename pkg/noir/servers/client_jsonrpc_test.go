@@ -0,0 +1,234 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/gorilla/websocket"
+	"github.com/net-prophet/noir/pkg/noir"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/webrtc/v3"
+	"github.com/sourcegraph/jsonrpc2"
+	websocketjsonrpc2 "github.com/sourcegraph/jsonrpc2/websocket"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func notify(method string, params string) *jsonrpc2.Request {
+	raw := json.RawMessage(params)
+	return &jsonrpc2.Request{Method: method, Params: &raw, Notif: true}
+}
+
+// nextRequest is the next request queued on queue
+func nextRequest(t *testing.T, queue noir.Queue) *pb.NoirRequest {
+	t.Helper()
+	message, err := queue.BlockUntilNext(time.Second)
+	if err != nil {
+		t.Fatalf("got %s waiting on %s", err, queue.Topic())
+	}
+	request := &pb.NoirRequest{}
+	if err := noir.UnmarshalRequest(message, request); err != nil {
+		t.Fatalf("error reading a request: %s", err)
+	}
+	return request
+}
+
+// notifications is a socket's client, it hands on what the gateway notifies
+type notifications chan *jsonrpc2.Request
+
+func (n notifications) Handle(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.Request) {
+	n <- req
+}
+
+func (n notifications) next(t *testing.T, method string) *jsonrpc2.Request {
+	t.Helper()
+	select {
+	case req := <-n:
+		if req.Method != method {
+			t.Fatalf("got %s want %s", req.Method, method)
+		}
+		return req
+	case <-time.After(time.Second):
+		t.Fatalf("got no %s", method)
+	}
+	return nil
+}
+
+func TestGatewayRoundTrip(t *testing.T) {
+	mgr, _ := noir.NewTestSetup()
+	mgr.SetResumeGrace(0)
+	routerQueue := *(*mgr.GetRouter()).GetQueue()
+	routerQueue.Cleanup()
+	defer routerQueue.Cleanup()
+
+	server := httptest.NewServer(NewGateway(mgr))
+	defer server.Close()
+	socket, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("error dialing the gateway: %s", err)
+	}
+	ctx := context.Background()
+	notified := make(notifications, 10)
+	client := jsonrpc2.NewConn(ctx, websocketjsonrpc2.NewObjectStream(socket), notified)
+	defer client.Close()
+
+	offer := webrtc.SessionDescription{Type: webrtc.SDPTypeOffer, SDP: "v=0\r\n"}
+	answer := webrtc.SessionDescription{Type: webrtc.SDPTypeAnswer, SDP: "v=0\r\n"}
+	packedAnswer, _ := json.Marshal(answer)
+	// the gateway's calls answered as a worker would, by the request's pid and id
+	var pid string
+	call := func(method string, params interface{}, answered func(request *pb.NoirRequest) *pb.SignalReply) webrtc.SessionDescription {
+		t.Helper()
+		result := make(chan webrtc.SessionDescription, 1)
+		go func() {
+			var desc webrtc.SessionDescription
+			if err := client.Call(ctx, method, params, &desc); err != nil {
+				t.Errorf("error calling %s: %s", method, err)
+			}
+			result <- desc
+		}()
+		var request *pb.NoirRequest
+		if method == "join" {
+			request = nextRequest(t, routerQueue)
+			pid = request.GetSignal().GetId()
+		} else {
+			request = nextRequest(t, mgr.GetQueue(pb.KeyTopicToPeer(pid)))
+		}
+		signal := request.GetSignal()
+		reply := answered(request)
+		reply.Id, reply.RequestId = signal.Id, signal.RequestId
+		mgr.EnqueueReply(mgr.GetQueue(pb.KeyTopicFromPeer(signal.Id)), &pb.NoirReply{Command: &pb.NoirReply_Signal{Signal: reply}})
+		select {
+		case desc := <-result:
+			return desc
+		case <-time.After(time.Second):
+			t.Fatalf("got no answer to %s", method)
+		}
+		return webrtc.SessionDescription{}
+	}
+
+	joined := call("join", noir.Join{Sid: "gateway-room", Offer: offer}, func(request *pb.NoirRequest) *pb.SignalReply {
+		if join := request.GetSignal().GetJoin(); join.GetSid() != "gateway-room" || string(join.GetDescription()) != offer.SDP {
+			t.Errorf("got %s want the join to gateway-room with its offer", join)
+		}
+		return &pb.SignalReply{Payload: &pb.SignalReply_Join{Join: &pb.JoinReply{Description: packedAnswer}}}
+	})
+	if joined != answer {
+		t.Errorf("got %v want the join's answer", joined)
+	}
+	defer mgr.GetQueue(pb.KeyTopicToPeer(pid)).Cleanup()
+	defer mgr.GetQueue(pb.KeyTopicFromPeer(pid)).Cleanup()
+
+	// trickles both ways
+	candidate := webrtc.ICECandidateInit{Candidate: "candidate:1 1 udp 2130706431 10.0.0.2 50000 typ host"}
+	client.Notify(ctx, "trickle", Trickle{Target: 1, Candidate: candidate})
+	trickle := nextRequest(t, mgr.GetQueue(pb.KeyTopicToPeer(pid))).GetSignal().GetTrickle()
+	if trickle.GetTarget() != pb.Trickle_PUBLISHER || !strings.Contains(trickle.GetInit(), candidate.Candidate) {
+		t.Errorf("got %s want the client's candidate for the publisher", trickle)
+	}
+	mgr.EnqueueReply(mgr.GetQueue(pb.KeyTopicFromPeer(pid)), &pb.NoirReply{Command: &pb.NoirReply_Signal{Signal: &pb.SignalReply{
+		Id: pid, Payload: &pb.SignalReply_Trickle{Trickle: trickleToProto(Trickle{Target: 0, Candidate: candidate})},
+	}}})
+	var sent Trickle
+	json.Unmarshal(*notified.next(t, "trickle").Params, &sent)
+	if sent.Candidate.Candidate != candidate.Candidate {
+		t.Errorf("got %v want the sfu's candidate", sent)
+	}
+
+	// a renegotiation the client offers
+	renegotiated := call("offer", noir.Negotiation{Desc: offer}, func(request *pb.NoirRequest) *pb.SignalReply {
+		var negotiation noir.Negotiation
+		json.Unmarshal(request.GetSignal().GetDescription(), &negotiation)
+		if negotiation.Desc != offer {
+			t.Errorf("got %v want the client's offer", negotiation.Desc)
+		}
+		return &pb.SignalReply{Payload: &pb.SignalReply_Description{Description: packedAnswer}}
+	})
+	if renegotiated != answer {
+		t.Errorf("got %v want the offer's answer", renegotiated)
+	}
+
+	// and one the sfu offers
+	packedOffer, _ := json.Marshal(offer)
+	mgr.EnqueueReply(mgr.GetQueue(pb.KeyTopicFromPeer(pid)), &pb.NoirReply{Command: &pb.NoirReply_Signal{Signal: &pb.SignalReply{
+		Id: pid, Payload: &pb.SignalReply_Description{Description: packedOffer},
+	}}})
+	var offered webrtc.SessionDescription
+	json.Unmarshal(*notified.next(t, "offer").Params, &offered)
+	if offered != offer {
+		t.Errorf("got %v want the sfu's offer", offered)
+	}
+	client.Notify(ctx, "answer", noir.Negotiation{Desc: answer})
+	var answered noir.Negotiation
+	json.Unmarshal(nextRequest(t, mgr.GetQueue(pb.KeyTopicToPeer(pid))).GetSignal().GetDescription(), &answered)
+	if answered.Desc != answer {
+		t.Errorf("got %v want the client's answer", answered.Desc)
+	}
+
+	// the socket closing kills its peer
+	client.Close()
+	if request := nextRequest(t, mgr.GetQueue(pb.KeyTopicToPeer(pid))); !request.GetSignal().GetKill() {
+		t.Errorf("got %s want the peer killed", request)
+	}
+}
+
+func TestClientRoomAdmin(t *testing.T) {
+	mgr, _ := noir.NewTestSetup()
+	routerQueue := *(*mgr.GetRouter()).GetQueue()
+	routerQueue.Cleanup()
+	defer routerQueue.Cleanup()
+
+	bridge := NewClientJSONRPCBridge("gateway-moderator", mgr)
+	kick := notify("roomadmin", `{"roomID":"gateway-room","callerID":"someone-else","kickPeer":{"pid":"gateway-target"}}`)
+	bridge.Handle(context.Background(), nil, kick)
+	if count, _ := routerQueue.Count(); count != 0 {
+		t.Errorf("got %d queued want room admin refused before joining", count)
+	}
+
+	bridge.events = mgr.SubscribeRoomEvents("gateway-room")
+	defer bridge.events.Close()
+	kick = notify("roomadmin", `{"roomID":"gateway-room","kickPeer":{"pid":"gateway-target"},"traceId":"gateway-trace"}`)
+	bridge.Handle(context.Background(), nil, kick)
+	message, err := routerQueue.BlockUntilNext(time.Second)
+	if err != nil {
+		t.Fatalf("error reading the room admin: %s", err)
+	}
+	request := &pb.NoirRequest{}
+	noir.UnmarshalRequest(message, request)
+	roomAdmin := request.GetAdmin().GetRoomAdmin()
+	if roomAdmin.GetCallerID() != "gateway-moderator" || roomAdmin.GetKickPeer().GetPid() != "gateway-target" {
+		t.Errorf("got %s want the kick stamped with the socket's pid", roomAdmin)
+	}
+	if request.GetTraceID() != "gateway-trace" {
+		t.Errorf("got trace %q want the params' traceId", request.GetTraceID())
+	}
+	if request.GetAdminID() != "" {
+		t.Errorf("got admin %s want a peer's room admin not taken for the admin api's", request.GetAdminID())
+	}
+}
+
+func TestClientCapabilities(t *testing.T) {
+	mgr, _ := noir.NewTestSetup()
+	routerQueue := *(*mgr.GetRouter()).GetQueue()
+	routerQueue.Cleanup()
+	defer routerQueue.Cleanup()
+
+	bridge := NewClientJSONRPCBridge("gateway-asker", mgr)
+	bridge.Handle(context.Background(), nil, notify("capabilities", `{"sid":"gateway-room","token":"gateway-token"}`))
+	message, err := routerQueue.BlockUntilNext(time.Second)
+	if err != nil {
+		t.Fatalf("error reading the capabilities request: %s", err)
+	}
+	request := &pb.NoirRequest{}
+	noir.UnmarshalRequest(message, request)
+	signal := request.GetSignal()
+	if ask := signal.GetCapabilities(); ask.GetSid() != "gateway-room" || ask.GetToken() != "gateway-token" {
+		t.Errorf("got %s want the room and token asked about", signal)
+	}
+	if !strings.HasPrefix(signal.GetId(), "capabilities-") {
+		t.Errorf("got pid %s want the answer under a pid of its own", signal.GetId())
+	}
+	// nobody answers, the reply would be the test's
+	mgr.GetQueue(pb.KeyTopicFromPeer(signal.GetId())).Cleanup()
+}
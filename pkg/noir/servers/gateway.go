@@ -0,0 +1,56 @@
+package servers
+
+import (
+	"github.com/gorilla/websocket"
+	"github.com/net-prophet/noir/pkg/noir"
+	log "github.com/pion/ion-log"
+	"github.com/sourcegraph/jsonrpc2"
+	websocketjsonrpc2 "github.com/sourcegraph/jsonrpc2/websocket"
+	"net"
+	"net/http"
+)
+
+// Gateway accepts browser websockets speaking the ion-sfu JSON-RPC protocol
+// (join, offer, answer, trickle...) and bridges them onto the noir queues.
+// Every socket is a new peer with a random pid, unless it resumes an old one; when the
+// socket closes the peer is detached and then killed, see Manager.DetachClient.
+// Mount it on your own mux, or use PublicJSONRPC
+type Gateway struct {
+	manager  *noir.Manager
+	upgrader websocket.Upgrader
+}
+
+func NewGateway(manager *noir.Manager) *Gateway {
+	return &Gateway{
+		manager: manager,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool {
+				return true
+			},
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+		},
+	}
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c, err := g.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Errorf("gateway upgrade error: %s", err)
+		return
+	}
+	defer c.Close()
+
+	pid := noir.RandomString(32)
+
+	p := NewClientJSONRPCBridge(pid, g.manager)
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		p.rateKey = host
+	}
+
+	// Close() detaches the user, which eventually sends Kill to its PeerChannel
+	defer p.Close()
+
+	jc := jsonrpc2.NewConn(r.Context(), websocketjsonrpc2.NewObjectStream(c), p)
+	<-jc.DisconnectNotify()
+}
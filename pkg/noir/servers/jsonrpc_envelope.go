@@ -0,0 +1,287 @@
+package servers
+
+import (
+	"encoding/json"
+	noir "github.com/net-prophet/noir/pkg/noir"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/webrtc/v3"
+	"github.com/sourcegraph/jsonrpc2"
+	"strings"
+)
+
+/*
+JSON-RPC Envelope:
+Replies come back from the worker as SignalReply, and go out to the socket as JSON-RPC 2.0
+messages. A reply carrying a RequestId answers that request, with a result or an error object of
+{code, message, data}, and echoes its id. Anything else is a notification, which has no id.
+The request id is kept in the SignalRequest.RequestId as its JSON, so a client that numbers its
+requests gets numbers back and one using strings gets strings, as the spec asks.
+
+A request may carry the trace it is part of as a traceId of its params, see request_tracing.go.
+The messages a reply turns into carry its traceID back as {"meta": {"traceId": ...}}, the
+metadata jsonrpc2 allows next to the result, so a client that sent none learns the one the
+request was given.
+*/
+
+// rpcMessage is one JSON-RPC message for the client, a reply when ID is set, a notification otherwise
+type rpcMessage struct {
+	ID      *jsonrpc2.ID
+	Method  string
+	Params  interface{}
+	Result  interface{}
+	Error   *jsonrpc2.Error
+	TraceID string
+}
+
+// rpcMeta is the meta of a message, the trace of the reply it came from
+type rpcMeta struct {
+	TraceID string `json:"traceId"`
+}
+
+// traced is the params of a request as far as its trace, params that are not an object have none
+type traced struct {
+	TraceID string `json:"traceId"`
+}
+
+func (m rpcMessage) notification() (*jsonrpc2.Request, error) {
+	notification := &jsonrpc2.Request{Method: m.Method, Notif: true}
+	if err := notification.SetParams(m.Params); err != nil {
+		return nil, err
+	}
+	if m.TraceID != "" {
+		if err := notification.SetMeta(rpcMeta{TraceID: m.TraceID}); err != nil {
+			return nil, err
+		}
+	}
+	return notification, nil
+}
+
+func (m rpcMessage) response() (*jsonrpc2.Response, error) {
+	response := &jsonrpc2.Response{ID: *m.ID, Error: m.Error}
+	if m.Error == nil {
+		if err := response.SetResult(m.Result); err != nil {
+			return nil, err
+		}
+	}
+	if m.TraceID != "" {
+		meta, err := json.Marshal(rpcMeta{TraceID: m.TraceID})
+		if err != nil {
+			return nil, err
+		}
+		response.Meta = (*json.RawMessage)(&meta)
+	}
+	return response, nil
+}
+
+func (m rpcMessage) MarshalJSON() ([]byte, error) {
+	if m.ID == nil {
+		notification, err := m.notification()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(notification)
+	}
+	response, err := m.response()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(response)
+}
+
+// paramsTraceID is the traceId a request's params carry, if any
+func paramsTraceID(params *json.RawMessage) string {
+	var trace traced
+	if params != nil {
+		json.Unmarshal(*params, &trace)
+	}
+	return trace.TraceID
+}
+
+// withoutTraceID is params without their traceId, for params parsed strictly
+func withoutTraceID(params *json.RawMessage) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(*params, &fields); err != nil {
+		return nil, err
+	}
+	delete(fields, "traceId")
+	return json.Marshal(fields)
+}
+
+// requestID is the RequestId a JSON-RPC request id travels as
+func requestID(id jsonrpc2.ID) string {
+	return id.String()
+}
+
+// replyID is the JSON-RPC id of a RequestId, false for none
+func replyID(requestId string) (*jsonrpc2.ID, bool) {
+	if requestId == "" {
+		return nil, false
+	}
+	id := &jsonrpc2.ID{}
+	if err := json.Unmarshal([]byte(requestId), id); err != nil {
+		// not from a JSON-RPC client, sent back as a string
+		id = &jsonrpc2.ID{Str: requestId, IsString: true}
+	}
+	return id, true
+}
+
+func notification(method string, params interface{}) rpcMessage {
+	return rpcMessage{Method: method, Params: params}
+}
+
+// reply answers the request when there is one, and notifies method otherwise
+func reply(requestId string, method string, result interface{}) rpcMessage {
+	id, ok := replyID(requestId)
+	if !ok {
+		return notification(method, result)
+	}
+	return rpcMessage{ID: id, Result: result}
+}
+
+func failureError(failure *pb.SignalError) *jsonrpc2.Error {
+	rpcError := &jsonrpc2.Error{
+		Code:    int64(failure.GetCode()),
+		Message: failure.GetMessage(),
+	}
+	rpcError.SetError(failure.GetCode().String())
+	return rpcError
+}
+
+// translateReply turns a SignalReply into the messages the client is sent
+func translateReply(signal *pb.SignalReply) []rpcMessage {
+	switch payload := signal.Payload.(type) {
+	case *pb.SignalReply_Kill:
+		return []rpcMessage{notification("closed", noir.Closed{Reason: closeReason(signal.CloseReason)})}
+	case *pb.SignalReply_Reconnect:
+		return []rpcMessage{notification("reconnect", true)}
+	case *pb.SignalReply_Resume:
+		return []rpcMessage{reply(signal.RequestId, "resume", payload.Resume)}
+	case *pb.SignalReply_SetLayer:
+		layer := payload.SetLayer
+		return []rpcMessage{reply(signal.RequestId, "setlayer", noir.SetLayer{
+			StreamID:     layer.StreamId,
+			Spatial:      layer.Spatial,
+			Temporal:     layer.Temporal,
+			Adapted:      layer.Adapted,
+			EstimateKbps: layer.EstimateKbps,
+		})}
+	case *pb.SignalReply_Stats:
+		return []rpcMessage{reply(signal.RequestId, "getstats", payload.Stats)}
+	case *pb.SignalReply_Capabilities:
+		return []rpcMessage{reply(signal.RequestId, "capabilities", payload.Capabilities)}
+	case *pb.SignalReply_Failure:
+		id, ok := replyID(signal.RequestId)
+		if !ok {
+			return []rpcMessage{notification("error", payload.Failure)}
+		}
+		return []rpcMessage{{ID: id, Error: failureError(payload.Failure)}}
+	case *pb.SignalReply_Subscriptions:
+		subscriptions := noir.Subscriptions{
+			Pids:   payload.Subscriptions.Pids,
+			Left:   payload.Subscriptions.Left,
+			Capped: payload.Subscriptions.Capped,
+		}
+		return []rpcMessage{reply(signal.RequestId, "subscriptions", subscriptions)}
+	case *pb.SignalReply_Tracks:
+		tracks := []noir.TrackInfo{}
+		for _, track := range payload.Tracks.Tracks {
+			tracks = append(tracks, noir.TrackInfo{
+				Mid:      track.Mid,
+				TrackID:  track.TrackID,
+				StreamID: track.StreamID,
+				Pid:      track.Pid,
+				Kind:     track.Kind,
+				Label:    track.Label,
+			})
+		}
+		return []rpcMessage{notification("tracks", tracks)}
+	case *pb.SignalReply_Layers:
+		layers := noir.TrackLayers{
+			Pid:      payload.Layers.Pid,
+			TrackID:  payload.Layers.TrackID,
+			StreamID: payload.Layers.StreamID,
+			Layers:   []noir.TrackLayer{},
+		}
+		for _, layer := range payload.Layers.Layers {
+			layers.Layers = append(layers.Layers, noir.TrackLayer{Spatial: layer.Spatial, Rid: layer.Rid, Active: layer.Active})
+		}
+		return []rpcMessage{notification("layers", layers)}
+	case *pb.SignalReply_ActiveSpeaker:
+		return []rpcMessage{notification("activeSpeaker", noir.ActiveSpeaker{
+			Pid:        payload.ActiveSpeaker.Pid,
+			AudioLevel: payload.ActiveSpeaker.AudioLevel,
+		})}
+	case *pb.SignalReply_Quality:
+		quality := payload.Quality
+		return []rpcMessage{notification("quality", noir.ConnectionQuality{
+			Level:        strings.ToLower(quality.Level.String()),
+			FractionLost: quality.FractionLost,
+			RttMs:        quality.RttMs,
+			JitterMs:     quality.JitterMs,
+		})}
+	case *pb.SignalReply_Ping:
+		return []rpcMessage{notification("ping", noir.Ping{Seq: payload.Ping})}
+	case *pb.SignalReply_Announcement:
+		return []rpcMessage{notification("announcement", noir.Announcement{
+			Text:     payload.Announcement.Text,
+			Severity: strings.ToLower(payload.Announcement.Severity.String()),
+			From:     payload.Announcement.From,
+		})}
+	case *pb.SignalReply_Paused:
+		return []rpcMessage{notification("paused", noir.Paused{
+			Sid:    payload.Paused.Sid,
+			Paused: payload.Paused.Paused,
+			By:     payload.Paused.By,
+		})}
+	case *pb.SignalReply_Data:
+		data := payload.Data
+		return []rpcMessage{notification("data", noir.Data{
+			Label:   data.Label,
+			Payload: data.Payload,
+			From:    data.From,
+		})}
+	case *pb.SignalReply_Leave:
+		return []rpcMessage{notification("leave", noir.Leave{
+			Pid:    payload.Leave.GetPid(),
+			Sid:    payload.Leave.GetSid(),
+			Reason: closeReason(payload.Leave.GetReason()),
+		})}
+	case *pb.SignalReply_Join:
+		var answer webrtc.SessionDescription
+		json.Unmarshal(payload.Join.Description, &answer)
+		messages := []rpcMessage{}
+		if iceServers := payload.Join.IceServers; len(iceServers) > 0 {
+			messages = append(messages, notification("iceServers", iceServers))
+		}
+		if pid := payload.Join.GetPid(); pid != "" {
+			messages = append(messages, notification("assigned", noir.Assigned{Pid: pid}))
+		}
+		if token := payload.Join.GetResumeToken(); token != "" {
+			messages = append(messages, notification("resumable", noir.Resumable{Token: token}))
+		}
+		return append(messages, reply(signal.RequestId, "answer", answer))
+	case *pb.SignalReply_Description:
+		var desc webrtc.SessionDescription
+		json.Unmarshal(payload.Description, &desc)
+		method := "offer"
+		if desc.Type == webrtc.SDPTypeAnswer {
+			method = "answer"
+		}
+		return []rpcMessage{reply(signal.RequestId, method, desc)}
+	case *pb.SignalReply_Trickle:
+		return []rpcMessage{notification("trickle", trickleFromProto(payload.Trickle))}
+	case *pb.SignalReply_TrickleBatch:
+		// ion-sdk clients only know single trickles
+		messages := []rpcMessage{}
+		for _, trickle := range payload.TrickleBatch.GetCandidates() {
+			messages = append(messages, notification("trickle", trickleFromProto(trickle)))
+		}
+		return messages
+	}
+	return nil
+}
+
+// closeReason is how clients are told a CloseReason, eg. room_closed
+func closeReason(reason pb.CloseReason) string {
+	return strings.ToLower(reason.String())
+}
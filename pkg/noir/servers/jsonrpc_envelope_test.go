@@ -0,0 +1,204 @@
+package servers
+
+import (
+	"encoding/json"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/sourcegraph/jsonrpc2"
+	"reflect"
+	"testing"
+)
+
+// an exchange captured from ion-sdk-js, sdp shortened
+const (
+	capturedJoin        = `{"method":"join","params":{"sid":"test room","offer":{"type":"offer","sdp":"v=0\r\n"}},"id":"3b2f8d9e-7c1a-4e55-9a0b-6f1d2c3e4a5b"}`
+	capturedAnswer      = `{"id":"3b2f8d9e-7c1a-4e55-9a0b-6f1d2c3e4a5b","result":{"type":"answer","sdp":"v=0\r\n"},"jsonrpc":"2.0"}`
+	capturedOffer       = `{"method":"offer","params":{"desc":{"type":"offer","sdp":"v=0\r\n"}},"id":7}`
+	capturedOfferAnswer = `{"id":7,"result":{"type":"answer","sdp":"v=0\r\n"},"jsonrpc":"2.0"}`
+	capturedTrickle     = `{"method":"trickle","params":{"target":0,"candidate":{"candidate":"candidate:1 1 udp 2130706431 10.0.0.2 50000 typ host","sdpMid":"0","sdpMLineIndex":0,"usernameFragment":null}},"jsonrpc":"2.0"}`
+	capturedSubOffer    = `{"method":"offer","params":{"type":"offer","sdp":"v=0\r\n"},"jsonrpc":"2.0"}`
+	capturedRoomFull    = `{"id":"3b2f8d9e-7c1a-4e55-9a0b-6f1d2c3e4a5b","error":{"code":2,"message":"room full","data":"ROOM_FULL"},"jsonrpc":"2.0"}`
+)
+
+func sameJSON(t *testing.T, got rpcMessage, want string) {
+	t.Helper()
+	marshaled, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("error marshaling %v: %s", got, err)
+	}
+	var gotValue, wantValue interface{}
+	json.Unmarshal(marshaled, &gotValue)
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		t.Fatalf("bad capture %s: %s", want, err)
+	}
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("got %s want %s", marshaled, want)
+	}
+}
+
+// capturedRequestId is the RequestId the bridge would queue a captured request with
+func capturedRequestId(t *testing.T, captured string) string {
+	var req jsonrpc2.Request
+	if err := json.Unmarshal([]byte(captured), &req); err != nil {
+		t.Fatalf("bad capture %s: %s", captured, err)
+	}
+	return requestID(req.ID)
+}
+
+func TestReplyEnvelope(t *testing.T) {
+	answer := []byte(`{"type":"answer","sdp":"v=0\r\n"}`)
+
+	join := translateReply(&pb.SignalReply{
+		RequestId: capturedRequestId(t, capturedJoin),
+		Payload:   &pb.SignalReply_Join{Join: &pb.JoinReply{Description: answer}},
+	})
+	if len(join) != 1 {
+		t.Fatalf("got %d messages want the join answer", len(join))
+	}
+	sameJSON(t, join[0], capturedAnswer)
+
+	assigned := translateReply(&pb.SignalReply{
+		RequestId: capturedRequestId(t, capturedJoin),
+		Payload:   &pb.SignalReply_Join{Join: &pb.JoinReply{Description: answer, Pid: "peer-7"}},
+	})
+	if len(assigned) != 2 {
+		t.Fatalf("got %d messages want the assigned pid before the join answer", len(assigned))
+	}
+	sameJSON(t, assigned[0], `{"method":"assigned","params":{"pid":"peer-7"},"jsonrpc":"2.0"}`)
+	sameJSON(t, assigned[1], capturedAnswer)
+
+	resumable := translateReply(&pb.SignalReply{
+		RequestId: capturedRequestId(t, capturedJoin),
+		Payload:   &pb.SignalReply_Join{Join: &pb.JoinReply{Description: answer, ResumeToken: "secret"}},
+	})
+	if len(resumable) != 2 {
+		t.Fatalf("got %d messages want the resume token before the join answer", len(resumable))
+	}
+	sameJSON(t, resumable[0], `{"method":"resumable","params":{"token":"secret"},"jsonrpc":"2.0"}`)
+
+	offer := translateReply(&pb.SignalReply{
+		RequestId: capturedRequestId(t, capturedOffer),
+		Payload:   &pb.SignalReply_Description{Description: answer},
+	})
+	sameJSON(t, offer[0], capturedOfferAnswer)
+
+	failure := translateReply(&pb.SignalReply{
+		RequestId: capturedRequestId(t, capturedJoin),
+		Payload: &pb.SignalReply_Failure{Failure: &pb.SignalError{
+			Code:    pb.SignalError_ROOM_FULL,
+			Message: "room full",
+		}},
+	})
+	sameJSON(t, failure[0], capturedRoomFull)
+
+	trickle := translateReply(&pb.SignalReply{
+		Payload: &pb.SignalReply_Trickle{Trickle: &pb.Trickle{
+			Target: pb.Trickle_PUBLISHER,
+			Init:   `{"candidate":"candidate:1 1 udp 2130706431 10.0.0.2 50000 typ host","sdpMid":"0","sdpMLineIndex":0}`,
+		}},
+	})
+	sameJSON(t, trickle[0], capturedTrickle)
+
+	subOffer := translateReply(&pb.SignalReply{
+		Payload: &pb.SignalReply_Description{Description: []byte(`{"type":"offer","sdp":"v=0\r\n"}`)},
+	})
+	sameJSON(t, subOffer[0], capturedSubOffer)
+
+	announcement := translateReply(&pb.SignalReply{
+		Payload: &pb.SignalReply_Announcement{Announcement: &pb.Announcement{
+			Text:     "meeting ends in 5 minutes",
+			Severity: pb.Announcement_WARNING,
+			From:     "moderator",
+		}},
+	})
+	sameJSON(t, announcement[0], `{"method":"announcement","params":{"text":"meeting ends in 5 minutes","severity":"warning","from":"moderator"},"jsonrpc":"2.0"}`)
+
+	paused := translateReply(&pb.SignalReply{
+		Payload: &pb.SignalReply_Paused{Paused: &pb.RoomPaused{Sid: "breakout", Paused: true, By: "moderator"}},
+	})
+	sameJSON(t, paused[0], `{"method":"paused","params":{"sid":"breakout","paused":true,"by":"moderator"},"jsonrpc":"2.0"}`)
+
+	quality := translateReply(&pb.SignalReply{
+		Payload: &pb.SignalReply_Quality{Quality: &pb.ConnectionQuality{
+			Level:        pb.ConnectionQuality_FAIR,
+			FractionLost: 0.25,
+			RttMs:        120,
+			JitterMs:     8,
+		}},
+	})
+	sameJSON(t, quality[0], `{"method":"quality","params":{"level":"fair","fractionLost":0.25,"rttMs":120,"jitterMs":8},"jsonrpc":"2.0"}`)
+
+	tracks := translateReply(&pb.SignalReply{
+		Payload: &pb.SignalReply_Tracks{Tracks: &pb.TrackInfos{Tracks: []*pb.TrackInfo{
+			{Mid: "1", TrackID: "video", StreamID: "screen", Pid: "presenter", Kind: "video", Label: "screenshare"},
+		}}},
+	})
+	sameJSON(t, tracks[0], `{"method":"tracks","params":[{"mid":"1","trackId":"video","streamId":"screen","pid":"presenter","kind":"video","label":"screenshare"}],"jsonrpc":"2.0"}`)
+
+	layers := translateReply(&pb.SignalReply{
+		Payload: &pb.SignalReply_Layers{Layers: &pb.TrackLayers{Pid: "presenter", TrackID: "video", StreamID: "camera", Layers: []*pb.TrackLayer{
+			{Spatial: 0, Rid: "q", Active: true}, {Spatial: 2, Rid: "f"},
+		}}},
+	})
+	sameJSON(t, layers[0], `{"method":"layers","params":{"pid":"presenter","trackId":"video","streamId":"camera","layers":[{"spatial":0,"rid":"q","active":true},{"spatial":2,"rid":"f","active":false}]},"jsonrpc":"2.0"}`)
+
+	adapted := translateReply(&pb.SignalReply{
+		Payload: &pb.SignalReply_SetLayer{SetLayer: &pb.SetLayerReply{StreamId: "camera", Spatial: 1, Adapted: true, EstimateKbps: 900}},
+	})
+	sameJSON(t, adapted[0], `{"method":"setlayer","params":{"streamId":"camera","spatial":1,"temporal":0,"adapted":true,"estimateKbps":900},"jsonrpc":"2.0"}`)
+
+	ping := translateReply(&pb.SignalReply{Payload: &pb.SignalReply_Ping{Ping: 3}})
+	sameJSON(t, ping[0], `{"method":"ping","params":{"seq":3},"jsonrpc":"2.0"}`)
+
+	closed := translateReply(&pb.SignalReply{Payload: &pb.SignalReply_Kill{Kill: true}, CloseReason: pb.CloseReason_ROOM_CLOSED})
+	sameJSON(t, closed[0], `{"method":"closed","params":{"reason":"room_closed"},"jsonrpc":"2.0"}`)
+}
+
+func TestReplyID(t *testing.T) {
+	for _, id := range []jsonrpc2.ID{{Num: 7}, {Str: "7", IsString: true}, {Str: `a "quoted" id`, IsString: true}} {
+		got, ok := replyID(requestID(id))
+		if !ok || *got != id {
+			t.Errorf("got %v want %v back", got, id)
+		}
+	}
+	if _, ok := replyID(""); ok {
+		t.Errorf("an empty RequestId should notify")
+	}
+	if got, _ := replyID("sdk-request"); !got.IsString || got.Str != "sdk-request" {
+		t.Errorf("got %v want a RequestId from elsewhere sent as a string", got)
+	}
+}
+
+func TestTraceEnvelope(t *testing.T) {
+	params := json.RawMessage(`{"sid":"traced","traceId":"client-trace"}`)
+	if trace := paramsTraceID(&params); trace != "client-trace" {
+		t.Errorf("got %q want the params' traceId", trace)
+	}
+	batch := json.RawMessage(`[{"target":0}]`)
+	if trace := paramsTraceID(&batch); trace != "" {
+		t.Errorf("got %q want no trace in params that are not an object", trace)
+	}
+	if stripped, err := withoutTraceID(&params); err != nil || string(stripped) != `{"sid":"traced"}` {
+		t.Errorf("got %s %v want the params without the traceId", stripped, err)
+	}
+
+	answer := translateReply(&pb.SignalReply{
+		RequestId: capturedRequestId(t, capturedOffer),
+		Payload:   &pb.SignalReply_Description{Description: []byte(`{"type":"answer","sdp":"v=0\r\n"}`)},
+	})[0]
+	answer.TraceID = "client-trace"
+	sameJSON(t, answer, `{"id":7,"result":{"type":"answer","sdp":"v=0\r\n"},"meta":{"traceId":"client-trace"},"jsonrpc":"2.0"}`)
+	closed := notification("closed", true)
+	closed.TraceID = "client-trace"
+	sameJSON(t, closed, `{"method":"closed","params":true,"meta":{"traceId":"client-trace"},"jsonrpc":"2.0"}`)
+}
+
+func TestCapabilitiesEnvelope(t *testing.T) {
+	capabilities := translateReply(&pb.SignalReply{
+		RequestId: "4",
+		Payload:   &pb.SignalReply_Capabilities{Capabilities: &pb.Capabilities{Version: "1.0"}},
+	})
+	if len(capabilities) != 1 {
+		t.Fatalf("got %d messages want the capabilities", len(capabilities))
+	}
+	sameJSON(t, capabilities[0], `{"id":4,"result":{"version":"1.0"},"jsonrpc":"2.0"}`)
+}
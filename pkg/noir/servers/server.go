@@ -13,31 +13,8 @@ import (
 // server.go contains public API handlers
 
 func PublicJSONRPC(mgr *noir.Manager, publicJrpcAddr string, key string, cert string) {
-	upgrader := websocket.Upgrader{
-		CheckOrigin: func(r *http.Request) bool {
-			return true
-		},
-		ReadBufferSize:  1024,
-		WriteBufferSize: 1024,
-	}
-
 	public := http.NewServeMux()
-	public.Handle("/ws", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		c, err := upgrader.Upgrade(w, r, nil)
-		if err != nil {
-			panic(err)
-		}
-		defer c.Close()
-
-		pid := noir.RandomString(32)
-
-		p := NewClientJSONRPCBridge(pid, mgr)
-
-		defer p.Close()
-
-		jc := jsonrpc2.NewConn(r.Context(), websocketjsonrpc2.NewObjectStream(c), p)
-		<-jc.DisconnectNotify()
-	}))
+	public.Handle("/ws", NewGateway(mgr))
 
 	server := http.Server{
 		Addr:    publicJrpcAddr,
@@ -104,6 +81,26 @@ func AdminJSONRPC(mgr *noir.Manager, adminJrpcAddr string, key string, cert stri
 
 }
 
+// AdminHTTP serves the admin HTTP API, see AdminServer
+func AdminHTTP(mgr *noir.Manager, adminHTTPAddr string, token string, key string, cert string) {
+	server := http.Server{
+		Addr:    adminHTTPAddr,
+		Handler: NewAdminServer(mgr, token),
+	}
+
+	var err error
+	if key != "" && cert != "" {
+		log.Infof("admin http listening at https://[%s]", adminHTTPAddr)
+		err = server.ListenAndServeTLS(cert, key)
+	} else {
+		log.Infof("admin http listening at http://[%s]", adminHTTPAddr)
+		err = server.ListenAndServe()
+	}
+	if err != nil {
+		panic(err)
+	}
+}
+
 func AdminGRPC(m *noir.Manager, grpcAddr string) {
 	lis, _ := net.Listen("tcp", grpcAddr)
 	grpc := NewGRPCServer(m)
@@ -1,7 +1,6 @@
 package noir
 
 import (
-	"github.com/net-prophet/noir/pkg/proto"
 	log "github.com/pion/ion-log"
 	//"github.com/pion/ion-sfu/pkg/middlewares/datachannel"
 	"math/rand"
@@ -64,11 +63,15 @@ func (s *noirSFU) ensureSession(sessionID string) *sfu.Session {
 	}
 
 	log.Infof("creating session %s", sessionID)
-	mgr := *s.manager
+	mgr := s.manager
 
 	session := sfu.NewSession(sessionID)
 
-	mgr.redis.Del(proto.KeyRoomUsers(sessionID))
+	// what an earlier session here left in the room's peer index goes, the peers joining now
+	// and those other nodes run stay
+	if _, err := mgr.pruneRoomUsers(sessionID); err != nil {
+		log.Errorf("error pruning the peers of %s: %s", sessionID, err)
+	}
 
 	session.OnClose(func() {
 		log.Infof("closing session %s", sessionID)
@@ -77,14 +80,14 @@ func (s *noirSFU) ensureSession(sessionID string) *sfu.Session {
 
 		if room != nil && err == nil {
 			if room.Options.MaxAgeSeconds == -1 {
-				log.Infof("closing empty room %s with expiry=-1", sessionID)
-				mgr.CloseRoom(sessionID)
-				if room.Options.Debug == 0 {
-					mgr.redis.Del(proto.KeyRoomData(sessionID))
-				}
+				// its data is its empty room policy's, see empty_rooms.go
+				log.Infof("unbinding empty room %s with expiry=-1", sessionID)
+				mgr.UnbindRoom(sessionID)
 			}
 		}
 
+		// a join of the room again reads whether it is paused from its data
+		mgr.setRoomPaused(sessionID, false)
 		s.mu.Lock()
 		delete(s.sessions, sessionID)
 		s.mu.Unlock()
@@ -0,0 +1,691 @@
+package noir
+
+import (
+	"github.com/pion/interceptor"
+	"github.com/pion/ion-sfu/pkg/buffer"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+	"github.com/pion/webrtc/v3"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// ion-sfu v1.6 does not export how peers, subscribers and downtracks are wired together,
+// these read it with reflect. TestSFUInternals fails when an ion-sfu upgrade renames them
+
+// subscriberOf digs out the peer's subscriber, nil before the peer joined
+func subscriberOf(peer *sfu.Peer) *sfu.Subscriber {
+	field := reflect.ValueOf(peer).Elem().FieldByName("subscriber")
+	if !field.IsValid() || field.IsNil() {
+		return nil
+	}
+	return (*sfu.Subscriber)(unsafe.Pointer(field.Pointer()))
+}
+
+// subscriberConnection is the pc a subscriber offers from, nil for a nil subscriber
+func subscriberConnection(subscriber *sfu.Subscriber) *webrtc.PeerConnection {
+	if subscriber == nil {
+		return nil
+	}
+	field := reflect.ValueOf(subscriber).Elem().FieldByName("pc")
+	if !field.IsValid() || field.IsNil() {
+		return nil
+	}
+	return (*webrtc.PeerConnection)(unsafe.Pointer(field.Pointer()))
+}
+
+// publisherOf digs out the peer's publisher, nil before the peer joined
+func publisherOf(peer *sfu.Peer) *sfu.Publisher {
+	field := reflect.ValueOf(peer).Elem().FieldByName("publisher")
+	if !field.IsValid() || field.IsNil() {
+		return nil
+	}
+	return (*sfu.Publisher)(unsafe.Pointer(field.Pointer()))
+}
+
+// publisherConnection is the pc a publisher receives on, nil for a nil publisher
+func publisherConnection(publisher *sfu.Publisher) *webrtc.PeerConnection {
+	if publisher == nil {
+		return nil
+	}
+	field := reflect.ValueOf(publisher).Elem().FieldByName("pc")
+	if !field.IsValid() || field.IsNil() {
+		return nil
+	}
+	return (*webrtc.PeerConnection)(unsafe.Pointer(field.Pointer()))
+}
+
+// receivedPackets sums the rtp packets a publisher got and lost, as its router's buffers counted them
+func receivedPackets(publisher *sfu.Publisher) (received uint64, lost uint64) {
+	for _, stream := range streamsOf(publisher) {
+		received += stream.packets
+		if stream.expected > stream.packets {
+			lost += stream.expected - stream.packets
+		}
+	}
+	return received, lost
+}
+
+// rtpStream is what a publisher's router buffered of an ssrc
+type rtpStream struct {
+	ssrc      uint32
+	clockRate uint32
+	packets   uint64
+	// from the extended highest sequence number, as the buffer's reception reports have it
+	expected uint64
+	// in clock rate units
+	jitter float64
+	// when the last packet arrived, in unix nanoseconds
+	arrived int64
+}
+
+// buffersOf is the interceptor keeping the buffer of every ssrc that reached the publisher
+func buffersOf(publisher *sfu.Publisher) *buffer.Interceptor {
+	if publisher == nil {
+		return nil
+	}
+	routed := reflect.ValueOf(publisher.GetRouter())
+	if routed.Kind() != reflect.Ptr || routed.IsNil() {
+		return nil
+	}
+	field := routed.Elem().FieldByName("buffer")
+	if !field.IsValid() || field.IsNil() {
+		return nil
+	}
+	return (*buffer.Interceptor)(unsafe.Pointer(field.Pointer()))
+}
+
+// capEstimate sets the most the REMBs of the buffer of ssrc carry, 0 leaving them to ion-sfu,
+// false when the ssrc has no buffer
+func capEstimate(interceptor *buffer.Interceptor, ssrc uint32, bitrate uint64) bool {
+	if interceptor == nil {
+		return false
+	}
+	interceptor.RLock()
+	defer interceptor.RUnlock()
+	buffers := reflect.ValueOf(interceptor).Elem().FieldByName("buffers")
+	for i := 0; i < buffers.Len(); i++ {
+		if buffers.Index(i).IsNil() {
+			continue
+		}
+		b := buffers.Index(i).Elem()
+		if uint32(b.FieldByName("mediaSSRC").Uint()) == ssrc {
+			atomic.StoreUint64((*uint64)(unsafe.Pointer(b.FieldByName("maxBitrate").UnsafeAddr())), bitrate)
+			return true
+		}
+	}
+	return false
+}
+
+// setBufferTime has the buffer of ssrc keep its packets for ms, false when the ssrc has no buffer.
+// The buffer reads its queue's duration as it pushes the ssrc's rtp, so this has to be called from
+// the goroutine reading it
+func setBufferTime(interceptor *buffer.Interceptor, ssrc uint32, ms uint32) bool {
+	if interceptor == nil {
+		return false
+	}
+	interceptor.RLock()
+	defer interceptor.RUnlock()
+	buffers := reflect.ValueOf(interceptor).Elem().FieldByName("buffers")
+	for i := 0; i < buffers.Len(); i++ {
+		if buffers.Index(i).IsNil() {
+			continue
+		}
+		b := buffers.Index(i).Elem()
+		if uint32(b.FieldByName("mediaSSRC").Uint()) == ssrc {
+			duration := b.FieldByName("pktQueue").FieldByName("duration")
+			*(*uint32)(unsafe.Pointer(duration.UnsafeAddr())) = uint32(uint64(ms) * b.FieldByName("clockRate").Uint() / 1000)
+			return true
+		}
+	}
+	return false
+}
+
+// streamsOf reads the buffer of every ssrc that reached the publisher
+func streamsOf(publisher *sfu.Publisher) []rtpStream {
+	interceptor := buffersOf(publisher)
+	if interceptor == nil {
+		return nil
+	}
+	interceptor.RLock()
+	defer interceptor.RUnlock()
+	buffers := reflect.ValueOf(interceptor).Elem().FieldByName("buffers")
+	streams := []rtpStream{}
+	for i := 0; i < buffers.Len(); i++ {
+		if buffers.Index(i).IsNil() {
+			continue
+		}
+		b := buffers.Index(i).Elem()
+		count := b.FieldByName("packetCount").Uint()
+		if count == 0 {
+			continue
+		}
+		streams = append(streams, rtpStream{
+			ssrc:      uint32(b.FieldByName("mediaSSRC").Uint()),
+			clockRate: uint32(b.FieldByName("clockRate").Uint()),
+			packets:   count,
+			expected:  (b.FieldByName("cycles").Uint() | b.FieldByName("maxSeqNo").Uint()) - b.FieldByName("baseSN").Uint() + 1,
+			jitter:    b.FieldByName("jitter").Float(),
+			arrived:   b.FieldByName("lastPacketTime").Int(),
+		})
+	}
+	return streams
+}
+
+// hookList is a mutex guarded list of callbacks, added and removed while they are being called.
+// It is copied on write, so callers run what list returns without holding the lock
+type hookList struct {
+	mu    sync.Mutex
+	next  int
+	hooks []hook
+}
+
+type hook struct {
+	id int
+	fn interface{}
+}
+
+// add appends fn, and returns what removes it again
+func (l *hookList) add(fn interface{}) func() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.next++
+	id := l.next
+	l.hooks = append(append([]hook(nil), l.hooks...), hook{id, fn})
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		kept := make([]hook, 0, len(l.hooks))
+		for _, hook := range l.hooks {
+			if hook.id != id {
+				kept = append(kept, hook)
+			}
+		}
+		l.hooks = kept
+	}
+}
+
+func (l *hookList) list() []hook {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.hooks
+}
+
+// subscriberHooks are hooked into a subscriber once, by hookSubscriber, and what noir runs on its
+// renegotiations and rtcp is added to and removed from them after
+type subscriberHooks struct {
+	// run ahead of every renegotiation the subscriber asks for
+	negotiate hookList
+	// handed the rtcp of every sender of the subscriber
+	rtcp hookList
+}
+
+var (
+	// guards the hooks below, and every swap of the sfu's internals installing them
+	hooksMu           sync.Mutex
+	subscriberHooksOf = map[*sfu.Subscriber]*subscriberHooks{}
+	publisherHooksOf  = map[*sfu.Publisher]*hookList{}
+)
+
+// hookPeer hooks into the transports of a peer that just joined, before the sfu sent or
+// received any media on them, see hookSubscriber and hookPublisher
+func hookPeer(peer *sfu.Peer) {
+	hookSubscriber(subscriberOf(peer))
+	hookPublisher(publisherOf(peer))
+}
+
+// unhookPeer forgets the hooks of a peer that is closing
+func unhookPeer(peer *sfu.Peer) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	delete(subscriberHooksOf, subscriberOf(peer))
+	delete(publisherHooksOf, publisherOf(peer))
+}
+
+// hookSubscriber wraps the subscriber's negotiate and has its pc give every sender it makes a
+// senderRTCP, once, nil before it joined. The sfu reads both without a lock of its own, so they
+// are swapped once, as soon as the peer joined, and never again. Senders the sfu made while the
+// peer joined are given a senderRTCP then
+func hookSubscriber(subscriber *sfu.Subscriber) *subscriberHooks {
+	if subscriber == nil {
+		return nil
+	}
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	if hooks, ok := subscriberHooksOf[subscriber]; ok {
+		return hooks
+	}
+	field := reflect.ValueOf(subscriber).Elem().FieldByName("negotiate")
+	pc := subscriberConnection(subscriber)
+	if !field.IsValid() || field.IsNil() || pc == nil {
+		return nil
+	}
+	hooks := &subscriberHooks{}
+	negotiate := (*func())(unsafe.Pointer(field.UnsafeAddr()))
+	original := *negotiate
+	*negotiate = func() {
+		for _, hook := range hooks.negotiate.list() {
+			hook.fn.(func())()
+		}
+		original()
+	}
+	bound := connectionInterceptor(pc)
+	*bound = &rtcpFanOut{Interceptor: *bound, hooks: &hooks.rtcp}
+	for _, sender := range pc.GetSenders() {
+		reader := senderReader(sender)
+		if _, ok := (*reader).(*senderRTCP); !ok {
+			*reader = &senderRTCP{reader: *reader, subscriber: &hooks.rtcp}
+		}
+	}
+	subscriberHooksOf[subscriber] = hooks
+	return hooks
+}
+
+// hookPublisher has the publisher's pc hand the rtp of every stream it binds to the hooks
+// returned, once, nil before it joined. The pc binds its streams once they arrive, after the
+// join, so the swap is ahead of them
+func hookPublisher(publisher *sfu.Publisher) *hookList {
+	pc := publisherConnection(publisher)
+	if pc == nil {
+		return nil
+	}
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	if hooks, ok := publisherHooksOf[publisher]; ok {
+		return hooks
+	}
+	hooks := &hookList{}
+	bound := connectionInterceptor(pc)
+	*bound = &rtpFanOut{Interceptor: *bound, hooks: hooks}
+	publisherHooksOf[publisher] = hooks
+	return hooks
+}
+
+// connectionInterceptor is what the pc binds its senders' and streams' readers with
+func connectionInterceptor(pc *webrtc.PeerConnection) *interceptor.Interceptor {
+	api := reflect.ValueOf(pc).Elem().FieldByName("api")
+	field := reflect.ValueOf((*webrtc.API)(unsafe.Pointer(api.Pointer()))).Elem().FieldByName("interceptor")
+	return (*interceptor.Interceptor)(unsafe.Pointer(field.UnsafeAddr()))
+}
+
+// hookNegotiate calls before ahead of every renegotiation the subscriber asks for until the
+// returned func removes it, false before it joined
+func hookNegotiate(subscriber *sfu.Subscriber, before func()) (func(), bool) {
+	hooks := hookSubscriber(subscriber)
+	if hooks == nil {
+		return nil, false
+	}
+	return hooks.negotiate.add(before), true
+}
+
+// negotiate asks the subscriber for a renegotiation, as adding a track does, false before it joined
+func negotiate(subscriber *sfu.Subscriber) bool {
+	field := reflect.ValueOf(subscriber).Elem().FieldByName("negotiate")
+	if !field.IsValid() || field.IsNil() {
+		return false
+	}
+	(*(*func())(unsafe.Pointer(field.UnsafeAddr())))()
+	return true
+}
+
+// negotiationFlags are the peer's remoteAnswerPending, which holds back its offers while true,
+// and negotiationPending, set by a renegotiation held back. Both are guarded by the peer's lock
+func negotiationFlags(peer *sfu.Peer) (answerPending *bool, negotiationPending *bool) {
+	value := reflect.ValueOf(peer).Elem()
+	answerPending = (*bool)(unsafe.Pointer(value.FieldByName("remoteAnswerPending").UnsafeAddr()))
+	negotiationPending = (*bool)(unsafe.Pointer(value.FieldByName("negotiationPending").UnsafeAddr()))
+	return answerPending, negotiationPending
+}
+
+// removeDownTrack stops sending a track to the subscriber, which then renegotiates without it.
+// ion-sfu only does this when the publisher goes, and would not add the track again otherwise
+func removeDownTrack(subscriber *sfu.Subscriber, track *sfu.DownTrack) {
+	subscriber.Lock()
+	streams := reflect.ValueOf(subscriber).Elem().FieldByName("tracks")
+	tracks := *(*map[string][]*sfu.DownTrack)(unsafe.Pointer(streams.UnsafeAddr()))
+	kept := []*sfu.DownTrack{}
+	for _, other := range tracks[track.StreamID()] {
+		if other != track {
+			kept = append(kept, other)
+		}
+	}
+	if len(kept) == 0 {
+		delete(tracks, track.StreamID())
+	} else {
+		tracks[track.StreamID()] = kept
+	}
+	subscriber.Unlock()
+
+	field := reflect.ValueOf(track).Elem().FieldByName("receiver")
+	if receiver := *(*sfu.Receiver)(unsafe.Pointer(field.UnsafeAddr())); receiver != nil {
+		// downtracks are filed under the subscriber's id on every layer they were on
+		subscriberID := downTrackSubscriber(track)
+		for layer := 0; layer < 3; layer++ {
+			receiver.DeleteDownTrack(layer, subscriberID)
+		}
+	}
+	track.Close()
+}
+
+// setPeerSession files the peer, and the publisher whose new tracks are published to the session,
+// under another session. It does not add the peer to the session or remove it from its old one.
+// The sfu reads them without a lock, so they are stored atomically, under the peer's lock
+func setPeerSession(peer *sfu.Peer, session *sfu.Session) bool {
+	field := reflect.ValueOf(peer).Elem().FieldByName("session")
+	publisher := publisherOf(peer)
+	if !field.IsValid() || publisher == nil {
+		return false
+	}
+	peer.Lock()
+	defer peer.Unlock()
+	atomic.StorePointer((*unsafe.Pointer)(unsafe.Pointer(field.UnsafeAddr())), unsafe.Pointer(session))
+	field = reflect.ValueOf(publisher).Elem().FieldByName("session")
+	atomic.StorePointer((*unsafe.Pointer)(unsafe.Pointer(field.UnsafeAddr())), unsafe.Pointer(session))
+	return true
+}
+
+// sfuPeerID is the sfu's own id for a peer, not its noir pid
+func sfuPeerID(peer *sfu.Peer) string {
+	return reflect.ValueOf(peer).Elem().FieldByName("id").String()
+}
+
+// downTracksOf lists every track sent to a subscriber
+func downTracksOf(subscriber *sfu.Subscriber) []*sfu.DownTrack {
+	subscriber.RLock()
+	defer subscriber.RUnlock()
+	tracks := []*sfu.DownTrack{}
+	streams := reflect.ValueOf(subscriber).Elem().FieldByName("tracks").MapRange()
+	for streams.Next() {
+		list := streams.Value()
+		for i := 0; i < list.Len(); i++ {
+			tracks = append(tracks, (*sfu.DownTrack)(unsafe.Pointer(list.Index(i).Pointer())))
+		}
+	}
+	return tracks
+}
+
+// downTrackPublisher is the sfu peer id of whoever publishes what the downtrack forwards
+func downTrackPublisher(track *sfu.DownTrack) string {
+	receiver := reflect.ValueOf(track).Elem().FieldByName("receiver")
+	if receiver.IsNil() || receiver.Elem().Kind() != reflect.Ptr {
+		return ""
+	}
+	peerID := receiver.Elem().Elem().FieldByName("peerID")
+	if !peerID.IsValid() || peerID.Kind() != reflect.String {
+		return ""
+	}
+	return peerID.String()
+}
+
+// downTrackSubscriber is the sfu's id of the peer the downtrack is sent to
+func downTrackSubscriber(track *sfu.DownTrack) string {
+	return reflect.ValueOf(track).Elem().FieldByName("peerID").String()
+}
+
+// downTrackSender is the sender a downtrack goes out on, nil until the sfu added it to the pc
+func downTrackSender(track *sfu.DownTrack) *webrtc.RTPSender {
+	field := reflect.ValueOf(track).Elem().FieldByName("transceiver")
+	if !field.IsValid() || field.IsNil() {
+		return nil
+	}
+	return (*webrtc.RTPTransceiver)(unsafe.Pointer(field.Pointer())).Sender()
+}
+
+// downTrackMid is the mid of the downtrack's transceiver, empty until an offer gave it one
+func downTrackMid(track *sfu.DownTrack) string {
+	field := reflect.ValueOf(track).Elem().FieldByName("transceiver")
+	if !field.IsValid() || field.IsNil() {
+		return ""
+	}
+	return (*webrtc.RTPTransceiver)(unsafe.Pointer(field.Pointer())).Mid()
+}
+
+// downTrackReceiver is the receiver of the published track a downtrack forwards
+func downTrackReceiver(track *sfu.DownTrack) sfu.Receiver {
+	field := reflect.ValueOf(track).Elem().FieldByName("receiver")
+	return *(*sfu.Receiver)(unsafe.Pointer(field.UnsafeAddr()))
+}
+
+// downTrackOnBind is what the downtrack calls once it is bound, set by ion-sfu's router
+func downTrackOnBind(track *sfu.DownTrack) func() {
+	field := reflect.ValueOf(track).Elem().FieldByName("onBind")
+	return *(*func())(unsafe.Pointer(field.UnsafeAddr()))
+}
+
+// downTrackSent is what a downtrack sent, counted for its sender reports
+func downTrackSent(track *sfu.DownTrack) (packets uint32, octets uint32) {
+	value := reflect.ValueOf(track).Elem()
+	packets = atomic.LoadUint32((*uint32)(unsafe.Pointer(value.FieldByName("packetCount").UnsafeAddr())))
+	octets = atomic.LoadUint32((*uint32)(unsafe.Pointer(value.FieldByName("octetCount").UnsafeAddr())))
+	return packets, octets
+}
+
+// downTrackLayer is the simulcast layer a downtrack sends, -1 when it forwards no simulcast
+func downTrackLayer(track *sfu.DownTrack) int32 {
+	value := reflect.ValueOf(track).Elem()
+	if sfu.DownTrackType(value.FieldByName("trackType").Int()) != sfu.SimulcastDownTrack {
+		return -1
+	}
+	return int32(value.FieldByName("currentSpatialLayer").Int())
+}
+
+// downTrackSSRC is the ssrc a downtrack is sent with, 0 until it is bound
+func downTrackSSRC(track *sfu.DownTrack) uint32 {
+	return uint32(reflect.ValueOf(track).Elem().FieldByName("ssrc").Uint())
+}
+
+// rtcpObserveFunc is handed a batch of rtcp, an rtcpFilterFunc keeps what it returns of one
+type rtcpObserveFunc func([]rtcp.Packet)
+type rtcpFilterFunc func([]rtcp.Packet) []rtcp.Packet
+
+// rtpObserveFunc is handed each rtp packet of the stream info describes, as the pc read it
+type rtpObserveFunc func(info *interceptor.StreamInfo, packet *rtp.Packet)
+
+// senderRTCP is a sender's rtcp reader, handing each batch ion-sfu's downtrack loop reads, its
+// only reader, to the hooks of its subscriber and then to its own. Observers see the batch as it
+// was read, filters decide what the loop acts on
+type senderRTCP struct {
+	reader     interceptor.RTCPReader
+	subscriber *hookList
+	hooks      hookList
+	// set once the loop first reads, and what is run when it does, see whenReading
+	mu      sync.Mutex
+	reading bool
+	onRead  []func()
+}
+
+func (s *senderRTCP) Read() ([]rtcp.Packet, interceptor.Attributes, error) {
+	s.mu.Lock()
+	s.reading = true
+	onRead := s.onRead
+	s.onRead = nil
+	s.mu.Unlock()
+	for _, fn := range onRead {
+		fn()
+	}
+	packets, attributes, err := s.reader.Read()
+	if err != nil {
+		return packets, attributes, err
+	}
+	if s.subscriber != nil {
+		packets = runRTCPHooks(s.subscriber.list(), packets)
+	}
+	return runRTCPHooks(s.hooks.list(), packets), attributes, nil
+}
+
+func runRTCPHooks(hooks []hook, packets []rtcp.Packet) []rtcp.Packet {
+	for _, hook := range hooks {
+		switch fn := hook.fn.(type) {
+		case rtcpObserveFunc:
+			fn(packets)
+		case rtcpFilterFunc:
+			packets = fn(packets)
+		}
+	}
+	return packets
+}
+
+// rtcpFanOut binds every rtcp reader of a subscriber's pc as a senderRTCP, see hookSubscriber
+type rtcpFanOut struct {
+	interceptor.Interceptor
+	hooks *hookList
+}
+
+func (f *rtcpFanOut) BindRTCPReader(reader interceptor.RTCPReader) interceptor.RTCPReader {
+	return &senderRTCP{reader: f.Interceptor.BindRTCPReader(reader), subscriber: f.hooks}
+}
+
+// rtpFanOut hands the rtp of every stream a publisher's pc binds to its hooks, after the sfu's
+// buffer got it, see hookPublisher
+type rtpFanOut struct {
+	interceptor.Interceptor
+	hooks *hookList
+}
+
+func (f *rtpFanOut) BindRemoteStream(info *interceptor.StreamInfo, reader interceptor.RTPReader) interceptor.RTPReader {
+	bound := f.Interceptor.BindRemoteStream(info, reader)
+	return interceptor.RTPReaderFunc(func() (*rtp.Packet, interceptor.Attributes, error) {
+		packet, attributes, err := bound.Read()
+		if err == nil {
+			for _, hook := range f.hooks.list() {
+				hook.fn.(rtpObserveFunc)(info, packet)
+			}
+		}
+		return packet, attributes, err
+	})
+}
+
+func senderReader(sender *webrtc.RTPSender) *interceptor.RTCPReader {
+	field := reflect.ValueOf(sender).Elem().FieldByName("interceptorRTCPReader")
+	return (*interceptor.RTCPReader)(unsafe.Pointer(field.UnsafeAddr()))
+}
+
+// senderRTCPOf is the sender's senderRTCP, given it here when its subscriber was never hooked
+func senderRTCPOf(sender *webrtc.RTPSender) *senderRTCP {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	reader := senderReader(sender)
+	if fanOut, ok := (*reader).(*senderRTCP); ok {
+		return fanOut
+	}
+	fanOut := &senderRTCP{reader: *reader}
+	*reader = fanOut
+	return fanOut
+}
+
+// whenReading runs fn from ion-sfu's downtrack loop as soon as it reads the sender's rtcp, by
+// when it holds the sender, and is false when it already started
+func (s *senderRTCP) whenReading(fn func()) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.reading {
+		return false
+	}
+	s.onRead = append(s.onRead, fn)
+	return true
+}
+
+// observeSenderRTCP hands observe the rtcp the sender reads until the returned func removes it
+func observeSenderRTCP(sender *webrtc.RTPSender, observe func([]rtcp.Packet)) func() {
+	return senderRTCPOf(sender).hooks.add(rtcpObserveFunc(observe))
+}
+
+// filterSenderRTCP has ion-sfu's downtrack loop act on the rtcp filter keeps of each batch the
+// sender reads, until the returned func removes it
+func filterSenderRTCP(sender *webrtc.RTPSender, filter func([]rtcp.Packet) []rtcp.Packet) func() {
+	return senderRTCPOf(sender).hooks.add(rtcpFilterFunc(filter))
+}
+
+// observeSubscriberRTCP hands observe the rtcp every sender of the subscriber reads, including
+// the ones it gets later, until the returned func removes it, false before it joined
+func observeSubscriberRTCP(subscriber *sfu.Subscriber, observe func([]rtcp.Packet)) (func(), bool) {
+	hooks := hookSubscriber(subscriber)
+	if hooks == nil {
+		return nil, false
+	}
+	return hooks.rtcp.add(rtcpObserveFunc(observe)), true
+}
+
+// observePublisherRTP hands observe every rtp packet of every stream the publisher sends, of each
+// simulcast layer, until the returned func removes it, false before it joined
+func observePublisherRTP(publisher *sfu.Publisher, observe func(info *interceptor.StreamInfo, packet *rtp.Packet)) (func(), bool) {
+	hooks := hookPublisher(publisher)
+	if hooks == nil {
+		return nil, false
+	}
+	return hooks.add(rtpObserveFunc(observe)), true
+}
+
+// receiversOf lists the tracks a publisher sends the sfu
+func receiversOf(publisher *sfu.Publisher) []sfu.Receiver {
+	if publisher == nil {
+		return nil
+	}
+	routed := reflect.ValueOf(publisher.GetRouter())
+	if routed.Kind() != reflect.Ptr || routed.IsNil() {
+		return nil
+	}
+	router := routed.Elem()
+	mu := (*sync.RWMutex)(unsafe.Pointer(router.FieldByName("RWMutex").UnsafeAddr()))
+	mu.RLock()
+	defer mu.RUnlock()
+	field := router.FieldByName("receivers")
+	receivers := []sfu.Receiver{}
+	for _, receiver := range *(*map[string]sfu.Receiver)(unsafe.Pointer(field.UnsafeAddr())) {
+		receivers = append(receivers, receiver)
+	}
+	return receivers
+}
+
+// receiverSimulcast is whether the receiver gets its track in simulcast layers
+func receiverSimulcast(receiver sfu.Receiver) bool {
+	state := reflect.ValueOf(receiver)
+	if state.Kind() != reflect.Ptr || state.IsNil() {
+		return false
+	}
+	field := state.Elem().FieldByName("isSimulcast")
+	return field.IsValid() && field.Bool()
+}
+
+// receiverDownTracks lists the downtracks a receiver forwards to, by the spatial layer they get
+func receiverDownTracks(receiver sfu.Receiver) [][]*sfu.DownTrack {
+	state := reflect.ValueOf(receiver)
+	if state.Kind() != reflect.Ptr || state.IsNil() || state.Elem().Type() != reflect.TypeOf(sfu.WebRTCReceiver{}) {
+		return nil
+	}
+	mu := (*sync.RWMutex)(unsafe.Pointer(state.Elem().FieldByName("RWMutex").UnsafeAddr()))
+	mu.RLock()
+	defer mu.RUnlock()
+	layers := *(*[3][]*sfu.DownTrack)(unsafe.Pointer(state.Elem().FieldByName("downTracks").UnsafeAddr()))
+	tracks := make([][]*sfu.DownTrack, len(layers))
+	for layer := range layers {
+		tracks[layer] = append([]*sfu.DownTrack{}, layers[layer]...)
+	}
+	return tracks
+}
+
+// observeReceiver hands writer every rtp packet of the receiver, as a subscriber's downtrack would
+// get them, filed under observerID for receiver.DeleteDownTrack. ion-sfu only binds downtracks to
+// a pc, so the downtrack is bound to a TrackLocalContext made up here
+func observeReceiver(receiver sfu.Receiver, observerID string, writer webrtc.TrackLocalWriter) error {
+	track, err := sfu.NewDownTrack(receiver.Codec().RTPCodecCapability, receiver, observerID)
+	if err != nil {
+		return err
+	}
+	track.OnBind(func() {})
+	bound := webrtc.TrackLocalContext{}
+	context := reflect.ValueOf(&bound).Elem()
+	*(*webrtc.RTPParameters)(unsafe.Pointer(context.FieldByName("params").UnsafeAddr())) = webrtc.RTPParameters{
+		Codecs: []webrtc.RTPCodecParameters{receiver.Codec()},
+	}
+	*(*webrtc.SSRC)(unsafe.Pointer(context.FieldByName("ssrc").UnsafeAddr())) = webrtc.SSRC(receiver.SSRC(0))
+	*(*webrtc.TrackLocalWriter)(unsafe.Pointer(context.FieldByName("writeStream").UnsafeAddr())) = writer
+	if _, err := track.Bind(bound); err != nil {
+		return err
+	}
+	receiver.AddDownTrack(track, false)
+	return nil
+}
@@ -0,0 +1,256 @@
+package noir
+
+import (
+	"github.com/pion/interceptor"
+	"github.com/pion/ion-sfu/pkg/buffer"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+	"github.com/pion/webrtc/v3"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestSFUInternals(t *testing.T) {
+	peer := reflect.TypeOf(sfu.Peer{})
+	if field, ok := peer.FieldByName("subscriber"); !ok || field.Type != reflect.TypeOf(&sfu.Subscriber{}) {
+		t.Errorf("sfu.Peer has no subscriber *sfu.Subscriber")
+	}
+	if field, ok := peer.FieldByName("publisher"); !ok || field.Type != reflect.TypeOf(&sfu.Publisher{}) {
+		t.Errorf("sfu.Peer has no publisher *sfu.Publisher")
+	}
+	if field, ok := peer.FieldByName("id"); !ok || field.Type.Kind() != reflect.String {
+		t.Errorf("sfu.Peer has no string id")
+	}
+	if field, ok := peer.FieldByName("session"); !ok || field.Type != reflect.TypeOf(&sfu.Session{}) {
+		t.Errorf("sfu.Peer has no session *sfu.Session")
+	}
+	if field, ok := reflect.TypeOf(sfu.Publisher{}).FieldByName("session"); !ok || field.Type != reflect.TypeOf(&sfu.Session{}) {
+		t.Errorf("sfu.Publisher has no session *sfu.Session")
+	}
+	for _, name := range []string{"remoteAnswerPending", "negotiationPending"} {
+		if field, ok := peer.FieldByName(name); !ok || field.Type.Kind() != reflect.Bool {
+			t.Errorf("sfu.Peer has no bool %s", name)
+		}
+	}
+
+	subscriber := reflect.TypeOf(sfu.Subscriber{})
+	if field, ok := subscriber.FieldByName("tracks"); !ok || field.Type != reflect.TypeOf(map[string][]*sfu.DownTrack{}) {
+		t.Errorf("sfu.Subscriber has no tracks map[string][]*sfu.DownTrack")
+	}
+	if field, ok := subscriber.FieldByName("negotiate"); !ok || field.Type != reflect.TypeOf(func() {}) {
+		t.Errorf("sfu.Subscriber has no negotiate func()")
+	}
+	if field, ok := subscriber.FieldByName("pc"); !ok || field.Type != reflect.TypeOf(&webrtc.PeerConnection{}) {
+		t.Errorf("sfu.Subscriber has no pc *webrtc.PeerConnection")
+	}
+
+	if field, ok := reflect.TypeOf(sfu.Publisher{}).FieldByName("pc"); !ok || field.Type != reflect.TypeOf(&webrtc.PeerConnection{}) {
+		t.Errorf("sfu.Publisher has no pc *webrtc.PeerConnection")
+	}
+	if publisher, err := sfu.NewPublisher(nil, "internals", sfu.WebRTCTransportConfig{}); err != nil {
+		t.Errorf("error making a publisher: %s", err)
+	} else {
+		field := reflect.ValueOf(publisher.GetRouter()).Elem().FieldByName("buffer")
+		if !field.IsValid() || field.Type() != reflect.TypeOf(&buffer.Interceptor{}) {
+			t.Errorf("the sfu router has no buffer *buffer.Interceptor")
+		}
+		router := reflect.ValueOf(publisher.GetRouter()).Elem()
+		if field := router.FieldByName("RWMutex"); !field.IsValid() || field.Type() != reflect.TypeOf(sync.RWMutex{}) {
+			t.Errorf("the sfu router does not embed sync.RWMutex")
+		}
+		if field := router.FieldByName("receivers"); !field.IsValid() || field.Type() != reflect.TypeOf(map[string]sfu.Receiver{}) {
+			t.Errorf("the sfu router has no receivers map[string]sfu.Receiver")
+		}
+		if receivers := receiversOf(publisher); len(receivers) != 0 {
+			t.Errorf("got %d receivers want none before anything was published", len(receivers))
+		}
+		publisher.Close()
+	}
+	if field, ok := reflect.TypeOf(buffer.Interceptor{}).FieldByName("buffers"); !ok || field.Type != reflect.TypeOf([]*buffer.Buffer{}) {
+		t.Errorf("buffer.Interceptor has no buffers []*buffer.Buffer")
+	}
+	for _, name := range []string{"packetCount", "cycles", "maxSeqNo", "baseSN", "mediaSSRC", "clockRate"} {
+		if field, ok := reflect.TypeOf(buffer.Buffer{}).FieldByName(name); !ok || field.Type.Kind() < reflect.Uint || field.Type.Kind() > reflect.Uint64 {
+			t.Errorf("buffer.Buffer has no unsigned %s", name)
+		}
+	}
+
+	if field, ok := reflect.TypeOf(buffer.Buffer{}).FieldByName("jitter"); !ok || field.Type.Kind() != reflect.Float64 {
+		t.Errorf("buffer.Buffer has no float64 jitter")
+	}
+	if field, ok := reflect.TypeOf(buffer.Buffer{}).FieldByName("maxBitrate"); !ok || field.Type.Kind() != reflect.Uint64 {
+		t.Errorf("buffer.Buffer has no uint64 maxBitrate")
+	}
+	if field, ok := reflect.TypeOf(buffer.Buffer{}).FieldByName("pktQueue"); !ok || field.Type.Kind() != reflect.Struct {
+		t.Errorf("buffer.Buffer has no pktQueue")
+	} else if duration, ok := field.Type.FieldByName("duration"); !ok || duration.Type.Kind() != reflect.Uint32 {
+		t.Errorf("the buffer's packet queue has no uint32 duration")
+	}
+
+	for _, name := range []string{"packetCount", "octetCount"} {
+		if field, ok := reflect.TypeOf(sfu.DownTrack{}).FieldByName(name); !ok || field.Type.Kind() != reflect.Uint32 {
+			t.Errorf("sfu.DownTrack has no uint32 %s", name)
+		}
+	}
+	if field, ok := reflect.TypeOf(sfu.DownTrack{}).FieldByName("trackType"); !ok || field.Type != reflect.TypeOf(sfu.SimpleDownTrack) {
+		t.Errorf("sfu.DownTrack has no trackType sfu.DownTrackType")
+	}
+	if field, ok := reflect.TypeOf(sfu.DownTrack{}).FieldByName("currentSpatialLayer"); !ok || field.Type.Kind() != reflect.Int {
+		t.Errorf("sfu.DownTrack has no int currentSpatialLayer")
+	}
+	if field, ok := reflect.TypeOf(sfu.DownTrack{}).FieldByName("receiver"); !ok || field.Type != reflect.TypeOf((*sfu.Receiver)(nil)).Elem() {
+		t.Errorf("sfu.DownTrack has no receiver sfu.Receiver")
+	}
+	if field, ok := reflect.TypeOf(sfu.DownTrack{}).FieldByName("peerID"); !ok || field.Type.Kind() != reflect.String {
+		t.Errorf("sfu.DownTrack has no string peerID")
+	}
+	if field, ok := reflect.TypeOf(sfu.DownTrack{}).FieldByName("transceiver"); !ok || field.Type != reflect.TypeOf(&webrtc.RTPTransceiver{}) {
+		t.Errorf("sfu.DownTrack has no transceiver *webrtc.RTPTransceiver")
+	}
+	if field, ok := reflect.TypeOf(sfu.DownTrack{}).FieldByName("ssrc"); !ok || field.Type.Kind() != reflect.Uint32 {
+		t.Errorf("sfu.DownTrack has no uint32 ssrc")
+	}
+	if field, ok := reflect.TypeOf(sfu.DownTrack{}).FieldByName("onBind"); !ok || field.Type != reflect.TypeOf(func() {}) {
+		t.Errorf("sfu.DownTrack has no onBind func()")
+	}
+	if field, ok := reflect.TypeOf(webrtc.RTPSender{}).FieldByName("interceptorRTCPReader"); !ok || field.Type != reflect.TypeOf((*interceptor.RTCPReader)(nil)).Elem() {
+		t.Errorf("webrtc.RTPSender has no interceptorRTCPReader interceptor.RTCPReader")
+	}
+	if field, ok := reflect.TypeOf(webrtc.PeerConnection{}).FieldByName("api"); !ok || field.Type != reflect.TypeOf(&webrtc.API{}) {
+		t.Errorf("webrtc.PeerConnection has no api *webrtc.API")
+	}
+	if field, ok := reflect.TypeOf(webrtc.API{}).FieldByName("interceptor"); !ok || field.Type != reflect.TypeOf((*interceptor.Interceptor)(nil)).Elem() {
+		t.Errorf("webrtc.API has no interceptor interceptor.Interceptor")
+	}
+	bound := reflect.TypeOf(webrtc.TrackLocalContext{})
+	if field, ok := bound.FieldByName("params"); !ok || field.Type != reflect.TypeOf(webrtc.RTPParameters{}) {
+		t.Errorf("webrtc.TrackLocalContext has no params webrtc.RTPParameters")
+	}
+	if field, ok := bound.FieldByName("ssrc"); !ok || field.Type != reflect.TypeOf(webrtc.SSRC(0)) {
+		t.Errorf("webrtc.TrackLocalContext has no ssrc webrtc.SSRC")
+	}
+	if field, ok := bound.FieldByName("writeStream"); !ok || field.Type != reflect.TypeOf((*webrtc.TrackLocalWriter)(nil)).Elem() {
+		t.Errorf("webrtc.TrackLocalContext has no writeStream webrtc.TrackLocalWriter")
+	}
+	if field, ok := reflect.TypeOf(sfu.WebRTCReceiver{}).FieldByName("peerID"); !ok || field.Type.Kind() != reflect.String {
+		t.Errorf("sfu.WebRTCReceiver has no string peerID")
+	}
+	if field, ok := reflect.TypeOf(sfu.WebRTCReceiver{}).FieldByName("downTracks"); !ok || field.Type != reflect.TypeOf([3][]*sfu.DownTrack{}) {
+		t.Errorf("sfu.WebRTCReceiver has no downTracks [3][]*sfu.DownTrack")
+	}
+	if field, ok := reflect.TypeOf(sfu.WebRTCReceiver{}).FieldByName("RWMutex"); !ok || field.Type != reflect.TypeOf(sync.RWMutex{}) {
+		t.Errorf("sfu.WebRTCReceiver has no sync.RWMutex")
+	}
+}
+
+func TestSFUInternalsBeforeJoin(t *testing.T) {
+	peer := sfu.NewPeer(nil)
+	if subscriberOf(peer) != nil {
+		t.Errorf("a peer that never joined should have no subscriber")
+	}
+}
+
+func TestSenderRTCP(t *testing.T) {
+	batch := []rtcp.Packet{&rtcp.PictureLossIndication{MediaSSRC: 1}, &rtcp.ReceiverReport{}}
+	subscriber := &hookList{}
+	fanOut := &senderRTCP{
+		reader: interceptor.RTCPReaderFunc(func() ([]rtcp.Packet, interceptor.Attributes, error) {
+			return batch, nil, nil
+		}),
+		subscriber: subscriber,
+	}
+	read := false
+	if !fanOut.whenReading(func() { read = true }) || read {
+		t.Errorf("want the func kept for the first read")
+	}
+	observed, seen := 0, 0
+	unobserve := subscriber.add(rtcpObserveFunc(func(packets []rtcp.Packet) { observed += len(packets) }))
+	unfilter := fanOut.hooks.add(rtcpFilterFunc(func(packets []rtcp.Packet) []rtcp.Packet { return packets[1:] }))
+	fanOut.hooks.add(rtcpObserveFunc(func(packets []rtcp.Packet) { seen += len(packets) }))
+	if packets, _, _ := fanOut.Read(); len(packets) != 1 || observed != 2 || seen != 1 {
+		t.Errorf("got %d packets, %d observed and %d seen want the batch observed whole and filtered after", len(packets), observed, seen)
+	}
+	if !read || fanOut.whenReading(func() {}) {
+		t.Errorf("want the func run by the first read, and none kept once reading")
+	}
+	unobserve()
+	unfilter()
+	if packets, _, _ := fanOut.Read(); len(packets) != 2 || observed != 2 || seen != 3 {
+		t.Errorf("got %d packets, %d observed and %d seen want the removed hooks gone", len(packets), observed, seen)
+	}
+}
+
+func TestHookSubscriber(t *testing.T) {
+	subscriber, err := sfu.NewSubscriber("hooked", sfu.WebRTCTransportConfig{})
+	if err != nil {
+		t.Fatalf("error making a subscriber: %s", err)
+	}
+	defer subscriber.Close()
+	if hookSubscriber(subscriber) != nil {
+		t.Errorf("a subscriber that never negotiated should not be hooked")
+	}
+	negotiated := 0
+	subscriber.OnNegotiationNeeded(func() {})
+	hooks := hookSubscriber(subscriber)
+	if hooks == nil || hookSubscriber(subscriber) != hooks {
+		t.Fatalf("want the subscriber hooked once")
+	}
+	defer func() {
+		hooksMu.Lock()
+		delete(subscriberHooksOf, subscriber)
+		hooksMu.Unlock()
+	}()
+	unhook, _ := hookNegotiate(subscriber, func() { negotiated++ })
+	negotiate(subscriber)
+	unhook()
+	negotiate(subscriber)
+	if negotiated != 1 {
+		t.Errorf("got the hook run %d times want once before it was removed", negotiated)
+	}
+
+	// senders made after the hook read through a senderRTCP of their own
+	track, _ := webrtc.NewTrackLocalStaticRTP(webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeVP8}, "video", "hooked")
+	sender, err := subscriberConnection(subscriber).AddTrack(track)
+	if err != nil {
+		t.Fatalf("error adding a track: %s", err)
+	}
+	if fanOut, ok := (*senderReader(sender)).(*senderRTCP); !ok || fanOut.subscriber != &hooks.rtcp || senderRTCPOf(sender) != fanOut {
+		t.Errorf("want the sender's reader the subscriber's fan out")
+	}
+}
+
+func TestCapEstimate(t *testing.T) {
+	buffers := buffer.NewBufferInterceptor()
+	reader := buffers.BindRemoteStream(&interceptor.StreamInfo{SSRC: 5, ClockRate: 90000}, interceptor.RTPReaderFunc(func() (*rtp.Packet, interceptor.Attributes, error) {
+		return &rtp.Packet{Header: rtp.Header{SSRC: 5, SequenceNumber: 1}}, nil, nil
+	}))
+	if _, _, err := reader.Read(); err != nil {
+		t.Fatalf("error reading a packet into the buffer: %s", err)
+	}
+	if !capEstimate(buffers, 5, 300000) || capEstimate(buffers, 6, 300000) || capEstimate(nil, 5, 300000) {
+		t.Fatalf("want only the buffered ssrc capped")
+	}
+	b := reflect.ValueOf(buffers).Elem().FieldByName("buffers").Index(0).Elem()
+	if capped := b.FieldByName("maxBitrate").Uint(); capped != 300000 {
+		t.Errorf("got %d want the buffer's REMBs capped at the target", capped)
+	}
+}
+
+func TestSetBufferTime(t *testing.T) {
+	buffers := buffer.NewBufferInterceptor()
+	reader := buffers.BindRemoteStream(&interceptor.StreamInfo{SSRC: 7, ClockRate: 90000}, interceptor.RTPReaderFunc(func() (*rtp.Packet, interceptor.Attributes, error) {
+		return &rtp.Packet{Header: rtp.Header{SSRC: 7, SequenceNumber: 1}}, nil, nil
+	}))
+	if _, _, err := reader.Read(); err != nil {
+		t.Fatalf("error reading a packet into the buffer: %s", err)
+	}
+	if !setBufferTime(buffers, 7, 3000) || setBufferTime(buffers, 8, 3000) {
+		t.Fatalf("want only the buffered ssrc sized")
+	}
+	queue := reflect.ValueOf(buffers).Elem().FieldByName("buffers").Index(0).Elem().FieldByName("pktQueue")
+	if duration := queue.FieldByName("duration").Uint(); duration != 270000 {
+		t.Errorf("got %d want 3s of the clock rate kept", duration)
+	}
+}
@@ -0,0 +1,294 @@
+package noir
+
+import (
+	"github.com/go-redis/redis"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	log "github.com/pion/ion-log"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+Signaling Traces:
+A traced room keeps its last SignalTraceCap requests and replies in redis, for debugging
+calls that failed. The queues the manager hands out are wrapped by TracingQueueFactory, so
+every message added to the router or a peer topic is traced without the senders knowing.
+Messages on the worker topics were routed from the router topic and are not traced twice.
+Traces are stored in the clear, so with an encrypted queue keyring every trace redacts SDP,
+or tracing would keep in redis the descriptions the queues encrypt.
+*/
+
+// How many messages a room's trace keeps, older ones are dropped
+const SignalTraceCap = 500
+
+// How long a manager trusts its list of traced rooms before reading it again
+const SignalTraceRefresh = time.Second
+
+const redactedSDP = "redacted"
+
+// TraceEntry is a request or reply of a traced room, one of Request and Reply is set
+type TraceEntry struct {
+	At      time.Time       `json:"at"`
+	Topic   string          `json:"topic"`
+	Pid     string          `json:"pid"`
+	Action  string          `json:"action"`
+	Request *pb.NoirRequest `json:"request,omitempty"`
+	Reply   *pb.NoirReply   `json:"reply,omitempty"`
+}
+
+// signalTracer caches the traced rooms (to whether they redact SDP) and the rooms of the peers it saw
+type signalTracer struct {
+	mu      sync.Mutex
+	fetched time.Time
+	rooms   map[string]bool
+	peers   map[string]string
+}
+
+// TracingQueueFactory wraps the queues of the router and peer topics to trace what is added to them,
+// redacting SDP if the manager's queues are encrypted
+func TracingQueueFactory(queues QueueFactory, manager *Manager) QueueFactory {
+	sealed := manager.QueueKeyring() != nil
+	return func(topic string, maxAge time.Duration) Queue {
+		queue := queues(topic, maxAge)
+		switch {
+		case topic == manager.RouterTopic(), strings.HasPrefix(topic, manager.Keys().TopicToPeer("")):
+			return &tracingQueue{Queue: queue, manager: manager, sealed: sealed}
+		case strings.HasPrefix(topic, manager.Keys().TopicFromPeer("")):
+			return &tracingQueue{Queue: queue, manager: manager, replies: true, sealed: sealed}
+		}
+		return queue
+	}
+}
+
+type tracingQueue struct {
+	Queue
+	manager *Manager
+	replies bool
+	sealed  bool
+}
+
+func (q *tracingQueue) Add(value []byte) error {
+	err := q.Queue.Add(value)
+	q.manager.traceMessage(q.Topic(), value, q.replies, q.sealed)
+	return err
+}
+
+func (q *tracingQueue) AddWithPriority(value []byte, priority Priority) error {
+	err := AddPriority(q.Queue, value, priority)
+	q.manager.traceMessage(q.Topic(), value, q.replies, q.sealed)
+	return err
+}
+
+// Requeue is not traced again, the message was when it was first added
+func (q *tracingQueue) Requeue(value []byte, priority Priority) error {
+	return Requeue(q.Queue, value, priority)
+}
+
+func (q *tracingQueue) Ack() error {
+	return Ack(q.Queue)
+}
+
+// TraceSignaling starts tracing a room over, or stops it. A stopped trace is kept to be read
+func (m *Manager) TraceSignaling(roomID string, enabled bool, redactSDP bool) error {
+	var err error
+	if enabled {
+		mode := "full"
+		if redactSDP {
+			mode = "redact"
+		}
+		_, err = m.redis.TxPipelined(func(pipe redis.Pipeliner) error {
+			pipe.Del(m.Keys().RoomTrace(roomID))
+			pipe.HSet(m.Keys().TracedRooms(), roomID, mode)
+			return nil
+		})
+	} else {
+		err = m.redis.HDel(m.Keys().TracedRooms(), roomID).Err()
+	}
+	// other managers notice within SignalTraceRefresh
+	m.tracer.mu.Lock()
+	m.tracer.fetched = time.Time{}
+	m.tracer.mu.Unlock()
+	return err
+}
+
+// GetSignalingTrace returns the traced messages of a room, oldest first
+func (m *Manager) GetSignalingTrace(sid string) ([]TraceEntry, error) {
+	values, err := m.redis.LRange(m.Keys().RoomTrace(sid), 0, -1).Result()
+	if err != nil {
+		return []TraceEntry{}, err
+	}
+	entries := make([]TraceEntry, 0, len(values))
+	for i := len(values) - 1; i >= 0; i-- {
+		var trace pb.SignalTrace
+		if err := proto.Unmarshal([]byte(values[i]), &trace); err != nil {
+			log.Warnf("skipping unreadable trace of %s: %s", sid, err)
+			continue
+		}
+		entries = append(entries, TraceEntry{
+			At:      trace.At.AsTime(),
+			Topic:   trace.Topic,
+			Pid:     trace.Pid,
+			Action:  trace.Action,
+			Request: trace.GetRequest(),
+			Reply:   trace.GetReply(),
+		})
+	}
+	return entries, nil
+}
+
+// tracing is false while no room is traced, which saves parsing every message
+func (m *Manager) tracing() bool {
+	t := &m.tracer
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if time.Since(t.fetched) > SignalTraceRefresh {
+		rooms, err := m.redis.HGetAll(m.Keys().TracedRooms()).Result()
+		if err != nil {
+			log.Errorf("error loading traced rooms: %s", err)
+		} else {
+			t.rooms = map[string]bool{}
+			for roomID, mode := range rooms {
+				t.rooms[roomID] = mode == "redact"
+			}
+		}
+		t.peers = map[string]string{}
+		t.fetched = time.Now()
+	}
+	return len(t.rooms) > 0
+}
+
+// tracedRoom returns the room of pid, unless roomID already says, and whether it is traced
+func (m *Manager) tracedRoom(pid string, roomID string) (string, bool, bool) {
+	t := &m.tracer
+	t.mu.Lock()
+	if roomID == "" {
+		roomID = t.peers[pid]
+	}
+	t.mu.Unlock()
+
+	if roomID == "" && pid != "" {
+		userData, err := m.GetRemoteUserData(pid)
+		if err != nil || userData == nil {
+			return "", false, false
+		}
+		roomID = userData.RoomID
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if pid != "" && t.peers != nil {
+		t.peers[pid] = roomID
+	}
+	redact, traced := t.rooms[roomID]
+	return roomID, redact, traced
+}
+
+func (m *Manager) traceMessage(topic string, value []byte, isReply bool, sealed bool) {
+	if !m.tracing() {
+		return
+	}
+	trace := &pb.SignalTrace{At: timestamppb.Now(), Topic: topic}
+	roomID := ""
+	if isReply {
+		reply := &pb.NoirReply{}
+		if err := UnmarshalReply(value, reply); err != nil {
+			return
+		}
+		trace.Pid = reply.GetSignal().GetId()
+		trace.Action = ReadReplyAction(reply)
+		trace.Message = &pb.SignalTrace_Reply{Reply: reply}
+	} else {
+		request := &pb.NoirRequest{}
+		if err := UnmarshalRequest(value, request); err != nil {
+			return
+		}
+		trace.Pid = request.GetSignal().GetId()
+		trace.Action, _ = ReadAction(request)
+		roomID = request.GetSignal().GetJoin().GetSid()
+		if roomID == "" {
+			roomID = request.GetAdmin().GetRoomAdmin().GetRoomID()
+		}
+		// tokens and passwords are credentials, they are never traced
+		if join := request.GetSignal().GetJoin(); join != nil {
+			join.Token = ""
+			join.Password = ""
+		}
+		if options := request.GetAdmin().GetRoomAdmin().GetCreateRoom().GetOptions(); options != nil {
+			options.JoinPassword = ""
+		}
+		if setPassword := request.GetAdmin().GetRoomAdmin().GetSetPassword(); setPassword != nil {
+			setPassword.Password = ""
+		}
+		trace.Message = &pb.SignalTrace_Request{Request: request}
+	}
+	if trace.Pid == "" {
+		trace.Pid = topicPeer(m.Keys(), topic)
+	}
+
+	roomID, redact, traced := m.tracedRoom(trace.Pid, roomID)
+	if !traced {
+		return
+	}
+	if redact || sealed {
+		redactTrace(trace)
+	}
+	packed, err := proto.Marshal(trace)
+	if err != nil {
+		log.Errorf("error packing trace of %s: %s", roomID, err)
+		return
+	}
+	key := m.Keys().RoomTrace(roomID)
+	_, err = m.redis.TxPipelined(func(pipe redis.Pipeliner) error {
+		pipe.LPush(key, packed)
+		pipe.LTrim(key, 0, SignalTraceCap-1)
+		return nil
+	})
+	if err != nil {
+		log.Errorf("error tracing %s in %s: %s", trace.Action, roomID, err)
+	}
+}
+
+// topicPeer is the pid of a peer topic, and empty for the router
+func topicPeer(keys pb.Keys, topic string) string {
+	for _, prefix := range []string{keys.TopicToPeer(""), keys.TopicFromPeer("")} {
+		if strings.HasPrefix(topic, prefix) {
+			return strings.TrimPrefix(topic, prefix)
+		}
+	}
+	return ""
+}
+
+// redactTrace blanks the descriptions and candidates, which name the peer's addresses
+func redactTrace(trace *pb.SignalTrace) {
+	if signal := trace.GetRequest().GetSignal(); signal != nil {
+		switch payload := signal.Payload.(type) {
+		case *pb.SignalRequest_Join:
+			payload.Join.Description = []byte(redactedSDP)
+		case *pb.SignalRequest_Description:
+			payload.Description = []byte(redactedSDP)
+		case *pb.SignalRequest_Trickle:
+			payload.Trickle.Init = redactedSDP
+		case *pb.SignalRequest_TrickleBatch:
+			for _, trickle := range payload.TrickleBatch.Candidates {
+				trickle.Init = redactedSDP
+			}
+		}
+	}
+	if signal := trace.GetReply().GetSignal(); signal != nil {
+		switch payload := signal.Payload.(type) {
+		case *pb.SignalReply_Join:
+			payload.Join.Description = []byte(redactedSDP)
+		case *pb.SignalReply_Description:
+			payload.Description = []byte(redactedSDP)
+		case *pb.SignalReply_Trickle:
+			payload.Trickle.Init = redactedSDP
+		case *pb.SignalReply_TrickleBatch:
+			for _, trickle := range payload.TrickleBatch.Candidates {
+				trickle.Init = redactedSDP
+			}
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package noir
+
+import (
+	"bytes"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"testing"
+	"time"
+)
+
+func TestSignalingTrace(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	defer redis.HDel(pb.KeyTracedRooms(), "traced")
+	mgr.SaveData(pb.KeyUserData("traced-peer"), &pb.NoirObject{
+		Data: &pb.NoirObject_User{User: &pb.UserData{Id: "traced-peer", RoomID: "traced"}},
+	}, 0)
+	mgr.GetQueue(pb.KeyTopicToPeer("traced-peer")).Cleanup()
+	mgr.GetQueue(pb.KeyTopicFromPeer("traced-peer")).Cleanup()
+
+	if err := mgr.TraceSignaling("traced", true, true); err != nil {
+		t.Fatalf("error tracing room: %s", err)
+	}
+	router := *mgr.GetRouter()
+	EnqueueRequest(*router.GetQueue(), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id: "traced-peer",
+				Payload: &pb.SignalRequest_Join{
+					Join: &pb.JoinRequest{Sid: "traced", Description: []byte(EXAMPLE_EMPTY_SDP), Token: "secret", Password: "hunter2"},
+				},
+			},
+		},
+	})
+	// nobody should route the join
+	(*router.GetQueue()).Cleanup()
+	EnqueueReply(mgr.GetQueue(pb.KeyTopicFromPeer("traced-peer")), &pb.NoirReply{
+		Command: &pb.NoirReply_Signal{
+			Signal: &pb.SignalReply{
+				Id:      "traced-peer",
+				Payload: &pb.SignalReply_Description{Description: []byte(EXAMPLE_EMPTY_SDP)},
+			},
+		},
+	})
+	EnqueueRequest(mgr.GetQueue(pb.KeyTopicToPeer("traced-peer")), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{Payload: &pb.SignalRequest_Kill{Kill: true}},
+		},
+	})
+	// Not in the traced room
+	EnqueueRequest(mgr.GetQueue(pb.KeyTopicToPeer("other-peer")), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{Id: "other-peer", Payload: &pb.SignalRequest_Kill{Kill: true}},
+		},
+	})
+
+	mgr.GetQueue(pb.KeyTopicToPeer("traced-peer")).Cleanup()
+	mgr.GetQueue(pb.KeyTopicFromPeer("traced-peer")).Cleanup()
+	mgr.GetQueue(pb.KeyTopicToPeer("other-peer")).Cleanup()
+
+	trace, err := mgr.GetSignalingTrace("traced")
+	if err != nil {
+		t.Fatalf("error getting trace: %s", err)
+	}
+	want := []string{"request.servers.join", "reply.signal.description", "request.servers.kill"}
+	if len(trace) != len(want) {
+		t.Fatalf("got %d entries want %d: %v", len(trace), len(want), trace)
+	}
+	for i, action := range want {
+		if trace[i].Action != action || trace[i].Pid != "traced-peer" {
+			t.Errorf("entry %d: got %s of %s want %s of traced-peer", i, trace[i].Action, trace[i].Pid, action)
+		}
+	}
+	join := trace[0].Request.GetSignal().GetJoin()
+	if string(join.GetDescription()) != redactedSDP || join.GetToken() != "" || join.GetPassword() != "" {
+		t.Errorf("join should be redacted and without credentials, got %s", join)
+	}
+	if string(trace[1].Reply.GetSignal().GetDescription()) != redactedSDP {
+		t.Errorf("reply description should be redacted")
+	}
+
+	queue := mgr.GetQueue(pb.KeyTopicToPeer("traced-peer"))
+	for i := 0; i < SignalTraceCap+10; i++ {
+		EnqueueRequest(queue, &pb.NoirRequest{
+			Command: &pb.NoirRequest_Signal{
+				Signal: &pb.SignalRequest{Id: "traced-peer", Payload: &pb.SignalRequest_Kill{Kill: true}},
+			},
+		})
+	}
+	queue.Cleanup()
+	if trace, _ := mgr.GetSignalingTrace("traced"); len(trace) != SignalTraceCap {
+		t.Errorf("got %d entries want the trace capped at %d", len(trace), SignalTraceCap)
+	}
+
+	mgr.TraceSignaling("traced", false, false)
+	EnqueueRequest(queue, &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{Id: "traced-peer", Payload: &pb.SignalRequest_Leave{Leave: true}},
+		},
+	})
+	queue.Cleanup()
+	if trace, _ := mgr.GetSignalingTrace("traced"); trace[len(trace)-1].Action == "request.servers.leave" {
+		t.Errorf("a stopped trace should not grow")
+	}
+}
+
+func TestSignalingTraceSealed(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	defer redis.HDel(pb.KeyTracedRooms(), "sealed")
+	mgr.SaveData(pb.KeyUserData("sealed-peer"), &pb.NoirObject{
+		Data: &pb.NoirObject_User{User: &pb.UserData{Id: "sealed-peer", RoomID: "sealed"}},
+	}, 0)
+	keyring, _ := NewQueueKeyring("k1", map[string][]byte{"k1": bytes.Repeat([]byte{7}, 32)})
+	mgr.SetQueueKeyring(keyring)
+	defer mgr.SetQueueKeyring(nil)
+	queues := TracingQueueFactory(EncryptedQueueFactory(func(topic string, _ time.Duration) Queue {
+		return NewTestQueue(topic)
+	}, keyring, mgr), mgr)
+	queue := queues(pb.KeyTopicFromPeer("sealed-peer"), 0)
+	queue.Cleanup()
+	defer queue.Cleanup()
+
+	// a full trace, but the queues are encrypted
+	if err := mgr.TraceSignaling("sealed", true, false); err != nil {
+		t.Fatalf("error tracing room: %s", err)
+	}
+	EnqueueReply(queue, &pb.NoirReply{
+		Command: &pb.NoirReply_Signal{
+			Signal: &pb.SignalReply{
+				Id:      "sealed-peer",
+				Payload: &pb.SignalReply_Description{Description: []byte(EXAMPLE_EMPTY_SDP)},
+			},
+		},
+	})
+	trace, err := mgr.GetSignalingTrace("sealed")
+	if err != nil || len(trace) != 1 {
+		t.Fatalf("got %v %s want the reply traced", trace, err)
+	}
+	if string(trace[0].Reply.GetSignal().GetDescription()) != redactedSDP {
+		t.Errorf("got %q want the description of an encrypted queue redacted", trace[0].Reply.GetSignal().GetDescription())
+	}
+}
@@ -0,0 +1,65 @@
+package noir
+
+import (
+	"errors"
+	"fmt"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"github.com/pion/webrtc/v3"
+	"reflect"
+)
+
+// ion-sfu simulcasts up to 3 spatial layers, 0 (low) to 2 (high)
+const MaxSpatialLayer = 2
+
+var (
+	ErrTrackNotFound = errors.New("no video track for stream")
+	ErrNotSimulcast  = errors.New("publisher is not sending simulcast")
+)
+
+// SwitchLayer switches a subscriber's video downtracks of streamID to a spatial layer and returns the
+// layers it will be sent. ion-sfu v1.6 picks temporal layers on its own, so temporal is only reported
+func SwitchLayer(peer *sfu.Peer, streamID string, spatial int32) (int32, int32, error) {
+	subscriber := subscriberOf(peer)
+	if subscriber == nil {
+		return 0, 0, sfu.ErrNoTransportEstablished
+	}
+
+	if spatial < 0 {
+		spatial = 0
+	} else if spatial > MaxSpatialLayer {
+		spatial = MaxSpatialLayer
+	}
+
+	found := false
+	for _, track := range subscriber.GetDownTracks(streamID) {
+		if track.Kind() != webrtc.RTPCodecTypeVideo {
+			continue
+		}
+		found = true
+		if applied, temporal, ok := switchDownTrack(track, spatial); ok {
+			return applied, temporal, nil
+		}
+	}
+
+	if !found {
+		return 0, 0, fmt.Errorf("%w %s", ErrTrackNotFound, streamID)
+	}
+	return 0, 0, fmt.Errorf("%w for stream %s, it has a single layer", ErrNotSimulcast, streamID)
+}
+
+// switchDownTrack switches a simulcast downtrack to a spatial layer and returns the layers it will
+// be sent, false for a downtrack that forwards no simulcast
+func switchDownTrack(track *sfu.DownTrack, spatial int32) (int32, int32, bool) {
+	state := reflect.ValueOf(track).Elem()
+	if sfu.DownTrackType(state.FieldByName("trackType").Int()) != sfu.SimulcastDownTrack {
+		return 0, 0, false
+	}
+	track.SwitchSpatialLayer(int(spatial))
+	simulcast := state.FieldByName("simulcast")
+	applied := int32(simulcast.FieldByName("targetSpatialLayer").Int())
+	temporal := int32(0)
+	if simulcast.FieldByName("temporalEnabled").Bool() {
+		temporal = int32(simulcast.FieldByName("currentTempLayer").Int())
+	}
+	return applied, temporal, true
+}
@@ -0,0 +1,33 @@
+package noir
+
+import (
+	"errors"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"reflect"
+	"testing"
+)
+
+// SwitchLayer reads ion-sfu internals, this breaks loudly when they get renamed
+func TestSimulcastFields(t *testing.T) {
+
+	downTrack := reflect.TypeOf(sfu.DownTrack{})
+	if _, ok := downTrack.FieldByName("trackType"); !ok {
+		t.Errorf("sfu.DownTrack has no trackType")
+	}
+	simulcast, ok := downTrack.FieldByName("simulcast")
+	if !ok {
+		t.Fatalf("sfu.DownTrack has no simulcast helpers")
+	}
+	for _, name := range []string{"targetSpatialLayer", "temporalEnabled", "currentTempLayer"} {
+		if _, ok := simulcast.Type.FieldByName(name); !ok {
+			t.Errorf("sfu.DownTrack simulcast has no %s", name)
+		}
+	}
+}
+
+func TestSwitchLayerBeforeJoin(t *testing.T) {
+	peer := sfu.NewPeer(nil)
+	if _, _, err := SwitchLayer(peer, "stream", 1); !errors.Is(err, sfu.ErrNoTransportEstablished) {
+		t.Errorf("got %v want %s", err, sfu.ErrNoTransportEstablished)
+	}
+}
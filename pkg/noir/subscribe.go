@@ -0,0 +1,387 @@
+package noir
+
+import (
+	"errors"
+	"fmt"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	log "github.com/pion/ion-log"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"sort"
+	"sync"
+)
+
+/*
+Selective Subscribe:
+ion-sfu sends every peer every track in its session. A peer that subscribes or unsubscribes
+picks the publishers it receives instead, starting from the ones it receives at the time:
+subscribe adds publishers, unsubscribe drops them, and unsubscribe without pids drops them all.
+Tracks of publishers it did not pick, including ones that join later, are taken out ahead of
+the subscriber's next offer, so a change costs one renegotiation. Both reply with the
+Subscriptions that result. When a picked publisher leaves, the peer is sent its Subscriptions
+with left set, and it should drop that publisher's tiles rather than wait on its tracks.
+
+Rooms with maxSubscribedTracks keep big meetings from sending every peer every track: a joining
+peer is subscribed to publishers automatically, in pid order, only while they fit under the
+limit. The ones that do not are sent in its Subscriptions as capped, and the peer has to swap
+them in, unsubscribing others first, as Subscribe past the limit fails. Once a peer subscribes or
+unsubscribes itself it is no longer subscribed to anyone automatically.
+
+A peer that knows who it wants, eg. the active speaker and whoever shares their screen, can join
+with them in its subscribe instead, and receives only those from its first negotiation on. Each
+has to be in the room, or the join fails with PEER_NOT_FOUND. They are subscribed automatically
+like above, so a listed publisher that has not published yet is received once it does, in the
+room's maxSubscribedTracks, and no one else is. A join with noAutoSubscribe and no subscribe
+receives no one until it subscribes.
+*/
+
+var (
+	ErrPublisherNotFound  = errors.New("no such publisher in the room")
+	ErrSubscriberNotReady = errors.New("peer has no subscriber yet")
+	ErrSubscriptionLimit  = errors.New("subscribing would pass the room's track limit")
+)
+
+// selection is the publishers a peer picked
+type selection struct {
+	pid    string
+	mu     sync.Mutex
+	wanted map[string]bool
+	// set by LimitSubscriptions, auto subscribes new publishers while under the limit of tracks
+	limit   int
+	auto    bool
+	capped  map[string]bool
+	limited bool
+	// set by SelectSubscriptions, the only publishers an automatic selection subscribes to
+	only map[string]bool
+	// takes the selection's prune off the subscriber once it is replaced or dropped
+	unhook func()
+}
+
+func (s *selection) pids() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pids := make([]string, 0, len(s.wanted))
+	for pid := range s.wanted {
+		pids = append(pids, pid)
+	}
+	sort.Strings(pids)
+	return pids
+}
+
+// admit subscribes an automatic selection to the publishers it did not see yet while their tracks
+// fit under its limit, and returns the ones that did not fit, s.mu has to be held
+func (s *selection) admit(tracks map[string][]*sfu.DownTrack) []string {
+	if !s.auto {
+		return nil
+	}
+	subscribed := 0
+	publishers := make([]string, 0, len(tracks))
+	for publisher := range tracks {
+		if s.wanted[publisher] {
+			subscribed += len(tracks[publisher])
+		}
+		publishers = append(publishers, publisher)
+	}
+	sort.Strings(publishers)
+	capped := []string{}
+	for _, publisher := range publishers {
+		if publisher == "" || s.wanted[publisher] || s.capped[publisher] {
+			continue
+		}
+		if s.only != nil && !s.only[publisher] {
+			continue
+		}
+		if s.limit > 0 && subscribed+len(tracks[publisher]) > s.limit {
+			s.capped[publisher] = true
+			capped = append(capped, publisher)
+			continue
+		}
+		s.wanted[publisher] = true
+		subscribed += len(tracks[publisher])
+	}
+	if len(capped) > 0 && !s.limited {
+		s.limited = true
+		subscriptionsLimited.Inc()
+	}
+	return capped
+}
+
+// pidsBySFUID maps the sfu's peer ids to noir pids, m.mu has to be held
+func (m *Manager) pidsBySFUID() map[string]string {
+	pids := make(map[string]string, len(m.users))
+	for pid, client := range m.users {
+		pids[sfuPeerID(client.SFUPeer())] = pid
+	}
+	return pids
+}
+
+// selectionOf is the peer's selection, made from the publishers it receives the first time
+func (m *Manager) selectionOf(pid string, peer *sfu.Peer) (*selection, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if picked := m.selections[pid]; picked != nil {
+		return picked, nil
+	}
+	subscriber := subscriberOf(peer)
+	if subscriber == nil {
+		return nil, ErrSubscriberNotReady
+	}
+	picked := &selection{pid: pid, wanted: map[string]bool{}}
+	pids := m.pidsBySFUID()
+	for _, track := range downTracksOf(subscriber) {
+		if publisher, ok := pids[downTrackPublisher(track)]; ok {
+			picked.wanted[publisher] = true
+		}
+	}
+	unhook, ok := hookNegotiate(subscriber, func() { go m.prune(subscriber, picked) })
+	if !ok {
+		return nil, ErrSubscriberNotReady
+	}
+	picked.unhook = unhook
+	m.selections[pid] = picked
+	return picked, nil
+}
+
+// LimitSubscriptions subscribes the peer to publishers automatically only while it gets at most
+// limit tracks, see the top of the file
+func (m *Manager) LimitSubscriptions(pid string, peer *sfu.Peer, limit int) error {
+	subscriber := subscriberOf(peer)
+	if subscriber == nil {
+		return ErrSubscriberNotReady
+	}
+	return m.autoSubscribe(subscriber, &selection{pid: pid, wanted: map[string]bool{}, capped: map[string]bool{}, limit: limit, auto: true})
+}
+
+// SelectSubscriptions subscribes the joining peer to publishers automatically only when they
+// are one of the publishers and while it gets at most limit tracks, 0 is no limit
+func (m *Manager) SelectSubscriptions(pid string, peer *sfu.Peer, publishers []string, limit int) error {
+	subscriber := subscriberOf(peer)
+	if subscriber == nil {
+		return ErrSubscriberNotReady
+	}
+	only := make(map[string]bool, len(publishers))
+	for _, publisher := range publishers {
+		only[publisher] = publisher != pid
+	}
+	return m.autoSubscribe(subscriber, &selection{pid: pid, wanted: map[string]bool{}, capped: map[string]bool{}, limit: limit, auto: true, only: only})
+}
+
+// CheckSubscriptions is ErrPublisherNotFound unless every publisher pid joining roomID would
+// subscribe to is in it
+func (m *Manager) CheckSubscriptions(roomID string, pid string, publishers []string) error {
+	for _, publisher := range publishers {
+		if publisher == pid {
+			continue
+		}
+		if !m.redis.HExists(m.Keys().RoomUsers(roomID), publisher).Val() {
+			return fmt.Errorf("%w: %s", ErrPublisherNotFound, publisher)
+		}
+	}
+	return nil
+}
+
+// autoSubscribe has picked choose the subscriber's publishers, from the ones in the room on
+func (m *Manager) autoSubscribe(subscriber *sfu.Subscriber, picked *selection) error {
+	m.mu.Lock()
+	unhook, ok := hookNegotiate(subscriber, func() { go m.prune(subscriber, picked) })
+	if !ok {
+		m.mu.Unlock()
+		return ErrSubscriberNotReady
+	}
+	picked.unhook = unhook
+	replaced := m.selections[picked.pid]
+	m.selections[picked.pid] = picked
+	m.mu.Unlock()
+	if replaced != nil && replaced.unhook != nil {
+		replaced.unhook()
+	}
+	// the publishers already in the room were added when it joined
+	m.prune(subscriber, picked)
+	return nil
+}
+
+// publishedTracks is the subscriber's tracks by the pid they are from
+func (m *Manager) publishedTracks(subscriber *sfu.Subscriber) map[string][]*sfu.DownTrack {
+	m.mu.RLock()
+	pids := m.pidsBySFUID()
+	m.mu.RUnlock()
+	tracks := map[string][]*sfu.DownTrack{}
+	for _, track := range downTracksOf(subscriber) {
+		publisher := pids[downTrackPublisher(track)]
+		tracks[publisher] = append(tracks[publisher], track)
+	}
+	return tracks
+}
+
+// prune removes the subscriber's tracks from publishers it did not pick, under the selection's
+// lock. ion-sfu starts reading a new downtrack's rtcp in a goroutine, which crashes when the
+// track was removed before it got going, so a track it has not read yet is pruned from there
+func (m *Manager) prune(subscriber *sfu.Subscriber, picked *selection) {
+	m.mu.RLock()
+	current := m.selections[picked.pid]
+	m.mu.RUnlock()
+	if current != picked {
+		// dropped, the peer left or moved rooms
+		return
+	}
+	tracks := m.publishedTracks(subscriber)
+	picked.mu.Lock()
+	capped := picked.admit(tracks)
+	for publisher, published := range tracks {
+		if picked.wanted[publisher] {
+			continue
+		}
+		for _, track := range published {
+			sender := downTrackSender(track)
+			if sender != nil && senderRTCPOf(sender).whenReading(func() { m.prune(subscriber, picked) }) {
+				continue
+			}
+			removeDownTrack(subscriber, track)
+		}
+	}
+	picked.mu.Unlock()
+	if len(capped) > 0 {
+		m.notifySubscriptions(picked.pid, &pb.Subscriptions{Pids: picked.pids(), Capped: capped})
+	}
+}
+
+// notifySubscriptions sends the peer its Subscriptions unasked
+func (m *Manager) notifySubscriptions(pid string, subscriptions *pb.Subscriptions) error {
+	err := m.EnqueueReply(m.GetQueue(m.Keys().TopicFromPeer(pid)), &pb.NoirReply{
+		Command: &pb.NoirReply_Signal{
+			Signal: &pb.SignalReply{
+				Id:      pid,
+				Payload: &pb.SignalReply_Subscriptions{Subscriptions: subscriptions},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	m.redis.Publish(m.Keys().PeerNewsChannel(pid), pid)
+	return nil
+}
+
+// Subscribe has the peer receive the room's publishers too, or stop receiving them, and
+// returns the publishers it receives from now on
+func (m *Manager) Subscribe(userData *pb.UserData, peer *sfu.Peer, publishers []string, subscribe bool) ([]string, error) {
+	picked, err := m.selectionOf(userData.Id, peer)
+	if err != nil {
+		return nil, err
+	}
+	subscriber := subscriberOf(peer)
+	picked.mu.Lock()
+	picked.auto = false
+	limit := picked.limit
+	picked.mu.Unlock()
+	if !subscribe {
+		picked.mu.Lock()
+		if len(publishers) == 0 {
+			picked.wanted = map[string]bool{}
+		}
+		for _, pid := range publishers {
+			delete(picked.wanted, pid)
+		}
+		picked.mu.Unlock()
+		m.prune(subscriber, picked)
+		return picked.pids(), nil
+	}
+
+	for _, pid := range publishers {
+		if pid == userData.Id {
+			continue
+		}
+		publisherData, err := m.GetRemoteUserData(pid)
+		publisher := m.localPeer(pid)
+		if err != nil || publisherData == nil || publisherData.RoomID != userData.RoomID || publisherOf(publisher) == nil {
+			return picked.pids(), fmt.Errorf("%w: %s", ErrPublisherNotFound, pid)
+		}
+		if limit > 0 && !m.fits(subscriber, picked, pid, len(receiversOf(publisherOf(publisher)))) {
+			return picked.pids(), fmt.Errorf("%w of %d tracks: %s", ErrSubscriptionLimit, limit, pid)
+		}
+		picked.mu.Lock()
+		picked.wanted[pid] = true
+		delete(picked.capped, pid)
+		picked.mu.Unlock()
+		if err := publisherOf(publisher).GetRouter().AddDownTracks(subscriber, nil); err != nil {
+			return picked.pids(), err
+		}
+	}
+	return picked.pids(), nil
+}
+
+// fits is true when the subscriber stays under its limit with the publisher's tracks
+func (m *Manager) fits(subscriber *sfu.Subscriber, picked *selection, publisher string, published int) bool {
+	tracks := m.publishedTracks(subscriber)
+	picked.mu.Lock()
+	defer picked.mu.Unlock()
+	if picked.wanted[publisher] {
+		return true
+	}
+	subscribed := 0
+	for pid := range picked.wanted {
+		subscribed += len(tracks[pid])
+	}
+	return subscribed+published <= picked.limit
+}
+
+// dropSelections forgets what pid picked, and tells whoever picked pid that it left
+func (m *Manager) dropSelections(pid string) {
+	m.mu.Lock()
+	dropped := m.selections[pid]
+	delete(m.selections, pid)
+	subscribers := map[string]*selection{}
+	for subscriber, picked := range m.selections {
+		subscribers[subscriber] = picked
+	}
+	m.mu.Unlock()
+	if dropped != nil && dropped.unhook != nil {
+		dropped.unhook()
+	}
+
+	for subscriber, picked := range subscribers {
+		picked.mu.Lock()
+		wanted := picked.wanted[pid]
+		delete(picked.wanted, pid)
+		delete(picked.capped, pid)
+		picked.mu.Unlock()
+		if !wanted {
+			continue
+		}
+		err := m.notifySubscriptions(subscriber, &pb.Subscriptions{Pids: picked.pids(), Left: pid})
+		if err != nil {
+			log.Errorf("error telling %s that %s left: %s", subscriber, pid, err)
+		}
+	}
+}
+
+// HandleSubscribe runs a subscribe or unsubscribe from the peer and replies with its Subscriptions
+func (w *worker) HandleSubscribe(request *pb.NoirRequest, userData *pb.UserData, peer *sfu.Peer) error {
+	signal := request.GetSignal()
+	var publishers []string
+	var err error
+	if subscribe := signal.GetSubscribe(); subscribe != nil {
+		publishers, err = w.manager.Subscribe(userData, peer, subscribe.Pids, true)
+	} else {
+		publishers, err = w.manager.Subscribe(userData, peer, signal.GetUnsubscribe().GetPids(), false)
+	}
+	if errors.Is(err, ErrPublisherNotFound) {
+		return w.SignalError(request, pb.SignalError_PEER_NOT_FOUND, err)
+	} else if errors.Is(err, ErrSubscriptionLimit) {
+		return w.SignalError(request, pb.SignalError_SUBSCRIPTION_LIMIT, err)
+	} else if err != nil {
+		return w.SignalError(request, pb.SignalError_UNKNOWN, err)
+	}
+	return w.SignalReply(signal.Id, &pb.NoirReply{
+		Id:      request.Id,
+		TraceID: request.TraceID,
+		Command: &pb.NoirReply_Signal{
+			Signal: &pb.SignalReply{
+				Id:        signal.Id,
+				RequestId: signal.RequestId,
+				Payload: &pb.SignalReply_Subscriptions{
+					Subscriptions: &pb.Subscriptions{Pids: publishers},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,265 @@
+package noir
+
+import (
+	"context"
+	"errors"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/protobuf/proto"
+	"sort"
+	"testing"
+	"time"
+)
+
+// receiving is true once the sfu forwards a track of pub to sub
+func receiving(mgr *Manager, sub string, pub string) bool {
+	subscriber, publisher := mgr.localPeer(sub), mgr.localPeer(pub)
+	if subscriber == nil || publisher == nil || subscriberOf(subscriber) == nil {
+		return false
+	}
+	for _, track := range downTracksOf(subscriberOf(subscriber)) {
+		if downTrackPublisher(track) == sfuPeerID(publisher) {
+			return true
+		}
+	}
+	return false
+}
+
+func waitFor(t *testing.T, what string, condition func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); {
+		if condition() {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %s", what)
+}
+
+func TestSelectiveSubscribe(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	redis.Del(pb.KeyRoomData("subscribe-room"), pb.KeyRoomUsers("subscribe-room"))
+	go func() {
+		for i := 0; i < 2; i++ {
+			routeOnce(mgr)
+		}
+	}()
+	bots := NewLoadBot(context.Background(), mgr, LoadBotConfig{Publish: true})
+	defer bots.Stop()
+	bots.Spawn(2, "subscribe-room")
+	pids := []string{}
+	for pid := range bots.bots {
+		pids = append(pids, pid)
+	}
+	if len(pids) != 2 {
+		t.Fatalf("got %d bots want 2", len(pids))
+	}
+	sort.Strings(pids)
+	sub, pub := pids[0], pids[1]
+	client := bots.bots[sub].client
+	waitFor(t, "the publisher's track", func() bool { return receiving(mgr, sub, pub) })
+
+	subscribed, err := client.Unsubscribe(pub)
+	if err != nil || len(subscribed) != 0 {
+		t.Fatalf("got %v %v want no subscriptions left", subscribed, err)
+	}
+	waitFor(t, "the track to go", func() bool { return !receiving(mgr, sub, pub) })
+
+	subscribed, err = client.Subscribe(pub)
+	if err != nil || len(subscribed) != 1 || subscribed[0] != pub {
+		t.Fatalf("got %v %v want subscribed to %s", subscribed, err, pub)
+	}
+	waitFor(t, "the track to come back", func() bool { return receiving(mgr, sub, pub) })
+
+	var failure *SignalFailure
+	if _, err := client.Subscribe("nobody"); !errors.As(err, &failure) || failure.Code != pb.SignalError_PEER_NOT_FOUND {
+		t.Errorf("got %v want PEER_NOT_FOUND", err)
+	}
+}
+
+func TestSubscribedPublisherLeaves(t *testing.T) {
+	mgr, _ := NewTestSetup()
+	queue := mgr.GetQueue(pb.KeyTopicFromPeer("grid-viewer"))
+	queue.Cleanup()
+	mgr.selections["grid-viewer"] = &selection{wanted: map[string]bool{"tile-1": true, "tile-2": true}}
+	mgr.selections["other-viewer"] = &selection{wanted: map[string]bool{"tile-2": true}}
+
+	mgr.dropSelections("tile-1")
+	message, err := queue.Next()
+	if err != nil {
+		t.Fatalf("got %s want a notification", err)
+	}
+	reply := &pb.NoirReply{}
+	proto.Unmarshal(message, reply)
+	subscriptions := reply.GetSignal().GetSubscriptions()
+	if subscriptions.GetLeft() != "tile-1" || len(subscriptions.GetPids()) != 1 || subscriptions.Pids[0] != "tile-2" {
+		t.Errorf("got %v want tile-1 left and tile-2 still subscribed", subscriptions)
+	}
+	if count, _ := mgr.GetQueue(pb.KeyTopicFromPeer("other-viewer")).Count(); count != 0 {
+		t.Errorf("got %d notifications for a peer that never subscribed to tile-1", count)
+	}
+}
+
+func TestSelectionAdmit(t *testing.T) {
+	tracks := map[string][]*sfu.DownTrack{
+		"cam-a": {{}, {}},
+		"cam-b": {{}},
+		"cam-c": {{}, {}},
+		"":      {{}},
+	}
+	picked := &selection{wanted: map[string]bool{}, capped: map[string]bool{}, limit: 3, auto: true}
+	capped := picked.admit(tracks)
+	if wanted := picked.pids(); len(wanted) != 2 || wanted[0] != "cam-a" || wanted[1] != "cam-b" {
+		t.Errorf("got %v want cam-a and cam-b in 3 tracks", wanted)
+	}
+	if len(capped) != 1 || capped[0] != "cam-c" {
+		t.Errorf("got capped %v want cam-c", capped)
+	}
+	// a publisher is only reported capped once
+	if capped := picked.admit(tracks); len(capped) != 0 {
+		t.Errorf("got capped %v again", capped)
+	}
+	picked.auto = false
+	tracks["cam-d"] = []*sfu.DownTrack{{}}
+	if capped := picked.admit(tracks); len(capped) != 0 || picked.wanted["cam-d"] {
+		t.Errorf("a peer that picked its publishers should not be subscribed automatically")
+	}
+}
+
+// publishing counts the bots the sfu gets a track from
+func publishing(mgr *Manager, bots *LoadBot) int {
+	bots.mu.Lock()
+	defer bots.mu.Unlock()
+	count := 0
+	for pid := range bots.bots {
+		peer := mgr.localPeer(pid)
+		if peer != nil && len(receiversOf(publisherOf(peer))) > 0 {
+			count++
+		}
+	}
+	return count
+}
+
+func TestMaxSubscribedTracks(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	redis.Del(pb.KeyRoomData("capped-room"), pb.KeyRoomUsers("capped-room"))
+	mgr.SetRoomData(&pb.RoomData{Id: "capped-room", NodeID: mgr.ID(), Options: &pb.RoomOptions{MaxAgeSeconds: -1, MaxSubscribedTracks: 1}})
+	defer redis.Del(pb.KeyRoomData("capped-room"), pb.KeyRoomUsers("capped-room"))
+	limitedBefore := testutil.ToFloat64(subscriptionsLimited)
+	bots := NewLoadBot(context.Background(), mgr, LoadBotConfig{Publish: true})
+	defer bots.Stop()
+	// ion-sfu crashes on a track that arrives while another peer is joining, so each bot
+	// publishes before the next one joins
+	for i := 1; i <= 3; i++ {
+		go routeOnce(mgr)
+		bots.Spawn(1, "capped-room")
+		waitFor(t, "the bot to publish", func() bool { return publishing(mgr, bots) == i })
+	}
+	pids := []string{}
+	for pid := range bots.bots {
+		pids = append(pids, pid)
+	}
+	if len(pids) != 3 {
+		t.Fatalf("got %d bots want 3", len(pids))
+	}
+	sort.Strings(pids)
+
+	// everyone gets one other bot's opus track, the third is left for them to swap in
+	waitFor(t, "every bot to reach the limit", func() bool {
+		return testutil.ToFloat64(subscriptionsLimited)-limitedBefore == 3
+	})
+	for _, sub := range pids {
+		count := 0
+		for _, pub := range pids {
+			if pub != sub && receiving(mgr, sub, pub) {
+				count++
+			}
+		}
+		if count != 1 {
+			t.Errorf("%s receives %d publishers want 1", sub, count)
+		}
+	}
+
+	var other string
+	for _, pub := range pids[1:] {
+		if !receiving(mgr, pids[0], pub) {
+			other = pub
+		}
+	}
+	client := bots.bots[pids[0]].client
+	var failure *SignalFailure
+	if _, err := client.Subscribe(other); !errors.As(err, &failure) || failure.Code != pb.SignalError_SUBSCRIPTION_LIMIT {
+		t.Errorf("got %v want SUBSCRIPTION_LIMIT", err)
+	}
+	if _, err := client.Unsubscribe(); err != nil {
+		t.Fatalf("error unsubscribing: %s", err)
+	}
+	if subscribed, err := client.Subscribe(other); err != nil || len(subscribed) != 1 || subscribed[0] != other {
+		t.Errorf("got %v %v want %s swapped in", subscribed, err, other)
+	}
+}
+
+func TestSelectionOnly(t *testing.T) {
+	tracks := map[string][]*sfu.DownTrack{
+		"speaker": {{}, {}},
+		"screen":  {{}},
+		"cam-c":   {{}},
+	}
+	picked := &selection{wanted: map[string]bool{}, capped: map[string]bool{}, auto: true, only: map[string]bool{"speaker": true, "screen": true}}
+	if capped := picked.admit(tracks); len(capped) != 0 {
+		t.Errorf("got capped %v want nothing capped without a limit", capped)
+	}
+	if wanted := picked.pids(); len(wanted) != 2 || wanted[0] != "screen" || wanted[1] != "speaker" {
+		t.Errorf("got %v want only the listed publishers", wanted)
+	}
+
+	limited := &selection{wanted: map[string]bool{}, capped: map[string]bool{}, limit: 2, auto: true, only: map[string]bool{"speaker": true, "screen": true}}
+	if capped := limited.admit(tracks); len(capped) != 1 || capped[0] != "speaker" {
+		t.Errorf("got capped %v want the listed publisher past the limit", capped)
+	}
+
+	// noAutoSubscribe lists no one
+	none := &selection{wanted: map[string]bool{}, capped: map[string]bool{}, auto: true, only: map[string]bool{}}
+	if none.admit(tracks); len(none.pids()) != 0 {
+		t.Errorf("got %v want no one subscribed", none.pids())
+	}
+}
+
+func TestJoinSubscriptions(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	keys := append(peerKeys("listed-speaker", "listing-viewer", "misled-viewer"), pb.KeyRoomData("listing-room"), pb.KeyRoomUsers("listing-room"))
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+
+	joinFake(t, mgr, "listed-speaker", "listing-room", newFakePeer())
+	defer mgr.DisconnectUser("listed-speaker")
+	if err := mgr.CheckSubscriptions("listing-room", "listing-viewer", []string{"listed-speaker", "listing-viewer"}); err != nil {
+		t.Errorf("got %s want a room member and the peer itself allowed", err)
+	}
+
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id: "misled-viewer",
+				Payload: &pb.SignalRequest_Join{
+					Join: &pb.JoinRequest{Sid: "listing-room", Description: []byte(EXAMPLE_EMPTY_SDP), Subscribe: []string{"listed-speaker", "nobody"}},
+				},
+			},
+		},
+	})
+	if err := worker.HandleNext(0); !errors.Is(err, ErrPublisherNotFound) {
+		mgr.DisconnectUser("misled-viewer")
+		t.Fatalf("got %v want a join subscribing to someone not in the room refused", err)
+	}
+	reply := nextSignalReply(t, mgr, "misled-viewer", func(reply *pb.SignalReply) bool { return reply.GetFailure() != nil })
+	if reply.GetFailure().GetCode() != pb.SignalError_PEER_NOT_FOUND {
+		t.Errorf("got %s want PEER_NOT_FOUND", reply)
+	}
+	if redis.HExists(pb.KeyRoomUsers("listing-room"), "misled-viewer").Val() {
+		t.Errorf("a refused join should not take a slot in the room")
+	}
+}
@@ -0,0 +1,112 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"google.golang.org/protobuf/proto"
+	"sort"
+	"sync"
+)
+
+/*
+Track Info:
+The tracks a subscriber gets say nothing of who they are from, so ahead of every offer that adds
+or takes away some of them the subscriber is sent its TrackInfos: for every track it receives, the
+mid of the track's transceiver in that offer, the publisher's pid, whether it is audio or video
+and the label the publisher gave its stream. Publishers label their streams with the trackLabels
+of their join or of a later offer, by stream id, eg. camera or screenshare. Labels add up, a
+stream labelled again gets the new label, and a stream never labelled has an empty one.
+*/
+
+// labelTracks adds the labels a publisher sent with a join or an offer to its UserData, the
+// caller saves it
+func labelTracks(userData *pb.UserData, labels map[string]string) {
+	if len(labels) == 0 {
+		return
+	}
+	if userData.TrackLabels == nil {
+		userData.TrackLabels = map[string]string{}
+	}
+	for streamID, label := range labels {
+		userData.TrackLabels[streamID] = label
+	}
+}
+
+// trackInfos are the tracks the subscriber receives now
+func (m *Manager) trackInfos(subscriber *sfu.Subscriber) *pb.TrackInfos {
+	m.mu.RLock()
+	pids := m.pidsBySFUID()
+	m.mu.RUnlock()
+	return m.describeTracks(downTracksOf(subscriber), pids)
+}
+
+// describeTracks are the TrackInfos of tracks, whose publishers are found by their sfu peer id in
+// pids, in publisher and stream order
+func (m *Manager) describeTracks(tracks []*sfu.DownTrack, pids map[string]string) *pb.TrackInfos {
+	labels := map[string]map[string]string{}
+	infos := &pb.TrackInfos{Tracks: make([]*pb.TrackInfo, 0, len(tracks))}
+	for _, track := range tracks {
+		pid := pids[downTrackPublisher(track)]
+		if _, ok := labels[pid]; !ok && pid != "" {
+			if publisher, err := m.GetRemoteUserData(pid); err == nil {
+				labels[pid] = publisher.GetTrackLabels()
+			}
+		}
+		infos.Tracks = append(infos.Tracks, &pb.TrackInfo{
+			Mid:      downTrackMid(track),
+			TrackID:  track.ID(),
+			StreamID: track.StreamID(),
+			Pid:      pid,
+			Kind:     track.Kind().String(),
+			Label:    labels[pid][track.StreamID()],
+		})
+	}
+	sort.Slice(infos.Tracks, func(i, j int) bool {
+		a, b := infos.Tracks[i], infos.Tracks[j]
+		if a.Pid != b.Pid {
+			return a.Pid < b.Pid
+		}
+		if a.StreamID != b.StreamID {
+			return a.StreamID < b.StreamID
+		}
+		return a.TrackID < b.TrackID
+	})
+	return infos
+}
+
+// trackInfoSender remembers the last TrackInfos a subscriber was sent
+type trackInfoSender struct {
+	mu   sync.Mutex
+	last *pb.TrackInfos
+}
+
+// changed is infos when they differ from the last ones, and nil when the subscriber has them
+func (s *trackInfoSender) changed(infos *pb.TrackInfos) *pb.TrackInfos {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.last != nil && proto.Equal(s.last, infos) {
+		return nil
+	}
+	s.last = infos
+	return infos
+}
+
+// sendTrackInfos sends the peer its TrackInfos if they changed since the last ones it was sent
+func (w *worker) sendTrackInfos(pid string, peer *sfu.Peer, sent *trackInfoSender) error {
+	subscriber := subscriberOf(peer)
+	if subscriber == nil {
+		return nil
+	}
+	infos := sent.changed(w.manager.trackInfos(subscriber))
+	if infos == nil {
+		return nil
+	}
+	return w.SignalReply(pid, &pb.NoirReply{
+		Command: &pb.NoirReply_Signal{
+			Signal: &pb.SignalReply{
+				Id:      pid,
+				Payload: &pb.SignalReply_Tracks{Tracks: infos},
+			},
+		},
+	})
+}
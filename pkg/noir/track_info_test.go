@@ -0,0 +1,71 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"github.com/pion/webrtc/v3"
+	"google.golang.org/protobuf/proto"
+	"testing"
+)
+
+// publishedTrack is a downtrack of trackID in streamID, from the publisher with sfu peer id peerID
+func publishedTrack(t *testing.T, mimeType string, trackID string, streamID string, peerID string) *sfu.DownTrack {
+	receiver := &labelledReceiver{trackID: trackID, streamID: streamID, peerID: peerID}
+	track, err := sfu.NewDownTrack(webrtc.RTPCodecCapability{MimeType: mimeType}, receiver, "subscriber")
+	if err != nil {
+		t.Fatalf("error making a downtrack: %s", err)
+	}
+	return track
+}
+
+type labelledReceiver struct {
+	sfu.Receiver
+	trackID  string
+	streamID string
+	peerID   string
+}
+
+func (r *labelledReceiver) TrackID() string  { return r.trackID }
+func (r *labelledReceiver) StreamID() string { return r.streamID }
+
+func TestDescribeTracks(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	redis.Del(pb.KeyUserData("labelled-presenter"), pb.KeyUserData("labelled-guest"))
+	defer redis.Del(pb.KeyUserData("labelled-presenter"), pb.KeyUserData("labelled-guest"))
+	presenter := &pb.UserData{Id: "labelled-presenter", TrackLabels: map[string]string{"webcam": "camera"}}
+	labelTracks(presenter, map[string]string{"screen": "screenshare"})
+	labelTracks(presenter, nil)
+	mgr.SaveData(pb.KeyUserData(presenter.Id), &pb.NoirObject{Data: &pb.NoirObject_User{User: presenter}}, 0)
+	mgr.SaveData(pb.KeyUserData("labelled-guest"), &pb.NoirObject{Data: &pb.NoirObject_User{User: &pb.UserData{Id: "labelled-guest"}}}, 0)
+
+	pids := map[string]string{"sfu-presenter": "labelled-presenter", "sfu-guest": "labelled-guest"}
+	infos := mgr.describeTracks([]*sfu.DownTrack{
+		publishedTrack(t, webrtc.MimeTypeOpus, "mic", "guest-stream", "sfu-guest"),
+		publishedTrack(t, webrtc.MimeTypeVP8, "display", "screen", "sfu-presenter"),
+		publishedTrack(t, webrtc.MimeTypeVP8, "face", "webcam", "sfu-presenter"),
+	}, pids)
+	want := []*pb.TrackInfo{
+		{TrackID: "mic", StreamID: "guest-stream", Pid: "labelled-guest", Kind: "audio"},
+		{TrackID: "display", StreamID: "screen", Pid: "labelled-presenter", Kind: "video", Label: "screenshare"},
+		{TrackID: "face", StreamID: "webcam", Pid: "labelled-presenter", Kind: "video", Label: "camera"},
+	}
+	if len(infos.Tracks) != len(want) {
+		t.Fatalf("got %v want %d tracks", infos.Tracks, len(want))
+	}
+	for i, info := range infos.Tracks {
+		if !proto.Equal(info, want[i]) {
+			t.Errorf("got %v want %v", info, want[i])
+		}
+	}
+
+	sent := &trackInfoSender{}
+	if sent.changed(infos) == nil {
+		t.Errorf("the first tracks should be sent")
+	}
+	if sent.changed(mgr.describeTracks(nil, pids)) == nil {
+		t.Errorf("tracks taken away should be sent")
+	}
+	if sent.changed(&pb.TrackInfos{Tracks: []*pb.TrackInfo{}}) != nil {
+		t.Errorf("the same tracks should not be sent again")
+	}
+}
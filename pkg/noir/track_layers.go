@@ -0,0 +1,202 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	log "github.com/pion/ion-log"
+	"github.com/pion/ion-sfu/pkg/sfu"
+	"github.com/pion/webrtc/v3"
+	"google.golang.org/protobuf/proto"
+	"sort"
+	"sync"
+	"time"
+)
+
+/*
+Track Layers:
+A publisher sending simulcast sends the sfu up to three layers of a video track, and browsers
+stop sending the higher ones when their CPU or uplink cannot keep up. While a peer's PeerChannel
+runs, every LayerInterval each of its video tracks is checked for the layers the sfu got, a
+layer being active while its last packet is under LayerTimeout old. Once a track's layers have
+changed and stayed that way for LayerDebounce, the room gets a LAYERS event and every other peer
+in it a TrackLayers reply, so a layer stalling for a moment is not announced, and neither is a
+track before its layers settle. The layers last announced are in the publisher's PeerStats, see
+GetRoomStats.
+ion-sfu v1.6 does not decode the video, so a layer is known by its rid, not its resolution or
+framerate: browsers send q, h and f at a quarter, half and full resolution. Its temporal layers
+are only known to a subscriber's SetLayerReply.
+*/
+
+const (
+	// How often the layers of a peer's tracks are checked
+	LayerInterval = time.Second
+	// How long after its last packet a layer is no longer active
+	LayerTimeout = 1500 * time.Millisecond
+	// How long a change to a track's layers has to hold before the room hears about it
+	LayerDebounce = 3 * time.Second
+)
+
+// the rid ion-sfu files each spatial layer under
+var layerRIDs = [MaxSpatialLayer + 1]string{"q", "h", "f"}
+
+// sampleLayers are the layers the sfu gets of the peer's video tracks now, by track id
+func sampleLayers(pid string, peer *sfu.Peer, now time.Time) []*pb.TrackLayers {
+	publisher := publisherOf(peer)
+	arrived := map[uint32]int64{}
+	for _, stream := range streamsOf(publisher) {
+		arrived[stream.ssrc] = stream.arrived
+	}
+	sampled := []*pb.TrackLayers{}
+	for _, receiver := range receiversOf(publisher) {
+		if receiver.Kind() != webrtc.RTPCodecTypeVideo {
+			continue
+		}
+		track := &pb.TrackLayers{Pid: pid, TrackID: receiver.TrackID(), StreamID: receiver.StreamID()}
+		simulcast := receiverSimulcast(receiver)
+		for spatial := 0; spatial <= MaxSpatialLayer; spatial++ {
+			ssrc := receiver.SSRC(spatial)
+			if ssrc == 0 {
+				continue
+			}
+			layer := &pb.TrackLayer{Spatial: int32(spatial)}
+			if simulcast {
+				layer.Rid = layerRIDs[spatial]
+			}
+			layer.Active = arrived[ssrc] > 0 && now.Sub(time.Unix(0, arrived[ssrc])) < LayerTimeout
+			track.Layers = append(track.Layers, layer)
+		}
+		sampled = append(sampled, track)
+	}
+	sort.Slice(sampled, func(i, j int) bool { return sampled[i].TrackID < sampled[j].TrackID })
+	return sampled
+}
+
+// layerTracker debounces the changes to the layers of one track
+type layerTracker struct {
+	reported  *pb.TrackLayers
+	candidate *pb.TrackLayers
+	since     time.Time
+}
+
+// settle is true when sampled differs from the layers last reported and has held for LayerDebounce,
+// it is reported from then on
+func (t *layerTracker) settle(sampled *pb.TrackLayers, now time.Time) bool {
+	if t.reported != nil && proto.Equal(t.reported, sampled) {
+		t.candidate = nil
+		return false
+	}
+	if t.candidate == nil || !proto.Equal(t.candidate, sampled) {
+		t.candidate, t.since = sampled, now
+	}
+	if now.Sub(t.since) < LayerDebounce {
+		return false
+	}
+	t.reported, t.candidate = t.candidate, nil
+	return true
+}
+
+// updateLayers settles the sampled layers of pid's tracks, announcing those that changed to the
+// room, and keeps the ones reported for its PeerStats
+func (m *Manager) updateLayers(trackers map[string]*layerTracker, pid string, roomID string, sampled []*pb.TrackLayers, now time.Time) {
+	current := map[string]bool{}
+	reported := []*pb.TrackLayers{}
+	for _, layers := range sampled {
+		current[layers.TrackID] = true
+		tracker := trackers[layers.TrackID]
+		if tracker == nil {
+			tracker = &layerTracker{}
+			trackers[layers.TrackID] = tracker
+		}
+		if tracker.settle(layers, now) {
+			m.announceLayers(roomID, tracker.reported)
+		}
+		if tracker.reported != nil {
+			reported = append(reported, tracker.reported)
+		}
+	}
+	// unpublished tracks are gone from the subscribers' TrackInfos
+	for trackID := range trackers {
+		if !current[trackID] {
+			delete(trackers, trackID)
+		}
+	}
+	m.setPublishedLayers(pid, reported)
+}
+
+func (m *Manager) setPublishedLayers(pid string, layers []*pb.TrackLayers) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(layers) == 0 {
+		delete(m.layers, pid)
+	} else {
+		m.layers[pid] = layers
+	}
+}
+
+// publishedLayers are the layers last announced of pid's video tracks, when it is on this node
+func (m *Manager) publishedLayers(pid string) []*pb.TrackLayers {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.layers[pid]
+}
+
+// announceLayers tells the room and each of its other peers the layers a publisher sends now
+func (m *Manager) announceLayers(roomID string, layers *pb.TrackLayers) {
+	log.Debugf("%s sends %d layers of %s in %s", layers.Pid, len(layers.Layers), layers.TrackID, roomID)
+	err := m.PublishRoomEvent(&pb.RoomEvent{
+		Type:   pb.RoomEvent_LAYERS,
+		Pid:    layers.Pid,
+		Sid:    roomID,
+		Kind:   webrtc.RTPCodecTypeVideo.String(),
+		Layers: layers,
+	})
+	if err != nil {
+		log.Errorf("error announcing the layers of %s: %s", layers.Pid, err)
+	}
+	for _, pid := range m.redis.HKeys(m.Keys().RoomUsers(roomID)).Val() {
+		if pid == layers.Pid {
+			continue
+		}
+		err := m.EnqueueReply(m.GetQueue(m.Keys().TopicFromPeer(pid)), &pb.NoirReply{
+			Command: &pb.NoirReply_Signal{
+				Signal: &pb.SignalReply{
+					Id:      pid,
+					Payload: &pb.SignalReply_Layers{Layers: layers},
+				},
+			},
+		})
+		if err != nil {
+			log.Debugf("error telling %s the layers of %s: %s", pid, layers.Pid, err)
+			continue
+		}
+		m.redis.Publish(m.Keys().PeerNewsChannel(pid), pid)
+	}
+}
+
+// watchLayers announces the changes to the layers the peer publishes until stop is closed
+func (w *worker) watchLayers(userData *pb.UserData, peer *sfu.Peer, userMu *sync.Mutex, stop <-chan struct{}) {
+	defer func() {
+		// a reconnect on this node files its own
+		if !w.manager.supersededPeer(userData.Id, peer) {
+			w.manager.setPublishedLayers(userData.Id, nil)
+		}
+	}()
+	ticker := time.NewTicker(LayerInterval)
+	defer ticker.Stop()
+	trackers := map[string]*layerTracker{}
+	lastRoom := ""
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			userMu.Lock()
+			roomID := userData.RoomID
+			userMu.Unlock()
+			// a peer that moved has its layers announced to the new room
+			if roomID != lastRoom {
+				trackers, lastRoom = map[string]*layerTracker{}, roomID
+			}
+			w.manager.updateLayers(trackers, userData.Id, roomID, sampleLayers(userData.Id, peer, now), now)
+		}
+	}
+}
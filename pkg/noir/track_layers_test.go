@@ -0,0 +1,92 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"testing"
+	"time"
+)
+
+func layersOf(active ...bool) *pb.TrackLayers {
+	layers := &pb.TrackLayers{Pid: "layers-pub", TrackID: "camera", StreamID: "stream"}
+	for spatial, on := range active {
+		layers.Layers = append(layers.Layers, &pb.TrackLayer{Spatial: int32(spatial), Rid: layerRIDs[spatial], Active: on})
+	}
+	return layers
+}
+
+func TestLayerDebounce(t *testing.T) {
+	tracker := &layerTracker{}
+	start := time.Now()
+	if tracker.settle(layersOf(true, true, true), start) {
+		t.Errorf("a track's first layers should settle before they are reported")
+	}
+	if !tracker.settle(layersOf(true, true, true), start.Add(LayerDebounce)) {
+		t.Errorf("layers held for LayerDebounce should be reported")
+	}
+
+	// the high layer stalls for a moment
+	tracker.settle(layersOf(true, true, false), start.Add(4*time.Second))
+	if tracker.settle(layersOf(true, true, true), start.Add(5*time.Second)) {
+		t.Errorf("a layer back before LayerDebounce should not be reported")
+	}
+	if tracker.settle(layersOf(true, true, false), start.Add(6*time.Second)) {
+		t.Errorf("a stall should count from when it started again")
+	}
+	if !tracker.settle(layersOf(true, true, false), start.Add(6*time.Second+LayerDebounce)) {
+		t.Errorf("a layer stopped for LayerDebounce should be reported")
+	}
+	if tracker.settle(layersOf(true, true, false), start.Add(20*time.Second)) {
+		t.Errorf("layers already reported should not be again")
+	}
+}
+
+func TestAnnounceLayers(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	keys := []string{pb.KeyRoomUsers("layers-room"), pb.KeyTopicFromPeer("layers-pub"), pb.KeyTopicFromPeer("layers-sub")}
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+	redis.HSet(pb.KeyRoomUsers("layers-room"), "layers-pub", "")
+	redis.HSet(pb.KeyRoomUsers("layers-room"), "layers-sub", "")
+	mgr.mu.Lock()
+	mgr.users["layers-pub"] = NewSFUPeer(*mgr.sfu)
+	mgr.mu.Unlock()
+	defer mgr.DisconnectUser("layers-pub")
+	events, cancel := mgr.SubscribeEvents()
+	defer cancel()
+
+	trackers := map[string]*layerTracker{}
+	start := time.Now()
+	mgr.updateLayers(trackers, "layers-pub", "layers-room", []*pb.TrackLayers{layersOf(true, true)}, start)
+	mgr.updateLayers(trackers, "layers-pub", "layers-room", []*pb.TrackLayers{layersOf(true, true)}, start.Add(LayerDebounce))
+
+	select {
+	case event := <-events:
+		if event.Type != pb.RoomEvent_LAYERS || event.GetLayers().GetTrackID() != "camera" || len(event.GetLayers().GetLayers()) != 2 {
+			t.Errorf("got %s want the LAYERS of camera", event.RoomEvent)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("got no LAYERS event")
+	}
+	message, err := mgr.GetQueue(pb.KeyTopicFromPeer("layers-sub")).BlockUntilNext(time.Second)
+	if err != nil {
+		t.Fatalf("got %s want the subscriber told the layers", err)
+	}
+	reply := pb.NoirReply{}
+	UnmarshalReply(message, &reply)
+	if layers := reply.GetSignal().GetLayers(); layers.GetPid() != "layers-pub" || !layers.GetLayers()[1].GetActive() {
+		t.Errorf("got %s want the layers of layers-pub", &reply)
+	}
+	if count, _ := mgr.GetQueue(pb.KeyTopicFromPeer("layers-pub")).Count(); count != 0 {
+		t.Errorf("got %d replies want the publisher left out", count)
+	}
+
+	for _, stats := range mgr.LocalRoomStats("layers-room") {
+		if stats.Pid == "layers-pub" && (len(stats.Layers) != 1 || stats.Layers[0].TrackID != "camera") {
+			t.Errorf("got %s want the reported layers in the publisher's stats", stats.Layers)
+		}
+	}
+	mgr.updateLayers(trackers, "layers-pub", "layers-room", nil, start.Add(2*LayerDebounce))
+	if len(trackers) != 0 || mgr.publishedLayers("layers-pub") != nil {
+		t.Errorf("an unpublished track's layers should be forgotten")
+	}
+}
@@ -0,0 +1,92 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"sync"
+	"time"
+)
+
+// Default for Manager.TrickleBatching(), when batching is on
+const TrickleFlushInterval = 20 * time.Millisecond
+
+// SetTrickleBatching coalesces the candidates the sfu gathers for a peer into TrickleBatch replies
+// of up to maxBatch, sent flushInterval after the first one at the latest. maxBatch under 2 turns it off
+func (m *Manager) SetTrickleBatching(maxBatch int, flushInterval time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.trickleBatchSize = maxBatch
+	m.trickleFlushInterval = flushInterval
+}
+
+func (m *Manager) TrickleBatching() (int, time.Duration) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.trickleFlushInterval > 0 {
+		return m.trickleBatchSize, m.trickleFlushInterval
+	}
+	return m.trickleBatchSize, TrickleFlushInterval
+}
+
+// SendTrickles replies with a Trickle, or a TrickleBatch for more than one
+func (w *worker) SendTrickles(pid string, trickles []*pb.Trickle) error {
+	signal := &pb.SignalReply{Id: pid}
+	if len(trickles) == 1 {
+		signal.Payload = &pb.SignalReply_Trickle{Trickle: trickles[0]}
+	} else {
+		signal.Payload = &pb.SignalReply_TrickleBatch{
+			TrickleBatch: &pb.TrickleBatch{Candidates: trickles},
+		}
+	}
+	return w.SignalReply(pid, &pb.NoirReply{
+		Command: &pb.NoirReply_Signal{Signal: signal},
+	})
+}
+
+// trickleBatcher holds candidates until it has maxBatch of them, or the first has waited flushInterval.
+// flush runs under the lock, so batches go out in the order they were gathered
+type trickleBatcher struct {
+	mu            sync.Mutex
+	pending       []*pb.Trickle
+	timer         *time.Timer
+	maxBatch      int
+	flushInterval time.Duration
+	flush         func([]*pb.Trickle)
+}
+
+func newTrickleBatcher(maxBatch int, flushInterval time.Duration, flush func([]*pb.Trickle)) *trickleBatcher {
+	return &trickleBatcher{
+		maxBatch:      maxBatch,
+		flushInterval: flushInterval,
+		flush:         flush,
+	}
+}
+
+func (b *trickleBatcher) Add(trickle *pb.Trickle) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pending = append(b.pending, trickle)
+	if len(b.pending) >= b.maxBatch {
+		b.flushLocked()
+	} else if b.timer == nil {
+		b.timer = time.AfterFunc(b.flushInterval, b.Flush)
+	}
+}
+
+func (b *trickleBatcher) Flush() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.flushLocked()
+}
+
+func (b *trickleBatcher) flushLocked() {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	if len(b.pending) == 0 {
+		return
+	}
+	batch := b.pending
+	b.pending = nil
+	b.flush(batch)
+}
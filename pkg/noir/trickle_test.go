@@ -0,0 +1,57 @@
+package noir
+
+import (
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"google.golang.org/protobuf/proto"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTrickleBatcher(t *testing.T) {
+	var mu sync.Mutex
+	batches := [][]*pb.Trickle{}
+	batcher := newTrickleBatcher(3, 50*time.Millisecond, func(trickles []*pb.Trickle) {
+		mu.Lock()
+		defer mu.Unlock()
+		batches = append(batches, trickles)
+	})
+
+	for _, init := range []string{"a", "b", "c", "d"} {
+		batcher.Add(&pb.Trickle{Init: init})
+	}
+	mu.Lock()
+	if len(batches) != 1 || len(batches[0]) != 3 || batches[0][2].Init != "c" {
+		t.Errorf("got %v want a, b and c sent as soon as the batch filled", batches)
+	}
+	mu.Unlock()
+
+	time.Sleep(100 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	if len(batches) != 2 || len(batches[1]) != 1 || batches[1][0].Init != "d" {
+		t.Errorf("got %v want d flushed after the interval", batches)
+	}
+}
+
+func TestSendTrickles(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	redis.Del(pb.KeyTopicFromPeer("trickler"))
+	w := (*mgr.GetWorker()).(*worker)
+
+	w.SendTrickles("trickler", []*pb.Trickle{{Init: "a"}})
+	w.SendTrickles("trickler", []*pb.Trickle{{Init: "b"}, {Init: "c"}})
+
+	fromPeer := mgr.GetQueue(pb.KeyTopicFromPeer("trickler"))
+	reply := pb.NoirReply{}
+	msg, _ := fromPeer.Next()
+	proto.Unmarshal(msg, &reply)
+	if reply.GetSignal().GetTrickle().GetInit() != "a" {
+		t.Errorf("got %s want a single trickle", &reply)
+	}
+	msg, _ = fromPeer.Next()
+	proto.Unmarshal(msg, &reply)
+	if candidates := reply.GetSignal().GetTrickleBatch().GetCandidates(); len(candidates) != 2 {
+		t.Errorf("got %s want a batch of 2", &reply)
+	}
+}
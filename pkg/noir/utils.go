@@ -10,6 +10,8 @@ import (
 	proto "google.golang.org/protobuf/proto"
 	"math/rand"
 	"os"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 )
@@ -76,8 +78,32 @@ func ReadSignalAction(signal *pb.SignalRequest) (string, error) {
 		return action + "description", nil
 	case *pb.SignalRequest_Trickle:
 		return action + "trickle", nil
+	case *pb.SignalRequest_TrickleBatch:
+		return action + "tricklebatch", nil
 	case *pb.SignalRequest_Kill:
 		return action + "kill", nil
+	case *pb.SignalRequest_Leave:
+		return action + "leave", nil
+	case *pb.SignalRequest_Data:
+		return action + "data", nil
+	case *pb.SignalRequest_Resume:
+		return action + "resume", nil
+	case *pb.SignalRequest_SetLayer:
+		return action + "setlayer", nil
+	case *pb.SignalRequest_Play:
+		return action + "play", nil
+	case *pb.SignalRequest_Mute:
+		return action + "mute", nil
+	case *pb.SignalRequest_Subscribe:
+		return action + "subscribe", nil
+	case *pb.SignalRequest_Unsubscribe:
+		return action + "unsubscribe", nil
+	case *pb.SignalRequest_GetStats:
+		return action + "getstats", nil
+	case *pb.SignalRequest_Pause:
+		return action + "pause", nil
+	case *pb.SignalRequest_Capabilities:
+		return action + "capabilities", nil
 	}
 	return action, errors.New("unhandled servers")
 }
@@ -87,6 +113,8 @@ func ReadAdminAction(admin *pb.AdminRequest) (string, error) {
 	switch admin.Payload.(type) {
 	case *pb.AdminRequest_RoomList:
 		return action + "list_rooms", nil
+	case *pb.AdminRequest_RoomStats:
+		return action + "room_stats", nil
 	case *pb.AdminRequest_RoomAdmin:
 			roomAdmin := admin.GetRoomAdmin()
 			switch roomAdmin.Method.(type) {
@@ -94,6 +122,29 @@ func ReadAdminAction(admin *pb.AdminRequest) (string, error) {
 					return action + "room.create", nil
 				case *pb.RoomAdminRequest_RoomJob:
 					return action + "room.runjob", nil
+				case *pb.RoomAdminRequest_CloseRoom:
+					return action + "room.close", nil
+				case *pb.RoomAdminRequest_StartRecording:
+					return action + "room.startrecording", nil
+				case *pb.RoomAdminRequest_StopRecording:
+					return action + "room.stoprecording", nil
+				case *pb.RoomAdminRequest_MutePeer:
+					return action + "room.mutepeer", nil
+				case *pb.RoomAdminRequest_KickPeer:
+					return action + "room.kickpeer", nil
+				case *pb.RoomAdminRequest_TraceSignaling:
+					return action + "room.tracesignaling", nil
+				case *pb.RoomAdminRequest_SetPassword:
+					return action + "room.setpassword", nil
+				case *pb.RoomAdminRequest_Announce:
+					return action + "room.announce", nil
+				case *pb.RoomAdminRequest_PauseRoom:
+					if !roomAdmin.GetPauseRoom().Paused {
+						return action + "room.resume", nil
+					}
+					return action + "room.pause", nil
+				case *pb.RoomAdminRequest_TransferOwnership:
+					return action + "room.transferownership", nil
 				default:
 					return action, errors.New("unhandled roomadmin")
 			}
@@ -102,6 +153,28 @@ func ReadAdminAction(admin *pb.AdminRequest) (string, error) {
 	}
 }
 
+// ReadReplyAction names a reply like ReadAction names requests, eg. "reply.signal.join"
+func ReadReplyAction(reply *pb.NoirReply) string {
+	action := "reply." + oneofName(reply)
+	if signal := reply.GetSignal(); signal != nil {
+		action += "." + oneofName(signal)
+	}
+	return action
+}
+
+// oneofName is the name of the field set in the message's first oneof
+func oneofName(message proto.Message) string {
+	reflected := message.ProtoReflect()
+	oneofs := reflected.Descriptor().Oneofs()
+	if oneofs.Len() == 0 {
+		return ""
+	}
+	if field := reflected.WhichOneof(oneofs.Get(0)); field != nil {
+		return strings.ToLower(string(field.Name()))
+	}
+	return ""
+}
+
 func ParseSDP(offer webrtc.SessionDescription) (*sdp.SessionDescription, error) {
 	desc, err := offer.Unmarshal()
 	if err != nil {
@@ -126,6 +199,14 @@ func FillDefaults(value *pb.NoirRequest) {
 		value.Id = "notify-" + RandomString(24)
 	}
 
+	if value.Version == "" {
+		value.Version = ProtocolVersion
+	}
+
+	if value.TraceID == "" {
+		value.TraceID = NewTraceID()
+	}
+
 	if value.Action == "" {
 		action, action_err := ReadAction(value)
 		if action_err != nil {
@@ -141,6 +222,9 @@ func MarshalRequest(value *pb.NoirRequest) ([]byte, error) {
 }
 
 func MarshalReply(value *pb.NoirReply) ([]byte, error) {
+	if value.Version == "" {
+		value.Version = ProtocolVersion
+	}
 	return proto.Marshal(value)
 }
 
@@ -153,17 +237,12 @@ func EnqueueRequest(queue Queue, value *pb.NoirRequest) error {
 	if err != nil {
 		return err
 	}
-	err = queue.Add(command)
-	return err
+	return AddPriority(queue, command, RequestPriority(value))
 }
 
+// EnqueueReply adds a reply under the DefaultBackpressure, see Manager.EnqueueReply
 func EnqueueReply(queue Queue, value *pb.NoirReply) error {
-	command, err := MarshalReply(value)
-	if err != nil {
-		return err
-	}
-	err = queue.Add(command)
-	return err
+	return EnqueueReplyWith(queue, value, DefaultBackpressure)
 }
 
 // TEST UTILS
@@ -177,7 +256,7 @@ func NewTestQueue(topic string) Queue {
 	return NewRedisQueue(rdb, topic, 60*time.Second)
 }
 
-func NewTestSetup() (Manager, *redis.Client) {
+func NewTestSetup() (*Manager, *redis.Client) {
 	driver := os.Getenv("TEST_REDIS")
 	rdb := redis.NewClient(&redis.Options{
 		Addr:     driver,
@@ -189,8 +268,9 @@ func NewTestSetup() (Manager, *redis.Client) {
 	return SetupNoir(&sfu, rdb, "test-worker", "*"), rdb
 }
 
-// listQueue is a queue for the tests!
+// listQueue is a queue for the tests! Safe to share between goroutines, as the others are
 type listQueue struct {
+	mu       sync.Mutex
 	messages [][]byte
 	topic    string
 }
@@ -204,20 +284,46 @@ func NewListQueue(topic string) Queue {
 }
 
 func (q *listQueue) Add(value []byte) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
 	q.messages = append(q.messages, value)
 	return nil
 }
 
+func (q *listQueue) Requeue(value []byte, _ Priority) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.messages = append([][]byte{value}, q.messages...)
+	return nil
+}
+
+func (q *listQueue) Close() error {
+	return q.Add(closedMessage)
+}
+
+func (q *listQueue) Reopen() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.messages = withoutClosed(q.messages)
+	return nil
+}
+
 func (q *listQueue) Cleanup() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
 	q.messages = [][]byte{}
 	return nil
 }
 
 func (q *listQueue) Next() ([]byte, error) {
-	count, _ := q.Count()
-	if count > 0 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.messages) > 0 {
 		next := q.messages[0]
 		q.messages = q.messages[1:]
+		if isClosedMessage(next) {
+			return nil, ErrQueueClosed
+		}
 		return next, nil
 	}
 	return nil, nil
@@ -228,5 +334,19 @@ func (q *listQueue) BlockUntilNext(timeout time.Duration) ([]byte, error) {
 }
 
 func (q *listQueue) Count() (int64, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
 	return int64(len(q.messages)), nil
 }
+
+func (q *listQueue) Peek(n int) ([][]byte, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if n > len(q.messages) {
+		n = len(q.messages)
+	}
+	if n < 0 {
+		n = 0
+	}
+	return withoutClosed(append([][]byte{}, q.messages[:n]...)), nil
+}
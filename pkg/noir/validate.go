@@ -0,0 +1,95 @@
+package noir
+
+import (
+	"errors"
+	"fmt"
+	pb "github.com/net-prophet/noir/pkg/proto"
+)
+
+/*
+Request Validation:
+Queued messages come from anything that can write to redis, so a worker checks them before
+acting on them. A message over MaxMessageSize() is dead lettered without being parsed, with
+only its start kept, and parsed requests missing a field their handler needs are refused with
+an INVALID_REQUEST error rather than half handled, eg. a join without a room.
+*/
+
+// Default for Manager.MaxMessageSize()
+const MaxMessageSize = 1 << 20
+
+// How much of an oversized message its dead letter keeps
+const deadLetterSampleSize = 1024
+
+var (
+	ErrMessageTooLarge = errors.New("message too large")
+	ErrInvalidRequest  = errors.New("invalid request")
+)
+
+// SetMaxMessageSize is the most bytes a queued message may have to be parsed, 0 keeps the
+// default and a negative size parses messages of any size
+func (m *Manager) SetMaxMessageSize(size int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.maxMessageSize = size
+}
+
+func (m *Manager) MaxMessageSize() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.maxMessageSize == 0 {
+		return MaxMessageSize
+	}
+	return m.maxMessageSize
+}
+
+// CheckMessageSize is an ErrMessageTooLarge for a message over MaxMessageSize()
+func (m *Manager) CheckMessageSize(message []byte) error {
+	if limit := m.MaxMessageSize(); limit > 0 && len(message) > limit {
+		return fmt.Errorf("%w: %d bytes, the most is %d", ErrMessageTooLarge, len(message), limit)
+	}
+	return nil
+}
+
+func deadLetterSample(message []byte) []byte {
+	if len(message) > deadLetterSampleSize {
+		return message[:deadLetterSampleSize]
+	}
+	return message
+}
+
+// ValidateRequest is an ErrInvalidRequest naming the first field the request needs and lacks,
+// or the action its payload is not
+func ValidateRequest(request *pb.NoirRequest) error {
+	if signal := request.GetSignal(); signal != nil {
+		if signal.Id == "" {
+			return fmt.Errorf("%w: %s has no pid", ErrInvalidRequest, request.Action)
+		}
+		if join := signal.GetJoin(); join != nil {
+			if join.Sid == "" {
+				return fmt.Errorf("%w: join has no sid", ErrInvalidRequest)
+			}
+			if len(join.Description) == 0 {
+				return fmt.Errorf("%w: join has no offer", ErrInvalidRequest)
+			}
+		}
+		if play := signal.GetPlay(); play != nil {
+			if play.Sid == "" {
+				return fmt.Errorf("%w: play has no sid", ErrInvalidRequest)
+			}
+			if play.Filename == "" {
+				return fmt.Errorf("%w: play has no filename", ErrInvalidRequest)
+			}
+		}
+	}
+	// the action picks the handler, which reads the payload of that action
+	if action, err := ReadAction(request); err == nil && request.Action != "" && action != request.Action {
+		return fmt.Errorf("%w: action %s with the payload of %s", ErrInvalidRequest, request.Action, action)
+	}
+	if len(request.TraceID) > MaxTraceIDLength {
+		return fmt.Errorf("%w: a traceID of %d bytes, the most is %d", ErrInvalidRequest, len(request.TraceID), MaxTraceIDLength)
+	}
+	if roomAdmin := request.GetAdmin().GetRoomAdmin(); roomAdmin != nil && roomAdmin.RoomID == "" {
+		return fmt.Errorf("%w: %s has no roomID", ErrInvalidRequest, request.Action)
+	}
+	return nil
+}
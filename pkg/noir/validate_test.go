@@ -0,0 +1,93 @@
+package noir
+
+import (
+	"errors"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"google.golang.org/protobuf/proto"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMaxMessageSize(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	redis.Del(pb.KeyDeadLetter("test-worker"))
+	mgr.SetMaxMessageSize(2048)
+	defer mgr.SetMaxMessageSize(0)
+
+	worker := *mgr.GetWorker()
+	queue := *worker.GetQueue()
+	queue.Cleanup()
+	defer queue.Cleanup()
+	EnqueueRequest(queue, &pb.NoirRequest{Action: "request.custom.big", Id: strings.Repeat("x", 4096)})
+	if err := worker.HandleNext(0); !errors.Is(err, ErrMessageTooLarge) {
+		t.Errorf("got %v want an oversized message refused", err)
+	}
+
+	entries, err := mgr.DrainDeadLetter(10)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("got %v %v want 1 dead letter", entries, err)
+	}
+	if !strings.Contains(entries[0].Error, "too large") || len(entries[0].Message) != deadLetterSampleSize {
+		t.Errorf("got %q and %d bytes want a too large reason and the start of the message", entries[0].Error, len(entries[0].Message))
+	}
+
+	mgr.SetMaxMessageSize(-1)
+	if err := mgr.CheckMessageSize(make([]byte, 2*MaxMessageSize)); err != nil {
+		t.Errorf("got %s want any size with no limit", err)
+	}
+}
+
+func TestValidateRequest(t *testing.T) {
+	for _, bad := range []*pb.NoirRequest{
+		{Command: &pb.NoirRequest_Signal{Signal: &pb.SignalRequest{Payload: &pb.SignalRequest_Kill{Kill: true}}}},
+		{Command: &pb.NoirRequest_Signal{Signal: &pb.SignalRequest{Id: "peer", Payload: &pb.SignalRequest_Join{Join: &pb.JoinRequest{Description: []byte(EXAMPLE_EMPTY_SDP)}}}}},
+		{Command: &pb.NoirRequest_Signal{Signal: &pb.SignalRequest{Id: "peer", Payload: &pb.SignalRequest_Join{Join: &pb.JoinRequest{Sid: "room"}}}}},
+		{Command: &pb.NoirRequest_Signal{Signal: &pb.SignalRequest{Id: "peer", Payload: &pb.SignalRequest_Play{Play: &pb.PlayRequest{Sid: "room"}}}}},
+		{Command: &pb.NoirRequest_Admin{Admin: &pb.AdminRequest{Payload: &pb.AdminRequest_RoomAdmin{RoomAdmin: &pb.RoomAdminRequest{}}}}},
+		{Action: "request.admin.room.close", Command: &pb.NoirRequest_Signal{Signal: &pb.SignalRequest{Id: "peer", Payload: &pb.SignalRequest_Kill{Kill: true}}}},
+	} {
+		if err := ValidateRequest(bad); !errors.Is(err, ErrInvalidRequest) {
+			t.Errorf("got %v want %s refused", err, bad)
+		}
+	}
+	join := &pb.NoirRequest{Command: &pb.NoirRequest_Signal{Signal: &pb.SignalRequest{Id: "peer", Payload: &pb.SignalRequest_Join{
+		Join: &pb.JoinRequest{Sid: "room", Description: []byte(EXAMPLE_EMPTY_SDP)},
+	}}}}
+	if err := ValidateRequest(join); err != nil {
+		t.Errorf("got %s want a complete join", err)
+	}
+}
+
+func TestWorkerJoinWithoutSid(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	redis.Del(pb.KeyTopicFromPeer("sidless-peer"), pb.KeyTopicToPeer("sidless-peer"))
+	defer redis.Del(pb.KeyTopicFromPeer("sidless-peer"), pb.KeyTopicToPeer("sidless-peer"))
+
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id:        "sidless-peer",
+				RequestId: "join-1",
+				Payload:   &pb.SignalRequest_Join{Join: &pb.JoinRequest{Description: []byte(EXAMPLE_EMPTY_SDP)}},
+			},
+		},
+	})
+	if err := worker.HandleNext(0); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("got %v want the join refused", err)
+	}
+
+	message, err := mgr.GetQueue(pb.KeyTopicFromPeer("sidless-peer")).BlockUntilNext(time.Second)
+	if err != nil {
+		t.Fatalf("got no reply: %s", err)
+	}
+	reply := pb.NoirReply{}
+	proto.Unmarshal(message, &reply)
+	if failure := reply.GetSignal().GetFailure(); failure.GetCode() != pb.SignalError_INVALID_REQUEST || !strings.Contains(failure.GetMessage(), "sid") {
+		t.Errorf("got %s want an INVALID_REQUEST naming the sid", &reply)
+	}
+	if _, err := mgr.GetRemoteUserData("sidless-peer"); err == nil {
+		t.Errorf("a refused join should not connect the peer")
+	}
+}
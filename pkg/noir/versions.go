@@ -0,0 +1,91 @@
+package noir
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+Protocol Versions:
+NoirRequest and NoirReply carry the version of the protocol their sender speaks, as major.minor.
+A minor version only adds fields and messages, which older code skips, so every minor of a
+supported major is understood; a new major changes what existing ones mean. Handle answers a
+request of a major this node does not support with VERSION_MISMATCH rather than misreading it,
+so nodes and clients can upgrade one at a time. Joining settles the version replies to the peer
+are framed in, the lower of the client's and ours, which is saved in its UserData and sent back
+in the JoinReply.
+
+Compatibility, by the versions a request declares:
+	none  clients from before requests had versions, read as LegacyProtocolVersion
+	1.x   supported, 1.0 is the first versioned protocol
+*/
+
+// The protocol this node speaks
+const (
+	ProtocolMajor = 1
+	ProtocolMinor = 0
+)
+
+// ProtocolVersion is stamped on every request and reply sent without one
+var ProtocolVersion = strconv.Itoa(ProtocolMajor) + "." + strconv.Itoa(ProtocolMinor)
+
+// LegacyProtocolVersion is what a request without a version speaks
+const LegacyProtocolVersion = "1.0"
+
+// supportedProtocols is the latest minor understood of every supported major
+var supportedProtocols = map[int]int{
+	1: ProtocolMinor,
+}
+
+var ErrVersionMismatch = errors.New("version mismatch")
+
+// SupportedVersions are the latest version of every protocol major this node understands
+func (m *Manager) SupportedVersions() []string {
+	majors := make([]int, 0, len(supportedProtocols))
+	for major := range supportedProtocols {
+		majors = append(majors, major)
+	}
+	sort.Ints(majors)
+	versions := make([]string, len(majors))
+	for i, major := range majors {
+		versions[i] = strconv.Itoa(major) + "." + strconv.Itoa(supportedProtocols[major])
+	}
+	return versions
+}
+
+// ParseProtocolVersion splits major.minor, a bare major is minor 0
+func ParseProtocolVersion(version string) (major int, minor int, err error) {
+	parts := strings.SplitN(version, ".", 2)
+	if major, err = strconv.Atoi(parts[0]); err != nil || major < 0 {
+		return 0, 0, fmt.Errorf("bad protocol version %q", version)
+	}
+	if len(parts) == 2 {
+		if minor, err = strconv.Atoi(parts[1]); err != nil || minor < 0 {
+			return 0, 0, fmt.Errorf("bad protocol version %q", version)
+		}
+	}
+	return major, minor, nil
+}
+
+// NegotiateProtocolVersion is the version to speak with a sender of version, the older minor of
+// its major, or ErrVersionMismatch if the major is not supported
+func NegotiateProtocolVersion(version string) (string, error) {
+	if version == "" {
+		version = LegacyProtocolVersion
+	}
+	major, minor, err := ParseProtocolVersion(version)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrVersionMismatch, err)
+	}
+	latest, ok := supportedProtocols[major]
+	if !ok {
+		return "", fmt.Errorf("%w: protocol %s is not supported", ErrVersionMismatch, version)
+	}
+	if minor > latest {
+		minor = latest
+	}
+	return strconv.Itoa(major) + "." + strconv.Itoa(minor), nil
+}
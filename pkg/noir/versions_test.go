@@ -0,0 +1,88 @@
+package noir
+
+import (
+	"errors"
+	pb "github.com/net-prophet/noir/pkg/proto"
+	"google.golang.org/protobuf/proto"
+	"testing"
+)
+
+func TestNegotiateProtocolVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+		err     error
+	}{
+		{"", LegacyProtocolVersion, nil},
+		{"1", "1.0", nil},
+		{"1.0", "1.0", nil},
+		{"1.7", "1.0", nil}, // a newer minor is spoken down to ours
+		{"2.0", "", ErrVersionMismatch},
+		{"one", "", ErrVersionMismatch},
+	}
+	for _, tt := range tests {
+		got, err := NegotiateProtocolVersion(tt.version)
+		if got != tt.want || !errors.Is(err, tt.err) {
+			t.Errorf("%q got %q (%v) want %q (%v)", tt.version, got, err, tt.want, tt.err)
+		}
+	}
+	mgr, _ := NewTestSetup()
+	if got := mgr.SupportedVersions(); len(got) != 1 || got[0] != ProtocolVersion {
+		t.Errorf("got %v want only %s supported", got, ProtocolVersion)
+	}
+}
+
+func TestJoinVersion(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	keys := []string{pb.KeyRoomData("versioned"), pb.KeyRoomUsers("versioned"), pb.KeyTopicFromPeer("versioned-peer"), pb.KeyTopicToPeer("versioned-peer")}
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+	worker := *mgr.GetWorker()
+	replies := mgr.GetQueue(pb.KeyTopicFromPeer("versioned-peer"))
+
+	join := func(version string) (*pb.NoirReply, error) {
+		EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+			Version: version,
+			Command: &pb.NoirRequest_Signal{
+				Signal: &pb.SignalRequest{
+					Id: "versioned-peer",
+					Payload: &pb.SignalRequest_Join{
+						Join: &pb.JoinRequest{Sid: "versioned", Description: []byte(EXAMPLE_EMPTY_SDP)},
+					},
+				},
+			},
+		})
+		err := worker.HandleNext(0)
+		// past the candidates trickled before the join reply
+		for {
+			msg, _ := replies.Next()
+			if msg == nil {
+				return nil, err
+			}
+			reply := &pb.NoirReply{}
+			proto.Unmarshal(msg, reply)
+			if reply.GetSignal().GetTrickle() == nil {
+				return reply, err
+			}
+		}
+	}
+
+	reply, err := join("2.0")
+	if err == nil || reply.GetSignal().GetFailure().GetCode() != pb.SignalError_VERSION_MISMATCH {
+		t.Errorf("got %s want a join of another major refused", reply)
+	}
+
+	reply, err = join("1.3")
+	if err != nil {
+		t.Fatalf("error joining: %s", err)
+	}
+	defer mgr.DisconnectUser("versioned-peer")
+	if reply.GetSignal().GetJoin().GetVersion() != "1.0" || reply.Version != "1.0" {
+		t.Errorf("got %s want the join settled on 1.0", reply)
+	}
+	userData, _ := mgr.GetRemoteUserData("versioned-peer")
+	if userData.GetVersion() != "1.0" {
+		t.Errorf("got %q want the settled version saved with the peer", userData.GetVersion())
+	}
+}
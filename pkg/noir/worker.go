@@ -4,22 +4,42 @@ import (
 	"github.com/go-redis/redis"
 	pb "github.com/net-prophet/noir/pkg/proto"
 	log "github.com/pion/ion-log"
+	"io"
+	"strings"
 	"sync"
 	"time"
 )
 
 const (
-	RouterTopic   = "noir/"
+	// The router topic under the default key prefix, see Manager.RouterTopic()
+	RouterTopic = pb.DefaultKeyPrefix
+	// Defaults for Manager.WebrtcTimeout() and Manager.RouterMaxAge()
 	WebrtcTimeout = 25 * time.Second
 	RouterMaxAge  = WebrtcTimeout
+	// How often HandleForever wakes up to check if it was stopped
+	WorkerPollTimeout = 1 * time.Second
+	// PeerChannel backs off from PeerQueueBackoff up to PeerQueueMaxBackoff between queue errors,
+	// and disconnects the peer after Manager.PeerQueueRetries() of them in a row
+	PeerQueueBackoff    = 100 * time.Millisecond
+	PeerQueueMaxBackoff = 5 * time.Second
+	PeerQueueRetries    = 10
+	// Default for Manager.HeartbeatInterval(), heartbeats expire after 3 intervals
+	WorkerHeartbeatInterval = 5 * time.Second
 )
 
 type Worker interface {
 	HandleForever()
 	HandleNext(timeout time.Duration) error
+	// Ready is true while the worker consumes its topic and redis answers, see health.go
+	Ready() bool
 	RegisterHandler(name string, handler JobHandler)
+	RegisterActionHandler(actionPrefix string, handler HandlerFunc)
 	GetQueue() *Queue
 	ID() string
+	// Drain takes no more joins and waits for the worker's peers to leave, see drain.go
+	Drain(deadline time.Duration) bool
+	Draining() bool
+	Stop()
 }
 
 // worker runs 2 go threads -- Router() takes incoming router messages and loadbalances
@@ -30,38 +50,175 @@ type worker struct {
 	jobHandlers map[string]JobHandler
 	queue       Queue
 	mu          sync.RWMutex
+	peers       map[string]bool
+	peerWG      sync.WaitGroup
+	done        chan struct{}
+	stopped     chan struct{}
+	stopOnce    sync.Once
+	running     atomicBool
+	draining    atomicBool
+	health      queueHealth
+	// by the action prefix they handle, Handle picks the longest that matches
+	actionHandlers map[string]HandlerFunc
+	// the protocol version settled with each peer at join, by pid
+	peerVersions sync.Map
+	// the *offerTracker of each peer's offers, by pid, see offer_ack.go
+	serverOffers sync.Map
+	// the *layerAdapter of each peer with adaptive layers, by pid, see adaptive_layers.go
+	layerAdapters sync.Map
+	// the *peerChannel running each peer, by pid, see reconnect.go
+	peerChannels sync.Map
+	// the room each peer moved to, by pid, for the sfu's callbacks that cannot wait on its userMu
+	peerRooms sync.Map
+	// stop the scrapes of the worker's queue and rooms, Stop calls them
+	untrack []func()
 }
 
 type JobHandler func(request *pb.NoirRequest) RunnableJob
 
-func NewRedisWorkerQueue(client *redis.Client, id string) Queue {
-	return NewRedisQueue(client, pb.KeyWorkerTopic(id), RouterMaxAge)
+// HandlerFunc handles the requests a worker gets whose action it was registered for
+type HandlerFunc func(request *pb.NoirRequest) error
+
+// NewRedisWorkerQueue is the queue of worker id under keys
+func NewRedisWorkerQueue(client *redis.Client, keys pb.Keys, id string, maxAge time.Duration) Queue {
+	return NewRedisQueue(client, keys.WorkerTopic(id), maxAge)
 }
 
 func NewRedisWorker(id string, manager *Manager, client *redis.Client) Worker {
-	return &worker{id: id, manager: manager, queue: NewRedisWorkerQueue(client, id), jobHandlers: map[string]JobHandler{}}
+	return NewWorker(id, manager, NewRedisWorkerQueue(client, manager.Keys(), id, manager.RouterMaxAge()))
 }
 
 func NewWorker(id string, manager *Manager, queue Queue) Worker {
-	return &worker{id: id, manager: manager, queue: queue, jobHandlers: map[string]JobHandler{}}
+	w := &worker{
+		id:             id,
+		manager:        manager,
+		queue:          queue,
+		jobHandlers:    map[string]JobHandler{},
+		actionHandlers: map[string]HandlerFunc{},
+		peers:          map[string]bool{},
+		done:           make(chan struct{}),
+		stopped:        make(chan struct{}),
+	}
+	w.RegisterActionHandler("request.servers.", func(request *pb.NoirRequest) error {
+		return w.HandleSignal(request, w.requestLogger(request))
+	})
+	w.RegisterActionHandler("request.admin.", w.HandleAdmin)
+	w.RegisterActionHandler("request.admin.room.", w.HandleRoomAdmin)
+	return w
 }
 
 func (w *worker) HandleForever() {
 	log.Debugf("worker starting on topic %s", w.queue.Topic())
+	w.mu.Lock()
+	w.untrack = append(w.untrack, TrackQueueDepth(w.queue), TrackRoomStats(w.manager))
+	w.mu.Unlock()
+	w.running.set(true)
+	defer close(w.stopped)
+	go w.heartbeat()
+	go w.watchSpeakers()
+	go w.watchPeerOwners()
 	for {
-		if err := w.HandleNext(0); err != nil {
+		select {
+		case <-w.done:
+			log.Debugf("worker stopped consuming %s", w.queue.Topic())
+			return
+		default:
+		}
+		err := w.HandleNext(WorkerPollTimeout)
+		if backoff := w.health.backoff(w.queue.Topic()); backoff > 0 {
+			time.Sleep(backoff)
+		} else if err != nil && err != io.EOF {
 			log.Errorf("worker handler error %s", err)
 			time.Sleep(1 * time.Second)
 		}
 	}
 }
 
+// Stop stops consuming the worker topic, handles whatever was already queued,
+// then kills every peer this worker owns and waits for their PeerChannels to return
+func (w *worker) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.done)
+		if w.running.get() {
+			<-w.stopped
+		}
+
+		for {
+			msg, err := w.queue.Next()
+			if err != nil || msg == nil {
+				break
+			}
+			var request pb.NoirRequest
+			if err := UnmarshalRequest(msg, &request); err != nil {
+				log.Errorf("message parse error: %s", err)
+				continue
+			}
+			if err := w.Handle(&request); err != nil {
+				log.Errorf("worker handler error %s", err)
+			}
+		}
+
+		w.mu.RLock()
+		for pid := range w.peers {
+			log.Infof("worker stopping, closing peer %s", pid)
+			EnqueueRequest(w.manager.GetQueue(w.manager.Keys().TopicToPeer(pid)), &pb.NoirRequest{
+				AdminID: w.manager.ID(),
+				Command: &pb.NoirRequest_Signal{
+					Signal: &pb.SignalRequest{
+						Id:          pid,
+						Payload:     &pb.SignalRequest_Kill{Kill: true},
+						CloseReason: pb.CloseReason_NODE_STOPPING,
+					},
+				},
+			})
+		}
+		w.mu.RUnlock()
+
+		w.peerWG.Wait()
+		w.manager.ClearHeartbeat(w.id)
+
+		w.mu.Lock()
+		for _, untrack := range w.untrack {
+			untrack()
+		}
+		w.untrack = nil
+		w.mu.Unlock()
+	})
+}
+
+// heartbeat tells the cluster this worker is alive until it is stopped
+func (w *worker) heartbeat() {
+	ticker := time.NewTicker(w.manager.HeartbeatInterval())
+	defer ticker.Stop()
+	for {
+		if err := w.manager.heartbeat(w.id, w.PeerCount(), w.Draining()); err != nil {
+			log.Errorf("error writing heartbeat: %s", err)
+		}
+		w.renewPeerOwners()
+		select {
+		case <-w.done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PeerCount is how many peers this worker runs a PeerChannel for
+func (w *worker) PeerCount() int {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return len(w.peers)
+}
+
 func (w *worker) HandleNext(timeout time.Duration) error {
 	request, err := w.NextCommand(timeout)
 	if err != nil {
 		return err
 	}
-	return w.Handle(request)
+	started := time.Now()
+	err = w.Handle(request)
+	observeHandle(request.Action, started, err)
+	return err
 }
 
 func (w *worker) RegisterHandler(name string, handler JobHandler) {
@@ -69,17 +226,38 @@ func (w *worker) RegisterHandler(name string, handler JobHandler) {
 	w.jobHandlers[name] = handler
 }
 
+// RegisterActionHandler has the worker hand handler the requests whose action starts with
+// actionPrefix, eg. "request.custom.whiteboard", replacing whatever was registered under it.
+// A request with a signal or admin payload is refused unless its action is the payload's, see
+// ValidateRequest
+func (w *worker) RegisterActionHandler(actionPrefix string, handler HandlerFunc) {
+	log.Debugf("register action handler: %s", actionPrefix)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.actionHandlers[actionPrefix] = handler
+}
+
 func (w *worker) NextCommand(timeout time.Duration) (*pb.NoirRequest, error) {
 	msg, popErr := w.queue.BlockUntilNext(timeout)
-	if popErr != nil {
+	w.health.read(popErr)
+	if popErr == io.EOF {
+		return nil, popErr // nothing queued before timeout
+	} else if popErr != nil {
 		log.Errorf("queue error %s", popErr)
 		return nil, popErr
 	}
 
+	if err := w.manager.CheckMessageSize(msg); err != nil {
+		log.Errorf("dropping message: %s", err)
+		w.manager.DeadLetter(w.queue.Topic(), deadLetterSample(msg), err)
+		return nil, err
+	}
+
 	var request pb.NoirRequest
 	p_err := UnmarshalRequest(msg, &request)
 	if p_err != nil {
 		log.Errorf("message parse error: %s", p_err)
+		w.manager.DeadLetter(w.queue.Topic(), msg, p_err)
 		return nil, p_err
 	}
 	return &request, nil
@@ -91,13 +269,55 @@ func (w *worker) ID() string {
 func (w *worker) GetQueue() *Queue {
 	return &w.queue
 }
-func (w *worker) Handle(request *pb.NoirRequest) error {
-	log.Debugf("handle %s", request.Action)
-	if request.GetSignal() != nil {
-		return w.HandleSignal(request)
+func (w *worker) Handle(request *pb.NoirRequest) (err error) {
+	w.manager.traceQueued(request, w.queue.Topic())
+	span := w.manager.startSpan(request, "handle", time.Now(), LogFields{"worker": w.id})
+	defer func() { span.End(err) }()
+	logger := w.requestLogger(request)
+	logger.Debugf("handle %s", request.Action)
+	// ahead of FirstDelivery, which the owner's PeerChannel runs on it
+	if w.forwardStray(request, logger) {
+		return nil
+	}
+	if !w.manager.FirstDelivery(request) {
+		return nil
+	}
+	if _, err := NegotiateProtocolVersion(request.Version); err != nil {
+		return w.refuse(request, pb.SignalError_VERSION_MISMATCH, err)
+	}
+	if err := ValidateRequest(request); err != nil {
+		return w.refuse(request, pb.SignalError_INVALID_REQUEST, err)
+	}
+	handler := w.actionHandler(request.Action)
+	if handler == nil {
+		logger.Debugf("no handler for %s", request.Action)
+		return nil
+	}
+	return handler(request)
+}
+
+// refuse tells the sender why a request is not handled, a signal's with code, instead of handling it
+func (w *worker) refuse(request *pb.NoirRequest, code pb.SignalError_Code, err error) error {
+	w.requestLogger(request).Warnf("refusing %s: %s", request.Action, err)
+	if request.GetSignal().GetId() != "" {
+		return w.SignalError(request, code, err)
 	}
-	if request.GetAdmin() != nil {
-		return w.HandleAdmin(request)
+	if request.GetAdminID() != "" {
+		return w.Reply(request, &pb.NoirReply{Command: &pb.NoirReply_Error{Error: err.Error()}})
+	}
+	return err
+}
+
+// actionHandler is the handler registered under the longest prefix of action
+func (w *worker) actionHandler(action string) HandlerFunc {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	var handler HandlerFunc
+	longest := -1
+	for prefix, registered := range w.actionHandlers {
+		if len(prefix) > longest && strings.HasPrefix(action, prefix) {
+			handler, longest = registered, len(prefix)
+		}
 	}
-	return nil
+	return handler
 }
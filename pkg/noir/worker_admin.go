@@ -7,11 +7,16 @@ import (
 	log "github.com/pion/ion-log"
 )
 
+// Reply answers an admin request on its admin's topic, a peer's room admin is not answered
 func (w *worker) Reply(request *pb.NoirRequest, reply *pb.NoirReply) error {
-	topic := pb.KeyTopicToAdmin(request.GetAdminID())
+	if request.GetAdminID() == "" {
+		return nil
+	}
+	topic := w.manager.Keys().TopicToAdmin(request.GetAdminID())
 	queue := w.manager.GetQueue(topic)
 	reply.Id = request.Id
-	if err := EnqueueReply(queue, reply) ; err != nil {
+	reply.TraceID = request.TraceID
+	if err := w.manager.EnqueueReply(queue, reply) ; err != nil {
 		log.Errorf("error replying to admin %s", err)
 		return err
 	}
@@ -25,34 +30,195 @@ func (w *worker) HandleRoomJob(request *pb.NoirRequest) {
 	handler, OK := w.jobHandlers[roomJob.GetHandler()]
 	if OK {
 		job := handler(request)
+		if job == nil {
+			log.Errorf("handler %s could not create a job", roomJob.GetHandler())
+			return
+		}
 		go job.Handle()
 	} else {
 		log.Errorf("no handler for job: %s", roomJob.GetHandler())
 	}
 }
 
+// HandleRoomAdmin runs a room admin request, peers running it (callerID) have to moderate the room
+func (w *worker) HandleRoomAdmin(request *pb.NoirRequest) error {
+	roomAdmin := request.GetAdmin().GetRoomAdmin()
+	if err := checkAdminCaller(request) ; err != nil {
+		log.Warnf("room=%s refused %s: %s", roomAdmin.GetRoomID(), request.Action, err)
+		return err
+	}
+	if err := w.manager.checkModerator(roomAdmin.GetRoomID(), roomAdmin.GetCallerID()) ; err != nil {
+		log.Warnf("room=%s refused %s: %s", roomAdmin.GetRoomID(), request.Action, err)
+		return err
+	}
+	if createRoom := roomAdmin.GetCreateRoom() ; createRoom != nil {
+		room, created, err := w.manager.OpenRoom(roomAdmin.GetRoomID(), createRoom)
+		if err != nil {
+			return err
+		}
+		log.Infof("room=%s opened created=%t", roomAdmin.GetRoomID(), created)
+		options := PublicRoomOptions(room.GetOptions())
+		return w.Reply(request, &pb.NoirReply{
+			Command: &pb.NoirReply_Admin{
+				Admin: &pb.AdminReply{
+					Payload: &pb.AdminReply_RoomAdmin{
+						RoomAdmin: &pb.RoomAdminReply{
+							RoomID: roomAdmin.GetRoomID(),
+							Payload: &pb.RoomAdminReply_CreateRoom{
+								CreateRoom: &pb.CreateRoomReply{
+									Options: options,
+									Created: created,
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+	if roomJob := roomAdmin.GetRoomJob() ; roomJob != nil {
+		log.Infof("room=%s job=%s", roomAdmin.GetRoomID(), roomJob.GetHandler())
+		w.HandleRoomJob(request)
+	}
+	if closeRoom := roomAdmin.GetCloseRoom() ; closeRoom != nil {
+		log.Infof("closing room %s", roomAdmin.GetRoomID())
+		evicted, err := w.manager.EvictRoom(roomAdmin.GetRoomID())
+		if err != nil {
+			return err
+		}
+		return w.Reply(request, &pb.NoirReply{
+			Command: &pb.NoirReply_Admin{
+				Admin: &pb.AdminReply{
+					Payload: &pb.AdminReply_RoomAdmin{
+						RoomAdmin: &pb.RoomAdminReply{
+							RoomID: roomAdmin.GetRoomID(),
+							Payload: &pb.RoomAdminReply_CloseRoom{
+								CloseRoom: &pb.CloseRoomReply{
+									Evicted: int32(evicted),
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+	if startRecording := roomAdmin.GetStartRecording() ; startRecording != nil {
+		outputDir, err := w.manager.RecordingOutputDir(startRecording.GetOutputDir(), request.GetAdminID() != "")
+		if err != nil {
+			log.Warnf("room=%s refused %s: %s", roomAdmin.GetRoomID(), request.Action, err)
+			return err
+		}
+		recordingID, err := w.manager.StartRecording(roomAdmin.GetRoomID(), outputDir)
+		if err != nil {
+			return err
+		}
+		return w.Reply(request, &pb.NoirReply{
+			Command: &pb.NoirReply_Admin{
+				Admin: &pb.AdminReply{
+					Payload: &pb.AdminReply_RoomAdmin{
+						RoomAdmin: &pb.RoomAdminReply{
+							RoomID: roomAdmin.GetRoomID(),
+							Payload: &pb.RoomAdminReply_StartRecording{
+								StartRecording: &pb.StartRecordingReply{
+									RecordingID: recordingID,
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+	if stopRecording := roomAdmin.GetStopRecording() ; stopRecording != nil {
+		stopped, err := w.manager.StopRecording(roomAdmin.GetRoomID(), stopRecording.GetRecordingID())
+		if err != nil {
+			return err
+		}
+		return w.Reply(request, &pb.NoirReply{
+			Command: &pb.NoirReply_Admin{
+				Admin: &pb.AdminReply{
+					Payload: &pb.AdminReply_RoomAdmin{
+						RoomAdmin: &pb.RoomAdminReply{
+							RoomID: roomAdmin.GetRoomID(),
+							Payload: &pb.RoomAdminReply_StopRecording{
+								StopRecording: &pb.StopRecordingReply{
+									Stopped: stopped,
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+	if mutePeer := roomAdmin.GetMutePeer() ; mutePeer != nil {
+		log.Infof("room=%s muted=%t %s of %s", roomAdmin.GetRoomID(), mutePeer.GetMuted(), mutePeer.GetKind(), mutePeer.GetPid())
+		return w.manager.MutePeer(roomAdmin.GetRoomID(), mutePeer.GetPid(), mutePeer.GetKind(), mutePeer.GetMuted())
+	}
+	if trace := roomAdmin.GetTraceSignaling() ; trace != nil {
+		log.Infof("room=%s tracing signaling=%t redacted=%t", roomAdmin.GetRoomID(), trace.GetEnabled(), trace.GetRedactSDP())
+		return w.manager.TraceSignaling(roomAdmin.GetRoomID(), trace.GetEnabled(), trace.GetRedactSDP())
+	}
+	if setPassword := roomAdmin.GetSetPassword() ; setPassword != nil {
+		log.Infof("room=%s password set=%t", roomAdmin.GetRoomID(), setPassword.GetPassword() != "")
+		return w.manager.SetRoomPassword(roomAdmin.GetRoomID(), setPassword.GetPassword())
+	}
+	if announce := roomAdmin.GetAnnounce() ; announce != nil {
+		announce.From = roomAdmin.GetCallerID()
+		delivered, err := w.manager.Announce(roomAdmin.GetRoomID(), announce)
+		if err != nil {
+			return err
+		}
+		log.Infof("room=%s announced %s to %d peers", roomAdmin.GetRoomID(), announce.GetSeverity(), delivered)
+		return w.Reply(request, &pb.NoirReply{
+			Command: &pb.NoirReply_Admin{
+				Admin: &pb.AdminReply{
+					Payload: &pb.AdminReply_RoomAdmin{
+						RoomAdmin: &pb.RoomAdminReply{
+							RoomID: roomAdmin.GetRoomID(),
+							Payload: &pb.RoomAdminReply_Announce{
+								Announce: &pb.AnnounceReply{
+									Delivered: int32(delivered),
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+	if pauseRoom := roomAdmin.GetPauseRoom() ; pauseRoom != nil {
+		log.Infof("room=%s paused=%t", roomAdmin.GetRoomID(), pauseRoom.GetPaused())
+		return w.manager.PauseRoom(roomAdmin.GetRoomID(), pauseRoom.GetPaused(), roomAdmin.GetCallerID())
+	}
+	if transfer := roomAdmin.GetTransferOwnership() ; transfer != nil {
+		if err := w.manager.checkOwner(roomAdmin.GetRoomID(), roomAdmin.GetCallerID()) ; err != nil {
+			log.Warnf("room=%s refused %s: %s", roomAdmin.GetRoomID(), request.Action, err)
+			return err
+		}
+		log.Infof("room=%s owner=%s", roomAdmin.GetRoomID(), transfer.GetPid())
+		return w.manager.TransferOwnership(roomAdmin.GetRoomID(), transfer.GetPid())
+	}
+	if kickPeer := roomAdmin.GetKickPeer() ; kickPeer != nil {
+		log.Infof("room=%s kicking %s", roomAdmin.GetRoomID(), kickPeer.GetPid())
+		return w.manager.KickPeer(roomAdmin.GetRoomID(), kickPeer.GetPid())
+	}
+	return nil
+}
+
 func (w *worker) HandleAdmin(request *pb.NoirRequest) error {
 	admin := request.GetAdmin()
 	if roomAdmin := admin.GetRoomAdmin() ; roomAdmin != nil {
-		if createRoom := roomAdmin.GetCreateRoom() ; createRoom != nil {
-			_, err := w.manager.GetRemoteRoomData(roomAdmin.RoomID)
-			if err == nil {
-				return errors.New("room already exists") // Room exists
-			}
-
-			log.Infof("creating room %s", roomAdmin.RoomID)
-			room := NewRoom(roomAdmin.RoomID)
-			room.SetOptions(createRoom.GetOptions())
-			return SaveRoomData(roomAdmin.RoomID, &room.data, w.manager)
-		}
-		if roomJob := roomAdmin.GetRoomJob() ; roomJob != nil {
-			log.Infof("room=%s job=%s", roomAdmin.RoomID, roomJob.Handler)
-			w.HandleRoomJob(request)
-		}
+		return w.HandleRoomAdmin(request)
+	} else if admin.GetRoomStats() != nil {
+		return w.HandleRoomStats(request)
+	} else if admin.GetWorkerState() != nil {
+		return w.HandleWorkerState(request)
 	} else if list := admin.GetRoomList() ; list != nil {
-		keys := w.manager.redis.ZCount(pb.KeyRoomScores(), "1", "+inf").Val()
+		keys := w.manager.redis.ZCount(w.manager.Keys().RoomScores(), "1", "+inf").Val()
 		rooms := []*pb.RoomListEntry{}
-		for _, z := range w.manager.redis.ZRangeByScoreWithScores(pb.KeyRoomScores(),
+		for _, z := range w.manager.redis.ZRangeByScoreWithScores(w.manager.Keys().RoomScores(),
 			redis.ZRangeBy{
 				Min:    "0",
 				Max:    "+inf",
@@ -88,5 +254,4 @@ func (w *worker) HandleAdmin(request *pb.NoirRequest) error {
 		log.Errorf("no handler for admin command: %s", request.Action)
 		return errors.New("no handler for command")
 	}
-	return nil
 }
@@ -3,247 +3,985 @@ package noir
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	pb "github.com/net-prophet/noir/pkg/proto"
 	log "github.com/pion/ion-log"
 	"github.com/pion/ion-sfu/pkg/sfu"
 	"github.com/pion/sdp/v3"
 	"github.com/pion/webrtc/v3"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"os"
+	"sync"
+	"time"
 )
 
-func (w *worker) HandleSignal(request *pb.NoirRequest) error {
+func (w *worker) HandleSignal(request *pb.NoirRequest, logger Logger) error {
 	signal := request.GetSignal()
 	if signal.GetJoin() != nil {
-		return w.HandleJoin(request)
+		return w.HandleJoin(request, logger)
+	}
+	if signal.GetLeave() {
+		return w.HandleLeave(request)
+	}
+	if signal.GetResume() {
+		return w.HandleResume(request)
+	}
+	if signal.GetPlay() != nil {
+		return w.HandlePlay(request)
+	}
+	if signal.GetCapabilities() != nil {
+		return w.HandleCapabilities(request)
 	}
 	return nil
 }
 
-func (w *worker) HandleJoin(request *pb.NoirRequest) error {
-	w.mu.Lock()
-	defer w.mu.Unlock()
-	mgr := *w.manager
+// HandlePlay runs the PlayFile job as the signal's pid, Kill that pid to stop playing. The play is
+// checked as a join of the room, and only files under the playback dir play
+func (w *worker) HandlePlay(request *pb.NoirRequest) error {
+	mgr := w.manager
+	signal := request.GetSignal()
+	play := signal.GetPlay()
+
+	// a refused token learns nothing of the room or its files
+	_, err := mgr.Authenticate(&pb.SignalRequest{
+		Id:      signal.Id,
+		Payload: &pb.SignalRequest_Join{Join: &pb.JoinRequest{Sid: play.GetSid(), Token: play.GetToken()}},
+	})
+	if err != nil {
+		return w.SignalError(request, pb.SignalError_UNAUTHORIZED, fmt.Errorf("unauthorized: %s", err))
+	}
+	if mgr.IsRoomClosed(play.Sid) {
+		return w.SignalError(request, pb.SignalError_ROOM_CLOSED, fmt.Errorf("room %s is closed", play.Sid))
+	}
+	if _, err := mgr.GetRemoteRoomData(play.Sid); err != nil {
+		return w.SignalError(request, pb.SignalError_ROOM_NOT_FOUND, fmt.Errorf("room %s not found", play.Sid))
+	}
+	// the job would take over the peer, whose topic the refusal cannot go to, see HandleJoin
+	if mgr.connectedPeer(signal.Id) != nil {
+		err := fmt.Errorf("%w: %s is connected", ErrPeerIDTaken, signal.Id)
+		w.requestLogger(request).Infof("signal error: %s, the play has no topic to refuse it on", err)
+		return err
+	}
+
+	filename, err := mgr.PlaybackPath(play.Filename)
+	if err != nil {
+		return w.SignalError(request, pb.SignalError_INVALID_REQUEST, err)
+	}
+	if _, err := os.Stat(filename); err != nil {
+		return w.SignalError(request, pb.SignalError_FILE_NOT_FOUND, fmt.Errorf("%s not found", play.Filename))
+	}
+	if _, err := ProbeMediaFile(filename); err != nil {
+		return w.SignalError(request, pb.SignalError_UNSUPPORTED_CODEC, err)
+	}
+	handler, ok := w.jobHandlers[PlayFileJobHandler]
+	if !ok {
+		return w.SignalError(request, pb.SignalError_UNKNOWN, errors.New("no PlayFile handler on this worker"))
+	}
+
+	options := PlayFileOptions{Filename: filename, StartMs: play.StartMs, EndMs: play.EndMs}
+	if play.Repeat {
+		options.Repeat = -1
+	}
+	if err := CheckPlayRange(filename, options); err != nil {
+		return w.SignalError(request, pb.SignalError_INVALID_REQUEST, err)
+	}
+	packed, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+	job := handler(&pb.NoirRequest{
+		// the filename is resolved already, the job is the node's own doing
+		AdminID: mgr.ID(),
+		Command: &pb.NoirRequest_Admin{
+			Admin: &pb.AdminRequest{
+				Payload: &pb.AdminRequest_RoomAdmin{
+					RoomAdmin: &pb.RoomAdminRequest{
+						RoomID: play.Sid,
+						Method: &pb.RoomAdminRequest_RoomJob{
+							RoomJob: &pb.RoomJobRequest{
+								Handler: PlayFileJobHandler,
+								Pid:     signal.Id,
+								Options: packed,
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	if job == nil {
+		return w.SignalError(request, pb.SignalError_UNKNOWN, errors.New("could not create PlayFile job"))
+	}
+	go job.Handle()
+
+	return w.SignalReply(signal.Id, &pb.NoirReply{
+		Id:      request.Id,
+		TraceID: request.TraceID,
+		Command: &pb.NoirReply_Signal{
+			Signal: &pb.SignalReply{
+				Id:        signal.Id,
+				RequestId: signal.RequestId,
+				Payload:   &pb.SignalReply_Play{Play: true},
+			},
+		},
+	})
+}
+
+// HandleResume reattaches a client to its peer, replies queued while it was detached are still waiting
+func (w *worker) HandleResume(request *pb.NoirRequest) error {
+	signal := request.GetSignal()
+	if err := w.manager.ResumeClient(signal.Id, signal.GetResumeToken()); errors.Is(err, ErrResumeRefused) {
+		return w.SignalError(request, pb.SignalError_UNAUTHORIZED, err)
+	} else if err != nil {
+		return w.SignalError(request, pb.SignalError_PEER_NOT_FOUND, err)
+	}
+	return w.SignalReply(signal.Id, &pb.NoirReply{
+		Id:      request.Id,
+		TraceID: request.TraceID,
+		Command: &pb.NoirReply_Signal{
+			Signal: &pb.SignalReply{
+				Id:        signal.Id,
+				RequestId: signal.RequestId,
+				Payload:   &pb.SignalReply_Resume{Resume: true},
+			},
+		},
+	})
+}
+
+// HandleLeave is a user-initiated departure, unlike Kill which is forced
+func (w *worker) HandleLeave(request *pb.NoirRequest) error {
+	signal := request.GetSignal()
+	return w.manager.LeaveClient(signal.Id)
+}
+
+// trustedJoin is a join the node sent for one of its own jobs, PeerJob.SendJoin sets its AdminID
+// to the node's id as the node's own kills do. Clients' requests never carry one
+func trustedJoin(request *pb.NoirRequest) bool {
+	return request.GetAdminID() != ""
+}
+
+// HandleJoin connects the joining peer, logger carries the join's fields down to its PeerChannel
+func (w *worker) HandleJoin(request *pb.NoirRequest, logger Logger) (err error) {
+	span := w.manager.startSpan(request, "join", time.Now(), LogFields{"worker": w.id})
+	defer func() { span.End(err) }()
+	mgr := w.manager
 
 	signal := request.GetSignal()
 	join := signal.GetJoin()
 	pid := signal.Id
+	// where the join's replies go, its replyTo or else pid, even when the server assigns it another
+	replyTo := joinReplyTo(signal)
+
+	if w.Draining() {
+		return w.refuseDraining(request)
+	}
+
+	// a job's join has no token or password, it is the node's own and joins with the role it asks for
+	trusted := trustedJoin(request)
+	identity, role := "", join.GetRole()
+	if !trusted {
+		identity, err = mgr.Authenticate(signal)
+		if err != nil {
+			return w.SignalError(request, pb.SignalError_UNAUTHORIZED, fmt.Errorf("unauthorized: %s", err))
+		}
+		role, err = mgr.AuthorizeRole(signal, identity)
+		if err != nil {
+			return w.SignalError(request, pb.SignalError_UNAUTHORIZED, fmt.Errorf("unauthorized as %s: %s", join.GetRole(), err))
+		}
+		allowed, retryAfter, err := mgr.AllowJoin(signal, identity)
+		if err != nil {
+			// a broken limiter should not keep everyone out
+			logger.Errorf("join limiter error: %s", err)
+		} else if !allowed {
+			err := fmt.Errorf("rate limited, retry in %s", retryAfter)
+			w.SignalFailure(request, &pb.SignalError{
+				Code:         pb.SignalError_RATE_LIMITED,
+				Message:      err.Error(),
+				RetryAfterMs: retryAfter.Milliseconds(),
+			})
+			return err
+		}
+		// bcrypt takes tens of ms, the password is checked before the worker is locked
+		room, _ := mgr.GetRemoteRoomData(join.Sid)
+		if !CheckRoomPassword(room.GetOptions(), join.GetPassword()) {
+			return w.SignalError(request, pb.SignalError_UNAUTHORIZED, fmt.Errorf("wrong password for room %s", join.Sid))
+		}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// the failures below still go to replyTo, they reply to the request
+	mode := mgr.PeerIDMode()
+	// a job reads and signals as the pid it picked, its topics are named after it
+	if mode == AssignedPeerIDs && !trusted {
+		assigned := mgr.AssignPeerID()
+		signal = proto.Clone(signal).(*pb.SignalRequest)
+		signal.Id, pid = assigned, assigned
+		join = signal.GetJoin()
+		logger = logger.With(LogFields{"pid": pid})
+	}
+
+	// a join of a connected pid is a reconnect, see reconnect.go
+	var previous *pb.UserData
+	var previousClient PeerAdapter
+	if mode == ClientPeerIDs {
+		previous = mgr.connectedPeer(pid)
+	}
+	if previous != nil {
+		if mgr.ReconnectPolicy() == RejectReconnect {
+			return w.SignalError(request, pb.SignalError_ALREADY_CONNECTED, fmt.Errorf("%w: %s", ErrAlreadyConnected, pid))
+		}
+		if err := mgr.checkReconnect(previous, identity, signal.GetResumeToken()); err != nil {
+			return w.SignalError(request, pb.SignalError_ALREADY_CONNECTED, err)
+		}
+		role = reconnectedRole(previous, join.Sid, identity, role)
+		mgr.mu.RLock()
+		previousClient = mgr.users[pid]
+		mgr.mu.RUnlock()
+	}
+	// a failed reconnect leaves the connected peer its place in the room
+	releaseSlot := func() {
+		if previous.GetRoomID() != join.Sid {
+			mgr.ReleasePeerSlot(join.Sid, pid)
+		}
+	}
+
+	if mgr.IsRoomClosed(join.Sid) {
+		return w.SignalError(request, pb.SignalError_ROOM_CLOSED, fmt.Errorf("room %s is closed", join.Sid))
+	}
 
 	roomData, err := mgr.GetRemoteRoomData(join.Sid)
+	if err != nil && !mgr.AllowAutoCreateRooms() {
+		return w.SignalError(request, pb.SignalError_ROOM_NOT_FOUND, fmt.Errorf("room %s not found", join.Sid))
+	}
 	options := roomData.GetOptions()
 
-	if err == nil && options.GetMaxPeers() > 0 {
-		room := mgr.rooms[join.Sid]
-		session := room.Session()
-		if session != nil && len(session.Peers()) >= int(options.GetMaxPeers()) {
-			return errors.New("room full")
+	if err := mgr.CheckSubscriptions(join.Sid, pid, join.GetSubscribe()); err != nil {
+		return w.SignalError(request, pb.SignalError_PEER_NOT_FOUND, err)
+	}
+
+	if err := CheckSDPLimits(join.Description, mgr.RoomSDPLimits(roomData)); err != nil {
+		return w.SignalError(request, pb.SignalError_OFFER_TOO_COMPLEX, err)
+	}
+
+	// ConnectUser and the sfu only ever see the offer with our codecs left in it
+	filtered, err := mgr.RoomMediaConfig(roomData).FilterOffer(webrtc.SessionDescription{
+		Type: webrtc.SDPTypeOffer,
+		SDP:  string(join.Description),
+	})
+	if errors.Is(err, ErrNoCompatibleCodec) {
+		return w.SignalError(request, pb.SignalError_NO_COMPATIBLE_CODEC, err)
+	} else if err != nil {
+		return w.SignalError(request, pb.SignalError_JOIN_FAILED, fmt.Errorf("bad offer: %s", err))
+	}
+	join.Description = []byte(filtered.SDP)
+
+	if desc, err := mgr.ValidateOffer(roomData, pid, role, filtered); desc == nil {
+		return w.SignalError(request, pb.SignalError_JOIN_FAILED, fmt.Errorf("bad offer: %s", err))
+	} else if err != nil {
+		return w.SignalError(request, pb.SignalError_OFFER_REJECTED, fmt.Errorf("rejected offer: %s", err))
+	}
+
+	// a join that fails once pid is claimed leaves it to no one
+	claimed, joined := false, false
+	defer func() {
+		if claimed && !joined {
+			mgr.releasePeer(pid, w.id)
+		}
+	}()
+	if mode == UniquePeerIDs {
+		err := mgr.ReservePeerID(pid, w.id)
+		if errors.Is(err, ErrPeerIDTaken) {
+			// the pid's own topic is the connected peer's
+			if join.GetReplyTo() == "" {
+				logger.Infof("signal error: %s, the join has no replyTo to refuse it on", err)
+				return err
+			}
+			return w.SignalError(request, pb.SignalError_PEER_ID_TAKEN, err)
+		} else if err != nil {
+			return w.SignalError(request, pb.SignalError_INTERNAL, fmt.Errorf("error reserving %s: %s", pid, err))
 		}
+		claimed = true
 	}
 
-	peer, userData, err := mgr.ConnectUser(signal)
+	reserved, err := mgr.ReservePeerSlot(join.Sid, pid, options.GetMaxPeers())
+	if errors.Is(err, ErrRoomClosing) {
+		err := fmt.Errorf("%w: %s, retry in %s", ErrRoomClosing, join.Sid, RoomClosingRetryAfter)
+		w.SignalFailure(request, &pb.SignalError{
+			Code:         pb.SignalError_ROOM_CLOSED,
+			Message:      err.Error(),
+			RetryAfterMs: RoomClosingRetryAfter.Milliseconds(),
+		})
+		return err
+	} else if err != nil {
+		return w.SignalError(request, pb.SignalError_INTERNAL, fmt.Errorf("error reserving a slot in %s: %s", join.Sid, err))
+	}
+	if !reserved {
+		return w.SignalError(request, pb.SignalError_ROOM_FULL, fmt.Errorf("room %s is full", join.Sid))
+	}
 
+	version, err := NegotiateProtocolVersion(request.Version)
 	if err != nil {
+		return w.SignalError(request, pb.SignalError_VERSION_MISMATCH, err)
+	}
+	client, userData, err := mgr.ConnectUser(signal, identity, role, version)
+	// its PeerChannel moves and mutes the peer, whatever reads it beside that takes userMu
+	userMu := &sync.Mutex{}
+
+	if errors.Is(err, ErrNodeAtCapacity) {
+		releaseSlot()
+		w.SignalFailure(request, &pb.SignalError{
+			Code:         pb.SignalError_NODE_AT_CAPACITY,
+			Message:      err.Error(),
+			RetryAfterMs: NodeCapacityRetryAfter.Milliseconds(),
+		})
 		return err
+	} else if errors.Is(err, ErrNodeBusy) {
+		releaseSlot()
+		w.SignalFailure(request, &pb.SignalError{
+			Code:         pb.SignalError_NODE_BUSY,
+			Message:      err.Error(),
+			RetryAfterMs: NodeBusyRetryAfter.Milliseconds(),
+		})
+		return err
+	} else if err != nil {
+		releaseSlot()
+		return w.SignalError(request, pb.SignalError_JOIN_FAILED, err)
+	}
+	if previous != nil {
+		logger.Infof("%s reconnected, replacing its peer", pid)
+		w.replaced(pid, previousClient, previous, join.Sid)
 	}
+	peer := client.SFUPeer()
 
-	recv := w.manager.GetQueue(pb.KeyTopicToPeer(pid))
+	mgr.setPeerCompression(pid, mgr.NegotiateCompression(join.GetCompression()))
 
-	log.Infof("listening on %s", recv.Topic())
+	// from here on, a PeerChannel left on another worker hands the peer's messages over
+	if err := mgr.ClaimPeer(pid, w.id); err != nil {
+		logger.Errorf("error claiming %s: %s", pid, err)
+	}
+	claimed = true
 
-	peer.OnIceCandidate = func(candidate *webrtc.ICECandidateInit, target int) {
-		bytes, err := json.Marshal(candidate)
-		if err != nil {
-			log.Errorf("OnIceCandidate error %s", err)
-		}
-		w.SignalReply(pid, &pb.NoirReply{
-			Command: &pb.NoirReply_Signal{
-				Signal: &pb.SignalReply{
-					Id: pid,
-					Payload: &pb.SignalReply_Trickle{
-						Trickle: &pb.Trickle{
-							Init:   string(bytes),
-							Target: pb.Trickle_Target(target),
-						},
-					},
-				},
-			},
-		})
-		if err != nil {
-			log.Errorf("OnIceCandidate send error %v ", err)
-		}
+	// The room may have been closed while we were connecting
+	if mgr.IsRoomClosed(join.Sid) {
+		err := w.SignalError(request, pb.SignalError_ROOM_CLOSED, fmt.Errorf("room %s closed while joining", join.Sid))
+		mgr.CloseClient(pid, pb.CloseReason_ROOM_CLOSED)
+		return err
+	}
 
+	recv := w.manager.GetQueue(w.manager.Keys().TopicToPeer(pid))
+	// the end of a session of the pid that had gone before reading it would stop this one
+	if err := recv.Reopen(); err != nil {
+		logger.Errorf("error reopening %s: %s", recv.Topic(), err)
 	}
 
-	peer.OnICEConnectionStateChange = func(state webrtc.ICEConnectionState) {
+	logger.Infof("listening on %s", recv.Topic())
 
+	// A peer whose replies persistently fail to get through is no use to its client
+	var tornDown sync.Once
+	tearDown := func(err error) {
+		tornDown.Do(func() {
+			logger.Errorf("disconnecting %s, its replies are not getting through: %s", pid, err)
+			w.goPeer(func() { mgr.CloseClient(pid, pb.CloseReason_UNREACHABLE) })
+		})
 	}
 
-	peer.OnOffer = func(description *webrtc.SessionDescription) {
-		bytes, err := json.Marshal(description)
-		if err != nil {
-			log.Errorf("OnIceCandidate error %s", err)
+	sendTrickles := func(trickles []*pb.Trickle) {
+		err := w.SendTrickles(pid, trickles)
+		if errors.Is(err, ErrReplyDropped) {
+			logger.Debugf("OnIceCandidate %s", err)
+		} else if err != nil {
+			tearDown(err)
 		}
-		w.SignalReply(pid, &pb.NoirReply{
+	}
+	sendOffer := func(requestID string, offer []byte) {
+		err := w.SignalReply(pid, &pb.NoirReply{
 			Command: &pb.NoirReply_Signal{
 				Signal: &pb.SignalReply{
-					Id:      pid,
-					Payload: &pb.SignalReply_Description{Description: bytes},
+					Id:        pid,
+					RequestId: requestID,
+					Payload:   &pb.SignalReply_Description{Description: offer},
 				},
 			},
 		})
 		if err != nil {
-			log.Errorf("OnIceCandidate send error %v ", err)
+			tearDown(err)
+		}
+	}
+	offers := newOfferTracker(mgr.WebrtcTimeout(), func(requestID string, offer []byte) {
+		logger.Infof("%s did not answer offer %s, sending it again", pid, requestID)
+		sendOffer(requestID, offer)
+	}, func() {
+		logger.Errorf("%s did not answer our offer twice, disconnecting it", pid)
+		mgr.CloseClient(pid, pb.CloseReason_NEGOTIATION_TIMEOUT)
+	})
+	w.serverOffers.Store(pid, offers)
+	// a reconnect is back in the room it joins, whatever room it moved to before
+	w.peerRooms.Store(pid, join.Sid)
+	defer func() {
+		if !joined {
+			w.dropOffers(pid, offers)
+			w.peerRooms.Delete(pid)
 		}
+	}()
 
+	var batcher *trickleBatcher
+	if maxBatch, flushInterval := mgr.TrickleBatching(); maxBatch > 1 {
+		batcher = newTrickleBatcher(maxBatch, flushInterval, sendTrickles)
 	}
 
+	icePolicy := mgr.RoomICEPolicy(join.Sid)
+	rtcpPolicy := mgr.RoomRTCPPolicy(join.Sid)
+	client.OnIceCandidate(func(candidate *webrtc.ICECandidateInit, target int) {
+		if !allowTrickle(icePolicy, *candidate, "outbound") {
+			return
+		}
+		bytes, err := json.Marshal(candidate)
+		if err != nil {
+			logger.Errorf("OnIceCandidate error %s", err)
+			return
+		}
+		trickle := &pb.Trickle{
+			Init:   string(bytes),
+			Target: pb.Trickle_Target(target),
+		}
+		if batcher != nil {
+			batcher.Add(trickle)
+		} else {
+			sendTrickles([]*pb.Trickle{trickle})
+		}
+	})
+
+	// Only announce the peer once media can actually flow, and let it go if it stops for good
+	var announced atomicBool
+	watchdog := newICEWatchdog(mgr.ICEGrace(), func() { mgr.disconnectLost(pid, client) })
+	client.OnICEConnectionStateChange(func(state webrtc.ICEConnectionState) {
+		logger.Debugf("ice %s", state)
+		watchdog.observe(state)
+		mgr.recordPeerStatus(copyUser(userData, userMu), peer)
+		if state == webrtc.ICEConnectionStateConnected && !announced.get() {
+			announced.set(true)
+			if err := w.manager.announceJoined(join.Sid, pid); err != nil {
+				logger.Errorf("error announcing %s joined: %s", pid, err)
+			}
+		}
+	})
+
+	trackInfo := &trackInfoSender{}
+	client.OnOffer(func(description *webrtc.SessionDescription) {
+		offer := *description
+		if err := FilterCandidates(&offer, icePolicy, "outbound"); err != nil {
+			logger.Errorf("error filtering candidates of offer to %s: %s", pid, err)
+		}
+		if rtcpPolicy.GetDisableNack() {
+			if err := StripNACK(&offer); err != nil {
+				logger.Errorf("error disabling nack in offer to %s: %s", pid, err)
+			}
+		}
+		offer.SDP = mgr.BeforeSendOffer(pid, offer.SDP)
+		bytes, err := json.Marshal(offer)
+		if err != nil {
+			logger.Errorf("OnOffer error %s", err)
+			return
+		}
+		subscriberOffers.Inc()
+		if err := w.sendTrackInfos(pid, peer, trackInfo); err != nil {
+			logger.Errorf("error sending %s its tracks: %s", pid, err)
+		}
+		sendOffer(offers.offered(bytes), bytes)
+	})
+
 	var offer webrtc.SessionDescription
 	offer = webrtc.SessionDescription{
 		Type: webrtc.SDPTypeOffer,
 		SDP:  string(join.Description),
 	}
+	if err := FilterCandidates(&offer, icePolicy, "inbound"); err != nil {
+		logger.Errorf("error filtering candidates of %s: %s", pid, err)
+	}
+
+	answer, err := client.Join(join.Sid, offer)
+
+	if err != nil {
+		err := w.SignalError(request, pb.SignalError_JOIN_FAILED, fmt.Errorf("error joining %s: %s", join.Sid, err))
+		mgr.CloseClient(pid, pb.CloseReason_JOIN_FAILED)
+		return err
+	}
 
-	answer, _ := peer.Join(join.Sid, offer)
+	// the sfu offers the room's tracks a debounce after the join, so this is in time unless it was slow
+	if window := mgr.OfferBundling(); window > 0 && !bundleOffers(peer, window) {
+		logger.Debugf("%s was offered before its offers could be bundled", pid)
+	}
+
+	if err := LimitBitrate(answer, userData.GetLimits().GetPublishKbps()); err != nil {
+		logger.Errorf("error limiting bitrate for %s: %s", pid, err)
+	}
+	if err := AcceptAudioLevel(offer, answer); err != nil {
+		logger.Errorf("error asking %s for audio levels: %s", pid, err)
+	}
+	if err := FilterCandidates(answer, icePolicy, "outbound"); err != nil {
+		logger.Errorf("error filtering candidates for %s: %s", pid, err)
+	}
+	if rtcpPolicy.GetDisableNack() {
+		if err := StripNACK(answer); err != nil {
+			logger.Errorf("error disabling nack for %s: %s", pid, err)
+		}
+	}
+	answer.SDP = mgr.BeforeSendAnswer(pid, answer.SDP)
 
 	w.manager.UpdateRoomScore(join.Sid)
+	mgr.ApplyRoomMutes(join.Sid)
+	// the room's data is newer than a pause or resume this node may have missed
+	mgr.setRoomPaused(join.Sid, roomData.GetPaused())
+	if !mgr.holdWhilePaused(func() string { return w.peerRoom(pid, join.Sid) }, peer) {
+		logger.Errorf("error holding tracks added to %s while %s is paused", pid, join.Sid)
+	}
+	if !mgr.holdMuted(peer) {
+		logger.Errorf("error muting the tracks of muted publishers added to %s", pid)
+	}
+	if roomData.GetPaused() {
+		pauseSubscriber(subscriberOf(peer), true)
+	}
+	if rtcpPolicy != nil && !applyRTCPPolicy(peer, rtcpPolicy) {
+		logger.Errorf("error applying the rtcp policy of %s to %s", join.Sid, pid)
+	}
+	if !mgr.requestKeyframesOnBind(peer) {
+		logger.Errorf("error requesting keyframes for the tracks added to %s", pid)
+	}
+	mgr.recordPeerStatus(userData, peer)
+	// the sfu negotiates holding a publisher's router, and recording reads the peer's own router,
+	// so two peers publishing to each other would wait on one another
+	if _, ok := hookNegotiate(subscriberOf(peer), func() { w.goPeer(func() { mgr.recordPeerStatus(copyUser(userData, userMu), peer) }) }); !ok {
+		logger.Errorf("error recording the tracks added to %s", pid)
+	}
+
+	packed, err := json.Marshal(answer)
+	if err != nil {
+		err := w.SignalError(request, pb.SignalError_INTERNAL, fmt.Errorf("error packing answer: %s", err))
+		mgr.CloseClient(pid, pb.CloseReason_JOIN_FAILED)
+		return err
+	}
 
-	packed, _ := json.Marshal(answer)
+	iceServers, err := mgr.ICEServers(pid)
+	if err != nil {
+		logger.Errorf("error getting ice servers for %s: %s", pid, err)
+	}
 
-	w.SignalReply(pid, &pb.NoirReply{
-		Id: request.Id,
+	resumeToken, err := mgr.issueResumeToken(pid)
+	if err != nil {
+		logger.Errorf("error issuing the resume token of %s: %s", pid, err)
+	}
+
+	joinReply := &pb.JoinReply{Description: packed, IceServers: iceServers, Version: userData.Version, ResumeToken: resumeToken}
+	if replyTo != pid {
+		joinReply.Pid = pid
+	}
+	err = w.SignalReply(replyTo, &pb.NoirReply{
+		Id:      request.Id,
+		TraceID: request.TraceID,
 		Command: &pb.NoirReply_Signal{
 			Signal: &pb.SignalReply{
 				Id:        pid,
 				RequestId: signal.RequestId,
-				Payload: &pb.SignalReply_Join{
-					Join: &pb.JoinReply{
-						Description: packed,
+				Payload:   &pb.SignalReply_Join{Join: joinReply},
+			},
+		},
+	})
+	if err != nil {
+		mgr.CloseClient(pid, pb.CloseReason_UNREACHABLE)
+		return err
+	}
+
+	// after the reply, so anything capped comes after the answer, and before the first offer
+	if publishers := join.GetSubscribe(); len(publishers) > 0 || join.GetNoAutoSubscribe() {
+		if err := mgr.SelectSubscriptions(pid, peer, publishers, int(options.GetMaxSubscribedTracks())); err != nil {
+			logger.Errorf("error selecting the subscriptions of %s: %s", pid, err)
+		}
+	} else if limit := options.GetMaxSubscribedTracks(); limit > 0 {
+		if err := mgr.LimitSubscriptions(pid, peer, int(limit)); err != nil {
+			logger.Errorf("error limiting the subscriptions of %s: %s", pid, err)
+		}
+	}
+
+	if roomData.GetPaused() {
+		if err := w.sendPaused(pid, join.Sid, &pb.RoomPaused{Paused: true}); err != nil {
+			logger.Errorf("error telling %s its room is paused: %s", pid, err)
+		}
+	}
+
+	w.peers[pid] = true
+	w.peerVersions.Store(pid, userData.Version)
+	w.peerWG.Add(1)
+	joined = true
+	w.goPeer(func() { w.PeerChannel(userData, userMu, client, logger) })
+
+	return nil
+}
+
+// HandleSetLayer picks the simulcast layer a subscriber receives and replies with the one applied
+func (w *worker) HandleSetLayer(request *pb.NoirRequest, peer *sfu.Peer) error {
+	signal := request.GetSignal()
+	setLayer := signal.GetSetLayer()
+	spatial, temporal, err := SwitchLayer(peer, setLayer.StreamId, setLayer.Spatial)
+	if errors.Is(err, ErrNotSimulcast) {
+		return w.SignalError(request, pb.SignalError_NOT_SIMULCAST, err)
+	} else if errors.Is(err, ErrTrackNotFound) {
+		return w.SignalError(request, pb.SignalError_TRACK_NOT_FOUND, err)
+	} else if err != nil {
+		return w.SignalError(request, pb.SignalError_UNKNOWN, err)
+	}
+	w.capAdaptedLayer(signal.Id, setLayer.StreamId, spatial)
+	return w.SignalReply(signal.Id, &pb.NoirReply{
+		Id:      request.Id,
+		TraceID: request.TraceID,
+		Command: &pb.NoirReply_Signal{
+			Signal: &pb.SignalReply{
+				Id:        signal.Id,
+				RequestId: signal.RequestId,
+				Payload: &pb.SignalReply_SetLayer{
+					SetLayer: &pb.SetLayerReply{
+						StreamId: setLayer.StreamId,
+						Spatial:  spatial,
+						Temporal: temporal,
 					},
 				},
 			},
 		},
 	})
+}
 
-	go w.PeerChannel(userData, peer)
+// SignalError replies to a failed signal request with a machine-readable code, and returns err
+func (w *worker) SignalError(request *pb.NoirRequest, code pb.SignalError_Code, err error) error {
+	w.SignalFailure(request, &pb.SignalError{
+		Code:    code,
+		Message: err.Error(),
+	})
+	return err
+}
 
-	return nil
+// SignalFailure replies to the request with the failure, on a join's replyTo when it has one
+func (w *worker) SignalFailure(request *pb.NoirRequest, failure *pb.SignalError) error {
+	signal := request.GetSignal()
+	w.requestLogger(request).Infof("signal error: %s", failure.Message)
+	return w.SignalReply(joinReplyTo(signal), &pb.NoirReply{
+		Id:      request.Id,
+		TraceID: request.TraceID,
+		Command: &pb.NoirReply_Signal{
+			Signal: &pb.SignalReply{
+				Id:        signal.Id,
+				RequestId: signal.RequestId,
+				Payload: &pb.SignalReply_Failure{
+					Failure: failure,
+				},
+			},
+		},
+	})
 }
 
+// joinReplyTo is the topic the replies to signal go to, the pid's unless it is a join naming another
+func joinReplyTo(signal *pb.SignalRequest) string {
+	if replyTo := signal.GetJoin().GetReplyTo(); replyTo != "" {
+		return replyTo
+	}
+	return signal.Id
+}
+
+// SignalReply sends pid a reply, framed in the protocol version settled at its join
 func (w *worker) SignalReply(pid string, reply *pb.NoirReply) error {
-	send := w.manager.GetQueue(pb.KeyTopicFromPeer(pid))
-	defer w.manager.redis.Publish(pb.KeyPeerNewsChannel(pid), pid)
-	return EnqueueReply(send, reply)
+	if version, ok := w.peerVersions.Load(pid); ok && reply.Version == "" {
+		reply.Version = version.(string)
+	}
+	send := w.manager.GetQueue(w.manager.Keys().TopicFromPeer(pid))
+	defer w.manager.redis.Publish(w.manager.Keys().PeerNewsChannel(pid), pid)
+	return w.manager.EnqueueReply(send, reply)
 }
 
-func (w *worker) PeerChannel(userData *pb.UserData, peer *sfu.Peer) {
-	recv := w.manager.GetQueue(pb.KeyTopicToPeer(userData.Id))
+func (w *worker) PeerChannel(userData *pb.UserData, userMu *sync.Mutex, client PeerAdapter, logger Logger) {
+	peer := client.SFUPeer()
+	logger = logger.With(LogFields{"pid": userData.Id, "sid": userData.RoomID, "worker": w.id, "action": "", "trace": ""})
+	defer w.peerWG.Done()
+	peerChannels.WithLabelValues(w.id).Inc()
+	defer peerChannels.WithLabelValues(w.id).Dec()
+	channel := &peerChannel{client: client, stop: make(chan struct{}), done: make(chan struct{})}
+	w.peerChannels.Store(userData.Id, channel)
+	defer close(channel.done)
+	defer func() {
+		if running, ok := w.peerChannels.Load(userData.Id); ok && running == channel {
+			w.peerChannels.Delete(userData.Id)
+		}
+	}()
+	defer func() {
+		// what is filed under the pid is the peer's that reconnected
+		if w.superseded(userData.Id, client) {
+			return
+		}
+		w.mu.Lock()
+		delete(w.peers, userData.Id)
+		w.mu.Unlock()
+		w.peerVersions.Delete(userData.Id)
+		w.peerRooms.Delete(userData.Id)
+		w.manager.forgetMutes(peer)
+		w.manager.setSubscribeCap(userData.Id, 0)
+		if offers, ok := w.serverOffers.Load(userData.Id); ok {
+			w.dropOffers(userData.Id, offers.(*offerTracker))
+		}
+		if err := w.manager.releasePeer(userData.Id, w.id); err != nil {
+			logger.Errorf("error releasing %s: %s", userData.Id, err)
+		}
+	}()
+	w.manager.setSubscribeCap(userData.Id, userData.GetLimits().GetSubscribeKbps())
+	// Negotiations run beside the loop, so a slow one never holds up trickle or kill
+	negotiations := make(chan *pb.NoirRequest, NegotiationBacklog)
+	defer close(negotiations)
+	w.goPeer(func() { w.Negotiate(userData, client, userMu, negotiations) })
+	if interval := w.manager.QualityInterval(); userData.QualityReports && interval > 0 {
+		stopReports := make(chan struct{})
+		defer close(stopReports)
+		w.goPeer(func() { w.reportQuality(userData.Id, peer, interval, stopReports, logger) })
+	}
+	if userData.AdaptiveLayers {
+		adapter := newLayerAdapter(w.manager.AdaptiveLayers())
+		adapter.subscribeCap = uint64(userData.GetLimits().GetSubscribeKbps()) * 1000
+		w.layerAdapters.Store(userData.Id, adapter)
+		defer func() {
+			// a reconnect on this node has its own
+			if current, ok := w.layerAdapters.Load(userData.Id); ok && current == adapter {
+				w.layerAdapters.Delete(userData.Id)
+			}
+		}()
+		stopAdapting := make(chan struct{})
+		defer close(stopAdapting)
+		w.goPeer(func() { w.adaptLayers(userData.Id, peer, adapter, stopAdapting, logger) })
+	}
+	stopLayers := make(chan struct{})
+	defer close(stopLayers)
+	w.goPeer(func() { w.watchLayers(userData, peer, userMu, stopLayers) })
+	if tapped, ok := client.(*tappedPeer); ok {
+		stopTaps := make(chan struct{})
+		defer close(stopTaps)
+		for _, feed := range tapped.feeds {
+			feed := feed
+			w.goPeer(func() { feed.deliver(stopTaps) })
+		}
+	}
+	if aggregation := w.manager.RoomRTCPPolicy(userData.RoomID).GetAggregation(); aggregation != pb.RTCPPolicy_NONE {
+		stopEstimates := make(chan struct{})
+		defer close(stopEstimates)
+		w.goPeer(func() { w.forwardEstimates(userData.Id, peer, aggregation, stopEstimates, logger) })
+	}
+	keepalive := &keepaliveWatch{}
+	if interval, misses := w.manager.Keepalive(); userData.Keepalive && interval > 0 {
+		stopPings := make(chan struct{})
+		defer close(stopPings)
+		w.goPeer(func() { w.keepAlive(userData.Id, interval, misses, keepalive, stopPings, logger) })
+	}
+	activity := newIdleWatch()
+	if timeout := w.manager.RoomIdleTimeout(userData.RoomID); timeout > 0 {
+		stopIdle := make(chan struct{})
+		defer close(stopIdle)
+		w.goPeer(func() { w.closeIdle(userData.Id, peer, timeout, activity, stopIdle, logger) })
+	}
+
+	// Stats are sampled over a window, so they are gathered beside the loop too
+	stats := newSFUDeadline()
+
+	recv := w.manager.GetQueue(w.manager.Keys().TopicToPeer(userData.Id))
+	icePolicy := w.manager.RoomICEPolicy(userData.RoomID)
+	failures := 0
 	for {
 		request := pb.NoirRequest{}
-		message, err := recv.BlockUntilNext(0)
-		if err != nil {
-			log.Errorf("getting message to peer %s", err)
+		// a reconnect on this node supersedes the peer between messages, see reconnect.go
+		message, err := recv.BlockUntilNext(WorkerPollTimeout)
+		select {
+		case <-channel.stop:
+			logger.Infof("%s reconnected, stopping its old PeerChannel", userData.Id)
+			if err == nil {
+				priority := PriorityNormal
+				if UnmarshalRequest(message, &request) == nil {
+					priority = RequestPriority(&request)
+				}
+				if err := Requeue(recv, message, priority); err != nil {
+					logger.Errorf("error handing a message to the reconnected %s: %s", userData.Id, err)
+				}
+			}
+			return
+		default:
+		}
+		if err == io.EOF {
+			if w.superseded(userData.Id, client) {
+				logger.Infof("%s reconnected, stopping its old PeerChannel", userData.Id)
+				return
+			}
+			continue
+		} else if errors.Is(err, ErrQueueClosed) {
+			// nothing reaches the peer once its topic is closed, so it is done with
+			logger.Debugf("topic to peer %s closed", userData.Id)
+			w.manager.DisconnectUser(userData.Id)
+			return
+		} else if err != nil {
+			failures++
+			if failures > w.manager.PeerQueueRetries() {
+				logger.Errorf("giving up on peer %s after %d queue errors: %s", userData.Id, failures, err)
+				w.manager.CloseClient(userData.Id, pb.CloseReason_UNREACHABLE)
+				return
+			}
+			backoff := peerQueueBackoff(failures)
+			logger.Errorf("getting message to peer %s, retrying in %s", err, backoff)
+			time.Sleep(backoff)
+			continue
+		}
+		failures = 0
+		if err := w.manager.CheckMessageSize(message); err != nil {
+			logger.Errorf("dropping message to peer %s: %s", userData.Id, err)
+			w.manager.DeadLetter(recv.Topic(), deadLetterSample(message), err)
+			continue
 		}
 		err = UnmarshalRequest(message, &request)
 		if err != nil {
-			log.Errorf("unmarshal message to peer %s", err)
+			logger.Errorf("unmarshal message to peer %s", err)
+			w.manager.DeadLetter(recv.Topic(), message, err)
+			continue
+		}
+		if w.superseded(userData.Id, client) {
+			logger.Infof("%s reconnected, stopping its old PeerChannel", userData.Id)
+			if err := Requeue(recv, message, RequestPriority(&request)); err != nil {
+				logger.Errorf("error handing %s to the reconnected %s: %s", request.Action, userData.Id, err)
+			}
+			return
+		}
+		if owner, moved := w.ownedElsewhere(userData.Id); moved {
+			logger.Infof("%s moved to worker %s, handing it over", userData.Id, owner)
+			if err := w.handOver(userData.Id, client, recv, message, &request); err != nil {
+				logger.Errorf("error handing %s over to %s: %s", request.Action, owner, err)
+			}
+			return
+		}
+		if !w.manager.FirstDelivery(&request) {
+			continue
 		}
+		handling := logger.With(LogFields{"action": request.Action, "trace": request.TraceID})
+		w.manager.traceQueued(&request, recv.Topic())
+		span := w.manager.startSpan(&request, "peer", time.Now(), LogFields{"worker": w.id})
 		switch request.Command.(type) {
 		case *pb.NoirRequest_Signal:
 			signal := request.GetSignal()
+			if activeSignal(signal) {
+				activity.touch()
+			}
 			switch signal.Payload.(type) {
 			case *pb.SignalRequest_Kill:
-				log.Debugf("got KillRequest for user %s", userData.Id)
-				w.manager.DisconnectUser(userData.Id)
+				handling.Debugf("got KillRequest for user %s", userData.Id)
+				// a client killing its peer leaves, only a node's own kills say why else
+				reason := signal.GetCloseReason()
+				if request.GetAdminID() == "" {
+					reason = pb.CloseReason_LEFT
+				}
+				w.manager.CloseClient(userData.Id, reason)
+				span.End(nil)
+				return
+			case *pb.SignalRequest_Leave:
+				handling.Debugf("got LeaveRequest for user %s", userData.Id)
+				w.manager.LeaveClient(userData.Id)
+				span.End(nil)
 				return
 			case *pb.SignalRequest_Description:
-				var desc Negotiation
-				err := json.Unmarshal(signal.GetDescription(), &desc)
+				select {
+				case negotiations <- &request:
+				default:
+					w.SignalError(&request, pb.SignalError_NEGOTIATION_FAILED, fmt.Errorf("more than %d negotiations pending", NegotiationBacklog))
+				}
+			case *pb.SignalRequest_Data:
+				if err := w.manager.RelayData(userData, signal.GetData()); err != nil {
+					handling.Errorf("error relaying data from %s: %s", userData.Id, err)
+				}
+			case *pb.SignalRequest_SetLayer:
+				w.HandleSetLayer(&request, peer)
+			case *pb.SignalRequest_Mute:
+				userMu.Lock()
+				err := w.HandleMute(userData, peer, signal.GetMute())
+				userMu.Unlock()
 				if err != nil {
-					log.Errorf("unmarshal err: %s", err)
-					continue
+					handling.Errorf("error muting %s: %s", userData.Id, err)
 				}
-				if desc.Desc.Type == webrtc.SDPTypeAnswer {
-					log.Debugf("got answer, setting description")
-					peer.SetRemoteDescription(desc.Desc)
-				} else if desc.Desc.Type == webrtc.SDPTypeOffer {
-					roomData, err := w.manager.GetRemoteRoomData(userData.GetRoomID())
-					if err != nil {
-						log.Errorf("err getting room to validate offer: %s", err)
-						continue
-					}
-
-					validated, err := w.manager.ValidateOffer(roomData, userData.Id, desc.Desc)
-
-					A, V, D, summary := TrackSummary(validated)
-
-					roomType := "room"
-
-					// Just one jobData track
-					if D == 1 && A == 0 && V == 0 {
-						userData.Publishing = false
-					} else if A > 0 || V > 0 {
-						// Publishing
-						options := roomData.GetOptions()
-
-						if options.GetIsChannel() == true {
-							roomType = "channel"
-							if roomData.GetPublisher() != "" {
-								log.Infof("channel already has a publisher, denying: %s", roomData.Id)
-								continue
-							} else if A > 1 || V > 1 {
-								log.Infof("cannot publish multiple video or audio tracks into channel %s: %s", roomData.Id, summary)
-								continue
-							} else {
-								roomData.Publisher = userData.Id
-								SaveRoomData(userData.RoomID, roomData, w.manager)
-							}
-						}
-						userData.Publishing = true
-						log.Infof("publishing [%dA/%dV/%dD] into %s %s: %s", A, V, D, roomType, userData.RoomID, summary)
-					}
-
-					if err != nil {
-						log.Infof("rejected offer: %s", err)
-						continue
-					}
-
-					answer, _ := peer.Answer(desc.Desc)
-					bytes, err := json.Marshal(answer)
-					log.Debugf("answering offer from %s: %s", request.Id, summary)
-					w.SignalReply(userData.Id, &pb.NoirReply{
-						Id: request.Id,
-						Command: &pb.NoirReply_Signal{
-							Signal: &pb.SignalReply{
-								Id:        userData.Id,
-								RequestId: signal.RequestId,
-								Payload:   &pb.SignalReply_Description{Description: bytes},
-							},
-						},
-					})
-					if err != nil {
-						log.Errorf("answer send error %v ", err)
-					}
-
-					w.manager.SaveData(pb.KeyUserData(userData.Id), &pb.NoirObject{
-						Data: &pb.NoirObject_User{User: userData},
-					}, 0)
+			case *pb.SignalRequest_Pause:
+				userMu.Lock()
+				err := w.HandlePause(userData, peer, signal.GetPause())
+				userMu.Unlock()
+				if err != nil {
+					handling.Errorf("error pausing %s: %s", userData.Id, err)
 				}
-			case *pb.SignalRequest_Trickle:
-				trickle := signal.GetTrickle()
-				var candidate webrtc.ICECandidateInit
-				err := json.Unmarshal([]byte(trickle.GetInit()), &candidate)
+			case *pb.SignalRequest_Move:
+				userMu.Lock()
+				err := w.HandleMove(&request, userData, peer)
+				userMu.Unlock()
 				if err != nil {
-					log.Errorf("unmarshal err: %s %s", err, trickle.GetInit())
-					continue
+					handling.Errorf("error moving %s: %s", userData.Id, err)
+				}
+			case *pb.SignalRequest_Subscribe, *pb.SignalRequest_Unsubscribe:
+				userMu.Lock()
+				w.HandleSubscribe(&request, userData, peer)
+				userMu.Unlock()
+			case *pb.SignalRequest_GetStats:
+				w.goPeer(func() { w.HandleGetStats(&request, userData.Id, peer, stats) })
+			case *pb.SignalRequest_Pong:
+				keepalive.pong(signal.GetPong())
+			case *pb.SignalRequest_Trickle:
+				trickle(client, signal.GetTrickle(), icePolicy)
+			case *pb.SignalRequest_TrickleBatch:
+				for _, candidate := range signal.GetTrickleBatch().GetCandidates() {
+					trickle(client, candidate, icePolicy)
 				}
-				peer.Trickle(candidate, int(trickle.Target.Number()))
 			default:
-				log.Errorf("unknown servers for peer %s", signal.Payload)
+				handling.Errorf("unknown servers for peer %s", signal.Payload)
 			}
 		default:
-			log.Errorf("unknown command for peer %s", request.Command)
+			handling.Errorf("unknown command for peer %s", request.Command)
 		}
+		span.End(nil)
+	}
+}
+
+// peerRoom is the room pid is in now, see HandleMove
+func (w *worker) peerRoom(pid string, joined string) string {
+	if roomID, ok := w.peerRooms.Load(pid); ok {
+		return roomID.(string)
+	}
+	return joined
+}
+
+// copyUser is userData as it stands, for reading beside the PeerChannel that changes it
+func copyUser(userData *pb.UserData, userMu *sync.Mutex) *pb.UserData {
+	userMu.Lock()
+	defer userMu.Unlock()
+	return proto.Clone(userData).(*pb.UserData)
+}
+
+func trickle(client PeerAdapter, trickle *pb.Trickle, icePolicy *pb.ICEPolicy) {
+	var candidate webrtc.ICECandidateInit
+	err := json.Unmarshal([]byte(trickle.GetInit()), &candidate)
+	if err != nil {
+		log.Errorf("unmarshal err: %s %s", err, trickle.GetInit())
+		return
 	}
+	if !allowTrickle(icePolicy, candidate, "inbound") {
+		return
+	}
+	client.Trickle(candidate, int(trickle.Target.Number()))
 }
 
+// peerQueueBackoff doubles for every consecutive failure, up to PeerQueueMaxBackoff
+func peerQueueBackoff(failures int) time.Duration {
+	backoff := PeerQueueBackoff
+	for i := 1; i < failures && backoff < PeerQueueMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > PeerQueueMaxBackoff {
+		return PeerQueueMaxBackoff
+	}
+	return backoff
+}
 
 func TrackSummary(desc *sdp.SessionDescription) (int, int, int, string) {
 	summary := ""
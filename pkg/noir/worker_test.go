@@ -1,9 +1,16 @@
 package noir
 
 import (
+	"encoding/json"
+	"errors"
 	pb "github.com/net-prophet/noir/pkg/proto"
 	"github.com/pion/webrtc/v3"
+	"google.golang.org/protobuf/proto"
+	"regexp"
+	"runtime"
+	"sync"
 	"testing"
+	"time"
 )
 
 // TODO - i copied in this real SDP because i dont know how to pberate
@@ -12,6 +19,7 @@ const EXAMPLE_EMPTY_SDP = "v=0\r\no=- 8158248220666482328 2 IN IP4 127.0.0.1\r\n
 
 func TestWorkerJoin(t *testing.T) {
 	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
 	redis.Del(pb.KeyRoomData("test"))
 
 	desc := webrtc.SessionDescription{SDP: EXAMPLE_EMPTY_SDP}
@@ -34,3 +42,580 @@ func TestWorkerJoin(t *testing.T) {
 	EnqueueRequest(*queue, request)
 	worker.HandleNext(0)
 }
+
+func TestWorkerStop(t *testing.T) {
+	mgr, _ := NewTestSetup()
+	worker := *mgr.GetWorker()
+	queue := *worker.GetQueue()
+	queue.Cleanup()
+
+	before := runtime.NumGoroutine()
+
+	go worker.HandleForever()
+	time.Sleep(100 * time.Millisecond)
+	worker.Stop()
+
+	leaked := runtime.NumGoroutine() - before
+	for i := 0; i < 10 && leaked > 0; i++ {
+		time.Sleep(100 * time.Millisecond)
+		leaked = runtime.NumGoroutine() - before
+	}
+	if leaked > 0 {
+		t.Errorf("got %d goroutines left after stop want 0", leaked)
+	}
+
+	request := &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id:      "stopped",
+				Payload: &pb.SignalRequest_Kill{Kill: true},
+			},
+		},
+	}
+	EnqueueRequest(queue, request)
+	time.Sleep(WorkerPollTimeout + 100*time.Millisecond)
+
+	if count, _ := queue.Count(); count != 1 {
+		t.Errorf("got %d queued want 1, stopped worker is still consuming", count)
+	}
+	queue.Cleanup()
+}
+
+func TestWorkerActionHandler(t *testing.T) {
+	mgr, _ := NewTestSetup()
+	w := (*mgr.GetWorker()).(*worker)
+	worker := *mgr.GetWorker()
+	queue := *worker.GetQueue()
+	queue.Cleanup()
+	defer queue.Cleanup()
+
+	handled := map[string]string{}
+	worker.RegisterActionHandler("request.custom.", func(request *pb.NoirRequest) error {
+		handled[request.Action] = "custom"
+		return nil
+	})
+	worker.RegisterActionHandler("request.custom.whiteboard", func(request *pb.NoirRequest) error {
+		handled[request.Action] = "whiteboard"
+		return nil
+	})
+
+	for _, action := range []string{"request.custom.whiteboard.draw", "request.custom.poll"} {
+		// a fresh id, deliveries are deduplicated by it
+		EnqueueRequest(queue, &pb.NoirRequest{Action: action})
+		if err := worker.HandleNext(0); err != nil {
+			t.Fatalf("error handling %s: %s", action, err)
+		}
+	}
+	if got := handled["request.custom.whiteboard.draw"]; got != "whiteboard" {
+		t.Errorf("got %q want the longest prefix handling a whiteboard action", got)
+	}
+	if got := handled["request.custom.poll"]; got != "custom" {
+		t.Errorf("got %q want the custom handler handling a poll", got)
+	}
+
+	// an action that is not its payload's never reaches the handler of the action
+	EnqueueRequest(queue, &pb.NoirRequest{
+		Action:  "request.admin.room.close",
+		Command: &pb.NoirRequest_Signal{Signal: &pb.SignalRequest{Id: "mislabelled-peer", Payload: &pb.SignalRequest_Kill{Kill: true}}},
+	})
+	if err := worker.HandleNext(0); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("got %v want the mislabelled request refused", err)
+	}
+	mgr.GetQueue(pb.KeyTopicFromPeer("mislabelled-peer")).Cleanup()
+	// and a room admin handler handed no room admin reads nothing of it
+	if err := w.HandleRoomAdmin(&pb.NoirRequest{AdminID: "action-admin"}); err != nil {
+		t.Errorf("got %s want a request without a room admin payload ignored", err)
+	}
+}
+
+func TestWorkerJoinMissingRoom(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	redis.Del(pb.KeyRoomData("missing"), pb.KeyTopicFromPeer("missing-peer"))
+
+	request := &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id:        "missing-peer",
+				RequestId: "join-1",
+				Payload: &pb.SignalRequest_Join{
+					Join: &pb.JoinRequest{
+						Sid:         "missing",
+						Description: []byte(EXAMPLE_EMPTY_SDP),
+					},
+				},
+			},
+		},
+	}
+	worker := *mgr.GetWorker()
+	queue := worker.GetQueue()
+	EnqueueRequest(*queue, request)
+	if err := worker.HandleNext(0); err == nil {
+		t.Errorf("joining a missing room should fail")
+	}
+
+	reply := pb.NoirReply{}
+	msg, _ := mgr.GetQueue(pb.KeyTopicFromPeer("missing-peer")).Next()
+	proto.Unmarshal(msg, &reply)
+	signal := reply.GetSignal()
+	if signal.GetFailure().GetCode() != pb.SignalError_ROOM_NOT_FOUND {
+		t.Errorf("got %s want ROOM_NOT_FOUND", &reply)
+	}
+	if signal.GetRequestId() != "join-1" {
+		t.Errorf("got request id %s want join-1", signal.GetRequestId())
+	}
+	if exists, _ := mgr.GetRemoteRoomExists("missing"); exists {
+		t.Errorf("rejected join should not create the room")
+	}
+}
+
+func TestWorkerCustomTimeout(t *testing.T) {
+	_, rdb := NewTestSetup()
+	config := Config{}
+	sfu := NewNoirSFU(config)
+	mgr := NewRedisManager(&sfu, rdb, "test-timeout-worker", "*")
+	mgr.SetTimeouts(90*time.Second, 0)
+
+	if got := mgr.RouterMaxAge(); got != 90*time.Second {
+		t.Errorf("got router max age %s want it to follow webrtc timeout 90s", got)
+	}
+
+	worker := NewRedisWorker("test-timeout-worker", mgr, rdb)
+	queue := *worker.GetQueue()
+	queue.Cleanup()
+	queue.Add([]byte("ttl"))
+
+	ttl := rdb.TTL(queue.Topic()).Val()
+	if ttl <= WebrtcTimeout || ttl > 90*time.Second {
+		t.Errorf("got queue ttl %s want 90s", ttl)
+	}
+	queue.Cleanup()
+}
+
+func TestWorkerResume(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	mgr.SetResumeGrace(time.Minute)
+	redis.Del(append(peerKeys("resume-peer"), pb.KeyRoomData("resume"), pb.KeyRoomUsers("resume"))...)
+
+	worker := *mgr.GetWorker()
+	queue := *worker.GetQueue()
+	EnqueueRequest(queue, &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id: "resume-peer",
+				Payload: &pb.SignalRequest_Join{
+					Join: &pb.JoinRequest{
+						Sid:         "resume",
+						Description: []byte(EXAMPLE_EMPTY_SDP),
+					},
+				},
+			},
+		},
+	})
+	if err := worker.HandleNext(0); err != nil {
+		t.Fatalf("error joining: %s", err)
+	}
+	defer mgr.DisconnectUser("resume-peer")
+
+	mgr.DetachClient("resume-peer")
+
+	// The join answer and any candidates are still queued ahead of the resume
+	replies := mgr.GetQueue(pb.KeyTopicFromPeer("resume-peer"))
+	readReplies := func() (string, *pb.SignalError, bool) {
+		token, failure, resumed := "", (*pb.SignalError)(nil), false
+		for msg, _ := replies.Next(); msg != nil; msg, _ = replies.Next() {
+			reply := pb.NoirReply{}
+			proto.Unmarshal(msg, &reply)
+			if join := reply.GetSignal().GetJoin(); join != nil {
+				token = join.GetResumeToken()
+			}
+			if reply.GetSignal().GetFailure() != nil {
+				failure = reply.GetSignal().GetFailure()
+			}
+			if reply.GetSignal().GetResume() {
+				resumed = true
+			}
+		}
+		return token, failure, resumed
+	}
+
+	resume := &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id:        "resume-peer",
+				RequestId: "resume-1",
+				Payload:   &pb.SignalRequest_Resume{Resume: true},
+			},
+		},
+	}
+	// without the join's token, or with another, the peer is not handed over
+	for _, token := range []string{"", "not-the-token"} {
+		resume.Id = ""
+		resume.GetSignal().ResumeToken = token
+		EnqueueRequest(queue, resume)
+		if err := worker.HandleNext(0); !errors.Is(err, ErrResumeRefused) {
+			t.Errorf("got %v want a resume with token %q refused", err, token)
+		}
+	}
+	token, failure, resumed := readReplies()
+	if token == "" {
+		t.Fatalf("got no resume token with the join")
+	}
+	if resumed || failure.GetCode() != pb.SignalError_UNAUTHORIZED {
+		t.Errorf("got %s want the resumes refused as UNAUTHORIZED", failure)
+	}
+	if err := mgr.CheckResumeToken("resume-peer", "not-the-token"); !errors.Is(err, ErrResumeRefused) {
+		t.Errorf("got %v want the gateway's check refusing another token", err)
+	}
+
+	resume.Id = ""
+	resume.GetSignal().ResumeToken = token
+	EnqueueRequest(queue, resume)
+	if err := worker.HandleNext(0); err != nil {
+		t.Fatalf("error resuming: %s", err)
+	}
+	if _, _, resumed := readReplies(); !resumed {
+		t.Errorf("got no resume reply")
+	}
+
+	// a second resume, not the first one again
+	resume.Id = ""
+	EnqueueRequest(queue, resume)
+	if err := worker.HandleNext(0); err == nil {
+		t.Errorf("resuming an attached peer should fail")
+	}
+}
+
+func TestPeerQueueBackoff(t *testing.T) {
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{1, PeerQueueBackoff},
+		{2, 2 * PeerQueueBackoff},
+		{4, 8 * PeerQueueBackoff},
+		{100, PeerQueueMaxBackoff},
+	}
+	for _, tt := range tests {
+		if got := peerQueueBackoff(tt.failures); got != tt.want {
+			t.Errorf("got %s after %d failures want %s", got, tt.failures, tt.want)
+		}
+	}
+}
+
+func TestWorkerJoinUnauthorized(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	mgr.SetAuthenticator(AuthenticatorFunc(func(signal *pb.SignalRequest) (string, error) {
+		if signal.GetJoin().GetToken() != "letmein" {
+			return "", errors.New("bad token")
+		}
+		return "alice", nil
+	}))
+	redis.Del(pb.KeyRoomData("auth"), pb.KeyRoomUsers("auth"), pb.KeyTopicFromPeer("auth-peer"))
+
+	worker := *mgr.GetWorker()
+	queue := *worker.GetQueue()
+	join := func(pid string, token string) error {
+		EnqueueRequest(queue, &pb.NoirRequest{
+			Command: &pb.NoirRequest_Signal{
+				Signal: &pb.SignalRequest{
+					Id: pid,
+					Payload: &pb.SignalRequest_Join{
+						Join: &pb.JoinRequest{
+							Sid:         "auth",
+							Description: []byte(EXAMPLE_EMPTY_SDP),
+							Token:       token,
+						},
+					},
+				},
+			},
+		})
+		return worker.HandleNext(0)
+	}
+
+	if err := join("auth-peer", "wrong"); err == nil {
+		t.Errorf("join with a bad token should fail")
+	}
+	reply := pb.NoirReply{}
+	msg, _ := mgr.GetQueue(pb.KeyTopicFromPeer("auth-peer")).Next()
+	proto.Unmarshal(msg, &reply)
+	if reply.GetSignal().GetFailure().GetCode() != pb.SignalError_UNAUTHORIZED {
+		t.Errorf("got %s want UNAUTHORIZED", &reply)
+	}
+	if exists, _ := mgr.GetRemoteRoomExists("auth"); exists {
+		t.Errorf("rejected join should not create the room")
+	}
+
+	if err := join("auth-peer", "letmein"); err != nil {
+		t.Fatalf("error joining: %s", err)
+	}
+	defer mgr.DisconnectUser("auth-peer")
+	userData, _ := mgr.GetRemoteUserData("auth-peer")
+	if userData.GetIdentity() != "alice" {
+		t.Errorf("got identity %s want alice", userData.GetIdentity())
+	}
+}
+
+func playRequest(pid string, play *pb.PlayRequest) *pb.NoirRequest {
+	return &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id:        pid,
+				RequestId: "play-1",
+				Payload:   &pb.SignalRequest_Play{Play: play},
+			},
+		},
+	}
+}
+
+func TestWorkerPlayMissingFile(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	keys := emptyRoomKeys("play", "play-peer")
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+	mgr.SetRoomData(&pb.RoomData{Id: "play"})
+
+	request := playRequest("play-peer", &pb.PlayRequest{Sid: "play", Filename: "nonexistent/video.ivf"})
+	if roomID, _ := mgr.LookupSignalRoomID(request.GetSignal()); roomID != "play" {
+		t.Errorf("got room %s want play requests routed to their sid", roomID)
+	}
+
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), request)
+	if err := worker.HandleNext(0); err == nil {
+		t.Errorf("playing a missing file should fail")
+	}
+
+	reply := pb.NoirReply{}
+	msg, _ := mgr.GetQueue(pb.KeyTopicFromPeer("play-peer")).Next()
+	proto.Unmarshal(msg, &reply)
+	if reply.GetSignal().GetFailure().GetCode() != pb.SignalError_FILE_NOT_FOUND {
+		t.Errorf("got %s want FILE_NOT_FOUND", &reply)
+	}
+}
+
+func TestWorkerPlayRefused(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	keys := append(emptyRoomKeys("play-refused", "play-refused-peer", "play-refused-taken"), emptyRoomKeys("play-refused-closed")...)
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+	mgr.SetRoomData(&pb.RoomData{Id: "play-refused"})
+	mgr.SetRoomData(&pb.RoomData{Id: "play-refused-closed"})
+	redis.Set(pb.KeyRoomClosed("play-refused-closed"), 1, 0)
+	worker := *mgr.GetWorker()
+
+	refused := func(pid string, play *pb.PlayRequest) *pb.SignalError {
+		EnqueueRequest(*worker.GetQueue(), playRequest(pid, play))
+		if err := worker.HandleNext(0); err == nil {
+			t.Errorf("%s should be refused", play)
+		}
+		return nextSignalReply(t, mgr, pid, func(reply *pb.SignalReply) bool { return reply.GetFailure() != nil }).GetFailure()
+	}
+	for _, tt := range []struct {
+		play *pb.PlayRequest
+		want pb.SignalError_Code
+	}{
+		{&pb.PlayRequest{Sid: "play-missing", Filename: "test.video"}, pb.SignalError_ROOM_NOT_FOUND},
+		{&pb.PlayRequest{Sid: "play-refused-closed", Filename: "test.video"}, pb.SignalError_ROOM_CLOSED},
+		// whether files outside the playback dir exist is nobody's business
+		{&pb.PlayRequest{Sid: "play-refused", Filename: "../config.toml"}, pb.SignalError_INVALID_REQUEST},
+		{&pb.PlayRequest{Sid: "play-refused", Filename: "../nonexistent"}, pb.SignalError_INVALID_REQUEST},
+	} {
+		if failure := refused("play-refused-peer", tt.play); failure.GetCode() != tt.want {
+			t.Errorf("got %s want %s for %s", failure, tt.want, tt.play)
+		}
+	}
+	if exists, _ := mgr.GetRemoteRoomExists("play-missing"); exists {
+		t.Errorf("a refused play should not create the room")
+	}
+
+	mgr.SetAuthenticator(AuthenticatorFunc(func(signal *pb.SignalRequest) (string, error) {
+		if signal.GetJoin().GetToken() != "letmein" {
+			return "", errors.New("bad token")
+		}
+		return "alice", nil
+	}))
+	failure := refused("play-refused-peer", &pb.PlayRequest{Sid: "play-refused", Filename: "nonexistent.ivf", Token: "wrong"})
+	if failure.GetCode() != pb.SignalError_UNAUTHORIZED {
+		t.Errorf("got %s want a play with a bad token UNAUTHORIZED", failure)
+	}
+	failure = refused("play-refused-peer", &pb.PlayRequest{Sid: "play-refused", Filename: "nonexistent.ivf", Token: "letmein"})
+	if failure.GetCode() != pb.SignalError_FILE_NOT_FOUND {
+		t.Errorf("got %s want a play with a good token checked", failure)
+	}
+	mgr.SetAuthenticator(nil)
+
+	// a play cannot take over a connected peer
+	mgr.SetAllowAutoCreateRooms(true)
+	defer mgr.SetAllowAutoCreateRooms(false)
+	joinFake(t, mgr, "play-refused-taken", "play-refused", newFakePeer())
+	defer mgr.DisconnectUser("play-refused-taken")
+	mgr.GetQueue(pb.KeyTopicFromPeer("play-refused-taken")).Cleanup()
+	EnqueueRequest(*worker.GetQueue(), playRequest("play-refused-taken", &pb.PlayRequest{Sid: "play-refused", Filename: "test.video"}))
+	if err := worker.HandleNext(0); !errors.Is(err, ErrPeerIDTaken) {
+		t.Errorf("got %v want a play as a connected pid refused", err)
+	}
+}
+
+func TestWorkerJoinFailed(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	redis.Del(pb.KeyRoomData("broken"), pb.KeyRoomUsers("broken"), pb.KeyTopicFromPeer("broken-peer"))
+
+	// Parses fine, but the sfu refuses an offer without a DTLS fingerprint
+	noFingerprint := regexp.MustCompile(`a=fingerprint:[^\r]*\r\n`).ReplaceAllString(EXAMPLE_EMPTY_SDP, "")
+	worker := *mgr.GetWorker()
+	EnqueueRequest(*worker.GetQueue(), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id:        "broken-peer",
+				RequestId: "join-1",
+				Payload: &pb.SignalRequest_Join{
+					Join: &pb.JoinRequest{
+						Sid:         "broken",
+						Description: []byte(noFingerprint),
+					},
+				},
+			},
+		},
+	})
+	if err := worker.HandleNext(0); err == nil {
+		t.Errorf("join without a fingerprint should fail")
+	}
+
+	reply := pb.NoirReply{}
+	msg, _ := mgr.GetQueue(pb.KeyTopicFromPeer("broken-peer")).Next()
+	proto.Unmarshal(msg, &reply)
+	signal := reply.GetSignal()
+	if signal.GetFailure().GetCode() != pb.SignalError_JOIN_FAILED || signal.GetRequestId() != "join-1" {
+		t.Errorf("got %s want JOIN_FAILED for join-1", &reply)
+	}
+	if exists := redis.HExists(pb.KeyRoomUsers("broken"), "broken-peer").Val(); exists {
+		t.Errorf("failed join should not leave the peer in the room")
+	}
+}
+
+// runSlowPeerChannel runs a PeerChannel whose sfu takes until release is closed to answer
+func runSlowPeerChannel(mgr *Manager, pid string, release chan struct{}) chan struct{} {
+	w := (*mgr.GetWorker()).(*worker)
+	fake := newFakePeer()
+	fake.answer = func(offer webrtc.SessionDescription) (*webrtc.SessionDescription, error) {
+		<-release
+		return nil, errors.New("released")
+	}
+	mgr.SetRoomData(&pb.RoomData{Id: "slow"})
+	mgr.GetQueue(pb.KeyTopicToPeer(pid)).Cleanup()
+	mgr.GetQueue(pb.KeyTopicFromPeer(pid)).Cleanup()
+
+	offer, _ := json.Marshal(Negotiation{Desc: webrtc.SessionDescription{Type: webrtc.SDPTypeOffer, SDP: EXAMPLE_EMPTY_SDP}})
+	EnqueueRequest(mgr.GetQueue(pb.KeyTopicToPeer(pid)), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id:        pid,
+				RequestId: "offer-1",
+				Payload:   &pb.SignalRequest_Description{Description: offer},
+			},
+		},
+	})
+
+	done := make(chan struct{})
+	w.peerWG.Add(1)
+	go func() {
+		w.PeerChannel(&pb.UserData{Id: pid, RoomID: "slow"}, &sync.Mutex{}, fake, NewIonLogger())
+		close(done)
+	}()
+	return done
+}
+
+func TestPeerChannelKillDuringSlowAnswer(t *testing.T) {
+	mgr, _ := NewTestSetup()
+	release := make(chan struct{})
+	defer close(release)
+	done := runSlowPeerChannel(mgr, "slow-peer", release)
+
+	time.Sleep(100 * time.Millisecond)
+	EnqueueRequest(mgr.GetQueue(pb.KeyTopicToPeer("slow-peer")), &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{Id: "slow-peer", Payload: &pb.SignalRequest_Kill{Kill: true}},
+		},
+	})
+	select {
+	case <-done:
+	case <-time.After(3 * time.Second):
+		t.Fatalf("kill was not handled while the answer was stuck")
+	}
+}
+
+func TestPeerChannelAnswerTimeout(t *testing.T) {
+	mgr, _ := NewTestSetup()
+	mgr.SetTimeouts(200*time.Millisecond, RouterMaxAge)
+	release := make(chan struct{})
+	defer close(release)
+	done := runSlowPeerChannel(mgr, "timeout-peer", release)
+
+	msg, err := mgr.GetQueue(pb.KeyTopicFromPeer("timeout-peer")).BlockUntilNext(3 * time.Second)
+	if err != nil {
+		t.Fatalf("got %s want a reply once the answer timed out", err)
+	}
+	reply := pb.NoirReply{}
+	proto.Unmarshal(msg, &reply)
+	signal := reply.GetSignal()
+	if signal.GetFailure().GetCode() != pb.SignalError_NEGOTIATION_FAILED || signal.GetRequestId() != "offer-1" {
+		t.Errorf("got %s want NEGOTIATION_FAILED for offer-1", &reply)
+	}
+
+	mgr.DisconnectUser("timeout-peer")
+	<-done
+}
+
+func TestPeerChannelQueueClosed(t *testing.T) {
+	mgr, redis := NewTestSetup()
+	mgr.SetAllowAutoCreateRooms(true)
+	keys := []string{pb.KeyRoomData("closing"), pb.KeyRoomUsers("closing"), pb.KeyUserData("closing-peer"),
+		pb.KeyPeerOwner("closing-peer"), pb.KeyTopicToPeer("closing-peer"), pb.KeyTopicFromPeer("closing-peer")}
+	redis.Del(keys...)
+	defer redis.Del(keys...)
+	fake := newFakePeer()
+	joinFake(t, mgr, "closing-peer", "closing", fake)
+
+	if err := mgr.GetQueue(pb.KeyTopicToPeer("closing-peer")).Close(); err != nil {
+		t.Fatalf("error closing the peer's topic: %s", err)
+	}
+	waitFor(t, "the peer to be closed with its topic", func() bool {
+		fake.mu.Lock()
+		defer fake.mu.Unlock()
+		return fake.closed
+	})
+	waitFor(t, "the PeerChannel to return", func() bool {
+		return (*mgr.GetWorker()).(*worker).PeerCount() == 0
+	})
+
+	// an end nobody read does not stop the pid's next session
+	toPeer := mgr.GetQueue(pb.KeyTopicToPeer("closing-peer"))
+	// without the kill the close queued after the PeerChannel returned, only the end is left
+	toPeer.Cleanup()
+	toPeer.Close()
+	rejoined := newFakePeer()
+	joinFake(t, mgr, "closing-peer", "closing", rejoined)
+	defer mgr.DisconnectUser("closing-peer")
+	candidate, _ := json.Marshal(webrtc.ICECandidateInit{Candidate: "candidate:1 1 udp 2130706431 192.168.1.2 50000 typ host"})
+	EnqueueRequest(toPeer, &pb.NoirRequest{
+		Command: &pb.NoirRequest_Signal{
+			Signal: &pb.SignalRequest{
+				Id:      "closing-peer",
+				Payload: &pb.SignalRequest_Trickle{Trickle: &pb.Trickle{Init: string(candidate)}},
+			},
+		},
+	})
+	waitFor(t, "the rejoined peer to read its topic on", func() bool {
+		rejoined.mu.Lock()
+		defer rejoined.mu.Unlock()
+		return len(rejoined.candidates) == 1
+	})
+	rejoined.mu.Lock()
+	defer rejoined.mu.Unlock()
+	if rejoined.closed {
+		t.Errorf("the rejoined peer should not be closed by the end left on its topic")
+	}
+}
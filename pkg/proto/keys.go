@@ -1,72 +1,347 @@
 package proto
 
+/*
+Keys:
+Every redis key and topic of a deployment is under its prefix, noir/ unless Manager.SetKeyPrefix
+says otherwise, so deployments, eg. staging and production or one per tenant, can share a redis
+without seeing each other. A Manager builds its keys with Manager.Keys(), the Key functions are
+the keys of DefaultKeys, for code that runs beside a deployment with the default prefix.
+*/
+
+// DefaultKeyPrefix is the prefix of DefaultKeys, and the router topic of the deployment using them
+const DefaultKeyPrefix = "noir/"
+
+var DefaultKeys = NewKeys(DefaultKeyPrefix)
+
+// Keys builds the keys under one prefix
+type Keys struct {
+	prefix string
+}
+
+// NewKeys is the keys under prefix, which gets a trailing slash, an empty prefix is DefaultKeyPrefix
+func NewKeys(prefix string) Keys {
+	if prefix == "" {
+		prefix = DefaultKeyPrefix
+	}
+	if prefix[len(prefix)-1] != '/' {
+		prefix += "/"
+	}
+	return Keys{prefix: prefix}
+}
+
+// Prefix is what every key starts with, it is the router topic too
+func (k Keys) Prefix() string {
+	return k.prefix
+}
+
 // Node Map
 
-func KeyNodeMap() string {
-	return "noir/map/nodes/"
+func (k Keys) NodeMap() string {
+	return k.prefix + "map/nodes/"
 }
 
 // Data Keys
 
+func (k Keys) RoomData(roomID string) string {
+	return k.prefix + "obj/room/" + roomID
+}
+
+// RoomDataPrefix is the SCAN pattern for every room, see Manager.ListRooms
+func (k Keys) RoomDataPrefix() string {
+	return k.prefix + "obj/room/"
+}
+
+func (k Keys) UserData(userID string) string {
+	return k.prefix + "obj/user/" + userID
+}
+
+func (k Keys) RoomClosed(roomID string) string {
+	return k.prefix + "obj/roomClosed/" + roomID
+}
+
+// Set when the last peer left a room, to the token of the leave that emptied it, see empty_rooms.go
+func (k Keys) RoomEmpty(roomID string) string {
+	return k.prefix + "obj/roomEmpty/" + roomID
+}
+
+// Set while an empty room is closed, joins are refused until it is gone
+func (k Keys) RoomClosing(roomID string) string {
+	return k.prefix + "obj/roomClosing/" + roomID
+}
+
+// Set while a peer has no client attached, see Manager.DetachClient
+func (k Keys) UserDetached(userID string) string {
+	return k.prefix + "obj/userDetached/" + userID
+}
+
+func (k Keys) UserResume(userID string) string {
+	return k.prefix + "obj/userResume/" + userID
+}
+
+// Set once a peer's JOINED was announced, its LEFT is only announced then, see Manager.CloseClient
+func (k Keys) UserJoined(userID string) string {
+	return k.prefix + "obj/userJoined/" + userID
+}
+
+// The worker whose PeerChannel consumes a peer's to-peer topic, see Manager.ClaimPeer
+func (k Keys) PeerOwner(userID string) string {
+	return k.prefix + "obj/peerOwner/" + userID
+}
+
+// Where a connected peer stands, see Manager.GetPeerStatus
+func (k Keys) PeerStatus(userID string) string {
+	return k.prefix + "obj/peerStatus/" + userID
+}
+
+// Set by the first worker to handle a request, see Manager.FirstDelivery
+func (k Keys) RequestSeen(requestID string) string {
+	return k.prefix + "obj/requestSeen/" + requestID
+}
+
+// A join token bucket, see NewRedisJoinLimiter
+func (k Keys) JoinRate(key string) string {
+	return k.prefix + "obj/joinRate/" + key
+}
+
+// Expires unless the worker keeps writing it, see Manager.Heartbeat
+func (k Keys) WorkerHeartbeat(nodeID string) string {
+	return k.prefix + "obj/heartbeat/" + nodeID
+}
+
+// WorkerHeartbeatPrefix is the SCAN pattern for every live worker, see Manager.ListWorkers
+func (k Keys) WorkerHeartbeatPrefix() string {
+	return k.prefix + "obj/heartbeat/"
+}
+
+// Held by the manager tearing down a dead worker's peers, see Manager.ReclaimWorker
+func (k Keys) WorkerReclaim(nodeID string) string {
+	return k.prefix + "obj/reclaim/" + nodeID
+}
+
+// Messages a worker could not parse, newest first, see Manager.DrainDeadLetter
+func (k Keys) DeadLetter(nodeID string) string {
+	return k.prefix + "dead-letter/" + nodeID
+}
+
+// The traced rooms, each mapped to "redact" or "full", see Manager.TraceSignaling
+func (k Keys) TracedRooms() string {
+	return k.prefix + "map/tracedRooms/"
+}
+
+// A room's signaling, newest first, see Manager.GetSignalingTrace
+func (k Keys) RoomTrace(roomID string) string {
+	return k.prefix + "trace/" + roomID
+}
+
+// Reverse Relations
+
+func (k Keys) NodeRooms(nodeID string) string {
+	return k.prefix + "map/nodeRooms/" + nodeID
+}
+
+func (k Keys) RoomUsers(roomID string) string {
+	return k.prefix + "map/roomUsers/" + roomID
+}
+
+// The pids an identity is joined as, see Manager.KickIdentity
+func (k Keys) IdentityPeers(identity string) string {
+	return k.prefix + "set/identityPeers/" + identity
+}
+
+// Channel Topics
+
+func (k Keys) RouterTopic() string {
+	return k.prefix + "topic/router"
+}
+
+func (k Keys) WorkerTopic(nodeID string) string {
+	return k.prefix + "topic/worker/" + nodeID
+}
+
+func (k Keys) TopicToPeer(peerID string) string {
+	return k.prefix + "topic/pc/" + peerID
+}
+
+func (k Keys) TopicFromPeer(peerID string) string {
+	return k.prefix + "topic/client/" + peerID
+}
+
+func (k Keys) TopicToAdmin(clientID string) string {
+	return k.prefix + "topic/to-admin/" + clientID
+}
+
+func (k Keys) TopicFromAdmin(clientID string) string {
+	return k.prefix + "topic/from-admin/" + clientID
+}
+
+func (k Keys) TopicToJob(jobID string) string {
+	return k.prefix + "topic/to-job/" + jobID
+}
+
+func (k Keys) TopicFromJob(jobID string) string {
+	return k.prefix + "topic/from-job/" + jobID
+}
+
+// Room Events - PUBLISH presence to every peer subscribed to the room
+
+func (k Keys) TopicRoomEvents(roomID string) string {
+	return k.prefix + "topic/room-events/" + roomID
+}
+
+// Topic News Channels - PUBLISH when topic has new messages
+
+func (k Keys) PeerNewsChannel(peerID string) string {
+	return k.prefix + "news/peers/" + peerID
+}
+
+// Told the pids another worker took over from workerID, see Manager.ClaimPeer
+func (k Keys) PeerOwnerChannel(workerID string) string {
+	return k.prefix + "news/peerOwners/" + workerID
+}
+
+// Scores -
+func (k Keys) RoomScores() string {
+	return k.prefix + "scores/rooms"
+}
+
+// The keys of DefaultKeys
+
+func KeyNodeMap() string {
+	return DefaultKeys.NodeMap()
+}
+
 func KeyRoomData(roomID string) string {
-	return "noir/obj/room/" + roomID
+	return DefaultKeys.RoomData(roomID)
+}
+
+func KeyRoomDataPrefix() string {
+	return DefaultKeys.RoomDataPrefix()
 }
 
 func KeyUserData(userID string) string {
-	return "noir/obj/user/" + userID
+	return DefaultKeys.UserData(userID)
 }
 
-// Reverse Relations
+func KeyRoomClosed(roomID string) string {
+	return DefaultKeys.RoomClosed(roomID)
+}
+
+func KeyRoomEmpty(roomID string) string {
+	return DefaultKeys.RoomEmpty(roomID)
+}
+
+func KeyRoomClosing(roomID string) string {
+	return DefaultKeys.RoomClosing(roomID)
+}
+
+func KeyUserDetached(userID string) string {
+	return DefaultKeys.UserDetached(userID)
+}
+
+func KeyUserResume(userID string) string {
+	return DefaultKeys.UserResume(userID)
+}
+
+func KeyUserJoined(userID string) string {
+	return DefaultKeys.UserJoined(userID)
+}
+
+func KeyPeerOwner(userID string) string {
+	return DefaultKeys.PeerOwner(userID)
+}
+
+func KeyPeerStatus(userID string) string {
+	return DefaultKeys.PeerStatus(userID)
+}
+
+func KeyRequestSeen(requestID string) string {
+	return DefaultKeys.RequestSeen(requestID)
+}
+
+func KeyJoinRate(key string) string {
+	return DefaultKeys.JoinRate(key)
+}
+
+func KeyWorkerHeartbeat(nodeID string) string {
+	return DefaultKeys.WorkerHeartbeat(nodeID)
+}
+
+func KeyWorkerHeartbeatPrefix() string {
+	return DefaultKeys.WorkerHeartbeatPrefix()
+}
+
+func KeyWorkerReclaim(nodeID string) string {
+	return DefaultKeys.WorkerReclaim(nodeID)
+}
+
+func KeyDeadLetter(nodeID string) string {
+	return DefaultKeys.DeadLetter(nodeID)
+}
+
+func KeyTracedRooms() string {
+	return DefaultKeys.TracedRooms()
+}
+
+func KeyRoomTrace(roomID string) string {
+	return DefaultKeys.RoomTrace(roomID)
+}
 
 func KeyNodeRooms(nodeID string) string {
-	return "noir/map/nodeRooms/" + nodeID
+	return DefaultKeys.NodeRooms(nodeID)
 }
 
 func KeyRoomUsers(roomID string) string {
-	return "noir/map/roomUsers/" + roomID
+	return DefaultKeys.RoomUsers(roomID)
 }
 
-// Channel Topics
+func KeyIdentityPeers(identity string) string {
+	return DefaultKeys.IdentityPeers(identity)
+}
 
 func KeyRouterTopic() string {
-	return "noir/topic/router"
+	return DefaultKeys.RouterTopic()
 }
 
 func KeyWorkerTopic(nodeID string) string {
-	return "noir/topic/worker/" + nodeID
+	return DefaultKeys.WorkerTopic(nodeID)
 }
 
 func KeyTopicToPeer(peerID string) string {
-	return "noir/topic/pc/" + peerID
+	return DefaultKeys.TopicToPeer(peerID)
 }
 
 func KeyTopicFromPeer(peerID string) string {
-	return "noir/topic/client/" + peerID
+	return DefaultKeys.TopicFromPeer(peerID)
 }
 
 func KeyTopicToAdmin(clientID string) string {
-	return "noir/topic/to-admin/" + clientID
+	return DefaultKeys.TopicToAdmin(clientID)
 }
 
 func KeyTopicFromAdmin(clientID string) string {
-	return "noir/topic/from-admin/" + clientID
+	return DefaultKeys.TopicFromAdmin(clientID)
 }
 
 func KeyTopicToJob(jobID string) string {
-	return "noir/topic/to-job/" + jobID
+	return DefaultKeys.TopicToJob(jobID)
 }
 
 func KeyTopicFromJob(jobID string) string {
-	return "noir/topic/from-job/" + jobID
+	return DefaultKeys.TopicFromJob(jobID)
 }
 
-// Topic News Channels - PUBLISH when topic has new messages
+func KeyTopicRoomEvents(roomID string) string {
+	return DefaultKeys.TopicRoomEvents(roomID)
+}
 
 func KeyPeerNewsChannel(peerID string) string {
-	return "noir/news/peers/" + peerID
+	return DefaultKeys.PeerNewsChannel(peerID)
+}
+
+func KeyPeerOwnerChannel(workerID string) string {
+	return DefaultKeys.PeerOwnerChannel(workerID)
 }
 
-// Scores -
 func KeyRoomScores() string {
-	return "noir/scores/rooms"
+	return DefaultKeys.RoomScores()
 }
@@ -21,6 +21,581 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Compression a client can read replies in, see JoinRequest.compression
+type Compression int32
+
+const (
+	Compression_NONE Compression = 0
+	Compression_GZIP Compression = 1
+	Compression_ZSTD Compression = 2
+)
+
+// Enum value maps for Compression.
+var (
+	Compression_name = map[int32]string{
+		0: "NONE",
+		1: "GZIP",
+		2: "ZSTD",
+	}
+	Compression_value = map[string]int32{
+		"NONE": 0,
+		"GZIP": 1,
+		"ZSTD": 2,
+	}
+)
+
+func (x Compression) Enum() *Compression {
+	p := new(Compression)
+	*p = x
+	return p
+}
+
+func (x Compression) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Compression) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_proto_noir_proto_enumTypes[0].Descriptor()
+}
+
+func (Compression) Type() protoreflect.EnumType {
+	return &file_pkg_proto_noir_proto_enumTypes[0]
+}
+
+func (x Compression) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Compression.Descriptor instead.
+func (Compression) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{0}
+}
+
+// CloseReason is why a peer was disconnected, see Manager.CloseClient
+type CloseReason int32
+
+const (
+	CloseReason_UNKNOWN             CloseReason = 0
+	CloseReason_LEFT                CloseReason = 1 // the peer left, or its client closed it
+	CloseReason_KICKED              CloseReason = 2 // a moderator kicked it
+	CloseReason_ROOM_CLOSED         CloseReason = 3
+	CloseReason_ICE_FAILED          CloseReason = 4 // ICE did not reconnect within the manager's ICE grace
+	CloseReason_DETACHED            CloseReason = 5 // its client went away and did not resume within the resume grace
+	CloseReason_JOIN_FAILED         CloseReason = 6
+	CloseReason_NODE_STOPPING       CloseReason = 7  // the worker running it stopped
+	CloseReason_UNREACHABLE         CloseReason = 8  // messages to or from it kept failing
+	CloseReason_JOB_ENDED           CloseReason = 9  // a job's peer, eg. a recorder, finished
+	CloseReason_IDLE                CloseReason = 10 // it neither published nor signaled for the idle timeout
+	CloseReason_NEGOTIATION_TIMEOUT CloseReason = 11 // it did not answer our offer, sent twice, within the webrtc timeout
+	CloseReason_MOVED               CloseReason = 12 // it moved to another room on the same transport, see Manager.MovePeer
+	CloseReason_KEEPALIVE_TIMEOUT   CloseReason = 13 // it left too many pings in a row unanswered, see Manager.SetKeepalive
+)
+
+// Enum value maps for CloseReason.
+var (
+	CloseReason_name = map[int32]string{
+		0:  "UNKNOWN",
+		1:  "LEFT",
+		2:  "KICKED",
+		3:  "ROOM_CLOSED",
+		4:  "ICE_FAILED",
+		5:  "DETACHED",
+		6:  "JOIN_FAILED",
+		7:  "NODE_STOPPING",
+		8:  "UNREACHABLE",
+		9:  "JOB_ENDED",
+		10: "IDLE",
+		11: "NEGOTIATION_TIMEOUT",
+		12: "MOVED",
+		13: "KEEPALIVE_TIMEOUT",
+	}
+	CloseReason_value = map[string]int32{
+		"UNKNOWN":             0,
+		"LEFT":                1,
+		"KICKED":              2,
+		"ROOM_CLOSED":         3,
+		"ICE_FAILED":          4,
+		"DETACHED":            5,
+		"JOIN_FAILED":         6,
+		"NODE_STOPPING":       7,
+		"UNREACHABLE":         8,
+		"JOB_ENDED":           9,
+		"IDLE":                10,
+		"NEGOTIATION_TIMEOUT": 11,
+		"MOVED":               12,
+		"KEEPALIVE_TIMEOUT":   13,
+	}
+)
+
+func (x CloseReason) Enum() *CloseReason {
+	p := new(CloseReason)
+	*p = x
+	return p
+}
+
+func (x CloseReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CloseReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_proto_noir_proto_enumTypes[1].Descriptor()
+}
+
+func (CloseReason) Type() protoreflect.EnumType {
+	return &file_pkg_proto_noir_proto_enumTypes[1]
+}
+
+func (x CloseReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CloseReason.Descriptor instead.
+func (CloseReason) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{1}
+}
+
+// Role is what a peer may do in its room
+type Role int32
+
+const (
+	Role_PUBLISHER  Role = 0 // publish and subscribe, the default
+	Role_SUBSCRIBER Role = 1 // only receive
+	Role_MODERATOR  Role = 2 // publish, subscribe and run room admin like mute, close and kick
+)
+
+// Enum value maps for Role.
+var (
+	Role_name = map[int32]string{
+		0: "PUBLISHER",
+		1: "SUBSCRIBER",
+		2: "MODERATOR",
+	}
+	Role_value = map[string]int32{
+		"PUBLISHER":  0,
+		"SUBSCRIBER": 1,
+		"MODERATOR":  2,
+	}
+)
+
+func (x Role) Enum() *Role {
+	p := new(Role)
+	*p = x
+	return p
+}
+
+func (x Role) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Role) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_proto_noir_proto_enumTypes[2].Descriptor()
+}
+
+func (Role) Type() protoreflect.EnumType {
+	return &file_pkg_proto_noir_proto_enumTypes[2]
+}
+
+func (x Role) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Role.Descriptor instead.
+func (Role) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{2}
+}
+
+// What becomes of a room once its last peer left, see Manager.SetEmptyRoomPolicy
+type EmptyRoomPolicy int32
+
+const (
+	EmptyRoomPolicy_EMPTY_ROOM_DEFAULT EmptyRoomPolicy = 0 // the manager's
+	EmptyRoomPolicy_EMPTY_ROOM_BY_AGE  EmptyRoomPolicy = 1 // closed when it never expires, maxAgeSeconds -1, kept until it expires otherwise
+	EmptyRoomPolicy_EMPTY_ROOM_CLOSE   EmptyRoomPolicy = 2 // closed, its data deleted and its recordings stopped
+	EmptyRoomPolicy_EMPTY_ROOM_KEEP    EmptyRoomPolicy = 3 // kept for its grace, then closed unless a peer joined it again
+)
+
+// Enum value maps for EmptyRoomPolicy.
+var (
+	EmptyRoomPolicy_name = map[int32]string{
+		0: "EMPTY_ROOM_DEFAULT",
+		1: "EMPTY_ROOM_BY_AGE",
+		2: "EMPTY_ROOM_CLOSE",
+		3: "EMPTY_ROOM_KEEP",
+	}
+	EmptyRoomPolicy_value = map[string]int32{
+		"EMPTY_ROOM_DEFAULT": 0,
+		"EMPTY_ROOM_BY_AGE":  1,
+		"EMPTY_ROOM_CLOSE":   2,
+		"EMPTY_ROOM_KEEP":    3,
+	}
+)
+
+func (x EmptyRoomPolicy) Enum() *EmptyRoomPolicy {
+	p := new(EmptyRoomPolicy)
+	*p = x
+	return p
+}
+
+func (x EmptyRoomPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EmptyRoomPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_proto_noir_proto_enumTypes[3].Descriptor()
+}
+
+func (EmptyRoomPolicy) Type() protoreflect.EnumType {
+	return &file_pkg_proto_noir_proto_enumTypes[3]
+}
+
+func (x EmptyRoomPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EmptyRoomPolicy.Descriptor instead.
+func (EmptyRoomPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{3}
+}
+
+type RoomEvent_Type int32
+
+const (
+	RoomEvent_JOINED         RoomEvent_Type = 0
+	RoomEvent_LEFT           RoomEvent_Type = 1
+	RoomEvent_MUTED          RoomEvent_Type = 2
+	RoomEvent_UNMUTED        RoomEvent_Type = 3
+	RoomEvent_KICKED         RoomEvent_Type = 4 // sent before the LEFT of a peer a moderator kicked
+	RoomEvent_ACTIVE_SPEAKER RoomEvent_Type = 5 // pid became the room's dominant speaker, see Manager.EnableActiveSpeaker
+	RoomEvent_OPENED         RoomEvent_Type = 6 // the room was created, there is no pid
+	RoomEvent_CLOSED         RoomEvent_Type = 7 // the room was closed and its peers evicted, there is no pid
+	RoomEvent_PAUSED         RoomEvent_Type = 8 // a moderator, pid, or an admin paused the room's media, see Manager.PauseRoom
+	RoomEvent_RESUMED        RoomEvent_Type = 9
+	RoomEvent_OWNER_CHANGED  RoomEvent_Type = 10 // pid is the room's new owner, see Manager.TransferOwnership
+	RoomEvent_LAYERS         RoomEvent_Type = 11 // the layers pid publishes a track in changed, see layers
+)
+
+// Enum value maps for RoomEvent_Type.
+var (
+	RoomEvent_Type_name = map[int32]string{
+		0:  "JOINED",
+		1:  "LEFT",
+		2:  "MUTED",
+		3:  "UNMUTED",
+		4:  "KICKED",
+		5:  "ACTIVE_SPEAKER",
+		6:  "OPENED",
+		7:  "CLOSED",
+		8:  "PAUSED",
+		9:  "RESUMED",
+		10: "OWNER_CHANGED",
+		11: "LAYERS",
+	}
+	RoomEvent_Type_value = map[string]int32{
+		"JOINED":         0,
+		"LEFT":           1,
+		"MUTED":          2,
+		"UNMUTED":        3,
+		"KICKED":         4,
+		"ACTIVE_SPEAKER": 5,
+		"OPENED":         6,
+		"CLOSED":         7,
+		"PAUSED":         8,
+		"RESUMED":        9,
+		"OWNER_CHANGED":  10,
+		"LAYERS":         11,
+	}
+)
+
+func (x RoomEvent_Type) Enum() *RoomEvent_Type {
+	p := new(RoomEvent_Type)
+	*p = x
+	return p
+}
+
+func (x RoomEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RoomEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_proto_noir_proto_enumTypes[4].Descriptor()
+}
+
+func (RoomEvent_Type) Type() protoreflect.EnumType {
+	return &file_pkg_proto_noir_proto_enumTypes[4]
+}
+
+func (x RoomEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RoomEvent_Type.Descriptor instead.
+func (RoomEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{4, 0}
+}
+
+type ConnectionQuality_Level int32
+
+const (
+	ConnectionQuality_GOOD ConnectionQuality_Level = 0
+	ConnectionQuality_FAIR ConnectionQuality_Level = 1
+	ConnectionQuality_POOR ConnectionQuality_Level = 2
+)
+
+// Enum value maps for ConnectionQuality_Level.
+var (
+	ConnectionQuality_Level_name = map[int32]string{
+		0: "GOOD",
+		1: "FAIR",
+		2: "POOR",
+	}
+	ConnectionQuality_Level_value = map[string]int32{
+		"GOOD": 0,
+		"FAIR": 1,
+		"POOR": 2,
+	}
+)
+
+func (x ConnectionQuality_Level) Enum() *ConnectionQuality_Level {
+	p := new(ConnectionQuality_Level)
+	*p = x
+	return p
+}
+
+func (x ConnectionQuality_Level) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConnectionQuality_Level) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_proto_noir_proto_enumTypes[5].Descriptor()
+}
+
+func (ConnectionQuality_Level) Type() protoreflect.EnumType {
+	return &file_pkg_proto_noir_proto_enumTypes[5]
+}
+
+func (x ConnectionQuality_Level) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConnectionQuality_Level.Descriptor instead.
+func (ConnectionQuality_Level) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{51, 0}
+}
+
+type Announcement_Severity int32
+
+const (
+	Announcement_INFO     Announcement_Severity = 0
+	Announcement_WARNING  Announcement_Severity = 1
+	Announcement_CRITICAL Announcement_Severity = 2
+)
+
+// Enum value maps for Announcement_Severity.
+var (
+	Announcement_Severity_name = map[int32]string{
+		0: "INFO",
+		1: "WARNING",
+		2: "CRITICAL",
+	}
+	Announcement_Severity_value = map[string]int32{
+		"INFO":     0,
+		"WARNING":  1,
+		"CRITICAL": 2,
+	}
+)
+
+func (x Announcement_Severity) Enum() *Announcement_Severity {
+	p := new(Announcement_Severity)
+	*p = x
+	return p
+}
+
+func (x Announcement_Severity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Announcement_Severity) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_proto_noir_proto_enumTypes[6].Descriptor()
+}
+
+func (Announcement_Severity) Type() protoreflect.EnumType {
+	return &file_pkg_proto_noir_proto_enumTypes[6]
+}
+
+func (x Announcement_Severity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Announcement_Severity.Descriptor instead.
+func (Announcement_Severity) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{52, 0}
+}
+
+// how the bandwidth estimates of a track's subscribers bound what its publisher sends
+type RTCPPolicy_Aggregation int32
+
+const (
+	RTCPPolicy_NONE      RTCPPolicy_Aggregation = 0 // ion-sfu drops them
+	RTCPPolicy_MINIMUM   RTCPPolicy_Aggregation = 1 // the weakest subscriber of the track
+	RTCPPolicy_PER_LAYER RTCPPolicy_Aggregation = 2 // the weakest subscriber of each simulcast layer, summed
+)
+
+// Enum value maps for RTCPPolicy_Aggregation.
+var (
+	RTCPPolicy_Aggregation_name = map[int32]string{
+		0: "NONE",
+		1: "MINIMUM",
+		2: "PER_LAYER",
+	}
+	RTCPPolicy_Aggregation_value = map[string]int32{
+		"NONE":      0,
+		"MINIMUM":   1,
+		"PER_LAYER": 2,
+	}
+)
+
+func (x RTCPPolicy_Aggregation) Enum() *RTCPPolicy_Aggregation {
+	p := new(RTCPPolicy_Aggregation)
+	*p = x
+	return p
+}
+
+func (x RTCPPolicy_Aggregation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RTCPPolicy_Aggregation) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_proto_noir_proto_enumTypes[7].Descriptor()
+}
+
+func (RTCPPolicy_Aggregation) Type() protoreflect.EnumType {
+	return &file_pkg_proto_noir_proto_enumTypes[7]
+}
+
+func (x RTCPPolicy_Aggregation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RTCPPolicy_Aggregation.Descriptor instead.
+func (RTCPPolicy_Aggregation) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{61, 0}
+}
+
+type SignalError_Code int32
+
+const (
+	SignalError_UNKNOWN             SignalError_Code = 0
+	SignalError_ROOM_NOT_FOUND      SignalError_Code = 1
+	SignalError_ROOM_FULL           SignalError_Code = 2
+	SignalError_PEER_NOT_FOUND      SignalError_Code = 3
+	SignalError_TRACK_NOT_FOUND     SignalError_Code = 4
+	SignalError_NOT_SIMULCAST       SignalError_Code = 5
+	SignalError_UNAUTHORIZED        SignalError_Code = 6
+	SignalError_FILE_NOT_FOUND      SignalError_Code = 7
+	SignalError_UNSUPPORTED_CODEC   SignalError_Code = 8
+	SignalError_ROOM_CLOSED         SignalError_Code = 9
+	SignalError_JOIN_FAILED         SignalError_Code = 10 // the sfu could not set up the peer, usually a bad offer
+	SignalError_NEGOTIATION_FAILED  SignalError_Code = 11 // the sfu could not answer a renegotiation offer
+	SignalError_OFFER_REJECTED      SignalError_Code = 12 // the offer breaks the room's rules, e.g. a second channel publisher
+	SignalError_INTERNAL            SignalError_Code = 13
+	SignalError_NO_COMPATIBLE_CODEC SignalError_Code = 14 // none of the offered codecs of an audio or video section are allowed
+	SignalError_RATE_LIMITED        SignalError_Code = 15 // too many joins, try again after retryAfterMs
+	SignalError_SUBSCRIPTION_LIMIT  SignalError_Code = 16 // subscribing would pass the room's maxSubscribedTracks, unsubscribe first
+	SignalError_VERSION_MISMATCH    SignalError_Code = 17 // the request's protocol major is not supported, see Manager.SupportedVersions
+	SignalError_INVALID_REQUEST     SignalError_Code = 18 // a required field is missing, see ValidateRequest
+	SignalError_NODE_AT_CAPACITY    SignalError_Code = 19 // the node runs its Manager.MaxPeers, join again to be placed elsewhere
+	SignalError_NODE_DRAINING       SignalError_Code = 20 // the worker is draining to be stopped, join again to be placed elsewhere
+	SignalError_OFFER_TOO_COMPLEX   SignalError_Code = 21 // the join's offer is over the room's SDPLimits
+	SignalError_PEER_ID_TAKEN       SignalError_Code = 22 // another connected peer has the join's pid, join as another or resume it
+	SignalError_NODE_BUSY           SignalError_Code = 23 // the node runs its Manager.MaxPeerGoroutines, join again to be placed elsewhere
+	SignalError_ALREADY_CONNECTED   SignalError_Code = 24 // a peer is connected as the join's pid and the ReconnectPolicy is reject, resume or leave it first
+)
+
+// Enum value maps for SignalError_Code.
+var (
+	SignalError_Code_name = map[int32]string{
+		0:  "UNKNOWN",
+		1:  "ROOM_NOT_FOUND",
+		2:  "ROOM_FULL",
+		3:  "PEER_NOT_FOUND",
+		4:  "TRACK_NOT_FOUND",
+		5:  "NOT_SIMULCAST",
+		6:  "UNAUTHORIZED",
+		7:  "FILE_NOT_FOUND",
+		8:  "UNSUPPORTED_CODEC",
+		9:  "ROOM_CLOSED",
+		10: "JOIN_FAILED",
+		11: "NEGOTIATION_FAILED",
+		12: "OFFER_REJECTED",
+		13: "INTERNAL",
+		14: "NO_COMPATIBLE_CODEC",
+		15: "RATE_LIMITED",
+		16: "SUBSCRIPTION_LIMIT",
+		17: "VERSION_MISMATCH",
+		18: "INVALID_REQUEST",
+		19: "NODE_AT_CAPACITY",
+		20: "NODE_DRAINING",
+		21: "OFFER_TOO_COMPLEX",
+		22: "PEER_ID_TAKEN",
+		23: "NODE_BUSY",
+		24: "ALREADY_CONNECTED",
+	}
+	SignalError_Code_value = map[string]int32{
+		"UNKNOWN":             0,
+		"ROOM_NOT_FOUND":      1,
+		"ROOM_FULL":           2,
+		"PEER_NOT_FOUND":      3,
+		"TRACK_NOT_FOUND":     4,
+		"NOT_SIMULCAST":       5,
+		"UNAUTHORIZED":        6,
+		"FILE_NOT_FOUND":      7,
+		"UNSUPPORTED_CODEC":   8,
+		"ROOM_CLOSED":         9,
+		"JOIN_FAILED":         10,
+		"NEGOTIATION_FAILED":  11,
+		"OFFER_REJECTED":      12,
+		"INTERNAL":            13,
+		"NO_COMPATIBLE_CODEC": 14,
+		"RATE_LIMITED":        15,
+		"SUBSCRIPTION_LIMIT":  16,
+		"VERSION_MISMATCH":    17,
+		"INVALID_REQUEST":     18,
+		"NODE_AT_CAPACITY":    19,
+		"NODE_DRAINING":       20,
+		"OFFER_TOO_COMPLEX":   21,
+		"PEER_ID_TAKEN":       22,
+		"NODE_BUSY":           23,
+		"ALREADY_CONNECTED":   24,
+	}
+)
+
+func (x SignalError_Code) Enum() *SignalError_Code {
+	p := new(SignalError_Code)
+	*p = x
+	return p
+}
+
+func (x SignalError_Code) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SignalError_Code) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_proto_noir_proto_enumTypes[8].Descriptor()
+}
+
+func (SignalError_Code) Type() protoreflect.EnumType {
+	return &file_pkg_proto_noir_proto_enumTypes[8]
+}
+
+func (x SignalError_Code) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SignalError_Code.Descriptor instead.
+func (SignalError_Code) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{63, 0}
+}
+
 type Trickle_Target int32
 
 const (
@@ -51,11 +626,11 @@ func (x Trickle_Target) String() string {
 }
 
 func (Trickle_Target) Descriptor() protoreflect.EnumDescriptor {
-	return file_pkg_proto_noir_proto_enumTypes[0].Descriptor()
+	return file_pkg_proto_noir_proto_enumTypes[9].Descriptor()
 }
 
 func (Trickle_Target) Type() protoreflect.EnumType {
-	return &file_pkg_proto_noir_proto_enumTypes[0]
+	return &file_pkg_proto_noir_proto_enumTypes[9]
 }
 
 func (x Trickle_Target) Number() protoreflect.EnumNumber {
@@ -64,7 +639,7 @@ func (x Trickle_Target) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Trickle_Target.Descriptor instead.
 func (Trickle_Target) EnumDescriptor() ([]byte, []int) {
-	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{21, 0}
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{70, 0}
 }
 
 type JobData_JobStatus int32
@@ -103,11 +678,11 @@ func (x JobData_JobStatus) String() string {
 }
 
 func (JobData_JobStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_pkg_proto_noir_proto_enumTypes[1].Descriptor()
+	return file_pkg_proto_noir_proto_enumTypes[10].Descriptor()
 }
 
 func (JobData_JobStatus) Type() protoreflect.EnumType {
-	return &file_pkg_proto_noir_proto_enumTypes[1]
+	return &file_pkg_proto_noir_proto_enumTypes[10]
 }
 
 func (x JobData_JobStatus) Number() protoreflect.EnumNumber {
@@ -116,7 +691,7 @@ func (x JobData_JobStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use JobData_JobStatus.Descriptor instead.
 func (JobData_JobStatus) EnumDescriptor() ([]byte, []int) {
-	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{28, 0}
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{83, 0}
 }
 
 // GRPC ADMIN API
@@ -218,6 +793,8 @@ type NoirRequest struct {
 	//	*NoirRequest_Admin
 	Command isNoirRequest_Command `protobuf_oneof:"command"`
 	AdminID string                `protobuf:"bytes,6,opt,name=adminID,proto3" json:"adminID,omitempty"`
+	Version string                `protobuf:"bytes,7,opt,name=version,proto3" json:"version,omitempty"` // major.minor of the protocol the sender speaks, see ProtocolVersion
+	TraceID string                `protobuf:"bytes,8,opt,name=traceID,proto3" json:"traceID,omitempty"` // follows the request and its replies through the queues, generated when empty
 }
 
 func (x *NoirRequest) Reset() {
@@ -301,6 +878,20 @@ func (x *NoirRequest) GetAdminID() string {
 	return ""
 }
 
+func (x *NoirRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *NoirRequest) GetTraceID() string {
+	if x != nil {
+		return x.TraceID
+	}
+	return ""
+}
+
 type isNoirRequest_Command interface {
 	isNoirRequest_Command()
 }
@@ -328,7 +919,12 @@ type NoirReply struct {
 	//	*NoirReply_Signal
 	//	*NoirReply_Admin
 	//	*NoirReply_Error
-	Command isNoirReply_Command `protobuf_oneof:"command"`
+	//	*NoirReply_Event
+	Command     isNoirReply_Command `protobuf_oneof:"command"`
+	Version     string              `protobuf:"bytes,7,opt,name=version,proto3" json:"version,omitempty"`                                // major.minor of the protocol the reply is framed in
+	Compression Compression         `protobuf:"varint,8,opt,name=compression,proto3,enum=noir.Compression" json:"compression,omitempty"` // set when the reply is sent compressed, in compressed
+	Compressed  []byte              `protobuf:"bytes,9,opt,name=compressed,proto3" json:"compressed,omitempty"`                          // the whole reply, marshaled then compressed, see UnmarshalReply
+	TraceID     string              `protobuf:"bytes,10,opt,name=traceID,proto3" json:"traceID,omitempty"`                               // of the request replied to, empty for replies to nothing
 }
 
 func (x *NoirReply) Reset() {
@@ -405,12 +1001,47 @@ func (x *NoirReply) GetError() string {
 	return ""
 }
 
-type isNoirReply_Command interface {
-	isNoirReply_Command()
+func (x *NoirReply) GetEvent() *RoomEvent {
+	if x, ok := x.GetCommand().(*NoirReply_Event); ok {
+		return x.Event
+	}
+	return nil
 }
 
-type NoirReply_Signal struct {
-	Signal *SignalReply `protobuf:"bytes,3,opt,name=signal,proto3,oneof"`
+func (x *NoirReply) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *NoirReply) GetCompression() Compression {
+	if x != nil {
+		return x.Compression
+	}
+	return Compression_NONE
+}
+
+func (x *NoirReply) GetCompressed() []byte {
+	if x != nil {
+		return x.Compressed
+	}
+	return nil
+}
+
+func (x *NoirReply) GetTraceID() string {
+	if x != nil {
+		return x.TraceID
+	}
+	return ""
+}
+
+type isNoirReply_Command interface {
+	isNoirReply_Command()
+}
+
+type NoirReply_Signal struct {
+	Signal *SignalReply `protobuf:"bytes,3,opt,name=signal,proto3,oneof"`
 }
 
 type NoirReply_Admin struct {
@@ -421,12 +1052,122 @@ type NoirReply_Error struct {
 	Error string `protobuf:"bytes,5,opt,name=error,proto3,oneof"`
 }
 
+type NoirReply_Event struct {
+	Event *RoomEvent `protobuf:"bytes,6,opt,name=event,proto3,oneof"`
+}
+
 func (*NoirReply_Signal) isNoirReply_Command() {}
 
 func (*NoirReply_Admin) isNoirReply_Command() {}
 
 func (*NoirReply_Error) isNoirReply_Command() {}
 
+func (*NoirReply_Event) isNoirReply_Command() {}
+
+// RoomEvent is published to everyone in a room on KeyTopicRoomEvents
+type RoomEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type       RoomEvent_Type       `protobuf:"varint,1,opt,name=type,proto3,enum=noir.RoomEvent_Type" json:"type,omitempty"`
+	Pid        string               `protobuf:"bytes,2,opt,name=pid,proto3" json:"pid,omitempty"`
+	Sid        string               `protobuf:"bytes,3,opt,name=sid,proto3" json:"sid,omitempty"`
+	At         *timestamp.Timestamp `protobuf:"bytes,4,opt,name=at,proto3" json:"at,omitempty"`
+	Kind       string               `protobuf:"bytes,5,opt,name=kind,proto3" json:"kind,omitempty"`                            // audio or video, for MUTED and UNMUTED
+	AudioLevel int32                `protobuf:"varint,6,opt,name=audioLevel,proto3" json:"audioLevel,omitempty"`               // for ACTIVE_SPEAKER, in -dBov: 0 is the loudest and 127 silence
+	Reason     CloseReason          `protobuf:"varint,7,opt,name=reason,proto3,enum=noir.CloseReason" json:"reason,omitempty"` // for LEFT, why the peer was disconnected
+	Layers     *TrackLayers         `protobuf:"bytes,8,opt,name=layers,proto3" json:"layers,omitempty"`                        // for LAYERS
+}
+
+func (x *RoomEvent) Reset() {
+	*x = RoomEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RoomEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomEvent) ProtoMessage() {}
+
+func (x *RoomEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomEvent.ProtoReflect.Descriptor instead.
+func (*RoomEvent) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RoomEvent) GetType() RoomEvent_Type {
+	if x != nil {
+		return x.Type
+	}
+	return RoomEvent_JOINED
+}
+
+func (x *RoomEvent) GetPid() string {
+	if x != nil {
+		return x.Pid
+	}
+	return ""
+}
+
+func (x *RoomEvent) GetSid() string {
+	if x != nil {
+		return x.Sid
+	}
+	return ""
+}
+
+func (x *RoomEvent) GetAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *RoomEvent) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *RoomEvent) GetAudioLevel() int32 {
+	if x != nil {
+		return x.AudioLevel
+	}
+	return 0
+}
+
+func (x *RoomEvent) GetReason() CloseReason {
+	if x != nil {
+		return x.Reason
+	}
+	return CloseReason_UNKNOWN
+}
+
+func (x *RoomEvent) GetLayers() *TrackLayers {
+	if x != nil {
+		return x.Layers
+	}
+	return nil
+}
+
 // ****************************************************
 //Admin Commands
 //***************************************************
@@ -439,13 +1180,15 @@ type AdminRequest struct {
 	//	*AdminRequest_RoomAdmin
 	//	*AdminRequest_RoomCount
 	//	*AdminRequest_RoomList
+	//	*AdminRequest_RoomStats
+	//	*AdminRequest_WorkerState
 	Payload isAdminRequest_Payload `protobuf_oneof:"payload"`
 }
 
 func (x *AdminRequest) Reset() {
 	*x = AdminRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_proto_noir_proto_msgTypes[4]
+		mi := &file_pkg_proto_noir_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -458,7 +1201,7 @@ func (x *AdminRequest) String() string {
 func (*AdminRequest) ProtoMessage() {}
 
 func (x *AdminRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_noir_proto_msgTypes[4]
+	mi := &file_pkg_proto_noir_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -471,7 +1214,7 @@ func (x *AdminRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AdminRequest.ProtoReflect.Descriptor instead.
 func (*AdminRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{4}
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{5}
 }
 
 func (m *AdminRequest) GetPayload() isAdminRequest_Payload {
@@ -502,6 +1245,20 @@ func (x *AdminRequest) GetRoomList() *RoomListRequest {
 	return nil
 }
 
+func (x *AdminRequest) GetRoomStats() *RoomStatsRequest {
+	if x, ok := x.GetPayload().(*AdminRequest_RoomStats); ok {
+		return x.RoomStats
+	}
+	return nil
+}
+
+func (x *AdminRequest) GetWorkerState() *WorkerStateRequest {
+	if x, ok := x.GetPayload().(*AdminRequest_WorkerState); ok {
+		return x.WorkerState
+	}
+	return nil
+}
+
 type isAdminRequest_Payload interface {
 	isAdminRequest_Payload()
 }
@@ -518,12 +1275,24 @@ type AdminRequest_RoomList struct {
 	RoomList *RoomListRequest `protobuf:"bytes,3,opt,name=roomList,proto3,oneof"`
 }
 
+type AdminRequest_RoomStats struct {
+	RoomStats *RoomStatsRequest `protobuf:"bytes,4,opt,name=roomStats,proto3,oneof"`
+}
+
+type AdminRequest_WorkerState struct {
+	WorkerState *WorkerStateRequest `protobuf:"bytes,5,opt,name=workerState,proto3,oneof"`
+}
+
 func (*AdminRequest_RoomAdmin) isAdminRequest_Payload() {}
 
 func (*AdminRequest_RoomCount) isAdminRequest_Payload() {}
 
 func (*AdminRequest_RoomList) isAdminRequest_Payload() {}
 
+func (*AdminRequest_RoomStats) isAdminRequest_Payload() {}
+
+func (*AdminRequest_WorkerState) isAdminRequest_Payload() {}
+
 type AdminReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -534,13 +1303,15 @@ type AdminReply struct {
 	//	*AdminReply_RoomAdmin
 	//	*AdminReply_RoomCount
 	//	*AdminReply_RoomList
+	//	*AdminReply_RoomStats
+	//	*AdminReply_WorkerState
 	Payload isAdminReply_Payload `protobuf_oneof:"payload"`
 }
 
 func (x *AdminReply) Reset() {
 	*x = AdminReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_proto_noir_proto_msgTypes[5]
+		mi := &file_pkg_proto_noir_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -553,7 +1324,7 @@ func (x *AdminReply) String() string {
 func (*AdminReply) ProtoMessage() {}
 
 func (x *AdminReply) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_noir_proto_msgTypes[5]
+	mi := &file_pkg_proto_noir_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -566,7 +1337,7 @@ func (x *AdminReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AdminReply.ProtoReflect.Descriptor instead.
 func (*AdminReply) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{5}
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{6}
 }
 
 func (m *AdminReply) GetPayload() isAdminReply_Payload {
@@ -604,6 +1375,20 @@ func (x *AdminReply) GetRoomList() *RoomListReply {
 	return nil
 }
 
+func (x *AdminReply) GetRoomStats() *RoomStatsReply {
+	if x, ok := x.GetPayload().(*AdminReply_RoomStats); ok {
+		return x.RoomStats
+	}
+	return nil
+}
+
+func (x *AdminReply) GetWorkerState() *WorkerStateReply {
+	if x, ok := x.GetPayload().(*AdminReply_WorkerState); ok {
+		return x.WorkerState
+	}
+	return nil
+}
+
 type isAdminReply_Payload interface {
 	isAdminReply_Payload()
 }
@@ -624,6 +1409,14 @@ type AdminReply_RoomList struct {
 	RoomList *RoomListReply `protobuf:"bytes,4,opt,name=roomList,proto3,oneof"`
 }
 
+type AdminReply_RoomStats struct {
+	RoomStats *RoomStatsReply `protobuf:"bytes,5,opt,name=roomStats,proto3,oneof"`
+}
+
+type AdminReply_WorkerState struct {
+	WorkerState *WorkerStateReply `protobuf:"bytes,6,opt,name=workerState,proto3,oneof"`
+}
+
 func (*AdminReply_Error) isAdminReply_Payload() {}
 
 func (*AdminReply_RoomAdmin) isAdminReply_Payload() {}
@@ -632,6 +1425,10 @@ func (*AdminReply_RoomCount) isAdminReply_Payload() {}
 
 func (*AdminReply_RoomList) isAdminReply_Payload() {}
 
+func (*AdminReply_RoomStats) isAdminReply_Payload() {}
+
+func (*AdminReply_WorkerState) isAdminReply_Payload() {}
+
 type RoomCountRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -641,7 +1438,7 @@ type RoomCountRequest struct {
 func (x *RoomCountRequest) Reset() {
 	*x = RoomCountRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_proto_noir_proto_msgTypes[6]
+		mi := &file_pkg_proto_noir_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -654,7 +1451,7 @@ func (x *RoomCountRequest) String() string {
 func (*RoomCountRequest) ProtoMessage() {}
 
 func (x *RoomCountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_noir_proto_msgTypes[6]
+	mi := &file_pkg_proto_noir_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -667,7 +1464,7 @@ func (x *RoomCountRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RoomCountRequest.ProtoReflect.Descriptor instead.
 func (*RoomCountRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{6}
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{7}
 }
 
 type RoomCountReply struct {
@@ -681,7 +1478,7 @@ type RoomCountReply struct {
 func (x *RoomCountReply) Reset() {
 	*x = RoomCountReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_proto_noir_proto_msgTypes[7]
+		mi := &file_pkg_proto_noir_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -694,7 +1491,7 @@ func (x *RoomCountReply) String() string {
 func (*RoomCountReply) ProtoMessage() {}
 
 func (x *RoomCountReply) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_noir_proto_msgTypes[7]
+	mi := &file_pkg_proto_noir_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -707,7 +1504,7 @@ func (x *RoomCountReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RoomCountReply.ProtoReflect.Descriptor instead.
 func (*RoomCountReply) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{7}
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *RoomCountReply) GetResult() int64 {
@@ -726,7 +1523,7 @@ type RoomListRequest struct {
 func (x *RoomListRequest) Reset() {
 	*x = RoomListRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_proto_noir_proto_msgTypes[8]
+		mi := &file_pkg_proto_noir_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -739,7 +1536,7 @@ func (x *RoomListRequest) String() string {
 func (*RoomListRequest) ProtoMessage() {}
 
 func (x *RoomListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_noir_proto_msgTypes[8]
+	mi := &file_pkg_proto_noir_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -752,7 +1549,7 @@ func (x *RoomListRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RoomListRequest.ProtoReflect.Descriptor instead.
 func (*RoomListRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{8}
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{9}
 }
 
 type RoomListEntry struct {
@@ -767,7 +1564,7 @@ type RoomListEntry struct {
 func (x *RoomListEntry) Reset() {
 	*x = RoomListEntry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_proto_noir_proto_msgTypes[9]
+		mi := &file_pkg_proto_noir_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -780,7 +1577,7 @@ func (x *RoomListEntry) String() string {
 func (*RoomListEntry) ProtoMessage() {}
 
 func (x *RoomListEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_noir_proto_msgTypes[9]
+	mi := &file_pkg_proto_noir_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -793,7 +1590,7 @@ func (x *RoomListEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RoomListEntry.ProtoReflect.Descriptor instead.
 func (*RoomListEntry) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{9}
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *RoomListEntry) GetId() string {
@@ -822,7 +1619,7 @@ type RoomListReply struct {
 func (x *RoomListReply) Reset() {
 	*x = RoomListReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_proto_noir_proto_msgTypes[10]
+		mi := &file_pkg_proto_noir_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -835,7 +1632,7 @@ func (x *RoomListReply) String() string {
 func (*RoomListReply) ProtoMessage() {}
 
 func (x *RoomListReply) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_noir_proto_msgTypes[10]
+	mi := &file_pkg_proto_noir_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -848,7 +1645,7 @@ func (x *RoomListReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RoomListReply.ProtoReflect.Descriptor instead.
 func (*RoomListReply) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{10}
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *RoomListReply) GetCount() int64 {
@@ -865,35 +1662,32 @@ func (x *RoomListReply) GetResult() []*RoomListEntry {
 	return nil
 }
 
-type RoomAdminRequest struct {
+// RoomStatsRequest asks a worker for the stats of its peers in a room, see Manager.GetRoomStats
+type RoomStatsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	RoomID string `protobuf:"bytes,1,opt,name=roomID,proto3" json:"roomID,omitempty"`
-	// Types that are assignable to Method:
-	//	*RoomAdminRequest_CreateRoom
-	//	*RoomAdminRequest_RoomJob
-	Method isRoomAdminRequest_Method `protobuf_oneof:"method"`
 }
 
-func (x *RoomAdminRequest) Reset() {
-	*x = RoomAdminRequest{}
+func (x *RoomStatsRequest) Reset() {
+	*x = RoomStatsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_proto_noir_proto_msgTypes[11]
+		mi := &file_pkg_proto_noir_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RoomAdminRequest) String() string {
+func (x *RoomStatsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RoomAdminRequest) ProtoMessage() {}
+func (*RoomStatsRequest) ProtoMessage() {}
 
-func (x *RoomAdminRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_noir_proto_msgTypes[11]
+func (x *RoomStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -904,85 +1698,51 @@ func (x *RoomAdminRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RoomAdminRequest.ProtoReflect.Descriptor instead.
-func (*RoomAdminRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use RoomStatsRequest.ProtoReflect.Descriptor instead.
+func (*RoomStatsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *RoomAdminRequest) GetRoomID() string {
+func (x *RoomStatsRequest) GetRoomID() string {
 	if x != nil {
 		return x.RoomID
 	}
 	return ""
 }
 
-func (m *RoomAdminRequest) GetMethod() isRoomAdminRequest_Method {
-	if m != nil {
-		return m.Method
-	}
-	return nil
-}
-
-func (x *RoomAdminRequest) GetCreateRoom() *CreateRoomRequest {
-	if x, ok := x.GetMethod().(*RoomAdminRequest_CreateRoom); ok {
-		return x.CreateRoom
-	}
-	return nil
-}
-
-func (x *RoomAdminRequest) GetRoomJob() *RoomJobRequest {
-	if x, ok := x.GetMethod().(*RoomAdminRequest_RoomJob); ok {
-		return x.RoomJob
-	}
-	return nil
-}
-
-type isRoomAdminRequest_Method interface {
-	isRoomAdminRequest_Method()
-}
-
-type RoomAdminRequest_CreateRoom struct {
-	CreateRoom *CreateRoomRequest `protobuf:"bytes,2,opt,name=createRoom,proto3,oneof"`
-}
-
-type RoomAdminRequest_RoomJob struct {
-	RoomJob *RoomJobRequest `protobuf:"bytes,3,opt,name=roomJob,proto3,oneof"`
-}
-
-func (*RoomAdminRequest_CreateRoom) isRoomAdminRequest_Method() {}
-
-func (*RoomAdminRequest_RoomJob) isRoomAdminRequest_Method() {}
-
-type RoomAdminReply struct {
+type PeerStats struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	RoomID string `protobuf:"bytes,1,opt,name=roomID,proto3" json:"roomID,omitempty"`
-	// Types that are assignable to Payload:
-	//	*RoomAdminReply_Error
-	//	*RoomAdminReply_CreateRoom
-	//	*RoomAdminReply_RoomJob
-	Payload isRoomAdminReply_Payload `protobuf_oneof:"payload"`
+	Pid              string         `protobuf:"bytes,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	BytesSent        uint64         `protobuf:"varint,2,opt,name=bytesSent,proto3" json:"bytesSent,omitempty"` // by the sfu to the peer, over both of its transports
+	BytesReceived    uint64         `protobuf:"varint,3,opt,name=bytesReceived,proto3" json:"bytesReceived,omitempty"`
+	PacketsReceived  uint64         `protobuf:"varint,4,opt,name=packetsReceived,proto3" json:"packetsReceived,omitempty"` // rtp from the peer's published tracks
+	PacketsLost      uint64         `protobuf:"varint,5,opt,name=packetsLost,proto3" json:"packetsLost,omitempty"`
+	PublishedTracks  int32          `protobuf:"varint,6,opt,name=publishedTracks,proto3" json:"publishedTracks,omitempty"`
+	SubscribedTracks int32          `protobuf:"varint,7,opt,name=subscribedTracks,proto3" json:"subscribedTracks,omitempty"`
+	Layers           []*TrackLayers `protobuf:"bytes,8,rep,name=layers,proto3" json:"layers,omitempty"`                // of its published video tracks, as last reported
+	TargetBitrate    uint64         `protobuf:"varint,9,opt,name=targetBitrate,proto3" json:"targetBitrate,omitempty"` // bits per second its subscribers' estimates allow it to publish, 0 when not aggregated
 }
 
-func (x *RoomAdminReply) Reset() {
-	*x = RoomAdminReply{}
+func (x *PeerStats) Reset() {
+	*x = PeerStats{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_proto_noir_proto_msgTypes[12]
+		mi := &file_pkg_proto_noir_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RoomAdminReply) String() string {
+func (x *PeerStats) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RoomAdminReply) ProtoMessage() {}
+func (*PeerStats) ProtoMessage() {}
 
-func (x *RoomAdminReply) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_noir_proto_msgTypes[12]
+func (x *PeerStats) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -993,125 +1753,86 @@ func (x *RoomAdminReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RoomAdminReply.ProtoReflect.Descriptor instead.
-func (*RoomAdminReply) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use PeerStats.ProtoReflect.Descriptor instead.
+func (*PeerStats) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *RoomAdminReply) GetRoomID() string {
+func (x *PeerStats) GetPid() string {
 	if x != nil {
-		return x.RoomID
+		return x.Pid
 	}
 	return ""
 }
 
-func (m *RoomAdminReply) GetPayload() isRoomAdminReply_Payload {
-	if m != nil {
-		return m.Payload
+func (x *PeerStats) GetBytesSent() uint64 {
+	if x != nil {
+		return x.BytesSent
 	}
-	return nil
+	return 0
 }
 
-func (x *RoomAdminReply) GetError() string {
-	if x, ok := x.GetPayload().(*RoomAdminReply_Error); ok {
-		return x.Error
+func (x *PeerStats) GetBytesReceived() uint64 {
+	if x != nil {
+		return x.BytesReceived
 	}
-	return ""
+	return 0
 }
 
-func (x *RoomAdminReply) GetCreateRoom() *CreateRoomReply {
-	if x, ok := x.GetPayload().(*RoomAdminReply_CreateRoom); ok {
-		return x.CreateRoom
+func (x *PeerStats) GetPacketsReceived() uint64 {
+	if x != nil {
+		return x.PacketsReceived
 	}
-	return nil
+	return 0
 }
 
-func (x *RoomAdminReply) GetRoomJob() *RoomJobReply {
-	if x, ok := x.GetPayload().(*RoomAdminReply_RoomJob); ok {
-		return x.RoomJob
+func (x *PeerStats) GetPacketsLost() uint64 {
+	if x != nil {
+		return x.PacketsLost
 	}
-	return nil
-}
-
-type isRoomAdminReply_Payload interface {
-	isRoomAdminReply_Payload()
-}
-
-type RoomAdminReply_Error struct {
-	Error string `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
-}
-
-type RoomAdminReply_CreateRoom struct {
-	CreateRoom *CreateRoomReply `protobuf:"bytes,3,opt,name=createRoom,proto3,oneof"`
-}
-
-type RoomAdminReply_RoomJob struct {
-	RoomJob *RoomJobReply `protobuf:"bytes,4,opt,name=roomJob,proto3,oneof"`
-}
-
-func (*RoomAdminReply_Error) isRoomAdminReply_Payload() {}
-
-func (*RoomAdminReply_CreateRoom) isRoomAdminReply_Payload() {}
-
-func (*RoomAdminReply_RoomJob) isRoomAdminReply_Payload() {}
-
-type CreateRoomRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Options *RoomOptions `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	return 0
 }
 
-func (x *CreateRoomRequest) Reset() {
-	*x = CreateRoomRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_proto_noir_proto_msgTypes[13]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *PeerStats) GetPublishedTracks() int32 {
+	if x != nil {
+		return x.PublishedTracks
 	}
+	return 0
 }
 
-func (x *CreateRoomRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*CreateRoomRequest) ProtoMessage() {}
-
-func (x *CreateRoomRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_noir_proto_msgTypes[13]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *PeerStats) GetSubscribedTracks() int32 {
+	if x != nil {
+		return x.SubscribedTracks
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use CreateRoomRequest.ProtoReflect.Descriptor instead.
-func (*CreateRoomRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{13}
+func (x *PeerStats) GetLayers() []*TrackLayers {
+	if x != nil {
+		return x.Layers
+	}
+	return nil
 }
 
-func (x *CreateRoomRequest) GetOptions() *RoomOptions {
+func (x *PeerStats) GetTargetBitrate() uint64 {
 	if x != nil {
-		return x.Options
+		return x.TargetBitrate
 	}
-	return nil
+	return 0
 }
 
-type CreateRoomReply struct {
+type RoomStatsReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Options *RoomOptions `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	RoomID string       `protobuf:"bytes,1,opt,name=roomID,proto3" json:"roomID,omitempty"`
+	NodeID string       `protobuf:"bytes,2,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	Peers  []*PeerStats `protobuf:"bytes,3,rep,name=peers,proto3" json:"peers,omitempty"`
 }
 
-func (x *CreateRoomReply) Reset() {
-	*x = CreateRoomReply{}
+func (x *RoomStatsReply) Reset() {
+	*x = RoomStatsReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_proto_noir_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1119,13 +1840,13 @@ func (x *CreateRoomReply) Reset() {
 	}
 }
 
-func (x *CreateRoomReply) String() string {
+func (x *RoomStatsReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateRoomReply) ProtoMessage() {}
+func (*RoomStatsReply) ProtoMessage() {}
 
-func (x *CreateRoomReply) ProtoReflect() protoreflect.Message {
+func (x *RoomStatsReply) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_proto_noir_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1137,30 +1858,41 @@ func (x *CreateRoomReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateRoomReply.ProtoReflect.Descriptor instead.
-func (*CreateRoomReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use RoomStatsReply.ProtoReflect.Descriptor instead.
+func (*RoomStatsReply) Descriptor() ([]byte, []int) {
 	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *CreateRoomReply) GetOptions() *RoomOptions {
+func (x *RoomStatsReply) GetRoomID() string {
 	if x != nil {
-		return x.Options
+		return x.RoomID
+	}
+	return ""
+}
+
+func (x *RoomStatsReply) GetNodeID() string {
+	if x != nil {
+		return x.NodeID
+	}
+	return ""
+}
+
+func (x *RoomStatsReply) GetPeers() []*PeerStats {
+	if x != nil {
+		return x.Peers
 	}
 	return nil
 }
 
-type RoomJobRequest struct {
+// WorkerStateRequest asks a worker what it runs, see Manager.ClusterSnapshot
+type WorkerStateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Handler string `protobuf:"bytes,1,opt,name=handler,proto3" json:"handler,omitempty"`
-	Pid     string `protobuf:"bytes,2,opt,name=pid,proto3" json:"pid,omitempty"` // peer id will be random if not specified
-	Options []byte `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
 }
 
-func (x *RoomJobRequest) Reset() {
-	*x = RoomJobRequest{}
+func (x *WorkerStateRequest) Reset() {
+	*x = WorkerStateRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_proto_noir_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1168,13 +1900,13 @@ func (x *RoomJobRequest) Reset() {
 	}
 }
 
-func (x *RoomJobRequest) String() string {
+func (x *WorkerStateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RoomJobRequest) ProtoMessage() {}
+func (*WorkerStateRequest) ProtoMessage() {}
 
-func (x *RoomJobRequest) ProtoReflect() protoreflect.Message {
+func (x *WorkerStateRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_proto_noir_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1186,45 +1918,26 @@ func (x *RoomJobRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RoomJobRequest.ProtoReflect.Descriptor instead.
-func (*RoomJobRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use WorkerStateRequest.ProtoReflect.Descriptor instead.
+func (*WorkerStateRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *RoomJobRequest) GetHandler() string {
-	if x != nil {
-		return x.Handler
-	}
-	return ""
-}
-
-func (x *RoomJobRequest) GetPid() string {
-	if x != nil {
-		return x.Pid
-	}
-	return ""
-}
-
-func (x *RoomJobRequest) GetOptions() []byte {
-	if x != nil {
-		return x.Options
-	}
-	return nil
-}
-
-type RoomJobReply struct {
+type WorkerStateReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Handler string `protobuf:"bytes,1,opt,name=handler,proto3" json:"handler,omitempty"`
-	Pid     string `protobuf:"bytes,2,opt,name=pid,proto3" json:"pid,omitempty"` // peer id will be random if not specified
-	Status  bool   `protobuf:"varint,3,opt,name=status,proto3" json:"status,omitempty"`
-	Options []byte `protobuf:"bytes,4,opt,name=options,proto3" json:"options,omitempty"`
+	NodeID         string               `protobuf:"bytes,1,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	AsOf           *timestamp.Timestamp `protobuf:"bytes,2,opt,name=asOf,proto3" json:"asOf,omitempty"`
+	Peers          []string             `protobuf:"bytes,3,rep,name=peers,proto3" json:"peers,omitempty"`                                                                                                    // the pids of the peers connected to it
+	Rooms          []string             `protobuf:"bytes,4,rep,name=rooms,proto3" json:"rooms,omitempty"`                                                                                                    // the rooms it has sessions of
+	PeerGoroutines int32                `protobuf:"varint,5,opt,name=peerGoroutines,proto3" json:"peerGoroutines,omitempty"`                                                                                 // see Manager.PeerGoroutines
+	PeerQueues     map[string]int64     `protobuf:"bytes,6,rep,name=peerQueues,proto3" json:"peerQueues,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"` // messages waiting for its peers by pid, the peers with none left out
 }
 
-func (x *RoomJobReply) Reset() {
-	*x = RoomJobReply{}
+func (x *WorkerStateReply) Reset() {
+	*x = WorkerStateReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_proto_noir_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1232,13 +1945,13 @@ func (x *RoomJobReply) Reset() {
 	}
 }
 
-func (x *RoomJobReply) String() string {
+func (x *WorkerStateReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RoomJobReply) ProtoMessage() {}
+func (*WorkerStateReply) ProtoMessage() {}
 
-func (x *RoomJobReply) ProtoReflect() protoreflect.Message {
+func (x *WorkerStateReply) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_proto_noir_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1250,60 +1963,78 @@ func (x *RoomJobReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RoomJobReply.ProtoReflect.Descriptor instead.
-func (*RoomJobReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use WorkerStateReply.ProtoReflect.Descriptor instead.
+func (*WorkerStateReply) Descriptor() ([]byte, []int) {
 	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *RoomJobReply) GetHandler() string {
+func (x *WorkerStateReply) GetNodeID() string {
 	if x != nil {
-		return x.Handler
+		return x.NodeID
 	}
 	return ""
 }
 
-func (x *RoomJobReply) GetPid() string {
+func (x *WorkerStateReply) GetAsOf() *timestamp.Timestamp {
 	if x != nil {
-		return x.Pid
+		return x.AsOf
 	}
-	return ""
+	return nil
 }
 
-func (x *RoomJobReply) GetStatus() bool {
+func (x *WorkerStateReply) GetPeers() []string {
 	if x != nil {
-		return x.Status
+		return x.Peers
 	}
-	return false
+	return nil
 }
 
-func (x *RoomJobReply) GetOptions() []byte {
+func (x *WorkerStateReply) GetRooms() []string {
 	if x != nil {
-		return x.Options
+		return x.Rooms
 	}
 	return nil
 }
 
-// ****************************************************
-//SIGNAL COMMANDS - ION-SFU COMPATIBLE
-//1 SIGNAL = 1 CLIENT CONNECTION
-//***************************************************
-type SignalRequest struct {
+func (x *WorkerStateReply) GetPeerGoroutines() int32 {
+	if x != nil {
+		return x.PeerGoroutines
+	}
+	return 0
+}
+
+func (x *WorkerStateReply) GetPeerQueues() map[string]int64 {
+	if x != nil {
+		return x.PeerQueues
+	}
+	return nil
+}
+
+type RoomAdminRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // SignalRequest.id should be called pid but we are ion-sfu compatible
-	// Types that are assignable to Payload:
-	//	*SignalRequest_Join
-	//	*SignalRequest_Description
-	//	*SignalRequest_Trickle
-	//	*SignalRequest_Kill
-	Payload   isSignalRequest_Payload `protobuf_oneof:"payload"`
-	RequestId string                  `protobuf:"bytes,6,opt,name=requestId,proto3" json:"requestId,omitempty"` // optional, for requests with replies
+	RoomID string `protobuf:"bytes,1,opt,name=roomID,proto3" json:"roomID,omitempty"`
+	// Types that are assignable to Method:
+	//	*RoomAdminRequest_CreateRoom
+	//	*RoomAdminRequest_RoomJob
+	//	*RoomAdminRequest_CloseRoom
+	//	*RoomAdminRequest_StartRecording
+	//	*RoomAdminRequest_StopRecording
+	//	*RoomAdminRequest_MutePeer
+	//	*RoomAdminRequest_KickPeer
+	//	*RoomAdminRequest_TraceSignaling
+	//	*RoomAdminRequest_SetPassword
+	//	*RoomAdminRequest_Announce
+	//	*RoomAdminRequest_PauseRoom
+	//	*RoomAdminRequest_TransferOwnership
+	Method   isRoomAdminRequest_Method `protobuf_oneof:"method"`
+	CallerID string                    `protobuf:"bytes,9,opt,name=callerID,proto3" json:"callerID,omitempty"` // set when a peer runs the action, it has to be a MODERATOR of the room
 }
 
-func (x *SignalRequest) Reset() {
-	*x = SignalRequest{}
+func (x *RoomAdminRequest) Reset() {
+	*x = RoomAdminRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_proto_noir_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1311,13 +2042,13 @@ func (x *SignalRequest) Reset() {
 	}
 }
 
-func (x *SignalRequest) String() string {
+func (x *RoomAdminRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SignalRequest) ProtoMessage() {}
+func (*RoomAdminRequest) ProtoMessage() {}
 
-func (x *SignalRequest) ProtoReflect() protoreflect.Message {
+func (x *RoomAdminRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_proto_noir_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1329,107 +2060,211 @@ func (x *SignalRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SignalRequest.ProtoReflect.Descriptor instead.
-func (*SignalRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RoomAdminRequest.ProtoReflect.Descriptor instead.
+func (*RoomAdminRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *SignalRequest) GetId() string {
+func (x *RoomAdminRequest) GetRoomID() string {
 	if x != nil {
-		return x.Id
+		return x.RoomID
 	}
 	return ""
 }
 
-func (m *SignalRequest) GetPayload() isSignalRequest_Payload {
+func (m *RoomAdminRequest) GetMethod() isRoomAdminRequest_Method {
 	if m != nil {
-		return m.Payload
+		return m.Method
 	}
 	return nil
 }
 
-func (x *SignalRequest) GetJoin() *JoinRequest {
-	if x, ok := x.GetPayload().(*SignalRequest_Join); ok {
-		return x.Join
+func (x *RoomAdminRequest) GetCreateRoom() *CreateRoomRequest {
+	if x, ok := x.GetMethod().(*RoomAdminRequest_CreateRoom); ok {
+		return x.CreateRoom
 	}
 	return nil
 }
 
-func (x *SignalRequest) GetDescription() []byte {
-	if x, ok := x.GetPayload().(*SignalRequest_Description); ok {
-		return x.Description
+func (x *RoomAdminRequest) GetRoomJob() *RoomJobRequest {
+	if x, ok := x.GetMethod().(*RoomAdminRequest_RoomJob); ok {
+		return x.RoomJob
 	}
 	return nil
 }
 
-func (x *SignalRequest) GetTrickle() *Trickle {
-	if x, ok := x.GetPayload().(*SignalRequest_Trickle); ok {
-		return x.Trickle
+func (x *RoomAdminRequest) GetCloseRoom() *CloseRoomRequest {
+	if x, ok := x.GetMethod().(*RoomAdminRequest_CloseRoom); ok {
+		return x.CloseRoom
 	}
 	return nil
 }
 
-func (x *SignalRequest) GetKill() bool {
-	if x, ok := x.GetPayload().(*SignalRequest_Kill); ok {
-		return x.Kill
+func (x *RoomAdminRequest) GetStartRecording() *StartRecordingRequest {
+	if x, ok := x.GetMethod().(*RoomAdminRequest_StartRecording); ok {
+		return x.StartRecording
 	}
-	return false
+	return nil
 }
 
-func (x *SignalRequest) GetRequestId() string {
-	if x != nil {
-		return x.RequestId
+func (x *RoomAdminRequest) GetStopRecording() *StopRecordingRequest {
+	if x, ok := x.GetMethod().(*RoomAdminRequest_StopRecording); ok {
+		return x.StopRecording
 	}
-	return ""
+	return nil
 }
 
-type isSignalRequest_Payload interface {
-	isSignalRequest_Payload()
+func (x *RoomAdminRequest) GetMutePeer() *MutePeerRequest {
+	if x, ok := x.GetMethod().(*RoomAdminRequest_MutePeer); ok {
+		return x.MutePeer
+	}
+	return nil
 }
 
-type SignalRequest_Join struct {
-	Join *JoinRequest `protobuf:"bytes,2,opt,name=join,proto3,oneof"`
+func (x *RoomAdminRequest) GetKickPeer() *KickPeerRequest {
+	if x, ok := x.GetMethod().(*RoomAdminRequest_KickPeer); ok {
+		return x.KickPeer
+	}
+	return nil
 }
 
-type SignalRequest_Description struct {
-	Description []byte `protobuf:"bytes,3,opt,name=description,proto3,oneof"`
+func (x *RoomAdminRequest) GetTraceSignaling() *TraceSignalingRequest {
+	if x, ok := x.GetMethod().(*RoomAdminRequest_TraceSignaling); ok {
+		return x.TraceSignaling
+	}
+	return nil
 }
 
-type SignalRequest_Trickle struct {
-	Trickle *Trickle `protobuf:"bytes,4,opt,name=trickle,proto3,oneof"`
+func (x *RoomAdminRequest) GetSetPassword() *SetPasswordRequest {
+	if x, ok := x.GetMethod().(*RoomAdminRequest_SetPassword); ok {
+		return x.SetPassword
+	}
+	return nil
 }
 
-type SignalRequest_Kill struct {
-	Kill bool `protobuf:"varint,5,opt,name=kill,proto3,oneof"`
+func (x *RoomAdminRequest) GetAnnounce() *Announcement {
+	if x, ok := x.GetMethod().(*RoomAdminRequest_Announce); ok {
+		return x.Announce
+	}
+	return nil
 }
 
-func (*SignalRequest_Join) isSignalRequest_Payload() {}
+func (x *RoomAdminRequest) GetPauseRoom() *PauseRoomRequest {
+	if x, ok := x.GetMethod().(*RoomAdminRequest_PauseRoom); ok {
+		return x.PauseRoom
+	}
+	return nil
+}
 
-func (*SignalRequest_Description) isSignalRequest_Payload() {}
+func (x *RoomAdminRequest) GetTransferOwnership() *TransferOwnershipRequest {
+	if x, ok := x.GetMethod().(*RoomAdminRequest_TransferOwnership); ok {
+		return x.TransferOwnership
+	}
+	return nil
+}
 
-func (*SignalRequest_Trickle) isSignalRequest_Payload() {}
+func (x *RoomAdminRequest) GetCallerID() string {
+	if x != nil {
+		return x.CallerID
+	}
+	return ""
+}
 
-func (*SignalRequest_Kill) isSignalRequest_Payload() {}
+type isRoomAdminRequest_Method interface {
+	isRoomAdminRequest_Method()
+}
 
-type SignalReply struct {
+type RoomAdminRequest_CreateRoom struct {
+	CreateRoom *CreateRoomRequest `protobuf:"bytes,2,opt,name=createRoom,proto3,oneof"`
+}
+
+type RoomAdminRequest_RoomJob struct {
+	RoomJob *RoomJobRequest `protobuf:"bytes,3,opt,name=roomJob,proto3,oneof"`
+}
+
+type RoomAdminRequest_CloseRoom struct {
+	CloseRoom *CloseRoomRequest `protobuf:"bytes,4,opt,name=closeRoom,proto3,oneof"`
+}
+
+type RoomAdminRequest_StartRecording struct {
+	StartRecording *StartRecordingRequest `protobuf:"bytes,5,opt,name=startRecording,proto3,oneof"`
+}
+
+type RoomAdminRequest_StopRecording struct {
+	StopRecording *StopRecordingRequest `protobuf:"bytes,6,opt,name=stopRecording,proto3,oneof"`
+}
+
+type RoomAdminRequest_MutePeer struct {
+	MutePeer *MutePeerRequest `protobuf:"bytes,7,opt,name=mutePeer,proto3,oneof"`
+}
+
+type RoomAdminRequest_KickPeer struct {
+	KickPeer *KickPeerRequest `protobuf:"bytes,8,opt,name=kickPeer,proto3,oneof"`
+}
+
+type RoomAdminRequest_TraceSignaling struct {
+	TraceSignaling *TraceSignalingRequest `protobuf:"bytes,10,opt,name=traceSignaling,proto3,oneof"`
+}
+
+type RoomAdminRequest_SetPassword struct {
+	SetPassword *SetPasswordRequest `protobuf:"bytes,11,opt,name=setPassword,proto3,oneof"`
+}
+
+type RoomAdminRequest_Announce struct {
+	Announce *Announcement `protobuf:"bytes,12,opt,name=announce,proto3,oneof"`
+}
+
+type RoomAdminRequest_PauseRoom struct {
+	PauseRoom *PauseRoomRequest `protobuf:"bytes,13,opt,name=pauseRoom,proto3,oneof"`
+}
+
+type RoomAdminRequest_TransferOwnership struct {
+	TransferOwnership *TransferOwnershipRequest `protobuf:"bytes,14,opt,name=transferOwnership,proto3,oneof"`
+}
+
+func (*RoomAdminRequest_CreateRoom) isRoomAdminRequest_Method() {}
+
+func (*RoomAdminRequest_RoomJob) isRoomAdminRequest_Method() {}
+
+func (*RoomAdminRequest_CloseRoom) isRoomAdminRequest_Method() {}
+
+func (*RoomAdminRequest_StartRecording) isRoomAdminRequest_Method() {}
+
+func (*RoomAdminRequest_StopRecording) isRoomAdminRequest_Method() {}
+
+func (*RoomAdminRequest_MutePeer) isRoomAdminRequest_Method() {}
+
+func (*RoomAdminRequest_KickPeer) isRoomAdminRequest_Method() {}
+
+func (*RoomAdminRequest_TraceSignaling) isRoomAdminRequest_Method() {}
+
+func (*RoomAdminRequest_SetPassword) isRoomAdminRequest_Method() {}
+
+func (*RoomAdminRequest_Announce) isRoomAdminRequest_Method() {}
+
+func (*RoomAdminRequest_PauseRoom) isRoomAdminRequest_Method() {}
+
+func (*RoomAdminRequest_TransferOwnership) isRoomAdminRequest_Method() {}
+
+type RoomAdminReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RoomID string `protobuf:"bytes,1,opt,name=roomID,proto3" json:"roomID,omitempty"`
 	// Types that are assignable to Payload:
-	//	*SignalReply_Join
-	//	*SignalReply_Description
-	//	*SignalReply_Trickle
-	//	*SignalReply_IceConnectionState
-	//	*SignalReply_Error
-	//	*SignalReply_Kill
-	Payload   isSignalReply_Payload `protobuf_oneof:"payload"`
-	RequestId string                `protobuf:"bytes,8,opt,name=requestId,proto3" json:"requestId,omitempty"` // optional, for requests with replies
+	//	*RoomAdminReply_Error
+	//	*RoomAdminReply_CreateRoom
+	//	*RoomAdminReply_RoomJob
+	//	*RoomAdminReply_CloseRoom
+	//	*RoomAdminReply_StartRecording
+	//	*RoomAdminReply_StopRecording
+	//	*RoomAdminReply_Announce
+	Payload isRoomAdminReply_Payload `protobuf_oneof:"payload"`
 }
 
-func (x *SignalReply) Reset() {
-	*x = SignalReply{}
+func (x *RoomAdminReply) Reset() {
+	*x = RoomAdminReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_proto_noir_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1437,13 +2272,13 @@ func (x *SignalReply) Reset() {
 	}
 }
 
-func (x *SignalReply) String() string {
+func (x *RoomAdminReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SignalReply) ProtoMessage() {}
+func (*RoomAdminReply) ProtoMessage() {}
 
-func (x *SignalReply) ProtoReflect() protoreflect.Message {
+func (x *RoomAdminReply) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_proto_noir_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1455,125 +2290,131 @@ func (x *SignalReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SignalReply.ProtoReflect.Descriptor instead.
-func (*SignalReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use RoomAdminReply.ProtoReflect.Descriptor instead.
+func (*RoomAdminReply) Descriptor() ([]byte, []int) {
 	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *SignalReply) GetId() string {
+func (x *RoomAdminReply) GetRoomID() string {
 	if x != nil {
-		return x.Id
+		return x.RoomID
 	}
 	return ""
 }
 
-func (m *SignalReply) GetPayload() isSignalReply_Payload {
+func (m *RoomAdminReply) GetPayload() isRoomAdminReply_Payload {
 	if m != nil {
 		return m.Payload
 	}
 	return nil
 }
 
-func (x *SignalReply) GetJoin() *JoinReply {
-	if x, ok := x.GetPayload().(*SignalReply_Join); ok {
-		return x.Join
+func (x *RoomAdminReply) GetError() string {
+	if x, ok := x.GetPayload().(*RoomAdminReply_Error); ok {
+		return x.Error
 	}
-	return nil
+	return ""
 }
 
-func (x *SignalReply) GetDescription() []byte {
-	if x, ok := x.GetPayload().(*SignalReply_Description); ok {
-		return x.Description
+func (x *RoomAdminReply) GetCreateRoom() *CreateRoomReply {
+	if x, ok := x.GetPayload().(*RoomAdminReply_CreateRoom); ok {
+		return x.CreateRoom
 	}
 	return nil
 }
 
-func (x *SignalReply) GetTrickle() *Trickle {
-	if x, ok := x.GetPayload().(*SignalReply_Trickle); ok {
-		return x.Trickle
+func (x *RoomAdminReply) GetRoomJob() *RoomJobReply {
+	if x, ok := x.GetPayload().(*RoomAdminReply_RoomJob); ok {
+		return x.RoomJob
 	}
 	return nil
 }
 
-func (x *SignalReply) GetIceConnectionState() string {
-	if x, ok := x.GetPayload().(*SignalReply_IceConnectionState); ok {
-		return x.IceConnectionState
+func (x *RoomAdminReply) GetCloseRoom() *CloseRoomReply {
+	if x, ok := x.GetPayload().(*RoomAdminReply_CloseRoom); ok {
+		return x.CloseRoom
 	}
-	return ""
+	return nil
 }
 
-func (x *SignalReply) GetError() string {
-	if x, ok := x.GetPayload().(*SignalReply_Error); ok {
-		return x.Error
+func (x *RoomAdminReply) GetStartRecording() *StartRecordingReply {
+	if x, ok := x.GetPayload().(*RoomAdminReply_StartRecording); ok {
+		return x.StartRecording
 	}
-	return ""
+	return nil
 }
 
-func (x *SignalReply) GetKill() bool {
-	if x, ok := x.GetPayload().(*SignalReply_Kill); ok {
-		return x.Kill
+func (x *RoomAdminReply) GetStopRecording() *StopRecordingReply {
+	if x, ok := x.GetPayload().(*RoomAdminReply_StopRecording); ok {
+		return x.StopRecording
 	}
-	return false
+	return nil
 }
 
-func (x *SignalReply) GetRequestId() string {
-	if x != nil {
-		return x.RequestId
+func (x *RoomAdminReply) GetAnnounce() *AnnounceReply {
+	if x, ok := x.GetPayload().(*RoomAdminReply_Announce); ok {
+		return x.Announce
 	}
-	return ""
+	return nil
 }
 
-type isSignalReply_Payload interface {
-	isSignalReply_Payload()
+type isRoomAdminReply_Payload interface {
+	isRoomAdminReply_Payload()
 }
 
-type SignalReply_Join struct {
-	Join *JoinReply `protobuf:"bytes,2,opt,name=join,proto3,oneof"`
+type RoomAdminReply_Error struct {
+	Error string `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
 }
 
-type SignalReply_Description struct {
-	Description []byte `protobuf:"bytes,3,opt,name=description,proto3,oneof"`
+type RoomAdminReply_CreateRoom struct {
+	CreateRoom *CreateRoomReply `protobuf:"bytes,3,opt,name=createRoom,proto3,oneof"`
 }
 
-type SignalReply_Trickle struct {
-	Trickle *Trickle `protobuf:"bytes,4,opt,name=trickle,proto3,oneof"`
+type RoomAdminReply_RoomJob struct {
+	RoomJob *RoomJobReply `protobuf:"bytes,4,opt,name=roomJob,proto3,oneof"`
 }
 
-type SignalReply_IceConnectionState struct {
-	IceConnectionState string `protobuf:"bytes,5,opt,name=iceConnectionState,proto3,oneof"`
+type RoomAdminReply_CloseRoom struct {
+	CloseRoom *CloseRoomReply `protobuf:"bytes,5,opt,name=closeRoom,proto3,oneof"`
 }
 
-type SignalReply_Error struct {
-	Error string `protobuf:"bytes,6,opt,name=error,proto3,oneof"`
+type RoomAdminReply_StartRecording struct {
+	StartRecording *StartRecordingReply `protobuf:"bytes,6,opt,name=startRecording,proto3,oneof"`
 }
 
-type SignalReply_Kill struct {
-	Kill bool `protobuf:"varint,7,opt,name=kill,proto3,oneof"`
+type RoomAdminReply_StopRecording struct {
+	StopRecording *StopRecordingReply `protobuf:"bytes,7,opt,name=stopRecording,proto3,oneof"`
 }
 
-func (*SignalReply_Join) isSignalReply_Payload() {}
+type RoomAdminReply_Announce struct {
+	Announce *AnnounceReply `protobuf:"bytes,8,opt,name=announce,proto3,oneof"`
+}
 
-func (*SignalReply_Description) isSignalReply_Payload() {}
+func (*RoomAdminReply_Error) isRoomAdminReply_Payload() {}
 
-func (*SignalReply_Trickle) isSignalReply_Payload() {}
+func (*RoomAdminReply_CreateRoom) isRoomAdminReply_Payload() {}
 
-func (*SignalReply_IceConnectionState) isSignalReply_Payload() {}
+func (*RoomAdminReply_RoomJob) isRoomAdminReply_Payload() {}
 
-func (*SignalReply_Error) isSignalReply_Payload() {}
+func (*RoomAdminReply_CloseRoom) isRoomAdminReply_Payload() {}
 
-func (*SignalReply_Kill) isSignalReply_Payload() {}
+func (*RoomAdminReply_StartRecording) isRoomAdminReply_Payload() {}
 
-type JoinRequest struct {
+func (*RoomAdminReply_StopRecording) isRoomAdminReply_Payload() {}
+
+func (*RoomAdminReply_Announce) isRoomAdminReply_Payload() {}
+
+// AnnounceReply is how many peers of the room were sent the announcement
+type AnnounceReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Sid         string `protobuf:"bytes,1,opt,name=sid,proto3" json:"sid,omitempty"`
-	Description []byte `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Delivered int32 `protobuf:"varint,1,opt,name=delivered,proto3" json:"delivered,omitempty"`
 }
 
-func (x *JoinRequest) Reset() {
-	*x = JoinRequest{}
+func (x *AnnounceReply) Reset() {
+	*x = AnnounceReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_proto_noir_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1581,13 +2422,13 @@ func (x *JoinRequest) Reset() {
 	}
 }
 
-func (x *JoinRequest) String() string {
+func (x *AnnounceReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JoinRequest) ProtoMessage() {}
+func (*AnnounceReply) ProtoMessage() {}
 
-func (x *JoinRequest) ProtoReflect() protoreflect.Message {
+func (x *AnnounceReply) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_proto_noir_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1599,35 +2440,31 @@ func (x *JoinRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JoinRequest.ProtoReflect.Descriptor instead.
-func (*JoinRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AnnounceReply.ProtoReflect.Descriptor instead.
+func (*AnnounceReply) Descriptor() ([]byte, []int) {
 	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *JoinRequest) GetSid() string {
-	if x != nil {
-		return x.Sid
-	}
-	return ""
-}
-
-func (x *JoinRequest) GetDescription() []byte {
+func (x *AnnounceReply) GetDelivered() int32 {
 	if x != nil {
-		return x.Description
+		return x.Delivered
 	}
-	return nil
+	return 0
 }
 
-type JoinReply struct {
+type CreateRoomRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Description []byte `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	Options  *RoomOptions      `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	Owner    string            `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	Metadata map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Template string            `protobuf:"bytes,4,opt,name=template,proto3" json:"template,omitempty"` // a room template registered on the manager, options set here replace its own, see Manager.RegisterRoomTemplate
 }
 
-func (x *JoinReply) Reset() {
-	*x = JoinReply{}
+func (x *CreateRoomRequest) Reset() {
+	*x = CreateRoomRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_proto_noir_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1635,13 +2472,13 @@ func (x *JoinReply) Reset() {
 	}
 }
 
-func (x *JoinReply) String() string {
+func (x *CreateRoomRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JoinReply) ProtoMessage() {}
+func (*CreateRoomRequest) ProtoMessage() {}
 
-func (x *JoinReply) ProtoReflect() protoreflect.Message {
+func (x *CreateRoomRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_proto_noir_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1653,29 +2490,51 @@ func (x *JoinReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JoinReply.ProtoReflect.Descriptor instead.
-func (*JoinReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateRoomRequest.ProtoReflect.Descriptor instead.
+func (*CreateRoomRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *JoinReply) GetDescription() []byte {
+func (x *CreateRoomRequest) GetOptions() *RoomOptions {
 	if x != nil {
-		return x.Description
+		return x.Options
 	}
 	return nil
 }
 
-type Trickle struct {
+func (x *CreateRoomRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *CreateRoomRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *CreateRoomRequest) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+// CreateRoomReply is the room's options, created is false when it already existed
+type CreateRoomReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Target Trickle_Target `protobuf:"varint,1,opt,name=target,proto3,enum=noir.Trickle_Target" json:"target,omitempty"`
-	Init   string         `protobuf:"bytes,2,opt,name=init,proto3" json:"init,omitempty"`
+	Options *RoomOptions `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	Created bool         `protobuf:"varint,3,opt,name=created,proto3" json:"created,omitempty"`
 }
 
-func (x *Trickle) Reset() {
-	*x = Trickle{}
+func (x *CreateRoomReply) Reset() {
+	*x = CreateRoomReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_proto_noir_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1683,13 +2542,13 @@ func (x *Trickle) Reset() {
 	}
 }
 
-func (x *Trickle) String() string {
+func (x *CreateRoomReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Trickle) ProtoMessage() {}
+func (*CreateRoomReply) ProtoMessage() {}
 
-func (x *Trickle) ProtoReflect() protoreflect.Message {
+func (x *CreateRoomReply) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_proto_noir_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1701,39 +2560,35 @@ func (x *Trickle) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Trickle.ProtoReflect.Descriptor instead.
-func (*Trickle) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateRoomReply.ProtoReflect.Descriptor instead.
+func (*CreateRoomReply) Descriptor() ([]byte, []int) {
 	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *Trickle) GetTarget() Trickle_Target {
+func (x *CreateRoomReply) GetOptions() *RoomOptions {
 	if x != nil {
-		return x.Target
+		return x.Options
 	}
-	return Trickle_PUBLISHER
+	return nil
 }
 
-func (x *Trickle) GetInit() string {
+func (x *CreateRoomReply) GetCreated() bool {
 	if x != nil {
-		return x.Init
+		return x.Created
 	}
-	return ""
+	return false
 }
 
-type NoirObject struct {
+type StartRecordingRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Types that are assignable to Data:
-	//	*NoirObject_Node
-	//	*NoirObject_Room
-	//	*NoirObject_User
-	Data isNoirObject_Data `protobuf_oneof:"data"`
+	OutputDir string `protobuf:"bytes,1,opt,name=outputDir,proto3" json:"outputDir,omitempty"` // empty uses the manager's recording dir, a moderator's is a dir under it
 }
 
-func (x *NoirObject) Reset() {
-	*x = NoirObject{}
+func (x *StartRecordingRequest) Reset() {
+	*x = StartRecordingRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_proto_noir_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1741,13 +2596,13 @@ func (x *NoirObject) Reset() {
 	}
 }
 
-func (x *NoirObject) String() string {
+func (x *StartRecordingRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NoirObject) ProtoMessage() {}
+func (*StartRecordingRequest) ProtoMessage() {}
 
-func (x *NoirObject) ProtoReflect() protoreflect.Message {
+func (x *StartRecordingRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_proto_noir_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1759,73 +2614,28 @@ func (x *NoirObject) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NoirObject.ProtoReflect.Descriptor instead.
-func (*NoirObject) Descriptor() ([]byte, []int) {
+// Deprecated: Use StartRecordingRequest.ProtoReflect.Descriptor instead.
+func (*StartRecordingRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{22}
 }
 
-func (m *NoirObject) GetData() isNoirObject_Data {
-	if m != nil {
-		return m.Data
-	}
-	return nil
-}
-
-func (x *NoirObject) GetNode() *NodeData {
-	if x, ok := x.GetData().(*NoirObject_Node); ok {
-		return x.Node
-	}
-	return nil
-}
-
-func (x *NoirObject) GetRoom() *RoomData {
-	if x, ok := x.GetData().(*NoirObject_Room); ok {
-		return x.Room
-	}
-	return nil
-}
-
-func (x *NoirObject) GetUser() *UserData {
-	if x, ok := x.GetData().(*NoirObject_User); ok {
-		return x.User
+func (x *StartRecordingRequest) GetOutputDir() string {
+	if x != nil {
+		return x.OutputDir
 	}
-	return nil
-}
-
-type isNoirObject_Data interface {
-	isNoirObject_Data()
-}
-
-type NoirObject_Node struct {
-	Node *NodeData `protobuf:"bytes,1,opt,name=node,proto3,oneof"`
-}
-
-type NoirObject_Room struct {
-	Room *RoomData `protobuf:"bytes,2,opt,name=room,proto3,oneof"`
-}
-
-type NoirObject_User struct {
-	User *UserData `protobuf:"bytes,3,opt,name=user,proto3,oneof"`
+	return ""
 }
 
-func (*NoirObject_Node) isNoirObject_Data() {}
-
-func (*NoirObject_Room) isNoirObject_Data() {}
-
-func (*NoirObject_User) isNoirObject_Data() {}
-
-type NodeData struct {
+type StartRecordingReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id         string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	LastUpdate *timestamp.Timestamp `protobuf:"bytes,2,opt,name=lastUpdate,proto3" json:"lastUpdate,omitempty"`
-	Services   []string             `protobuf:"bytes,3,rep,name=services,proto3" json:"services,omitempty"`
+	RecordingID string `protobuf:"bytes,1,opt,name=recordingID,proto3" json:"recordingID,omitempty"`
 }
 
-func (x *NodeData) Reset() {
-	*x = NodeData{}
+func (x *StartRecordingReply) Reset() {
+	*x = StartRecordingReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_proto_noir_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1833,13 +2643,13 @@ func (x *NodeData) Reset() {
 	}
 }
 
-func (x *NodeData) String() string {
+func (x *StartRecordingReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NodeData) ProtoMessage() {}
+func (*StartRecordingReply) ProtoMessage() {}
 
-func (x *NodeData) ProtoReflect() protoreflect.Message {
+func (x *StartRecordingReply) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_proto_noir_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1851,47 +2661,28 @@ func (x *NodeData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NodeData.ProtoReflect.Descriptor instead.
-func (*NodeData) Descriptor() ([]byte, []int) {
+// Deprecated: Use StartRecordingReply.ProtoReflect.Descriptor instead.
+func (*StartRecordingReply) Descriptor() ([]byte, []int) {
 	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *NodeData) GetId() string {
+func (x *StartRecordingReply) GetRecordingID() string {
 	if x != nil {
-		return x.Id
+		return x.RecordingID
 	}
 	return ""
 }
 
-func (x *NodeData) GetLastUpdate() *timestamp.Timestamp {
-	if x != nil {
-		return x.LastUpdate
-	}
-	return nil
-}
-
-func (x *NodeData) GetServices() []string {
-	if x != nil {
-		return x.Services
-	}
-	return nil
-}
-
-type RoomData struct {
+type StopRecordingRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id         string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Created    *timestamp.Timestamp `protobuf:"bytes,2,opt,name=created,proto3" json:"created,omitempty"`
-	LastUpdate *timestamp.Timestamp `protobuf:"bytes,3,opt,name=lastUpdate,proto3" json:"lastUpdate,omitempty"`
-	NodeID     string               `protobuf:"bytes,4,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
-	Options    *RoomOptions         `protobuf:"bytes,5,opt,name=options,proto3" json:"options,omitempty"`
-	Publisher  string               `protobuf:"bytes,6,opt,name=publisher,proto3" json:"publisher,omitempty"`
+	RecordingID string `protobuf:"bytes,1,opt,name=recordingID,proto3" json:"recordingID,omitempty"` // empty stops every recording in the room
 }
 
-func (x *RoomData) Reset() {
-	*x = RoomData{}
+func (x *StopRecordingRequest) Reset() {
+	*x = StopRecordingRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_proto_noir_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1899,13 +2690,13 @@ func (x *RoomData) Reset() {
 	}
 }
 
-func (x *RoomData) String() string {
+func (x *StopRecordingRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RoomData) ProtoMessage() {}
+func (*StopRecordingRequest) ProtoMessage() {}
 
-func (x *RoomData) ProtoReflect() protoreflect.Message {
+func (x *StopRecordingRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_proto_noir_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1917,70 +2708,28 @@ func (x *RoomData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RoomData.ProtoReflect.Descriptor instead.
-func (*RoomData) Descriptor() ([]byte, []int) {
+// Deprecated: Use StopRecordingRequest.ProtoReflect.Descriptor instead.
+func (*StopRecordingRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *RoomData) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *RoomData) GetCreated() *timestamp.Timestamp {
-	if x != nil {
-		return x.Created
-	}
-	return nil
-}
-
-func (x *RoomData) GetLastUpdate() *timestamp.Timestamp {
-	if x != nil {
-		return x.LastUpdate
-	}
-	return nil
-}
-
-func (x *RoomData) GetNodeID() string {
-	if x != nil {
-		return x.NodeID
-	}
-	return ""
-}
-
-func (x *RoomData) GetOptions() *RoomOptions {
-	if x != nil {
-		return x.Options
-	}
-	return nil
-}
-
-func (x *RoomData) GetPublisher() string {
+func (x *StopRecordingRequest) GetRecordingID() string {
 	if x != nil {
-		return x.Publisher
+		return x.RecordingID
 	}
 	return ""
 }
 
-type RoomOptions struct {
+type StopRecordingReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Debug           int32  `protobuf:"varint,1,opt,name=debug,proto3" json:"debug,omitempty"`
-	Title           string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	MaxAgeSeconds   int32  `protobuf:"varint,3,opt,name=maxAgeSeconds,proto3" json:"maxAgeSeconds,omitempty"`
-	KeyExpiryFactor int32  `protobuf:"varint,4,opt,name=keyExpiryFactor,proto3" json:"keyExpiryFactor,omitempty"`
-	JoinPassword    string `protobuf:"bytes,5,opt,name=joinPassword,proto3" json:"joinPassword,omitempty"`
-	PublishPassword string `protobuf:"bytes,6,opt,name=publishPassword,proto3" json:"publishPassword,omitempty"`
-	MaxPeers        int32  `protobuf:"varint,7,opt,name=maxPeers,proto3" json:"maxPeers,omitempty"`
-	IsChannel       bool   `protobuf:"varint,8,opt,name=isChannel,proto3" json:"isChannel,omitempty"`
+	Stopped []string `protobuf:"bytes,1,rep,name=stopped,proto3" json:"stopped,omitempty"`
 }
 
-func (x *RoomOptions) Reset() {
-	*x = RoomOptions{}
+func (x *StopRecordingReply) Reset() {
+	*x = StopRecordingReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_proto_noir_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1988,13 +2737,13 @@ func (x *RoomOptions) Reset() {
 	}
 }
 
-func (x *RoomOptions) String() string {
+func (x *StopRecordingReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RoomOptions) ProtoMessage() {}
+func (*StopRecordingReply) ProtoMessage() {}
 
-func (x *RoomOptions) ProtoReflect() protoreflect.Message {
+func (x *StopRecordingReply) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_proto_noir_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2006,97 +2755,108 @@ func (x *RoomOptions) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RoomOptions.ProtoReflect.Descriptor instead.
-func (*RoomOptions) Descriptor() ([]byte, []int) {
+// Deprecated: Use StopRecordingReply.ProtoReflect.Descriptor instead.
+func (*StopRecordingReply) Descriptor() ([]byte, []int) {
 	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *RoomOptions) GetDebug() int32 {
+func (x *StopRecordingReply) GetStopped() []string {
 	if x != nil {
-		return x.Debug
+		return x.Stopped
 	}
-	return 0
+	return nil
 }
 
-func (x *RoomOptions) GetTitle() string {
-	if x != nil {
-		return x.Title
-	}
-	return ""
+type MutePeerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pid   string `protobuf:"bytes,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	Kind  string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`    // audio or video
+	Muted bool   `protobuf:"varint,3,opt,name=muted,proto3" json:"muted,omitempty"` // false unmutes
 }
 
-func (x *RoomOptions) GetMaxAgeSeconds() int32 {
-	if x != nil {
-		return x.MaxAgeSeconds
+func (x *MutePeerRequest) Reset() {
+	*x = MutePeerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *RoomOptions) GetKeyExpiryFactor() int32 {
-	if x != nil {
-		return x.KeyExpiryFactor
-	}
-	return 0
+func (x *MutePeerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *RoomOptions) GetJoinPassword() string {
-	if x != nil {
-		return x.JoinPassword
+func (*MutePeerRequest) ProtoMessage() {}
+
+func (x *MutePeerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *RoomOptions) GetPublishPassword() string {
+// Deprecated: Use MutePeerRequest.ProtoReflect.Descriptor instead.
+func (*MutePeerRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *MutePeerRequest) GetPid() string {
 	if x != nil {
-		return x.PublishPassword
+		return x.Pid
 	}
 	return ""
 }
 
-func (x *RoomOptions) GetMaxPeers() int32 {
+func (x *MutePeerRequest) GetKind() string {
 	if x != nil {
-		return x.MaxPeers
+		return x.Kind
 	}
-	return 0
+	return ""
 }
 
-func (x *RoomOptions) GetIsChannel() bool {
+func (x *MutePeerRequest) GetMuted() bool {
 	if x != nil {
-		return x.IsChannel
+		return x.Muted
 	}
 	return false
 }
 
-type UserData struct {
+// TraceSignalingRequest starts or stops keeping the room's signaling, see Manager.GetSignalingTrace
+type TraceSignalingRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id         string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Created    *timestamp.Timestamp `protobuf:"bytes,2,opt,name=created,proto3" json:"created,omitempty"`
-	LastUpdate *timestamp.Timestamp `protobuf:"bytes,4,opt,name=lastUpdate,proto3" json:"lastUpdate,omitempty"`
-	RoomID     string               `protobuf:"bytes,5,opt,name=roomID,proto3" json:"roomID,omitempty"`
-	Options    *UserOptions         `protobuf:"bytes,6,opt,name=options,proto3" json:"options,omitempty"`
-	Publishing bool                 `protobuf:"varint,7,opt,name=publishing,proto3" json:"publishing,omitempty"`
+	Enabled   bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	RedactSDP bool `protobuf:"varint,2,opt,name=redactSDP,proto3" json:"redactSDP,omitempty"` // leave descriptions and candidates out of the trace
 }
 
-func (x *UserData) Reset() {
-	*x = UserData{}
+func (x *TraceSignalingRequest) Reset() {
+	*x = TraceSignalingRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_proto_noir_proto_msgTypes[26]
+		mi := &file_pkg_proto_noir_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UserData) String() string {
+func (x *TraceSignalingRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserData) ProtoMessage() {}
+func (*TraceSignalingRequest) ProtoMessage() {}
 
-func (x *UserData) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_noir_proto_msgTypes[26]
+func (x *TraceSignalingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2107,81 +2867,51 @@ func (x *UserData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserData.ProtoReflect.Descriptor instead.
-func (*UserData) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{26}
-}
-
-func (x *UserData) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *UserData) GetCreated() *timestamp.Timestamp {
-	if x != nil {
-		return x.Created
-	}
-	return nil
-}
-
-func (x *UserData) GetLastUpdate() *timestamp.Timestamp {
-	if x != nil {
-		return x.LastUpdate
-	}
-	return nil
-}
-
-func (x *UserData) GetRoomID() string {
-	if x != nil {
-		return x.RoomID
-	}
-	return ""
+// Deprecated: Use TraceSignalingRequest.ProtoReflect.Descriptor instead.
+func (*TraceSignalingRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *UserData) GetOptions() *UserOptions {
+func (x *TraceSignalingRequest) GetEnabled() bool {
 	if x != nil {
-		return x.Options
+		return x.Enabled
 	}
-	return nil
+	return false
 }
 
-func (x *UserData) GetPublishing() bool {
+func (x *TraceSignalingRequest) GetRedactSDP() bool {
 	if x != nil {
-		return x.Publishing
+		return x.RedactSDP
 	}
 	return false
 }
 
-type UserOptions struct {
+// SetPasswordRequest replaces the room's join password, an empty password clears it
+type SetPasswordRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Debug           int32  `protobuf:"varint,1,opt,name=debug,proto3" json:"debug,omitempty"`
-	Title           string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	MaxAgeSeconds   int32  `protobuf:"varint,3,opt,name=maxAgeSeconds,proto3" json:"maxAgeSeconds,omitempty"`
-	KeyExpiryFactor int32  `protobuf:"varint,4,opt,name=keyExpiryFactor,proto3" json:"keyExpiryFactor,omitempty"`
+	Password string `protobuf:"bytes,1,opt,name=password,proto3" json:"password,omitempty"`
 }
 
-func (x *UserOptions) Reset() {
-	*x = UserOptions{}
+func (x *SetPasswordRequest) Reset() {
+	*x = SetPasswordRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_proto_noir_proto_msgTypes[27]
+		mi := &file_pkg_proto_noir_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UserOptions) String() string {
+func (x *SetPasswordRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserOptions) ProtoMessage() {}
+func (*SetPasswordRequest) ProtoMessage() {}
 
-func (x *UserOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_noir_proto_msgTypes[27]
+func (x *SetPasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2192,69 +2922,92 @@ func (x *UserOptions) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserOptions.ProtoReflect.Descriptor instead.
-func (*UserOptions) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use SetPasswordRequest.ProtoReflect.Descriptor instead.
+func (*SetPasswordRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *UserOptions) GetDebug() int32 {
+func (x *SetPasswordRequest) GetPassword() string {
 	if x != nil {
-		return x.Debug
+		return x.Password
 	}
-	return 0
+	return ""
 }
 
-func (x *UserOptions) GetTitle() string {
-	if x != nil {
-		return x.Title
+// PauseRoomRequest stops forwarding all media in the room, keeping its peers connected
+type PauseRoomRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Paused bool `protobuf:"varint,1,opt,name=paused,proto3" json:"paused,omitempty"` // false resumes
+}
+
+func (x *PauseRoomRequest) Reset() {
+	*x = PauseRoomRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (x *UserOptions) GetMaxAgeSeconds() int32 {
-	if x != nil {
-		return x.MaxAgeSeconds
+func (x *PauseRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseRoomRequest) ProtoMessage() {}
+
+func (x *PauseRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *UserOptions) GetKeyExpiryFactor() int32 {
+// Deprecated: Use PauseRoomRequest.ProtoReflect.Descriptor instead.
+func (*PauseRoomRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PauseRoomRequest) GetPaused() bool {
 	if x != nil {
-		return x.KeyExpiryFactor
+		return x.Paused
 	}
-	return 0
+	return false
 }
 
-type JobData struct {
+// TransferOwnershipRequest makes another peer of the room its owner, only the owner or an admin may
+type TransferOwnershipRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id         string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Handler    string               `protobuf:"bytes,2,opt,name=handler,proto3" json:"handler,omitempty"`
-	Status     JobData_JobStatus    `protobuf:"varint,3,opt,name=status,proto3,enum=noir.JobData_JobStatus" json:"status,omitempty"`
-	Created    *timestamp.Timestamp `protobuf:"bytes,4,opt,name=created,proto3" json:"created,omitempty"`
-	LastUpdate *timestamp.Timestamp `protobuf:"bytes,5,opt,name=lastUpdate,proto3" json:"lastUpdate,omitempty"`
-	NodeID     string               `protobuf:"bytes,6,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	Pid string `protobuf:"bytes,1,opt,name=pid,proto3" json:"pid,omitempty"`
 }
 
-func (x *JobData) Reset() {
-	*x = JobData{}
+func (x *TransferOwnershipRequest) Reset() {
+	*x = TransferOwnershipRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_proto_noir_proto_msgTypes[28]
+		mi := &file_pkg_proto_noir_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *JobData) String() string {
+func (x *TransferOwnershipRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JobData) ProtoMessage() {}
+func (*TransferOwnershipRequest) ProtoMessage() {}
 
-func (x *JobData) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_noir_proto_msgTypes[28]
+func (x *TransferOwnershipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2265,81 +3018,242 @@ func (x *JobData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JobData.ProtoReflect.Descriptor instead.
-func (*JobData) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use TransferOwnershipRequest.ProtoReflect.Descriptor instead.
+func (*TransferOwnershipRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *JobData) GetId() string {
+func (x *TransferOwnershipRequest) GetPid() string {
 	if x != nil {
-		return x.Id
+		return x.Pid
 	}
 	return ""
 }
 
-func (x *JobData) GetHandler() string {
+// KickPeerRequest disconnects a peer of the room
+type KickPeerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pid string `protobuf:"bytes,1,opt,name=pid,proto3" json:"pid,omitempty"`
+}
+
+func (x *KickPeerRequest) Reset() {
+	*x = KickPeerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KickPeerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KickPeerRequest) ProtoMessage() {}
+
+func (x *KickPeerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KickPeerRequest.ProtoReflect.Descriptor instead.
+func (*KickPeerRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *KickPeerRequest) GetPid() string {
 	if x != nil {
-		return x.Handler
+		return x.Pid
 	}
 	return ""
 }
 
-func (x *JobData) GetStatus() JobData_JobStatus {
-	if x != nil {
-		return x.Status
+type CloseRoomRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CloseRoomRequest) Reset() {
+	*x = CloseRoomRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return JobData_CREATED
 }
 
-func (x *JobData) GetCreated() *timestamp.Timestamp {
+func (x *CloseRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseRoomRequest) ProtoMessage() {}
+
+func (x *CloseRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseRoomRequest.ProtoReflect.Descriptor instead.
+func (*CloseRoomRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{32}
+}
+
+type CloseRoomReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Evicted int32 `protobuf:"varint,1,opt,name=evicted,proto3" json:"evicted,omitempty"` // number of peers that were kicked out
+}
+
+func (x *CloseRoomReply) Reset() {
+	*x = CloseRoomReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CloseRoomReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseRoomReply) ProtoMessage() {}
+
+func (x *CloseRoomReply) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseRoomReply.ProtoReflect.Descriptor instead.
+func (*CloseRoomReply) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CloseRoomReply) GetEvicted() int32 {
 	if x != nil {
-		return x.Created
+		return x.Evicted
 	}
-	return nil
+	return 0
 }
 
-func (x *JobData) GetLastUpdate() *timestamp.Timestamp {
+type RoomJobRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Handler string `protobuf:"bytes,1,opt,name=handler,proto3" json:"handler,omitempty"`
+	Pid     string `protobuf:"bytes,2,opt,name=pid,proto3" json:"pid,omitempty"` // peer id will be random if not specified
+	Options []byte `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+}
+
+func (x *RoomJobRequest) Reset() {
+	*x = RoomJobRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RoomJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomJobRequest) ProtoMessage() {}
+
+func (x *RoomJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomJobRequest.ProtoReflect.Descriptor instead.
+func (*RoomJobRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *RoomJobRequest) GetHandler() string {
 	if x != nil {
-		return x.LastUpdate
+		return x.Handler
 	}
-	return nil
+	return ""
 }
 
-func (x *JobData) GetNodeID() string {
+func (x *RoomJobRequest) GetPid() string {
 	if x != nil {
-		return x.NodeID
+		return x.Pid
 	}
 	return ""
 }
 
-type PeerJobData struct {
+func (x *RoomJobRequest) GetOptions() []byte {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type RoomJobReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	RoomID          string   `protobuf:"bytes,1,opt,name=roomID,proto3" json:"roomID,omitempty"`
-	UserID          string   `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"`
-	PublishTracks   []string `protobuf:"bytes,3,rep,name=publishTracks,proto3" json:"publishTracks,omitempty"`
-	SubscribeTracks []string `protobuf:"bytes,4,rep,name=subscribeTracks,proto3" json:"subscribeTracks,omitempty"`
+	Handler string `protobuf:"bytes,1,opt,name=handler,proto3" json:"handler,omitempty"`
+	Pid     string `protobuf:"bytes,2,opt,name=pid,proto3" json:"pid,omitempty"` // peer id will be random if not specified
+	Status  bool   `protobuf:"varint,3,opt,name=status,proto3" json:"status,omitempty"`
+	Options []byte `protobuf:"bytes,4,opt,name=options,proto3" json:"options,omitempty"`
 }
 
-func (x *PeerJobData) Reset() {
-	*x = PeerJobData{}
+func (x *RoomJobReply) Reset() {
+	*x = RoomJobReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_proto_noir_proto_msgTypes[29]
+		mi := &file_pkg_proto_noir_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PeerJobData) String() string {
+func (x *RoomJobReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PeerJobData) ProtoMessage() {}
+func (*RoomJobReply) ProtoMessage() {}
 
-func (x *PeerJobData) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_proto_noir_proto_msgTypes[29]
+func (x *RoomJobReply) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2350,432 +3264,6429 @@ func (x *PeerJobData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PeerJobData.ProtoReflect.Descriptor instead.
-func (*PeerJobData) Descriptor() ([]byte, []int) {
-	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use RoomJobReply.ProtoReflect.Descriptor instead.
+func (*RoomJobReply) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *PeerJobData) GetRoomID() string {
+func (x *RoomJobReply) GetHandler() string {
 	if x != nil {
-		return x.RoomID
+		return x.Handler
 	}
 	return ""
 }
 
-func (x *PeerJobData) GetUserID() string {
+func (x *RoomJobReply) GetPid() string {
 	if x != nil {
-		return x.UserID
+		return x.Pid
 	}
 	return ""
 }
 
-func (x *PeerJobData) GetPublishTracks() []string {
+func (x *RoomJobReply) GetStatus() bool {
 	if x != nil {
-		return x.PublishTracks
+		return x.Status
 	}
-	return nil
+	return false
 }
 
-func (x *PeerJobData) GetSubscribeTracks() []string {
+func (x *RoomJobReply) GetOptions() []byte {
 	if x != nil {
-		return x.SubscribeTracks
+		return x.Options
 	}
 	return nil
 }
 
-var File_pkg_proto_noir_proto protoreflect.FileDescriptor
-
-var file_pkg_proto_noir_proto_rawDesc = []byte{
-	0x0a, 0x14, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6e, 0x6f, 0x69, 0x72,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x6e, 0x6f, 0x69, 0x72, 0x1a, 0x1f, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x29, 0x0a,
-	0x0b, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08,
-	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
-	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x22, 0xc5, 0x01, 0x0a, 0x0b, 0x4e, 0x6f, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
-	0x64, 0x12, 0x0e, 0x0a, 0x02, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x61,
-	0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x06, 0x73, 0x69, 0x67,
-	0x6e, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6e, 0x6f, 0x69, 0x72,
-	0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00,
-	0x52, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x12, 0x2a, 0x0a, 0x05, 0x61, 0x64, 0x6d, 0x69,
-	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x41,
-	0x64, 0x6d, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x05, 0x61,
-	0x64, 0x6d, 0x69, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x49, 0x44, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x49, 0x44, 0x42, 0x09,
-	0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x22, 0xa5, 0x01, 0x0a, 0x09, 0x4e, 0x6f,
-	0x69, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x61, 0x74, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x02, 0x61, 0x74, 0x12, 0x2b, 0x0a, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x61,
-	0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x53,
-	0x69, 0x67, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x06, 0x73, 0x69,
-	0x67, 0x6e, 0x61, 0x6c, 0x12, 0x28, 0x0a, 0x05, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x05, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x16,
-	0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
-	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
-	0x64, 0x22, 0xbe, 0x01, 0x0a, 0x0c, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x36, 0x0a, 0x09, 0x72, 0x6f, 0x6f, 0x6d, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x52, 0x6f, 0x6f,
-	0x6d, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52,
-	0x09, 0x72, 0x6f, 0x6f, 0x6d, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x36, 0x0a, 0x09, 0x72, 0x6f,
-	0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
-	0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x09, 0x72, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x75,
-	0x6e, 0x74, 0x12, 0x33, 0x0a, 0x08, 0x72, 0x6f, 0x6f, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x52, 0x6f, 0x6f, 0x6d,
-	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x08, 0x72,
-	0x6f, 0x6f, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f,
-	0x61, 0x64, 0x22, 0xce, 0x01, 0x0a, 0x0a, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x12, 0x16, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x34, 0x0a, 0x09, 0x72, 0x6f, 0x6f,
-	0x6d, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6e,
-	0x6f, 0x69, 0x72, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x48, 0x00, 0x52, 0x09, 0x72, 0x6f, 0x6f, 0x6d, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12,
-	0x34, 0x0a, 0x09, 0x72, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x6f,
-	0x75, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x09, 0x72, 0x6f, 0x6f, 0x6d,
-	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x31, 0x0a, 0x08, 0x72, 0x6f, 0x6f, 0x6d, 0x4c, 0x69, 0x73,
-	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x52,
-	0x6f, 0x6f, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x08,
-	0x72, 0x6f, 0x6f, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c,
-	0x6f, 0x61, 0x64, 0x22, 0x12, 0x0a, 0x10, 0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x28, 0x0a, 0x0e, 0x52, 0x6f, 0x6f, 0x6d, 0x43,
-	0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73,
-	0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c,
-	0x74, 0x22, 0x11, 0x0a, 0x0f, 0x52, 0x6f, 0x6f, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x22, 0x35, 0x0a, 0x0d, 0x52, 0x6f, 0x6f, 0x6d, 0x4c, 0x69, 0x73, 0x74,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x52, 0x0a, 0x0d, 0x52,
-	0x6f, 0x6f, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x14, 0x0a, 0x05,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x12, 0x2b, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x4c, 0x69,
-	0x73, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22,
-	0xa1, 0x01, 0x0a, 0x10, 0x52, 0x6f, 0x6f, 0x6d, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x12, 0x39, 0x0a, 0x0a,
-	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x17, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f,
-	0x6f, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x63, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x30, 0x0a, 0x07, 0x72, 0x6f, 0x6f, 0x6d, 0x4a,
-	0x6f, 0x62, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e,
-	0x52, 0x6f, 0x6f, 0x6d, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00,
-	0x52, 0x07, 0x72, 0x6f, 0x6f, 0x6d, 0x4a, 0x6f, 0x62, 0x42, 0x08, 0x0a, 0x06, 0x6d, 0x65, 0x74,
-	0x68, 0x6f, 0x64, 0x22, 0xb4, 0x01, 0x0a, 0x0e, 0x52, 0x6f, 0x6f, 0x6d, 0x41, 0x64, 0x6d, 0x69,
-	0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x12, 0x16,
-	0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
-	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x37, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x52, 0x6f, 0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6e, 0x6f, 0x69,
-	0x72, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x48, 0x00, 0x52, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x12,
-	0x2e, 0x0a, 0x07, 0x72, 0x6f, 0x6f, 0x6d, 0x4a, 0x6f, 0x62, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x12, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x4a, 0x6f, 0x62, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x07, 0x72, 0x6f, 0x6f, 0x6d, 0x4a, 0x6f, 0x62, 0x42,
-	0x09, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x40, 0x0a, 0x11, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x2b, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x11, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x4f, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x3e, 0x0a, 0x0f,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
-	0x2b, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x11, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x4f, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x56, 0x0a, 0x0e,
-	0x52, 0x6f, 0x6f, 0x6d, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18,
-	0x0a, 0x07, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x6f, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x22, 0x6c, 0x0a, 0x0c, 0x52, 0x6f, 0x6f, 0x6d, 0x4a, 0x6f, 0x62, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x12, 0x10,
-	0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x69, 0x64,
-	0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x22, 0xd6, 0x01, 0x0a, 0x0d, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x02, 0x69, 0x64, 0x12, 0x27, 0x0a, 0x04, 0x6a, 0x6f, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x04, 0x6a, 0x6f, 0x69, 0x6e, 0x12, 0x22, 0x0a,
-	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0c, 0x48, 0x00, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x29, 0x0a, 0x07, 0x74, 0x72, 0x69, 0x63, 0x6b, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x54, 0x72, 0x69, 0x63, 0x6b, 0x6c,
-	0x65, 0x48, 0x00, 0x52, 0x07, 0x74, 0x72, 0x69, 0x63, 0x6b, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x04,
-	0x6b, 0x69, 0x6c, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x04, 0x6b, 0x69,
-	0x6c, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64,
-	0x42, 0x09, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x9c, 0x02, 0x0a, 0x0b,
-	0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x25, 0x0a, 0x04, 0x6a,
-	0x6f, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6e, 0x6f, 0x69, 0x72,
-	0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x04, 0x6a, 0x6f,
-	0x69, 0x6e, 0x12, 0x22, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
-	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x29, 0x0a, 0x07, 0x74, 0x72, 0x69, 0x63, 0x6b, 0x6c,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x54,
-	0x72, 0x69, 0x63, 0x6b, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x07, 0x74, 0x72, 0x69, 0x63, 0x6b, 0x6c,
-	0x65, 0x12, 0x30, 0x0a, 0x12, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
-	0x12, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74,
-	0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x04, 0x6b,
-	0x69, 0x6c, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x04, 0x6b, 0x69, 0x6c,
-	0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x18, 0x08,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x42,
-	0x09, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x41, 0x0a, 0x0b, 0x4a, 0x6f,
-	0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x69, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x64,
-	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x2d, 0x0a,
-	0x09, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65,
-	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x74, 0x0a, 0x07,
-	0x54, 0x72, 0x69, 0x63, 0x6b, 0x6c, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65,
-	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x54,
-	0x72, 0x69, 0x63, 0x6b, 0x6c, 0x65, 0x2e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x06, 0x74,
-	0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x6e, 0x69, 0x74, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x69, 0x6e, 0x69, 0x74, 0x22, 0x27, 0x0a, 0x06, 0x54, 0x61, 0x72,
-	0x67, 0x65, 0x74, 0x12, 0x0d, 0x0a, 0x09, 0x50, 0x55, 0x42, 0x4c, 0x49, 0x53, 0x48, 0x45, 0x52,
-	0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x55, 0x42, 0x53, 0x43, 0x52, 0x49, 0x42, 0x45, 0x52,
-	0x10, 0x01, 0x22, 0x86, 0x01, 0x0a, 0x0a, 0x4e, 0x6f, 0x69, 0x72, 0x4f, 0x62, 0x6a, 0x65, 0x63,
-	0x74, 0x12, 0x24, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x0e, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x44, 0x61, 0x74, 0x61, 0x48,
-	0x00, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x24, 0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x6d, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x52, 0x6f, 0x6f,
-	0x6d, 0x44, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x04, 0x72, 0x6f, 0x6f, 0x6d, 0x12, 0x24, 0x0a,
-	0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6e, 0x6f,
-	0x69, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x04, 0x75,
-	0x73, 0x65, 0x72, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x72, 0x0a, 0x08, 0x4e,
-	0x6f, 0x64, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x3a, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18,
-	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x22,
-	0xef, 0x01, 0x0a, 0x08, 0x52, 0x6f, 0x6f, 0x6d, 0x44, 0x61, 0x74, 0x61, 0x12, 0x0e, 0x0a, 0x02,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x34, 0x0a, 0x07,
-	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x64, 0x12, 0x3a, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
-	0x6d, 0x70, 0x52, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x16,
-	0x0a, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x6e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x12, 0x2b, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x52,
-	0x6f, 0x6f, 0x6d, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65,
-	0x72, 0x22, 0x91, 0x02, 0x0a, 0x0b, 0x52, 0x6f, 0x6f, 0x6d, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x62, 0x75, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x05, 0x64, 0x65, 0x62, 0x75, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x24, 0x0a,
-	0x0d, 0x6d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x6d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x53, 0x65, 0x63, 0x6f,
-	0x6e, 0x64, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x6b, 0x65, 0x79, 0x45, 0x78, 0x70, 0x69, 0x72, 0x79,
-	0x46, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x6b, 0x65,
-	0x79, 0x45, 0x78, 0x70, 0x69, 0x72, 0x79, 0x46, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x22, 0x0a,
-	0x0c, 0x6a, 0x6f, 0x69, 0x6e, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0c, 0x6a, 0x6f, 0x69, 0x6e, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
-	0x64, 0x12, 0x28, 0x0a, 0x0f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x50, 0x61, 0x73, 0x73,
-	0x77, 0x6f, 0x72, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x70, 0x75, 0x62, 0x6c,
-	0x69, 0x73, 0x68, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6d,
-	0x61, 0x78, 0x50, 0x65, 0x65, 0x72, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d,
-	0x61, 0x78, 0x50, 0x65, 0x65, 0x72, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x73, 0x43, 0x68, 0x61,
-	0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x73, 0x43, 0x68,
-	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x22, 0xf1, 0x01, 0x0a, 0x08, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61,
-	0x74, 0x61, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
-	0x69, 0x64, 0x12, 0x34, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
-	0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x3a, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
-	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x12, 0x2b, 0x0a, 0x07,
-	0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
-	0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x75, 0x62,
-	0x6c, 0x69, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x70,
-	0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x22, 0x89, 0x01, 0x0a, 0x0b, 0x55, 0x73,
-	0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x62,
-	0x75, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x64, 0x65, 0x62, 0x75, 0x67, 0x12,
-	0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x53,
-	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x6d, 0x61,
-	0x78, 0x41, 0x67, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x6b,
-	0x65, 0x79, 0x45, 0x78, 0x70, 0x69, 0x72, 0x79, 0x46, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x6b, 0x65, 0x79, 0x45, 0x78, 0x70, 0x69, 0x72, 0x79, 0x46,
-	0x61, 0x63, 0x74, 0x6f, 0x72, 0x22, 0xad, 0x02, 0x0a, 0x07, 0x4a, 0x6f, 0x62, 0x44, 0x61, 0x74,
-	0x61, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
-	0x64, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x12, 0x2f, 0x0a, 0x06, 0x73,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x6e, 0x6f,
-	0x69, 0x72, 0x2e, 0x4a, 0x6f, 0x62, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x34, 0x0a, 0x07,
-	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x64, 0x12, 0x3a, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
-	0x6d, 0x70, 0x52, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x16,
-	0x0a, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x6e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x22, 0x3d, 0x0a, 0x09, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x10, 0x00,
-	0x12, 0x0b, 0x0a, 0x07, 0x52, 0x55, 0x4e, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0b, 0x0a,
-	0x07, 0x53, 0x54, 0x4f, 0x50, 0x50, 0x45, 0x44, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52,
-	0x52, 0x4f, 0x52, 0x10, 0x03, 0x22, 0x8d, 0x01, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x4a, 0x6f,
-	0x62, 0x44, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x12, 0x16, 0x0a,
-	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75,
-	0x73, 0x65, 0x72, 0x49, 0x44, 0x12, 0x24, 0x0a, 0x0d, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68,
-	0x54, 0x72, 0x61, 0x63, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x75,
-	0x62, 0x6c, 0x69, 0x73, 0x68, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x73,
-	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x73, 0x18, 0x04,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x54,
-	0x72, 0x61, 0x63, 0x6b, 0x73, 0x32, 0xca, 0x01, 0x0a, 0x04, 0x4e, 0x6f, 0x69, 0x72, 0x12, 0x31,
-	0x0a, 0x09, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x11, 0x2e, 0x6e, 0x6f,
-	0x69, 0x72, 0x2e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x1a, 0x0f,
-	0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x4e, 0x6f, 0x69, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x30,
-	0x01, 0x12, 0x26, 0x0a, 0x04, 0x53, 0x65, 0x6e, 0x64, 0x12, 0x11, 0x2e, 0x6e, 0x6f, 0x69, 0x72,
-	0x2e, 0x4e, 0x6f, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0b, 0x2e, 0x6e,
-	0x6f, 0x69, 0x72, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x2f, 0x0a, 0x05, 0x41, 0x64, 0x6d,
-	0x69, 0x6e, 0x12, 0x11, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x4e, 0x6f, 0x69, 0x72, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x4e, 0x6f, 0x69,
-	0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x28, 0x01, 0x30, 0x01, 0x12, 0x36, 0x0a, 0x06, 0x53, 0x69,
-	0x67, 0x6e, 0x61, 0x6c, 0x12, 0x13, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x53, 0x69, 0x67, 0x6e,
-	0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x6e, 0x6f, 0x69, 0x72,
-	0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x28, 0x01,
-	0x30, 0x01, 0x32, 0x3d, 0x0a, 0x03, 0x53, 0x46, 0x55, 0x12, 0x36, 0x0a, 0x06, 0x53, 0x69, 0x67,
-	0x6e, 0x61, 0x6c, 0x12, 0x13, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61,
-	0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e,
-	0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x28, 0x01, 0x30,
-	0x01, 0x42, 0x27, 0x5a, 0x25, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x6e, 0x65, 0x74, 0x2d, 0x70, 0x72, 0x6f, 0x70, 0x68, 0x65, 0x74, 0x2f, 0x6e, 0x6f, 0x69, 0x72,
-	0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+// ****************************************************
+//SIGNAL COMMANDS - ION-SFU COMPATIBLE
+//1 SIGNAL = 1 CLIENT CONNECTION
+//***************************************************
+type SignalRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // SignalRequest.id should be called pid but we are ion-sfu compatible
+	// Types that are assignable to Payload:
+	//	*SignalRequest_Join
+	//	*SignalRequest_Description
+	//	*SignalRequest_Trickle
+	//	*SignalRequest_Kill
+	//	*SignalRequest_Leave
+	//	*SignalRequest_Data
+	//	*SignalRequest_Resume
+	//	*SignalRequest_SetLayer
+	//	*SignalRequest_Play
+	//	*SignalRequest_Mute
+	//	*SignalRequest_TrickleBatch
+	//	*SignalRequest_Subscribe
+	//	*SignalRequest_Unsubscribe
+	//	*SignalRequest_GetStats
+	//	*SignalRequest_Pause
+	//	*SignalRequest_Move
+	//	*SignalRequest_Pong
+	//	*SignalRequest_Capabilities
+	Payload     isSignalRequest_Payload `protobuf_oneof:"payload"`
+	RequestId   string                  `protobuf:"bytes,6,opt,name=requestId,proto3" json:"requestId,omitempty"`                                                                                              // optional, for requests with replies
+	CloseReason CloseReason             `protobuf:"varint,16,opt,name=closeReason,proto3,enum=noir.CloseReason" json:"closeReason,omitempty"`                                                                  // why a kill disconnects the peer
+	TrackLabels map[string]string       `protobuf:"bytes,19,rep,name=trackLabels,proto3" json:"trackLabels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // with a join or an offer, the app's label of the streams it publishes by stream id, eg. screenshare
+	ResumeToken string                  `protobuf:"bytes,23,opt,name=resumeToken,proto3" json:"resumeToken,omitempty"`                                                                                         // with a resume, or a reconnecting join of a peer without an identity, the resumeToken of its JoinReply
 }
 
-var (
-	file_pkg_proto_noir_proto_rawDescOnce sync.Once
-	file_pkg_proto_noir_proto_rawDescData = file_pkg_proto_noir_proto_rawDesc
-)
+func (x *SignalRequest) Reset() {
+	*x = SignalRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_pkg_proto_noir_proto_rawDescGZIP() []byte {
-	file_pkg_proto_noir_proto_rawDescOnce.Do(func() {
-		file_pkg_proto_noir_proto_rawDescData = protoimpl.X.CompressGZIP(file_pkg_proto_noir_proto_rawDescData)
-	})
-	return file_pkg_proto_noir_proto_rawDescData
+func (x *SignalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_pkg_proto_noir_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_pkg_proto_noir_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
-var file_pkg_proto_noir_proto_goTypes = []interface{}{
-	(Trickle_Target)(0),         // 0: noir.Trickle.Target
-	(JobData_JobStatus)(0),      // 1: noir.JobData.JobStatus
-	(*AdminClient)(nil),         // 2: noir.AdminClient
-	(*Empty)(nil),               // 3: noir.Empty
-	(*NoirRequest)(nil),         // 4: noir.NoirRequest
-	(*NoirReply)(nil),           // 5: noir.NoirReply
-	(*AdminRequest)(nil),        // 6: noir.AdminRequest
-	(*AdminReply)(nil),          // 7: noir.AdminReply
-	(*RoomCountRequest)(nil),    // 8: noir.RoomCountRequest
-	(*RoomCountReply)(nil),      // 9: noir.RoomCountReply
-	(*RoomListRequest)(nil),     // 10: noir.RoomListRequest
-	(*RoomListEntry)(nil),       // 11: noir.RoomListEntry
-	(*RoomListReply)(nil),       // 12: noir.RoomListReply
-	(*RoomAdminRequest)(nil),    // 13: noir.RoomAdminRequest
-	(*RoomAdminReply)(nil),      // 14: noir.RoomAdminReply
-	(*CreateRoomRequest)(nil),   // 15: noir.CreateRoomRequest
-	(*CreateRoomReply)(nil),     // 16: noir.CreateRoomReply
-	(*RoomJobRequest)(nil),      // 17: noir.RoomJobRequest
-	(*RoomJobReply)(nil),        // 18: noir.RoomJobReply
-	(*SignalRequest)(nil),       // 19: noir.SignalRequest
-	(*SignalReply)(nil),         // 20: noir.SignalReply
-	(*JoinRequest)(nil),         // 21: noir.JoinRequest
-	(*JoinReply)(nil),           // 22: noir.JoinReply
-	(*Trickle)(nil),             // 23: noir.Trickle
-	(*NoirObject)(nil),          // 24: noir.NoirObject
-	(*NodeData)(nil),            // 25: noir.NodeData
-	(*RoomData)(nil),            // 26: noir.RoomData
-	(*RoomOptions)(nil),         // 27: noir.RoomOptions
-	(*UserData)(nil),            // 28: noir.UserData
-	(*UserOptions)(nil),         // 29: noir.UserOptions
-	(*JobData)(nil),             // 30: noir.JobData
-	(*PeerJobData)(nil),         // 31: noir.PeerJobData
-	(*timestamp.Timestamp)(nil), // 32: google.protobuf.Timestamp
+func (*SignalRequest) ProtoMessage() {}
+
+func (x *SignalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_pkg_proto_noir_proto_depIdxs = []int32{
-	19, // 0: noir.NoirRequest.signal:type_name -> noir.SignalRequest
-	6,  // 1: noir.NoirRequest.admin:type_name -> noir.AdminRequest
-	20, // 2: noir.NoirReply.signal:type_name -> noir.SignalReply
-	7,  // 3: noir.NoirReply.admin:type_name -> noir.AdminReply
-	13, // 4: noir.AdminRequest.roomAdmin:type_name -> noir.RoomAdminRequest
-	8,  // 5: noir.AdminRequest.roomCount:type_name -> noir.RoomCountRequest
-	10, // 6: noir.AdminRequest.roomList:type_name -> noir.RoomListRequest
-	14, // 7: noir.AdminReply.roomAdmin:type_name -> noir.RoomAdminReply
-	9,  // 8: noir.AdminReply.roomCount:type_name -> noir.RoomCountReply
-	12, // 9: noir.AdminReply.roomList:type_name -> noir.RoomListReply
-	11, // 10: noir.RoomListReply.result:type_name -> noir.RoomListEntry
-	15, // 11: noir.RoomAdminRequest.createRoom:type_name -> noir.CreateRoomRequest
-	17, // 12: noir.RoomAdminRequest.roomJob:type_name -> noir.RoomJobRequest
-	16, // 13: noir.RoomAdminReply.createRoom:type_name -> noir.CreateRoomReply
-	18, // 14: noir.RoomAdminReply.roomJob:type_name -> noir.RoomJobReply
-	27, // 15: noir.CreateRoomRequest.options:type_name -> noir.RoomOptions
-	27, // 16: noir.CreateRoomReply.options:type_name -> noir.RoomOptions
-	21, // 17: noir.SignalRequest.join:type_name -> noir.JoinRequest
-	23, // 18: noir.SignalRequest.trickle:type_name -> noir.Trickle
-	22, // 19: noir.SignalReply.join:type_name -> noir.JoinReply
-	23, // 20: noir.SignalReply.trickle:type_name -> noir.Trickle
-	0,  // 21: noir.Trickle.target:type_name -> noir.Trickle.Target
-	25, // 22: noir.NoirObject.node:type_name -> noir.NodeData
-	26, // 23: noir.NoirObject.room:type_name -> noir.RoomData
-	28, // 24: noir.NoirObject.user:type_name -> noir.UserData
-	32, // 25: noir.NodeData.lastUpdate:type_name -> google.protobuf.Timestamp
-	32, // 26: noir.RoomData.created:type_name -> google.protobuf.Timestamp
-	32, // 27: noir.RoomData.lastUpdate:type_name -> google.protobuf.Timestamp
-	27, // 28: noir.RoomData.options:type_name -> noir.RoomOptions
-	32, // 29: noir.UserData.created:type_name -> google.protobuf.Timestamp
-	32, // 30: noir.UserData.lastUpdate:type_name -> google.protobuf.Timestamp
-	29, // 31: noir.UserData.options:type_name -> noir.UserOptions
-	1,  // 32: noir.JobData.status:type_name -> noir.JobData.JobStatus
-	32, // 33: noir.JobData.created:type_name -> google.protobuf.Timestamp
-	32, // 34: noir.JobData.lastUpdate:type_name -> google.protobuf.Timestamp
-	2,  // 35: noir.Noir.Subscribe:input_type -> noir.AdminClient
-	4,  // 36: noir.Noir.Send:input_type -> noir.NoirRequest
-	4,  // 37: noir.Noir.Admin:input_type -> noir.NoirRequest
-	19, // 38: noir.Noir.Signal:input_type -> noir.SignalRequest
-	19, // 39: noir.SFU.Signal:input_type -> noir.SignalRequest
-	5,  // 40: noir.Noir.Subscribe:output_type -> noir.NoirReply
-	3,  // 41: noir.Noir.Send:output_type -> noir.Empty
-	5,  // 42: noir.Noir.Admin:output_type -> noir.NoirReply
-	20, // 43: noir.Noir.Signal:output_type -> noir.SignalReply
-	20, // 44: noir.SFU.Signal:output_type -> noir.SignalReply
-	40, // [40:45] is the sub-list for method output_type
-	35, // [35:40] is the sub-list for method input_type
-	35, // [35:35] is the sub-list for extension type_name
-	35, // [35:35] is the sub-list for extension extendee
-	0,  // [0:35] is the sub-list for field type_name
+
+// Deprecated: Use SignalRequest.ProtoReflect.Descriptor instead.
+func (*SignalRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{36}
 }
 
-func init() { file_pkg_proto_noir_proto_init() }
-func file_pkg_proto_noir_proto_init() {
-	if File_pkg_proto_noir_proto != nil {
-		return
+func (x *SignalRequest) GetId() string {
+	if x != nil {
+		return x.Id
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_pkg_proto_noir_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AdminClient); i {
+	return ""
+}
+
+func (m *SignalRequest) GetPayload() isSignalRequest_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (x *SignalRequest) GetJoin() *JoinRequest {
+	if x, ok := x.GetPayload().(*SignalRequest_Join); ok {
+		return x.Join
+	}
+	return nil
+}
+
+func (x *SignalRequest) GetDescription() []byte {
+	if x, ok := x.GetPayload().(*SignalRequest_Description); ok {
+		return x.Description
+	}
+	return nil
+}
+
+func (x *SignalRequest) GetTrickle() *Trickle {
+	if x, ok := x.GetPayload().(*SignalRequest_Trickle); ok {
+		return x.Trickle
+	}
+	return nil
+}
+
+func (x *SignalRequest) GetKill() bool {
+	if x, ok := x.GetPayload().(*SignalRequest_Kill); ok {
+		return x.Kill
+	}
+	return false
+}
+
+func (x *SignalRequest) GetLeave() bool {
+	if x, ok := x.GetPayload().(*SignalRequest_Leave); ok {
+		return x.Leave
+	}
+	return false
+}
+
+func (x *SignalRequest) GetData() *DataMessage {
+	if x, ok := x.GetPayload().(*SignalRequest_Data); ok {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SignalRequest) GetResume() bool {
+	if x, ok := x.GetPayload().(*SignalRequest_Resume); ok {
+		return x.Resume
+	}
+	return false
+}
+
+func (x *SignalRequest) GetSetLayer() *SetLayerRequest {
+	if x, ok := x.GetPayload().(*SignalRequest_SetLayer); ok {
+		return x.SetLayer
+	}
+	return nil
+}
+
+func (x *SignalRequest) GetPlay() *PlayRequest {
+	if x, ok := x.GetPayload().(*SignalRequest_Play); ok {
+		return x.Play
+	}
+	return nil
+}
+
+func (x *SignalRequest) GetMute() *MuteRequest {
+	if x, ok := x.GetPayload().(*SignalRequest_Mute); ok {
+		return x.Mute
+	}
+	return nil
+}
+
+func (x *SignalRequest) GetTrickleBatch() *TrickleBatch {
+	if x, ok := x.GetPayload().(*SignalRequest_TrickleBatch); ok {
+		return x.TrickleBatch
+	}
+	return nil
+}
+
+func (x *SignalRequest) GetSubscribe() *SubscribeRequest {
+	if x, ok := x.GetPayload().(*SignalRequest_Subscribe); ok {
+		return x.Subscribe
+	}
+	return nil
+}
+
+func (x *SignalRequest) GetUnsubscribe() *SubscribeRequest {
+	if x, ok := x.GetPayload().(*SignalRequest_Unsubscribe); ok {
+		return x.Unsubscribe
+	}
+	return nil
+}
+
+func (x *SignalRequest) GetGetStats() *GetStatsRequest {
+	if x, ok := x.GetPayload().(*SignalRequest_GetStats); ok {
+		return x.GetStats
+	}
+	return nil
+}
+
+func (x *SignalRequest) GetPause() *RoomPaused {
+	if x, ok := x.GetPayload().(*SignalRequest_Pause); ok {
+		return x.Pause
+	}
+	return nil
+}
+
+func (x *SignalRequest) GetMove() *MoveRequest {
+	if x, ok := x.GetPayload().(*SignalRequest_Move); ok {
+		return x.Move
+	}
+	return nil
+}
+
+func (x *SignalRequest) GetPong() uint64 {
+	if x, ok := x.GetPayload().(*SignalRequest_Pong); ok {
+		return x.Pong
+	}
+	return 0
+}
+
+func (x *SignalRequest) GetCapabilities() *CapabilitiesRequest {
+	if x, ok := x.GetPayload().(*SignalRequest_Capabilities); ok {
+		return x.Capabilities
+	}
+	return nil
+}
+
+func (x *SignalRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *SignalRequest) GetCloseReason() CloseReason {
+	if x != nil {
+		return x.CloseReason
+	}
+	return CloseReason_UNKNOWN
+}
+
+func (x *SignalRequest) GetTrackLabels() map[string]string {
+	if x != nil {
+		return x.TrackLabels
+	}
+	return nil
+}
+
+func (x *SignalRequest) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+type isSignalRequest_Payload interface {
+	isSignalRequest_Payload()
+}
+
+type SignalRequest_Join struct {
+	Join *JoinRequest `protobuf:"bytes,2,opt,name=join,proto3,oneof"`
+}
+
+type SignalRequest_Description struct {
+	Description []byte `protobuf:"bytes,3,opt,name=description,proto3,oneof"`
+}
+
+type SignalRequest_Trickle struct {
+	Trickle *Trickle `protobuf:"bytes,4,opt,name=trickle,proto3,oneof"`
+}
+
+type SignalRequest_Kill struct {
+	Kill bool `protobuf:"varint,5,opt,name=kill,proto3,oneof"`
+}
+
+type SignalRequest_Leave struct {
+	Leave bool `protobuf:"varint,7,opt,name=leave,proto3,oneof"`
+}
+
+type SignalRequest_Data struct {
+	Data *DataMessage `protobuf:"bytes,8,opt,name=data,proto3,oneof"`
+}
+
+type SignalRequest_Resume struct {
+	Resume bool `protobuf:"varint,9,opt,name=resume,proto3,oneof"` // reattach to a peer detached less than the resume grace ago
+}
+
+type SignalRequest_SetLayer struct {
+	SetLayer *SetLayerRequest `protobuf:"bytes,10,opt,name=setLayer,proto3,oneof"`
+}
+
+type SignalRequest_Play struct {
+	Play *PlayRequest `protobuf:"bytes,11,opt,name=play,proto3,oneof"` // id is the pid the file plays as
+}
+
+type SignalRequest_Mute struct {
+	Mute *MuteRequest `protobuf:"bytes,12,opt,name=mute,proto3,oneof"`
+}
+
+type SignalRequest_TrickleBatch struct {
+	TrickleBatch *TrickleBatch `protobuf:"bytes,13,opt,name=trickleBatch,proto3,oneof"` // trickled in order, like that many trickles
+}
+
+type SignalRequest_Subscribe struct {
+	Subscribe *SubscribeRequest `protobuf:"bytes,14,opt,name=subscribe,proto3,oneof"` // receive these publishers too, see Subscriptions
+}
+
+type SignalRequest_Unsubscribe struct {
+	Unsubscribe *SubscribeRequest `protobuf:"bytes,15,opt,name=unsubscribe,proto3,oneof"` // stop receiving these publishers, every one without pids
+}
+
+type SignalRequest_GetStats struct {
+	GetStats *GetStatsRequest `protobuf:"bytes,17,opt,name=getStats,proto3,oneof"` // a snapshot of the peer's own transports and tracks
+}
+
+type SignalRequest_Pause struct {
+	Pause *RoomPaused `protobuf:"bytes,18,opt,name=pause,proto3,oneof"` // stop or start forwarding media to the peer, as its room was paused
+}
+
+type SignalRequest_Move struct {
+	Move *MoveRequest `protobuf:"bytes,20,opt,name=move,proto3,oneof"` // move the peer to another room on its transport, see Manager.MovePeer
+}
+
+type SignalRequest_Pong struct {
+	Pong uint64 `protobuf:"varint,21,opt,name=pong,proto3,oneof"` // answers the ping of that sequence number, for peers that joined with keepalive
+}
+
+type SignalRequest_Capabilities struct {
+	Capabilities *CapabilitiesRequest `protobuf:"bytes,22,opt,name=capabilities,proto3,oneof"` // what the server and a room support, asked before joining, id is the pid the reply goes to
+}
+
+func (*SignalRequest_Join) isSignalRequest_Payload() {}
+
+func (*SignalRequest_Description) isSignalRequest_Payload() {}
+
+func (*SignalRequest_Trickle) isSignalRequest_Payload() {}
+
+func (*SignalRequest_Kill) isSignalRequest_Payload() {}
+
+func (*SignalRequest_Leave) isSignalRequest_Payload() {}
+
+func (*SignalRequest_Data) isSignalRequest_Payload() {}
+
+func (*SignalRequest_Resume) isSignalRequest_Payload() {}
+
+func (*SignalRequest_SetLayer) isSignalRequest_Payload() {}
+
+func (*SignalRequest_Play) isSignalRequest_Payload() {}
+
+func (*SignalRequest_Mute) isSignalRequest_Payload() {}
+
+func (*SignalRequest_TrickleBatch) isSignalRequest_Payload() {}
+
+func (*SignalRequest_Subscribe) isSignalRequest_Payload() {}
+
+func (*SignalRequest_Unsubscribe) isSignalRequest_Payload() {}
+
+func (*SignalRequest_GetStats) isSignalRequest_Payload() {}
+
+func (*SignalRequest_Pause) isSignalRequest_Payload() {}
+
+func (*SignalRequest_Move) isSignalRequest_Payload() {}
+
+func (*SignalRequest_Pong) isSignalRequest_Payload() {}
+
+func (*SignalRequest_Capabilities) isSignalRequest_Payload() {}
+
+type SignalReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Types that are assignable to Payload:
+	//	*SignalReply_Join
+	//	*SignalReply_Description
+	//	*SignalReply_Trickle
+	//	*SignalReply_IceConnectionState
+	//	*SignalReply_Error
+	//	*SignalReply_Kill
+	//	*SignalReply_Leave
+	//	*SignalReply_Failure
+	//	*SignalReply_Data
+	//	*SignalReply_Resume
+	//	*SignalReply_SetLayer
+	//	*SignalReply_Play
+	//	*SignalReply_Reconnect
+	//	*SignalReply_TrickleBatch
+	//	*SignalReply_Subscriptions
+	//	*SignalReply_ActiveSpeaker
+	//	*SignalReply_Announcement
+	//	*SignalReply_Quality
+	//	*SignalReply_Stats
+	//	*SignalReply_Paused
+	//	*SignalReply_Tracks
+	//	*SignalReply_Moved
+	//	*SignalReply_Layers
+	//	*SignalReply_Ping
+	//	*SignalReply_Capabilities
+	Payload     isSignalReply_Payload `protobuf_oneof:"payload"`
+	RequestId   string                `protobuf:"bytes,8,opt,name=requestId,proto3" json:"requestId,omitempty"`                             // optional, for requests with replies
+	CloseReason CloseReason           `protobuf:"varint,21,opt,name=closeReason,proto3,enum=noir.CloseReason" json:"closeReason,omitempty"` // why a kill disconnected the peer
+}
+
+func (x *SignalReply) Reset() {
+	*x = SignalReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignalReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalReply) ProtoMessage() {}
+
+func (x *SignalReply) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalReply.ProtoReflect.Descriptor instead.
+func (*SignalReply) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SignalReply) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (m *SignalReply) GetPayload() isSignalReply_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (x *SignalReply) GetJoin() *JoinReply {
+	if x, ok := x.GetPayload().(*SignalReply_Join); ok {
+		return x.Join
+	}
+	return nil
+}
+
+func (x *SignalReply) GetDescription() []byte {
+	if x, ok := x.GetPayload().(*SignalReply_Description); ok {
+		return x.Description
+	}
+	return nil
+}
+
+func (x *SignalReply) GetTrickle() *Trickle {
+	if x, ok := x.GetPayload().(*SignalReply_Trickle); ok {
+		return x.Trickle
+	}
+	return nil
+}
+
+func (x *SignalReply) GetIceConnectionState() string {
+	if x, ok := x.GetPayload().(*SignalReply_IceConnectionState); ok {
+		return x.IceConnectionState
+	}
+	return ""
+}
+
+func (x *SignalReply) GetError() string {
+	if x, ok := x.GetPayload().(*SignalReply_Error); ok {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *SignalReply) GetKill() bool {
+	if x, ok := x.GetPayload().(*SignalReply_Kill); ok {
+		return x.Kill
+	}
+	return false
+}
+
+func (x *SignalReply) GetLeave() *PeerLeave {
+	if x, ok := x.GetPayload().(*SignalReply_Leave); ok {
+		return x.Leave
+	}
+	return nil
+}
+
+func (x *SignalReply) GetFailure() *SignalError {
+	if x, ok := x.GetPayload().(*SignalReply_Failure); ok {
+		return x.Failure
+	}
+	return nil
+}
+
+func (x *SignalReply) GetData() *DataMessage {
+	if x, ok := x.GetPayload().(*SignalReply_Data); ok {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SignalReply) GetResume() bool {
+	if x, ok := x.GetPayload().(*SignalReply_Resume); ok {
+		return x.Resume
+	}
+	return false
+}
+
+func (x *SignalReply) GetSetLayer() *SetLayerReply {
+	if x, ok := x.GetPayload().(*SignalReply_SetLayer); ok {
+		return x.SetLayer
+	}
+	return nil
+}
+
+func (x *SignalReply) GetPlay() bool {
+	if x, ok := x.GetPayload().(*SignalReply_Play); ok {
+		return x.Play
+	}
+	return false
+}
+
+func (x *SignalReply) GetReconnect() bool {
+	if x, ok := x.GetPayload().(*SignalReply_Reconnect); ok {
+		return x.Reconnect
+	}
+	return false
+}
+
+func (x *SignalReply) GetTrickleBatch() *TrickleBatch {
+	if x, ok := x.GetPayload().(*SignalReply_TrickleBatch); ok {
+		return x.TrickleBatch
+	}
+	return nil
+}
+
+func (x *SignalReply) GetSubscriptions() *Subscriptions {
+	if x, ok := x.GetPayload().(*SignalReply_Subscriptions); ok {
+		return x.Subscriptions
+	}
+	return nil
+}
+
+func (x *SignalReply) GetActiveSpeaker() *ActiveSpeaker {
+	if x, ok := x.GetPayload().(*SignalReply_ActiveSpeaker); ok {
+		return x.ActiveSpeaker
+	}
+	return nil
+}
+
+func (x *SignalReply) GetAnnouncement() *Announcement {
+	if x, ok := x.GetPayload().(*SignalReply_Announcement); ok {
+		return x.Announcement
+	}
+	return nil
+}
+
+func (x *SignalReply) GetQuality() *ConnectionQuality {
+	if x, ok := x.GetPayload().(*SignalReply_Quality); ok {
+		return x.Quality
+	}
+	return nil
+}
+
+func (x *SignalReply) GetStats() *PeerStatsSnapshot {
+	if x, ok := x.GetPayload().(*SignalReply_Stats); ok {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *SignalReply) GetPaused() *RoomPaused {
+	if x, ok := x.GetPayload().(*SignalReply_Paused); ok {
+		return x.Paused
+	}
+	return nil
+}
+
+func (x *SignalReply) GetTracks() *TrackInfos {
+	if x, ok := x.GetPayload().(*SignalReply_Tracks); ok {
+		return x.Tracks
+	}
+	return nil
+}
+
+func (x *SignalReply) GetMoved() *RoomMoved {
+	if x, ok := x.GetPayload().(*SignalReply_Moved); ok {
+		return x.Moved
+	}
+	return nil
+}
+
+func (x *SignalReply) GetLayers() *TrackLayers {
+	if x, ok := x.GetPayload().(*SignalReply_Layers); ok {
+		return x.Layers
+	}
+	return nil
+}
+
+func (x *SignalReply) GetPing() uint64 {
+	if x, ok := x.GetPayload().(*SignalReply_Ping); ok {
+		return x.Ping
+	}
+	return 0
+}
+
+func (x *SignalReply) GetCapabilities() *Capabilities {
+	if x, ok := x.GetPayload().(*SignalReply_Capabilities); ok {
+		return x.Capabilities
+	}
+	return nil
+}
+
+func (x *SignalReply) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *SignalReply) GetCloseReason() CloseReason {
+	if x != nil {
+		return x.CloseReason
+	}
+	return CloseReason_UNKNOWN
+}
+
+type isSignalReply_Payload interface {
+	isSignalReply_Payload()
+}
+
+type SignalReply_Join struct {
+	Join *JoinReply `protobuf:"bytes,2,opt,name=join,proto3,oneof"`
+}
+
+type SignalReply_Description struct {
+	Description []byte `protobuf:"bytes,3,opt,name=description,proto3,oneof"`
+}
+
+type SignalReply_Trickle struct {
+	Trickle *Trickle `protobuf:"bytes,4,opt,name=trickle,proto3,oneof"`
+}
+
+type SignalReply_IceConnectionState struct {
+	IceConnectionState string `protobuf:"bytes,5,opt,name=iceConnectionState,proto3,oneof"`
+}
+
+type SignalReply_Error struct {
+	Error string `protobuf:"bytes,6,opt,name=error,proto3,oneof"`
+}
+
+type SignalReply_Kill struct {
+	Kill bool `protobuf:"varint,7,opt,name=kill,proto3,oneof"`
+}
+
+type SignalReply_Leave struct {
+	Leave *PeerLeave `protobuf:"bytes,9,opt,name=leave,proto3,oneof"`
+}
+
+type SignalReply_Failure struct {
+	Failure *SignalError `protobuf:"bytes,10,opt,name=failure,proto3,oneof"`
+}
+
+type SignalReply_Data struct {
+	Data *DataMessage `protobuf:"bytes,11,opt,name=data,proto3,oneof"`
+}
+
+type SignalReply_Resume struct {
+	Resume bool `protobuf:"varint,12,opt,name=resume,proto3,oneof"`
+}
+
+type SignalReply_SetLayer struct {
+	SetLayer *SetLayerReply `protobuf:"bytes,13,opt,name=setLayer,proto3,oneof"`
+}
+
+type SignalReply_Play struct {
+	Play bool `protobuf:"varint,14,opt,name=play,proto3,oneof"` // the file started playing
+}
+
+type SignalReply_Reconnect struct {
+	Reconnect bool `protobuf:"varint,15,opt,name=reconnect,proto3,oneof"` // the peer's worker died, the client has to join again
+}
+
+type SignalReply_TrickleBatch struct {
+	TrickleBatch *TrickleBatch `protobuf:"bytes,16,opt,name=trickleBatch,proto3,oneof"` // candidates gathered together, see Manager.SetTrickleBatching
+}
+
+type SignalReply_Subscriptions struct {
+	Subscriptions *Subscriptions `protobuf:"bytes,17,opt,name=subscriptions,proto3,oneof"`
+}
+
+type SignalReply_ActiveSpeaker struct {
+	ActiveSpeaker *ActiveSpeaker `protobuf:"bytes,18,opt,name=activeSpeaker,proto3,oneof"` // the room's dominant speaker changed
+}
+
+type SignalReply_Announcement struct {
+	Announcement *Announcement `protobuf:"bytes,19,opt,name=announcement,proto3,oneof"` // a moderator's notice to the room
+}
+
+type SignalReply_Quality struct {
+	Quality *ConnectionQuality `protobuf:"bytes,20,opt,name=quality,proto3,oneof"` // how well the peer receives, if it asked to know
+}
+
+type SignalReply_Stats struct {
+	Stats *PeerStatsSnapshot `protobuf:"bytes,22,opt,name=stats,proto3,oneof"` // the reply to a GetStatsRequest
+}
+
+type SignalReply_Paused struct {
+	Paused *RoomPaused `protobuf:"bytes,23,opt,name=paused,proto3,oneof"` // the room's media was paused or resumed, and when joining a paused room
+}
+
+type SignalReply_Tracks struct {
+	Tracks *TrackInfos `protobuf:"bytes,24,opt,name=tracks,proto3,oneof"` // what the peer receives, sent ahead of every offer that changes it
+}
+
+type SignalReply_Moved struct {
+	Moved *RoomMoved `protobuf:"bytes,25,opt,name=moved,proto3,oneof"` // the peer is in another room now, the next offer swaps its tracks
+}
+
+type SignalReply_Layers struct {
+	Layers *TrackLayers `protobuf:"bytes,26,opt,name=layers,proto3,oneof"` // the layers a publisher in the room sends of a track changed
+}
+
+type SignalReply_Ping struct {
+	Ping uint64 `protobuf:"varint,27,opt,name=ping,proto3,oneof"` // answer with a pong of this sequence number, or be closed for a KEEPALIVE_TIMEOUT
+}
+
+type SignalReply_Capabilities struct {
+	Capabilities *Capabilities `protobuf:"bytes,28,opt,name=capabilities,proto3,oneof"` // the reply to a CapabilitiesRequest
+}
+
+func (*SignalReply_Join) isSignalReply_Payload() {}
+
+func (*SignalReply_Description) isSignalReply_Payload() {}
+
+func (*SignalReply_Trickle) isSignalReply_Payload() {}
+
+func (*SignalReply_IceConnectionState) isSignalReply_Payload() {}
+
+func (*SignalReply_Error) isSignalReply_Payload() {}
+
+func (*SignalReply_Kill) isSignalReply_Payload() {}
+
+func (*SignalReply_Leave) isSignalReply_Payload() {}
+
+func (*SignalReply_Failure) isSignalReply_Payload() {}
+
+func (*SignalReply_Data) isSignalReply_Payload() {}
+
+func (*SignalReply_Resume) isSignalReply_Payload() {}
+
+func (*SignalReply_SetLayer) isSignalReply_Payload() {}
+
+func (*SignalReply_Play) isSignalReply_Payload() {}
+
+func (*SignalReply_Reconnect) isSignalReply_Payload() {}
+
+func (*SignalReply_TrickleBatch) isSignalReply_Payload() {}
+
+func (*SignalReply_Subscriptions) isSignalReply_Payload() {}
+
+func (*SignalReply_ActiveSpeaker) isSignalReply_Payload() {}
+
+func (*SignalReply_Announcement) isSignalReply_Payload() {}
+
+func (*SignalReply_Quality) isSignalReply_Payload() {}
+
+func (*SignalReply_Stats) isSignalReply_Payload() {}
+
+func (*SignalReply_Paused) isSignalReply_Payload() {}
+
+func (*SignalReply_Tracks) isSignalReply_Payload() {}
+
+func (*SignalReply_Moved) isSignalReply_Payload() {}
+
+func (*SignalReply_Layers) isSignalReply_Payload() {}
+
+func (*SignalReply_Ping) isSignalReply_Payload() {}
+
+func (*SignalReply_Capabilities) isSignalReply_Payload() {}
+
+// CapabilitiesRequest asks what joining sid would settle, without joining, see Manager.Capabilities
+type CapabilitiesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sid   string `protobuf:"bytes,1,opt,name=sid,proto3" json:"sid,omitempty"`     // the room about to be joined, empty asks about the server alone
+	Token string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"` // the token the join would carry, checked as a join's when the manager has an Authenticator
+}
+
+func (x *CapabilitiesRequest) Reset() {
+	*x = CapabilitiesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CapabilitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapabilitiesRequest) ProtoMessage() {}
+
+func (x *CapabilitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapabilitiesRequest.ProtoReflect.Descriptor instead.
+func (*CapabilitiesRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CapabilitiesRequest) GetSid() string {
+	if x != nil {
+		return x.Sid
+	}
+	return ""
+}
+
+func (x *CapabilitiesRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// Capabilities are what a server, and the room asked about, let a peer do
+type Capabilities struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version           string            `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"` // the protocol a join would be answered in, see NegotiateProtocolVersion
+	SupportedVersions []string          `protobuf:"bytes,2,rep,name=supportedVersions,proto3" json:"supportedVersions,omitempty"`
+	Codecs            []string          `protobuf:"bytes,3,rep,name=codecs,proto3" json:"codecs,omitempty"`              // mime types a peer may negotiate in the room, or on the server
+	Recording         bool              `protobuf:"varint,4,opt,name=recording,proto3" json:"recording,omitempty"`       // the room can be recorded
+	Playback          bool              `protobuf:"varint,5,opt,name=playback,proto3" json:"playback,omitempty"`         // files can be played into the room
+	AudioMix          bool              `protobuf:"varint,6,opt,name=audioMix,proto3" json:"audioMix,omitempty"`         // a mix of the room's audio can be published
+	IceServers        []*ICEServer      `protobuf:"bytes,7,rep,name=iceServers,proto3" json:"iceServers,omitempty"`      // the STUN/TURN urls of the JoinReply, its credentials are only given with it
+	AuthRequired      bool              `protobuf:"varint,8,opt,name=authRequired,proto3" json:"authRequired,omitempty"` // joins need a token
+	Room              *RoomCapabilities `protobuf:"bytes,9,opt,name=room,proto3" json:"room,omitempty"`                  // unset when no sid was asked about
+}
+
+func (x *Capabilities) Reset() {
+	*x = Capabilities{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Capabilities) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Capabilities) ProtoMessage() {}
+
+func (x *Capabilities) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Capabilities.ProtoReflect.Descriptor instead.
+func (*Capabilities) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *Capabilities) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Capabilities) GetSupportedVersions() []string {
+	if x != nil {
+		return x.SupportedVersions
+	}
+	return nil
+}
+
+func (x *Capabilities) GetCodecs() []string {
+	if x != nil {
+		return x.Codecs
+	}
+	return nil
+}
+
+func (x *Capabilities) GetRecording() bool {
+	if x != nil {
+		return x.Recording
+	}
+	return false
+}
+
+func (x *Capabilities) GetPlayback() bool {
+	if x != nil {
+		return x.Playback
+	}
+	return false
+}
+
+func (x *Capabilities) GetAudioMix() bool {
+	if x != nil {
+		return x.AudioMix
+	}
+	return false
+}
+
+func (x *Capabilities) GetIceServers() []*ICEServer {
+	if x != nil {
+		return x.IceServers
+	}
+	return nil
+}
+
+func (x *Capabilities) GetAuthRequired() bool {
+	if x != nil {
+		return x.AuthRequired
+	}
+	return false
+}
+
+func (x *Capabilities) GetRoom() *RoomCapabilities {
+	if x != nil {
+		return x.Room
+	}
+	return nil
+}
+
+// RoomCapabilities are the settings of a room a peer has to know before joining it
+type RoomCapabilities struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Exists           bool  `protobuf:"varint,1,opt,name=exists,proto3" json:"exists,omitempty"`         // false when the room has not been created yet, the rest is then what a join creating it gets
+	AutoCreate       bool  `protobuf:"varint,2,opt,name=autoCreate,proto3" json:"autoCreate,omitempty"` // a join creates the room when it does not exist
+	AudioOnly        bool  `protobuf:"varint,3,opt,name=audioOnly,proto3" json:"audioOnly,omitempty"`
+	MaxPeers         int32 `protobuf:"varint,4,opt,name=maxPeers,proto3" json:"maxPeers,omitempty"`                 // 0 is no limit
+	PasswordRequired bool  `protobuf:"varint,5,opt,name=passwordRequired,proto3" json:"passwordRequired,omitempty"` // joins need the room's password
+	IsChannel        bool  `protobuf:"varint,6,opt,name=isChannel,proto3" json:"isChannel,omitempty"`
+	Paused           bool  `protobuf:"varint,7,opt,name=paused,proto3" json:"paused,omitempty"`
+}
+
+func (x *RoomCapabilities) Reset() {
+	*x = RoomCapabilities{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RoomCapabilities) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomCapabilities) ProtoMessage() {}
+
+func (x *RoomCapabilities) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomCapabilities.ProtoReflect.Descriptor instead.
+func (*RoomCapabilities) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RoomCapabilities) GetExists() bool {
+	if x != nil {
+		return x.Exists
+	}
+	return false
+}
+
+func (x *RoomCapabilities) GetAutoCreate() bool {
+	if x != nil {
+		return x.AutoCreate
+	}
+	return false
+}
+
+func (x *RoomCapabilities) GetAudioOnly() bool {
+	if x != nil {
+		return x.AudioOnly
+	}
+	return false
+}
+
+func (x *RoomCapabilities) GetMaxPeers() int32 {
+	if x != nil {
+		return x.MaxPeers
+	}
+	return 0
+}
+
+func (x *RoomCapabilities) GetPasswordRequired() bool {
+	if x != nil {
+		return x.PasswordRequired
+	}
+	return false
+}
+
+func (x *RoomCapabilities) GetIsChannel() bool {
+	if x != nil {
+		return x.IsChannel
+	}
+	return false
+}
+
+func (x *RoomCapabilities) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+// TrackInfos are every track a subscriber receives
+type TrackInfos struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tracks []*TrackInfo `protobuf:"bytes,1,rep,name=tracks,proto3" json:"tracks,omitempty"`
+}
+
+func (x *TrackInfos) Reset() {
+	*x = TrackInfos{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrackInfos) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackInfos) ProtoMessage() {}
+
+func (x *TrackInfos) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackInfos.ProtoReflect.Descriptor instead.
+func (*TrackInfos) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *TrackInfos) GetTracks() []*TrackInfo {
+	if x != nil {
+		return x.Tracks
+	}
+	return nil
+}
+
+// TrackInfo is who a track the subscriber receives is from
+type TrackInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mid      string `protobuf:"bytes,1,opt,name=mid,proto3" json:"mid,omitempty"` // of the transceiver in the offer the TrackInfos came ahead of
+	TrackID  string `protobuf:"bytes,2,opt,name=trackID,proto3" json:"trackID,omitempty"`
+	StreamID string `protobuf:"bytes,3,opt,name=streamID,proto3" json:"streamID,omitempty"`
+	Pid      string `protobuf:"bytes,4,opt,name=pid,proto3" json:"pid,omitempty"`     // the publisher
+	Kind     string `protobuf:"bytes,5,opt,name=kind,proto3" json:"kind,omitempty"`   // audio or video
+	Label    string `protobuf:"bytes,6,opt,name=label,proto3" json:"label,omitempty"` // the publisher's label of the stream, see SignalRequest.trackLabels
+}
+
+func (x *TrackInfo) Reset() {
+	*x = TrackInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrackInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackInfo) ProtoMessage() {}
+
+func (x *TrackInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackInfo.ProtoReflect.Descriptor instead.
+func (*TrackInfo) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *TrackInfo) GetMid() string {
+	if x != nil {
+		return x.Mid
+	}
+	return ""
+}
+
+func (x *TrackInfo) GetTrackID() string {
+	if x != nil {
+		return x.TrackID
+	}
+	return ""
+}
+
+func (x *TrackInfo) GetStreamID() string {
+	if x != nil {
+		return x.StreamID
+	}
+	return ""
+}
+
+func (x *TrackInfo) GetPid() string {
+	if x != nil {
+		return x.Pid
+	}
+	return ""
+}
+
+func (x *TrackInfo) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *TrackInfo) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+// TrackLayers are the simulcast layers the sfu gets of a publisher's video track
+type TrackLayers struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pid      string        `protobuf:"bytes,1,opt,name=pid,proto3" json:"pid,omitempty"` // the publisher
+	TrackID  string        `protobuf:"bytes,2,opt,name=trackID,proto3" json:"trackID,omitempty"`
+	StreamID string        `protobuf:"bytes,3,opt,name=streamID,proto3" json:"streamID,omitempty"`
+	Layers   []*TrackLayer `protobuf:"bytes,4,rep,name=layers,proto3" json:"layers,omitempty"` // lowest first, one for tracks that are not simulcast
+}
+
+func (x *TrackLayers) Reset() {
+	*x = TrackLayers{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrackLayers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackLayers) ProtoMessage() {}
+
+func (x *TrackLayers) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackLayers.ProtoReflect.Descriptor instead.
+func (*TrackLayers) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *TrackLayers) GetPid() string {
+	if x != nil {
+		return x.Pid
+	}
+	return ""
+}
+
+func (x *TrackLayers) GetTrackID() string {
+	if x != nil {
+		return x.TrackID
+	}
+	return ""
+}
+
+func (x *TrackLayers) GetStreamID() string {
+	if x != nil {
+		return x.StreamID
+	}
+	return ""
+}
+
+func (x *TrackLayers) GetLayers() []*TrackLayer {
+	if x != nil {
+		return x.Layers
+	}
+	return nil
+}
+
+type TrackLayer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Spatial int32  `protobuf:"varint,1,opt,name=spatial,proto3" json:"spatial,omitempty"` // 0 (low) to 2 (high), what SetLayerRequest picks
+	Rid     string `protobuf:"bytes,2,opt,name=rid,proto3" json:"rid,omitempty"`          // q, h or f for a quarter, half or full resolution, empty without simulcast
+	Active  bool   `protobuf:"varint,3,opt,name=active,proto3" json:"active,omitempty"`   // whether the publisher sends it now
+}
+
+func (x *TrackLayer) Reset() {
+	*x = TrackLayer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrackLayer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackLayer) ProtoMessage() {}
+
+func (x *TrackLayer) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackLayer.ProtoReflect.Descriptor instead.
+func (*TrackLayer) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *TrackLayer) GetSpatial() int32 {
+	if x != nil {
+		return x.Spatial
+	}
+	return 0
+}
+
+func (x *TrackLayer) GetRid() string {
+	if x != nil {
+		return x.Rid
+	}
+	return ""
+}
+
+func (x *TrackLayer) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+// MoveRequest moves a peer to the room sid without a new PeerConnection
+type MoveRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sid string `protobuf:"bytes,1,opt,name=sid,proto3" json:"sid,omitempty"`
+}
+
+func (x *MoveRequest) Reset() {
+	*x = MoveRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MoveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveRequest) ProtoMessage() {}
+
+func (x *MoveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveRequest.ProtoReflect.Descriptor instead.
+func (*MoveRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *MoveRequest) GetSid() string {
+	if x != nil {
+		return x.Sid
+	}
+	return ""
+}
+
+// RoomMoved is the room a peer left, from, for the room it is in now, to
+type RoomMoved struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+}
+
+func (x *RoomMoved) Reset() {
+	*x = RoomMoved{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RoomMoved) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomMoved) ProtoMessage() {}
+
+func (x *RoomMoved) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomMoved.ProtoReflect.Descriptor instead.
+func (*RoomMoved) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *RoomMoved) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *RoomMoved) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+// RoomPaused is whether the peer's room forwards media, by is the moderator that paused it
+type RoomPaused struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sid    string `protobuf:"bytes,1,opt,name=sid,proto3" json:"sid,omitempty"`
+	Paused bool   `protobuf:"varint,2,opt,name=paused,proto3" json:"paused,omitempty"`
+	By     string `protobuf:"bytes,3,opt,name=by,proto3" json:"by,omitempty"`
+}
+
+func (x *RoomPaused) Reset() {
+	*x = RoomPaused{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RoomPaused) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomPaused) ProtoMessage() {}
+
+func (x *RoomPaused) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomPaused.ProtoReflect.Descriptor instead.
+func (*RoomPaused) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *RoomPaused) GetSid() string {
+	if x != nil {
+		return x.Sid
+	}
+	return ""
+}
+
+func (x *RoomPaused) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *RoomPaused) GetBy() string {
+	if x != nil {
+		return x.By
+	}
+	return ""
+}
+
+// GetStatsRequest asks for the stats of the peer sending it, sampled over Manager.StatsWindow
+type GetStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsRequest) ProtoMessage() {}
+
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{48}
+}
+
+// TrackStats are a track the peer publishes, inbound, or one it is sent, outbound
+type TrackStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TrackId       string `protobuf:"bytes,1,opt,name=trackId,proto3" json:"trackId,omitempty"`
+	StreamId      string `protobuf:"bytes,2,opt,name=streamId,proto3" json:"streamId,omitempty"`
+	Kind          string `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"` // audio or video
+	Inbound       bool   `protobuf:"varint,4,opt,name=inbound,proto3" json:"inbound,omitempty"`
+	Packets       uint64 `protobuf:"varint,5,opt,name=packets,proto3" json:"packets,omitempty"`
+	PacketsLost   uint64 `protobuf:"varint,6,opt,name=packetsLost,proto3" json:"packetsLost,omitempty"`      // inbound, as the sfu counted them
+	JitterMs      int32  `protobuf:"varint,7,opt,name=jitterMs,proto3" json:"jitterMs,omitempty"`            // inbound, the worst of its layers
+	Bytes         uint64 `protobuf:"varint,8,opt,name=bytes,proto3" json:"bytes,omitempty"`                  // outbound
+	Layers        int32  `protobuf:"varint,9,opt,name=layers,proto3" json:"layers,omitempty"`                // inbound, the simulcast layers published, 1 without simulcast
+	SpatialLayer  int32  `protobuf:"varint,10,opt,name=spatialLayer,proto3" json:"spatialLayer,omitempty"`   // outbound, the simulcast layer sent, -1 without simulcast
+	TargetBitrate uint64 `protobuf:"varint,11,opt,name=targetBitrate,proto3" json:"targetBitrate,omitempty"` // inbound, bits per second its subscribers' estimates allow
+}
+
+func (x *TrackStats) Reset() {
+	*x = TrackStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrackStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackStats) ProtoMessage() {}
+
+func (x *TrackStats) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackStats.ProtoReflect.Descriptor instead.
+func (*TrackStats) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *TrackStats) GetTrackId() string {
+	if x != nil {
+		return x.TrackId
+	}
+	return ""
+}
+
+func (x *TrackStats) GetStreamId() string {
+	if x != nil {
+		return x.StreamId
+	}
+	return ""
+}
+
+func (x *TrackStats) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *TrackStats) GetInbound() bool {
+	if x != nil {
+		return x.Inbound
+	}
+	return false
+}
+
+func (x *TrackStats) GetPackets() uint64 {
+	if x != nil {
+		return x.Packets
+	}
+	return 0
+}
+
+func (x *TrackStats) GetPacketsLost() uint64 {
+	if x != nil {
+		return x.PacketsLost
+	}
+	return 0
+}
+
+func (x *TrackStats) GetJitterMs() int32 {
+	if x != nil {
+		return x.JitterMs
+	}
+	return 0
+}
+
+func (x *TrackStats) GetBytes() uint64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *TrackStats) GetLayers() int32 {
+	if x != nil {
+		return x.Layers
+	}
+	return 0
+}
+
+func (x *TrackStats) GetSpatialLayer() int32 {
+	if x != nil {
+		return x.SpatialLayer
+	}
+	return 0
+}
+
+func (x *TrackStats) GetTargetBitrate() uint64 {
+	if x != nil {
+		return x.TargetBitrate
+	}
+	return 0
+}
+
+// PeerStatsSnapshot is a peer's transports and tracks, rates are over the sample window
+type PeerStatsSnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Totals          *PeerStats    `protobuf:"bytes,1,opt,name=totals,proto3" json:"totals,omitempty"`
+	WindowMs        int32         `protobuf:"varint,2,opt,name=windowMs,proto3" json:"windowMs,omitempty"`
+	InboundBitrate  uint64        `protobuf:"varint,3,opt,name=inboundBitrate,proto3" json:"inboundBitrate,omitempty"`   // bits per second the peer sent the sfu
+	OutboundBitrate uint64        `protobuf:"varint,4,opt,name=outboundBitrate,proto3" json:"outboundBitrate,omitempty"` // bits per second the sfu sent the peer
+	FractionLost    float32       `protobuf:"fixed32,5,opt,name=fractionLost,proto3" json:"fractionLost,omitempty"`      // of the rtp the peer published
+	JitterMs        int32         `protobuf:"varint,6,opt,name=jitterMs,proto3" json:"jitterMs,omitempty"`               // the worst of its published tracks
+	RttMs           int32         `protobuf:"varint,7,opt,name=rttMs,proto3" json:"rttMs,omitempty"`                     // the worst of its ICE candidate pairs
+	Tracks          []*TrackStats `protobuf:"bytes,8,rep,name=tracks,proto3" json:"tracks,omitempty"`
+}
+
+func (x *PeerStatsSnapshot) Reset() {
+	*x = PeerStatsSnapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerStatsSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerStatsSnapshot) ProtoMessage() {}
+
+func (x *PeerStatsSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerStatsSnapshot.ProtoReflect.Descriptor instead.
+func (*PeerStatsSnapshot) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *PeerStatsSnapshot) GetTotals() *PeerStats {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+func (x *PeerStatsSnapshot) GetWindowMs() int32 {
+	if x != nil {
+		return x.WindowMs
+	}
+	return 0
+}
+
+func (x *PeerStatsSnapshot) GetInboundBitrate() uint64 {
+	if x != nil {
+		return x.InboundBitrate
+	}
+	return 0
+}
+
+func (x *PeerStatsSnapshot) GetOutboundBitrate() uint64 {
+	if x != nil {
+		return x.OutboundBitrate
+	}
+	return 0
+}
+
+func (x *PeerStatsSnapshot) GetFractionLost() float32 {
+	if x != nil {
+		return x.FractionLost
+	}
+	return 0
+}
+
+func (x *PeerStatsSnapshot) GetJitterMs() int32 {
+	if x != nil {
+		return x.JitterMs
+	}
+	return 0
+}
+
+func (x *PeerStatsSnapshot) GetRttMs() int32 {
+	if x != nil {
+		return x.RttMs
+	}
+	return 0
+}
+
+func (x *PeerStatsSnapshot) GetTracks() []*TrackStats {
+	if x != nil {
+		return x.Tracks
+	}
+	return nil
+}
+
+// ConnectionQuality estimates a subscriber's downlink from the receiver reports it sent,
+// the worst of its tracks since the last estimate
+type ConnectionQuality struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Level        ConnectionQuality_Level `protobuf:"varint,1,opt,name=level,proto3,enum=noir.ConnectionQuality_Level" json:"level,omitempty"`
+	FractionLost float32                 `protobuf:"fixed32,2,opt,name=fractionLost,proto3" json:"fractionLost,omitempty"` // 0 to 1
+	RttMs        int32                   `protobuf:"varint,3,opt,name=rttMs,proto3" json:"rttMs,omitempty"`                // 0 when not known yet
+	JitterMs     int32                   `protobuf:"varint,4,opt,name=jitterMs,proto3" json:"jitterMs,omitempty"`
+}
+
+func (x *ConnectionQuality) Reset() {
+	*x = ConnectionQuality{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConnectionQuality) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectionQuality) ProtoMessage() {}
+
+func (x *ConnectionQuality) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectionQuality.ProtoReflect.Descriptor instead.
+func (*ConnectionQuality) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ConnectionQuality) GetLevel() ConnectionQuality_Level {
+	if x != nil {
+		return x.Level
+	}
+	return ConnectionQuality_GOOD
+}
+
+func (x *ConnectionQuality) GetFractionLost() float32 {
+	if x != nil {
+		return x.FractionLost
+	}
+	return 0
+}
+
+func (x *ConnectionQuality) GetRttMs() int32 {
+	if x != nil {
+		return x.RttMs
+	}
+	return 0
+}
+
+func (x *ConnectionQuality) GetJitterMs() int32 {
+	if x != nil {
+		return x.JitterMs
+	}
+	return 0
+}
+
+// Announcement is a notice a moderator or admin sends everyone in a room
+type Announcement struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Text     string                `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Severity Announcement_Severity `protobuf:"varint,2,opt,name=severity,proto3,enum=noir.Announcement_Severity" json:"severity,omitempty"`
+	From     string                `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"` // the moderator's pid, empty when sent by an admin
+}
+
+func (x *Announcement) Reset() {
+	*x = Announcement{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Announcement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Announcement) ProtoMessage() {}
+
+func (x *Announcement) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Announcement.ProtoReflect.Descriptor instead.
+func (*Announcement) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *Announcement) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Announcement) GetSeverity() Announcement_Severity {
+	if x != nil {
+		return x.Severity
+	}
+	return Announcement_INFO
+}
+
+func (x *Announcement) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+type ActiveSpeaker struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pid        string `protobuf:"bytes,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	AudioLevel int32  `protobuf:"varint,2,opt,name=audioLevel,proto3" json:"audioLevel,omitempty"` // in -dBov, 0 is the loudest and 127 silence
+}
+
+func (x *ActiveSpeaker) Reset() {
+	*x = ActiveSpeaker{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActiveSpeaker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActiveSpeaker) ProtoMessage() {}
+
+func (x *ActiveSpeaker) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActiveSpeaker.ProtoReflect.Descriptor instead.
+func (*ActiveSpeaker) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ActiveSpeaker) GetPid() string {
+	if x != nil {
+		return x.Pid
+	}
+	return ""
+}
+
+func (x *ActiveSpeaker) GetAudioLevel() int32 {
+	if x != nil {
+		return x.AudioLevel
+	}
+	return 0
+}
+
+type SubscribeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pids []string `protobuf:"bytes,1,rep,name=pids,proto3" json:"pids,omitempty"`
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *SubscribeRequest) GetPids() []string {
+	if x != nil {
+		return x.Pids
+	}
+	return nil
+}
+
+// Subscriptions are the publishers a peer receives, in reply to subscribe and unsubscribe,
+// or unasked with left set when one of them left the room
+type Subscriptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pids   []string `protobuf:"bytes,1,rep,name=pids,proto3" json:"pids,omitempty"`
+	Left   string   `protobuf:"bytes,2,opt,name=left,proto3" json:"left,omitempty"`
+	Capped []string `protobuf:"bytes,3,rep,name=capped,proto3" json:"capped,omitempty"` // publishers not subscribed because the peer is at its room's maxSubscribedTracks
+}
+
+func (x *Subscriptions) Reset() {
+	*x = Subscriptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Subscriptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Subscriptions) ProtoMessage() {}
+
+func (x *Subscriptions) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Subscriptions.ProtoReflect.Descriptor instead.
+func (*Subscriptions) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *Subscriptions) GetPids() []string {
+	if x != nil {
+		return x.Pids
+	}
+	return nil
+}
+
+func (x *Subscriptions) GetLeft() string {
+	if x != nil {
+		return x.Left
+	}
+	return ""
+}
+
+func (x *Subscriptions) GetCapped() []string {
+	if x != nil {
+		return x.Capped
+	}
+	return nil
+}
+
+type JoinRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sid             string         `protobuf:"bytes,1,opt,name=sid,proto3" json:"sid,omitempty"`
+	Description     []byte         `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Limits          *BitrateLimits `protobuf:"bytes,3,opt,name=limits,proto3" json:"limits,omitempty"`                                         // optional, server defaults apply when unset
+	Token           string         `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"`                                           // opaque, checked by the manager's Authenticator
+	Role            Role           `protobuf:"varint,5,opt,name=role,proto3,enum=noir.Role" json:"role,omitempty"`                             // asked for, the Authenticator decides if it is granted
+	RateKey         string         `protobuf:"bytes,6,opt,name=rateKey,proto3" json:"rateKey,omitempty"`                                       // optional, joins without an identity are rate limited by it, eg. the client's IP
+	Password        string         `protobuf:"bytes,7,opt,name=password,proto3" json:"password,omitempty"`                                     // has to match the room's password when it has one
+	QualityReports  bool           `protobuf:"varint,8,opt,name=qualityReports,proto3" json:"qualityReports,omitempty"`                        // send the peer a ConnectionQuality every Manager.QualityInterval
+	Compression     []Compression  `protobuf:"varint,9,rep,packed,name=compression,proto3,enum=noir.Compression" json:"compression,omitempty"` // the compressions the client reads its replies in, none sends them as is
+	Keepalive       bool           `protobuf:"varint,10,opt,name=keepalive,proto3" json:"keepalive,omitempty"`                                 // ping the peer every Manager.Keepalive interval, see SignalReply.ping
+	Subscribe       []string       `protobuf:"bytes,11,rep,name=subscribe,proto3" json:"subscribe,omitempty"`                                  // receive only these publishers from the first negotiation, each has to be in the room
+	NoAutoSubscribe bool           `protobuf:"varint,12,opt,name=noAutoSubscribe,proto3" json:"noAutoSubscribe,omitempty"`                     // with no subscribe, receive no one until the peer subscribes
+	AdaptiveLayers  bool           `protobuf:"varint,13,opt,name=adaptiveLayers,proto3" json:"adaptiveLayers,omitempty"`                       // switch the simulcast layers the peer receives to fit its downlink, see Manager.SetAdaptiveLayers
+	ReplyTo         string         `protobuf:"bytes,14,opt,name=replyTo,proto3" json:"replyTo,omitempty"`                                      // optional, the topic the join's answer and refusals go to instead of its pid's, eg. so a PEER_ID_TAKEN is not read by the client of the connected peer
+}
+
+func (x *JoinRequest) Reset() {
+	*x = JoinRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[56]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JoinRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinRequest) ProtoMessage() {}
+
+func (x *JoinRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[56]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinRequest.ProtoReflect.Descriptor instead.
+func (*JoinRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *JoinRequest) GetSid() string {
+	if x != nil {
+		return x.Sid
+	}
+	return ""
+}
+
+func (x *JoinRequest) GetDescription() []byte {
+	if x != nil {
+		return x.Description
+	}
+	return nil
+}
+
+func (x *JoinRequest) GetLimits() *BitrateLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *JoinRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *JoinRequest) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_PUBLISHER
+}
+
+func (x *JoinRequest) GetRateKey() string {
+	if x != nil {
+		return x.RateKey
+	}
+	return ""
+}
+
+func (x *JoinRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *JoinRequest) GetQualityReports() bool {
+	if x != nil {
+		return x.QualityReports
+	}
+	return false
+}
+
+func (x *JoinRequest) GetCompression() []Compression {
+	if x != nil {
+		return x.Compression
+	}
+	return nil
+}
+
+func (x *JoinRequest) GetKeepalive() bool {
+	if x != nil {
+		return x.Keepalive
+	}
+	return false
+}
+
+func (x *JoinRequest) GetSubscribe() []string {
+	if x != nil {
+		return x.Subscribe
+	}
+	return nil
+}
+
+func (x *JoinRequest) GetNoAutoSubscribe() bool {
+	if x != nil {
+		return x.NoAutoSubscribe
+	}
+	return false
+}
+
+func (x *JoinRequest) GetAdaptiveLayers() bool {
+	if x != nil {
+		return x.AdaptiveLayers
+	}
+	return false
+}
+
+func (x *JoinRequest) GetReplyTo() string {
+	if x != nil {
+		return x.ReplyTo
+	}
+	return ""
+}
+
+// Bitrate caps in kbps, 0 means no limit
+type BitrateLimits struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PublishKbps   uint32 `protobuf:"varint,1,opt,name=publishKbps,proto3" json:"publishKbps,omitempty"`     // inbound from the peer
+	SubscribeKbps uint32 `protobuf:"varint,2,opt,name=subscribeKbps,proto3" json:"subscribeKbps,omitempty"` // outbound to the peer
+}
+
+func (x *BitrateLimits) Reset() {
+	*x = BitrateLimits{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[57]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BitrateLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BitrateLimits) ProtoMessage() {}
+
+func (x *BitrateLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[57]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BitrateLimits.ProtoReflect.Descriptor instead.
+func (*BitrateLimits) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *BitrateLimits) GetPublishKbps() uint32 {
+	if x != nil {
+		return x.PublishKbps
+	}
+	return 0
+}
+
+func (x *BitrateLimits) GetSubscribeKbps() uint32 {
+	if x != nil {
+		return x.SubscribeKbps
+	}
+	return 0
+}
+
+type JoinReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Description []byte       `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	IceServers  []*ICEServer `protobuf:"bytes,2,rep,name=iceServers,proto3" json:"iceServers,omitempty"`   // optional, STUN/TURN servers the peer should use
+	Version     string       `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`         // the protocol version replies to the peer are framed in
+	Pid         string       `protobuf:"bytes,4,opt,name=pid,proto3" json:"pid,omitempty"`                 // the peer's id from now on, when the server assigns them, see Manager.SetPeerIDMode
+	ResumeToken string       `protobuf:"bytes,5,opt,name=resumeToken,proto3" json:"resumeToken,omitempty"` // the secret a client resumes the peer with, see Manager.ResumeClient
+}
+
+func (x *JoinReply) Reset() {
+	*x = JoinReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[58]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JoinReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinReply) ProtoMessage() {}
+
+func (x *JoinReply) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[58]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinReply.ProtoReflect.Descriptor instead.
+func (*JoinReply) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *JoinReply) GetDescription() []byte {
+	if x != nil {
+		return x.Description
+	}
+	return nil
+}
+
+func (x *JoinReply) GetIceServers() []*ICEServer {
+	if x != nil {
+		return x.IceServers
+	}
+	return nil
+}
+
+func (x *JoinReply) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *JoinReply) GetPid() string {
+	if x != nil {
+		return x.Pid
+	}
+	return ""
+}
+
+func (x *JoinReply) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+type ICEServer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Urls       []string             `protobuf:"bytes,1,rep,name=urls,proto3" json:"urls,omitempty"`
+	Username   string               `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Credential string               `protobuf:"bytes,3,opt,name=credential,proto3" json:"credential,omitempty"`
+	Expires    *timestamp.Timestamp `protobuf:"bytes,4,opt,name=expires,proto3" json:"expires,omitempty"` // when the credential stops working, refresh before then
+}
+
+func (x *ICEServer) Reset() {
+	*x = ICEServer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[59]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ICEServer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ICEServer) ProtoMessage() {}
+
+func (x *ICEServer) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[59]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ICEServer.ProtoReflect.Descriptor instead.
+func (*ICEServer) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ICEServer) GetUrls() []string {
+	if x != nil {
+		return x.Urls
+	}
+	return nil
+}
+
+func (x *ICEServer) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ICEServer) GetCredential() string {
+	if x != nil {
+		return x.Credential
+	}
+	return ""
+}
+
+func (x *ICEServer) GetExpires() *timestamp.Timestamp {
+	if x != nil {
+		return x.Expires
+	}
+	return nil
+}
+
+// ICEPolicy drops the ICE candidates that do not match it, both ways
+type ICEPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RelayOnly bool `protobuf:"varint,1,opt,name=relayOnly,proto3" json:"relayOnly,omitempty"`
+	TcpOnly   bool `protobuf:"varint,2,opt,name=tcpOnly,proto3" json:"tcpOnly,omitempty"`
+	NoHost    bool `protobuf:"varint,3,opt,name=noHost,proto3" json:"noHost,omitempty"` // keeps peers' local addresses private
+}
+
+func (x *ICEPolicy) Reset() {
+	*x = ICEPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[60]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ICEPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ICEPolicy) ProtoMessage() {}
+
+func (x *ICEPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[60]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ICEPolicy.ProtoReflect.Descriptor instead.
+func (*ICEPolicy) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ICEPolicy) GetRelayOnly() bool {
+	if x != nil {
+		return x.RelayOnly
+	}
+	return false
+}
+
+func (x *ICEPolicy) GetTcpOnly() bool {
+	if x != nil {
+		return x.TcpOnly
+	}
+	return false
+}
+
+func (x *ICEPolicy) GetNoHost() bool {
+	if x != nil {
+		return x.NoHost
+	}
+	return false
+}
+
+// RTCPPolicy tunes how lost packets and keyframes are asked for, the zero policy is ion-sfu's own
+type RTCPPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DisableNack      bool                   `protobuf:"varint,1,opt,name=disableNack,proto3" json:"disableNack,omitempty"`           // lost packets are neither asked for again nor resent
+	MaxRetransmits   int32                  `protobuf:"varint,2,opt,name=maxRetransmits,proto3" json:"maxRetransmits,omitempty"`     // times one packet is resent to a subscriber, 0 is no cap
+	MinPliIntervalMs int32                  `protobuf:"varint,3,opt,name=minPliIntervalMs,proto3" json:"minPliIntervalMs,omitempty"` // between the keyframes asked of a publisher's track, ion-sfu waits 500ms at least
+	Aggregation      RTCPPolicy_Aggregation `protobuf:"varint,4,opt,name=aggregation,proto3,enum=noir.RTCPPolicy_Aggregation" json:"aggregation,omitempty"`
+	BufferMs         int32                  `protobuf:"varint,5,opt,name=bufferMs,proto3" json:"bufferMs,omitempty"` // how long a publisher's packets are kept to be resent, 0 is ion-sfu's 1000ms
+}
+
+func (x *RTCPPolicy) Reset() {
+	*x = RTCPPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[61]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RTCPPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RTCPPolicy) ProtoMessage() {}
+
+func (x *RTCPPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[61]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RTCPPolicy.ProtoReflect.Descriptor instead.
+func (*RTCPPolicy) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *RTCPPolicy) GetDisableNack() bool {
+	if x != nil {
+		return x.DisableNack
+	}
+	return false
+}
+
+func (x *RTCPPolicy) GetMaxRetransmits() int32 {
+	if x != nil {
+		return x.MaxRetransmits
+	}
+	return 0
+}
+
+func (x *RTCPPolicy) GetMinPliIntervalMs() int32 {
+	if x != nil {
+		return x.MinPliIntervalMs
+	}
+	return 0
+}
+
+func (x *RTCPPolicy) GetAggregation() RTCPPolicy_Aggregation {
+	if x != nil {
+		return x.Aggregation
+	}
+	return RTCPPolicy_NONE
+}
+
+func (x *RTCPPolicy) GetBufferMs() int32 {
+	if x != nil {
+		return x.BufferMs
+	}
+	return 0
+}
+
+// SDPLimits bound the offer a peer joins with, 0 keeps the manager's limit and a negative one is no limit
+type SDPLimits struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MaxBytes         int32 `protobuf:"varint,1,opt,name=maxBytes,proto3" json:"maxBytes,omitempty"`
+	MaxMediaSections int32 `protobuf:"varint,2,opt,name=maxMediaSections,proto3" json:"maxMediaSections,omitempty"` // m-lines
+	MaxCodecs        int32 `protobuf:"varint,3,opt,name=maxCodecs,proto3" json:"maxCodecs,omitempty"`               // rtpmap lines, over all media sections
+	MaxCandidates    int32 `protobuf:"varint,4,opt,name=maxCandidates,proto3" json:"maxCandidates,omitempty"`
+}
+
+func (x *SDPLimits) Reset() {
+	*x = SDPLimits{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[62]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SDPLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SDPLimits) ProtoMessage() {}
+
+func (x *SDPLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[62]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SDPLimits.ProtoReflect.Descriptor instead.
+func (*SDPLimits) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *SDPLimits) GetMaxBytes() int32 {
+	if x != nil {
+		return x.MaxBytes
+	}
+	return 0
+}
+
+func (x *SDPLimits) GetMaxMediaSections() int32 {
+	if x != nil {
+		return x.MaxMediaSections
+	}
+	return 0
+}
+
+func (x *SDPLimits) GetMaxCodecs() int32 {
+	if x != nil {
+		return x.MaxCodecs
+	}
+	return 0
+}
+
+func (x *SDPLimits) GetMaxCandidates() int32 {
+	if x != nil {
+		return x.MaxCandidates
+	}
+	return 0
+}
+
+// SignalError is a machine-readable reason a signal request failed
+type SignalError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code         SignalError_Code `protobuf:"varint,1,opt,name=code,proto3,enum=noir.SignalError_Code" json:"code,omitempty"`
+	Message      string           `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	RetryAfterMs int64            `protobuf:"varint,3,opt,name=retryAfterMs,proto3" json:"retryAfterMs,omitempty"` // optional, when it is worth trying again
+}
+
+func (x *SignalError) Reset() {
+	*x = SignalError{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[63]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignalError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalError) ProtoMessage() {}
+
+func (x *SignalError) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[63]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalError.ProtoReflect.Descriptor instead.
+func (*SignalError) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *SignalError) GetCode() SignalError_Code {
+	if x != nil {
+		return x.Code
+	}
+	return SignalError_UNKNOWN
+}
+
+func (x *SignalError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SignalError) GetRetryAfterMs() int64 {
+	if x != nil {
+		return x.RetryAfterMs
+	}
+	return 0
+}
+
+// MuteRequest stops forwarding this peer's published tracks of a kind to the rest of the room
+type MuteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind  string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"` // audio or video
+	Muted bool   `protobuf:"varint,2,opt,name=muted,proto3" json:"muted,omitempty"`
+}
+
+func (x *MuteRequest) Reset() {
+	*x = MuteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[64]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MuteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MuteRequest) ProtoMessage() {}
+
+func (x *MuteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[64]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MuteRequest.ProtoReflect.Descriptor instead.
+func (*MuteRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *MuteRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *MuteRequest) GetMuted() bool {
+	if x != nil {
+		return x.Muted
+	}
+	return false
+}
+
+// PlayRequest streams an IVF (VP8/VP9) or Ogg (Opus) file into a room as a publishing peer
+type PlayRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sid      string `protobuf:"bytes,1,opt,name=sid,proto3" json:"sid,omitempty"`
+	Filename string `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"` // a file under the manager's playback dir, see Manager.PlaybackPath
+	Repeat   bool   `protobuf:"varint,3,opt,name=repeat,proto3" json:"repeat,omitempty"`    // loop until the pid is killed
+	StartMs  int64  `protobuf:"varint,4,opt,name=startMs,proto3" json:"startMs,omitempty"`  // where in the file playing starts, past its end is an INVALID_REQUEST
+	EndMs    int64  `protobuf:"varint,5,opt,name=endMs,proto3" json:"endMs,omitempty"`      // where it stops, 0 is the end of the file, repeat loops the segment between them
+	Token    string `protobuf:"bytes,6,opt,name=token,proto3" json:"token,omitempty"`       // the token a join would carry, checked as a join's when the manager has an Authenticator
+}
+
+func (x *PlayRequest) Reset() {
+	*x = PlayRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[65]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayRequest) ProtoMessage() {}
+
+func (x *PlayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[65]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayRequest.ProtoReflect.Descriptor instead.
+func (*PlayRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *PlayRequest) GetSid() string {
+	if x != nil {
+		return x.Sid
+	}
+	return ""
+}
+
+func (x *PlayRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *PlayRequest) GetRepeat() bool {
+	if x != nil {
+		return x.Repeat
+	}
+	return false
+}
+
+func (x *PlayRequest) GetStartMs() int64 {
+	if x != nil {
+		return x.StartMs
+	}
+	return 0
+}
+
+func (x *PlayRequest) GetEndMs() int64 {
+	if x != nil {
+		return x.EndMs
+	}
+	return 0
+}
+
+func (x *PlayRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// SetLayerRequest asks for a simulcast layer of a stream this peer is subscribed to
+type SetLayerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StreamId string `protobuf:"bytes,1,opt,name=streamId,proto3" json:"streamId,omitempty"`
+	Spatial  int32  `protobuf:"varint,2,opt,name=spatial,proto3" json:"spatial,omitempty"` // 0 is the lowest resolution
+	Temporal int32  `protobuf:"varint,3,opt,name=temporal,proto3" json:"temporal,omitempty"`
+}
+
+func (x *SetLayerRequest) Reset() {
+	*x = SetLayerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[66]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetLayerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLayerRequest) ProtoMessage() {}
+
+func (x *SetLayerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[66]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLayerRequest.ProtoReflect.Descriptor instead.
+func (*SetLayerRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *SetLayerRequest) GetStreamId() string {
+	if x != nil {
+		return x.StreamId
+	}
+	return ""
+}
+
+func (x *SetLayerRequest) GetSpatial() int32 {
+	if x != nil {
+		return x.Spatial
+	}
+	return 0
+}
+
+func (x *SetLayerRequest) GetTemporal() int32 {
+	if x != nil {
+		return x.Temporal
+	}
+	return 0
+}
+
+// SetLayerReply has the layers actually being sent, which can differ from the request
+type SetLayerReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StreamId     string `protobuf:"bytes,1,opt,name=streamId,proto3" json:"streamId,omitempty"`
+	Spatial      int32  `protobuf:"varint,2,opt,name=spatial,proto3" json:"spatial,omitempty"`
+	Temporal     int32  `protobuf:"varint,3,opt,name=temporal,proto3" json:"temporal,omitempty"`
+	Adapted      bool   `protobuf:"varint,4,opt,name=adapted,proto3" json:"adapted,omitempty"`           // sent unasked, the peer's adaptive layers switched the stream
+	EstimateKbps uint32 `protobuf:"varint,5,opt,name=estimateKbps,proto3" json:"estimateKbps,omitempty"` // with adapted, the downlink estimate the stream was switched for
+}
+
+func (x *SetLayerReply) Reset() {
+	*x = SetLayerReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[67]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetLayerReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLayerReply) ProtoMessage() {}
+
+func (x *SetLayerReply) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[67]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLayerReply.ProtoReflect.Descriptor instead.
+func (*SetLayerReply) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *SetLayerReply) GetStreamId() string {
+	if x != nil {
+		return x.StreamId
+	}
+	return ""
+}
+
+func (x *SetLayerReply) GetSpatial() int32 {
+	if x != nil {
+		return x.Spatial
+	}
+	return 0
+}
+
+func (x *SetLayerReply) GetTemporal() int32 {
+	if x != nil {
+		return x.Temporal
+	}
+	return 0
+}
+
+func (x *SetLayerReply) GetAdapted() bool {
+	if x != nil {
+		return x.Adapted
+	}
+	return false
+}
+
+func (x *SetLayerReply) GetEstimateKbps() uint32 {
+	if x != nil {
+		return x.EstimateKbps
+	}
+	return 0
+}
+
+// DataMessage is relayed between peers in a room through noir, not the sfu
+type DataMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Label   string `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	Payload []byte `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	To      string `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`     // target pid, empty broadcasts to the whole room
+	From    string `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"` // set by noir
+}
+
+func (x *DataMessage) Reset() {
+	*x = DataMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[68]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DataMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataMessage) ProtoMessage() {}
+
+func (x *DataMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[68]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataMessage.ProtoReflect.Descriptor instead.
+func (*DataMessage) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *DataMessage) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *DataMessage) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *DataMessage) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *DataMessage) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+// PeerLeave tells the rest of the room that a peer left on its own (vs kill)
+type PeerLeave struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pid    string      `protobuf:"bytes,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	Sid    string      `protobuf:"bytes,2,opt,name=sid,proto3" json:"sid,omitempty"`
+	Reason CloseReason `protobuf:"varint,3,opt,name=reason,proto3,enum=noir.CloseReason" json:"reason,omitempty"`
+}
+
+func (x *PeerLeave) Reset() {
+	*x = PeerLeave{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[69]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerLeave) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerLeave) ProtoMessage() {}
+
+func (x *PeerLeave) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[69]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerLeave.ProtoReflect.Descriptor instead.
+func (*PeerLeave) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *PeerLeave) GetPid() string {
+	if x != nil {
+		return x.Pid
+	}
+	return ""
+}
+
+func (x *PeerLeave) GetSid() string {
+	if x != nil {
+		return x.Sid
+	}
+	return ""
+}
+
+func (x *PeerLeave) GetReason() CloseReason {
+	if x != nil {
+		return x.Reason
+	}
+	return CloseReason_UNKNOWN
+}
+
+type Trickle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Target Trickle_Target `protobuf:"varint,1,opt,name=target,proto3,enum=noir.Trickle_Target" json:"target,omitempty"`
+	Init   string         `protobuf:"bytes,2,opt,name=init,proto3" json:"init,omitempty"`
+}
+
+func (x *Trickle) Reset() {
+	*x = Trickle{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[70]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Trickle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trickle) ProtoMessage() {}
+
+func (x *Trickle) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[70]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trickle.ProtoReflect.Descriptor instead.
+func (*Trickle) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *Trickle) GetTarget() Trickle_Target {
+	if x != nil {
+		return x.Target
+	}
+	return Trickle_PUBLISHER
+}
+
+func (x *Trickle) GetInit() string {
+	if x != nil {
+		return x.Init
+	}
+	return ""
+}
+
+// TrickleBatch saves a queue round trip per candidate while ICE gathers
+type TrickleBatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Candidates []*Trickle `protobuf:"bytes,1,rep,name=candidates,proto3" json:"candidates,omitempty"`
+}
+
+func (x *TrickleBatch) Reset() {
+	*x = TrickleBatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[71]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrickleBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrickleBatch) ProtoMessage() {}
+
+func (x *TrickleBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[71]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrickleBatch.ProtoReflect.Descriptor instead.
+func (*TrickleBatch) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *TrickleBatch) GetCandidates() []*Trickle {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+type NoirObject struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Data:
+	//	*NoirObject_Node
+	//	*NoirObject_Room
+	//	*NoirObject_User
+	//	*NoirObject_Heartbeat
+	Data isNoirObject_Data `protobuf_oneof:"data"`
+}
+
+func (x *NoirObject) Reset() {
+	*x = NoirObject{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[72]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NoirObject) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoirObject) ProtoMessage() {}
+
+func (x *NoirObject) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[72]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoirObject.ProtoReflect.Descriptor instead.
+func (*NoirObject) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{72}
+}
+
+func (m *NoirObject) GetData() isNoirObject_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (x *NoirObject) GetNode() *NodeData {
+	if x, ok := x.GetData().(*NoirObject_Node); ok {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *NoirObject) GetRoom() *RoomData {
+	if x, ok := x.GetData().(*NoirObject_Room); ok {
+		return x.Room
+	}
+	return nil
+}
+
+func (x *NoirObject) GetUser() *UserData {
+	if x, ok := x.GetData().(*NoirObject_User); ok {
+		return x.User
+	}
+	return nil
+}
+
+func (x *NoirObject) GetHeartbeat() *WorkerHeartbeat {
+	if x, ok := x.GetData().(*NoirObject_Heartbeat); ok {
+		return x.Heartbeat
+	}
+	return nil
+}
+
+type isNoirObject_Data interface {
+	isNoirObject_Data()
+}
+
+type NoirObject_Node struct {
+	Node *NodeData `protobuf:"bytes,1,opt,name=node,proto3,oneof"`
+}
+
+type NoirObject_Room struct {
+	Room *RoomData `protobuf:"bytes,2,opt,name=room,proto3,oneof"`
+}
+
+type NoirObject_User struct {
+	User *UserData `protobuf:"bytes,3,opt,name=user,proto3,oneof"`
+}
+
+type NoirObject_Heartbeat struct {
+	Heartbeat *WorkerHeartbeat `protobuf:"bytes,4,opt,name=heartbeat,proto3,oneof"`
+}
+
+func (*NoirObject_Node) isNoirObject_Data() {}
+
+func (*NoirObject_Room) isNoirObject_Data() {}
+
+func (*NoirObject_User) isNoirObject_Data() {}
+
+func (*NoirObject_Heartbeat) isNoirObject_Data() {}
+
+// DeadLetter is a message a worker could not parse, kept so it can be inspected
+type DeadLetter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Topic   string               `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	Error   string               `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	At      *timestamp.Timestamp `protobuf:"bytes,3,opt,name=at,proto3" json:"at,omitempty"`
+	Message []byte               `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *DeadLetter) Reset() {
+	*x = DeadLetter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[73]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeadLetter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadLetter) ProtoMessage() {}
+
+func (x *DeadLetter) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[73]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadLetter.ProtoReflect.Descriptor instead.
+func (*DeadLetter) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *DeadLetter) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *DeadLetter) GetMessage() []byte {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+// SignalTrace is a request or reply of a traced room, see Manager.GetSignalingTrace
+type SignalTrace struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	At     *timestamp.Timestamp `protobuf:"bytes,1,opt,name=at,proto3" json:"at,omitempty"`
+	Topic  string               `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	Pid    string               `protobuf:"bytes,3,opt,name=pid,proto3" json:"pid,omitempty"`
+	Action string               `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	// Types that are assignable to Message:
+	//	*SignalTrace_Request
+	//	*SignalTrace_Reply
+	Message isSignalTrace_Message `protobuf_oneof:"message"`
+}
+
+func (x *SignalTrace) Reset() {
+	*x = SignalTrace{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[74]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignalTrace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalTrace) ProtoMessage() {}
+
+func (x *SignalTrace) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[74]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalTrace.ProtoReflect.Descriptor instead.
+func (*SignalTrace) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *SignalTrace) GetAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *SignalTrace) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *SignalTrace) GetPid() string {
+	if x != nil {
+		return x.Pid
+	}
+	return ""
+}
+
+func (x *SignalTrace) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (m *SignalTrace) GetMessage() isSignalTrace_Message {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (x *SignalTrace) GetRequest() *NoirRequest {
+	if x, ok := x.GetMessage().(*SignalTrace_Request); ok {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *SignalTrace) GetReply() *NoirReply {
+	if x, ok := x.GetMessage().(*SignalTrace_Reply); ok {
+		return x.Reply
+	}
+	return nil
+}
+
+type isSignalTrace_Message interface {
+	isSignalTrace_Message()
+}
+
+type SignalTrace_Request struct {
+	Request *NoirRequest `protobuf:"bytes,5,opt,name=request,proto3,oneof"`
+}
+
+type SignalTrace_Reply struct {
+	Reply *NoirReply `protobuf:"bytes,6,opt,name=reply,proto3,oneof"`
+}
+
+func (*SignalTrace_Request) isSignalTrace_Message() {}
+
+func (*SignalTrace_Reply) isSignalTrace_Message() {}
+
+// WorkerHeartbeat is written by every running worker, it expires when the worker stops writing it
+type WorkerHeartbeat struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	LastSeen *timestamp.Timestamp `protobuf:"bytes,2,opt,name=lastSeen,proto3" json:"lastSeen,omitempty"`
+	Peers    int32                `protobuf:"varint,3,opt,name=peers,proto3" json:"peers,omitempty"`
+	MaxPeers int32                `protobuf:"varint,4,opt,name=maxPeers,proto3" json:"maxPeers,omitempty"` // the most peers it takes, 0 is no limit
+	Draining bool                 `protobuf:"varint,5,opt,name=draining,proto3" json:"draining,omitempty"` // it takes no more joins, see Worker.Drain
+}
+
+func (x *WorkerHeartbeat) Reset() {
+	*x = WorkerHeartbeat{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[75]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkerHeartbeat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerHeartbeat) ProtoMessage() {}
+
+func (x *WorkerHeartbeat) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[75]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerHeartbeat.ProtoReflect.Descriptor instead.
+func (*WorkerHeartbeat) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *WorkerHeartbeat) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WorkerHeartbeat) GetLastSeen() *timestamp.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+func (x *WorkerHeartbeat) GetPeers() int32 {
+	if x != nil {
+		return x.Peers
+	}
+	return 0
+}
+
+func (x *WorkerHeartbeat) GetMaxPeers() int32 {
+	if x != nil {
+		return x.MaxPeers
+	}
+	return 0
+}
+
+func (x *WorkerHeartbeat) GetDraining() bool {
+	if x != nil {
+		return x.Draining
+	}
+	return false
+}
+
+type NodeData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	LastUpdate *timestamp.Timestamp `protobuf:"bytes,2,opt,name=lastUpdate,proto3" json:"lastUpdate,omitempty"`
+	Services   []string             `protobuf:"bytes,3,rep,name=services,proto3" json:"services,omitempty"`
+}
+
+func (x *NodeData) Reset() {
+	*x = NodeData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[76]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodeData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeData) ProtoMessage() {}
+
+func (x *NodeData) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[76]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeData.ProtoReflect.Descriptor instead.
+func (*NodeData) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *NodeData) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *NodeData) GetLastUpdate() *timestamp.Timestamp {
+	if x != nil {
+		return x.LastUpdate
+	}
+	return nil
+}
+
+func (x *NodeData) GetServices() []string {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+type RoomData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         string                `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Created    *timestamp.Timestamp  `protobuf:"bytes,2,opt,name=created,proto3" json:"created,omitempty"`
+	LastUpdate *timestamp.Timestamp  `protobuf:"bytes,3,opt,name=lastUpdate,proto3" json:"lastUpdate,omitempty"`
+	NodeID     string                `protobuf:"bytes,4,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	Options    *RoomOptions          `protobuf:"bytes,5,opt,name=options,proto3" json:"options,omitempty"`
+	Publisher  string                `protobuf:"bytes,6,opt,name=publisher,proto3" json:"publisher,omitempty"`
+	Owner      string                `protobuf:"bytes,7,opt,name=owner,proto3" json:"owner,omitempty"`                                                                                               // the pid that owns the room, it moderates it whatever its role
+	Metadata   map[string]string     `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // free-form, for dashboards and integrations
+	Recordings map[string]*Recording `protobuf:"bytes,9,rep,name=recordings,proto3" json:"recordings,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Paused     bool                  `protobuf:"varint,10,opt,name=paused,proto3" json:"paused,omitempty"` // no media is forwarded, see Manager.PauseRoom
+}
+
+func (x *RoomData) Reset() {
+	*x = RoomData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[77]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RoomData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomData) ProtoMessage() {}
+
+func (x *RoomData) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[77]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomData.ProtoReflect.Descriptor instead.
+func (*RoomData) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *RoomData) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RoomData) GetCreated() *timestamp.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *RoomData) GetLastUpdate() *timestamp.Timestamp {
+	if x != nil {
+		return x.LastUpdate
+	}
+	return nil
+}
+
+func (x *RoomData) GetNodeID() string {
+	if x != nil {
+		return x.NodeID
+	}
+	return ""
+}
+
+func (x *RoomData) GetOptions() *RoomOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *RoomData) GetPublisher() string {
+	if x != nil {
+		return x.Publisher
+	}
+	return ""
+}
+
+func (x *RoomData) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *RoomData) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *RoomData) GetRecordings() map[string]*Recording {
+	if x != nil {
+		return x.Recordings
+	}
+	return nil
+}
+
+func (x *RoomData) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+// Recording is a recorder peer writing every track in the room to outputDir/id/
+type Recording struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OutputDir string               `protobuf:"bytes,2,opt,name=outputDir,proto3" json:"outputDir,omitempty"`
+	Pid       string               `protobuf:"bytes,3,opt,name=pid,proto3" json:"pid,omitempty"`
+	Started   *timestamp.Timestamp `protobuf:"bytes,4,opt,name=started,proto3" json:"started,omitempty"`
+	Stopped   *timestamp.Timestamp `protobuf:"bytes,5,opt,name=stopped,proto3" json:"stopped,omitempty"` // unset while recording
+}
+
+func (x *Recording) Reset() {
+	*x = Recording{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[78]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Recording) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Recording) ProtoMessage() {}
+
+func (x *Recording) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[78]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Recording.ProtoReflect.Descriptor instead.
+func (*Recording) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *Recording) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Recording) GetOutputDir() string {
+	if x != nil {
+		return x.OutputDir
+	}
+	return ""
+}
+
+func (x *Recording) GetPid() string {
+	if x != nil {
+		return x.Pid
+	}
+	return ""
+}
+
+func (x *Recording) GetStarted() *timestamp.Timestamp {
+	if x != nil {
+		return x.Started
+	}
+	return nil
+}
+
+func (x *Recording) GetStopped() *timestamp.Timestamp {
+	if x != nil {
+		return x.Stopped
+	}
+	return nil
+}
+
+type RoomOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Debug                  int32           `protobuf:"varint,1,opt,name=debug,proto3" json:"debug,omitempty"`
+	Title                  string          `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	MaxAgeSeconds          int32           `protobuf:"varint,3,opt,name=maxAgeSeconds,proto3" json:"maxAgeSeconds,omitempty"`
+	KeyExpiryFactor        int32           `protobuf:"varint,4,opt,name=keyExpiryFactor,proto3" json:"keyExpiryFactor,omitempty"`
+	JoinPassword           string          `protobuf:"bytes,5,opt,name=joinPassword,proto3" json:"joinPassword,omitempty"` // only set in a CreateRoomRequest, it is stored as joinPasswordHash
+	PublishPassword        string          `protobuf:"bytes,6,opt,name=publishPassword,proto3" json:"publishPassword,omitempty"`
+	MaxPeers               int32           `protobuf:"varint,7,opt,name=maxPeers,proto3" json:"maxPeers,omitempty"`
+	IsChannel              bool            `protobuf:"varint,8,opt,name=isChannel,proto3" json:"isChannel,omitempty"`
+	AudioOnly              bool            `protobuf:"varint,9,opt,name=audioOnly,proto3" json:"audioOnly,omitempty"`                            // peers may not publish video, see AudioOnlyPolicy
+	IcePolicy              *ICEPolicy      `protobuf:"bytes,10,opt,name=icePolicy,proto3" json:"icePolicy,omitempty"`                            // replaces the manager's ICE policy for the room
+	ActiveSpeakerThreshold int32           `protobuf:"varint,11,opt,name=activeSpeakerThreshold,proto3" json:"activeSpeakerThreshold,omitempty"` // -dBov a peer has to be as loud as to be speaking, 0 is off
+	MaxSubscribedTracks    int32           `protobuf:"varint,12,opt,name=maxSubscribedTracks,proto3" json:"maxSubscribedTracks,omitempty"`       // most tracks a peer is subscribed to automatically, 0 is no limit
+	JoinPasswordHash       string          `protobuf:"bytes,13,opt,name=joinPasswordHash,proto3" json:"joinPasswordHash,omitempty"`              // salt:sha256 of the join password, see HashRoomPassword
+	IdleTimeoutSeconds     int32           `protobuf:"varint,14,opt,name=idleTimeoutSeconds,proto3" json:"idleTimeoutSeconds,omitempty"`         // replaces the manager's idle timeout for the room, -1 never closes idle peers
+	Codecs                 []string        `protobuf:"bytes,15,rep,name=codecs,proto3" json:"codecs,omitempty"`                                  // mime types like video/VP8 peers may negotiate, replaces the manager's MediaConfig codecs for the room
+	RtcpPolicy             *RTCPPolicy     `protobuf:"bytes,16,opt,name=rtcpPolicy,proto3" json:"rtcpPolicy,omitempty"`                          // replaces the manager's RTCP policy for the room
+	AutoTransferOwnership  bool            `protobuf:"varint,17,opt,name=autoTransferOwnership,proto3" json:"autoTransferOwnership,omitempty"`   // the peer connected the longest becomes owner when the owner leaves
+	SdpLimits              *SDPLimits      `protobuf:"bytes,18,opt,name=sdpLimits,proto3" json:"sdpLimits,omitempty"`                            // replace the manager's limits for the room, limit by limit
+	EmptyRoom              EmptyRoomPolicy `protobuf:"varint,19,opt,name=emptyRoom,proto3,enum=noir.EmptyRoomPolicy" json:"emptyRoom,omitempty"` // replaces the manager's empty room policy for the room
+	EmptyGraceSeconds      int32           `protobuf:"varint,20,opt,name=emptyGraceSeconds,proto3" json:"emptyGraceSeconds,omitempty"`           // how long a kept empty room waits for a peer, 0 is the manager's grace
+}
+
+func (x *RoomOptions) Reset() {
+	*x = RoomOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[79]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RoomOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomOptions) ProtoMessage() {}
+
+func (x *RoomOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[79]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomOptions.ProtoReflect.Descriptor instead.
+func (*RoomOptions) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *RoomOptions) GetDebug() int32 {
+	if x != nil {
+		return x.Debug
+	}
+	return 0
+}
+
+func (x *RoomOptions) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *RoomOptions) GetMaxAgeSeconds() int32 {
+	if x != nil {
+		return x.MaxAgeSeconds
+	}
+	return 0
+}
+
+func (x *RoomOptions) GetKeyExpiryFactor() int32 {
+	if x != nil {
+		return x.KeyExpiryFactor
+	}
+	return 0
+}
+
+func (x *RoomOptions) GetJoinPassword() string {
+	if x != nil {
+		return x.JoinPassword
+	}
+	return ""
+}
+
+func (x *RoomOptions) GetPublishPassword() string {
+	if x != nil {
+		return x.PublishPassword
+	}
+	return ""
+}
+
+func (x *RoomOptions) GetMaxPeers() int32 {
+	if x != nil {
+		return x.MaxPeers
+	}
+	return 0
+}
+
+func (x *RoomOptions) GetIsChannel() bool {
+	if x != nil {
+		return x.IsChannel
+	}
+	return false
+}
+
+func (x *RoomOptions) GetAudioOnly() bool {
+	if x != nil {
+		return x.AudioOnly
+	}
+	return false
+}
+
+func (x *RoomOptions) GetIcePolicy() *ICEPolicy {
+	if x != nil {
+		return x.IcePolicy
+	}
+	return nil
+}
+
+func (x *RoomOptions) GetActiveSpeakerThreshold() int32 {
+	if x != nil {
+		return x.ActiveSpeakerThreshold
+	}
+	return 0
+}
+
+func (x *RoomOptions) GetMaxSubscribedTracks() int32 {
+	if x != nil {
+		return x.MaxSubscribedTracks
+	}
+	return 0
+}
+
+func (x *RoomOptions) GetJoinPasswordHash() string {
+	if x != nil {
+		return x.JoinPasswordHash
+	}
+	return ""
+}
+
+func (x *RoomOptions) GetIdleTimeoutSeconds() int32 {
+	if x != nil {
+		return x.IdleTimeoutSeconds
+	}
+	return 0
+}
+
+func (x *RoomOptions) GetCodecs() []string {
+	if x != nil {
+		return x.Codecs
+	}
+	return nil
+}
+
+func (x *RoomOptions) GetRtcpPolicy() *RTCPPolicy {
+	if x != nil {
+		return x.RtcpPolicy
+	}
+	return nil
+}
+
+func (x *RoomOptions) GetAutoTransferOwnership() bool {
+	if x != nil {
+		return x.AutoTransferOwnership
+	}
+	return false
+}
+
+func (x *RoomOptions) GetSdpLimits() *SDPLimits {
+	if x != nil {
+		return x.SdpLimits
+	}
+	return nil
+}
+
+func (x *RoomOptions) GetEmptyRoom() EmptyRoomPolicy {
+	if x != nil {
+		return x.EmptyRoom
+	}
+	return EmptyRoomPolicy_EMPTY_ROOM_DEFAULT
+}
+
+func (x *RoomOptions) GetEmptyGraceSeconds() int32 {
+	if x != nil {
+		return x.EmptyGraceSeconds
+	}
+	return 0
+}
+
+type UserData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id             string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Created        *timestamp.Timestamp `protobuf:"bytes,2,opt,name=created,proto3" json:"created,omitempty"`
+	LastUpdate     *timestamp.Timestamp `protobuf:"bytes,4,opt,name=lastUpdate,proto3" json:"lastUpdate,omitempty"`
+	RoomID         string               `protobuf:"bytes,5,opt,name=roomID,proto3" json:"roomID,omitempty"`
+	Options        *UserOptions         `protobuf:"bytes,6,opt,name=options,proto3" json:"options,omitempty"`
+	Publishing     bool                 `protobuf:"varint,7,opt,name=publishing,proto3" json:"publishing,omitempty"`
+	Limits         *BitrateLimits       `protobuf:"bytes,8,opt,name=limits,proto3" json:"limits,omitempty"`
+	Identity       string               `protobuf:"bytes,9,opt,name=identity,proto3" json:"identity,omitempty"` // who the Authenticator said joined
+	AudioMuted     bool                 `protobuf:"varint,10,opt,name=audioMuted,proto3" json:"audioMuted,omitempty"`
+	VideoMuted     bool                 `protobuf:"varint,11,opt,name=videoMuted,proto3" json:"videoMuted,omitempty"`
+	Role           Role                 `protobuf:"varint,12,opt,name=role,proto3,enum=noir.Role" json:"role,omitempty"`
+	QualityReports bool                 `protobuf:"varint,13,opt,name=qualityReports,proto3" json:"qualityReports,omitempty"`                                                                                  // asked for with JoinRequest.qualityReports
+	Version        string               `protobuf:"bytes,14,opt,name=version,proto3" json:"version,omitempty"`                                                                                                 // the protocol version settled at join
+	TrackLabels    map[string]string    `protobuf:"bytes,15,rep,name=trackLabels,proto3" json:"trackLabels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // the labels of the streams it publishes, see SignalRequest.trackLabels
+	Keepalive      bool                 `protobuf:"varint,16,opt,name=keepalive,proto3" json:"keepalive,omitempty"`                                                                                            // asked for with JoinRequest.keepalive
+	AdaptiveLayers bool                 `protobuf:"varint,17,opt,name=adaptiveLayers,proto3" json:"adaptiveLayers,omitempty"`                                                                                  // asked for with JoinRequest.adaptiveLayers
+}
+
+func (x *UserData) Reset() {
+	*x = UserData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[80]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserData) ProtoMessage() {}
+
+func (x *UserData) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[80]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserData.ProtoReflect.Descriptor instead.
+func (*UserData) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *UserData) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UserData) GetCreated() *timestamp.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *UserData) GetLastUpdate() *timestamp.Timestamp {
+	if x != nil {
+		return x.LastUpdate
+	}
+	return nil
+}
+
+func (x *UserData) GetRoomID() string {
+	if x != nil {
+		return x.RoomID
+	}
+	return ""
+}
+
+func (x *UserData) GetOptions() *UserOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *UserData) GetPublishing() bool {
+	if x != nil {
+		return x.Publishing
+	}
+	return false
+}
+
+func (x *UserData) GetLimits() *BitrateLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *UserData) GetIdentity() string {
+	if x != nil {
+		return x.Identity
+	}
+	return ""
+}
+
+func (x *UserData) GetAudioMuted() bool {
+	if x != nil {
+		return x.AudioMuted
+	}
+	return false
+}
+
+func (x *UserData) GetVideoMuted() bool {
+	if x != nil {
+		return x.VideoMuted
+	}
+	return false
+}
+
+func (x *UserData) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_PUBLISHER
+}
+
+func (x *UserData) GetQualityReports() bool {
+	if x != nil {
+		return x.QualityReports
+	}
+	return false
+}
+
+func (x *UserData) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *UserData) GetTrackLabels() map[string]string {
+	if x != nil {
+		return x.TrackLabels
+	}
+	return nil
+}
+
+func (x *UserData) GetKeepalive() bool {
+	if x != nil {
+		return x.Keepalive
+	}
+	return false
+}
+
+func (x *UserData) GetAdaptiveLayers() bool {
+	if x != nil {
+		return x.AdaptiveLayers
+	}
+	return false
+}
+
+// PeerStatus is where a connected peer stands, as its worker last recorded it, see Manager.GetPeerStatus
+type PeerStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pid                 string               `protobuf:"bytes,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	RoomID              string               `protobuf:"bytes,2,opt,name=roomID,proto3" json:"roomID,omitempty"`
+	Role                Role                 `protobuf:"varint,3,opt,name=role,proto3,enum=noir.Role" json:"role,omitempty"`
+	IceState            string               `protobuf:"bytes,4,opt,name=iceState,proto3" json:"iceState,omitempty"`                     // eg. connected, of the connection it publishes on
+	PublisherSignaling  string               `protobuf:"bytes,5,opt,name=publisherSignaling,proto3" json:"publisherSignaling,omitempty"` // the signaling states of its connections, eg. stable
+	SubscriberSignaling string               `protobuf:"bytes,6,opt,name=subscriberSignaling,proto3" json:"subscriberSignaling,omitempty"`
+	PublishedTracks     int32                `protobuf:"varint,7,opt,name=publishedTracks,proto3" json:"publishedTracks,omitempty"`
+	SubscribedTracks    int32                `protobuf:"varint,8,opt,name=subscribedTracks,proto3" json:"subscribedTracks,omitempty"`
+	Joined              *timestamp.Timestamp `protobuf:"bytes,9,opt,name=joined,proto3" json:"joined,omitempty"`
+	Updated             *timestamp.Timestamp `protobuf:"bytes,10,opt,name=updated,proto3" json:"updated,omitempty"` // when it was recorded
+	Worker              string               `protobuf:"bytes,11,opt,name=worker,proto3" json:"worker,omitempty"`   // the worker that owns the peer
+}
+
+func (x *PeerStatus) Reset() {
+	*x = PeerStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[81]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerStatus) ProtoMessage() {}
+
+func (x *PeerStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[81]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerStatus.ProtoReflect.Descriptor instead.
+func (*PeerStatus) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *PeerStatus) GetPid() string {
+	if x != nil {
+		return x.Pid
+	}
+	return ""
+}
+
+func (x *PeerStatus) GetRoomID() string {
+	if x != nil {
+		return x.RoomID
+	}
+	return ""
+}
+
+func (x *PeerStatus) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_PUBLISHER
+}
+
+func (x *PeerStatus) GetIceState() string {
+	if x != nil {
+		return x.IceState
+	}
+	return ""
+}
+
+func (x *PeerStatus) GetPublisherSignaling() string {
+	if x != nil {
+		return x.PublisherSignaling
+	}
+	return ""
+}
+
+func (x *PeerStatus) GetSubscriberSignaling() string {
+	if x != nil {
+		return x.SubscriberSignaling
+	}
+	return ""
+}
+
+func (x *PeerStatus) GetPublishedTracks() int32 {
+	if x != nil {
+		return x.PublishedTracks
+	}
+	return 0
+}
+
+func (x *PeerStatus) GetSubscribedTracks() int32 {
+	if x != nil {
+		return x.SubscribedTracks
+	}
+	return 0
+}
+
+func (x *PeerStatus) GetJoined() *timestamp.Timestamp {
+	if x != nil {
+		return x.Joined
+	}
+	return nil
+}
+
+func (x *PeerStatus) GetUpdated() *timestamp.Timestamp {
+	if x != nil {
+		return x.Updated
+	}
+	return nil
+}
+
+func (x *PeerStatus) GetWorker() string {
+	if x != nil {
+		return x.Worker
+	}
+	return ""
+}
+
+type UserOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Debug           int32  `protobuf:"varint,1,opt,name=debug,proto3" json:"debug,omitempty"`
+	Title           string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	MaxAgeSeconds   int32  `protobuf:"varint,3,opt,name=maxAgeSeconds,proto3" json:"maxAgeSeconds,omitempty"`
+	KeyExpiryFactor int32  `protobuf:"varint,4,opt,name=keyExpiryFactor,proto3" json:"keyExpiryFactor,omitempty"`
+}
+
+func (x *UserOptions) Reset() {
+	*x = UserOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[82]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserOptions) ProtoMessage() {}
+
+func (x *UserOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[82]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserOptions.ProtoReflect.Descriptor instead.
+func (*UserOptions) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *UserOptions) GetDebug() int32 {
+	if x != nil {
+		return x.Debug
+	}
+	return 0
+}
+
+func (x *UserOptions) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UserOptions) GetMaxAgeSeconds() int32 {
+	if x != nil {
+		return x.MaxAgeSeconds
+	}
+	return 0
+}
+
+func (x *UserOptions) GetKeyExpiryFactor() int32 {
+	if x != nil {
+		return x.KeyExpiryFactor
+	}
+	return 0
+}
+
+type JobData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Handler    string               `protobuf:"bytes,2,opt,name=handler,proto3" json:"handler,omitempty"`
+	Status     JobData_JobStatus    `protobuf:"varint,3,opt,name=status,proto3,enum=noir.JobData_JobStatus" json:"status,omitempty"`
+	Created    *timestamp.Timestamp `protobuf:"bytes,4,opt,name=created,proto3" json:"created,omitempty"`
+	LastUpdate *timestamp.Timestamp `protobuf:"bytes,5,opt,name=lastUpdate,proto3" json:"lastUpdate,omitempty"`
+	NodeID     string               `protobuf:"bytes,6,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+}
+
+func (x *JobData) Reset() {
+	*x = JobData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[83]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobData) ProtoMessage() {}
+
+func (x *JobData) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[83]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobData.ProtoReflect.Descriptor instead.
+func (*JobData) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *JobData) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *JobData) GetHandler() string {
+	if x != nil {
+		return x.Handler
+	}
+	return ""
+}
+
+func (x *JobData) GetStatus() JobData_JobStatus {
+	if x != nil {
+		return x.Status
+	}
+	return JobData_CREATED
+}
+
+func (x *JobData) GetCreated() *timestamp.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *JobData) GetLastUpdate() *timestamp.Timestamp {
+	if x != nil {
+		return x.LastUpdate
+	}
+	return nil
+}
+
+func (x *JobData) GetNodeID() string {
+	if x != nil {
+		return x.NodeID
+	}
+	return ""
+}
+
+type PeerJobData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RoomID          string   `protobuf:"bytes,1,opt,name=roomID,proto3" json:"roomID,omitempty"`
+	UserID          string   `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"`
+	PublishTracks   []string `protobuf:"bytes,3,rep,name=publishTracks,proto3" json:"publishTracks,omitempty"`
+	SubscribeTracks []string `protobuf:"bytes,4,rep,name=subscribeTracks,proto3" json:"subscribeTracks,omitempty"`
+}
+
+func (x *PeerJobData) Reset() {
+	*x = PeerJobData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_proto_noir_proto_msgTypes[84]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerJobData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerJobData) ProtoMessage() {}
+
+func (x *PeerJobData) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_proto_noir_proto_msgTypes[84]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerJobData.ProtoReflect.Descriptor instead.
+func (*PeerJobData) Descriptor() ([]byte, []int) {
+	return file_pkg_proto_noir_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *PeerJobData) GetRoomID() string {
+	if x != nil {
+		return x.RoomID
+	}
+	return ""
+}
+
+func (x *PeerJobData) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *PeerJobData) GetPublishTracks() []string {
+	if x != nil {
+		return x.PublishTracks
+	}
+	return nil
+}
+
+func (x *PeerJobData) GetSubscribeTracks() []string {
+	if x != nil {
+		return x.SubscribeTracks
+	}
+	return nil
+}
+
+var File_pkg_proto_noir_proto protoreflect.FileDescriptor
+
+var file_pkg_proto_noir_proto_rawDesc = []byte{
+	0x0a, 0x14, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6e, 0x6f, 0x69, 0x72,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x6e, 0x6f, 0x69, 0x72, 0x1a, 0x1f, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x29, 0x0a,
+	0x0b, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x44, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x22, 0xf9, 0x01, 0x0a, 0x0b, 0x4e, 0x6f, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x0e, 0x0a, 0x02, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x61,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x06, 0x73, 0x69, 0x67,
+	0x6e, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6e, 0x6f, 0x69, 0x72,
+	0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00,
+	0x52, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x12, 0x2a, 0x0a, 0x05, 0x61, 0x64, 0x6d, 0x69,
+	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x41,
+	0x64, 0x6d, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x05, 0x61,
+	0x64, 0x6d, 0x69, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x49, 0x44, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x49, 0x44, 0x12, 0x18,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x72, 0x61, 0x63,
+	0x65, 0x49, 0x44, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74, 0x72, 0x61, 0x63, 0x65,
+	0x49, 0x44, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x22, 0xd7, 0x02,
+	0x0a, 0x09, 0x4e, 0x6f, 0x69, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x61,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x61, 0x74, 0x12, 0x2b, 0x0a, 0x06, 0x73,
+	0x69, 0x67, 0x6e, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6e, 0x6f,
+	0x69, 0x72, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00,
+	0x52, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x12, 0x28, 0x0a, 0x05, 0x61, 0x64, 0x6d, 0x69,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x41,
+	0x64, 0x6d, 0x69, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x05, 0x61, 0x64, 0x6d,
+	0x69, 0x6e, 0x12, 0x16, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x27, 0x0a, 0x05, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6e, 0x6f, 0x69, 0x72,
+	0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x05, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x33, 0x0a,
+	0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x11, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x72, 0x61, 0x63, 0x65, 0x49, 0x44, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x74, 0x72, 0x61, 0x63, 0x65, 0x49, 0x44, 0x42, 0x09, 0x0a, 0x07,
+	0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x22, 0xb6, 0x03, 0x0a, 0x09, 0x52, 0x6f, 0x6f, 0x6d,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x69,
+	0x64, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x73, 0x69, 0x64, 0x12, 0x2a, 0x0a, 0x02, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x02, 0x61, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x4c, 0x65, 0x76, 0x65,
+	0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x4c, 0x65,
+	0x76, 0x65, 0x6c, 0x12, 0x29, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65,
+	0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x29,
+	0x0a, 0x06, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x4c, 0x61, 0x79, 0x65, 0x72,
+	0x73, 0x52, 0x06, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x22, 0xa4, 0x01, 0x0a, 0x04, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x4a, 0x4f, 0x49, 0x4e, 0x45, 0x44, 0x10, 0x00, 0x12, 0x08,
+	0x0a, 0x04, 0x4c, 0x45, 0x46, 0x54, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x4d, 0x55, 0x54, 0x45,
+	0x44, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4d, 0x55, 0x54, 0x45, 0x44, 0x10, 0x03,
+	0x12, 0x0a, 0x0a, 0x06, 0x4b, 0x49, 0x43, 0x4b, 0x45, 0x44, 0x10, 0x04, 0x12, 0x12, 0x0a, 0x0e,
+	0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x5f, 0x53, 0x50, 0x45, 0x41, 0x4b, 0x45, 0x52, 0x10, 0x05,
+	0x12, 0x0a, 0x0a, 0x06, 0x4f, 0x50, 0x45, 0x4e, 0x45, 0x44, 0x10, 0x06, 0x12, 0x0a, 0x0a, 0x06,
+	0x43, 0x4c, 0x4f, 0x53, 0x45, 0x44, 0x10, 0x07, 0x12, 0x0a, 0x0a, 0x06, 0x50, 0x41, 0x55, 0x53,
+	0x45, 0x44, 0x10, 0x08, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x45, 0x53, 0x55, 0x4d, 0x45, 0x44, 0x10,
+	0x09, 0x12, 0x11, 0x0a, 0x0d, 0x4f, 0x57, 0x4e, 0x45, 0x52, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47,
+	0x45, 0x44, 0x10, 0x0a, 0x12, 0x0a, 0x0a, 0x06, 0x4c, 0x41, 0x59, 0x45, 0x52, 0x53, 0x10, 0x0b,
+	0x22, 0xb4, 0x02, 0x0a, 0x0c, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x36, 0x0a, 0x09, 0x72, 0x6f, 0x6f, 0x6d, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x52, 0x6f, 0x6f, 0x6d,
+	0x41, 0x64, 0x6d, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x09,
+	0x72, 0x6f, 0x6f, 0x6d, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x36, 0x0a, 0x09, 0x72, 0x6f, 0x6f,
+	0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6e,
+	0x6f, 0x69, 0x72, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x09, 0x72, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x33, 0x0a, 0x08, 0x72, 0x6f, 0x6f, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x4c,
+	0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x08, 0x72, 0x6f,
+	0x6f, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x36, 0x0a, 0x09, 0x72, 0x6f, 0x6f, 0x6d, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6e, 0x6f, 0x69, 0x72,
+	0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x48, 0x00, 0x52, 0x09, 0x72, 0x6f, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x3c,
+	0x0a, 0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x65,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52,
+	0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x42, 0x09, 0x0a, 0x07,
+	0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0xc0, 0x02, 0x0a, 0x0a, 0x41, 0x64, 0x6d, 0x69,
+	0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x34,
+	0x0a, 0x09, 0x72, 0x6f, 0x6f, 0x6d, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x41, 0x64, 0x6d,
+	0x69, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x09, 0x72, 0x6f, 0x6f, 0x6d, 0x41,
+	0x64, 0x6d, 0x69, 0x6e, 0x12, 0x34, 0x0a, 0x09, 0x72, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x52,
+	0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52,
+	0x09, 0x72, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x31, 0x0a, 0x08, 0x72, 0x6f,
+	0x6f, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6e,
+	0x6f, 0x69, 0x72, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x48, 0x00, 0x52, 0x08, 0x72, 0x6f, 0x6f, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x34, 0x0a,
+	0x09, 0x72, 0x6f, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x09, 0x72, 0x6f, 0x6f, 0x6d, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x12, 0x3a, 0x0a, 0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e,
+	0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x48, 0x00, 0x52, 0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x42,
+	0x09, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x12, 0x0a, 0x10, 0x52, 0x6f,
+	0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x28,
+	0x0a, 0x0e, 0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x11, 0x0a, 0x0f, 0x52, 0x6f, 0x6f, 0x6d,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x35, 0x0a, 0x0d, 0x52,
+	0x6f, 0x6f, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x22, 0x52, 0x0a, 0x0d, 0x52, 0x6f, 0x6f, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2b, 0x0a, 0x06, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6e, 0x6f, 0x69, 0x72,
+	0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x2a, 0x0a, 0x10, 0x52, 0x6f, 0x6f, 0x6d, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f,
+	0x6f, 0x6d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d,
+	0x49, 0x44, 0x22, 0xd4, 0x02, 0x0a, 0x09, 0x50, 0x65, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70,
+	0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x79, 0x74, 0x65, 0x73, 0x53, 0x65, 0x6e, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x62, 0x79, 0x74, 0x65, 0x73, 0x53, 0x65, 0x6e, 0x74,
+	0x12, 0x24, 0x0a, 0x0d, 0x62, 0x79, 0x74, 0x65, 0x73, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x62, 0x79, 0x74, 0x65, 0x73, 0x52, 0x65,
+	0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x28, 0x0a, 0x0f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64,
+	0x12, 0x20, 0x0a, 0x0b, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x4c, 0x6f, 0x73, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x4c, 0x6f,
+	0x73, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x64, 0x54,
+	0x72, 0x61, 0x63, 0x6b, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x70, 0x75, 0x62,
+	0x6c, 0x69, 0x73, 0x68, 0x65, 0x64, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x73, 0x12, 0x2a, 0x0a, 0x10,
+	0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x64, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x73,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x64, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x73, 0x12, 0x29, 0x0a, 0x06, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e,
+	0x54, 0x72, 0x61, 0x63, 0x6b, 0x4c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x52, 0x06, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x42, 0x69, 0x74,
+	0x72, 0x61, 0x74, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x42, 0x69, 0x74, 0x72, 0x61, 0x74, 0x65, 0x22, 0x67, 0x0a, 0x0e, 0x52, 0x6f, 0x6f,
+	0x6d, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x6f, 0x6f,
+	0x6d, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x12, 0x25, 0x0a, 0x05, 0x70,
+	0x65, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6e, 0x6f, 0x69,
+	0x72, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x70, 0x65, 0x65,
+	0x72, 0x73, 0x22, 0x14, 0x0a, 0x12, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xb5, 0x02, 0x0a, 0x10, 0x57, 0x6f, 0x72,
+	0x6b, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a,
+	0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e,
+	0x6f, 0x64, 0x65, 0x49, 0x44, 0x12, 0x2e, 0x0a, 0x04, 0x61, 0x73, 0x4f, 0x66, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x04, 0x61, 0x73, 0x4f, 0x66, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x72,
+	0x6f, 0x6f, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x72, 0x6f, 0x6f, 0x6d,
+	0x73, 0x12, 0x26, 0x0a, 0x0e, 0x70, 0x65, 0x65, 0x72, 0x47, 0x6f, 0x72, 0x6f, 0x75, 0x74, 0x69,
+	0x6e, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x70, 0x65, 0x65, 0x72, 0x47,
+	0x6f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x46, 0x0a, 0x0a, 0x70, 0x65, 0x65,
+	0x72, 0x51, 0x75, 0x65, 0x75, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e,
+	0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x51, 0x75, 0x65, 0x75, 0x65, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x70, 0x65, 0x65, 0x72, 0x51, 0x75, 0x65, 0x75, 0x65,
+	0x73, 0x1a, 0x3d, 0x0a, 0x0f, 0x50, 0x65, 0x65, 0x72, 0x51, 0x75, 0x65, 0x75, 0x65, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0xa9, 0x06, 0x0a, 0x10, 0x52, 0x6f, 0x6f, 0x6d, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x12, 0x39, 0x0a,
+	0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52,
+	0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x30, 0x0a, 0x07, 0x72, 0x6f, 0x6f, 0x6d,
+	0x4a, 0x6f, 0x62, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6e, 0x6f, 0x69, 0x72,
+	0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48,
+	0x00, 0x52, 0x07, 0x72, 0x6f, 0x6f, 0x6d, 0x4a, 0x6f, 0x62, 0x12, 0x36, 0x0a, 0x09, 0x63, 0x6c,
+	0x6f, 0x73, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x09, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x6f,
+	0x6f, 0x6d, 0x12, 0x45, 0x0a, 0x0e, 0x73, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6e, 0x6f, 0x69,
+	0x72, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0e, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x42, 0x0a, 0x0d, 0x73, 0x74, 0x6f,
+	0x70, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0d,
+	0x73, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x33, 0x0a,
+	0x08, 0x6d, 0x75, 0x74, 0x65, 0x50, 0x65, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x4d, 0x75, 0x74, 0x65, 0x50, 0x65, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x08, 0x6d, 0x75, 0x74, 0x65, 0x50, 0x65,
+	0x65, 0x72, 0x12, 0x33, 0x0a, 0x08, 0x6b, 0x69, 0x63, 0x6b, 0x50, 0x65, 0x65, 0x72, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x4b, 0x69, 0x63, 0x6b,
+	0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x08, 0x6b,
+	0x69, 0x63, 0x6b, 0x50, 0x65, 0x65, 0x72, 0x12, 0x45, 0x0a, 0x0e, 0x74, 0x72, 0x61, 0x63, 0x65,
+	0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x69, 0x6e, 0x67, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x53, 0x69, 0x67, 0x6e,
+	0x61, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0e,
+	0x74, 0x72, 0x61, 0x63, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x69, 0x6e, 0x67, 0x12, 0x3c,
+	0x0a, 0x0b, 0x73, 0x65, 0x74, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x53, 0x65, 0x74, 0x50, 0x61,
+	0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52,
+	0x0b, 0x73, 0x65, 0x74, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x30, 0x0a, 0x08,
+	0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x48, 0x00, 0x52, 0x08, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x12, 0x36,
+	0x0a, 0x09, 0x70, 0x61, 0x75, 0x73, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x50, 0x61, 0x75, 0x73, 0x65, 0x52, 0x6f,
+	0x6f, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x09, 0x70, 0x61, 0x75,
+	0x73, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x4e, 0x0a, 0x11, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x65, 0x72, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1e, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x48, 0x00, 0x52, 0x11, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4f, 0x77, 0x6e,
+	0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72,
+	0x49, 0x44, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72,
+	0x49, 0x44, 0x42, 0x08, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x22, 0xa4, 0x03, 0x0a,
+	0x0e, 0x52, 0x6f, 0x6f, 0x6d, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12,
+	0x37, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x0a, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x2e, 0x0a, 0x07, 0x72, 0x6f, 0x6f, 0x6d,
+	0x4a, 0x6f, 0x62, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6e, 0x6f, 0x69, 0x72,
+	0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52,
+	0x07, 0x72, 0x6f, 0x6f, 0x6d, 0x4a, 0x6f, 0x62, 0x12, 0x34, 0x0a, 0x09, 0x63, 0x6c, 0x6f, 0x73,
+	0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6e, 0x6f,
+	0x69, 0x72, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x48, 0x00, 0x52, 0x09, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x43,
+	0x0a, 0x0e, 0x73, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x48, 0x00, 0x52, 0x0e, 0x73, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x69, 0x6e, 0x67, 0x12, 0x40, 0x0a, 0x0d, 0x73, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x69, 0x6e, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6e, 0x6f, 0x69,
+	0x72, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x0d, 0x73, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x31, 0x0a, 0x08, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63,
+	0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x41,
+	0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x08,
+	0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x42, 0x09, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x22, 0x2d, 0x0a, 0x0d, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x65,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72,
+	0x65, 0x64, 0x22, 0xf2, 0x01, 0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f,
+	0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6e, 0x6f, 0x69, 0x72,
+	0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x41, 0x0a, 0x08, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e,
+	0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1a,
+	0x0a, 0x08, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x58, 0x0a, 0x0f, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2b, 0x0a, 0x07, 0x6f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6e, 0x6f,
+	0x69, 0x72, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07,
+	0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x22, 0x35, 0x0a, 0x15, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x44, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x44, 0x69, 0x72, 0x22, 0x37, 0x0a, 0x13, 0x53, 0x74, 0x61, 0x72,
+	0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x20, 0x0a, 0x0b, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x49,
+	0x44, 0x22, 0x38, 0x0a, 0x14, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69,
+	0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x49, 0x44, 0x22, 0x2e, 0x0a, 0x12, 0x53,
+	0x74, 0x6f, 0x70, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x74, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x07, 0x73, 0x74, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x22, 0x4d, 0x0a, 0x0f, 0x4d,
+	0x75, 0x74, 0x65, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x69, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6b, 0x69, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x75, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x05, 0x6d, 0x75, 0x74, 0x65, 0x64, 0x22, 0x4f, 0x0a, 0x15, 0x54, 0x72,
+	0x61, 0x63, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x1c, 0x0a,
+	0x09, 0x72, 0x65, 0x64, 0x61, 0x63, 0x74, 0x53, 0x44, 0x50, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x09, 0x72, 0x65, 0x64, 0x61, 0x63, 0x74, 0x53, 0x44, 0x50, 0x22, 0x30, 0x0a, 0x12, 0x53,
+	0x65, 0x74, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x22, 0x2a, 0x0a,
+	0x10, 0x50, 0x61, 0x75, 0x73, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x75, 0x73, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x06, 0x70, 0x61, 0x75, 0x73, 0x65, 0x64, 0x22, 0x2c, 0x0a, 0x18, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x66, 0x65, 0x72, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x70, 0x69, 0x64, 0x22, 0x23, 0x0a, 0x0f, 0x4b, 0x69, 0x63, 0x6b, 0x50,
+	0x65, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x69, 0x64, 0x22, 0x12, 0x0a, 0x10,
+	0x43, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x2a, 0x0a, 0x0e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x6f, 0x6f, 0x6d, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x76, 0x69, 0x63, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x07, 0x65, 0x76, 0x69, 0x63, 0x74, 0x65, 0x64, 0x22, 0x56, 0x0a, 0x0e,
+	0x52, 0x6f, 0x6f, 0x6d, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x6f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x22, 0x6c, 0x0a, 0x0c, 0x52, 0x6f, 0x6f, 0x6d, 0x4a, 0x6f, 0x62, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x12, 0x10,
+	0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x69, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x22, 0xa4, 0x08, 0x0a, 0x0d, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x27, 0x0a, 0x04, 0x6a, 0x6f, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x04, 0x6a, 0x6f, 0x69, 0x6e, 0x12, 0x22, 0x0a,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0c, 0x48, 0x00, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x29, 0x0a, 0x07, 0x74, 0x72, 0x69, 0x63, 0x6b, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x54, 0x72, 0x69, 0x63, 0x6b, 0x6c,
+	0x65, 0x48, 0x00, 0x52, 0x07, 0x74, 0x72, 0x69, 0x63, 0x6b, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x04,
+	0x6b, 0x69, 0x6c, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x04, 0x6b, 0x69,
+	0x6c, 0x6c, 0x12, 0x16, 0x0a, 0x05, 0x6c, 0x65, 0x61, 0x76, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x08, 0x48, 0x00, 0x52, 0x05, 0x6c, 0x65, 0x61, 0x76, 0x65, 0x12, 0x27, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e,
+	0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x12, 0x18, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x12, 0x33, 0x0a,
+	0x08, 0x73, 0x65, 0x74, 0x4c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x53, 0x65, 0x74, 0x4c, 0x61, 0x79, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x08, 0x73, 0x65, 0x74, 0x4c, 0x61, 0x79,
+	0x65, 0x72, 0x12, 0x27, 0x0a, 0x04, 0x70, 0x6c, 0x61, 0x79, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x04, 0x70, 0x6c, 0x61, 0x79, 0x12, 0x27, 0x0a, 0x04, 0x6d,
+	0x75, 0x74, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6e, 0x6f, 0x69, 0x72,
+	0x2e, 0x4d, 0x75, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x04,
+	0x6d, 0x75, 0x74, 0x65, 0x12, 0x38, 0x0a, 0x0c, 0x74, 0x72, 0x69, 0x63, 0x6b, 0x6c, 0x65, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6e, 0x6f, 0x69,
+	0x72, 0x2e, 0x54, 0x72, 0x69, 0x63, 0x6b, 0x6c, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00,
+	0x52, 0x0c, 0x74, 0x72, 0x69, 0x63, 0x6b, 0x6c, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x36,
+	0x0a, 0x09, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x09, 0x73, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x3a, 0x0a, 0x0b, 0x75, 0x6e, 0x73, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6e, 0x6f,
+	0x69, 0x72, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x75, 0x6e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x12, 0x33, 0x0a, 0x08, 0x67, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x18, 0x11,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x08, 0x67,
+	0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x05, 0x70, 0x61, 0x75, 0x73, 0x65,
+	0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x52, 0x6f,
+	0x6f, 0x6d, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x48, 0x00, 0x52, 0x05, 0x70, 0x61, 0x75, 0x73,
+	0x65, 0x12, 0x27, 0x0a, 0x04, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x48, 0x00, 0x52, 0x04, 0x6d, 0x6f, 0x76, 0x65, 0x12, 0x14, 0x0a, 0x04, 0x70, 0x6f,
+	0x6e, 0x67, 0x18, 0x15, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x04, 0x70, 0x6f, 0x6e, 0x67,
+	0x12, 0x3f, 0x0a, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73,
+	0x18, 0x16, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x43, 0x61,
+	0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x48, 0x00, 0x52, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65,
+	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12,
+	0x33, 0x0a, 0x0b, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x10,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x43, 0x6c, 0x6f, 0x73,
+	0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x0b, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x12, 0x46, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x18, 0x13, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6e, 0x6f, 0x69, 0x72,
+	0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x54,
+	0x72, 0x61, 0x63, 0x6b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x0b, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x20, 0x0a, 0x0b,
+	0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x17, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x1a, 0x3e,
+	0x0a, 0x10, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x09,
+	0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0xa7, 0x09, 0x0a, 0x0b, 0x53, 0x69,
+	0x67, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x25, 0x0a, 0x04, 0x6a, 0x6f, 0x69,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x4a,
+	0x6f, 0x69, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x04, 0x6a, 0x6f, 0x69, 0x6e,
+	0x12, 0x22, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x29, 0x0a, 0x07, 0x74, 0x72, 0x69, 0x63, 0x6b, 0x6c, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x54, 0x72, 0x69,
+	0x63, 0x6b, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x07, 0x74, 0x72, 0x69, 0x63, 0x6b, 0x6c, 0x65, 0x12,
+	0x30, 0x0a, 0x12, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x12, 0x69,
+	0x63, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x16, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x04, 0x6b, 0x69, 0x6c,
+	0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x04, 0x6b, 0x69, 0x6c, 0x6c, 0x12,
+	0x27, 0x0a, 0x05, 0x6c, 0x65, 0x61, 0x76, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x48,
+	0x00, 0x52, 0x05, 0x6c, 0x65, 0x61, 0x76, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x66, 0x61, 0x69, 0x6c,
+	0x75, 0x72, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6e, 0x6f, 0x69, 0x72,
+	0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x07,
+	0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x12, 0x27, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x44, 0x61, 0x74,
+	0x61, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x12, 0x18, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08,
+	0x48, 0x00, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x08, 0x73, 0x65,
+	0x74, 0x4c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6e,
+	0x6f, 0x69, 0x72, 0x2e, 0x53, 0x65, 0x74, 0x4c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x48, 0x00, 0x52, 0x08, 0x73, 0x65, 0x74, 0x4c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x14, 0x0a,
+	0x04, 0x70, 0x6c, 0x61, 0x79, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x04, 0x70,
+	0x6c, 0x61, 0x79, 0x12, 0x1e, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x09, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x12, 0x38, 0x0a, 0x0c, 0x74, 0x72, 0x69, 0x63, 0x6b, 0x6c, 0x65, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6e, 0x6f, 0x69, 0x72,
+	0x2e, 0x54, 0x72, 0x69, 0x63, 0x6b, 0x6c, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52,
+	0x0c, 0x74, 0x72, 0x69, 0x63, 0x6b, 0x6c, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x3b, 0x0a,
+	0x0d, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x11,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x48, 0x00, 0x52, 0x0d, 0x73, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x3b, 0x0a, 0x0d, 0x61, 0x63,
+	0x74, 0x69, 0x76, 0x65, 0x53, 0x70, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x18, 0x12, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x53,
+	0x70, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x48, 0x00, 0x52, 0x0d, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65,
+	0x53, 0x70, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x12, 0x38, 0x0a, 0x0c, 0x61, 0x6e, 0x6e, 0x6f, 0x75,
+	0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x48, 0x00, 0x52, 0x0c, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x12, 0x33, 0x0a, 0x07, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x14, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x48, 0x00, 0x52, 0x07, 0x71,
+	0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x2f, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18,
+	0x16, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x50, 0x65, 0x65,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x48, 0x00,
+	0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x06, 0x70, 0x61, 0x75, 0x73, 0x65,
+	0x64, 0x18, 0x17, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x52,
+	0x6f, 0x6f, 0x6d, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x48, 0x00, 0x52, 0x06, 0x70, 0x61, 0x75,
+	0x73, 0x65, 0x64, 0x12, 0x2a, 0x0a, 0x06, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x73, 0x18, 0x18, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b,
+	0x49, 0x6e, 0x66, 0x6f, 0x73, 0x48, 0x00, 0x52, 0x06, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x73, 0x12,
+	0x27, 0x0a, 0x05, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x4d, 0x6f, 0x76, 0x65, 0x64, 0x48,
+	0x00, 0x52, 0x05, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x12, 0x2b, 0x0a, 0x06, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x73, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e,
+	0x54, 0x72, 0x61, 0x63, 0x6b, 0x4c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x48, 0x00, 0x52, 0x06, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x14, 0x0a, 0x04, 0x70, 0x69, 0x6e, 0x67, 0x18, 0x1b, 0x20,
+	0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x04, 0x70, 0x69, 0x6e, 0x67, 0x12, 0x38, 0x0a, 0x0c, 0x63,
+	0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x1c, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x69, 0x65, 0x73, 0x48, 0x00, 0x52, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x49, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x49, 0x64, 0x12, 0x33, 0x0a, 0x0b, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e,
+	0x43, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x0b, 0x63, 0x6c, 0x6f,
+	0x73, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x42, 0x09, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x22, 0x3d, 0x0a, 0x13, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x22, 0xc5, 0x02, 0x0a, 0x0c, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x69, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2c, 0x0a,
+	0x11, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72,
+	0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63,
+	0x6f, 0x64, 0x65, 0x63, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x64,
+	0x65, 0x63, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e,
+	0x67, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x12, 0x1a, 0x0a,
+	0x08, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x4d, 0x69, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x4d, 0x69, 0x78, 0x12, 0x2f, 0x0a, 0x0a, 0x69, 0x63, 0x65,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x49, 0x43, 0x45, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x0a,
+	0x69, 0x63, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x75,
+	0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0c, 0x61, 0x75, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x12, 0x2a,
+	0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x6d, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6e,
+	0x6f, 0x69, 0x72, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x69, 0x65, 0x73, 0x52, 0x04, 0x72, 0x6f, 0x6f, 0x6d, 0x22, 0xe6, 0x01, 0x0a, 0x10, 0x52,
+	0x6f, 0x6f, 0x6d, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x75, 0x74, 0x6f, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x61, 0x75, 0x74,
+	0x6f, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x75, 0x64, 0x69, 0x6f,
+	0x4f, 0x6e, 0x6c, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x61, 0x75, 0x64, 0x69,
+	0x6f, 0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x50, 0x65, 0x65, 0x72,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x50, 0x65, 0x65, 0x72,
+	0x73, 0x12, 0x2a, 0x0a, 0x10, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x69, 0x72, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x70, 0x61, 0x73,
+	0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x12, 0x1c, 0x0a,
+	0x09, 0x69, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x09, 0x69, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x70,
+	0x61, 0x75, 0x73, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x61, 0x75,
+	0x73, 0x65, 0x64, 0x22, 0x35, 0x0a, 0x0a, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f,
+	0x73, 0x12, 0x27, 0x0a, 0x06, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x06, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x73, 0x22, 0x8f, 0x01, 0x0a, 0x09, 0x54,
+	0x72, 0x61, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x72,
+	0x61, 0x63, 0x6b, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74, 0x72, 0x61,
+	0x63, 0x6b, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x44,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x44,
+	0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70,
+	0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x22, 0x7f, 0x0a, 0x0b,
+	0x54, 0x72, 0x61, 0x63, 0x6b, 0x4c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x70,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x69, 0x64, 0x12, 0x18, 0x0a,
+	0x07, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x74, 0x72, 0x61, 0x63, 0x6b, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x49, 0x44, 0x12, 0x28, 0x0a, 0x06, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b,
+	0x4c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x06, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x22, 0x50, 0x0a,
+	0x0a, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x4c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x73,
+	0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x73, 0x70,
+	0x61, 0x74, 0x69, 0x61, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x72, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x22,
+	0x1f, 0x0a, 0x0b, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x73, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x69, 0x64,
+	0x22, 0x2f, 0x0a, 0x09, 0x52, 0x6f, 0x6f, 0x6d, 0x4d, 0x6f, 0x76, 0x65, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f,
+	0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74,
+	0x6f, 0x22, 0x46, 0x0a, 0x0a, 0x52, 0x6f, 0x6f, 0x6d, 0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x12,
+	0x10, 0x0a, 0x03, 0x73, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x69,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x75, 0x73, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x06, 0x70, 0x61, 0x75, 0x73, 0x65, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x62, 0x79, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x62, 0x79, 0x22, 0x11, 0x0a, 0x0f, 0x47, 0x65, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xc0, 0x02, 0x0a,
+	0x0a, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x74,
+	0x72, 0x61, 0x63, 0x6b, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74, 0x72,
+	0x61, 0x63, 0x6b, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x6e, 0x62, 0x6f, 0x75, 0x6e, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x6e, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x12,
+	0x18, 0x0a, 0x07, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x07, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x4c, 0x6f, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b,
+	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x4c, 0x6f, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6a,
+	0x69, 0x74, 0x74, 0x65, 0x72, 0x4d, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6a,
+	0x69, 0x74, 0x74, 0x65, 0x72, 0x4d, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c,
+	0x4c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x73, 0x70, 0x61,
+	0x74, 0x69, 0x61, 0x6c, 0x4c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x24, 0x0a, 0x0d, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x42, 0x69, 0x74, 0x72, 0x61, 0x74, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x42, 0x69, 0x74, 0x72, 0x61, 0x74, 0x65, 0x22,
+	0xaa, 0x02, 0x0a, 0x11, 0x50, 0x65, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x27, 0x0a, 0x06, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x50, 0x65, 0x65,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x06, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x12, 0x1a,
+	0x0a, 0x08, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x4d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x08, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x4d, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x69, 0x6e,
+	0x62, 0x6f, 0x75, 0x6e, 0x64, 0x42, 0x69, 0x74, 0x72, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0e, 0x69, 0x6e, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x42, 0x69, 0x74, 0x72, 0x61,
+	0x74, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x6f, 0x75, 0x74, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x42, 0x69,
+	0x74, 0x72, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x6f, 0x75, 0x74,
+	0x62, 0x6f, 0x75, 0x6e, 0x64, 0x42, 0x69, 0x74, 0x72, 0x61, 0x74, 0x65, 0x12, 0x22, 0x0a, 0x0c,
+	0x66, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x02, 0x52, 0x0c, 0x66, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x73, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x6a, 0x69, 0x74, 0x74, 0x65, 0x72, 0x4d, 0x73, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x6a, 0x69, 0x74, 0x74, 0x65, 0x72, 0x4d, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x72, 0x74, 0x74, 0x4d, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x72, 0x74, 0x74,
+	0x4d, 0x73, 0x12, 0x28, 0x0a, 0x06, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x73, 0x18, 0x08, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x52, 0x06, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x73, 0x22, 0xc5, 0x01, 0x0a,
+	0x11, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x51, 0x75, 0x61, 0x6c, 0x69,
+	0x74, 0x79, 0x12, 0x33, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1d, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x4c, 0x65, 0x76, 0x65, 0x6c,
+	0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x22, 0x0a, 0x0c, 0x66, 0x72, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0c, 0x66,
+	0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x72,
+	0x74, 0x74, 0x4d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x72, 0x74, 0x74, 0x4d,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6a, 0x69, 0x74, 0x74, 0x65, 0x72, 0x4d, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x6a, 0x69, 0x74, 0x74, 0x65, 0x72, 0x4d, 0x73, 0x22, 0x25, 0x0a,
+	0x05, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x08, 0x0a, 0x04, 0x47, 0x4f, 0x4f, 0x44, 0x10, 0x00,
+	0x12, 0x08, 0x0a, 0x04, 0x46, 0x41, 0x49, 0x52, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x4f,
+	0x4f, 0x52, 0x10, 0x02, 0x22, 0xa0, 0x01, 0x0a, 0x0c, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x37, 0x0a, 0x08, 0x73, 0x65, 0x76,
+	0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x6e, 0x6f,
+	0x69, 0x72, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e,
+	0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69,
+	0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x22, 0x2f, 0x0a, 0x08, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69,
+	0x74, 0x79, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x4e, 0x46, 0x4f, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07,
+	0x57, 0x41, 0x52, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x52, 0x49,
+	0x54, 0x49, 0x43, 0x41, 0x4c, 0x10, 0x02, 0x22, 0x41, 0x0a, 0x0d, 0x41, 0x63, 0x74, 0x69, 0x76,
+	0x65, 0x53, 0x70, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x69, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x75,
+	0x64, 0x69, 0x6f, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a,
+	0x61, 0x75, 0x64, 0x69, 0x6f, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0x26, 0x0a, 0x10, 0x53, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x70, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x70, 0x69,
+	0x64, 0x73, 0x22, 0x4f, 0x0a, 0x0d, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x04, 0x70, 0x69, 0x64, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x65, 0x66, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x65, 0x66, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63,
+	0x61, 0x70, 0x70, 0x65, 0x64, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x63, 0x61, 0x70,
+	0x70, 0x65, 0x64, 0x22, 0xdf, 0x03, 0x0a, 0x0b, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x73, 0x69, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x42,
+	0x69, 0x74, 0x72, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x06, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1e, 0x0a, 0x04, 0x72, 0x6f,
+	0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0a, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e,
+	0x52, 0x6f, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x61,
+	0x74, 0x65, 0x4b, 0x65, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x61, 0x74,
+	0x65, 0x4b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64,
+	0x12, 0x26, 0x0a, 0x0e, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x74,
+	0x79, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x33, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x70,
+	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x11, 0x2e,
+	0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a,
+	0x09, 0x6b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x09, 0x6b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09,
+	0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x6e, 0x6f, 0x41,
+	0x75, 0x74, 0x6f, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0f, 0x6e, 0x6f, 0x41, 0x75, 0x74, 0x6f, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x62, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x61, 0x64, 0x61, 0x70, 0x74, 0x69, 0x76, 0x65, 0x4c,
+	0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x61, 0x64, 0x61,
+	0x70, 0x74, 0x69, 0x76, 0x65, 0x4c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x72,
+	0x65, 0x70, 0x6c, 0x79, 0x54, 0x6f, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65,
+	0x70, 0x6c, 0x79, 0x54, 0x6f, 0x22, 0x57, 0x0a, 0x0d, 0x42, 0x69, 0x74, 0x72, 0x61, 0x74, 0x65,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73,
+	0x68, 0x4b, 0x62, 0x70, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x70, 0x75, 0x62,
+	0x6c, 0x69, 0x73, 0x68, 0x4b, 0x62, 0x70, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x4b, 0x62, 0x70, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0d, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4b, 0x62, 0x70, 0x73, 0x22, 0xac,
+	0x01, 0x0a, 0x09, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x20, 0x0a, 0x0b,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2f,
+	0x0a, 0x0a, 0x69, 0x63, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x49, 0x43, 0x45, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x52, 0x0a, 0x69, 0x63, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x69, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x72,
+	0x65, 0x73, 0x75, 0x6d, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x91, 0x01,
+	0x0a, 0x09, 0x49, 0x43, 0x45, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x75,
+	0x72, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x75, 0x72, 0x6c, 0x73, 0x12,
+	0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63,
+	0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x12, 0x34, 0x0a, 0x07, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65,
+	0x73, 0x22, 0x5b, 0x0a, 0x09, 0x49, 0x43, 0x45, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x1c,
+	0x0a, 0x09, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x4f, 0x6e, 0x6c, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x09, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07,
+	0x74, 0x63, 0x70, 0x4f, 0x6e, 0x6c, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x74,
+	0x63, 0x70, 0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x48, 0x6f, 0x73, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6e, 0x6f, 0x48, 0x6f, 0x73, 0x74, 0x22, 0x93,
+	0x02, 0x0a, 0x0a, 0x52, 0x54, 0x43, 0x50, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x20, 0x0a,
+	0x0b, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x4e, 0x61, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x4e, 0x61, 0x63, 0x6b, 0x12,
+	0x26, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x74,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x6d, 0x69, 0x6e, 0x50, 0x6c,
+	0x69, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x4d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x10, 0x6d, 0x69, 0x6e, 0x50, 0x6c, 0x69, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x4d, 0x73, 0x12, 0x3e, 0x0a, 0x0b, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e,
+	0x52, 0x54, 0x43, 0x50, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65,
+	0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x4d, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x4d, 0x73, 0x22,
+	0x33, 0x0a, 0x0b, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x08,
+	0x0a, 0x04, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x4d, 0x49, 0x4e, 0x49,
+	0x4d, 0x55, 0x4d, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x50, 0x45, 0x52, 0x5f, 0x4c, 0x41, 0x59,
+	0x45, 0x52, 0x10, 0x02, 0x22, 0x97, 0x01, 0x0a, 0x09, 0x53, 0x44, 0x50, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x2a,
+	0x0a, 0x10, 0x6d, 0x61, 0x78, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x6d, 0x61, 0x78, 0x4d, 0x65, 0x64,
+	0x69, 0x61, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x61,
+	0x78, 0x43, 0x6f, 0x64, 0x65, 0x63, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6d,
+	0x61, 0x78, 0x43, 0x6f, 0x64, 0x65, 0x63, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x43,
+	0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0d, 0x6d, 0x61, 0x78, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x22, 0xec,
+	0x04, 0x0a, 0x0b, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x2a,
+	0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x6e,
+	0x6f, 0x69, 0x72, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x2e,
+	0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x74, 0x72, 0x79, 0x41, 0x66, 0x74,
+	0x65, 0x72, 0x4d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x72, 0x65, 0x74, 0x72,
+	0x79, 0x41, 0x66, 0x74, 0x65, 0x72, 0x4d, 0x73, 0x22, 0xf2, 0x03, 0x0a, 0x04, 0x43, 0x6f, 0x64,
+	0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x12,
+	0x0a, 0x0e, 0x52, 0x4f, 0x4f, 0x4d, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44,
+	0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x52, 0x4f, 0x4f, 0x4d, 0x5f, 0x46, 0x55, 0x4c, 0x4c, 0x10,
+	0x02, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x45, 0x45, 0x52, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f,
+	0x55, 0x4e, 0x44, 0x10, 0x03, 0x12, 0x13, 0x0a, 0x0f, 0x54, 0x52, 0x41, 0x43, 0x4b, 0x5f, 0x4e,
+	0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x04, 0x12, 0x11, 0x0a, 0x0d, 0x4e, 0x4f,
+	0x54, 0x5f, 0x53, 0x49, 0x4d, 0x55, 0x4c, 0x43, 0x41, 0x53, 0x54, 0x10, 0x05, 0x12, 0x10, 0x0a,
+	0x0c, 0x55, 0x4e, 0x41, 0x55, 0x54, 0x48, 0x4f, 0x52, 0x49, 0x5a, 0x45, 0x44, 0x10, 0x06, 0x12,
+	0x12, 0x0a, 0x0e, 0x46, 0x49, 0x4c, 0x45, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e,
+	0x44, 0x10, 0x07, 0x12, 0x15, 0x0a, 0x11, 0x55, 0x4e, 0x53, 0x55, 0x50, 0x50, 0x4f, 0x52, 0x54,
+	0x45, 0x44, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x43, 0x10, 0x08, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x4f,
+	0x4f, 0x4d, 0x5f, 0x43, 0x4c, 0x4f, 0x53, 0x45, 0x44, 0x10, 0x09, 0x12, 0x0f, 0x0a, 0x0b, 0x4a,
+	0x4f, 0x49, 0x4e, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x0a, 0x12, 0x16, 0x0a, 0x12,
+	0x4e, 0x45, 0x47, 0x4f, 0x54, 0x49, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x46, 0x41, 0x49, 0x4c,
+	0x45, 0x44, 0x10, 0x0b, 0x12, 0x12, 0x0a, 0x0e, 0x4f, 0x46, 0x46, 0x45, 0x52, 0x5f, 0x52, 0x45,
+	0x4a, 0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x0c, 0x12, 0x0c, 0x0a, 0x08, 0x49, 0x4e, 0x54, 0x45,
+	0x52, 0x4e, 0x41, 0x4c, 0x10, 0x0d, 0x12, 0x17, 0x0a, 0x13, 0x4e, 0x4f, 0x5f, 0x43, 0x4f, 0x4d,
+	0x50, 0x41, 0x54, 0x49, 0x42, 0x4c, 0x45, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x43, 0x10, 0x0e, 0x12,
+	0x10, 0x0a, 0x0c, 0x52, 0x41, 0x54, 0x45, 0x5f, 0x4c, 0x49, 0x4d, 0x49, 0x54, 0x45, 0x44, 0x10,
+	0x0f, 0x12, 0x16, 0x0a, 0x12, 0x53, 0x55, 0x42, 0x53, 0x43, 0x52, 0x49, 0x50, 0x54, 0x49, 0x4f,
+	0x4e, 0x5f, 0x4c, 0x49, 0x4d, 0x49, 0x54, 0x10, 0x10, 0x12, 0x14, 0x0a, 0x10, 0x56, 0x45, 0x52,
+	0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x49, 0x53, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x10, 0x11, 0x12,
+	0x13, 0x0a, 0x0f, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45,
+	0x53, 0x54, 0x10, 0x12, 0x12, 0x14, 0x0a, 0x10, 0x4e, 0x4f, 0x44, 0x45, 0x5f, 0x41, 0x54, 0x5f,
+	0x43, 0x41, 0x50, 0x41, 0x43, 0x49, 0x54, 0x59, 0x10, 0x13, 0x12, 0x11, 0x0a, 0x0d, 0x4e, 0x4f,
+	0x44, 0x45, 0x5f, 0x44, 0x52, 0x41, 0x49, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x14, 0x12, 0x15, 0x0a,
+	0x11, 0x4f, 0x46, 0x46, 0x45, 0x52, 0x5f, 0x54, 0x4f, 0x4f, 0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x4c,
+	0x45, 0x58, 0x10, 0x15, 0x12, 0x11, 0x0a, 0x0d, 0x50, 0x45, 0x45, 0x52, 0x5f, 0x49, 0x44, 0x5f,
+	0x54, 0x41, 0x4b, 0x45, 0x4e, 0x10, 0x16, 0x12, 0x0d, 0x0a, 0x09, 0x4e, 0x4f, 0x44, 0x45, 0x5f,
+	0x42, 0x55, 0x53, 0x59, 0x10, 0x17, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x4c, 0x52, 0x45, 0x41, 0x44,
+	0x59, 0x5f, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x18, 0x22, 0x37, 0x0a,
+	0x0b, 0x4d, 0x75, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x6d, 0x75, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x05, 0x6d, 0x75, 0x74, 0x65, 0x64, 0x22, 0x99, 0x01, 0x0a, 0x0b, 0x50, 0x6c, 0x61, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x70, 0x65, 0x61, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65, 0x70, 0x65, 0x61, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x4d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x4d, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6e, 0x64, 0x4d, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x65, 0x6e, 0x64, 0x4d, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x22, 0x63, 0x0a, 0x0f, 0x53, 0x65, 0x74, 0x4c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x07, 0x73, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x74,
+	0x65, 0x6d, 0x70, 0x6f, 0x72, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x74,
+	0x65, 0x6d, 0x70, 0x6f, 0x72, 0x61, 0x6c, 0x22, 0x9f, 0x01, 0x0a, 0x0d, 0x53, 0x65, 0x74, 0x4c,
+	0x61, 0x79, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x73, 0x70, 0x61, 0x74, 0x69, 0x61, 0x6c, 0x12,
+	0x1a, 0x0a, 0x08, 0x74, 0x65, 0x6d, 0x70, 0x6f, 0x72, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x74, 0x65, 0x6d, 0x70, 0x6f, 0x72, 0x61, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x61,
+	0x64, 0x61, 0x70, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x61, 0x64,
+	0x61, 0x70, 0x74, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74,
+	0x65, 0x4b, 0x62, 0x70, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x65, 0x73, 0x74,
+	0x69, 0x6d, 0x61, 0x74, 0x65, 0x4b, 0x62, 0x70, 0x73, 0x22, 0x61, 0x0a, 0x0b, 0x44, 0x61, 0x74,
+	0x61, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65,
+	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x18,
+	0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x22, 0x5a, 0x0a, 0x09,
+	0x50, 0x65, 0x65, 0x72, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x73,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x69, 0x64, 0x12, 0x29, 0x0a,
+	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e,
+	0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x74, 0x0a, 0x07, 0x54, 0x72, 0x69, 0x63,
+	0x6b, 0x6c, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x54, 0x72, 0x69, 0x63, 0x6b,
+	0x6c, 0x65, 0x2e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x6e, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x69, 0x6e, 0x69, 0x74, 0x22, 0x27, 0x0a, 0x06, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12,
+	0x0d, 0x0a, 0x09, 0x50, 0x55, 0x42, 0x4c, 0x49, 0x53, 0x48, 0x45, 0x52, 0x10, 0x00, 0x12, 0x0e,
+	0x0a, 0x0a, 0x53, 0x55, 0x42, 0x53, 0x43, 0x52, 0x49, 0x42, 0x45, 0x52, 0x10, 0x01, 0x22, 0x3d,
+	0x0a, 0x0c, 0x54, 0x72, 0x69, 0x63, 0x6b, 0x6c, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x2d,
+	0x0a, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x54, 0x72, 0x69, 0x63, 0x6b, 0x6c,
+	0x65, 0x52, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x22, 0xbd, 0x01,
+	0x0a, 0x0a, 0x4e, 0x6f, 0x69, 0x72, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x24, 0x0a, 0x04,
+	0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6e, 0x6f, 0x69,
+	0x72, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x44, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x04, 0x6e, 0x6f,
+	0x64, 0x65, 0x12, 0x24, 0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0e, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x44, 0x61, 0x74, 0x61,
+	0x48, 0x00, 0x52, 0x04, 0x72, 0x6f, 0x6f, 0x6d, 0x12, 0x24, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x55, 0x73,
+	0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x35,
+	0x0a, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x48,
+	0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x48, 0x00, 0x52, 0x09, 0x68, 0x65, 0x61, 0x72,
+	0x74, 0x62, 0x65, 0x61, 0x74, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x7e, 0x0a,
+	0x0a, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74,
+	0x6f, 0x70, 0x69, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69,
+	0x63, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x2a, 0x0a, 0x02, 0x61, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x02, 0x61, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xdc, 0x01,
+	0x0a, 0x0b, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x54, 0x72, 0x61, 0x63, 0x65, 0x12, 0x2a, 0x0a,
+	0x02, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x02, 0x61, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70,
+	0x69, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12,
+	0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x69,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x07, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6e, 0x6f, 0x69,
+	0x72, 0x2e, 0x4e, 0x6f, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52,
+	0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c,
+	0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x4e,
+	0x6f, 0x69, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x48, 0x00, 0x52, 0x05, 0x72, 0x65, 0x70, 0x6c,
+	0x79, 0x42, 0x09, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xa7, 0x01, 0x0a,
+	0x0f, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x36, 0x0a, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08,
+	0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x65, 0x65, 0x72,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x12, 0x1a,
+	0x0a, 0x08, 0x6d, 0x61, 0x78, 0x50, 0x65, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x08, 0x6d, 0x61, 0x78, 0x50, 0x65, 0x65, 0x72, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x72,
+	0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x64, 0x72,
+	0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x22, 0x72, 0x0a, 0x08, 0x4e, 0x6f, 0x64, 0x65, 0x44, 0x61,
+	0x74, 0x61, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x3a, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x22, 0xa4, 0x04, 0x0a, 0x08, 0x52,
+	0x6f, 0x6f, 0x6d, 0x44, 0x61, 0x74, 0x61, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x34, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x3a, 0x0a,
+	0x0a, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6c,
+	0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x64,
+	0x65, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49,
+	0x44, 0x12, 0x2b, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c,
+	0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05,
+	0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e,
+	0x65, 0x72, 0x12, 0x38, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x08,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x52, 0x6f, 0x6f, 0x6d,
+	0x44, 0x61, 0x74, 0x61, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x3e, 0x0a, 0x0a,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1e, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x52, 0x6f, 0x6f, 0x6d, 0x44, 0x61, 0x74, 0x61,
+	0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x0a, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x70, 0x61, 0x75, 0x73, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x61,
+	0x75, 0x73, 0x65, 0x64, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x1a, 0x4e, 0x0a, 0x0f, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x25, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0xb7, 0x01, 0x0a, 0x09, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x1c, 0x0a, 0x09, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x44, 0x69, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x44, 0x69, 0x72, 0x12, 0x10, 0x0a,
+	0x03, 0x70, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x69, 0x64, 0x12,
+	0x34, 0x0a, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x34, 0x0a, 0x07, 0x73, 0x74, 0x6f, 0x70, 0x70, 0x65, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x07, 0x73, 0x74, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x22, 0xb6, 0x06, 0x0a, 0x0b,
+	0x52, 0x6f, 0x6f, 0x6d, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x64,
+	0x65, 0x62, 0x75, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x64, 0x65, 0x62, 0x75,
+	0x67, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x41, 0x67,
+	0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d,
+	0x6d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x28, 0x0a,
+	0x0f, 0x6b, 0x65, 0x79, 0x45, 0x78, 0x70, 0x69, 0x72, 0x79, 0x46, 0x61, 0x63, 0x74, 0x6f, 0x72,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x6b, 0x65, 0x79, 0x45, 0x78, 0x70, 0x69, 0x72,
+	0x79, 0x46, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x6a, 0x6f, 0x69, 0x6e, 0x50,
+	0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6a,
+	0x6f, 0x69, 0x6e, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x28, 0x0a, 0x0f, 0x70,
+	0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x50, 0x61, 0x73,
+	0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x50, 0x65, 0x65, 0x72,
+	0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x50, 0x65, 0x65, 0x72,
+	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12,
+	0x1c, 0x0a, 0x09, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x4f, 0x6e, 0x6c, 0x79, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x09, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x2d, 0x0a,
+	0x09, 0x69, 0x63, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0f, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x49, 0x43, 0x45, 0x50, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x52, 0x09, 0x69, 0x63, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x36, 0x0a, 0x16,
+	0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x53, 0x70, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x54, 0x68, 0x72,
+	0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x16, 0x61, 0x63,
+	0x74, 0x69, 0x76, 0x65, 0x53, 0x70, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x54, 0x68, 0x72, 0x65, 0x73,
+	0x68, 0x6f, 0x6c, 0x64, 0x12, 0x30, 0x0a, 0x13, 0x6d, 0x61, 0x78, 0x53, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x62, 0x65, 0x64, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x13, 0x6d, 0x61, 0x78, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x64,
+	0x54, 0x72, 0x61, 0x63, 0x6b, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x6a, 0x6f, 0x69, 0x6e, 0x50, 0x61,
+	0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x48, 0x61, 0x73, 0x68, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x10, 0x6a, 0x6f, 0x69, 0x6e, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x48, 0x61,
+	0x73, 0x68, 0x12, 0x2e, 0x0a, 0x12, 0x69, 0x64, 0x6c, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75,
+	0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12,
+	0x69, 0x64, 0x6c, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x73, 0x18, 0x0f, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x73, 0x12, 0x30, 0x0a, 0x0a, 0x72, 0x74,
+	0x63, 0x70, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x52, 0x54, 0x43, 0x50, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x52, 0x0a, 0x72, 0x74, 0x63, 0x70, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x34, 0x0a, 0x15,
+	0x61, 0x75, 0x74, 0x6f, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4f, 0x77, 0x6e, 0x65,
+	0x72, 0x73, 0x68, 0x69, 0x70, 0x18, 0x11, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x61, 0x75, 0x74,
+	0x6f, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68,
+	0x69, 0x70, 0x12, 0x2d, 0x0a, 0x09, 0x73, 0x64, 0x70, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18,
+	0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x53, 0x44, 0x50,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x09, 0x73, 0x64, 0x70, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x73, 0x12, 0x33, 0x0a, 0x09, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x6f, 0x6f, 0x6d, 0x18, 0x13,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x52, 0x6f, 0x6f, 0x6d, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x09, 0x65, 0x6d, 0x70,
+	0x74, 0x79, 0x52, 0x6f, 0x6f, 0x6d, 0x12, 0x2c, 0x0a, 0x11, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x47,
+	0x72, 0x61, 0x63, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x14, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x11, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x47, 0x72, 0x61, 0x63, 0x65, 0x53, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x22, 0xa5, 0x05, 0x0a, 0x08, 0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74,
+	0x61, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x34, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x3a, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x12, 0x2b, 0x0a, 0x07, 0x6f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6e,
+	0x6f, 0x69, 0x72, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52,
+	0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x12, 0x2b, 0x0a, 0x06, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e,
+	0x42, 0x69, 0x74, 0x72, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x06, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x4d, 0x75, 0x74, 0x65, 0x64, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x4d, 0x75, 0x74, 0x65,
+	0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x4d, 0x75, 0x74, 0x65, 0x64, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x76, 0x69, 0x64, 0x65, 0x6f, 0x4d, 0x75, 0x74, 0x65,
+	0x64, 0x12, 0x1e, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x0a, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x6f, 0x6c,
+	0x65, 0x12, 0x26, 0x0a, 0x0e, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x71, 0x75, 0x61, 0x6c, 0x69,
+	0x74, 0x79, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x41, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e,
+	0x55, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x63, 0x6b,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6b, 0x65, 0x65, 0x70, 0x61, 0x6c,
+	0x69, 0x76, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x6b, 0x65, 0x65, 0x70, 0x61,
+	0x6c, 0x69, 0x76, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x61, 0x64, 0x61, 0x70, 0x74, 0x69, 0x76, 0x65,
+	0x4c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x61, 0x64,
+	0x61, 0x70, 0x74, 0x69, 0x76, 0x65, 0x4c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x1a, 0x3e, 0x0a, 0x10,
+	0x54, 0x72, 0x61, 0x63, 0x6b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xac, 0x03, 0x0a,
+	0x0a, 0x50, 0x65, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x70,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x70, 0x69, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72,
+	0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x12, 0x1e, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x0a, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52,
+	0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x2e, 0x0a, 0x12, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x53, 0x69,
+	0x67, 0x6e, 0x61, 0x6c, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x70,
+	0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x69, 0x6e,
+	0x67, 0x12, 0x30, 0x0a, 0x13, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x53,
+	0x69, 0x67, 0x6e, 0x61, 0x6c, 0x69, 0x6e, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13,
+	0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c,
+	0x69, 0x6e, 0x67, 0x12, 0x28, 0x0a, 0x0f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x64,
+	0x54, 0x72, 0x61, 0x63, 0x6b, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x64, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x73, 0x12, 0x2a, 0x0a,
+	0x10, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x64, 0x54, 0x72, 0x61, 0x63, 0x6b,
+	0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x64, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x73, 0x12, 0x32, 0x0a, 0x06, 0x6a, 0x6f, 0x69,
+	0x6e, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x06, 0x6a, 0x6f, 0x69, 0x6e, 0x65, 0x64, 0x12, 0x34, 0x0a,
+	0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x22, 0x89, 0x01, 0x0a, 0x0b,
+	0x55, 0x73, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x64,
+	0x65, 0x62, 0x75, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x64, 0x65, 0x62, 0x75,
+	0x67, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x41, 0x67,
+	0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d,
+	0x6d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x28, 0x0a,
+	0x0f, 0x6b, 0x65, 0x79, 0x45, 0x78, 0x70, 0x69, 0x72, 0x79, 0x46, 0x61, 0x63, 0x74, 0x6f, 0x72,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x6b, 0x65, 0x79, 0x45, 0x78, 0x70, 0x69, 0x72,
+	0x79, 0x46, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x22, 0xad, 0x02, 0x0a, 0x07, 0x4a, 0x6f, 0x62, 0x44,
+	0x61, 0x74, 0x61, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x12, 0x2f, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e,
+	0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x4a, 0x6f, 0x62, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x4a, 0x6f, 0x62,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x34,
+	0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x12, 0x3a, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x22, 0x3d, 0x0a, 0x09, 0x4a, 0x6f, 0x62, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x55, 0x4e, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12,
+	0x0b, 0x0a, 0x07, 0x53, 0x54, 0x4f, 0x50, 0x50, 0x45, 0x44, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05,
+	0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x03, 0x22, 0x8d, 0x01, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72,
+	0x4a, 0x6f, 0x62, 0x44, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x44, 0x12,
+	0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12, 0x24, 0x0a, 0x0d, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x73, 0x68, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d,
+	0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x73, 0x12, 0x28, 0x0a,
+	0x0f, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x73, 0x2a, 0x2b, 0x0a, 0x0b, 0x43, 0x6f, 0x6d, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00,
+	0x12, 0x08, 0x0a, 0x04, 0x47, 0x5a, 0x49, 0x50, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x5a, 0x53,
+	0x54, 0x44, 0x10, 0x02, 0x2a, 0xe8, 0x01, 0x0a, 0x0b, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x52, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10,
+	0x00, 0x12, 0x08, 0x0a, 0x04, 0x4c, 0x45, 0x46, 0x54, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x4b,
+	0x49, 0x43, 0x4b, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x4f, 0x4f, 0x4d, 0x5f,
+	0x43, 0x4c, 0x4f, 0x53, 0x45, 0x44, 0x10, 0x03, 0x12, 0x0e, 0x0a, 0x0a, 0x49, 0x43, 0x45, 0x5f,
+	0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x45, 0x54, 0x41,
+	0x43, 0x48, 0x45, 0x44, 0x10, 0x05, 0x12, 0x0f, 0x0a, 0x0b, 0x4a, 0x4f, 0x49, 0x4e, 0x5f, 0x46,
+	0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x06, 0x12, 0x11, 0x0a, 0x0d, 0x4e, 0x4f, 0x44, 0x45, 0x5f,
+	0x53, 0x54, 0x4f, 0x50, 0x50, 0x49, 0x4e, 0x47, 0x10, 0x07, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x4e,
+	0x52, 0x45, 0x41, 0x43, 0x48, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x08, 0x12, 0x0d, 0x0a, 0x09, 0x4a,
+	0x4f, 0x42, 0x5f, 0x45, 0x4e, 0x44, 0x45, 0x44, 0x10, 0x09, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x44,
+	0x4c, 0x45, 0x10, 0x0a, 0x12, 0x17, 0x0a, 0x13, 0x4e, 0x45, 0x47, 0x4f, 0x54, 0x49, 0x41, 0x54,
+	0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x4f, 0x55, 0x54, 0x10, 0x0b, 0x12, 0x09, 0x0a,
+	0x05, 0x4d, 0x4f, 0x56, 0x45, 0x44, 0x10, 0x0c, 0x12, 0x15, 0x0a, 0x11, 0x4b, 0x45, 0x45, 0x50,
+	0x41, 0x4c, 0x49, 0x56, 0x45, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x4f, 0x55, 0x54, 0x10, 0x0d, 0x2a,
+	0x34, 0x0a, 0x04, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x50, 0x55, 0x42, 0x4c, 0x49,
+	0x53, 0x48, 0x45, 0x52, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x55, 0x42, 0x53, 0x43, 0x52,
+	0x49, 0x42, 0x45, 0x52, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x4d, 0x4f, 0x44, 0x45, 0x52, 0x41,
+	0x54, 0x4f, 0x52, 0x10, 0x02, 0x2a, 0x6b, 0x0a, 0x0f, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x6f,
+	0x6f, 0x6d, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x16, 0x0a, 0x12, 0x45, 0x4d, 0x50, 0x54,
+	0x59, 0x5f, 0x52, 0x4f, 0x4f, 0x4d, 0x5f, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x10, 0x00,
+	0x12, 0x15, 0x0a, 0x11, 0x45, 0x4d, 0x50, 0x54, 0x59, 0x5f, 0x52, 0x4f, 0x4f, 0x4d, 0x5f, 0x42,
+	0x59, 0x5f, 0x41, 0x47, 0x45, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x45, 0x4d, 0x50, 0x54, 0x59,
+	0x5f, 0x52, 0x4f, 0x4f, 0x4d, 0x5f, 0x43, 0x4c, 0x4f, 0x53, 0x45, 0x10, 0x02, 0x12, 0x13, 0x0a,
+	0x0f, 0x45, 0x4d, 0x50, 0x54, 0x59, 0x5f, 0x52, 0x4f, 0x4f, 0x4d, 0x5f, 0x4b, 0x45, 0x45, 0x50,
+	0x10, 0x03, 0x32, 0xca, 0x01, 0x0a, 0x04, 0x4e, 0x6f, 0x69, 0x72, 0x12, 0x31, 0x0a, 0x09, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x11, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e,
+	0x41, 0x64, 0x6d, 0x69, 0x6e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x1a, 0x0f, 0x2e, 0x6e, 0x6f,
+	0x69, 0x72, 0x2e, 0x4e, 0x6f, 0x69, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x30, 0x01, 0x12, 0x26,
+	0x0a, 0x04, 0x53, 0x65, 0x6e, 0x64, 0x12, 0x11, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x4e, 0x6f,
+	0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0b, 0x2e, 0x6e, 0x6f, 0x69, 0x72,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x2f, 0x0a, 0x05, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12,
+	0x11, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x4e, 0x6f, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x4e, 0x6f, 0x69, 0x72, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x28, 0x01, 0x30, 0x01, 0x12, 0x36, 0x0a, 0x06, 0x53, 0x69, 0x67, 0x6e, 0x61,
+	0x6c, 0x12, 0x13, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x53, 0x69,
+	0x67, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x32,
+	0x3d, 0x0a, 0x03, 0x53, 0x46, 0x55, 0x12, 0x36, 0x0a, 0x06, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c,
+	0x12, 0x13, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x6e, 0x6f, 0x69, 0x72, 0x2e, 0x53, 0x69, 0x67,
+	0x6e, 0x61, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x42, 0x27,
+	0x5a, 0x25, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x65, 0x74,
+	0x2d, 0x70, 0x72, 0x6f, 0x70, 0x68, 0x65, 0x74, 0x2f, 0x6e, 0x6f, 0x69, 0x72, 0x2f, 0x70, 0x6b,
+	0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_pkg_proto_noir_proto_rawDescOnce sync.Once
+	file_pkg_proto_noir_proto_rawDescData = file_pkg_proto_noir_proto_rawDesc
+)
+
+func file_pkg_proto_noir_proto_rawDescGZIP() []byte {
+	file_pkg_proto_noir_proto_rawDescOnce.Do(func() {
+		file_pkg_proto_noir_proto_rawDescData = protoimpl.X.CompressGZIP(file_pkg_proto_noir_proto_rawDescData)
+	})
+	return file_pkg_proto_noir_proto_rawDescData
+}
+
+var file_pkg_proto_noir_proto_enumTypes = make([]protoimpl.EnumInfo, 11)
+var file_pkg_proto_noir_proto_msgTypes = make([]protoimpl.MessageInfo, 91)
+var file_pkg_proto_noir_proto_goTypes = []interface{}{
+	(Compression)(0),                 // 0: noir.Compression
+	(CloseReason)(0),                 // 1: noir.CloseReason
+	(Role)(0),                        // 2: noir.Role
+	(EmptyRoomPolicy)(0),             // 3: noir.EmptyRoomPolicy
+	(RoomEvent_Type)(0),              // 4: noir.RoomEvent.Type
+	(ConnectionQuality_Level)(0),     // 5: noir.ConnectionQuality.Level
+	(Announcement_Severity)(0),       // 6: noir.Announcement.Severity
+	(RTCPPolicy_Aggregation)(0),      // 7: noir.RTCPPolicy.Aggregation
+	(SignalError_Code)(0),            // 8: noir.SignalError.Code
+	(Trickle_Target)(0),              // 9: noir.Trickle.Target
+	(JobData_JobStatus)(0),           // 10: noir.JobData.JobStatus
+	(*AdminClient)(nil),              // 11: noir.AdminClient
+	(*Empty)(nil),                    // 12: noir.Empty
+	(*NoirRequest)(nil),              // 13: noir.NoirRequest
+	(*NoirReply)(nil),                // 14: noir.NoirReply
+	(*RoomEvent)(nil),                // 15: noir.RoomEvent
+	(*AdminRequest)(nil),             // 16: noir.AdminRequest
+	(*AdminReply)(nil),               // 17: noir.AdminReply
+	(*RoomCountRequest)(nil),         // 18: noir.RoomCountRequest
+	(*RoomCountReply)(nil),           // 19: noir.RoomCountReply
+	(*RoomListRequest)(nil),          // 20: noir.RoomListRequest
+	(*RoomListEntry)(nil),            // 21: noir.RoomListEntry
+	(*RoomListReply)(nil),            // 22: noir.RoomListReply
+	(*RoomStatsRequest)(nil),         // 23: noir.RoomStatsRequest
+	(*PeerStats)(nil),                // 24: noir.PeerStats
+	(*RoomStatsReply)(nil),           // 25: noir.RoomStatsReply
+	(*WorkerStateRequest)(nil),       // 26: noir.WorkerStateRequest
+	(*WorkerStateReply)(nil),         // 27: noir.WorkerStateReply
+	(*RoomAdminRequest)(nil),         // 28: noir.RoomAdminRequest
+	(*RoomAdminReply)(nil),           // 29: noir.RoomAdminReply
+	(*AnnounceReply)(nil),            // 30: noir.AnnounceReply
+	(*CreateRoomRequest)(nil),        // 31: noir.CreateRoomRequest
+	(*CreateRoomReply)(nil),          // 32: noir.CreateRoomReply
+	(*StartRecordingRequest)(nil),    // 33: noir.StartRecordingRequest
+	(*StartRecordingReply)(nil),      // 34: noir.StartRecordingReply
+	(*StopRecordingRequest)(nil),     // 35: noir.StopRecordingRequest
+	(*StopRecordingReply)(nil),       // 36: noir.StopRecordingReply
+	(*MutePeerRequest)(nil),          // 37: noir.MutePeerRequest
+	(*TraceSignalingRequest)(nil),    // 38: noir.TraceSignalingRequest
+	(*SetPasswordRequest)(nil),       // 39: noir.SetPasswordRequest
+	(*PauseRoomRequest)(nil),         // 40: noir.PauseRoomRequest
+	(*TransferOwnershipRequest)(nil), // 41: noir.TransferOwnershipRequest
+	(*KickPeerRequest)(nil),          // 42: noir.KickPeerRequest
+	(*CloseRoomRequest)(nil),         // 43: noir.CloseRoomRequest
+	(*CloseRoomReply)(nil),           // 44: noir.CloseRoomReply
+	(*RoomJobRequest)(nil),           // 45: noir.RoomJobRequest
+	(*RoomJobReply)(nil),             // 46: noir.RoomJobReply
+	(*SignalRequest)(nil),            // 47: noir.SignalRequest
+	(*SignalReply)(nil),              // 48: noir.SignalReply
+	(*CapabilitiesRequest)(nil),      // 49: noir.CapabilitiesRequest
+	(*Capabilities)(nil),             // 50: noir.Capabilities
+	(*RoomCapabilities)(nil),         // 51: noir.RoomCapabilities
+	(*TrackInfos)(nil),               // 52: noir.TrackInfos
+	(*TrackInfo)(nil),                // 53: noir.TrackInfo
+	(*TrackLayers)(nil),              // 54: noir.TrackLayers
+	(*TrackLayer)(nil),               // 55: noir.TrackLayer
+	(*MoveRequest)(nil),              // 56: noir.MoveRequest
+	(*RoomMoved)(nil),                // 57: noir.RoomMoved
+	(*RoomPaused)(nil),               // 58: noir.RoomPaused
+	(*GetStatsRequest)(nil),          // 59: noir.GetStatsRequest
+	(*TrackStats)(nil),               // 60: noir.TrackStats
+	(*PeerStatsSnapshot)(nil),        // 61: noir.PeerStatsSnapshot
+	(*ConnectionQuality)(nil),        // 62: noir.ConnectionQuality
+	(*Announcement)(nil),             // 63: noir.Announcement
+	(*ActiveSpeaker)(nil),            // 64: noir.ActiveSpeaker
+	(*SubscribeRequest)(nil),         // 65: noir.SubscribeRequest
+	(*Subscriptions)(nil),            // 66: noir.Subscriptions
+	(*JoinRequest)(nil),              // 67: noir.JoinRequest
+	(*BitrateLimits)(nil),            // 68: noir.BitrateLimits
+	(*JoinReply)(nil),                // 69: noir.JoinReply
+	(*ICEServer)(nil),                // 70: noir.ICEServer
+	(*ICEPolicy)(nil),                // 71: noir.ICEPolicy
+	(*RTCPPolicy)(nil),               // 72: noir.RTCPPolicy
+	(*SDPLimits)(nil),                // 73: noir.SDPLimits
+	(*SignalError)(nil),              // 74: noir.SignalError
+	(*MuteRequest)(nil),              // 75: noir.MuteRequest
+	(*PlayRequest)(nil),              // 76: noir.PlayRequest
+	(*SetLayerRequest)(nil),          // 77: noir.SetLayerRequest
+	(*SetLayerReply)(nil),            // 78: noir.SetLayerReply
+	(*DataMessage)(nil),              // 79: noir.DataMessage
+	(*PeerLeave)(nil),                // 80: noir.PeerLeave
+	(*Trickle)(nil),                  // 81: noir.Trickle
+	(*TrickleBatch)(nil),             // 82: noir.TrickleBatch
+	(*NoirObject)(nil),               // 83: noir.NoirObject
+	(*DeadLetter)(nil),               // 84: noir.DeadLetter
+	(*SignalTrace)(nil),              // 85: noir.SignalTrace
+	(*WorkerHeartbeat)(nil),          // 86: noir.WorkerHeartbeat
+	(*NodeData)(nil),                 // 87: noir.NodeData
+	(*RoomData)(nil),                 // 88: noir.RoomData
+	(*Recording)(nil),                // 89: noir.Recording
+	(*RoomOptions)(nil),              // 90: noir.RoomOptions
+	(*UserData)(nil),                 // 91: noir.UserData
+	(*PeerStatus)(nil),               // 92: noir.PeerStatus
+	(*UserOptions)(nil),              // 93: noir.UserOptions
+	(*JobData)(nil),                  // 94: noir.JobData
+	(*PeerJobData)(nil),              // 95: noir.PeerJobData
+	nil,                              // 96: noir.WorkerStateReply.PeerQueuesEntry
+	nil,                              // 97: noir.CreateRoomRequest.MetadataEntry
+	nil,                              // 98: noir.SignalRequest.TrackLabelsEntry
+	nil,                              // 99: noir.RoomData.MetadataEntry
+	nil,                              // 100: noir.RoomData.RecordingsEntry
+	nil,                              // 101: noir.UserData.TrackLabelsEntry
+	(*timestamp.Timestamp)(nil),      // 102: google.protobuf.Timestamp
+}
+var file_pkg_proto_noir_proto_depIdxs = []int32{
+	47,  // 0: noir.NoirRequest.signal:type_name -> noir.SignalRequest
+	16,  // 1: noir.NoirRequest.admin:type_name -> noir.AdminRequest
+	48,  // 2: noir.NoirReply.signal:type_name -> noir.SignalReply
+	17,  // 3: noir.NoirReply.admin:type_name -> noir.AdminReply
+	15,  // 4: noir.NoirReply.event:type_name -> noir.RoomEvent
+	0,   // 5: noir.NoirReply.compression:type_name -> noir.Compression
+	4,   // 6: noir.RoomEvent.type:type_name -> noir.RoomEvent.Type
+	102, // 7: noir.RoomEvent.at:type_name -> google.protobuf.Timestamp
+	1,   // 8: noir.RoomEvent.reason:type_name -> noir.CloseReason
+	54,  // 9: noir.RoomEvent.layers:type_name -> noir.TrackLayers
+	28,  // 10: noir.AdminRequest.roomAdmin:type_name -> noir.RoomAdminRequest
+	18,  // 11: noir.AdminRequest.roomCount:type_name -> noir.RoomCountRequest
+	20,  // 12: noir.AdminRequest.roomList:type_name -> noir.RoomListRequest
+	23,  // 13: noir.AdminRequest.roomStats:type_name -> noir.RoomStatsRequest
+	26,  // 14: noir.AdminRequest.workerState:type_name -> noir.WorkerStateRequest
+	29,  // 15: noir.AdminReply.roomAdmin:type_name -> noir.RoomAdminReply
+	19,  // 16: noir.AdminReply.roomCount:type_name -> noir.RoomCountReply
+	22,  // 17: noir.AdminReply.roomList:type_name -> noir.RoomListReply
+	25,  // 18: noir.AdminReply.roomStats:type_name -> noir.RoomStatsReply
+	27,  // 19: noir.AdminReply.workerState:type_name -> noir.WorkerStateReply
+	21,  // 20: noir.RoomListReply.result:type_name -> noir.RoomListEntry
+	54,  // 21: noir.PeerStats.layers:type_name -> noir.TrackLayers
+	24,  // 22: noir.RoomStatsReply.peers:type_name -> noir.PeerStats
+	102, // 23: noir.WorkerStateReply.asOf:type_name -> google.protobuf.Timestamp
+	96,  // 24: noir.WorkerStateReply.peerQueues:type_name -> noir.WorkerStateReply.PeerQueuesEntry
+	31,  // 25: noir.RoomAdminRequest.createRoom:type_name -> noir.CreateRoomRequest
+	45,  // 26: noir.RoomAdminRequest.roomJob:type_name -> noir.RoomJobRequest
+	43,  // 27: noir.RoomAdminRequest.closeRoom:type_name -> noir.CloseRoomRequest
+	33,  // 28: noir.RoomAdminRequest.startRecording:type_name -> noir.StartRecordingRequest
+	35,  // 29: noir.RoomAdminRequest.stopRecording:type_name -> noir.StopRecordingRequest
+	37,  // 30: noir.RoomAdminRequest.mutePeer:type_name -> noir.MutePeerRequest
+	42,  // 31: noir.RoomAdminRequest.kickPeer:type_name -> noir.KickPeerRequest
+	38,  // 32: noir.RoomAdminRequest.traceSignaling:type_name -> noir.TraceSignalingRequest
+	39,  // 33: noir.RoomAdminRequest.setPassword:type_name -> noir.SetPasswordRequest
+	63,  // 34: noir.RoomAdminRequest.announce:type_name -> noir.Announcement
+	40,  // 35: noir.RoomAdminRequest.pauseRoom:type_name -> noir.PauseRoomRequest
+	41,  // 36: noir.RoomAdminRequest.transferOwnership:type_name -> noir.TransferOwnershipRequest
+	32,  // 37: noir.RoomAdminReply.createRoom:type_name -> noir.CreateRoomReply
+	46,  // 38: noir.RoomAdminReply.roomJob:type_name -> noir.RoomJobReply
+	44,  // 39: noir.RoomAdminReply.closeRoom:type_name -> noir.CloseRoomReply
+	34,  // 40: noir.RoomAdminReply.startRecording:type_name -> noir.StartRecordingReply
+	36,  // 41: noir.RoomAdminReply.stopRecording:type_name -> noir.StopRecordingReply
+	30,  // 42: noir.RoomAdminReply.announce:type_name -> noir.AnnounceReply
+	90,  // 43: noir.CreateRoomRequest.options:type_name -> noir.RoomOptions
+	97,  // 44: noir.CreateRoomRequest.metadata:type_name -> noir.CreateRoomRequest.MetadataEntry
+	90,  // 45: noir.CreateRoomReply.options:type_name -> noir.RoomOptions
+	67,  // 46: noir.SignalRequest.join:type_name -> noir.JoinRequest
+	81,  // 47: noir.SignalRequest.trickle:type_name -> noir.Trickle
+	79,  // 48: noir.SignalRequest.data:type_name -> noir.DataMessage
+	77,  // 49: noir.SignalRequest.setLayer:type_name -> noir.SetLayerRequest
+	76,  // 50: noir.SignalRequest.play:type_name -> noir.PlayRequest
+	75,  // 51: noir.SignalRequest.mute:type_name -> noir.MuteRequest
+	82,  // 52: noir.SignalRequest.trickleBatch:type_name -> noir.TrickleBatch
+	65,  // 53: noir.SignalRequest.subscribe:type_name -> noir.SubscribeRequest
+	65,  // 54: noir.SignalRequest.unsubscribe:type_name -> noir.SubscribeRequest
+	59,  // 55: noir.SignalRequest.getStats:type_name -> noir.GetStatsRequest
+	58,  // 56: noir.SignalRequest.pause:type_name -> noir.RoomPaused
+	56,  // 57: noir.SignalRequest.move:type_name -> noir.MoveRequest
+	49,  // 58: noir.SignalRequest.capabilities:type_name -> noir.CapabilitiesRequest
+	1,   // 59: noir.SignalRequest.closeReason:type_name -> noir.CloseReason
+	98,  // 60: noir.SignalRequest.trackLabels:type_name -> noir.SignalRequest.TrackLabelsEntry
+	69,  // 61: noir.SignalReply.join:type_name -> noir.JoinReply
+	81,  // 62: noir.SignalReply.trickle:type_name -> noir.Trickle
+	80,  // 63: noir.SignalReply.leave:type_name -> noir.PeerLeave
+	74,  // 64: noir.SignalReply.failure:type_name -> noir.SignalError
+	79,  // 65: noir.SignalReply.data:type_name -> noir.DataMessage
+	78,  // 66: noir.SignalReply.setLayer:type_name -> noir.SetLayerReply
+	82,  // 67: noir.SignalReply.trickleBatch:type_name -> noir.TrickleBatch
+	66,  // 68: noir.SignalReply.subscriptions:type_name -> noir.Subscriptions
+	64,  // 69: noir.SignalReply.activeSpeaker:type_name -> noir.ActiveSpeaker
+	63,  // 70: noir.SignalReply.announcement:type_name -> noir.Announcement
+	62,  // 71: noir.SignalReply.quality:type_name -> noir.ConnectionQuality
+	61,  // 72: noir.SignalReply.stats:type_name -> noir.PeerStatsSnapshot
+	58,  // 73: noir.SignalReply.paused:type_name -> noir.RoomPaused
+	52,  // 74: noir.SignalReply.tracks:type_name -> noir.TrackInfos
+	57,  // 75: noir.SignalReply.moved:type_name -> noir.RoomMoved
+	54,  // 76: noir.SignalReply.layers:type_name -> noir.TrackLayers
+	50,  // 77: noir.SignalReply.capabilities:type_name -> noir.Capabilities
+	1,   // 78: noir.SignalReply.closeReason:type_name -> noir.CloseReason
+	70,  // 79: noir.Capabilities.iceServers:type_name -> noir.ICEServer
+	51,  // 80: noir.Capabilities.room:type_name -> noir.RoomCapabilities
+	53,  // 81: noir.TrackInfos.tracks:type_name -> noir.TrackInfo
+	55,  // 82: noir.TrackLayers.layers:type_name -> noir.TrackLayer
+	24,  // 83: noir.PeerStatsSnapshot.totals:type_name -> noir.PeerStats
+	60,  // 84: noir.PeerStatsSnapshot.tracks:type_name -> noir.TrackStats
+	5,   // 85: noir.ConnectionQuality.level:type_name -> noir.ConnectionQuality.Level
+	6,   // 86: noir.Announcement.severity:type_name -> noir.Announcement.Severity
+	68,  // 87: noir.JoinRequest.limits:type_name -> noir.BitrateLimits
+	2,   // 88: noir.JoinRequest.role:type_name -> noir.Role
+	0,   // 89: noir.JoinRequest.compression:type_name -> noir.Compression
+	70,  // 90: noir.JoinReply.iceServers:type_name -> noir.ICEServer
+	102, // 91: noir.ICEServer.expires:type_name -> google.protobuf.Timestamp
+	7,   // 92: noir.RTCPPolicy.aggregation:type_name -> noir.RTCPPolicy.Aggregation
+	8,   // 93: noir.SignalError.code:type_name -> noir.SignalError.Code
+	1,   // 94: noir.PeerLeave.reason:type_name -> noir.CloseReason
+	9,   // 95: noir.Trickle.target:type_name -> noir.Trickle.Target
+	81,  // 96: noir.TrickleBatch.candidates:type_name -> noir.Trickle
+	87,  // 97: noir.NoirObject.node:type_name -> noir.NodeData
+	88,  // 98: noir.NoirObject.room:type_name -> noir.RoomData
+	91,  // 99: noir.NoirObject.user:type_name -> noir.UserData
+	86,  // 100: noir.NoirObject.heartbeat:type_name -> noir.WorkerHeartbeat
+	102, // 101: noir.DeadLetter.at:type_name -> google.protobuf.Timestamp
+	102, // 102: noir.SignalTrace.at:type_name -> google.protobuf.Timestamp
+	13,  // 103: noir.SignalTrace.request:type_name -> noir.NoirRequest
+	14,  // 104: noir.SignalTrace.reply:type_name -> noir.NoirReply
+	102, // 105: noir.WorkerHeartbeat.lastSeen:type_name -> google.protobuf.Timestamp
+	102, // 106: noir.NodeData.lastUpdate:type_name -> google.protobuf.Timestamp
+	102, // 107: noir.RoomData.created:type_name -> google.protobuf.Timestamp
+	102, // 108: noir.RoomData.lastUpdate:type_name -> google.protobuf.Timestamp
+	90,  // 109: noir.RoomData.options:type_name -> noir.RoomOptions
+	99,  // 110: noir.RoomData.metadata:type_name -> noir.RoomData.MetadataEntry
+	100, // 111: noir.RoomData.recordings:type_name -> noir.RoomData.RecordingsEntry
+	102, // 112: noir.Recording.started:type_name -> google.protobuf.Timestamp
+	102, // 113: noir.Recording.stopped:type_name -> google.protobuf.Timestamp
+	71,  // 114: noir.RoomOptions.icePolicy:type_name -> noir.ICEPolicy
+	72,  // 115: noir.RoomOptions.rtcpPolicy:type_name -> noir.RTCPPolicy
+	73,  // 116: noir.RoomOptions.sdpLimits:type_name -> noir.SDPLimits
+	3,   // 117: noir.RoomOptions.emptyRoom:type_name -> noir.EmptyRoomPolicy
+	102, // 118: noir.UserData.created:type_name -> google.protobuf.Timestamp
+	102, // 119: noir.UserData.lastUpdate:type_name -> google.protobuf.Timestamp
+	93,  // 120: noir.UserData.options:type_name -> noir.UserOptions
+	68,  // 121: noir.UserData.limits:type_name -> noir.BitrateLimits
+	2,   // 122: noir.UserData.role:type_name -> noir.Role
+	101, // 123: noir.UserData.trackLabels:type_name -> noir.UserData.TrackLabelsEntry
+	2,   // 124: noir.PeerStatus.role:type_name -> noir.Role
+	102, // 125: noir.PeerStatus.joined:type_name -> google.protobuf.Timestamp
+	102, // 126: noir.PeerStatus.updated:type_name -> google.protobuf.Timestamp
+	10,  // 127: noir.JobData.status:type_name -> noir.JobData.JobStatus
+	102, // 128: noir.JobData.created:type_name -> google.protobuf.Timestamp
+	102, // 129: noir.JobData.lastUpdate:type_name -> google.protobuf.Timestamp
+	89,  // 130: noir.RoomData.RecordingsEntry.value:type_name -> noir.Recording
+	11,  // 131: noir.Noir.Subscribe:input_type -> noir.AdminClient
+	13,  // 132: noir.Noir.Send:input_type -> noir.NoirRequest
+	13,  // 133: noir.Noir.Admin:input_type -> noir.NoirRequest
+	47,  // 134: noir.Noir.Signal:input_type -> noir.SignalRequest
+	47,  // 135: noir.SFU.Signal:input_type -> noir.SignalRequest
+	14,  // 136: noir.Noir.Subscribe:output_type -> noir.NoirReply
+	12,  // 137: noir.Noir.Send:output_type -> noir.Empty
+	14,  // 138: noir.Noir.Admin:output_type -> noir.NoirReply
+	48,  // 139: noir.Noir.Signal:output_type -> noir.SignalReply
+	48,  // 140: noir.SFU.Signal:output_type -> noir.SignalReply
+	136, // [136:141] is the sub-list for method output_type
+	131, // [131:136] is the sub-list for method input_type
+	131, // [131:131] is the sub-list for extension type_name
+	131, // [131:131] is the sub-list for extension extendee
+	0,   // [0:131] is the sub-list for field type_name
+}
+
+func init() { file_pkg_proto_noir_proto_init() }
+func file_pkg_proto_noir_proto_init() {
+	if File_pkg_proto_noir_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_pkg_proto_noir_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AdminClient); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Empty); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NoirRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NoirReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoomEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AdminRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AdminReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoomCountRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoomCountReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoomListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoomListEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoomListReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoomStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoomStatsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkerStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkerStateReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoomAdminRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoomAdminReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AnnounceReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateRoomRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateRoomReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartRecordingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartRecordingReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StopRecordingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StopRecordingReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MutePeerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TraceSignalingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetPasswordRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PauseRoomRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransferOwnershipRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KickPeerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CloseRoomRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CloseRoomReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoomJobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoomJobReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignalRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2786,8 +9697,8 @@ func file_pkg_proto_noir_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_proto_noir_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Empty); i {
+		file_pkg_proto_noir_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignalReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2798,8 +9709,8 @@ func file_pkg_proto_noir_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_proto_noir_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NoirRequest); i {
+		file_pkg_proto_noir_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CapabilitiesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2810,8 +9721,8 @@ func file_pkg_proto_noir_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_proto_noir_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NoirReply); i {
+		file_pkg_proto_noir_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Capabilities); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2822,8 +9733,8 @@ func file_pkg_proto_noir_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_proto_noir_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AdminRequest); i {
+		file_pkg_proto_noir_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoomCapabilities); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2834,8 +9745,8 @@ func file_pkg_proto_noir_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_proto_noir_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AdminReply); i {
+		file_pkg_proto_noir_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrackInfos); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2846,8 +9757,8 @@ func file_pkg_proto_noir_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_proto_noir_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RoomCountRequest); i {
+		file_pkg_proto_noir_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrackInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2858,8 +9769,8 @@ func file_pkg_proto_noir_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_proto_noir_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RoomCountReply); i {
+		file_pkg_proto_noir_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrackLayers); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2870,8 +9781,8 @@ func file_pkg_proto_noir_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_proto_noir_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RoomListRequest); i {
+		file_pkg_proto_noir_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrackLayer); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2882,8 +9793,8 @@ func file_pkg_proto_noir_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_proto_noir_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RoomListEntry); i {
+		file_pkg_proto_noir_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MoveRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2894,8 +9805,8 @@ func file_pkg_proto_noir_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_proto_noir_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RoomListReply); i {
+		file_pkg_proto_noir_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoomMoved); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2906,8 +9817,8 @@ func file_pkg_proto_noir_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_proto_noir_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RoomAdminRequest); i {
+		file_pkg_proto_noir_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoomPaused); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2918,8 +9829,8 @@ func file_pkg_proto_noir_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_proto_noir_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RoomAdminReply); i {
+		file_pkg_proto_noir_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetStatsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2930,8 +9841,8 @@ func file_pkg_proto_noir_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_proto_noir_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateRoomRequest); i {
+		file_pkg_proto_noir_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrackStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2942,8 +9853,8 @@ func file_pkg_proto_noir_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_proto_noir_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateRoomReply); i {
+		file_pkg_proto_noir_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerStatsSnapshot); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2954,8 +9865,8 @@ func file_pkg_proto_noir_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_proto_noir_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RoomJobRequest); i {
+		file_pkg_proto_noir_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConnectionQuality); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2966,8 +9877,8 @@ func file_pkg_proto_noir_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_proto_noir_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RoomJobReply); i {
+		file_pkg_proto_noir_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Announcement); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2978,8 +9889,8 @@ func file_pkg_proto_noir_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_proto_noir_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignalRequest); i {
+		file_pkg_proto_noir_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActiveSpeaker); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2990,8 +9901,8 @@ func file_pkg_proto_noir_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_proto_noir_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignalReply); i {
+		file_pkg_proto_noir_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3002,7 +9913,19 @@ func file_pkg_proto_noir_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_proto_noir_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+		file_pkg_proto_noir_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Subscriptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*JoinRequest); i {
 			case 0:
 				return &v.state
@@ -3014,7 +9937,19 @@ func file_pkg_proto_noir_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_proto_noir_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+		file_pkg_proto_noir_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BitrateLimits); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*JoinReply); i {
 			case 0:
 				return &v.state
@@ -3026,7 +9961,139 @@ func file_pkg_proto_noir_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_proto_noir_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+		file_pkg_proto_noir_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ICEServer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ICEPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RTCPPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SDPLimits); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignalError); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MuteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[65].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlayRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[66].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetLayerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[67].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetLayerReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[68].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DataMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[69].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerLeave); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[70].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Trickle); i {
 			case 0:
 				return &v.state
@@ -3038,7 +10105,19 @@ func file_pkg_proto_noir_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_proto_noir_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+		file_pkg_proto_noir_proto_msgTypes[71].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrickleBatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[72].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*NoirObject); i {
 			case 0:
 				return &v.state
@@ -3050,7 +10129,43 @@ func file_pkg_proto_noir_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_proto_noir_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+		file_pkg_proto_noir_proto_msgTypes[73].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeadLetter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[74].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignalTrace); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[75].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkerHeartbeat); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[76].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*NodeData); i {
 			case 0:
 				return &v.state
@@ -3062,7 +10177,7 @@ func file_pkg_proto_noir_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_proto_noir_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+		file_pkg_proto_noir_proto_msgTypes[77].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RoomData); i {
 			case 0:
 				return &v.state
@@ -3074,7 +10189,19 @@ func file_pkg_proto_noir_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_proto_noir_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+		file_pkg_proto_noir_proto_msgTypes[78].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Recording); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[79].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RoomOptions); i {
 			case 0:
 				return &v.state
@@ -3086,7 +10213,7 @@ func file_pkg_proto_noir_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_proto_noir_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+		file_pkg_proto_noir_proto_msgTypes[80].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UserData); i {
 			case 0:
 				return &v.state
@@ -3098,7 +10225,19 @@ func file_pkg_proto_noir_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_proto_noir_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+		file_pkg_proto_noir_proto_msgTypes[81].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_proto_noir_proto_msgTypes[82].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UserOptions); i {
 			case 0:
 				return &v.state
@@ -3110,7 +10249,7 @@ func file_pkg_proto_noir_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_proto_noir_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+		file_pkg_proto_noir_proto_msgTypes[83].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*JobData); i {
 			case 0:
 				return &v.state
@@ -3122,7 +10261,7 @@ func file_pkg_proto_noir_proto_init() {
 				return nil
 			}
 		}
-		file_pkg_proto_noir_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+		file_pkg_proto_noir_proto_msgTypes[84].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PeerJobData); i {
 			case 0:
 				return &v.state
@@ -3143,53 +10282,110 @@ func file_pkg_proto_noir_proto_init() {
 		(*NoirReply_Signal)(nil),
 		(*NoirReply_Admin)(nil),
 		(*NoirReply_Error)(nil),
+		(*NoirReply_Event)(nil),
 	}
-	file_pkg_proto_noir_proto_msgTypes[4].OneofWrappers = []interface{}{
+	file_pkg_proto_noir_proto_msgTypes[5].OneofWrappers = []interface{}{
 		(*AdminRequest_RoomAdmin)(nil),
 		(*AdminRequest_RoomCount)(nil),
 		(*AdminRequest_RoomList)(nil),
+		(*AdminRequest_RoomStats)(nil),
+		(*AdminRequest_WorkerState)(nil),
 	}
-	file_pkg_proto_noir_proto_msgTypes[5].OneofWrappers = []interface{}{
+	file_pkg_proto_noir_proto_msgTypes[6].OneofWrappers = []interface{}{
 		(*AdminReply_Error)(nil),
 		(*AdminReply_RoomAdmin)(nil),
 		(*AdminReply_RoomCount)(nil),
 		(*AdminReply_RoomList)(nil),
+		(*AdminReply_RoomStats)(nil),
+		(*AdminReply_WorkerState)(nil),
 	}
-	file_pkg_proto_noir_proto_msgTypes[11].OneofWrappers = []interface{}{
+	file_pkg_proto_noir_proto_msgTypes[17].OneofWrappers = []interface{}{
 		(*RoomAdminRequest_CreateRoom)(nil),
 		(*RoomAdminRequest_RoomJob)(nil),
+		(*RoomAdminRequest_CloseRoom)(nil),
+		(*RoomAdminRequest_StartRecording)(nil),
+		(*RoomAdminRequest_StopRecording)(nil),
+		(*RoomAdminRequest_MutePeer)(nil),
+		(*RoomAdminRequest_KickPeer)(nil),
+		(*RoomAdminRequest_TraceSignaling)(nil),
+		(*RoomAdminRequest_SetPassword)(nil),
+		(*RoomAdminRequest_Announce)(nil),
+		(*RoomAdminRequest_PauseRoom)(nil),
+		(*RoomAdminRequest_TransferOwnership)(nil),
 	}
-	file_pkg_proto_noir_proto_msgTypes[12].OneofWrappers = []interface{}{
+	file_pkg_proto_noir_proto_msgTypes[18].OneofWrappers = []interface{}{
 		(*RoomAdminReply_Error)(nil),
 		(*RoomAdminReply_CreateRoom)(nil),
 		(*RoomAdminReply_RoomJob)(nil),
+		(*RoomAdminReply_CloseRoom)(nil),
+		(*RoomAdminReply_StartRecording)(nil),
+		(*RoomAdminReply_StopRecording)(nil),
+		(*RoomAdminReply_Announce)(nil),
 	}
-	file_pkg_proto_noir_proto_msgTypes[17].OneofWrappers = []interface{}{
+	file_pkg_proto_noir_proto_msgTypes[36].OneofWrappers = []interface{}{
 		(*SignalRequest_Join)(nil),
 		(*SignalRequest_Description)(nil),
 		(*SignalRequest_Trickle)(nil),
 		(*SignalRequest_Kill)(nil),
-	}
-	file_pkg_proto_noir_proto_msgTypes[18].OneofWrappers = []interface{}{
+		(*SignalRequest_Leave)(nil),
+		(*SignalRequest_Data)(nil),
+		(*SignalRequest_Resume)(nil),
+		(*SignalRequest_SetLayer)(nil),
+		(*SignalRequest_Play)(nil),
+		(*SignalRequest_Mute)(nil),
+		(*SignalRequest_TrickleBatch)(nil),
+		(*SignalRequest_Subscribe)(nil),
+		(*SignalRequest_Unsubscribe)(nil),
+		(*SignalRequest_GetStats)(nil),
+		(*SignalRequest_Pause)(nil),
+		(*SignalRequest_Move)(nil),
+		(*SignalRequest_Pong)(nil),
+		(*SignalRequest_Capabilities)(nil),
+	}
+	file_pkg_proto_noir_proto_msgTypes[37].OneofWrappers = []interface{}{
 		(*SignalReply_Join)(nil),
 		(*SignalReply_Description)(nil),
 		(*SignalReply_Trickle)(nil),
 		(*SignalReply_IceConnectionState)(nil),
 		(*SignalReply_Error)(nil),
 		(*SignalReply_Kill)(nil),
-	}
-	file_pkg_proto_noir_proto_msgTypes[22].OneofWrappers = []interface{}{
+		(*SignalReply_Leave)(nil),
+		(*SignalReply_Failure)(nil),
+		(*SignalReply_Data)(nil),
+		(*SignalReply_Resume)(nil),
+		(*SignalReply_SetLayer)(nil),
+		(*SignalReply_Play)(nil),
+		(*SignalReply_Reconnect)(nil),
+		(*SignalReply_TrickleBatch)(nil),
+		(*SignalReply_Subscriptions)(nil),
+		(*SignalReply_ActiveSpeaker)(nil),
+		(*SignalReply_Announcement)(nil),
+		(*SignalReply_Quality)(nil),
+		(*SignalReply_Stats)(nil),
+		(*SignalReply_Paused)(nil),
+		(*SignalReply_Tracks)(nil),
+		(*SignalReply_Moved)(nil),
+		(*SignalReply_Layers)(nil),
+		(*SignalReply_Ping)(nil),
+		(*SignalReply_Capabilities)(nil),
+	}
+	file_pkg_proto_noir_proto_msgTypes[72].OneofWrappers = []interface{}{
 		(*NoirObject_Node)(nil),
 		(*NoirObject_Room)(nil),
 		(*NoirObject_User)(nil),
+		(*NoirObject_Heartbeat)(nil),
+	}
+	file_pkg_proto_noir_proto_msgTypes[74].OneofWrappers = []interface{}{
+		(*SignalTrace_Request)(nil),
+		(*SignalTrace_Reply)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pkg_proto_noir_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   30,
+			NumEnums:      11,
+			NumMessages:   91,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
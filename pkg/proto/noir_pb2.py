@@ -20,15 +20,147 @@ DESCRIPTOR = _descriptor.FileDescriptor(
   syntax='proto3',
   serialized_options=b'Z%github.com/net-prophet/noir/pkg/proto',
   create_key=_descriptor._internal_create_key,
-  serialized_pb=b'\n\x14pkg/proto/noir.proto\x12\x04noir\x1a\x1fgoogle/protobuf/timestamp.proto\"\x1f\n\x0b\x41\x64minClient\x12\x10\n\x08\x63lientID\x18\x01 \x01(\t\"\x07\n\x05\x45mpty\"\x9d\x01\n\x0bNoirRequest\x12\n\n\x02id\x18\x01 \x01(\t\x12\n\n\x02\x61t\x18\x02 \x01(\t\x12\x0e\n\x06\x61\x63tion\x18\x03 \x01(\t\x12%\n\x06signal\x18\x04 \x01(\x0b\x32\x13.noir.SignalRequestH\x00\x12#\n\x05\x61\x64min\x18\x05 \x01(\x0b\x32\x12.noir.AdminRequestH\x00\x12\x0f\n\x07\x61\x64minID\x18\x06 \x01(\tB\t\n\x07\x63ommand\"\x87\x01\n\tNoirReply\x12\n\n\x02id\x18\x01 \x01(\t\x12\n\n\x02\x61t\x18\x02 \x01(\t\x12#\n\x06signal\x18\x03 \x01(\x0b\x32\x11.noir.SignalReplyH\x00\x12!\n\x05\x61\x64min\x18\x04 \x01(\x0b\x32\x10.noir.AdminReplyH\x00\x12\x0f\n\x05\x65rror\x18\x05 \x01(\tH\x00\x42\t\n\x07\x63ommand\"\x9e\x01\n\x0c\x41\x64minRequest\x12+\n\troomAdmin\x18\x01 \x01(\x0b\x32\x16.noir.RoomAdminRequestH\x00\x12+\n\troomCount\x18\x02 \x01(\x0b\x32\x16.noir.RoomCountRequestH\x00\x12)\n\x08roomList\x18\x03 \x01(\x0b\x32\x15.noir.RoomListRequestH\x00\x42\t\n\x07payload\"\xa7\x01\n\nAdminReply\x12\x0f\n\x05\x65rror\x18\x01 \x01(\tH\x00\x12)\n\troomAdmin\x18\x02 \x01(\x0b\x32\x14.noir.RoomAdminReplyH\x00\x12)\n\troomCount\x18\x03 \x01(\x0b\x32\x14.noir.RoomCountReplyH\x00\x12\'\n\x08roomList\x18\x04 \x01(\x0b\x32\x13.noir.RoomListReplyH\x00\x42\t\n\x07payload\"\x12\n\x10RoomCountRequest\" \n\x0eRoomCountReply\x12\x0e\n\x06result\x18\x01 \x01(\x03\"\x11\n\x0fRoomListRequest\"*\n\rRoomListEntry\x12\n\n\x02id\x18\x01 \x01(\t\x12\r\n\x05score\x18\x02 \x01(\x03\"C\n\rRoomListReply\x12\r\n\x05\x63ount\x18\x01 \x01(\x03\x12#\n\x06result\x18\x02 \x03(\x0b\x32\x13.noir.RoomListEntry\"\x84\x01\n\x10RoomAdminRequest\x12\x0e\n\x06roomID\x18\x01 \x01(\t\x12-\n\ncreateRoom\x18\x02 \x01(\x0b\x32\x17.noir.CreateRoomRequestH\x00\x12\'\n\x07roomJob\x18\x03 \x01(\x0b\x32\x14.noir.RoomJobRequestH\x00\x42\x08\n\x06method\"\x90\x01\n\x0eRoomAdminReply\x12\x0e\n\x06roomID\x18\x01 \x01(\t\x12\x0f\n\x05\x65rror\x18\x02 \x01(\tH\x00\x12+\n\ncreateRoom\x18\x03 \x01(\x0b\x32\x15.noir.CreateRoomReplyH\x00\x12%\n\x07roomJob\x18\x04 \x01(\x0b\x32\x12.noir.RoomJobReplyH\x00\x42\t\n\x07payload\"7\n\x11\x43reateRoomRequest\x12\"\n\x07options\x18\x01 \x01(\x0b\x32\x11.noir.RoomOptions\"5\n\x0f\x43reateRoomReply\x12\"\n\x07options\x18\x02 \x01(\x0b\x32\x11.noir.RoomOptions\"?\n\x0eRoomJobRequest\x12\x0f\n\x07handler\x18\x01 \x01(\t\x12\x0b\n\x03pid\x18\x02 \x01(\t\x12\x0f\n\x07options\x18\x03 \x01(\x0c\"M\n\x0cRoomJobReply\x12\x0f\n\x07handler\x18\x01 \x01(\t\x12\x0b\n\x03pid\x18\x02 \x01(\t\x12\x0e\n\x06status\x18\x03 \x01(\x08\x12\x0f\n\x07options\x18\x04 \x01(\x0c\"\xa5\x01\n\rSignalRequest\x12\n\n\x02id\x18\x01 \x01(\t\x12!\n\x04join\x18\x02 \x01(\x0b\x32\x11.noir.JoinRequestH\x00\x12\x15\n\x0b\x64\x65scription\x18\x03 \x01(\x0cH\x00\x12 \n\x07trickle\x18\x04 \x01(\x0b\x32\r.noir.TrickleH\x00\x12\x0e\n\x04kill\x18\x05 \x01(\x08H\x00\x12\x11\n\trequestId\x18\x06 \x01(\tB\t\n\x07payload\"\xd0\x01\n\x0bSignalReply\x12\n\n\x02id\x18\x01 \x01(\t\x12\x1f\n\x04join\x18\x02 \x01(\x0b\x32\x0f.noir.JoinReplyH\x00\x12\x15\n\x0b\x64\x65scription\x18\x03 \x01(\x0cH\x00\x12 \n\x07trickle\x18\x04 \x01(\x0b\x32\r.noir.TrickleH\x00\x12\x1c\n\x12iceConnectionState\x18\x05 \x01(\tH\x00\x12\x0f\n\x05\x65rror\x18\x06 \x01(\tH\x00\x12\x0e\n\x04kill\x18\x07 \x01(\x08H\x00\x12\x11\n\trequestId\x18\x08 \x01(\tB\t\n\x07payload\"/\n\x0bJoinRequest\x12\x0b\n\x03sid\x18\x01 \x01(\t\x12\x13\n\x0b\x64\x65scription\x18\x02 \x01(\x0c\" \n\tJoinReply\x12\x13\n\x0b\x64\x65scription\x18\x01 \x01(\x0c\"f\n\x07Trickle\x12$\n\x06target\x18\x01 \x01(\x0e\x32\x14.noir.Trickle.Target\x12\x0c\n\x04init\x18\x02 \x01(\t\"\'\n\x06Target\x12\r\n\tPUBLISHER\x10\x00\x12\x0e\n\nSUBSCRIBER\x10\x01\"t\n\nNoirObject\x12\x1e\n\x04node\x18\x01 \x01(\x0b\x32\x0e.noir.NodeDataH\x00\x12\x1e\n\x04room\x18\x02 \x01(\x0b\x32\x0e.noir.RoomDataH\x00\x12\x1e\n\x04user\x18\x03 \x01(\x0b\x32\x0e.noir.UserDataH\x00\x42\x06\n\x04\x64\x61ta\"X\n\x08NodeData\x12\n\n\x02id\x18\x01 \x01(\t\x12.\n\nlastUpdate\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x10\n\x08services\x18\x03 \x03(\t\"\xba\x01\n\x08RoomData\x12\n\n\x02id\x18\x01 \x01(\t\x12+\n\x07\x63reated\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12.\n\nlastUpdate\x18\x03 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x0e\n\x06nodeID\x18\x04 \x01(\t\x12\"\n\x07options\x18\x05 \x01(\x0b\x32\x11.noir.RoomOptions\x12\x11\n\tpublisher\x18\x06 \x01(\t\"\xaf\x01\n\x0bRoomOptions\x12\r\n\x05\x64\x65\x62ug\x18\x01 \x01(\x05\x12\r\n\x05title\x18\x02 \x01(\t\x12\x15\n\rmaxAgeSeconds\x18\x03 \x01(\x05\x12\x17\n\x0fkeyExpiryFactor\x18\x04 \x01(\x05\x12\x14\n\x0cjoinPassword\x18\x05 \x01(\t\x12\x17\n\x0fpublishPassword\x18\x06 \x01(\t\x12\x10\n\x08maxPeers\x18\x07 \x01(\x05\x12\x11\n\tisChannel\x18\x08 \x01(\x08\"\xbb\x01\n\x08UserData\x12\n\n\x02id\x18\x01 \x01(\t\x12+\n\x07\x63reated\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12.\n\nlastUpdate\x18\x04 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x0e\n\x06roomID\x18\x05 \x01(\t\x12\"\n\x07options\x18\x06 \x01(\x0b\x32\x11.noir.UserOptions\x12\x12\n\npublishing\x18\x07 \x01(\x08\"[\n\x0bUserOptions\x12\r\n\x05\x64\x65\x62ug\x18\x01 \x01(\x05\x12\r\n\x05title\x18\x02 \x01(\t\x12\x15\n\rmaxAgeSeconds\x18\x03 \x01(\x05\x12\x17\n\x0fkeyExpiryFactor\x18\x04 \x01(\x05\"\xfb\x01\n\x07JobData\x12\n\n\x02id\x18\x01 \x01(\t\x12\x0f\n\x07handler\x18\x02 \x01(\t\x12\'\n\x06status\x18\x03 \x01(\x0e\x32\x17.noir.JobData.JobStatus\x12+\n\x07\x63reated\x18\x04 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12.\n\nlastUpdate\x18\x05 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x0e\n\x06nodeID\x18\x06 \x01(\t\"=\n\tJobStatus\x12\x0b\n\x07\x43REATED\x10\x00\x12\x0b\n\x07RUNNING\x10\x01\x12\x0b\n\x07STOPPED\x10\x02\x12\t\n\x05\x45RROR\x10\x03\"]\n\x0bPeerJobData\x12\x0e\n\x06roomID\x18\x01 \x01(\t\x12\x0e\n\x06userID\x18\x02 \x01(\t\x12\x15\n\rpublishTracks\x18\x03 \x03(\t\x12\x17\n\x0fsubscribeTracks\x18\x04 \x03(\t2\xca\x01\n\x04Noir\x12\x31\n\tSubscribe\x12\x11.noir.AdminClient\x1a\x0f.noir.NoirReply0\x01\x12&\n\x04Send\x12\x11.noir.NoirRequest\x1a\x0b.noir.Empty\x12/\n\x05\x41\x64min\x12\x11.noir.NoirRequest\x1a\x0f.noir.NoirReply(\x01\x30\x01\x12\x36\n\x06Signal\x12\x13.noir.SignalRequest\x1a\x11.noir.SignalReply\"\x00(\x01\x30\x01\x32=\n\x03SFU\x12\x36\n\x06Signal\x12\x13.noir.SignalRequest\x1a\x11.noir.SignalReply\"\x00(\x01\x30\x01\x42\'Z%github.com/net-prophet/noir/pkg/protob\x06proto3'
+  serialized_pb=b'\n\x14pkg/proto/noir.proto\x12\x04noir\x1a\x1fgoogle/protobuf/timestamp.proto\"\x1f\n\x0b\x41\x64minClient\x12\x10\n\x08\x63lientID\x18\x01 \x01(\t\"\x07\n\x05\x45mpty\"\xbf\x01\n\x0bNoirRequest\x12\n\n\x02id\x18\x01 \x01(\t\x12\n\n\x02\x61t\x18\x02 \x01(\t\x12\x0e\n\x06\x61\x63tion\x18\x03 \x01(\t\x12%\n\x06signal\x18\x04 \x01(\x0b\x32\x13.noir.SignalRequestH\x00\x12#\n\x05\x61\x64min\x18\x05 \x01(\x0b\x32\x12.noir.AdminRequestH\x00\x12\x0f\n\x07\x61\x64minID\x18\x06 \x01(\t\x12\x0f\n\x07version\x18\x07 \x01(\t\x12\x0f\n\x07traceID\x18\x08 \x01(\tB\t\n\x07\x63ommand\"\x87\x02\n\tNoirReply\x12\n\n\x02id\x18\x01 \x01(\t\x12\n\n\x02\x61t\x18\x02 \x01(\t\x12#\n\x06signal\x18\x03 \x01(\x0b\x32\x11.noir.SignalReplyH\x00\x12!\n\x05\x61\x64min\x18\x04 \x01(\x0b\x32\x10.noir.AdminReplyH\x00\x12\x0f\n\x05\x65rror\x18\x05 \x01(\tH\x00\x12 \n\x05\x65vent\x18\x06 \x01(\x0b\x32\x0f.noir.RoomEventH\x00\x12\x0f\n\x07version\x18\x07 \x01(\t\x12&\n\x0b\x63ompression\x18\x08 \x01(\x0e\x32\x11.noir.Compression\x12\x12\n\ncompressed\x18\t \x01(\x0c\x12\x0f\n\x07traceID\x18\n \x01(\tB\t\n\x07\x63ommand\"\x80\x03\n\tRoomEvent\x12\"\n\x04type\x18\x01 \x01(\x0e\x32\x14.noir.RoomEvent.Type\x12\x0b\n\x03pid\x18\x02 \x01(\t\x12\x0b\n\x03sid\x18\x03 \x01(\t\x12&\n\x02\x61t\x18\x04 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x0c\n\x04kind\x18\x05 \x01(\t\x12\x12\n\naudioLevel\x18\x06 \x01(\x05\x12!\n\x06reason\x18\x07 \x01(\x0e\x32\x11.noir.CloseReason\x12!\n\x06layers\x18\x08 \x01(\x0b\x32\x11.noir.TrackLayers\"\xa4\x01\n\x04Type\x12\n\n\x06JOINED\x10\x00\x12\x08\n\x04LEFT\x10\x01\x12\t\n\x05MUTED\x10\x02\x12\x0b\n\x07UNMUTED\x10\x03\x12\n\n\x06KICKED\x10\x04\x12\x12\n\x0e\x41\x43TIVE_SPEAKER\x10\x05\x12\n\n\x06OPENED\x10\x06\x12\n\n\x06\x43LOSED\x10\x07\x12\n\n\x06PAUSED\x10\x08\x12\x0b\n\x07RESUMED\x10\t\x12\x11\n\rOWNER_CHANGED\x10\n\x12\n\n\x06LAYERS\x10\x0b\"\xfc\x01\n\x0c\x41\x64minRequest\x12+\n\troomAdmin\x18\x01 \x01(\x0b\x32\x16.noir.RoomAdminRequestH\x00\x12+\n\troomCount\x18\x02 \x01(\x0b\x32\x16.noir.RoomCountRequestH\x00\x12)\n\x08roomList\x18\x03 \x01(\x0b\x32\x15.noir.RoomListRequestH\x00\x12+\n\troomStats\x18\x04 \x01(\x0b\x32\x16.noir.RoomStatsRequestH\x00\x12/\n\x0bworkerState\x18\x05 \x01(\x0b\x32\x18.noir.WorkerStateRequestH\x00\x42\t\n\x07payload\"\x81\x02\n\nAdminReply\x12\x0f\n\x05\x65rror\x18\x01 \x01(\tH\x00\x12)\n\troomAdmin\x18\x02 \x01(\x0b\x32\x14.noir.RoomAdminReplyH\x00\x12)\n\troomCount\x18\x03 \x01(\x0b\x32\x14.noir.RoomCountReplyH\x00\x12\'\n\x08roomList\x18\x04 \x01(\x0b\x32\x13.noir.RoomListReplyH\x00\x12)\n\troomStats\x18\x05 \x01(\x0b\x32\x14.noir.RoomStatsReplyH\x00\x12-\n\x0bworkerState\x18\x06 \x01(\x0b\x32\x16.noir.WorkerStateReplyH\x00\x42\t\n\x07payload\"\x12\n\x10RoomCountRequest\" \n\x0eRoomCountReply\x12\x0e\n\x06result\x18\x01 \x01(\x03\"\x11\n\x0fRoomListRequest\"*\n\rRoomListEntry\x12\n\n\x02id\x18\x01 \x01(\t\x12\r\n\x05score\x18\x02 \x01(\x03\"C\n\rRoomListReply\x12\r\n\x05\x63ount\x18\x01 \x01(\x03\x12#\n\x06result\x18\x02 \x03(\x0b\x32\x13.noir.RoomListEntry\"\"\n\x10RoomStatsRequest\x12\x0e\n\x06roomID\x18\x01 \x01(\t\"\xdd\x01\n\tPeerStats\x12\x0b\n\x03pid\x18\x01 \x01(\t\x12\x11\n\tbytesSent\x18\x02 \x01(\x04\x12\x15\n\rbytesReceived\x18\x03 \x01(\x04\x12\x17\n\x0fpacketsReceived\x18\x04 \x01(\x04\x12\x13\n\x0bpacketsLost\x18\x05 \x01(\x04\x12\x17\n\x0fpublishedTracks\x18\x06 \x01(\x05\x12\x18\n\x10subscribedTracks\x18\x07 \x01(\x05\x12!\n\x06layers\x18\x08 \x03(\x0b\x32\x11.noir.TrackLayers\x12\x15\n\rtargetBitrate\x18\t \x01(\x04\"P\n\x0eRoomStatsReply\x12\x0e\n\x06roomID\x18\x01 \x01(\t\x12\x0e\n\x06nodeID\x18\x02 \x01(\t\x12\x1e\n\x05peers\x18\x03 \x03(\x0b\x32\x0f.noir.PeerStats\"\x14\n\x12WorkerStateRequest\"\xf1\x01\n\x10WorkerStateReply\x12\x0e\n\x06nodeID\x18\x01 \x01(\t\x12(\n\x04\x61sOf\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\r\n\x05peers\x18\x03 \x03(\t\x12\r\n\x05rooms\x18\x04 \x03(\t\x12\x16\n\x0epeerGoroutines\x18\x05 \x01(\x05\x12:\n\npeerQueues\x18\x06 \x03(\x0b\x32&.noir.WorkerStateReply.PeerQueuesEntry\x1a\x31\n\x0fPeerQueuesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x03:\x02\x38\x01\"\xff\x04\n\x10RoomAdminRequest\x12\x0e\n\x06roomID\x18\x01 \x01(\t\x12-\n\ncreateRoom\x18\x02 \x01(\x0b\x32\x17.noir.CreateRoomRequestH\x00\x12\'\n\x07roomJob\x18\x03 \x01(\x0b\x32\x14.noir.RoomJobRequestH\x00\x12+\n\tcloseRoom\x18\x04 \x01(\x0b\x32\x16.noir.CloseRoomRequestH\x00\x12\x35\n\x0estartRecording\x18\x05 \x01(\x0b\x32\x1b.noir.StartRecordingRequestH\x00\x12\x33\n\rstopRecording\x18\x06 \x01(\x0b\x32\x1a.noir.StopRecordingRequestH\x00\x12)\n\x08mutePeer\x18\x07 \x01(\x0b\x32\x15.noir.MutePeerRequestH\x00\x12)\n\x08kickPeer\x18\x08 \x01(\x0b\x32\x15.noir.KickPeerRequestH\x00\x12\x35\n\x0etraceSignaling\x18\n \x01(\x0b\x32\x1b.noir.TraceSignalingRequestH\x00\x12/\n\x0bsetPassword\x18\x0b \x01(\x0b\x32\x18.noir.SetPasswordRequestH\x00\x12&\n\x08\x61nnounce\x18\x0c \x01(\x0b\x32\x12.noir.AnnouncementH\x00\x12+\n\tpauseRoom\x18\r \x01(\x0b\x32\x16.noir.PauseRoomRequestH\x00\x12;\n\x11transferOwnership\x18\x0e \x01(\x0b\x32\x1e.noir.TransferOwnershipRequestH\x00\x12\x10\n\x08\x63\x61llerID\x18\t \x01(\tB\x08\n\x06method\"\xcc\x02\n\x0eRoomAdminReply\x12\x0e\n\x06roomID\x18\x01 \x01(\t\x12\x0f\n\x05\x65rror\x18\x02 \x01(\tH\x00\x12+\n\ncreateRoom\x18\x03 \x01(\x0b\x32\x15.noir.CreateRoomReplyH\x00\x12%\n\x07roomJob\x18\x04 \x01(\x0b\x32\x12.noir.RoomJobReplyH\x00\x12)\n\tcloseRoom\x18\x05 \x01(\x0b\x32\x14.noir.CloseRoomReplyH\x00\x12\x33\n\x0estartRecording\x18\x06 \x01(\x0b\x32\x19.noir.StartRecordingReplyH\x00\x12\x31\n\rstopRecording\x18\x07 \x01(\x0b\x32\x18.noir.StopRecordingReplyH\x00\x12\'\n\x08\x61nnounce\x18\x08 \x01(\x0b\x32\x13.noir.AnnounceReplyH\x00\x42\t\n\x07payload\"\"\n\rAnnounceReply\x12\x11\n\tdelivered\x18\x01 \x01(\x05\"\xc2\x01\n\x11\x43reateRoomRequest\x12\"\n\x07options\x18\x01 \x01(\x0b\x32\x11.noir.RoomOptions\x12\r\n\x05owner\x18\x02 \x01(\t\x12\x37\n\x08metadata\x18\x03 \x03(\x0b\x32%.noir.CreateRoomRequest.MetadataEntry\x12\x10\n\x08template\x18\x04 \x01(\t\x1a/\n\rMetadataEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01\"F\n\x0f\x43reateRoomReply\x12\"\n\x07options\x18\x02 \x01(\x0b\x32\x11.noir.RoomOptions\x12\x0f\n\x07\x63reated\x18\x03 \x01(\x08\"*\n\x15StartRecordingRequest\x12\x11\n\toutputDir\x18\x01 \x01(\t\"*\n\x13StartRecordingReply\x12\x13\n\x0brecordingID\x18\x01 \x01(\t\"+\n\x14StopRecordingRequest\x12\x13\n\x0brecordingID\x18\x01 \x01(\t\"%\n\x12StopRecordingReply\x12\x0f\n\x07stopped\x18\x01 \x03(\t\";\n\x0fMutePeerRequest\x12\x0b\n\x03pid\x18\x01 \x01(\t\x12\x0c\n\x04kind\x18\x02 \x01(\t\x12\r\n\x05muted\x18\x03 \x01(\x08\";\n\x15TraceSignalingRequest\x12\x0f\n\x07\x65nabled\x18\x01 \x01(\x08\x12\x11\n\tredactSDP\x18\x02 \x01(\x08\"&\n\x12SetPasswordRequest\x12\x10\n\x08password\x18\x01 \x01(\t\"\"\n\x10PauseRoomRequest\x12\x0e\n\x06paused\x18\x01 \x01(\x08\"\'\n\x18TransferOwnershipRequest\x12\x0b\n\x03pid\x18\x01 \x01(\t\"\x1e\n\x0fKickPeerRequest\x12\x0b\n\x03pid\x18\x01 \x01(\t\"\x12\n\x10\x43loseRoomRequest\"!\n\x0e\x43loseRoomReply\x12\x0f\n\x07\x65victed\x18\x01 \x01(\x05\"?\n\x0eRoomJobRequest\x12\x0f\n\x07handler\x18\x01 \x01(\t\x12\x0b\n\x03pid\x18\x02 \x01(\t\x12\x0f\n\x07options\x18\x03 \x01(\x0c\"M\n\x0cRoomJobReply\x12\x0f\n\x07handler\x18\x01 \x01(\t\x12\x0b\n\x03pid\x18\x02 \x01(\t\x12\x0e\n\x06status\x18\x03 \x01(\x08\x12\x0f\n\x07options\x18\x04 \x01(\x0c\"\xc4\x06\n\rSignalRequest\x12\n\n\x02id\x18\x01 \x01(\t\x12!\n\x04join\x18\x02 \x01(\x0b\x32\x11.noir.JoinRequestH\x00\x12\x15\n\x0b\x64\x65scription\x18\x03 \x01(\x0cH\x00\x12 \n\x07trickle\x18\x04 \x01(\x0b\x32\r.noir.TrickleH\x00\x12\x0e\n\x04kill\x18\x05 \x01(\x08H\x00\x12\x0f\n\x05leave\x18\x07 \x01(\x08H\x00\x12!\n\x04\x64\x61ta\x18\x08 \x01(\x0b\x32\x11.noir.DataMessageH\x00\x12\x10\n\x06resume\x18\t \x01(\x08H\x00\x12)\n\x08setLayer\x18\n \x01(\x0b\x32\x15.noir.SetLayerRequestH\x00\x12!\n\x04play\x18\x0b \x01(\x0b\x32\x11.noir.PlayRequestH\x00\x12!\n\x04mute\x18\x0c \x01(\x0b\x32\x11.noir.MuteRequestH\x00\x12*\n\x0ctrickleBatch\x18\r \x01(\x0b\x32\x12.noir.TrickleBatchH\x00\x12+\n\tsubscribe\x18\x0e \x01(\x0b\x32\x16.noir.SubscribeRequestH\x00\x12-\n\x0bunsubscribe\x18\x0f \x01(\x0b\x32\x16.noir.SubscribeRequestH\x00\x12)\n\x08getStats\x18\x11 \x01(\x0b\x32\x15.noir.GetStatsRequestH\x00\x12!\n\x05pause\x18\x12 \x01(\x0b\x32\x10.noir.RoomPausedH\x00\x12!\n\x04move\x18\x14 \x01(\x0b\x32\x11.noir.MoveRequestH\x00\x12\x0e\n\x04pong\x18\x15 \x01(\x04H\x00\x12\x31\n\x0c\x63\x61pabilities\x18\x16 \x01(\x0b\x32\x19.noir.CapabilitiesRequestH\x00\x12\x11\n\trequestId\x18\x06 \x01(\t\x12&\n\x0b\x63loseReason\x18\x10 \x01(\x0e\x32\x11.noir.CloseReason\x12\x39\n\x0btrackLabels\x18\x13 \x03(\x0b\x32$.noir.SignalRequest.TrackLabelsEntry\x12\x13\n\x0bresumeToken\x18\x17 \x01(\t\x1a\x32\n\x10TrackLabelsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01\x42\t\n\x07payload\"\x98\x07\n\x0bSignalReply\x12\n\n\x02id\x18\x01 \x01(\t\x12\x1f\n\x04join\x18\x02 \x01(\x0b\x32\x0f.noir.JoinReplyH\x00\x12\x15\n\x0b\x64\x65scription\x18\x03 \x01(\x0cH\x00\x12 \n\x07trickle\x18\x04 \x01(\x0b\x32\r.noir.TrickleH\x00\x12\x1c\n\x12iceConnectionState\x18\x05 \x01(\tH\x00\x12\x0f\n\x05\x65rror\x18\x06 \x01(\tH\x00\x12\x0e\n\x04kill\x18\x07 \x01(\x08H\x00\x12 \n\x05leave\x18\t \x01(\x0b\x32\x0f.noir.PeerLeaveH\x00\x12$\n\x07\x66\x61ilure\x18\n \x01(\x0b\x32\x11.noir.SignalErrorH\x00\x12!\n\x04\x64\x61ta\x18\x0b \x01(\x0b\x32\x11.noir.DataMessageH\x00\x12\x10\n\x06resume\x18\x0c \x01(\x08H\x00\x12\'\n\x08setLayer\x18\r \x01(\x0b\x32\x13.noir.SetLayerReplyH\x00\x12\x0e\n\x04play\x18\x0e \x01(\x08H\x00\x12\x13\n\treconnect\x18\x0f \x01(\x08H\x00\x12*\n\x0ctrickleBatch\x18\x10 \x01(\x0b\x32\x12.noir.TrickleBatchH\x00\x12,\n\rsubscriptions\x18\x11 \x01(\x0b\x32\x13.noir.SubscriptionsH\x00\x12,\n\ractiveSpeaker\x18\x12 \x01(\x0b\x32\x13.noir.ActiveSpeakerH\x00\x12*\n\x0c\x61nnouncement\x18\x13 \x01(\x0b\x32\x12.noir.AnnouncementH\x00\x12*\n\x07quality\x18\x14 \x01(\x0b\x32\x17.noir.ConnectionQualityH\x00\x12(\n\x05stats\x18\x16 \x01(\x0b\x32\x17.noir.PeerStatsSnapshotH\x00\x12\"\n\x06paused\x18\x17 \x01(\x0b\x32\x10.noir.RoomPausedH\x00\x12\"\n\x06tracks\x18\x18 \x01(\x0b\x32\x10.noir.TrackInfosH\x00\x12 \n\x05moved\x18\x19 \x01(\x0b\x32\x0f.noir.RoomMovedH\x00\x12#\n\x06layers\x18\x1a \x01(\x0b\x32\x11.noir.TrackLayersH\x00\x12\x0e\n\x04ping\x18\x1b \x01(\x04H\x00\x12*\n\x0c\x63\x61pabilities\x18\x1c \x01(\x0b\x32\x12.noir.CapabilitiesH\x00\x12\x11\n\trequestId\x18\x08 \x01(\t\x12&\n\x0b\x63loseReason\x18\x15 \x01(\x0e\x32\x11.noir.CloseReasonB\t\n\x07payload\"1\n\x13\x43\x61pabilitiesRequest\x12\x0b\n\x03sid\x18\x01 \x01(\t\x12\r\n\x05token\x18\x02 \x01(\t\"\xe2\x01\n\x0c\x43\x61pabilities\x12\x0f\n\x07version\x18\x01 \x01(\t\x12\x19\n\x11supportedVersions\x18\x02 \x03(\t\x12\x0e\n\x06\x63odecs\x18\x03 \x03(\t\x12\x11\n\trecording\x18\x04 \x01(\x08\x12\x10\n\x08playback\x18\x05 \x01(\x08\x12\x10\n\x08\x61udioMix\x18\x06 \x01(\x08\x12#\n\niceServers\x18\x07 \x03(\x0b\x32\x0f.noir.ICEServer\x12\x14\n\x0c\x61uthRequired\x18\x08 \x01(\x08\x12$\n\x04room\x18\t \x01(\x0b\x32\x16.noir.RoomCapabilities\"\x98\x01\n\x10RoomCapabilities\x12\x0e\n\x06\x65xists\x18\x01 \x01(\x08\x12\x12\n\nautoCreate\x18\x02 \x01(\x08\x12\x11\n\taudioOnly\x18\x03 \x01(\x08\x12\x10\n\x08maxPeers\x18\x04 \x01(\x05\x12\x18\n\x10passwordRequired\x18\x05 \x01(\x08\x12\x11\n\tisChannel\x18\x06 \x01(\x08\x12\x0e\n\x06paused\x18\x07 \x01(\x08\"-\n\nTrackInfos\x12\x1f\n\x06tracks\x18\x01 \x03(\x0b\x32\x0f.noir.TrackInfo\"e\n\tTrackInfo\x12\x0b\n\x03mid\x18\x01 \x01(\t\x12\x0f\n\x07trackID\x18\x02 \x01(\t\x12\x10\n\x08streamID\x18\x03 \x01(\t\x12\x0b\n\x03pid\x18\x04 \x01(\t\x12\x0c\n\x04kind\x18\x05 \x01(\t\x12\r\n\x05label\x18\x06 \x01(\t\"_\n\x0bTrackLayers\x12\x0b\n\x03pid\x18\x01 \x01(\t\x12\x0f\n\x07trackID\x18\x02 \x01(\t\x12\x10\n\x08streamID\x18\x03 \x01(\t\x12 \n\x06layers\x18\x04 \x03(\x0b\x32\x10.noir.TrackLayer\":\n\nTrackLayer\x12\x0f\n\x07spatial\x18\x01 \x01(\x05\x12\x0b\n\x03rid\x18\x02 \x01(\t\x12\x0e\n\x06\x61\x63tive\x18\x03 \x01(\x08\"\x1a\n\x0bMoveRequest\x12\x0b\n\x03sid\x18\x01 \x01(\t\"%\n\tRoomMoved\x12\x0c\n\x04\x66rom\x18\x01 \x01(\t\x12\n\n\x02to\x18\x02 \x01(\t\"5\n\nRoomPaused\x12\x0b\n\x03sid\x18\x01 \x01(\t\x12\x0e\n\x06paused\x18\x02 \x01(\x08\x12\n\n\x02\x62y\x18\x03 \x01(\t\"\x11\n\x0fGetStatsRequest\"\xd2\x01\n\nTrackStats\x12\x0f\n\x07trackId\x18\x01 \x01(\t\x12\x10\n\x08streamId\x18\x02 \x01(\t\x12\x0c\n\x04kind\x18\x03 \x01(\t\x12\x0f\n\x07inbound\x18\x04 \x01(\x08\x12\x0f\n\x07packets\x18\x05 \x01(\x04\x12\x13\n\x0bpacketsLost\x18\x06 \x01(\x04\x12\x10\n\x08jitterMs\x18\x07 \x01(\x05\x12\r\n\x05\x62ytes\x18\x08 \x01(\x04\x12\x0e\n\x06layers\x18\t \x01(\x05\x12\x14\n\x0cspatialLayer\x18\n \x01(\x05\x12\x15\n\rtargetBitrate\x18\x0b \x01(\x04\"\xd0\x01\n\x11PeerStatsSnapshot\x12\x1f\n\x06totals\x18\x01 \x01(\x0b\x32\x0f.noir.PeerStats\x12\x10\n\x08windowMs\x18\x02 \x01(\x05\x12\x16\n\x0einboundBitrate\x18\x03 \x01(\x04\x12\x17\n\x0foutboundBitrate\x18\x04 \x01(\x04\x12\x14\n\x0c\x66ractionLost\x18\x05 \x01(\x02\x12\x10\n\x08jitterMs\x18\x06 \x01(\x05\x12\r\n\x05rttMs\x18\x07 \x01(\x05\x12 \n\x06tracks\x18\x08 \x03(\x0b\x32\x10.noir.TrackStats\"\x9f\x01\n\x11\x43onnectionQuality\x12,\n\x05level\x18\x01 \x01(\x0e\x32\x1d.noir.ConnectionQuality.Level\x12\x14\n\x0c\x66ractionLost\x18\x02 \x01(\x02\x12\r\n\x05rttMs\x18\x03 \x01(\x05\x12\x10\n\x08jitterMs\x18\x04 \x01(\x05\"%\n\x05Level\x12\x08\n\x04GOOD\x10\x00\x12\x08\n\x04\x46\x41IR\x10\x01\x12\x08\n\x04POOR\x10\x02\"\x8a\x01\n\x0c\x41nnouncement\x12\x0c\n\x04text\x18\x01 \x01(\t\x12-\n\x08severity\x18\x02 \x01(\x0e\x32\x1b.noir.Announcement.Severity\x12\x0c\n\x04\x66rom\x18\x03 \x01(\t\"/\n\x08Severity\x12\x08\n\x04INFO\x10\x00\x12\x0b\n\x07WARNING\x10\x01\x12\x0c\n\x08\x43RITICAL\x10\x02\"0\n\rActiveSpeaker\x12\x0b\n\x03pid\x18\x01 \x01(\t\x12\x12\n\naudioLevel\x18\x02 \x01(\x05\" \n\x10SubscribeRequest\x12\x0c\n\x04pids\x18\x01 \x03(\t\";\n\rSubscriptions\x12\x0c\n\x04pids\x18\x01 \x03(\t\x12\x0c\n\x04left\x18\x02 \x01(\t\x12\x0e\n\x06\x63\x61pped\x18\x03 \x03(\t\"\xc8\x02\n\x0bJoinRequest\x12\x0b\n\x03sid\x18\x01 \x01(\t\x12\x13\n\x0b\x64\x65scription\x18\x02 \x01(\x0c\x12#\n\x06limits\x18\x03 \x01(\x0b\x32\x13.noir.BitrateLimits\x12\r\n\x05token\x18\x04 \x01(\t\x12\x18\n\x04role\x18\x05 \x01(\x0e\x32\n.noir.Role\x12\x0f\n\x07rateKey\x18\x06 \x01(\t\x12\x10\n\x08password\x18\x07 \x01(\t\x12\x16\n\x0equalityReports\x18\x08 \x01(\x08\x12&\n\x0b\x63ompression\x18\t \x03(\x0e\x32\x11.noir.Compression\x12\x11\n\tkeepalive\x18\n \x01(\x08\x12\x11\n\tsubscribe\x18\x0b \x03(\t\x12\x17\n\x0fnoAutoSubscribe\x18\x0c \x01(\x08\x12\x16\n\x0e\x61\x64\x61ptiveLayers\x18\r \x01(\x08\x12\x0f\n\x07replyTo\x18\x0e \x01(\t\";\n\rBitrateLimits\x12\x13\n\x0bpublishKbps\x18\x01 \x01(\r\x12\x15\n\rsubscribeKbps\x18\x02 \x01(\r\"x\n\tJoinReply\x12\x13\n\x0b\x64\x65scription\x18\x01 \x01(\x0c\x12#\n\niceServers\x18\x02 \x03(\x0b\x32\x0f.noir.ICEServer\x12\x0f\n\x07version\x18\x03 \x01(\t\x12\x0b\n\x03pid\x18\x04 \x01(\t\x12\x13\n\x0bresumeToken\x18\x05 \x01(\t\"l\n\tICEServer\x12\x0c\n\x04urls\x18\x01 \x03(\t\x12\x10\n\x08username\x18\x02 \x01(\t\x12\x12\n\ncredential\x18\x03 \x01(\t\x12+\n\x07\x65xpires\x18\x04 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\"?\n\tICEPolicy\x12\x11\n\trelayOnly\x18\x01 \x01(\x08\x12\x0f\n\x07tcpOnly\x18\x02 \x01(\x08\x12\x0e\n\x06noHost\x18\x03 \x01(\x08\"\xcd\x01\n\nRTCPPolicy\x12\x13\n\x0b\x64isableNack\x18\x01 \x01(\x08\x12\x16\n\x0emaxRetransmits\x18\x02 \x01(\x05\x12\x18\n\x10minPliIntervalMs\x18\x03 \x01(\x05\x12\x31\n\x0b\x61ggregation\x18\x04 \x01(\x0e\x32\x1c.noir.RTCPPolicy.Aggregation\x12\x10\n\x08\x62ufferMs\x18\x05 \x01(\x05\"3\n\x0b\x41ggregation\x12\x08\n\x04NONE\x10\x00\x12\x0b\n\x07MINIMUM\x10\x01\x12\r\n\tPER_LAYER\x10\x02\"a\n\tSDPLimits\x12\x10\n\x08maxBytes\x18\x01 \x01(\x05\x12\x18\n\x10maxMediaSections\x18\x02 \x01(\x05\x12\x11\n\tmaxCodecs\x18\x03 \x01(\x05\x12\x15\n\rmaxCandidates\x18\x04 \x01(\x05\"\xcf\x04\n\x0bSignalError\x12$\n\x04\x63ode\x18\x01 \x01(\x0e\x32\x16.noir.SignalError.Code\x12\x0f\n\x07message\x18\x02 \x01(\t\x12\x14\n\x0cretryAfterMs\x18\x03 \x01(\x03\"\xf2\x03\n\x04\x43ode\x12\x0b\n\x07UNKNOWN\x10\x00\x12\x12\n\x0eROOM_NOT_FOUND\x10\x01\x12\r\n\tROOM_FULL\x10\x02\x12\x12\n\x0ePEER_NOT_FOUND\x10\x03\x12\x13\n\x0fTRACK_NOT_FOUND\x10\x04\x12\x11\n\rNOT_SIMULCAST\x10\x05\x12\x10\n\x0cUNAUTHORIZED\x10\x06\x12\x12\n\x0e\x46ILE_NOT_FOUND\x10\x07\x12\x15\n\x11UNSUPPORTED_CODEC\x10\x08\x12\x0f\n\x0bROOM_CLOSED\x10\t\x12\x0f\n\x0bJOIN_FAILED\x10\n\x12\x16\n\x12NEGOTIATION_FAILED\x10\x0b\x12\x12\n\x0eOFFER_REJECTED\x10\x0c\x12\x0c\n\x08INTERNAL\x10\r\x12\x17\n\x13NO_COMPATIBLE_CODEC\x10\x0e\x12\x10\n\x0cRATE_LIMITED\x10\x0f\x12\x16\n\x12SUBSCRIPTION_LIMIT\x10\x10\x12\x14\n\x10VERSION_MISMATCH\x10\x11\x12\x13\n\x0fINVALID_REQUEST\x10\x12\x12\x14\n\x10NODE_AT_CAPACITY\x10\x13\x12\x11\n\rNODE_DRAINING\x10\x14\x12\x15\n\x11OFFER_TOO_COMPLEX\x10\x15\x12\x11\n\rPEER_ID_TAKEN\x10\x16\x12\r\n\tNODE_BUSY\x10\x17\x12\x15\n\x11\x41LREADY_CONNECTED\x10\x18\"*\n\x0bMuteRequest\x12\x0c\n\x04kind\x18\x01 \x01(\t\x12\r\n\x05muted\x18\x02 \x01(\x08\"k\n\x0bPlayRequest\x12\x0b\n\x03sid\x18\x01 \x01(\t\x12\x10\n\x08\x66ilename\x18\x02 \x01(\t\x12\x0e\n\x06repeat\x18\x03 \x01(\x08\x12\x0f\n\x07startMs\x18\x04 \x01(\x03\x12\r\n\x05\x65ndMs\x18\x05 \x01(\x03\x12\r\n\x05token\x18\x06 \x01(\t\"F\n\x0fSetLayerRequest\x12\x10\n\x08streamId\x18\x01 \x01(\t\x12\x0f\n\x07spatial\x18\x02 \x01(\x05\x12\x10\n\x08temporal\x18\x03 \x01(\x05\"k\n\rSetLayerReply\x12\x10\n\x08streamId\x18\x01 \x01(\t\x12\x0f\n\x07spatial\x18\x02 \x01(\x05\x12\x10\n\x08temporal\x18\x03 \x01(\x05\x12\x0f\n\x07\x61\x64\x61pted\x18\x04 \x01(\x08\x12\x14\n\x0c\x65stimateKbps\x18\x05 \x01(\r\"G\n\x0b\x44\x61taMessage\x12\r\n\x05label\x18\x01 \x01(\t\x12\x0f\n\x07payload\x18\x02 \x01(\x0c\x12\n\n\x02to\x18\x03 \x01(\t\x12\x0c\n\x04\x66rom\x18\x04 \x01(\t\"H\n\tPeerLeave\x12\x0b\n\x03pid\x18\x01 \x01(\t\x12\x0b\n\x03sid\x18\x02 \x01(\t\x12!\n\x06reason\x18\x03 \x01(\x0e\x32\x11.noir.CloseReason\"f\n\x07Trickle\x12$\n\x06target\x18\x01 \x01(\x0e\x32\x14.noir.Trickle.Target\x12\x0c\n\x04init\x18\x02 \x01(\t\"\'\n\x06Target\x12\r\n\tPUBLISHER\x10\x00\x12\x0e\n\nSUBSCRIBER\x10\x01\"1\n\x0cTrickleBatch\x12!\n\ncandidates\x18\x01 \x03(\x0b\x32\r.noir.Trickle\"\xa0\x01\n\nNoirObject\x12\x1e\n\x04node\x18\x01 \x01(\x0b\x32\x0e.noir.NodeDataH\x00\x12\x1e\n\x04room\x18\x02 \x01(\x0b\x32\x0e.noir.RoomDataH\x00\x12\x1e\n\x04user\x18\x03 \x01(\x0b\x32\x0e.noir.UserDataH\x00\x12*\n\theartbeat\x18\x04 \x01(\x0b\x32\x15.noir.WorkerHeartbeatH\x00\x42\x06\n\x04\x64\x61ta\"c\n\nDeadLetter\x12\r\n\x05topic\x18\x01 \x01(\t\x12\r\n\x05\x65rror\x18\x02 \x01(\t\x12&\n\x02\x61t\x18\x03 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x0f\n\x07message\x18\x04 \x01(\x0c\"\xb4\x01\n\x0bSignalTrace\x12&\n\x02\x61t\x18\x01 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\r\n\x05topic\x18\x02 \x01(\t\x12\x0b\n\x03pid\x18\x03 \x01(\t\x12\x0e\n\x06\x61\x63tion\x18\x04 \x01(\t\x12$\n\x07request\x18\x05 \x01(\x0b\x32\x11.noir.NoirRequestH\x00\x12 \n\x05reply\x18\x06 \x01(\x0b\x32\x0f.noir.NoirReplyH\x00\x42\t\n\x07message\"~\n\x0fWorkerHeartbeat\x12\n\n\x02id\x18\x01 \x01(\t\x12,\n\x08lastSeen\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\r\n\x05peers\x18\x03 \x01(\x05\x12\x10\n\x08maxPeers\x18\x04 \x01(\x05\x12\x10\n\x08\x64raining\x18\x05 \x01(\x08\"X\n\x08NodeData\x12\n\n\x02id\x18\x01 \x01(\t\x12.\n\nlastUpdate\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x10\n\x08services\x18\x03 \x03(\t\"\xb2\x03\n\x08RoomData\x12\n\n\x02id\x18\x01 \x01(\t\x12+\n\x07\x63reated\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12.\n\nlastUpdate\x18\x03 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x0e\n\x06nodeID\x18\x04 \x01(\t\x12\"\n\x07options\x18\x05 \x01(\x0b\x32\x11.noir.RoomOptions\x12\x11\n\tpublisher\x18\x06 \x01(\t\x12\r\n\x05owner\x18\x07 \x01(\t\x12.\n\x08metadata\x18\x08 \x03(\x0b\x32\x1c.noir.RoomData.MetadataEntry\x12\x32\n\nrecordings\x18\t \x03(\x0b\x32\x1e.noir.RoomData.RecordingsEntry\x12\x0e\n\x06paused\x18\n \x01(\x08\x1a/\n\rMetadataEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01\x1a\x42\n\x0fRecordingsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1e\n\x05value\x18\x02 \x01(\x0b\x32\x0f.noir.Recording:\x02\x38\x01\"\x91\x01\n\tRecording\x12\n\n\x02id\x18\x01 \x01(\t\x12\x11\n\toutputDir\x18\x02 \x01(\t\x12\x0b\n\x03pid\x18\x03 \x01(\t\x12+\n\x07started\x18\x04 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12+\n\x07stopped\x18\x05 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\"\x97\x04\n\x0bRoomOptions\x12\r\n\x05\x64\x65\x62ug\x18\x01 \x01(\x05\x12\r\n\x05title\x18\x02 \x01(\t\x12\x15\n\rmaxAgeSeconds\x18\x03 \x01(\x05\x12\x17\n\x0fkeyExpiryFactor\x18\x04 \x01(\x05\x12\x14\n\x0cjoinPassword\x18\x05 \x01(\t\x12\x17\n\x0fpublishPassword\x18\x06 \x01(\t\x12\x10\n\x08maxPeers\x18\x07 \x01(\x05\x12\x11\n\tisChannel\x18\x08 \x01(\x08\x12\x11\n\taudioOnly\x18\t \x01(\x08\x12\"\n\ticePolicy\x18\n \x01(\x0b\x32\x0f.noir.ICEPolicy\x12\x1e\n\x16\x61\x63tiveSpeakerThreshold\x18\x0b \x01(\x05\x12\x1b\n\x13maxSubscribedTracks\x18\x0c \x01(\x05\x12\x18\n\x10joinPasswordHash\x18\r \x01(\t\x12\x1a\n\x12idleTimeoutSeconds\x18\x0e \x01(\x05\x12\x0e\n\x06\x63odecs\x18\x0f \x03(\t\x12$\n\nrtcpPolicy\x18\x10 \x01(\x0b\x32\x10.noir.RTCPPolicy\x12\x1d\n\x15\x61utoTransferOwnership\x18\x11 \x01(\x08\x12\"\n\tsdpLimits\x18\x12 \x01(\x0b\x32\x0f.noir.SDPLimits\x12(\n\temptyRoom\x18\x13 \x01(\x0e\x32\x15.noir.EmptyRoomPolicy\x12\x19\n\x11\x65mptyGraceSeconds\x18\x14 \x01(\x05\"\xf2\x03\n\x08UserData\x12\n\n\x02id\x18\x01 \x01(\t\x12+\n\x07\x63reated\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12.\n\nlastUpdate\x18\x04 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x0e\n\x06roomID\x18\x05 \x01(\t\x12\"\n\x07options\x18\x06 \x01(\x0b\x32\x11.noir.UserOptions\x12\x12\n\npublishing\x18\x07 \x01(\x08\x12#\n\x06limits\x18\x08 \x01(\x0b\x32\x13.noir.BitrateLimits\x12\x10\n\x08identity\x18\t \x01(\t\x12\x12\n\naudioMuted\x18\n \x01(\x08\x12\x12\n\nvideoMuted\x18\x0b \x01(\x08\x12\x18\n\x04role\x18\x0c \x01(\x0e\x32\n.noir.Role\x12\x16\n\x0equalityReports\x18\r \x01(\x08\x12\x0f\n\x07version\x18\x0e \x01(\t\x12\x34\n\x0btrackLabels\x18\x0f \x03(\x0b\x32\x1f.noir.UserData.TrackLabelsEntry\x12\x11\n\tkeepalive\x18\x10 \x01(\x08\x12\x16\n\x0e\x61\x64\x61ptiveLayers\x18\x11 \x01(\x08\x1a\x32\n\x10TrackLabelsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01\"\xaa\x02\n\nPeerStatus\x12\x0b\n\x03pid\x18\x01 \x01(\t\x12\x0e\n\x06roomID\x18\x02 \x01(\t\x12\x18\n\x04role\x18\x03 \x01(\x0e\x32\n.noir.Role\x12\x10\n\x08iceState\x18\x04 \x01(\t\x12\x1a\n\x12publisherSignaling\x18\x05 \x01(\t\x12\x1b\n\x13subscriberSignaling\x18\x06 \x01(\t\x12\x17\n\x0fpublishedTracks\x18\x07 \x01(\x05\x12\x18\n\x10subscribedTracks\x18\x08 \x01(\x05\x12*\n\x06joined\x18\t \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12+\n\x07updated\x18\n \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x0e\n\x06worker\x18\x0b \x01(\t\"[\n\x0bUserOptions\x12\r\n\x05\x64\x65\x62ug\x18\x01 \x01(\x05\x12\r\n\x05title\x18\x02 \x01(\t\x12\x15\n\rmaxAgeSeconds\x18\x03 \x01(\x05\x12\x17\n\x0fkeyExpiryFactor\x18\x04 \x01(\x05\"\xfb\x01\n\x07JobData\x12\n\n\x02id\x18\x01 \x01(\t\x12\x0f\n\x07handler\x18\x02 \x01(\t\x12\'\n\x06status\x18\x03 \x01(\x0e\x32\x17.noir.JobData.JobStatus\x12+\n\x07\x63reated\x18\x04 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12.\n\nlastUpdate\x18\x05 \x01(\x0b\x32\x1a.google.protobuf.Timestamp\x12\x0e\n\x06nodeID\x18\x06 \x01(\t\"=\n\tJobStatus\x12\x0b\n\x07\x43REATED\x10\x00\x12\x0b\n\x07RUNNING\x10\x01\x12\x0b\n\x07STOPPED\x10\x02\x12\t\n\x05\x45RROR\x10\x03\"]\n\x0bPeerJobData\x12\x0e\n\x06roomID\x18\x01 \x01(\t\x12\x0e\n\x06userID\x18\x02 \x01(\t\x12\x15\n\rpublishTracks\x18\x03 \x03(\t\x12\x17\n\x0fsubscribeTracks\x18\x04 \x03(\t*+\n\x0b\x43ompression\x12\x08\n\x04NONE\x10\x00\x12\x08\n\x04GZIP\x10\x01\x12\x08\n\x04ZSTD\x10\x02*\xe8\x01\n\x0b\x43loseReason\x12\x0b\n\x07UNKNOWN\x10\x00\x12\x08\n\x04LEFT\x10\x01\x12\n\n\x06KICKED\x10\x02\x12\x0f\n\x0bROOM_CLOSED\x10\x03\x12\x0e\n\nICE_FAILED\x10\x04\x12\x0c\n\x08\x44\x45TACHED\x10\x05\x12\x0f\n\x0bJOIN_FAILED\x10\x06\x12\x11\n\rNODE_STOPPING\x10\x07\x12\x0f\n\x0bUNREACHABLE\x10\x08\x12\r\n\tJOB_ENDED\x10\t\x12\x08\n\x04IDLE\x10\n\x12\x17\n\x13NEGOTIATION_TIMEOUT\x10\x0b\x12\t\n\x05MOVED\x10\x0c\x12\x15\n\x11KEEPALIVE_TIMEOUT\x10\r*4\n\x04Role\x12\r\n\tPUBLISHER\x10\x00\x12\x0e\n\nSUBSCRIBER\x10\x01\x12\r\n\tMODERATOR\x10\x02*k\n\x0f\x45mptyRoomPolicy\x12\x16\n\x12\x45MPTY_ROOM_DEFAULT\x10\x00\x12\x15\n\x11\x45MPTY_ROOM_BY_AGE\x10\x01\x12\x14\n\x10\x45MPTY_ROOM_CLOSE\x10\x02\x12\x13\n\x0f\x45MPTY_ROOM_KEEP\x10\x03\x32\xca\x01\n\x04Noir\x12\x31\n\tSubscribe\x12\x11.noir.AdminClient\x1a\x0f.noir.NoirReply0\x01\x12&\n\x04Send\x12\x11.noir.NoirRequest\x1a\x0b.noir.Empty\x12/\n\x05\x41\x64min\x12\x11.noir.NoirRequest\x1a\x0f.noir.NoirReply(\x01\x30\x01\x12\x36\n\x06Signal\x12\x13.noir.SignalRequest\x1a\x11.noir.SignalReply\"\x00(\x01\x30\x01\x32=\n\x03SFU\x12\x36\n\x06Signal\x12\x13.noir.SignalRequest\x1a\x11.noir.SignalReply\"\x00(\x01\x30\x01\x42\'Z%github.com/net-prophet/noir/pkg/protob\x06proto3'
   ,
   dependencies=[google_dot_protobuf_dot_timestamp__pb2.DESCRIPTOR,])
 
+_COMPRESSION = _descriptor.EnumDescriptor(
+  name='Compression',
+  full_name='noir.Compression',
+  filename=None,
+  file=DESCRIPTOR,
+  create_key=_descriptor._internal_create_key,
+  values=[
+    _descriptor.EnumValueDescriptor(
+      name='NONE', index=0, number=0,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='GZIP', index=1, number=1,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='ZSTD', index=2, number=2,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+  ],
+  containing_type=None,
+  serialized_options=None,
+  serialized_start=12960,
+  serialized_end=13003,
+)
+_sym_db.RegisterEnumDescriptor(_COMPRESSION)
 
+Compression = enum_type_wrapper.EnumTypeWrapper(_COMPRESSION)
+NONE = 0
+GZIP = 1
+ZSTD = 2
+_CLOSEREASON = _descriptor.EnumDescriptor(
+  name='CloseReason',
+  full_name='noir.CloseReason',
+  filename=None,
+  file=DESCRIPTOR,
+  create_key=_descriptor._internal_create_key,
+  values=[
+    _descriptor.EnumValueDescriptor(
+      name='UNKNOWN', index=0, number=0,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='LEFT', index=1, number=1,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='KICKED', index=2, number=2,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='ROOM_CLOSED', index=3, number=3,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='ICE_FAILED', index=4, number=4,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='DETACHED', index=5, number=5,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='JOIN_FAILED', index=6, number=6,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='NODE_STOPPING', index=7, number=7,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='UNREACHABLE', index=8, number=8,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='JOB_ENDED', index=9, number=9,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='IDLE', index=10, number=10,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='NEGOTIATION_TIMEOUT', index=11, number=11,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='MOVED', index=12, number=12,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='KEEPALIVE_TIMEOUT', index=13, number=13,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+  ],
+  containing_type=None,
+  serialized_options=None,
+  serialized_start=13006,
+  serialized_end=13238,
+)
+_sym_db.RegisterEnumDescriptor(_CLOSEREASON)
 
-_TRICKLE_TARGET = _descriptor.EnumDescriptor(
-  name='Target',
-  full_name='noir.Trickle.Target',
+CloseReason = enum_type_wrapper.EnumTypeWrapper(_CLOSEREASON)
+UNKNOWN = 0
+LEFT = 1
+KICKED = 2
+ROOM_CLOSED = 3
+ICE_FAILED = 4
+DETACHED = 5
+JOIN_FAILED = 6
+NODE_STOPPING = 7
+UNREACHABLE = 8
+JOB_ENDED = 9
+IDLE = 10
+NEGOTIATION_TIMEOUT = 11
+MOVED = 12
+KEEPALIVE_TIMEOUT = 13
+_ROLE = _descriptor.EnumDescriptor(
+  name='Role',
+  full_name='noir.Role',
   filename=None,
   file=DESCRIPTOR,
   create_key=_descriptor._internal_create_key,
@@ -43,65 +175,3605 @@ _TRICKLE_TARGET = _descriptor.EnumDescriptor(
       serialized_options=None,
       type=None,
       create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='MODERATOR', index=2, number=2,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
   ],
   containing_type=None,
   serialized_options=None,
-  serialized_start=1983,
-  serialized_end=2022,
+  serialized_start=13240,
+  serialized_end=13292,
 )
-_sym_db.RegisterEnumDescriptor(_TRICKLE_TARGET)
+_sym_db.RegisterEnumDescriptor(_ROLE)
 
-_JOBDATA_JOBSTATUS = _descriptor.EnumDescriptor(
-  name='JobStatus',
-  full_name='noir.JobData.JobStatus',
+Role = enum_type_wrapper.EnumTypeWrapper(_ROLE)
+PUBLISHER = 0
+SUBSCRIBER = 1
+MODERATOR = 2
+_EMPTYROOMPOLICY = _descriptor.EnumDescriptor(
+  name='EmptyRoomPolicy',
+  full_name='noir.EmptyRoomPolicy',
   filename=None,
   file=DESCRIPTOR,
   create_key=_descriptor._internal_create_key,
   values=[
     _descriptor.EnumValueDescriptor(
-      name='CREATED', index=0, number=0,
+      name='EMPTY_ROOM_DEFAULT', index=0, number=0,
       serialized_options=None,
       type=None,
       create_key=_descriptor._internal_create_key),
     _descriptor.EnumValueDescriptor(
-      name='RUNNING', index=1, number=1,
+      name='EMPTY_ROOM_BY_AGE', index=1, number=1,
       serialized_options=None,
       type=None,
       create_key=_descriptor._internal_create_key),
     _descriptor.EnumValueDescriptor(
-      name='STOPPED', index=2, number=2,
+      name='EMPTY_ROOM_CLOSE', index=2, number=2,
       serialized_options=None,
       type=None,
       create_key=_descriptor._internal_create_key),
     _descriptor.EnumValueDescriptor(
-      name='ERROR', index=3, number=3,
+      name='EMPTY_ROOM_KEEP', index=3, number=3,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+  ],
+  containing_type=None,
+  serialized_options=None,
+  serialized_start=13294,
+  serialized_end=13401,
+)
+_sym_db.RegisterEnumDescriptor(_EMPTYROOMPOLICY)
+
+EmptyRoomPolicy = enum_type_wrapper.EnumTypeWrapper(_EMPTYROOMPOLICY)
+EMPTY_ROOM_DEFAULT = 0
+EMPTY_ROOM_BY_AGE = 1
+EMPTY_ROOM_CLOSE = 2
+EMPTY_ROOM_KEEP = 3
+
+
+_ROOMEVENT_TYPE = _descriptor.EnumDescriptor(
+  name='Type',
+  full_name='noir.RoomEvent.Type',
+  filename=None,
+  file=DESCRIPTOR,
+  create_key=_descriptor._internal_create_key,
+  values=[
+    _descriptor.EnumValueDescriptor(
+      name='JOINED', index=0, number=0,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='LEFT', index=1, number=1,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='MUTED', index=2, number=2,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='UNMUTED', index=3, number=3,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='KICKED', index=4, number=4,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='ACTIVE_SPEAKER', index=5, number=5,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='OPENED', index=6, number=6,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='CLOSED', index=7, number=7,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='PAUSED', index=8, number=8,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='RESUMED', index=9, number=9,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='OWNER_CHANGED', index=10, number=10,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='LAYERS', index=11, number=11,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+  ],
+  containing_type=None,
+  serialized_options=None,
+  serialized_start=786,
+  serialized_end=950,
+)
+_sym_db.RegisterEnumDescriptor(_ROOMEVENT_TYPE)
+
+_CONNECTIONQUALITY_LEVEL = _descriptor.EnumDescriptor(
+  name='Level',
+  full_name='noir.ConnectionQuality.Level',
+  filename=None,
+  file=DESCRIPTOR,
+  create_key=_descriptor._internal_create_key,
+  values=[
+    _descriptor.EnumValueDescriptor(
+      name='GOOD', index=0, number=0,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='FAIR', index=1, number=1,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='POOR', index=2, number=2,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+  ],
+  containing_type=None,
+  serialized_options=None,
+  serialized_start=7377,
+  serialized_end=7414,
+)
+_sym_db.RegisterEnumDescriptor(_CONNECTIONQUALITY_LEVEL)
+
+_ANNOUNCEMENT_SEVERITY = _descriptor.EnumDescriptor(
+  name='Severity',
+  full_name='noir.Announcement.Severity',
+  filename=None,
+  file=DESCRIPTOR,
+  create_key=_descriptor._internal_create_key,
+  values=[
+    _descriptor.EnumValueDescriptor(
+      name='INFO', index=0, number=0,
       serialized_options=None,
       type=None,
       create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='WARNING', index=1, number=1,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='CRITICAL', index=2, number=2,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+  ],
+  containing_type=None,
+  serialized_options=None,
+  serialized_start=7508,
+  serialized_end=7555,
+)
+_sym_db.RegisterEnumDescriptor(_ANNOUNCEMENT_SEVERITY)
+
+_RTCPPOLICY_AGGREGATION = _descriptor.EnumDescriptor(
+  name='Aggregation',
+  full_name='noir.RTCPPolicy.Aggregation',
+  filename=None,
+  file=DESCRIPTOR,
+  create_key=_descriptor._internal_create_key,
+  values=[
+    _descriptor.EnumValueDescriptor(
+      name='NONE', index=0, number=0,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='MINIMUM', index=1, number=1,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='PER_LAYER', index=2, number=2,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+  ],
+  containing_type=None,
+  serialized_options=None,
+  serialized_start=8546,
+  serialized_end=8597,
+)
+_sym_db.RegisterEnumDescriptor(_RTCPPOLICY_AGGREGATION)
+
+_SIGNALERROR_CODE = _descriptor.EnumDescriptor(
+  name='Code',
+  full_name='noir.SignalError.Code',
+  filename=None,
+  file=DESCRIPTOR,
+  create_key=_descriptor._internal_create_key,
+  values=[
+    _descriptor.EnumValueDescriptor(
+      name='UNKNOWN', index=0, number=0,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='ROOM_NOT_FOUND', index=1, number=1,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='ROOM_FULL', index=2, number=2,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='PEER_NOT_FOUND', index=3, number=3,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='TRACK_NOT_FOUND', index=4, number=4,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='NOT_SIMULCAST', index=5, number=5,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='UNAUTHORIZED', index=6, number=6,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='FILE_NOT_FOUND', index=7, number=7,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='UNSUPPORTED_CODEC', index=8, number=8,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='ROOM_CLOSED', index=9, number=9,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='JOIN_FAILED', index=10, number=10,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='NEGOTIATION_FAILED', index=11, number=11,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='OFFER_REJECTED', index=12, number=12,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='INTERNAL', index=13, number=13,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='NO_COMPATIBLE_CODEC', index=14, number=14,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='RATE_LIMITED', index=15, number=15,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='SUBSCRIPTION_LIMIT', index=16, number=16,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='VERSION_MISMATCH', index=17, number=17,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='INVALID_REQUEST', index=18, number=18,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='NODE_AT_CAPACITY', index=19, number=19,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='NODE_DRAINING', index=20, number=20,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='OFFER_TOO_COMPLEX', index=21, number=21,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='PEER_ID_TAKEN', index=22, number=22,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='NODE_BUSY', index=23, number=23,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='ALREADY_CONNECTED', index=24, number=24,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+  ],
+  containing_type=None,
+  serialized_options=None,
+  serialized_start=8792,
+  serialized_end=9290,
+)
+_sym_db.RegisterEnumDescriptor(_SIGNALERROR_CODE)
+
+_TRICKLE_TARGET = _descriptor.EnumDescriptor(
+  name='Target',
+  full_name='noir.Trickle.Target',
+  filename=None,
+  file=DESCRIPTOR,
+  create_key=_descriptor._internal_create_key,
+  values=[
+    _descriptor.EnumValueDescriptor(
+      name='PUBLISHER', index=0, number=0,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='SUBSCRIBER', index=1, number=1,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+  ],
+  containing_type=None,
+  serialized_options=None,
+  serialized_start=9836,
+  serialized_end=9875,
+)
+_sym_db.RegisterEnumDescriptor(_TRICKLE_TARGET)
+
+_JOBDATA_JOBSTATUS = _descriptor.EnumDescriptor(
+  name='JobStatus',
+  full_name='noir.JobData.JobStatus',
+  filename=None,
+  file=DESCRIPTOR,
+  create_key=_descriptor._internal_create_key,
+  values=[
+    _descriptor.EnumValueDescriptor(
+      name='CREATED', index=0, number=0,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='RUNNING', index=1, number=1,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='STOPPED', index=2, number=2,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+    _descriptor.EnumValueDescriptor(
+      name='ERROR', index=3, number=3,
+      serialized_options=None,
+      type=None,
+      create_key=_descriptor._internal_create_key),
+  ],
+  containing_type=None,
+  serialized_options=None,
+  serialized_start=12802,
+  serialized_end=12863,
+)
+_sym_db.RegisterEnumDescriptor(_JOBDATA_JOBSTATUS)
+
+
+_ADMINCLIENT = _descriptor.Descriptor(
+  name='AdminClient',
+  full_name='noir.AdminClient',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='clientID', full_name='noir.AdminClient.clientID', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=63,
+  serialized_end=94,
+)
+
+
+_EMPTY = _descriptor.Descriptor(
+  name='Empty',
+  full_name='noir.Empty',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=96,
+  serialized_end=103,
+)
+
+
+_NOIRREQUEST = _descriptor.Descriptor(
+  name='NoirRequest',
+  full_name='noir.NoirRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='id', full_name='noir.NoirRequest.id', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='at', full_name='noir.NoirRequest.at', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='action', full_name='noir.NoirRequest.action', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='signal', full_name='noir.NoirRequest.signal', index=3,
+      number=4, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='admin', full_name='noir.NoirRequest.admin', index=4,
+      number=5, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='adminID', full_name='noir.NoirRequest.adminID', index=5,
+      number=6, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='version', full_name='noir.NoirRequest.version', index=6,
+      number=7, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='traceID', full_name='noir.NoirRequest.traceID', index=7,
+      number=8, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+    _descriptor.OneofDescriptor(
+      name='command', full_name='noir.NoirRequest.command',
+      index=0, containing_type=None,
+      create_key=_descriptor._internal_create_key,
+    fields=[]),
+  ],
+  serialized_start=106,
+  serialized_end=297,
+)
+
+
+_NOIRREPLY = _descriptor.Descriptor(
+  name='NoirReply',
+  full_name='noir.NoirReply',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='id', full_name='noir.NoirReply.id', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='at', full_name='noir.NoirReply.at', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='signal', full_name='noir.NoirReply.signal', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='admin', full_name='noir.NoirReply.admin', index=3,
+      number=4, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='error', full_name='noir.NoirReply.error', index=4,
+      number=5, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='event', full_name='noir.NoirReply.event', index=5,
+      number=6, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='version', full_name='noir.NoirReply.version', index=6,
+      number=7, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='compression', full_name='noir.NoirReply.compression', index=7,
+      number=8, type=14, cpp_type=8, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='compressed', full_name='noir.NoirReply.compressed', index=8,
+      number=9, type=12, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"",
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='traceID', full_name='noir.NoirReply.traceID', index=9,
+      number=10, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+    _descriptor.OneofDescriptor(
+      name='command', full_name='noir.NoirReply.command',
+      index=0, containing_type=None,
+      create_key=_descriptor._internal_create_key,
+    fields=[]),
+  ],
+  serialized_start=300,
+  serialized_end=563,
+)
+
+
+_ROOMEVENT = _descriptor.Descriptor(
+  name='RoomEvent',
+  full_name='noir.RoomEvent',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='type', full_name='noir.RoomEvent.type', index=0,
+      number=1, type=14, cpp_type=8, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='pid', full_name='noir.RoomEvent.pid', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='sid', full_name='noir.RoomEvent.sid', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='at', full_name='noir.RoomEvent.at', index=3,
+      number=4, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='kind', full_name='noir.RoomEvent.kind', index=4,
+      number=5, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='audioLevel', full_name='noir.RoomEvent.audioLevel', index=5,
+      number=6, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='reason', full_name='noir.RoomEvent.reason', index=6,
+      number=7, type=14, cpp_type=8, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='layers', full_name='noir.RoomEvent.layers', index=7,
+      number=8, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+    _ROOMEVENT_TYPE,
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=566,
+  serialized_end=950,
+)
+
+
+_ADMINREQUEST = _descriptor.Descriptor(
+  name='AdminRequest',
+  full_name='noir.AdminRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='roomAdmin', full_name='noir.AdminRequest.roomAdmin', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='roomCount', full_name='noir.AdminRequest.roomCount', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='roomList', full_name='noir.AdminRequest.roomList', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='roomStats', full_name='noir.AdminRequest.roomStats', index=3,
+      number=4, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='workerState', full_name='noir.AdminRequest.workerState', index=4,
+      number=5, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+    _descriptor.OneofDescriptor(
+      name='payload', full_name='noir.AdminRequest.payload',
+      index=0, containing_type=None,
+      create_key=_descriptor._internal_create_key,
+    fields=[]),
+  ],
+  serialized_start=953,
+  serialized_end=1205,
+)
+
+
+_ADMINREPLY = _descriptor.Descriptor(
+  name='AdminReply',
+  full_name='noir.AdminReply',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='error', full_name='noir.AdminReply.error', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='roomAdmin', full_name='noir.AdminReply.roomAdmin', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='roomCount', full_name='noir.AdminReply.roomCount', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='roomList', full_name='noir.AdminReply.roomList', index=3,
+      number=4, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='roomStats', full_name='noir.AdminReply.roomStats', index=4,
+      number=5, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='workerState', full_name='noir.AdminReply.workerState', index=5,
+      number=6, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+    _descriptor.OneofDescriptor(
+      name='payload', full_name='noir.AdminReply.payload',
+      index=0, containing_type=None,
+      create_key=_descriptor._internal_create_key,
+    fields=[]),
+  ],
+  serialized_start=1208,
+  serialized_end=1465,
+)
+
+
+_ROOMCOUNTREQUEST = _descriptor.Descriptor(
+  name='RoomCountRequest',
+  full_name='noir.RoomCountRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=1467,
+  serialized_end=1485,
+)
+
+
+_ROOMCOUNTREPLY = _descriptor.Descriptor(
+  name='RoomCountReply',
+  full_name='noir.RoomCountReply',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='result', full_name='noir.RoomCountReply.result', index=0,
+      number=1, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=1487,
+  serialized_end=1519,
+)
+
+
+_ROOMLISTREQUEST = _descriptor.Descriptor(
+  name='RoomListRequest',
+  full_name='noir.RoomListRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=1521,
+  serialized_end=1538,
+)
+
+
+_ROOMLISTENTRY = _descriptor.Descriptor(
+  name='RoomListEntry',
+  full_name='noir.RoomListEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='id', full_name='noir.RoomListEntry.id', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='score', full_name='noir.RoomListEntry.score', index=1,
+      number=2, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=1540,
+  serialized_end=1582,
+)
+
+
+_ROOMLISTREPLY = _descriptor.Descriptor(
+  name='RoomListReply',
+  full_name='noir.RoomListReply',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='count', full_name='noir.RoomListReply.count', index=0,
+      number=1, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='result', full_name='noir.RoomListReply.result', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=1584,
+  serialized_end=1651,
+)
+
+
+_ROOMSTATSREQUEST = _descriptor.Descriptor(
+  name='RoomStatsRequest',
+  full_name='noir.RoomStatsRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='roomID', full_name='noir.RoomStatsRequest.roomID', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=1653,
+  serialized_end=1687,
+)
+
+
+_PEERSTATS = _descriptor.Descriptor(
+  name='PeerStats',
+  full_name='noir.PeerStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='pid', full_name='noir.PeerStats.pid', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='bytesSent', full_name='noir.PeerStats.bytesSent', index=1,
+      number=2, type=4, cpp_type=4, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='bytesReceived', full_name='noir.PeerStats.bytesReceived', index=2,
+      number=3, type=4, cpp_type=4, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='packetsReceived', full_name='noir.PeerStats.packetsReceived', index=3,
+      number=4, type=4, cpp_type=4, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='packetsLost', full_name='noir.PeerStats.packetsLost', index=4,
+      number=5, type=4, cpp_type=4, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='publishedTracks', full_name='noir.PeerStats.publishedTracks', index=5,
+      number=6, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='subscribedTracks', full_name='noir.PeerStats.subscribedTracks', index=6,
+      number=7, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='layers', full_name='noir.PeerStats.layers', index=7,
+      number=8, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='targetBitrate', full_name='noir.PeerStats.targetBitrate', index=8,
+      number=9, type=4, cpp_type=4, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=1690,
+  serialized_end=1911,
+)
+
+
+_ROOMSTATSREPLY = _descriptor.Descriptor(
+  name='RoomStatsReply',
+  full_name='noir.RoomStatsReply',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='roomID', full_name='noir.RoomStatsReply.roomID', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='nodeID', full_name='noir.RoomStatsReply.nodeID', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='peers', full_name='noir.RoomStatsReply.peers', index=2,
+      number=3, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=1913,
+  serialized_end=1993,
+)
+
+
+_WORKERSTATEREQUEST = _descriptor.Descriptor(
+  name='WorkerStateRequest',
+  full_name='noir.WorkerStateRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=1995,
+  serialized_end=2015,
+)
+
+
+_WORKERSTATEREPLY_PEERQUEUESENTRY = _descriptor.Descriptor(
+  name='PeerQueuesEntry',
+  full_name='noir.WorkerStateReply.PeerQueuesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='noir.WorkerStateReply.PeerQueuesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='noir.WorkerStateReply.PeerQueuesEntry.value', index=1,
+      number=2, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=2210,
+  serialized_end=2259,
+)
+
+
+_WORKERSTATEREPLY = _descriptor.Descriptor(
+  name='WorkerStateReply',
+  full_name='noir.WorkerStateReply',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='nodeID', full_name='noir.WorkerStateReply.nodeID', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='asOf', full_name='noir.WorkerStateReply.asOf', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='peers', full_name='noir.WorkerStateReply.peers', index=2,
+      number=3, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='rooms', full_name='noir.WorkerStateReply.rooms', index=3,
+      number=4, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='peerGoroutines', full_name='noir.WorkerStateReply.peerGoroutines', index=4,
+      number=5, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='peerQueues', full_name='noir.WorkerStateReply.peerQueues', index=5,
+      number=6, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[_WORKERSTATEREPLY_PEERQUEUESENTRY, ],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=2018,
+  serialized_end=2259,
+)
+
+
+_ROOMADMINREQUEST = _descriptor.Descriptor(
+  name='RoomAdminRequest',
+  full_name='noir.RoomAdminRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='roomID', full_name='noir.RoomAdminRequest.roomID', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='createRoom', full_name='noir.RoomAdminRequest.createRoom', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='roomJob', full_name='noir.RoomAdminRequest.roomJob', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='closeRoom', full_name='noir.RoomAdminRequest.closeRoom', index=3,
+      number=4, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='startRecording', full_name='noir.RoomAdminRequest.startRecording', index=4,
+      number=5, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='stopRecording', full_name='noir.RoomAdminRequest.stopRecording', index=5,
+      number=6, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='mutePeer', full_name='noir.RoomAdminRequest.mutePeer', index=6,
+      number=7, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='kickPeer', full_name='noir.RoomAdminRequest.kickPeer', index=7,
+      number=8, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='traceSignaling', full_name='noir.RoomAdminRequest.traceSignaling', index=8,
+      number=10, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='setPassword', full_name='noir.RoomAdminRequest.setPassword', index=9,
+      number=11, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='announce', full_name='noir.RoomAdminRequest.announce', index=10,
+      number=12, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='pauseRoom', full_name='noir.RoomAdminRequest.pauseRoom', index=11,
+      number=13, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='transferOwnership', full_name='noir.RoomAdminRequest.transferOwnership', index=12,
+      number=14, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='callerID', full_name='noir.RoomAdminRequest.callerID', index=13,
+      number=9, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+    _descriptor.OneofDescriptor(
+      name='method', full_name='noir.RoomAdminRequest.method',
+      index=0, containing_type=None,
+      create_key=_descriptor._internal_create_key,
+    fields=[]),
+  ],
+  serialized_start=2262,
+  serialized_end=2901,
+)
+
+
+_ROOMADMINREPLY = _descriptor.Descriptor(
+  name='RoomAdminReply',
+  full_name='noir.RoomAdminReply',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='roomID', full_name='noir.RoomAdminReply.roomID', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='error', full_name='noir.RoomAdminReply.error', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='createRoom', full_name='noir.RoomAdminReply.createRoom', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='roomJob', full_name='noir.RoomAdminReply.roomJob', index=3,
+      number=4, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='closeRoom', full_name='noir.RoomAdminReply.closeRoom', index=4,
+      number=5, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='startRecording', full_name='noir.RoomAdminReply.startRecording', index=5,
+      number=6, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='stopRecording', full_name='noir.RoomAdminReply.stopRecording', index=6,
+      number=7, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='announce', full_name='noir.RoomAdminReply.announce', index=7,
+      number=8, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+    _descriptor.OneofDescriptor(
+      name='payload', full_name='noir.RoomAdminReply.payload',
+      index=0, containing_type=None,
+      create_key=_descriptor._internal_create_key,
+    fields=[]),
+  ],
+  serialized_start=2904,
+  serialized_end=3236,
+)
+
+
+_ANNOUNCEREPLY = _descriptor.Descriptor(
+  name='AnnounceReply',
+  full_name='noir.AnnounceReply',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='delivered', full_name='noir.AnnounceReply.delivered', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3238,
+  serialized_end=3272,
+)
+
+
+_CREATEROOMREQUEST_METADATAENTRY = _descriptor.Descriptor(
+  name='MetadataEntry',
+  full_name='noir.CreateRoomRequest.MetadataEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='noir.CreateRoomRequest.MetadataEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='noir.CreateRoomRequest.MetadataEntry.value', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3422,
+  serialized_end=3469,
+)
+
+
+_CREATEROOMREQUEST = _descriptor.Descriptor(
+  name='CreateRoomRequest',
+  full_name='noir.CreateRoomRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='options', full_name='noir.CreateRoomRequest.options', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='owner', full_name='noir.CreateRoomRequest.owner', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='metadata', full_name='noir.CreateRoomRequest.metadata', index=2,
+      number=3, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='template', full_name='noir.CreateRoomRequest.template', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[_CREATEROOMREQUEST_METADATAENTRY, ],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3275,
+  serialized_end=3469,
+)
+
+
+_CREATEROOMREPLY = _descriptor.Descriptor(
+  name='CreateRoomReply',
+  full_name='noir.CreateRoomReply',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='options', full_name='noir.CreateRoomReply.options', index=0,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='created', full_name='noir.CreateRoomReply.created', index=1,
+      number=3, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3471,
+  serialized_end=3541,
+)
+
+
+_STARTRECORDINGREQUEST = _descriptor.Descriptor(
+  name='StartRecordingRequest',
+  full_name='noir.StartRecordingRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='outputDir', full_name='noir.StartRecordingRequest.outputDir', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3543,
+  serialized_end=3585,
+)
+
+
+_STARTRECORDINGREPLY = _descriptor.Descriptor(
+  name='StartRecordingReply',
+  full_name='noir.StartRecordingReply',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='recordingID', full_name='noir.StartRecordingReply.recordingID', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3587,
+  serialized_end=3629,
+)
+
+
+_STOPRECORDINGREQUEST = _descriptor.Descriptor(
+  name='StopRecordingRequest',
+  full_name='noir.StopRecordingRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='recordingID', full_name='noir.StopRecordingRequest.recordingID', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3631,
+  serialized_end=3674,
+)
+
+
+_STOPRECORDINGREPLY = _descriptor.Descriptor(
+  name='StopRecordingReply',
+  full_name='noir.StopRecordingReply',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='stopped', full_name='noir.StopRecordingReply.stopped', index=0,
+      number=1, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3676,
+  serialized_end=3713,
+)
+
+
+_MUTEPEERREQUEST = _descriptor.Descriptor(
+  name='MutePeerRequest',
+  full_name='noir.MutePeerRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='pid', full_name='noir.MutePeerRequest.pid', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='kind', full_name='noir.MutePeerRequest.kind', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='muted', full_name='noir.MutePeerRequest.muted', index=2,
+      number=3, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3715,
+  serialized_end=3774,
+)
+
+
+_TRACESIGNALINGREQUEST = _descriptor.Descriptor(
+  name='TraceSignalingRequest',
+  full_name='noir.TraceSignalingRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='enabled', full_name='noir.TraceSignalingRequest.enabled', index=0,
+      number=1, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='redactSDP', full_name='noir.TraceSignalingRequest.redactSDP', index=1,
+      number=2, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3776,
+  serialized_end=3835,
+)
+
+
+_SETPASSWORDREQUEST = _descriptor.Descriptor(
+  name='SetPasswordRequest',
+  full_name='noir.SetPasswordRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='password', full_name='noir.SetPasswordRequest.password', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3837,
+  serialized_end=3875,
+)
+
+
+_PAUSEROOMREQUEST = _descriptor.Descriptor(
+  name='PauseRoomRequest',
+  full_name='noir.PauseRoomRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='paused', full_name='noir.PauseRoomRequest.paused', index=0,
+      number=1, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3877,
+  serialized_end=3911,
+)
+
+
+_TRANSFEROWNERSHIPREQUEST = _descriptor.Descriptor(
+  name='TransferOwnershipRequest',
+  full_name='noir.TransferOwnershipRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='pid', full_name='noir.TransferOwnershipRequest.pid', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3913,
+  serialized_end=3952,
+)
+
+
+_KICKPEERREQUEST = _descriptor.Descriptor(
+  name='KickPeerRequest',
+  full_name='noir.KickPeerRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='pid', full_name='noir.KickPeerRequest.pid', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3954,
+  serialized_end=3984,
+)
+
+
+_CLOSEROOMREQUEST = _descriptor.Descriptor(
+  name='CloseRoomRequest',
+  full_name='noir.CloseRoomRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=3986,
+  serialized_end=4004,
+)
+
+
+_CLOSEROOMREPLY = _descriptor.Descriptor(
+  name='CloseRoomReply',
+  full_name='noir.CloseRoomReply',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='evicted', full_name='noir.CloseRoomReply.evicted', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4006,
+  serialized_end=4039,
+)
+
+
+_ROOMJOBREQUEST = _descriptor.Descriptor(
+  name='RoomJobRequest',
+  full_name='noir.RoomJobRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='handler', full_name='noir.RoomJobRequest.handler', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='pid', full_name='noir.RoomJobRequest.pid', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='options', full_name='noir.RoomJobRequest.options', index=2,
+      number=3, type=12, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"",
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4041,
+  serialized_end=4104,
+)
+
+
+_ROOMJOBREPLY = _descriptor.Descriptor(
+  name='RoomJobReply',
+  full_name='noir.RoomJobReply',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='handler', full_name='noir.RoomJobReply.handler', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='pid', full_name='noir.RoomJobReply.pid', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='status', full_name='noir.RoomJobReply.status', index=2,
+      number=3, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='options', full_name='noir.RoomJobReply.options', index=3,
+      number=4, type=12, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"",
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4106,
+  serialized_end=4183,
+)
+
+
+_SIGNALREQUEST_TRACKLABELSENTRY = _descriptor.Descriptor(
+  name='TrackLabelsEntry',
+  full_name='noir.SignalRequest.TrackLabelsEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='noir.SignalRequest.TrackLabelsEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='noir.SignalRequest.TrackLabelsEntry.value', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4961,
+  serialized_end=5011,
+)
+
+
+_SIGNALREQUEST = _descriptor.Descriptor(
+  name='SignalRequest',
+  full_name='noir.SignalRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='id', full_name='noir.SignalRequest.id', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='join', full_name='noir.SignalRequest.join', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='description', full_name='noir.SignalRequest.description', index=2,
+      number=3, type=12, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"",
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='trickle', full_name='noir.SignalRequest.trickle', index=3,
+      number=4, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='kill', full_name='noir.SignalRequest.kill', index=4,
+      number=5, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='leave', full_name='noir.SignalRequest.leave', index=5,
+      number=7, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='data', full_name='noir.SignalRequest.data', index=6,
+      number=8, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='resume', full_name='noir.SignalRequest.resume', index=7,
+      number=9, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='setLayer', full_name='noir.SignalRequest.setLayer', index=8,
+      number=10, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='play', full_name='noir.SignalRequest.play', index=9,
+      number=11, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='mute', full_name='noir.SignalRequest.mute', index=10,
+      number=12, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='trickleBatch', full_name='noir.SignalRequest.trickleBatch', index=11,
+      number=13, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='subscribe', full_name='noir.SignalRequest.subscribe', index=12,
+      number=14, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='unsubscribe', full_name='noir.SignalRequest.unsubscribe', index=13,
+      number=15, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='getStats', full_name='noir.SignalRequest.getStats', index=14,
+      number=17, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='pause', full_name='noir.SignalRequest.pause', index=15,
+      number=18, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='move', full_name='noir.SignalRequest.move', index=16,
+      number=20, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='pong', full_name='noir.SignalRequest.pong', index=17,
+      number=21, type=4, cpp_type=4, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='capabilities', full_name='noir.SignalRequest.capabilities', index=18,
+      number=22, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='requestId', full_name='noir.SignalRequest.requestId', index=19,
+      number=6, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='closeReason', full_name='noir.SignalRequest.closeReason', index=20,
+      number=16, type=14, cpp_type=8, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='trackLabels', full_name='noir.SignalRequest.trackLabels', index=21,
+      number=19, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='resumeToken', full_name='noir.SignalRequest.resumeToken', index=22,
+      number=23, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[_SIGNALREQUEST_TRACKLABELSENTRY, ],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+    _descriptor.OneofDescriptor(
+      name='payload', full_name='noir.SignalRequest.payload',
+      index=0, containing_type=None,
+      create_key=_descriptor._internal_create_key,
+    fields=[]),
+  ],
+  serialized_start=4186,
+  serialized_end=5022,
+)
+
+
+_SIGNALREPLY = _descriptor.Descriptor(
+  name='SignalReply',
+  full_name='noir.SignalReply',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='id', full_name='noir.SignalReply.id', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='join', full_name='noir.SignalReply.join', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='description', full_name='noir.SignalReply.description', index=2,
+      number=3, type=12, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"",
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='trickle', full_name='noir.SignalReply.trickle', index=3,
+      number=4, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='iceConnectionState', full_name='noir.SignalReply.iceConnectionState', index=4,
+      number=5, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='error', full_name='noir.SignalReply.error', index=5,
+      number=6, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='kill', full_name='noir.SignalReply.kill', index=6,
+      number=7, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='leave', full_name='noir.SignalReply.leave', index=7,
+      number=9, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='failure', full_name='noir.SignalReply.failure', index=8,
+      number=10, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='data', full_name='noir.SignalReply.data', index=9,
+      number=11, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='resume', full_name='noir.SignalReply.resume', index=10,
+      number=12, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='setLayer', full_name='noir.SignalReply.setLayer', index=11,
+      number=13, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='play', full_name='noir.SignalReply.play', index=12,
+      number=14, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='reconnect', full_name='noir.SignalReply.reconnect', index=13,
+      number=15, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='trickleBatch', full_name='noir.SignalReply.trickleBatch', index=14,
+      number=16, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='subscriptions', full_name='noir.SignalReply.subscriptions', index=15,
+      number=17, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='activeSpeaker', full_name='noir.SignalReply.activeSpeaker', index=16,
+      number=18, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='announcement', full_name='noir.SignalReply.announcement', index=17,
+      number=19, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='quality', full_name='noir.SignalReply.quality', index=18,
+      number=20, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='stats', full_name='noir.SignalReply.stats', index=19,
+      number=22, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='paused', full_name='noir.SignalReply.paused', index=20,
+      number=23, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='tracks', full_name='noir.SignalReply.tracks', index=21,
+      number=24, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='moved', full_name='noir.SignalReply.moved', index=22,
+      number=25, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='layers', full_name='noir.SignalReply.layers', index=23,
+      number=26, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='ping', full_name='noir.SignalReply.ping', index=24,
+      number=27, type=4, cpp_type=4, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='capabilities', full_name='noir.SignalReply.capabilities', index=25,
+      number=28, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='requestId', full_name='noir.SignalReply.requestId', index=26,
+      number=8, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='closeReason', full_name='noir.SignalReply.closeReason', index=27,
+      number=21, type=14, cpp_type=8, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+    _descriptor.OneofDescriptor(
+      name='payload', full_name='noir.SignalReply.payload',
+      index=0, containing_type=None,
+      create_key=_descriptor._internal_create_key,
+    fields=[]),
+  ],
+  serialized_start=5025,
+  serialized_end=5945,
+)
+
+
+_CAPABILITIESREQUEST = _descriptor.Descriptor(
+  name='CapabilitiesRequest',
+  full_name='noir.CapabilitiesRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='sid', full_name='noir.CapabilitiesRequest.sid', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='token', full_name='noir.CapabilitiesRequest.token', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5947,
+  serialized_end=5996,
+)
+
+
+_CAPABILITIES = _descriptor.Descriptor(
+  name='Capabilities',
+  full_name='noir.Capabilities',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='version', full_name='noir.Capabilities.version', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='supportedVersions', full_name='noir.Capabilities.supportedVersions', index=1,
+      number=2, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='codecs', full_name='noir.Capabilities.codecs', index=2,
+      number=3, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='recording', full_name='noir.Capabilities.recording', index=3,
+      number=4, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='playback', full_name='noir.Capabilities.playback', index=4,
+      number=5, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='audioMix', full_name='noir.Capabilities.audioMix', index=5,
+      number=6, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='iceServers', full_name='noir.Capabilities.iceServers', index=6,
+      number=7, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='authRequired', full_name='noir.Capabilities.authRequired', index=7,
+      number=8, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='room', full_name='noir.Capabilities.room', index=8,
+      number=9, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5999,
+  serialized_end=6225,
+)
+
+
+_ROOMCAPABILITIES = _descriptor.Descriptor(
+  name='RoomCapabilities',
+  full_name='noir.RoomCapabilities',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='exists', full_name='noir.RoomCapabilities.exists', index=0,
+      number=1, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='autoCreate', full_name='noir.RoomCapabilities.autoCreate', index=1,
+      number=2, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='audioOnly', full_name='noir.RoomCapabilities.audioOnly', index=2,
+      number=3, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='maxPeers', full_name='noir.RoomCapabilities.maxPeers', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='passwordRequired', full_name='noir.RoomCapabilities.passwordRequired', index=4,
+      number=5, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='isChannel', full_name='noir.RoomCapabilities.isChannel', index=5,
+      number=6, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='paused', full_name='noir.RoomCapabilities.paused', index=6,
+      number=7, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6228,
+  serialized_end=6380,
+)
+
+
+_TRACKINFOS = _descriptor.Descriptor(
+  name='TrackInfos',
+  full_name='noir.TrackInfos',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='tracks', full_name='noir.TrackInfos.tracks', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6382,
+  serialized_end=6427,
+)
+
+
+_TRACKINFO = _descriptor.Descriptor(
+  name='TrackInfo',
+  full_name='noir.TrackInfo',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='mid', full_name='noir.TrackInfo.mid', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='trackID', full_name='noir.TrackInfo.trackID', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='streamID', full_name='noir.TrackInfo.streamID', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='pid', full_name='noir.TrackInfo.pid', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='kind', full_name='noir.TrackInfo.kind', index=4,
+      number=5, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='label', full_name='noir.TrackInfo.label', index=5,
+      number=6, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6429,
+  serialized_end=6530,
+)
+
+
+_TRACKLAYERS = _descriptor.Descriptor(
+  name='TrackLayers',
+  full_name='noir.TrackLayers',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='pid', full_name='noir.TrackLayers.pid', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='trackID', full_name='noir.TrackLayers.trackID', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='streamID', full_name='noir.TrackLayers.streamID', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='layers', full_name='noir.TrackLayers.layers', index=3,
+      number=4, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6532,
+  serialized_end=6627,
+)
+
+
+_TRACKLAYER = _descriptor.Descriptor(
+  name='TrackLayer',
+  full_name='noir.TrackLayer',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='spatial', full_name='noir.TrackLayer.spatial', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='rid', full_name='noir.TrackLayer.rid', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='active', full_name='noir.TrackLayer.active', index=2,
+      number=3, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6629,
+  serialized_end=6687,
+)
+
+
+_MOVEREQUEST = _descriptor.Descriptor(
+  name='MoveRequest',
+  full_name='noir.MoveRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='sid', full_name='noir.MoveRequest.sid', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6689,
+  serialized_end=6715,
+)
+
+
+_ROOMMOVED = _descriptor.Descriptor(
+  name='RoomMoved',
+  full_name='noir.RoomMoved',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='from', full_name='noir.RoomMoved.from', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='to', full_name='noir.RoomMoved.to', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6717,
+  serialized_end=6754,
+)
+
+
+_ROOMPAUSED = _descriptor.Descriptor(
+  name='RoomPaused',
+  full_name='noir.RoomPaused',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='sid', full_name='noir.RoomPaused.sid', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='paused', full_name='noir.RoomPaused.paused', index=1,
+      number=2, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='by', full_name='noir.RoomPaused.by', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6756,
+  serialized_end=6809,
+)
+
+
+_GETSTATSREQUEST = _descriptor.Descriptor(
+  name='GetStatsRequest',
+  full_name='noir.GetStatsRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6811,
+  serialized_end=6828,
+)
+
+
+_TRACKSTATS = _descriptor.Descriptor(
+  name='TrackStats',
+  full_name='noir.TrackStats',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='trackId', full_name='noir.TrackStats.trackId', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='streamId', full_name='noir.TrackStats.streamId', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='kind', full_name='noir.TrackStats.kind', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='inbound', full_name='noir.TrackStats.inbound', index=3,
+      number=4, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='packets', full_name='noir.TrackStats.packets', index=4,
+      number=5, type=4, cpp_type=4, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='packetsLost', full_name='noir.TrackStats.packetsLost', index=5,
+      number=6, type=4, cpp_type=4, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='jitterMs', full_name='noir.TrackStats.jitterMs', index=6,
+      number=7, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='bytes', full_name='noir.TrackStats.bytes', index=7,
+      number=8, type=4, cpp_type=4, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='layers', full_name='noir.TrackStats.layers', index=8,
+      number=9, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='spatialLayer', full_name='noir.TrackStats.spatialLayer', index=9,
+      number=10, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='targetBitrate', full_name='noir.TrackStats.targetBitrate', index=10,
+      number=11, type=4, cpp_type=4, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6831,
+  serialized_end=7041,
+)
+
+
+_PEERSTATSSNAPSHOT = _descriptor.Descriptor(
+  name='PeerStatsSnapshot',
+  full_name='noir.PeerStatsSnapshot',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='totals', full_name='noir.PeerStatsSnapshot.totals', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='windowMs', full_name='noir.PeerStatsSnapshot.windowMs', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='inboundBitrate', full_name='noir.PeerStatsSnapshot.inboundBitrate', index=2,
+      number=3, type=4, cpp_type=4, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='outboundBitrate', full_name='noir.PeerStatsSnapshot.outboundBitrate', index=3,
+      number=4, type=4, cpp_type=4, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='fractionLost', full_name='noir.PeerStatsSnapshot.fractionLost', index=4,
+      number=5, type=2, cpp_type=6, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='jitterMs', full_name='noir.PeerStatsSnapshot.jitterMs', index=5,
+      number=6, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='rttMs', full_name='noir.PeerStatsSnapshot.rttMs', index=6,
+      number=7, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='tracks', full_name='noir.PeerStatsSnapshot.tracks', index=7,
+      number=8, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=7044,
+  serialized_end=7252,
+)
+
+
+_CONNECTIONQUALITY = _descriptor.Descriptor(
+  name='ConnectionQuality',
+  full_name='noir.ConnectionQuality',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='level', full_name='noir.ConnectionQuality.level', index=0,
+      number=1, type=14, cpp_type=8, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='fractionLost', full_name='noir.ConnectionQuality.fractionLost', index=1,
+      number=2, type=2, cpp_type=6, label=1,
+      has_default_value=False, default_value=float(0),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='rttMs', full_name='noir.ConnectionQuality.rttMs', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='jitterMs', full_name='noir.ConnectionQuality.jitterMs', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+    _CONNECTIONQUALITY_LEVEL,
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=7255,
+  serialized_end=7414,
+)
+
+
+_ANNOUNCEMENT = _descriptor.Descriptor(
+  name='Announcement',
+  full_name='noir.Announcement',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='text', full_name='noir.Announcement.text', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='severity', full_name='noir.Announcement.severity', index=1,
+      number=2, type=14, cpp_type=8, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='from', full_name='noir.Announcement.from', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+    _ANNOUNCEMENT_SEVERITY,
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=7417,
+  serialized_end=7555,
+)
+
+
+_ACTIVESPEAKER = _descriptor.Descriptor(
+  name='ActiveSpeaker',
+  full_name='noir.ActiveSpeaker',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='pid', full_name='noir.ActiveSpeaker.pid', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='audioLevel', full_name='noir.ActiveSpeaker.audioLevel', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=7557,
+  serialized_end=7605,
+)
+
+
+_SUBSCRIBEREQUEST = _descriptor.Descriptor(
+  name='SubscribeRequest',
+  full_name='noir.SubscribeRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='pids', full_name='noir.SubscribeRequest.pids', index=0,
+      number=1, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
   ],
-  containing_type=None,
   serialized_options=None,
-  serialized_start=3073,
-  serialized_end=3134,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=7607,
+  serialized_end=7639,
 )
-_sym_db.RegisterEnumDescriptor(_JOBDATA_JOBSTATUS)
 
 
-_ADMINCLIENT = _descriptor.Descriptor(
-  name='AdminClient',
-  full_name='noir.AdminClient',
+_SUBSCRIPTIONS = _descriptor.Descriptor(
+  name='Subscriptions',
+  full_name='noir.Subscriptions',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   create_key=_descriptor._internal_create_key,
   fields=[
     _descriptor.FieldDescriptor(
-      name='clientID', full_name='noir.AdminClient.clientID', index=0,
-      number=1, type=9, cpp_type=9, label=1,
+      name='pids', full_name='noir.Subscriptions.pids', index=0,
+      number=1, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='left', full_name='noir.Subscriptions.left', index=1,
+      number=2, type=9, cpp_type=9, label=1,
       has_default_value=False, default_value=b"".decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='capped', full_name='noir.Subscriptions.capped', index=2,
+      number=3, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
   ],
   extensions=[
   ],
@@ -114,19 +3786,117 @@ _ADMINCLIENT = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=63,
-  serialized_end=94,
+  serialized_start=7641,
+  serialized_end=7700,
 )
 
 
-_EMPTY = _descriptor.Descriptor(
-  name='Empty',
-  full_name='noir.Empty',
+_JOINREQUEST = _descriptor.Descriptor(
+  name='JoinRequest',
+  full_name='noir.JoinRequest',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   create_key=_descriptor._internal_create_key,
   fields=[
+    _descriptor.FieldDescriptor(
+      name='sid', full_name='noir.JoinRequest.sid', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='description', full_name='noir.JoinRequest.description', index=1,
+      number=2, type=12, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"",
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='limits', full_name='noir.JoinRequest.limits', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='token', full_name='noir.JoinRequest.token', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='role', full_name='noir.JoinRequest.role', index=4,
+      number=5, type=14, cpp_type=8, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='rateKey', full_name='noir.JoinRequest.rateKey', index=5,
+      number=6, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='password', full_name='noir.JoinRequest.password', index=6,
+      number=7, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='qualityReports', full_name='noir.JoinRequest.qualityReports', index=7,
+      number=8, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='compression', full_name='noir.JoinRequest.compression', index=8,
+      number=9, type=14, cpp_type=8, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='keepalive', full_name='noir.JoinRequest.keepalive', index=9,
+      number=10, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='subscribe', full_name='noir.JoinRequest.subscribe', index=10,
+      number=11, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='noAutoSubscribe', full_name='noir.JoinRequest.noAutoSubscribe', index=11,
+      number=12, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='adaptiveLayers', full_name='noir.JoinRequest.adaptiveLayers', index=12,
+      number=13, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='replyTo', full_name='noir.JoinRequest.replyTo', index=13,
+      number=14, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
   ],
   extensions=[
   ],
@@ -139,57 +3909,89 @@ _EMPTY = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=96,
-  serialized_end=103,
+  serialized_start=7703,
+  serialized_end=8031,
 )
 
 
-_NOIRREQUEST = _descriptor.Descriptor(
-  name='NoirRequest',
-  full_name='noir.NoirRequest',
+_BITRATELIMITS = _descriptor.Descriptor(
+  name='BitrateLimits',
+  full_name='noir.BitrateLimits',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   create_key=_descriptor._internal_create_key,
   fields=[
     _descriptor.FieldDescriptor(
-      name='id', full_name='noir.NoirRequest.id', index=0,
-      number=1, type=9, cpp_type=9, label=1,
-      has_default_value=False, default_value=b"".decode('utf-8'),
+      name='publishKbps', full_name='noir.BitrateLimits.publishKbps', index=0,
+      number=1, type=13, cpp_type=3, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='at', full_name='noir.NoirRequest.at', index=1,
-      number=2, type=9, cpp_type=9, label=1,
-      has_default_value=False, default_value=b"".decode('utf-8'),
+      name='subscribeKbps', full_name='noir.BitrateLimits.subscribeKbps', index=1,
+      number=2, type=13, cpp_type=3, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=8033,
+  serialized_end=8092,
+)
+
+
+_JOINREPLY = _descriptor.Descriptor(
+  name='JoinReply',
+  full_name='noir.JoinReply',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
     _descriptor.FieldDescriptor(
-      name='action', full_name='noir.NoirRequest.action', index=2,
-      number=3, type=9, cpp_type=9, label=1,
-      has_default_value=False, default_value=b"".decode('utf-8'),
+      name='description', full_name='noir.JoinReply.description', index=0,
+      number=1, type=12, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"",
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='signal', full_name='noir.NoirRequest.signal', index=3,
-      number=4, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
+      name='iceServers', full_name='noir.JoinReply.iceServers', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='admin', full_name='noir.NoirRequest.admin', index=4,
-      number=5, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
+      name='version', full_name='noir.JoinReply.version', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='adminID', full_name='noir.NoirRequest.adminID', index=5,
-      number=6, type=9, cpp_type=9, label=1,
+      name='pid', full_name='noir.JoinReply.pid', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='resumeToken', full_name='noir.JoinReply.resumeToken', index=4,
+      number=5, type=9, cpp_type=9, label=1,
       has_default_value=False, default_value=b"".decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
@@ -205,57 +4007,91 @@ _NOIRREQUEST = _descriptor.Descriptor(
   syntax='proto3',
   extension_ranges=[],
   oneofs=[
-    _descriptor.OneofDescriptor(
-      name='command', full_name='noir.NoirRequest.command',
-      index=0, containing_type=None,
-      create_key=_descriptor._internal_create_key,
-    fields=[]),
   ],
-  serialized_start=106,
-  serialized_end=263,
+  serialized_start=8094,
+  serialized_end=8214,
 )
 
 
-_NOIRREPLY = _descriptor.Descriptor(
-  name='NoirReply',
-  full_name='noir.NoirReply',
+_ICESERVER = _descriptor.Descriptor(
+  name='ICEServer',
+  full_name='noir.ICEServer',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   create_key=_descriptor._internal_create_key,
   fields=[
     _descriptor.FieldDescriptor(
-      name='id', full_name='noir.NoirReply.id', index=0,
-      number=1, type=9, cpp_type=9, label=1,
-      has_default_value=False, default_value=b"".decode('utf-8'),
+      name='urls', full_name='noir.ICEServer.urls', index=0,
+      number=1, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='at', full_name='noir.NoirReply.at', index=1,
+      name='username', full_name='noir.ICEServer.username', index=1,
       number=2, type=9, cpp_type=9, label=1,
       has_default_value=False, default_value=b"".decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='signal', full_name='noir.NoirReply.signal', index=2,
-      number=3, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
+      name='credential', full_name='noir.ICEServer.credential', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='admin', full_name='noir.NoirReply.admin', index=3,
+      name='expires', full_name='noir.ICEServer.expires', index=3,
       number=4, type=11, cpp_type=10, label=1,
       has_default_value=False, default_value=None,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=8216,
+  serialized_end=8324,
+)
+
+
+_ICEPOLICY = _descriptor.Descriptor(
+  name='ICEPolicy',
+  full_name='noir.ICEPolicy',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
     _descriptor.FieldDescriptor(
-      name='error', full_name='noir.NoirReply.error', index=4,
-      number=5, type=9, cpp_type=9, label=1,
-      has_default_value=False, default_value=b"".decode('utf-8'),
+      name='relayOnly', full_name='noir.ICEPolicy.relayOnly', index=0,
+      number=1, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='tcpOnly', full_name='noir.ICEPolicy.tcpOnly', index=1,
+      number=2, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='noHost', full_name='noir.ICEPolicy.noHost', index=2,
+      number=3, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
@@ -270,43 +4106,52 @@ _NOIRREPLY = _descriptor.Descriptor(
   syntax='proto3',
   extension_ranges=[],
   oneofs=[
-    _descriptor.OneofDescriptor(
-      name='command', full_name='noir.NoirReply.command',
-      index=0, containing_type=None,
-      create_key=_descriptor._internal_create_key,
-    fields=[]),
   ],
-  serialized_start=266,
-  serialized_end=401,
+  serialized_start=8326,
+  serialized_end=8389,
 )
 
 
-_ADMINREQUEST = _descriptor.Descriptor(
-  name='AdminRequest',
-  full_name='noir.AdminRequest',
+_RTCPPOLICY = _descriptor.Descriptor(
+  name='RTCPPolicy',
+  full_name='noir.RTCPPolicy',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   create_key=_descriptor._internal_create_key,
   fields=[
     _descriptor.FieldDescriptor(
-      name='roomAdmin', full_name='noir.AdminRequest.roomAdmin', index=0,
-      number=1, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
+      name='disableNack', full_name='noir.RTCPPolicy.disableNack', index=0,
+      number=1, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='maxRetransmits', full_name='noir.RTCPPolicy.maxRetransmits', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='minPliIntervalMs', full_name='noir.RTCPPolicy.minPliIntervalMs', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='roomCount', full_name='noir.AdminRequest.roomCount', index=1,
-      number=2, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
+      name='aggregation', full_name='noir.RTCPPolicy.aggregation', index=3,
+      number=4, type=14, cpp_type=8, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='roomList', full_name='noir.AdminRequest.roomList', index=2,
-      number=3, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
+      name='bufferMs', full_name='noir.RTCPPolicy.bufferMs', index=4,
+      number=5, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
@@ -315,56 +4160,52 @@ _ADMINREQUEST = _descriptor.Descriptor(
   ],
   nested_types=[],
   enum_types=[
+    _RTCPPOLICY_AGGREGATION,
   ],
   serialized_options=None,
   is_extendable=False,
   syntax='proto3',
   extension_ranges=[],
   oneofs=[
-    _descriptor.OneofDescriptor(
-      name='payload', full_name='noir.AdminRequest.payload',
-      index=0, containing_type=None,
-      create_key=_descriptor._internal_create_key,
-    fields=[]),
   ],
-  serialized_start=404,
-  serialized_end=562,
+  serialized_start=8392,
+  serialized_end=8597,
 )
 
 
-_ADMINREPLY = _descriptor.Descriptor(
-  name='AdminReply',
-  full_name='noir.AdminReply',
+_SDPLIMITS = _descriptor.Descriptor(
+  name='SDPLimits',
+  full_name='noir.SDPLimits',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   create_key=_descriptor._internal_create_key,
   fields=[
     _descriptor.FieldDescriptor(
-      name='error', full_name='noir.AdminReply.error', index=0,
-      number=1, type=9, cpp_type=9, label=1,
-      has_default_value=False, default_value=b"".decode('utf-8'),
+      name='maxBytes', full_name='noir.SDPLimits.maxBytes', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='roomAdmin', full_name='noir.AdminReply.roomAdmin', index=1,
-      number=2, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
+      name='maxMediaSections', full_name='noir.SDPLimits.maxMediaSections', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='roomCount', full_name='noir.AdminReply.roomCount', index=2,
-      number=3, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
+      name='maxCodecs', full_name='noir.SDPLimits.maxCodecs', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='roomList', full_name='noir.AdminReply.roomList', index=3,
-      number=4, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
+      name='maxCandidates', full_name='noir.SDPLimits.maxCandidates', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
@@ -379,30 +4220,47 @@ _ADMINREPLY = _descriptor.Descriptor(
   syntax='proto3',
   extension_ranges=[],
   oneofs=[
-    _descriptor.OneofDescriptor(
-      name='payload', full_name='noir.AdminReply.payload',
-      index=0, containing_type=None,
-      create_key=_descriptor._internal_create_key,
-    fields=[]),
   ],
-  serialized_start=565,
-  serialized_end=732,
+  serialized_start=8599,
+  serialized_end=8696,
 )
 
 
-_ROOMCOUNTREQUEST = _descriptor.Descriptor(
-  name='RoomCountRequest',
-  full_name='noir.RoomCountRequest',
+_SIGNALERROR = _descriptor.Descriptor(
+  name='SignalError',
+  full_name='noir.SignalError',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   create_key=_descriptor._internal_create_key,
   fields=[
+    _descriptor.FieldDescriptor(
+      name='code', full_name='noir.SignalError.code', index=0,
+      number=1, type=14, cpp_type=8, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='message', full_name='noir.SignalError.message', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='retryAfterMs', full_name='noir.SignalError.retryAfterMs', index=2,
+      number=3, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
   ],
   extensions=[
   ],
   nested_types=[],
   enum_types=[
+    _SIGNALERROR_CODE,
   ],
   serialized_options=None,
   is_extendable=False,
@@ -410,23 +4268,30 @@ _ROOMCOUNTREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=734,
-  serialized_end=752,
+  serialized_start=8699,
+  serialized_end=9290,
 )
 
 
-_ROOMCOUNTREPLY = _descriptor.Descriptor(
-  name='RoomCountReply',
-  full_name='noir.RoomCountReply',
+_MUTEREQUEST = _descriptor.Descriptor(
+  name='MuteRequest',
+  full_name='noir.MuteRequest',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   create_key=_descriptor._internal_create_key,
   fields=[
     _descriptor.FieldDescriptor(
-      name='result', full_name='noir.RoomCountReply.result', index=0,
-      number=1, type=3, cpp_type=2, label=1,
-      has_default_value=False, default_value=0,
+      name='kind', full_name='noir.MuteRequest.kind', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='muted', full_name='noir.MuteRequest.muted', index=1,
+      number=2, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
@@ -442,19 +4307,61 @@ _ROOMCOUNTREPLY = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=754,
-  serialized_end=786,
+  serialized_start=9292,
+  serialized_end=9334,
 )
 
 
-_ROOMLISTREQUEST = _descriptor.Descriptor(
-  name='RoomListRequest',
-  full_name='noir.RoomListRequest',
+_PLAYREQUEST = _descriptor.Descriptor(
+  name='PlayRequest',
+  full_name='noir.PlayRequest',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   create_key=_descriptor._internal_create_key,
   fields=[
+    _descriptor.FieldDescriptor(
+      name='sid', full_name='noir.PlayRequest.sid', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='filename', full_name='noir.PlayRequest.filename', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='repeat', full_name='noir.PlayRequest.repeat', index=2,
+      number=3, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='startMs', full_name='noir.PlayRequest.startMs', index=3,
+      number=4, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='endMs', full_name='noir.PlayRequest.endMs', index=4,
+      number=5, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='token', full_name='noir.PlayRequest.token', index=5,
+      number=6, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
   ],
   extensions=[
   ],
@@ -467,29 +4374,36 @@ _ROOMLISTREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=788,
-  serialized_end=805,
+  serialized_start=9336,
+  serialized_end=9443,
 )
 
 
-_ROOMLISTENTRY = _descriptor.Descriptor(
-  name='RoomListEntry',
-  full_name='noir.RoomListEntry',
+_SETLAYERREQUEST = _descriptor.Descriptor(
+  name='SetLayerRequest',
+  full_name='noir.SetLayerRequest',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   create_key=_descriptor._internal_create_key,
   fields=[
     _descriptor.FieldDescriptor(
-      name='id', full_name='noir.RoomListEntry.id', index=0,
+      name='streamId', full_name='noir.SetLayerRequest.streamId', index=0,
       number=1, type=9, cpp_type=9, label=1,
       has_default_value=False, default_value=b"".decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='score', full_name='noir.RoomListEntry.score', index=1,
-      number=2, type=3, cpp_type=2, label=1,
+      name='spatial', full_name='noir.SetLayerRequest.spatial', index=1,
+      number=2, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='temporal', full_name='noir.SetLayerRequest.temporal', index=2,
+      number=3, type=5, cpp_type=1, label=1,
       has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
@@ -506,30 +4420,51 @@ _ROOMLISTENTRY = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=807,
-  serialized_end=849,
+  serialized_start=9445,
+  serialized_end=9515,
 )
 
 
-_ROOMLISTREPLY = _descriptor.Descriptor(
-  name='RoomListReply',
-  full_name='noir.RoomListReply',
+_SETLAYERREPLY = _descriptor.Descriptor(
+  name='SetLayerReply',
+  full_name='noir.SetLayerReply',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   create_key=_descriptor._internal_create_key,
   fields=[
     _descriptor.FieldDescriptor(
-      name='count', full_name='noir.RoomListReply.count', index=0,
-      number=1, type=3, cpp_type=2, label=1,
+      name='streamId', full_name='noir.SetLayerReply.streamId', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='spatial', full_name='noir.SetLayerReply.spatial', index=1,
+      number=2, type=5, cpp_type=1, label=1,
       has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='result', full_name='noir.RoomListReply.result', index=1,
-      number=2, type=11, cpp_type=10, label=3,
-      has_default_value=False, default_value=[],
+      name='temporal', full_name='noir.SetLayerReply.temporal', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='adapted', full_name='noir.SetLayerReply.adapted', index=3,
+      number=4, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='estimateKbps', full_name='noir.SetLayerReply.estimateKbps', index=4,
+      number=5, type=13, cpp_type=3, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
@@ -545,37 +4480,44 @@ _ROOMLISTREPLY = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=851,
-  serialized_end=918,
+  serialized_start=9517,
+  serialized_end=9624,
 )
 
 
-_ROOMADMINREQUEST = _descriptor.Descriptor(
-  name='RoomAdminRequest',
-  full_name='noir.RoomAdminRequest',
+_DATAMESSAGE = _descriptor.Descriptor(
+  name='DataMessage',
+  full_name='noir.DataMessage',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   create_key=_descriptor._internal_create_key,
   fields=[
     _descriptor.FieldDescriptor(
-      name='roomID', full_name='noir.RoomAdminRequest.roomID', index=0,
+      name='label', full_name='noir.DataMessage.label', index=0,
       number=1, type=9, cpp_type=9, label=1,
       has_default_value=False, default_value=b"".decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='createRoom', full_name='noir.RoomAdminRequest.createRoom', index=1,
-      number=2, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
+      name='payload', full_name='noir.DataMessage.payload', index=1,
+      number=2, type=12, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"",
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='roomJob', full_name='noir.RoomAdminRequest.roomJob', index=2,
-      number=3, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
+      name='to', full_name='noir.DataMessage.to', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='from', full_name='noir.DataMessage.from', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
@@ -590,50 +4532,77 @@ _ROOMADMINREQUEST = _descriptor.Descriptor(
   syntax='proto3',
   extension_ranges=[],
   oneofs=[
-    _descriptor.OneofDescriptor(
-      name='method', full_name='noir.RoomAdminRequest.method',
-      index=0, containing_type=None,
-      create_key=_descriptor._internal_create_key,
-    fields=[]),
   ],
-  serialized_start=921,
-  serialized_end=1053,
+  serialized_start=9626,
+  serialized_end=9697,
 )
 
 
-_ROOMADMINREPLY = _descriptor.Descriptor(
-  name='RoomAdminReply',
-  full_name='noir.RoomAdminReply',
+_PEERLEAVE = _descriptor.Descriptor(
+  name='PeerLeave',
+  full_name='noir.PeerLeave',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   create_key=_descriptor._internal_create_key,
   fields=[
     _descriptor.FieldDescriptor(
-      name='roomID', full_name='noir.RoomAdminReply.roomID', index=0,
+      name='pid', full_name='noir.PeerLeave.pid', index=0,
       number=1, type=9, cpp_type=9, label=1,
       has_default_value=False, default_value=b"".decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='error', full_name='noir.RoomAdminReply.error', index=1,
+      name='sid', full_name='noir.PeerLeave.sid', index=1,
       number=2, type=9, cpp_type=9, label=1,
       has_default_value=False, default_value=b"".decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='createRoom', full_name='noir.RoomAdminReply.createRoom', index=2,
-      number=3, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
+      name='reason', full_name='noir.PeerLeave.reason', index=2,
+      number=3, type=14, cpp_type=8, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=9699,
+  serialized_end=9771,
+)
+
+
+_TRICKLE = _descriptor.Descriptor(
+  name='Trickle',
+  full_name='noir.Trickle',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='target', full_name='noir.Trickle.target', index=0,
+      number=1, type=14, cpp_type=8, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='roomJob', full_name='noir.RoomAdminReply.roomJob', index=3,
-      number=4, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
+      name='init', full_name='noir.Trickle.init', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
@@ -642,35 +4611,31 @@ _ROOMADMINREPLY = _descriptor.Descriptor(
   ],
   nested_types=[],
   enum_types=[
+    _TRICKLE_TARGET,
   ],
   serialized_options=None,
   is_extendable=False,
   syntax='proto3',
   extension_ranges=[],
   oneofs=[
-    _descriptor.OneofDescriptor(
-      name='payload', full_name='noir.RoomAdminReply.payload',
-      index=0, containing_type=None,
-      create_key=_descriptor._internal_create_key,
-    fields=[]),
   ],
-  serialized_start=1056,
-  serialized_end=1200,
+  serialized_start=9773,
+  serialized_end=9875,
 )
 
 
-_CREATEROOMREQUEST = _descriptor.Descriptor(
-  name='CreateRoomRequest',
-  full_name='noir.CreateRoomRequest',
+_TRICKLEBATCH = _descriptor.Descriptor(
+  name='TrickleBatch',
+  full_name='noir.TrickleBatch',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   create_key=_descriptor._internal_create_key,
   fields=[
     _descriptor.FieldDescriptor(
-      name='options', full_name='noir.CreateRoomRequest.options', index=0,
-      number=1, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
+      name='candidates', full_name='noir.TrickleBatch.candidates', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
@@ -686,22 +4651,43 @@ _CREATEROOMREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1202,
-  serialized_end=1257,
+  serialized_start=9877,
+  serialized_end=9926,
 )
 
 
-_CREATEROOMREPLY = _descriptor.Descriptor(
-  name='CreateRoomReply',
-  full_name='noir.CreateRoomReply',
+_NOIROBJECT = _descriptor.Descriptor(
+  name='NoirObject',
+  full_name='noir.NoirObject',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   create_key=_descriptor._internal_create_key,
   fields=[
     _descriptor.FieldDescriptor(
-      name='options', full_name='noir.CreateRoomReply.options', index=0,
-      number=2, type=11, cpp_type=10, label=1,
+      name='node', full_name='noir.NoirObject.node', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='room', full_name='noir.NoirObject.room', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='user', full_name='noir.NoirObject.user', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='heartbeat', full_name='noir.NoirObject.heartbeat', index=3,
+      number=4, type=11, cpp_type=10, label=1,
       has_default_value=False, default_value=None,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
@@ -717,37 +4703,49 @@ _CREATEROOMREPLY = _descriptor.Descriptor(
   syntax='proto3',
   extension_ranges=[],
   oneofs=[
+    _descriptor.OneofDescriptor(
+      name='data', full_name='noir.NoirObject.data',
+      index=0, containing_type=None,
+      create_key=_descriptor._internal_create_key,
+    fields=[]),
   ],
-  serialized_start=1259,
-  serialized_end=1312,
+  serialized_start=9929,
+  serialized_end=10089,
 )
 
 
-_ROOMJOBREQUEST = _descriptor.Descriptor(
-  name='RoomJobRequest',
-  full_name='noir.RoomJobRequest',
+_DEADLETTER = _descriptor.Descriptor(
+  name='DeadLetter',
+  full_name='noir.DeadLetter',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   create_key=_descriptor._internal_create_key,
   fields=[
     _descriptor.FieldDescriptor(
-      name='handler', full_name='noir.RoomJobRequest.handler', index=0,
+      name='topic', full_name='noir.DeadLetter.topic', index=0,
       number=1, type=9, cpp_type=9, label=1,
       has_default_value=False, default_value=b"".decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='pid', full_name='noir.RoomJobRequest.pid', index=1,
+      name='error', full_name='noir.DeadLetter.error', index=1,
       number=2, type=9, cpp_type=9, label=1,
       has_default_value=False, default_value=b"".decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='options', full_name='noir.RoomJobRequest.options', index=2,
-      number=3, type=12, cpp_type=9, label=1,
+      name='at', full_name='noir.DeadLetter.at', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='message', full_name='noir.DeadLetter.message', index=3,
+      number=4, type=12, cpp_type=9, label=1,
       has_default_value=False, default_value=b"",
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
@@ -764,44 +4762,58 @@ _ROOMJOBREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1314,
-  serialized_end=1377,
+  serialized_start=10091,
+  serialized_end=10190,
 )
 
 
-_ROOMJOBREPLY = _descriptor.Descriptor(
-  name='RoomJobReply',
-  full_name='noir.RoomJobReply',
+_SIGNALTRACE = _descriptor.Descriptor(
+  name='SignalTrace',
+  full_name='noir.SignalTrace',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   create_key=_descriptor._internal_create_key,
   fields=[
     _descriptor.FieldDescriptor(
-      name='handler', full_name='noir.RoomJobReply.handler', index=0,
-      number=1, type=9, cpp_type=9, label=1,
-      has_default_value=False, default_value=b"".decode('utf-8'),
+      name='at', full_name='noir.SignalTrace.at', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='pid', full_name='noir.RoomJobReply.pid', index=1,
+      name='topic', full_name='noir.SignalTrace.topic', index=1,
       number=2, type=9, cpp_type=9, label=1,
       has_default_value=False, default_value=b"".decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='status', full_name='noir.RoomJobReply.status', index=2,
-      number=3, type=8, cpp_type=7, label=1,
-      has_default_value=False, default_value=False,
+      name='pid', full_name='noir.SignalTrace.pid', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='options', full_name='noir.RoomJobReply.options', index=3,
-      number=4, type=12, cpp_type=9, label=1,
-      has_default_value=False, default_value=b"",
+      name='action', full_name='noir.SignalTrace.action', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='request', full_name='noir.SignalTrace.request', index=4,
+      number=5, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='reply', full_name='noir.SignalTrace.reply', index=5,
+      number=6, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
@@ -816,62 +4828,60 @@ _ROOMJOBREPLY = _descriptor.Descriptor(
   syntax='proto3',
   extension_ranges=[],
   oneofs=[
+    _descriptor.OneofDescriptor(
+      name='message', full_name='noir.SignalTrace.message',
+      index=0, containing_type=None,
+      create_key=_descriptor._internal_create_key,
+    fields=[]),
   ],
-  serialized_start=1379,
-  serialized_end=1456,
+  serialized_start=10193,
+  serialized_end=10373,
 )
 
 
-_SIGNALREQUEST = _descriptor.Descriptor(
-  name='SignalRequest',
-  full_name='noir.SignalRequest',
+_WORKERHEARTBEAT = _descriptor.Descriptor(
+  name='WorkerHeartbeat',
+  full_name='noir.WorkerHeartbeat',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   create_key=_descriptor._internal_create_key,
   fields=[
     _descriptor.FieldDescriptor(
-      name='id', full_name='noir.SignalRequest.id', index=0,
+      name='id', full_name='noir.WorkerHeartbeat.id', index=0,
       number=1, type=9, cpp_type=9, label=1,
       has_default_value=False, default_value=b"".decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='join', full_name='noir.SignalRequest.join', index=1,
+      name='lastSeen', full_name='noir.WorkerHeartbeat.lastSeen', index=1,
       number=2, type=11, cpp_type=10, label=1,
       has_default_value=False, default_value=None,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='description', full_name='noir.SignalRequest.description', index=2,
-      number=3, type=12, cpp_type=9, label=1,
-      has_default_value=False, default_value=b"",
+      name='peers', full_name='noir.WorkerHeartbeat.peers', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='trickle', full_name='noir.SignalRequest.trickle', index=3,
-      number=4, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
+      name='maxPeers', full_name='noir.WorkerHeartbeat.maxPeers', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='kill', full_name='noir.SignalRequest.kill', index=4,
+      name='draining', full_name='noir.WorkerHeartbeat.draining', index=4,
       number=5, type=8, cpp_type=7, label=1,
       has_default_value=False, default_value=False,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
-    _descriptor.FieldDescriptor(
-      name='requestId', full_name='noir.SignalRequest.requestId', index=5,
-      number=6, type=9, cpp_type=9, label=1,
-      has_default_value=False, default_value=b"".decode('utf-8'),
-      message_type=None, enum_type=None, containing_type=None,
-      is_extension=False, extension_scope=None,
-      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
   ],
   extensions=[
   ],
@@ -883,77 +4893,76 @@ _SIGNALREQUEST = _descriptor.Descriptor(
   syntax='proto3',
   extension_ranges=[],
   oneofs=[
-    _descriptor.OneofDescriptor(
-      name='payload', full_name='noir.SignalRequest.payload',
-      index=0, containing_type=None,
-      create_key=_descriptor._internal_create_key,
-    fields=[]),
   ],
-  serialized_start=1459,
-  serialized_end=1624,
+  serialized_start=10375,
+  serialized_end=10501,
 )
 
 
-_SIGNALREPLY = _descriptor.Descriptor(
-  name='SignalReply',
-  full_name='noir.SignalReply',
+_NODEDATA = _descriptor.Descriptor(
+  name='NodeData',
+  full_name='noir.NodeData',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   create_key=_descriptor._internal_create_key,
   fields=[
     _descriptor.FieldDescriptor(
-      name='id', full_name='noir.SignalReply.id', index=0,
+      name='id', full_name='noir.NodeData.id', index=0,
       number=1, type=9, cpp_type=9, label=1,
       has_default_value=False, default_value=b"".decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='join', full_name='noir.SignalReply.join', index=1,
+      name='lastUpdate', full_name='noir.NodeData.lastUpdate', index=1,
       number=2, type=11, cpp_type=10, label=1,
       has_default_value=False, default_value=None,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='description', full_name='noir.SignalReply.description', index=2,
-      number=3, type=12, cpp_type=9, label=1,
-      has_default_value=False, default_value=b"",
-      message_type=None, enum_type=None, containing_type=None,
-      is_extension=False, extension_scope=None,
-      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
-    _descriptor.FieldDescriptor(
-      name='trickle', full_name='noir.SignalReply.trickle', index=3,
-      number=4, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
-      message_type=None, enum_type=None, containing_type=None,
-      is_extension=False, extension_scope=None,
-      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
-    _descriptor.FieldDescriptor(
-      name='iceConnectionState', full_name='noir.SignalReply.iceConnectionState', index=4,
-      number=5, type=9, cpp_type=9, label=1,
-      has_default_value=False, default_value=b"".decode('utf-8'),
+      name='services', full_name='noir.NodeData.services', index=2,
+      number=3, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  serialized_options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=10503,
+  serialized_end=10591,
+)
+
+
+_ROOMDATA_METADATAENTRY = _descriptor.Descriptor(
+  name='MetadataEntry',
+  full_name='noir.RoomData.MetadataEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  create_key=_descriptor._internal_create_key,
+  fields=[
     _descriptor.FieldDescriptor(
-      name='error', full_name='noir.SignalReply.error', index=5,
-      number=6, type=9, cpp_type=9, label=1,
+      name='key', full_name='noir.RoomData.MetadataEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
       has_default_value=False, default_value=b"".decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='kill', full_name='noir.SignalReply.kill', index=6,
-      number=7, type=8, cpp_type=7, label=1,
-      has_default_value=False, default_value=False,
-      message_type=None, enum_type=None, containing_type=None,
-      is_extension=False, extension_scope=None,
-      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
-    _descriptor.FieldDescriptor(
-      name='requestId', full_name='noir.SignalReply.requestId', index=7,
-      number=8, type=9, cpp_type=9, label=1,
+      name='value', full_name='noir.RoomData.MetadataEntry.value', index=1,
+      number=2, type=9, cpp_type=9, label=1,
       has_default_value=False, default_value=b"".decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
@@ -969,36 +4978,31 @@ _SIGNALREPLY = _descriptor.Descriptor(
   syntax='proto3',
   extension_ranges=[],
   oneofs=[
-    _descriptor.OneofDescriptor(
-      name='payload', full_name='noir.SignalReply.payload',
-      index=0, containing_type=None,
-      create_key=_descriptor._internal_create_key,
-    fields=[]),
   ],
-  serialized_start=1627,
-  serialized_end=1835,
+  serialized_start=3422,
+  serialized_end=3469,
 )
 
 
-_JOINREQUEST = _descriptor.Descriptor(
-  name='JoinRequest',
-  full_name='noir.JoinRequest',
+_ROOMDATA_RECORDINGSENTRY = _descriptor.Descriptor(
+  name='RecordingsEntry',
+  full_name='noir.RoomData.RecordingsEntry',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   create_key=_descriptor._internal_create_key,
   fields=[
     _descriptor.FieldDescriptor(
-      name='sid', full_name='noir.JoinRequest.sid', index=0,
+      name='key', full_name='noir.RoomData.RecordingsEntry.key', index=0,
       number=1, type=9, cpp_type=9, label=1,
       has_default_value=False, default_value=b"".decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='description', full_name='noir.JoinRequest.description', index=1,
-      number=2, type=12, cpp_type=9, label=1,
-      has_default_value=False, default_value=b"",
+      name='value', full_name='noir.RoomData.RecordingsEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
@@ -1014,30 +5018,93 @@ _JOINREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1837,
-  serialized_end=1884,
+  serialized_start=10962,
+  serialized_end=11028,
 )
 
 
-_JOINREPLY = _descriptor.Descriptor(
-  name='JoinReply',
-  full_name='noir.JoinReply',
+_ROOMDATA = _descriptor.Descriptor(
+  name='RoomData',
+  full_name='noir.RoomData',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   create_key=_descriptor._internal_create_key,
   fields=[
     _descriptor.FieldDescriptor(
-      name='description', full_name='noir.JoinReply.description', index=0,
-      number=1, type=12, cpp_type=9, label=1,
-      has_default_value=False, default_value=b"",
+      name='id', full_name='noir.RoomData.id', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='created', full_name='noir.RoomData.created', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='lastUpdate', full_name='noir.RoomData.lastUpdate', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='nodeID', full_name='noir.RoomData.nodeID', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='options', full_name='noir.RoomData.options', index=4,
+      number=5, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='publisher', full_name='noir.RoomData.publisher', index=5,
+      number=6, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='owner', full_name='noir.RoomData.owner', index=6,
+      number=7, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='metadata', full_name='noir.RoomData.metadata', index=7,
+      number=8, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='recordings', full_name='noir.RoomData.recordings', index=8,
+      number=9, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='paused', full_name='noir.RoomData.paused', index=9,
+      number=10, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
   ],
   extensions=[
   ],
-  nested_types=[],
+  nested_types=[_ROOMDATA_METADATAENTRY, _ROOMDATA_RECORDINGSENTRY, ],
   enum_types=[
   ],
   serialized_options=None,
@@ -1046,30 +5113,51 @@ _JOINREPLY = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1886,
-  serialized_end=1918,
+  serialized_start=10594,
+  serialized_end=11028,
 )
 
 
-_TRICKLE = _descriptor.Descriptor(
-  name='Trickle',
-  full_name='noir.Trickle',
+_RECORDING = _descriptor.Descriptor(
+  name='Recording',
+  full_name='noir.Recording',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   create_key=_descriptor._internal_create_key,
   fields=[
     _descriptor.FieldDescriptor(
-      name='target', full_name='noir.Trickle.target', index=0,
-      number=1, type=14, cpp_type=8, label=1,
-      has_default_value=False, default_value=0,
+      name='id', full_name='noir.Recording.id', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='outputDir', full_name='noir.Recording.outputDir', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='pid', full_name='noir.Recording.pid', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='started', full_name='noir.Recording.started', index=3,
+      number=4, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='init', full_name='noir.Trickle.init', index=1,
-      number=2, type=9, cpp_type=9, label=1,
-      has_default_value=False, default_value=b"".decode('utf-8'),
+      name='stopped', full_name='noir.Recording.stopped', index=4,
+      number=5, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
@@ -1078,7 +5166,6 @@ _TRICKLE = _descriptor.Descriptor(
   ],
   nested_types=[],
   enum_types=[
-    _TRICKLE_TARGET,
   ],
   serialized_options=None,
   is_extendable=False,
@@ -1086,40 +5173,159 @@ _TRICKLE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1920,
-  serialized_end=2022,
+  serialized_start=11031,
+  serialized_end=11176,
 )
 
 
-_NOIROBJECT = _descriptor.Descriptor(
-  name='NoirObject',
-  full_name='noir.NoirObject',
+_ROOMOPTIONS = _descriptor.Descriptor(
+  name='RoomOptions',
+  full_name='noir.RoomOptions',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   create_key=_descriptor._internal_create_key,
   fields=[
     _descriptor.FieldDescriptor(
-      name='node', full_name='noir.NoirObject.node', index=0,
-      number=1, type=11, cpp_type=10, label=1,
+      name='debug', full_name='noir.RoomOptions.debug', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='title', full_name='noir.RoomOptions.title', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='maxAgeSeconds', full_name='noir.RoomOptions.maxAgeSeconds', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='keyExpiryFactor', full_name='noir.RoomOptions.keyExpiryFactor', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='joinPassword', full_name='noir.RoomOptions.joinPassword', index=4,
+      number=5, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='publishPassword', full_name='noir.RoomOptions.publishPassword', index=5,
+      number=6, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='maxPeers', full_name='noir.RoomOptions.maxPeers', index=6,
+      number=7, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='isChannel', full_name='noir.RoomOptions.isChannel', index=7,
+      number=8, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='audioOnly', full_name='noir.RoomOptions.audioOnly', index=8,
+      number=9, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='icePolicy', full_name='noir.RoomOptions.icePolicy', index=9,
+      number=10, type=11, cpp_type=10, label=1,
       has_default_value=False, default_value=None,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='room', full_name='noir.NoirObject.room', index=1,
-      number=2, type=11, cpp_type=10, label=1,
+      name='activeSpeakerThreshold', full_name='noir.RoomOptions.activeSpeakerThreshold', index=10,
+      number=11, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='maxSubscribedTracks', full_name='noir.RoomOptions.maxSubscribedTracks', index=11,
+      number=12, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='joinPasswordHash', full_name='noir.RoomOptions.joinPasswordHash', index=12,
+      number=13, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='idleTimeoutSeconds', full_name='noir.RoomOptions.idleTimeoutSeconds', index=13,
+      number=14, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='codecs', full_name='noir.RoomOptions.codecs', index=14,
+      number=15, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='rtcpPolicy', full_name='noir.RoomOptions.rtcpPolicy', index=15,
+      number=16, type=11, cpp_type=10, label=1,
       has_default_value=False, default_value=None,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='user', full_name='noir.NoirObject.user', index=2,
-      number=3, type=11, cpp_type=10, label=1,
+      name='autoTransferOwnership', full_name='noir.RoomOptions.autoTransferOwnership', index=16,
+      number=17, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='sdpLimits', full_name='noir.RoomOptions.sdpLimits', index=17,
+      number=18, type=11, cpp_type=10, label=1,
       has_default_value=False, default_value=None,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='emptyRoom', full_name='noir.RoomOptions.emptyRoom', index=18,
+      number=19, type=14, cpp_type=8, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='emptyGraceSeconds', full_name='noir.RoomOptions.emptyGraceSeconds', index=19,
+      number=20, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
   ],
   extensions=[
   ],
@@ -1131,43 +5337,31 @@ _NOIROBJECT = _descriptor.Descriptor(
   syntax='proto3',
   extension_ranges=[],
   oneofs=[
-    _descriptor.OneofDescriptor(
-      name='data', full_name='noir.NoirObject.data',
-      index=0, containing_type=None,
-      create_key=_descriptor._internal_create_key,
-    fields=[]),
   ],
-  serialized_start=2024,
-  serialized_end=2140,
+  serialized_start=11179,
+  serialized_end=11714,
 )
 
 
-_NODEDATA = _descriptor.Descriptor(
-  name='NodeData',
-  full_name='noir.NodeData',
+_USERDATA_TRACKLABELSENTRY = _descriptor.Descriptor(
+  name='TrackLabelsEntry',
+  full_name='noir.UserData.TrackLabelsEntry',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   create_key=_descriptor._internal_create_key,
   fields=[
     _descriptor.FieldDescriptor(
-      name='id', full_name='noir.NodeData.id', index=0,
+      name='key', full_name='noir.UserData.TrackLabelsEntry.key', index=0,
       number=1, type=9, cpp_type=9, label=1,
       has_default_value=False, default_value=b"".decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='lastUpdate', full_name='noir.NodeData.lastUpdate', index=1,
-      number=2, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
-      message_type=None, enum_type=None, containing_type=None,
-      is_extension=False, extension_scope=None,
-      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
-    _descriptor.FieldDescriptor(
-      name='services', full_name='noir.NodeData.services', index=2,
-      number=3, type=9, cpp_type=9, label=3,
-      has_default_value=False, default_value=[],
+      name='value', full_name='noir.UserData.TrackLabelsEntry.value', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
@@ -1183,138 +5377,127 @@ _NODEDATA = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2142,
-  serialized_end=2230,
+  serialized_start=4961,
+  serialized_end=5011,
 )
 
 
-_ROOMDATA = _descriptor.Descriptor(
-  name='RoomData',
-  full_name='noir.RoomData',
+_USERDATA = _descriptor.Descriptor(
+  name='UserData',
+  full_name='noir.UserData',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   create_key=_descriptor._internal_create_key,
   fields=[
     _descriptor.FieldDescriptor(
-      name='id', full_name='noir.RoomData.id', index=0,
+      name='id', full_name='noir.UserData.id', index=0,
       number=1, type=9, cpp_type=9, label=1,
       has_default_value=False, default_value=b"".decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='created', full_name='noir.RoomData.created', index=1,
+      name='created', full_name='noir.UserData.created', index=1,
       number=2, type=11, cpp_type=10, label=1,
       has_default_value=False, default_value=None,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='lastUpdate', full_name='noir.RoomData.lastUpdate', index=2,
-      number=3, type=11, cpp_type=10, label=1,
+      name='lastUpdate', full_name='noir.UserData.lastUpdate', index=2,
+      number=4, type=11, cpp_type=10, label=1,
       has_default_value=False, default_value=None,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='nodeID', full_name='noir.RoomData.nodeID', index=3,
-      number=4, type=9, cpp_type=9, label=1,
+      name='roomID', full_name='noir.UserData.roomID', index=3,
+      number=5, type=9, cpp_type=9, label=1,
       has_default_value=False, default_value=b"".decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='options', full_name='noir.RoomData.options', index=4,
-      number=5, type=11, cpp_type=10, label=1,
+      name='options', full_name='noir.UserData.options', index=4,
+      number=6, type=11, cpp_type=10, label=1,
       has_default_value=False, default_value=None,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='publisher', full_name='noir.RoomData.publisher', index=5,
-      number=6, type=9, cpp_type=9, label=1,
-      has_default_value=False, default_value=b"".decode('utf-8'),
+      name='publishing', full_name='noir.UserData.publishing', index=5,
+      number=7, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
-  ],
-  extensions=[
-  ],
-  nested_types=[],
-  enum_types=[
-  ],
-  serialized_options=None,
-  is_extendable=False,
-  syntax='proto3',
-  extension_ranges=[],
-  oneofs=[
-  ],
-  serialized_start=2233,
-  serialized_end=2419,
-)
-
-
-_ROOMOPTIONS = _descriptor.Descriptor(
-  name='RoomOptions',
-  full_name='noir.RoomOptions',
-  filename=None,
-  file=DESCRIPTOR,
-  containing_type=None,
-  create_key=_descriptor._internal_create_key,
-  fields=[
     _descriptor.FieldDescriptor(
-      name='debug', full_name='noir.RoomOptions.debug', index=0,
-      number=1, type=5, cpp_type=1, label=1,
-      has_default_value=False, default_value=0,
+      name='limits', full_name='noir.UserData.limits', index=6,
+      number=8, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='title', full_name='noir.RoomOptions.title', index=1,
-      number=2, type=9, cpp_type=9, label=1,
+      name='identity', full_name='noir.UserData.identity', index=7,
+      number=9, type=9, cpp_type=9, label=1,
       has_default_value=False, default_value=b"".decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='maxAgeSeconds', full_name='noir.RoomOptions.maxAgeSeconds', index=2,
-      number=3, type=5, cpp_type=1, label=1,
-      has_default_value=False, default_value=0,
+      name='audioMuted', full_name='noir.UserData.audioMuted', index=8,
+      number=10, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='keyExpiryFactor', full_name='noir.RoomOptions.keyExpiryFactor', index=3,
-      number=4, type=5, cpp_type=1, label=1,
+      name='videoMuted', full_name='noir.UserData.videoMuted', index=9,
+      number=11, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='role', full_name='noir.UserData.role', index=10,
+      number=12, type=14, cpp_type=8, label=1,
       has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='joinPassword', full_name='noir.RoomOptions.joinPassword', index=4,
-      number=5, type=9, cpp_type=9, label=1,
-      has_default_value=False, default_value=b"".decode('utf-8'),
+      name='qualityReports', full_name='noir.UserData.qualityReports', index=11,
+      number=13, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='publishPassword', full_name='noir.RoomOptions.publishPassword', index=5,
-      number=6, type=9, cpp_type=9, label=1,
+      name='version', full_name='noir.UserData.version', index=12,
+      number=14, type=9, cpp_type=9, label=1,
       has_default_value=False, default_value=b"".decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='maxPeers', full_name='noir.RoomOptions.maxPeers', index=6,
-      number=7, type=5, cpp_type=1, label=1,
-      has_default_value=False, default_value=0,
+      name='trackLabels', full_name='noir.UserData.trackLabels', index=13,
+      number=15, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='isChannel', full_name='noir.RoomOptions.isChannel', index=7,
-      number=8, type=8, cpp_type=7, label=1,
+      name='keepalive', full_name='noir.UserData.keepalive', index=14,
+      number=16, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='adaptiveLayers', full_name='noir.UserData.adaptiveLayers', index=15,
+      number=17, type=8, cpp_type=7, label=1,
       has_default_value=False, default_value=False,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
@@ -1322,7 +5505,7 @@ _ROOMOPTIONS = _descriptor.Descriptor(
   ],
   extensions=[
   ],
-  nested_types=[],
+  nested_types=[_USERDATA_TRACKLABELSENTRY, ],
   enum_types=[
   ],
   serialized_options=None,
@@ -1331,58 +5514,93 @@ _ROOMOPTIONS = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2422,
-  serialized_end=2597,
+  serialized_start=11717,
+  serialized_end=12215,
 )
 
 
-_USERDATA = _descriptor.Descriptor(
-  name='UserData',
-  full_name='noir.UserData',
+_PEERSTATUS = _descriptor.Descriptor(
+  name='PeerStatus',
+  full_name='noir.PeerStatus',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   create_key=_descriptor._internal_create_key,
   fields=[
     _descriptor.FieldDescriptor(
-      name='id', full_name='noir.UserData.id', index=0,
-      number=1, type=9, cpp_type=9, label=1,
+      name='pid', full_name='noir.PeerStatus.pid', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='roomID', full_name='noir.PeerStatus.roomID', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='role', full_name='noir.PeerStatus.role', index=2,
+      number=3, type=14, cpp_type=8, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='iceState', full_name='noir.PeerStatus.iceState', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='publisherSignaling', full_name='noir.PeerStatus.publisherSignaling', index=4,
+      number=5, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
+    _descriptor.FieldDescriptor(
+      name='subscriberSignaling', full_name='noir.PeerStatus.subscriberSignaling', index=5,
+      number=6, type=9, cpp_type=9, label=1,
       has_default_value=False, default_value=b"".decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='created', full_name='noir.UserData.created', index=1,
-      number=2, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
+      name='publishedTracks', full_name='noir.PeerStatus.publishedTracks', index=6,
+      number=7, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='lastUpdate', full_name='noir.UserData.lastUpdate', index=2,
-      number=4, type=11, cpp_type=10, label=1,
-      has_default_value=False, default_value=None,
+      name='subscribedTracks', full_name='noir.PeerStatus.subscribedTracks', index=7,
+      number=8, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='roomID', full_name='noir.UserData.roomID', index=3,
-      number=5, type=9, cpp_type=9, label=1,
-      has_default_value=False, default_value=b"".decode('utf-8'),
+      name='joined', full_name='noir.PeerStatus.joined', index=8,
+      number=9, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='options', full_name='noir.UserData.options', index=4,
-      number=6, type=11, cpp_type=10, label=1,
+      name='updated', full_name='noir.PeerStatus.updated', index=9,
+      number=10, type=11, cpp_type=10, label=1,
       has_default_value=False, default_value=None,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
     _descriptor.FieldDescriptor(
-      name='publishing', full_name='noir.UserData.publishing', index=5,
-      number=7, type=8, cpp_type=7, label=1,
-      has_default_value=False, default_value=False,
+      name='worker', full_name='noir.PeerStatus.worker', index=10,
+      number=11, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=b"".decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       serialized_options=None, file=DESCRIPTOR,  create_key=_descriptor._internal_create_key),
@@ -1398,8 +5616,8 @@ _USERDATA = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2600,
-  serialized_end=2787,
+  serialized_start=12218,
+  serialized_end=12516,
 )
 
 
@@ -1451,8 +5669,8 @@ _USEROPTIONS = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2789,
-  serialized_end=2880,
+  serialized_start=12518,
+  serialized_end=12609,
 )
 
 
@@ -1519,8 +5737,8 @@ _JOBDATA = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2883,
-  serialized_end=3134,
+  serialized_start=12612,
+  serialized_end=12863,
 )
 
 
@@ -1572,8 +5790,8 @@ _PEERJOBDATA = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=3136,
-  serialized_end=3229,
+  serialized_start=12865,
+  serialized_end=12958,
 )
 
 _NOIRREQUEST.fields_by_name['signal'].message_type = _SIGNALREQUEST
@@ -1586,6 +5804,8 @@ _NOIRREQUEST.oneofs_by_name['command'].fields.append(
 _NOIRREQUEST.fields_by_name['admin'].containing_oneof = _NOIRREQUEST.oneofs_by_name['command']
 _NOIRREPLY.fields_by_name['signal'].message_type = _SIGNALREPLY
 _NOIRREPLY.fields_by_name['admin'].message_type = _ADMINREPLY
+_NOIRREPLY.fields_by_name['event'].message_type = _ROOMEVENT
+_NOIRREPLY.fields_by_name['compression'].enum_type = _COMPRESSION
 _NOIRREPLY.oneofs_by_name['command'].fields.append(
   _NOIRREPLY.fields_by_name['signal'])
 _NOIRREPLY.fields_by_name['signal'].containing_oneof = _NOIRREPLY.oneofs_by_name['command']
@@ -1595,9 +5815,19 @@ _NOIRREPLY.fields_by_name['admin'].containing_oneof = _NOIRREPLY.oneofs_by_name[
 _NOIRREPLY.oneofs_by_name['command'].fields.append(
   _NOIRREPLY.fields_by_name['error'])
 _NOIRREPLY.fields_by_name['error'].containing_oneof = _NOIRREPLY.oneofs_by_name['command']
+_NOIRREPLY.oneofs_by_name['command'].fields.append(
+  _NOIRREPLY.fields_by_name['event'])
+_NOIRREPLY.fields_by_name['event'].containing_oneof = _NOIRREPLY.oneofs_by_name['command']
+_ROOMEVENT.fields_by_name['type'].enum_type = _ROOMEVENT_TYPE
+_ROOMEVENT.fields_by_name['at'].message_type = google_dot_protobuf_dot_timestamp__pb2._TIMESTAMP
+_ROOMEVENT.fields_by_name['reason'].enum_type = _CLOSEREASON
+_ROOMEVENT.fields_by_name['layers'].message_type = _TRACKLAYERS
+_ROOMEVENT_TYPE.containing_type = _ROOMEVENT
 _ADMINREQUEST.fields_by_name['roomAdmin'].message_type = _ROOMADMINREQUEST
 _ADMINREQUEST.fields_by_name['roomCount'].message_type = _ROOMCOUNTREQUEST
 _ADMINREQUEST.fields_by_name['roomList'].message_type = _ROOMLISTREQUEST
+_ADMINREQUEST.fields_by_name['roomStats'].message_type = _ROOMSTATSREQUEST
+_ADMINREQUEST.fields_by_name['workerState'].message_type = _WORKERSTATEREQUEST
 _ADMINREQUEST.oneofs_by_name['payload'].fields.append(
   _ADMINREQUEST.fields_by_name['roomAdmin'])
 _ADMINREQUEST.fields_by_name['roomAdmin'].containing_oneof = _ADMINREQUEST.oneofs_by_name['payload']
@@ -1607,9 +5837,17 @@ _ADMINREQUEST.fields_by_name['roomCount'].containing_oneof = _ADMINREQUEST.oneof
 _ADMINREQUEST.oneofs_by_name['payload'].fields.append(
   _ADMINREQUEST.fields_by_name['roomList'])
 _ADMINREQUEST.fields_by_name['roomList'].containing_oneof = _ADMINREQUEST.oneofs_by_name['payload']
+_ADMINREQUEST.oneofs_by_name['payload'].fields.append(
+  _ADMINREQUEST.fields_by_name['roomStats'])
+_ADMINREQUEST.fields_by_name['roomStats'].containing_oneof = _ADMINREQUEST.oneofs_by_name['payload']
+_ADMINREQUEST.oneofs_by_name['payload'].fields.append(
+  _ADMINREQUEST.fields_by_name['workerState'])
+_ADMINREQUEST.fields_by_name['workerState'].containing_oneof = _ADMINREQUEST.oneofs_by_name['payload']
 _ADMINREPLY.fields_by_name['roomAdmin'].message_type = _ROOMADMINREPLY
 _ADMINREPLY.fields_by_name['roomCount'].message_type = _ROOMCOUNTREPLY
 _ADMINREPLY.fields_by_name['roomList'].message_type = _ROOMLISTREPLY
+_ADMINREPLY.fields_by_name['roomStats'].message_type = _ROOMSTATSREPLY
+_ADMINREPLY.fields_by_name['workerState'].message_type = _WORKERSTATEREPLY
 _ADMINREPLY.oneofs_by_name['payload'].fields.append(
   _ADMINREPLY.fields_by_name['error'])
 _ADMINREPLY.fields_by_name['error'].containing_oneof = _ADMINREPLY.oneofs_by_name['payload']
@@ -1622,17 +5860,72 @@ _ADMINREPLY.fields_by_name['roomCount'].containing_oneof = _ADMINREPLY.oneofs_by
 _ADMINREPLY.oneofs_by_name['payload'].fields.append(
   _ADMINREPLY.fields_by_name['roomList'])
 _ADMINREPLY.fields_by_name['roomList'].containing_oneof = _ADMINREPLY.oneofs_by_name['payload']
+_ADMINREPLY.oneofs_by_name['payload'].fields.append(
+  _ADMINREPLY.fields_by_name['roomStats'])
+_ADMINREPLY.fields_by_name['roomStats'].containing_oneof = _ADMINREPLY.oneofs_by_name['payload']
+_ADMINREPLY.oneofs_by_name['payload'].fields.append(
+  _ADMINREPLY.fields_by_name['workerState'])
+_ADMINREPLY.fields_by_name['workerState'].containing_oneof = _ADMINREPLY.oneofs_by_name['payload']
 _ROOMLISTREPLY.fields_by_name['result'].message_type = _ROOMLISTENTRY
+_PEERSTATS.fields_by_name['layers'].message_type = _TRACKLAYERS
+_ROOMSTATSREPLY.fields_by_name['peers'].message_type = _PEERSTATS
+_WORKERSTATEREPLY_PEERQUEUESENTRY.containing_type = _WORKERSTATEREPLY
+_WORKERSTATEREPLY.fields_by_name['asOf'].message_type = google_dot_protobuf_dot_timestamp__pb2._TIMESTAMP
+_WORKERSTATEREPLY.fields_by_name['peerQueues'].message_type = _WORKERSTATEREPLY_PEERQUEUESENTRY
 _ROOMADMINREQUEST.fields_by_name['createRoom'].message_type = _CREATEROOMREQUEST
 _ROOMADMINREQUEST.fields_by_name['roomJob'].message_type = _ROOMJOBREQUEST
+_ROOMADMINREQUEST.fields_by_name['closeRoom'].message_type = _CLOSEROOMREQUEST
+_ROOMADMINREQUEST.fields_by_name['startRecording'].message_type = _STARTRECORDINGREQUEST
+_ROOMADMINREQUEST.fields_by_name['stopRecording'].message_type = _STOPRECORDINGREQUEST
+_ROOMADMINREQUEST.fields_by_name['mutePeer'].message_type = _MUTEPEERREQUEST
+_ROOMADMINREQUEST.fields_by_name['kickPeer'].message_type = _KICKPEERREQUEST
+_ROOMADMINREQUEST.fields_by_name['traceSignaling'].message_type = _TRACESIGNALINGREQUEST
+_ROOMADMINREQUEST.fields_by_name['setPassword'].message_type = _SETPASSWORDREQUEST
+_ROOMADMINREQUEST.fields_by_name['announce'].message_type = _ANNOUNCEMENT
+_ROOMADMINREQUEST.fields_by_name['pauseRoom'].message_type = _PAUSEROOMREQUEST
+_ROOMADMINREQUEST.fields_by_name['transferOwnership'].message_type = _TRANSFEROWNERSHIPREQUEST
 _ROOMADMINREQUEST.oneofs_by_name['method'].fields.append(
   _ROOMADMINREQUEST.fields_by_name['createRoom'])
 _ROOMADMINREQUEST.fields_by_name['createRoom'].containing_oneof = _ROOMADMINREQUEST.oneofs_by_name['method']
 _ROOMADMINREQUEST.oneofs_by_name['method'].fields.append(
   _ROOMADMINREQUEST.fields_by_name['roomJob'])
 _ROOMADMINREQUEST.fields_by_name['roomJob'].containing_oneof = _ROOMADMINREQUEST.oneofs_by_name['method']
+_ROOMADMINREQUEST.oneofs_by_name['method'].fields.append(
+  _ROOMADMINREQUEST.fields_by_name['closeRoom'])
+_ROOMADMINREQUEST.fields_by_name['closeRoom'].containing_oneof = _ROOMADMINREQUEST.oneofs_by_name['method']
+_ROOMADMINREQUEST.oneofs_by_name['method'].fields.append(
+  _ROOMADMINREQUEST.fields_by_name['startRecording'])
+_ROOMADMINREQUEST.fields_by_name['startRecording'].containing_oneof = _ROOMADMINREQUEST.oneofs_by_name['method']
+_ROOMADMINREQUEST.oneofs_by_name['method'].fields.append(
+  _ROOMADMINREQUEST.fields_by_name['stopRecording'])
+_ROOMADMINREQUEST.fields_by_name['stopRecording'].containing_oneof = _ROOMADMINREQUEST.oneofs_by_name['method']
+_ROOMADMINREQUEST.oneofs_by_name['method'].fields.append(
+  _ROOMADMINREQUEST.fields_by_name['mutePeer'])
+_ROOMADMINREQUEST.fields_by_name['mutePeer'].containing_oneof = _ROOMADMINREQUEST.oneofs_by_name['method']
+_ROOMADMINREQUEST.oneofs_by_name['method'].fields.append(
+  _ROOMADMINREQUEST.fields_by_name['kickPeer'])
+_ROOMADMINREQUEST.fields_by_name['kickPeer'].containing_oneof = _ROOMADMINREQUEST.oneofs_by_name['method']
+_ROOMADMINREQUEST.oneofs_by_name['method'].fields.append(
+  _ROOMADMINREQUEST.fields_by_name['traceSignaling'])
+_ROOMADMINREQUEST.fields_by_name['traceSignaling'].containing_oneof = _ROOMADMINREQUEST.oneofs_by_name['method']
+_ROOMADMINREQUEST.oneofs_by_name['method'].fields.append(
+  _ROOMADMINREQUEST.fields_by_name['setPassword'])
+_ROOMADMINREQUEST.fields_by_name['setPassword'].containing_oneof = _ROOMADMINREQUEST.oneofs_by_name['method']
+_ROOMADMINREQUEST.oneofs_by_name['method'].fields.append(
+  _ROOMADMINREQUEST.fields_by_name['announce'])
+_ROOMADMINREQUEST.fields_by_name['announce'].containing_oneof = _ROOMADMINREQUEST.oneofs_by_name['method']
+_ROOMADMINREQUEST.oneofs_by_name['method'].fields.append(
+  _ROOMADMINREQUEST.fields_by_name['pauseRoom'])
+_ROOMADMINREQUEST.fields_by_name['pauseRoom'].containing_oneof = _ROOMADMINREQUEST.oneofs_by_name['method']
+_ROOMADMINREQUEST.oneofs_by_name['method'].fields.append(
+  _ROOMADMINREQUEST.fields_by_name['transferOwnership'])
+_ROOMADMINREQUEST.fields_by_name['transferOwnership'].containing_oneof = _ROOMADMINREQUEST.oneofs_by_name['method']
 _ROOMADMINREPLY.fields_by_name['createRoom'].message_type = _CREATEROOMREPLY
 _ROOMADMINREPLY.fields_by_name['roomJob'].message_type = _ROOMJOBREPLY
+_ROOMADMINREPLY.fields_by_name['closeRoom'].message_type = _CLOSEROOMREPLY
+_ROOMADMINREPLY.fields_by_name['startRecording'].message_type = _STARTRECORDINGREPLY
+_ROOMADMINREPLY.fields_by_name['stopRecording'].message_type = _STOPRECORDINGREPLY
+_ROOMADMINREPLY.fields_by_name['announce'].message_type = _ANNOUNCEREPLY
 _ROOMADMINREPLY.oneofs_by_name['payload'].fields.append(
   _ROOMADMINREPLY.fields_by_name['error'])
 _ROOMADMINREPLY.fields_by_name['error'].containing_oneof = _ROOMADMINREPLY.oneofs_by_name['payload']
@@ -1642,10 +5935,38 @@ _ROOMADMINREPLY.fields_by_name['createRoom'].containing_oneof = _ROOMADMINREPLY.
 _ROOMADMINREPLY.oneofs_by_name['payload'].fields.append(
   _ROOMADMINREPLY.fields_by_name['roomJob'])
 _ROOMADMINREPLY.fields_by_name['roomJob'].containing_oneof = _ROOMADMINREPLY.oneofs_by_name['payload']
+_ROOMADMINREPLY.oneofs_by_name['payload'].fields.append(
+  _ROOMADMINREPLY.fields_by_name['closeRoom'])
+_ROOMADMINREPLY.fields_by_name['closeRoom'].containing_oneof = _ROOMADMINREPLY.oneofs_by_name['payload']
+_ROOMADMINREPLY.oneofs_by_name['payload'].fields.append(
+  _ROOMADMINREPLY.fields_by_name['startRecording'])
+_ROOMADMINREPLY.fields_by_name['startRecording'].containing_oneof = _ROOMADMINREPLY.oneofs_by_name['payload']
+_ROOMADMINREPLY.oneofs_by_name['payload'].fields.append(
+  _ROOMADMINREPLY.fields_by_name['stopRecording'])
+_ROOMADMINREPLY.fields_by_name['stopRecording'].containing_oneof = _ROOMADMINREPLY.oneofs_by_name['payload']
+_ROOMADMINREPLY.oneofs_by_name['payload'].fields.append(
+  _ROOMADMINREPLY.fields_by_name['announce'])
+_ROOMADMINREPLY.fields_by_name['announce'].containing_oneof = _ROOMADMINREPLY.oneofs_by_name['payload']
+_CREATEROOMREQUEST_METADATAENTRY.containing_type = _CREATEROOMREQUEST
 _CREATEROOMREQUEST.fields_by_name['options'].message_type = _ROOMOPTIONS
+_CREATEROOMREQUEST.fields_by_name['metadata'].message_type = _CREATEROOMREQUEST_METADATAENTRY
 _CREATEROOMREPLY.fields_by_name['options'].message_type = _ROOMOPTIONS
+_SIGNALREQUEST_TRACKLABELSENTRY.containing_type = _SIGNALREQUEST
 _SIGNALREQUEST.fields_by_name['join'].message_type = _JOINREQUEST
 _SIGNALREQUEST.fields_by_name['trickle'].message_type = _TRICKLE
+_SIGNALREQUEST.fields_by_name['data'].message_type = _DATAMESSAGE
+_SIGNALREQUEST.fields_by_name['setLayer'].message_type = _SETLAYERREQUEST
+_SIGNALREQUEST.fields_by_name['play'].message_type = _PLAYREQUEST
+_SIGNALREQUEST.fields_by_name['mute'].message_type = _MUTEREQUEST
+_SIGNALREQUEST.fields_by_name['trickleBatch'].message_type = _TRICKLEBATCH
+_SIGNALREQUEST.fields_by_name['subscribe'].message_type = _SUBSCRIBEREQUEST
+_SIGNALREQUEST.fields_by_name['unsubscribe'].message_type = _SUBSCRIBEREQUEST
+_SIGNALREQUEST.fields_by_name['getStats'].message_type = _GETSTATSREQUEST
+_SIGNALREQUEST.fields_by_name['pause'].message_type = _ROOMPAUSED
+_SIGNALREQUEST.fields_by_name['move'].message_type = _MOVEREQUEST
+_SIGNALREQUEST.fields_by_name['capabilities'].message_type = _CAPABILITIESREQUEST
+_SIGNALREQUEST.fields_by_name['closeReason'].enum_type = _CLOSEREASON
+_SIGNALREQUEST.fields_by_name['trackLabels'].message_type = _SIGNALREQUEST_TRACKLABELSENTRY
 _SIGNALREQUEST.oneofs_by_name['payload'].fields.append(
   _SIGNALREQUEST.fields_by_name['join'])
 _SIGNALREQUEST.fields_by_name['join'].containing_oneof = _SIGNALREQUEST.oneofs_by_name['payload']
@@ -1658,8 +5979,66 @@ _SIGNALREQUEST.fields_by_name['trickle'].containing_oneof = _SIGNALREQUEST.oneof
 _SIGNALREQUEST.oneofs_by_name['payload'].fields.append(
   _SIGNALREQUEST.fields_by_name['kill'])
 _SIGNALREQUEST.fields_by_name['kill'].containing_oneof = _SIGNALREQUEST.oneofs_by_name['payload']
+_SIGNALREQUEST.oneofs_by_name['payload'].fields.append(
+  _SIGNALREQUEST.fields_by_name['leave'])
+_SIGNALREQUEST.fields_by_name['leave'].containing_oneof = _SIGNALREQUEST.oneofs_by_name['payload']
+_SIGNALREQUEST.oneofs_by_name['payload'].fields.append(
+  _SIGNALREQUEST.fields_by_name['data'])
+_SIGNALREQUEST.fields_by_name['data'].containing_oneof = _SIGNALREQUEST.oneofs_by_name['payload']
+_SIGNALREQUEST.oneofs_by_name['payload'].fields.append(
+  _SIGNALREQUEST.fields_by_name['resume'])
+_SIGNALREQUEST.fields_by_name['resume'].containing_oneof = _SIGNALREQUEST.oneofs_by_name['payload']
+_SIGNALREQUEST.oneofs_by_name['payload'].fields.append(
+  _SIGNALREQUEST.fields_by_name['setLayer'])
+_SIGNALREQUEST.fields_by_name['setLayer'].containing_oneof = _SIGNALREQUEST.oneofs_by_name['payload']
+_SIGNALREQUEST.oneofs_by_name['payload'].fields.append(
+  _SIGNALREQUEST.fields_by_name['play'])
+_SIGNALREQUEST.fields_by_name['play'].containing_oneof = _SIGNALREQUEST.oneofs_by_name['payload']
+_SIGNALREQUEST.oneofs_by_name['payload'].fields.append(
+  _SIGNALREQUEST.fields_by_name['mute'])
+_SIGNALREQUEST.fields_by_name['mute'].containing_oneof = _SIGNALREQUEST.oneofs_by_name['payload']
+_SIGNALREQUEST.oneofs_by_name['payload'].fields.append(
+  _SIGNALREQUEST.fields_by_name['trickleBatch'])
+_SIGNALREQUEST.fields_by_name['trickleBatch'].containing_oneof = _SIGNALREQUEST.oneofs_by_name['payload']
+_SIGNALREQUEST.oneofs_by_name['payload'].fields.append(
+  _SIGNALREQUEST.fields_by_name['subscribe'])
+_SIGNALREQUEST.fields_by_name['subscribe'].containing_oneof = _SIGNALREQUEST.oneofs_by_name['payload']
+_SIGNALREQUEST.oneofs_by_name['payload'].fields.append(
+  _SIGNALREQUEST.fields_by_name['unsubscribe'])
+_SIGNALREQUEST.fields_by_name['unsubscribe'].containing_oneof = _SIGNALREQUEST.oneofs_by_name['payload']
+_SIGNALREQUEST.oneofs_by_name['payload'].fields.append(
+  _SIGNALREQUEST.fields_by_name['getStats'])
+_SIGNALREQUEST.fields_by_name['getStats'].containing_oneof = _SIGNALREQUEST.oneofs_by_name['payload']
+_SIGNALREQUEST.oneofs_by_name['payload'].fields.append(
+  _SIGNALREQUEST.fields_by_name['pause'])
+_SIGNALREQUEST.fields_by_name['pause'].containing_oneof = _SIGNALREQUEST.oneofs_by_name['payload']
+_SIGNALREQUEST.oneofs_by_name['payload'].fields.append(
+  _SIGNALREQUEST.fields_by_name['move'])
+_SIGNALREQUEST.fields_by_name['move'].containing_oneof = _SIGNALREQUEST.oneofs_by_name['payload']
+_SIGNALREQUEST.oneofs_by_name['payload'].fields.append(
+  _SIGNALREQUEST.fields_by_name['pong'])
+_SIGNALREQUEST.fields_by_name['pong'].containing_oneof = _SIGNALREQUEST.oneofs_by_name['payload']
+_SIGNALREQUEST.oneofs_by_name['payload'].fields.append(
+  _SIGNALREQUEST.fields_by_name['capabilities'])
+_SIGNALREQUEST.fields_by_name['capabilities'].containing_oneof = _SIGNALREQUEST.oneofs_by_name['payload']
 _SIGNALREPLY.fields_by_name['join'].message_type = _JOINREPLY
 _SIGNALREPLY.fields_by_name['trickle'].message_type = _TRICKLE
+_SIGNALREPLY.fields_by_name['leave'].message_type = _PEERLEAVE
+_SIGNALREPLY.fields_by_name['failure'].message_type = _SIGNALERROR
+_SIGNALREPLY.fields_by_name['data'].message_type = _DATAMESSAGE
+_SIGNALREPLY.fields_by_name['setLayer'].message_type = _SETLAYERREPLY
+_SIGNALREPLY.fields_by_name['trickleBatch'].message_type = _TRICKLEBATCH
+_SIGNALREPLY.fields_by_name['subscriptions'].message_type = _SUBSCRIPTIONS
+_SIGNALREPLY.fields_by_name['activeSpeaker'].message_type = _ACTIVESPEAKER
+_SIGNALREPLY.fields_by_name['announcement'].message_type = _ANNOUNCEMENT
+_SIGNALREPLY.fields_by_name['quality'].message_type = _CONNECTIONQUALITY
+_SIGNALREPLY.fields_by_name['stats'].message_type = _PEERSTATSSNAPSHOT
+_SIGNALREPLY.fields_by_name['paused'].message_type = _ROOMPAUSED
+_SIGNALREPLY.fields_by_name['tracks'].message_type = _TRACKINFOS
+_SIGNALREPLY.fields_by_name['moved'].message_type = _ROOMMOVED
+_SIGNALREPLY.fields_by_name['layers'].message_type = _TRACKLAYERS
+_SIGNALREPLY.fields_by_name['capabilities'].message_type = _CAPABILITIES
+_SIGNALREPLY.fields_by_name['closeReason'].enum_type = _CLOSEREASON
 _SIGNALREPLY.oneofs_by_name['payload'].fields.append(
   _SIGNALREPLY.fields_by_name['join'])
 _SIGNALREPLY.fields_by_name['join'].containing_oneof = _SIGNALREPLY.oneofs_by_name['payload']
@@ -1678,11 +6057,90 @@ _SIGNALREPLY.fields_by_name['error'].containing_oneof = _SIGNALREPLY.oneofs_by_n
 _SIGNALREPLY.oneofs_by_name['payload'].fields.append(
   _SIGNALREPLY.fields_by_name['kill'])
 _SIGNALREPLY.fields_by_name['kill'].containing_oneof = _SIGNALREPLY.oneofs_by_name['payload']
+_SIGNALREPLY.oneofs_by_name['payload'].fields.append(
+  _SIGNALREPLY.fields_by_name['leave'])
+_SIGNALREPLY.fields_by_name['leave'].containing_oneof = _SIGNALREPLY.oneofs_by_name['payload']
+_SIGNALREPLY.oneofs_by_name['payload'].fields.append(
+  _SIGNALREPLY.fields_by_name['failure'])
+_SIGNALREPLY.fields_by_name['failure'].containing_oneof = _SIGNALREPLY.oneofs_by_name['payload']
+_SIGNALREPLY.oneofs_by_name['payload'].fields.append(
+  _SIGNALREPLY.fields_by_name['data'])
+_SIGNALREPLY.fields_by_name['data'].containing_oneof = _SIGNALREPLY.oneofs_by_name['payload']
+_SIGNALREPLY.oneofs_by_name['payload'].fields.append(
+  _SIGNALREPLY.fields_by_name['resume'])
+_SIGNALREPLY.fields_by_name['resume'].containing_oneof = _SIGNALREPLY.oneofs_by_name['payload']
+_SIGNALREPLY.oneofs_by_name['payload'].fields.append(
+  _SIGNALREPLY.fields_by_name['setLayer'])
+_SIGNALREPLY.fields_by_name['setLayer'].containing_oneof = _SIGNALREPLY.oneofs_by_name['payload']
+_SIGNALREPLY.oneofs_by_name['payload'].fields.append(
+  _SIGNALREPLY.fields_by_name['play'])
+_SIGNALREPLY.fields_by_name['play'].containing_oneof = _SIGNALREPLY.oneofs_by_name['payload']
+_SIGNALREPLY.oneofs_by_name['payload'].fields.append(
+  _SIGNALREPLY.fields_by_name['reconnect'])
+_SIGNALREPLY.fields_by_name['reconnect'].containing_oneof = _SIGNALREPLY.oneofs_by_name['payload']
+_SIGNALREPLY.oneofs_by_name['payload'].fields.append(
+  _SIGNALREPLY.fields_by_name['trickleBatch'])
+_SIGNALREPLY.fields_by_name['trickleBatch'].containing_oneof = _SIGNALREPLY.oneofs_by_name['payload']
+_SIGNALREPLY.oneofs_by_name['payload'].fields.append(
+  _SIGNALREPLY.fields_by_name['subscriptions'])
+_SIGNALREPLY.fields_by_name['subscriptions'].containing_oneof = _SIGNALREPLY.oneofs_by_name['payload']
+_SIGNALREPLY.oneofs_by_name['payload'].fields.append(
+  _SIGNALREPLY.fields_by_name['activeSpeaker'])
+_SIGNALREPLY.fields_by_name['activeSpeaker'].containing_oneof = _SIGNALREPLY.oneofs_by_name['payload']
+_SIGNALREPLY.oneofs_by_name['payload'].fields.append(
+  _SIGNALREPLY.fields_by_name['announcement'])
+_SIGNALREPLY.fields_by_name['announcement'].containing_oneof = _SIGNALREPLY.oneofs_by_name['payload']
+_SIGNALREPLY.oneofs_by_name['payload'].fields.append(
+  _SIGNALREPLY.fields_by_name['quality'])
+_SIGNALREPLY.fields_by_name['quality'].containing_oneof = _SIGNALREPLY.oneofs_by_name['payload']
+_SIGNALREPLY.oneofs_by_name['payload'].fields.append(
+  _SIGNALREPLY.fields_by_name['stats'])
+_SIGNALREPLY.fields_by_name['stats'].containing_oneof = _SIGNALREPLY.oneofs_by_name['payload']
+_SIGNALREPLY.oneofs_by_name['payload'].fields.append(
+  _SIGNALREPLY.fields_by_name['paused'])
+_SIGNALREPLY.fields_by_name['paused'].containing_oneof = _SIGNALREPLY.oneofs_by_name['payload']
+_SIGNALREPLY.oneofs_by_name['payload'].fields.append(
+  _SIGNALREPLY.fields_by_name['tracks'])
+_SIGNALREPLY.fields_by_name['tracks'].containing_oneof = _SIGNALREPLY.oneofs_by_name['payload']
+_SIGNALREPLY.oneofs_by_name['payload'].fields.append(
+  _SIGNALREPLY.fields_by_name['moved'])
+_SIGNALREPLY.fields_by_name['moved'].containing_oneof = _SIGNALREPLY.oneofs_by_name['payload']
+_SIGNALREPLY.oneofs_by_name['payload'].fields.append(
+  _SIGNALREPLY.fields_by_name['layers'])
+_SIGNALREPLY.fields_by_name['layers'].containing_oneof = _SIGNALREPLY.oneofs_by_name['payload']
+_SIGNALREPLY.oneofs_by_name['payload'].fields.append(
+  _SIGNALREPLY.fields_by_name['ping'])
+_SIGNALREPLY.fields_by_name['ping'].containing_oneof = _SIGNALREPLY.oneofs_by_name['payload']
+_SIGNALREPLY.oneofs_by_name['payload'].fields.append(
+  _SIGNALREPLY.fields_by_name['capabilities'])
+_SIGNALREPLY.fields_by_name['capabilities'].containing_oneof = _SIGNALREPLY.oneofs_by_name['payload']
+_CAPABILITIES.fields_by_name['iceServers'].message_type = _ICESERVER
+_CAPABILITIES.fields_by_name['room'].message_type = _ROOMCAPABILITIES
+_TRACKINFOS.fields_by_name['tracks'].message_type = _TRACKINFO
+_TRACKLAYERS.fields_by_name['layers'].message_type = _TRACKLAYER
+_PEERSTATSSNAPSHOT.fields_by_name['totals'].message_type = _PEERSTATS
+_PEERSTATSSNAPSHOT.fields_by_name['tracks'].message_type = _TRACKSTATS
+_CONNECTIONQUALITY.fields_by_name['level'].enum_type = _CONNECTIONQUALITY_LEVEL
+_CONNECTIONQUALITY_LEVEL.containing_type = _CONNECTIONQUALITY
+_ANNOUNCEMENT.fields_by_name['severity'].enum_type = _ANNOUNCEMENT_SEVERITY
+_ANNOUNCEMENT_SEVERITY.containing_type = _ANNOUNCEMENT
+_JOINREQUEST.fields_by_name['limits'].message_type = _BITRATELIMITS
+_JOINREQUEST.fields_by_name['role'].enum_type = _ROLE
+_JOINREQUEST.fields_by_name['compression'].enum_type = _COMPRESSION
+_JOINREPLY.fields_by_name['iceServers'].message_type = _ICESERVER
+_ICESERVER.fields_by_name['expires'].message_type = google_dot_protobuf_dot_timestamp__pb2._TIMESTAMP
+_RTCPPOLICY.fields_by_name['aggregation'].enum_type = _RTCPPOLICY_AGGREGATION
+_RTCPPOLICY_AGGREGATION.containing_type = _RTCPPOLICY
+_SIGNALERROR.fields_by_name['code'].enum_type = _SIGNALERROR_CODE
+_SIGNALERROR_CODE.containing_type = _SIGNALERROR
+_PEERLEAVE.fields_by_name['reason'].enum_type = _CLOSEREASON
 _TRICKLE.fields_by_name['target'].enum_type = _TRICKLE_TARGET
 _TRICKLE_TARGET.containing_type = _TRICKLE
+_TRICKLEBATCH.fields_by_name['candidates'].message_type = _TRICKLE
 _NOIROBJECT.fields_by_name['node'].message_type = _NODEDATA
 _NOIROBJECT.fields_by_name['room'].message_type = _ROOMDATA
 _NOIROBJECT.fields_by_name['user'].message_type = _USERDATA
+_NOIROBJECT.fields_by_name['heartbeat'].message_type = _WORKERHEARTBEAT
 _NOIROBJECT.oneofs_by_name['data'].fields.append(
   _NOIROBJECT.fields_by_name['node'])
 _NOIROBJECT.fields_by_name['node'].containing_oneof = _NOIROBJECT.oneofs_by_name['data']
@@ -1692,13 +6150,45 @@ _NOIROBJECT.fields_by_name['room'].containing_oneof = _NOIROBJECT.oneofs_by_name
 _NOIROBJECT.oneofs_by_name['data'].fields.append(
   _NOIROBJECT.fields_by_name['user'])
 _NOIROBJECT.fields_by_name['user'].containing_oneof = _NOIROBJECT.oneofs_by_name['data']
+_NOIROBJECT.oneofs_by_name['data'].fields.append(
+  _NOIROBJECT.fields_by_name['heartbeat'])
+_NOIROBJECT.fields_by_name['heartbeat'].containing_oneof = _NOIROBJECT.oneofs_by_name['data']
+_DEADLETTER.fields_by_name['at'].message_type = google_dot_protobuf_dot_timestamp__pb2._TIMESTAMP
+_SIGNALTRACE.fields_by_name['at'].message_type = google_dot_protobuf_dot_timestamp__pb2._TIMESTAMP
+_SIGNALTRACE.fields_by_name['request'].message_type = _NOIRREQUEST
+_SIGNALTRACE.fields_by_name['reply'].message_type = _NOIRREPLY
+_SIGNALTRACE.oneofs_by_name['message'].fields.append(
+  _SIGNALTRACE.fields_by_name['request'])
+_SIGNALTRACE.fields_by_name['request'].containing_oneof = _SIGNALTRACE.oneofs_by_name['message']
+_SIGNALTRACE.oneofs_by_name['message'].fields.append(
+  _SIGNALTRACE.fields_by_name['reply'])
+_SIGNALTRACE.fields_by_name['reply'].containing_oneof = _SIGNALTRACE.oneofs_by_name['message']
+_WORKERHEARTBEAT.fields_by_name['lastSeen'].message_type = google_dot_protobuf_dot_timestamp__pb2._TIMESTAMP
 _NODEDATA.fields_by_name['lastUpdate'].message_type = google_dot_protobuf_dot_timestamp__pb2._TIMESTAMP
+_ROOMDATA_METADATAENTRY.containing_type = _ROOMDATA
+_ROOMDATA_RECORDINGSENTRY.fields_by_name['value'].message_type = _RECORDING
+_ROOMDATA_RECORDINGSENTRY.containing_type = _ROOMDATA
 _ROOMDATA.fields_by_name['created'].message_type = google_dot_protobuf_dot_timestamp__pb2._TIMESTAMP
 _ROOMDATA.fields_by_name['lastUpdate'].message_type = google_dot_protobuf_dot_timestamp__pb2._TIMESTAMP
 _ROOMDATA.fields_by_name['options'].message_type = _ROOMOPTIONS
+_ROOMDATA.fields_by_name['metadata'].message_type = _ROOMDATA_METADATAENTRY
+_ROOMDATA.fields_by_name['recordings'].message_type = _ROOMDATA_RECORDINGSENTRY
+_RECORDING.fields_by_name['started'].message_type = google_dot_protobuf_dot_timestamp__pb2._TIMESTAMP
+_RECORDING.fields_by_name['stopped'].message_type = google_dot_protobuf_dot_timestamp__pb2._TIMESTAMP
+_ROOMOPTIONS.fields_by_name['icePolicy'].message_type = _ICEPOLICY
+_ROOMOPTIONS.fields_by_name['rtcpPolicy'].message_type = _RTCPPOLICY
+_ROOMOPTIONS.fields_by_name['sdpLimits'].message_type = _SDPLIMITS
+_ROOMOPTIONS.fields_by_name['emptyRoom'].enum_type = _EMPTYROOMPOLICY
+_USERDATA_TRACKLABELSENTRY.containing_type = _USERDATA
 _USERDATA.fields_by_name['created'].message_type = google_dot_protobuf_dot_timestamp__pb2._TIMESTAMP
 _USERDATA.fields_by_name['lastUpdate'].message_type = google_dot_protobuf_dot_timestamp__pb2._TIMESTAMP
 _USERDATA.fields_by_name['options'].message_type = _USEROPTIONS
+_USERDATA.fields_by_name['limits'].message_type = _BITRATELIMITS
+_USERDATA.fields_by_name['role'].enum_type = _ROLE
+_USERDATA.fields_by_name['trackLabels'].message_type = _USERDATA_TRACKLABELSENTRY
+_PEERSTATUS.fields_by_name['role'].enum_type = _ROLE
+_PEERSTATUS.fields_by_name['joined'].message_type = google_dot_protobuf_dot_timestamp__pb2._TIMESTAMP
+_PEERSTATUS.fields_by_name['updated'].message_type = google_dot_protobuf_dot_timestamp__pb2._TIMESTAMP
 _JOBDATA.fields_by_name['status'].enum_type = _JOBDATA_JOBSTATUS
 _JOBDATA.fields_by_name['created'].message_type = google_dot_protobuf_dot_timestamp__pb2._TIMESTAMP
 _JOBDATA.fields_by_name['lastUpdate'].message_type = google_dot_protobuf_dot_timestamp__pb2._TIMESTAMP
@@ -1707,6 +6197,7 @@ DESCRIPTOR.message_types_by_name['AdminClient'] = _ADMINCLIENT
 DESCRIPTOR.message_types_by_name['Empty'] = _EMPTY
 DESCRIPTOR.message_types_by_name['NoirRequest'] = _NOIRREQUEST
 DESCRIPTOR.message_types_by_name['NoirReply'] = _NOIRREPLY
+DESCRIPTOR.message_types_by_name['RoomEvent'] = _ROOMEVENT
 DESCRIPTOR.message_types_by_name['AdminRequest'] = _ADMINREQUEST
 DESCRIPTOR.message_types_by_name['AdminReply'] = _ADMINREPLY
 DESCRIPTOR.message_types_by_name['RoomCountRequest'] = _ROOMCOUNTREQUEST
@@ -1714,25 +6205,83 @@ DESCRIPTOR.message_types_by_name['RoomCountReply'] = _ROOMCOUNTREPLY
 DESCRIPTOR.message_types_by_name['RoomListRequest'] = _ROOMLISTREQUEST
 DESCRIPTOR.message_types_by_name['RoomListEntry'] = _ROOMLISTENTRY
 DESCRIPTOR.message_types_by_name['RoomListReply'] = _ROOMLISTREPLY
+DESCRIPTOR.message_types_by_name['RoomStatsRequest'] = _ROOMSTATSREQUEST
+DESCRIPTOR.message_types_by_name['PeerStats'] = _PEERSTATS
+DESCRIPTOR.message_types_by_name['RoomStatsReply'] = _ROOMSTATSREPLY
+DESCRIPTOR.message_types_by_name['WorkerStateRequest'] = _WORKERSTATEREQUEST
+DESCRIPTOR.message_types_by_name['WorkerStateReply'] = _WORKERSTATEREPLY
 DESCRIPTOR.message_types_by_name['RoomAdminRequest'] = _ROOMADMINREQUEST
 DESCRIPTOR.message_types_by_name['RoomAdminReply'] = _ROOMADMINREPLY
+DESCRIPTOR.message_types_by_name['AnnounceReply'] = _ANNOUNCEREPLY
 DESCRIPTOR.message_types_by_name['CreateRoomRequest'] = _CREATEROOMREQUEST
 DESCRIPTOR.message_types_by_name['CreateRoomReply'] = _CREATEROOMREPLY
+DESCRIPTOR.message_types_by_name['StartRecordingRequest'] = _STARTRECORDINGREQUEST
+DESCRIPTOR.message_types_by_name['StartRecordingReply'] = _STARTRECORDINGREPLY
+DESCRIPTOR.message_types_by_name['StopRecordingRequest'] = _STOPRECORDINGREQUEST
+DESCRIPTOR.message_types_by_name['StopRecordingReply'] = _STOPRECORDINGREPLY
+DESCRIPTOR.message_types_by_name['MutePeerRequest'] = _MUTEPEERREQUEST
+DESCRIPTOR.message_types_by_name['TraceSignalingRequest'] = _TRACESIGNALINGREQUEST
+DESCRIPTOR.message_types_by_name['SetPasswordRequest'] = _SETPASSWORDREQUEST
+DESCRIPTOR.message_types_by_name['PauseRoomRequest'] = _PAUSEROOMREQUEST
+DESCRIPTOR.message_types_by_name['TransferOwnershipRequest'] = _TRANSFEROWNERSHIPREQUEST
+DESCRIPTOR.message_types_by_name['KickPeerRequest'] = _KICKPEERREQUEST
+DESCRIPTOR.message_types_by_name['CloseRoomRequest'] = _CLOSEROOMREQUEST
+DESCRIPTOR.message_types_by_name['CloseRoomReply'] = _CLOSEROOMREPLY
 DESCRIPTOR.message_types_by_name['RoomJobRequest'] = _ROOMJOBREQUEST
 DESCRIPTOR.message_types_by_name['RoomJobReply'] = _ROOMJOBREPLY
 DESCRIPTOR.message_types_by_name['SignalRequest'] = _SIGNALREQUEST
 DESCRIPTOR.message_types_by_name['SignalReply'] = _SIGNALREPLY
+DESCRIPTOR.message_types_by_name['CapabilitiesRequest'] = _CAPABILITIESREQUEST
+DESCRIPTOR.message_types_by_name['Capabilities'] = _CAPABILITIES
+DESCRIPTOR.message_types_by_name['RoomCapabilities'] = _ROOMCAPABILITIES
+DESCRIPTOR.message_types_by_name['TrackInfos'] = _TRACKINFOS
+DESCRIPTOR.message_types_by_name['TrackInfo'] = _TRACKINFO
+DESCRIPTOR.message_types_by_name['TrackLayers'] = _TRACKLAYERS
+DESCRIPTOR.message_types_by_name['TrackLayer'] = _TRACKLAYER
+DESCRIPTOR.message_types_by_name['MoveRequest'] = _MOVEREQUEST
+DESCRIPTOR.message_types_by_name['RoomMoved'] = _ROOMMOVED
+DESCRIPTOR.message_types_by_name['RoomPaused'] = _ROOMPAUSED
+DESCRIPTOR.message_types_by_name['GetStatsRequest'] = _GETSTATSREQUEST
+DESCRIPTOR.message_types_by_name['TrackStats'] = _TRACKSTATS
+DESCRIPTOR.message_types_by_name['PeerStatsSnapshot'] = _PEERSTATSSNAPSHOT
+DESCRIPTOR.message_types_by_name['ConnectionQuality'] = _CONNECTIONQUALITY
+DESCRIPTOR.message_types_by_name['Announcement'] = _ANNOUNCEMENT
+DESCRIPTOR.message_types_by_name['ActiveSpeaker'] = _ACTIVESPEAKER
+DESCRIPTOR.message_types_by_name['SubscribeRequest'] = _SUBSCRIBEREQUEST
+DESCRIPTOR.message_types_by_name['Subscriptions'] = _SUBSCRIPTIONS
 DESCRIPTOR.message_types_by_name['JoinRequest'] = _JOINREQUEST
+DESCRIPTOR.message_types_by_name['BitrateLimits'] = _BITRATELIMITS
 DESCRIPTOR.message_types_by_name['JoinReply'] = _JOINREPLY
+DESCRIPTOR.message_types_by_name['ICEServer'] = _ICESERVER
+DESCRIPTOR.message_types_by_name['ICEPolicy'] = _ICEPOLICY
+DESCRIPTOR.message_types_by_name['RTCPPolicy'] = _RTCPPOLICY
+DESCRIPTOR.message_types_by_name['SDPLimits'] = _SDPLIMITS
+DESCRIPTOR.message_types_by_name['SignalError'] = _SIGNALERROR
+DESCRIPTOR.message_types_by_name['MuteRequest'] = _MUTEREQUEST
+DESCRIPTOR.message_types_by_name['PlayRequest'] = _PLAYREQUEST
+DESCRIPTOR.message_types_by_name['SetLayerRequest'] = _SETLAYERREQUEST
+DESCRIPTOR.message_types_by_name['SetLayerReply'] = _SETLAYERREPLY
+DESCRIPTOR.message_types_by_name['DataMessage'] = _DATAMESSAGE
+DESCRIPTOR.message_types_by_name['PeerLeave'] = _PEERLEAVE
 DESCRIPTOR.message_types_by_name['Trickle'] = _TRICKLE
+DESCRIPTOR.message_types_by_name['TrickleBatch'] = _TRICKLEBATCH
 DESCRIPTOR.message_types_by_name['NoirObject'] = _NOIROBJECT
+DESCRIPTOR.message_types_by_name['DeadLetter'] = _DEADLETTER
+DESCRIPTOR.message_types_by_name['SignalTrace'] = _SIGNALTRACE
+DESCRIPTOR.message_types_by_name['WorkerHeartbeat'] = _WORKERHEARTBEAT
 DESCRIPTOR.message_types_by_name['NodeData'] = _NODEDATA
 DESCRIPTOR.message_types_by_name['RoomData'] = _ROOMDATA
+DESCRIPTOR.message_types_by_name['Recording'] = _RECORDING
 DESCRIPTOR.message_types_by_name['RoomOptions'] = _ROOMOPTIONS
 DESCRIPTOR.message_types_by_name['UserData'] = _USERDATA
+DESCRIPTOR.message_types_by_name['PeerStatus'] = _PEERSTATUS
 DESCRIPTOR.message_types_by_name['UserOptions'] = _USEROPTIONS
 DESCRIPTOR.message_types_by_name['JobData'] = _JOBDATA
 DESCRIPTOR.message_types_by_name['PeerJobData'] = _PEERJOBDATA
+DESCRIPTOR.enum_types_by_name['Compression'] = _COMPRESSION
+DESCRIPTOR.enum_types_by_name['CloseReason'] = _CLOSEREASON
+DESCRIPTOR.enum_types_by_name['Role'] = _ROLE
+DESCRIPTOR.enum_types_by_name['EmptyRoomPolicy'] = _EMPTYROOMPOLICY
 _sym_db.RegisterFileDescriptor(DESCRIPTOR)
 
 AdminClient = _reflection.GeneratedProtocolMessageType('AdminClient', (_message.Message,), {
@@ -1763,6 +6312,13 @@ NoirReply = _reflection.GeneratedProtocolMessageType('NoirReply', (_message.Mess
   })
 _sym_db.RegisterMessage(NoirReply)
 
+RoomEvent = _reflection.GeneratedProtocolMessageType('RoomEvent', (_message.Message,), {
+  'DESCRIPTOR' : _ROOMEVENT,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.RoomEvent)
+  })
+_sym_db.RegisterMessage(RoomEvent)
+
 AdminRequest = _reflection.GeneratedProtocolMessageType('AdminRequest', (_message.Message,), {
   'DESCRIPTOR' : _ADMINREQUEST,
   '__module__' : 'pkg.proto.noir_pb2'
@@ -1812,6 +6368,49 @@ RoomListReply = _reflection.GeneratedProtocolMessageType('RoomListReply', (_mess
   })
 _sym_db.RegisterMessage(RoomListReply)
 
+RoomStatsRequest = _reflection.GeneratedProtocolMessageType('RoomStatsRequest', (_message.Message,), {
+  'DESCRIPTOR' : _ROOMSTATSREQUEST,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.RoomStatsRequest)
+  })
+_sym_db.RegisterMessage(RoomStatsRequest)
+
+PeerStats = _reflection.GeneratedProtocolMessageType('PeerStats', (_message.Message,), {
+  'DESCRIPTOR' : _PEERSTATS,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.PeerStats)
+  })
+_sym_db.RegisterMessage(PeerStats)
+
+RoomStatsReply = _reflection.GeneratedProtocolMessageType('RoomStatsReply', (_message.Message,), {
+  'DESCRIPTOR' : _ROOMSTATSREPLY,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.RoomStatsReply)
+  })
+_sym_db.RegisterMessage(RoomStatsReply)
+
+WorkerStateRequest = _reflection.GeneratedProtocolMessageType('WorkerStateRequest', (_message.Message,), {
+  'DESCRIPTOR' : _WORKERSTATEREQUEST,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.WorkerStateRequest)
+  })
+_sym_db.RegisterMessage(WorkerStateRequest)
+
+WorkerStateReply = _reflection.GeneratedProtocolMessageType('WorkerStateReply', (_message.Message,), {
+
+  'PeerQueuesEntry' : _reflection.GeneratedProtocolMessageType('PeerQueuesEntry', (_message.Message,), {
+    'DESCRIPTOR' : _WORKERSTATEREPLY_PEERQUEUESENTRY,
+    '__module__' : 'pkg.proto.noir_pb2'
+    # @@protoc_insertion_point(class_scope:noir.WorkerStateReply.PeerQueuesEntry)
+    })
+  ,
+  'DESCRIPTOR' : _WORKERSTATEREPLY,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.WorkerStateReply)
+  })
+_sym_db.RegisterMessage(WorkerStateReply)
+_sym_db.RegisterMessage(WorkerStateReply.PeerQueuesEntry)
+
 RoomAdminRequest = _reflection.GeneratedProtocolMessageType('RoomAdminRequest', (_message.Message,), {
   'DESCRIPTOR' : _ROOMADMINREQUEST,
   '__module__' : 'pkg.proto.noir_pb2'
@@ -1826,12 +6425,27 @@ RoomAdminReply = _reflection.GeneratedProtocolMessageType('RoomAdminReply', (_me
   })
 _sym_db.RegisterMessage(RoomAdminReply)
 
+AnnounceReply = _reflection.GeneratedProtocolMessageType('AnnounceReply', (_message.Message,), {
+  'DESCRIPTOR' : _ANNOUNCEREPLY,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.AnnounceReply)
+  })
+_sym_db.RegisterMessage(AnnounceReply)
+
 CreateRoomRequest = _reflection.GeneratedProtocolMessageType('CreateRoomRequest', (_message.Message,), {
+
+  'MetadataEntry' : _reflection.GeneratedProtocolMessageType('MetadataEntry', (_message.Message,), {
+    'DESCRIPTOR' : _CREATEROOMREQUEST_METADATAENTRY,
+    '__module__' : 'pkg.proto.noir_pb2'
+    # @@protoc_insertion_point(class_scope:noir.CreateRoomRequest.MetadataEntry)
+    })
+  ,
   'DESCRIPTOR' : _CREATEROOMREQUEST,
   '__module__' : 'pkg.proto.noir_pb2'
   # @@protoc_insertion_point(class_scope:noir.CreateRoomRequest)
   })
 _sym_db.RegisterMessage(CreateRoomRequest)
+_sym_db.RegisterMessage(CreateRoomRequest.MetadataEntry)
 
 CreateRoomReply = _reflection.GeneratedProtocolMessageType('CreateRoomReply', (_message.Message,), {
   'DESCRIPTOR' : _CREATEROOMREPLY,
@@ -1840,6 +6454,90 @@ CreateRoomReply = _reflection.GeneratedProtocolMessageType('CreateRoomReply', (_
   })
 _sym_db.RegisterMessage(CreateRoomReply)
 
+StartRecordingRequest = _reflection.GeneratedProtocolMessageType('StartRecordingRequest', (_message.Message,), {
+  'DESCRIPTOR' : _STARTRECORDINGREQUEST,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.StartRecordingRequest)
+  })
+_sym_db.RegisterMessage(StartRecordingRequest)
+
+StartRecordingReply = _reflection.GeneratedProtocolMessageType('StartRecordingReply', (_message.Message,), {
+  'DESCRIPTOR' : _STARTRECORDINGREPLY,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.StartRecordingReply)
+  })
+_sym_db.RegisterMessage(StartRecordingReply)
+
+StopRecordingRequest = _reflection.GeneratedProtocolMessageType('StopRecordingRequest', (_message.Message,), {
+  'DESCRIPTOR' : _STOPRECORDINGREQUEST,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.StopRecordingRequest)
+  })
+_sym_db.RegisterMessage(StopRecordingRequest)
+
+StopRecordingReply = _reflection.GeneratedProtocolMessageType('StopRecordingReply', (_message.Message,), {
+  'DESCRIPTOR' : _STOPRECORDINGREPLY,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.StopRecordingReply)
+  })
+_sym_db.RegisterMessage(StopRecordingReply)
+
+MutePeerRequest = _reflection.GeneratedProtocolMessageType('MutePeerRequest', (_message.Message,), {
+  'DESCRIPTOR' : _MUTEPEERREQUEST,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.MutePeerRequest)
+  })
+_sym_db.RegisterMessage(MutePeerRequest)
+
+TraceSignalingRequest = _reflection.GeneratedProtocolMessageType('TraceSignalingRequest', (_message.Message,), {
+  'DESCRIPTOR' : _TRACESIGNALINGREQUEST,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.TraceSignalingRequest)
+  })
+_sym_db.RegisterMessage(TraceSignalingRequest)
+
+SetPasswordRequest = _reflection.GeneratedProtocolMessageType('SetPasswordRequest', (_message.Message,), {
+  'DESCRIPTOR' : _SETPASSWORDREQUEST,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.SetPasswordRequest)
+  })
+_sym_db.RegisterMessage(SetPasswordRequest)
+
+PauseRoomRequest = _reflection.GeneratedProtocolMessageType('PauseRoomRequest', (_message.Message,), {
+  'DESCRIPTOR' : _PAUSEROOMREQUEST,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.PauseRoomRequest)
+  })
+_sym_db.RegisterMessage(PauseRoomRequest)
+
+TransferOwnershipRequest = _reflection.GeneratedProtocolMessageType('TransferOwnershipRequest', (_message.Message,), {
+  'DESCRIPTOR' : _TRANSFEROWNERSHIPREQUEST,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.TransferOwnershipRequest)
+  })
+_sym_db.RegisterMessage(TransferOwnershipRequest)
+
+KickPeerRequest = _reflection.GeneratedProtocolMessageType('KickPeerRequest', (_message.Message,), {
+  'DESCRIPTOR' : _KICKPEERREQUEST,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.KickPeerRequest)
+  })
+_sym_db.RegisterMessage(KickPeerRequest)
+
+CloseRoomRequest = _reflection.GeneratedProtocolMessageType('CloseRoomRequest', (_message.Message,), {
+  'DESCRIPTOR' : _CLOSEROOMREQUEST,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.CloseRoomRequest)
+  })
+_sym_db.RegisterMessage(CloseRoomRequest)
+
+CloseRoomReply = _reflection.GeneratedProtocolMessageType('CloseRoomReply', (_message.Message,), {
+  'DESCRIPTOR' : _CLOSEROOMREPLY,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.CloseRoomReply)
+  })
+_sym_db.RegisterMessage(CloseRoomReply)
+
 RoomJobRequest = _reflection.GeneratedProtocolMessageType('RoomJobRequest', (_message.Message,), {
   'DESCRIPTOR' : _ROOMJOBREQUEST,
   '__module__' : 'pkg.proto.noir_pb2'
@@ -1855,11 +6553,19 @@ RoomJobReply = _reflection.GeneratedProtocolMessageType('RoomJobReply', (_messag
 _sym_db.RegisterMessage(RoomJobReply)
 
 SignalRequest = _reflection.GeneratedProtocolMessageType('SignalRequest', (_message.Message,), {
+
+  'TrackLabelsEntry' : _reflection.GeneratedProtocolMessageType('TrackLabelsEntry', (_message.Message,), {
+    'DESCRIPTOR' : _SIGNALREQUEST_TRACKLABELSENTRY,
+    '__module__' : 'pkg.proto.noir_pb2'
+    # @@protoc_insertion_point(class_scope:noir.SignalRequest.TrackLabelsEntry)
+    })
+  ,
   'DESCRIPTOR' : _SIGNALREQUEST,
   '__module__' : 'pkg.proto.noir_pb2'
   # @@protoc_insertion_point(class_scope:noir.SignalRequest)
   })
 _sym_db.RegisterMessage(SignalRequest)
+_sym_db.RegisterMessage(SignalRequest.TrackLabelsEntry)
 
 SignalReply = _reflection.GeneratedProtocolMessageType('SignalReply', (_message.Message,), {
   'DESCRIPTOR' : _SIGNALREPLY,
@@ -1868,6 +6574,132 @@ SignalReply = _reflection.GeneratedProtocolMessageType('SignalReply', (_message.
   })
 _sym_db.RegisterMessage(SignalReply)
 
+CapabilitiesRequest = _reflection.GeneratedProtocolMessageType('CapabilitiesRequest', (_message.Message,), {
+  'DESCRIPTOR' : _CAPABILITIESREQUEST,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.CapabilitiesRequest)
+  })
+_sym_db.RegisterMessage(CapabilitiesRequest)
+
+Capabilities = _reflection.GeneratedProtocolMessageType('Capabilities', (_message.Message,), {
+  'DESCRIPTOR' : _CAPABILITIES,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.Capabilities)
+  })
+_sym_db.RegisterMessage(Capabilities)
+
+RoomCapabilities = _reflection.GeneratedProtocolMessageType('RoomCapabilities', (_message.Message,), {
+  'DESCRIPTOR' : _ROOMCAPABILITIES,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.RoomCapabilities)
+  })
+_sym_db.RegisterMessage(RoomCapabilities)
+
+TrackInfos = _reflection.GeneratedProtocolMessageType('TrackInfos', (_message.Message,), {
+  'DESCRIPTOR' : _TRACKINFOS,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.TrackInfos)
+  })
+_sym_db.RegisterMessage(TrackInfos)
+
+TrackInfo = _reflection.GeneratedProtocolMessageType('TrackInfo', (_message.Message,), {
+  'DESCRIPTOR' : _TRACKINFO,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.TrackInfo)
+  })
+_sym_db.RegisterMessage(TrackInfo)
+
+TrackLayers = _reflection.GeneratedProtocolMessageType('TrackLayers', (_message.Message,), {
+  'DESCRIPTOR' : _TRACKLAYERS,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.TrackLayers)
+  })
+_sym_db.RegisterMessage(TrackLayers)
+
+TrackLayer = _reflection.GeneratedProtocolMessageType('TrackLayer', (_message.Message,), {
+  'DESCRIPTOR' : _TRACKLAYER,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.TrackLayer)
+  })
+_sym_db.RegisterMessage(TrackLayer)
+
+MoveRequest = _reflection.GeneratedProtocolMessageType('MoveRequest', (_message.Message,), {
+  'DESCRIPTOR' : _MOVEREQUEST,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.MoveRequest)
+  })
+_sym_db.RegisterMessage(MoveRequest)
+
+RoomMoved = _reflection.GeneratedProtocolMessageType('RoomMoved', (_message.Message,), {
+  'DESCRIPTOR' : _ROOMMOVED,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.RoomMoved)
+  })
+_sym_db.RegisterMessage(RoomMoved)
+
+RoomPaused = _reflection.GeneratedProtocolMessageType('RoomPaused', (_message.Message,), {
+  'DESCRIPTOR' : _ROOMPAUSED,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.RoomPaused)
+  })
+_sym_db.RegisterMessage(RoomPaused)
+
+GetStatsRequest = _reflection.GeneratedProtocolMessageType('GetStatsRequest', (_message.Message,), {
+  'DESCRIPTOR' : _GETSTATSREQUEST,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.GetStatsRequest)
+  })
+_sym_db.RegisterMessage(GetStatsRequest)
+
+TrackStats = _reflection.GeneratedProtocolMessageType('TrackStats', (_message.Message,), {
+  'DESCRIPTOR' : _TRACKSTATS,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.TrackStats)
+  })
+_sym_db.RegisterMessage(TrackStats)
+
+PeerStatsSnapshot = _reflection.GeneratedProtocolMessageType('PeerStatsSnapshot', (_message.Message,), {
+  'DESCRIPTOR' : _PEERSTATSSNAPSHOT,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.PeerStatsSnapshot)
+  })
+_sym_db.RegisterMessage(PeerStatsSnapshot)
+
+ConnectionQuality = _reflection.GeneratedProtocolMessageType('ConnectionQuality', (_message.Message,), {
+  'DESCRIPTOR' : _CONNECTIONQUALITY,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.ConnectionQuality)
+  })
+_sym_db.RegisterMessage(ConnectionQuality)
+
+Announcement = _reflection.GeneratedProtocolMessageType('Announcement', (_message.Message,), {
+  'DESCRIPTOR' : _ANNOUNCEMENT,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.Announcement)
+  })
+_sym_db.RegisterMessage(Announcement)
+
+ActiveSpeaker = _reflection.GeneratedProtocolMessageType('ActiveSpeaker', (_message.Message,), {
+  'DESCRIPTOR' : _ACTIVESPEAKER,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.ActiveSpeaker)
+  })
+_sym_db.RegisterMessage(ActiveSpeaker)
+
+SubscribeRequest = _reflection.GeneratedProtocolMessageType('SubscribeRequest', (_message.Message,), {
+  'DESCRIPTOR' : _SUBSCRIBEREQUEST,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.SubscribeRequest)
+  })
+_sym_db.RegisterMessage(SubscribeRequest)
+
+Subscriptions = _reflection.GeneratedProtocolMessageType('Subscriptions', (_message.Message,), {
+  'DESCRIPTOR' : _SUBSCRIPTIONS,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.Subscriptions)
+  })
+_sym_db.RegisterMessage(Subscriptions)
+
 JoinRequest = _reflection.GeneratedProtocolMessageType('JoinRequest', (_message.Message,), {
   'DESCRIPTOR' : _JOINREQUEST,
   '__module__' : 'pkg.proto.noir_pb2'
@@ -1875,6 +6707,13 @@ JoinRequest = _reflection.GeneratedProtocolMessageType('JoinRequest', (_message.
   })
 _sym_db.RegisterMessage(JoinRequest)
 
+BitrateLimits = _reflection.GeneratedProtocolMessageType('BitrateLimits', (_message.Message,), {
+  'DESCRIPTOR' : _BITRATELIMITS,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.BitrateLimits)
+  })
+_sym_db.RegisterMessage(BitrateLimits)
+
 JoinReply = _reflection.GeneratedProtocolMessageType('JoinReply', (_message.Message,), {
   'DESCRIPTOR' : _JOINREPLY,
   '__module__' : 'pkg.proto.noir_pb2'
@@ -1882,6 +6721,83 @@ JoinReply = _reflection.GeneratedProtocolMessageType('JoinReply', (_message.Mess
   })
 _sym_db.RegisterMessage(JoinReply)
 
+ICEServer = _reflection.GeneratedProtocolMessageType('ICEServer', (_message.Message,), {
+  'DESCRIPTOR' : _ICESERVER,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.ICEServer)
+  })
+_sym_db.RegisterMessage(ICEServer)
+
+ICEPolicy = _reflection.GeneratedProtocolMessageType('ICEPolicy', (_message.Message,), {
+  'DESCRIPTOR' : _ICEPOLICY,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.ICEPolicy)
+  })
+_sym_db.RegisterMessage(ICEPolicy)
+
+RTCPPolicy = _reflection.GeneratedProtocolMessageType('RTCPPolicy', (_message.Message,), {
+  'DESCRIPTOR' : _RTCPPOLICY,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.RTCPPolicy)
+  })
+_sym_db.RegisterMessage(RTCPPolicy)
+
+SDPLimits = _reflection.GeneratedProtocolMessageType('SDPLimits', (_message.Message,), {
+  'DESCRIPTOR' : _SDPLIMITS,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.SDPLimits)
+  })
+_sym_db.RegisterMessage(SDPLimits)
+
+SignalError = _reflection.GeneratedProtocolMessageType('SignalError', (_message.Message,), {
+  'DESCRIPTOR' : _SIGNALERROR,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.SignalError)
+  })
+_sym_db.RegisterMessage(SignalError)
+
+MuteRequest = _reflection.GeneratedProtocolMessageType('MuteRequest', (_message.Message,), {
+  'DESCRIPTOR' : _MUTEREQUEST,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.MuteRequest)
+  })
+_sym_db.RegisterMessage(MuteRequest)
+
+PlayRequest = _reflection.GeneratedProtocolMessageType('PlayRequest', (_message.Message,), {
+  'DESCRIPTOR' : _PLAYREQUEST,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.PlayRequest)
+  })
+_sym_db.RegisterMessage(PlayRequest)
+
+SetLayerRequest = _reflection.GeneratedProtocolMessageType('SetLayerRequest', (_message.Message,), {
+  'DESCRIPTOR' : _SETLAYERREQUEST,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.SetLayerRequest)
+  })
+_sym_db.RegisterMessage(SetLayerRequest)
+
+SetLayerReply = _reflection.GeneratedProtocolMessageType('SetLayerReply', (_message.Message,), {
+  'DESCRIPTOR' : _SETLAYERREPLY,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.SetLayerReply)
+  })
+_sym_db.RegisterMessage(SetLayerReply)
+
+DataMessage = _reflection.GeneratedProtocolMessageType('DataMessage', (_message.Message,), {
+  'DESCRIPTOR' : _DATAMESSAGE,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.DataMessage)
+  })
+_sym_db.RegisterMessage(DataMessage)
+
+PeerLeave = _reflection.GeneratedProtocolMessageType('PeerLeave', (_message.Message,), {
+  'DESCRIPTOR' : _PEERLEAVE,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.PeerLeave)
+  })
+_sym_db.RegisterMessage(PeerLeave)
+
 Trickle = _reflection.GeneratedProtocolMessageType('Trickle', (_message.Message,), {
   'DESCRIPTOR' : _TRICKLE,
   '__module__' : 'pkg.proto.noir_pb2'
@@ -1889,6 +6805,13 @@ Trickle = _reflection.GeneratedProtocolMessageType('Trickle', (_message.Message,
   })
 _sym_db.RegisterMessage(Trickle)
 
+TrickleBatch = _reflection.GeneratedProtocolMessageType('TrickleBatch', (_message.Message,), {
+  'DESCRIPTOR' : _TRICKLEBATCH,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.TrickleBatch)
+  })
+_sym_db.RegisterMessage(TrickleBatch)
+
 NoirObject = _reflection.GeneratedProtocolMessageType('NoirObject', (_message.Message,), {
   'DESCRIPTOR' : _NOIROBJECT,
   '__module__' : 'pkg.proto.noir_pb2'
@@ -1896,6 +6819,27 @@ NoirObject = _reflection.GeneratedProtocolMessageType('NoirObject', (_message.Me
   })
 _sym_db.RegisterMessage(NoirObject)
 
+DeadLetter = _reflection.GeneratedProtocolMessageType('DeadLetter', (_message.Message,), {
+  'DESCRIPTOR' : _DEADLETTER,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.DeadLetter)
+  })
+_sym_db.RegisterMessage(DeadLetter)
+
+SignalTrace = _reflection.GeneratedProtocolMessageType('SignalTrace', (_message.Message,), {
+  'DESCRIPTOR' : _SIGNALTRACE,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.SignalTrace)
+  })
+_sym_db.RegisterMessage(SignalTrace)
+
+WorkerHeartbeat = _reflection.GeneratedProtocolMessageType('WorkerHeartbeat', (_message.Message,), {
+  'DESCRIPTOR' : _WORKERHEARTBEAT,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.WorkerHeartbeat)
+  })
+_sym_db.RegisterMessage(WorkerHeartbeat)
+
 NodeData = _reflection.GeneratedProtocolMessageType('NodeData', (_message.Message,), {
   'DESCRIPTOR' : _NODEDATA,
   '__module__' : 'pkg.proto.noir_pb2'
@@ -1904,11 +6848,34 @@ NodeData = _reflection.GeneratedProtocolMessageType('NodeData', (_message.Messag
 _sym_db.RegisterMessage(NodeData)
 
 RoomData = _reflection.GeneratedProtocolMessageType('RoomData', (_message.Message,), {
+
+  'MetadataEntry' : _reflection.GeneratedProtocolMessageType('MetadataEntry', (_message.Message,), {
+    'DESCRIPTOR' : _ROOMDATA_METADATAENTRY,
+    '__module__' : 'pkg.proto.noir_pb2'
+    # @@protoc_insertion_point(class_scope:noir.RoomData.MetadataEntry)
+    })
+  ,
+
+  'RecordingsEntry' : _reflection.GeneratedProtocolMessageType('RecordingsEntry', (_message.Message,), {
+    'DESCRIPTOR' : _ROOMDATA_RECORDINGSENTRY,
+    '__module__' : 'pkg.proto.noir_pb2'
+    # @@protoc_insertion_point(class_scope:noir.RoomData.RecordingsEntry)
+    })
+  ,
   'DESCRIPTOR' : _ROOMDATA,
   '__module__' : 'pkg.proto.noir_pb2'
   # @@protoc_insertion_point(class_scope:noir.RoomData)
   })
 _sym_db.RegisterMessage(RoomData)
+_sym_db.RegisterMessage(RoomData.MetadataEntry)
+_sym_db.RegisterMessage(RoomData.RecordingsEntry)
+
+Recording = _reflection.GeneratedProtocolMessageType('Recording', (_message.Message,), {
+  'DESCRIPTOR' : _RECORDING,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.Recording)
+  })
+_sym_db.RegisterMessage(Recording)
 
 RoomOptions = _reflection.GeneratedProtocolMessageType('RoomOptions', (_message.Message,), {
   'DESCRIPTOR' : _ROOMOPTIONS,
@@ -1918,11 +6885,26 @@ RoomOptions = _reflection.GeneratedProtocolMessageType('RoomOptions', (_message.
 _sym_db.RegisterMessage(RoomOptions)
 
 UserData = _reflection.GeneratedProtocolMessageType('UserData', (_message.Message,), {
+
+  'TrackLabelsEntry' : _reflection.GeneratedProtocolMessageType('TrackLabelsEntry', (_message.Message,), {
+    'DESCRIPTOR' : _USERDATA_TRACKLABELSENTRY,
+    '__module__' : 'pkg.proto.noir_pb2'
+    # @@protoc_insertion_point(class_scope:noir.UserData.TrackLabelsEntry)
+    })
+  ,
   'DESCRIPTOR' : _USERDATA,
   '__module__' : 'pkg.proto.noir_pb2'
   # @@protoc_insertion_point(class_scope:noir.UserData)
   })
 _sym_db.RegisterMessage(UserData)
+_sym_db.RegisterMessage(UserData.TrackLabelsEntry)
+
+PeerStatus = _reflection.GeneratedProtocolMessageType('PeerStatus', (_message.Message,), {
+  'DESCRIPTOR' : _PEERSTATUS,
+  '__module__' : 'pkg.proto.noir_pb2'
+  # @@protoc_insertion_point(class_scope:noir.PeerStatus)
+  })
+_sym_db.RegisterMessage(PeerStatus)
 
 UserOptions = _reflection.GeneratedProtocolMessageType('UserOptions', (_message.Message,), {
   'DESCRIPTOR' : _USEROPTIONS,
@@ -1955,8 +6937,8 @@ _NOIR = _descriptor.ServiceDescriptor(
   index=0,
   serialized_options=None,
   create_key=_descriptor._internal_create_key,
-  serialized_start=3232,
-  serialized_end=3434,
+  serialized_start=13404,
+  serialized_end=13606,
   methods=[
   _descriptor.MethodDescriptor(
     name='Subscribe',
@@ -2011,8 +6993,8 @@ _SFU = _descriptor.ServiceDescriptor(
   index=1,
   serialized_options=None,
   create_key=_descriptor._internal_create_key,
-  serialized_start=3436,
-  serialized_end=3497,
+  serialized_start=13608,
+  serialized_end=13669,
   methods=[
   _descriptor.MethodDescriptor(
     name='Signal',